@@ -186,6 +186,24 @@ pub struct Hook {
 pub struct Hooks {
     #[serde(default, skip_serializing_if = "Vec::is_empty")]
     pub prestart: Vec<Hook>,
+    #[serde(
+        default,
+        rename = "createRuntime",
+        skip_serializing_if = "Vec::is_empty"
+    )]
+    pub create_runtime: Vec<Hook>,
+    #[serde(
+        default,
+        rename = "createContainer",
+        skip_serializing_if = "Vec::is_empty"
+    )]
+    pub create_container: Vec<Hook>,
+    #[serde(
+        default,
+        rename = "startContainer",
+        skip_serializing_if = "Vec::is_empty"
+    )]
+    pub start_container: Vec<Hook>,
     #[serde(default, skip_serializing_if = "Vec::is_empty")]
     pub poststart: Vec<Hook>,
     #[serde(default, skip_serializing_if = "Vec::is_empty")]
@@ -462,6 +480,8 @@ pub struct LinuxResources {
     pub network: Option<LinuxNetwork>,
     #[serde(default, skip_serializing_if = "HashMap::is_empty")]
     pub rdma: HashMap<String, LinuxRdma>,
+    #[serde(default, skip_serializing_if = "HashMap::is_empty")]
+    pub unified: HashMap<String, String>,
 }
 
 #[derive(Serialize, Deserialize, Debug, Default, Clone, PartialEq)]
@@ -708,11 +728,15 @@ pub struct LinuxSeccomp {
     #[serde(default, skip_serializing_if = "Vec::is_empty")]
     pub architectures: Vec<Arch>,
     #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub flags: Vec<LinuxSeccompFlag>,
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
     pub syscalls: Vec<LinuxSyscall>,
 }
 
 pub type Arch = String;
 
+pub type LinuxSeccompFlag = String;
+
 pub const ARCHX86: &str = "SCMP_ARCH_X86";
 pub const ARCHX86_64: &str = "SCMP_ARCH_X86_64";
 pub const ARCHX32: &str = "SCMP_ARCH_X32";
@@ -768,6 +792,8 @@ pub struct LinuxSyscall {
     pub names: Vec<String>,
     #[serde(default, skip_serializing_if = "String::is_empty")]
     pub action: LinuxSeccompAction,
+    #[serde(default, rename = "errnoRet", skip_serializing_if = "Option::is_none")]
+    pub errno_ret: Option<u32>,
     #[serde(default, skip_serializing_if = "Vec::is_empty")]
     pub args: Vec<LinuxSeccompArg>,
 }
@@ -1369,6 +1395,9 @@ mod tests {
                         timeout: None,
                     },
                 ],
+                create_runtime: vec![],
+                create_container: vec![],
+                start_container: vec![],
                 poststart: vec![crate::Hook {
                     path: "/usr/bin/notify-start".to_string(),
                     args: vec![],
@@ -1507,6 +1536,7 @@ mod tests {
                         ],
                     }),
                     rdma: Default::default(),
+                    unified: Default::default(),
                 }),
                 cgroups_path: "/myRuntime/myContainer".to_string(),
                 namespaces: vec![
@@ -1562,9 +1592,11 @@ mod tests {
                 seccomp: Some(crate::LinuxSeccomp {
                     default_action: "SCMP_ACT_ALLOW".to_string(),
                     architectures: vec!["SCMP_ARCH_X86".to_string(), "SCMP_ARCH_X32".to_string()],
+                    flags: vec![],
                     syscalls: vec![crate::LinuxSyscall {
                         names: vec!["getcwd".to_string(), "chmod".to_string()],
                         action: "SCMP_ACT_ERRNO".to_string(),
+                        errno_ret: None,
                         args: vec![],
                     }],
                 }),
@@ -3615,6 +3615,7 @@ pub struct PidsStats {
     // message fields
     pub current: u64,
     pub limit: u64,
+    pub zombies: u64,
     // special fields
     pub unknown_fields: ::protobuf::UnknownFields,
     pub cached_size: ::protobuf::CachedSize,
@@ -3660,6 +3661,21 @@ impl PidsStats {
     pub fn set_limit(&mut self, v: u64) {
         self.limit = v;
     }
+
+    // uint64 zombies = 3;
+
+
+    pub fn get_zombies(&self) -> u64 {
+        self.zombies
+    }
+    pub fn clear_zombies(&mut self) {
+        self.zombies = 0;
+    }
+
+    // Param is passed by value, moved
+    pub fn set_zombies(&mut self, v: u64) {
+        self.zombies = v;
+    }
 }
 
 impl ::protobuf::Message for PidsStats {
@@ -3685,6 +3701,13 @@ impl ::protobuf::Message for PidsStats {
                     let tmp = is.read_uint64()?;
                     self.limit = tmp;
                 },
+                3 => {
+                    if wire_type != ::protobuf::wire_format::WireTypeVarint {
+                        return ::std::result::Result::Err(::protobuf::rt::unexpected_wire_type(wire_type));
+                    }
+                    let tmp = is.read_uint64()?;
+                    self.zombies = tmp;
+                },
                 _ => {
                     ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
                 },
@@ -3703,6 +3726,9 @@ impl ::protobuf::Message for PidsStats {
         if self.limit != 0 {
             my_size += ::protobuf::rt::value_size(2, self.limit, ::protobuf::wire_format::WireTypeVarint);
         }
+        if self.zombies != 0 {
+            my_size += ::protobuf::rt::value_size(3, self.zombies, ::protobuf::wire_format::WireTypeVarint);
+        }
         my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
         self.cached_size.set(my_size);
         my_size
@@ -3715,6 +3741,9 @@ impl ::protobuf::Message for PidsStats {
         if self.limit != 0 {
             os.write_uint64(2, self.limit)?;
         }
+        if self.zombies != 0 {
+            os.write_uint64(3, self.zombies)?;
+        }
         os.write_unknown_fields(self.get_unknown_fields())?;
         ::std::result::Result::Ok(())
     }
@@ -3764,6 +3793,11 @@ impl ::protobuf::Message for PidsStats {
                     |m: &PidsStats| { &m.limit },
                     |m: &mut PidsStats| { &mut m.limit },
                 ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeUint64>(
+                    "zombies",
+                    |m: &PidsStats| { &m.zombies },
+                    |m: &mut PidsStats| { &mut m.zombies },
+                ));
                 ::protobuf::reflect::MessageDescriptor::new_pb_name::<PidsStats>(
                     "PidsStats",
                     fields,
@@ -3785,6 +3819,7 @@ impl ::protobuf::Clear for PidsStats {
     fn clear(&mut self) {
         self.current = 0;
         self.limit = 0;
+        self.zombies = 0;
         self.unknown_fields.clear();
     }
 }
@@ -4071,6 +4106,7 @@ pub struct MemoryStats {
     pub kernel_usage: ::protobuf::SingularPtrField<MemoryData>,
     pub use_hierarchy: bool,
     pub stats: ::std::collections::HashMap<::std::string::String, u64>,
+    pub idle: u64,
     // special fields
     pub unknown_fields: ::protobuf::UnknownFields,
     pub cached_size: ::protobuf::CachedSize,
@@ -4240,6 +4276,21 @@ impl MemoryStats {
     pub fn take_stats(&mut self) -> ::std::collections::HashMap<::std::string::String, u64> {
         ::std::mem::replace(&mut self.stats, ::std::collections::HashMap::new())
     }
+
+    // uint64 idle = 7;
+
+
+    pub fn get_idle(&self) -> u64 {
+        self.idle
+    }
+    pub fn clear_idle(&mut self) {
+        self.idle = 0;
+    }
+
+    // Param is passed by value, moved
+    pub fn set_idle(&mut self, v: u64) {
+        self.idle = v;
+    }
 }
 
 impl ::protobuf::Message for MemoryStats {
@@ -4292,6 +4343,13 @@ impl ::protobuf::Message for MemoryStats {
                 6 => {
                     ::protobuf::rt::read_map_into::<::protobuf::types::ProtobufTypeString, ::protobuf::types::ProtobufTypeUint64>(wire_type, is, &mut self.stats)?;
                 },
+                7 => {
+                    if wire_type != ::protobuf::wire_format::WireTypeVarint {
+                        return ::std::result::Result::Err(::protobuf::rt::unexpected_wire_type(wire_type));
+                    }
+                    let tmp = is.read_uint64()?;
+                    self.idle = tmp;
+                },
                 _ => {
                     ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
                 },
@@ -4323,6 +4381,9 @@ impl ::protobuf::Message for MemoryStats {
             my_size += 2;
         }
         my_size += ::protobuf::rt::compute_map_size::<::protobuf::types::ProtobufTypeString, ::protobuf::types::ProtobufTypeUint64>(6, &self.stats);
+        if self.idle != 0 {
+            my_size += ::protobuf::rt::value_size(7, self.idle, ::protobuf::wire_format::WireTypeVarint);
+        }
         my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
         self.cached_size.set(my_size);
         my_size
@@ -4351,6 +4412,9 @@ impl ::protobuf::Message for MemoryStats {
             os.write_bool(5, self.use_hierarchy)?;
         }
         ::protobuf::rt::write_map_with_cached_sizes::<::protobuf::types::ProtobufTypeString, ::protobuf::types::ProtobufTypeUint64>(6, &self.stats, os)?;
+        if self.idle != 0 {
+            os.write_uint64(7, self.idle)?;
+        }
         os.write_unknown_fields(self.get_unknown_fields())?;
         ::std::result::Result::Ok(())
     }
@@ -4420,6 +4484,11 @@ impl ::protobuf::Message for MemoryStats {
                     |m: &MemoryStats| { &m.stats },
                     |m: &mut MemoryStats| { &mut m.stats },
                 ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeUint64>(
+                    "idle",
+                    |m: &MemoryStats| { &m.idle },
+                    |m: &mut MemoryStats| { &mut m.idle },
+                ));
                 ::protobuf::reflect::MessageDescriptor::new_pb_name::<MemoryStats>(
                     "MemoryStats",
                     fields,
@@ -4445,6 +4514,7 @@ impl ::protobuf::Clear for MemoryStats {
         self.kernel_usage.clear();
         self.use_hierarchy = false;
         self.stats.clear();
+        self.idle = 0;
         self.unknown_fields.clear();
     }
 }
@@ -12282,6 +12352,184 @@ impl ::protobuf::reflect::ProtobufValue for StringUser {
     }
 }
 
+#[derive(PartialEq,Clone,Default)]
+pub struct ReleaseDeviceRequest {
+    // message fields
+    pub device: ::protobuf::SingularPtrField<Device>,
+    // special fields
+    pub unknown_fields: ::protobuf::UnknownFields,
+    pub cached_size: ::protobuf::CachedSize,
+}
+
+impl<'a> ::std::default::Default for &'a ReleaseDeviceRequest {
+    fn default() -> &'a ReleaseDeviceRequest {
+        <ReleaseDeviceRequest as ::protobuf::Message>::default_instance()
+    }
+}
+
+impl ReleaseDeviceRequest {
+    pub fn new() -> ReleaseDeviceRequest {
+        ::std::default::Default::default()
+    }
+
+    // .grpc.Device device = 1;
+
+
+    pub fn get_device(&self) -> &Device {
+        self.device.as_ref().unwrap_or_else(|| Device::default_instance())
+    }
+    pub fn clear_device(&mut self) {
+        self.device.clear();
+    }
+
+    pub fn has_device(&self) -> bool {
+        self.device.is_some()
+    }
+
+    // Param is passed by value, moved
+    pub fn set_device(&mut self, v: Device) {
+        self.device = ::protobuf::SingularPtrField::some(v);
+    }
+
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_device(&mut self) -> &mut Device {
+        if self.device.is_none() {
+            self.device.set_default();
+        }
+        self.device.as_mut().unwrap()
+    }
+
+    // Take field
+    pub fn take_device(&mut self) -> Device {
+        self.device.take().unwrap_or_else(|| Device::new())
+    }
+}
+
+impl ::protobuf::Message for ReleaseDeviceRequest {
+    fn is_initialized(&self) -> bool {
+        for v in &self.device {
+            if !v.is_initialized() {
+                return false;
+            }
+        };
+        true
+    }
+
+    fn merge_from(&mut self, is: &mut ::protobuf::CodedInputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        while !is.eof()? {
+            let (field_number, wire_type) = is.read_tag_unpack()?;
+            match field_number {
+                1 => {
+                    ::protobuf::rt::read_singular_message_into(wire_type, is, &mut self.device)?;
+                },
+                _ => {
+                    ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
+                },
+            };
+        }
+        ::std::result::Result::Ok(())
+    }
+
+    // Compute sizes of nested messages
+    #[allow(unused_variables)]
+    fn compute_size(&self) -> u32 {
+        let mut my_size = 0;
+        if let Some(ref v) = self.device.as_ref() {
+            let len = v.compute_size();
+            my_size += 1 + ::protobuf::rt::compute_raw_varint32_size(len) + len;
+        }
+        my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
+        self.cached_size.set(my_size);
+        my_size
+    }
+
+    fn write_to_with_cached_sizes(&self, os: &mut ::protobuf::CodedOutputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        if let Some(ref v) = self.device.as_ref() {
+            os.write_tag(1, ::protobuf::wire_format::WireTypeLengthDelimited)?;
+            os.write_raw_varint32(v.get_cached_size())?;
+            v.write_to_with_cached_sizes(os)?;
+        }
+        os.write_unknown_fields(self.get_unknown_fields())?;
+        ::std::result::Result::Ok(())
+    }
+
+    fn get_cached_size(&self) -> u32 {
+        self.cached_size.get()
+    }
+
+    fn get_unknown_fields(&self) -> &::protobuf::UnknownFields {
+        &self.unknown_fields
+    }
+
+    fn mut_unknown_fields(&mut self) -> &mut ::protobuf::UnknownFields {
+        &mut self.unknown_fields
+    }
+
+    fn as_any(&self) -> &dyn (::std::any::Any) {
+        self as &dyn (::std::any::Any)
+    }
+    fn as_any_mut(&mut self) -> &mut dyn (::std::any::Any) {
+        self as &mut dyn (::std::any::Any)
+    }
+    fn into_any(self: Box<Self>) -> ::std::boxed::Box<dyn (::std::any::Any)> {
+        self
+    }
+
+    fn descriptor(&self) -> &'static ::protobuf::reflect::MessageDescriptor {
+        Self::descriptor_static()
+    }
+
+    fn new() -> ReleaseDeviceRequest {
+        ReleaseDeviceRequest::new()
+    }
+
+    fn descriptor_static() -> &'static ::protobuf::reflect::MessageDescriptor {
+        static mut descriptor: ::protobuf::lazy::Lazy<::protobuf::reflect::MessageDescriptor> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            descriptor.get(|| {
+                let mut fields = ::std::vec::Vec::new();
+                fields.push(::protobuf::reflect::accessor::make_singular_ptr_field_accessor::<_, ::protobuf::types::ProtobufTypeMessage<Device>>(
+                    "device",
+                    |m: &ReleaseDeviceRequest| { &m.device },
+                    |m: &mut ReleaseDeviceRequest| { &mut m.device },
+                ));
+                ::protobuf::reflect::MessageDescriptor::new_pb_name::<ReleaseDeviceRequest>(
+                    "ReleaseDeviceRequest",
+                    fields,
+                    file_descriptor_proto()
+                )
+            })
+        }
+    }
+
+    fn default_instance() -> &'static ReleaseDeviceRequest {
+        static mut instance: ::protobuf::lazy::Lazy<ReleaseDeviceRequest> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            instance.get(ReleaseDeviceRequest::new)
+        }
+    }
+}
+
+impl ::protobuf::Clear for ReleaseDeviceRequest {
+    fn clear(&mut self) {
+        self.device.clear();
+        self.unknown_fields.clear();
+    }
+}
+
+impl ::std::fmt::Debug for ReleaseDeviceRequest {
+    fn fmt(&self, f: &mut ::std::fmt::Formatter<'_>) -> ::std::fmt::Result {
+        ::protobuf::text_format::fmt(self, f)
+    }
+}
+
+impl ::protobuf::reflect::ProtobufValue for ReleaseDeviceRequest {
+    fn as_ref(&self) -> ::protobuf::reflect::ReflectValueRef {
+        ::protobuf::reflect::ReflectValueRef::Message(self)
+    }
+}
+
 #[derive(PartialEq,Clone,Default)]
 pub struct CopyFileRequest {
     // message fields
@@ -12293,6 +12541,7 @@ pub struct CopyFileRequest {
     pub gid: i32,
     pub offset: i64,
     pub data: ::std::vec::Vec<u8>,
+    pub root: ::std::string::String,
     // special fields
     pub unknown_fields: ::protobuf::UnknownFields,
     pub cached_size: ::protobuf::CachedSize,
@@ -12450,6 +12699,32 @@ impl CopyFileRequest {
     pub fn take_data(&mut self) -> ::std::vec::Vec<u8> {
         ::std::mem::replace(&mut self.data, ::std::vec::Vec::new())
     }
+
+    // string root = 9;
+
+
+    pub fn get_root(&self) -> &str {
+        &self.root
+    }
+    pub fn clear_root(&mut self) {
+        self.root.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_root(&mut self, v: ::std::string::String) {
+        self.root = v;
+    }
+
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_root(&mut self) -> &mut ::std::string::String {
+        &mut self.root
+    }
+
+    // Take field
+    pub fn take_root(&mut self) -> ::std::string::String {
+        ::std::mem::replace(&mut self.root, ::std::string::String::new())
+    }
 }
 
 impl ::protobuf::Message for CopyFileRequest {
@@ -12509,6 +12784,9 @@ impl ::protobuf::Message for CopyFileRequest {
                 8 => {
                     ::protobuf::rt::read_singular_proto3_bytes_into(wire_type, is, &mut self.data)?;
                 },
+                9 => {
+                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.root)?;
+                },
                 _ => {
                     ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
                 },
@@ -12545,6 +12823,9 @@ impl ::protobuf::Message for CopyFileRequest {
         if !self.data.is_empty() {
             my_size += ::protobuf::rt::bytes_size(8, &self.data);
         }
+        if !self.root.is_empty() {
+            my_size += ::protobuf::rt::string_size(9, &self.root);
+        }
         my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
         self.cached_size.set(my_size);
         my_size
@@ -12575,6 +12856,9 @@ impl ::protobuf::Message for CopyFileRequest {
         if !self.data.is_empty() {
             os.write_bytes(8, &self.data)?;
         }
+        if !self.root.is_empty() {
+            os.write_string(9, &self.root)?;
+        }
         os.write_unknown_fields(self.get_unknown_fields())?;
         ::std::result::Result::Ok(())
     }
@@ -12654,6 +12938,11 @@ impl ::protobuf::Message for CopyFileRequest {
                     |m: &CopyFileRequest| { &m.data },
                     |m: &mut CopyFileRequest| { &mut m.data },
                 ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
+                    "root",
+                    |m: &CopyFileRequest| { &m.root },
+                    |m: &mut CopyFileRequest| { &mut m.root },
+                ));
                 ::protobuf::reflect::MessageDescriptor::new_pb_name::<CopyFileRequest>(
                     "CopyFileRequest",
                     fields,
@@ -12681,6 +12970,7 @@ impl ::protobuf::Clear for CopyFileRequest {
         self.gid = 0;
         self.offset = 0;
         self.data.clear();
+        self.root.clear();
         self.unknown_fields.clear();
     }
 }
@@ -12698,65 +12988,1883 @@ impl ::protobuf::reflect::ProtobufValue for CopyFileRequest {
 }
 
 #[derive(PartialEq,Clone,Default)]
-pub struct StartTracingRequest {
+pub struct ReadFileRequest {
+    // message fields
+    pub path: ::std::string::String,
+    pub root: ::std::string::String,
+    pub offset: i64,
+    pub length: i64,
     // special fields
     pub unknown_fields: ::protobuf::UnknownFields,
     pub cached_size: ::protobuf::CachedSize,
 }
 
-impl<'a> ::std::default::Default for &'a StartTracingRequest {
-    fn default() -> &'a StartTracingRequest {
-        <StartTracingRequest as ::protobuf::Message>::default_instance()
+impl<'a> ::std::default::Default for &'a ReadFileRequest {
+    fn default() -> &'a ReadFileRequest {
+        <ReadFileRequest as ::protobuf::Message>::default_instance()
     }
 }
 
-impl StartTracingRequest {
-    pub fn new() -> StartTracingRequest {
+impl ReadFileRequest {
+    pub fn new() -> ReadFileRequest {
         ::std::default::Default::default()
     }
-}
 
-impl ::protobuf::Message for StartTracingRequest {
-    fn is_initialized(&self) -> bool {
-        true
+    // string path = 1;
+
+
+    pub fn get_path(&self) -> &str {
+        &self.path
+    }
+    pub fn clear_path(&mut self) {
+        self.path.clear();
     }
 
-    fn merge_from(&mut self, is: &mut ::protobuf::CodedInputStream<'_>) -> ::protobuf::ProtobufResult<()> {
-        while !is.eof()? {
-            let (field_number, wire_type) = is.read_tag_unpack()?;
-            match field_number {
-                _ => {
-                    ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
-                },
-            };
-        }
-        ::std::result::Result::Ok(())
+    // Param is passed by value, moved
+    pub fn set_path(&mut self, v: ::std::string::String) {
+        self.path = v;
     }
 
-    // Compute sizes of nested messages
-    #[allow(unused_variables)]
-    fn compute_size(&self) -> u32 {
-        let mut my_size = 0;
-        my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
-        self.cached_size.set(my_size);
-        my_size
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_path(&mut self) -> &mut ::std::string::String {
+        &mut self.path
     }
 
-    fn write_to_with_cached_sizes(&self, os: &mut ::protobuf::CodedOutputStream<'_>) -> ::protobuf::ProtobufResult<()> {
-        os.write_unknown_fields(self.get_unknown_fields())?;
-        ::std::result::Result::Ok(())
+    // Take field
+    pub fn take_path(&mut self) -> ::std::string::String {
+        ::std::mem::replace(&mut self.path, ::std::string::String::new())
     }
 
-    fn get_cached_size(&self) -> u32 {
-        self.cached_size.get()
+    // string root = 2;
+
+
+    pub fn get_root(&self) -> &str {
+        &self.root
+    }
+    pub fn clear_root(&mut self) {
+        self.root.clear();
     }
 
-    fn get_unknown_fields(&self) -> &::protobuf::UnknownFields {
-        &self.unknown_fields
+    // Param is passed by value, moved
+    pub fn set_root(&mut self, v: ::std::string::String) {
+        self.root = v;
     }
 
-    fn mut_unknown_fields(&mut self) -> &mut ::protobuf::UnknownFields {
-        &mut self.unknown_fields
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_root(&mut self) -> &mut ::std::string::String {
+        &mut self.root
+    }
+
+    // Take field
+    pub fn take_root(&mut self) -> ::std::string::String {
+        ::std::mem::replace(&mut self.root, ::std::string::String::new())
+    }
+
+    // int64 offset = 3;
+
+
+    pub fn get_offset(&self) -> i64 {
+        self.offset
+    }
+    pub fn clear_offset(&mut self) {
+        self.offset = 0;
+    }
+
+    // Param is passed by value, moved
+    pub fn set_offset(&mut self, v: i64) {
+        self.offset = v;
+    }
+
+    // int64 length = 4;
+
+
+    pub fn get_length(&self) -> i64 {
+        self.length
+    }
+    pub fn clear_length(&mut self) {
+        self.length = 0;
+    }
+
+    // Param is passed by value, moved
+    pub fn set_length(&mut self, v: i64) {
+        self.length = v;
+    }
+}
+
+impl ::protobuf::Message for ReadFileRequest {
+    fn is_initialized(&self) -> bool {
+        true
+    }
+
+    fn merge_from(&mut self, is: &mut ::protobuf::CodedInputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        while !is.eof()? {
+            let (field_number, wire_type) = is.read_tag_unpack()?;
+            match field_number {
+                1 => {
+                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.path)?;
+                },
+                2 => {
+                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.root)?;
+                },
+                3 => {
+                    if wire_type != ::protobuf::wire_format::WireTypeVarint {
+                        return ::std::result::Result::Err(::protobuf::rt::unexpected_wire_type(wire_type));
+                    }
+                    let tmp = is.read_int64()?;
+                    self.offset = tmp;
+                },
+                4 => {
+                    if wire_type != ::protobuf::wire_format::WireTypeVarint {
+                        return ::std::result::Result::Err(::protobuf::rt::unexpected_wire_type(wire_type));
+                    }
+                    let tmp = is.read_int64()?;
+                    self.length = tmp;
+                },
+                _ => {
+                    ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
+                },
+            };
+        }
+        ::std::result::Result::Ok(())
+    }
+
+    // Compute sizes of nested messages
+    #[allow(unused_variables)]
+    fn compute_size(&self) -> u32 {
+        let mut my_size = 0;
+        if !self.path.is_empty() {
+            my_size += ::protobuf::rt::string_size(1, &self.path);
+        }
+        if !self.root.is_empty() {
+            my_size += ::protobuf::rt::string_size(2, &self.root);
+        }
+        if self.offset != 0 {
+            my_size += ::protobuf::rt::value_size(3, self.offset, ::protobuf::wire_format::WireTypeVarint);
+        }
+        if self.length != 0 {
+            my_size += ::protobuf::rt::value_size(4, self.length, ::protobuf::wire_format::WireTypeVarint);
+        }
+        my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
+        self.cached_size.set(my_size);
+        my_size
+    }
+
+    fn write_to_with_cached_sizes(&self, os: &mut ::protobuf::CodedOutputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        if !self.path.is_empty() {
+            os.write_string(1, &self.path)?;
+        }
+        if !self.root.is_empty() {
+            os.write_string(2, &self.root)?;
+        }
+        if self.offset != 0 {
+            os.write_int64(3, self.offset)?;
+        }
+        if self.length != 0 {
+            os.write_int64(4, self.length)?;
+        }
+        os.write_unknown_fields(self.get_unknown_fields())?;
+        ::std::result::Result::Ok(())
+    }
+
+    fn get_cached_size(&self) -> u32 {
+        self.cached_size.get()
+    }
+
+    fn get_unknown_fields(&self) -> &::protobuf::UnknownFields {
+        &self.unknown_fields
+    }
+
+    fn mut_unknown_fields(&mut self) -> &mut ::protobuf::UnknownFields {
+        &mut self.unknown_fields
+    }
+
+    fn as_any(&self) -> &dyn (::std::any::Any) {
+        self as &dyn (::std::any::Any)
+    }
+    fn as_any_mut(&mut self) -> &mut dyn (::std::any::Any) {
+        self as &mut dyn (::std::any::Any)
+    }
+    fn into_any(self: Box<Self>) -> ::std::boxed::Box<dyn (::std::any::Any)> {
+        self
+    }
+
+    fn descriptor(&self) -> &'static ::protobuf::reflect::MessageDescriptor {
+        Self::descriptor_static()
+    }
+
+    fn new() -> ReadFileRequest {
+        ReadFileRequest::new()
+    }
+
+    fn descriptor_static() -> &'static ::protobuf::reflect::MessageDescriptor {
+        static mut descriptor: ::protobuf::lazy::Lazy<::protobuf::reflect::MessageDescriptor> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            descriptor.get(|| {
+                let mut fields = ::std::vec::Vec::new();
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
+                    "path",
+                    |m: &ReadFileRequest| { &m.path },
+                    |m: &mut ReadFileRequest| { &mut m.path },
+                ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
+                    "root",
+                    |m: &ReadFileRequest| { &m.root },
+                    |m: &mut ReadFileRequest| { &mut m.root },
+                ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeInt64>(
+                    "offset",
+                    |m: &ReadFileRequest| { &m.offset },
+                    |m: &mut ReadFileRequest| { &mut m.offset },
+                ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeInt64>(
+                    "length",
+                    |m: &ReadFileRequest| { &m.length },
+                    |m: &mut ReadFileRequest| { &mut m.length },
+                ));
+                ::protobuf::reflect::MessageDescriptor::new_pb_name::<ReadFileRequest>(
+                    "ReadFileRequest",
+                    fields,
+                    file_descriptor_proto()
+                )
+            })
+        }
+    }
+
+    fn default_instance() -> &'static ReadFileRequest {
+        static mut instance: ::protobuf::lazy::Lazy<ReadFileRequest> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            instance.get(ReadFileRequest::new)
+        }
+    }
+}
+
+impl ::protobuf::Clear for ReadFileRequest {
+    fn clear(&mut self) {
+        self.path.clear();
+        self.root.clear();
+        self.offset = 0;
+        self.length = 0;
+        self.unknown_fields.clear();
+    }
+}
+
+impl ::std::fmt::Debug for ReadFileRequest {
+    fn fmt(&self, f: &mut ::std::fmt::Formatter<'_>) -> ::std::fmt::Result {
+        ::protobuf::text_format::fmt(self, f)
+    }
+}
+
+impl ::protobuf::reflect::ProtobufValue for ReadFileRequest {
+    fn as_ref(&self) -> ::protobuf::reflect::ReflectValueRef {
+        ::protobuf::reflect::ReflectValueRef::Message(self)
+    }
+}
+
+#[derive(PartialEq,Clone,Default)]
+pub struct ReadFileResponse {
+    // message fields
+    pub data: ::std::vec::Vec<u8>,
+    pub offset: i64,
+    pub file_size: i64,
+    pub file_mode: u32,
+    pub uid: i32,
+    pub gid: i32,
+    // special fields
+    pub unknown_fields: ::protobuf::UnknownFields,
+    pub cached_size: ::protobuf::CachedSize,
+}
+
+impl<'a> ::std::default::Default for &'a ReadFileResponse {
+    fn default() -> &'a ReadFileResponse {
+        <ReadFileResponse as ::protobuf::Message>::default_instance()
+    }
+}
+
+impl ReadFileResponse {
+    pub fn new() -> ReadFileResponse {
+        ::std::default::Default::default()
+    }
+
+    // bytes data = 1;
+
+
+    pub fn get_data(&self) -> &[u8] {
+        &self.data
+    }
+    pub fn clear_data(&mut self) {
+        self.data.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_data(&mut self, v: ::std::vec::Vec<u8>) {
+        self.data = v;
+    }
+
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_data(&mut self) -> &mut ::std::vec::Vec<u8> {
+        &mut self.data
+    }
+
+    // Take field
+    pub fn take_data(&mut self) -> ::std::vec::Vec<u8> {
+        ::std::mem::replace(&mut self.data, ::std::vec::Vec::new())
+    }
+
+    // int64 offset = 2;
+
+
+    pub fn get_offset(&self) -> i64 {
+        self.offset
+    }
+    pub fn clear_offset(&mut self) {
+        self.offset = 0;
+    }
+
+    // Param is passed by value, moved
+    pub fn set_offset(&mut self, v: i64) {
+        self.offset = v;
+    }
+
+    // int64 file_size = 3;
+
+
+    pub fn get_file_size(&self) -> i64 {
+        self.file_size
+    }
+    pub fn clear_file_size(&mut self) {
+        self.file_size = 0;
+    }
+
+    // Param is passed by value, moved
+    pub fn set_file_size(&mut self, v: i64) {
+        self.file_size = v;
+    }
+
+    // uint32 file_mode = 4;
+
+
+    pub fn get_file_mode(&self) -> u32 {
+        self.file_mode
+    }
+    pub fn clear_file_mode(&mut self) {
+        self.file_mode = 0;
+    }
+
+    // Param is passed by value, moved
+    pub fn set_file_mode(&mut self, v: u32) {
+        self.file_mode = v;
+    }
+
+    // int32 uid = 5;
+
+
+    pub fn get_uid(&self) -> i32 {
+        self.uid
+    }
+    pub fn clear_uid(&mut self) {
+        self.uid = 0;
+    }
+
+    // Param is passed by value, moved
+    pub fn set_uid(&mut self, v: i32) {
+        self.uid = v;
+    }
+
+    // int32 gid = 6;
+
+
+    pub fn get_gid(&self) -> i32 {
+        self.gid
+    }
+    pub fn clear_gid(&mut self) {
+        self.gid = 0;
+    }
+
+    // Param is passed by value, moved
+    pub fn set_gid(&mut self, v: i32) {
+        self.gid = v;
+    }
+}
+
+impl ::protobuf::Message for ReadFileResponse {
+    fn is_initialized(&self) -> bool {
+        true
+    }
+
+    fn merge_from(&mut self, is: &mut ::protobuf::CodedInputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        while !is.eof()? {
+            let (field_number, wire_type) = is.read_tag_unpack()?;
+            match field_number {
+                1 => {
+                    ::protobuf::rt::read_singular_proto3_bytes_into(wire_type, is, &mut self.data)?;
+                },
+                2 => {
+                    if wire_type != ::protobuf::wire_format::WireTypeVarint {
+                        return ::std::result::Result::Err(::protobuf::rt::unexpected_wire_type(wire_type));
+                    }
+                    let tmp = is.read_int64()?;
+                    self.offset = tmp;
+                },
+                3 => {
+                    if wire_type != ::protobuf::wire_format::WireTypeVarint {
+                        return ::std::result::Result::Err(::protobuf::rt::unexpected_wire_type(wire_type));
+                    }
+                    let tmp = is.read_int64()?;
+                    self.file_size = tmp;
+                },
+                4 => {
+                    if wire_type != ::protobuf::wire_format::WireTypeVarint {
+                        return ::std::result::Result::Err(::protobuf::rt::unexpected_wire_type(wire_type));
+                    }
+                    let tmp = is.read_uint32()?;
+                    self.file_mode = tmp;
+                },
+                5 => {
+                    if wire_type != ::protobuf::wire_format::WireTypeVarint {
+                        return ::std::result::Result::Err(::protobuf::rt::unexpected_wire_type(wire_type));
+                    }
+                    let tmp = is.read_int32()?;
+                    self.uid = tmp;
+                },
+                6 => {
+                    if wire_type != ::protobuf::wire_format::WireTypeVarint {
+                        return ::std::result::Result::Err(::protobuf::rt::unexpected_wire_type(wire_type));
+                    }
+                    let tmp = is.read_int32()?;
+                    self.gid = tmp;
+                },
+                _ => {
+                    ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
+                },
+            };
+        }
+        ::std::result::Result::Ok(())
+    }
+
+    // Compute sizes of nested messages
+    #[allow(unused_variables)]
+    fn compute_size(&self) -> u32 {
+        let mut my_size = 0;
+        if !self.data.is_empty() {
+            my_size += ::protobuf::rt::bytes_size(1, &self.data);
+        }
+        if self.offset != 0 {
+            my_size += ::protobuf::rt::value_size(2, self.offset, ::protobuf::wire_format::WireTypeVarint);
+        }
+        if self.file_size != 0 {
+            my_size += ::protobuf::rt::value_size(3, self.file_size, ::protobuf::wire_format::WireTypeVarint);
+        }
+        if self.file_mode != 0 {
+            my_size += ::protobuf::rt::value_size(4, self.file_mode, ::protobuf::wire_format::WireTypeVarint);
+        }
+        if self.uid != 0 {
+            my_size += ::protobuf::rt::value_size(5, self.uid, ::protobuf::wire_format::WireTypeVarint);
+        }
+        if self.gid != 0 {
+            my_size += ::protobuf::rt::value_size(6, self.gid, ::protobuf::wire_format::WireTypeVarint);
+        }
+        my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
+        self.cached_size.set(my_size);
+        my_size
+    }
+
+    fn write_to_with_cached_sizes(&self, os: &mut ::protobuf::CodedOutputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        if !self.data.is_empty() {
+            os.write_bytes(1, &self.data)?;
+        }
+        if self.offset != 0 {
+            os.write_int64(2, self.offset)?;
+        }
+        if self.file_size != 0 {
+            os.write_int64(3, self.file_size)?;
+        }
+        if self.file_mode != 0 {
+            os.write_uint32(4, self.file_mode)?;
+        }
+        if self.uid != 0 {
+            os.write_int32(5, self.uid)?;
+        }
+        if self.gid != 0 {
+            os.write_int32(6, self.gid)?;
+        }
+        os.write_unknown_fields(self.get_unknown_fields())?;
+        ::std::result::Result::Ok(())
+    }
+
+    fn get_cached_size(&self) -> u32 {
+        self.cached_size.get()
+    }
+
+    fn get_unknown_fields(&self) -> &::protobuf::UnknownFields {
+        &self.unknown_fields
+    }
+
+    fn mut_unknown_fields(&mut self) -> &mut ::protobuf::UnknownFields {
+        &mut self.unknown_fields
+    }
+
+    fn as_any(&self) -> &dyn (::std::any::Any) {
+        self as &dyn (::std::any::Any)
+    }
+    fn as_any_mut(&mut self) -> &mut dyn (::std::any::Any) {
+        self as &mut dyn (::std::any::Any)
+    }
+    fn into_any(self: Box<Self>) -> ::std::boxed::Box<dyn (::std::any::Any)> {
+        self
+    }
+
+    fn descriptor(&self) -> &'static ::protobuf::reflect::MessageDescriptor {
+        Self::descriptor_static()
+    }
+
+    fn new() -> ReadFileResponse {
+        ReadFileResponse::new()
+    }
+
+    fn descriptor_static() -> &'static ::protobuf::reflect::MessageDescriptor {
+        static mut descriptor: ::protobuf::lazy::Lazy<::protobuf::reflect::MessageDescriptor> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            descriptor.get(|| {
+                let mut fields = ::std::vec::Vec::new();
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeBytes>(
+                    "data",
+                    |m: &ReadFileResponse| { &m.data },
+                    |m: &mut ReadFileResponse| { &mut m.data },
+                ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeInt64>(
+                    "offset",
+                    |m: &ReadFileResponse| { &m.offset },
+                    |m: &mut ReadFileResponse| { &mut m.offset },
+                ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeInt64>(
+                    "file_size",
+                    |m: &ReadFileResponse| { &m.file_size },
+                    |m: &mut ReadFileResponse| { &mut m.file_size },
+                ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeUint32>(
+                    "file_mode",
+                    |m: &ReadFileResponse| { &m.file_mode },
+                    |m: &mut ReadFileResponse| { &mut m.file_mode },
+                ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeInt32>(
+                    "uid",
+                    |m: &ReadFileResponse| { &m.uid },
+                    |m: &mut ReadFileResponse| { &mut m.uid },
+                ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeInt32>(
+                    "gid",
+                    |m: &ReadFileResponse| { &m.gid },
+                    |m: &mut ReadFileResponse| { &mut m.gid },
+                ));
+                ::protobuf::reflect::MessageDescriptor::new_pb_name::<ReadFileResponse>(
+                    "ReadFileResponse",
+                    fields,
+                    file_descriptor_proto()
+                )
+            })
+        }
+    }
+
+    fn default_instance() -> &'static ReadFileResponse {
+        static mut instance: ::protobuf::lazy::Lazy<ReadFileResponse> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            instance.get(ReadFileResponse::new)
+        }
+    }
+}
+
+impl ::protobuf::Clear for ReadFileResponse {
+    fn clear(&mut self) {
+        self.data.clear();
+        self.offset = 0;
+        self.file_size = 0;
+        self.file_mode = 0;
+        self.uid = 0;
+        self.gid = 0;
+        self.unknown_fields.clear();
+    }
+}
+
+impl ::std::fmt::Debug for ReadFileResponse {
+    fn fmt(&self, f: &mut ::std::fmt::Formatter<'_>) -> ::std::fmt::Result {
+        ::protobuf::text_format::fmt(self, f)
+    }
+}
+
+impl ::protobuf::reflect::ProtobufValue for ReadFileResponse {
+    fn as_ref(&self) -> ::protobuf::reflect::ReflectValueRef {
+        ::protobuf::reflect::ReflectValueRef::Message(self)
+    }
+}
+
+#[derive(PartialEq,Clone,Default)]
+pub struct StartTracingRequest {
+    // special fields
+    pub unknown_fields: ::protobuf::UnknownFields,
+    pub cached_size: ::protobuf::CachedSize,
+}
+
+impl<'a> ::std::default::Default for &'a StartTracingRequest {
+    fn default() -> &'a StartTracingRequest {
+        <StartTracingRequest as ::protobuf::Message>::default_instance()
+    }
+}
+
+impl StartTracingRequest {
+    pub fn new() -> StartTracingRequest {
+        ::std::default::Default::default()
+    }
+}
+
+impl ::protobuf::Message for StartTracingRequest {
+    fn is_initialized(&self) -> bool {
+        true
+    }
+
+    fn merge_from(&mut self, is: &mut ::protobuf::CodedInputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        while !is.eof()? {
+            let (field_number, wire_type) = is.read_tag_unpack()?;
+            match field_number {
+                _ => {
+                    ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
+                },
+            };
+        }
+        ::std::result::Result::Ok(())
+    }
+
+    // Compute sizes of nested messages
+    #[allow(unused_variables)]
+    fn compute_size(&self) -> u32 {
+        let mut my_size = 0;
+        my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
+        self.cached_size.set(my_size);
+        my_size
+    }
+
+    fn write_to_with_cached_sizes(&self, os: &mut ::protobuf::CodedOutputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        os.write_unknown_fields(self.get_unknown_fields())?;
+        ::std::result::Result::Ok(())
+    }
+
+    fn get_cached_size(&self) -> u32 {
+        self.cached_size.get()
+    }
+
+    fn get_unknown_fields(&self) -> &::protobuf::UnknownFields {
+        &self.unknown_fields
+    }
+
+    fn mut_unknown_fields(&mut self) -> &mut ::protobuf::UnknownFields {
+        &mut self.unknown_fields
+    }
+
+    fn as_any(&self) -> &dyn (::std::any::Any) {
+        self as &dyn (::std::any::Any)
+    }
+    fn as_any_mut(&mut self) -> &mut dyn (::std::any::Any) {
+        self as &mut dyn (::std::any::Any)
+    }
+    fn into_any(self: Box<Self>) -> ::std::boxed::Box<dyn (::std::any::Any)> {
+        self
+    }
+
+    fn descriptor(&self) -> &'static ::protobuf::reflect::MessageDescriptor {
+        Self::descriptor_static()
+    }
+
+    fn new() -> StartTracingRequest {
+        StartTracingRequest::new()
+    }
+
+    fn descriptor_static() -> &'static ::protobuf::reflect::MessageDescriptor {
+        static mut descriptor: ::protobuf::lazy::Lazy<::protobuf::reflect::MessageDescriptor> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            descriptor.get(|| {
+                let fields = ::std::vec::Vec::new();
+                ::protobuf::reflect::MessageDescriptor::new_pb_name::<StartTracingRequest>(
+                    "StartTracingRequest",
+                    fields,
+                    file_descriptor_proto()
+                )
+            })
+        }
+    }
+
+    fn default_instance() -> &'static StartTracingRequest {
+        static mut instance: ::protobuf::lazy::Lazy<StartTracingRequest> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            instance.get(StartTracingRequest::new)
+        }
+    }
+}
+
+impl ::protobuf::Clear for StartTracingRequest {
+    fn clear(&mut self) {
+        self.unknown_fields.clear();
+    }
+}
+
+impl ::std::fmt::Debug for StartTracingRequest {
+    fn fmt(&self, f: &mut ::std::fmt::Formatter<'_>) -> ::std::fmt::Result {
+        ::protobuf::text_format::fmt(self, f)
+    }
+}
+
+impl ::protobuf::reflect::ProtobufValue for StartTracingRequest {
+    fn as_ref(&self) -> ::protobuf::reflect::ReflectValueRef {
+        ::protobuf::reflect::ReflectValueRef::Message(self)
+    }
+}
+
+#[derive(PartialEq,Clone,Default)]
+pub struct StopTracingRequest {
+    // special fields
+    pub unknown_fields: ::protobuf::UnknownFields,
+    pub cached_size: ::protobuf::CachedSize,
+}
+
+impl<'a> ::std::default::Default for &'a StopTracingRequest {
+    fn default() -> &'a StopTracingRequest {
+        <StopTracingRequest as ::protobuf::Message>::default_instance()
+    }
+}
+
+impl StopTracingRequest {
+    pub fn new() -> StopTracingRequest {
+        ::std::default::Default::default()
+    }
+}
+
+impl ::protobuf::Message for StopTracingRequest {
+    fn is_initialized(&self) -> bool {
+        true
+    }
+
+    fn merge_from(&mut self, is: &mut ::protobuf::CodedInputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        while !is.eof()? {
+            let (field_number, wire_type) = is.read_tag_unpack()?;
+            match field_number {
+                _ => {
+                    ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
+                },
+            };
+        }
+        ::std::result::Result::Ok(())
+    }
+
+    // Compute sizes of nested messages
+    #[allow(unused_variables)]
+    fn compute_size(&self) -> u32 {
+        let mut my_size = 0;
+        my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
+        self.cached_size.set(my_size);
+        my_size
+    }
+
+    fn write_to_with_cached_sizes(&self, os: &mut ::protobuf::CodedOutputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        os.write_unknown_fields(self.get_unknown_fields())?;
+        ::std::result::Result::Ok(())
+    }
+
+    fn get_cached_size(&self) -> u32 {
+        self.cached_size.get()
+    }
+
+    fn get_unknown_fields(&self) -> &::protobuf::UnknownFields {
+        &self.unknown_fields
+    }
+
+    fn mut_unknown_fields(&mut self) -> &mut ::protobuf::UnknownFields {
+        &mut self.unknown_fields
+    }
+
+    fn as_any(&self) -> &dyn (::std::any::Any) {
+        self as &dyn (::std::any::Any)
+    }
+    fn as_any_mut(&mut self) -> &mut dyn (::std::any::Any) {
+        self as &mut dyn (::std::any::Any)
+    }
+    fn into_any(self: Box<Self>) -> ::std::boxed::Box<dyn (::std::any::Any)> {
+        self
+    }
+
+    fn descriptor(&self) -> &'static ::protobuf::reflect::MessageDescriptor {
+        Self::descriptor_static()
+    }
+
+    fn new() -> StopTracingRequest {
+        StopTracingRequest::new()
+    }
+
+    fn descriptor_static() -> &'static ::protobuf::reflect::MessageDescriptor {
+        static mut descriptor: ::protobuf::lazy::Lazy<::protobuf::reflect::MessageDescriptor> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            descriptor.get(|| {
+                let fields = ::std::vec::Vec::new();
+                ::protobuf::reflect::MessageDescriptor::new_pb_name::<StopTracingRequest>(
+                    "StopTracingRequest",
+                    fields,
+                    file_descriptor_proto()
+                )
+            })
+        }
+    }
+
+    fn default_instance() -> &'static StopTracingRequest {
+        static mut instance: ::protobuf::lazy::Lazy<StopTracingRequest> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            instance.get(StopTracingRequest::new)
+        }
+    }
+}
+
+impl ::protobuf::Clear for StopTracingRequest {
+    fn clear(&mut self) {
+        self.unknown_fields.clear();
+    }
+}
+
+impl ::std::fmt::Debug for StopTracingRequest {
+    fn fmt(&self, f: &mut ::std::fmt::Formatter<'_>) -> ::std::fmt::Result {
+        ::protobuf::text_format::fmt(self, f)
+    }
+}
+
+impl ::protobuf::reflect::ProtobufValue for StopTracingRequest {
+    fn as_ref(&self) -> ::protobuf::reflect::ReflectValueRef {
+        ::protobuf::reflect::ReflectValueRef::Message(self)
+    }
+}
+
+#[derive(PartialEq,Clone,Default)]
+pub struct GetOOMEventRequest {
+    // special fields
+    pub unknown_fields: ::protobuf::UnknownFields,
+    pub cached_size: ::protobuf::CachedSize,
+}
+
+impl<'a> ::std::default::Default for &'a GetOOMEventRequest {
+    fn default() -> &'a GetOOMEventRequest {
+        <GetOOMEventRequest as ::protobuf::Message>::default_instance()
+    }
+}
+
+impl GetOOMEventRequest {
+    pub fn new() -> GetOOMEventRequest {
+        ::std::default::Default::default()
+    }
+}
+
+impl ::protobuf::Message for GetOOMEventRequest {
+    fn is_initialized(&self) -> bool {
+        true
+    }
+
+    fn merge_from(&mut self, is: &mut ::protobuf::CodedInputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        while !is.eof()? {
+            let (field_number, wire_type) = is.read_tag_unpack()?;
+            match field_number {
+                _ => {
+                    ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
+                },
+            };
+        }
+        ::std::result::Result::Ok(())
+    }
+
+    // Compute sizes of nested messages
+    #[allow(unused_variables)]
+    fn compute_size(&self) -> u32 {
+        let mut my_size = 0;
+        my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
+        self.cached_size.set(my_size);
+        my_size
+    }
+
+    fn write_to_with_cached_sizes(&self, os: &mut ::protobuf::CodedOutputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        os.write_unknown_fields(self.get_unknown_fields())?;
+        ::std::result::Result::Ok(())
+    }
+
+    fn get_cached_size(&self) -> u32 {
+        self.cached_size.get()
+    }
+
+    fn get_unknown_fields(&self) -> &::protobuf::UnknownFields {
+        &self.unknown_fields
+    }
+
+    fn mut_unknown_fields(&mut self) -> &mut ::protobuf::UnknownFields {
+        &mut self.unknown_fields
+    }
+
+    fn as_any(&self) -> &dyn (::std::any::Any) {
+        self as &dyn (::std::any::Any)
+    }
+    fn as_any_mut(&mut self) -> &mut dyn (::std::any::Any) {
+        self as &mut dyn (::std::any::Any)
+    }
+    fn into_any(self: Box<Self>) -> ::std::boxed::Box<dyn (::std::any::Any)> {
+        self
+    }
+
+    fn descriptor(&self) -> &'static ::protobuf::reflect::MessageDescriptor {
+        Self::descriptor_static()
+    }
+
+    fn new() -> GetOOMEventRequest {
+        GetOOMEventRequest::new()
+    }
+
+    fn descriptor_static() -> &'static ::protobuf::reflect::MessageDescriptor {
+        static mut descriptor: ::protobuf::lazy::Lazy<::protobuf::reflect::MessageDescriptor> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            descriptor.get(|| {
+                let fields = ::std::vec::Vec::new();
+                ::protobuf::reflect::MessageDescriptor::new_pb_name::<GetOOMEventRequest>(
+                    "GetOOMEventRequest",
+                    fields,
+                    file_descriptor_proto()
+                )
+            })
+        }
+    }
+
+    fn default_instance() -> &'static GetOOMEventRequest {
+        static mut instance: ::protobuf::lazy::Lazy<GetOOMEventRequest> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            instance.get(GetOOMEventRequest::new)
+        }
+    }
+}
+
+impl ::protobuf::Clear for GetOOMEventRequest {
+    fn clear(&mut self) {
+        self.unknown_fields.clear();
+    }
+}
+
+impl ::std::fmt::Debug for GetOOMEventRequest {
+    fn fmt(&self, f: &mut ::std::fmt::Formatter<'_>) -> ::std::fmt::Result {
+        ::protobuf::text_format::fmt(self, f)
+    }
+}
+
+impl ::protobuf::reflect::ProtobufValue for GetOOMEventRequest {
+    fn as_ref(&self) -> ::protobuf::reflect::ReflectValueRef {
+        ::protobuf::reflect::ReflectValueRef::Message(self)
+    }
+}
+
+#[derive(PartialEq,Clone,Default)]
+pub struct OOMEvent {
+    // message fields
+    pub container_id: ::std::string::String,
+    // special fields
+    pub unknown_fields: ::protobuf::UnknownFields,
+    pub cached_size: ::protobuf::CachedSize,
+}
+
+impl<'a> ::std::default::Default for &'a OOMEvent {
+    fn default() -> &'a OOMEvent {
+        <OOMEvent as ::protobuf::Message>::default_instance()
+    }
+}
+
+impl OOMEvent {
+    pub fn new() -> OOMEvent {
+        ::std::default::Default::default()
+    }
+
+    // string container_id = 1;
+
+
+    pub fn get_container_id(&self) -> &str {
+        &self.container_id
+    }
+    pub fn clear_container_id(&mut self) {
+        self.container_id.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_container_id(&mut self, v: ::std::string::String) {
+        self.container_id = v;
+    }
+
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_container_id(&mut self) -> &mut ::std::string::String {
+        &mut self.container_id
+    }
+
+    // Take field
+    pub fn take_container_id(&mut self) -> ::std::string::String {
+        ::std::mem::replace(&mut self.container_id, ::std::string::String::new())
+    }
+}
+
+impl ::protobuf::Message for OOMEvent {
+    fn is_initialized(&self) -> bool {
+        true
+    }
+
+    fn merge_from(&mut self, is: &mut ::protobuf::CodedInputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        while !is.eof()? {
+            let (field_number, wire_type) = is.read_tag_unpack()?;
+            match field_number {
+                1 => {
+                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.container_id)?;
+                },
+                _ => {
+                    ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
+                },
+            };
+        }
+        ::std::result::Result::Ok(())
+    }
+
+    // Compute sizes of nested messages
+    #[allow(unused_variables)]
+    fn compute_size(&self) -> u32 {
+        let mut my_size = 0;
+        if !self.container_id.is_empty() {
+            my_size += ::protobuf::rt::string_size(1, &self.container_id);
+        }
+        my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
+        self.cached_size.set(my_size);
+        my_size
+    }
+
+    fn write_to_with_cached_sizes(&self, os: &mut ::protobuf::CodedOutputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        if !self.container_id.is_empty() {
+            os.write_string(1, &self.container_id)?;
+        }
+        os.write_unknown_fields(self.get_unknown_fields())?;
+        ::std::result::Result::Ok(())
+    }
+
+    fn get_cached_size(&self) -> u32 {
+        self.cached_size.get()
+    }
+
+    fn get_unknown_fields(&self) -> &::protobuf::UnknownFields {
+        &self.unknown_fields
+    }
+
+    fn mut_unknown_fields(&mut self) -> &mut ::protobuf::UnknownFields {
+        &mut self.unknown_fields
+    }
+
+    fn as_any(&self) -> &dyn (::std::any::Any) {
+        self as &dyn (::std::any::Any)
+    }
+    fn as_any_mut(&mut self) -> &mut dyn (::std::any::Any) {
+        self as &mut dyn (::std::any::Any)
+    }
+    fn into_any(self: Box<Self>) -> ::std::boxed::Box<dyn (::std::any::Any)> {
+        self
+    }
+
+    fn descriptor(&self) -> &'static ::protobuf::reflect::MessageDescriptor {
+        Self::descriptor_static()
+    }
+
+    fn new() -> OOMEvent {
+        OOMEvent::new()
+    }
+
+    fn descriptor_static() -> &'static ::protobuf::reflect::MessageDescriptor {
+        static mut descriptor: ::protobuf::lazy::Lazy<::protobuf::reflect::MessageDescriptor> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            descriptor.get(|| {
+                let mut fields = ::std::vec::Vec::new();
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
+                    "container_id",
+                    |m: &OOMEvent| { &m.container_id },
+                    |m: &mut OOMEvent| { &mut m.container_id },
+                ));
+                ::protobuf::reflect::MessageDescriptor::new_pb_name::<OOMEvent>(
+                    "OOMEvent",
+                    fields,
+                    file_descriptor_proto()
+                )
+            })
+        }
+    }
+
+    fn default_instance() -> &'static OOMEvent {
+        static mut instance: ::protobuf::lazy::Lazy<OOMEvent> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            instance.get(OOMEvent::new)
+        }
+    }
+}
+
+impl ::protobuf::Clear for OOMEvent {
+    fn clear(&mut self) {
+        self.container_id.clear();
+        self.unknown_fields.clear();
+    }
+}
+
+impl ::std::fmt::Debug for OOMEvent {
+    fn fmt(&self, f: &mut ::std::fmt::Formatter<'_>) -> ::std::fmt::Result {
+        ::protobuf::text_format::fmt(self, f)
+    }
+}
+
+impl ::protobuf::reflect::ProtobufValue for OOMEvent {
+    fn as_ref(&self) -> ::protobuf::reflect::ReflectValueRef {
+        ::protobuf::reflect::ReflectValueRef::Message(self)
+    }
+}
+
+#[derive(PartialEq,Clone,Default)]
+pub struct GetMetricsRequest {
+    // special fields
+    pub unknown_fields: ::protobuf::UnknownFields,
+    pub cached_size: ::protobuf::CachedSize,
+}
+
+impl<'a> ::std::default::Default for &'a GetMetricsRequest {
+    fn default() -> &'a GetMetricsRequest {
+        <GetMetricsRequest as ::protobuf::Message>::default_instance()
+    }
+}
+
+impl GetMetricsRequest {
+    pub fn new() -> GetMetricsRequest {
+        ::std::default::Default::default()
+    }
+}
+
+impl ::protobuf::Message for GetMetricsRequest {
+    fn is_initialized(&self) -> bool {
+        true
+    }
+
+    fn merge_from(&mut self, is: &mut ::protobuf::CodedInputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        while !is.eof()? {
+            let (field_number, wire_type) = is.read_tag_unpack()?;
+            match field_number {
+                _ => {
+                    ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
+                },
+            };
+        }
+        ::std::result::Result::Ok(())
+    }
+
+    // Compute sizes of nested messages
+    #[allow(unused_variables)]
+    fn compute_size(&self) -> u32 {
+        let mut my_size = 0;
+        my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
+        self.cached_size.set(my_size);
+        my_size
+    }
+
+    fn write_to_with_cached_sizes(&self, os: &mut ::protobuf::CodedOutputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        os.write_unknown_fields(self.get_unknown_fields())?;
+        ::std::result::Result::Ok(())
+    }
+
+    fn get_cached_size(&self) -> u32 {
+        self.cached_size.get()
+    }
+
+    fn get_unknown_fields(&self) -> &::protobuf::UnknownFields {
+        &self.unknown_fields
+    }
+
+    fn mut_unknown_fields(&mut self) -> &mut ::protobuf::UnknownFields {
+        &mut self.unknown_fields
+    }
+
+    fn as_any(&self) -> &dyn (::std::any::Any) {
+        self as &dyn (::std::any::Any)
+    }
+    fn as_any_mut(&mut self) -> &mut dyn (::std::any::Any) {
+        self as &mut dyn (::std::any::Any)
+    }
+    fn into_any(self: Box<Self>) -> ::std::boxed::Box<dyn (::std::any::Any)> {
+        self
+    }
+
+    fn descriptor(&self) -> &'static ::protobuf::reflect::MessageDescriptor {
+        Self::descriptor_static()
+    }
+
+    fn new() -> GetMetricsRequest {
+        GetMetricsRequest::new()
+    }
+
+    fn descriptor_static() -> &'static ::protobuf::reflect::MessageDescriptor {
+        static mut descriptor: ::protobuf::lazy::Lazy<::protobuf::reflect::MessageDescriptor> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            descriptor.get(|| {
+                let fields = ::std::vec::Vec::new();
+                ::protobuf::reflect::MessageDescriptor::new_pb_name::<GetMetricsRequest>(
+                    "GetMetricsRequest",
+                    fields,
+                    file_descriptor_proto()
+                )
+            })
+        }
+    }
+
+    fn default_instance() -> &'static GetMetricsRequest {
+        static mut instance: ::protobuf::lazy::Lazy<GetMetricsRequest> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            instance.get(GetMetricsRequest::new)
+        }
+    }
+}
+
+impl ::protobuf::Clear for GetMetricsRequest {
+    fn clear(&mut self) {
+        self.unknown_fields.clear();
+    }
+}
+
+impl ::std::fmt::Debug for GetMetricsRequest {
+    fn fmt(&self, f: &mut ::std::fmt::Formatter<'_>) -> ::std::fmt::Result {
+        ::protobuf::text_format::fmt(self, f)
+    }
+}
+
+impl ::protobuf::reflect::ProtobufValue for GetMetricsRequest {
+    fn as_ref(&self) -> ::protobuf::reflect::ReflectValueRef {
+        ::protobuf::reflect::ReflectValueRef::Message(self)
+    }
+}
+
+#[derive(PartialEq,Clone,Default)]
+pub struct Metrics {
+    // message fields
+    pub metrics: ::std::string::String,
+    // special fields
+    pub unknown_fields: ::protobuf::UnknownFields,
+    pub cached_size: ::protobuf::CachedSize,
+}
+
+impl<'a> ::std::default::Default for &'a Metrics {
+    fn default() -> &'a Metrics {
+        <Metrics as ::protobuf::Message>::default_instance()
+    }
+}
+
+impl Metrics {
+    pub fn new() -> Metrics {
+        ::std::default::Default::default()
+    }
+
+    // string metrics = 1;
+
+
+    pub fn get_metrics(&self) -> &str {
+        &self.metrics
+    }
+    pub fn clear_metrics(&mut self) {
+        self.metrics.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_metrics(&mut self, v: ::std::string::String) {
+        self.metrics = v;
+    }
+
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_metrics(&mut self) -> &mut ::std::string::String {
+        &mut self.metrics
+    }
+
+    // Take field
+    pub fn take_metrics(&mut self) -> ::std::string::String {
+        ::std::mem::replace(&mut self.metrics, ::std::string::String::new())
+    }
+}
+
+impl ::protobuf::Message for Metrics {
+    fn is_initialized(&self) -> bool {
+        true
+    }
+
+    fn merge_from(&mut self, is: &mut ::protobuf::CodedInputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        while !is.eof()? {
+            let (field_number, wire_type) = is.read_tag_unpack()?;
+            match field_number {
+                1 => {
+                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.metrics)?;
+                },
+                _ => {
+                    ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
+                },
+            };
+        }
+        ::std::result::Result::Ok(())
+    }
+
+    // Compute sizes of nested messages
+    #[allow(unused_variables)]
+    fn compute_size(&self) -> u32 {
+        let mut my_size = 0;
+        if !self.metrics.is_empty() {
+            my_size += ::protobuf::rt::string_size(1, &self.metrics);
+        }
+        my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
+        self.cached_size.set(my_size);
+        my_size
+    }
+
+    fn write_to_with_cached_sizes(&self, os: &mut ::protobuf::CodedOutputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        if !self.metrics.is_empty() {
+            os.write_string(1, &self.metrics)?;
+        }
+        os.write_unknown_fields(self.get_unknown_fields())?;
+        ::std::result::Result::Ok(())
+    }
+
+    fn get_cached_size(&self) -> u32 {
+        self.cached_size.get()
+    }
+
+    fn get_unknown_fields(&self) -> &::protobuf::UnknownFields {
+        &self.unknown_fields
+    }
+
+    fn mut_unknown_fields(&mut self) -> &mut ::protobuf::UnknownFields {
+        &mut self.unknown_fields
+    }
+
+    fn as_any(&self) -> &dyn (::std::any::Any) {
+        self as &dyn (::std::any::Any)
+    }
+    fn as_any_mut(&mut self) -> &mut dyn (::std::any::Any) {
+        self as &mut dyn (::std::any::Any)
+    }
+    fn into_any(self: Box<Self>) -> ::std::boxed::Box<dyn (::std::any::Any)> {
+        self
+    }
+
+    fn descriptor(&self) -> &'static ::protobuf::reflect::MessageDescriptor {
+        Self::descriptor_static()
+    }
+
+    fn new() -> Metrics {
+        Metrics::new()
+    }
+
+    fn descriptor_static() -> &'static ::protobuf::reflect::MessageDescriptor {
+        static mut descriptor: ::protobuf::lazy::Lazy<::protobuf::reflect::MessageDescriptor> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            descriptor.get(|| {
+                let mut fields = ::std::vec::Vec::new();
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
+                    "metrics",
+                    |m: &Metrics| { &m.metrics },
+                    |m: &mut Metrics| { &mut m.metrics },
+                ));
+                ::protobuf::reflect::MessageDescriptor::new_pb_name::<Metrics>(
+                    "Metrics",
+                    fields,
+                    file_descriptor_proto()
+                )
+            })
+        }
+    }
+
+    fn default_instance() -> &'static Metrics {
+        static mut instance: ::protobuf::lazy::Lazy<Metrics> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            instance.get(Metrics::new)
+        }
+    }
+}
+
+impl ::protobuf::Clear for Metrics {
+    fn clear(&mut self) {
+        self.metrics.clear();
+        self.unknown_fields.clear();
+    }
+}
+
+impl ::std::fmt::Debug for Metrics {
+    fn fmt(&self, f: &mut ::std::fmt::Formatter<'_>) -> ::std::fmt::Result {
+        ::protobuf::text_format::fmt(self, f)
+    }
+}
+
+impl ::protobuf::reflect::ProtobufValue for Metrics {
+    fn as_ref(&self) -> ::protobuf::reflect::ReflectValueRef {
+        ::protobuf::reflect::ReflectValueRef::Message(self)
+    }
+}
+
+#[derive(PartialEq,Clone,Default)]
+pub struct GetTDReportRequest {
+    // message fields
+    pub report_data: ::std::vec::Vec<u8>,
+    // special fields
+    pub unknown_fields: ::protobuf::UnknownFields,
+    pub cached_size: ::protobuf::CachedSize,
+}
+
+impl<'a> ::std::default::Default for &'a GetTDReportRequest {
+    fn default() -> &'a GetTDReportRequest {
+        <GetTDReportRequest as ::protobuf::Message>::default_instance()
+    }
+}
+
+impl GetTDReportRequest {
+    pub fn new() -> GetTDReportRequest {
+        ::std::default::Default::default()
+    }
+
+    // bytes report_data = 1;
+
+
+    pub fn get_report_data(&self) -> &[u8] {
+        &self.report_data
+    }
+    pub fn clear_report_data(&mut self) {
+        self.report_data.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_report_data(&mut self, v: ::std::vec::Vec<u8>) {
+        self.report_data = v;
+    }
+
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_report_data(&mut self) -> &mut ::std::vec::Vec<u8> {
+        &mut self.report_data
+    }
+
+    // Take field
+    pub fn take_report_data(&mut self) -> ::std::vec::Vec<u8> {
+        ::std::mem::replace(&mut self.report_data, ::std::vec::Vec::new())
+    }
+}
+
+impl ::protobuf::Message for GetTDReportRequest {
+    fn is_initialized(&self) -> bool {
+        true
+    }
+
+    fn merge_from(&mut self, is: &mut ::protobuf::CodedInputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        while !is.eof()? {
+            let (field_number, wire_type) = is.read_tag_unpack()?;
+            match field_number {
+                1 => {
+                    ::protobuf::rt::read_singular_proto3_bytes_into(wire_type, is, &mut self.report_data)?;
+                },
+                _ => {
+                    ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
+                },
+            };
+        }
+        ::std::result::Result::Ok(())
+    }
+
+    // Compute sizes of nested messages
+    #[allow(unused_variables)]
+    fn compute_size(&self) -> u32 {
+        let mut my_size = 0;
+        if !self.report_data.is_empty() {
+            my_size += ::protobuf::rt::bytes_size(1, &self.report_data);
+        }
+        my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
+        self.cached_size.set(my_size);
+        my_size
+    }
+
+    fn write_to_with_cached_sizes(&self, os: &mut ::protobuf::CodedOutputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        if !self.report_data.is_empty() {
+            os.write_bytes(1, &self.report_data)?;
+        }
+        os.write_unknown_fields(self.get_unknown_fields())?;
+        ::std::result::Result::Ok(())
+    }
+
+    fn get_cached_size(&self) -> u32 {
+        self.cached_size.get()
+    }
+
+    fn get_unknown_fields(&self) -> &::protobuf::UnknownFields {
+        &self.unknown_fields
+    }
+
+    fn mut_unknown_fields(&mut self) -> &mut ::protobuf::UnknownFields {
+        &mut self.unknown_fields
+    }
+
+    fn as_any(&self) -> &dyn (::std::any::Any) {
+        self as &dyn (::std::any::Any)
+    }
+    fn as_any_mut(&mut self) -> &mut dyn (::std::any::Any) {
+        self as &mut dyn (::std::any::Any)
+    }
+    fn into_any(self: Box<Self>) -> ::std::boxed::Box<dyn (::std::any::Any)> {
+        self
+    }
+
+    fn descriptor(&self) -> &'static ::protobuf::reflect::MessageDescriptor {
+        Self::descriptor_static()
+    }
+
+    fn new() -> GetTDReportRequest {
+        GetTDReportRequest::new()
+    }
+
+    fn descriptor_static() -> &'static ::protobuf::reflect::MessageDescriptor {
+        static mut descriptor: ::protobuf::lazy::Lazy<::protobuf::reflect::MessageDescriptor> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            descriptor.get(|| {
+                let mut fields = ::std::vec::Vec::new();
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeBytes>(
+                    "report_data",
+                    |m: &GetTDReportRequest| { &m.report_data },
+                    |m: &mut GetTDReportRequest| { &mut m.report_data },
+                ));
+                ::protobuf::reflect::MessageDescriptor::new_pb_name::<GetTDReportRequest>(
+                    "GetTDReportRequest",
+                    fields,
+                    file_descriptor_proto()
+                )
+            })
+        }
+    }
+
+    fn default_instance() -> &'static GetTDReportRequest {
+        static mut instance: ::protobuf::lazy::Lazy<GetTDReportRequest> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            instance.get(GetTDReportRequest::new)
+        }
+    }
+}
+
+impl ::protobuf::Clear for GetTDReportRequest {
+    fn clear(&mut self) {
+        self.report_data.clear();
+        self.unknown_fields.clear();
+    }
+}
+
+impl ::std::fmt::Debug for GetTDReportRequest {
+    fn fmt(&self, f: &mut ::std::fmt::Formatter<'_>) -> ::std::fmt::Result {
+        ::protobuf::text_format::fmt(self, f)
+    }
+}
+
+impl ::protobuf::reflect::ProtobufValue for GetTDReportRequest {
+    fn as_ref(&self) -> ::protobuf::reflect::ReflectValueRef {
+        ::protobuf::reflect::ReflectValueRef::Message(self)
+    }
+}
+
+#[derive(PartialEq,Clone,Default)]
+pub struct GetTDReportResponse {
+    // message fields
+    pub report: ::std::vec::Vec<u8>,
+    // special fields
+    pub unknown_fields: ::protobuf::UnknownFields,
+    pub cached_size: ::protobuf::CachedSize,
+}
+
+impl<'a> ::std::default::Default for &'a GetTDReportResponse {
+    fn default() -> &'a GetTDReportResponse {
+        <GetTDReportResponse as ::protobuf::Message>::default_instance()
+    }
+}
+
+impl GetTDReportResponse {
+    pub fn new() -> GetTDReportResponse {
+        ::std::default::Default::default()
+    }
+
+    // bytes report = 1;
+
+
+    pub fn get_report(&self) -> &[u8] {
+        &self.report
+    }
+    pub fn clear_report(&mut self) {
+        self.report.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_report(&mut self, v: ::std::vec::Vec<u8>) {
+        self.report = v;
+    }
+
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_report(&mut self) -> &mut ::std::vec::Vec<u8> {
+        &mut self.report
+    }
+
+    // Take field
+    pub fn take_report(&mut self) -> ::std::vec::Vec<u8> {
+        ::std::mem::replace(&mut self.report, ::std::vec::Vec::new())
+    }
+}
+
+impl ::protobuf::Message for GetTDReportResponse {
+    fn is_initialized(&self) -> bool {
+        true
+    }
+
+    fn merge_from(&mut self, is: &mut ::protobuf::CodedInputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        while !is.eof()? {
+            let (field_number, wire_type) = is.read_tag_unpack()?;
+            match field_number {
+                1 => {
+                    ::protobuf::rt::read_singular_proto3_bytes_into(wire_type, is, &mut self.report)?;
+                },
+                _ => {
+                    ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
+                },
+            };
+        }
+        ::std::result::Result::Ok(())
+    }
+
+    // Compute sizes of nested messages
+    #[allow(unused_variables)]
+    fn compute_size(&self) -> u32 {
+        let mut my_size = 0;
+        if !self.report.is_empty() {
+            my_size += ::protobuf::rt::bytes_size(1, &self.report);
+        }
+        my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
+        self.cached_size.set(my_size);
+        my_size
+    }
+
+    fn write_to_with_cached_sizes(&self, os: &mut ::protobuf::CodedOutputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        if !self.report.is_empty() {
+            os.write_bytes(1, &self.report)?;
+        }
+        os.write_unknown_fields(self.get_unknown_fields())?;
+        ::std::result::Result::Ok(())
+    }
+
+    fn get_cached_size(&self) -> u32 {
+        self.cached_size.get()
+    }
+
+    fn get_unknown_fields(&self) -> &::protobuf::UnknownFields {
+        &self.unknown_fields
+    }
+
+    fn mut_unknown_fields(&mut self) -> &mut ::protobuf::UnknownFields {
+        &mut self.unknown_fields
+    }
+
+    fn as_any(&self) -> &dyn (::std::any::Any) {
+        self as &dyn (::std::any::Any)
+    }
+    fn as_any_mut(&mut self) -> &mut dyn (::std::any::Any) {
+        self as &mut dyn (::std::any::Any)
+    }
+    fn into_any(self: Box<Self>) -> ::std::boxed::Box<dyn (::std::any::Any)> {
+        self
+    }
+
+    fn descriptor(&self) -> &'static ::protobuf::reflect::MessageDescriptor {
+        Self::descriptor_static()
+    }
+
+    fn new() -> GetTDReportResponse {
+        GetTDReportResponse::new()
+    }
+
+    fn descriptor_static() -> &'static ::protobuf::reflect::MessageDescriptor {
+        static mut descriptor: ::protobuf::lazy::Lazy<::protobuf::reflect::MessageDescriptor> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            descriptor.get(|| {
+                let mut fields = ::std::vec::Vec::new();
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeBytes>(
+                    "report",
+                    |m: &GetTDReportResponse| { &m.report },
+                    |m: &mut GetTDReportResponse| { &mut m.report },
+                ));
+                ::protobuf::reflect::MessageDescriptor::new_pb_name::<GetTDReportResponse>(
+                    "GetTDReportResponse",
+                    fields,
+                    file_descriptor_proto()
+                )
+            })
+        }
+    }
+
+    fn default_instance() -> &'static GetTDReportResponse {
+        static mut instance: ::protobuf::lazy::Lazy<GetTDReportResponse> = ::protobuf::lazy::Lazy::INIT;
+        unsafe {
+            instance.get(GetTDReportResponse::new)
+        }
+    }
+}
+
+impl ::protobuf::Clear for GetTDReportResponse {
+    fn clear(&mut self) {
+        self.report.clear();
+        self.unknown_fields.clear();
+    }
+}
+
+impl ::std::fmt::Debug for GetTDReportResponse {
+    fn fmt(&self, f: &mut ::std::fmt::Formatter<'_>) -> ::std::fmt::Result {
+        ::protobuf::text_format::fmt(self, f)
+    }
+}
+
+impl ::protobuf::reflect::ProtobufValue for GetTDReportResponse {
+    fn as_ref(&self) -> ::protobuf::reflect::ReflectValueRef {
+        ::protobuf::reflect::ReflectValueRef::Message(self)
+    }
+}
+
+#[derive(PartialEq,Clone,Default)]
+pub struct InstallFileRequest {
+    // message fields
+    pub source: ::std::string::String,
+    pub path: ::std::string::String,
+    // special fields
+    pub unknown_fields: ::protobuf::UnknownFields,
+    pub cached_size: ::protobuf::CachedSize,
+}
+
+impl<'a> ::std::default::Default for &'a InstallFileRequest {
+    fn default() -> &'a InstallFileRequest {
+        <InstallFileRequest as ::protobuf::Message>::default_instance()
+    }
+}
+
+impl InstallFileRequest {
+    pub fn new() -> InstallFileRequest {
+        ::std::default::Default::default()
+    }
+
+    // string source = 1;
+
+
+    pub fn get_source(&self) -> &str {
+        &self.source
+    }
+    pub fn clear_source(&mut self) {
+        self.source.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_source(&mut self, v: ::std::string::String) {
+        self.source = v;
+    }
+
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_source(&mut self) -> &mut ::std::string::String {
+        &mut self.source
+    }
+
+    // Take field
+    pub fn take_source(&mut self) -> ::std::string::String {
+        ::std::mem::replace(&mut self.source, ::std::string::String::new())
+    }
+
+    // string path = 2;
+
+
+    pub fn get_path(&self) -> &str {
+        &self.path
+    }
+    pub fn clear_path(&mut self) {
+        self.path.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_path(&mut self, v: ::std::string::String) {
+        self.path = v;
+    }
+
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_path(&mut self) -> &mut ::std::string::String {
+        &mut self.path
+    }
+
+    // Take field
+    pub fn take_path(&mut self) -> ::std::string::String {
+        ::std::mem::replace(&mut self.path, ::std::string::String::new())
+    }
+}
+
+impl ::protobuf::Message for InstallFileRequest {
+    fn is_initialized(&self) -> bool {
+        true
+    }
+
+    fn merge_from(&mut self, is: &mut ::protobuf::CodedInputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        while !is.eof()? {
+            let (field_number, wire_type) = is.read_tag_unpack()?;
+            match field_number {
+                1 => {
+                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.source)?;
+                },
+                2 => {
+                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.path)?;
+                },
+                _ => {
+                    ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
+                },
+            };
+        }
+        ::std::result::Result::Ok(())
+    }
+
+    // Compute sizes of nested messages
+    #[allow(unused_variables)]
+    fn compute_size(&self) -> u32 {
+        let mut my_size = 0;
+        if !self.source.is_empty() {
+            my_size += ::protobuf::rt::string_size(1, &self.source);
+        }
+        if !self.path.is_empty() {
+            my_size += ::protobuf::rt::string_size(2, &self.path);
+        }
+        my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
+        self.cached_size.set(my_size);
+        my_size
+    }
+
+    fn write_to_with_cached_sizes(&self, os: &mut ::protobuf::CodedOutputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        if !self.source.is_empty() {
+            os.write_string(1, &self.source)?;
+        }
+        if !self.path.is_empty() {
+            os.write_string(2, &self.path)?;
+        }
+        os.write_unknown_fields(self.get_unknown_fields())?;
+        ::std::result::Result::Ok(())
+    }
+
+    fn get_cached_size(&self) -> u32 {
+        self.cached_size.get()
+    }
+
+    fn get_unknown_fields(&self) -> &::protobuf::UnknownFields {
+        &self.unknown_fields
+    }
+
+    fn mut_unknown_fields(&mut self) -> &mut ::protobuf::UnknownFields {
+        &mut self.unknown_fields
     }
 
     fn as_any(&self) -> &dyn (::std::any::Any) {
@@ -12773,17 +14881,27 @@ impl ::protobuf::Message for StartTracingRequest {
         Self::descriptor_static()
     }
 
-    fn new() -> StartTracingRequest {
-        StartTracingRequest::new()
+    fn new() -> InstallFileRequest {
+        InstallFileRequest::new()
     }
 
     fn descriptor_static() -> &'static ::protobuf::reflect::MessageDescriptor {
         static mut descriptor: ::protobuf::lazy::Lazy<::protobuf::reflect::MessageDescriptor> = ::protobuf::lazy::Lazy::INIT;
         unsafe {
             descriptor.get(|| {
-                let fields = ::std::vec::Vec::new();
-                ::protobuf::reflect::MessageDescriptor::new_pb_name::<StartTracingRequest>(
-                    "StartTracingRequest",
+                let mut fields = ::std::vec::Vec::new();
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
+                    "source",
+                    |m: &InstallFileRequest| { &m.source },
+                    |m: &mut InstallFileRequest| { &mut m.source },
+                ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
+                    "path",
+                    |m: &InstallFileRequest| { &m.path },
+                    |m: &mut InstallFileRequest| { &mut m.path },
+                ));
+                ::protobuf::reflect::MessageDescriptor::new_pb_name::<InstallFileRequest>(
+                    "InstallFileRequest",
                     fields,
                     file_descriptor_proto()
                 )
@@ -12791,52 +14909,109 @@ impl ::protobuf::Message for StartTracingRequest {
         }
     }
 
-    fn default_instance() -> &'static StartTracingRequest {
-        static mut instance: ::protobuf::lazy::Lazy<StartTracingRequest> = ::protobuf::lazy::Lazy::INIT;
+    fn default_instance() -> &'static InstallFileRequest {
+        static mut instance: ::protobuf::lazy::Lazy<InstallFileRequest> = ::protobuf::lazy::Lazy::INIT;
         unsafe {
-            instance.get(StartTracingRequest::new)
+            instance.get(InstallFileRequest::new)
         }
     }
 }
 
-impl ::protobuf::Clear for StartTracingRequest {
+impl ::protobuf::Clear for InstallFileRequest {
     fn clear(&mut self) {
+        self.source.clear();
+        self.path.clear();
         self.unknown_fields.clear();
     }
 }
 
-impl ::std::fmt::Debug for StartTracingRequest {
+impl ::std::fmt::Debug for InstallFileRequest {
     fn fmt(&self, f: &mut ::std::fmt::Formatter<'_>) -> ::std::fmt::Result {
         ::protobuf::text_format::fmt(self, f)
     }
 }
 
-impl ::protobuf::reflect::ProtobufValue for StartTracingRequest {
+impl ::protobuf::reflect::ProtobufValue for InstallFileRequest {
     fn as_ref(&self) -> ::protobuf::reflect::ReflectValueRef {
         ::protobuf::reflect::ReflectValueRef::Message(self)
     }
 }
 
 #[derive(PartialEq,Clone,Default)]
-pub struct StopTracingRequest {
+pub struct LoadCrashKernelRequest {
+    // message fields
+    pub kernel: ::std::string::String,
+    pub initrd: ::std::string::String,
     // special fields
     pub unknown_fields: ::protobuf::UnknownFields,
     pub cached_size: ::protobuf::CachedSize,
 }
 
-impl<'a> ::std::default::Default for &'a StopTracingRequest {
-    fn default() -> &'a StopTracingRequest {
-        <StopTracingRequest as ::protobuf::Message>::default_instance()
+impl<'a> ::std::default::Default for &'a LoadCrashKernelRequest {
+    fn default() -> &'a LoadCrashKernelRequest {
+        <LoadCrashKernelRequest as ::protobuf::Message>::default_instance()
     }
 }
 
-impl StopTracingRequest {
-    pub fn new() -> StopTracingRequest {
+impl LoadCrashKernelRequest {
+    pub fn new() -> LoadCrashKernelRequest {
         ::std::default::Default::default()
     }
+
+    // string kernel = 1;
+
+
+    pub fn get_kernel(&self) -> &str {
+        &self.kernel
+    }
+    pub fn clear_kernel(&mut self) {
+        self.kernel.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_kernel(&mut self, v: ::std::string::String) {
+        self.kernel = v;
+    }
+
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_kernel(&mut self) -> &mut ::std::string::String {
+        &mut self.kernel
+    }
+
+    // Take field
+    pub fn take_kernel(&mut self) -> ::std::string::String {
+        ::std::mem::replace(&mut self.kernel, ::std::string::String::new())
+    }
+
+    // string initrd = 2;
+
+
+    pub fn get_initrd(&self) -> &str {
+        &self.initrd
+    }
+    pub fn clear_initrd(&mut self) {
+        self.initrd.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_initrd(&mut self, v: ::std::string::String) {
+        self.initrd = v;
+    }
+
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_initrd(&mut self) -> &mut ::std::string::String {
+        &mut self.initrd
+    }
+
+    // Take field
+    pub fn take_initrd(&mut self) -> ::std::string::String {
+        ::std::mem::replace(&mut self.initrd, ::std::string::String::new())
+    }
 }
 
-impl ::protobuf::Message for StopTracingRequest {
+impl ::protobuf::Message for LoadCrashKernelRequest {
     fn is_initialized(&self) -> bool {
         true
     }
@@ -12845,6 +15020,12 @@ impl ::protobuf::Message for StopTracingRequest {
         while !is.eof()? {
             let (field_number, wire_type) = is.read_tag_unpack()?;
             match field_number {
+                1 => {
+                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.kernel)?;
+                },
+                2 => {
+                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.initrd)?;
+                },
                 _ => {
                     ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
                 },
@@ -12857,12 +15038,24 @@ impl ::protobuf::Message for StopTracingRequest {
     #[allow(unused_variables)]
     fn compute_size(&self) -> u32 {
         let mut my_size = 0;
+        if !self.kernel.is_empty() {
+            my_size += ::protobuf::rt::string_size(1, &self.kernel);
+        }
+        if !self.initrd.is_empty() {
+            my_size += ::protobuf::rt::string_size(2, &self.initrd);
+        }
         my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
         self.cached_size.set(my_size);
         my_size
     }
 
     fn write_to_with_cached_sizes(&self, os: &mut ::protobuf::CodedOutputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        if !self.kernel.is_empty() {
+            os.write_string(1, &self.kernel)?;
+        }
+        if !self.initrd.is_empty() {
+            os.write_string(2, &self.initrd)?;
+        }
         os.write_unknown_fields(self.get_unknown_fields())?;
         ::std::result::Result::Ok(())
     }
@@ -12893,17 +15086,27 @@ impl ::protobuf::Message for StopTracingRequest {
         Self::descriptor_static()
     }
 
-    fn new() -> StopTracingRequest {
-        StopTracingRequest::new()
+    fn new() -> LoadCrashKernelRequest {
+        LoadCrashKernelRequest::new()
     }
 
     fn descriptor_static() -> &'static ::protobuf::reflect::MessageDescriptor {
         static mut descriptor: ::protobuf::lazy::Lazy<::protobuf::reflect::MessageDescriptor> = ::protobuf::lazy::Lazy::INIT;
         unsafe {
             descriptor.get(|| {
-                let fields = ::std::vec::Vec::new();
-                ::protobuf::reflect::MessageDescriptor::new_pb_name::<StopTracingRequest>(
-                    "StopTracingRequest",
+                let mut fields = ::std::vec::Vec::new();
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
+                    "kernel",
+                    |m: &LoadCrashKernelRequest| { &m.kernel },
+                    |m: &mut LoadCrashKernelRequest| { &mut m.kernel },
+                ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
+                    "initrd",
+                    |m: &LoadCrashKernelRequest| { &m.initrd },
+                    |m: &mut LoadCrashKernelRequest| { &mut m.initrd },
+                ));
+                ::protobuf::reflect::MessageDescriptor::new_pb_name::<LoadCrashKernelRequest>(
+                    "LoadCrashKernelRequest",
                     fields,
                     file_descriptor_proto()
                 )
@@ -12911,52 +15114,168 @@ impl ::protobuf::Message for StopTracingRequest {
         }
     }
 
-    fn default_instance() -> &'static StopTracingRequest {
-        static mut instance: ::protobuf::lazy::Lazy<StopTracingRequest> = ::protobuf::lazy::Lazy::INIT;
+    fn default_instance() -> &'static LoadCrashKernelRequest {
+        static mut instance: ::protobuf::lazy::Lazy<LoadCrashKernelRequest> = ::protobuf::lazy::Lazy::INIT;
         unsafe {
-            instance.get(StopTracingRequest::new)
+            instance.get(LoadCrashKernelRequest::new)
         }
     }
 }
 
-impl ::protobuf::Clear for StopTracingRequest {
+impl ::protobuf::Clear for LoadCrashKernelRequest {
     fn clear(&mut self) {
+        self.kernel.clear();
+        self.initrd.clear();
         self.unknown_fields.clear();
     }
 }
 
-impl ::std::fmt::Debug for StopTracingRequest {
+impl ::std::fmt::Debug for LoadCrashKernelRequest {
     fn fmt(&self, f: &mut ::std::fmt::Formatter<'_>) -> ::std::fmt::Result {
         ::protobuf::text_format::fmt(self, f)
     }
 }
 
-impl ::protobuf::reflect::ProtobufValue for StopTracingRequest {
+impl ::protobuf::reflect::ProtobufValue for LoadCrashKernelRequest {
     fn as_ref(&self) -> ::protobuf::reflect::ReflectValueRef {
         ::protobuf::reflect::ReflectValueRef::Message(self)
     }
 }
 
 #[derive(PartialEq,Clone,Default)]
-pub struct GetOOMEventRequest {
+pub struct StartProfilingRequest {
+    // message fields
+    pub session: ::std::string::String,
+    pub tool: ::std::string::String,
+    pub duration: u32,
+    pub frequency: u32,
+    pub script: ::std::string::String,
     // special fields
     pub unknown_fields: ::protobuf::UnknownFields,
     pub cached_size: ::protobuf::CachedSize,
 }
 
-impl<'a> ::std::default::Default for &'a GetOOMEventRequest {
-    fn default() -> &'a GetOOMEventRequest {
-        <GetOOMEventRequest as ::protobuf::Message>::default_instance()
+impl<'a> ::std::default::Default for &'a StartProfilingRequest {
+    fn default() -> &'a StartProfilingRequest {
+        <StartProfilingRequest as ::protobuf::Message>::default_instance()
     }
 }
 
-impl GetOOMEventRequest {
-    pub fn new() -> GetOOMEventRequest {
+impl StartProfilingRequest {
+    pub fn new() -> StartProfilingRequest {
         ::std::default::Default::default()
     }
+
+    // string session = 1;
+
+
+    pub fn get_session(&self) -> &str {
+        &self.session
+    }
+    pub fn clear_session(&mut self) {
+        self.session.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_session(&mut self, v: ::std::string::String) {
+        self.session = v;
+    }
+
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_session(&mut self) -> &mut ::std::string::String {
+        &mut self.session
+    }
+
+    // Take field
+    pub fn take_session(&mut self) -> ::std::string::String {
+        ::std::mem::replace(&mut self.session, ::std::string::String::new())
+    }
+
+    // string tool = 2;
+
+
+    pub fn get_tool(&self) -> &str {
+        &self.tool
+    }
+    pub fn clear_tool(&mut self) {
+        self.tool.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_tool(&mut self, v: ::std::string::String) {
+        self.tool = v;
+    }
+
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_tool(&mut self) -> &mut ::std::string::String {
+        &mut self.tool
+    }
+
+    // Take field
+    pub fn take_tool(&mut self) -> ::std::string::String {
+        ::std::mem::replace(&mut self.tool, ::std::string::String::new())
+    }
+
+    // uint32 duration = 3;
+
+
+    pub fn get_duration(&self) -> u32 {
+        self.duration
+    }
+    pub fn clear_duration(&mut self) {
+        self.duration = 0;
+    }
+
+    // Param is passed by value, moved
+    pub fn set_duration(&mut self, v: u32) {
+        self.duration = v;
+    }
+
+    // uint32 frequency = 4;
+
+
+    pub fn get_frequency(&self) -> u32 {
+        self.frequency
+    }
+    pub fn clear_frequency(&mut self) {
+        self.frequency = 0;
+    }
+
+    // Param is passed by value, moved
+    pub fn set_frequency(&mut self, v: u32) {
+        self.frequency = v;
+    }
+
+    // string script = 5;
+
+
+    pub fn get_script(&self) -> &str {
+        &self.script
+    }
+    pub fn clear_script(&mut self) {
+        self.script.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_script(&mut self, v: ::std::string::String) {
+        self.script = v;
+    }
+
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_script(&mut self) -> &mut ::std::string::String {
+        &mut self.script
+    }
+
+    // Take field
+    pub fn take_script(&mut self) -> ::std::string::String {
+        ::std::mem::replace(&mut self.script, ::std::string::String::new())
+    }
 }
 
-impl ::protobuf::Message for GetOOMEventRequest {
+impl ::protobuf::Message for StartProfilingRequest {
     fn is_initialized(&self) -> bool {
         true
     }
@@ -12965,6 +15284,29 @@ impl ::protobuf::Message for GetOOMEventRequest {
         while !is.eof()? {
             let (field_number, wire_type) = is.read_tag_unpack()?;
             match field_number {
+                1 => {
+                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.session)?;
+                },
+                2 => {
+                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.tool)?;
+                },
+                3 => {
+                    if wire_type != ::protobuf::wire_format::WireTypeVarint {
+                        return ::std::result::Result::Err(::protobuf::rt::unexpected_wire_type(wire_type));
+                    }
+                    let tmp = is.read_uint32()?;
+                    self.duration = tmp;
+                },
+                4 => {
+                    if wire_type != ::protobuf::wire_format::WireTypeVarint {
+                        return ::std::result::Result::Err(::protobuf::rt::unexpected_wire_type(wire_type));
+                    }
+                    let tmp = is.read_uint32()?;
+                    self.frequency = tmp;
+                },
+                5 => {
+                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.script)?;
+                },
                 _ => {
                     ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
                 },
@@ -12977,12 +15319,42 @@ impl ::protobuf::Message for GetOOMEventRequest {
     #[allow(unused_variables)]
     fn compute_size(&self) -> u32 {
         let mut my_size = 0;
+        if !self.session.is_empty() {
+            my_size += ::protobuf::rt::string_size(1, &self.session);
+        }
+        if !self.tool.is_empty() {
+            my_size += ::protobuf::rt::string_size(2, &self.tool);
+        }
+        if self.duration != 0 {
+            my_size += ::protobuf::rt::value_size(3, self.duration, ::protobuf::wire_format::WireTypeVarint);
+        }
+        if self.frequency != 0 {
+            my_size += ::protobuf::rt::value_size(4, self.frequency, ::protobuf::wire_format::WireTypeVarint);
+        }
+        if !self.script.is_empty() {
+            my_size += ::protobuf::rt::string_size(5, &self.script);
+        }
         my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
         self.cached_size.set(my_size);
         my_size
     }
 
     fn write_to_with_cached_sizes(&self, os: &mut ::protobuf::CodedOutputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        if !self.session.is_empty() {
+            os.write_string(1, &self.session)?;
+        }
+        if !self.tool.is_empty() {
+            os.write_string(2, &self.tool)?;
+        }
+        if self.duration != 0 {
+            os.write_uint32(3, self.duration)?;
+        }
+        if self.frequency != 0 {
+            os.write_uint32(4, self.frequency)?;
+        }
+        if !self.script.is_empty() {
+            os.write_string(5, &self.script)?;
+        }
         os.write_unknown_fields(self.get_unknown_fields())?;
         ::std::result::Result::Ok(())
     }
@@ -13013,17 +15385,42 @@ impl ::protobuf::Message for GetOOMEventRequest {
         Self::descriptor_static()
     }
 
-    fn new() -> GetOOMEventRequest {
-        GetOOMEventRequest::new()
+    fn new() -> StartProfilingRequest {
+        StartProfilingRequest::new()
     }
 
     fn descriptor_static() -> &'static ::protobuf::reflect::MessageDescriptor {
         static mut descriptor: ::protobuf::lazy::Lazy<::protobuf::reflect::MessageDescriptor> = ::protobuf::lazy::Lazy::INIT;
         unsafe {
             descriptor.get(|| {
-                let fields = ::std::vec::Vec::new();
-                ::protobuf::reflect::MessageDescriptor::new_pb_name::<GetOOMEventRequest>(
-                    "GetOOMEventRequest",
+                let mut fields = ::std::vec::Vec::new();
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
+                    "session",
+                    |m: &StartProfilingRequest| { &m.session },
+                    |m: &mut StartProfilingRequest| { &mut m.session },
+                ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
+                    "tool",
+                    |m: &StartProfilingRequest| { &m.tool },
+                    |m: &mut StartProfilingRequest| { &mut m.tool },
+                ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeUint32>(
+                    "duration",
+                    |m: &StartProfilingRequest| { &m.duration },
+                    |m: &mut StartProfilingRequest| { &mut m.duration },
+                ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeUint32>(
+                    "frequency",
+                    |m: &StartProfilingRequest| { &m.frequency },
+                    |m: &mut StartProfilingRequest| { &mut m.frequency },
+                ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
+                    "script",
+                    |m: &StartProfilingRequest| { &m.script },
+                    |m: &mut StartProfilingRequest| { &mut m.script },
+                ));
+                ::protobuf::reflect::MessageDescriptor::new_pb_name::<StartProfilingRequest>(
+                    "StartProfilingRequest",
                     fields,
                     file_descriptor_proto()
                 )
@@ -13031,80 +15428,112 @@ impl ::protobuf::Message for GetOOMEventRequest {
         }
     }
 
-    fn default_instance() -> &'static GetOOMEventRequest {
-        static mut instance: ::protobuf::lazy::Lazy<GetOOMEventRequest> = ::protobuf::lazy::Lazy::INIT;
+    fn default_instance() -> &'static StartProfilingRequest {
+        static mut instance: ::protobuf::lazy::Lazy<StartProfilingRequest> = ::protobuf::lazy::Lazy::INIT;
         unsafe {
-            instance.get(GetOOMEventRequest::new)
+            instance.get(StartProfilingRequest::new)
         }
     }
 }
 
-impl ::protobuf::Clear for GetOOMEventRequest {
+impl ::protobuf::Clear for StartProfilingRequest {
     fn clear(&mut self) {
+        self.session.clear();
+        self.tool.clear();
+        self.duration = 0;
+        self.frequency = 0;
+        self.script.clear();
         self.unknown_fields.clear();
     }
 }
 
-impl ::std::fmt::Debug for GetOOMEventRequest {
+impl ::std::fmt::Debug for StartProfilingRequest {
     fn fmt(&self, f: &mut ::std::fmt::Formatter<'_>) -> ::std::fmt::Result {
         ::protobuf::text_format::fmt(self, f)
     }
 }
 
-impl ::protobuf::reflect::ProtobufValue for GetOOMEventRequest {
+impl ::protobuf::reflect::ProtobufValue for StartProfilingRequest {
     fn as_ref(&self) -> ::protobuf::reflect::ReflectValueRef {
         ::protobuf::reflect::ReflectValueRef::Message(self)
     }
 }
 
 #[derive(PartialEq,Clone,Default)]
-pub struct OOMEvent {
+pub struct LoadLivepatchRequest {
     // message fields
-    pub container_id: ::std::string::String,
+    pub module: ::std::string::String,
+    pub path: ::std::string::String,
     // special fields
     pub unknown_fields: ::protobuf::UnknownFields,
     pub cached_size: ::protobuf::CachedSize,
 }
 
-impl<'a> ::std::default::Default for &'a OOMEvent {
-    fn default() -> &'a OOMEvent {
-        <OOMEvent as ::protobuf::Message>::default_instance()
+impl<'a> ::std::default::Default for &'a LoadLivepatchRequest {
+    fn default() -> &'a LoadLivepatchRequest {
+        <LoadLivepatchRequest as ::protobuf::Message>::default_instance()
     }
 }
 
-impl OOMEvent {
-    pub fn new() -> OOMEvent {
+impl LoadLivepatchRequest {
+    pub fn new() -> LoadLivepatchRequest {
         ::std::default::Default::default()
     }
 
-    // string container_id = 1;
+    // string module = 1;
 
 
-    pub fn get_container_id(&self) -> &str {
-        &self.container_id
+    pub fn get_module(&self) -> &str {
+        &self.module
     }
-    pub fn clear_container_id(&mut self) {
-        self.container_id.clear();
+    pub fn clear_module(&mut self) {
+        self.module.clear();
     }
 
     // Param is passed by value, moved
-    pub fn set_container_id(&mut self, v: ::std::string::String) {
-        self.container_id = v;
+    pub fn set_module(&mut self, v: ::std::string::String) {
+        self.module = v;
     }
 
     // Mutable pointer to the field.
     // If field is not initialized, it is initialized with default value first.
-    pub fn mut_container_id(&mut self) -> &mut ::std::string::String {
-        &mut self.container_id
+    pub fn mut_module(&mut self) -> &mut ::std::string::String {
+        &mut self.module
     }
 
     // Take field
-    pub fn take_container_id(&mut self) -> ::std::string::String {
-        ::std::mem::replace(&mut self.container_id, ::std::string::String::new())
+    pub fn take_module(&mut self) -> ::std::string::String {
+        ::std::mem::replace(&mut self.module, ::std::string::String::new())
+    }
+
+    // string path = 2;
+
+
+    pub fn get_path(&self) -> &str {
+        &self.path
+    }
+    pub fn clear_path(&mut self) {
+        self.path.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_path(&mut self, v: ::std::string::String) {
+        self.path = v;
+    }
+
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_path(&mut self) -> &mut ::std::string::String {
+        &mut self.path
+    }
+
+    // Take field
+    pub fn take_path(&mut self) -> ::std::string::String {
+        ::std::mem::replace(&mut self.path, ::std::string::String::new())
     }
 }
 
-impl ::protobuf::Message for OOMEvent {
+impl ::protobuf::Message for LoadLivepatchRequest {
     fn is_initialized(&self) -> bool {
         true
     }
@@ -13114,7 +15543,10 @@ impl ::protobuf::Message for OOMEvent {
             let (field_number, wire_type) = is.read_tag_unpack()?;
             match field_number {
                 1 => {
-                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.container_id)?;
+                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.module)?;
+                },
+                2 => {
+                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.path)?;
                 },
                 _ => {
                     ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
@@ -13128,8 +15560,11 @@ impl ::protobuf::Message for OOMEvent {
     #[allow(unused_variables)]
     fn compute_size(&self) -> u32 {
         let mut my_size = 0;
-        if !self.container_id.is_empty() {
-            my_size += ::protobuf::rt::string_size(1, &self.container_id);
+        if !self.module.is_empty() {
+            my_size += ::protobuf::rt::string_size(1, &self.module);
+        }
+        if !self.path.is_empty() {
+            my_size += ::protobuf::rt::string_size(2, &self.path);
         }
         my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
         self.cached_size.set(my_size);
@@ -13137,8 +15572,11 @@ impl ::protobuf::Message for OOMEvent {
     }
 
     fn write_to_with_cached_sizes(&self, os: &mut ::protobuf::CodedOutputStream<'_>) -> ::protobuf::ProtobufResult<()> {
-        if !self.container_id.is_empty() {
-            os.write_string(1, &self.container_id)?;
+        if !self.module.is_empty() {
+            os.write_string(1, &self.module)?;
+        }
+        if !self.path.is_empty() {
+            os.write_string(2, &self.path)?;
         }
         os.write_unknown_fields(self.get_unknown_fields())?;
         ::std::result::Result::Ok(())
@@ -13170,8 +15608,8 @@ impl ::protobuf::Message for OOMEvent {
         Self::descriptor_static()
     }
 
-    fn new() -> OOMEvent {
-        OOMEvent::new()
+    fn new() -> LoadLivepatchRequest {
+        LoadLivepatchRequest::new()
     }
 
     fn descriptor_static() -> &'static ::protobuf::reflect::MessageDescriptor {
@@ -13180,12 +15618,17 @@ impl ::protobuf::Message for OOMEvent {
             descriptor.get(|| {
                 let mut fields = ::std::vec::Vec::new();
                 fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
-                    "container_id",
-                    |m: &OOMEvent| { &m.container_id },
-                    |m: &mut OOMEvent| { &mut m.container_id },
+                    "module",
+                    |m: &LoadLivepatchRequest| { &m.module },
+                    |m: &mut LoadLivepatchRequest| { &mut m.module },
                 ));
-                ::protobuf::reflect::MessageDescriptor::new_pb_name::<OOMEvent>(
-                    "OOMEvent",
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
+                    "path",
+                    |m: &LoadLivepatchRequest| { &m.path },
+                    |m: &mut LoadLivepatchRequest| { &mut m.path },
+                ));
+                ::protobuf::reflect::MessageDescriptor::new_pb_name::<LoadLivepatchRequest>(
+                    "LoadLivepatchRequest",
                     fields,
                     file_descriptor_proto()
                 )
@@ -13193,53 +15636,125 @@ impl ::protobuf::Message for OOMEvent {
         }
     }
 
-    fn default_instance() -> &'static OOMEvent {
-        static mut instance: ::protobuf::lazy::Lazy<OOMEvent> = ::protobuf::lazy::Lazy::INIT;
+    fn default_instance() -> &'static LoadLivepatchRequest {
+        static mut instance: ::protobuf::lazy::Lazy<LoadLivepatchRequest> = ::protobuf::lazy::Lazy::INIT;
         unsafe {
-            instance.get(OOMEvent::new)
+            instance.get(LoadLivepatchRequest::new)
         }
     }
 }
 
-impl ::protobuf::Clear for OOMEvent {
+impl ::protobuf::Clear for LoadLivepatchRequest {
     fn clear(&mut self) {
-        self.container_id.clear();
+        self.module.clear();
+        self.path.clear();
         self.unknown_fields.clear();
     }
 }
 
-impl ::std::fmt::Debug for OOMEvent {
+impl ::std::fmt::Debug for LoadLivepatchRequest {
     fn fmt(&self, f: &mut ::std::fmt::Formatter<'_>) -> ::std::fmt::Result {
         ::protobuf::text_format::fmt(self, f)
     }
 }
 
-impl ::protobuf::reflect::ProtobufValue for OOMEvent {
+impl ::protobuf::reflect::ProtobufValue for LoadLivepatchRequest {
     fn as_ref(&self) -> ::protobuf::reflect::ReflectValueRef {
         ::protobuf::reflect::ReflectValueRef::Message(self)
     }
 }
 
 #[derive(PartialEq,Clone,Default)]
-pub struct GetMetricsRequest {
+pub struct CheckpointContainerRequest {
+    // message fields
+    pub container_id: ::std::string::String,
+    pub images_dir: ::std::string::String,
+    pub leave_running: bool,
     // special fields
     pub unknown_fields: ::protobuf::UnknownFields,
     pub cached_size: ::protobuf::CachedSize,
 }
 
-impl<'a> ::std::default::Default for &'a GetMetricsRequest {
-    fn default() -> &'a GetMetricsRequest {
-        <GetMetricsRequest as ::protobuf::Message>::default_instance()
+impl<'a> ::std::default::Default for &'a CheckpointContainerRequest {
+    fn default() -> &'a CheckpointContainerRequest {
+        <CheckpointContainerRequest as ::protobuf::Message>::default_instance()
     }
 }
 
-impl GetMetricsRequest {
-    pub fn new() -> GetMetricsRequest {
+impl CheckpointContainerRequest {
+    pub fn new() -> CheckpointContainerRequest {
         ::std::default::Default::default()
     }
+
+    // string container_id = 1;
+
+
+    pub fn get_container_id(&self) -> &str {
+        &self.container_id
+    }
+    pub fn clear_container_id(&mut self) {
+        self.container_id.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_container_id(&mut self, v: ::std::string::String) {
+        self.container_id = v;
+    }
+
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_container_id(&mut self) -> &mut ::std::string::String {
+        &mut self.container_id
+    }
+
+    // Take field
+    pub fn take_container_id(&mut self) -> ::std::string::String {
+        ::std::mem::replace(&mut self.container_id, ::std::string::String::new())
+    }
+
+    // string images_dir = 2;
+
+
+    pub fn get_images_dir(&self) -> &str {
+        &self.images_dir
+    }
+    pub fn clear_images_dir(&mut self) {
+        self.images_dir.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_images_dir(&mut self, v: ::std::string::String) {
+        self.images_dir = v;
+    }
+
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_images_dir(&mut self) -> &mut ::std::string::String {
+        &mut self.images_dir
+    }
+
+    // Take field
+    pub fn take_images_dir(&mut self) -> ::std::string::String {
+        ::std::mem::replace(&mut self.images_dir, ::std::string::String::new())
+    }
+
+    // bool leave_running = 3;
+
+
+    pub fn get_leave_running(&self) -> bool {
+        self.leave_running
+    }
+    pub fn clear_leave_running(&mut self) {
+        self.leave_running = false;
+    }
+
+    // Param is passed by value, moved
+    pub fn set_leave_running(&mut self, v: bool) {
+        self.leave_running = v;
+    }
 }
 
-impl ::protobuf::Message for GetMetricsRequest {
+impl ::protobuf::Message for CheckpointContainerRequest {
     fn is_initialized(&self) -> bool {
         true
     }
@@ -13248,6 +15763,19 @@ impl ::protobuf::Message for GetMetricsRequest {
         while !is.eof()? {
             let (field_number, wire_type) = is.read_tag_unpack()?;
             match field_number {
+                1 => {
+                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.container_id)?;
+                },
+                2 => {
+                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.images_dir)?;
+                },
+                3 => {
+                    if wire_type != ::protobuf::wire_format::WireTypeVarint {
+                        return ::std::result::Result::Err(::protobuf::rt::unexpected_wire_type(wire_type));
+                    }
+                    let tmp = is.read_bool()?;
+                    self.leave_running = tmp;
+                },
                 _ => {
                     ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
                 },
@@ -13260,12 +15788,30 @@ impl ::protobuf::Message for GetMetricsRequest {
     #[allow(unused_variables)]
     fn compute_size(&self) -> u32 {
         let mut my_size = 0;
+        if !self.container_id.is_empty() {
+            my_size += ::protobuf::rt::string_size(1, &self.container_id);
+        }
+        if !self.images_dir.is_empty() {
+            my_size += ::protobuf::rt::string_size(2, &self.images_dir);
+        }
+        if self.leave_running != false {
+            my_size += 2;
+        }
         my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
         self.cached_size.set(my_size);
         my_size
     }
 
     fn write_to_with_cached_sizes(&self, os: &mut ::protobuf::CodedOutputStream<'_>) -> ::protobuf::ProtobufResult<()> {
+        if !self.container_id.is_empty() {
+            os.write_string(1, &self.container_id)?;
+        }
+        if !self.images_dir.is_empty() {
+            os.write_string(2, &self.images_dir)?;
+        }
+        if self.leave_running != false {
+            os.write_bool(3, self.leave_running)?;
+        }
         os.write_unknown_fields(self.get_unknown_fields())?;
         ::std::result::Result::Ok(())
     }
@@ -13296,17 +15842,32 @@ impl ::protobuf::Message for GetMetricsRequest {
         Self::descriptor_static()
     }
 
-    fn new() -> GetMetricsRequest {
-        GetMetricsRequest::new()
+    fn new() -> CheckpointContainerRequest {
+        CheckpointContainerRequest::new()
     }
 
     fn descriptor_static() -> &'static ::protobuf::reflect::MessageDescriptor {
         static mut descriptor: ::protobuf::lazy::Lazy<::protobuf::reflect::MessageDescriptor> = ::protobuf::lazy::Lazy::INIT;
         unsafe {
             descriptor.get(|| {
-                let fields = ::std::vec::Vec::new();
-                ::protobuf::reflect::MessageDescriptor::new_pb_name::<GetMetricsRequest>(
-                    "GetMetricsRequest",
+                let mut fields = ::std::vec::Vec::new();
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
+                    "container_id",
+                    |m: &CheckpointContainerRequest| { &m.container_id },
+                    |m: &mut CheckpointContainerRequest| { &mut m.container_id },
+                ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
+                    "images_dir",
+                    |m: &CheckpointContainerRequest| { &m.images_dir },
+                    |m: &mut CheckpointContainerRequest| { &mut m.images_dir },
+                ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeBool>(
+                    "leave_running",
+                    |m: &CheckpointContainerRequest| { &m.leave_running },
+                    |m: &mut CheckpointContainerRequest| { &mut m.leave_running },
+                ));
+                ::protobuf::reflect::MessageDescriptor::new_pb_name::<CheckpointContainerRequest>(
+                    "CheckpointContainerRequest",
                     fields,
                     file_descriptor_proto()
                 )
@@ -13314,80 +15875,110 @@ impl ::protobuf::Message for GetMetricsRequest {
         }
     }
 
-    fn default_instance() -> &'static GetMetricsRequest {
-        static mut instance: ::protobuf::lazy::Lazy<GetMetricsRequest> = ::protobuf::lazy::Lazy::INIT;
+    fn default_instance() -> &'static CheckpointContainerRequest {
+        static mut instance: ::protobuf::lazy::Lazy<CheckpointContainerRequest> = ::protobuf::lazy::Lazy::INIT;
         unsafe {
-            instance.get(GetMetricsRequest::new)
+            instance.get(CheckpointContainerRequest::new)
         }
     }
 }
 
-impl ::protobuf::Clear for GetMetricsRequest {
+impl ::protobuf::Clear for CheckpointContainerRequest {
     fn clear(&mut self) {
+        self.container_id.clear();
+        self.images_dir.clear();
+        self.leave_running = false;
         self.unknown_fields.clear();
     }
 }
 
-impl ::std::fmt::Debug for GetMetricsRequest {
+impl ::std::fmt::Debug for CheckpointContainerRequest {
     fn fmt(&self, f: &mut ::std::fmt::Formatter<'_>) -> ::std::fmt::Result {
         ::protobuf::text_format::fmt(self, f)
     }
 }
 
-impl ::protobuf::reflect::ProtobufValue for GetMetricsRequest {
+impl ::protobuf::reflect::ProtobufValue for CheckpointContainerRequest {
     fn as_ref(&self) -> ::protobuf::reflect::ReflectValueRef {
         ::protobuf::reflect::ReflectValueRef::Message(self)
     }
 }
 
 #[derive(PartialEq,Clone,Default)]
-pub struct Metrics {
+pub struct RestoreContainerRequest {
     // message fields
-    pub metrics: ::std::string::String,
+    pub container_id: ::std::string::String,
+    pub images_dir: ::std::string::String,
     // special fields
     pub unknown_fields: ::protobuf::UnknownFields,
     pub cached_size: ::protobuf::CachedSize,
 }
 
-impl<'a> ::std::default::Default for &'a Metrics {
-    fn default() -> &'a Metrics {
-        <Metrics as ::protobuf::Message>::default_instance()
+impl<'a> ::std::default::Default for &'a RestoreContainerRequest {
+    fn default() -> &'a RestoreContainerRequest {
+        <RestoreContainerRequest as ::protobuf::Message>::default_instance()
     }
 }
 
-impl Metrics {
-    pub fn new() -> Metrics {
+impl RestoreContainerRequest {
+    pub fn new() -> RestoreContainerRequest {
         ::std::default::Default::default()
     }
 
-    // string metrics = 1;
+    // string container_id = 1;
 
 
-    pub fn get_metrics(&self) -> &str {
-        &self.metrics
+    pub fn get_container_id(&self) -> &str {
+        &self.container_id
     }
-    pub fn clear_metrics(&mut self) {
-        self.metrics.clear();
+    pub fn clear_container_id(&mut self) {
+        self.container_id.clear();
     }
 
     // Param is passed by value, moved
-    pub fn set_metrics(&mut self, v: ::std::string::String) {
-        self.metrics = v;
+    pub fn set_container_id(&mut self, v: ::std::string::String) {
+        self.container_id = v;
     }
 
     // Mutable pointer to the field.
     // If field is not initialized, it is initialized with default value first.
-    pub fn mut_metrics(&mut self) -> &mut ::std::string::String {
-        &mut self.metrics
+    pub fn mut_container_id(&mut self) -> &mut ::std::string::String {
+        &mut self.container_id
     }
 
     // Take field
-    pub fn take_metrics(&mut self) -> ::std::string::String {
-        ::std::mem::replace(&mut self.metrics, ::std::string::String::new())
+    pub fn take_container_id(&mut self) -> ::std::string::String {
+        ::std::mem::replace(&mut self.container_id, ::std::string::String::new())
+    }
+
+    // string images_dir = 2;
+
+
+    pub fn get_images_dir(&self) -> &str {
+        &self.images_dir
+    }
+    pub fn clear_images_dir(&mut self) {
+        self.images_dir.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_images_dir(&mut self, v: ::std::string::String) {
+        self.images_dir = v;
+    }
+
+    // Mutable pointer to the field.
+    // If field is not initialized, it is initialized with default value first.
+    pub fn mut_images_dir(&mut self) -> &mut ::std::string::String {
+        &mut self.images_dir
+    }
+
+    // Take field
+    pub fn take_images_dir(&mut self) -> ::std::string::String {
+        ::std::mem::replace(&mut self.images_dir, ::std::string::String::new())
     }
 }
 
-impl ::protobuf::Message for Metrics {
+impl ::protobuf::Message for RestoreContainerRequest {
     fn is_initialized(&self) -> bool {
         true
     }
@@ -13397,7 +15988,10 @@ impl ::protobuf::Message for Metrics {
             let (field_number, wire_type) = is.read_tag_unpack()?;
             match field_number {
                 1 => {
-                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.metrics)?;
+                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.container_id)?;
+                },
+                2 => {
+                    ::protobuf::rt::read_singular_proto3_string_into(wire_type, is, &mut self.images_dir)?;
                 },
                 _ => {
                     ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
@@ -13411,8 +16005,11 @@ impl ::protobuf::Message for Metrics {
     #[allow(unused_variables)]
     fn compute_size(&self) -> u32 {
         let mut my_size = 0;
-        if !self.metrics.is_empty() {
-            my_size += ::protobuf::rt::string_size(1, &self.metrics);
+        if !self.container_id.is_empty() {
+            my_size += ::protobuf::rt::string_size(1, &self.container_id);
+        }
+        if !self.images_dir.is_empty() {
+            my_size += ::protobuf::rt::string_size(2, &self.images_dir);
         }
         my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
         self.cached_size.set(my_size);
@@ -13420,8 +16017,11 @@ impl ::protobuf::Message for Metrics {
     }
 
     fn write_to_with_cached_sizes(&self, os: &mut ::protobuf::CodedOutputStream<'_>) -> ::protobuf::ProtobufResult<()> {
-        if !self.metrics.is_empty() {
-            os.write_string(1, &self.metrics)?;
+        if !self.container_id.is_empty() {
+            os.write_string(1, &self.container_id)?;
+        }
+        if !self.images_dir.is_empty() {
+            os.write_string(2, &self.images_dir)?;
         }
         os.write_unknown_fields(self.get_unknown_fields())?;
         ::std::result::Result::Ok(())
@@ -13453,8 +16053,8 @@ impl ::protobuf::Message for Metrics {
         Self::descriptor_static()
     }
 
-    fn new() -> Metrics {
-        Metrics::new()
+    fn new() -> RestoreContainerRequest {
+        RestoreContainerRequest::new()
     }
 
     fn descriptor_static() -> &'static ::protobuf::reflect::MessageDescriptor {
@@ -13463,12 +16063,17 @@ impl ::protobuf::Message for Metrics {
             descriptor.get(|| {
                 let mut fields = ::std::vec::Vec::new();
                 fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
-                    "metrics",
-                    |m: &Metrics| { &m.metrics },
-                    |m: &mut Metrics| { &mut m.metrics },
+                    "container_id",
+                    |m: &RestoreContainerRequest| { &m.container_id },
+                    |m: &mut RestoreContainerRequest| { &mut m.container_id },
                 ));
-                ::protobuf::reflect::MessageDescriptor::new_pb_name::<Metrics>(
-                    "Metrics",
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
+                    "images_dir",
+                    |m: &RestoreContainerRequest| { &m.images_dir },
+                    |m: &mut RestoreContainerRequest| { &mut m.images_dir },
+                ));
+                ::protobuf::reflect::MessageDescriptor::new_pb_name::<RestoreContainerRequest>(
+                    "RestoreContainerRequest",
                     fields,
                     file_descriptor_proto()
                 )
@@ -13476,28 +16081,29 @@ impl ::protobuf::Message for Metrics {
         }
     }
 
-    fn default_instance() -> &'static Metrics {
-        static mut instance: ::protobuf::lazy::Lazy<Metrics> = ::protobuf::lazy::Lazy::INIT;
+    fn default_instance() -> &'static RestoreContainerRequest {
+        static mut instance: ::protobuf::lazy::Lazy<RestoreContainerRequest> = ::protobuf::lazy::Lazy::INIT;
         unsafe {
-            instance.get(Metrics::new)
+            instance.get(RestoreContainerRequest::new)
         }
     }
 }
 
-impl ::protobuf::Clear for Metrics {
+impl ::protobuf::Clear for RestoreContainerRequest {
     fn clear(&mut self) {
-        self.metrics.clear();
+        self.container_id.clear();
+        self.images_dir.clear();
         self.unknown_fields.clear();
     }
 }
 
-impl ::std::fmt::Debug for Metrics {
+impl ::std::fmt::Debug for RestoreContainerRequest {
     fn fmt(&self, f: &mut ::std::fmt::Formatter<'_>) -> ::std::fmt::Result {
         ::protobuf::text_format::fmt(self, f)
     }
 }
 
-impl ::protobuf::reflect::ProtobufValue for Metrics {
+impl ::protobuf::reflect::ProtobufValue for RestoreContainerRequest {
     fn as_ref(&self) -> ::protobuf::reflect::ReflectValueRef {
         ::protobuf::reflect::ReflectValueRef::Message(self)
     }
@@ -13547,172 +16153,212 @@ static file_descriptor_proto_data: &'static [u8] = b"\
     \x04R\x10throttledPeriods\x12%\n\x0ethrottled_time\x18\x03\x20\x01(\x04R\
     \rthrottledTime\"v\n\x08CpuStats\x12+\n\tcpu_usage\x18\x01\x20\x01(\x0b2\
     \x0e.grpc.CpuUsageR\x08cpuUsage\x12=\n\x0fthrottling_data\x18\x02\x20\
-    \x01(\x0b2\x14.grpc.ThrottlingDataR\x0ethrottlingData\";\n\tPidsStats\
+    \x01(\x0b2\x14.grpc.ThrottlingDataR\x0ethrottlingData\"U\n\tPidsStats\
     \x12\x18\n\x07current\x18\x01\x20\x01(\x04R\x07current\x12\x14\n\x05limi\
-    t\x18\x02\x20\x01(\x04R\x05limit\"o\n\nMemoryData\x12\x14\n\x05usage\x18\
-    \x01\x20\x01(\x04R\x05usage\x12\x1b\n\tmax_usage\x18\x02\x20\x01(\x04R\
-    \x08maxUsage\x12\x18\n\x07failcnt\x18\x03\x20\x01(\x04R\x07failcnt\x12\
-    \x14\n\x05limit\x18\x04\x20\x01(\x04R\x05limit\"\xc4\x02\n\x0bMemoryStat\
-    s\x12\x14\n\x05cache\x18\x01\x20\x01(\x04R\x05cache\x12&\n\x05usage\x18\
-    \x02\x20\x01(\x0b2\x10.grpc.MemoryDataR\x05usage\x12/\n\nswap_usage\x18\
-    \x03\x20\x01(\x0b2\x10.grpc.MemoryDataR\tswapUsage\x123\n\x0ckernel_usag\
-    e\x18\x04\x20\x01(\x0b2\x10.grpc.MemoryDataR\x0bkernelUsage\x12#\n\ruse_\
-    hierarchy\x18\x05\x20\x01(\x08R\x0cuseHierarchy\x122\n\x05stats\x18\x06\
-    \x20\x03(\x0b2\x1c.grpc.MemoryStats.StatsEntryR\x05stats\x1a8\n\nStatsEn\
-    try\x12\x10\n\x03key\x18\x01\x20\x01(\tR\x03key\x12\x14\n\x05value\x18\
-    \x02\x20\x01(\x04R\x05value:\x028\x01\"c\n\x0fBlkioStatsEntry\x12\x14\n\
-    \x05major\x18\x01\x20\x01(\x04R\x05major\x12\x14\n\x05minor\x18\x02\x20\
-    \x01(\x04R\x05minor\x12\x0e\n\x02op\x18\x03\x20\x01(\tR\x02op\x12\x14\n\
-    \x05value\x18\x04\x20\x01(\x04R\x05value\"\xde\x04\n\nBlkioStats\x12R\n\
-    \x1aio_service_bytes_recursive\x18\x01\x20\x03(\x0b2\x15.grpc.BlkioStats\
-    EntryR\x17ioServiceBytesRecursive\x12I\n\x15io_serviced_recursive\x18\
-    \x02\x20\x03(\x0b2\x15.grpc.BlkioStatsEntryR\x13ioServicedRecursive\x12E\
-    \n\x13io_queued_recursive\x18\x03\x20\x03(\x0b2\x15.grpc.BlkioStatsEntry\
-    R\x11ioQueuedRecursive\x12P\n\x19io_service_time_recursive\x18\x04\x20\
-    \x03(\x0b2\x15.grpc.BlkioStatsEntryR\x16ioServiceTimeRecursive\x12J\n\
-    \x16io_wait_time_recursive\x18\x05\x20\x03(\x0b2\x15.grpc.BlkioStatsEntr\
-    yR\x13ioWaitTimeRecursive\x12E\n\x13io_merged_recursive\x18\x06\x20\x03(\
-    \x0b2\x15.grpc.BlkioStatsEntryR\x11ioMergedRecursive\x12A\n\x11io_time_r\
-    ecursive\x18\x07\x20\x03(\x0b2\x15.grpc.BlkioStatsEntryR\x0fioTimeRecurs\
-    ive\x12B\n\x11sectors_recursive\x18\x08\x20\x03(\x0b2\x15.grpc.BlkioStat\
-    sEntryR\x10sectorsRecursive\"[\n\x0cHugetlbStats\x12\x14\n\x05usage\x18\
-    \x01\x20\x01(\x04R\x05usage\x12\x1b\n\tmax_usage\x18\x02\x20\x01(\x04R\
-    \x08maxUsage\x12\x18\n\x07failcnt\x18\x03\x20\x01(\x04R\x07failcnt\"\xf2\
-    \x02\n\x0bCgroupStats\x12+\n\tcpu_stats\x18\x01\x20\x01(\x0b2\x0e.grpc.C\
-    puStatsR\x08cpuStats\x124\n\x0cmemory_stats\x18\x02\x20\x01(\x0b2\x11.gr\
-    pc.MemoryStatsR\x0bmemoryStats\x12.\n\npids_stats\x18\x03\x20\x01(\x0b2\
-    \x0f.grpc.PidsStatsR\tpidsStats\x121\n\x0bblkio_stats\x18\x04\x20\x01(\
-    \x0b2\x10.grpc.BlkioStatsR\nblkioStats\x12H\n\rhugetlb_stats\x18\x05\x20\
-    \x03(\x0b2#.grpc.CgroupStats.HugetlbStatsEntryR\x0chugetlbStats\x1aS\n\
-    \x11HugetlbStatsEntry\x12\x10\n\x03key\x18\x01\x20\x01(\tR\x03key\x12(\n\
-    \x05value\x18\x02\x20\x01(\x0b2\x12.grpc.HugetlbStatsR\x05value:\x028\
-    \x01\"\x8e\x02\n\x0cNetworkStats\x12\x12\n\x04name\x18\x01\x20\x01(\tR\
-    \x04name\x12\x19\n\x08rx_bytes\x18\x02\x20\x01(\x04R\x07rxBytes\x12\x1d\
-    \n\nrx_packets\x18\x03\x20\x01(\x04R\trxPackets\x12\x1b\n\trx_errors\x18\
-    \x04\x20\x01(\x04R\x08rxErrors\x12\x1d\n\nrx_dropped\x18\x05\x20\x01(\
-    \x04R\trxDropped\x12\x19\n\x08tx_bytes\x18\x06\x20\x01(\x04R\x07txBytes\
-    \x12\x1d\n\ntx_packets\x18\x07\x20\x01(\x04R\ttxPackets\x12\x1b\n\ttx_er\
-    rors\x18\x08\x20\x01(\x04R\x08txErrors\x12\x1d\n\ntx_dropped\x18\t\x20\
-    \x01(\x04R\ttxDropped\"\x87\x01\n\x16StatsContainerResponse\x124\n\x0ccg\
-    roup_stats\x18\x01\x20\x01(\x0b2\x11.grpc.CgroupStatsR\x0bcgroupStats\
-    \x127\n\rnetwork_stats\x18\x02\x20\x03(\x0b2\x12.grpc.NetworkStatsR\x0cn\
-    etworkStats\"d\n\x12WriteStreamRequest\x12!\n\x0ccontainer_id\x18\x01\
-    \x20\x01(\tR\x0bcontainerId\x12\x17\n\x07exec_id\x18\x02\x20\x01(\tR\x06\
-    execId\x12\x12\n\x04data\x18\x03\x20\x01(\x0cR\x04data\"'\n\x13WriteStre\
-    amResponse\x12\x10\n\x03len\x18\x01\x20\x01(\rR\x03len\"a\n\x11ReadStrea\
-    mRequest\x12!\n\x0ccontainer_id\x18\x01\x20\x01(\tR\x0bcontainerId\x12\
-    \x17\n\x07exec_id\x18\x02\x20\x01(\tR\x06execId\x12\x10\n\x03len\x18\x03\
-    \x20\x01(\rR\x03len\"(\n\x12ReadStreamResponse\x12\x12\n\x04data\x18\x01\
-    \x20\x01(\x0cR\x04data\"O\n\x11CloseStdinRequest\x12!\n\x0ccontainer_id\
-    \x18\x01\x20\x01(\tR\x0bcontainerId\x12\x17\n\x07exec_id\x18\x02\x20\x01\
-    (\tR\x06execId\"{\n\x13TtyWinResizeRequest\x12!\n\x0ccontainer_id\x18\
-    \x01\x20\x01(\tR\x0bcontainerId\x12\x17\n\x07exec_id\x18\x02\x20\x01(\tR\
-    \x06execId\x12\x10\n\x03row\x18\x03\x20\x01(\rR\x03row\x12\x16\n\x06colu\
-    mn\x18\x04\x20\x01(\rR\x06column\"B\n\x0cKernelModule\x12\x12\n\x04name\
-    \x18\x01\x20\x01(\tR\x04name\x12\x1e\n\nparameters\x18\x02\x20\x03(\tR\n\
-    parameters\"\x96\x02\n\x14CreateSandboxRequest\x12\x1a\n\x08hostname\x18\
-    \x01\x20\x01(\tR\x08hostname\x12\x10\n\x03dns\x18\x02\x20\x03(\tR\x03dns\
-    \x12)\n\x08storages\x18\x03\x20\x03(\x0b2\r.grpc.StorageR\x08storages\
-    \x12#\n\rsandbox_pidns\x18\x04\x20\x01(\x08R\x0csandboxPidns\x12\x1d\n\n\
-    sandbox_id\x18\x05\x20\x01(\tR\tsandboxId\x12&\n\x0fguest_hook_path\x18\
-    \x06\x20\x01(\tR\rguestHookPath\x129\n\x0ekernel_modules\x18\x07\x20\x03\
-    (\x0b2\x12.grpc.KernelModuleR\rkernelModules\"\x17\n\x15DestroySandboxRe\
-    quest\">\n\nInterfaces\x120\n\nInterfaces\x18\x01\x20\x03(\x0b2\x10.type\
-    s.InterfaceR\nInterfaces\".\n\x06Routes\x12$\n\x06Routes\x18\x01\x20\x03\
-    (\x0b2\x0c.types.RouteR\x06Routes\"H\n\x16UpdateInterfaceRequest\x12.\n\
-    \tinterface\x18\x01\x20\x01(\x0b2\x10.types.InterfaceR\tinterface\";\n\
-    \x13UpdateRoutesRequest\x12$\n\x06routes\x18\x01\x20\x01(\x0b2\x0c.grpc.\
-    RoutesR\x06routes\"\x17\n\x15ListInterfacesRequest\"\x13\n\x11ListRoutes\
-    Request\"F\n\x0cARPNeighbors\x126\n\x0cARPNeighbors\x18\x01\x20\x03(\x0b\
-    2\x12.types.ARPNeighborR\x0cARPNeighbors\"J\n\x16AddARPNeighborsRequest\
-    \x120\n\tneighbors\x18\x01\x20\x01(\x0b2\x12.grpc.ARPNeighborsR\tneighbo\
-    rs\"]\n\x13OnlineCPUMemRequest\x12\x12\n\x04wait\x18\x01\x20\x01(\x08R\
-    \x04wait\x12\x17\n\x07nb_cpus\x18\x02\x20\x01(\rR\x06nbCpus\x12\x19\n\
-    \x08cpu_only\x18\x03\x20\x01(\x08R\x07cpuOnly\",\n\x16ReseedRandomDevReq\
-    uest\x12\x12\n\x04data\x18\x02\x20\x01(\x0cR\x04data\"\xc8\x01\n\x0cAgen\
-    tDetails\x12\x18\n\x07version\x18\x01\x20\x01(\tR\x07version\x12\x1f\n\
-    \x0binit_daemon\x18\x02\x20\x01(\x08R\ninitDaemon\x12'\n\x0fdevice_handl\
-    ers\x18\x03\x20\x03(\tR\x0edeviceHandlers\x12)\n\x10storage_handlers\x18\
-    \x04\x20\x03(\tR\x0fstorageHandlers\x12)\n\x10supports_seccomp\x18\x05\
-    \x20\x01(\x08R\x0fsupportsSeccomp\"g\n\x13GuestDetailsRequest\x12$\n\x0e\
-    mem_block_size\x18\x01\x20\x01(\x08R\x0cmemBlockSize\x12*\n\x11mem_hotpl\
-    ug_probe\x18\x02\x20\x01(\x08R\x0fmemHotplugProbe\"\xbb\x01\n\x14GuestDe\
-    tailsResponse\x12/\n\x14mem_block_size_bytes\x18\x01\x20\x01(\x04R\x11me\
-    mBlockSizeBytes\x127\n\ragent_details\x18\x02\x20\x01(\x0b2\x12.grpc.Age\
-    ntDetailsR\x0cagentDetails\x129\n\x19support_mem_hotplug_probe\x18\x03\
-    \x20\x01(\x08R\x16supportMemHotplugProbe\"L\n\x18MemHotplugByProbeReques\
-    t\x120\n\x13memHotplugProbeAddr\x18\x01\x20\x03(\x04R\x13memHotplugProbe\
-    Addr\"?\n\x17SetGuestDateTimeRequest\x12\x10\n\x03Sec\x18\x01\x20\x01(\
-    \x03R\x03Sec\x12\x12\n\x04Usec\x18\x02\x20\x01(\x03R\x04Usec\"\xb3\x01\n\
-    \x07Storage\x12\x16\n\x06driver\x18\x01\x20\x01(\tR\x06driver\x12%\n\x0e\
-    driver_options\x18\x02\x20\x03(\tR\rdriverOptions\x12\x16\n\x06source\
-    \x18\x03\x20\x01(\tR\x06source\x12\x16\n\x06fstype\x18\x04\x20\x01(\tR\
-    \x06fstype\x12\x18\n\x07options\x18\x05\x20\x03(\tR\x07options\x12\x1f\n\
-    \x0bmount_point\x18\x06\x20\x01(\tR\nmountPoint\"\x86\x01\n\x06Device\
-    \x12\x0e\n\x02id\x18\x01\x20\x01(\tR\x02id\x12\x12\n\x04type\x18\x02\x20\
-    \x01(\tR\x04type\x12\x17\n\x07vm_path\x18\x03\x20\x01(\tR\x06vmPath\x12%\
-    \n\x0econtainer_path\x18\x04\x20\x01(\tR\rcontainerPath\x12\x18\n\x07opt\
-    ions\x18\x05\x20\x03(\tR\x07options\"X\n\nStringUser\x12\x10\n\x03uid\
-    \x18\x01\x20\x01(\tR\x03uid\x12\x10\n\x03gid\x18\x02\x20\x01(\tR\x03gid\
-    \x12&\n\x0eadditionalGids\x18\x03\x20\x03(\tR\x0eadditionalGids\"\xca\
-    \x01\n\x0fCopyFileRequest\x12\x12\n\x04path\x18\x01\x20\x01(\tR\x04path\
-    \x12\x1b\n\tfile_size\x18\x02\x20\x01(\x03R\x08fileSize\x12\x1b\n\tfile_\
-    mode\x18\x03\x20\x01(\rR\x08fileMode\x12\x19\n\x08dir_mode\x18\x04\x20\
-    \x01(\rR\x07dirMode\x12\x10\n\x03uid\x18\x05\x20\x01(\x05R\x03uid\x12\
-    \x10\n\x03gid\x18\x06\x20\x01(\x05R\x03gid\x12\x16\n\x06offset\x18\x07\
-    \x20\x01(\x03R\x06offset\x12\x12\n\x04data\x18\x08\x20\x01(\x0cR\x04data\
-    \"\x15\n\x13StartTracingRequest\"\x14\n\x12StopTracingRequest\"\x14\n\
-    \x12GetOOMEventRequest\"-\n\x08OOMEvent\x12!\n\x0ccontainer_id\x18\x01\
-    \x20\x01(\tR\x0bcontainerId\"\x13\n\x11GetMetricsRequest\"#\n\x07Metrics\
-    \x12\x18\n\x07metrics\x18\x01\x20\x01(\tR\x07metrics2\xcb\x11\n\x0cAgent\
-    Service\x12G\n\x0fCreateContainer\x12\x1c.grpc.CreateContainerRequest\
-    \x1a\x16.google.protobuf.Empty\x12E\n\x0eStartContainer\x12\x1b.grpc.Sta\
-    rtContainerRequest\x1a\x16.google.protobuf.Empty\x12G\n\x0fRemoveContain\
-    er\x12\x1c.grpc.RemoveContainerRequest\x1a\x16.google.protobuf.Empty\x12\
-    ?\n\x0bExecProcess\x12\x18.grpc.ExecProcessRequest\x1a\x16.google.protob\
-    uf.Empty\x12C\n\rSignalProcess\x12\x1a.grpc.SignalProcessRequest\x1a\x16\
-    .google.protobuf.Empty\x12B\n\x0bWaitProcess\x12\x18.grpc.WaitProcessReq\
-    uest\x1a\x19.grpc.WaitProcessResponse\x12H\n\rListProcesses\x12\x1a.grpc\
-    .ListProcessesRequest\x1a\x1b.grpc.ListProcessesResponse\x12G\n\x0fUpdat\
-    eContainer\x12\x1c.grpc.UpdateContainerRequest\x1a\x16.google.protobuf.E\
-    mpty\x12K\n\x0eStatsContainer\x12\x1b.grpc.StatsContainerRequest\x1a\x1c\
-    .grpc.StatsContainerResponse\x12E\n\x0ePauseContainer\x12\x1b.grpc.Pause\
-    ContainerRequest\x1a\x16.google.protobuf.Empty\x12G\n\x0fResumeContainer\
-    \x12\x1c.grpc.ResumeContainerRequest\x1a\x16.google.protobuf.Empty\x12A\
-    \n\nWriteStdin\x12\x18.grpc.WriteStreamRequest\x1a\x19.grpc.WriteStreamR\
-    esponse\x12?\n\nReadStdout\x12\x17.grpc.ReadStreamRequest\x1a\x18.grpc.R\
-    eadStreamResponse\x12?\n\nReadStderr\x12\x17.grpc.ReadStreamRequest\x1a\
-    \x18.grpc.ReadStreamResponse\x12=\n\nCloseStdin\x12\x17.grpc.CloseStdinR\
-    equest\x1a\x16.google.protobuf.Empty\x12A\n\x0cTtyWinResize\x12\x19.grpc\
-    .TtyWinResizeRequest\x1a\x16.google.protobuf.Empty\x12A\n\x0fUpdateInter\
-    face\x12\x1c.grpc.UpdateInterfaceRequest\x1a\x10.types.Interface\x127\n\
-    \x0cUpdateRoutes\x12\x19.grpc.UpdateRoutesRequest\x1a\x0c.grpc.Routes\
-    \x12?\n\x0eListInterfaces\x12\x1b.grpc.ListInterfacesRequest\x1a\x10.grp\
-    c.Interfaces\x123\n\nListRoutes\x12\x17.grpc.ListRoutesRequest\x1a\x0c.g\
-    rpc.Routes\x12G\n\x0fAddARPNeighbors\x12\x1c.grpc.AddARPNeighborsRequest\
-    \x1a\x16.google.protobuf.Empty\x12A\n\x0cStartTracing\x12\x19.grpc.Start\
-    TracingRequest\x1a\x16.google.protobuf.Empty\x12?\n\x0bStopTracing\x12\
-    \x18.grpc.StopTracingRequest\x1a\x16.google.protobuf.Empty\x124\n\nGetMe\
-    trics\x12\x17.grpc.GetMetricsRequest\x1a\r.grpc.Metrics\x12C\n\rCreateSa\
-    ndbox\x12\x1a.grpc.CreateSandboxRequest\x1a\x16.google.protobuf.Empty\
-    \x12E\n\x0eDestroySandbox\x12\x1b.grpc.DestroySandboxRequest\x1a\x16.goo\
-    gle.protobuf.Empty\x12A\n\x0cOnlineCPUMem\x12\x19.grpc.OnlineCPUMemReque\
-    st\x1a\x16.google.protobuf.Empty\x12G\n\x0fReseedRandomDev\x12\x1c.grpc.\
-    ReseedRandomDevRequest\x1a\x16.google.protobuf.Empty\x12H\n\x0fGetGuestD\
-    etails\x12\x19.grpc.GuestDetailsRequest\x1a\x1a.grpc.GuestDetailsRespons\
-    e\x12K\n\x11MemHotplugByProbe\x12\x1e.grpc.MemHotplugByProbeRequest\x1a\
-    \x16.google.protobuf.Empty\x12I\n\x10SetGuestDateTime\x12\x1d.grpc.SetGu\
-    estDateTimeRequest\x1a\x16.google.protobuf.Empty\x129\n\x08CopyFile\x12\
-    \x15.grpc.CopyFileRequest\x1a\x16.google.protobuf.Empty\x127\n\x0bGetOOM\
-    Event\x12\x18.grpc.GetOOMEventRequest\x1a\x0e.grpc.OOMEventB`Z^github.co\
-    m/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/p\
-    rotocols/grpcJ\x80\x9e\x01\n\x07\x12\x05\x07\0\x8a\x04\x01\nm\n\x01\x0c\
-    \x12\x03\x07\0\x122c\n\x20Copyright\x202017\x20HyperHQ\x20Inc.\n\x20Copy\
-    right\x202019\x20Ant\x20Financial\n\n\x20SPDX-License-Identifier:\x20Apa\
-    che-2.0\n\n\n\x08\n\x01\x08\x12\x03\t\0u\n\t\n\x02\x08\x0b\x12\x03\t\0u\
-    \n\x08\n\x01\x02\x12\x03\x0b\0\r\n\t\n\x02\x03\0\x12\x03\r\0Y\n\n\n\x02\
+    t\x18\x02\x20\x01(\x04R\x05limit\x12\x18\n\x07zombies\x18\x03\x20\x01(\
+    \x04R\x07zombies\"o\n\nMemoryData\x12\x14\n\x05usage\x18\x01\x20\x01(\
+    \x04R\x05usage\x12\x1b\n\tmax_usage\x18\x02\x20\x01(\x04R\x08maxUsage\
+    \x12\x18\n\x07failcnt\x18\x03\x20\x01(\x04R\x07failcnt\x12\x14\n\x05limi\
+    t\x18\x04\x20\x01(\x04R\x05limit\"\xd8\x02\n\x0bMemoryStats\x12\x14\n\
+    \x05cache\x18\x01\x20\x01(\x04R\x05cache\x12&\n\x05usage\x18\x02\x20\x01\
+    (\x0b2\x10.grpc.MemoryDataR\x05usage\x12/\n\nswap_usage\x18\x03\x20\x01(\
+    \x0b2\x10.grpc.MemoryDataR\tswapUsage\x123\n\x0ckernel_usage\x18\x04\x20\
+    \x01(\x0b2\x10.grpc.MemoryDataR\x0bkernelUsage\x12#\n\ruse_hierarchy\x18\
+    \x05\x20\x01(\x08R\x0cuseHierarchy\x122\n\x05stats\x18\x06\x20\x03(\x0b2\
+    \x1c.grpc.MemoryStats.StatsEntryR\x05stats\x12\x12\n\x04idle\x18\x07\x20\
+    \x01(\x04R\x04idle\x1a8\n\nStatsEntry\x12\x10\n\x03key\x18\x01\x20\x01(\
+    \tR\x03key\x12\x14\n\x05value\x18\x02\x20\x01(\x04R\x05value:\x028\x01\"\
+    c\n\x0fBlkioStatsEntry\x12\x14\n\x05major\x18\x01\x20\x01(\x04R\x05major\
+    \x12\x14\n\x05minor\x18\x02\x20\x01(\x04R\x05minor\x12\x0e\n\x02op\x18\
+    \x03\x20\x01(\tR\x02op\x12\x14\n\x05value\x18\x04\x20\x01(\x04R\x05value\
+    \"\xde\x04\n\nBlkioStats\x12R\n\x1aio_service_bytes_recursive\x18\x01\
+    \x20\x03(\x0b2\x15.grpc.BlkioStatsEntryR\x17ioServiceBytesRecursive\x12I\
+    \n\x15io_serviced_recursive\x18\x02\x20\x03(\x0b2\x15.grpc.BlkioStatsEnt\
+    ryR\x13ioServicedRecursive\x12E\n\x13io_queued_recursive\x18\x03\x20\x03\
+    (\x0b2\x15.grpc.BlkioStatsEntryR\x11ioQueuedRecursive\x12P\n\x19io_servi\
+    ce_time_recursive\x18\x04\x20\x03(\x0b2\x15.grpc.BlkioStatsEntryR\x16ioS\
+    erviceTimeRecursive\x12J\n\x16io_wait_time_recursive\x18\x05\x20\x03(\
+    \x0b2\x15.grpc.BlkioStatsEntryR\x13ioWaitTimeRecursive\x12E\n\x13io_merg\
+    ed_recursive\x18\x06\x20\x03(\x0b2\x15.grpc.BlkioStatsEntryR\x11ioMerged\
+    Recursive\x12A\n\x11io_time_recursive\x18\x07\x20\x03(\x0b2\x15.grpc.Blk\
+    ioStatsEntryR\x0fioTimeRecursive\x12B\n\x11sectors_recursive\x18\x08\x20\
+    \x03(\x0b2\x15.grpc.BlkioStatsEntryR\x10sectorsRecursive\"[\n\x0cHugetlb\
+    Stats\x12\x14\n\x05usage\x18\x01\x20\x01(\x04R\x05usage\x12\x1b\n\tmax_u\
+    sage\x18\x02\x20\x01(\x04R\x08maxUsage\x12\x18\n\x07failcnt\x18\x03\x20\
+    \x01(\x04R\x07failcnt\"\xf2\x02\n\x0bCgroupStats\x12+\n\tcpu_stats\x18\
+    \x01\x20\x01(\x0b2\x0e.grpc.CpuStatsR\x08cpuStats\x124\n\x0cmemory_stats\
+    \x18\x02\x20\x01(\x0b2\x11.grpc.MemoryStatsR\x0bmemoryStats\x12.\n\npids\
+    _stats\x18\x03\x20\x01(\x0b2\x0f.grpc.PidsStatsR\tpidsStats\x121\n\x0bbl\
+    kio_stats\x18\x04\x20\x01(\x0b2\x10.grpc.BlkioStatsR\nblkioStats\x12H\n\
+    \rhugetlb_stats\x18\x05\x20\x03(\x0b2#.grpc.CgroupStats.HugetlbStatsEntr\
+    yR\x0chugetlbStats\x1aS\n\x11HugetlbStatsEntry\x12\x10\n\x03key\x18\x01\
+    \x20\x01(\tR\x03key\x12(\n\x05value\x18\x02\x20\x01(\x0b2\x12.grpc.Huget\
+    lbStatsR\x05value:\x028\x01\"\x8e\x02\n\x0cNetworkStats\x12\x12\n\x04nam\
+    e\x18\x01\x20\x01(\tR\x04name\x12\x19\n\x08rx_bytes\x18\x02\x20\x01(\x04\
+    R\x07rxBytes\x12\x1d\n\nrx_packets\x18\x03\x20\x01(\x04R\trxPackets\x12\
+    \x1b\n\trx_errors\x18\x04\x20\x01(\x04R\x08rxErrors\x12\x1d\n\nrx_droppe\
+    d\x18\x05\x20\x01(\x04R\trxDropped\x12\x19\n\x08tx_bytes\x18\x06\x20\x01\
+    (\x04R\x07txBytes\x12\x1d\n\ntx_packets\x18\x07\x20\x01(\x04R\ttxPackets\
+    \x12\x1b\n\ttx_errors\x18\x08\x20\x01(\x04R\x08txErrors\x12\x1d\n\ntx_dr\
+    opped\x18\t\x20\x01(\x04R\ttxDropped\"\x87\x01\n\x16StatsContainerRespon\
+    se\x124\n\x0ccgroup_stats\x18\x01\x20\x01(\x0b2\x11.grpc.CgroupStatsR\
+    \x0bcgroupStats\x127\n\rnetwork_stats\x18\x02\x20\x03(\x0b2\x12.grpc.Net\
+    workStatsR\x0cnetworkStats\"d\n\x12WriteStreamRequest\x12!\n\x0ccontaine\
+    r_id\x18\x01\x20\x01(\tR\x0bcontainerId\x12\x17\n\x07exec_id\x18\x02\x20\
+    \x01(\tR\x06execId\x12\x12\n\x04data\x18\x03\x20\x01(\x0cR\x04data\"'\n\
+    \x13WriteStreamResponse\x12\x10\n\x03len\x18\x01\x20\x01(\rR\x03len\"a\n\
+    \x11ReadStreamRequest\x12!\n\x0ccontainer_id\x18\x01\x20\x01(\tR\x0bcont\
+    ainerId\x12\x17\n\x07exec_id\x18\x02\x20\x01(\tR\x06execId\x12\x10\n\x03\
+    len\x18\x03\x20\x01(\rR\x03len\"(\n\x12ReadStreamResponse\x12\x12\n\x04d\
+    ata\x18\x01\x20\x01(\x0cR\x04data\"O\n\x11CloseStdinRequest\x12!\n\x0cco\
+    ntainer_id\x18\x01\x20\x01(\tR\x0bcontainerId\x12\x17\n\x07exec_id\x18\
+    \x02\x20\x01(\tR\x06execId\"{\n\x13TtyWinResizeRequest\x12!\n\x0ccontain\
+    er_id\x18\x01\x20\x01(\tR\x0bcontainerId\x12\x17\n\x07exec_id\x18\x02\
+    \x20\x01(\tR\x06execId\x12\x10\n\x03row\x18\x03\x20\x01(\rR\x03row\x12\
+    \x16\n\x06column\x18\x04\x20\x01(\rR\x06column\"B\n\x0cKernelModule\x12\
+    \x12\n\x04name\x18\x01\x20\x01(\tR\x04name\x12\x1e\n\nparameters\x18\x02\
+    \x20\x03(\tR\nparameters\"\x96\x02\n\x14CreateSandboxRequest\x12\x1a\n\
+    \x08hostname\x18\x01\x20\x01(\tR\x08hostname\x12\x10\n\x03dns\x18\x02\
+    \x20\x03(\tR\x03dns\x12)\n\x08storages\x18\x03\x20\x03(\x0b2\r.grpc.Stor\
+    ageR\x08storages\x12#\n\rsandbox_pidns\x18\x04\x20\x01(\x08R\x0csandboxP\
+    idns\x12\x1d\n\nsandbox_id\x18\x05\x20\x01(\tR\tsandboxId\x12&\n\x0fgues\
+    t_hook_path\x18\x06\x20\x01(\tR\rguestHookPath\x129\n\x0ekernel_modules\
+    \x18\x07\x20\x03(\x0b2\x12.grpc.KernelModuleR\rkernelModules\"\x17\n\x15\
+    DestroySandboxRequest\">\n\nInterfaces\x120\n\nInterfaces\x18\x01\x20\
+    \x03(\x0b2\x10.types.InterfaceR\nInterfaces\".\n\x06Routes\x12$\n\x06Rou\
+    tes\x18\x01\x20\x03(\x0b2\x0c.types.RouteR\x06Routes\"H\n\x16UpdateInter\
+    faceRequest\x12.\n\tinterface\x18\x01\x20\x01(\x0b2\x10.types.InterfaceR\
+    \tinterface\";\n\x13UpdateRoutesRequest\x12$\n\x06routes\x18\x01\x20\x01\
+    (\x0b2\x0c.grpc.RoutesR\x06routes\"\x17\n\x15ListInterfacesRequest\"\x13\
+    \n\x11ListRoutesRequest\"F\n\x0cARPNeighbors\x126\n\x0cARPNeighbors\x18\
+    \x01\x20\x03(\x0b2\x12.types.ARPNeighborR\x0cARPNeighbors\"J\n\x16AddARP\
+    NeighborsRequest\x120\n\tneighbors\x18\x01\x20\x01(\x0b2\x12.grpc.ARPNei\
+    ghborsR\tneighbors\"]\n\x13OnlineCPUMemRequest\x12\x12\n\x04wait\x18\x01\
+    \x20\x01(\x08R\x04wait\x12\x17\n\x07nb_cpus\x18\x02\x20\x01(\rR\x06nbCpu\
+    s\x12\x19\n\x08cpu_only\x18\x03\x20\x01(\x08R\x07cpuOnly\",\n\x16ReseedR\
+    andomDevRequest\x12\x12\n\x04data\x18\x02\x20\x01(\x0cR\x04data\"\xc8\
+    \x01\n\x0cAgentDetails\x12\x18\n\x07version\x18\x01\x20\x01(\tR\x07versi\
+    on\x12\x1f\n\x0binit_daemon\x18\x02\x20\x01(\x08R\ninitDaemon\x12'\n\x0f\
+    device_handlers\x18\x03\x20\x03(\tR\x0edeviceHandlers\x12)\n\x10storage_\
+    handlers\x18\x04\x20\x03(\tR\x0fstorageHandlers\x12)\n\x10supports_secco\
+    mp\x18\x05\x20\x01(\x08R\x0fsupportsSeccomp\"g\n\x13GuestDetailsRequest\
+    \x12$\n\x0emem_block_size\x18\x01\x20\x01(\x08R\x0cmemBlockSize\x12*\n\
+    \x11mem_hotplug_probe\x18\x02\x20\x01(\x08R\x0fmemHotplugProbe\"\xbb\x01\
+    \n\x14GuestDetailsResponse\x12/\n\x14mem_block_size_bytes\x18\x01\x20\
+    \x01(\x04R\x11memBlockSizeBytes\x127\n\ragent_details\x18\x02\x20\x01(\
+    \x0b2\x12.grpc.AgentDetailsR\x0cagentDetails\x129\n\x19support_mem_hotpl\
+    ug_probe\x18\x03\x20\x01(\x08R\x16supportMemHotplugProbe\"L\n\x18MemHotp\
+    lugByProbeRequest\x120\n\x13memHotplugProbeAddr\x18\x01\x20\x03(\x04R\
+    \x13memHotplugProbeAddr\"?\n\x17SetGuestDateTimeRequest\x12\x10\n\x03Sec\
+    \x18\x01\x20\x01(\x03R\x03Sec\x12\x12\n\x04Usec\x18\x02\x20\x01(\x03R\
+    \x04Usec\"\xb3\x01\n\x07Storage\x12\x16\n\x06driver\x18\x01\x20\x01(\tR\
+    \x06driver\x12%\n\x0edriver_options\x18\x02\x20\x03(\tR\rdriverOptions\
+    \x12\x16\n\x06source\x18\x03\x20\x01(\tR\x06source\x12\x16\n\x06fstype\
+    \x18\x04\x20\x01(\tR\x06fstype\x12\x18\n\x07options\x18\x05\x20\x03(\tR\
+    \x07options\x12\x1f\n\x0bmount_point\x18\x06\x20\x01(\tR\nmountPoint\"\
+    \x86\x01\n\x06Device\x12\x0e\n\x02id\x18\x01\x20\x01(\tR\x02id\x12\x12\n\
+    \x04type\x18\x02\x20\x01(\tR\x04type\x12\x17\n\x07vm_path\x18\x03\x20\
+    \x01(\tR\x06vmPath\x12%\n\x0econtainer_path\x18\x04\x20\x01(\tR\rcontain\
+    erPath\x12\x18\n\x07options\x18\x05\x20\x03(\tR\x07options\"X\n\nStringU\
+    ser\x12\x10\n\x03uid\x18\x01\x20\x01(\tR\x03uid\x12\x10\n\x03gid\x18\x02\
+    \x20\x01(\tR\x03gid\x12&\n\x0eadditionalGids\x18\x03\x20\x03(\tR\x0eaddi\
+    tionalGids\"<\n\x14ReleaseDeviceRequest\x12$\n\x06device\x18\x01\x20\x01\
+    (\x0b2\x0c.grpc.DeviceR\x06device\"\xde\x01\n\x0fCopyFileRequest\x12\x12\
+    \n\x04path\x18\x01\x20\x01(\tR\x04path\x12\x1b\n\tfile_size\x18\x02\x20\
+    \x01(\x03R\x08fileSize\x12\x1b\n\tfile_mode\x18\x03\x20\x01(\rR\x08fileM\
+    ode\x12\x19\n\x08dir_mode\x18\x04\x20\x01(\rR\x07dirMode\x12\x10\n\x03ui\
+    d\x18\x05\x20\x01(\x05R\x03uid\x12\x10\n\x03gid\x18\x06\x20\x01(\x05R\
+    \x03gid\x12\x16\n\x06offset\x18\x07\x20\x01(\x03R\x06offset\x12\x12\n\
+    \x04data\x18\x08\x20\x01(\x0cR\x04data\x12\x12\n\x04root\x18\t\x20\x01(\
+    \tR\x04root\"i\n\x0fReadFileRequest\x12\x12\n\x04path\x18\x01\x20\x01(\t\
+    R\x04path\x12\x12\n\x04root\x18\x02\x20\x01(\tR\x04root\x12\x16\n\x06off\
+    set\x18\x03\x20\x01(\x03R\x06offset\x12\x16\n\x06length\x18\x04\x20\x01(\
+    \x03R\x06length\"\x9c\x01\n\x10ReadFileResponse\x12\x12\n\x04data\x18\
+    \x01\x20\x01(\x0cR\x04data\x12\x16\n\x06offset\x18\x02\x20\x01(\x03R\x06\
+    offset\x12\x1b\n\tfile_size\x18\x03\x20\x01(\x03R\x08fileSize\x12\x1b\n\
+    \tfile_mode\x18\x04\x20\x01(\rR\x08fileMode\x12\x10\n\x03uid\x18\x05\x20\
+    \x01(\x05R\x03uid\x12\x10\n\x03gid\x18\x06\x20\x01(\x05R\x03gid\"\x15\n\
+    \x13StartTracingRequest\"\x14\n\x12StopTracingRequest\"\x14\n\x12GetOOME\
+    ventRequest\"-\n\x08OOMEvent\x12!\n\x0ccontainer_id\x18\x01\x20\x01(\tR\
+    \x0bcontainerId\"\x13\n\x11GetMetricsRequest\"#\n\x07Metrics\x12\x18\n\
+    \x07metrics\x18\x01\x20\x01(\tR\x07metrics\"5\n\x12GetTDReportRequest\
+    \x12\x1f\n\x0breport_data\x18\x01\x20\x01(\x0cR\nreportData\"-\n\x13GetT\
+    DReportResponse\x12\x16\n\x06report\x18\x01\x20\x01(\x0cR\x06report\"@\n\
+    \x12InstallFileRequest\x12\x16\n\x06source\x18\x01\x20\x01(\tR\x06source\
+    \x12\x12\n\x04path\x18\x02\x20\x01(\tR\x04path\"H\n\x16LoadCrashKernelRe\
+    quest\x12\x16\n\x06kernel\x18\x01\x20\x01(\tR\x06kernel\x12\x16\n\x06ini\
+    trd\x18\x02\x20\x01(\tR\x06initrd\"\x97\x01\n\x15StartProfilingRequest\
+    \x12\x18\n\x07session\x18\x01\x20\x01(\tR\x07session\x12\x12\n\x04tool\
+    \x18\x02\x20\x01(\tR\x04tool\x12\x1a\n\x08duration\x18\x03\x20\x01(\rR\
+    \x08duration\x12\x1c\n\tfrequency\x18\x04\x20\x01(\rR\tfrequency\x12\x16\
+    \n\x06script\x18\x05\x20\x01(\tR\x06script\"B\n\x14LoadLivepatchRequest\
+    \x12\x16\n\x06module\x18\x01\x20\x01(\tR\x06module\x12\x12\n\x04path\x18\
+    \x02\x20\x01(\tR\x04path\"\x83\x01\n\x1aCheckpointContainerRequest\x12!\
+    \n\x0ccontainer_id\x18\x01\x20\x01(\tR\x0bcontainerId\x12\x1d\n\nimages_\
+    dir\x18\x02\x20\x01(\tR\timagesDir\x12#\n\rleave_running\x18\x03\x20\x01\
+    (\x08R\x0cleaveRunning\"[\n\x17RestoreContainerRequest\x12!\n\x0ccontain\
+    er_id\x18\x01\x20\x01(\tR\x0bcontainerId\x12\x1d\n\nimages_dir\x18\x02\
+    \x20\x01(\tR\timagesDir2\x81\x17\n\x0cAgentService\x12G\n\x0fCreateConta\
+    iner\x12\x1c.grpc.CreateContainerRequest\x1a\x16.google.protobuf.Empty\
+    \x12E\n\x0eStartContainer\x12\x1b.grpc.StartContainerRequest\x1a\x16.goo\
+    gle.protobuf.Empty\x12G\n\x0fRemoveContainer\x12\x1c.grpc.RemoveContaine\
+    rRequest\x1a\x16.google.protobuf.Empty\x12?\n\x0bExecProcess\x12\x18.grp\
+    c.ExecProcessRequest\x1a\x16.google.protobuf.Empty\x12C\n\rSignalProcess\
+    \x12\x1a.grpc.SignalProcessRequest\x1a\x16.google.protobuf.Empty\x12B\n\
+    \x0bWaitProcess\x12\x18.grpc.WaitProcessRequest\x1a\x19.grpc.WaitProcess\
+    Response\x12H\n\rListProcesses\x12\x1a.grpc.ListProcessesRequest\x1a\x1b\
+    .grpc.ListProcessesResponse\x12G\n\x0fUpdateContainer\x12\x1c.grpc.Updat\
+    eContainerRequest\x1a\x16.google.protobuf.Empty\x12K\n\x0eStatsContainer\
+    \x12\x1b.grpc.StatsContainerRequest\x1a\x1c.grpc.StatsContainerResponse\
+    \x12E\n\x0ePauseContainer\x12\x1b.grpc.PauseContainerRequest\x1a\x16.goo\
+    gle.protobuf.Empty\x12G\n\x0fResumeContainer\x12\x1c.grpc.ResumeContaine\
+    rRequest\x1a\x16.google.protobuf.Empty\x12A\n\nWriteStdin\x12\x18.grpc.W\
+    riteStreamRequest\x1a\x19.grpc.WriteStreamResponse\x12?\n\nReadStdout\
+    \x12\x17.grpc.ReadStreamRequest\x1a\x18.grpc.ReadStreamResponse\x12?\n\n\
+    ReadStderr\x12\x17.grpc.ReadStreamRequest\x1a\x18.grpc.ReadStreamRespons\
+    e\x12=\n\nCloseStdin\x12\x17.grpc.CloseStdinRequest\x1a\x16.google.proto\
+    buf.Empty\x12A\n\x0cTtyWinResize\x12\x19.grpc.TtyWinResizeRequest\x1a\
+    \x16.google.protobuf.Empty\x12A\n\x0fUpdateInterface\x12\x1c.grpc.Update\
+    InterfaceRequest\x1a\x10.types.Interface\x127\n\x0cUpdateRoutes\x12\x19.\
+    grpc.UpdateRoutesRequest\x1a\x0c.grpc.Routes\x12?\n\x0eListInterfaces\
+    \x12\x1b.grpc.ListInterfacesRequest\x1a\x10.grpc.Interfaces\x123\n\nList\
+    Routes\x12\x17.grpc.ListRoutesRequest\x1a\x0c.grpc.Routes\x12G\n\x0fAddA\
+    RPNeighbors\x12\x1c.grpc.AddARPNeighborsRequest\x1a\x16.google.protobuf.\
+    Empty\x12A\n\x0cStartTracing\x12\x19.grpc.StartTracingRequest\x1a\x16.go\
+    ogle.protobuf.Empty\x12?\n\x0bStopTracing\x12\x18.grpc.StopTracingReques\
+    t\x1a\x16.google.protobuf.Empty\x124\n\nGetMetrics\x12\x17.grpc.GetMetri\
+    csRequest\x1a\r.grpc.Metrics\x12C\n\rCreateSandbox\x12\x1a.grpc.CreateSa\
+    ndboxRequest\x1a\x16.google.protobuf.Empty\x12E\n\x0eDestroySandbox\x12\
+    \x1b.grpc.DestroySandboxRequest\x1a\x16.google.protobuf.Empty\x12A\n\x0c\
+    OnlineCPUMem\x12\x19.grpc.OnlineCPUMemRequest\x1a\x16.google.protobuf.Em\
+    pty\x12G\n\x0fReseedRandomDev\x12\x1c.grpc.ReseedRandomDevRequest\x1a\
+    \x16.google.protobuf.Empty\x12H\n\x0fGetGuestDetails\x12\x19.grpc.GuestD\
+    etailsRequest\x1a\x1a.grpc.GuestDetailsResponse\x12K\n\x11MemHotplugByPr\
+    obe\x12\x1e.grpc.MemHotplugByProbeRequest\x1a\x16.google.protobuf.Empty\
+    \x12I\n\x10SetGuestDateTime\x12\x1d.grpc.SetGuestDateTimeRequest\x1a\x16\
+    .google.protobuf.Empty\x129\n\x08CopyFile\x12\x15.grpc.CopyFileRequest\
+    \x1a\x16.google.protobuf.Empty\x127\n\x0bGetOOMEvent\x12\x18.grpc.GetOOM\
+    EventRequest\x1a\x0e.grpc.OOMEvent\x12>\n\x0cSuspendGuest\x12\x16.google\
+    .protobuf.Empty\x1a\x16.google.protobuf.Empty\x129\n\x08ReadFile\x12\x15\
+    .grpc.ReadFileRequest\x1a\x16.grpc.ReadFileResponse\x12B\n\x0bGetTDRepor\
+    t\x12\x18.grpc.GetTDReportRequest\x1a\x19.grpc.GetTDReportResponse\x12C\
+    \n\rReleaseDevice\x12\x1a.grpc.ReleaseDeviceRequest\x1a\x16.google.proto\
+    buf.Empty\x12?\n\x0bInstallFile\x12\x18.grpc.InstallFileRequest\x1a\x16.\
+    google.protobuf.Empty\x12G\n\x0fLoadCrashKernel\x12\x1c.grpc.LoadCrashKe\
+    rnelRequest\x1a\x16.google.protobuf.Empty\x12E\n\x0eStartProfiling\x12\
+    \x1b.grpc.StartProfilingRequest\x1a\x16.google.protobuf.Empty\x12C\n\rLo\
+    adLivepatch\x12\x1a.grpc.LoadLivepatchRequest\x1a\x16.google.protobuf.Em\
+    pty\x12O\n\x13CheckpointContainer\x12\x20.grpc.CheckpointContainerReques\
+    t\x1a\x16.google.protobuf.Empty\x12I\n\x10RestoreContainer\x12\x1d.grpc.\
+    RestoreContainerRequest\x1a\x16.google.protobuf.EmptyB`Z^github.com/kata\
+    -containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protoco\
+    ls/grpcJ\xf4\xca\x01\n\x07\x12\x05\x07\0\x8f\x05\x01\nm\n\x01\x0c\x12\
+    \x03\x07\0\x122c\n\x20Copyright\x202017\x20HyperHQ\x20Inc.\n\x20Copyrigh\
+    t\x202019\x20Ant\x20Financial\n\n\x20SPDX-License-Identifier:\x20Apache-\
+    2.0\n\n\n\x08\n\x01\x08\x12\x03\t\0u\n\t\n\x02\x08\x0b\x12\x03\t\0u\n\
+    \x08\n\x01\x02\x12\x03\x0b\0\r\n\t\n\x02\x03\0\x12\x03\r\0Y\n\n\n\x02\
     \x03\x01\x12\x04\x0e\0\x86\x01\n\t\n\x02\x03\x02\x12\x03\x10\0%\n\x16\n\
-    \x02\x06\0\x12\x04\x13\0E\x01\x1a\n\x20unstable\n\n\n\n\x03\x06\0\x01\
+    \x02\x06\0\x12\x04\x13\0]\x01\x1a\n\x20unstable\n\n\n\n\x03\x06\0\x01\
     \x12\x03\x13\x08\x14\n\x18\n\x04\x06\0\x02\0\x12\x03\x15\x08T\x1a\x0b\
     \x20execution\n\n\x0c\n\x05\x06\0\x02\0\x01\x12\x03\x15\x0c\x1b\n\x0c\n\
     \x05\x06\0\x02\0\x02\x12\x03\x15\x1c2\n\x0c\n\x05\x06\0\x02\0\x03\x12\
@@ -13805,678 +16451,874 @@ static file_descriptor_proto_data: &'static [u8] = b"\
     \n\x0c\n\x05\x06\0\x02\x1f\x02\x12\x03C\x15$\n\x0c\n\x05\x06\0\x02\x1f\
     \x03\x12\x03C/D\n\x0b\n\x04\x06\0\x02\x20\x12\x03D\x08?\n\x0c\n\x05\x06\
     \0\x02\x20\x01\x12\x03D\x0c\x17\n\x0c\n\x05\x06\0\x02\x20\x02\x12\x03D\
-    \x18*\n\x0c\n\x05\x06\0\x02\x20\x03\x12\x03D5=\n\n\n\x02\x04\0\x12\x04G\
-    \0U\x01\n\n\n\x03\x04\0\x01\x12\x03G\x08\x1e\n\x0b\n\x04\x04\0\x02\0\x12\
-    \x03H\x08\x20\n\x0c\n\x05\x04\0\x02\0\x05\x12\x03H\x08\x0e\n\x0c\n\x05\
-    \x04\0\x02\0\x01\x12\x03H\x0f\x1b\n\x0c\n\x05\x04\0\x02\0\x03\x12\x03H\
-    \x1e\x1f\n\x0b\n\x04\x04\0\x02\x01\x12\x03I\x08\x1b\n\x0c\n\x05\x04\0\
-    \x02\x01\x05\x12\x03I\x08\x0e\n\x0c\n\x05\x04\0\x02\x01\x01\x12\x03I\x0f\
-    \x16\n\x0c\n\x05\x04\0\x02\x01\x03\x12\x03I\x19\x1a\n\x0b\n\x04\x04\0\
-    \x02\x02\x12\x03J\x08#\n\x0c\n\x05\x04\0\x02\x02\x06\x12\x03J\x08\x12\n\
-    \x0c\n\x05\x04\0\x02\x02\x01\x12\x03J\x13\x1e\n\x0c\n\x05\x04\0\x02\x02\
-    \x03\x12\x03J!\"\n\x0b\n\x04\x04\0\x02\x03\x12\x03K\x08$\n\x0c\n\x05\x04\
-    \0\x02\x03\x04\x12\x03K\x08\x10\n\x0c\n\x05\x04\0\x02\x03\x06\x12\x03K\
-    \x11\x17\n\x0c\n\x05\x04\0\x02\x03\x01\x12\x03K\x18\x1f\n\x0c\n\x05\x04\
-    \0\x02\x03\x03\x12\x03K\"#\n\x0b\n\x04\x04\0\x02\x04\x12\x03L\x08&\n\x0c\
-    \n\x05\x04\0\x02\x04\x04\x12\x03L\x08\x10\n\x0c\n\x05\x04\0\x02\x04\x06\
-    \x12\x03L\x11\x18\n\x0c\n\x05\x04\0\x02\x04\x01\x12\x03L\x19!\n\x0c\n\
-    \x05\x04\0\x02\x04\x03\x12\x03L$%\n\x0b\n\x04\x04\0\x02\x05\x12\x03M\x08\
-    \x15\n\x0c\n\x05\x04\0\x02\x05\x06\x12\x03M\x08\x0c\n\x0c\n\x05\x04\0\
-    \x02\x05\x01\x12\x03M\r\x10\n\x0c\n\x05\x04\0\x02\x05\x03\x12\x03M\x13\
-    \x14\n\xba\x02\n\x04\x04\0\x02\x06\x12\x03T\x08\x1f\x1a\xac\x02\x20This\
-    \x20field\x20is\x20used\x20to\x20indicate\x20if\x20the\x20container\x20n\
-    eeds\x20to\x20join\n\x20sandbox\x20shared\x20pid\x20ns\x20or\x20create\
-    \x20a\x20new\x20namespace.\x20This\x20field\x20is\n\x20meant\x20to\x20ov\
-    erride\x20the\x20NEWPID\x20config\x20settings\x20in\x20the\x20OCI\x20spe\
-    c.\n\x20The\x20agent\x20would\x20receive\x20an\x20OCI\x20spec\x20with\
-    \x20PID\x20namespace\x20cleared\n\x20out\x20altogether\x20and\x20not\x20\
-    just\x20the\x20pid\x20ns\x20path.\n\n\x0c\n\x05\x04\0\x02\x06\x05\x12\
-    \x03T\x08\x0c\n\x0c\n\x05\x04\0\x02\x06\x01\x12\x03T\r\x1a\n\x0c\n\x05\
-    \x04\0\x02\x06\x03\x12\x03T\x1d\x1e\n\n\n\x02\x04\x01\x12\x04W\0Y\x01\n\
-    \n\n\x03\x04\x01\x01\x12\x03W\x08\x1d\n\x0b\n\x04\x04\x01\x02\0\x12\x03X\
-    \x08\x20\n\x0c\n\x05\x04\x01\x02\0\x05\x12\x03X\x08\x0e\n\x0c\n\x05\x04\
-    \x01\x02\0\x01\x12\x03X\x0f\x1b\n\x0c\n\x05\x04\x01\x02\0\x03\x12\x03X\
-    \x1e\x1f\n\n\n\x02\x04\x02\x12\x04[\0d\x01\n\n\n\x03\x04\x02\x01\x12\x03\
-    [\x08\x1e\n\x0b\n\x04\x04\x02\x02\0\x12\x03\\\x08\x20\n\x0c\n\x05\x04\
-    \x02\x02\0\x05\x12\x03\\\x08\x0e\n\x0c\n\x05\x04\x02\x02\0\x01\x12\x03\\\
-    \x0f\x1b\n\x0c\n\x05\x04\x02\x02\0\x03\x12\x03\\\x1e\x1f\n\xbc\x01\n\x04\
-    \x04\x02\x02\x01\x12\x03c\x08\x1b\x1a\xae\x01\x20RemoveContainer\x20will\
-    \x20return\x20an\x20error\x20if\n\x20it\x20could\x20not\x20kill\x20some\
-    \x20container\x20processes\n\x20after\x20timeout\x20seconds.\n\x20Settin\
-    g\x20timeout\x20to\x200\x20means\x20RemoveContainer\x20will\n\x20wait\
-    \x20for\x20ever.\n\n\x0c\n\x05\x04\x02\x02\x01\x05\x12\x03c\x08\x0e\n\
-    \x0c\n\x05\x04\x02\x02\x01\x01\x12\x03c\x0f\x16\n\x0c\n\x05\x04\x02\x02\
-    \x01\x03\x12\x03c\x19\x1a\n\n\n\x02\x04\x03\x12\x04f\0k\x01\n\n\n\x03\
-    \x04\x03\x01\x12\x03f\x08\x1a\n\x0b\n\x04\x04\x03\x02\0\x12\x03g\x08\x20\
-    \n\x0c\n\x05\x04\x03\x02\0\x05\x12\x03g\x08\x0e\n\x0c\n\x05\x04\x03\x02\
-    \0\x01\x12\x03g\x0f\x1b\n\x0c\n\x05\x04\x03\x02\0\x03\x12\x03g\x1e\x1f\n\
-    \x0b\n\x04\x04\x03\x02\x01\x12\x03h\x08\x1b\n\x0c\n\x05\x04\x03\x02\x01\
-    \x05\x12\x03h\x08\x0e\n\x0c\n\x05\x04\x03\x02\x01\x01\x12\x03h\x0f\x16\n\
-    \x0c\n\x05\x04\x03\x02\x01\x03\x12\x03h\x19\x1a\n\x0b\n\x04\x04\x03\x02\
-    \x02\x12\x03i\x08#\n\x0c\n\x05\x04\x03\x02\x02\x06\x12\x03i\x08\x12\n\
-    \x0c\n\x05\x04\x03\x02\x02\x01\x12\x03i\x13\x1e\n\x0c\n\x05\x04\x03\x02\
-    \x02\x03\x12\x03i!\"\n\x0b\n\x04\x04\x03\x02\x03\x12\x03j\x08\x1c\n\x0c\
-    \n\x05\x04\x03\x02\x03\x06\x12\x03j\x08\x0f\n\x0c\n\x05\x04\x03\x02\x03\
-    \x01\x12\x03j\x10\x17\n\x0c\n\x05\x04\x03\x02\x03\x03\x12\x03j\x1a\x1b\n\
-    \n\n\x02\x04\x04\x12\x04m\0u\x01\n\n\n\x03\x04\x04\x01\x12\x03m\x08\x1c\
-    \n\x0b\n\x04\x04\x04\x02\0\x12\x03n\x08\x20\n\x0c\n\x05\x04\x04\x02\0\
-    \x05\x12\x03n\x08\x0e\n\x0c\n\x05\x04\x04\x02\0\x01\x12\x03n\x0f\x1b\n\
-    \x0c\n\x05\x04\x04\x02\0\x03\x12\x03n\x1e\x1f\n\xe8\x01\n\x04\x04\x04\
-    \x02\x01\x12\x03s\x08\x1b\x1a\xda\x01\x20Special\x20case\x20for\x20Signa\
-    lProcess():\x20exec_id\x20can\x20be\x20empty(\"\"),\n\x20which\x20means\
-    \x20to\x20send\x20the\x20signal\x20to\x20all\x20the\x20processes\x20incl\
-    uding\x20their\x20descendants.\n\x20Other\x20APIs\x20with\x20exec_id\x20\
-    should\x20treat\x20empty\x20exec_id\x20as\x20an\x20invalid\x20request.\n\
-    \n\x0c\n\x05\x04\x04\x02\x01\x05\x12\x03s\x08\x0e\n\x0c\n\x05\x04\x04\
-    \x02\x01\x01\x12\x03s\x0f\x16\n\x0c\n\x05\x04\x04\x02\x01\x03\x12\x03s\
-    \x19\x1a\n\x0b\n\x04\x04\x04\x02\x02\x12\x03t\x08\x1a\n\x0c\n\x05\x04\
-    \x04\x02\x02\x05\x12\x03t\x08\x0e\n\x0c\n\x05\x04\x04\x02\x02\x01\x12\
-    \x03t\x0f\x15\n\x0c\n\x05\x04\x04\x02\x02\x03\x12\x03t\x18\x19\n\n\n\x02\
-    \x04\x05\x12\x04w\0z\x01\n\n\n\x03\x04\x05\x01\x12\x03w\x08\x1a\n\x0b\n\
-    \x04\x04\x05\x02\0\x12\x03x\x08\x20\n\x0c\n\x05\x04\x05\x02\0\x05\x12\
-    \x03x\x08\x0e\n\x0c\n\x05\x04\x05\x02\0\x01\x12\x03x\x0f\x1b\n\x0c\n\x05\
-    \x04\x05\x02\0\x03\x12\x03x\x1e\x1f\n\x0b\n\x04\x04\x05\x02\x01\x12\x03y\
-    \x08\x1b\n\x0c\n\x05\x04\x05\x02\x01\x05\x12\x03y\x08\x0e\n\x0c\n\x05\
-    \x04\x05\x02\x01\x01\x12\x03y\x0f\x16\n\x0c\n\x05\x04\x05\x02\x01\x03\
-    \x12\x03y\x19\x1a\n\n\n\x02\x04\x06\x12\x04|\0~\x01\n\n\n\x03\x04\x06\
-    \x01\x12\x03|\x08\x1b\n\x0b\n\x04\x04\x06\x02\0\x12\x03}\x08\x19\n\x0c\n\
-    \x05\x04\x06\x02\0\x05\x12\x03}\x08\r\n\x0c\n\x05\x04\x06\x02\0\x01\x12\
-    \x03}\x0e\x14\n\x0c\n\x05\x04\x06\x02\0\x03\x12\x03}\x17\x18\nm\n\x02\
-    \x04\x07\x12\x06\x81\x01\0\x85\x01\x01\x1a_\x20ListProcessesRequest\x20c\
-    ontains\x20the\x20options\x20used\x20to\x20list\x20running\x20processes\
-    \x20inside\x20the\x20container\n\n\x0b\n\x03\x04\x07\x01\x12\x04\x81\x01\
-    \x08\x1c\n\x0c\n\x04\x04\x07\x02\0\x12\x04\x82\x01\x08\x20\n\r\n\x05\x04\
-    \x07\x02\0\x05\x12\x04\x82\x01\x08\x0e\n\r\n\x05\x04\x07\x02\0\x01\x12\
-    \x04\x82\x01\x0f\x1b\n\r\n\x05\x04\x07\x02\0\x03\x12\x04\x82\x01\x1e\x1f\
-    \n\x0c\n\x04\x04\x07\x02\x01\x12\x04\x83\x01\x08\x1a\n\r\n\x05\x04\x07\
-    \x02\x01\x05\x12\x04\x83\x01\x08\x0e\n\r\n\x05\x04\x07\x02\x01\x01\x12\
-    \x04\x83\x01\x0f\x15\n\r\n\x05\x04\x07\x02\x01\x03\x12\x04\x83\x01\x18\
-    \x19\n\x0c\n\x04\x04\x07\x02\x02\x12\x04\x84\x01\x08!\n\r\n\x05\x04\x07\
-    \x02\x02\x04\x12\x04\x84\x01\x08\x10\n\r\n\x05\x04\x07\x02\x02\x05\x12\
-    \x04\x84\x01\x11\x17\n\r\n\x05\x04\x07\x02\x02\x01\x12\x04\x84\x01\x18\
-    \x1c\n\r\n\x05\x04\x07\x02\x02\x03\x12\x04\x84\x01\x1f\x20\nc\n\x02\x04\
-    \x08\x12\x06\x88\x01\0\x8a\x01\x01\x1aU\x20ListProcessesResponse\x20repr\
-    esents\x20the\x20list\x20of\x20running\x20processes\x20inside\x20the\x20\
-    container\n\n\x0b\n\x03\x04\x08\x01\x12\x04\x88\x01\x08\x1d\n\x0c\n\x04\
-    \x04\x08\x02\0\x12\x04\x89\x01\x08\x1f\n\r\n\x05\x04\x08\x02\0\x05\x12\
-    \x04\x89\x01\x08\r\n\r\n\x05\x04\x08\x02\0\x01\x12\x04\x89\x01\x0e\x1a\n\
-    \r\n\x05\x04\x08\x02\0\x03\x12\x04\x89\x01\x1d\x1e\n\x0c\n\x02\x04\t\x12\
-    \x06\x8c\x01\0\x8f\x01\x01\n\x0b\n\x03\x04\t\x01\x12\x04\x8c\x01\x08\x1e\
-    \n\x0c\n\x04\x04\t\x02\0\x12\x04\x8d\x01\x08\x20\n\r\n\x05\x04\t\x02\0\
-    \x05\x12\x04\x8d\x01\x08\x0e\n\r\n\x05\x04\t\x02\0\x01\x12\x04\x8d\x01\
-    \x0f\x1b\n\r\n\x05\x04\t\x02\0\x03\x12\x04\x8d\x01\x1e\x1f\n\x0c\n\x04\
-    \x04\t\x02\x01\x12\x04\x8e\x01\x08%\n\r\n\x05\x04\t\x02\x01\x06\x12\x04\
-    \x8e\x01\x08\x16\n\r\n\x05\x04\t\x02\x01\x01\x12\x04\x8e\x01\x17\x20\n\r\
-    \n\x05\x04\t\x02\x01\x03\x12\x04\x8e\x01#$\n\x0c\n\x02\x04\n\x12\x06\x91\
-    \x01\0\x93\x01\x01\n\x0b\n\x03\x04\n\x01\x12\x04\x91\x01\x08\x1d\n\x0c\n\
-    \x04\x04\n\x02\0\x12\x04\x92\x01\x04\x1c\n\r\n\x05\x04\n\x02\0\x05\x12\
-    \x04\x92\x01\x04\n\n\r\n\x05\x04\n\x02\0\x01\x12\x04\x92\x01\x0b\x17\n\r\
-    \n\x05\x04\n\x02\0\x03\x12\x04\x92\x01\x1a\x1b\n\x0c\n\x02\x04\x0b\x12\
-    \x06\x95\x01\0\x97\x01\x01\n\x0b\n\x03\x04\x0b\x01\x12\x04\x95\x01\x08\
-    \x1d\n\x0c\n\x04\x04\x0b\x02\0\x12\x04\x96\x01\x04\x1c\n\r\n\x05\x04\x0b\
-    \x02\0\x05\x12\x04\x96\x01\x04\n\n\r\n\x05\x04\x0b\x02\0\x01\x12\x04\x96\
-    \x01\x0b\x17\n\r\n\x05\x04\x0b\x02\0\x03\x12\x04\x96\x01\x1a\x1b\n\x0c\n\
-    \x02\x04\x0c\x12\x06\x99\x01\0\x9b\x01\x01\n\x0b\n\x03\x04\x0c\x01\x12\
-    \x04\x99\x01\x08\x1e\n\x0c\n\x04\x04\x0c\x02\0\x12\x04\x9a\x01\x04\x1c\n\
-    \r\n\x05\x04\x0c\x02\0\x05\x12\x04\x9a\x01\x04\n\n\r\n\x05\x04\x0c\x02\0\
-    \x01\x12\x04\x9a\x01\x0b\x17\n\r\n\x05\x04\x0c\x02\0\x03\x12\x04\x9a\x01\
-    \x1a\x1b\n\x0c\n\x02\x04\r\x12\x06\x9d\x01\0\xa2\x01\x01\n\x0b\n\x03\x04\
-    \r\x01\x12\x04\x9d\x01\x08\x10\n\x0c\n\x04\x04\r\x02\0\x12\x04\x9e\x01\
-    \x08\x1f\n\r\n\x05\x04\r\x02\0\x05\x12\x04\x9e\x01\x08\x0e\n\r\n\x05\x04\
-    \r\x02\0\x01\x12\x04\x9e\x01\x0f\x1a\n\r\n\x05\x04\r\x02\0\x03\x12\x04\
-    \x9e\x01\x1d\x1e\n\x0c\n\x04\x04\r\x02\x01\x12\x04\x9f\x01\x08)\n\r\n\
-    \x05\x04\r\x02\x01\x04\x12\x04\x9f\x01\x08\x10\n\r\n\x05\x04\r\x02\x01\
-    \x05\x12\x04\x9f\x01\x11\x17\n\r\n\x05\x04\r\x02\x01\x01\x12\x04\x9f\x01\
-    \x18$\n\r\n\x05\x04\r\x02\x01\x03\x12\x04\x9f\x01'(\n\x0c\n\x04\x04\r\
-    \x02\x02\x12\x04\xa0\x01\x08'\n\r\n\x05\x04\r\x02\x02\x05\x12\x04\xa0\
-    \x01\x08\x0e\n\r\n\x05\x04\r\x02\x02\x01\x12\x04\xa0\x01\x0f\"\n\r\n\x05\
-    \x04\r\x02\x02\x03\x12\x04\xa0\x01%&\n\x0c\n\x04\x04\r\x02\x03\x12\x04\
-    \xa1\x01\x08%\n\r\n\x05\x04\r\x02\x03\x05\x12\x04\xa1\x01\x08\x0e\n\r\n\
-    \x05\x04\r\x02\x03\x01\x12\x04\xa1\x01\x0f\x20\n\r\n\x05\x04\r\x02\x03\
-    \x03\x12\x04\xa1\x01#$\n\x0c\n\x02\x04\x0e\x12\x06\xa4\x01\0\xa8\x01\x01\
-    \n\x0b\n\x03\x04\x0e\x01\x12\x04\xa4\x01\x08\x16\n\x0c\n\x04\x04\x0e\x02\
-    \0\x12\x04\xa5\x01\x08\x1b\n\r\n\x05\x04\x0e\x02\0\x05\x12\x04\xa5\x01\
-    \x08\x0e\n\r\n\x05\x04\x0e\x02\0\x01\x12\x04\xa5\x01\x0f\x16\n\r\n\x05\
-    \x04\x0e\x02\0\x03\x12\x04\xa5\x01\x19\x1a\n\x0c\n\x04\x04\x0e\x02\x01\
-    \x12\x04\xa6\x01\x08%\n\r\n\x05\x04\x0e\x02\x01\x05\x12\x04\xa6\x01\x08\
-    \x0e\n\r\n\x05\x04\x0e\x02\x01\x01\x12\x04\xa6\x01\x0f\x20\n\r\n\x05\x04\
-    \x0e\x02\x01\x03\x12\x04\xa6\x01#$\n\x0c\n\x04\x04\x0e\x02\x02\x12\x04\
-    \xa7\x01\x08\"\n\r\n\x05\x04\x0e\x02\x02\x05\x12\x04\xa7\x01\x08\x0e\n\r\
-    \n\x05\x04\x0e\x02\x02\x01\x12\x04\xa7\x01\x0f\x1d\n\r\n\x05\x04\x0e\x02\
-    \x02\x03\x12\x04\xa7\x01\x20!\n\x0c\n\x02\x04\x0f\x12\x06\xaa\x01\0\xad\
-    \x01\x01\n\x0b\n\x03\x04\x0f\x01\x12\x04\xaa\x01\x08\x10\n\x0c\n\x04\x04\
-    \x0f\x02\0\x12\x04\xab\x01\x08\x1f\n\r\n\x05\x04\x0f\x02\0\x06\x12\x04\
-    \xab\x01\x08\x10\n\r\n\x05\x04\x0f\x02\0\x01\x12\x04\xab\x01\x11\x1a\n\r\
-    \n\x05\x04\x0f\x02\0\x03\x12\x04\xab\x01\x1d\x1e\n\x0c\n\x04\x04\x0f\x02\
-    \x01\x12\x04\xac\x01\x08+\n\r\n\x05\x04\x0f\x02\x01\x06\x12\x04\xac\x01\
-    \x08\x16\n\r\n\x05\x04\x0f\x02\x01\x01\x12\x04\xac\x01\x17&\n\r\n\x05\
-    \x04\x0f\x02\x01\x03\x12\x04\xac\x01)*\n\x0c\n\x02\x04\x10\x12\x06\xaf\
-    \x01\0\xb2\x01\x01\n\x0b\n\x03\x04\x10\x01\x12\x04\xaf\x01\x08\x11\n\x0c\
-    \n\x04\x04\x10\x02\0\x12\x04\xb0\x01\x08\x1b\n\r\n\x05\x04\x10\x02\0\x05\
-    \x12\x04\xb0\x01\x08\x0e\n\r\n\x05\x04\x10\x02\0\x01\x12\x04\xb0\x01\x0f\
-    \x16\n\r\n\x05\x04\x10\x02\0\x03\x12\x04\xb0\x01\x19\x1a\n\x0c\n\x04\x04\
-    \x10\x02\x01\x12\x04\xb1\x01\x08\x19\n\r\n\x05\x04\x10\x02\x01\x05\x12\
-    \x04\xb1\x01\x08\x0e\n\r\n\x05\x04\x10\x02\x01\x01\x12\x04\xb1\x01\x0f\
-    \x14\n\r\n\x05\x04\x10\x02\x01\x03\x12\x04\xb1\x01\x17\x18\n\x0c\n\x02\
-    \x04\x11\x12\x06\xb4\x01\0\xb9\x01\x01\n\x0b\n\x03\x04\x11\x01\x12\x04\
-    \xb4\x01\x08\x12\n\x0c\n\x04\x04\x11\x02\0\x12\x04\xb5\x01\x08\x19\n\r\n\
-    \x05\x04\x11\x02\0\x05\x12\x04\xb5\x01\x08\x0e\n\r\n\x05\x04\x11\x02\0\
-    \x01\x12\x04\xb5\x01\x0f\x14\n\r\n\x05\x04\x11\x02\0\x03\x12\x04\xb5\x01\
-    \x17\x18\n\x0c\n\x04\x04\x11\x02\x01\x12\x04\xb6\x01\x08\x1d\n\r\n\x05\
-    \x04\x11\x02\x01\x05\x12\x04\xb6\x01\x08\x0e\n\r\n\x05\x04\x11\x02\x01\
-    \x01\x12\x04\xb6\x01\x0f\x18\n\r\n\x05\x04\x11\x02\x01\x03\x12\x04\xb6\
-    \x01\x1b\x1c\n\x0c\n\x04\x04\x11\x02\x02\x12\x04\xb7\x01\x08\x1b\n\r\n\
-    \x05\x04\x11\x02\x02\x05\x12\x04\xb7\x01\x08\x0e\n\r\n\x05\x04\x11\x02\
-    \x02\x01\x12\x04\xb7\x01\x0f\x16\n\r\n\x05\x04\x11\x02\x02\x03\x12\x04\
-    \xb7\x01\x19\x1a\n\x0c\n\x04\x04\x11\x02\x03\x12\x04\xb8\x01\x08\x19\n\r\
-    \n\x05\x04\x11\x02\x03\x05\x12\x04\xb8\x01\x08\x0e\n\r\n\x05\x04\x11\x02\
-    \x03\x01\x12\x04\xb8\x01\x0f\x14\n\r\n\x05\x04\x11\x02\x03\x03\x12\x04\
-    \xb8\x01\x17\x18\n\x0c\n\x02\x04\x12\x12\x06\xbb\x01\0\xc2\x01\x01\n\x0b\
-    \n\x03\x04\x12\x01\x12\x04\xbb\x01\x08\x13\n\x0c\n\x04\x04\x12\x02\0\x12\
-    \x04\xbc\x01\x08\x19\n\r\n\x05\x04\x12\x02\0\x05\x12\x04\xbc\x01\x08\x0e\
-    \n\r\n\x05\x04\x12\x02\0\x01\x12\x04\xbc\x01\x0f\x14\n\r\n\x05\x04\x12\
-    \x02\0\x03\x12\x04\xbc\x01\x17\x18\n\x0c\n\x04\x04\x12\x02\x01\x12\x04\
-    \xbd\x01\x08\x1d\n\r\n\x05\x04\x12\x02\x01\x06\x12\x04\xbd\x01\x08\x12\n\
-    \r\n\x05\x04\x12\x02\x01\x01\x12\x04\xbd\x01\x13\x18\n\r\n\x05\x04\x12\
-    \x02\x01\x03\x12\x04\xbd\x01\x1b\x1c\n\x0c\n\x04\x04\x12\x02\x02\x12\x04\
-    \xbe\x01\x08\"\n\r\n\x05\x04\x12\x02\x02\x06\x12\x04\xbe\x01\x08\x12\n\r\
-    \n\x05\x04\x12\x02\x02\x01\x12\x04\xbe\x01\x13\x1d\n\r\n\x05\x04\x12\x02\
-    \x02\x03\x12\x04\xbe\x01\x20!\n\x0c\n\x04\x04\x12\x02\x03\x12\x04\xbf\
-    \x01\x08$\n\r\n\x05\x04\x12\x02\x03\x06\x12\x04\xbf\x01\x08\x12\n\r\n\
-    \x05\x04\x12\x02\x03\x01\x12\x04\xbf\x01\x13\x1f\n\r\n\x05\x04\x12\x02\
-    \x03\x03\x12\x04\xbf\x01\"#\n\x0c\n\x04\x04\x12\x02\x04\x12\x04\xc0\x01\
-    \x08\x1f\n\r\n\x05\x04\x12\x02\x04\x05\x12\x04\xc0\x01\x08\x0c\n\r\n\x05\
-    \x04\x12\x02\x04\x01\x12\x04\xc0\x01\r\x1a\n\r\n\x05\x04\x12\x02\x04\x03\
-    \x12\x04\xc0\x01\x1d\x1e\n\x0c\n\x04\x04\x12\x02\x05\x12\x04\xc1\x01\x08\
-    &\n\r\n\x05\x04\x12\x02\x05\x06\x12\x04\xc1\x01\x08\x1b\n\r\n\x05\x04\
-    \x12\x02\x05\x01\x12\x04\xc1\x01\x1c!\n\r\n\x05\x04\x12\x02\x05\x03\x12\
-    \x04\xc1\x01$%\n\x0c\n\x02\x04\x13\x12\x06\xc5\x01\0\xca\x01\x01\n\x0b\n\
-    \x03\x04\x13\x01\x12\x04\xc5\x01\x08\x17\n\x0c\n\x04\x04\x13\x02\0\x12\
-    \x04\xc6\x01\x08\x19\n\r\n\x05\x04\x13\x02\0\x05\x12\x04\xc6\x01\x08\x0e\
-    \n\r\n\x05\x04\x13\x02\0\x01\x12\x04\xc6\x01\x0f\x14\n\r\n\x05\x04\x13\
-    \x02\0\x03\x12\x04\xc6\x01\x17\x18\n\x0c\n\x04\x04\x13\x02\x01\x12\x04\
-    \xc7\x01\x08\x19\n\r\n\x05\x04\x13\x02\x01\x05\x12\x04\xc7\x01\x08\x0e\n\
-    \r\n\x05\x04\x13\x02\x01\x01\x12\x04\xc7\x01\x0f\x14\n\r\n\x05\x04\x13\
-    \x02\x01\x03\x12\x04\xc7\x01\x17\x18\n\x0c\n\x04\x04\x13\x02\x02\x12\x04\
-    \xc8\x01\x08\x16\n\r\n\x05\x04\x13\x02\x02\x05\x12\x04\xc8\x01\x08\x0e\n\
-    \r\n\x05\x04\x13\x02\x02\x01\x12\x04\xc8\x01\x0f\x11\n\r\n\x05\x04\x13\
-    \x02\x02\x03\x12\x04\xc8\x01\x14\x15\n\x0c\n\x04\x04\x13\x02\x03\x12\x04\
-    \xc9\x01\x08\x19\n\r\n\x05\x04\x13\x02\x03\x05\x12\x04\xc9\x01\x08\x0e\n\
-    \r\n\x05\x04\x13\x02\x03\x01\x12\x04\xc9\x01\x0f\x14\n\r\n\x05\x04\x13\
-    \x02\x03\x03\x12\x04\xc9\x01\x17\x18\n\x0c\n\x02\x04\x14\x12\x06\xcc\x01\
-    \0\xd5\x01\x01\n\x0b\n\x03\x04\x14\x01\x12\x04\xcc\x01\x08\x12\nH\n\x04\
-    \x04\x14\x02\0\x12\x04\xcd\x01\x08@\":\x20number\x20of\x20bytes\x20trans\
-    ferred\x20to\x20and\x20from\x20the\x20block\x20device\n\n\r\n\x05\x04\
-    \x14\x02\0\x04\x12\x04\xcd\x01\x08\x10\n\r\n\x05\x04\x14\x02\0\x06\x12\
-    \x04\xcd\x01\x11\x20\n\r\n\x05\x04\x14\x02\0\x01\x12\x04\xcd\x01!;\n\r\n\
-    \x05\x04\x14\x02\0\x03\x12\x04\xcd\x01>?\n\x0c\n\x04\x04\x14\x02\x01\x12\
-    \x04\xce\x01\x08;\n\r\n\x05\x04\x14\x02\x01\x04\x12\x04\xce\x01\x08\x10\
-    \n\r\n\x05\x04\x14\x02\x01\x06\x12\x04\xce\x01\x11\x20\n\r\n\x05\x04\x14\
-    \x02\x01\x01\x12\x04\xce\x01!6\n\r\n\x05\x04\x14\x02\x01\x03\x12\x04\xce\
-    \x019:\n\x0c\n\x04\x04\x14\x02\x02\x12\x04\xcf\x01\x089\n\r\n\x05\x04\
-    \x14\x02\x02\x04\x12\x04\xcf\x01\x08\x10\n\r\n\x05\x04\x14\x02\x02\x06\
-    \x12\x04\xcf\x01\x11\x20\n\r\n\x05\x04\x14\x02\x02\x01\x12\x04\xcf\x01!4\
-    \n\r\n\x05\x04\x14\x02\x02\x03\x12\x04\xcf\x0178\n\x0c\n\x04\x04\x14\x02\
-    \x03\x12\x04\xd0\x01\x08?\n\r\n\x05\x04\x14\x02\x03\x04\x12\x04\xd0\x01\
-    \x08\x10\n\r\n\x05\x04\x14\x02\x03\x06\x12\x04\xd0\x01\x11\x20\n\r\n\x05\
-    \x04\x14\x02\x03\x01\x12\x04\xd0\x01!:\n\r\n\x05\x04\x14\x02\x03\x03\x12\
-    \x04\xd0\x01=>\n\x0c\n\x04\x04\x14\x02\x04\x12\x04\xd1\x01\x08<\n\r\n\
-    \x05\x04\x14\x02\x04\x04\x12\x04\xd1\x01\x08\x10\n\r\n\x05\x04\x14\x02\
-    \x04\x06\x12\x04\xd1\x01\x11\x20\n\r\n\x05\x04\x14\x02\x04\x01\x12\x04\
-    \xd1\x01!7\n\r\n\x05\x04\x14\x02\x04\x03\x12\x04\xd1\x01:;\n\x0c\n\x04\
-    \x04\x14\x02\x05\x12\x04\xd2\x01\x089\n\r\n\x05\x04\x14\x02\x05\x04\x12\
-    \x04\xd2\x01\x08\x10\n\r\n\x05\x04\x14\x02\x05\x06\x12\x04\xd2\x01\x11\
-    \x20\n\r\n\x05\x04\x14\x02\x05\x01\x12\x04\xd2\x01!4\n\r\n\x05\x04\x14\
-    \x02\x05\x03\x12\x04\xd2\x0178\n\x0c\n\x04\x04\x14\x02\x06\x12\x04\xd3\
-    \x01\x087\n\r\n\x05\x04\x14\x02\x06\x04\x12\x04\xd3\x01\x08\x10\n\r\n\
-    \x05\x04\x14\x02\x06\x06\x12\x04\xd3\x01\x11\x20\n\r\n\x05\x04\x14\x02\
-    \x06\x01\x12\x04\xd3\x01!2\n\r\n\x05\x04\x14\x02\x06\x03\x12\x04\xd3\x01\
-    56\n\x0c\n\x04\x04\x14\x02\x07\x12\x04\xd4\x01\x087\n\r\n\x05\x04\x14\
-    \x02\x07\x04\x12\x04\xd4\x01\x08\x10\n\r\n\x05\x04\x14\x02\x07\x06\x12\
-    \x04\xd4\x01\x11\x20\n\r\n\x05\x04\x14\x02\x07\x01\x12\x04\xd4\x01!2\n\r\
-    \n\x05\x04\x14\x02\x07\x03\x12\x04\xd4\x0156\n\x0c\n\x02\x04\x15\x12\x06\
-    \xd7\x01\0\xdb\x01\x01\n\x0b\n\x03\x04\x15\x01\x12\x04\xd7\x01\x08\x14\n\
-    \x0c\n\x04\x04\x15\x02\0\x12\x04\xd8\x01\x08\x19\n\r\n\x05\x04\x15\x02\0\
-    \x05\x12\x04\xd8\x01\x08\x0e\n\r\n\x05\x04\x15\x02\0\x01\x12\x04\xd8\x01\
-    \x0f\x14\n\r\n\x05\x04\x15\x02\0\x03\x12\x04\xd8\x01\x17\x18\n\x0c\n\x04\
-    \x04\x15\x02\x01\x12\x04\xd9\x01\x08\x1d\n\r\n\x05\x04\x15\x02\x01\x05\
-    \x12\x04\xd9\x01\x08\x0e\n\r\n\x05\x04\x15\x02\x01\x01\x12\x04\xd9\x01\
-    \x0f\x18\n\r\n\x05\x04\x15\x02\x01\x03\x12\x04\xd9\x01\x1b\x1c\n\x0c\n\
-    \x04\x04\x15\x02\x02\x12\x04\xda\x01\x08\x1b\n\r\n\x05\x04\x15\x02\x02\
-    \x05\x12\x04\xda\x01\x08\x0e\n\r\n\x05\x04\x15\x02\x02\x01\x12\x04\xda\
-    \x01\x0f\x16\n\r\n\x05\x04\x15\x02\x02\x03\x12\x04\xda\x01\x19\x1a\n\x0c\
-    \n\x02\x04\x16\x12\x06\xdd\x01\0\xe4\x01\x01\n\x0b\n\x03\x04\x16\x01\x12\
-    \x04\xdd\x01\x08\x13\n\x0c\n\x04\x04\x16\x02\0\x12\x04\xde\x01\x04\x1b\n\
-    \r\n\x05\x04\x16\x02\0\x06\x12\x04\xde\x01\x04\x0c\n\r\n\x05\x04\x16\x02\
-    \0\x01\x12\x04\xde\x01\r\x16\n\r\n\x05\x04\x16\x02\0\x03\x12\x04\xde\x01\
-    \x19\x1a\n\x0c\n\x04\x04\x16\x02\x01\x12\x04\xdf\x01\x04\"\n\r\n\x05\x04\
-    \x16\x02\x01\x06\x12\x04\xdf\x01\x04\x0f\n\r\n\x05\x04\x16\x02\x01\x01\
-    \x12\x04\xdf\x01\x10\x1c\n\r\n\x05\x04\x16\x02\x01\x03\x12\x04\xdf\x01\
-    \x20!\n\x0c\n\x04\x04\x16\x02\x02\x12\x04\xe0\x01\x04\x1d\n\r\n\x05\x04\
-    \x16\x02\x02\x06\x12\x04\xe0\x01\x04\r\n\r\n\x05\x04\x16\x02\x02\x01\x12\
-    \x04\xe0\x01\x0e\x18\n\r\n\x05\x04\x16\x02\x02\x03\x12\x04\xe0\x01\x1b\
-    \x1c\n\x0c\n\x04\x04\x16\x02\x03\x12\x04\xe1\x01\x04\x1f\n\r\n\x05\x04\
-    \x16\x02\x03\x06\x12\x04\xe1\x01\x04\x0e\n\r\n\x05\x04\x16\x02\x03\x01\
-    \x12\x04\xe1\x01\x0f\x1a\n\r\n\x05\x04\x16\x02\x03\x03\x12\x04\xe1\x01\
-    \x1d\x1e\nR\n\x04\x04\x16\x02\x04\x12\x04\xe2\x01\x040\"D\x20the\x20map\
-    \x20is\x20in\x20the\x20format\x20\"size\x20of\x20hugepage:\x20stats\x20o\
-    f\x20the\x20hugepage\"\n\n\r\n\x05\x04\x16\x02\x04\x06\x12\x04\xe2\x01\
-    \x04\x1d\n\r\n\x05\x04\x16\x02\x04\x01\x12\x04\xe2\x01\x1e+\n\r\n\x05\
-    \x04\x16\x02\x04\x03\x12\x04\xe2\x01./\n\x0c\n\x02\x04\x17\x12\x06\xe6\
-    \x01\0\xf0\x01\x01\n\x0b\n\x03\x04\x17\x01\x12\x04\xe6\x01\x08\x14\n\x0c\
-    \n\x04\x04\x17\x02\0\x12\x04\xe7\x01\x08\x18\n\r\n\x05\x04\x17\x02\0\x05\
-    \x12\x04\xe7\x01\x08\x0e\n\r\n\x05\x04\x17\x02\0\x01\x12\x04\xe7\x01\x0f\
-    \x13\n\r\n\x05\x04\x17\x02\0\x03\x12\x04\xe7\x01\x16\x17\n\x0c\n\x04\x04\
-    \x17\x02\x01\x12\x04\xe8\x01\x08\x1c\n\r\n\x05\x04\x17\x02\x01\x05\x12\
-    \x04\xe8\x01\x08\x0e\n\r\n\x05\x04\x17\x02\x01\x01\x12\x04\xe8\x01\x0f\
-    \x17\n\r\n\x05\x04\x17\x02\x01\x03\x12\x04\xe8\x01\x1a\x1b\n\x0c\n\x04\
-    \x04\x17\x02\x02\x12\x04\xe9\x01\x08\x1e\n\r\n\x05\x04\x17\x02\x02\x05\
-    \x12\x04\xe9\x01\x08\x0e\n\r\n\x05\x04\x17\x02\x02\x01\x12\x04\xe9\x01\
-    \x0f\x19\n\r\n\x05\x04\x17\x02\x02\x03\x12\x04\xe9\x01\x1c\x1d\n\x0c\n\
-    \x04\x04\x17\x02\x03\x12\x04\xea\x01\x08\x1e\n\r\n\x05\x04\x17\x02\x03\
-    \x05\x12\x04\xea\x01\x08\x0e\n\r\n\x05\x04\x17\x02\x03\x01\x12\x04\xea\
-    \x01\x0f\x18\n\r\n\x05\x04\x17\x02\x03\x03\x12\x04\xea\x01\x1c\x1d\n\x0c\
-    \n\x04\x04\x17\x02\x04\x12\x04\xeb\x01\x08\x1e\n\r\n\x05\x04\x17\x02\x04\
-    \x05\x12\x04\xeb\x01\x08\x0e\n\r\n\x05\x04\x17\x02\x04\x01\x12\x04\xeb\
-    \x01\x0f\x19\n\r\n\x05\x04\x17\x02\x04\x03\x12\x04\xeb\x01\x1c\x1d\n\x0c\
-    \n\x04\x04\x17\x02\x05\x12\x04\xec\x01\x08\x1c\n\r\n\x05\x04\x17\x02\x05\
-    \x05\x12\x04\xec\x01\x08\x0e\n\r\n\x05\x04\x17\x02\x05\x01\x12\x04\xec\
-    \x01\x0f\x17\n\r\n\x05\x04\x17\x02\x05\x03\x12\x04\xec\x01\x1a\x1b\n\x0c\
-    \n\x04\x04\x17\x02\x06\x12\x04\xed\x01\x08\x1e\n\r\n\x05\x04\x17\x02\x06\
-    \x05\x12\x04\xed\x01\x08\x0e\n\r\n\x05\x04\x17\x02\x06\x01\x12\x04\xed\
-    \x01\x0f\x19\n\r\n\x05\x04\x17\x02\x06\x03\x12\x04\xed\x01\x1c\x1d\n\x0c\
-    \n\x04\x04\x17\x02\x07\x12\x04\xee\x01\x08\x1d\n\r\n\x05\x04\x17\x02\x07\
-    \x05\x12\x04\xee\x01\x08\x0e\n\r\n\x05\x04\x17\x02\x07\x01\x12\x04\xee\
-    \x01\x0f\x18\n\r\n\x05\x04\x17\x02\x07\x03\x12\x04\xee\x01\x1b\x1c\n\x0c\
-    \n\x04\x04\x17\x02\x08\x12\x04\xef\x01\x08\x1e\n\r\n\x05\x04\x17\x02\x08\
-    \x05\x12\x04\xef\x01\x08\x0e\n\r\n\x05\x04\x17\x02\x08\x01\x12\x04\xef\
-    \x01\x0f\x19\n\r\n\x05\x04\x17\x02\x08\x03\x12\x04\xef\x01\x1c\x1d\n\x0c\
-    \n\x02\x04\x18\x12\x06\xf2\x01\0\xf5\x01\x01\n\x0b\n\x03\x04\x18\x01\x12\
-    \x04\xf2\x01\x08\x1e\n\x0c\n\x04\x04\x18\x02\0\x12\x04\xf3\x01\x08%\n\r\
-    \n\x05\x04\x18\x02\0\x06\x12\x04\xf3\x01\x08\x13\n\r\n\x05\x04\x18\x02\0\
-    \x01\x12\x04\xf3\x01\x14\x20\n\r\n\x05\x04\x18\x02\0\x03\x12\x04\xf3\x01\
-    #$\n\x0c\n\x04\x04\x18\x02\x01\x12\x04\xf4\x01\x080\n\r\n\x05\x04\x18\
-    \x02\x01\x04\x12\x04\xf4\x01\x08\x10\n\r\n\x05\x04\x18\x02\x01\x06\x12\
-    \x04\xf4\x01\x11\x1d\n\r\n\x05\x04\x18\x02\x01\x01\x12\x04\xf4\x01\x1e+\
-    \n\r\n\x05\x04\x18\x02\x01\x03\x12\x04\xf4\x01./\n\x0c\n\x02\x04\x19\x12\
-    \x06\xf7\x01\0\xfb\x01\x01\n\x0b\n\x03\x04\x19\x01\x12\x04\xf7\x01\x08\
-    \x1a\n\x0c\n\x04\x04\x19\x02\0\x12\x04\xf8\x01\x08\x20\n\r\n\x05\x04\x19\
-    \x02\0\x05\x12\x04\xf8\x01\x08\x0e\n\r\n\x05\x04\x19\x02\0\x01\x12\x04\
-    \xf8\x01\x0f\x1b\n\r\n\x05\x04\x19\x02\0\x03\x12\x04\xf8\x01\x1e\x1f\n\
-    \x0c\n\x04\x04\x19\x02\x01\x12\x04\xf9\x01\x08\x1b\n\r\n\x05\x04\x19\x02\
-    \x01\x05\x12\x04\xf9\x01\x08\x0e\n\r\n\x05\x04\x19\x02\x01\x01\x12\x04\
-    \xf9\x01\x0f\x16\n\r\n\x05\x04\x19\x02\x01\x03\x12\x04\xf9\x01\x19\x1a\n\
-    \x0c\n\x04\x04\x19\x02\x02\x12\x04\xfa\x01\x08\x17\n\r\n\x05\x04\x19\x02\
-    \x02\x05\x12\x04\xfa\x01\x08\r\n\r\n\x05\x04\x19\x02\x02\x01\x12\x04\xfa\
-    \x01\x0e\x12\n\r\n\x05\x04\x19\x02\x02\x03\x12\x04\xfa\x01\x15\x16\n\x0c\
-    \n\x02\x04\x1a\x12\x06\xfd\x01\0\xff\x01\x01\n\x0b\n\x03\x04\x1a\x01\x12\
-    \x04\xfd\x01\x08\x1b\n\x0c\n\x04\x04\x1a\x02\0\x12\x04\xfe\x01\x08\x17\n\
-    \r\n\x05\x04\x1a\x02\0\x05\x12\x04\xfe\x01\x08\x0e\n\r\n\x05\x04\x1a\x02\
-    \0\x01\x12\x04\xfe\x01\x0f\x12\n\r\n\x05\x04\x1a\x02\0\x03\x12\x04\xfe\
-    \x01\x15\x16\n\x0c\n\x02\x04\x1b\x12\x06\x81\x02\0\x85\x02\x01\n\x0b\n\
-    \x03\x04\x1b\x01\x12\x04\x81\x02\x08\x19\n\x0c\n\x04\x04\x1b\x02\0\x12\
-    \x04\x82\x02\x08\x20\n\r\n\x05\x04\x1b\x02\0\x05\x12\x04\x82\x02\x08\x0e\
-    \n\r\n\x05\x04\x1b\x02\0\x01\x12\x04\x82\x02\x0f\x1b\n\r\n\x05\x04\x1b\
-    \x02\0\x03\x12\x04\x82\x02\x1e\x1f\n\x0c\n\x04\x04\x1b\x02\x01\x12\x04\
-    \x83\x02\x08\x1b\n\r\n\x05\x04\x1b\x02\x01\x05\x12\x04\x83\x02\x08\x0e\n\
-    \r\n\x05\x04\x1b\x02\x01\x01\x12\x04\x83\x02\x0f\x16\n\r\n\x05\x04\x1b\
-    \x02\x01\x03\x12\x04\x83\x02\x19\x1a\n\x0c\n\x04\x04\x1b\x02\x02\x12\x04\
-    \x84\x02\x08\x17\n\r\n\x05\x04\x1b\x02\x02\x05\x12\x04\x84\x02\x08\x0e\n\
-    \r\n\x05\x04\x1b\x02\x02\x01\x12\x04\x84\x02\x0f\x12\n\r\n\x05\x04\x1b\
-    \x02\x02\x03\x12\x04\x84\x02\x15\x16\n\x0c\n\x02\x04\x1c\x12\x06\x87\x02\
-    \0\x89\x02\x01\n\x0b\n\x03\x04\x1c\x01\x12\x04\x87\x02\x08\x1a\n\x0c\n\
-    \x04\x04\x1c\x02\0\x12\x04\x88\x02\x08\x17\n\r\n\x05\x04\x1c\x02\0\x05\
-    \x12\x04\x88\x02\x08\r\n\r\n\x05\x04\x1c\x02\0\x01\x12\x04\x88\x02\x0e\
-    \x12\n\r\n\x05\x04\x1c\x02\0\x03\x12\x04\x88\x02\x15\x16\n\x0c\n\x02\x04\
-    \x1d\x12\x06\x8b\x02\0\x8e\x02\x01\n\x0b\n\x03\x04\x1d\x01\x12\x04\x8b\
-    \x02\x08\x19\n\x0c\n\x04\x04\x1d\x02\0\x12\x04\x8c\x02\x08\x20\n\r\n\x05\
-    \x04\x1d\x02\0\x05\x12\x04\x8c\x02\x08\x0e\n\r\n\x05\x04\x1d\x02\0\x01\
-    \x12\x04\x8c\x02\x0f\x1b\n\r\n\x05\x04\x1d\x02\0\x03\x12\x04\x8c\x02\x1e\
-    \x1f\n\x0c\n\x04\x04\x1d\x02\x01\x12\x04\x8d\x02\x08\x1b\n\r\n\x05\x04\
-    \x1d\x02\x01\x05\x12\x04\x8d\x02\x08\x0e\n\r\n\x05\x04\x1d\x02\x01\x01\
-    \x12\x04\x8d\x02\x0f\x16\n\r\n\x05\x04\x1d\x02\x01\x03\x12\x04\x8d\x02\
-    \x19\x1a\n\x0c\n\x02\x04\x1e\x12\x06\x90\x02\0\x95\x02\x01\n\x0b\n\x03\
-    \x04\x1e\x01\x12\x04\x90\x02\x08\x1b\n\x0c\n\x04\x04\x1e\x02\0\x12\x04\
-    \x91\x02\x08\x20\n\r\n\x05\x04\x1e\x02\0\x05\x12\x04\x91\x02\x08\x0e\n\r\
-    \n\x05\x04\x1e\x02\0\x01\x12\x04\x91\x02\x0f\x1b\n\r\n\x05\x04\x1e\x02\0\
-    \x03\x12\x04\x91\x02\x1e\x1f\n\x0c\n\x04\x04\x1e\x02\x01\x12\x04\x92\x02\
-    \x08\x1b\n\r\n\x05\x04\x1e\x02\x01\x05\x12\x04\x92\x02\x08\x0e\n\r\n\x05\
-    \x04\x1e\x02\x01\x01\x12\x04\x92\x02\x0f\x16\n\r\n\x05\x04\x1e\x02\x01\
-    \x03\x12\x04\x92\x02\x19\x1a\n\x0c\n\x04\x04\x1e\x02\x02\x12\x04\x93\x02\
-    \x08\x17\n\r\n\x05\x04\x1e\x02\x02\x05\x12\x04\x93\x02\x08\x0e\n\r\n\x05\
-    \x04\x1e\x02\x02\x01\x12\x04\x93\x02\x0f\x12\n\r\n\x05\x04\x1e\x02\x02\
-    \x03\x12\x04\x93\x02\x15\x16\n\x0c\n\x04\x04\x1e\x02\x03\x12\x04\x94\x02\
-    \x08\x1a\n\r\n\x05\x04\x1e\x02\x03\x05\x12\x04\x94\x02\x08\x0e\n\r\n\x05\
-    \x04\x1e\x02\x03\x01\x12\x04\x94\x02\x0f\x15\n\r\n\x05\x04\x1e\x02\x03\
-    \x03\x12\x04\x94\x02\x18\x19\n\x0c\n\x02\x04\x1f\x12\x06\x97\x02\0\x9d\
-    \x02\x01\n\x0b\n\x03\x04\x1f\x01\x12\x04\x97\x02\x08\x14\n<\n\x04\x04\
-    \x1f\x02\0\x12\x04\x99\x02\x08\x18\x1a.\x20This\x20field\x20is\x20the\
-    \x20name\x20of\x20the\x20kernel\x20module.\n\n\r\n\x05\x04\x1f\x02\0\x05\
-    \x12\x04\x99\x02\x08\x0e\n\r\n\x05\x04\x1f\x02\0\x01\x12\x04\x99\x02\x0f\
-    \x13\n\r\n\x05\x04\x1f\x02\0\x03\x12\x04\x99\x02\x16\x17\n\x8a\x01\n\x04\
-    \x04\x1f\x02\x01\x12\x04\x9c\x02\x08'\x1a|\x20This\x20field\x20are\x20th\
-    e\x20parameters\x20for\x20the\x20kernel\x20module\x20which\x20are\n\x20w\
-    hitespace-delimited\x20key=value\x20pairs\x20passed\x20to\x20modprobe(8)\
-    .\n\n\r\n\x05\x04\x1f\x02\x01\x04\x12\x04\x9c\x02\x08\x10\n\r\n\x05\x04\
-    \x1f\x02\x01\x05\x12\x04\x9c\x02\x11\x17\n\r\n\x05\x04\x1f\x02\x01\x01\
-    \x12\x04\x9c\x02\x18\"\n\r\n\x05\x04\x1f\x02\x01\x03\x12\x04\x9c\x02%&\n\
-    \x0c\n\x02\x04\x20\x12\x06\x9f\x02\0\xb2\x02\x01\n\x0b\n\x03\x04\x20\x01\
-    \x12\x04\x9f\x02\x08\x1c\n\x0c\n\x04\x04\x20\x02\0\x12\x04\xa0\x02\x08\
-    \x1c\n\r\n\x05\x04\x20\x02\0\x05\x12\x04\xa0\x02\x08\x0e\n\r\n\x05\x04\
-    \x20\x02\0\x01\x12\x04\xa0\x02\x0f\x17\n\r\n\x05\x04\x20\x02\0\x03\x12\
-    \x04\xa0\x02\x1a\x1b\n\x0c\n\x04\x04\x20\x02\x01\x12\x04\xa1\x02\x08\x20\
-    \n\r\n\x05\x04\x20\x02\x01\x04\x12\x04\xa1\x02\x08\x10\n\r\n\x05\x04\x20\
-    \x02\x01\x05\x12\x04\xa1\x02\x11\x17\n\r\n\x05\x04\x20\x02\x01\x01\x12\
-    \x04\xa1\x02\x18\x1b\n\r\n\x05\x04\x20\x02\x01\x03\x12\x04\xa1\x02\x1e\
-    \x1f\n\x0c\n\x04\x04\x20\x02\x02\x12\x04\xa2\x02\x08&\n\r\n\x05\x04\x20\
-    \x02\x02\x04\x12\x04\xa2\x02\x08\x10\n\r\n\x05\x04\x20\x02\x02\x06\x12\
-    \x04\xa2\x02\x11\x18\n\r\n\x05\x04\x20\x02\x02\x01\x12\x04\xa2\x02\x19!\
-    \n\r\n\x05\x04\x20\x02\x02\x03\x12\x04\xa2\x02$%\n\xea\x01\n\x04\x04\x20\
-    \x02\x03\x12\x04\xa8\x02\x08\x1f\x1a\xdb\x01\x20This\x20field\x20means\
-    \x20that\x20a\x20pause\x20process\x20needs\x20to\x20be\x20created\x20by\
-    \x20the\n\x20agent.\x20This\x20pid\x20namespace\x20of\x20the\x20pause\
-    \x20process\x20will\x20be\x20treated\x20as\n\x20a\x20shared\x20pid\x20na\
-    mespace.\x20All\x20containers\x20created\x20will\x20join\x20this\x20shar\
-    ed\n\x20pid\x20namespace.\n\n\r\n\x05\x04\x20\x02\x03\x05\x12\x04\xa8\
-    \x02\x08\x0c\n\r\n\x05\x04\x20\x02\x03\x01\x12\x04\xa8\x02\r\x1a\n\r\n\
-    \x05\x04\x20\x02\x03\x03\x12\x04\xa8\x02\x1d\x1e\n\xc5\x01\n\x04\x04\x20\
-    \x02\x04\x12\x04\xac\x02\x08\x1e\x1a\xb6\x01\x20SandboxId\x20identifies\
-    \x20which\x20sandbox\x20is\x20using\x20the\x20agent.\x20We\x20allow\x20o\
-    nly\n\x20one\x20sandbox\x20per\x20agent\x20and\x20implicitly\x20require\
-    \x20that\x20CreateSandbox\x20is\n\x20called\x20before\x20other\x20sandbo\
-    x/network\x20calls.\n\n\r\n\x05\x04\x20\x02\x04\x05\x12\x04\xac\x02\x08\
-    \x0e\n\r\n\x05\x04\x20\x02\x04\x01\x12\x04\xac\x02\x0f\x19\n\r\n\x05\x04\
-    \x20\x02\x04\x03\x12\x04\xac\x02\x1c\x1d\n\x98\x01\n\x04\x04\x20\x02\x05\
-    \x12\x04\xaf\x02\x08#\x1a\x89\x01\x20This\x20field,\x20if\x20non-empty,\
-    \x20designates\x20an\x20absolute\x20path\x20to\x20a\x20directory\n\x20th\
-    at\x20the\x20agent\x20will\x20search\x20for\x20OCI\x20hooks\x20to\x20run\
-    \x20within\x20the\x20guest.\n\n\r\n\x05\x04\x20\x02\x05\x05\x12\x04\xaf\
-    \x02\x08\x0e\n\r\n\x05\x04\x20\x02\x05\x01\x12\x04\xaf\x02\x0f\x1e\n\r\n\
-    \x05\x04\x20\x02\x05\x03\x12\x04\xaf\x02!\"\nZ\n\x04\x04\x20\x02\x06\x12\
-    \x04\xb1\x02\x081\x1aL\x20This\x20field\x20is\x20the\x20list\x20of\x20ke\
-    rnel\x20modules\x20to\x20be\x20loaded\x20in\x20the\x20guest\x20kernel.\n\
-    \n\r\n\x05\x04\x20\x02\x06\x04\x12\x04\xb1\x02\x08\x10\n\r\n\x05\x04\x20\
-    \x02\x06\x06\x12\x04\xb1\x02\x11\x1d\n\r\n\x05\x04\x20\x02\x06\x01\x12\
-    \x04\xb1\x02\x1e,\n\r\n\x05\x04\x20\x02\x06\x03\x12\x04\xb1\x02/0\n\x0c\
-    \n\x02\x04!\x12\x06\xb4\x02\0\xb5\x02\x01\n\x0b\n\x03\x04!\x01\x12\x04\
-    \xb4\x02\x08\x1d\n\x0c\n\x02\x04\"\x12\x06\xb7\x02\0\xb9\x02\x01\n\x0b\n\
-    \x03\x04\"\x01\x12\x04\xb7\x02\x08\x12\n\x0c\n\x04\x04\"\x02\0\x12\x04\
-    \xb8\x02\x080\n\r\n\x05\x04\"\x02\0\x04\x12\x04\xb8\x02\x08\x10\n\r\n\
-    \x05\x04\"\x02\0\x06\x12\x04\xb8\x02\x11\x20\n\r\n\x05\x04\"\x02\0\x01\
-    \x12\x04\xb8\x02!+\n\r\n\x05\x04\"\x02\0\x03\x12\x04\xb8\x02./\n\x0c\n\
-    \x02\x04#\x12\x06\xbb\x02\0\xbd\x02\x01\n\x0b\n\x03\x04#\x01\x12\x04\xbb\
-    \x02\x08\x0e\n\x0c\n\x04\x04#\x02\0\x12\x04\xbc\x02\x08(\n\r\n\x05\x04#\
-    \x02\0\x04\x12\x04\xbc\x02\x08\x10\n\r\n\x05\x04#\x02\0\x06\x12\x04\xbc\
-    \x02\x11\x1c\n\r\n\x05\x04#\x02\0\x01\x12\x04\xbc\x02\x1d#\n\r\n\x05\x04\
-    #\x02\0\x03\x12\x04\xbc\x02&'\n\x0c\n\x02\x04$\x12\x06\xbf\x02\0\xc1\x02\
-    \x01\n\x0b\n\x03\x04$\x01\x12\x04\xbf\x02\x08\x1e\n\x0c\n\x04\x04$\x02\0\
-    \x12\x04\xc0\x02\x08&\n\r\n\x05\x04$\x02\0\x06\x12\x04\xc0\x02\x08\x17\n\
-    \r\n\x05\x04$\x02\0\x01\x12\x04\xc0\x02\x18!\n\r\n\x05\x04$\x02\0\x03\
-    \x12\x04\xc0\x02$%\n\x0c\n\x02\x04%\x12\x06\xc3\x02\0\xc5\x02\x01\n\x0b\
-    \n\x03\x04%\x01\x12\x04\xc3\x02\x08\x1b\n\x0c\n\x04\x04%\x02\0\x12\x04\
-    \xc4\x02\x08\x1a\n\r\n\x05\x04%\x02\0\x06\x12\x04\xc4\x02\x08\x0e\n\r\n\
-    \x05\x04%\x02\0\x01\x12\x04\xc4\x02\x0f\x15\n\r\n\x05\x04%\x02\0\x03\x12\
-    \x04\xc4\x02\x18\x19\n\x0c\n\x02\x04&\x12\x06\xc7\x02\0\xc8\x02\x01\n\
-    \x0b\n\x03\x04&\x01\x12\x04\xc7\x02\x08\x1d\n\x0c\n\x02\x04'\x12\x06\xca\
-    \x02\0\xcb\x02\x01\n\x0b\n\x03\x04'\x01\x12\x04\xca\x02\x08\x19\n\x0c\n\
-    \x02\x04(\x12\x06\xcd\x02\0\xcf\x02\x01\n\x0b\n\x03\x04(\x01\x12\x04\xcd\
-    \x02\x08\x14\n\x0c\n\x04\x04(\x02\0\x12\x04\xce\x02\x073\n\r\n\x05\x04(\
-    \x02\0\x04\x12\x04\xce\x02\x07\x0f\n\r\n\x05\x04(\x02\0\x06\x12\x04\xce\
-    \x02\x10!\n\r\n\x05\x04(\x02\0\x01\x12\x04\xce\x02\".\n\r\n\x05\x04(\x02\
-    \0\x03\x12\x04\xce\x0212\n\x0c\n\x02\x04)\x12\x06\xd1\x02\0\xd3\x02\x01\
-    \n\x0b\n\x03\x04)\x01\x12\x04\xd1\x02\x08\x1e\n\x0c\n\x04\x04)\x02\0\x12\
-    \x04\xd2\x02\x07\"\n\r\n\x05\x04)\x02\0\x06\x12\x04\xd2\x02\x07\x13\n\r\
-    \n\x05\x04)\x02\0\x01\x12\x04\xd2\x02\x14\x1d\n\r\n\x05\x04)\x02\0\x03\
-    \x12\x04\xd2\x02\x20!\n\x0c\n\x02\x04*\x12\x06\xd5\x02\0\xe0\x02\x01\n\
-    \x0b\n\x03\x04*\x01\x12\x04\xd5\x02\x08\x1b\n\xf6\x01\n\x04\x04*\x02\0\
-    \x12\x04\xd9\x02\x08\x16\x1a\xe7\x01\x20Wait\x20specifies\x20if\x20the\
-    \x20caller\x20waits\x20for\x20the\x20agent\x20to\x20online\x20all\x20res\
-    ources.\n\x20If\x20true\x20the\x20agent\x20returns\x20once\x20all\x20res\
-    ources\x20have\x20been\x20connected,\x20otherwise\x20all\n\x20resources\
-    \x20are\x20connected\x20asynchronously\x20and\x20the\x20agent\x20returns\
-    \x20immediately.\n\n\r\n\x05\x04*\x02\0\x05\x12\x04\xd9\x02\x08\x0c\n\r\
-    \n\x05\x04*\x02\0\x01\x12\x04\xd9\x02\r\x11\n\r\n\x05\x04*\x02\0\x03\x12\
-    \x04\xd9\x02\x14\x15\n`\n\x04\x04*\x02\x01\x12\x04\xdc\x02\x08\x1b\x1aR\
-    \x20NbCpus\x20specifies\x20the\x20number\x20of\x20CPUs\x20that\x20were\
-    \x20added\x20and\x20the\x20agent\x20has\x20to\x20online.\n\n\r\n\x05\x04\
-    *\x02\x01\x05\x12\x04\xdc\x02\x08\x0e\n\r\n\x05\x04*\x02\x01\x01\x12\x04\
-    \xdc\x02\x0f\x16\n\r\n\x05\x04*\x02\x01\x03\x12\x04\xdc\x02\x19\x1a\nA\n\
-    \x04\x04*\x02\x02\x12\x04\xdf\x02\x08\x1a\x1a3\x20CpuOnly\x20specifies\
-    \x20whether\x20only\x20online\x20CPU\x20or\x20not.\n\n\r\n\x05\x04*\x02\
-    \x02\x05\x12\x04\xdf\x02\x08\x0c\n\r\n\x05\x04*\x02\x02\x01\x12\x04\xdf\
-    \x02\r\x15\n\r\n\x05\x04*\x02\x02\x03\x12\x04\xdf\x02\x18\x19\n\x0c\n\
-    \x02\x04+\x12\x06\xe2\x02\0\xe5\x02\x01\n\x0b\n\x03\x04+\x01\x12\x04\xe2\
-    \x02\x08\x1e\nM\n\x04\x04+\x02\0\x12\x04\xe4\x02\x08\x17\x1a?\x20Data\
-    \x20specifies\x20the\x20random\x20data\x20used\x20to\x20reseed\x20the\
-    \x20guest\x20crng.\n\n\r\n\x05\x04+\x02\0\x05\x12\x04\xe4\x02\x08\r\n\r\
-    \n\x05\x04+\x02\0\x01\x12\x04\xe4\x02\x0e\x12\n\r\n\x05\x04+\x02\0\x03\
-    \x12\x04\xe4\x02\x15\x16\nX\n\x02\x04,\x12\x06\xe8\x02\0\xf8\x02\x01\x1a\
-    J\x20AgentDetails\x20provides\x20information\x20to\x20the\x20client\x20a\
-    bout\x20the\x20running\x20agent.\n\n\x0b\n\x03\x04,\x01\x12\x04\xe8\x02\
-    \x08\x14\nC\n\x04\x04,\x02\0\x12\x04\xea\x02\x08\x1b\x1a5\x20Semantic\
-    \x20version\x20of\x20agent\x20(see\x20https://semver.org).\n\n\r\n\x05\
-    \x04,\x02\0\x05\x12\x04\xea\x02\x08\x0e\n\r\n\x05\x04,\x02\0\x01\x12\x04\
-    \xea\x02\x0f\x16\n\r\n\x05\x04,\x02\0\x03\x12\x04\xea\x02\x19\x1a\n5\n\
-    \x04\x04,\x02\x01\x12\x04\xed\x02\x08\x1d\x1a'\x20Set\x20if\x20the\x20ag\
-    ent\x20is\x20running\x20as\x20PID\x201.\n\n\r\n\x05\x04,\x02\x01\x05\x12\
-    \x04\xed\x02\x08\x0c\n\r\n\x05\x04,\x02\x01\x01\x12\x04\xed\x02\r\x18\n\
-    \r\n\x05\x04,\x02\x01\x03\x12\x04\xed\x02\x1b\x1c\n2\n\x04\x04,\x02\x02\
-    \x12\x04\xf0\x02\x08,\x1a$\x20List\x20of\x20available\x20device\x20handl\
-    ers.\n\n\r\n\x05\x04,\x02\x02\x04\x12\x04\xf0\x02\x08\x10\n\r\n\x05\x04,\
-    \x02\x02\x05\x12\x04\xf0\x02\x11\x17\n\r\n\x05\x04,\x02\x02\x01\x12\x04\
-    \xf0\x02\x18'\n\r\n\x05\x04,\x02\x02\x03\x12\x04\xf0\x02*+\n3\n\x04\x04,\
-    \x02\x03\x12\x04\xf3\x02\x08-\x1a%\x20List\x20of\x20available\x20storage\
-    \x20handlers.\n\n\r\n\x05\x04,\x02\x03\x04\x12\x04\xf3\x02\x08\x10\n\r\n\
-    \x05\x04,\x02\x03\x05\x12\x04\xf3\x02\x11\x17\n\r\n\x05\x04,\x02\x03\x01\
-    \x12\x04\xf3\x02\x18(\n\r\n\x05\x04,\x02\x03\x03\x12\x04\xf3\x02+,\np\n\
-    \x04\x04,\x02\x04\x12\x04\xf7\x02\x08\"\x1ab\x20Set\x20only\x20if\x20the\
-    \x20agent\x20is\x20built\x20with\x20seccomp\x20support\x20and\x20the\x20\
-    guest\n\x20environment\x20supports\x20seccomp.\n\n\r\n\x05\x04,\x02\x04\
-    \x05\x12\x04\xf7\x02\x08\x0c\n\r\n\x05\x04,\x02\x04\x01\x12\x04\xf7\x02\
-    \r\x1d\n\r\n\x05\x04,\x02\x04\x03\x12\x04\xf7\x02\x20!\n\x0c\n\x02\x04-\
-    \x12\x06\xfa\x02\0\x84\x03\x01\n\x0b\n\x03\x04-\x01\x12\x04\xfa\x02\x08\
-    \x1b\n\xd5\x01\n\x04\x04-\x02\0\x12\x04\xfe\x02\x08\x20\x1a\xc6\x01\x20M\
-    emBlockSize\x20asks\x20server\x20to\x20return\x20the\x20system\x20memory\
-    \x20block\x20size\x20that\x20can\x20be\x20used\n\x20for\x20memory\x20hot\
-    plug\x20alignment.\x20Typically\x20the\x20server\x20returns\x20what's\
-    \x20in\n\x20/sys/devices/system/memory/block_size_bytes.\n\n\r\n\x05\x04\
-    -\x02\0\x05\x12\x04\xfe\x02\x08\x0c\n\r\n\x05\x04-\x02\0\x01\x12\x04\xfe\
-    \x02\r\x1b\n\r\n\x05\x04-\x02\0\x03\x12\x04\xfe\x02\x1e\x1f\n\xd1\x01\n\
-    \x04\x04-\x02\x01\x12\x04\x83\x03\x08#\x1a\xc2\x01\x20MemoryHotplugProbe\
-    \x20asks\x20server\x20to\x20return\x20whether\x20guest\x20kernel\x20supp\
-    orts\x20memory\x20hotplug\n\x20via\x20probeinterface.\x20Typically\x20th\
-    e\x20server\x20will\x20check\x20if\x20the\x20path\n\x20/sys/devices/syst\
-    em/memory/probe\x20exists.\n\n\r\n\x05\x04-\x02\x01\x05\x12\x04\x83\x03\
-    \x08\x0c\n\r\n\x05\x04-\x02\x01\x01\x12\x04\x83\x03\r\x1e\n\r\n\x05\x04-\
-    \x02\x01\x03\x12\x04\x83\x03!\"\n\x0c\n\x02\x04.\x12\x06\x86\x03\0\x8d\
-    \x03\x01\n\x0b\n\x03\x04.\x01\x12\x04\x86\x03\x08\x1c\nP\n\x04\x04.\x02\
-    \0\x12\x04\x88\x03\x08(\x1aB\x20MemBlockSizeBytes\x20returns\x20the\x20s\
-    ystem\x20memory\x20block\x20size\x20in\x20bytes.\n\n\r\n\x05\x04.\x02\0\
-    \x05\x12\x04\x88\x03\x08\x0e\n\r\n\x05\x04.\x02\0\x01\x12\x04\x88\x03\
-    \x0f#\n\r\n\x05\x04.\x02\0\x03\x12\x04\x88\x03&'\n\x0c\n\x04\x04.\x02\
-    \x01\x12\x04\x8a\x03\x08'\n\r\n\x05\x04.\x02\x01\x06\x12\x04\x8a\x03\x08\
-    \x14\n\r\n\x05\x04.\x02\x01\x01\x12\x04\x8a\x03\x15\"\n\r\n\x05\x04.\x02\
-    \x01\x03\x12\x04\x8a\x03%&\n\x0c\n\x04\x04.\x02\x02\x12\x04\x8c\x03\x08+\
-    \n\r\n\x05\x04.\x02\x02\x05\x12\x04\x8c\x03\x08\x0c\n\r\n\x05\x04.\x02\
-    \x02\x01\x12\x04\x8c\x03\r&\n\r\n\x05\x04.\x02\x02\x03\x12\x04\x8c\x03)*\
-    \n\x0c\n\x02\x04/\x12\x06\x8f\x03\0\x93\x03\x01\n\x0b\n\x03\x04/\x01\x12\
-    \x04\x8f\x03\x08\x20\n\xb2\x01\n\x04\x04/\x02\0\x12\x04\x92\x03\x080\x1a\
-    \xa3\x01\x20server\x20needs\x20to\x20send\x20the\x20value\x20of\x20memHo\
-    tplugProbeAddr\x20into\x20file\x20/sys/devices/system/memory/probe,\n\
-    \x20in\x20order\x20to\x20notify\x20the\x20guest\x20kernel\x20about\x20ho\
-    t-add\x20memory\x20event\n\n\r\n\x05\x04/\x02\0\x04\x12\x04\x92\x03\x08\
-    \x10\n\r\n\x05\x04/\x02\0\x05\x12\x04\x92\x03\x11\x17\n\r\n\x05\x04/\x02\
-    \0\x01\x12\x04\x92\x03\x18+\n\r\n\x05\x04/\x02\0\x03\x12\x04\x92\x03./\n\
-    \x0c\n\x02\x040\x12\x06\x95\x03\0\x9a\x03\x01\n\x0b\n\x03\x040\x01\x12\
-    \x04\x95\x03\x08\x1f\n/\n\x04\x040\x02\0\x12\x04\x97\x03\x08\x16\x1a!\
-    \x20Sec\x20the\x20second\x20since\x20the\x20Epoch.\n\n\r\n\x05\x040\x02\
-    \0\x05\x12\x04\x97\x03\x08\r\n\r\n\x05\x040\x02\0\x01\x12\x04\x97\x03\
-    \x0e\x11\n\r\n\x05\x040\x02\0\x03\x12\x04\x97\x03\x14\x15\nF\n\x04\x040\
-    \x02\x01\x12\x04\x99\x03\x08\x17\x1a8\x20Usec\x20the\x20microseconds\x20\
-    portion\x20of\x20time\x20since\x20the\x20Epoch.\n\n\r\n\x05\x040\x02\x01\
-    \x05\x12\x04\x99\x03\x08\r\n\r\n\x05\x040\x02\x01\x01\x12\x04\x99\x03\
-    \x0e\x12\n\r\n\x05\x040\x02\x01\x03\x12\x04\x99\x03\x15\x16\n\xa3\x01\n\
-    \x02\x041\x12\x06\x9e\x03\0\xb8\x03\x01\x1a\x94\x01\x20Storage\x20repres\
-    ents\x20both\x20the\x20rootfs\x20of\x20the\x20container,\x20and\x20any\
-    \x20volume\x20that\n\x20could\x20have\x20been\x20defined\x20through\x20t\
-    he\x20Mount\x20list\x20of\x20the\x20OCI\x20specification.\n\n\x0b\n\x03\
-    \x041\x01\x12\x04\x9e\x03\x08\x0f\n\x8b\x02\n\x04\x041\x02\0\x12\x04\xa3\
-    \x03\x08\x1a\x1a\xfc\x01\x20Driver\x20is\x20used\x20to\x20define\x20the\
-    \x20way\x20the\x20storage\x20is\x20passed\x20through\x20the\n\x20virtual\
-    \x20machine.\x20It\x20can\x20be\x20\"9p\",\x20\"blk\",\x20or\x20somethin\
-    g\x20else,\x20but\x20for\n\x20all\x20cases,\x20this\x20will\x20define\
-    \x20if\x20some\x20extra\x20steps\x20are\x20required\x20before\n\x20this\
-    \x20storage\x20gets\x20mounted\x20into\x20the\x20container.\n\n\r\n\x05\
-    \x041\x02\0\x05\x12\x04\xa3\x03\x08\x0e\n\r\n\x05\x041\x02\0\x01\x12\x04\
-    \xa3\x03\x0f\x15\n\r\n\x05\x041\x02\0\x03\x12\x04\xa3\x03\x18\x19\n\xd0\
-    \x01\n\x04\x041\x02\x01\x12\x04\xa7\x03\x08+\x1a\xc1\x01\x20DriverOption\
-    s\x20allows\x20the\x20caller\x20to\x20define\x20a\x20list\x20of\x20optio\
-    ns\x20such\n\x20as\x20block\x20sizes,\x20numbers\x20of\x20luns,\x20...\
-    \x20which\x20are\x20very\x20specific\x20to\n\x20every\x20device\x20and\
-    \x20cannot\x20be\x20generalized\x20through\x20extra\x20fields.\n\n\r\n\
-    \x05\x041\x02\x01\x04\x12\x04\xa7\x03\x08\x10\n\r\n\x05\x041\x02\x01\x05\
-    \x12\x04\xa7\x03\x11\x17\n\r\n\x05\x041\x02\x01\x01\x12\x04\xa7\x03\x18&\
-    \n\r\n\x05\x041\x02\x01\x03\x12\x04\xa7\x03)*\n\xce\x02\n\x04\x041\x02\
-    \x02\x12\x04\xad\x03\x08\x1a\x1a\xbf\x02\x20Source\x20can\x20be\x20anyth\
-    ing\x20representing\x20the\x20source\x20of\x20the\x20storage.\x20This\n\
-    \x20will\x20be\x20handled\x20by\x20the\x20proper\x20handler\x20based\x20\
-    on\x20the\x20Driver\x20used.\n\x20For\x20instance,\x20it\x20can\x20be\
-    \x20a\x20very\x20simple\x20path\x20if\x20the\x20caller\x20knows\x20the\n\
-    \x20name\x20of\x20device\x20inside\x20the\x20VM,\x20or\x20it\x20can\x20b\
-    e\x20some\x20sort\x20of\x20identifier\n\x20to\x20let\x20the\x20agent\x20\
-    find\x20the\x20device\x20inside\x20the\x20VM.\n\n\r\n\x05\x041\x02\x02\
-    \x05\x12\x04\xad\x03\x08\x0e\n\r\n\x05\x041\x02\x02\x01\x12\x04\xad\x03\
-    \x0f\x15\n\r\n\x05\x041\x02\x02\x03\x12\x04\xad\x03\x18\x19\n\xdb\x01\n\
-    \x04\x041\x02\x03\x12\x04\xb1\x03\x08\x1a\x1a\xcc\x01\x20Fstype\x20repre\
-    sents\x20the\x20filesystem\x20that\x20needs\x20to\x20be\x20used\x20to\
-    \x20mount\x20the\n\x20storage\x20inside\x20the\x20VM.\x20For\x20instance\
-    ,\x20it\x20could\x20be\x20\"xfs\"\x20for\x20block\n\x20device,\x20\"9p\"\
-    \x20for\x20shared\x20filesystem,\x20or\x20\"tmpfs\"\x20for\x20shared\x20\
-    /dev/shm.\n\n\r\n\x05\x041\x02\x03\x05\x12\x04\xb1\x03\x08\x0e\n\r\n\x05\
-    \x041\x02\x03\x01\x12\x04\xb1\x03\x0f\x15\n\r\n\x05\x041\x02\x03\x03\x12\
-    \x04\xb1\x03\x18\x19\nw\n\x04\x041\x02\x04\x12\x04\xb4\x03\x08$\x1ai\x20\
-    Options\x20describes\x20the\x20additional\x20options\x20that\x20might\
-    \x20be\x20needed\x20to\n\x20mount\x20properly\x20the\x20storage\x20files\
-    ytem.\n\n\r\n\x05\x041\x02\x04\x04\x12\x04\xb4\x03\x08\x10\n\r\n\x05\x04\
-    1\x02\x04\x05\x12\x04\xb4\x03\x11\x17\n\r\n\x05\x041\x02\x04\x01\x12\x04\
-    \xb4\x03\x18\x1f\n\r\n\x05\x041\x02\x04\x03\x12\x04\xb4\x03\"#\na\n\x04\
-    \x041\x02\x05\x12\x04\xb7\x03\x08\x1f\x1aS\x20MountPoint\x20refers\x20to\
-    \x20the\x20path\x20where\x20the\x20storage\x20should\x20be\x20mounted\n\
-    \x20inside\x20the\x20VM.\n\n\r\n\x05\x041\x02\x05\x05\x12\x04\xb7\x03\
-    \x08\x0e\n\r\n\x05\x041\x02\x05\x01\x12\x04\xb7\x03\x0f\x1a\n\r\n\x05\
-    \x041\x02\x05\x03\x12\x04\xb7\x03\x1d\x1e\n\x88\x01\n\x02\x042\x12\x06\
-    \xbc\x03\0\xdc\x03\x01\x1az\x20Device\x20represents\x20only\x20the\x20de\
-    vices\x20that\x20could\x20have\x20been\x20defined\x20through\x20the\n\
-    \x20Linux\x20Device\x20list\x20of\x20the\x20OCI\x20specification.\n\n\
-    \x0b\n\x03\x042\x01\x12\x04\xbc\x03\x08\x0e\n\xb0\x01\n\x04\x042\x02\0\
-    \x12\x04\xc0\x03\x08\x16\x1a\xa1\x01\x20Id\x20can\x20be\x20used\x20to\
-    \x20identify\x20the\x20device\x20inside\x20the\x20VM.\x20Some\x20devices\
-    \n\x20might\x20not\x20need\x20it\x20to\x20be\x20identified\x20on\x20the\
-    \x20VM,\x20and\x20will\x20rely\x20on\x20the\n\x20provided\x20VmPath\x20i\
-    nstead.\n\n\r\n\x05\x042\x02\0\x05\x12\x04\xc0\x03\x08\x0e\n\r\n\x05\x04\
-    2\x02\0\x01\x12\x04\xc0\x03\x0f\x11\n\r\n\x05\x042\x02\0\x03\x12\x04\xc0\
-    \x03\x14\x15\n\xbd\x01\n\x04\x042\x02\x01\x12\x04\xc5\x03\x08\x18\x1a\
-    \xae\x01\x20Type\x20defines\x20the\x20type\x20of\x20device\x20described.\
-    \x20This\x20can\x20be\x20\"blk\",\n\x20\"scsi\",\x20\"vfio\",\x20...\n\
-    \x20Particularly,\x20this\x20should\x20be\x20used\x20to\x20trigger\x20th\
-    e\x20use\x20of\x20the\n\x20appropriate\x20device\x20handler.\n\n\r\n\x05\
-    \x042\x02\x01\x05\x12\x04\xc5\x03\x08\x0e\n\r\n\x05\x042\x02\x01\x01\x12\
-    \x04\xc5\x03\x0f\x13\n\r\n\x05\x042\x02\x01\x03\x12\x04\xc5\x03\x16\x17\
-    \n\xab\x02\n\x04\x042\x02\x02\x12\x04\xcb\x03\x08\x1b\x1a\x9c\x02\x20VmP\
-    ath\x20can\x20be\x20used\x20by\x20the\x20caller\x20to\x20provide\x20dire\
-    ctly\x20the\x20path\x20of\n\x20the\x20device\x20as\x20it\x20will\x20appe\
-    ar\x20inside\x20the\x20VM.\x20For\x20some\x20devices,\x20the\n\x20device\
-    \x20id\x20or\x20the\x20list\x20of\x20options\x20passed\x20might\x20not\
-    \x20be\x20enough\x20to\x20find\n\x20the\x20device.\x20In\x20those\x20cas\
-    es,\x20the\x20caller\x20should\x20predict\x20and\x20provide\n\x20this\
-    \x20vm_path.\n\n\r\n\x05\x042\x02\x02\x05\x12\x04\xcb\x03\x08\x0e\n\r\n\
-    \x05\x042\x02\x02\x01\x12\x04\xcb\x03\x0f\x16\n\r\n\x05\x042\x02\x02\x03\
-    \x12\x04\xcb\x03\x19\x1a\n\xd4\x05\n\x04\x042\x02\x03\x12\x04\xd7\x03\
-    \x08\"\x1a\xc5\x05\x20ContainerPath\x20defines\x20the\x20path\x20where\
-    \x20the\x20device\x20should\x20be\x20found\x20inside\n\x20the\x20contain\
-    er.\x20This\x20path\x20should\x20match\x20the\x20path\x20of\x20the\x20de\
-    vice\x20from\n\x20the\x20device\x20list\x20listed\x20inside\x20the\x20OC\
-    I\x20spec.\x20This\x20is\x20used\x20in\x20order\n\x20to\x20identify\x20t\
-    he\x20right\x20device\x20in\x20the\x20spec\x20and\x20update\x20it\x20wit\
-    h\x20the\n\x20right\x20options\x20such\x20as\x20major/minor\x20numbers\
-    \x20as\x20they\x20appear\x20inside\n\x20the\x20VM\x20for\x20instance.\
-    \x20Note\x20that\x20an\x20empty\x20ctr_path\x20should\x20be\x20used\n\
-    \x20to\x20make\x20sure\x20the\x20device\x20handler\x20inside\x20the\x20a\
-    gent\x20is\x20called,\x20but\n\x20no\x20spec\x20update\x20needs\x20to\
-    \x20be\x20performed.\x20This\x20has\x20to\x20happen\x20for\x20the\n\x20c\
-    ase\x20of\x20rootfs,\x20when\x20a\x20device\x20has\x20to\x20be\x20waited\
-    \x20for\x20after\x20it\x20has\n\x20been\x20hotplugged.\x20An\x20equivale\
-    nt\x20Storage\x20entry\x20should\x20be\x20defined\x20if\n\x20any\x20moun\
-    t\x20needs\x20to\x20be\x20performed\x20afterwards.\n\n\r\n\x05\x042\x02\
-    \x03\x05\x12\x04\xd7\x03\x08\x0e\n\r\n\x05\x042\x02\x03\x01\x12\x04\xd7\
-    \x03\x0f\x1d\n\r\n\x05\x042\x02\x03\x03\x12\x04\xd7\x03\x20!\n\xca\x01\n\
-    \x04\x042\x02\x04\x12\x04\xdb\x03\x08$\x1a\xbb\x01\x20Options\x20allows\
-    \x20the\x20caller\x20to\x20define\x20a\x20list\x20of\x20options\x20such\
-    \x20as\x20block\n\x20sizes,\x20numbers\x20of\x20luns,\x20...\x20which\
-    \x20are\x20very\x20specific\x20to\x20every\x20device\n\x20and\x20cannot\
-    \x20be\x20generalized\x20through\x20extra\x20fields.\n\n\r\n\x05\x042\
-    \x02\x04\x04\x12\x04\xdb\x03\x08\x10\n\r\n\x05\x042\x02\x04\x05\x12\x04\
-    \xdb\x03\x11\x17\n\r\n\x05\x042\x02\x04\x01\x12\x04\xdb\x03\x18\x1f\n\r\
-    \n\x05\x042\x02\x04\x03\x12\x04\xdb\x03\"#\n\x0c\n\x02\x043\x12\x06\xde\
-    \x03\0\xe2\x03\x01\n\x0b\n\x03\x043\x01\x12\x04\xde\x03\x08\x12\n\x0c\n\
-    \x04\x043\x02\0\x12\x04\xdf\x03\x08\x17\n\r\n\x05\x043\x02\0\x05\x12\x04\
-    \xdf\x03\x08\x0e\n\r\n\x05\x043\x02\0\x01\x12\x04\xdf\x03\x0f\x12\n\r\n\
-    \x05\x043\x02\0\x03\x12\x04\xdf\x03\x15\x16\n\x0c\n\x04\x043\x02\x01\x12\
-    \x04\xe0\x03\x08\x17\n\r\n\x05\x043\x02\x01\x05\x12\x04\xe0\x03\x08\x0e\
-    \n\r\n\x05\x043\x02\x01\x01\x12\x04\xe0\x03\x0f\x12\n\r\n\x05\x043\x02\
-    \x01\x03\x12\x04\xe0\x03\x15\x16\n\x0c\n\x04\x043\x02\x02\x12\x04\xe1\
-    \x03\x08+\n\r\n\x05\x043\x02\x02\x04\x12\x04\xe1\x03\x08\x10\n\r\n\x05\
-    \x043\x02\x02\x05\x12\x04\xe1\x03\x11\x17\n\r\n\x05\x043\x02\x02\x01\x12\
-    \x04\xe1\x03\x18&\n\r\n\x05\x043\x02\x02\x03\x12\x04\xe1\x03)*\n\x0c\n\
-    \x02\x044\x12\x06\xe4\x03\0\xf8\x03\x01\n\x0b\n\x03\x044\x01\x12\x04\xe4\
-    \x03\x08\x17\nj\n\x04\x044\x02\0\x12\x04\xe7\x03\x08\x18\x1a\\\x20Path\
-    \x20is\x20the\x20destination\x20file\x20in\x20the\x20guest.\x20It\x20mus\
-    t\x20be\x20absolute,\n\x20canonical\x20and\x20below\x20/run.\n\n\r\n\x05\
-    \x044\x02\0\x05\x12\x04\xe7\x03\x08\x0e\n\r\n\x05\x044\x02\0\x01\x12\x04\
-    \xe7\x03\x0f\x13\n\r\n\x05\x044\x02\0\x03\x12\x04\xe7\x03\x16\x17\n\xbd\
-    \x01\n\x04\x044\x02\x01\x12\x04\xeb\x03\x08\x1c\x1a\xae\x01\x20FileSize\
-    \x20is\x20the\x20expected\x20file\x20size,\x20for\x20security\x20reasons\
-    \x20write\x20operations\n\x20are\x20made\x20in\x20a\x20temporary\x20file\
-    ,\x20once\x20it\x20has\x20the\x20expected\x20size,\x20it's\x20moved\n\
-    \x20to\x20the\x20destination\x20path.\n\n\r\n\x05\x044\x02\x01\x05\x12\
-    \x04\xeb\x03\x08\r\n\r\n\x05\x044\x02\x01\x01\x12\x04\xeb\x03\x0e\x17\n\
-    \r\n\x05\x044\x02\x01\x03\x12\x04\xeb\x03\x1a\x1b\n*\n\x04\x044\x02\x02\
-    \x12\x04\xed\x03\x08\x1d\x1a\x1c\x20FileMode\x20is\x20the\x20file\x20mod\
-    e.\n\n\r\n\x05\x044\x02\x02\x05\x12\x04\xed\x03\x08\x0e\n\r\n\x05\x044\
-    \x02\x02\x01\x12\x04\xed\x03\x0f\x18\n\r\n\x05\x044\x02\x02\x03\x12\x04\
-    \xed\x03\x1b\x1c\nS\n\x04\x044\x02\x03\x12\x04\xef\x03\x08\x1c\x1aE\x20D\
-    irMode\x20is\x20the\x20mode\x20for\x20the\x20parent\x20directories\x20of\
-    \x20destination\x20path.\n\n\r\n\x05\x044\x02\x03\x05\x12\x04\xef\x03\
-    \x08\x0e\n\r\n\x05\x044\x02\x03\x01\x12\x04\xef\x03\x0f\x17\n\r\n\x05\
-    \x044\x02\x03\x03\x12\x04\xef\x03\x1a\x1b\n+\n\x04\x044\x02\x04\x12\x04\
-    \xf1\x03\x08\x16\x1a\x1d\x20Uid\x20is\x20the\x20numeric\x20user\x20id.\n\
-    \n\r\n\x05\x044\x02\x04\x05\x12\x04\xf1\x03\x08\r\n\r\n\x05\x044\x02\x04\
-    \x01\x12\x04\xf1\x03\x0e\x11\n\r\n\x05\x044\x02\x04\x03\x12\x04\xf1\x03\
-    \x14\x15\n,\n\x04\x044\x02\x05\x12\x04\xf3\x03\x08\x16\x1a\x1e\x20Gid\
-    \x20is\x20the\x20numeric\x20group\x20id.\n\n\r\n\x05\x044\x02\x05\x05\
-    \x12\x04\xf3\x03\x08\r\n\r\n\x05\x044\x02\x05\x01\x12\x04\xf3\x03\x0e\
-    \x11\n\r\n\x05\x044\x02\x05\x03\x12\x04\xf3\x03\x14\x15\n4\n\x04\x044\
-    \x02\x06\x12\x04\xf5\x03\x08\x19\x1a&\x20Offset\x20for\x20the\x20next\
-    \x20write\x20operation.\n\n\r\n\x05\x044\x02\x06\x05\x12\x04\xf5\x03\x08\
-    \r\n\r\n\x05\x044\x02\x06\x01\x12\x04\xf5\x03\x0e\x14\n\r\n\x05\x044\x02\
-    \x06\x03\x12\x04\xf5\x03\x17\x18\n6\n\x04\x044\x02\x07\x12\x04\xf7\x03\
-    \x08\x17\x1a(\x20Data\x20to\x20write\x20in\x20the\x20destination\x20file\
-    .\n\n\r\n\x05\x044\x02\x07\x05\x12\x04\xf7\x03\x08\r\n\r\n\x05\x044\x02\
-    \x07\x01\x12\x04\xf7\x03\x0e\x12\n\r\n\x05\x044\x02\x07\x03\x12\x04\xf7\
-    \x03\x15\x16\n\x0c\n\x02\x045\x12\x06\xfa\x03\0\xfb\x03\x01\n\x0b\n\x03\
-    \x045\x01\x12\x04\xfa\x03\x08\x1b\n\x0c\n\x02\x046\x12\x06\xfd\x03\0\xfe\
-    \x03\x01\n\x0b\n\x03\x046\x01\x12\x04\xfd\x03\x08\x1a\n\n\n\x02\x047\x12\
-    \x04\x80\x04\0\x1d\n\x0b\n\x03\x047\x01\x12\x04\x80\x04\x08\x1a\n\x0c\n\
-    \x02\x048\x12\x06\x82\x04\0\x84\x04\x01\n\x0b\n\x03\x048\x01\x12\x04\x82\
-    \x04\x08\x10\n\x0c\n\x04\x048\x02\0\x12\x04\x83\x04\x08\x20\n\r\n\x05\
-    \x048\x02\0\x05\x12\x04\x83\x04\x08\x0e\n\r\n\x05\x048\x02\0\x01\x12\x04\
-    \x83\x04\x0f\x1b\n\r\n\x05\x048\x02\0\x03\x12\x04\x83\x04\x1e\x1f\n\n\n\
-    \x02\x049\x12\x04\x86\x04\0\x1c\n\x0b\n\x03\x049\x01\x12\x04\x86\x04\x08\
-    \x19\n\x0c\n\x02\x04:\x12\x06\x88\x04\0\x8a\x04\x01\n\x0b\n\x03\x04:\x01\
-    \x12\x04\x88\x04\x08\x0f\n\x0c\n\x04\x04:\x02\0\x12\x04\x89\x04\x08\x1b\
-    \n\r\n\x05\x04:\x02\0\x05\x12\x04\x89\x04\x08\x0e\n\r\n\x05\x04:\x02\0\
-    \x01\x12\x04\x89\x04\x0f\x16\n\r\n\x05\x04:\x02\0\x03\x12\x04\x89\x04\
-    \x19\x1ab\x06proto3\
+    \x18*\n\x0c\n\x05\x06\0\x02\x20\x03\x12\x03D5=\n\x0b\n\x04\x06\0\x02!\
+    \x12\x03E\x08P\n\x0c\n\x05\x06\0\x02!\x01\x12\x03E\x0c\x18\n\x0c\n\x05\
+    \x06\0\x02!\x02\x12\x03E\x19.\n\x0c\n\x05\x06\0\x02!\x03\x12\x03E9N\n\
+    \x0b\n\x04\x06\0\x02\"\x12\x03F\x08A\n\x0c\n\x05\x06\0\x02\"\x01\x12\x03\
+    F\x0c\x14\n\x0c\n\x05\x06\0\x02\"\x02\x12\x03F\x15$\n\x0c\n\x05\x06\0\
+    \x02\"\x03\x12\x03F/?\n\x0b\n\x04\x06\0\x02#\x12\x03G\x08J\n\x0c\n\x05\
+    \x06\0\x02#\x01\x12\x03G\x0c\x17\n\x0c\n\x05\x06\0\x02#\x02\x12\x03G\x18\
+    *\n\x0c\n\x05\x06\0\x02#\x03\x12\x03G5H\n\xcf\x01\n\x04\x06\0\x02$\x12\
+    \x03K\x08P\x1a\xc1\x01\x20ReleaseDevice\x20has\x20the\x20guest\x20stop\
+    \x20using\x20a\x20device\x20about\x20to\x20be\x20hot\n\x20unplugged:\x20\
+    the\x20mounts\x20of\x20a\x20block\x20device\x20are\x20removed\x20and\x20\
+    its\x20buffers\n\x20written\x20out.\x20It\x20fails\x20when\x20a\x20mount\
+    \x20of\x20the\x20device\x20is\x20busy.\n\n\x0c\n\x05\x06\0\x02$\x01\x12\
+    \x03K\x0c\x19\n\x0c\n\x05\x06\0\x02$\x02\x12\x03K\x1a.\n\x0c\n\x05\x06\0\
+    \x02$\x03\x12\x03K9N\n\xd7\x01\n\x04\x06\0\x02%\x12\x03P\x08L\x1a\xc9\
+    \x01\x20The\x20files\x20the\x20next\x20RPCs\x20refer\x20to\x20are\x20cop\
+    ied\x20in\x20the\x20guest\x20beforehand,\n\x20by\x20CopyFile.\n\x20Insta\
+    llFile\x20installs\x20a\x20guest\x20file,\x20bind\x20mounted\x20over\x20\
+    the\x20existing\n\x20one\x20when\x20the\x20guest\x20root\x20filesystem\
+    \x20is\x20read-only.\n\n\x0c\n\x05\x06\0\x02%\x01\x12\x03P\x0c\x17\n\x0c\
+    \n\x05\x06\0\x02%\x02\x12\x03P\x18*\n\x0c\n\x05\x06\0\x02%\x03\x12\x03P5\
+    J\nl\n\x04\x06\0\x02&\x12\x03S\x08T\x1a_\x20LoadCrashKernel\x20loads\x20\
+    the\x20kernel\x20booted\x20by\x20the\x20guest\x20kernel\x20on\n\x20panic\
+    ,\x20to\x20capture\x20the\x20vmcore.\n\n\x0c\n\x05\x06\0\x02&\x01\x12\
+    \x03S\x0c\x1b\n\x0c\n\x05\x06\0\x02&\x02\x12\x03S\x1c2\n\x0c\n\x05\x06\0\
+    \x02&\x03\x12\x03S=R\nq\n\x04\x06\0\x02'\x12\x03V\x08R\x1ad\x20StartProf\
+    iling\x20starts\x20a\x20profiling\x20session\x20of\x20the\x20guest,\x20a\
+    nd\x20returns\n\x20once\x20the\x20profiling\x20tool\x20runs.\n\n\x0c\n\
+    \x05\x06\0\x02'\x01\x12\x03V\x0c\x1a\n\x0c\n\x05\x06\0\x02'\x02\x12\x03V\
+    \x1b0\n\x0c\n\x05\x06\0\x02'\x03\x12\x03V;P\nL\n\x04\x06\0\x02(\x12\x03X\
+    \x08P\x1a?\x20LoadLivepatch\x20applies\x20a\x20kernel\x20livepatch\x20mo\
+    dule\x20to\x20the\x20guest.\n\n\x0c\n\x05\x06\0\x02(\x01\x12\x03X\x0c\
+    \x19\n\x0c\n\x05\x06\0\x02(\x02\x12\x03X\x1a.\n\x0c\n\x05\x06\0\x02(\x03\
+    \x12\x03X9N\nb\n\x04\x06\0\x02)\x12\x03[\x08\\\x1aU\x20CheckpointContain\
+    er\x20and\x20RestoreContainer\x20run\x20CRIU\x20on\x20the\x20processes\n\
+    \x20of\x20a\x20container.\n\n\x0c\n\x05\x06\0\x02)\x01\x12\x03[\x0c\x1f\
+    \n\x0c\n\x05\x06\0\x02)\x02\x12\x03[\x20:\n\x0c\n\x05\x06\0\x02)\x03\x12\
+    \x03[EZ\n\x0b\n\x04\x06\0\x02*\x12\x03\\\x08V\n\x0c\n\x05\x06\0\x02*\x01\
+    \x12\x03\\\x0c\x1c\n\x0c\n\x05\x06\0\x02*\x02\x12\x03\\\x1d4\n\x0c\n\x05\
+    \x06\0\x02*\x03\x12\x03\\?T\n\n\n\x02\x04\0\x12\x04_\0m\x01\n\n\n\x03\
+    \x04\0\x01\x12\x03_\x08\x1e\n\x0b\n\x04\x04\0\x02\0\x12\x03`\x08\x20\n\
+    \x0c\n\x05\x04\0\x02\0\x05\x12\x03`\x08\x0e\n\x0c\n\x05\x04\0\x02\0\x01\
+    \x12\x03`\x0f\x1b\n\x0c\n\x05\x04\0\x02\0\x03\x12\x03`\x1e\x1f\n\x0b\n\
+    \x04\x04\0\x02\x01\x12\x03a\x08\x1b\n\x0c\n\x05\x04\0\x02\x01\x05\x12\
+    \x03a\x08\x0e\n\x0c\n\x05\x04\0\x02\x01\x01\x12\x03a\x0f\x16\n\x0c\n\x05\
+    \x04\0\x02\x01\x03\x12\x03a\x19\x1a\n\x0b\n\x04\x04\0\x02\x02\x12\x03b\
+    \x08#\n\x0c\n\x05\x04\0\x02\x02\x06\x12\x03b\x08\x12\n\x0c\n\x05\x04\0\
+    \x02\x02\x01\x12\x03b\x13\x1e\n\x0c\n\x05\x04\0\x02\x02\x03\x12\x03b!\"\
+    \n\x0b\n\x04\x04\0\x02\x03\x12\x03c\x08$\n\x0c\n\x05\x04\0\x02\x03\x04\
+    \x12\x03c\x08\x10\n\x0c\n\x05\x04\0\x02\x03\x06\x12\x03c\x11\x17\n\x0c\n\
+    \x05\x04\0\x02\x03\x01\x12\x03c\x18\x1f\n\x0c\n\x05\x04\0\x02\x03\x03\
+    \x12\x03c\"#\n\x0b\n\x04\x04\0\x02\x04\x12\x03d\x08&\n\x0c\n\x05\x04\0\
+    \x02\x04\x04\x12\x03d\x08\x10\n\x0c\n\x05\x04\0\x02\x04\x06\x12\x03d\x11\
+    \x18\n\x0c\n\x05\x04\0\x02\x04\x01\x12\x03d\x19!\n\x0c\n\x05\x04\0\x02\
+    \x04\x03\x12\x03d$%\n\x0b\n\x04\x04\0\x02\x05\x12\x03e\x08\x15\n\x0c\n\
+    \x05\x04\0\x02\x05\x06\x12\x03e\x08\x0c\n\x0c\n\x05\x04\0\x02\x05\x01\
+    \x12\x03e\r\x10\n\x0c\n\x05\x04\0\x02\x05\x03\x12\x03e\x13\x14\n\xba\x02\
+    \n\x04\x04\0\x02\x06\x12\x03l\x08\x1f\x1a\xac\x02\x20This\x20field\x20is\
+    \x20used\x20to\x20indicate\x20if\x20the\x20container\x20needs\x20to\x20j\
+    oin\n\x20sandbox\x20shared\x20pid\x20ns\x20or\x20create\x20a\x20new\x20n\
+    amespace.\x20This\x20field\x20is\n\x20meant\x20to\x20override\x20the\x20\
+    NEWPID\x20config\x20settings\x20in\x20the\x20OCI\x20spec.\n\x20The\x20ag\
+    ent\x20would\x20receive\x20an\x20OCI\x20spec\x20with\x20PID\x20namespace\
+    \x20cleared\n\x20out\x20altogether\x20and\x20not\x20just\x20the\x20pid\
+    \x20ns\x20path.\n\n\x0c\n\x05\x04\0\x02\x06\x05\x12\x03l\x08\x0c\n\x0c\n\
+    \x05\x04\0\x02\x06\x01\x12\x03l\r\x1a\n\x0c\n\x05\x04\0\x02\x06\x03\x12\
+    \x03l\x1d\x1e\n\n\n\x02\x04\x01\x12\x04o\0q\x01\n\n\n\x03\x04\x01\x01\
+    \x12\x03o\x08\x1d\n\x0b\n\x04\x04\x01\x02\0\x12\x03p\x08\x20\n\x0c\n\x05\
+    \x04\x01\x02\0\x05\x12\x03p\x08\x0e\n\x0c\n\x05\x04\x01\x02\0\x01\x12\
+    \x03p\x0f\x1b\n\x0c\n\x05\x04\x01\x02\0\x03\x12\x03p\x1e\x1f\n\n\n\x02\
+    \x04\x02\x12\x04s\0|\x01\n\n\n\x03\x04\x02\x01\x12\x03s\x08\x1e\n\x0b\n\
+    \x04\x04\x02\x02\0\x12\x03t\x08\x20\n\x0c\n\x05\x04\x02\x02\0\x05\x12\
+    \x03t\x08\x0e\n\x0c\n\x05\x04\x02\x02\0\x01\x12\x03t\x0f\x1b\n\x0c\n\x05\
+    \x04\x02\x02\0\x03\x12\x03t\x1e\x1f\n\xbc\x01\n\x04\x04\x02\x02\x01\x12\
+    \x03{\x08\x1b\x1a\xae\x01\x20RemoveContainer\x20will\x20return\x20an\x20\
+    error\x20if\n\x20it\x20could\x20not\x20kill\x20some\x20container\x20proc\
+    esses\n\x20after\x20timeout\x20seconds.\n\x20Setting\x20timeout\x20to\
+    \x200\x20means\x20RemoveContainer\x20will\n\x20wait\x20for\x20ever.\n\n\
+    \x0c\n\x05\x04\x02\x02\x01\x05\x12\x03{\x08\x0e\n\x0c\n\x05\x04\x02\x02\
+    \x01\x01\x12\x03{\x0f\x16\n\x0c\n\x05\x04\x02\x02\x01\x03\x12\x03{\x19\
+    \x1a\n\x0b\n\x02\x04\x03\x12\x05~\0\x83\x01\x01\n\n\n\x03\x04\x03\x01\
+    \x12\x03~\x08\x1a\n\x0b\n\x04\x04\x03\x02\0\x12\x03\x7f\x08\x20\n\x0c\n\
+    \x05\x04\x03\x02\0\x05\x12\x03\x7f\x08\x0e\n\x0c\n\x05\x04\x03\x02\0\x01\
+    \x12\x03\x7f\x0f\x1b\n\x0c\n\x05\x04\x03\x02\0\x03\x12\x03\x7f\x1e\x1f\n\
+    \x0c\n\x04\x04\x03\x02\x01\x12\x04\x80\x01\x08\x1b\n\r\n\x05\x04\x03\x02\
+    \x01\x05\x12\x04\x80\x01\x08\x0e\n\r\n\x05\x04\x03\x02\x01\x01\x12\x04\
+    \x80\x01\x0f\x16\n\r\n\x05\x04\x03\x02\x01\x03\x12\x04\x80\x01\x19\x1a\n\
+    \x0c\n\x04\x04\x03\x02\x02\x12\x04\x81\x01\x08#\n\r\n\x05\x04\x03\x02\
+    \x02\x06\x12\x04\x81\x01\x08\x12\n\r\n\x05\x04\x03\x02\x02\x01\x12\x04\
+    \x81\x01\x13\x1e\n\r\n\x05\x04\x03\x02\x02\x03\x12\x04\x81\x01!\"\n\x0c\
+    \n\x04\x04\x03\x02\x03\x12\x04\x82\x01\x08\x1c\n\r\n\x05\x04\x03\x02\x03\
+    \x06\x12\x04\x82\x01\x08\x0f\n\r\n\x05\x04\x03\x02\x03\x01\x12\x04\x82\
+    \x01\x10\x17\n\r\n\x05\x04\x03\x02\x03\x03\x12\x04\x82\x01\x1a\x1b\n\x0c\
+    \n\x02\x04\x04\x12\x06\x85\x01\0\x8d\x01\x01\n\x0b\n\x03\x04\x04\x01\x12\
+    \x04\x85\x01\x08\x1c\n\x0c\n\x04\x04\x04\x02\0\x12\x04\x86\x01\x08\x20\n\
+    \r\n\x05\x04\x04\x02\0\x05\x12\x04\x86\x01\x08\x0e\n\r\n\x05\x04\x04\x02\
+    \0\x01\x12\x04\x86\x01\x0f\x1b\n\r\n\x05\x04\x04\x02\0\x03\x12\x04\x86\
+    \x01\x1e\x1f\n\xe9\x01\n\x04\x04\x04\x02\x01\x12\x04\x8b\x01\x08\x1b\x1a\
+    \xda\x01\x20Special\x20case\x20for\x20SignalProcess():\x20exec_id\x20can\
+    \x20be\x20empty(\"\"),\n\x20which\x20means\x20to\x20send\x20the\x20signa\
+    l\x20to\x20all\x20the\x20processes\x20including\x20their\x20descendants.\
+    \n\x20Other\x20APIs\x20with\x20exec_id\x20should\x20treat\x20empty\x20ex\
+    ec_id\x20as\x20an\x20invalid\x20request.\n\n\r\n\x05\x04\x04\x02\x01\x05\
+    \x12\x04\x8b\x01\x08\x0e\n\r\n\x05\x04\x04\x02\x01\x01\x12\x04\x8b\x01\
+    \x0f\x16\n\r\n\x05\x04\x04\x02\x01\x03\x12\x04\x8b\x01\x19\x1a\n\x0c\n\
+    \x04\x04\x04\x02\x02\x12\x04\x8c\x01\x08\x1a\n\r\n\x05\x04\x04\x02\x02\
+    \x05\x12\x04\x8c\x01\x08\x0e\n\r\n\x05\x04\x04\x02\x02\x01\x12\x04\x8c\
+    \x01\x0f\x15\n\r\n\x05\x04\x04\x02\x02\x03\x12\x04\x8c\x01\x18\x19\n\x0c\
+    \n\x02\x04\x05\x12\x06\x8f\x01\0\x92\x01\x01\n\x0b\n\x03\x04\x05\x01\x12\
+    \x04\x8f\x01\x08\x1a\n\x0c\n\x04\x04\x05\x02\0\x12\x04\x90\x01\x08\x20\n\
+    \r\n\x05\x04\x05\x02\0\x05\x12\x04\x90\x01\x08\x0e\n\r\n\x05\x04\x05\x02\
+    \0\x01\x12\x04\x90\x01\x0f\x1b\n\r\n\x05\x04\x05\x02\0\x03\x12\x04\x90\
+    \x01\x1e\x1f\n\x0c\n\x04\x04\x05\x02\x01\x12\x04\x91\x01\x08\x1b\n\r\n\
+    \x05\x04\x05\x02\x01\x05\x12\x04\x91\x01\x08\x0e\n\r\n\x05\x04\x05\x02\
+    \x01\x01\x12\x04\x91\x01\x0f\x16\n\r\n\x05\x04\x05\x02\x01\x03\x12\x04\
+    \x91\x01\x19\x1a\n\x0c\n\x02\x04\x06\x12\x06\x94\x01\0\x96\x01\x01\n\x0b\
+    \n\x03\x04\x06\x01\x12\x04\x94\x01\x08\x1b\n\x0c\n\x04\x04\x06\x02\0\x12\
+    \x04\x95\x01\x08\x19\n\r\n\x05\x04\x06\x02\0\x05\x12\x04\x95\x01\x08\r\n\
+    \r\n\x05\x04\x06\x02\0\x01\x12\x04\x95\x01\x0e\x14\n\r\n\x05\x04\x06\x02\
+    \0\x03\x12\x04\x95\x01\x17\x18\nm\n\x02\x04\x07\x12\x06\x99\x01\0\x9d\
+    \x01\x01\x1a_\x20ListProcessesRequest\x20contains\x20the\x20options\x20u\
+    sed\x20to\x20list\x20running\x20processes\x20inside\x20the\x20container\
+    \n\n\x0b\n\x03\x04\x07\x01\x12\x04\x99\x01\x08\x1c\n\x0c\n\x04\x04\x07\
+    \x02\0\x12\x04\x9a\x01\x08\x20\n\r\n\x05\x04\x07\x02\0\x05\x12\x04\x9a\
+    \x01\x08\x0e\n\r\n\x05\x04\x07\x02\0\x01\x12\x04\x9a\x01\x0f\x1b\n\r\n\
+    \x05\x04\x07\x02\0\x03\x12\x04\x9a\x01\x1e\x1f\n\x0c\n\x04\x04\x07\x02\
+    \x01\x12\x04\x9b\x01\x08\x1a\n\r\n\x05\x04\x07\x02\x01\x05\x12\x04\x9b\
+    \x01\x08\x0e\n\r\n\x05\x04\x07\x02\x01\x01\x12\x04\x9b\x01\x0f\x15\n\r\n\
+    \x05\x04\x07\x02\x01\x03\x12\x04\x9b\x01\x18\x19\n\x0c\n\x04\x04\x07\x02\
+    \x02\x12\x04\x9c\x01\x08!\n\r\n\x05\x04\x07\x02\x02\x04\x12\x04\x9c\x01\
+    \x08\x10\n\r\n\x05\x04\x07\x02\x02\x05\x12\x04\x9c\x01\x11\x17\n\r\n\x05\
+    \x04\x07\x02\x02\x01\x12\x04\x9c\x01\x18\x1c\n\r\n\x05\x04\x07\x02\x02\
+    \x03\x12\x04\x9c\x01\x1f\x20\nc\n\x02\x04\x08\x12\x06\xa0\x01\0\xa2\x01\
+    \x01\x1aU\x20ListProcessesResponse\x20represents\x20the\x20list\x20of\
+    \x20running\x20processes\x20inside\x20the\x20container\n\n\x0b\n\x03\x04\
+    \x08\x01\x12\x04\xa0\x01\x08\x1d\n\x0c\n\x04\x04\x08\x02\0\x12\x04\xa1\
+    \x01\x08\x1f\n\r\n\x05\x04\x08\x02\0\x05\x12\x04\xa1\x01\x08\r\n\r\n\x05\
+    \x04\x08\x02\0\x01\x12\x04\xa1\x01\x0e\x1a\n\r\n\x05\x04\x08\x02\0\x03\
+    \x12\x04\xa1\x01\x1d\x1e\n\x0c\n\x02\x04\t\x12\x06\xa4\x01\0\xa7\x01\x01\
+    \n\x0b\n\x03\x04\t\x01\x12\x04\xa4\x01\x08\x1e\n\x0c\n\x04\x04\t\x02\0\
+    \x12\x04\xa5\x01\x08\x20\n\r\n\x05\x04\t\x02\0\x05\x12\x04\xa5\x01\x08\
+    \x0e\n\r\n\x05\x04\t\x02\0\x01\x12\x04\xa5\x01\x0f\x1b\n\r\n\x05\x04\t\
+    \x02\0\x03\x12\x04\xa5\x01\x1e\x1f\n\x0c\n\x04\x04\t\x02\x01\x12\x04\xa6\
+    \x01\x08%\n\r\n\x05\x04\t\x02\x01\x06\x12\x04\xa6\x01\x08\x16\n\r\n\x05\
+    \x04\t\x02\x01\x01\x12\x04\xa6\x01\x17\x20\n\r\n\x05\x04\t\x02\x01\x03\
+    \x12\x04\xa6\x01#$\n\x0c\n\x02\x04\n\x12\x06\xa9\x01\0\xab\x01\x01\n\x0b\
+    \n\x03\x04\n\x01\x12\x04\xa9\x01\x08\x1d\n\x0c\n\x04\x04\n\x02\0\x12\x04\
+    \xaa\x01\x04\x1c\n\r\n\x05\x04\n\x02\0\x05\x12\x04\xaa\x01\x04\n\n\r\n\
+    \x05\x04\n\x02\0\x01\x12\x04\xaa\x01\x0b\x17\n\r\n\x05\x04\n\x02\0\x03\
+    \x12\x04\xaa\x01\x1a\x1b\n\x0c\n\x02\x04\x0b\x12\x06\xad\x01\0\xaf\x01\
+    \x01\n\x0b\n\x03\x04\x0b\x01\x12\x04\xad\x01\x08\x1d\n\x0c\n\x04\x04\x0b\
+    \x02\0\x12\x04\xae\x01\x04\x1c\n\r\n\x05\x04\x0b\x02\0\x05\x12\x04\xae\
+    \x01\x04\n\n\r\n\x05\x04\x0b\x02\0\x01\x12\x04\xae\x01\x0b\x17\n\r\n\x05\
+    \x04\x0b\x02\0\x03\x12\x04\xae\x01\x1a\x1b\n\x0c\n\x02\x04\x0c\x12\x06\
+    \xb1\x01\0\xb3\x01\x01\n\x0b\n\x03\x04\x0c\x01\x12\x04\xb1\x01\x08\x1e\n\
+    \x0c\n\x04\x04\x0c\x02\0\x12\x04\xb2\x01\x04\x1c\n\r\n\x05\x04\x0c\x02\0\
+    \x05\x12\x04\xb2\x01\x04\n\n\r\n\x05\x04\x0c\x02\0\x01\x12\x04\xb2\x01\
+    \x0b\x17\n\r\n\x05\x04\x0c\x02\0\x03\x12\x04\xb2\x01\x1a\x1b\n\x0c\n\x02\
+    \x04\r\x12\x06\xb5\x01\0\xba\x01\x01\n\x0b\n\x03\x04\r\x01\x12\x04\xb5\
+    \x01\x08\x10\n\x0c\n\x04\x04\r\x02\0\x12\x04\xb6\x01\x08\x1f\n\r\n\x05\
+    \x04\r\x02\0\x05\x12\x04\xb6\x01\x08\x0e\n\r\n\x05\x04\r\x02\0\x01\x12\
+    \x04\xb6\x01\x0f\x1a\n\r\n\x05\x04\r\x02\0\x03\x12\x04\xb6\x01\x1d\x1e\n\
+    \x0c\n\x04\x04\r\x02\x01\x12\x04\xb7\x01\x08)\n\r\n\x05\x04\r\x02\x01\
+    \x04\x12\x04\xb7\x01\x08\x10\n\r\n\x05\x04\r\x02\x01\x05\x12\x04\xb7\x01\
+    \x11\x17\n\r\n\x05\x04\r\x02\x01\x01\x12\x04\xb7\x01\x18$\n\r\n\x05\x04\
+    \r\x02\x01\x03\x12\x04\xb7\x01'(\n\x0c\n\x04\x04\r\x02\x02\x12\x04\xb8\
+    \x01\x08'\n\r\n\x05\x04\r\x02\x02\x05\x12\x04\xb8\x01\x08\x0e\n\r\n\x05\
+    \x04\r\x02\x02\x01\x12\x04\xb8\x01\x0f\"\n\r\n\x05\x04\r\x02\x02\x03\x12\
+    \x04\xb8\x01%&\n\x0c\n\x04\x04\r\x02\x03\x12\x04\xb9\x01\x08%\n\r\n\x05\
+    \x04\r\x02\x03\x05\x12\x04\xb9\x01\x08\x0e\n\r\n\x05\x04\r\x02\x03\x01\
+    \x12\x04\xb9\x01\x0f\x20\n\r\n\x05\x04\r\x02\x03\x03\x12\x04\xb9\x01#$\n\
+    \x0c\n\x02\x04\x0e\x12\x06\xbc\x01\0\xc0\x01\x01\n\x0b\n\x03\x04\x0e\x01\
+    \x12\x04\xbc\x01\x08\x16\n\x0c\n\x04\x04\x0e\x02\0\x12\x04\xbd\x01\x08\
+    \x1b\n\r\n\x05\x04\x0e\x02\0\x05\x12\x04\xbd\x01\x08\x0e\n\r\n\x05\x04\
+    \x0e\x02\0\x01\x12\x04\xbd\x01\x0f\x16\n\r\n\x05\x04\x0e\x02\0\x03\x12\
+    \x04\xbd\x01\x19\x1a\n\x0c\n\x04\x04\x0e\x02\x01\x12\x04\xbe\x01\x08%\n\
+    \r\n\x05\x04\x0e\x02\x01\x05\x12\x04\xbe\x01\x08\x0e\n\r\n\x05\x04\x0e\
+    \x02\x01\x01\x12\x04\xbe\x01\x0f\x20\n\r\n\x05\x04\x0e\x02\x01\x03\x12\
+    \x04\xbe\x01#$\n\x0c\n\x04\x04\x0e\x02\x02\x12\x04\xbf\x01\x08\"\n\r\n\
+    \x05\x04\x0e\x02\x02\x05\x12\x04\xbf\x01\x08\x0e\n\r\n\x05\x04\x0e\x02\
+    \x02\x01\x12\x04\xbf\x01\x0f\x1d\n\r\n\x05\x04\x0e\x02\x02\x03\x12\x04\
+    \xbf\x01\x20!\n\x0c\n\x02\x04\x0f\x12\x06\xc2\x01\0\xc5\x01\x01\n\x0b\n\
+    \x03\x04\x0f\x01\x12\x04\xc2\x01\x08\x10\n\x0c\n\x04\x04\x0f\x02\0\x12\
+    \x04\xc3\x01\x08\x1f\n\r\n\x05\x04\x0f\x02\0\x06\x12\x04\xc3\x01\x08\x10\
+    \n\r\n\x05\x04\x0f\x02\0\x01\x12\x04\xc3\x01\x11\x1a\n\r\n\x05\x04\x0f\
+    \x02\0\x03\x12\x04\xc3\x01\x1d\x1e\n\x0c\n\x04\x04\x0f\x02\x01\x12\x04\
+    \xc4\x01\x08+\n\r\n\x05\x04\x0f\x02\x01\x06\x12\x04\xc4\x01\x08\x16\n\r\
+    \n\x05\x04\x0f\x02\x01\x01\x12\x04\xc4\x01\x17&\n\r\n\x05\x04\x0f\x02\
+    \x01\x03\x12\x04\xc4\x01)*\n\x0c\n\x02\x04\x10\x12\x06\xc7\x01\0\xcb\x01\
+    \x01\n\x0b\n\x03\x04\x10\x01\x12\x04\xc7\x01\x08\x11\n\x0c\n\x04\x04\x10\
+    \x02\0\x12\x04\xc8\x01\x08\x1b\n\r\n\x05\x04\x10\x02\0\x05\x12\x04\xc8\
+    \x01\x08\x0e\n\r\n\x05\x04\x10\x02\0\x01\x12\x04\xc8\x01\x0f\x16\n\r\n\
+    \x05\x04\x10\x02\0\x03\x12\x04\xc8\x01\x19\x1a\n\x0c\n\x04\x04\x10\x02\
+    \x01\x12\x04\xc9\x01\x08\x19\n\r\n\x05\x04\x10\x02\x01\x05\x12\x04\xc9\
+    \x01\x08\x0e\n\r\n\x05\x04\x10\x02\x01\x01\x12\x04\xc9\x01\x0f\x14\n\r\n\
+    \x05\x04\x10\x02\x01\x03\x12\x04\xc9\x01\x17\x18\nG\n\x04\x04\x10\x02\
+    \x02\x12\x04\xca\x01\x08\x1b\"9\x20number\x20of\x20exited\x20processes\
+    \x20of\x20the\x20cgroup\x20not\x20reaped\x20yet\n\n\r\n\x05\x04\x10\x02\
+    \x02\x05\x12\x04\xca\x01\x08\x0e\n\r\n\x05\x04\x10\x02\x02\x01\x12\x04\
+    \xca\x01\x0f\x16\n\r\n\x05\x04\x10\x02\x02\x03\x12\x04\xca\x01\x19\x1a\n\
+    \x0c\n\x02\x04\x11\x12\x06\xcd\x01\0\xd2\x01\x01\n\x0b\n\x03\x04\x11\x01\
+    \x12\x04\xcd\x01\x08\x12\n\x0c\n\x04\x04\x11\x02\0\x12\x04\xce\x01\x08\
+    \x19\n\r\n\x05\x04\x11\x02\0\x05\x12\x04\xce\x01\x08\x0e\n\r\n\x05\x04\
+    \x11\x02\0\x01\x12\x04\xce\x01\x0f\x14\n\r\n\x05\x04\x11\x02\0\x03\x12\
+    \x04\xce\x01\x17\x18\n\x0c\n\x04\x04\x11\x02\x01\x12\x04\xcf\x01\x08\x1d\
+    \n\r\n\x05\x04\x11\x02\x01\x05\x12\x04\xcf\x01\x08\x0e\n\r\n\x05\x04\x11\
+    \x02\x01\x01\x12\x04\xcf\x01\x0f\x18\n\r\n\x05\x04\x11\x02\x01\x03\x12\
+    \x04\xcf\x01\x1b\x1c\n\x0c\n\x04\x04\x11\x02\x02\x12\x04\xd0\x01\x08\x1b\
+    \n\r\n\x05\x04\x11\x02\x02\x05\x12\x04\xd0\x01\x08\x0e\n\r\n\x05\x04\x11\
+    \x02\x02\x01\x12\x04\xd0\x01\x0f\x16\n\r\n\x05\x04\x11\x02\x02\x03\x12\
+    \x04\xd0\x01\x19\x1a\n\x0c\n\x04\x04\x11\x02\x03\x12\x04\xd1\x01\x08\x19\
+    \n\r\n\x05\x04\x11\x02\x03\x05\x12\x04\xd1\x01\x08\x0e\n\r\n\x05\x04\x11\
+    \x02\x03\x01\x12\x04\xd1\x01\x0f\x14\n\r\n\x05\x04\x11\x02\x03\x03\x12\
+    \x04\xd1\x01\x17\x18\n\x0c\n\x02\x04\x12\x12\x06\xd4\x01\0\xde\x01\x01\n\
+    \x0b\n\x03\x04\x12\x01\x12\x04\xd4\x01\x08\x13\n\x0c\n\x04\x04\x12\x02\0\
+    \x12\x04\xd5\x01\x08\x19\n\r\n\x05\x04\x12\x02\0\x05\x12\x04\xd5\x01\x08\
+    \x0e\n\r\n\x05\x04\x12\x02\0\x01\x12\x04\xd5\x01\x0f\x14\n\r\n\x05\x04\
+    \x12\x02\0\x03\x12\x04\xd5\x01\x17\x18\n\x0c\n\x04\x04\x12\x02\x01\x12\
+    \x04\xd6\x01\x08\x1d\n\r\n\x05\x04\x12\x02\x01\x06\x12\x04\xd6\x01\x08\
+    \x12\n\r\n\x05\x04\x12\x02\x01\x01\x12\x04\xd6\x01\x13\x18\n\r\n\x05\x04\
+    \x12\x02\x01\x03\x12\x04\xd6\x01\x1b\x1c\n\x0c\n\x04\x04\x12\x02\x02\x12\
+    \x04\xd7\x01\x08\"\n\r\n\x05\x04\x12\x02\x02\x06\x12\x04\xd7\x01\x08\x12\
+    \n\r\n\x05\x04\x12\x02\x02\x01\x12\x04\xd7\x01\x13\x1d\n\r\n\x05\x04\x12\
+    \x02\x02\x03\x12\x04\xd7\x01\x20!\n\x0c\n\x04\x04\x12\x02\x03\x12\x04\
+    \xd8\x01\x08$\n\r\n\x05\x04\x12\x02\x03\x06\x12\x04\xd8\x01\x08\x12\n\r\
+    \n\x05\x04\x12\x02\x03\x01\x12\x04\xd8\x01\x13\x1f\n\r\n\x05\x04\x12\x02\
+    \x03\x03\x12\x04\xd8\x01\"#\n\x0c\n\x04\x04\x12\x02\x04\x12\x04\xd9\x01\
+    \x08\x1f\n\r\n\x05\x04\x12\x02\x04\x05\x12\x04\xd9\x01\x08\x0c\n\r\n\x05\
+    \x04\x12\x02\x04\x01\x12\x04\xd9\x01\r\x1a\n\r\n\x05\x04\x12\x02\x04\x03\
+    \x12\x04\xd9\x01\x1d\x1e\n\x0c\n\x04\x04\x12\x02\x05\x12\x04\xda\x01\x08\
+    &\n\r\n\x05\x04\x12\x02\x05\x06\x12\x04\xda\x01\x08\x1b\n\r\n\x05\x04\
+    \x12\x02\x05\x01\x12\x04\xda\x01\x1c!\n\r\n\x05\x04\x12\x02\x05\x03\x12\
+    \x04\xda\x01$%\n}\n\x04\x04\x12\x02\x06\x12\x04\xdd\x01\x08\x18\x1ao\x20\
+    idle\x20is\x20the\x20memory\x20of\x20the\x20cgroup\x20the\x20guest\x20di\
+    d\x20not\x20access\x20during\x20the\n\x20last\x20idle\x20page\x20trackin\
+    g\x20scan,\x20in\x20bytes.\n\n\r\n\x05\x04\x12\x02\x06\x05\x12\x04\xdd\
+    \x01\x08\x0e\n\r\n\x05\x04\x12\x02\x06\x01\x12\x04\xdd\x01\x0f\x13\n\r\n\
+    \x05\x04\x12\x02\x06\x03\x12\x04\xdd\x01\x16\x17\n\x0c\n\x02\x04\x13\x12\
+    \x06\xe1\x01\0\xe6\x01\x01\n\x0b\n\x03\x04\x13\x01\x12\x04\xe1\x01\x08\
+    \x17\n\x0c\n\x04\x04\x13\x02\0\x12\x04\xe2\x01\x08\x19\n\r\n\x05\x04\x13\
+    \x02\0\x05\x12\x04\xe2\x01\x08\x0e\n\r\n\x05\x04\x13\x02\0\x01\x12\x04\
+    \xe2\x01\x0f\x14\n\r\n\x05\x04\x13\x02\0\x03\x12\x04\xe2\x01\x17\x18\n\
+    \x0c\n\x04\x04\x13\x02\x01\x12\x04\xe3\x01\x08\x19\n\r\n\x05\x04\x13\x02\
+    \x01\x05\x12\x04\xe3\x01\x08\x0e\n\r\n\x05\x04\x13\x02\x01\x01\x12\x04\
+    \xe3\x01\x0f\x14\n\r\n\x05\x04\x13\x02\x01\x03\x12\x04\xe3\x01\x17\x18\n\
+    \x0c\n\x04\x04\x13\x02\x02\x12\x04\xe4\x01\x08\x16\n\r\n\x05\x04\x13\x02\
+    \x02\x05\x12\x04\xe4\x01\x08\x0e\n\r\n\x05\x04\x13\x02\x02\x01\x12\x04\
+    \xe4\x01\x0f\x11\n\r\n\x05\x04\x13\x02\x02\x03\x12\x04\xe4\x01\x14\x15\n\
+    \x0c\n\x04\x04\x13\x02\x03\x12\x04\xe5\x01\x08\x19\n\r\n\x05\x04\x13\x02\
+    \x03\x05\x12\x04\xe5\x01\x08\x0e\n\r\n\x05\x04\x13\x02\x03\x01\x12\x04\
+    \xe5\x01\x0f\x14\n\r\n\x05\x04\x13\x02\x03\x03\x12\x04\xe5\x01\x17\x18\n\
+    \x0c\n\x02\x04\x14\x12\x06\xe8\x01\0\xf1\x01\x01\n\x0b\n\x03\x04\x14\x01\
+    \x12\x04\xe8\x01\x08\x12\nH\n\x04\x04\x14\x02\0\x12\x04\xe9\x01\x08@\":\
+    \x20number\x20of\x20bytes\x20transferred\x20to\x20and\x20from\x20the\x20\
+    block\x20device\n\n\r\n\x05\x04\x14\x02\0\x04\x12\x04\xe9\x01\x08\x10\n\
+    \r\n\x05\x04\x14\x02\0\x06\x12\x04\xe9\x01\x11\x20\n\r\n\x05\x04\x14\x02\
+    \0\x01\x12\x04\xe9\x01!;\n\r\n\x05\x04\x14\x02\0\x03\x12\x04\xe9\x01>?\n\
+    \x0c\n\x04\x04\x14\x02\x01\x12\x04\xea\x01\x08;\n\r\n\x05\x04\x14\x02\
+    \x01\x04\x12\x04\xea\x01\x08\x10\n\r\n\x05\x04\x14\x02\x01\x06\x12\x04\
+    \xea\x01\x11\x20\n\r\n\x05\x04\x14\x02\x01\x01\x12\x04\xea\x01!6\n\r\n\
+    \x05\x04\x14\x02\x01\x03\x12\x04\xea\x019:\n\x0c\n\x04\x04\x14\x02\x02\
+    \x12\x04\xeb\x01\x089\n\r\n\x05\x04\x14\x02\x02\x04\x12\x04\xeb\x01\x08\
+    \x10\n\r\n\x05\x04\x14\x02\x02\x06\x12\x04\xeb\x01\x11\x20\n\r\n\x05\x04\
+    \x14\x02\x02\x01\x12\x04\xeb\x01!4\n\r\n\x05\x04\x14\x02\x02\x03\x12\x04\
+    \xeb\x0178\n\x0c\n\x04\x04\x14\x02\x03\x12\x04\xec\x01\x08?\n\r\n\x05\
+    \x04\x14\x02\x03\x04\x12\x04\xec\x01\x08\x10\n\r\n\x05\x04\x14\x02\x03\
+    \x06\x12\x04\xec\x01\x11\x20\n\r\n\x05\x04\x14\x02\x03\x01\x12\x04\xec\
+    \x01!:\n\r\n\x05\x04\x14\x02\x03\x03\x12\x04\xec\x01=>\n\x0c\n\x04\x04\
+    \x14\x02\x04\x12\x04\xed\x01\x08<\n\r\n\x05\x04\x14\x02\x04\x04\x12\x04\
+    \xed\x01\x08\x10\n\r\n\x05\x04\x14\x02\x04\x06\x12\x04\xed\x01\x11\x20\n\
+    \r\n\x05\x04\x14\x02\x04\x01\x12\x04\xed\x01!7\n\r\n\x05\x04\x14\x02\x04\
+    \x03\x12\x04\xed\x01:;\n\x0c\n\x04\x04\x14\x02\x05\x12\x04\xee\x01\x089\
+    \n\r\n\x05\x04\x14\x02\x05\x04\x12\x04\xee\x01\x08\x10\n\r\n\x05\x04\x14\
+    \x02\x05\x06\x12\x04\xee\x01\x11\x20\n\r\n\x05\x04\x14\x02\x05\x01\x12\
+    \x04\xee\x01!4\n\r\n\x05\x04\x14\x02\x05\x03\x12\x04\xee\x0178\n\x0c\n\
+    \x04\x04\x14\x02\x06\x12\x04\xef\x01\x087\n\r\n\x05\x04\x14\x02\x06\x04\
+    \x12\x04\xef\x01\x08\x10\n\r\n\x05\x04\x14\x02\x06\x06\x12\x04\xef\x01\
+    \x11\x20\n\r\n\x05\x04\x14\x02\x06\x01\x12\x04\xef\x01!2\n\r\n\x05\x04\
+    \x14\x02\x06\x03\x12\x04\xef\x0156\n\x0c\n\x04\x04\x14\x02\x07\x12\x04\
+    \xf0\x01\x087\n\r\n\x05\x04\x14\x02\x07\x04\x12\x04\xf0\x01\x08\x10\n\r\
+    \n\x05\x04\x14\x02\x07\x06\x12\x04\xf0\x01\x11\x20\n\r\n\x05\x04\x14\x02\
+    \x07\x01\x12\x04\xf0\x01!2\n\r\n\x05\x04\x14\x02\x07\x03\x12\x04\xf0\x01\
+    56\n\x0c\n\x02\x04\x15\x12\x06\xf3\x01\0\xf7\x01\x01\n\x0b\n\x03\x04\x15\
+    \x01\x12\x04\xf3\x01\x08\x14\n\x0c\n\x04\x04\x15\x02\0\x12\x04\xf4\x01\
+    \x08\x19\n\r\n\x05\x04\x15\x02\0\x05\x12\x04\xf4\x01\x08\x0e\n\r\n\x05\
+    \x04\x15\x02\0\x01\x12\x04\xf4\x01\x0f\x14\n\r\n\x05\x04\x15\x02\0\x03\
+    \x12\x04\xf4\x01\x17\x18\n\x0c\n\x04\x04\x15\x02\x01\x12\x04\xf5\x01\x08\
+    \x1d\n\r\n\x05\x04\x15\x02\x01\x05\x12\x04\xf5\x01\x08\x0e\n\r\n\x05\x04\
+    \x15\x02\x01\x01\x12\x04\xf5\x01\x0f\x18\n\r\n\x05\x04\x15\x02\x01\x03\
+    \x12\x04\xf5\x01\x1b\x1c\n\x0c\n\x04\x04\x15\x02\x02\x12\x04\xf6\x01\x08\
+    \x1b\n\r\n\x05\x04\x15\x02\x02\x05\x12\x04\xf6\x01\x08\x0e\n\r\n\x05\x04\
+    \x15\x02\x02\x01\x12\x04\xf6\x01\x0f\x16\n\r\n\x05\x04\x15\x02\x02\x03\
+    \x12\x04\xf6\x01\x19\x1a\n\x0c\n\x02\x04\x16\x12\x06\xf9\x01\0\x80\x02\
+    \x01\n\x0b\n\x03\x04\x16\x01\x12\x04\xf9\x01\x08\x13\n\x0c\n\x04\x04\x16\
+    \x02\0\x12\x04\xfa\x01\x04\x1b\n\r\n\x05\x04\x16\x02\0\x06\x12\x04\xfa\
+    \x01\x04\x0c\n\r\n\x05\x04\x16\x02\0\x01\x12\x04\xfa\x01\r\x16\n\r\n\x05\
+    \x04\x16\x02\0\x03\x12\x04\xfa\x01\x19\x1a\n\x0c\n\x04\x04\x16\x02\x01\
+    \x12\x04\xfb\x01\x04\"\n\r\n\x05\x04\x16\x02\x01\x06\x12\x04\xfb\x01\x04\
+    \x0f\n\r\n\x05\x04\x16\x02\x01\x01\x12\x04\xfb\x01\x10\x1c\n\r\n\x05\x04\
+    \x16\x02\x01\x03\x12\x04\xfb\x01\x20!\n\x0c\n\x04\x04\x16\x02\x02\x12\
+    \x04\xfc\x01\x04\x1d\n\r\n\x05\x04\x16\x02\x02\x06\x12\x04\xfc\x01\x04\r\
+    \n\r\n\x05\x04\x16\x02\x02\x01\x12\x04\xfc\x01\x0e\x18\n\r\n\x05\x04\x16\
+    \x02\x02\x03\x12\x04\xfc\x01\x1b\x1c\n\x0c\n\x04\x04\x16\x02\x03\x12\x04\
+    \xfd\x01\x04\x1f\n\r\n\x05\x04\x16\x02\x03\x06\x12\x04\xfd\x01\x04\x0e\n\
+    \r\n\x05\x04\x16\x02\x03\x01\x12\x04\xfd\x01\x0f\x1a\n\r\n\x05\x04\x16\
+    \x02\x03\x03\x12\x04\xfd\x01\x1d\x1e\nR\n\x04\x04\x16\x02\x04\x12\x04\
+    \xfe\x01\x040\"D\x20the\x20map\x20is\x20in\x20the\x20format\x20\"size\
+    \x20of\x20hugepage:\x20stats\x20of\x20the\x20hugepage\"\n\n\r\n\x05\x04\
+    \x16\x02\x04\x06\x12\x04\xfe\x01\x04\x1d\n\r\n\x05\x04\x16\x02\x04\x01\
+    \x12\x04\xfe\x01\x1e+\n\r\n\x05\x04\x16\x02\x04\x03\x12\x04\xfe\x01./\n\
+    \x0c\n\x02\x04\x17\x12\x06\x82\x02\0\x8c\x02\x01\n\x0b\n\x03\x04\x17\x01\
+    \x12\x04\x82\x02\x08\x14\n\x0c\n\x04\x04\x17\x02\0\x12\x04\x83\x02\x08\
+    \x18\n\r\n\x05\x04\x17\x02\0\x05\x12\x04\x83\x02\x08\x0e\n\r\n\x05\x04\
+    \x17\x02\0\x01\x12\x04\x83\x02\x0f\x13\n\r\n\x05\x04\x17\x02\0\x03\x12\
+    \x04\x83\x02\x16\x17\n\x0c\n\x04\x04\x17\x02\x01\x12\x04\x84\x02\x08\x1c\
+    \n\r\n\x05\x04\x17\x02\x01\x05\x12\x04\x84\x02\x08\x0e\n\r\n\x05\x04\x17\
+    \x02\x01\x01\x12\x04\x84\x02\x0f\x17\n\r\n\x05\x04\x17\x02\x01\x03\x12\
+    \x04\x84\x02\x1a\x1b\n\x0c\n\x04\x04\x17\x02\x02\x12\x04\x85\x02\x08\x1e\
+    \n\r\n\x05\x04\x17\x02\x02\x05\x12\x04\x85\x02\x08\x0e\n\r\n\x05\x04\x17\
+    \x02\x02\x01\x12\x04\x85\x02\x0f\x19\n\r\n\x05\x04\x17\x02\x02\x03\x12\
+    \x04\x85\x02\x1c\x1d\n\x0c\n\x04\x04\x17\x02\x03\x12\x04\x86\x02\x08\x1e\
+    \n\r\n\x05\x04\x17\x02\x03\x05\x12\x04\x86\x02\x08\x0e\n\r\n\x05\x04\x17\
+    \x02\x03\x01\x12\x04\x86\x02\x0f\x18\n\r\n\x05\x04\x17\x02\x03\x03\x12\
+    \x04\x86\x02\x1c\x1d\n\x0c\n\x04\x04\x17\x02\x04\x12\x04\x87\x02\x08\x1e\
+    \n\r\n\x05\x04\x17\x02\x04\x05\x12\x04\x87\x02\x08\x0e\n\r\n\x05\x04\x17\
+    \x02\x04\x01\x12\x04\x87\x02\x0f\x19\n\r\n\x05\x04\x17\x02\x04\x03\x12\
+    \x04\x87\x02\x1c\x1d\n\x0c\n\x04\x04\x17\x02\x05\x12\x04\x88\x02\x08\x1c\
+    \n\r\n\x05\x04\x17\x02\x05\x05\x12\x04\x88\x02\x08\x0e\n\r\n\x05\x04\x17\
+    \x02\x05\x01\x12\x04\x88\x02\x0f\x17\n\r\n\x05\x04\x17\x02\x05\x03\x12\
+    \x04\x88\x02\x1a\x1b\n\x0c\n\x04\x04\x17\x02\x06\x12\x04\x89\x02\x08\x1e\
+    \n\r\n\x05\x04\x17\x02\x06\x05\x12\x04\x89\x02\x08\x0e\n\r\n\x05\x04\x17\
+    \x02\x06\x01\x12\x04\x89\x02\x0f\x19\n\r\n\x05\x04\x17\x02\x06\x03\x12\
+    \x04\x89\x02\x1c\x1d\n\x0c\n\x04\x04\x17\x02\x07\x12\x04\x8a\x02\x08\x1d\
+    \n\r\n\x05\x04\x17\x02\x07\x05\x12\x04\x8a\x02\x08\x0e\n\r\n\x05\x04\x17\
+    \x02\x07\x01\x12\x04\x8a\x02\x0f\x18\n\r\n\x05\x04\x17\x02\x07\x03\x12\
+    \x04\x8a\x02\x1b\x1c\n\x0c\n\x04\x04\x17\x02\x08\x12\x04\x8b\x02\x08\x1e\
+    \n\r\n\x05\x04\x17\x02\x08\x05\x12\x04\x8b\x02\x08\x0e\n\r\n\x05\x04\x17\
+    \x02\x08\x01\x12\x04\x8b\x02\x0f\x19\n\r\n\x05\x04\x17\x02\x08\x03\x12\
+    \x04\x8b\x02\x1c\x1d\n\x0c\n\x02\x04\x18\x12\x06\x8e\x02\0\x91\x02\x01\n\
+    \x0b\n\x03\x04\x18\x01\x12\x04\x8e\x02\x08\x1e\n\x0c\n\x04\x04\x18\x02\0\
+    \x12\x04\x8f\x02\x08%\n\r\n\x05\x04\x18\x02\0\x06\x12\x04\x8f\x02\x08\
+    \x13\n\r\n\x05\x04\x18\x02\0\x01\x12\x04\x8f\x02\x14\x20\n\r\n\x05\x04\
+    \x18\x02\0\x03\x12\x04\x8f\x02#$\n\x0c\n\x04\x04\x18\x02\x01\x12\x04\x90\
+    \x02\x080\n\r\n\x05\x04\x18\x02\x01\x04\x12\x04\x90\x02\x08\x10\n\r\n\
+    \x05\x04\x18\x02\x01\x06\x12\x04\x90\x02\x11\x1d\n\r\n\x05\x04\x18\x02\
+    \x01\x01\x12\x04\x90\x02\x1e+\n\r\n\x05\x04\x18\x02\x01\x03\x12\x04\x90\
+    \x02./\n\x0c\n\x02\x04\x19\x12\x06\x93\x02\0\x97\x02\x01\n\x0b\n\x03\x04\
+    \x19\x01\x12\x04\x93\x02\x08\x1a\n\x0c\n\x04\x04\x19\x02\0\x12\x04\x94\
+    \x02\x08\x20\n\r\n\x05\x04\x19\x02\0\x05\x12\x04\x94\x02\x08\x0e\n\r\n\
+    \x05\x04\x19\x02\0\x01\x12\x04\x94\x02\x0f\x1b\n\r\n\x05\x04\x19\x02\0\
+    \x03\x12\x04\x94\x02\x1e\x1f\n\x0c\n\x04\x04\x19\x02\x01\x12\x04\x95\x02\
+    \x08\x1b\n\r\n\x05\x04\x19\x02\x01\x05\x12\x04\x95\x02\x08\x0e\n\r\n\x05\
+    \x04\x19\x02\x01\x01\x12\x04\x95\x02\x0f\x16\n\r\n\x05\x04\x19\x02\x01\
+    \x03\x12\x04\x95\x02\x19\x1a\n\x0c\n\x04\x04\x19\x02\x02\x12\x04\x96\x02\
+    \x08\x17\n\r\n\x05\x04\x19\x02\x02\x05\x12\x04\x96\x02\x08\r\n\r\n\x05\
+    \x04\x19\x02\x02\x01\x12\x04\x96\x02\x0e\x12\n\r\n\x05\x04\x19\x02\x02\
+    \x03\x12\x04\x96\x02\x15\x16\n\x0c\n\x02\x04\x1a\x12\x06\x99\x02\0\x9b\
+    \x02\x01\n\x0b\n\x03\x04\x1a\x01\x12\x04\x99\x02\x08\x1b\n\x0c\n\x04\x04\
+    \x1a\x02\0\x12\x04\x9a\x02\x08\x17\n\r\n\x05\x04\x1a\x02\0\x05\x12\x04\
+    \x9a\x02\x08\x0e\n\r\n\x05\x04\x1a\x02\0\x01\x12\x04\x9a\x02\x0f\x12\n\r\
+    \n\x05\x04\x1a\x02\0\x03\x12\x04\x9a\x02\x15\x16\n\x0c\n\x02\x04\x1b\x12\
+    \x06\x9d\x02\0\xa1\x02\x01\n\x0b\n\x03\x04\x1b\x01\x12\x04\x9d\x02\x08\
+    \x19\n\x0c\n\x04\x04\x1b\x02\0\x12\x04\x9e\x02\x08\x20\n\r\n\x05\x04\x1b\
+    \x02\0\x05\x12\x04\x9e\x02\x08\x0e\n\r\n\x05\x04\x1b\x02\0\x01\x12\x04\
+    \x9e\x02\x0f\x1b\n\r\n\x05\x04\x1b\x02\0\x03\x12\x04\x9e\x02\x1e\x1f\n\
+    \x0c\n\x04\x04\x1b\x02\x01\x12\x04\x9f\x02\x08\x1b\n\r\n\x05\x04\x1b\x02\
+    \x01\x05\x12\x04\x9f\x02\x08\x0e\n\r\n\x05\x04\x1b\x02\x01\x01\x12\x04\
+    \x9f\x02\x0f\x16\n\r\n\x05\x04\x1b\x02\x01\x03\x12\x04\x9f\x02\x19\x1a\n\
+    \x0c\n\x04\x04\x1b\x02\x02\x12\x04\xa0\x02\x08\x17\n\r\n\x05\x04\x1b\x02\
+    \x02\x05\x12\x04\xa0\x02\x08\x0e\n\r\n\x05\x04\x1b\x02\x02\x01\x12\x04\
+    \xa0\x02\x0f\x12\n\r\n\x05\x04\x1b\x02\x02\x03\x12\x04\xa0\x02\x15\x16\n\
+    \x0c\n\x02\x04\x1c\x12\x06\xa3\x02\0\xa5\x02\x01\n\x0b\n\x03\x04\x1c\x01\
+    \x12\x04\xa3\x02\x08\x1a\n\x0c\n\x04\x04\x1c\x02\0\x12\x04\xa4\x02\x08\
+    \x17\n\r\n\x05\x04\x1c\x02\0\x05\x12\x04\xa4\x02\x08\r\n\r\n\x05\x04\x1c\
+    \x02\0\x01\x12\x04\xa4\x02\x0e\x12\n\r\n\x05\x04\x1c\x02\0\x03\x12\x04\
+    \xa4\x02\x15\x16\n\x0c\n\x02\x04\x1d\x12\x06\xa7\x02\0\xaa\x02\x01\n\x0b\
+    \n\x03\x04\x1d\x01\x12\x04\xa7\x02\x08\x19\n\x0c\n\x04\x04\x1d\x02\0\x12\
+    \x04\xa8\x02\x08\x20\n\r\n\x05\x04\x1d\x02\0\x05\x12\x04\xa8\x02\x08\x0e\
+    \n\r\n\x05\x04\x1d\x02\0\x01\x12\x04\xa8\x02\x0f\x1b\n\r\n\x05\x04\x1d\
+    \x02\0\x03\x12\x04\xa8\x02\x1e\x1f\n\x0c\n\x04\x04\x1d\x02\x01\x12\x04\
+    \xa9\x02\x08\x1b\n\r\n\x05\x04\x1d\x02\x01\x05\x12\x04\xa9\x02\x08\x0e\n\
+    \r\n\x05\x04\x1d\x02\x01\x01\x12\x04\xa9\x02\x0f\x16\n\r\n\x05\x04\x1d\
+    \x02\x01\x03\x12\x04\xa9\x02\x19\x1a\n\x0c\n\x02\x04\x1e\x12\x06\xac\x02\
+    \0\xb1\x02\x01\n\x0b\n\x03\x04\x1e\x01\x12\x04\xac\x02\x08\x1b\n\x0c\n\
+    \x04\x04\x1e\x02\0\x12\x04\xad\x02\x08\x20\n\r\n\x05\x04\x1e\x02\0\x05\
+    \x12\x04\xad\x02\x08\x0e\n\r\n\x05\x04\x1e\x02\0\x01\x12\x04\xad\x02\x0f\
+    \x1b\n\r\n\x05\x04\x1e\x02\0\x03\x12\x04\xad\x02\x1e\x1f\n\x0c\n\x04\x04\
+    \x1e\x02\x01\x12\x04\xae\x02\x08\x1b\n\r\n\x05\x04\x1e\x02\x01\x05\x12\
+    \x04\xae\x02\x08\x0e\n\r\n\x05\x04\x1e\x02\x01\x01\x12\x04\xae\x02\x0f\
+    \x16\n\r\n\x05\x04\x1e\x02\x01\x03\x12\x04\xae\x02\x19\x1a\n\x0c\n\x04\
+    \x04\x1e\x02\x02\x12\x04\xaf\x02\x08\x17\n\r\n\x05\x04\x1e\x02\x02\x05\
+    \x12\x04\xaf\x02\x08\x0e\n\r\n\x05\x04\x1e\x02\x02\x01\x12\x04\xaf\x02\
+    \x0f\x12\n\r\n\x05\x04\x1e\x02\x02\x03\x12\x04\xaf\x02\x15\x16\n\x0c\n\
+    \x04\x04\x1e\x02\x03\x12\x04\xb0\x02\x08\x1a\n\r\n\x05\x04\x1e\x02\x03\
+    \x05\x12\x04\xb0\x02\x08\x0e\n\r\n\x05\x04\x1e\x02\x03\x01\x12\x04\xb0\
+    \x02\x0f\x15\n\r\n\x05\x04\x1e\x02\x03\x03\x12\x04\xb0\x02\x18\x19\n\x0c\
+    \n\x02\x04\x1f\x12\x06\xb3\x02\0\xb9\x02\x01\n\x0b\n\x03\x04\x1f\x01\x12\
+    \x04\xb3\x02\x08\x14\n<\n\x04\x04\x1f\x02\0\x12\x04\xb5\x02\x08\x18\x1a.\
+    \x20This\x20field\x20is\x20the\x20name\x20of\x20the\x20kernel\x20module.\
+    \n\n\r\n\x05\x04\x1f\x02\0\x05\x12\x04\xb5\x02\x08\x0e\n\r\n\x05\x04\x1f\
+    \x02\0\x01\x12\x04\xb5\x02\x0f\x13\n\r\n\x05\x04\x1f\x02\0\x03\x12\x04\
+    \xb5\x02\x16\x17\n\x8a\x01\n\x04\x04\x1f\x02\x01\x12\x04\xb8\x02\x08'\
+    \x1a|\x20This\x20field\x20are\x20the\x20parameters\x20for\x20the\x20kern\
+    el\x20module\x20which\x20are\n\x20whitespace-delimited\x20key=value\x20p\
+    airs\x20passed\x20to\x20modprobe(8).\n\n\r\n\x05\x04\x1f\x02\x01\x04\x12\
+    \x04\xb8\x02\x08\x10\n\r\n\x05\x04\x1f\x02\x01\x05\x12\x04\xb8\x02\x11\
+    \x17\n\r\n\x05\x04\x1f\x02\x01\x01\x12\x04\xb8\x02\x18\"\n\r\n\x05\x04\
+    \x1f\x02\x01\x03\x12\x04\xb8\x02%&\n\x0c\n\x02\x04\x20\x12\x06\xbb\x02\0\
+    \xce\x02\x01\n\x0b\n\x03\x04\x20\x01\x12\x04\xbb\x02\x08\x1c\n\x0c\n\x04\
+    \x04\x20\x02\0\x12\x04\xbc\x02\x08\x1c\n\r\n\x05\x04\x20\x02\0\x05\x12\
+    \x04\xbc\x02\x08\x0e\n\r\n\x05\x04\x20\x02\0\x01\x12\x04\xbc\x02\x0f\x17\
+    \n\r\n\x05\x04\x20\x02\0\x03\x12\x04\xbc\x02\x1a\x1b\n\x0c\n\x04\x04\x20\
+    \x02\x01\x12\x04\xbd\x02\x08\x20\n\r\n\x05\x04\x20\x02\x01\x04\x12\x04\
+    \xbd\x02\x08\x10\n\r\n\x05\x04\x20\x02\x01\x05\x12\x04\xbd\x02\x11\x17\n\
+    \r\n\x05\x04\x20\x02\x01\x01\x12\x04\xbd\x02\x18\x1b\n\r\n\x05\x04\x20\
+    \x02\x01\x03\x12\x04\xbd\x02\x1e\x1f\n\x0c\n\x04\x04\x20\x02\x02\x12\x04\
+    \xbe\x02\x08&\n\r\n\x05\x04\x20\x02\x02\x04\x12\x04\xbe\x02\x08\x10\n\r\
+    \n\x05\x04\x20\x02\x02\x06\x12\x04\xbe\x02\x11\x18\n\r\n\x05\x04\x20\x02\
+    \x02\x01\x12\x04\xbe\x02\x19!\n\r\n\x05\x04\x20\x02\x02\x03\x12\x04\xbe\
+    \x02$%\n\xea\x01\n\x04\x04\x20\x02\x03\x12\x04\xc4\x02\x08\x1f\x1a\xdb\
+    \x01\x20This\x20field\x20means\x20that\x20a\x20pause\x20process\x20needs\
+    \x20to\x20be\x20created\x20by\x20the\n\x20agent.\x20This\x20pid\x20names\
+    pace\x20of\x20the\x20pause\x20process\x20will\x20be\x20treated\x20as\n\
+    \x20a\x20shared\x20pid\x20namespace.\x20All\x20containers\x20created\x20\
+    will\x20join\x20this\x20shared\n\x20pid\x20namespace.\n\n\r\n\x05\x04\
+    \x20\x02\x03\x05\x12\x04\xc4\x02\x08\x0c\n\r\n\x05\x04\x20\x02\x03\x01\
+    \x12\x04\xc4\x02\r\x1a\n\r\n\x05\x04\x20\x02\x03\x03\x12\x04\xc4\x02\x1d\
+    \x1e\n\xc5\x01\n\x04\x04\x20\x02\x04\x12\x04\xc8\x02\x08\x1e\x1a\xb6\x01\
+    \x20SandboxId\x20identifies\x20which\x20sandbox\x20is\x20using\x20the\
+    \x20agent.\x20We\x20allow\x20only\n\x20one\x20sandbox\x20per\x20agent\
+    \x20and\x20implicitly\x20require\x20that\x20CreateSandbox\x20is\n\x20cal\
+    led\x20before\x20other\x20sandbox/network\x20calls.\n\n\r\n\x05\x04\x20\
+    \x02\x04\x05\x12\x04\xc8\x02\x08\x0e\n\r\n\x05\x04\x20\x02\x04\x01\x12\
+    \x04\xc8\x02\x0f\x19\n\r\n\x05\x04\x20\x02\x04\x03\x12\x04\xc8\x02\x1c\
+    \x1d\n\x98\x01\n\x04\x04\x20\x02\x05\x12\x04\xcb\x02\x08#\x1a\x89\x01\
+    \x20This\x20field,\x20if\x20non-empty,\x20designates\x20an\x20absolute\
+    \x20path\x20to\x20a\x20directory\n\x20that\x20the\x20agent\x20will\x20se\
+    arch\x20for\x20OCI\x20hooks\x20to\x20run\x20within\x20the\x20guest.\n\n\
+    \r\n\x05\x04\x20\x02\x05\x05\x12\x04\xcb\x02\x08\x0e\n\r\n\x05\x04\x20\
+    \x02\x05\x01\x12\x04\xcb\x02\x0f\x1e\n\r\n\x05\x04\x20\x02\x05\x03\x12\
+    \x04\xcb\x02!\"\nZ\n\x04\x04\x20\x02\x06\x12\x04\xcd\x02\x081\x1aL\x20Th\
+    is\x20field\x20is\x20the\x20list\x20of\x20kernel\x20modules\x20to\x20be\
+    \x20loaded\x20in\x20the\x20guest\x20kernel.\n\n\r\n\x05\x04\x20\x02\x06\
+    \x04\x12\x04\xcd\x02\x08\x10\n\r\n\x05\x04\x20\x02\x06\x06\x12\x04\xcd\
+    \x02\x11\x1d\n\r\n\x05\x04\x20\x02\x06\x01\x12\x04\xcd\x02\x1e,\n\r\n\
+    \x05\x04\x20\x02\x06\x03\x12\x04\xcd\x02/0\n\x0c\n\x02\x04!\x12\x06\xd0\
+    \x02\0\xd1\x02\x01\n\x0b\n\x03\x04!\x01\x12\x04\xd0\x02\x08\x1d\n\x0c\n\
+    \x02\x04\"\x12\x06\xd3\x02\0\xd5\x02\x01\n\x0b\n\x03\x04\"\x01\x12\x04\
+    \xd3\x02\x08\x12\n\x0c\n\x04\x04\"\x02\0\x12\x04\xd4\x02\x080\n\r\n\x05\
+    \x04\"\x02\0\x04\x12\x04\xd4\x02\x08\x10\n\r\n\x05\x04\"\x02\0\x06\x12\
+    \x04\xd4\x02\x11\x20\n\r\n\x05\x04\"\x02\0\x01\x12\x04\xd4\x02!+\n\r\n\
+    \x05\x04\"\x02\0\x03\x12\x04\xd4\x02./\n\x0c\n\x02\x04#\x12\x06\xd7\x02\
+    \0\xd9\x02\x01\n\x0b\n\x03\x04#\x01\x12\x04\xd7\x02\x08\x0e\n\x0c\n\x04\
+    \x04#\x02\0\x12\x04\xd8\x02\x08(\n\r\n\x05\x04#\x02\0\x04\x12\x04\xd8\
+    \x02\x08\x10\n\r\n\x05\x04#\x02\0\x06\x12\x04\xd8\x02\x11\x1c\n\r\n\x05\
+    \x04#\x02\0\x01\x12\x04\xd8\x02\x1d#\n\r\n\x05\x04#\x02\0\x03\x12\x04\
+    \xd8\x02&'\n\x0c\n\x02\x04$\x12\x06\xdb\x02\0\xdd\x02\x01\n\x0b\n\x03\
+    \x04$\x01\x12\x04\xdb\x02\x08\x1e\n\x0c\n\x04\x04$\x02\0\x12\x04\xdc\x02\
+    \x08&\n\r\n\x05\x04$\x02\0\x06\x12\x04\xdc\x02\x08\x17\n\r\n\x05\x04$\
+    \x02\0\x01\x12\x04\xdc\x02\x18!\n\r\n\x05\x04$\x02\0\x03\x12\x04\xdc\x02\
+    $%\n\x0c\n\x02\x04%\x12\x06\xdf\x02\0\xe1\x02\x01\n\x0b\n\x03\x04%\x01\
+    \x12\x04\xdf\x02\x08\x1b\n\x0c\n\x04\x04%\x02\0\x12\x04\xe0\x02\x08\x1a\
+    \n\r\n\x05\x04%\x02\0\x06\x12\x04\xe0\x02\x08\x0e\n\r\n\x05\x04%\x02\0\
+    \x01\x12\x04\xe0\x02\x0f\x15\n\r\n\x05\x04%\x02\0\x03\x12\x04\xe0\x02\
+    \x18\x19\n\x0c\n\x02\x04&\x12\x06\xe3\x02\0\xe4\x02\x01\n\x0b\n\x03\x04&\
+    \x01\x12\x04\xe3\x02\x08\x1d\n\x0c\n\x02\x04'\x12\x06\xe6\x02\0\xe7\x02\
+    \x01\n\x0b\n\x03\x04'\x01\x12\x04\xe6\x02\x08\x19\n\x0c\n\x02\x04(\x12\
+    \x06\xe9\x02\0\xeb\x02\x01\n\x0b\n\x03\x04(\x01\x12\x04\xe9\x02\x08\x14\
+    \n\x0c\n\x04\x04(\x02\0\x12\x04\xea\x02\x073\n\r\n\x05\x04(\x02\0\x04\
+    \x12\x04\xea\x02\x07\x0f\n\r\n\x05\x04(\x02\0\x06\x12\x04\xea\x02\x10!\n\
+    \r\n\x05\x04(\x02\0\x01\x12\x04\xea\x02\".\n\r\n\x05\x04(\x02\0\x03\x12\
+    \x04\xea\x0212\n\x0c\n\x02\x04)\x12\x06\xed\x02\0\xef\x02\x01\n\x0b\n\
+    \x03\x04)\x01\x12\x04\xed\x02\x08\x1e\n\x0c\n\x04\x04)\x02\0\x12\x04\xee\
+    \x02\x07\"\n\r\n\x05\x04)\x02\0\x06\x12\x04\xee\x02\x07\x13\n\r\n\x05\
+    \x04)\x02\0\x01\x12\x04\xee\x02\x14\x1d\n\r\n\x05\x04)\x02\0\x03\x12\x04\
+    \xee\x02\x20!\n\x0c\n\x02\x04*\x12\x06\xf1\x02\0\xfc\x02\x01\n\x0b\n\x03\
+    \x04*\x01\x12\x04\xf1\x02\x08\x1b\n\xf6\x01\n\x04\x04*\x02\0\x12\x04\xf5\
+    \x02\x08\x16\x1a\xe7\x01\x20Wait\x20specifies\x20if\x20the\x20caller\x20\
+    waits\x20for\x20the\x20agent\x20to\x20online\x20all\x20resources.\n\x20I\
+    f\x20true\x20the\x20agent\x20returns\x20once\x20all\x20resources\x20have\
+    \x20been\x20connected,\x20otherwise\x20all\n\x20resources\x20are\x20conn\
+    ected\x20asynchronously\x20and\x20the\x20agent\x20returns\x20immediately\
+    .\n\n\r\n\x05\x04*\x02\0\x05\x12\x04\xf5\x02\x08\x0c\n\r\n\x05\x04*\x02\
+    \0\x01\x12\x04\xf5\x02\r\x11\n\r\n\x05\x04*\x02\0\x03\x12\x04\xf5\x02\
+    \x14\x15\n`\n\x04\x04*\x02\x01\x12\x04\xf8\x02\x08\x1b\x1aR\x20NbCpus\
+    \x20specifies\x20the\x20number\x20of\x20CPUs\x20that\x20were\x20added\
+    \x20and\x20the\x20agent\x20has\x20to\x20online.\n\n\r\n\x05\x04*\x02\x01\
+    \x05\x12\x04\xf8\x02\x08\x0e\n\r\n\x05\x04*\x02\x01\x01\x12\x04\xf8\x02\
+    \x0f\x16\n\r\n\x05\x04*\x02\x01\x03\x12\x04\xf8\x02\x19\x1a\nA\n\x04\x04\
+    *\x02\x02\x12\x04\xfb\x02\x08\x1a\x1a3\x20CpuOnly\x20specifies\x20whethe\
+    r\x20only\x20online\x20CPU\x20or\x20not.\n\n\r\n\x05\x04*\x02\x02\x05\
+    \x12\x04\xfb\x02\x08\x0c\n\r\n\x05\x04*\x02\x02\x01\x12\x04\xfb\x02\r\
+    \x15\n\r\n\x05\x04*\x02\x02\x03\x12\x04\xfb\x02\x18\x19\n\x0c\n\x02\x04+\
+    \x12\x06\xfe\x02\0\x81\x03\x01\n\x0b\n\x03\x04+\x01\x12\x04\xfe\x02\x08\
+    \x1e\nM\n\x04\x04+\x02\0\x12\x04\x80\x03\x08\x17\x1a?\x20Data\x20specifi\
+    es\x20the\x20random\x20data\x20used\x20to\x20reseed\x20the\x20guest\x20c\
+    rng.\n\n\r\n\x05\x04+\x02\0\x05\x12\x04\x80\x03\x08\r\n\r\n\x05\x04+\x02\
+    \0\x01\x12\x04\x80\x03\x0e\x12\n\r\n\x05\x04+\x02\0\x03\x12\x04\x80\x03\
+    \x15\x16\nX\n\x02\x04,\x12\x06\x84\x03\0\x94\x03\x01\x1aJ\x20AgentDetail\
+    s\x20provides\x20information\x20to\x20the\x20client\x20about\x20the\x20r\
+    unning\x20agent.\n\n\x0b\n\x03\x04,\x01\x12\x04\x84\x03\x08\x14\nC\n\x04\
+    \x04,\x02\0\x12\x04\x86\x03\x08\x1b\x1a5\x20Semantic\x20version\x20of\
+    \x20agent\x20(see\x20https://semver.org).\n\n\r\n\x05\x04,\x02\0\x05\x12\
+    \x04\x86\x03\x08\x0e\n\r\n\x05\x04,\x02\0\x01\x12\x04\x86\x03\x0f\x16\n\
+    \r\n\x05\x04,\x02\0\x03\x12\x04\x86\x03\x19\x1a\n5\n\x04\x04,\x02\x01\
+    \x12\x04\x89\x03\x08\x1d\x1a'\x20Set\x20if\x20the\x20agent\x20is\x20runn\
+    ing\x20as\x20PID\x201.\n\n\r\n\x05\x04,\x02\x01\x05\x12\x04\x89\x03\x08\
+    \x0c\n\r\n\x05\x04,\x02\x01\x01\x12\x04\x89\x03\r\x18\n\r\n\x05\x04,\x02\
+    \x01\x03\x12\x04\x89\x03\x1b\x1c\n2\n\x04\x04,\x02\x02\x12\x04\x8c\x03\
+    \x08,\x1a$\x20List\x20of\x20available\x20device\x20handlers.\n\n\r\n\x05\
+    \x04,\x02\x02\x04\x12\x04\x8c\x03\x08\x10\n\r\n\x05\x04,\x02\x02\x05\x12\
+    \x04\x8c\x03\x11\x17\n\r\n\x05\x04,\x02\x02\x01\x12\x04\x8c\x03\x18'\n\r\
+    \n\x05\x04,\x02\x02\x03\x12\x04\x8c\x03*+\n3\n\x04\x04,\x02\x03\x12\x04\
+    \x8f\x03\x08-\x1a%\x20List\x20of\x20available\x20storage\x20handlers.\n\
+    \n\r\n\x05\x04,\x02\x03\x04\x12\x04\x8f\x03\x08\x10\n\r\n\x05\x04,\x02\
+    \x03\x05\x12\x04\x8f\x03\x11\x17\n\r\n\x05\x04,\x02\x03\x01\x12\x04\x8f\
+    \x03\x18(\n\r\n\x05\x04,\x02\x03\x03\x12\x04\x8f\x03+,\np\n\x04\x04,\x02\
+    \x04\x12\x04\x93\x03\x08\"\x1ab\x20Set\x20only\x20if\x20the\x20agent\x20\
+    is\x20built\x20with\x20seccomp\x20support\x20and\x20the\x20guest\n\x20en\
+    vironment\x20supports\x20seccomp.\n\n\r\n\x05\x04,\x02\x04\x05\x12\x04\
+    \x93\x03\x08\x0c\n\r\n\x05\x04,\x02\x04\x01\x12\x04\x93\x03\r\x1d\n\r\n\
+    \x05\x04,\x02\x04\x03\x12\x04\x93\x03\x20!\n\x0c\n\x02\x04-\x12\x06\x96\
+    \x03\0\xa0\x03\x01\n\x0b\n\x03\x04-\x01\x12\x04\x96\x03\x08\x1b\n\xd5\
+    \x01\n\x04\x04-\x02\0\x12\x04\x9a\x03\x08\x20\x1a\xc6\x01\x20MemBlockSiz\
+    e\x20asks\x20server\x20to\x20return\x20the\x20system\x20memory\x20block\
+    \x20size\x20that\x20can\x20be\x20used\n\x20for\x20memory\x20hotplug\x20a\
+    lignment.\x20Typically\x20the\x20server\x20returns\x20what's\x20in\n\x20\
+    /sys/devices/system/memory/block_size_bytes.\n\n\r\n\x05\x04-\x02\0\x05\
+    \x12\x04\x9a\x03\x08\x0c\n\r\n\x05\x04-\x02\0\x01\x12\x04\x9a\x03\r\x1b\
+    \n\r\n\x05\x04-\x02\0\x03\x12\x04\x9a\x03\x1e\x1f\n\xd1\x01\n\x04\x04-\
+    \x02\x01\x12\x04\x9f\x03\x08#\x1a\xc2\x01\x20MemoryHotplugProbe\x20asks\
+    \x20server\x20to\x20return\x20whether\x20guest\x20kernel\x20supports\x20\
+    memory\x20hotplug\n\x20via\x20probeinterface.\x20Typically\x20the\x20ser\
+    ver\x20will\x20check\x20if\x20the\x20path\n\x20/sys/devices/system/memor\
+    y/probe\x20exists.\n\n\r\n\x05\x04-\x02\x01\x05\x12\x04\x9f\x03\x08\x0c\
+    \n\r\n\x05\x04-\x02\x01\x01\x12\x04\x9f\x03\r\x1e\n\r\n\x05\x04-\x02\x01\
+    \x03\x12\x04\x9f\x03!\"\n\x0c\n\x02\x04.\x12\x06\xa2\x03\0\xa9\x03\x01\n\
+    \x0b\n\x03\x04.\x01\x12\x04\xa2\x03\x08\x1c\nP\n\x04\x04.\x02\0\x12\x04\
+    \xa4\x03\x08(\x1aB\x20MemBlockSizeBytes\x20returns\x20the\x20system\x20m\
+    emory\x20block\x20size\x20in\x20bytes.\n\n\r\n\x05\x04.\x02\0\x05\x12\
+    \x04\xa4\x03\x08\x0e\n\r\n\x05\x04.\x02\0\x01\x12\x04\xa4\x03\x0f#\n\r\n\
+    \x05\x04.\x02\0\x03\x12\x04\xa4\x03&'\n\x0c\n\x04\x04.\x02\x01\x12\x04\
+    \xa6\x03\x08'\n\r\n\x05\x04.\x02\x01\x06\x12\x04\xa6\x03\x08\x14\n\r\n\
+    \x05\x04.\x02\x01\x01\x12\x04\xa6\x03\x15\"\n\r\n\x05\x04.\x02\x01\x03\
+    \x12\x04\xa6\x03%&\n\x0c\n\x04\x04.\x02\x02\x12\x04\xa8\x03\x08+\n\r\n\
+    \x05\x04.\x02\x02\x05\x12\x04\xa8\x03\x08\x0c\n\r\n\x05\x04.\x02\x02\x01\
+    \x12\x04\xa8\x03\r&\n\r\n\x05\x04.\x02\x02\x03\x12\x04\xa8\x03)*\n\x0c\n\
+    \x02\x04/\x12\x06\xab\x03\0\xaf\x03\x01\n\x0b\n\x03\x04/\x01\x12\x04\xab\
+    \x03\x08\x20\n\xb2\x01\n\x04\x04/\x02\0\x12\x04\xae\x03\x080\x1a\xa3\x01\
+    \x20server\x20needs\x20to\x20send\x20the\x20value\x20of\x20memHotplugPro\
+    beAddr\x20into\x20file\x20/sys/devices/system/memory/probe,\n\x20in\x20o\
+    rder\x20to\x20notify\x20the\x20guest\x20kernel\x20about\x20hot-add\x20me\
+    mory\x20event\n\n\r\n\x05\x04/\x02\0\x04\x12\x04\xae\x03\x08\x10\n\r\n\
+    \x05\x04/\x02\0\x05\x12\x04\xae\x03\x11\x17\n\r\n\x05\x04/\x02\0\x01\x12\
+    \x04\xae\x03\x18+\n\r\n\x05\x04/\x02\0\x03\x12\x04\xae\x03./\n\x0c\n\x02\
+    \x040\x12\x06\xb1\x03\0\xb6\x03\x01\n\x0b\n\x03\x040\x01\x12\x04\xb1\x03\
+    \x08\x1f\n/\n\x04\x040\x02\0\x12\x04\xb3\x03\x08\x16\x1a!\x20Sec\x20the\
+    \x20second\x20since\x20the\x20Epoch.\n\n\r\n\x05\x040\x02\0\x05\x12\x04\
+    \xb3\x03\x08\r\n\r\n\x05\x040\x02\0\x01\x12\x04\xb3\x03\x0e\x11\n\r\n\
+    \x05\x040\x02\0\x03\x12\x04\xb3\x03\x14\x15\nF\n\x04\x040\x02\x01\x12\
+    \x04\xb5\x03\x08\x17\x1a8\x20Usec\x20the\x20microseconds\x20portion\x20o\
+    f\x20time\x20since\x20the\x20Epoch.\n\n\r\n\x05\x040\x02\x01\x05\x12\x04\
+    \xb5\x03\x08\r\n\r\n\x05\x040\x02\x01\x01\x12\x04\xb5\x03\x0e\x12\n\r\n\
+    \x05\x040\x02\x01\x03\x12\x04\xb5\x03\x15\x16\n\xa3\x01\n\x02\x041\x12\
+    \x06\xba\x03\0\xd4\x03\x01\x1a\x94\x01\x20Storage\x20represents\x20both\
+    \x20the\x20rootfs\x20of\x20the\x20container,\x20and\x20any\x20volume\x20\
+    that\n\x20could\x20have\x20been\x20defined\x20through\x20the\x20Mount\
+    \x20list\x20of\x20the\x20OCI\x20specification.\n\n\x0b\n\x03\x041\x01\
+    \x12\x04\xba\x03\x08\x0f\n\x8b\x02\n\x04\x041\x02\0\x12\x04\xbf\x03\x08\
+    \x1a\x1a\xfc\x01\x20Driver\x20is\x20used\x20to\x20define\x20the\x20way\
+    \x20the\x20storage\x20is\x20passed\x20through\x20the\n\x20virtual\x20mac\
+    hine.\x20It\x20can\x20be\x20\"9p\",\x20\"blk\",\x20or\x20something\x20el\
+    se,\x20but\x20for\n\x20all\x20cases,\x20this\x20will\x20define\x20if\x20\
+    some\x20extra\x20steps\x20are\x20required\x20before\n\x20this\x20storage\
+    \x20gets\x20mounted\x20into\x20the\x20container.\n\n\r\n\x05\x041\x02\0\
+    \x05\x12\x04\xbf\x03\x08\x0e\n\r\n\x05\x041\x02\0\x01\x12\x04\xbf\x03\
+    \x0f\x15\n\r\n\x05\x041\x02\0\x03\x12\x04\xbf\x03\x18\x19\n\xd0\x01\n\
+    \x04\x041\x02\x01\x12\x04\xc3\x03\x08+\x1a\xc1\x01\x20DriverOptions\x20a\
+    llows\x20the\x20caller\x20to\x20define\x20a\x20list\x20of\x20options\x20\
+    such\n\x20as\x20block\x20sizes,\x20numbers\x20of\x20luns,\x20...\x20whic\
+    h\x20are\x20very\x20specific\x20to\n\x20every\x20device\x20and\x20cannot\
+    \x20be\x20generalized\x20through\x20extra\x20fields.\n\n\r\n\x05\x041\
+    \x02\x01\x04\x12\x04\xc3\x03\x08\x10\n\r\n\x05\x041\x02\x01\x05\x12\x04\
+    \xc3\x03\x11\x17\n\r\n\x05\x041\x02\x01\x01\x12\x04\xc3\x03\x18&\n\r\n\
+    \x05\x041\x02\x01\x03\x12\x04\xc3\x03)*\n\xce\x02\n\x04\x041\x02\x02\x12\
+    \x04\xc9\x03\x08\x1a\x1a\xbf\x02\x20Source\x20can\x20be\x20anything\x20r\
+    epresenting\x20the\x20source\x20of\x20the\x20storage.\x20This\n\x20will\
+    \x20be\x20handled\x20by\x20the\x20proper\x20handler\x20based\x20on\x20th\
+    e\x20Driver\x20used.\n\x20For\x20instance,\x20it\x20can\x20be\x20a\x20ve\
+    ry\x20simple\x20path\x20if\x20the\x20caller\x20knows\x20the\n\x20name\
+    \x20of\x20device\x20inside\x20the\x20VM,\x20or\x20it\x20can\x20be\x20som\
+    e\x20sort\x20of\x20identifier\n\x20to\x20let\x20the\x20agent\x20find\x20\
+    the\x20device\x20inside\x20the\x20VM.\n\n\r\n\x05\x041\x02\x02\x05\x12\
+    \x04\xc9\x03\x08\x0e\n\r\n\x05\x041\x02\x02\x01\x12\x04\xc9\x03\x0f\x15\
+    \n\r\n\x05\x041\x02\x02\x03\x12\x04\xc9\x03\x18\x19\n\xdb\x01\n\x04\x041\
+    \x02\x03\x12\x04\xcd\x03\x08\x1a\x1a\xcc\x01\x20Fstype\x20represents\x20\
+    the\x20filesystem\x20that\x20needs\x20to\x20be\x20used\x20to\x20mount\
+    \x20the\n\x20storage\x20inside\x20the\x20VM.\x20For\x20instance,\x20it\
+    \x20could\x20be\x20\"xfs\"\x20for\x20block\n\x20device,\x20\"9p\"\x20for\
+    \x20shared\x20filesystem,\x20or\x20\"tmpfs\"\x20for\x20shared\x20/dev/sh\
+    m.\n\n\r\n\x05\x041\x02\x03\x05\x12\x04\xcd\x03\x08\x0e\n\r\n\x05\x041\
+    \x02\x03\x01\x12\x04\xcd\x03\x0f\x15\n\r\n\x05\x041\x02\x03\x03\x12\x04\
+    \xcd\x03\x18\x19\nw\n\x04\x041\x02\x04\x12\x04\xd0\x03\x08$\x1ai\x20Opti\
+    ons\x20describes\x20the\x20additional\x20options\x20that\x20might\x20be\
+    \x20needed\x20to\n\x20mount\x20properly\x20the\x20storage\x20filesytem.\
+    \n\n\r\n\x05\x041\x02\x04\x04\x12\x04\xd0\x03\x08\x10\n\r\n\x05\x041\x02\
+    \x04\x05\x12\x04\xd0\x03\x11\x17\n\r\n\x05\x041\x02\x04\x01\x12\x04\xd0\
+    \x03\x18\x1f\n\r\n\x05\x041\x02\x04\x03\x12\x04\xd0\x03\"#\na\n\x04\x041\
+    \x02\x05\x12\x04\xd3\x03\x08\x1f\x1aS\x20MountPoint\x20refers\x20to\x20t\
+    he\x20path\x20where\x20the\x20storage\x20should\x20be\x20mounted\n\x20in\
+    side\x20the\x20VM.\n\n\r\n\x05\x041\x02\x05\x05\x12\x04\xd3\x03\x08\x0e\
+    \n\r\n\x05\x041\x02\x05\x01\x12\x04\xd3\x03\x0f\x1a\n\r\n\x05\x041\x02\
+    \x05\x03\x12\x04\xd3\x03\x1d\x1e\n\x88\x01\n\x02\x042\x12\x06\xd8\x03\0\
+    \xf8\x03\x01\x1az\x20Device\x20represents\x20only\x20the\x20devices\x20t\
+    hat\x20could\x20have\x20been\x20defined\x20through\x20the\n\x20Linux\x20\
+    Device\x20list\x20of\x20the\x20OCI\x20specification.\n\n\x0b\n\x03\x042\
+    \x01\x12\x04\xd8\x03\x08\x0e\n\xb0\x01\n\x04\x042\x02\0\x12\x04\xdc\x03\
+    \x08\x16\x1a\xa1\x01\x20Id\x20can\x20be\x20used\x20to\x20identify\x20the\
+    \x20device\x20inside\x20the\x20VM.\x20Some\x20devices\n\x20might\x20not\
+    \x20need\x20it\x20to\x20be\x20identified\x20on\x20the\x20VM,\x20and\x20w\
+    ill\x20rely\x20on\x20the\n\x20provided\x20VmPath\x20instead.\n\n\r\n\x05\
+    \x042\x02\0\x05\x12\x04\xdc\x03\x08\x0e\n\r\n\x05\x042\x02\0\x01\x12\x04\
+    \xdc\x03\x0f\x11\n\r\n\x05\x042\x02\0\x03\x12\x04\xdc\x03\x14\x15\n\xbd\
+    \x01\n\x04\x042\x02\x01\x12\x04\xe1\x03\x08\x18\x1a\xae\x01\x20Type\x20d\
+    efines\x20the\x20type\x20of\x20device\x20described.\x20This\x20can\x20be\
+    \x20\"blk\",\n\x20\"scsi\",\x20\"vfio\",\x20...\n\x20Particularly,\x20th\
+    is\x20should\x20be\x20used\x20to\x20trigger\x20the\x20use\x20of\x20the\n\
+    \x20appropriate\x20device\x20handler.\n\n\r\n\x05\x042\x02\x01\x05\x12\
+    \x04\xe1\x03\x08\x0e\n\r\n\x05\x042\x02\x01\x01\x12\x04\xe1\x03\x0f\x13\
+    \n\r\n\x05\x042\x02\x01\x03\x12\x04\xe1\x03\x16\x17\n\xab\x02\n\x04\x042\
+    \x02\x02\x12\x04\xe7\x03\x08\x1b\x1a\x9c\x02\x20VmPath\x20can\x20be\x20u\
+    sed\x20by\x20the\x20caller\x20to\x20provide\x20directly\x20the\x20path\
+    \x20of\n\x20the\x20device\x20as\x20it\x20will\x20appear\x20inside\x20the\
+    \x20VM.\x20For\x20some\x20devices,\x20the\n\x20device\x20id\x20or\x20the\
+    \x20list\x20of\x20options\x20passed\x20might\x20not\x20be\x20enough\x20t\
+    o\x20find\n\x20the\x20device.\x20In\x20those\x20cases,\x20the\x20caller\
+    \x20should\x20predict\x20and\x20provide\n\x20this\x20vm_path.\n\n\r\n\
+    \x05\x042\x02\x02\x05\x12\x04\xe7\x03\x08\x0e\n\r\n\x05\x042\x02\x02\x01\
+    \x12\x04\xe7\x03\x0f\x16\n\r\n\x05\x042\x02\x02\x03\x12\x04\xe7\x03\x19\
+    \x1a\n\xd4\x05\n\x04\x042\x02\x03\x12\x04\xf3\x03\x08\"\x1a\xc5\x05\x20C\
+    ontainerPath\x20defines\x20the\x20path\x20where\x20the\x20device\x20shou\
+    ld\x20be\x20found\x20inside\n\x20the\x20container.\x20This\x20path\x20sh\
+    ould\x20match\x20the\x20path\x20of\x20the\x20device\x20from\n\x20the\x20\
+    device\x20list\x20listed\x20inside\x20the\x20OCI\x20spec.\x20This\x20is\
+    \x20used\x20in\x20order\n\x20to\x20identify\x20the\x20right\x20device\
+    \x20in\x20the\x20spec\x20and\x20update\x20it\x20with\x20the\n\x20right\
+    \x20options\x20such\x20as\x20major/minor\x20numbers\x20as\x20they\x20app\
+    ear\x20inside\n\x20the\x20VM\x20for\x20instance.\x20Note\x20that\x20an\
+    \x20empty\x20ctr_path\x20should\x20be\x20used\n\x20to\x20make\x20sure\
+    \x20the\x20device\x20handler\x20inside\x20the\x20agent\x20is\x20called,\
+    \x20but\n\x20no\x20spec\x20update\x20needs\x20to\x20be\x20performed.\x20\
+    This\x20has\x20to\x20happen\x20for\x20the\n\x20case\x20of\x20rootfs,\x20\
+    when\x20a\x20device\x20has\x20to\x20be\x20waited\x20for\x20after\x20it\
+    \x20has\n\x20been\x20hotplugged.\x20An\x20equivalent\x20Storage\x20entry\
+    \x20should\x20be\x20defined\x20if\n\x20any\x20mount\x20needs\x20to\x20be\
+    \x20performed\x20afterwards.\n\n\r\n\x05\x042\x02\x03\x05\x12\x04\xf3\
+    \x03\x08\x0e\n\r\n\x05\x042\x02\x03\x01\x12\x04\xf3\x03\x0f\x1d\n\r\n\
+    \x05\x042\x02\x03\x03\x12\x04\xf3\x03\x20!\n\xca\x01\n\x04\x042\x02\x04\
+    \x12\x04\xf7\x03\x08$\x1a\xbb\x01\x20Options\x20allows\x20the\x20caller\
+    \x20to\x20define\x20a\x20list\x20of\x20options\x20such\x20as\x20block\n\
+    \x20sizes,\x20numbers\x20of\x20luns,\x20...\x20which\x20are\x20very\x20s\
+    pecific\x20to\x20every\x20device\n\x20and\x20cannot\x20be\x20generalized\
+    \x20through\x20extra\x20fields.\n\n\r\n\x05\x042\x02\x04\x04\x12\x04\xf7\
+    \x03\x08\x10\n\r\n\x05\x042\x02\x04\x05\x12\x04\xf7\x03\x11\x17\n\r\n\
+    \x05\x042\x02\x04\x01\x12\x04\xf7\x03\x18\x1f\n\r\n\x05\x042\x02\x04\x03\
+    \x12\x04\xf7\x03\"#\n\x0c\n\x02\x043\x12\x06\xfa\x03\0\xfe\x03\x01\n\x0b\
+    \n\x03\x043\x01\x12\x04\xfa\x03\x08\x12\n\x0c\n\x04\x043\x02\0\x12\x04\
+    \xfb\x03\x08\x17\n\r\n\x05\x043\x02\0\x05\x12\x04\xfb\x03\x08\x0e\n\r\n\
+    \x05\x043\x02\0\x01\x12\x04\xfb\x03\x0f\x12\n\r\n\x05\x043\x02\0\x03\x12\
+    \x04\xfb\x03\x15\x16\n\x0c\n\x04\x043\x02\x01\x12\x04\xfc\x03\x08\x17\n\
+    \r\n\x05\x043\x02\x01\x05\x12\x04\xfc\x03\x08\x0e\n\r\n\x05\x043\x02\x01\
+    \x01\x12\x04\xfc\x03\x0f\x12\n\r\n\x05\x043\x02\x01\x03\x12\x04\xfc\x03\
+    \x15\x16\n\x0c\n\x04\x043\x02\x02\x12\x04\xfd\x03\x08+\n\r\n\x05\x043\
+    \x02\x02\x04\x12\x04\xfd\x03\x08\x10\n\r\n\x05\x043\x02\x02\x05\x12\x04\
+    \xfd\x03\x11\x17\n\r\n\x05\x043\x02\x02\x01\x12\x04\xfd\x03\x18&\n\r\n\
+    \x05\x043\x02\x02\x03\x12\x04\xfd\x03)*\n\x0c\n\x02\x044\x12\x06\x80\x04\
+    \0\x84\x04\x01\n\x0b\n\x03\x044\x01\x12\x04\x80\x04\x08\x1c\ni\n\x04\x04\
+    4\x02\0\x12\x04\x83\x04\x08\x1a\x1a[\x20device\x20identifies\x20the\x20d\
+    evice\x20the\x20way\x20it\x20is\x20described\x20when\x20creating\n\x20a\
+    \x20container\x20using\x20it.\n\n\r\n\x05\x044\x02\0\x06\x12\x04\x83\x04\
+    \x08\x0e\n\r\n\x05\x044\x02\0\x01\x12\x04\x83\x04\x0f\x15\n\r\n\x05\x044\
+    \x02\0\x03\x12\x04\x83\x04\x18\x19\n\x0c\n\x02\x045\x12\x06\x86\x04\0\
+    \x9e\x04\x01\n\x0b\n\x03\x045\x01\x12\x04\x86\x04\x08\x17\nj\n\x04\x045\
+    \x02\0\x12\x04\x89\x04\x08\x18\x1a\\\x20Path\x20is\x20the\x20destination\
+    \x20file\x20in\x20the\x20guest.\x20It\x20must\x20be\x20absolute,\n\x20ca\
+    nonical\x20and\x20below\x20/run.\n\n\r\n\x05\x045\x02\0\x05\x12\x04\x89\
+    \x04\x08\x0e\n\r\n\x05\x045\x02\0\x01\x12\x04\x89\x04\x0f\x13\n\r\n\x05\
+    \x045\x02\0\x03\x12\x04\x89\x04\x16\x17\n\xbd\x01\n\x04\x045\x02\x01\x12\
+    \x04\x8d\x04\x08\x1c\x1a\xae\x01\x20FileSize\x20is\x20the\x20expected\
+    \x20file\x20size,\x20for\x20security\x20reasons\x20write\x20operations\n\
+    \x20are\x20made\x20in\x20a\x20temporary\x20file,\x20once\x20it\x20has\
+    \x20the\x20expected\x20size,\x20it's\x20moved\n\x20to\x20the\x20destinat\
+    ion\x20path.\n\n\r\n\x05\x045\x02\x01\x05\x12\x04\x8d\x04\x08\r\n\r\n\
+    \x05\x045\x02\x01\x01\x12\x04\x8d\x04\x0e\x17\n\r\n\x05\x045\x02\x01\x03\
+    \x12\x04\x8d\x04\x1a\x1b\n*\n\x04\x045\x02\x02\x12\x04\x8f\x04\x08\x1d\
+    \x1a\x1c\x20FileMode\x20is\x20the\x20file\x20mode.\n\n\r\n\x05\x045\x02\
+    \x02\x05\x12\x04\x8f\x04\x08\x0e\n\r\n\x05\x045\x02\x02\x01\x12\x04\x8f\
+    \x04\x0f\x18\n\r\n\x05\x045\x02\x02\x03\x12\x04\x8f\x04\x1b\x1c\nS\n\x04\
+    \x045\x02\x03\x12\x04\x91\x04\x08\x1c\x1aE\x20DirMode\x20is\x20the\x20mo\
+    de\x20for\x20the\x20parent\x20directories\x20of\x20destination\x20path.\
+    \n\n\r\n\x05\x045\x02\x03\x05\x12\x04\x91\x04\x08\x0e\n\r\n\x05\x045\x02\
+    \x03\x01\x12\x04\x91\x04\x0f\x17\n\r\n\x05\x045\x02\x03\x03\x12\x04\x91\
+    \x04\x1a\x1b\n+\n\x04\x045\x02\x04\x12\x04\x93\x04\x08\x16\x1a\x1d\x20Ui\
+    d\x20is\x20the\x20numeric\x20user\x20id.\n\n\r\n\x05\x045\x02\x04\x05\
+    \x12\x04\x93\x04\x08\r\n\r\n\x05\x045\x02\x04\x01\x12\x04\x93\x04\x0e\
+    \x11\n\r\n\x05\x045\x02\x04\x03\x12\x04\x93\x04\x14\x15\n,\n\x04\x045\
+    \x02\x05\x12\x04\x95\x04\x08\x16\x1a\x1e\x20Gid\x20is\x20the\x20numeric\
+    \x20group\x20id.\n\n\r\n\x05\x045\x02\x05\x05\x12\x04\x95\x04\x08\r\n\r\
+    \n\x05\x045\x02\x05\x01\x12\x04\x95\x04\x0e\x11\n\r\n\x05\x045\x02\x05\
+    \x03\x12\x04\x95\x04\x14\x15\n4\n\x04\x045\x02\x06\x12\x04\x97\x04\x08\
+    \x19\x1a&\x20Offset\x20for\x20the\x20next\x20write\x20operation.\n\n\r\n\
+    \x05\x045\x02\x06\x05\x12\x04\x97\x04\x08\r\n\r\n\x05\x045\x02\x06\x01\
+    \x12\x04\x97\x04\x0e\x14\n\r\n\x05\x045\x02\x06\x03\x12\x04\x97\x04\x17\
+    \x18\n6\n\x04\x045\x02\x07\x12\x04\x99\x04\x08\x17\x1a(\x20Data\x20to\
+    \x20write\x20in\x20the\x20destination\x20file.\n\n\r\n\x05\x045\x02\x07\
+    \x05\x12\x04\x99\x04\x08\r\n\r\n\x05\x045\x02\x07\x01\x12\x04\x99\x04\
+    \x0e\x12\n\r\n\x05\x045\x02\x07\x03\x12\x04\x99\x04\x15\x16\n\xc0\x01\n\
+    \x04\x045\x02\x08\x12\x04\x9d\x04\x08\x18\x1a\xb1\x01\x20Root,\x20when\
+    \x20set,\x20is\x20the\x20container\x20rootfs\x20the\x20path\x20is\x20rel\
+    ative\x20to.\n\x20The\x20path\x20is\x20resolved\x20in\x20it\x20without\
+    \x20following\x20symlinks,\x20and\x20the\n\x20missing\x20parent\x20direc\
+    tories\x20are\x20created\x20in\x20it.\n\n\r\n\x05\x045\x02\x08\x05\x12\
+    \x04\x9d\x04\x08\x0e\n\r\n\x05\x045\x02\x08\x01\x12\x04\x9d\x04\x0f\x13\
+    \n\r\n\x05\x045\x02\x08\x03\x12\x04\x9d\x04\x16\x17\n\x0c\n\x02\x046\x12\
+    \x06\xa0\x04\0\xab\x04\x01\n\x0b\n\x03\x046\x01\x12\x04\xa0\x04\x08\x17\
+    \n{\n\x04\x046\x02\0\x12\x04\xa3\x04\x08\x18\x1am\x20Path\x20is\x20the\
+    \x20file\x20to\x20read\x20in\x20the\x20guest.\x20It\x20must\x20be\x20abs\
+    olute,\n\x20canonical\x20and\x20below\x20/run,\x20or\x20relative\x20to\
+    \x20root.\n\n\r\n\x05\x046\x02\0\x05\x12\x04\xa3\x04\x08\x0e\n\r\n\x05\
+    \x046\x02\0\x01\x12\x04\xa3\x04\x0f\x13\n\r\n\x05\x046\x02\0\x03\x12\x04\
+    \xa3\x04\x16\x17\n\x88\x01\n\x04\x046\x02\x01\x12\x04\xa6\x04\x08\x18\
+    \x1az\x20Root,\x20when\x20set,\x20is\x20the\x20container\x20rootfs\x20th\
+    e\x20path\x20is\x20relative\x20to.\n\x20The\x20path\x20is\x20resolved\
+    \x20in\x20it\x20without\x20following\x20symlinks.\n\n\r\n\x05\x046\x02\
+    \x01\x05\x12\x04\xa6\x04\x08\x0e\n\r\n\x05\x046\x02\x01\x01\x12\x04\xa6\
+    \x04\x0f\x13\n\r\n\x05\x046\x02\x01\x03\x12\x04\xa6\x04\x16\x17\n+\n\x04\
+    \x046\x02\x02\x12\x04\xa8\x04\x08\x19\x1a\x1d\x20Offset\x20of\x20the\x20\
+    part\x20to\x20read.\n\n\r\n\x05\x046\x02\x02\x05\x12\x04\xa8\x04\x08\r\n\
+    \r\n\x05\x046\x02\x02\x01\x12\x04\xa8\x04\x0e\x14\n\r\n\x05\x046\x02\x02\
+    \x03\x12\x04\xa8\x04\x17\x18\nA\n\x04\x046\x02\x03\x12\x04\xaa\x04\x08\
+    \x19\x1a3\x20Length\x20is\x20the\x20maximum\x20length\x20of\x20the\x20pa\
+    rt\x20to\x20read.\n\n\r\n\x05\x046\x02\x03\x05\x12\x04\xaa\x04\x08\r\n\r\
+    \n\x05\x046\x02\x03\x01\x12\x04\xaa\x04\x0e\x14\n\r\n\x05\x046\x02\x03\
+    \x03\x12\x04\xaa\x04\x17\x18\n\x0c\n\x02\x047\x12\x06\xad\x04\0\xba\x04\
+    \x01\n\x0b\n\x03\x047\x01\x12\x04\xad\x04\x08\x18\nD\n\x04\x047\x02\0\
+    \x12\x04\xaf\x04\x08\x17\x1a6\x20Data\x20read\x20at\x20offset,\x20empty\
+    \x20past\x20the\x20end\x20of\x20the\x20file.\n\n\r\n\x05\x047\x02\0\x05\
+    \x12\x04\xaf\x04\x08\r\n\r\n\x05\x047\x02\0\x01\x12\x04\xaf\x04\x0e\x12\
+    \n\r\n\x05\x047\x02\0\x03\x12\x04\xaf\x04\x15\x16\n,\n\x04\x047\x02\x01\
+    \x12\x04\xb1\x04\x08\x19\x1a\x1e\x20Offset\x20the\x20data\x20was\x20read\
+    \x20at.\n\n\r\n\x05\x047\x02\x01\x05\x12\x04\xb1\x04\x08\r\n\r\n\x05\x04\
+    7\x02\x01\x01\x12\x04\xb1\x04\x0e\x14\n\r\n\x05\x047\x02\x01\x03\x12\x04\
+    \xb1\x04\x17\x18\n7\n\x04\x047\x02\x02\x12\x04\xb3\x04\x08\x1c\x1a)\x20F\
+    ileSize\x20is\x20the\x20size\x20of\x20the\x20whole\x20file.\n\n\r\n\x05\
+    \x047\x02\x02\x05\x12\x04\xb3\x04\x08\r\n\r\n\x05\x047\x02\x02\x01\x12\
+    \x04\xb3\x04\x0e\x17\n\r\n\x05\x047\x02\x02\x03\x12\x04\xb3\x04\x1a\x1b\
+    \n*\n\x04\x047\x02\x03\x12\x04\xb5\x04\x08\x1d\x1a\x1c\x20FileMode\x20is\
+    \x20the\x20file\x20mode.\n\n\r\n\x05\x047\x02\x03\x05\x12\x04\xb5\x04\
+    \x08\x0e\n\r\n\x05\x047\x02\x03\x01\x12\x04\xb5\x04\x0f\x18\n\r\n\x05\
+    \x047\x02\x03\x03\x12\x04\xb5\x04\x1b\x1c\n+\n\x04\x047\x02\x04\x12\x04\
+    \xb7\x04\x08\x16\x1a\x1d\x20Uid\x20is\x20the\x20numeric\x20user\x20id.\n\
+    \n\r\n\x05\x047\x02\x04\x05\x12\x04\xb7\x04\x08\r\n\r\n\x05\x047\x02\x04\
+    \x01\x12\x04\xb7\x04\x0e\x11\n\r\n\x05\x047\x02\x04\x03\x12\x04\xb7\x04\
+    \x14\x15\n,\n\x04\x047\x02\x05\x12\x04\xb9\x04\x08\x16\x1a\x1e\x20Gid\
+    \x20is\x20the\x20numeric\x20group\x20id.\n\n\r\n\x05\x047\x02\x05\x05\
+    \x12\x04\xb9\x04\x08\r\n\r\n\x05\x047\x02\x05\x01\x12\x04\xb9\x04\x0e\
+    \x11\n\r\n\x05\x047\x02\x05\x03\x12\x04\xb9\x04\x14\x15\n\x0c\n\x02\x048\
+    \x12\x06\xbc\x04\0\xbd\x04\x01\n\x0b\n\x03\x048\x01\x12\x04\xbc\x04\x08\
+    \x1b\n\x0c\n\x02\x049\x12\x06\xbf\x04\0\xc0\x04\x01\n\x0b\n\x03\x049\x01\
+    \x12\x04\xbf\x04\x08\x1a\n\n\n\x02\x04:\x12\x04\xc2\x04\0\x1d\n\x0b\n\
+    \x03\x04:\x01\x12\x04\xc2\x04\x08\x1a\n\x0c\n\x02\x04;\x12\x06\xc4\x04\0\
+    \xc6\x04\x01\n\x0b\n\x03\x04;\x01\x12\x04\xc4\x04\x08\x10\n\x0c\n\x04\
+    \x04;\x02\0\x12\x04\xc5\x04\x08\x20\n\r\n\x05\x04;\x02\0\x05\x12\x04\xc5\
+    \x04\x08\x0e\n\r\n\x05\x04;\x02\0\x01\x12\x04\xc5\x04\x0f\x1b\n\r\n\x05\
+    \x04;\x02\0\x03\x12\x04\xc5\x04\x1e\x1f\n\n\n\x02\x04<\x12\x04\xc8\x04\0\
+    \x1c\n\x0b\n\x03\x04<\x01\x12\x04\xc8\x04\x08\x19\n\x0c\n\x02\x04=\x12\
+    \x06\xca\x04\0\xcc\x04\x01\n\x0b\n\x03\x04=\x01\x12\x04\xca\x04\x08\x0f\
+    \n\x0c\n\x04\x04=\x02\0\x12\x04\xcb\x04\x08\x1b\n\r\n\x05\x04=\x02\0\x05\
+    \x12\x04\xcb\x04\x08\x0e\n\r\n\x05\x04=\x02\0\x01\x12\x04\xcb\x04\x0f\
+    \x16\n\r\n\x05\x04=\x02\0\x03\x12\x04\xcb\x04\x19\x1a\n\x0c\n\x02\x04>\
+    \x12\x06\xce\x04\0\xd1\x04\x01\n\x0b\n\x03\x04>\x01\x12\x04\xce\x04\x08\
+    \x1a\nE\n\x04\x04>\x02\0\x12\x04\xd0\x04\x08\x1e\x1a7\x20report_data\x20\
+    is\x20bound\x20to\x20the\x20report,\x2064\x20bytes\x20at\x20most.\n\n\r\
+    \n\x05\x04>\x02\0\x05\x12\x04\xd0\x04\x08\r\n\r\n\x05\x04>\x02\0\x01\x12\
+    \x04\xd0\x04\x0e\x19\n\r\n\x05\x04>\x02\0\x03\x12\x04\xd0\x04\x1c\x1d\n\
+    \x0c\n\x02\x04?\x12\x06\xd3\x04\0\xd6\x04\x01\n\x0b\n\x03\x04?\x01\x12\
+    \x04\xd3\x04\x08\x1b\nM\n\x04\x04?\x02\0\x12\x04\xd5\x04\x08\x19\x1a?\
+    \x20report\x20is\x20the\x20TDREPORT\x20of\x20the\x20guest,\x20MACed\x20b\
+    y\x20the\x20TDX\x20module.\n\n\r\n\x05\x04?\x02\0\x05\x12\x04\xd5\x04\
+    \x08\r\n\r\n\x05\x04?\x02\0\x01\x12\x04\xd5\x04\x0e\x14\n\r\n\x05\x04?\
+    \x02\0\x03\x12\x04\xd5\x04\x17\x18\n\x0c\n\x02\x04@\x12\x06\xd8\x04\0\
+    \xdf\x04\x01\n\x0b\n\x03\x04@\x01\x12\x04\xd8\x04\x08\x1a\n\x88\x01\n\
+    \x04\x04@\x02\0\x12\x04\xdb\x04\x08\x1a\x1az\x20source\x20is\x20the\x20f\
+    ile\x20copied\x20in\x20the\x20guest,\x20kept\x20as\x20the\x20source\x20o\
+    f\x20the\n\x20bind\x20mount\x20when\x20the\x20existing\x20file\x20cannot\
+    \x20be\x20replaced.\n\n\r\n\x05\x04@\x02\0\x05\x12\x04\xdb\x04\x08\x0e\n\
+    \r\n\x05\x04@\x02\0\x01\x12\x04\xdb\x04\x0f\x15\n\r\n\x05\x04@\x02\0\x03\
+    \x12\x04\xdb\x04\x18\x19\nl\n\x04\x04@\x02\x01\x12\x04\xde\x04\x08\x18\
+    \x1a^\x20path\x20is\x20the\x20absolute\x20guest\x20path\x20the\x20file\
+    \x20is\x20installed\x20at,\x20the\n\x20existing\x20file\x20being\x20repl\
+    aced.\n\n\r\n\x05\x04@\x02\x01\x05\x12\x04\xde\x04\x08\x0e\n\r\n\x05\x04\
+    @\x02\x01\x01\x12\x04\xde\x04\x0f\x13\n\r\n\x05\x04@\x02\x01\x03\x12\x04\
+    \xde\x04\x16\x17\n\x0c\n\x02\x04A\x12\x06\xe1\x04\0\xe6\x04\x01\n\x0b\n\
+    \x03\x04A\x01\x12\x04\xe1\x04\x08\x1e\nv\n\x04\x04A\x02\0\x12\x04\xe4\
+    \x04\x08\x1a\x1ah\x20kernel\x20and\x20initrd\x20are\x20the\x20crash\x20k\
+    ernel\x20and\x20its\x20initrd\x20copied\x20in\n\x20the\x20guest,\x20the\
+    \x20initrd\x20being\x20optional.\n\n\r\n\x05\x04A\x02\0\x05\x12\x04\xe4\
+    \x04\x08\x0e\n\r\n\x05\x04A\x02\0\x01\x12\x04\xe4\x04\x0f\x15\n\r\n\x05\
+    \x04A\x02\0\x03\x12\x04\xe4\x04\x18\x19\n\x0c\n\x04\x04A\x02\x01\x12\x04\
+    \xe5\x04\x08\x1a\n\r\n\x05\x04A\x02\x01\x05\x12\x04\xe5\x04\x08\x0e\n\r\
+    \n\x05\x04A\x02\x01\x01\x12\x04\xe5\x04\x0f\x15\n\r\n\x05\x04A\x02\x01\
+    \x03\x12\x04\xe5\x04\x18\x19\n\x0c\n\x02\x04B\x12\x06\xe8\x04\0\xf7\x04\
+    \x01\n\x0b\n\x03\x04B\x01\x12\x04\xe8\x04\x08\x1d\n\x95\x01\n\x04\x04B\
+    \x02\0\x12\x04\xec\x04\x08\x1b\x1a\x86\x01\x20session\x20names\x20the\
+    \x20directory\x20of\x20the\x20session\x20in\x20the\x20shared\n\x20direct\
+    ory,\x20which\x20the\x20output\x20and\x20the\x20status\x20of\x20the\x20s\
+    ession\x20are\n\x20written\x20to.\n\n\r\n\x05\x04B\x02\0\x05\x12\x04\xec\
+    \x04\x08\x0e\n\r\n\x05\x04B\x02\0\x01\x12\x04\xec\x04\x0f\x16\n\r\n\x05\
+    \x04B\x02\0\x03\x12\x04\xec\x04\x19\x1a\n)\n\x04\x04B\x02\x01\x12\x04\
+    \xee\x04\x08\x18\x1a\x1b\x20tool\x20is\x20perf\x20or\x20bpftrace.\n\n\r\
+    \n\x05\x04B\x02\x01\x05\x12\x04\xee\x04\x08\x0e\n\r\n\x05\x04B\x02\x01\
+    \x01\x12\x04\xee\x04\x0f\x13\n\r\n\x05\x04B\x02\x01\x03\x12\x04\xee\x04\
+    \x16\x17\nB\n\x04\x04B\x02\x02\x12\x04\xf0\x04\x08\x1c\x1a4\x20duration\
+    \x20is\x20the\x20length\x20of\x20the\x20session,\x20in\x20seconds.\n\n\r\
+    \n\x05\x04B\x02\x02\x05\x12\x04\xf0\x04\x08\x0e\n\r\n\x05\x04B\x02\x02\
+    \x01\x12\x04\xf0\x04\x0f\x17\n\r\n\x05\x04B\x02\x02\x03\x12\x04\xf0\x04\
+    \x1a\x1b\nX\n\x04\x04B\x02\x03\x12\x04\xf3\x04\x08\x1d\x1aJ\x20frequency\
+    \x20is\x20the\x20perf\x20sampling\x20frequency,\x20in\x20Hz,\x20the\x20p\
+    erf\x20default\n\x20if\x200.\n\n\r\n\x05\x04B\x02\x03\x05\x12\x04\xf3\
+    \x04\x08\x0e\n\r\n\x05\x04B\x02\x03\x01\x12\x04\xf3\x04\x0f\x18\n\r\n\
+    \x05\x04B\x02\x03\x03\x12\x04\xf3\x04\x1b\x1c\nX\n\x04\x04B\x02\x04\x12\
+    \x04\xf6\x04\x08\x1a\x1aJ\x20script\x20is\x20the\x20name\x20of\x20the\
+    \x20bpftrace\x20script\x20shipped\x20with\x20the\x20guest\n\x20image.\n\
+    \n\r\n\x05\x04B\x02\x04\x05\x12\x04\xf6\x04\x08\x0e\n\r\n\x05\x04B\x02\
+    \x04\x01\x12\x04\xf6\x04\x0f\x15\n\r\n\x05\x04B\x02\x04\x03\x12\x04\xf6\
+    \x04\x18\x19\n\x0c\n\x02\x04C\x12\x06\xf9\x04\0\xfe\x04\x01\n\x0b\n\x03\
+    \x04C\x01\x12\x04\xf9\x04\x08\x1c\n;\n\x04\x04C\x02\0\x12\x04\xfb\x04\
+    \x08\x1a\x1a-\x20module\x20is\x20the\x20name\x20of\x20the\x20livepatch\
+    \x20module.\n\n\r\n\x05\x04C\x02\0\x05\x12\x04\xfb\x04\x08\x0e\n\r\n\x05\
+    \x04C\x02\0\x01\x12\x04\xfb\x04\x0f\x15\n\r\n\x05\x04C\x02\0\x03\x12\x04\
+    \xfb\x04\x18\x19\nL\n\x04\x04C\x02\x01\x12\x04\xfd\x04\x08\x18\x1a>\x20p\
+    ath\x20is\x20the\x20module\x20copied\x20in\x20the\x20guest,\x20removed\
+    \x20once\x20loaded.\n\n\r\n\x05\x04C\x02\x01\x05\x12\x04\xfd\x04\x08\x0e\
+    \n\r\n\x05\x04C\x02\x01\x01\x12\x04\xfd\x04\x0f\x13\n\r\n\x05\x04C\x02\
+    \x01\x03\x12\x04\xfd\x04\x16\x17\n\x0c\n\x02\x04D\x12\x06\x80\x05\0\x87\
+    \x05\x01\n\x0b\n\x03\x04D\x01\x12\x04\x80\x05\x08\"\n\x0c\n\x04\x04D\x02\
+    \0\x12\x04\x81\x05\x08\x20\n\r\n\x05\x04D\x02\0\x05\x12\x04\x81\x05\x08\
+    \x0e\n\r\n\x05\x04D\x02\0\x01\x12\x04\x81\x05\x0f\x1b\n\r\n\x05\x04D\x02\
+    \0\x03\x12\x04\x81\x05\x1e\x1f\nQ\n\x04\x04D\x02\x01\x12\x04\x83\x05\x08\
+    \x1e\x1aC\x20images_dir\x20is\x20the\x20guest\x20directory\x20the\x20CRI\
+    U\x20images\x20are\x20written\x20to.\n\n\r\n\x05\x04D\x02\x01\x05\x12\
+    \x04\x83\x05\x08\x0e\n\r\n\x05\x04D\x02\x01\x01\x12\x04\x83\x05\x0f\x19\
+    \n\r\n\x05\x04D\x02\x01\x03\x12\x04\x83\x05\x1c\x1d\nY\n\x04\x04D\x02\
+    \x02\x12\x04\x86\x05\x08\x1f\x1aK\x20leave_running\x20keeps\x20the\x20co\
+    ntainer\x20running,\x20its\x20processes\x20exit\n\x20otherwise.\n\n\r\n\
+    \x05\x04D\x02\x02\x05\x12\x04\x86\x05\x08\x0c\n\r\n\x05\x04D\x02\x02\x01\
+    \x12\x04\x86\x05\r\x1a\n\r\n\x05\x04D\x02\x02\x03\x12\x04\x86\x05\x1d\
+    \x1e\n\x0c\n\x02\x04E\x12\x06\x89\x05\0\x8f\x05\x01\n\x0b\n\x03\x04E\x01\
+    \x12\x04\x89\x05\x08\x1f\nd\n\x04\x04E\x02\0\x12\x04\x8c\x05\x08\x20\x1a\
+    V\x20container_id\x20is\x20the\x20created\x20container\x20the\x20checkpo\
+    inted\x20one\x20is\n\x20restored\x20in\x20place\x20of.\n\n\r\n\x05\x04E\
+    \x02\0\x05\x12\x04\x8c\x05\x08\x0e\n\r\n\x05\x04E\x02\0\x01\x12\x04\x8c\
+    \x05\x0f\x1b\n\r\n\x05\x04E\x02\0\x03\x12\x04\x8c\x05\x1e\x1f\nE\n\x04\
+    \x04E\x02\x01\x12\x04\x8e\x05\x08\x1e\x1a7\x20images_dir\x20is\x20the\
+    \x20guest\x20directory\x20of\x20the\x20CRIU\x20images.\n\n\r\n\x05\x04E\
+    \x02\x01\x05\x12\x04\x8e\x05\x08\x0e\n\r\n\x05\x04E\x02\x01\x01\x12\x04\
+    \x8e\x05\x0f\x19\n\r\n\x05\x04E\x02\x01\x03\x12\x04\x8e\x05\x1c\x1db\x06\
+    proto3\
 ";
 
 static mut file_descriptor_proto_lazy: ::protobuf::lazy::Lazy<::protobuf::descriptor::FileDescriptorProto> = ::protobuf::lazy::Lazy::INIT;
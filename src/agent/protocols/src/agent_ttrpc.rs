@@ -235,6 +235,66 @@ impl AgentServiceClient {
         ::ttrpc::client_request!(self, req, timeout_nano, "grpc.AgentService", "GetOOMEvent", cres);
         Ok(cres)
     }
+
+    pub fn suspend_guest(&self, req: &super::empty::Empty, timeout_nano: i64) -> ::ttrpc::Result<super::empty::Empty> {
+        let mut cres = super::empty::Empty::new();
+        ::ttrpc::client_request!(self, req, timeout_nano, "grpc.AgentService", "SuspendGuest", cres);
+        Ok(cres)
+    }
+
+    pub fn read_file(&self, req: &super::agent::ReadFileRequest, timeout_nano: i64) -> ::ttrpc::Result<super::agent::ReadFileResponse> {
+        let mut cres = super::agent::ReadFileResponse::new();
+        ::ttrpc::client_request!(self, req, timeout_nano, "grpc.AgentService", "ReadFile", cres);
+        Ok(cres)
+    }
+
+    pub fn get_td_report(&self, req: &super::agent::GetTDReportRequest, timeout_nano: i64) -> ::ttrpc::Result<super::agent::GetTDReportResponse> {
+        let mut cres = super::agent::GetTDReportResponse::new();
+        ::ttrpc::client_request!(self, req, timeout_nano, "grpc.AgentService", "GetTDReport", cres);
+        Ok(cres)
+    }
+
+    pub fn release_device(&self, req: &super::agent::ReleaseDeviceRequest, timeout_nano: i64) -> ::ttrpc::Result<super::empty::Empty> {
+        let mut cres = super::empty::Empty::new();
+        ::ttrpc::client_request!(self, req, timeout_nano, "grpc.AgentService", "ReleaseDevice", cres);
+        Ok(cres)
+    }
+
+    pub fn install_file(&self, req: &super::agent::InstallFileRequest, timeout_nano: i64) -> ::ttrpc::Result<super::empty::Empty> {
+        let mut cres = super::empty::Empty::new();
+        ::ttrpc::client_request!(self, req, timeout_nano, "grpc.AgentService", "InstallFile", cres);
+        Ok(cres)
+    }
+
+    pub fn load_crash_kernel(&self, req: &super::agent::LoadCrashKernelRequest, timeout_nano: i64) -> ::ttrpc::Result<super::empty::Empty> {
+        let mut cres = super::empty::Empty::new();
+        ::ttrpc::client_request!(self, req, timeout_nano, "grpc.AgentService", "LoadCrashKernel", cres);
+        Ok(cres)
+    }
+
+    pub fn start_profiling(&self, req: &super::agent::StartProfilingRequest, timeout_nano: i64) -> ::ttrpc::Result<super::empty::Empty> {
+        let mut cres = super::empty::Empty::new();
+        ::ttrpc::client_request!(self, req, timeout_nano, "grpc.AgentService", "StartProfiling", cres);
+        Ok(cres)
+    }
+
+    pub fn load_livepatch(&self, req: &super::agent::LoadLivepatchRequest, timeout_nano: i64) -> ::ttrpc::Result<super::empty::Empty> {
+        let mut cres = super::empty::Empty::new();
+        ::ttrpc::client_request!(self, req, timeout_nano, "grpc.AgentService", "LoadLivepatch", cres);
+        Ok(cres)
+    }
+
+    pub fn checkpoint_container(&self, req: &super::agent::CheckpointContainerRequest, timeout_nano: i64) -> ::ttrpc::Result<super::empty::Empty> {
+        let mut cres = super::empty::Empty::new();
+        ::ttrpc::client_request!(self, req, timeout_nano, "grpc.AgentService", "CheckpointContainer", cres);
+        Ok(cres)
+    }
+
+    pub fn restore_container(&self, req: &super::agent::RestoreContainerRequest, timeout_nano: i64) -> ::ttrpc::Result<super::empty::Empty> {
+        let mut cres = super::empty::Empty::new();
+        ::ttrpc::client_request!(self, req, timeout_nano, "grpc.AgentService", "RestoreContainer", cres);
+        Ok(cres)
+    }
 }
 
 struct CreateContainerMethod {
@@ -600,6 +660,116 @@ impl ::ttrpc::MethodHandler for GetOomEventMethod {
     }
 }
 
+struct SuspendGuestMethod {
+    service: Arc<std::boxed::Box<dyn AgentService + Send + Sync>>,
+}
+
+impl ::ttrpc::MethodHandler for SuspendGuestMethod {
+    fn handler(&self, ctx: ::ttrpc::TtrpcContext, req: ::ttrpc::Request) -> ::ttrpc::Result<()> {
+        ::ttrpc::request_handler!(self, ctx, req, empty, Empty, suspend_guest);
+        Ok(())
+    }
+}
+
+struct ReadFileMethod {
+    service: Arc<std::boxed::Box<dyn AgentService + Send + Sync>>,
+}
+
+impl ::ttrpc::MethodHandler for ReadFileMethod {
+    fn handler(&self, ctx: ::ttrpc::TtrpcContext, req: ::ttrpc::Request) -> ::ttrpc::Result<()> {
+        ::ttrpc::request_handler!(self, ctx, req, agent, ReadFileRequest, read_file);
+        Ok(())
+    }
+}
+
+struct GetTdReportMethod {
+    service: Arc<std::boxed::Box<dyn AgentService + Send + Sync>>,
+}
+
+impl ::ttrpc::MethodHandler for GetTdReportMethod {
+    fn handler(&self, ctx: ::ttrpc::TtrpcContext, req: ::ttrpc::Request) -> ::ttrpc::Result<()> {
+        ::ttrpc::request_handler!(self, ctx, req, agent, GetTDReportRequest, get_td_report);
+        Ok(())
+    }
+}
+
+struct ReleaseDeviceMethod {
+    service: Arc<std::boxed::Box<dyn AgentService + Send + Sync>>,
+}
+
+impl ::ttrpc::MethodHandler for ReleaseDeviceMethod {
+    fn handler(&self, ctx: ::ttrpc::TtrpcContext, req: ::ttrpc::Request) -> ::ttrpc::Result<()> {
+        ::ttrpc::request_handler!(self, ctx, req, agent, ReleaseDeviceRequest, release_device);
+        Ok(())
+    }
+}
+
+struct InstallFileMethod {
+    service: Arc<std::boxed::Box<dyn AgentService + Send + Sync>>,
+}
+
+impl ::ttrpc::MethodHandler for InstallFileMethod {
+    fn handler(&self, ctx: ::ttrpc::TtrpcContext, req: ::ttrpc::Request) -> ::ttrpc::Result<()> {
+        ::ttrpc::request_handler!(self, ctx, req, agent, InstallFileRequest, install_file);
+        Ok(())
+    }
+}
+
+struct LoadCrashKernelMethod {
+    service: Arc<std::boxed::Box<dyn AgentService + Send + Sync>>,
+}
+
+impl ::ttrpc::MethodHandler for LoadCrashKernelMethod {
+    fn handler(&self, ctx: ::ttrpc::TtrpcContext, req: ::ttrpc::Request) -> ::ttrpc::Result<()> {
+        ::ttrpc::request_handler!(self, ctx, req, agent, LoadCrashKernelRequest, load_crash_kernel);
+        Ok(())
+    }
+}
+
+struct StartProfilingMethod {
+    service: Arc<std::boxed::Box<dyn AgentService + Send + Sync>>,
+}
+
+impl ::ttrpc::MethodHandler for StartProfilingMethod {
+    fn handler(&self, ctx: ::ttrpc::TtrpcContext, req: ::ttrpc::Request) -> ::ttrpc::Result<()> {
+        ::ttrpc::request_handler!(self, ctx, req, agent, StartProfilingRequest, start_profiling);
+        Ok(())
+    }
+}
+
+struct LoadLivepatchMethod {
+    service: Arc<std::boxed::Box<dyn AgentService + Send + Sync>>,
+}
+
+impl ::ttrpc::MethodHandler for LoadLivepatchMethod {
+    fn handler(&self, ctx: ::ttrpc::TtrpcContext, req: ::ttrpc::Request) -> ::ttrpc::Result<()> {
+        ::ttrpc::request_handler!(self, ctx, req, agent, LoadLivepatchRequest, load_livepatch);
+        Ok(())
+    }
+}
+
+struct CheckpointContainerMethod {
+    service: Arc<std::boxed::Box<dyn AgentService + Send + Sync>>,
+}
+
+impl ::ttrpc::MethodHandler for CheckpointContainerMethod {
+    fn handler(&self, ctx: ::ttrpc::TtrpcContext, req: ::ttrpc::Request) -> ::ttrpc::Result<()> {
+        ::ttrpc::request_handler!(self, ctx, req, agent, CheckpointContainerRequest, checkpoint_container);
+        Ok(())
+    }
+}
+
+struct RestoreContainerMethod {
+    service: Arc<std::boxed::Box<dyn AgentService + Send + Sync>>,
+}
+
+impl ::ttrpc::MethodHandler for RestoreContainerMethod {
+    fn handler(&self, ctx: ::ttrpc::TtrpcContext, req: ::ttrpc::Request) -> ::ttrpc::Result<()> {
+        ::ttrpc::request_handler!(self, ctx, req, agent, RestoreContainerRequest, restore_container);
+        Ok(())
+    }
+}
+
 pub trait AgentService {
     fn create_container(&self, _ctx: &::ttrpc::TtrpcContext, _req: super::agent::CreateContainerRequest) -> ::ttrpc::Result<super::empty::Empty> {
         Err(::ttrpc::Error::RpcStatus(::ttrpc::get_status(::ttrpc::Code::NOT_FOUND, "/grpc.AgentService/CreateContainer is not supported".to_string())))
@@ -700,6 +870,36 @@ pub trait AgentService {
     fn get_oom_event(&self, _ctx: &::ttrpc::TtrpcContext, _req: super::agent::GetOOMEventRequest) -> ::ttrpc::Result<super::agent::OOMEvent> {
         Err(::ttrpc::Error::RpcStatus(::ttrpc::get_status(::ttrpc::Code::NOT_FOUND, "/grpc.AgentService/GetOOMEvent is not supported".to_string())))
     }
+    fn suspend_guest(&self, _ctx: &::ttrpc::TtrpcContext, _req: super::empty::Empty) -> ::ttrpc::Result<super::empty::Empty> {
+        Err(::ttrpc::Error::RpcStatus(::ttrpc::get_status(::ttrpc::Code::NOT_FOUND, "/grpc.AgentService/SuspendGuest is not supported".to_string())))
+    }
+    fn read_file(&self, _ctx: &::ttrpc::TtrpcContext, _req: super::agent::ReadFileRequest) -> ::ttrpc::Result<super::agent::ReadFileResponse> {
+        Err(::ttrpc::Error::RpcStatus(::ttrpc::get_status(::ttrpc::Code::NOT_FOUND, "/grpc.AgentService/ReadFile is not supported".to_string())))
+    }
+    fn get_td_report(&self, _ctx: &::ttrpc::TtrpcContext, _req: super::agent::GetTDReportRequest) -> ::ttrpc::Result<super::agent::GetTDReportResponse> {
+        Err(::ttrpc::Error::RpcStatus(::ttrpc::get_status(::ttrpc::Code::NOT_FOUND, "/grpc.AgentService/GetTDReport is not supported".to_string())))
+    }
+    fn release_device(&self, _ctx: &::ttrpc::TtrpcContext, _req: super::agent::ReleaseDeviceRequest) -> ::ttrpc::Result<super::empty::Empty> {
+        Err(::ttrpc::Error::RpcStatus(::ttrpc::get_status(::ttrpc::Code::NOT_FOUND, "/grpc.AgentService/ReleaseDevice is not supported".to_string())))
+    }
+    fn install_file(&self, _ctx: &::ttrpc::TtrpcContext, _req: super::agent::InstallFileRequest) -> ::ttrpc::Result<super::empty::Empty> {
+        Err(::ttrpc::Error::RpcStatus(::ttrpc::get_status(::ttrpc::Code::NOT_FOUND, "/grpc.AgentService/InstallFile is not supported".to_string())))
+    }
+    fn load_crash_kernel(&self, _ctx: &::ttrpc::TtrpcContext, _req: super::agent::LoadCrashKernelRequest) -> ::ttrpc::Result<super::empty::Empty> {
+        Err(::ttrpc::Error::RpcStatus(::ttrpc::get_status(::ttrpc::Code::NOT_FOUND, "/grpc.AgentService/LoadCrashKernel is not supported".to_string())))
+    }
+    fn start_profiling(&self, _ctx: &::ttrpc::TtrpcContext, _req: super::agent::StartProfilingRequest) -> ::ttrpc::Result<super::empty::Empty> {
+        Err(::ttrpc::Error::RpcStatus(::ttrpc::get_status(::ttrpc::Code::NOT_FOUND, "/grpc.AgentService/StartProfiling is not supported".to_string())))
+    }
+    fn load_livepatch(&self, _ctx: &::ttrpc::TtrpcContext, _req: super::agent::LoadLivepatchRequest) -> ::ttrpc::Result<super::empty::Empty> {
+        Err(::ttrpc::Error::RpcStatus(::ttrpc::get_status(::ttrpc::Code::NOT_FOUND, "/grpc.AgentService/LoadLivepatch is not supported".to_string())))
+    }
+    fn checkpoint_container(&self, _ctx: &::ttrpc::TtrpcContext, _req: super::agent::CheckpointContainerRequest) -> ::ttrpc::Result<super::empty::Empty> {
+        Err(::ttrpc::Error::RpcStatus(::ttrpc::get_status(::ttrpc::Code::NOT_FOUND, "/grpc.AgentService/CheckpointContainer is not supported".to_string())))
+    }
+    fn restore_container(&self, _ctx: &::ttrpc::TtrpcContext, _req: super::agent::RestoreContainerRequest) -> ::ttrpc::Result<super::empty::Empty> {
+        Err(::ttrpc::Error::RpcStatus(::ttrpc::get_status(::ttrpc::Code::NOT_FOUND, "/grpc.AgentService/RestoreContainer is not supported".to_string())))
+    }
 }
 
 pub fn create_agent_service(service: Arc<std::boxed::Box<dyn AgentService + Send + Sync>>) -> HashMap <String, Box<dyn ::ttrpc::MethodHandler + Send + Sync>> {
@@ -804,5 +1004,35 @@ pub fn create_agent_service(service: Arc<std::boxed::Box<dyn AgentService + Send
     methods.insert("/grpc.AgentService/GetOOMEvent".to_string(),
                     std::boxed::Box::new(GetOomEventMethod{service: service.clone()}) as std::boxed::Box<dyn ::ttrpc::MethodHandler + Send + Sync>);
 
+    methods.insert("/grpc.AgentService/SuspendGuest".to_string(),
+                    std::boxed::Box::new(SuspendGuestMethod{service: service.clone()}) as std::boxed::Box<dyn ::ttrpc::MethodHandler + Send + Sync>);
+
+    methods.insert("/grpc.AgentService/ReadFile".to_string(),
+                    std::boxed::Box::new(ReadFileMethod{service: service.clone()}) as std::boxed::Box<dyn ::ttrpc::MethodHandler + Send + Sync>);
+
+    methods.insert("/grpc.AgentService/GetTDReport".to_string(),
+                    std::boxed::Box::new(GetTdReportMethod{service: service.clone()}) as std::boxed::Box<dyn ::ttrpc::MethodHandler + Send + Sync>);
+
+    methods.insert("/grpc.AgentService/ReleaseDevice".to_string(),
+                    std::boxed::Box::new(ReleaseDeviceMethod{service: service.clone()}) as std::boxed::Box<dyn ::ttrpc::MethodHandler + Send + Sync>);
+
+    methods.insert("/grpc.AgentService/InstallFile".to_string(),
+                    std::boxed::Box::new(InstallFileMethod{service: service.clone()}) as std::boxed::Box<dyn ::ttrpc::MethodHandler + Send + Sync>);
+
+    methods.insert("/grpc.AgentService/LoadCrashKernel".to_string(),
+                    std::boxed::Box::new(LoadCrashKernelMethod{service: service.clone()}) as std::boxed::Box<dyn ::ttrpc::MethodHandler + Send + Sync>);
+
+    methods.insert("/grpc.AgentService/StartProfiling".to_string(),
+                    std::boxed::Box::new(StartProfilingMethod{service: service.clone()}) as std::boxed::Box<dyn ::ttrpc::MethodHandler + Send + Sync>);
+
+    methods.insert("/grpc.AgentService/LoadLivepatch".to_string(),
+                    std::boxed::Box::new(LoadLivepatchMethod{service: service.clone()}) as std::boxed::Box<dyn ::ttrpc::MethodHandler + Send + Sync>);
+
+    methods.insert("/grpc.AgentService/CheckpointContainer".to_string(),
+                    std::boxed::Box::new(CheckpointContainerMethod{service: service.clone()}) as std::boxed::Box<dyn ::ttrpc::MethodHandler + Send + Sync>);
+
+    methods.insert("/grpc.AgentService/RestoreContainer".to_string(),
+                    std::boxed::Box::new(RestoreContainerMethod{service: service.clone()}) as std::boxed::Box<dyn ::ttrpc::MethodHandler + Send + Sync>);
+
     methods
 }
@@ -2829,6 +2829,9 @@ pub struct Hooks {
     pub Prestart: ::protobuf::RepeatedField<Hook>,
     pub Poststart: ::protobuf::RepeatedField<Hook>,
     pub Poststop: ::protobuf::RepeatedField<Hook>,
+    pub CreateRuntime: ::protobuf::RepeatedField<Hook>,
+    pub CreateContainer: ::protobuf::RepeatedField<Hook>,
+    pub StartContainer: ::protobuf::RepeatedField<Hook>,
     // special fields
     pub unknown_fields: ::protobuf::UnknownFields,
     pub cached_size: ::protobuf::CachedSize,
@@ -2919,6 +2922,81 @@ impl Hooks {
     pub fn take_Poststop(&mut self) -> ::protobuf::RepeatedField<Hook> {
         ::std::mem::replace(&mut self.Poststop, ::protobuf::RepeatedField::new())
     }
+
+    // repeated .grpc.Hook CreateRuntime = 4;
+
+
+    pub fn get_CreateRuntime(&self) -> &[Hook] {
+        &self.CreateRuntime
+    }
+    pub fn clear_CreateRuntime(&mut self) {
+        self.CreateRuntime.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_CreateRuntime(&mut self, v: ::protobuf::RepeatedField<Hook>) {
+        self.CreateRuntime = v;
+    }
+
+    // Mutable pointer to the field.
+    pub fn mut_CreateRuntime(&mut self) -> &mut ::protobuf::RepeatedField<Hook> {
+        &mut self.CreateRuntime
+    }
+
+    // Take field
+    pub fn take_CreateRuntime(&mut self) -> ::protobuf::RepeatedField<Hook> {
+        ::std::mem::replace(&mut self.CreateRuntime, ::protobuf::RepeatedField::new())
+    }
+
+    // repeated .grpc.Hook CreateContainer = 5;
+
+
+    pub fn get_CreateContainer(&self) -> &[Hook] {
+        &self.CreateContainer
+    }
+    pub fn clear_CreateContainer(&mut self) {
+        self.CreateContainer.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_CreateContainer(&mut self, v: ::protobuf::RepeatedField<Hook>) {
+        self.CreateContainer = v;
+    }
+
+    // Mutable pointer to the field.
+    pub fn mut_CreateContainer(&mut self) -> &mut ::protobuf::RepeatedField<Hook> {
+        &mut self.CreateContainer
+    }
+
+    // Take field
+    pub fn take_CreateContainer(&mut self) -> ::protobuf::RepeatedField<Hook> {
+        ::std::mem::replace(&mut self.CreateContainer, ::protobuf::RepeatedField::new())
+    }
+
+    // repeated .grpc.Hook StartContainer = 6;
+
+
+    pub fn get_StartContainer(&self) -> &[Hook] {
+        &self.StartContainer
+    }
+    pub fn clear_StartContainer(&mut self) {
+        self.StartContainer.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_StartContainer(&mut self, v: ::protobuf::RepeatedField<Hook>) {
+        self.StartContainer = v;
+    }
+
+    // Mutable pointer to the field.
+    pub fn mut_StartContainer(&mut self) -> &mut ::protobuf::RepeatedField<Hook> {
+        &mut self.StartContainer
+    }
+
+    // Take field
+    pub fn take_StartContainer(&mut self) -> ::protobuf::RepeatedField<Hook> {
+        ::std::mem::replace(&mut self.StartContainer, ::protobuf::RepeatedField::new())
+    }
 }
 
 impl ::protobuf::Message for Hooks {
@@ -2938,6 +3016,21 @@ impl ::protobuf::Message for Hooks {
                 return false;
             }
         };
+        for v in &self.CreateRuntime {
+            if !v.is_initialized() {
+                return false;
+            }
+        };
+        for v in &self.CreateContainer {
+            if !v.is_initialized() {
+                return false;
+            }
+        };
+        for v in &self.StartContainer {
+            if !v.is_initialized() {
+                return false;
+            }
+        };
         true
     }
 
@@ -2954,6 +3047,15 @@ impl ::protobuf::Message for Hooks {
                 3 => {
                     ::protobuf::rt::read_repeated_message_into(wire_type, is, &mut self.Poststop)?;
                 },
+                4 => {
+                    ::protobuf::rt::read_repeated_message_into(wire_type, is, &mut self.CreateRuntime)?;
+                },
+                5 => {
+                    ::protobuf::rt::read_repeated_message_into(wire_type, is, &mut self.CreateContainer)?;
+                },
+                6 => {
+                    ::protobuf::rt::read_repeated_message_into(wire_type, is, &mut self.StartContainer)?;
+                },
                 _ => {
                     ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
                 },
@@ -2978,6 +3080,18 @@ impl ::protobuf::Message for Hooks {
             let len = value.compute_size();
             my_size += 1 + ::protobuf::rt::compute_raw_varint32_size(len) + len;
         };
+        for value in &self.CreateRuntime {
+            let len = value.compute_size();
+            my_size += 1 + ::protobuf::rt::compute_raw_varint32_size(len) + len;
+        };
+        for value in &self.CreateContainer {
+            let len = value.compute_size();
+            my_size += 1 + ::protobuf::rt::compute_raw_varint32_size(len) + len;
+        };
+        for value in &self.StartContainer {
+            let len = value.compute_size();
+            my_size += 1 + ::protobuf::rt::compute_raw_varint32_size(len) + len;
+        };
         my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
         self.cached_size.set(my_size);
         my_size
@@ -2999,6 +3113,21 @@ impl ::protobuf::Message for Hooks {
             os.write_raw_varint32(v.get_cached_size())?;
             v.write_to_with_cached_sizes(os)?;
         };
+        for v in &self.CreateRuntime {
+            os.write_tag(4, ::protobuf::wire_format::WireTypeLengthDelimited)?;
+            os.write_raw_varint32(v.get_cached_size())?;
+            v.write_to_with_cached_sizes(os)?;
+        };
+        for v in &self.CreateContainer {
+            os.write_tag(5, ::protobuf::wire_format::WireTypeLengthDelimited)?;
+            os.write_raw_varint32(v.get_cached_size())?;
+            v.write_to_with_cached_sizes(os)?;
+        };
+        for v in &self.StartContainer {
+            os.write_tag(6, ::protobuf::wire_format::WireTypeLengthDelimited)?;
+            os.write_raw_varint32(v.get_cached_size())?;
+            v.write_to_with_cached_sizes(os)?;
+        };
         os.write_unknown_fields(self.get_unknown_fields())?;
         ::std::result::Result::Ok(())
     }
@@ -3053,6 +3182,21 @@ impl ::protobuf::Message for Hooks {
                     |m: &Hooks| { &m.Poststop },
                     |m: &mut Hooks| { &mut m.Poststop },
                 ));
+                fields.push(::protobuf::reflect::accessor::make_repeated_field_accessor::<_, ::protobuf::types::ProtobufTypeMessage<Hook>>(
+                    "CreateRuntime",
+                    |m: &Hooks| { &m.CreateRuntime },
+                    |m: &mut Hooks| { &mut m.CreateRuntime },
+                ));
+                fields.push(::protobuf::reflect::accessor::make_repeated_field_accessor::<_, ::protobuf::types::ProtobufTypeMessage<Hook>>(
+                    "CreateContainer",
+                    |m: &Hooks| { &m.CreateContainer },
+                    |m: &mut Hooks| { &mut m.CreateContainer },
+                ));
+                fields.push(::protobuf::reflect::accessor::make_repeated_field_accessor::<_, ::protobuf::types::ProtobufTypeMessage<Hook>>(
+                    "StartContainer",
+                    |m: &Hooks| { &m.StartContainer },
+                    |m: &mut Hooks| { &mut m.StartContainer },
+                ));
                 ::protobuf::reflect::MessageDescriptor::new_pb_name::<Hooks>(
                     "Hooks",
                     fields,
@@ -3075,6 +3219,9 @@ impl ::protobuf::Clear for Hooks {
         self.Prestart.clear();
         self.Poststart.clear();
         self.Poststop.clear();
+        self.CreateRuntime.clear();
+        self.CreateContainer.clear();
+        self.StartContainer.clear();
         self.unknown_fields.clear();
     }
 }
@@ -5251,6 +5398,7 @@ pub struct LinuxResources {
     pub BlockIO: ::protobuf::SingularPtrField<LinuxBlockIO>,
     pub HugepageLimits: ::protobuf::RepeatedField<LinuxHugepageLimit>,
     pub Network: ::protobuf::SingularPtrField<LinuxNetwork>,
+    pub Unified: ::std::collections::HashMap<::std::string::String, ::std::string::String>,
     // special fields
     pub unknown_fields: ::protobuf::UnknownFields,
     pub cached_size: ::protobuf::CachedSize,
@@ -5481,6 +5629,31 @@ impl LinuxResources {
     pub fn take_Network(&mut self) -> LinuxNetwork {
         self.Network.take().unwrap_or_else(|| LinuxNetwork::new())
     }
+
+    // repeated .grpc.LinuxResources.UnifiedEntry Unified = 8;
+
+
+    pub fn get_Unified(&self) -> &::std::collections::HashMap<::std::string::String, ::std::string::String> {
+        &self.Unified
+    }
+    pub fn clear_Unified(&mut self) {
+        self.Unified.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_Unified(&mut self, v: ::std::collections::HashMap<::std::string::String, ::std::string::String>) {
+        self.Unified = v;
+    }
+
+    // Mutable pointer to the field.
+    pub fn mut_Unified(&mut self) -> &mut ::std::collections::HashMap<::std::string::String, ::std::string::String> {
+        &mut self.Unified
+    }
+
+    // Take field
+    pub fn take_Unified(&mut self) -> ::std::collections::HashMap<::std::string::String, ::std::string::String> {
+        ::std::mem::replace(&mut self.Unified, ::std::collections::HashMap::new())
+    }
 }
 
 impl ::protobuf::Message for LinuxResources {
@@ -5548,6 +5721,9 @@ impl ::protobuf::Message for LinuxResources {
                 7 => {
                     ::protobuf::rt::read_singular_message_into(wire_type, is, &mut self.Network)?;
                 },
+                8 => {
+                    ::protobuf::rt::read_map_into::<::protobuf::types::ProtobufTypeString, ::protobuf::types::ProtobufTypeString>(wire_type, is, &mut self.Unified)?;
+                },
                 _ => {
                     ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
                 },
@@ -5588,6 +5764,7 @@ impl ::protobuf::Message for LinuxResources {
             let len = v.compute_size();
             my_size += 1 + ::protobuf::rt::compute_raw_varint32_size(len) + len;
         }
+        my_size += ::protobuf::rt::compute_map_size::<::protobuf::types::ProtobufTypeString, ::protobuf::types::ProtobufTypeString>(8, &self.Unified);
         my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
         self.cached_size.set(my_size);
         my_size
@@ -5629,6 +5806,7 @@ impl ::protobuf::Message for LinuxResources {
             os.write_raw_varint32(v.get_cached_size())?;
             v.write_to_with_cached_sizes(os)?;
         }
+        ::protobuf::rt::write_map_with_cached_sizes::<::protobuf::types::ProtobufTypeString, ::protobuf::types::ProtobufTypeString>(8, &self.Unified, os)?;
         os.write_unknown_fields(self.get_unknown_fields())?;
         ::std::result::Result::Ok(())
     }
@@ -5703,6 +5881,11 @@ impl ::protobuf::Message for LinuxResources {
                     |m: &LinuxResources| { &m.Network },
                     |m: &mut LinuxResources| { &mut m.Network },
                 ));
+                fields.push(::protobuf::reflect::accessor::make_map_accessor::<_, ::protobuf::types::ProtobufTypeString, ::protobuf::types::ProtobufTypeString>(
+                    "Unified",
+                    |m: &LinuxResources| { &m.Unified },
+                    |m: &mut LinuxResources| { &mut m.Unified },
+                ));
                 ::protobuf::reflect::MessageDescriptor::new_pb_name::<LinuxResources>(
                     "LinuxResources",
                     fields,
@@ -5729,6 +5912,7 @@ impl ::protobuf::Clear for LinuxResources {
         self.BlockIO.clear();
         self.HugepageLimits.clear();
         self.Network.clear();
+        self.Unified.clear();
         self.unknown_fields.clear();
     }
 }
@@ -8487,6 +8671,7 @@ pub struct LinuxSeccomp {
     pub DefaultAction: ::std::string::String,
     pub Architectures: ::protobuf::RepeatedField<::std::string::String>,
     pub Syscalls: ::protobuf::RepeatedField<LinuxSyscall>,
+    pub Flags: ::protobuf::RepeatedField<::std::string::String>,
     // special fields
     pub unknown_fields: ::protobuf::UnknownFields,
     pub cached_size: ::protobuf::CachedSize,
@@ -8578,6 +8763,31 @@ impl LinuxSeccomp {
     pub fn take_Syscalls(&mut self) -> ::protobuf::RepeatedField<LinuxSyscall> {
         ::std::mem::replace(&mut self.Syscalls, ::protobuf::RepeatedField::new())
     }
+
+    // repeated string Flags = 4;
+
+
+    pub fn get_Flags(&self) -> &[::std::string::String] {
+        &self.Flags
+    }
+    pub fn clear_Flags(&mut self) {
+        self.Flags.clear();
+    }
+
+    // Param is passed by value, moved
+    pub fn set_Flags(&mut self, v: ::protobuf::RepeatedField<::std::string::String>) {
+        self.Flags = v;
+    }
+
+    // Mutable pointer to the field.
+    pub fn mut_Flags(&mut self) -> &mut ::protobuf::RepeatedField<::std::string::String> {
+        &mut self.Flags
+    }
+
+    // Take field
+    pub fn take_Flags(&mut self) -> ::protobuf::RepeatedField<::std::string::String> {
+        ::std::mem::replace(&mut self.Flags, ::protobuf::RepeatedField::new())
+    }
 }
 
 impl ::protobuf::Message for LinuxSeccomp {
@@ -8603,6 +8813,9 @@ impl ::protobuf::Message for LinuxSeccomp {
                 3 => {
                     ::protobuf::rt::read_repeated_message_into(wire_type, is, &mut self.Syscalls)?;
                 },
+                4 => {
+                    ::protobuf::rt::read_repeated_string_into(wire_type, is, &mut self.Flags)?;
+                },
                 _ => {
                     ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
                 },
@@ -8625,6 +8838,9 @@ impl ::protobuf::Message for LinuxSeccomp {
             let len = value.compute_size();
             my_size += 1 + ::protobuf::rt::compute_raw_varint32_size(len) + len;
         };
+        for value in &self.Flags {
+            my_size += ::protobuf::rt::string_size(4, &value);
+        };
         my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
         self.cached_size.set(my_size);
         my_size
@@ -8642,6 +8858,9 @@ impl ::protobuf::Message for LinuxSeccomp {
             os.write_raw_varint32(v.get_cached_size())?;
             v.write_to_with_cached_sizes(os)?;
         };
+        for v in &self.Flags {
+            os.write_string(4, &v)?;
+        };
         os.write_unknown_fields(self.get_unknown_fields())?;
         ::std::result::Result::Ok(())
     }
@@ -8696,6 +8915,11 @@ impl ::protobuf::Message for LinuxSeccomp {
                     |m: &LinuxSeccomp| { &m.Syscalls },
                     |m: &mut LinuxSeccomp| { &mut m.Syscalls },
                 ));
+                fields.push(::protobuf::reflect::accessor::make_repeated_field_accessor::<_, ::protobuf::types::ProtobufTypeString>(
+                    "Flags",
+                    |m: &LinuxSeccomp| { &m.Flags },
+                    |m: &mut LinuxSeccomp| { &mut m.Flags },
+                ));
                 ::protobuf::reflect::MessageDescriptor::new_pb_name::<LinuxSeccomp>(
                     "LinuxSeccomp",
                     fields,
@@ -8718,6 +8942,7 @@ impl ::protobuf::Clear for LinuxSeccomp {
         self.DefaultAction.clear();
         self.Architectures.clear();
         self.Syscalls.clear();
+        self.Flags.clear();
         self.unknown_fields.clear();
     }
 }
@@ -9008,6 +9233,7 @@ pub struct LinuxSyscall {
     pub Names: ::protobuf::RepeatedField<::std::string::String>,
     pub Action: ::std::string::String,
     pub Args: ::protobuf::RepeatedField<LinuxSeccompArg>,
+    pub ErrnoRet: u32,
     // special fields
     pub unknown_fields: ::protobuf::UnknownFields,
     pub cached_size: ::protobuf::CachedSize,
@@ -9099,6 +9325,21 @@ impl LinuxSyscall {
     pub fn take_Args(&mut self) -> ::protobuf::RepeatedField<LinuxSeccompArg> {
         ::std::mem::replace(&mut self.Args, ::protobuf::RepeatedField::new())
     }
+
+    // uint32 ErrnoRet = 4;
+
+
+    pub fn get_ErrnoRet(&self) -> u32 {
+        self.ErrnoRet
+    }
+    pub fn clear_ErrnoRet(&mut self) {
+        self.ErrnoRet = 0;
+    }
+
+    // Param is passed by value, moved
+    pub fn set_ErrnoRet(&mut self, v: u32) {
+        self.ErrnoRet = v;
+    }
 }
 
 impl ::protobuf::Message for LinuxSyscall {
@@ -9124,6 +9365,13 @@ impl ::protobuf::Message for LinuxSyscall {
                 3 => {
                     ::protobuf::rt::read_repeated_message_into(wire_type, is, &mut self.Args)?;
                 },
+                4 => {
+                    if wire_type != ::protobuf::wire_format::WireTypeVarint {
+                        return ::std::result::Result::Err(::protobuf::rt::unexpected_wire_type(wire_type));
+                    }
+                    let tmp = is.read_uint32()?;
+                    self.ErrnoRet = tmp;
+                },
                 _ => {
                     ::protobuf::rt::read_unknown_or_skip_group(field_number, wire_type, is, self.mut_unknown_fields())?;
                 },
@@ -9146,6 +9394,9 @@ impl ::protobuf::Message for LinuxSyscall {
             let len = value.compute_size();
             my_size += 1 + ::protobuf::rt::compute_raw_varint32_size(len) + len;
         };
+        if self.ErrnoRet != 0 {
+            my_size += ::protobuf::rt::value_size(4, self.ErrnoRet, ::protobuf::wire_format::WireTypeVarint);
+        }
         my_size += ::protobuf::rt::unknown_fields_size(self.get_unknown_fields());
         self.cached_size.set(my_size);
         my_size
@@ -9163,6 +9414,9 @@ impl ::protobuf::Message for LinuxSyscall {
             os.write_raw_varint32(v.get_cached_size())?;
             v.write_to_with_cached_sizes(os)?;
         };
+        if self.ErrnoRet != 0 {
+            os.write_uint32(4, self.ErrnoRet)?;
+        }
         os.write_unknown_fields(self.get_unknown_fields())?;
         ::std::result::Result::Ok(())
     }
@@ -9217,6 +9471,11 @@ impl ::protobuf::Message for LinuxSyscall {
                     |m: &LinuxSyscall| { &m.Args },
                     |m: &mut LinuxSyscall| { &mut m.Args },
                 ));
+                fields.push(::protobuf::reflect::accessor::make_simple_field_accessor::<_, ::protobuf::types::ProtobufTypeUint32>(
+                    "ErrnoRet",
+                    |m: &LinuxSyscall| { &m.ErrnoRet },
+                    |m: &mut LinuxSyscall| { &mut m.ErrnoRet },
+                ));
                 ::protobuf::reflect::MessageDescriptor::new_pb_name::<LinuxSyscall>(
                     "LinuxSyscall",
                     fields,
@@ -9239,6 +9498,7 @@ impl ::protobuf::Clear for LinuxSyscall {
         self.Names.clear();
         self.Action.clear();
         self.Args.clear();
+        self.ErrnoRet = 0;
         self.unknown_fields.clear();
     }
 }
@@ -9460,41 +9720,45 @@ static file_descriptor_proto_data: &'static [u8] = b"\
     \x06source\x12\x12\n\x04type\x18\x03\x20\x01(\tR\x04type\x12\x18\n\x07op\
     tions\x18\x04\x20\x03(\tR\x07options\"6\n\x04Root\x12\x12\n\x04Path\x18\
     \x01\x20\x01(\tR\x04Path\x12\x1a\n\x08Readonly\x18\x02\x20\x01(\x08R\x08\
-    Readonly\"\x93\x01\n\x05Hooks\x12,\n\x08Prestart\x18\x01\x20\x03(\x0b2\n\
+    Readonly\"\xc1\x02\n\x05Hooks\x12,\n\x08Prestart\x18\x01\x20\x03(\x0b2\n\
     .grpc.HookR\x08PrestartB\x04\xc8\xde\x1f\0\x12.\n\tPoststart\x18\x02\x20\
     \x03(\x0b2\n.grpc.HookR\tPoststartB\x04\xc8\xde\x1f\0\x12,\n\x08Poststop\
-    \x18\x03\x20\x03(\x0b2\n.grpc.HookR\x08PoststopB\x04\xc8\xde\x1f\0\"Z\n\
-    \x04Hook\x12\x12\n\x04Path\x18\x01\x20\x01(\tR\x04Path\x12\x12\n\x04Args\
-    \x18\x02\x20\x03(\tR\x04Args\x12\x10\n\x03Env\x18\x03\x20\x03(\tR\x03Env\
-    \x12\x18\n\x07Timeout\x18\x04\x20\x01(\x03R\x07Timeout\"\xa9\x05\n\x05Li\
-    nux\x12<\n\x0bUIDMappings\x18\x01\x20\x03(\x0b2\x14.grpc.LinuxIDMappingR\
-    \x0bUIDMappingsB\x04\xc8\xde\x1f\0\x12<\n\x0bGIDMappings\x18\x02\x20\x03\
-    (\x0b2\x14.grpc.LinuxIDMappingR\x0bGIDMappingsB\x04\xc8\xde\x1f\0\x12/\n\
-    \x06Sysctl\x18\x03\x20\x03(\x0b2\x17.grpc.Linux.SysctlEntryR\x06Sysctl\
-    \x122\n\tResources\x18\x04\x20\x01(\x0b2\x14.grpc.LinuxResourcesR\tResou\
-    rces\x12\x20\n\x0bCgroupsPath\x18\x05\x20\x01(\tR\x0bCgroupsPath\x12:\n\
-    \nNamespaces\x18\x06\x20\x03(\x0b2\x14.grpc.LinuxNamespaceR\nNamespacesB\
-    \x04\xc8\xde\x1f\0\x121\n\x07Devices\x18\x07\x20\x03(\x0b2\x11.grpc.Linu\
-    xDeviceR\x07DevicesB\x04\xc8\xde\x1f\0\x12,\n\x07Seccomp\x18\x08\x20\x01\
-    (\x0b2\x12.grpc.LinuxSeccompR\x07Seccomp\x12,\n\x11RootfsPropagation\x18\
-    \t\x20\x01(\tR\x11RootfsPropagation\x12\x20\n\x0bMaskedPaths\x18\n\x20\
-    \x03(\tR\x0bMaskedPaths\x12$\n\rReadonlyPaths\x18\x0b\x20\x03(\tR\rReado\
-    nlyPaths\x12\x1e\n\nMountLabel\x18\x0c\x20\x01(\tR\nMountLabel\x12/\n\
-    \x08IntelRdt\x18\r\x20\x01(\x0b2\x13.grpc.LinuxIntelRdtR\x08IntelRdt\x1a\
-    9\n\x0bSysctlEntry\x12\x10\n\x03key\x18\x01\x20\x01(\tR\x03key\x12\x14\n\
-    \x05value\x18\x02\x20\x01(\tR\x05value:\x028\x01\"\x1f\n\x07Windows\x12\
-    \x14\n\x05dummy\x18\x01\x20\x01(\tR\x05dummy\"\x1f\n\x07Solaris\x12\x14\
-    \n\x05dummy\x18\x01\x20\x01(\tR\x05dummy\"^\n\x0eLinuxIDMapping\x12\x16\
-    \n\x06HostID\x18\x01\x20\x01(\rR\x06HostID\x12\x20\n\x0bContainerID\x18\
-    \x02\x20\x01(\rR\x0bContainerID\x12\x12\n\x04Size\x18\x03\x20\x01(\rR\
-    \x04Size\"8\n\x0eLinuxNamespace\x12\x12\n\x04Type\x18\x01\x20\x01(\tR\
-    \x04Type\x12\x12\n\x04Path\x18\x02\x20\x01(\tR\x04Path\"\xa1\x01\n\x0bLi\
-    nuxDevice\x12\x12\n\x04Path\x18\x01\x20\x01(\tR\x04Path\x12\x12\n\x04Typ\
-    e\x18\x02\x20\x01(\tR\x04Type\x12\x14\n\x05Major\x18\x03\x20\x01(\x03R\
+    \x18\x03\x20\x03(\x0b2\n.grpc.HookR\x08PoststopB\x04\xc8\xde\x1f\0\x126\
+    \n\rCreateRuntime\x18\x04\x20\x03(\x0b2\n.grpc.HookR\rCreateRuntimeB\x04\
+    \xc8\xde\x1f\0\x12:\n\x0fCreateContainer\x18\x05\x20\x03(\x0b2\n.grpc.Ho\
+    okR\x0fCreateContainerB\x04\xc8\xde\x1f\0\x128\n\x0eStartContainer\x18\
+    \x06\x20\x03(\x0b2\n.grpc.HookR\x0eStartContainerB\x04\xc8\xde\x1f\0\"Z\
+    \n\x04Hook\x12\x12\n\x04Path\x18\x01\x20\x01(\tR\x04Path\x12\x12\n\x04Ar\
+    gs\x18\x02\x20\x03(\tR\x04Args\x12\x10\n\x03Env\x18\x03\x20\x03(\tR\x03E\
+    nv\x12\x18\n\x07Timeout\x18\x04\x20\x01(\x03R\x07Timeout\"\xa9\x05\n\x05\
+    Linux\x12<\n\x0bUIDMappings\x18\x01\x20\x03(\x0b2\x14.grpc.LinuxIDMappin\
+    gR\x0bUIDMappingsB\x04\xc8\xde\x1f\0\x12<\n\x0bGIDMappings\x18\x02\x20\
+    \x03(\x0b2\x14.grpc.LinuxIDMappingR\x0bGIDMappingsB\x04\xc8\xde\x1f\0\
+    \x12/\n\x06Sysctl\x18\x03\x20\x03(\x0b2\x17.grpc.Linux.SysctlEntryR\x06S\
+    ysctl\x122\n\tResources\x18\x04\x20\x01(\x0b2\x14.grpc.LinuxResourcesR\t\
+    Resources\x12\x20\n\x0bCgroupsPath\x18\x05\x20\x01(\tR\x0bCgroupsPath\
+    \x12:\n\nNamespaces\x18\x06\x20\x03(\x0b2\x14.grpc.LinuxNamespaceR\nName\
+    spacesB\x04\xc8\xde\x1f\0\x121\n\x07Devices\x18\x07\x20\x03(\x0b2\x11.gr\
+    pc.LinuxDeviceR\x07DevicesB\x04\xc8\xde\x1f\0\x12,\n\x07Seccomp\x18\x08\
+    \x20\x01(\x0b2\x12.grpc.LinuxSeccompR\x07Seccomp\x12,\n\x11RootfsPropaga\
+    tion\x18\t\x20\x01(\tR\x11RootfsPropagation\x12\x20\n\x0bMaskedPaths\x18\
+    \n\x20\x03(\tR\x0bMaskedPaths\x12$\n\rReadonlyPaths\x18\x0b\x20\x03(\tR\
+    \rReadonlyPaths\x12\x1e\n\nMountLabel\x18\x0c\x20\x01(\tR\nMountLabel\
+    \x12/\n\x08IntelRdt\x18\r\x20\x01(\x0b2\x13.grpc.LinuxIntelRdtR\x08Intel\
+    Rdt\x1a9\n\x0bSysctlEntry\x12\x10\n\x03key\x18\x01\x20\x01(\tR\x03key\
+    \x12\x14\n\x05value\x18\x02\x20\x01(\tR\x05value:\x028\x01\"\x1f\n\x07Wi\
+    ndows\x12\x14\n\x05dummy\x18\x01\x20\x01(\tR\x05dummy\"\x1f\n\x07Solaris\
+    \x12\x14\n\x05dummy\x18\x01\x20\x01(\tR\x05dummy\"^\n\x0eLinuxIDMapping\
+    \x12\x16\n\x06HostID\x18\x01\x20\x01(\rR\x06HostID\x12\x20\n\x0bContaine\
+    rID\x18\x02\x20\x01(\rR\x0bContainerID\x12\x12\n\x04Size\x18\x03\x20\x01\
+    (\rR\x04Size\"8\n\x0eLinuxNamespace\x12\x12\n\x04Type\x18\x01\x20\x01(\t\
+    R\x04Type\x12\x12\n\x04Path\x18\x02\x20\x01(\tR\x04Path\"\xa1\x01\n\x0bL\
+    inuxDevice\x12\x12\n\x04Path\x18\x01\x20\x01(\tR\x04Path\x12\x12\n\x04Ty\
+    pe\x18\x02\x20\x01(\tR\x04Type\x12\x14\n\x05Major\x18\x03\x20\x01(\x03R\
     \x05Major\x12\x14\n\x05Minor\x18\x04\x20\x01(\x03R\x05Minor\x12\x1a\n\
     \x08FileMode\x18\x05\x20\x01(\rR\x08FileMode\x12\x10\n\x03UID\x18\x06\
-    \x20\x01(\rR\x03UID\x12\x10\n\x03GID\x18\x07\x20\x01(\rR\x03GID\"\xdf\
-    \x02\n\x0eLinuxResources\x127\n\x07Devices\x18\x01\x20\x03(\x0b2\x17.grp\
+    \x20\x01(\rR\x03UID\x12\x10\n\x03GID\x18\x07\x20\x01(\rR\x03GID\"\xd8\
+    \x03\n\x0eLinuxResources\x127\n\x07Devices\x18\x01\x20\x03(\x0b2\x17.grp\
     c.LinuxDeviceCgroupR\x07DevicesB\x04\xc8\xde\x1f\0\x12)\n\x06Memory\x18\
     \x02\x20\x01(\x0b2\x11.grpc.LinuxMemoryR\x06Memory\x12\x20\n\x03CPU\x18\
     \x03\x20\x01(\x0b2\x0e.grpc.LinuxCPUR\x03CPU\x12#\n\x04Pids\x18\x04\x20\
@@ -9502,37 +9766,40 @@ static file_descriptor_proto_data: &'static [u8] = b"\
     (\x0b2\x12.grpc.LinuxBlockIOR\x07BlockIO\x12F\n\x0eHugepageLimits\x18\
     \x06\x20\x03(\x0b2\x18.grpc.LinuxHugepageLimitR\x0eHugepageLimitsB\x04\
     \xc8\xde\x1f\0\x12,\n\x07Network\x18\x07\x20\x01(\x0b2\x12.grpc.LinuxNet\
-    workR\x07Network\"\xdb\x01\n\x0bLinuxMemory\x12\x14\n\x05Limit\x18\x01\
-    \x20\x01(\x03R\x05Limit\x12\x20\n\x0bReservation\x18\x02\x20\x01(\x03R\
-    \x0bReservation\x12\x12\n\x04Swap\x18\x03\x20\x01(\x03R\x04Swap\x12\x16\
-    \n\x06Kernel\x18\x04\x20\x01(\x03R\x06Kernel\x12\x1c\n\tKernelTCP\x18\
-    \x05\x20\x01(\x03R\tKernelTCP\x12\x1e\n\nSwappiness\x18\x06\x20\x01(\x04\
-    R\nSwappiness\x12*\n\x10DisableOOMKiller\x18\x07\x20\x01(\x08R\x10Disabl\
-    eOOMKiller\"\xca\x01\n\x08LinuxCPU\x12\x16\n\x06Shares\x18\x01\x20\x01(\
-    \x04R\x06Shares\x12\x14\n\x05Quota\x18\x02\x20\x01(\x03R\x05Quota\x12\
-    \x16\n\x06Period\x18\x03\x20\x01(\x04R\x06Period\x12(\n\x0fRealtimeRunti\
-    me\x18\x04\x20\x01(\x03R\x0fRealtimeRuntime\x12&\n\x0eRealtimePeriod\x18\
-    \x05\x20\x01(\x04R\x0eRealtimePeriod\x12\x12\n\x04Cpus\x18\x06\x20\x01(\
-    \tR\x04Cpus\x12\x12\n\x04Mems\x18\x07\x20\x01(\tR\x04Mems\"w\n\x11LinuxW\
-    eightDevice\x12\x14\n\x05Major\x18\x01\x20\x01(\x03R\x05Major\x12\x14\n\
-    \x05Minor\x18\x02\x20\x01(\x03R\x05Minor\x12\x16\n\x06Weight\x18\x03\x20\
-    \x01(\rR\x06Weight\x12\x1e\n\nLeafWeight\x18\x04\x20\x01(\rR\nLeafWeight\
-    \"U\n\x13LinuxThrottleDevice\x12\x14\n\x05Major\x18\x01\x20\x01(\x03R\
-    \x05Major\x12\x14\n\x05Minor\x18\x02\x20\x01(\x03R\x05Minor\x12\x12\n\
-    \x04Rate\x18\x03\x20\x01(\x04R\x04Rate\"\xed\x03\n\x0cLinuxBlockIO\x12\
-    \x16\n\x06Weight\x18\x01\x20\x01(\rR\x06Weight\x12\x1e\n\nLeafWeight\x18\
-    \x02\x20\x01(\rR\nLeafWeight\x12A\n\x0cWeightDevice\x18\x03\x20\x03(\x0b\
-    2\x17.grpc.LinuxWeightDeviceR\x0cWeightDeviceB\x04\xc8\xde\x1f\0\x12U\n\
-    \x15ThrottleReadBpsDevice\x18\x04\x20\x03(\x0b2\x19.grpc.LinuxThrottleDe\
-    viceR\x15ThrottleReadBpsDeviceB\x04\xc8\xde\x1f\0\x12W\n\x16ThrottleWrit\
-    eBpsDevice\x18\x05\x20\x03(\x0b2\x19.grpc.LinuxThrottleDeviceR\x16Thrott\
-    leWriteBpsDeviceB\x04\xc8\xde\x1f\0\x12W\n\x16ThrottleReadIOPSDevice\x18\
-    \x06\x20\x03(\x0b2\x19.grpc.LinuxThrottleDeviceR\x16ThrottleReadIOPSDevi\
-    ceB\x04\xc8\xde\x1f\0\x12Y\n\x17ThrottleWriteIOPSDevice\x18\x07\x20\x03(\
-    \x0b2\x19.grpc.LinuxThrottleDeviceR\x17ThrottleWriteIOPSDeviceB\x04\xc8\
-    \xde\x1f\0\"!\n\tLinuxPids\x12\x14\n\x05Limit\x18\x01\x20\x01(\x03R\x05L\
-    imit\"\x81\x01\n\x11LinuxDeviceCgroup\x12\x14\n\x05Allow\x18\x01\x20\x01\
-    (\x08R\x05Allow\x12\x12\n\x04Type\x18\x02\x20\x01(\tR\x04Type\x12\x14\n\
+    workR\x07Network\x12;\n\x07Unified\x18\x08\x20\x03(\x0b2!.grpc.LinuxReso\
+    urces.UnifiedEntryR\x07Unified\x1a:\n\x0cUnifiedEntry\x12\x10\n\x03key\
+    \x18\x01\x20\x01(\tR\x03key\x12\x14\n\x05value\x18\x02\x20\x01(\tR\x05va\
+    lue:\x028\x01\"\xdb\x01\n\x0bLinuxMemory\x12\x14\n\x05Limit\x18\x01\x20\
+    \x01(\x03R\x05Limit\x12\x20\n\x0bReservation\x18\x02\x20\x01(\x03R\x0bRe\
+    servation\x12\x12\n\x04Swap\x18\x03\x20\x01(\x03R\x04Swap\x12\x16\n\x06K\
+    ernel\x18\x04\x20\x01(\x03R\x06Kernel\x12\x1c\n\tKernelTCP\x18\x05\x20\
+    \x01(\x03R\tKernelTCP\x12\x1e\n\nSwappiness\x18\x06\x20\x01(\x04R\nSwapp\
+    iness\x12*\n\x10DisableOOMKiller\x18\x07\x20\x01(\x08R\x10DisableOOMKill\
+    er\"\xca\x01\n\x08LinuxCPU\x12\x16\n\x06Shares\x18\x01\x20\x01(\x04R\x06\
+    Shares\x12\x14\n\x05Quota\x18\x02\x20\x01(\x03R\x05Quota\x12\x16\n\x06Pe\
+    riod\x18\x03\x20\x01(\x04R\x06Period\x12(\n\x0fRealtimeRuntime\x18\x04\
+    \x20\x01(\x03R\x0fRealtimeRuntime\x12&\n\x0eRealtimePeriod\x18\x05\x20\
+    \x01(\x04R\x0eRealtimePeriod\x12\x12\n\x04Cpus\x18\x06\x20\x01(\tR\x04Cp\
+    us\x12\x12\n\x04Mems\x18\x07\x20\x01(\tR\x04Mems\"w\n\x11LinuxWeightDevi\
+    ce\x12\x14\n\x05Major\x18\x01\x20\x01(\x03R\x05Major\x12\x14\n\x05Minor\
+    \x18\x02\x20\x01(\x03R\x05Minor\x12\x16\n\x06Weight\x18\x03\x20\x01(\rR\
+    \x06Weight\x12\x1e\n\nLeafWeight\x18\x04\x20\x01(\rR\nLeafWeight\"U\n\
+    \x13LinuxThrottleDevice\x12\x14\n\x05Major\x18\x01\x20\x01(\x03R\x05Majo\
+    r\x12\x14\n\x05Minor\x18\x02\x20\x01(\x03R\x05Minor\x12\x12\n\x04Rate\
+    \x18\x03\x20\x01(\x04R\x04Rate\"\xed\x03\n\x0cLinuxBlockIO\x12\x16\n\x06\
+    Weight\x18\x01\x20\x01(\rR\x06Weight\x12\x1e\n\nLeafWeight\x18\x02\x20\
+    \x01(\rR\nLeafWeight\x12A\n\x0cWeightDevice\x18\x03\x20\x03(\x0b2\x17.gr\
+    pc.LinuxWeightDeviceR\x0cWeightDeviceB\x04\xc8\xde\x1f\0\x12U\n\x15Throt\
+    tleReadBpsDevice\x18\x04\x20\x03(\x0b2\x19.grpc.LinuxThrottleDeviceR\x15\
+    ThrottleReadBpsDeviceB\x04\xc8\xde\x1f\0\x12W\n\x16ThrottleWriteBpsDevic\
+    e\x18\x05\x20\x03(\x0b2\x19.grpc.LinuxThrottleDeviceR\x16ThrottleWriteBp\
+    sDeviceB\x04\xc8\xde\x1f\0\x12W\n\x16ThrottleReadIOPSDevice\x18\x06\x20\
+    \x03(\x0b2\x19.grpc.LinuxThrottleDeviceR\x16ThrottleReadIOPSDeviceB\x04\
+    \xc8\xde\x1f\0\x12Y\n\x17ThrottleWriteIOPSDevice\x18\x07\x20\x03(\x0b2\
+    \x19.grpc.LinuxThrottleDeviceR\x17ThrottleWriteIOPSDeviceB\x04\xc8\xde\
+    \x1f\0\"!\n\tLinuxPids\x12\x14\n\x05Limit\x18\x01\x20\x01(\x03R\x05Limit\
+    \"\x81\x01\n\x11LinuxDeviceCgroup\x12\x14\n\x05Allow\x18\x01\x20\x01(\
+    \x08R\x05Allow\x12\x12\n\x04Type\x18\x02\x20\x01(\tR\x04Type\x12\x14\n\
     \x05Major\x18\x03\x20\x01(\x03R\x05Major\x12\x14\n\x05Minor\x18\x04\x20\
     \x01(\x03R\x05Minor\x12\x16\n\x06Access\x18\x05\x20\x01(\tR\x06Access\"l\
     \n\x0cLinuxNetwork\x12\x18\n\x07ClassID\x18\x01\x20\x01(\rR\x07ClassID\
@@ -9541,741 +9808,742 @@ static file_descriptor_proto_data: &'static [u8] = b"\
     \x08Pagesize\x18\x01\x20\x01(\tR\x08Pagesize\x12\x14\n\x05Limit\x18\x02\
     \x20\x01(\x04R\x05Limit\"H\n\x16LinuxInterfacePriority\x12\x12\n\x04Name\
     \x18\x01\x20\x01(\tR\x04Name\x12\x1a\n\x08Priority\x18\x02\x20\x01(\rR\
-    \x08Priority\"\x90\x01\n\x0cLinuxSeccomp\x12$\n\rDefaultAction\x18\x01\
+    \x08Priority\"\xa6\x01\n\x0cLinuxSeccomp\x12$\n\rDefaultAction\x18\x01\
     \x20\x01(\tR\rDefaultAction\x12$\n\rArchitectures\x18\x02\x20\x03(\tR\rA\
     rchitectures\x124\n\x08Syscalls\x18\x03\x20\x03(\x0b2\x12.grpc.LinuxSysc\
-    allR\x08SyscallsB\x04\xc8\xde\x1f\0\"i\n\x0fLinuxSeccompArg\x12\x14\n\
-    \x05Index\x18\x01\x20\x01(\x04R\x05Index\x12\x14\n\x05Value\x18\x02\x20\
-    \x01(\x04R\x05Value\x12\x1a\n\x08ValueTwo\x18\x03\x20\x01(\x04R\x08Value\
-    Two\x12\x0e\n\x02Op\x18\x04\x20\x01(\tR\x02Op\"m\n\x0cLinuxSyscall\x12\
-    \x14\n\x05Names\x18\x01\x20\x03(\tR\x05Names\x12\x16\n\x06Action\x18\x02\
-    \x20\x01(\tR\x06Action\x12/\n\x04Args\x18\x03\x20\x03(\x0b2\x15.grpc.Lin\
-    uxSeccompArgR\x04ArgsB\x04\xc8\xde\x1f\0\"5\n\rLinuxIntelRdt\x12$\n\rL3C\
-    acheSchema\x18\x01\x20\x01(\tR\rL3CacheSchemaBpZ^github.com/kata-contain\
-    ers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc\
-    \xf8\xe1\x1e\x01\xa8\xe2\x1e\x01\xc0\xe2\x1e\x01\xb8\xe2\x1e\x01J\xfe\
-    \x93\x01\n\x07\x12\x05\x07\0\xd0\x03\x01\nz\n\x01\x0c\x12\x03\x07\0\x122\
-    p\n\x20Copyright\x20(c)\x202017\x20Intel\x20Corporation\n\x20Copyright\
-    \x20(c)\x202019\x20Ant\x20Financial\n\n\x20SPDX-License-Identifier:\x20A\
-    pache-2.0\n\n\n\x08\n\x01\x08\x12\x03\t\0u\n\t\n\x02\x08\x0b\x12\x03\t\0\
-    u\n\x08\n\x01\x02\x12\x03\x0b\0\r\n\t\n\x02\x03\0\x12\x03\r\07\n\t\n\x02\
-    \x03\x01\x12\x03\x0e\0(\n\x08\n\x01\x08\x12\x03\x10\0$\n\x0b\n\x04\x08\
-    \xa5\xec\x03\x12\x03\x10\0$\n\x08\n\x01\x08\x12\x03\x11\0'\n\x0b\n\x04\
-    \x08\x9f\xec\x03\x12\x03\x11\0'\n\x08\n\x01\x08\x12\x03\x12\0&\n\x0b\n\
-    \x04\x08\xa7\xec\x03\x12\x03\x12\0&\n\x08\n\x01\x08\x12\x03\x13\0'\n\x0b\
-    \n\x04\x08\xa8\xec\x03\x12\x03\x13\0'\n\n\n\x02\x04\0\x12\x04\x15\02\x01\
-    \n\n\n\x03\x04\0\x01\x12\x03\x15\x08\x0c\nm\n\x04\x04\0\x02\0\x12\x03\
-    \x17\x08\x1b\x1a`\x20Version\x20of\x20the\x20Open\x20Container\x20Initia\
-    tive\x20Runtime\x20Specification\x20with\x20which\x20the\x20bundle\x20co\
-    mplies.\n\n\r\n\x05\x04\0\x02\0\x04\x12\x04\x17\x08\x15\x0e\n\x0c\n\x05\
-    \x04\0\x02\0\x05\x12\x03\x17\x08\x0e\n\x0c\n\x05\x04\0\x02\0\x01\x12\x03\
-    \x17\x0f\x16\n\x0c\n\x05\x04\0\x02\0\x03\x12\x03\x17\x19\x1a\n8\n\x04\
-    \x04\0\x02\x01\x12\x03\x1a\x08\x1c\x1a+\x20Process\x20configures\x20the\
-    \x20container\x20process.\n\n\r\n\x05\x04\0\x02\x01\x04\x12\x04\x1a\x08\
-    \x17\x1b\n\x0c\n\x05\x04\0\x02\x01\x06\x12\x03\x1a\x08\x0f\n\x0c\n\x05\
-    \x04\0\x02\x01\x01\x12\x03\x1a\x10\x17\n\x0c\n\x05\x04\0\x02\x01\x03\x12\
-    \x03\x1a\x1a\x1b\n?\n\x04\x04\0\x02\x02\x12\x03\x1d\x08\x16\x1a2\x20Root\
-    \x20configures\x20the\x20container's\x20root\x20filesystem.\n\n\r\n\x05\
-    \x04\0\x02\x02\x04\x12\x04\x1d\x08\x1a\x1c\n\x0c\n\x05\x04\0\x02\x02\x06\
-    \x12\x03\x1d\x08\x0c\n\x0c\n\x05\x04\0\x02\x02\x01\x12\x03\x1d\r\x11\n\
-    \x0c\n\x05\x04\0\x02\x02\x03\x12\x03\x1d\x14\x15\n<\n\x04\x04\0\x02\x03\
-    \x12\x03\x20\x08\x1c\x1a/\x20Hostname\x20configures\x20the\x20container'\
-    s\x20hostname.\n\n\r\n\x05\x04\0\x02\x03\x04\x12\x04\x20\x08\x1d\x16\n\
-    \x0c\n\x05\x04\0\x02\x03\x05\x12\x03\x20\x08\x0e\n\x0c\n\x05\x04\0\x02\
-    \x03\x01\x12\x03\x20\x0f\x17\n\x0c\n\x05\x04\0\x02\x03\x03\x12\x03\x20\
-    \x1a\x1b\nD\n\x04\x04\0\x02\x04\x12\x03#\x08A\x1a7\x20Mounts\x20configur\
-    es\x20additional\x20mounts\x20(on\x20top\x20of\x20Root).\n\n\x0c\n\x05\
-    \x04\0\x02\x04\x04\x12\x03#\x08\x10\n\x0c\n\x05\x04\0\x02\x04\x06\x12\
-    \x03#\x11\x16\n\x0c\n\x05\x04\0\x02\x04\x01\x12\x03#\x17\x1d\n\x0c\n\x05\
-    \x04\0\x02\x04\x03\x12\x03#\x20!\n\x0c\n\x05\x04\0\x02\x04\x08\x12\x03#\
-    \"@\n\x0f\n\x08\x04\0\x02\x04\x08\xe9\xfb\x03\x12\x03##?\nI\n\x04\x04\0\
-    \x02\x05\x12\x03&\x08\x18\x1a<\x20Hooks\x20configures\x20callbacks\x20fo\
-    r\x20container\x20lifecycle\x20events.\n\n\r\n\x05\x04\0\x02\x05\x04\x12\
-    \x04&\x08#A\n\x0c\n\x05\x04\0\x02\x05\x06\x12\x03&\x08\r\n\x0c\n\x05\x04\
-    \0\x02\x05\x01\x12\x03&\x0e\x13\n\x0c\n\x05\x04\0\x02\x05\x03\x12\x03&\
-    \x16\x17\nI\n\x04\x04\0\x02\x06\x12\x03)\x08,\x1a<\x20Annotations\x20con\
-    tains\x20arbitrary\x20metadata\x20for\x20the\x20container.\n\n\r\n\x05\
-    \x04\0\x02\x06\x04\x12\x04)\x08&\x18\n\x0c\n\x05\x04\0\x02\x06\x06\x12\
-    \x03)\x08\x1b\n\x0c\n\x05\x04\0\x02\x06\x01\x12\x03)\x1c'\n\x0c\n\x05\
-    \x04\0\x02\x06\x03\x12\x03)*+\nS\n\x04\x04\0\x02\x07\x12\x03,\x08\x18\
-    \x1aF\x20Linux\x20is\x20platform-specific\x20configuration\x20for\x20Lin\
-    ux\x20based\x20containers.\n\n\r\n\x05\x04\0\x02\x07\x04\x12\x04,\x08),\
-    \n\x0c\n\x05\x04\0\x02\x07\x06\x12\x03,\x08\r\n\x0c\n\x05\x04\0\x02\x07\
-    \x01\x12\x03,\x0e\x13\n\x0c\n\x05\x04\0\x02\x07\x03\x12\x03,\x16\x17\nW\
-    \n\x04\x04\0\x02\x08\x12\x03/\x08\x1c\x1aJ\x20Solaris\x20is\x20platform-\
-    specific\x20configuration\x20for\x20Solaris\x20based\x20containers.\n\n\
-    \r\n\x05\x04\0\x02\x08\x04\x12\x04/\x08,\x18\n\x0c\n\x05\x04\0\x02\x08\
-    \x06\x12\x03/\x08\x0f\n\x0c\n\x05\x04\0\x02\x08\x01\x12\x03/\x10\x17\n\
-    \x0c\n\x05\x04\0\x02\x08\x03\x12\x03/\x1a\x1b\nW\n\x04\x04\0\x02\t\x12\
-    \x031\x08\x1d\x1aJ\x20Windows\x20is\x20platform-specific\x20configuratio\
-    n\x20for\x20Windows\x20based\x20containers.\n\n\r\n\x05\x04\0\x02\t\x04\
-    \x12\x041\x08/\x1c\n\x0c\n\x05\x04\0\x02\t\x06\x12\x031\x08\x0f\n\x0c\n\
-    \x05\x04\0\x02\t\x01\x12\x031\x10\x17\n\x0c\n\x05\x04\0\x02\t\x03\x12\
-    \x031\x1a\x1c\n\n\n\x02\x04\x01\x12\x044\0Y\x01\n\n\n\x03\x04\x01\x01\
-    \x12\x034\x08\x0f\nJ\n\x04\x04\x01\x02\0\x12\x036\x08\x1a\x1a=\x20Termin\
-    al\x20creates\x20an\x20interactive\x20terminal\x20for\x20the\x20containe\
-    r.\n\n\r\n\x05\x04\x01\x02\0\x04\x12\x046\x084\x11\n\x0c\n\x05\x04\x01\
-    \x02\0\x05\x12\x036\x08\x0c\n\x0c\n\x05\x04\x01\x02\0\x01\x12\x036\r\x15\
-    \n\x0c\n\x05\x04\x01\x02\0\x03\x12\x036\x18\x19\n=\n\x04\x04\x01\x02\x01\
-    \x12\x039\x08\x1c\x1a0\x20ConsoleSize\x20specifies\x20the\x20size\x20of\
-    \x20the\x20console.\n\n\r\n\x05\x04\x01\x02\x01\x04\x12\x049\x086\x1a\n\
-    \x0c\n\x05\x04\x01\x02\x01\x06\x12\x039\x08\x0b\n\x0c\n\x05\x04\x01\x02\
-    \x01\x01\x12\x039\x0c\x17\n\x0c\n\x05\x04\x01\x02\x01\x03\x12\x039\x1a\
-    \x1b\n?\n\x04\x04\x01\x02\x02\x12\x03<\x085\x1a2\x20User\x20specifies\
-    \x20user\x20information\x20for\x20the\x20process.\n\n\r\n\x05\x04\x01\
-    \x02\x02\x04\x12\x04<\x089\x1c\n\x0c\n\x05\x04\x01\x02\x02\x06\x12\x03<\
-    \x08\x0c\n\x0c\n\x05\x04\x01\x02\x02\x01\x12\x03<\r\x11\n\x0c\n\x05\x04\
-    \x01\x02\x02\x03\x12\x03<\x14\x15\n\x0c\n\x05\x04\x01\x02\x02\x08\x12\
-    \x03<\x164\n\x0f\n\x08\x04\x01\x02\x02\x08\xe9\xfb\x03\x12\x03<\x173\nV\
-    \n\x04\x04\x01\x02\x03\x12\x03?\x08!\x1aI\x20Args\x20specifies\x20the\
-    \x20binary\x20and\x20arguments\x20for\x20the\x20application\x20to\x20exe\
-    cute.\n\n\x0c\n\x05\x04\x01\x02\x03\x04\x12\x03?\x08\x10\n\x0c\n\x05\x04\
-    \x01\x02\x03\x05\x12\x03?\x11\x17\n\x0c\n\x05\x04\x01\x02\x03\x01\x12\
-    \x03?\x18\x1c\n\x0c\n\x05\x04\x01\x02\x03\x03\x12\x03?\x1f\x20\nE\n\x04\
-    \x04\x01\x02\x04\x12\x03B\x08\x20\x1a8\x20Env\x20populates\x20the\x20pro\
-    cess\x20environment\x20for\x20the\x20process.\n\n\x0c\n\x05\x04\x01\x02\
-    \x04\x04\x12\x03B\x08\x10\n\x0c\n\x05\x04\x01\x02\x04\x05\x12\x03B\x11\
-    \x17\n\x0c\n\x05\x04\x01\x02\x04\x01\x12\x03B\x18\x1b\n\x0c\n\x05\x04\
-    \x01\x02\x04\x03\x12\x03B\x1e\x1f\nr\n\x04\x04\x01\x02\x05\x12\x03F\x08\
-    \x17\x1ae\x20Cwd\x20is\x20the\x20current\x20working\x20directory\x20for\
-    \x20the\x20process\x20and\x20must\x20be\n\x20relative\x20to\x20the\x20co\
-    ntainer's\x20root.\n\n\r\n\x05\x04\x01\x02\x05\x04\x12\x04F\x08B\x20\n\
-    \x0c\n\x05\x04\x01\x02\x05\x05\x12\x03F\x08\x0e\n\x0c\n\x05\x04\x01\x02\
-    \x05\x01\x12\x03F\x0f\x12\n\x0c\n\x05\x04\x01\x02\x05\x03\x12\x03F\x15\
-    \x16\nQ\n\x04\x04\x01\x02\x06\x12\x03I\x08+\x1aD\x20Capabilities\x20are\
-    \x20Linux\x20capabilities\x20that\x20are\x20kept\x20for\x20the\x20proces\
-    s.\n\n\r\n\x05\x04\x01\x02\x06\x04\x12\x04I\x08F\x17\n\x0c\n\x05\x04\x01\
-    \x02\x06\x06\x12\x03I\x08\x19\n\x0c\n\x05\x04\x01\x02\x06\x01\x12\x03I\
-    \x1a&\n\x0c\n\x05\x04\x01\x02\x06\x03\x12\x03I)*\nH\n\x04\x04\x01\x02\
-    \x07\x12\x03L\x08H\x1a;\x20Rlimits\x20specifies\x20rlimit\x20options\x20\
-    to\x20apply\x20to\x20the\x20process.\n\n\x0c\n\x05\x04\x01\x02\x07\x04\
-    \x12\x03L\x08\x10\n\x0c\n\x05\x04\x01\x02\x07\x06\x12\x03L\x11\x1c\n\x0c\
-    \n\x05\x04\x01\x02\x07\x01\x12\x03L\x1d$\n\x0c\n\x05\x04\x01\x02\x07\x03\
-    \x12\x03L'(\n\x0c\n\x05\x04\x01\x02\x07\x08\x12\x03L)G\n\x0f\n\x08\x04\
-    \x01\x02\x07\x08\xe9\xfb\x03\x12\x03L*F\nu\n\x04\x04\x01\x02\x08\x12\x03\
-    O\x08!\x1ah\x20NoNewPrivileges\x20controls\x20whether\x20additional\x20p\
-    rivileges\x20could\x20be\x20gained\x20by\x20processes\x20in\x20the\x20co\
-    ntainer.\t\n\n\r\n\x05\x04\x01\x02\x08\x04\x12\x04O\x08LH\n\x0c\n\x05\
-    \x04\x01\x02\x08\x05\x12\x03O\x08\x0c\n\x0c\n\x05\x04\x01\x02\x08\x01\
-    \x12\x03O\r\x1c\n\x0c\n\x05\x04\x01\x02\x08\x03\x12\x03O\x1f\x20\nP\n\
-    \x04\x04\x01\x02\t\x12\x03R\x08$\x1aC\x20ApparmorProfile\x20specifies\
-    \x20the\x20apparmor\x20profile\x20for\x20the\x20container.\n\n\r\n\x05\
-    \x04\x01\x02\t\x04\x12\x04R\x08O!\n\x0c\n\x05\x04\x01\x02\t\x05\x12\x03R\
-    \x08\x0e\n\x0c\n\x05\x04\x01\x02\t\x01\x12\x03R\x0f\x1e\n\x0c\n\x05\x04\
-    \x01\x02\t\x03\x12\x03R!#\n:\n\x04\x04\x01\x02\n\x12\x03U\x08\x1f\x1a-\
-    \x20Specify\x20an\x20oom_score_adj\x20for\x20the\x20container.\n\n\r\n\
-    \x05\x04\x01\x02\n\x04\x12\x04U\x08R$\n\x0c\n\x05\x04\x01\x02\n\x05\x12\
-    \x03U\x08\r\n\x0c\n\x05\x04\x01\x02\n\x01\x12\x03U\x0e\x19\n\x0c\n\x05\
-    \x04\x01\x02\n\x03\x12\x03U\x1c\x1e\n_\n\x04\x04\x01\x02\x0b\x12\x03X\
-    \x08!\x1aR\x20SelinuxLabel\x20specifies\x20the\x20selinux\x20context\x20\
-    that\x20the\x20container\x20process\x20is\x20run\x20as.\n\n\r\n\x05\x04\
-    \x01\x02\x0b\x04\x12\x04X\x08U\x1f\n\x0c\n\x05\x04\x01\x02\x0b\x05\x12\
-    \x03X\x08\x0e\n\x0c\n\x05\x04\x01\x02\x0b\x01\x12\x03X\x0f\x1b\n\x0c\n\
-    \x05\x04\x01\x02\x0b\x03\x12\x03X\x1e\x20\n\n\n\x02\x04\x02\x12\x04[\0a\
-    \x01\n\n\n\x03\x04\x02\x01\x12\x03[\x08\x0b\n9\n\x04\x04\x02\x02\0\x12\
-    \x03]\x08\x1a\x1a,\x20Height\x20is\x20the\x20vertical\x20dimension\x20of\
-    \x20a\x20box.\n\n\r\n\x05\x04\x02\x02\0\x04\x12\x04]\x08[\r\n\x0c\n\x05\
-    \x04\x02\x02\0\x05\x12\x03]\x08\x0e\n\x0c\n\x05\x04\x02\x02\0\x01\x12\
-    \x03]\x0f\x15\n\x0c\n\x05\x04\x02\x02\0\x03\x12\x03]\x18\x19\n;\n\x04\
-    \x04\x02\x02\x01\x12\x03`\x08\x19\x1a.\x20Width\x20is\x20the\x20horizont\
-    al\x20dimension\x20of\x20a\x20box.\t\n\n\r\n\x05\x04\x02\x02\x01\x04\x12\
-    \x04`\x08]\x1a\n\x0c\n\x05\x04\x02\x02\x01\x05\x12\x03`\x08\x0e\n\x0c\n\
-    \x05\x04\x02\x02\x01\x01\x12\x03`\x0f\x14\n\x0c\n\x05\x04\x02\x02\x01\
-    \x03\x12\x03`\x17\x18\n\n\n\x02\x04\x03\x12\x04c\0o\x01\n\n\n\x03\x04\
-    \x03\x01\x12\x03c\x08\x0c\n\"\n\x04\x04\x03\x02\0\x12\x03e\x08\x17\x1a\
-    \x15\x20UID\x20is\x20the\x20user\x20id.\n\n\r\n\x05\x04\x03\x02\0\x04\
-    \x12\x04e\x08c\x0e\n\x0c\n\x05\x04\x03\x02\0\x05\x12\x03e\x08\x0e\n\x0c\
-    \n\x05\x04\x03\x02\0\x01\x12\x03e\x0f\x12\n\x0c\n\x05\x04\x03\x02\0\x03\
-    \x12\x03e\x15\x16\n#\n\x04\x04\x03\x02\x01\x12\x03h\x08\x17\x1a\x16\x20G\
-    ID\x20is\x20the\x20group\x20id.\n\n\r\n\x05\x04\x03\x02\x01\x04\x12\x04h\
-    \x08e\x17\n\x0c\n\x05\x04\x03\x02\x01\x05\x12\x03h\x08\x0e\n\x0c\n\x05\
-    \x04\x03\x02\x01\x01\x12\x03h\x0f\x12\n\x0c\n\x05\x04\x03\x02\x01\x03\
-    \x12\x03h\x15\x16\nW\n\x04\x04\x03\x02\x02\x12\x03k\x08+\x1aJ\x20Additio\
-    nalGids\x20are\x20additional\x20group\x20ids\x20set\x20for\x20the\x20con\
-    tainer's\x20process.\n\n\x0c\n\x05\x04\x03\x02\x02\x04\x12\x03k\x08\x10\
-    \n\x0c\n\x05\x04\x03\x02\x02\x05\x12\x03k\x11\x17\n\x0c\n\x05\x04\x03\
-    \x02\x02\x01\x12\x03k\x18&\n\x0c\n\x05\x04\x03\x02\x02\x03\x12\x03k)*\n)\
-    \n\x04\x04\x03\x02\x03\x12\x03n\x08\x1c\x1a\x1c\x20Username\x20is\x20the\
-    \x20user\x20name.\n\n\r\n\x05\x04\x03\x02\x03\x04\x12\x04n\x08k+\n\x0c\n\
-    \x05\x04\x03\x02\x03\x05\x12\x03n\x08\x0e\n\x0c\n\x05\x04\x03\x02\x03\
-    \x01\x12\x03n\x0f\x17\n\x0c\n\x05\x04\x03\x02\x03\x03\x12\x03n\x1a\x1b\n\
-    \x0b\n\x02\x04\x04\x12\x05q\0\x80\x01\x01\n\n\n\x03\x04\x04\x01\x12\x03q\
-    \x08\x19\nI\n\x04\x04\x04\x02\0\x12\x03s\x08%\x1a<\x20Bounding\x20is\x20\
-    the\x20set\x20of\x20capabilities\x20checked\x20by\x20the\x20kernel.\n\n\
-    \x0c\n\x05\x04\x04\x02\0\x04\x12\x03s\x08\x10\n\x0c\n\x05\x04\x04\x02\0\
-    \x05\x12\x03s\x11\x17\n\x0c\n\x05\x04\x04\x02\0\x01\x12\x03s\x18\x20\n\
-    \x0c\n\x05\x04\x04\x02\0\x03\x12\x03s#$\nJ\n\x04\x04\x04\x02\x01\x12\x03\
-    v\x08&\x1a=\x20Effective\x20is\x20the\x20set\x20of\x20capabilities\x20ch\
-    ecked\x20by\x20the\x20kernel.\n\n\x0c\n\x05\x04\x04\x02\x01\x04\x12\x03v\
-    \x08\x10\n\x0c\n\x05\x04\x04\x02\x01\x05\x12\x03v\x11\x17\n\x0c\n\x05\
-    \x04\x04\x02\x01\x01\x12\x03v\x18!\n\x0c\n\x05\x04\x04\x02\x01\x03\x12\
-    \x03v$%\nG\n\x04\x04\x04\x02\x02\x12\x03y\x08(\x1a:\x20Inheritable\x20is\
-    \x20the\x20capabilities\x20preserved\x20across\x20execve.\n\n\x0c\n\x05\
-    \x04\x04\x02\x02\x04\x12\x03y\x08\x10\n\x0c\n\x05\x04\x04\x02\x02\x05\
-    \x12\x03y\x11\x17\n\x0c\n\x05\x04\x04\x02\x02\x01\x12\x03y\x18#\n\x0c\n\
-    \x05\x04\x04\x02\x02\x03\x12\x03y&'\nM\n\x04\x04\x04\x02\x03\x12\x03|\
-    \x08&\x1a@\x20Permitted\x20is\x20the\x20limiting\x20superset\x20for\x20e\
-    ffective\x20capabilities.\n\n\x0c\n\x05\x04\x04\x02\x03\x04\x12\x03|\x08\
-    \x10\n\x0c\n\x05\x04\x04\x02\x03\x05\x12\x03|\x11\x17\n\x0c\n\x05\x04\
-    \x04\x02\x03\x01\x12\x03|\x18!\n\x0c\n\x05\x04\x04\x02\x03\x03\x12\x03|$\
-    %\nH\n\x04\x04\x04\x02\x04\x12\x03\x7f\x08$\x1a;\x20Ambient\x20is\x20the\
-    \x20ambient\x20set\x20of\x20capabilities\x20that\x20are\x20kept.\n\n\x0c\
-    \n\x05\x04\x04\x02\x04\x04\x12\x03\x7f\x08\x10\n\x0c\n\x05\x04\x04\x02\
-    \x04\x05\x12\x03\x7f\x11\x17\n\x0c\n\x05\x04\x04\x02\x04\x01\x12\x03\x7f\
-    \x18\x1f\n\x0c\n\x05\x04\x04\x02\x04\x03\x12\x03\x7f\"#\n\x0c\n\x02\x04\
-    \x05\x12\x06\x82\x01\0\x8b\x01\x01\n\x0b\n\x03\x04\x05\x01\x12\x04\x82\
-    \x01\x08\x13\n)\n\x04\x04\x05\x02\0\x12\x04\x84\x01\x08\x18\x1a\x1b\x20T\
-    ype\x20of\x20the\x20rlimit\x20to\x20set\n\n\x0f\n\x05\x04\x05\x02\0\x04\
-    \x12\x06\x84\x01\x08\x82\x01\x15\n\r\n\x05\x04\x05\x02\0\x05\x12\x04\x84\
-    \x01\x08\x0e\n\r\n\x05\x04\x05\x02\0\x01\x12\x04\x84\x01\x0f\x13\n\r\n\
-    \x05\x04\x05\x02\0\x03\x12\x04\x84\x01\x16\x17\n=\n\x04\x04\x05\x02\x01\
-    \x12\x04\x87\x01\x08\x18\x1a/\x20Hard\x20is\x20the\x20hard\x20limit\x20f\
-    or\x20the\x20specified\x20type\n\n\x0f\n\x05\x04\x05\x02\x01\x04\x12\x06\
-    \x87\x01\x08\x84\x01\x18\n\r\n\x05\x04\x05\x02\x01\x05\x12\x04\x87\x01\
-    \x08\x0e\n\r\n\x05\x04\x05\x02\x01\x01\x12\x04\x87\x01\x0f\x13\n\r\n\x05\
-    \x04\x05\x02\x01\x03\x12\x04\x87\x01\x16\x17\n=\n\x04\x04\x05\x02\x02\
-    \x12\x04\x8a\x01\x08\x18\x1a/\x20Soft\x20is\x20the\x20soft\x20limit\x20f\
-    or\x20the\x20specified\x20type\n\n\x0f\n\x05\x04\x05\x02\x02\x04\x12\x06\
-    \x8a\x01\x08\x87\x01\x18\n\r\n\x05\x04\x05\x02\x02\x05\x12\x04\x8a\x01\
-    \x08\x0e\n\r\n\x05\x04\x05\x02\x02\x01\x12\x04\x8a\x01\x0f\x13\n\r\n\x05\
-    \x04\x05\x02\x02\x03\x12\x04\x8a\x01\x16\x17\n\x0c\n\x02\x04\x06\x12\x06\
-    \x8d\x01\0\x98\x01\x01\n\x0b\n\x03\x04\x06\x01\x12\x04\x8d\x01\x08\r\n_\
-    \n\x04\x04\x06\x02\0\x12\x04\x8f\x01\x08\x1f\x1aQ\x20destination\x20is\
-    \x20the\x20path\x20inside\x20the\x20container\x20expect\x20when\x20it\
-    \x20starts\x20with\x20\"tmp:/\"\n\n\x0f\n\x05\x04\x06\x02\0\x04\x12\x06\
-    \x8f\x01\x08\x8d\x01\x0f\n\r\n\x05\x04\x06\x02\0\x05\x12\x04\x8f\x01\x08\
-    \x0e\n\r\n\x05\x04\x06\x02\0\x01\x12\x04\x8f\x01\x0f\x1a\n\r\n\x05\x04\
-    \x06\x02\0\x03\x12\x04\x8f\x01\x1d\x1e\n\xb4\x02\n\x04\x04\x06\x02\x01\
-    \x12\x04\x95\x01\x08\x1a\x1a\xa5\x02\x20source\x20is\x20the\x20path\x20i\
+    allR\x08SyscallsB\x04\xc8\xde\x1f\0\x12\x14\n\x05Flags\x18\x04\x20\x03(\
+    \tR\x05Flags\"i\n\x0fLinuxSeccompArg\x12\x14\n\x05Index\x18\x01\x20\x01(\
+    \x04R\x05Index\x12\x14\n\x05Value\x18\x02\x20\x01(\x04R\x05Value\x12\x1a\
+    \n\x08ValueTwo\x18\x03\x20\x01(\x04R\x08ValueTwo\x12\x0e\n\x02Op\x18\x04\
+    \x20\x01(\tR\x02Op\"\x89\x01\n\x0cLinuxSyscall\x12\x14\n\x05Names\x18\
+    \x01\x20\x03(\tR\x05Names\x12\x16\n\x06Action\x18\x02\x20\x01(\tR\x06Act\
+    ion\x12/\n\x04Args\x18\x03\x20\x03(\x0b2\x15.grpc.LinuxSeccompArgR\x04Ar\
+    gsB\x04\xc8\xde\x1f\0\x12\x1a\n\x08ErrnoRet\x18\x04\x20\x01(\rR\x08Errno\
+    Ret\"5\n\rLinuxIntelRdt\x12$\n\rL3CacheSchema\x18\x01\x20\x01(\tR\rL3Cac\
+    heSchemaBpZ^github.com/kata-containers/kata-containers/src/runtime/virtc\
+    ontainers/pkg/agent/protocols/grpc\xf8\xe1\x1e\x01\xa8\xe2\x1e\x01\xc0\
+    \xe2\x1e\x01\xb8\xe2\x1e\x01J\xfe\x93\x01\n\x07\x12\x05\x07\0\xd0\x03\
+    \x01\nz\n\x01\x0c\x12\x03\x07\0\x122p\n\x20Copyright\x20(c)\x202017\x20I\
+    ntel\x20Corporation\n\x20Copyright\x20(c)\x202019\x20Ant\x20Financial\n\
+    \n\x20SPDX-License-Identifier:\x20Apache-2.0\n\n\n\x08\n\x01\x08\x12\x03\
+    \t\0u\n\t\n\x02\x08\x0b\x12\x03\t\0u\n\x08\n\x01\x02\x12\x03\x0b\0\r\n\t\
+    \n\x02\x03\0\x12\x03\r\07\n\t\n\x02\x03\x01\x12\x03\x0e\0(\n\x08\n\x01\
+    \x08\x12\x03\x10\0$\n\x0b\n\x04\x08\xa5\xec\x03\x12\x03\x10\0$\n\x08\n\
+    \x01\x08\x12\x03\x11\0'\n\x0b\n\x04\x08\x9f\xec\x03\x12\x03\x11\0'\n\x08\
+    \n\x01\x08\x12\x03\x12\0&\n\x0b\n\x04\x08\xa7\xec\x03\x12\x03\x12\0&\n\
+    \x08\n\x01\x08\x12\x03\x13\0'\n\x0b\n\x04\x08\xa8\xec\x03\x12\x03\x13\0'\
+    \n\n\n\x02\x04\0\x12\x04\x15\02\x01\n\n\n\x03\x04\0\x01\x12\x03\x15\x08\
+    \x0c\nm\n\x04\x04\0\x02\0\x12\x03\x17\x08\x1b\x1a`\x20Version\x20of\x20t\
+    he\x20Open\x20Container\x20Initiative\x20Runtime\x20Specification\x20wit\
+    h\x20which\x20the\x20bundle\x20complies.\n\n\r\n\x05\x04\0\x02\0\x04\x12\
+    \x04\x17\x08\x15\x0e\n\x0c\n\x05\x04\0\x02\0\x05\x12\x03\x17\x08\x0e\n\
+    \x0c\n\x05\x04\0\x02\0\x01\x12\x03\x17\x0f\x16\n\x0c\n\x05\x04\0\x02\0\
+    \x03\x12\x03\x17\x19\x1a\n8\n\x04\x04\0\x02\x01\x12\x03\x1a\x08\x1c\x1a+\
+    \x20Process\x20configures\x20the\x20container\x20process.\n\n\r\n\x05\
+    \x04\0\x02\x01\x04\x12\x04\x1a\x08\x17\x1b\n\x0c\n\x05\x04\0\x02\x01\x06\
+    \x12\x03\x1a\x08\x0f\n\x0c\n\x05\x04\0\x02\x01\x01\x12\x03\x1a\x10\x17\n\
+    \x0c\n\x05\x04\0\x02\x01\x03\x12\x03\x1a\x1a\x1b\n?\n\x04\x04\0\x02\x02\
+    \x12\x03\x1d\x08\x16\x1a2\x20Root\x20configures\x20the\x20container's\
+    \x20root\x20filesystem.\n\n\r\n\x05\x04\0\x02\x02\x04\x12\x04\x1d\x08\
+    \x1a\x1c\n\x0c\n\x05\x04\0\x02\x02\x06\x12\x03\x1d\x08\x0c\n\x0c\n\x05\
+    \x04\0\x02\x02\x01\x12\x03\x1d\r\x11\n\x0c\n\x05\x04\0\x02\x02\x03\x12\
+    \x03\x1d\x14\x15\n<\n\x04\x04\0\x02\x03\x12\x03\x20\x08\x1c\x1a/\x20Host\
+    name\x20configures\x20the\x20container's\x20hostname.\n\n\r\n\x05\x04\0\
+    \x02\x03\x04\x12\x04\x20\x08\x1d\x16\n\x0c\n\x05\x04\0\x02\x03\x05\x12\
+    \x03\x20\x08\x0e\n\x0c\n\x05\x04\0\x02\x03\x01\x12\x03\x20\x0f\x17\n\x0c\
+    \n\x05\x04\0\x02\x03\x03\x12\x03\x20\x1a\x1b\nD\n\x04\x04\0\x02\x04\x12\
+    \x03#\x08A\x1a7\x20Mounts\x20configures\x20additional\x20mounts\x20(on\
+    \x20top\x20of\x20Root).\n\n\x0c\n\x05\x04\0\x02\x04\x04\x12\x03#\x08\x10\
+    \n\x0c\n\x05\x04\0\x02\x04\x06\x12\x03#\x11\x16\n\x0c\n\x05\x04\0\x02\
+    \x04\x01\x12\x03#\x17\x1d\n\x0c\n\x05\x04\0\x02\x04\x03\x12\x03#\x20!\n\
+    \x0c\n\x05\x04\0\x02\x04\x08\x12\x03#\"@\n\x0f\n\x08\x04\0\x02\x04\x08\
+    \xe9\xfb\x03\x12\x03##?\nI\n\x04\x04\0\x02\x05\x12\x03&\x08\x18\x1a<\x20\
+    Hooks\x20configures\x20callbacks\x20for\x20container\x20lifecycle\x20eve\
+    nts.\n\n\r\n\x05\x04\0\x02\x05\x04\x12\x04&\x08#A\n\x0c\n\x05\x04\0\x02\
+    \x05\x06\x12\x03&\x08\r\n\x0c\n\x05\x04\0\x02\x05\x01\x12\x03&\x0e\x13\n\
+    \x0c\n\x05\x04\0\x02\x05\x03\x12\x03&\x16\x17\nI\n\x04\x04\0\x02\x06\x12\
+    \x03)\x08,\x1a<\x20Annotations\x20contains\x20arbitrary\x20metadata\x20f\
+    or\x20the\x20container.\n\n\r\n\x05\x04\0\x02\x06\x04\x12\x04)\x08&\x18\
+    \n\x0c\n\x05\x04\0\x02\x06\x06\x12\x03)\x08\x1b\n\x0c\n\x05\x04\0\x02\
+    \x06\x01\x12\x03)\x1c'\n\x0c\n\x05\x04\0\x02\x06\x03\x12\x03)*+\nS\n\x04\
+    \x04\0\x02\x07\x12\x03,\x08\x18\x1aF\x20Linux\x20is\x20platform-specific\
+    \x20configuration\x20for\x20Linux\x20based\x20containers.\n\n\r\n\x05\
+    \x04\0\x02\x07\x04\x12\x04,\x08),\n\x0c\n\x05\x04\0\x02\x07\x06\x12\x03,\
+    \x08\r\n\x0c\n\x05\x04\0\x02\x07\x01\x12\x03,\x0e\x13\n\x0c\n\x05\x04\0\
+    \x02\x07\x03\x12\x03,\x16\x17\nW\n\x04\x04\0\x02\x08\x12\x03/\x08\x1c\
+    \x1aJ\x20Solaris\x20is\x20platform-specific\x20configuration\x20for\x20S\
+    olaris\x20based\x20containers.\n\n\r\n\x05\x04\0\x02\x08\x04\x12\x04/\
+    \x08,\x18\n\x0c\n\x05\x04\0\x02\x08\x06\x12\x03/\x08\x0f\n\x0c\n\x05\x04\
+    \0\x02\x08\x01\x12\x03/\x10\x17\n\x0c\n\x05\x04\0\x02\x08\x03\x12\x03/\
+    \x1a\x1b\nW\n\x04\x04\0\x02\t\x12\x031\x08\x1d\x1aJ\x20Windows\x20is\x20\
+    platform-specific\x20configuration\x20for\x20Windows\x20based\x20contain\
+    ers.\n\n\r\n\x05\x04\0\x02\t\x04\x12\x041\x08/\x1c\n\x0c\n\x05\x04\0\x02\
+    \t\x06\x12\x031\x08\x0f\n\x0c\n\x05\x04\0\x02\t\x01\x12\x031\x10\x17\n\
+    \x0c\n\x05\x04\0\x02\t\x03\x12\x031\x1a\x1c\n\n\n\x02\x04\x01\x12\x044\0\
+    Y\x01\n\n\n\x03\x04\x01\x01\x12\x034\x08\x0f\nJ\n\x04\x04\x01\x02\0\x12\
+    \x036\x08\x1a\x1a=\x20Terminal\x20creates\x20an\x20interactive\x20termin\
+    al\x20for\x20the\x20container.\n\n\r\n\x05\x04\x01\x02\0\x04\x12\x046\
+    \x084\x11\n\x0c\n\x05\x04\x01\x02\0\x05\x12\x036\x08\x0c\n\x0c\n\x05\x04\
+    \x01\x02\0\x01\x12\x036\r\x15\n\x0c\n\x05\x04\x01\x02\0\x03\x12\x036\x18\
+    \x19\n=\n\x04\x04\x01\x02\x01\x12\x039\x08\x1c\x1a0\x20ConsoleSize\x20sp\
+    ecifies\x20the\x20size\x20of\x20the\x20console.\n\n\r\n\x05\x04\x01\x02\
+    \x01\x04\x12\x049\x086\x1a\n\x0c\n\x05\x04\x01\x02\x01\x06\x12\x039\x08\
+    \x0b\n\x0c\n\x05\x04\x01\x02\x01\x01\x12\x039\x0c\x17\n\x0c\n\x05\x04\
+    \x01\x02\x01\x03\x12\x039\x1a\x1b\n?\n\x04\x04\x01\x02\x02\x12\x03<\x085\
+    \x1a2\x20User\x20specifies\x20user\x20information\x20for\x20the\x20proce\
+    ss.\n\n\r\n\x05\x04\x01\x02\x02\x04\x12\x04<\x089\x1c\n\x0c\n\x05\x04\
+    \x01\x02\x02\x06\x12\x03<\x08\x0c\n\x0c\n\x05\x04\x01\x02\x02\x01\x12\
+    \x03<\r\x11\n\x0c\n\x05\x04\x01\x02\x02\x03\x12\x03<\x14\x15\n\x0c\n\x05\
+    \x04\x01\x02\x02\x08\x12\x03<\x164\n\x0f\n\x08\x04\x01\x02\x02\x08\xe9\
+    \xfb\x03\x12\x03<\x173\nV\n\x04\x04\x01\x02\x03\x12\x03?\x08!\x1aI\x20Ar\
+    gs\x20specifies\x20the\x20binary\x20and\x20arguments\x20for\x20the\x20ap\
+    plication\x20to\x20execute.\n\n\x0c\n\x05\x04\x01\x02\x03\x04\x12\x03?\
+    \x08\x10\n\x0c\n\x05\x04\x01\x02\x03\x05\x12\x03?\x11\x17\n\x0c\n\x05\
+    \x04\x01\x02\x03\x01\x12\x03?\x18\x1c\n\x0c\n\x05\x04\x01\x02\x03\x03\
+    \x12\x03?\x1f\x20\nE\n\x04\x04\x01\x02\x04\x12\x03B\x08\x20\x1a8\x20Env\
+    \x20populates\x20the\x20process\x20environment\x20for\x20the\x20process.\
+    \n\n\x0c\n\x05\x04\x01\x02\x04\x04\x12\x03B\x08\x10\n\x0c\n\x05\x04\x01\
+    \x02\x04\x05\x12\x03B\x11\x17\n\x0c\n\x05\x04\x01\x02\x04\x01\x12\x03B\
+    \x18\x1b\n\x0c\n\x05\x04\x01\x02\x04\x03\x12\x03B\x1e\x1f\nr\n\x04\x04\
+    \x01\x02\x05\x12\x03F\x08\x17\x1ae\x20Cwd\x20is\x20the\x20current\x20wor\
+    king\x20directory\x20for\x20the\x20process\x20and\x20must\x20be\n\x20rel\
+    ative\x20to\x20the\x20container's\x20root.\n\n\r\n\x05\x04\x01\x02\x05\
+    \x04\x12\x04F\x08B\x20\n\x0c\n\x05\x04\x01\x02\x05\x05\x12\x03F\x08\x0e\
+    \n\x0c\n\x05\x04\x01\x02\x05\x01\x12\x03F\x0f\x12\n\x0c\n\x05\x04\x01\
+    \x02\x05\x03\x12\x03F\x15\x16\nQ\n\x04\x04\x01\x02\x06\x12\x03I\x08+\x1a\
+    D\x20Capabilities\x20are\x20Linux\x20capabilities\x20that\x20are\x20kept\
+    \x20for\x20the\x20process.\n\n\r\n\x05\x04\x01\x02\x06\x04\x12\x04I\x08F\
+    \x17\n\x0c\n\x05\x04\x01\x02\x06\x06\x12\x03I\x08\x19\n\x0c\n\x05\x04\
+    \x01\x02\x06\x01\x12\x03I\x1a&\n\x0c\n\x05\x04\x01\x02\x06\x03\x12\x03I)\
+    *\nH\n\x04\x04\x01\x02\x07\x12\x03L\x08H\x1a;\x20Rlimits\x20specifies\
+    \x20rlimit\x20options\x20to\x20apply\x20to\x20the\x20process.\n\n\x0c\n\
+    \x05\x04\x01\x02\x07\x04\x12\x03L\x08\x10\n\x0c\n\x05\x04\x01\x02\x07\
+    \x06\x12\x03L\x11\x1c\n\x0c\n\x05\x04\x01\x02\x07\x01\x12\x03L\x1d$\n\
+    \x0c\n\x05\x04\x01\x02\x07\x03\x12\x03L'(\n\x0c\n\x05\x04\x01\x02\x07\
+    \x08\x12\x03L)G\n\x0f\n\x08\x04\x01\x02\x07\x08\xe9\xfb\x03\x12\x03L*F\n\
+    u\n\x04\x04\x01\x02\x08\x12\x03O\x08!\x1ah\x20NoNewPrivileges\x20control\
+    s\x20whether\x20additional\x20privileges\x20could\x20be\x20gained\x20by\
+    \x20processes\x20in\x20the\x20container.\t\n\n\r\n\x05\x04\x01\x02\x08\
+    \x04\x12\x04O\x08LH\n\x0c\n\x05\x04\x01\x02\x08\x05\x12\x03O\x08\x0c\n\
+    \x0c\n\x05\x04\x01\x02\x08\x01\x12\x03O\r\x1c\n\x0c\n\x05\x04\x01\x02\
+    \x08\x03\x12\x03O\x1f\x20\nP\n\x04\x04\x01\x02\t\x12\x03R\x08$\x1aC\x20A\
+    pparmorProfile\x20specifies\x20the\x20apparmor\x20profile\x20for\x20the\
+    \x20container.\n\n\r\n\x05\x04\x01\x02\t\x04\x12\x04R\x08O!\n\x0c\n\x05\
+    \x04\x01\x02\t\x05\x12\x03R\x08\x0e\n\x0c\n\x05\x04\x01\x02\t\x01\x12\
+    \x03R\x0f\x1e\n\x0c\n\x05\x04\x01\x02\t\x03\x12\x03R!#\n:\n\x04\x04\x01\
+    \x02\n\x12\x03U\x08\x1f\x1a-\x20Specify\x20an\x20oom_score_adj\x20for\
+    \x20the\x20container.\n\n\r\n\x05\x04\x01\x02\n\x04\x12\x04U\x08R$\n\x0c\
+    \n\x05\x04\x01\x02\n\x05\x12\x03U\x08\r\n\x0c\n\x05\x04\x01\x02\n\x01\
+    \x12\x03U\x0e\x19\n\x0c\n\x05\x04\x01\x02\n\x03\x12\x03U\x1c\x1e\n_\n\
+    \x04\x04\x01\x02\x0b\x12\x03X\x08!\x1aR\x20SelinuxLabel\x20specifies\x20\
+    the\x20selinux\x20context\x20that\x20the\x20container\x20process\x20is\
+    \x20run\x20as.\n\n\r\n\x05\x04\x01\x02\x0b\x04\x12\x04X\x08U\x1f\n\x0c\n\
+    \x05\x04\x01\x02\x0b\x05\x12\x03X\x08\x0e\n\x0c\n\x05\x04\x01\x02\x0b\
+    \x01\x12\x03X\x0f\x1b\n\x0c\n\x05\x04\x01\x02\x0b\x03\x12\x03X\x1e\x20\n\
+    \n\n\x02\x04\x02\x12\x04[\0a\x01\n\n\n\x03\x04\x02\x01\x12\x03[\x08\x0b\
+    \n9\n\x04\x04\x02\x02\0\x12\x03]\x08\x1a\x1a,\x20Height\x20is\x20the\x20\
+    vertical\x20dimension\x20of\x20a\x20box.\n\n\r\n\x05\x04\x02\x02\0\x04\
+    \x12\x04]\x08[\r\n\x0c\n\x05\x04\x02\x02\0\x05\x12\x03]\x08\x0e\n\x0c\n\
+    \x05\x04\x02\x02\0\x01\x12\x03]\x0f\x15\n\x0c\n\x05\x04\x02\x02\0\x03\
+    \x12\x03]\x18\x19\n;\n\x04\x04\x02\x02\x01\x12\x03`\x08\x19\x1a.\x20Widt\
+    h\x20is\x20the\x20horizontal\x20dimension\x20of\x20a\x20box.\t\n\n\r\n\
+    \x05\x04\x02\x02\x01\x04\x12\x04`\x08]\x1a\n\x0c\n\x05\x04\x02\x02\x01\
+    \x05\x12\x03`\x08\x0e\n\x0c\n\x05\x04\x02\x02\x01\x01\x12\x03`\x0f\x14\n\
+    \x0c\n\x05\x04\x02\x02\x01\x03\x12\x03`\x17\x18\n\n\n\x02\x04\x03\x12\
+    \x04c\0o\x01\n\n\n\x03\x04\x03\x01\x12\x03c\x08\x0c\n\"\n\x04\x04\x03\
+    \x02\0\x12\x03e\x08\x17\x1a\x15\x20UID\x20is\x20the\x20user\x20id.\n\n\r\
+    \n\x05\x04\x03\x02\0\x04\x12\x04e\x08c\x0e\n\x0c\n\x05\x04\x03\x02\0\x05\
+    \x12\x03e\x08\x0e\n\x0c\n\x05\x04\x03\x02\0\x01\x12\x03e\x0f\x12\n\x0c\n\
+    \x05\x04\x03\x02\0\x03\x12\x03e\x15\x16\n#\n\x04\x04\x03\x02\x01\x12\x03\
+    h\x08\x17\x1a\x16\x20GID\x20is\x20the\x20group\x20id.\n\n\r\n\x05\x04\
+    \x03\x02\x01\x04\x12\x04h\x08e\x17\n\x0c\n\x05\x04\x03\x02\x01\x05\x12\
+    \x03h\x08\x0e\n\x0c\n\x05\x04\x03\x02\x01\x01\x12\x03h\x0f\x12\n\x0c\n\
+    \x05\x04\x03\x02\x01\x03\x12\x03h\x15\x16\nW\n\x04\x04\x03\x02\x02\x12\
+    \x03k\x08+\x1aJ\x20AdditionalGids\x20are\x20additional\x20group\x20ids\
+    \x20set\x20for\x20the\x20container's\x20process.\n\n\x0c\n\x05\x04\x03\
+    \x02\x02\x04\x12\x03k\x08\x10\n\x0c\n\x05\x04\x03\x02\x02\x05\x12\x03k\
+    \x11\x17\n\x0c\n\x05\x04\x03\x02\x02\x01\x12\x03k\x18&\n\x0c\n\x05\x04\
+    \x03\x02\x02\x03\x12\x03k)*\n)\n\x04\x04\x03\x02\x03\x12\x03n\x08\x1c\
+    \x1a\x1c\x20Username\x20is\x20the\x20user\x20name.\n\n\r\n\x05\x04\x03\
+    \x02\x03\x04\x12\x04n\x08k+\n\x0c\n\x05\x04\x03\x02\x03\x05\x12\x03n\x08\
+    \x0e\n\x0c\n\x05\x04\x03\x02\x03\x01\x12\x03n\x0f\x17\n\x0c\n\x05\x04\
+    \x03\x02\x03\x03\x12\x03n\x1a\x1b\n\x0b\n\x02\x04\x04\x12\x05q\0\x80\x01\
+    \x01\n\n\n\x03\x04\x04\x01\x12\x03q\x08\x19\nI\n\x04\x04\x04\x02\0\x12\
+    \x03s\x08%\x1a<\x20Bounding\x20is\x20the\x20set\x20of\x20capabilities\
+    \x20checked\x20by\x20the\x20kernel.\n\n\x0c\n\x05\x04\x04\x02\0\x04\x12\
+    \x03s\x08\x10\n\x0c\n\x05\x04\x04\x02\0\x05\x12\x03s\x11\x17\n\x0c\n\x05\
+    \x04\x04\x02\0\x01\x12\x03s\x18\x20\n\x0c\n\x05\x04\x04\x02\0\x03\x12\
+    \x03s#$\nJ\n\x04\x04\x04\x02\x01\x12\x03v\x08&\x1a=\x20Effective\x20is\
+    \x20the\x20set\x20of\x20capabilities\x20checked\x20by\x20the\x20kernel.\
+    \n\n\x0c\n\x05\x04\x04\x02\x01\x04\x12\x03v\x08\x10\n\x0c\n\x05\x04\x04\
+    \x02\x01\x05\x12\x03v\x11\x17\n\x0c\n\x05\x04\x04\x02\x01\x01\x12\x03v\
+    \x18!\n\x0c\n\x05\x04\x04\x02\x01\x03\x12\x03v$%\nG\n\x04\x04\x04\x02\
+    \x02\x12\x03y\x08(\x1a:\x20Inheritable\x20is\x20the\x20capabilities\x20p\
+    reserved\x20across\x20execve.\n\n\x0c\n\x05\x04\x04\x02\x02\x04\x12\x03y\
+    \x08\x10\n\x0c\n\x05\x04\x04\x02\x02\x05\x12\x03y\x11\x17\n\x0c\n\x05\
+    \x04\x04\x02\x02\x01\x12\x03y\x18#\n\x0c\n\x05\x04\x04\x02\x02\x03\x12\
+    \x03y&'\nM\n\x04\x04\x04\x02\x03\x12\x03|\x08&\x1a@\x20Permitted\x20is\
+    \x20the\x20limiting\x20superset\x20for\x20effective\x20capabilities.\n\n\
+    \x0c\n\x05\x04\x04\x02\x03\x04\x12\x03|\x08\x10\n\x0c\n\x05\x04\x04\x02\
+    \x03\x05\x12\x03|\x11\x17\n\x0c\n\x05\x04\x04\x02\x03\x01\x12\x03|\x18!\
+    \n\x0c\n\x05\x04\x04\x02\x03\x03\x12\x03|$%\nH\n\x04\x04\x04\x02\x04\x12\
+    \x03\x7f\x08$\x1a;\x20Ambient\x20is\x20the\x20ambient\x20set\x20of\x20ca\
+    pabilities\x20that\x20are\x20kept.\n\n\x0c\n\x05\x04\x04\x02\x04\x04\x12\
+    \x03\x7f\x08\x10\n\x0c\n\x05\x04\x04\x02\x04\x05\x12\x03\x7f\x11\x17\n\
+    \x0c\n\x05\x04\x04\x02\x04\x01\x12\x03\x7f\x18\x1f\n\x0c\n\x05\x04\x04\
+    \x02\x04\x03\x12\x03\x7f\"#\n\x0c\n\x02\x04\x05\x12\x06\x82\x01\0\x8b\
+    \x01\x01\n\x0b\n\x03\x04\x05\x01\x12\x04\x82\x01\x08\x13\n)\n\x04\x04\
+    \x05\x02\0\x12\x04\x84\x01\x08\x18\x1a\x1b\x20Type\x20of\x20the\x20rlimi\
+    t\x20to\x20set\n\n\x0f\n\x05\x04\x05\x02\0\x04\x12\x06\x84\x01\x08\x82\
+    \x01\x15\n\r\n\x05\x04\x05\x02\0\x05\x12\x04\x84\x01\x08\x0e\n\r\n\x05\
+    \x04\x05\x02\0\x01\x12\x04\x84\x01\x0f\x13\n\r\n\x05\x04\x05\x02\0\x03\
+    \x12\x04\x84\x01\x16\x17\n=\n\x04\x04\x05\x02\x01\x12\x04\x87\x01\x08\
+    \x18\x1a/\x20Hard\x20is\x20the\x20hard\x20limit\x20for\x20the\x20specifi\
+    ed\x20type\n\n\x0f\n\x05\x04\x05\x02\x01\x04\x12\x06\x87\x01\x08\x84\x01\
+    \x18\n\r\n\x05\x04\x05\x02\x01\x05\x12\x04\x87\x01\x08\x0e\n\r\n\x05\x04\
+    \x05\x02\x01\x01\x12\x04\x87\x01\x0f\x13\n\r\n\x05\x04\x05\x02\x01\x03\
+    \x12\x04\x87\x01\x16\x17\n=\n\x04\x04\x05\x02\x02\x12\x04\x8a\x01\x08\
+    \x18\x1a/\x20Soft\x20is\x20the\x20soft\x20limit\x20for\x20the\x20specifi\
+    ed\x20type\n\n\x0f\n\x05\x04\x05\x02\x02\x04\x12\x06\x8a\x01\x08\x87\x01\
+    \x18\n\r\n\x05\x04\x05\x02\x02\x05\x12\x04\x8a\x01\x08\x0e\n\r\n\x05\x04\
+    \x05\x02\x02\x01\x12\x04\x8a\x01\x0f\x13\n\r\n\x05\x04\x05\x02\x02\x03\
+    \x12\x04\x8a\x01\x16\x17\n\x0c\n\x02\x04\x06\x12\x06\x8d\x01\0\x98\x01\
+    \x01\n\x0b\n\x03\x04\x06\x01\x12\x04\x8d\x01\x08\r\n_\n\x04\x04\x06\x02\
+    \0\x12\x04\x8f\x01\x08\x1f\x1aQ\x20destination\x20is\x20the\x20path\x20i\
     nside\x20the\x20container\x20expect\x20when\x20it\x20starts\x20with\x20\
-    \"vm:/dev/\"\x20or\x20\"tmp:/\"\n\x20the\x20path\x20which\x20starts\x20w\
-    ith\x20\"vm:/dev/\"\x20refers\x20the\x20guest\x20vm's\x20\"/dev\",\n\x20\
-    especially,\x20\"vm:/dev/hostfs/\"\x20refers\x20to\x20the\x20shared\x20f\
-    ilesystem.\n\x20\"tmp:/\"\x20is\x20a\x20temporary\x20directory\x20which\
-    \x20is\x20used\x20for\x20temporary\x20mounts.\n\n\x0f\n\x05\x04\x06\x02\
-    \x01\x04\x12\x06\x95\x01\x08\x8f\x01\x1f\n\r\n\x05\x04\x06\x02\x01\x05\
-    \x12\x04\x95\x01\x08\x0e\n\r\n\x05\x04\x06\x02\x01\x01\x12\x04\x95\x01\
-    \x0f\x15\n\r\n\x05\x04\x06\x02\x01\x03\x12\x04\x95\x01\x18\x19\n\x0c\n\
-    \x04\x04\x06\x02\x02\x12\x04\x96\x01\x08\x18\n\x0f\n\x05\x04\x06\x02\x02\
-    \x04\x12\x06\x96\x01\x08\x95\x01\x1a\n\r\n\x05\x04\x06\x02\x02\x05\x12\
-    \x04\x96\x01\x08\x0e\n\r\n\x05\x04\x06\x02\x02\x01\x12\x04\x96\x01\x0f\
-    \x13\n\r\n\x05\x04\x06\x02\x02\x03\x12\x04\x96\x01\x16\x17\n\x0c\n\x04\
-    \x04\x06\x02\x03\x12\x04\x97\x01\x08$\n\r\n\x05\x04\x06\x02\x03\x04\x12\
-    \x04\x97\x01\x08\x10\n\r\n\x05\x04\x06\x02\x03\x05\x12\x04\x97\x01\x11\
-    \x17\n\r\n\x05\x04\x06\x02\x03\x01\x12\x04\x97\x01\x18\x1f\n\r\n\x05\x04\
-    \x06\x02\x03\x03\x12\x04\x97\x01\"#\n\x0c\n\x02\x04\x07\x12\x06\x9a\x01\
-    \0\xa0\x01\x01\n\x0b\n\x03\x04\x07\x01\x12\x04\x9a\x01\x08\x0c\nM\n\x04\
-    \x04\x07\x02\0\x12\x04\x9c\x01\x08\x18\x1a?\x20Path\x20is\x20the\x20abso\
-    lute\x20path\x20to\x20the\x20container's\x20root\x20filesystem.\n\n\x0f\
-    \n\x05\x04\x07\x02\0\x04\x12\x06\x9c\x01\x08\x9a\x01\x0e\n\r\n\x05\x04\
-    \x07\x02\0\x05\x12\x04\x9c\x01\x08\x0e\n\r\n\x05\x04\x07\x02\0\x01\x12\
-    \x04\x9c\x01\x0f\x13\n\r\n\x05\x04\x07\x02\0\x03\x12\x04\x9c\x01\x16\x17\
-    \nm\n\x04\x04\x07\x02\x01\x12\x04\x9f\x01\x08\x1a\x1a_\x20Readonly\x20ma\
-    kes\x20the\x20root\x20filesystem\x20for\x20the\x20container\x20readonly\
-    \x20before\x20the\x20process\x20is\x20executed.\n\n\x0f\n\x05\x04\x07\
-    \x02\x01\x04\x12\x06\x9f\x01\x08\x9c\x01\x18\n\r\n\x05\x04\x07\x02\x01\
-    \x05\x12\x04\x9f\x01\x08\x0c\n\r\n\x05\x04\x07\x02\x01\x01\x12\x04\x9f\
-    \x01\r\x15\n\r\n\x05\x04\x07\x02\x01\x03\x12\x04\x9f\x01\x18\x19\n\x0c\n\
-    \x02\x04\x08\x12\x06\xa2\x01\0\xab\x01\x01\n\x0b\n\x03\x04\x08\x01\x12\
-    \x04\xa2\x01\x08\r\n_\n\x04\x04\x08\x02\0\x12\x04\xa4\x01\x08C\x1aQ\x20P\
-    restart\x20is\x20a\x20list\x20of\x20hooks\x20to\x20be\x20run\x20before\
-    \x20the\x20container\x20process\x20is\x20executed.\n\n\r\n\x05\x04\x08\
-    \x02\0\x04\x12\x04\xa4\x01\x08\x10\n\r\n\x05\x04\x08\x02\0\x06\x12\x04\
-    \xa4\x01\x11\x15\n\r\n\x05\x04\x08\x02\0\x01\x12\x04\xa4\x01\x16\x1e\n\r\
-    \n\x05\x04\x08\x02\0\x03\x12\x04\xa4\x01!\"\n\r\n\x05\x04\x08\x02\0\x08\
-    \x12\x04\xa4\x01$B\n\x10\n\x08\x04\x08\x02\0\x08\xe9\xfb\x03\x12\x04\xa4\
-    \x01%A\n^\n\x04\x04\x08\x02\x01\x12\x04\xa7\x01\x08D\x1aP\x20Poststart\
-    \x20is\x20a\x20list\x20of\x20hooks\x20to\x20be\x20run\x20after\x20the\
-    \x20container\x20process\x20is\x20started.\n\n\r\n\x05\x04\x08\x02\x01\
-    \x04\x12\x04\xa7\x01\x08\x10\n\r\n\x05\x04\x08\x02\x01\x06\x12\x04\xa7\
-    \x01\x11\x15\n\r\n\x05\x04\x08\x02\x01\x01\x12\x04\xa7\x01\x16\x1f\n\r\n\
-    \x05\x04\x08\x02\x01\x03\x12\x04\xa7\x01\"#\n\r\n\x05\x04\x08\x02\x01\
-    \x08\x12\x04\xa7\x01%C\n\x10\n\x08\x04\x08\x02\x01\x08\xe9\xfb\x03\x12\
-    \x04\xa7\x01&B\nX\n\x04\x04\x08\x02\x02\x12\x04\xaa\x01\x08C\x1aJ\x20Pos\
-    tstop\x20is\x20a\x20list\x20of\x20hooks\x20to\x20be\x20run\x20after\x20t\
-    he\x20container\x20process\x20exits.\n\n\r\n\x05\x04\x08\x02\x02\x04\x12\
-    \x04\xaa\x01\x08\x10\n\r\n\x05\x04\x08\x02\x02\x06\x12\x04\xaa\x01\x11\
-    \x15\n\r\n\x05\x04\x08\x02\x02\x01\x12\x04\xaa\x01\x16\x1e\n\r\n\x05\x04\
-    \x08\x02\x02\x03\x12\x04\xaa\x01!\"\n\r\n\x05\x04\x08\x02\x02\x08\x12\
-    \x04\xaa\x01$B\n\x10\n\x08\x04\x08\x02\x02\x08\xe9\xfb\x03\x12\x04\xaa\
-    \x01%A\n\x0c\n\x02\x04\t\x12\x06\xad\x01\0\xb2\x01\x01\n\x0b\n\x03\x04\t\
-    \x01\x12\x04\xad\x01\x08\x0c\n\x0c\n\x04\x04\t\x02\0\x12\x04\xae\x01\x08\
-    \x18\n\x0f\n\x05\x04\t\x02\0\x04\x12\x06\xae\x01\x08\xad\x01\x0e\n\r\n\
-    \x05\x04\t\x02\0\x05\x12\x04\xae\x01\x08\x0e\n\r\n\x05\x04\t\x02\0\x01\
-    \x12\x04\xae\x01\x0f\x13\n\r\n\x05\x04\t\x02\0\x03\x12\x04\xae\x01\x16\
-    \x17\n\x0c\n\x04\x04\t\x02\x01\x12\x04\xaf\x01\x08!\n\r\n\x05\x04\t\x02\
-    \x01\x04\x12\x04\xaf\x01\x08\x10\n\r\n\x05\x04\t\x02\x01\x05\x12\x04\xaf\
-    \x01\x11\x17\n\r\n\x05\x04\t\x02\x01\x01\x12\x04\xaf\x01\x18\x1c\n\r\n\
-    \x05\x04\t\x02\x01\x03\x12\x04\xaf\x01\x1f\x20\n\x0c\n\x04\x04\t\x02\x02\
-    \x12\x04\xb0\x01\x08\x20\n\r\n\x05\x04\t\x02\x02\x04\x12\x04\xb0\x01\x08\
-    \x10\n\r\n\x05\x04\t\x02\x02\x05\x12\x04\xb0\x01\x11\x17\n\r\n\x05\x04\t\
-    \x02\x02\x01\x12\x04\xb0\x01\x18\x1b\n\r\n\x05\x04\t\x02\x02\x03\x12\x04\
-    \xb0\x01\x1e\x1f\n\x0c\n\x04\x04\t\x02\x03\x12\x04\xb1\x01\x08\x1a\n\x0f\
-    \n\x05\x04\t\x02\x03\x04\x12\x06\xb1\x01\x08\xb0\x01\x20\n\r\n\x05\x04\t\
-    \x02\x03\x05\x12\x04\xb1\x01\x08\r\n\r\n\x05\x04\t\x02\x03\x01\x12\x04\
-    \xb1\x01\x0e\x15\n\r\n\x05\x04\t\x02\x03\x03\x12\x04\xb1\x01\x18\x19\n\
-    \x0c\n\x02\x04\n\x12\x06\xb4\x01\0\xdf\x01\x01\n\x0b\n\x03\x04\n\x01\x12\
-    \x04\xb4\x01\x08\r\nR\n\x04\x04\n\x02\0\x12\x04\xb6\x01\x08P\x1aD\x20UID\
-    Mapping\x20specifies\x20user\x20mappings\x20for\x20supporting\x20user\
-    \x20namespaces.\n\n\r\n\x05\x04\n\x02\0\x04\x12\x04\xb6\x01\x08\x10\n\r\
-    \n\x05\x04\n\x02\0\x06\x12\x04\xb6\x01\x11\x1f\n\r\n\x05\x04\n\x02\0\x01\
-    \x12\x04\xb6\x01\x20+\n\r\n\x05\x04\n\x02\0\x03\x12\x04\xb6\x01./\n\r\n\
-    \x05\x04\n\x02\0\x08\x12\x04\xb6\x011O\n\x10\n\x08\x04\n\x02\0\x08\xe9\
-    \xfb\x03\x12\x04\xb6\x012N\nS\n\x04\x04\n\x02\x01\x12\x04\xb9\x01\x08P\
-    \x1aE\x20GIDMapping\x20specifies\x20group\x20mappings\x20for\x20supporti\
-    ng\x20user\x20namespaces.\n\n\r\n\x05\x04\n\x02\x01\x04\x12\x04\xb9\x01\
-    \x08\x10\n\r\n\x05\x04\n\x02\x01\x06\x12\x04\xb9\x01\x11\x1f\n\r\n\x05\
-    \x04\n\x02\x01\x01\x12\x04\xb9\x01\x20+\n\r\n\x05\x04\n\x02\x01\x03\x12\
-    \x04\xb9\x01./\n\r\n\x05\x04\n\x02\x01\x08\x12\x04\xb9\x011O\n\x10\n\x08\
-    \x04\n\x02\x01\x08\xe9\xfb\x03\x12\x04\xb9\x012N\n[\n\x04\x04\n\x02\x02\
-    \x12\x04\xbc\x01\x08'\x1aM\x20Sysctl\x20are\x20a\x20set\x20of\x20key\x20\
-    value\x20pairs\x20that\x20are\x20set\x20for\x20the\x20container\x20on\
-    \x20start\n\n\x0f\n\x05\x04\n\x02\x02\x04\x12\x06\xbc\x01\x08\xb9\x01P\n\
-    \r\n\x05\x04\n\x02\x02\x06\x12\x04\xbc\x01\x08\x1b\n\r\n\x05\x04\n\x02\
-    \x02\x01\x12\x04\xbc\x01\x1c\"\n\r\n\x05\x04\n\x02\x02\x03\x12\x04\xbc\
-    \x01%&\ni\n\x04\x04\n\x02\x03\x12\x04\xc0\x01\x08%\x1a[\x20Resources\x20\
-    contain\x20cgroup\x20information\x20for\x20handling\x20resource\x20const\
-    raints\n\x20for\x20the\x20container\n\n\x0f\n\x05\x04\n\x02\x03\x04\x12\
-    \x06\xc0\x01\x08\xbc\x01'\n\r\n\x05\x04\n\x02\x03\x06\x12\x04\xc0\x01\
-    \x08\x16\n\r\n\x05\x04\n\x02\x03\x01\x12\x04\xc0\x01\x17\x20\n\r\n\x05\
-    \x04\n\x02\x03\x03\x12\x04\xc0\x01#$\n\x87\x02\n\x04\x04\n\x02\x04\x12\
-    \x04\xc5\x01\x08\x1f\x1a\xf8\x01\x20CgroupsPath\x20specifies\x20the\x20p\
-    ath\x20to\x20cgroups\x20that\x20are\x20created\x20and/or\x20joined\x20by\
-    \x20the\x20container.\n\x20The\x20path\x20is\x20expected\x20to\x20be\x20\
-    relative\x20to\x20the\x20cgroups\x20mountpoint.\n\x20If\x20resources\x20\
-    are\x20specified,\x20the\x20cgroups\x20at\x20CgroupsPath\x20will\x20be\
-    \x20updated\x20based\x20on\x20resources.\n\n\x0f\n\x05\x04\n\x02\x04\x04\
-    \x12\x06\xc5\x01\x08\xc0\x01%\n\r\n\x05\x04\n\x02\x04\x05\x12\x04\xc5\
-    \x01\x08\x0e\n\r\n\x05\x04\n\x02\x04\x01\x12\x04\xc5\x01\x0f\x1a\n\r\n\
-    \x05\x04\n\x02\x04\x03\x12\x04\xc5\x01\x1d\x1e\nb\n\x04\x04\n\x02\x05\
-    \x12\x04\xc8\x01\x08O\x1aT\x20Namespaces\x20contains\x20the\x20namespace\
-    s\x20that\x20are\x20created\x20and/or\x20joined\x20by\x20the\x20containe\
-    r\n\n\r\n\x05\x04\n\x02\x05\x04\x12\x04\xc8\x01\x08\x10\n\r\n\x05\x04\n\
-    \x02\x05\x06\x12\x04\xc8\x01\x11\x1f\n\r\n\x05\x04\n\x02\x05\x01\x12\x04\
-    \xc8\x01\x20*\n\r\n\x05\x04\n\x02\x05\x03\x12\x04\xc8\x01-.\n\r\n\x05\
-    \x04\n\x02\x05\x08\x12\x04\xc8\x010N\n\x10\n\x08\x04\n\x02\x05\x08\xe9\
-    \xfb\x03\x12\x04\xc8\x011M\nU\n\x04\x04\n\x02\x06\x12\x04\xcb\x01\x08I\
-    \x1aG\x20Devices\x20are\x20a\x20list\x20of\x20device\x20nodes\x20that\
-    \x20are\x20created\x20for\x20the\x20container\n\n\r\n\x05\x04\n\x02\x06\
-    \x04\x12\x04\xcb\x01\x08\x10\n\r\n\x05\x04\n\x02\x06\x06\x12\x04\xcb\x01\
-    \x11\x1c\n\r\n\x05\x04\n\x02\x06\x01\x12\x04\xcb\x01\x1d$\n\r\n\x05\x04\
-    \n\x02\x06\x03\x12\x04\xcb\x01'(\n\r\n\x05\x04\n\x02\x06\x08\x12\x04\xcb\
-    \x01*H\n\x10\n\x08\x04\n\x02\x06\x08\xe9\xfb\x03\x12\x04\xcb\x01+G\nR\n\
-    \x04\x04\n\x02\x07\x12\x04\xce\x01\x08!\x1aD\x20Seccomp\x20specifies\x20\
-    the\x20seccomp\x20security\x20settings\x20for\x20the\x20container.\n\n\
-    \x0f\n\x05\x04\n\x02\x07\x04\x12\x06\xce\x01\x08\xcb\x01I\n\r\n\x05\x04\
-    \n\x02\x07\x06\x12\x04\xce\x01\x08\x14\n\r\n\x05\x04\n\x02\x07\x01\x12\
-    \x04\xce\x01\x15\x1c\n\r\n\x05\x04\n\x02\x07\x03\x12\x04\xce\x01\x1f\x20\
-    \nY\n\x04\x04\n\x02\x08\x12\x04\xd1\x01\x08%\x1aK\x20RootfsPropagation\
-    \x20is\x20the\x20rootfs\x20mount\x20propagation\x20mode\x20for\x20the\
-    \x20container.\n\n\x0f\n\x05\x04\n\x02\x08\x04\x12\x06\xd1\x01\x08\xce\
-    \x01!\n\r\n\x05\x04\n\x02\x08\x05\x12\x04\xd1\x01\x08\x0e\n\r\n\x05\x04\
-    \n\x02\x08\x01\x12\x04\xd1\x01\x0f\x20\n\r\n\x05\x04\n\x02\x08\x03\x12\
-    \x04\xd1\x01#$\nO\n\x04\x04\n\x02\t\x12\x04\xd4\x01\x08)\x1aA\x20MaskedP\
-    aths\x20masks\x20over\x20the\x20provided\x20paths\x20inside\x20the\x20co\
-    ntainer.\n\n\r\n\x05\x04\n\x02\t\x04\x12\x04\xd4\x01\x08\x10\n\r\n\x05\
-    \x04\n\x02\t\x05\x12\x04\xd4\x01\x11\x17\n\r\n\x05\x04\n\x02\t\x01\x12\
-    \x04\xd4\x01\x18#\n\r\n\x05\x04\n\x02\t\x03\x12\x04\xd4\x01&(\nQ\n\x04\
-    \x04\n\x02\n\x12\x04\xd7\x01\x08+\x1aC\x20ReadonlyPaths\x20sets\x20the\
-    \x20provided\x20paths\x20as\x20RO\x20inside\x20the\x20container.\n\n\r\n\
-    \x05\x04\n\x02\n\x04\x12\x04\xd7\x01\x08\x10\n\r\n\x05\x04\n\x02\n\x05\
-    \x12\x04\xd7\x01\x11\x17\n\r\n\x05\x04\n\x02\n\x01\x12\x04\xd7\x01\x18%\
-    \n\r\n\x05\x04\n\x02\n\x03\x12\x04\xd7\x01(*\nY\n\x04\x04\n\x02\x0b\x12\
-    \x04\xda\x01\x08\x1f\x1aK\x20MountLabel\x20specifies\x20the\x20selinux\
-    \x20context\x20for\x20the\x20mounts\x20in\x20the\x20container.\n\n\x0f\n\
-    \x05\x04\n\x02\x0b\x04\x12\x06\xda\x01\x08\xd7\x01+\n\r\n\x05\x04\n\x02\
-    \x0b\x05\x12\x04\xda\x01\x08\x0e\n\r\n\x05\x04\n\x02\x0b\x01\x12\x04\xda\
-    \x01\x0f\x19\n\r\n\x05\x04\n\x02\x0b\x03\x12\x04\xda\x01\x1c\x1e\n\x9d\
-    \x01\n\x04\x04\n\x02\x0c\x12\x04\xde\x01\x08$\x1a\x8e\x01\x20IntelRdt\
-    \x20contains\x20Intel\x20Resource\x20Director\x20Technology\x20(RDT)\x20\
-    information\n\x20for\x20handling\x20resource\x20constraints\x20(e.g.,\
-    \x20L3\x20cache)\x20for\x20the\x20container\n\n\x0f\n\x05\x04\n\x02\x0c\
-    \x04\x12\x06\xde\x01\x08\xda\x01\x1f\n\r\n\x05\x04\n\x02\x0c\x06\x12\x04\
-    \xde\x01\x08\x15\n\r\n\x05\x04\n\x02\x0c\x01\x12\x04\xde\x01\x16\x1e\n\r\
-    \n\x05\x04\n\x02\x0c\x03\x12\x04\xde\x01!#\n\x0c\n\x02\x04\x0b\x12\x06\
-    \xe1\x01\0\xe4\x01\x01\n\x0b\n\x03\x04\x0b\x01\x12\x04\xe1\x01\x08\x0f\n\
-    )\n\x04\x04\x0b\x02\0\x12\x04\xe3\x01\x08\x19\x1a\x1b\x20Dummy\x20string\
-    ,\x20never\x20used.\n\n\x0f\n\x05\x04\x0b\x02\0\x04\x12\x06\xe3\x01\x08\
-    \xe1\x01\x11\n\r\n\x05\x04\x0b\x02\0\x05\x12\x04\xe3\x01\x08\x0e\n\r\n\
-    \x05\x04\x0b\x02\0\x01\x12\x04\xe3\x01\x0f\x14\n\r\n\x05\x04\x0b\x02\0\
-    \x03\x12\x04\xe3\x01\x17\x18\n\x0c\n\x02\x04\x0c\x12\x06\xe6\x01\0\xe9\
-    \x01\x01\n\x0b\n\x03\x04\x0c\x01\x12\x04\xe6\x01\x08\x0f\n)\n\x04\x04\
-    \x0c\x02\0\x12\x04\xe8\x01\x08\x19\x1a\x1b\x20Dummy\x20string,\x20never\
-    \x20used.\n\n\x0f\n\x05\x04\x0c\x02\0\x04\x12\x06\xe8\x01\x08\xe6\x01\
-    \x11\n\r\n\x05\x04\x0c\x02\0\x05\x12\x04\xe8\x01\x08\x0e\n\r\n\x05\x04\
-    \x0c\x02\0\x01\x12\x04\xe8\x01\x0f\x14\n\r\n\x05\x04\x0c\x02\0\x03\x12\
-    \x04\xe8\x01\x17\x18\n\x0c\n\x02\x04\r\x12\x06\xeb\x01\0\xf4\x01\x01\n\
-    \x0b\n\x03\x04\r\x01\x12\x04\xeb\x01\x08\x16\nX\n\x04\x04\r\x02\0\x12\
-    \x04\xed\x01\x08\x1a\x1aJ\x20HostID\x20is\x20the\x20starting\x20UID/GID\
-    \x20on\x20the\x20host\x20to\x20be\x20mapped\x20to\x20'ContainerID'\n\n\
-    \x0f\n\x05\x04\r\x02\0\x04\x12\x06\xed\x01\x08\xeb\x01\x18\n\r\n\x05\x04\
-    \r\x02\0\x05\x12\x04\xed\x01\x08\x0e\n\r\n\x05\x04\r\x02\0\x01\x12\x04\
-    \xed\x01\x0f\x15\n\r\n\x05\x04\r\x02\0\x03\x12\x04\xed\x01\x18\x19\nD\n\
-    \x04\x04\r\x02\x01\x12\x04\xf0\x01\x08\x1f\x1a6\x20ContainerID\x20is\x20\
-    the\x20starting\x20UID/GID\x20in\x20the\x20container\n\n\x0f\n\x05\x04\r\
-    \x02\x01\x04\x12\x06\xf0\x01\x08\xed\x01\x1a\n\r\n\x05\x04\r\x02\x01\x05\
-    \x12\x04\xf0\x01\x08\x0e\n\r\n\x05\x04\r\x02\x01\x01\x12\x04\xf0\x01\x0f\
-    \x1a\n\r\n\x05\x04\r\x02\x01\x03\x12\x04\xf0\x01\x1d\x1e\n6\n\x04\x04\r\
-    \x02\x02\x12\x04\xf3\x01\x08\x18\x1a(\x20Size\x20is\x20the\x20number\x20\
-    of\x20IDs\x20to\x20be\x20mapped\n\n\x0f\n\x05\x04\r\x02\x02\x04\x12\x06\
-    \xf3\x01\x08\xf0\x01\x1f\n\r\n\x05\x04\r\x02\x02\x05\x12\x04\xf3\x01\x08\
-    \x0e\n\r\n\x05\x04\r\x02\x02\x01\x12\x04\xf3\x01\x0f\x13\n\r\n\x05\x04\r\
-    \x02\x02\x03\x12\x04\xf3\x01\x16\x17\n\x0c\n\x02\x04\x0e\x12\x06\xf6\x01\
-    \0\xfd\x01\x01\n\x0b\n\x03\x04\x0e\x01\x12\x04\xf6\x01\x08\x16\n-\n\x04\
-    \x04\x0e\x02\0\x12\x04\xf8\x01\x08\x18\x1a\x1f\x20Type\x20is\x20the\x20t\
-    ype\x20of\x20namespace\n\n\x0f\n\x05\x04\x0e\x02\0\x04\x12\x06\xf8\x01\
-    \x08\xf6\x01\x18\n\r\n\x05\x04\x0e\x02\0\x05\x12\x04\xf8\x01\x08\x0e\n\r\
-    \n\x05\x04\x0e\x02\0\x01\x12\x04\xf8\x01\x0f\x13\n\r\n\x05\x04\x0e\x02\0\
-    \x03\x12\x04\xf8\x01\x16\x17\nu\n\x04\x04\x0e\x02\x01\x12\x04\xfc\x01\
-    \x08\x18\x1ag\x20Path\x20is\x20a\x20path\x20to\x20an\x20existing\x20name\
-    space\x20persisted\x20on\x20disk\x20that\x20can\x20be\x20joined\n\x20and\
-    \x20is\x20of\x20the\x20same\x20type\n\n\x0f\n\x05\x04\x0e\x02\x01\x04\
-    \x12\x06\xfc\x01\x08\xf8\x01\x18\n\r\n\x05\x04\x0e\x02\x01\x05\x12\x04\
-    \xfc\x01\x08\x0e\n\r\n\x05\x04\x0e\x02\x01\x01\x12\x04\xfc\x01\x0f\x13\n\
-    \r\n\x05\x04\x0e\x02\x01\x03\x12\x04\xfc\x01\x16\x17\n\x0c\n\x02\x04\x0f\
-    \x12\x06\xff\x01\0\x94\x02\x01\n\x0b\n\x03\x04\x0f\x01\x12\x04\xff\x01\
-    \x08\x13\n#\n\x04\x04\x0f\x02\0\x12\x04\x81\x02\x08\x18\x1a\x15\x20Path\
-    \x20to\x20the\x20device.\n\n\x0f\n\x05\x04\x0f\x02\0\x04\x12\x06\x81\x02\
-    \x08\xff\x01\x15\n\r\n\x05\x04\x0f\x02\0\x05\x12\x04\x81\x02\x08\x0e\n\r\
-    \n\x05\x04\x0f\x02\0\x01\x12\x04\x81\x02\x0f\x13\n\r\n\x05\x04\x0f\x02\0\
-    \x03\x12\x04\x81\x02\x16\x17\n.\n\x04\x04\x0f\x02\x01\x12\x04\x84\x02\
-    \x08\x18\x1a\x20\x20Device\x20type,\x20block,\x20char,\x20etc.\n\n\x0f\n\
-    \x05\x04\x0f\x02\x01\x04\x12\x06\x84\x02\x08\x81\x02\x18\n\r\n\x05\x04\
-    \x0f\x02\x01\x05\x12\x04\x84\x02\x08\x0e\n\r\n\x05\x04\x0f\x02\x01\x01\
-    \x12\x04\x84\x02\x0f\x13\n\r\n\x05\x04\x0f\x02\x01\x03\x12\x04\x84\x02\
-    \x16\x17\n3\n\x04\x04\x0f\x02\x02\x12\x04\x87\x02\x08\x18\x1a%\x20Major\
-    \x20is\x20the\x20device's\x20major\x20number.\n\n\x0f\n\x05\x04\x0f\x02\
-    \x02\x04\x12\x06\x87\x02\x08\x84\x02\x18\n\r\n\x05\x04\x0f\x02\x02\x05\
-    \x12\x04\x87\x02\x08\r\n\r\n\x05\x04\x0f\x02\x02\x01\x12\x04\x87\x02\x0e\
-    \x13\n\r\n\x05\x04\x0f\x02\x02\x03\x12\x04\x87\x02\x16\x17\n3\n\x04\x04\
-    \x0f\x02\x03\x12\x04\x8a\x02\x08\x18\x1a%\x20Minor\x20is\x20the\x20devic\
-    e's\x20minor\x20number.\n\n\x0f\n\x05\x04\x0f\x02\x03\x04\x12\x06\x8a\
-    \x02\x08\x87\x02\x18\n\r\n\x05\x04\x0f\x02\x03\x05\x12\x04\x8a\x02\x08\r\
-    \n\r\n\x05\x04\x0f\x02\x03\x01\x12\x04\x8a\x02\x0e\x13\n\r\n\x05\x04\x0f\
-    \x02\x03\x03\x12\x04\x8a\x02\x16\x17\n8\n\x04\x04\x0f\x02\x04\x12\x04\
-    \x8d\x02\x08\x1c\x1a*\x20FileMode\x20permission\x20bits\x20for\x20the\
-    \x20device.\n\n\x0f\n\x05\x04\x0f\x02\x04\x04\x12\x06\x8d\x02\x08\x8a\
-    \x02\x18\n\r\n\x05\x04\x0f\x02\x04\x05\x12\x04\x8d\x02\x08\x0e\n\r\n\x05\
-    \x04\x0f\x02\x04\x01\x12\x04\x8d\x02\x0f\x17\n\r\n\x05\x04\x0f\x02\x04\
-    \x03\x12\x04\x8d\x02\x1a\x1b\n\"\n\x04\x04\x0f\x02\x05\x12\x04\x90\x02\
-    \x08\x17\x1a\x14\x20UID\x20of\x20the\x20device.\n\n\x0f\n\x05\x04\x0f\
-    \x02\x05\x04\x12\x06\x90\x02\x08\x8d\x02\x1c\n\r\n\x05\x04\x0f\x02\x05\
-    \x05\x12\x04\x90\x02\x08\x0e\n\r\n\x05\x04\x0f\x02\x05\x01\x12\x04\x90\
-    \x02\x0f\x12\n\r\n\x05\x04\x0f\x02\x05\x03\x12\x04\x90\x02\x15\x16\n\"\n\
-    \x04\x04\x0f\x02\x06\x12\x04\x93\x02\x08\x17\x1a\x14\x20Gid\x20of\x20the\
-    \x20device.\n\n\x0f\n\x05\x04\x0f\x02\x06\x04\x12\x06\x93\x02\x08\x90\
-    \x02\x17\n\r\n\x05\x04\x0f\x02\x06\x05\x12\x04\x93\x02\x08\x0e\n\r\n\x05\
-    \x04\x0f\x02\x06\x01\x12\x04\x93\x02\x0f\x12\n\r\n\x05\x04\x0f\x02\x06\
-    \x03\x12\x04\x93\x02\x15\x16\n\x0c\n\x02\x04\x10\x12\x06\x96\x02\0\xab\
-    \x02\x01\n\x0b\n\x03\x04\x10\x01\x12\x04\x96\x02\x08\x16\n8\n\x04\x04\
-    \x10\x02\0\x12\x04\x98\x02\x08O\x1a*\x20Devices\x20configures\x20the\x20\
-    device\x20whitelist.\n\n\r\n\x05\x04\x10\x02\0\x04\x12\x04\x98\x02\x08\
-    \x10\n\r\n\x05\x04\x10\x02\0\x06\x12\x04\x98\x02\x11\"\n\r\n\x05\x04\x10\
-    \x02\0\x01\x12\x04\x98\x02#*\n\r\n\x05\x04\x10\x02\0\x03\x12\x04\x98\x02\
-    -.\n\r\n\x05\x04\x10\x02\0\x08\x12\x04\x98\x020N\n\x10\n\x08\x04\x10\x02\
-    \0\x08\xe9\xfb\x03\x12\x04\x98\x021M\n0\n\x04\x04\x10\x02\x01\x12\x04\
-    \x9b\x02\x08\x1f\x1a\"\x20Memory\x20restriction\x20configuration\n\n\x0f\
-    \n\x05\x04\x10\x02\x01\x04\x12\x06\x9b\x02\x08\x98\x02O\n\r\n\x05\x04\
-    \x10\x02\x01\x06\x12\x04\x9b\x02\x08\x13\n\r\n\x05\x04\x10\x02\x01\x01\
-    \x12\x04\x9b\x02\x14\x1a\n\r\n\x05\x04\x10\x02\x01\x03\x12\x04\x9b\x02\
-    \x1d\x1e\n6\n\x04\x04\x10\x02\x02\x12\x04\x9e\x02\x08\x19\x1a(\x20CPU\
-    \x20resource\x20restriction\x20configuration\n\n\x0f\n\x05\x04\x10\x02\
-    \x02\x04\x12\x06\x9e\x02\x08\x9b\x02\x1f\n\r\n\x05\x04\x10\x02\x02\x06\
-    \x12\x04\x9e\x02\x08\x10\n\r\n\x05\x04\x10\x02\x02\x01\x12\x04\x9e\x02\
-    \x11\x14\n\r\n\x05\x04\x10\x02\x02\x03\x12\x04\x9e\x02\x17\x18\n8\n\x04\
-    \x04\x10\x02\x03\x12\x04\xa1\x02\x08\x1b\x1a*\x20Task\x20resource\x20res\
-    triction\x20configuration.\n\n\x0f\n\x05\x04\x10\x02\x03\x04\x12\x06\xa1\
-    \x02\x08\x9e\x02\x19\n\r\n\x05\x04\x10\x02\x03\x06\x12\x04\xa1\x02\x08\
-    \x11\n\r\n\x05\x04\x10\x02\x03\x01\x12\x04\xa1\x02\x12\x16\n\r\n\x05\x04\
-    \x10\x02\x03\x03\x12\x04\xa1\x02\x19\x1a\n1\n\x04\x04\x10\x02\x04\x12\
-    \x04\xa4\x02\x08!\x1a#\x20BlockIO\x20restriction\x20configuration\n\n\
-    \x0f\n\x05\x04\x10\x02\x04\x04\x12\x06\xa4\x02\x08\xa1\x02\x1b\n\r\n\x05\
-    \x04\x10\x02\x04\x06\x12\x04\xa4\x02\x08\x14\n\r\n\x05\x04\x10\x02\x04\
-    \x01\x12\x04\xa4\x02\x15\x1c\n\r\n\x05\x04\x10\x02\x04\x03\x12\x04\xa4\
-    \x02\x1f\x20\n(\n\x04\x04\x10\x02\x05\x12\x04\xa7\x02\x08W\x1a\x1a\x20Hu\
-    getlb\x20limit\x20(in\x20bytes)\n\n\r\n\x05\x04\x10\x02\x05\x04\x12\x04\
-    \xa7\x02\x08\x10\n\r\n\x05\x04\x10\x02\x05\x06\x12\x04\xa7\x02\x11#\n\r\
-    \n\x05\x04\x10\x02\x05\x01\x12\x04\xa7\x02$2\n\r\n\x05\x04\x10\x02\x05\
-    \x03\x12\x04\xa7\x0256\n\r\n\x05\x04\x10\x02\x05\x08\x12\x04\xa7\x028V\n\
-    \x10\n\x08\x04\x10\x02\x05\x08\xe9\xfb\x03\x12\x04\xa7\x029U\n1\n\x04\
-    \x04\x10\x02\x06\x12\x04\xaa\x02\x08!\x1a#\x20Network\x20restriction\x20\
-    configuration\n\n\x0f\n\x05\x04\x10\x02\x06\x04\x12\x06\xaa\x02\x08\xa7\
-    \x02W\n\r\n\x05\x04\x10\x02\x06\x06\x12\x04\xaa\x02\x08\x14\n\r\n\x05\
-    \x04\x10\x02\x06\x01\x12\x04\xaa\x02\x15\x1c\n\r\n\x05\x04\x10\x02\x06\
-    \x03\x12\x04\xaa\x02\x1f\x20\n\x0c\n\x02\x04\x11\x12\x06\xad\x02\0\xc2\
-    \x02\x01\n\x0b\n\x03\x04\x11\x01\x12\x04\xad\x02\x08\x13\n(\n\x04\x04\
-    \x11\x02\0\x12\x04\xaf\x02\x08\x18\x1a\x1a\x20Memory\x20limit\x20(in\x20\
-    bytes).\n\n\x0f\n\x05\x04\x11\x02\0\x04\x12\x06\xaf\x02\x08\xad\x02\x15\
-    \n\r\n\x05\x04\x11\x02\0\x05\x12\x04\xaf\x02\x08\r\n\r\n\x05\x04\x11\x02\
-    \0\x01\x12\x04\xaf\x02\x0e\x13\n\r\n\x05\x04\x11\x02\0\x03\x12\x04\xaf\
-    \x02\x16\x17\n<\n\x04\x04\x11\x02\x01\x12\x04\xb2\x02\x08\x1e\x1a.\x20Me\
-    mory\x20reservation\x20or\x20soft_limit\x20(in\x20bytes).\n\n\x0f\n\x05\
-    \x04\x11\x02\x01\x04\x12\x06\xb2\x02\x08\xaf\x02\x18\n\r\n\x05\x04\x11\
-    \x02\x01\x05\x12\x04\xb2\x02\x08\r\n\r\n\x05\x04\x11\x02\x01\x01\x12\x04\
-    \xb2\x02\x0e\x19\n\r\n\x05\x04\x11\x02\x01\x03\x12\x04\xb2\x02\x1c\x1d\n\
-    3\n\x04\x04\x11\x02\x02\x12\x04\xb5\x02\x08\x17\x1a%\x20Total\x20memory\
-    \x20limit\x20(memory\x20+\x20swap).\n\n\x0f\n\x05\x04\x11\x02\x02\x04\
-    \x12\x06\xb5\x02\x08\xb2\x02\x1e\n\r\n\x05\x04\x11\x02\x02\x05\x12\x04\
-    \xb5\x02\x08\r\n\r\n\x05\x04\x11\x02\x02\x01\x12\x04\xb5\x02\x0e\x12\n\r\
-    \n\x05\x04\x11\x02\x02\x03\x12\x04\xb5\x02\x15\x16\n/\n\x04\x04\x11\x02\
-    \x03\x12\x04\xb8\x02\x08\x19\x1a!\x20Kernel\x20memory\x20limit\x20(in\
-    \x20bytes).\n\n\x0f\n\x05\x04\x11\x02\x03\x04\x12\x06\xb8\x02\x08\xb5\
-    \x02\x17\n\r\n\x05\x04\x11\x02\x03\x05\x12\x04\xb8\x02\x08\r\n\r\n\x05\
-    \x04\x11\x02\x03\x01\x12\x04\xb8\x02\x0e\x14\n\r\n\x05\x04\x11\x02\x03\
-    \x03\x12\x04\xb8\x02\x17\x18\n6\n\x04\x04\x11\x02\x04\x12\x04\xbb\x02\
-    \x08\x1c\x1a(\x20Kernel\x20memory\x20limit\x20for\x20tcp\x20(in\x20bytes\
-    )\n\n\x0f\n\x05\x04\x11\x02\x04\x04\x12\x06\xbb\x02\x08\xb8\x02\x19\n\r\
-    \n\x05\x04\x11\x02\x04\x05\x12\x04\xbb\x02\x08\r\n\r\n\x05\x04\x11\x02\
-    \x04\x01\x12\x04\xbb\x02\x0e\x17\n\r\n\x05\x04\x11\x02\x04\x03\x12\x04\
-    \xbb\x02\x1a\x1b\nA\n\x04\x04\x11\x02\x05\x12\x04\xbe\x02\x08\x1e\x1a3\
-    \x20How\x20aggressive\x20the\x20kernel\x20will\x20swap\x20memory\x20page\
-    s.\n\n\x0f\n\x05\x04\x11\x02\x05\x04\x12\x06\xbe\x02\x08\xbb\x02\x1c\n\r\
-    \n\x05\x04\x11\x02\x05\x05\x12\x04\xbe\x02\x08\x0e\n\r\n\x05\x04\x11\x02\
-    \x05\x01\x12\x04\xbe\x02\x0f\x19\n\r\n\x05\x04\x11\x02\x05\x03\x12\x04\
-    \xbe\x02\x1c\x1d\nU\n\x04\x04\x11\x02\x06\x12\x04\xc1\x02\x08\"\x1aG\x20\
-    DisableOOMKiller\x20disables\x20the\x20OOM\x20killer\x20for\x20out\x20of\
-    \x20memory\x20conditions\n\n\x0f\n\x05\x04\x11\x02\x06\x04\x12\x06\xc1\
-    \x02\x08\xbe\x02\x1e\n\r\n\x05\x04\x11\x02\x06\x05\x12\x04\xc1\x02\x08\
-    \x0c\n\r\n\x05\x04\x11\x02\x06\x01\x12\x04\xc1\x02\r\x1d\n\r\n\x05\x04\
-    \x11\x02\x06\x03\x12\x04\xc1\x02\x20!\n\x0c\n\x02\x04\x12\x12\x06\xc4\
-    \x02\0\xd9\x02\x01\n\x0b\n\x03\x04\x12\x01\x12\x04\xc4\x02\x08\x10\nW\n\
-    \x04\x04\x12\x02\0\x12\x04\xc6\x02\x08\x1a\x1aI\x20CPU\x20shares\x20(rel\
-    ative\x20weight\x20(ratio)\x20vs.\x20other\x20cgroups\x20with\x20cpu\x20\
-    shares).\n\n\x0f\n\x05\x04\x12\x02\0\x04\x12\x06\xc6\x02\x08\xc4\x02\x12\
-    \n\r\n\x05\x04\x12\x02\0\x05\x12\x04\xc6\x02\x08\x0e\n\r\n\x05\x04\x12\
-    \x02\0\x01\x12\x04\xc6\x02\x0f\x15\n\r\n\x05\x04\x12\x02\0\x03\x12\x04\
-    \xc6\x02\x18\x19\nQ\n\x04\x04\x12\x02\x01\x12\x04\xc9\x02\x08\x18\x1aC\
-    \x20CPU\x20hardcap\x20limit\x20(in\x20usecs).\x20Allowed\x20cpu\x20time\
-    \x20in\x20a\x20given\x20period.\n\n\x0f\n\x05\x04\x12\x02\x01\x04\x12\
-    \x06\xc9\x02\x08\xc6\x02\x1a\n\r\n\x05\x04\x12\x02\x01\x05\x12\x04\xc9\
-    \x02\x08\r\n\r\n\x05\x04\x12\x02\x01\x01\x12\x04\xc9\x02\x0e\x13\n\r\n\
-    \x05\x04\x12\x02\x01\x03\x12\x04\xc9\x02\x16\x17\nA\n\x04\x04\x12\x02\
-    \x02\x12\x04\xcc\x02\x08\x1a\x1a3\x20CPU\x20period\x20to\x20be\x20used\
-    \x20for\x20hardcapping\x20(in\x20usecs).\n\n\x0f\n\x05\x04\x12\x02\x02\
-    \x04\x12\x06\xcc\x02\x08\xc9\x02\x18\n\r\n\x05\x04\x12\x02\x02\x05\x12\
-    \x04\xcc\x02\x08\x0e\n\r\n\x05\x04\x12\x02\x02\x01\x12\x04\xcc\x02\x0f\
-    \x15\n\r\n\x05\x04\x12\x02\x02\x03\x12\x04\xcc\x02\x18\x19\nE\n\x04\x04\
-    \x12\x02\x03\x12\x04\xcf\x02\x08\"\x1a7\x20How\x20much\x20time\x20realti\
-    me\x20scheduling\x20may\x20use\x20(in\x20usecs).\n\n\x0f\n\x05\x04\x12\
-    \x02\x03\x04\x12\x06\xcf\x02\x08\xcc\x02\x1a\n\r\n\x05\x04\x12\x02\x03\
-    \x05\x12\x04\xcf\x02\x08\r\n\r\n\x05\x04\x12\x02\x03\x01\x12\x04\xcf\x02\
-    \x0e\x1d\n\r\n\x05\x04\x12\x02\x03\x03\x12\x04\xcf\x02\x20!\nI\n\x04\x04\
-    \x12\x02\x04\x12\x04\xd2\x02\x08\"\x1a;\x20CPU\x20period\x20to\x20be\x20\
-    used\x20for\x20realtime\x20scheduling\x20(in\x20usecs).\n\n\x0f\n\x05\
-    \x04\x12\x02\x04\x04\x12\x06\xd2\x02\x08\xcf\x02\"\n\r\n\x05\x04\x12\x02\
-    \x04\x05\x12\x04\xd2\x02\x08\x0e\n\r\n\x05\x04\x12\x02\x04\x01\x12\x04\
-    \xd2\x02\x0f\x1d\n\r\n\x05\x04\x12\x02\x04\x03\x12\x04\xd2\x02\x20!\nS\n\
-    \x04\x04\x12\x02\x05\x12\x04\xd5\x02\x08\x18\x1aE\x20CPUs\x20to\x20use\
-    \x20within\x20the\x20cpuset.\x20Default\x20is\x20to\x20use\x20any\x20CPU\
-    \x20available.\n\n\x0f\n\x05\x04\x12\x02\x05\x04\x12\x06\xd5\x02\x08\xd2\
-    \x02\"\n\r\n\x05\x04\x12\x02\x05\x05\x12\x04\xd5\x02\x08\x0e\n\r\n\x05\
-    \x04\x12\x02\x05\x01\x12\x04\xd5\x02\x0f\x13\n\r\n\x05\x04\x12\x02\x05\
-    \x03\x12\x04\xd5\x02\x16\x17\n`\n\x04\x04\x12\x02\x06\x12\x04\xd8\x02\
-    \x08\x18\x1aR\x20List\x20of\x20memory\x20nodes\x20in\x20the\x20cpuset.\
-    \x20Default\x20is\x20to\x20use\x20any\x20available\x20memory\x20node.\n\
-    \n\x0f\n\x05\x04\x12\x02\x06\x04\x12\x06\xd8\x02\x08\xd5\x02\x18\n\r\n\
-    \x05\x04\x12\x02\x06\x05\x12\x04\xd8\x02\x08\x0e\n\r\n\x05\x04\x12\x02\
-    \x06\x01\x12\x04\xd8\x02\x0f\x13\n\r\n\x05\x04\x12\x02\x06\x03\x12\x04\
-    \xd8\x02\x16\x17\n\x0c\n\x02\x04\x13\x12\x06\xdb\x02\0\xe7\x02\x01\n\x0b\
-    \n\x03\x04\x13\x01\x12\x04\xdb\x02\x08\x19\n3\n\x04\x04\x13\x02\0\x12\
-    \x04\xdd\x02\x08\x18\x1a%\x20Major\x20is\x20the\x20device's\x20major\x20\
-    number.\n\n\x0f\n\x05\x04\x13\x02\0\x04\x12\x06\xdd\x02\x08\xdb\x02\x1b\
-    \n\r\n\x05\x04\x13\x02\0\x05\x12\x04\xdd\x02\x08\r\n\r\n\x05\x04\x13\x02\
-    \0\x01\x12\x04\xdd\x02\x0e\x13\n\r\n\x05\x04\x13\x02\0\x03\x12\x04\xdd\
-    \x02\x16\x17\n3\n\x04\x04\x13\x02\x01\x12\x04\xe0\x02\x08\x18\x1a%\x20Mi\
-    nor\x20is\x20the\x20device's\x20minor\x20number.\n\n\x0f\n\x05\x04\x13\
-    \x02\x01\x04\x12\x06\xe0\x02\x08\xdd\x02\x18\n\r\n\x05\x04\x13\x02\x01\
-    \x05\x12\x04\xe0\x02\x08\r\n\r\n\x05\x04\x13\x02\x01\x01\x12\x04\xe0\x02\
-    \x0e\x13\n\r\n\x05\x04\x13\x02\x01\x03\x12\x04\xe0\x02\x16\x17\n<\n\x04\
-    \x04\x13\x02\x02\x12\x04\xe3\x02\x08\x1a\x1a.\x20Weight\x20is\x20the\x20\
-    bandwidth\x20rate\x20for\x20the\x20device.\n\n\x0f\n\x05\x04\x13\x02\x02\
-    \x04\x12\x06\xe3\x02\x08\xe0\x02\x18\n\r\n\x05\x04\x13\x02\x02\x05\x12\
-    \x04\xe3\x02\x08\x0e\n\r\n\x05\x04\x13\x02\x02\x01\x12\x04\xe3\x02\x0f\
-    \x15\n\r\n\x05\x04\x13\x02\x02\x03\x12\x04\xe3\x02\x18\x19\n\x83\x01\n\
-    \x04\x04\x13\x02\x03\x12\x04\xe6\x02\x08\x1e\x1au\x20LeafWeight\x20is\
-    \x20the\x20bandwidth\x20rate\x20for\x20the\x20device\x20while\x20competi\
-    ng\x20with\x20the\x20cgroup's\x20child\x20cgroups,\x20CFQ\x20scheduler\
-    \x20only\n\n\x0f\n\x05\x04\x13\x02\x03\x04\x12\x06\xe6\x02\x08\xe3\x02\
-    \x1a\n\r\n\x05\x04\x13\x02\x03\x05\x12\x04\xe6\x02\x08\x0e\n\r\n\x05\x04\
-    \x13\x02\x03\x01\x12\x04\xe6\x02\x0f\x19\n\r\n\x05\x04\x13\x02\x03\x03\
-    \x12\x04\xe6\x02\x1c\x1d\n\x0c\n\x02\x04\x14\x12\x06\xe9\x02\0\xf2\x02\
-    \x01\n\x0b\n\x03\x04\x14\x01\x12\x04\xe9\x02\x08\x1b\n3\n\x04\x04\x14\
-    \x02\0\x12\x04\xeb\x02\x08\x18\x1a%\x20Major\x20is\x20the\x20device's\
-    \x20major\x20number.\n\n\x0f\n\x05\x04\x14\x02\0\x04\x12\x06\xeb\x02\x08\
-    \xe9\x02\x1d\n\r\n\x05\x04\x14\x02\0\x05\x12\x04\xeb\x02\x08\r\n\r\n\x05\
-    \x04\x14\x02\0\x01\x12\x04\xeb\x02\x0e\x13\n\r\n\x05\x04\x14\x02\0\x03\
-    \x12\x04\xeb\x02\x16\x17\n3\n\x04\x04\x14\x02\x01\x12\x04\xee\x02\x08\
-    \x18\x1a%\x20Minor\x20is\x20the\x20device's\x20minor\x20number.\n\n\x0f\
-    \n\x05\x04\x14\x02\x01\x04\x12\x06\xee\x02\x08\xeb\x02\x18\n\r\n\x05\x04\
-    \x14\x02\x01\x05\x12\x04\xee\x02\x08\r\n\r\n\x05\x04\x14\x02\x01\x01\x12\
-    \x04\xee\x02\x0e\x13\n\r\n\x05\x04\x14\x02\x01\x03\x12\x04\xee\x02\x16\
-    \x17\n?\n\x04\x04\x14\x02\x02\x12\x04\xf1\x02\x08\x18\x1a1\x20Rate\x20is\
-    \x20the\x20IO\x20rate\x20limit\x20per\x20cgroup\x20per\x20device\n\n\x0f\
-    \n\x05\x04\x14\x02\x02\x04\x12\x06\xf1\x02\x08\xee\x02\x18\n\r\n\x05\x04\
-    \x14\x02\x02\x05\x12\x04\xf1\x02\x08\x0e\n\r\n\x05\x04\x14\x02\x02\x01\
-    \x12\x04\xf1\x02\x0f\x13\n\r\n\x05\x04\x14\x02\x02\x03\x12\x04\xf1\x02\
-    \x16\x17\n\x0c\n\x02\x04\x15\x12\x06\xf4\x02\0\x89\x03\x01\n\x0b\n\x03\
-    \x04\x15\x01\x12\x04\xf4\x02\x08\x14\n+\n\x04\x04\x15\x02\0\x12\x04\xf6\
-    \x02\x08\x1a\x1a\x1d\x20Specifies\x20per\x20cgroup\x20weight\n\n\x0f\n\
-    \x05\x04\x15\x02\0\x04\x12\x06\xf6\x02\x08\xf4\x02\x16\n\r\n\x05\x04\x15\
-    \x02\0\x05\x12\x04\xf6\x02\x08\x0e\n\r\n\x05\x04\x15\x02\0\x01\x12\x04\
-    \xf6\x02\x0f\x15\n\r\n\x05\x04\x15\x02\0\x03\x12\x04\xf6\x02\x18\x19\n\
-    \x7f\n\x04\x04\x15\x02\x01\x12\x04\xf9\x02\x08\x1e\x1aq\x20Specifies\x20\
-    tasks'\x20weight\x20in\x20the\x20given\x20cgroup\x20while\x20competing\
-    \x20with\x20the\x20cgroup's\x20child\x20cgroups,\x20CFQ\x20scheduler\x20\
-    only\n\n\x0f\n\x05\x04\x15\x02\x01\x04\x12\x06\xf9\x02\x08\xf6\x02\x1a\n\
-    \r\n\x05\x04\x15\x02\x01\x05\x12\x04\xf9\x02\x08\x0e\n\r\n\x05\x04\x15\
-    \x02\x01\x01\x12\x04\xf9\x02\x0f\x19\n\r\n\x05\x04\x15\x02\x01\x03\x12\
-    \x04\xf9\x02\x1c\x1d\nF\n\x04\x04\x15\x02\x02\x12\x04\xfc\x02\x08T\x1a8\
-    \x20Weight\x20per\x20cgroup\x20per\x20device,\x20can\x20override\x20Blki\
-    oWeight\n\n\r\n\x05\x04\x15\x02\x02\x04\x12\x04\xfc\x02\x08\x10\n\r\n\
-    \x05\x04\x15\x02\x02\x06\x12\x04\xfc\x02\x11\"\n\r\n\x05\x04\x15\x02\x02\
-    \x01\x12\x04\xfc\x02#/\n\r\n\x05\x04\x15\x02\x02\x03\x12\x04\xfc\x0223\n\
-    \r\n\x05\x04\x15\x02\x02\x08\x12\x04\xfc\x025S\n\x10\n\x08\x04\x15\x02\
-    \x02\x08\xe9\xfb\x03\x12\x04\xfc\x026R\nJ\n\x04\x04\x15\x02\x03\x12\x04\
-    \xff\x02\x08_\x1a<\x20IO\x20read\x20rate\x20limit\x20per\x20cgroup\x20pe\
-    r\x20device,\x20bytes\x20per\x20second\n\n\r\n\x05\x04\x15\x02\x03\x04\
-    \x12\x04\xff\x02\x08\x10\n\r\n\x05\x04\x15\x02\x03\x06\x12\x04\xff\x02\
-    \x11$\n\r\n\x05\x04\x15\x02\x03\x01\x12\x04\xff\x02%:\n\r\n\x05\x04\x15\
-    \x02\x03\x03\x12\x04\xff\x02=>\n\r\n\x05\x04\x15\x02\x03\x08\x12\x04\xff\
-    \x02@^\n\x10\n\x08\x04\x15\x02\x03\x08\xe9\xfb\x03\x12\x04\xff\x02A]\nK\
-    \n\x04\x04\x15\x02\x04\x12\x04\x82\x03\x08`\x1a=\x20IO\x20write\x20rate\
-    \x20limit\x20per\x20cgroup\x20per\x20device,\x20bytes\x20per\x20second\n\
-    \n\r\n\x05\x04\x15\x02\x04\x04\x12\x04\x82\x03\x08\x10\n\r\n\x05\x04\x15\
-    \x02\x04\x06\x12\x04\x82\x03\x11$\n\r\n\x05\x04\x15\x02\x04\x01\x12\x04\
-    \x82\x03%;\n\r\n\x05\x04\x15\x02\x04\x03\x12\x04\x82\x03>?\n\r\n\x05\x04\
-    \x15\x02\x04\x08\x12\x04\x82\x03A_\n\x10\n\x08\x04\x15\x02\x04\x08\xe9\
-    \xfb\x03\x12\x04\x82\x03B^\nG\n\x04\x04\x15\x02\x05\x12\x04\x85\x03\x08`\
-    \x1a9\x20IO\x20read\x20rate\x20limit\x20per\x20cgroup\x20per\x20device,\
-    \x20IO\x20per\x20second\n\n\r\n\x05\x04\x15\x02\x05\x04\x12\x04\x85\x03\
-    \x08\x10\n\r\n\x05\x04\x15\x02\x05\x06\x12\x04\x85\x03\x11$\n\r\n\x05\
-    \x04\x15\x02\x05\x01\x12\x04\x85\x03%;\n\r\n\x05\x04\x15\x02\x05\x03\x12\
-    \x04\x85\x03>?\n\r\n\x05\x04\x15\x02\x05\x08\x12\x04\x85\x03A_\n\x10\n\
-    \x08\x04\x15\x02\x05\x08\xe9\xfb\x03\x12\x04\x85\x03B^\nH\n\x04\x04\x15\
-    \x02\x06\x12\x04\x88\x03\x08a\x1a:\x20IO\x20write\x20rate\x20limit\x20pe\
-    r\x20cgroup\x20per\x20device,\x20IO\x20per\x20second\n\n\r\n\x05\x04\x15\
-    \x02\x06\x04\x12\x04\x88\x03\x08\x10\n\r\n\x05\x04\x15\x02\x06\x06\x12\
-    \x04\x88\x03\x11$\n\r\n\x05\x04\x15\x02\x06\x01\x12\x04\x88\x03%<\n\r\n\
-    \x05\x04\x15\x02\x06\x03\x12\x04\x88\x03?@\n\r\n\x05\x04\x15\x02\x06\x08\
-    \x12\x04\x88\x03B`\n\x10\n\x08\x04\x15\x02\x06\x08\xe9\xfb\x03\x12\x04\
-    \x88\x03C_\n\x0c\n\x02\x04\x16\x12\x06\x8b\x03\0\x8e\x03\x01\n\x0b\n\x03\
-    \x04\x16\x01\x12\x04\x8b\x03\x08\x11\n>\n\x04\x04\x16\x02\0\x12\x04\x8d\
-    \x03\x08\x18\x1a0\x20Maximum\x20number\x20of\x20PIDs.\x20Default\x20is\
-    \x20\"no\x20limit\".\n\n\x0f\n\x05\x04\x16\x02\0\x04\x12\x06\x8d\x03\x08\
-    \x8b\x03\x13\n\r\n\x05\x04\x16\x02\0\x05\x12\x04\x8d\x03\x08\r\n\r\n\x05\
-    \x04\x16\x02\0\x01\x12\x04\x8d\x03\x0e\x13\n\r\n\x05\x04\x16\x02\0\x03\
-    \x12\x04\x8d\x03\x16\x17\n\x0c\n\x02\x04\x17\x12\x06\x90\x03\0\x9f\x03\
-    \x01\n\x0b\n\x03\x04\x17\x01\x12\x04\x90\x03\x08\x19\n\x1d\n\x04\x04\x17\
-    \x02\0\x12\x04\x92\x03\x08\x17\x1a\x0f\x20Allow\x20or\x20deny\n\n\x0f\n\
-    \x05\x04\x17\x02\0\x04\x12\x06\x92\x03\x08\x90\x03\x1b\n\r\n\x05\x04\x17\
-    \x02\0\x05\x12\x04\x92\x03\x08\x0c\n\r\n\x05\x04\x17\x02\0\x01\x12\x04\
-    \x92\x03\r\x12\n\r\n\x05\x04\x17\x02\0\x03\x12\x04\x92\x03\x15\x16\n.\n\
-    \x04\x04\x17\x02\x01\x12\x04\x95\x03\x08\x18\x1a\x20\x20Device\x20type,\
-    \x20block,\x20char,\x20etc.\n\n\x0f\n\x05\x04\x17\x02\x01\x04\x12\x06\
-    \x95\x03\x08\x92\x03\x17\n\r\n\x05\x04\x17\x02\x01\x05\x12\x04\x95\x03\
-    \x08\x0e\n\r\n\x05\x04\x17\x02\x01\x01\x12\x04\x95\x03\x0f\x13\n\r\n\x05\
-    \x04\x17\x02\x01\x03\x12\x04\x95\x03\x16\x17\n3\n\x04\x04\x17\x02\x02\
-    \x12\x04\x98\x03\x08\x18\x1a%\x20Major\x20is\x20the\x20device's\x20major\
-    \x20number.\n\n\x0f\n\x05\x04\x17\x02\x02\x04\x12\x06\x98\x03\x08\x95\
-    \x03\x18\n\r\n\x05\x04\x17\x02\x02\x05\x12\x04\x98\x03\x08\r\n\r\n\x05\
-    \x04\x17\x02\x02\x01\x12\x04\x98\x03\x0e\x13\n\r\n\x05\x04\x17\x02\x02\
-    \x03\x12\x04\x98\x03\x16\x17\n3\n\x04\x04\x17\x02\x03\x12\x04\x9b\x03\
+    \"tmp:/\"\n\n\x0f\n\x05\x04\x06\x02\0\x04\x12\x06\x8f\x01\x08\x8d\x01\
+    \x0f\n\r\n\x05\x04\x06\x02\0\x05\x12\x04\x8f\x01\x08\x0e\n\r\n\x05\x04\
+    \x06\x02\0\x01\x12\x04\x8f\x01\x0f\x1a\n\r\n\x05\x04\x06\x02\0\x03\x12\
+    \x04\x8f\x01\x1d\x1e\n\xb4\x02\n\x04\x04\x06\x02\x01\x12\x04\x95\x01\x08\
+    \x1a\x1a\xa5\x02\x20source\x20is\x20the\x20path\x20inside\x20the\x20cont\
+    ainer\x20expect\x20when\x20it\x20starts\x20with\x20\"vm:/dev/\"\x20or\
+    \x20\"tmp:/\"\n\x20the\x20path\x20which\x20starts\x20with\x20\"vm:/dev/\
+    \"\x20refers\x20the\x20guest\x20vm's\x20\"/dev\",\n\x20especially,\x20\"\
+    vm:/dev/hostfs/\"\x20refers\x20to\x20the\x20shared\x20filesystem.\n\x20\
+    \"tmp:/\"\x20is\x20a\x20temporary\x20directory\x20which\x20is\x20used\
+    \x20for\x20temporary\x20mounts.\n\n\x0f\n\x05\x04\x06\x02\x01\x04\x12\
+    \x06\x95\x01\x08\x8f\x01\x1f\n\r\n\x05\x04\x06\x02\x01\x05\x12\x04\x95\
+    \x01\x08\x0e\n\r\n\x05\x04\x06\x02\x01\x01\x12\x04\x95\x01\x0f\x15\n\r\n\
+    \x05\x04\x06\x02\x01\x03\x12\x04\x95\x01\x18\x19\n\x0c\n\x04\x04\x06\x02\
+    \x02\x12\x04\x96\x01\x08\x18\n\x0f\n\x05\x04\x06\x02\x02\x04\x12\x06\x96\
+    \x01\x08\x95\x01\x1a\n\r\n\x05\x04\x06\x02\x02\x05\x12\x04\x96\x01\x08\
+    \x0e\n\r\n\x05\x04\x06\x02\x02\x01\x12\x04\x96\x01\x0f\x13\n\r\n\x05\x04\
+    \x06\x02\x02\x03\x12\x04\x96\x01\x16\x17\n\x0c\n\x04\x04\x06\x02\x03\x12\
+    \x04\x97\x01\x08$\n\r\n\x05\x04\x06\x02\x03\x04\x12\x04\x97\x01\x08\x10\
+    \n\r\n\x05\x04\x06\x02\x03\x05\x12\x04\x97\x01\x11\x17\n\r\n\x05\x04\x06\
+    \x02\x03\x01\x12\x04\x97\x01\x18\x1f\n\r\n\x05\x04\x06\x02\x03\x03\x12\
+    \x04\x97\x01\"#\n\x0c\n\x02\x04\x07\x12\x06\x9a\x01\0\xa0\x01\x01\n\x0b\
+    \n\x03\x04\x07\x01\x12\x04\x9a\x01\x08\x0c\nM\n\x04\x04\x07\x02\0\x12\
+    \x04\x9c\x01\x08\x18\x1a?\x20Path\x20is\x20the\x20absolute\x20path\x20to\
+    \x20the\x20container's\x20root\x20filesystem.\n\n\x0f\n\x05\x04\x07\x02\
+    \0\x04\x12\x06\x9c\x01\x08\x9a\x01\x0e\n\r\n\x05\x04\x07\x02\0\x05\x12\
+    \x04\x9c\x01\x08\x0e\n\r\n\x05\x04\x07\x02\0\x01\x12\x04\x9c\x01\x0f\x13\
+    \n\r\n\x05\x04\x07\x02\0\x03\x12\x04\x9c\x01\x16\x17\nm\n\x04\x04\x07\
+    \x02\x01\x12\x04\x9f\x01\x08\x1a\x1a_\x20Readonly\x20makes\x20the\x20roo\
+    t\x20filesystem\x20for\x20the\x20container\x20readonly\x20before\x20the\
+    \x20process\x20is\x20executed.\n\n\x0f\n\x05\x04\x07\x02\x01\x04\x12\x06\
+    \x9f\x01\x08\x9c\x01\x18\n\r\n\x05\x04\x07\x02\x01\x05\x12\x04\x9f\x01\
+    \x08\x0c\n\r\n\x05\x04\x07\x02\x01\x01\x12\x04\x9f\x01\r\x15\n\r\n\x05\
+    \x04\x07\x02\x01\x03\x12\x04\x9f\x01\x18\x19\n\x0c\n\x02\x04\x08\x12\x06\
+    \xa2\x01\0\xab\x01\x01\n\x0b\n\x03\x04\x08\x01\x12\x04\xa2\x01\x08\r\n_\
+    \n\x04\x04\x08\x02\0\x12\x04\xa4\x01\x08C\x1aQ\x20Prestart\x20is\x20a\
+    \x20list\x20of\x20hooks\x20to\x20be\x20run\x20before\x20the\x20container\
+    \x20process\x20is\x20executed.\n\n\r\n\x05\x04\x08\x02\0\x04\x12\x04\xa4\
+    \x01\x08\x10\n\r\n\x05\x04\x08\x02\0\x06\x12\x04\xa4\x01\x11\x15\n\r\n\
+    \x05\x04\x08\x02\0\x01\x12\x04\xa4\x01\x16\x1e\n\r\n\x05\x04\x08\x02\0\
+    \x03\x12\x04\xa4\x01!\"\n\r\n\x05\x04\x08\x02\0\x08\x12\x04\xa4\x01$B\n\
+    \x10\n\x08\x04\x08\x02\0\x08\xe9\xfb\x03\x12\x04\xa4\x01%A\n^\n\x04\x04\
+    \x08\x02\x01\x12\x04\xa7\x01\x08D\x1aP\x20Poststart\x20is\x20a\x20list\
+    \x20of\x20hooks\x20to\x20be\x20run\x20after\x20the\x20container\x20proce\
+    ss\x20is\x20started.\n\n\r\n\x05\x04\x08\x02\x01\x04\x12\x04\xa7\x01\x08\
+    \x10\n\r\n\x05\x04\x08\x02\x01\x06\x12\x04\xa7\x01\x11\x15\n\r\n\x05\x04\
+    \x08\x02\x01\x01\x12\x04\xa7\x01\x16\x1f\n\r\n\x05\x04\x08\x02\x01\x03\
+    \x12\x04\xa7\x01\"#\n\r\n\x05\x04\x08\x02\x01\x08\x12\x04\xa7\x01%C\n\
+    \x10\n\x08\x04\x08\x02\x01\x08\xe9\xfb\x03\x12\x04\xa7\x01&B\nX\n\x04\
+    \x04\x08\x02\x02\x12\x04\xaa\x01\x08C\x1aJ\x20Poststop\x20is\x20a\x20lis\
+    t\x20of\x20hooks\x20to\x20be\x20run\x20after\x20the\x20container\x20proc\
+    ess\x20exits.\n\n\r\n\x05\x04\x08\x02\x02\x04\x12\x04\xaa\x01\x08\x10\n\
+    \r\n\x05\x04\x08\x02\x02\x06\x12\x04\xaa\x01\x11\x15\n\r\n\x05\x04\x08\
+    \x02\x02\x01\x12\x04\xaa\x01\x16\x1e\n\r\n\x05\x04\x08\x02\x02\x03\x12\
+    \x04\xaa\x01!\"\n\r\n\x05\x04\x08\x02\x02\x08\x12\x04\xaa\x01$B\n\x10\n\
+    \x08\x04\x08\x02\x02\x08\xe9\xfb\x03\x12\x04\xaa\x01%A\n\x0c\n\x02\x04\t\
+    \x12\x06\xad\x01\0\xb2\x01\x01\n\x0b\n\x03\x04\t\x01\x12\x04\xad\x01\x08\
+    \x0c\n\x0c\n\x04\x04\t\x02\0\x12\x04\xae\x01\x08\x18\n\x0f\n\x05\x04\t\
+    \x02\0\x04\x12\x06\xae\x01\x08\xad\x01\x0e\n\r\n\x05\x04\t\x02\0\x05\x12\
+    \x04\xae\x01\x08\x0e\n\r\n\x05\x04\t\x02\0\x01\x12\x04\xae\x01\x0f\x13\n\
+    \r\n\x05\x04\t\x02\0\x03\x12\x04\xae\x01\x16\x17\n\x0c\n\x04\x04\t\x02\
+    \x01\x12\x04\xaf\x01\x08!\n\r\n\x05\x04\t\x02\x01\x04\x12\x04\xaf\x01\
+    \x08\x10\n\r\n\x05\x04\t\x02\x01\x05\x12\x04\xaf\x01\x11\x17\n\r\n\x05\
+    \x04\t\x02\x01\x01\x12\x04\xaf\x01\x18\x1c\n\r\n\x05\x04\t\x02\x01\x03\
+    \x12\x04\xaf\x01\x1f\x20\n\x0c\n\x04\x04\t\x02\x02\x12\x04\xb0\x01\x08\
+    \x20\n\r\n\x05\x04\t\x02\x02\x04\x12\x04\xb0\x01\x08\x10\n\r\n\x05\x04\t\
+    \x02\x02\x05\x12\x04\xb0\x01\x11\x17\n\r\n\x05\x04\t\x02\x02\x01\x12\x04\
+    \xb0\x01\x18\x1b\n\r\n\x05\x04\t\x02\x02\x03\x12\x04\xb0\x01\x1e\x1f\n\
+    \x0c\n\x04\x04\t\x02\x03\x12\x04\xb1\x01\x08\x1a\n\x0f\n\x05\x04\t\x02\
+    \x03\x04\x12\x06\xb1\x01\x08\xb0\x01\x20\n\r\n\x05\x04\t\x02\x03\x05\x12\
+    \x04\xb1\x01\x08\r\n\r\n\x05\x04\t\x02\x03\x01\x12\x04\xb1\x01\x0e\x15\n\
+    \r\n\x05\x04\t\x02\x03\x03\x12\x04\xb1\x01\x18\x19\n\x0c\n\x02\x04\n\x12\
+    \x06\xb4\x01\0\xdf\x01\x01\n\x0b\n\x03\x04\n\x01\x12\x04\xb4\x01\x08\r\n\
+    R\n\x04\x04\n\x02\0\x12\x04\xb6\x01\x08P\x1aD\x20UIDMapping\x20specifies\
+    \x20user\x20mappings\x20for\x20supporting\x20user\x20namespaces.\n\n\r\n\
+    \x05\x04\n\x02\0\x04\x12\x04\xb6\x01\x08\x10\n\r\n\x05\x04\n\x02\0\x06\
+    \x12\x04\xb6\x01\x11\x1f\n\r\n\x05\x04\n\x02\0\x01\x12\x04\xb6\x01\x20+\
+    \n\r\n\x05\x04\n\x02\0\x03\x12\x04\xb6\x01./\n\r\n\x05\x04\n\x02\0\x08\
+    \x12\x04\xb6\x011O\n\x10\n\x08\x04\n\x02\0\x08\xe9\xfb\x03\x12\x04\xb6\
+    \x012N\nS\n\x04\x04\n\x02\x01\x12\x04\xb9\x01\x08P\x1aE\x20GIDMapping\
+    \x20specifies\x20group\x20mappings\x20for\x20supporting\x20user\x20names\
+    paces.\n\n\r\n\x05\x04\n\x02\x01\x04\x12\x04\xb9\x01\x08\x10\n\r\n\x05\
+    \x04\n\x02\x01\x06\x12\x04\xb9\x01\x11\x1f\n\r\n\x05\x04\n\x02\x01\x01\
+    \x12\x04\xb9\x01\x20+\n\r\n\x05\x04\n\x02\x01\x03\x12\x04\xb9\x01./\n\r\
+    \n\x05\x04\n\x02\x01\x08\x12\x04\xb9\x011O\n\x10\n\x08\x04\n\x02\x01\x08\
+    \xe9\xfb\x03\x12\x04\xb9\x012N\n[\n\x04\x04\n\x02\x02\x12\x04\xbc\x01\
+    \x08'\x1aM\x20Sysctl\x20are\x20a\x20set\x20of\x20key\x20value\x20pairs\
+    \x20that\x20are\x20set\x20for\x20the\x20container\x20on\x20start\n\n\x0f\
+    \n\x05\x04\n\x02\x02\x04\x12\x06\xbc\x01\x08\xb9\x01P\n\r\n\x05\x04\n\
+    \x02\x02\x06\x12\x04\xbc\x01\x08\x1b\n\r\n\x05\x04\n\x02\x02\x01\x12\x04\
+    \xbc\x01\x1c\"\n\r\n\x05\x04\n\x02\x02\x03\x12\x04\xbc\x01%&\ni\n\x04\
+    \x04\n\x02\x03\x12\x04\xc0\x01\x08%\x1a[\x20Resources\x20contain\x20cgro\
+    up\x20information\x20for\x20handling\x20resource\x20constraints\n\x20for\
+    \x20the\x20container\n\n\x0f\n\x05\x04\n\x02\x03\x04\x12\x06\xc0\x01\x08\
+    \xbc\x01'\n\r\n\x05\x04\n\x02\x03\x06\x12\x04\xc0\x01\x08\x16\n\r\n\x05\
+    \x04\n\x02\x03\x01\x12\x04\xc0\x01\x17\x20\n\r\n\x05\x04\n\x02\x03\x03\
+    \x12\x04\xc0\x01#$\n\x87\x02\n\x04\x04\n\x02\x04\x12\x04\xc5\x01\x08\x1f\
+    \x1a\xf8\x01\x20CgroupsPath\x20specifies\x20the\x20path\x20to\x20cgroups\
+    \x20that\x20are\x20created\x20and/or\x20joined\x20by\x20the\x20container\
+    .\n\x20The\x20path\x20is\x20expected\x20to\x20be\x20relative\x20to\x20th\
+    e\x20cgroups\x20mountpoint.\n\x20If\x20resources\x20are\x20specified,\
+    \x20the\x20cgroups\x20at\x20CgroupsPath\x20will\x20be\x20updated\x20base\
+    d\x20on\x20resources.\n\n\x0f\n\x05\x04\n\x02\x04\x04\x12\x06\xc5\x01\
+    \x08\xc0\x01%\n\r\n\x05\x04\n\x02\x04\x05\x12\x04\xc5\x01\x08\x0e\n\r\n\
+    \x05\x04\n\x02\x04\x01\x12\x04\xc5\x01\x0f\x1a\n\r\n\x05\x04\n\x02\x04\
+    \x03\x12\x04\xc5\x01\x1d\x1e\nb\n\x04\x04\n\x02\x05\x12\x04\xc8\x01\x08O\
+    \x1aT\x20Namespaces\x20contains\x20the\x20namespaces\x20that\x20are\x20c\
+    reated\x20and/or\x20joined\x20by\x20the\x20container\n\n\r\n\x05\x04\n\
+    \x02\x05\x04\x12\x04\xc8\x01\x08\x10\n\r\n\x05\x04\n\x02\x05\x06\x12\x04\
+    \xc8\x01\x11\x1f\n\r\n\x05\x04\n\x02\x05\x01\x12\x04\xc8\x01\x20*\n\r\n\
+    \x05\x04\n\x02\x05\x03\x12\x04\xc8\x01-.\n\r\n\x05\x04\n\x02\x05\x08\x12\
+    \x04\xc8\x010N\n\x10\n\x08\x04\n\x02\x05\x08\xe9\xfb\x03\x12\x04\xc8\x01\
+    1M\nU\n\x04\x04\n\x02\x06\x12\x04\xcb\x01\x08I\x1aG\x20Devices\x20are\
+    \x20a\x20list\x20of\x20device\x20nodes\x20that\x20are\x20created\x20for\
+    \x20the\x20container\n\n\r\n\x05\x04\n\x02\x06\x04\x12\x04\xcb\x01\x08\
+    \x10\n\r\n\x05\x04\n\x02\x06\x06\x12\x04\xcb\x01\x11\x1c\n\r\n\x05\x04\n\
+    \x02\x06\x01\x12\x04\xcb\x01\x1d$\n\r\n\x05\x04\n\x02\x06\x03\x12\x04\
+    \xcb\x01'(\n\r\n\x05\x04\n\x02\x06\x08\x12\x04\xcb\x01*H\n\x10\n\x08\x04\
+    \n\x02\x06\x08\xe9\xfb\x03\x12\x04\xcb\x01+G\nR\n\x04\x04\n\x02\x07\x12\
+    \x04\xce\x01\x08!\x1aD\x20Seccomp\x20specifies\x20the\x20seccomp\x20secu\
+    rity\x20settings\x20for\x20the\x20container.\n\n\x0f\n\x05\x04\n\x02\x07\
+    \x04\x12\x06\xce\x01\x08\xcb\x01I\n\r\n\x05\x04\n\x02\x07\x06\x12\x04\
+    \xce\x01\x08\x14\n\r\n\x05\x04\n\x02\x07\x01\x12\x04\xce\x01\x15\x1c\n\r\
+    \n\x05\x04\n\x02\x07\x03\x12\x04\xce\x01\x1f\x20\nY\n\x04\x04\n\x02\x08\
+    \x12\x04\xd1\x01\x08%\x1aK\x20RootfsPropagation\x20is\x20the\x20rootfs\
+    \x20mount\x20propagation\x20mode\x20for\x20the\x20container.\n\n\x0f\n\
+    \x05\x04\n\x02\x08\x04\x12\x06\xd1\x01\x08\xce\x01!\n\r\n\x05\x04\n\x02\
+    \x08\x05\x12\x04\xd1\x01\x08\x0e\n\r\n\x05\x04\n\x02\x08\x01\x12\x04\xd1\
+    \x01\x0f\x20\n\r\n\x05\x04\n\x02\x08\x03\x12\x04\xd1\x01#$\nO\n\x04\x04\
+    \n\x02\t\x12\x04\xd4\x01\x08)\x1aA\x20MaskedPaths\x20masks\x20over\x20th\
+    e\x20provided\x20paths\x20inside\x20the\x20container.\n\n\r\n\x05\x04\n\
+    \x02\t\x04\x12\x04\xd4\x01\x08\x10\n\r\n\x05\x04\n\x02\t\x05\x12\x04\xd4\
+    \x01\x11\x17\n\r\n\x05\x04\n\x02\t\x01\x12\x04\xd4\x01\x18#\n\r\n\x05\
+    \x04\n\x02\t\x03\x12\x04\xd4\x01&(\nQ\n\x04\x04\n\x02\n\x12\x04\xd7\x01\
+    \x08+\x1aC\x20ReadonlyPaths\x20sets\x20the\x20provided\x20paths\x20as\
+    \x20RO\x20inside\x20the\x20container.\n\n\r\n\x05\x04\n\x02\n\x04\x12\
+    \x04\xd7\x01\x08\x10\n\r\n\x05\x04\n\x02\n\x05\x12\x04\xd7\x01\x11\x17\n\
+    \r\n\x05\x04\n\x02\n\x01\x12\x04\xd7\x01\x18%\n\r\n\x05\x04\n\x02\n\x03\
+    \x12\x04\xd7\x01(*\nY\n\x04\x04\n\x02\x0b\x12\x04\xda\x01\x08\x1f\x1aK\
+    \x20MountLabel\x20specifies\x20the\x20selinux\x20context\x20for\x20the\
+    \x20mounts\x20in\x20the\x20container.\n\n\x0f\n\x05\x04\n\x02\x0b\x04\
+    \x12\x06\xda\x01\x08\xd7\x01+\n\r\n\x05\x04\n\x02\x0b\x05\x12\x04\xda\
+    \x01\x08\x0e\n\r\n\x05\x04\n\x02\x0b\x01\x12\x04\xda\x01\x0f\x19\n\r\n\
+    \x05\x04\n\x02\x0b\x03\x12\x04\xda\x01\x1c\x1e\n\x9d\x01\n\x04\x04\n\x02\
+    \x0c\x12\x04\xde\x01\x08$\x1a\x8e\x01\x20IntelRdt\x20contains\x20Intel\
+    \x20Resource\x20Director\x20Technology\x20(RDT)\x20information\n\x20for\
+    \x20handling\x20resource\x20constraints\x20(e.g.,\x20L3\x20cache)\x20for\
+    \x20the\x20container\n\n\x0f\n\x05\x04\n\x02\x0c\x04\x12\x06\xde\x01\x08\
+    \xda\x01\x1f\n\r\n\x05\x04\n\x02\x0c\x06\x12\x04\xde\x01\x08\x15\n\r\n\
+    \x05\x04\n\x02\x0c\x01\x12\x04\xde\x01\x16\x1e\n\r\n\x05\x04\n\x02\x0c\
+    \x03\x12\x04\xde\x01!#\n\x0c\n\x02\x04\x0b\x12\x06\xe1\x01\0\xe4\x01\x01\
+    \n\x0b\n\x03\x04\x0b\x01\x12\x04\xe1\x01\x08\x0f\n)\n\x04\x04\x0b\x02\0\
+    \x12\x04\xe3\x01\x08\x19\x1a\x1b\x20Dummy\x20string,\x20never\x20used.\n\
+    \n\x0f\n\x05\x04\x0b\x02\0\x04\x12\x06\xe3\x01\x08\xe1\x01\x11\n\r\n\x05\
+    \x04\x0b\x02\0\x05\x12\x04\xe3\x01\x08\x0e\n\r\n\x05\x04\x0b\x02\0\x01\
+    \x12\x04\xe3\x01\x0f\x14\n\r\n\x05\x04\x0b\x02\0\x03\x12\x04\xe3\x01\x17\
+    \x18\n\x0c\n\x02\x04\x0c\x12\x06\xe6\x01\0\xe9\x01\x01\n\x0b\n\x03\x04\
+    \x0c\x01\x12\x04\xe6\x01\x08\x0f\n)\n\x04\x04\x0c\x02\0\x12\x04\xe8\x01\
+    \x08\x19\x1a\x1b\x20Dummy\x20string,\x20never\x20used.\n\n\x0f\n\x05\x04\
+    \x0c\x02\0\x04\x12\x06\xe8\x01\x08\xe6\x01\x11\n\r\n\x05\x04\x0c\x02\0\
+    \x05\x12\x04\xe8\x01\x08\x0e\n\r\n\x05\x04\x0c\x02\0\x01\x12\x04\xe8\x01\
+    \x0f\x14\n\r\n\x05\x04\x0c\x02\0\x03\x12\x04\xe8\x01\x17\x18\n\x0c\n\x02\
+    \x04\r\x12\x06\xeb\x01\0\xf4\x01\x01\n\x0b\n\x03\x04\r\x01\x12\x04\xeb\
+    \x01\x08\x16\nX\n\x04\x04\r\x02\0\x12\x04\xed\x01\x08\x1a\x1aJ\x20HostID\
+    \x20is\x20the\x20starting\x20UID/GID\x20on\x20the\x20host\x20to\x20be\
+    \x20mapped\x20to\x20'ContainerID'\n\n\x0f\n\x05\x04\r\x02\0\x04\x12\x06\
+    \xed\x01\x08\xeb\x01\x18\n\r\n\x05\x04\r\x02\0\x05\x12\x04\xed\x01\x08\
+    \x0e\n\r\n\x05\x04\r\x02\0\x01\x12\x04\xed\x01\x0f\x15\n\r\n\x05\x04\r\
+    \x02\0\x03\x12\x04\xed\x01\x18\x19\nD\n\x04\x04\r\x02\x01\x12\x04\xf0\
+    \x01\x08\x1f\x1a6\x20ContainerID\x20is\x20the\x20starting\x20UID/GID\x20\
+    in\x20the\x20container\n\n\x0f\n\x05\x04\r\x02\x01\x04\x12\x06\xf0\x01\
+    \x08\xed\x01\x1a\n\r\n\x05\x04\r\x02\x01\x05\x12\x04\xf0\x01\x08\x0e\n\r\
+    \n\x05\x04\r\x02\x01\x01\x12\x04\xf0\x01\x0f\x1a\n\r\n\x05\x04\r\x02\x01\
+    \x03\x12\x04\xf0\x01\x1d\x1e\n6\n\x04\x04\r\x02\x02\x12\x04\xf3\x01\x08\
+    \x18\x1a(\x20Size\x20is\x20the\x20number\x20of\x20IDs\x20to\x20be\x20map\
+    ped\n\n\x0f\n\x05\x04\r\x02\x02\x04\x12\x06\xf3\x01\x08\xf0\x01\x1f\n\r\
+    \n\x05\x04\r\x02\x02\x05\x12\x04\xf3\x01\x08\x0e\n\r\n\x05\x04\r\x02\x02\
+    \x01\x12\x04\xf3\x01\x0f\x13\n\r\n\x05\x04\r\x02\x02\x03\x12\x04\xf3\x01\
+    \x16\x17\n\x0c\n\x02\x04\x0e\x12\x06\xf6\x01\0\xfd\x01\x01\n\x0b\n\x03\
+    \x04\x0e\x01\x12\x04\xf6\x01\x08\x16\n-\n\x04\x04\x0e\x02\0\x12\x04\xf8\
+    \x01\x08\x18\x1a\x1f\x20Type\x20is\x20the\x20type\x20of\x20namespace\n\n\
+    \x0f\n\x05\x04\x0e\x02\0\x04\x12\x06\xf8\x01\x08\xf6\x01\x18\n\r\n\x05\
+    \x04\x0e\x02\0\x05\x12\x04\xf8\x01\x08\x0e\n\r\n\x05\x04\x0e\x02\0\x01\
+    \x12\x04\xf8\x01\x0f\x13\n\r\n\x05\x04\x0e\x02\0\x03\x12\x04\xf8\x01\x16\
+    \x17\nu\n\x04\x04\x0e\x02\x01\x12\x04\xfc\x01\x08\x18\x1ag\x20Path\x20is\
+    \x20a\x20path\x20to\x20an\x20existing\x20namespace\x20persisted\x20on\
+    \x20disk\x20that\x20can\x20be\x20joined\n\x20and\x20is\x20of\x20the\x20s\
+    ame\x20type\n\n\x0f\n\x05\x04\x0e\x02\x01\x04\x12\x06\xfc\x01\x08\xf8\
+    \x01\x18\n\r\n\x05\x04\x0e\x02\x01\x05\x12\x04\xfc\x01\x08\x0e\n\r\n\x05\
+    \x04\x0e\x02\x01\x01\x12\x04\xfc\x01\x0f\x13\n\r\n\x05\x04\x0e\x02\x01\
+    \x03\x12\x04\xfc\x01\x16\x17\n\x0c\n\x02\x04\x0f\x12\x06\xff\x01\0\x94\
+    \x02\x01\n\x0b\n\x03\x04\x0f\x01\x12\x04\xff\x01\x08\x13\n#\n\x04\x04\
+    \x0f\x02\0\x12\x04\x81\x02\x08\x18\x1a\x15\x20Path\x20to\x20the\x20devic\
+    e.\n\n\x0f\n\x05\x04\x0f\x02\0\x04\x12\x06\x81\x02\x08\xff\x01\x15\n\r\n\
+    \x05\x04\x0f\x02\0\x05\x12\x04\x81\x02\x08\x0e\n\r\n\x05\x04\x0f\x02\0\
+    \x01\x12\x04\x81\x02\x0f\x13\n\r\n\x05\x04\x0f\x02\0\x03\x12\x04\x81\x02\
+    \x16\x17\n.\n\x04\x04\x0f\x02\x01\x12\x04\x84\x02\x08\x18\x1a\x20\x20Dev\
+    ice\x20type,\x20block,\x20char,\x20etc.\n\n\x0f\n\x05\x04\x0f\x02\x01\
+    \x04\x12\x06\x84\x02\x08\x81\x02\x18\n\r\n\x05\x04\x0f\x02\x01\x05\x12\
+    \x04\x84\x02\x08\x0e\n\r\n\x05\x04\x0f\x02\x01\x01\x12\x04\x84\x02\x0f\
+    \x13\n\r\n\x05\x04\x0f\x02\x01\x03\x12\x04\x84\x02\x16\x17\n3\n\x04\x04\
+    \x0f\x02\x02\x12\x04\x87\x02\x08\x18\x1a%\x20Major\x20is\x20the\x20devic\
+    e's\x20major\x20number.\n\n\x0f\n\x05\x04\x0f\x02\x02\x04\x12\x06\x87\
+    \x02\x08\x84\x02\x18\n\r\n\x05\x04\x0f\x02\x02\x05\x12\x04\x87\x02\x08\r\
+    \n\r\n\x05\x04\x0f\x02\x02\x01\x12\x04\x87\x02\x0e\x13\n\r\n\x05\x04\x0f\
+    \x02\x02\x03\x12\x04\x87\x02\x16\x17\n3\n\x04\x04\x0f\x02\x03\x12\x04\
+    \x8a\x02\x08\x18\x1a%\x20Minor\x20is\x20the\x20device's\x20minor\x20numb\
+    er.\n\n\x0f\n\x05\x04\x0f\x02\x03\x04\x12\x06\x8a\x02\x08\x87\x02\x18\n\
+    \r\n\x05\x04\x0f\x02\x03\x05\x12\x04\x8a\x02\x08\r\n\r\n\x05\x04\x0f\x02\
+    \x03\x01\x12\x04\x8a\x02\x0e\x13\n\r\n\x05\x04\x0f\x02\x03\x03\x12\x04\
+    \x8a\x02\x16\x17\n8\n\x04\x04\x0f\x02\x04\x12\x04\x8d\x02\x08\x1c\x1a*\
+    \x20FileMode\x20permission\x20bits\x20for\x20the\x20device.\n\n\x0f\n\
+    \x05\x04\x0f\x02\x04\x04\x12\x06\x8d\x02\x08\x8a\x02\x18\n\r\n\x05\x04\
+    \x0f\x02\x04\x05\x12\x04\x8d\x02\x08\x0e\n\r\n\x05\x04\x0f\x02\x04\x01\
+    \x12\x04\x8d\x02\x0f\x17\n\r\n\x05\x04\x0f\x02\x04\x03\x12\x04\x8d\x02\
+    \x1a\x1b\n\"\n\x04\x04\x0f\x02\x05\x12\x04\x90\x02\x08\x17\x1a\x14\x20UI\
+    D\x20of\x20the\x20device.\n\n\x0f\n\x05\x04\x0f\x02\x05\x04\x12\x06\x90\
+    \x02\x08\x8d\x02\x1c\n\r\n\x05\x04\x0f\x02\x05\x05\x12\x04\x90\x02\x08\
+    \x0e\n\r\n\x05\x04\x0f\x02\x05\x01\x12\x04\x90\x02\x0f\x12\n\r\n\x05\x04\
+    \x0f\x02\x05\x03\x12\x04\x90\x02\x15\x16\n\"\n\x04\x04\x0f\x02\x06\x12\
+    \x04\x93\x02\x08\x17\x1a\x14\x20Gid\x20of\x20the\x20device.\n\n\x0f\n\
+    \x05\x04\x0f\x02\x06\x04\x12\x06\x93\x02\x08\x90\x02\x17\n\r\n\x05\x04\
+    \x0f\x02\x06\x05\x12\x04\x93\x02\x08\x0e\n\r\n\x05\x04\x0f\x02\x06\x01\
+    \x12\x04\x93\x02\x0f\x12\n\r\n\x05\x04\x0f\x02\x06\x03\x12\x04\x93\x02\
+    \x15\x16\n\x0c\n\x02\x04\x10\x12\x06\x96\x02\0\xab\x02\x01\n\x0b\n\x03\
+    \x04\x10\x01\x12\x04\x96\x02\x08\x16\n8\n\x04\x04\x10\x02\0\x12\x04\x98\
+    \x02\x08O\x1a*\x20Devices\x20configures\x20the\x20device\x20whitelist.\n\
+    \n\r\n\x05\x04\x10\x02\0\x04\x12\x04\x98\x02\x08\x10\n\r\n\x05\x04\x10\
+    \x02\0\x06\x12\x04\x98\x02\x11\"\n\r\n\x05\x04\x10\x02\0\x01\x12\x04\x98\
+    \x02#*\n\r\n\x05\x04\x10\x02\0\x03\x12\x04\x98\x02-.\n\r\n\x05\x04\x10\
+    \x02\0\x08\x12\x04\x98\x020N\n\x10\n\x08\x04\x10\x02\0\x08\xe9\xfb\x03\
+    \x12\x04\x98\x021M\n0\n\x04\x04\x10\x02\x01\x12\x04\x9b\x02\x08\x1f\x1a\
+    \"\x20Memory\x20restriction\x20configuration\n\n\x0f\n\x05\x04\x10\x02\
+    \x01\x04\x12\x06\x9b\x02\x08\x98\x02O\n\r\n\x05\x04\x10\x02\x01\x06\x12\
+    \x04\x9b\x02\x08\x13\n\r\n\x05\x04\x10\x02\x01\x01\x12\x04\x9b\x02\x14\
+    \x1a\n\r\n\x05\x04\x10\x02\x01\x03\x12\x04\x9b\x02\x1d\x1e\n6\n\x04\x04\
+    \x10\x02\x02\x12\x04\x9e\x02\x08\x19\x1a(\x20CPU\x20resource\x20restrict\
+    ion\x20configuration\n\n\x0f\n\x05\x04\x10\x02\x02\x04\x12\x06\x9e\x02\
+    \x08\x9b\x02\x1f\n\r\n\x05\x04\x10\x02\x02\x06\x12\x04\x9e\x02\x08\x10\n\
+    \r\n\x05\x04\x10\x02\x02\x01\x12\x04\x9e\x02\x11\x14\n\r\n\x05\x04\x10\
+    \x02\x02\x03\x12\x04\x9e\x02\x17\x18\n8\n\x04\x04\x10\x02\x03\x12\x04\
+    \xa1\x02\x08\x1b\x1a*\x20Task\x20resource\x20restriction\x20configuratio\
+    n.\n\n\x0f\n\x05\x04\x10\x02\x03\x04\x12\x06\xa1\x02\x08\x9e\x02\x19\n\r\
+    \n\x05\x04\x10\x02\x03\x06\x12\x04\xa1\x02\x08\x11\n\r\n\x05\x04\x10\x02\
+    \x03\x01\x12\x04\xa1\x02\x12\x16\n\r\n\x05\x04\x10\x02\x03\x03\x12\x04\
+    \xa1\x02\x19\x1a\n1\n\x04\x04\x10\x02\x04\x12\x04\xa4\x02\x08!\x1a#\x20B\
+    lockIO\x20restriction\x20configuration\n\n\x0f\n\x05\x04\x10\x02\x04\x04\
+    \x12\x06\xa4\x02\x08\xa1\x02\x1b\n\r\n\x05\x04\x10\x02\x04\x06\x12\x04\
+    \xa4\x02\x08\x14\n\r\n\x05\x04\x10\x02\x04\x01\x12\x04\xa4\x02\x15\x1c\n\
+    \r\n\x05\x04\x10\x02\x04\x03\x12\x04\xa4\x02\x1f\x20\n(\n\x04\x04\x10\
+    \x02\x05\x12\x04\xa7\x02\x08W\x1a\x1a\x20Hugetlb\x20limit\x20(in\x20byte\
+    s)\n\n\r\n\x05\x04\x10\x02\x05\x04\x12\x04\xa7\x02\x08\x10\n\r\n\x05\x04\
+    \x10\x02\x05\x06\x12\x04\xa7\x02\x11#\n\r\n\x05\x04\x10\x02\x05\x01\x12\
+    \x04\xa7\x02$2\n\r\n\x05\x04\x10\x02\x05\x03\x12\x04\xa7\x0256\n\r\n\x05\
+    \x04\x10\x02\x05\x08\x12\x04\xa7\x028V\n\x10\n\x08\x04\x10\x02\x05\x08\
+    \xe9\xfb\x03\x12\x04\xa7\x029U\n1\n\x04\x04\x10\x02\x06\x12\x04\xaa\x02\
+    \x08!\x1a#\x20Network\x20restriction\x20configuration\n\n\x0f\n\x05\x04\
+    \x10\x02\x06\x04\x12\x06\xaa\x02\x08\xa7\x02W\n\r\n\x05\x04\x10\x02\x06\
+    \x06\x12\x04\xaa\x02\x08\x14\n\r\n\x05\x04\x10\x02\x06\x01\x12\x04\xaa\
+    \x02\x15\x1c\n\r\n\x05\x04\x10\x02\x06\x03\x12\x04\xaa\x02\x1f\x20\n\x0c\
+    \n\x02\x04\x11\x12\x06\xad\x02\0\xc2\x02\x01\n\x0b\n\x03\x04\x11\x01\x12\
+    \x04\xad\x02\x08\x13\n(\n\x04\x04\x11\x02\0\x12\x04\xaf\x02\x08\x18\x1a\
+    \x1a\x20Memory\x20limit\x20(in\x20bytes).\n\n\x0f\n\x05\x04\x11\x02\0\
+    \x04\x12\x06\xaf\x02\x08\xad\x02\x15\n\r\n\x05\x04\x11\x02\0\x05\x12\x04\
+    \xaf\x02\x08\r\n\r\n\x05\x04\x11\x02\0\x01\x12\x04\xaf\x02\x0e\x13\n\r\n\
+    \x05\x04\x11\x02\0\x03\x12\x04\xaf\x02\x16\x17\n<\n\x04\x04\x11\x02\x01\
+    \x12\x04\xb2\x02\x08\x1e\x1a.\x20Memory\x20reservation\x20or\x20soft_lim\
+    it\x20(in\x20bytes).\n\n\x0f\n\x05\x04\x11\x02\x01\x04\x12\x06\xb2\x02\
+    \x08\xaf\x02\x18\n\r\n\x05\x04\x11\x02\x01\x05\x12\x04\xb2\x02\x08\r\n\r\
+    \n\x05\x04\x11\x02\x01\x01\x12\x04\xb2\x02\x0e\x19\n\r\n\x05\x04\x11\x02\
+    \x01\x03\x12\x04\xb2\x02\x1c\x1d\n3\n\x04\x04\x11\x02\x02\x12\x04\xb5\
+    \x02\x08\x17\x1a%\x20Total\x20memory\x20limit\x20(memory\x20+\x20swap).\
+    \n\n\x0f\n\x05\x04\x11\x02\x02\x04\x12\x06\xb5\x02\x08\xb2\x02\x1e\n\r\n\
+    \x05\x04\x11\x02\x02\x05\x12\x04\xb5\x02\x08\r\n\r\n\x05\x04\x11\x02\x02\
+    \x01\x12\x04\xb5\x02\x0e\x12\n\r\n\x05\x04\x11\x02\x02\x03\x12\x04\xb5\
+    \x02\x15\x16\n/\n\x04\x04\x11\x02\x03\x12\x04\xb8\x02\x08\x19\x1a!\x20Ke\
+    rnel\x20memory\x20limit\x20(in\x20bytes).\n\n\x0f\n\x05\x04\x11\x02\x03\
+    \x04\x12\x06\xb8\x02\x08\xb5\x02\x17\n\r\n\x05\x04\x11\x02\x03\x05\x12\
+    \x04\xb8\x02\x08\r\n\r\n\x05\x04\x11\x02\x03\x01\x12\x04\xb8\x02\x0e\x14\
+    \n\r\n\x05\x04\x11\x02\x03\x03\x12\x04\xb8\x02\x17\x18\n6\n\x04\x04\x11\
+    \x02\x04\x12\x04\xbb\x02\x08\x1c\x1a(\x20Kernel\x20memory\x20limit\x20fo\
+    r\x20tcp\x20(in\x20bytes)\n\n\x0f\n\x05\x04\x11\x02\x04\x04\x12\x06\xbb\
+    \x02\x08\xb8\x02\x19\n\r\n\x05\x04\x11\x02\x04\x05\x12\x04\xbb\x02\x08\r\
+    \n\r\n\x05\x04\x11\x02\x04\x01\x12\x04\xbb\x02\x0e\x17\n\r\n\x05\x04\x11\
+    \x02\x04\x03\x12\x04\xbb\x02\x1a\x1b\nA\n\x04\x04\x11\x02\x05\x12\x04\
+    \xbe\x02\x08\x1e\x1a3\x20How\x20aggressive\x20the\x20kernel\x20will\x20s\
+    wap\x20memory\x20pages.\n\n\x0f\n\x05\x04\x11\x02\x05\x04\x12\x06\xbe\
+    \x02\x08\xbb\x02\x1c\n\r\n\x05\x04\x11\x02\x05\x05\x12\x04\xbe\x02\x08\
+    \x0e\n\r\n\x05\x04\x11\x02\x05\x01\x12\x04\xbe\x02\x0f\x19\n\r\n\x05\x04\
+    \x11\x02\x05\x03\x12\x04\xbe\x02\x1c\x1d\nU\n\x04\x04\x11\x02\x06\x12\
+    \x04\xc1\x02\x08\"\x1aG\x20DisableOOMKiller\x20disables\x20the\x20OOM\
+    \x20killer\x20for\x20out\x20of\x20memory\x20conditions\n\n\x0f\n\x05\x04\
+    \x11\x02\x06\x04\x12\x06\xc1\x02\x08\xbe\x02\x1e\n\r\n\x05\x04\x11\x02\
+    \x06\x05\x12\x04\xc1\x02\x08\x0c\n\r\n\x05\x04\x11\x02\x06\x01\x12\x04\
+    \xc1\x02\r\x1d\n\r\n\x05\x04\x11\x02\x06\x03\x12\x04\xc1\x02\x20!\n\x0c\
+    \n\x02\x04\x12\x12\x06\xc4\x02\0\xd9\x02\x01\n\x0b\n\x03\x04\x12\x01\x12\
+    \x04\xc4\x02\x08\x10\nW\n\x04\x04\x12\x02\0\x12\x04\xc6\x02\x08\x1a\x1aI\
+    \x20CPU\x20shares\x20(relative\x20weight\x20(ratio)\x20vs.\x20other\x20c\
+    groups\x20with\x20cpu\x20shares).\n\n\x0f\n\x05\x04\x12\x02\0\x04\x12\
+    \x06\xc6\x02\x08\xc4\x02\x12\n\r\n\x05\x04\x12\x02\0\x05\x12\x04\xc6\x02\
+    \x08\x0e\n\r\n\x05\x04\x12\x02\0\x01\x12\x04\xc6\x02\x0f\x15\n\r\n\x05\
+    \x04\x12\x02\0\x03\x12\x04\xc6\x02\x18\x19\nQ\n\x04\x04\x12\x02\x01\x12\
+    \x04\xc9\x02\x08\x18\x1aC\x20CPU\x20hardcap\x20limit\x20(in\x20usecs).\
+    \x20Allowed\x20cpu\x20time\x20in\x20a\x20given\x20period.\n\n\x0f\n\x05\
+    \x04\x12\x02\x01\x04\x12\x06\xc9\x02\x08\xc6\x02\x1a\n\r\n\x05\x04\x12\
+    \x02\x01\x05\x12\x04\xc9\x02\x08\r\n\r\n\x05\x04\x12\x02\x01\x01\x12\x04\
+    \xc9\x02\x0e\x13\n\r\n\x05\x04\x12\x02\x01\x03\x12\x04\xc9\x02\x16\x17\n\
+    A\n\x04\x04\x12\x02\x02\x12\x04\xcc\x02\x08\x1a\x1a3\x20CPU\x20period\
+    \x20to\x20be\x20used\x20for\x20hardcapping\x20(in\x20usecs).\n\n\x0f\n\
+    \x05\x04\x12\x02\x02\x04\x12\x06\xcc\x02\x08\xc9\x02\x18\n\r\n\x05\x04\
+    \x12\x02\x02\x05\x12\x04\xcc\x02\x08\x0e\n\r\n\x05\x04\x12\x02\x02\x01\
+    \x12\x04\xcc\x02\x0f\x15\n\r\n\x05\x04\x12\x02\x02\x03\x12\x04\xcc\x02\
+    \x18\x19\nE\n\x04\x04\x12\x02\x03\x12\x04\xcf\x02\x08\"\x1a7\x20How\x20m\
+    uch\x20time\x20realtime\x20scheduling\x20may\x20use\x20(in\x20usecs).\n\
+    \n\x0f\n\x05\x04\x12\x02\x03\x04\x12\x06\xcf\x02\x08\xcc\x02\x1a\n\r\n\
+    \x05\x04\x12\x02\x03\x05\x12\x04\xcf\x02\x08\r\n\r\n\x05\x04\x12\x02\x03\
+    \x01\x12\x04\xcf\x02\x0e\x1d\n\r\n\x05\x04\x12\x02\x03\x03\x12\x04\xcf\
+    \x02\x20!\nI\n\x04\x04\x12\x02\x04\x12\x04\xd2\x02\x08\"\x1a;\x20CPU\x20\
+    period\x20to\x20be\x20used\x20for\x20realtime\x20scheduling\x20(in\x20us\
+    ecs).\n\n\x0f\n\x05\x04\x12\x02\x04\x04\x12\x06\xd2\x02\x08\xcf\x02\"\n\
+    \r\n\x05\x04\x12\x02\x04\x05\x12\x04\xd2\x02\x08\x0e\n\r\n\x05\x04\x12\
+    \x02\x04\x01\x12\x04\xd2\x02\x0f\x1d\n\r\n\x05\x04\x12\x02\x04\x03\x12\
+    \x04\xd2\x02\x20!\nS\n\x04\x04\x12\x02\x05\x12\x04\xd5\x02\x08\x18\x1aE\
+    \x20CPUs\x20to\x20use\x20within\x20the\x20cpuset.\x20Default\x20is\x20to\
+    \x20use\x20any\x20CPU\x20available.\n\n\x0f\n\x05\x04\x12\x02\x05\x04\
+    \x12\x06\xd5\x02\x08\xd2\x02\"\n\r\n\x05\x04\x12\x02\x05\x05\x12\x04\xd5\
+    \x02\x08\x0e\n\r\n\x05\x04\x12\x02\x05\x01\x12\x04\xd5\x02\x0f\x13\n\r\n\
+    \x05\x04\x12\x02\x05\x03\x12\x04\xd5\x02\x16\x17\n`\n\x04\x04\x12\x02\
+    \x06\x12\x04\xd8\x02\x08\x18\x1aR\x20List\x20of\x20memory\x20nodes\x20in\
+    \x20the\x20cpuset.\x20Default\x20is\x20to\x20use\x20any\x20available\x20\
+    memory\x20node.\n\n\x0f\n\x05\x04\x12\x02\x06\x04\x12\x06\xd8\x02\x08\
+    \xd5\x02\x18\n\r\n\x05\x04\x12\x02\x06\x05\x12\x04\xd8\x02\x08\x0e\n\r\n\
+    \x05\x04\x12\x02\x06\x01\x12\x04\xd8\x02\x0f\x13\n\r\n\x05\x04\x12\x02\
+    \x06\x03\x12\x04\xd8\x02\x16\x17\n\x0c\n\x02\x04\x13\x12\x06\xdb\x02\0\
+    \xe7\x02\x01\n\x0b\n\x03\x04\x13\x01\x12\x04\xdb\x02\x08\x19\n3\n\x04\
+    \x04\x13\x02\0\x12\x04\xdd\x02\x08\x18\x1a%\x20Major\x20is\x20the\x20dev\
+    ice's\x20major\x20number.\n\n\x0f\n\x05\x04\x13\x02\0\x04\x12\x06\xdd\
+    \x02\x08\xdb\x02\x1b\n\r\n\x05\x04\x13\x02\0\x05\x12\x04\xdd\x02\x08\r\n\
+    \r\n\x05\x04\x13\x02\0\x01\x12\x04\xdd\x02\x0e\x13\n\r\n\x05\x04\x13\x02\
+    \0\x03\x12\x04\xdd\x02\x16\x17\n3\n\x04\x04\x13\x02\x01\x12\x04\xe0\x02\
     \x08\x18\x1a%\x20Minor\x20is\x20the\x20device's\x20minor\x20number.\n\n\
-    \x0f\n\x05\x04\x17\x02\x03\x04\x12\x06\x9b\x03\x08\x98\x03\x18\n\r\n\x05\
-    \x04\x17\x02\x03\x05\x12\x04\x9b\x03\x08\r\n\r\n\x05\x04\x17\x02\x03\x01\
-    \x12\x04\x9b\x03\x0e\x13\n\r\n\x05\x04\x17\x02\x03\x03\x12\x04\x9b\x03\
-    \x16\x17\n6\n\x04\x04\x17\x02\x04\x12\x04\x9e\x03\x08\x1a\x1a(\x20Cgroup\
-    \x20access\x20permissions\x20format,\x20rwm.\n\n\x0f\n\x05\x04\x17\x02\
-    \x04\x04\x12\x06\x9e\x03\x08\x9b\x03\x18\n\r\n\x05\x04\x17\x02\x04\x05\
-    \x12\x04\x9e\x03\x08\x0e\n\r\n\x05\x04\x17\x02\x04\x01\x12\x04\x9e\x03\
-    \x0f\x15\n\r\n\x05\x04\x17\x02\x04\x03\x12\x04\x9e\x03\x18\x19\n\x0c\n\
-    \x02\x04\x18\x12\x06\xa1\x03\0\xa7\x03\x01\n\x0b\n\x03\x04\x18\x01\x12\
-    \x04\xa1\x03\x08\x14\nD\n\x04\x04\x18\x02\0\x12\x04\xa3\x03\x08\x1b\x1a6\
-    \x20Set\x20class\x20identifier\x20for\x20container's\x20network\x20packe\
-    ts\n\n\x0f\n\x05\x04\x18\x02\0\x04\x12\x06\xa3\x03\x08\xa1\x03\x16\n\r\n\
-    \x05\x04\x18\x02\0\x05\x12\x04\xa3\x03\x08\x0e\n\r\n\x05\x04\x18\x02\0\
-    \x01\x12\x04\xa3\x03\x0f\x16\n\r\n\x05\x04\x18\x02\0\x03\x12\x04\xa3\x03\
-    \x19\x1a\n=\n\x04\x04\x18\x02\x01\x12\x04\xa6\x03\x08W\x1a/\x20Set\x20pr\
-    iority\x20of\x20network\x20traffic\x20for\x20container\n\n\r\n\x05\x04\
-    \x18\x02\x01\x04\x12\x04\xa6\x03\x08\x10\n\r\n\x05\x04\x18\x02\x01\x06\
-    \x12\x04\xa6\x03\x11'\n\r\n\x05\x04\x18\x02\x01\x01\x12\x04\xa6\x03(2\n\
-    \r\n\x05\x04\x18\x02\x01\x03\x12\x04\xa6\x0356\n\r\n\x05\x04\x18\x02\x01\
-    \x08\x12\x04\xa6\x038V\n\x10\n\x08\x04\x18\x02\x01\x08\xe9\xfb\x03\x12\
-    \x04\xa6\x039U\n\x0c\n\x02\x04\x19\x12\x06\xa9\x03\0\xaf\x03\x01\n\x0b\n\
-    \x03\x04\x19\x01\x12\x04\xa9\x03\x08\x1a\n-\n\x04\x04\x19\x02\0\x12\x04\
-    \xab\x03\x08\x1c\x1a\x1f\x20Pagesize\x20is\x20the\x20hugepage\x20size\n\
-    \n\x0f\n\x05\x04\x19\x02\0\x04\x12\x06\xab\x03\x08\xa9\x03\x1c\n\r\n\x05\
-    \x04\x19\x02\0\x05\x12\x04\xab\x03\x08\x0e\n\r\n\x05\x04\x19\x02\0\x01\
-    \x12\x04\xab\x03\x0f\x17\n\r\n\x05\x04\x19\x02\0\x03\x12\x04\xab\x03\x1a\
-    \x1b\nB\n\x04\x04\x19\x02\x01\x12\x04\xae\x03\x08\x19\x1a4\x20Limit\x20i\
-    s\x20the\x20limit\x20of\x20\"hugepagesize\"\x20hugetlb\x20usage\n\n\x0f\
-    \n\x05\x04\x19\x02\x01\x04\x12\x06\xae\x03\x08\xab\x03\x1c\n\r\n\x05\x04\
-    \x19\x02\x01\x05\x12\x04\xae\x03\x08\x0e\n\r\n\x05\x04\x19\x02\x01\x01\
-    \x12\x04\xae\x03\x0f\x14\n\r\n\x05\x04\x19\x02\x01\x03\x12\x04\xae\x03\
-    \x17\x18\n\x0c\n\x02\x04\x1a\x12\x06\xb1\x03\0\xb7\x03\x01\n\x0b\n\x03\
-    \x04\x1a\x01\x12\x04\xb1\x03\x08\x1e\n9\n\x04\x04\x1a\x02\0\x12\x04\xb3\
-    \x03\x08\x18\x1a+\x20Name\x20is\x20the\x20name\x20of\x20the\x20network\
-    \x20interface\n\n\x0f\n\x05\x04\x1a\x02\0\x04\x12\x06\xb3\x03\x08\xb1\
-    \x03\x20\n\r\n\x05\x04\x1a\x02\0\x05\x12\x04\xb3\x03\x08\x0e\n\r\n\x05\
-    \x04\x1a\x02\0\x01\x12\x04\xb3\x03\x0f\x13\n\r\n\x05\x04\x1a\x02\0\x03\
-    \x12\x04\xb3\x03\x16\x17\n*\n\x04\x04\x1a\x02\x01\x12\x04\xb6\x03\x08\
-    \x1c\x1a\x1c\x20Priority\x20for\x20the\x20interface\n\n\x0f\n\x05\x04\
-    \x1a\x02\x01\x04\x12\x06\xb6\x03\x08\xb3\x03\x18\n\r\n\x05\x04\x1a\x02\
-    \x01\x05\x12\x04\xb6\x03\x08\x0e\n\r\n\x05\x04\x1a\x02\x01\x01\x12\x04\
-    \xb6\x03\x0f\x17\n\r\n\x05\x04\x1a\x02\x01\x03\x12\x04\xb6\x03\x1a\x1b\n\
-    \x0c\n\x02\x04\x1b\x12\x06\xb9\x03\0\xbd\x03\x01\n\x0b\n\x03\x04\x1b\x01\
-    \x12\x04\xb9\x03\x08\x14\n\x0c\n\x04\x04\x1b\x02\0\x12\x04\xba\x03\x08!\
-    \n\x0f\n\x05\x04\x1b\x02\0\x04\x12\x06\xba\x03\x08\xb9\x03\x16\n\r\n\x05\
-    \x04\x1b\x02\0\x05\x12\x04\xba\x03\x08\x0e\n\r\n\x05\x04\x1b\x02\0\x01\
-    \x12\x04\xba\x03\x0f\x1c\n\r\n\x05\x04\x1b\x02\0\x03\x12\x04\xba\x03\x1f\
-    \x20\n\x0c\n\x04\x04\x1b\x02\x01\x12\x04\xbb\x03\x08*\n\r\n\x05\x04\x1b\
-    \x02\x01\x04\x12\x04\xbb\x03\x08\x10\n\r\n\x05\x04\x1b\x02\x01\x05\x12\
-    \x04\xbb\x03\x11\x17\n\r\n\x05\x04\x1b\x02\x01\x01\x12\x04\xbb\x03\x18%\
-    \n\r\n\x05\x04\x1b\x02\x01\x03\x12\x04\xbb\x03()\n\x0c\n\x04\x04\x1b\x02\
-    \x02\x12\x04\xbc\x03\x08K\n\r\n\x05\x04\x1b\x02\x02\x04\x12\x04\xbc\x03\
-    \x08\x10\n\r\n\x05\x04\x1b\x02\x02\x06\x12\x04\xbc\x03\x11\x1d\n\r\n\x05\
-    \x04\x1b\x02\x02\x01\x12\x04\xbc\x03\x1e&\n\r\n\x05\x04\x1b\x02\x02\x03\
-    \x12\x04\xbc\x03)*\n\r\n\x05\x04\x1b\x02\x02\x08\x12\x04\xbc\x03,J\n\x10\
-    \n\x08\x04\x1b\x02\x02\x08\xe9\xfb\x03\x12\x04\xbc\x03-I\n\x0c\n\x02\x04\
-    \x1c\x12\x06\xbf\x03\0\xc4\x03\x01\n\x0b\n\x03\x04\x1c\x01\x12\x04\xbf\
-    \x03\x08\x17\n\x0c\n\x04\x04\x1c\x02\0\x12\x04\xc0\x03\x08\x19\n\x0f\n\
-    \x05\x04\x1c\x02\0\x04\x12\x06\xc0\x03\x08\xbf\x03\x19\n\r\n\x05\x04\x1c\
-    \x02\0\x05\x12\x04\xc0\x03\x08\x0e\n\r\n\x05\x04\x1c\x02\0\x01\x12\x04\
-    \xc0\x03\x0f\x14\n\r\n\x05\x04\x1c\x02\0\x03\x12\x04\xc0\x03\x17\x18\n\
-    \x0c\n\x04\x04\x1c\x02\x01\x12\x04\xc1\x03\x08\x19\n\x0f\n\x05\x04\x1c\
-    \x02\x01\x04\x12\x06\xc1\x03\x08\xc0\x03\x19\n\r\n\x05\x04\x1c\x02\x01\
-    \x05\x12\x04\xc1\x03\x08\x0e\n\r\n\x05\x04\x1c\x02\x01\x01\x12\x04\xc1\
-    \x03\x0f\x14\n\r\n\x05\x04\x1c\x02\x01\x03\x12\x04\xc1\x03\x17\x18\n\x0c\
-    \n\x04\x04\x1c\x02\x02\x12\x04\xc2\x03\x08\x1c\n\x0f\n\x05\x04\x1c\x02\
-    \x02\x04\x12\x06\xc2\x03\x08\xc1\x03\x19\n\r\n\x05\x04\x1c\x02\x02\x05\
-    \x12\x04\xc2\x03\x08\x0e\n\r\n\x05\x04\x1c\x02\x02\x01\x12\x04\xc2\x03\
-    \x0f\x17\n\r\n\x05\x04\x1c\x02\x02\x03\x12\x04\xc2\x03\x1a\x1b\n\x0c\n\
-    \x04\x04\x1c\x02\x03\x12\x04\xc3\x03\x08\x16\n\x0f\n\x05\x04\x1c\x02\x03\
-    \x04\x12\x06\xc3\x03\x08\xc2\x03\x1c\n\r\n\x05\x04\x1c\x02\x03\x05\x12\
-    \x04\xc3\x03\x08\x0e\n\r\n\x05\x04\x1c\x02\x03\x01\x12\x04\xc3\x03\x0f\
-    \x11\n\r\n\x05\x04\x1c\x02\x03\x03\x12\x04\xc3\x03\x14\x15\n\x0c\n\x02\
-    \x04\x1d\x12\x06\xc6\x03\0\xca\x03\x01\n\x0b\n\x03\x04\x1d\x01\x12\x04\
-    \xc6\x03\x08\x14\n\x0c\n\x04\x04\x1d\x02\0\x12\x04\xc7\x03\x08\"\n\r\n\
-    \x05\x04\x1d\x02\0\x04\x12\x04\xc7\x03\x08\x10\n\r\n\x05\x04\x1d\x02\0\
-    \x05\x12\x04\xc7\x03\x11\x17\n\r\n\x05\x04\x1d\x02\0\x01\x12\x04\xc7\x03\
-    \x18\x1d\n\r\n\x05\x04\x1d\x02\0\x03\x12\x04\xc7\x03\x20!\n\x0c\n\x04\
-    \x04\x1d\x02\x01\x12\x04\xc8\x03\x08\x1a\n\x0f\n\x05\x04\x1d\x02\x01\x04\
-    \x12\x06\xc8\x03\x08\xc7\x03\"\n\r\n\x05\x04\x1d\x02\x01\x05\x12\x04\xc8\
-    \x03\x08\x0e\n\r\n\x05\x04\x1d\x02\x01\x01\x12\x04\xc8\x03\x0f\x15\n\r\n\
-    \x05\x04\x1d\x02\x01\x03\x12\x04\xc8\x03\x18\x19\n\x0c\n\x04\x04\x1d\x02\
-    \x02\x12\x04\xc9\x03\x08J\n\r\n\x05\x04\x1d\x02\x02\x04\x12\x04\xc9\x03\
-    \x08\x10\n\r\n\x05\x04\x1d\x02\x02\x06\x12\x04\xc9\x03\x11\x20\n\r\n\x05\
-    \x04\x1d\x02\x02\x01\x12\x04\xc9\x03!%\n\r\n\x05\x04\x1d\x02\x02\x03\x12\
-    \x04\xc9\x03()\n\r\n\x05\x04\x1d\x02\x02\x08\x12\x04\xc9\x03+I\n\x10\n\
-    \x08\x04\x1d\x02\x02\x08\xe9\xfb\x03\x12\x04\xc9\x03,H\n\x0c\n\x02\x04\
-    \x1e\x12\x06\xcc\x03\0\xd0\x03\x01\n\x0b\n\x03\x04\x1e\x01\x12\x04\xcc\
-    \x03\x08\x15\n}\n\x04\x04\x1e\x02\0\x12\x04\xcf\x03\x08!\x1ao\x20The\x20\
-    schema\x20for\x20L3\x20cache\x20id\x20and\x20capacity\x20bitmask\x20(CBM\
-    )\n\x20Format:\x20\"L3:<cache_id0>=<cbm0>;<cache_id1>=<cbm1>;...\"\n\n\
-    \x0f\n\x05\x04\x1e\x02\0\x04\x12\x06\xcf\x03\x08\xcc\x03\x17\n\r\n\x05\
-    \x04\x1e\x02\0\x05\x12\x04\xcf\x03\x08\x0e\n\r\n\x05\x04\x1e\x02\0\x01\
-    \x12\x04\xcf\x03\x0f\x1c\n\r\n\x05\x04\x1e\x02\0\x03\x12\x04\xcf\x03\x1f\
-    \x20b\x06proto3\
+    \x0f\n\x05\x04\x13\x02\x01\x04\x12\x06\xe0\x02\x08\xdd\x02\x18\n\r\n\x05\
+    \x04\x13\x02\x01\x05\x12\x04\xe0\x02\x08\r\n\r\n\x05\x04\x13\x02\x01\x01\
+    \x12\x04\xe0\x02\x0e\x13\n\r\n\x05\x04\x13\x02\x01\x03\x12\x04\xe0\x02\
+    \x16\x17\n<\n\x04\x04\x13\x02\x02\x12\x04\xe3\x02\x08\x1a\x1a.\x20Weight\
+    \x20is\x20the\x20bandwidth\x20rate\x20for\x20the\x20device.\n\n\x0f\n\
+    \x05\x04\x13\x02\x02\x04\x12\x06\xe3\x02\x08\xe0\x02\x18\n\r\n\x05\x04\
+    \x13\x02\x02\x05\x12\x04\xe3\x02\x08\x0e\n\r\n\x05\x04\x13\x02\x02\x01\
+    \x12\x04\xe3\x02\x0f\x15\n\r\n\x05\x04\x13\x02\x02\x03\x12\x04\xe3\x02\
+    \x18\x19\n\x83\x01\n\x04\x04\x13\x02\x03\x12\x04\xe6\x02\x08\x1e\x1au\
+    \x20LeafWeight\x20is\x20the\x20bandwidth\x20rate\x20for\x20the\x20device\
+    \x20while\x20competing\x20with\x20the\x20cgroup's\x20child\x20cgroups,\
+    \x20CFQ\x20scheduler\x20only\n\n\x0f\n\x05\x04\x13\x02\x03\x04\x12\x06\
+    \xe6\x02\x08\xe3\x02\x1a\n\r\n\x05\x04\x13\x02\x03\x05\x12\x04\xe6\x02\
+    \x08\x0e\n\r\n\x05\x04\x13\x02\x03\x01\x12\x04\xe6\x02\x0f\x19\n\r\n\x05\
+    \x04\x13\x02\x03\x03\x12\x04\xe6\x02\x1c\x1d\n\x0c\n\x02\x04\x14\x12\x06\
+    \xe9\x02\0\xf2\x02\x01\n\x0b\n\x03\x04\x14\x01\x12\x04\xe9\x02\x08\x1b\n\
+    3\n\x04\x04\x14\x02\0\x12\x04\xeb\x02\x08\x18\x1a%\x20Major\x20is\x20the\
+    \x20device's\x20major\x20number.\n\n\x0f\n\x05\x04\x14\x02\0\x04\x12\x06\
+    \xeb\x02\x08\xe9\x02\x1d\n\r\n\x05\x04\x14\x02\0\x05\x12\x04\xeb\x02\x08\
+    \r\n\r\n\x05\x04\x14\x02\0\x01\x12\x04\xeb\x02\x0e\x13\n\r\n\x05\x04\x14\
+    \x02\0\x03\x12\x04\xeb\x02\x16\x17\n3\n\x04\x04\x14\x02\x01\x12\x04\xee\
+    \x02\x08\x18\x1a%\x20Minor\x20is\x20the\x20device's\x20minor\x20number.\
+    \n\n\x0f\n\x05\x04\x14\x02\x01\x04\x12\x06\xee\x02\x08\xeb\x02\x18\n\r\n\
+    \x05\x04\x14\x02\x01\x05\x12\x04\xee\x02\x08\r\n\r\n\x05\x04\x14\x02\x01\
+    \x01\x12\x04\xee\x02\x0e\x13\n\r\n\x05\x04\x14\x02\x01\x03\x12\x04\xee\
+    \x02\x16\x17\n?\n\x04\x04\x14\x02\x02\x12\x04\xf1\x02\x08\x18\x1a1\x20Ra\
+    te\x20is\x20the\x20IO\x20rate\x20limit\x20per\x20cgroup\x20per\x20device\
+    \n\n\x0f\n\x05\x04\x14\x02\x02\x04\x12\x06\xf1\x02\x08\xee\x02\x18\n\r\n\
+    \x05\x04\x14\x02\x02\x05\x12\x04\xf1\x02\x08\x0e\n\r\n\x05\x04\x14\x02\
+    \x02\x01\x12\x04\xf1\x02\x0f\x13\n\r\n\x05\x04\x14\x02\x02\x03\x12\x04\
+    \xf1\x02\x16\x17\n\x0c\n\x02\x04\x15\x12\x06\xf4\x02\0\x89\x03\x01\n\x0b\
+    \n\x03\x04\x15\x01\x12\x04\xf4\x02\x08\x14\n+\n\x04\x04\x15\x02\0\x12\
+    \x04\xf6\x02\x08\x1a\x1a\x1d\x20Specifies\x20per\x20cgroup\x20weight\n\n\
+    \x0f\n\x05\x04\x15\x02\0\x04\x12\x06\xf6\x02\x08\xf4\x02\x16\n\r\n\x05\
+    \x04\x15\x02\0\x05\x12\x04\xf6\x02\x08\x0e\n\r\n\x05\x04\x15\x02\0\x01\
+    \x12\x04\xf6\x02\x0f\x15\n\r\n\x05\x04\x15\x02\0\x03\x12\x04\xf6\x02\x18\
+    \x19\n\x7f\n\x04\x04\x15\x02\x01\x12\x04\xf9\x02\x08\x1e\x1aq\x20Specifi\
+    es\x20tasks'\x20weight\x20in\x20the\x20given\x20cgroup\x20while\x20compe\
+    ting\x20with\x20the\x20cgroup's\x20child\x20cgroups,\x20CFQ\x20scheduler\
+    \x20only\n\n\x0f\n\x05\x04\x15\x02\x01\x04\x12\x06\xf9\x02\x08\xf6\x02\
+    \x1a\n\r\n\x05\x04\x15\x02\x01\x05\x12\x04\xf9\x02\x08\x0e\n\r\n\x05\x04\
+    \x15\x02\x01\x01\x12\x04\xf9\x02\x0f\x19\n\r\n\x05\x04\x15\x02\x01\x03\
+    \x12\x04\xf9\x02\x1c\x1d\nF\n\x04\x04\x15\x02\x02\x12\x04\xfc\x02\x08T\
+    \x1a8\x20Weight\x20per\x20cgroup\x20per\x20device,\x20can\x20override\
+    \x20BlkioWeight\n\n\r\n\x05\x04\x15\x02\x02\x04\x12\x04\xfc\x02\x08\x10\
+    \n\r\n\x05\x04\x15\x02\x02\x06\x12\x04\xfc\x02\x11\"\n\r\n\x05\x04\x15\
+    \x02\x02\x01\x12\x04\xfc\x02#/\n\r\n\x05\x04\x15\x02\x02\x03\x12\x04\xfc\
+    \x0223\n\r\n\x05\x04\x15\x02\x02\x08\x12\x04\xfc\x025S\n\x10\n\x08\x04\
+    \x15\x02\x02\x08\xe9\xfb\x03\x12\x04\xfc\x026R\nJ\n\x04\x04\x15\x02\x03\
+    \x12\x04\xff\x02\x08_\x1a<\x20IO\x20read\x20rate\x20limit\x20per\x20cgro\
+    up\x20per\x20device,\x20bytes\x20per\x20second\n\n\r\n\x05\x04\x15\x02\
+    \x03\x04\x12\x04\xff\x02\x08\x10\n\r\n\x05\x04\x15\x02\x03\x06\x12\x04\
+    \xff\x02\x11$\n\r\n\x05\x04\x15\x02\x03\x01\x12\x04\xff\x02%:\n\r\n\x05\
+    \x04\x15\x02\x03\x03\x12\x04\xff\x02=>\n\r\n\x05\x04\x15\x02\x03\x08\x12\
+    \x04\xff\x02@^\n\x10\n\x08\x04\x15\x02\x03\x08\xe9\xfb\x03\x12\x04\xff\
+    \x02A]\nK\n\x04\x04\x15\x02\x04\x12\x04\x82\x03\x08`\x1a=\x20IO\x20write\
+    \x20rate\x20limit\x20per\x20cgroup\x20per\x20device,\x20bytes\x20per\x20\
+    second\n\n\r\n\x05\x04\x15\x02\x04\x04\x12\x04\x82\x03\x08\x10\n\r\n\x05\
+    \x04\x15\x02\x04\x06\x12\x04\x82\x03\x11$\n\r\n\x05\x04\x15\x02\x04\x01\
+    \x12\x04\x82\x03%;\n\r\n\x05\x04\x15\x02\x04\x03\x12\x04\x82\x03>?\n\r\n\
+    \x05\x04\x15\x02\x04\x08\x12\x04\x82\x03A_\n\x10\n\x08\x04\x15\x02\x04\
+    \x08\xe9\xfb\x03\x12\x04\x82\x03B^\nG\n\x04\x04\x15\x02\x05\x12\x04\x85\
+    \x03\x08`\x1a9\x20IO\x20read\x20rate\x20limit\x20per\x20cgroup\x20per\
+    \x20device,\x20IO\x20per\x20second\n\n\r\n\x05\x04\x15\x02\x05\x04\x12\
+    \x04\x85\x03\x08\x10\n\r\n\x05\x04\x15\x02\x05\x06\x12\x04\x85\x03\x11$\
+    \n\r\n\x05\x04\x15\x02\x05\x01\x12\x04\x85\x03%;\n\r\n\x05\x04\x15\x02\
+    \x05\x03\x12\x04\x85\x03>?\n\r\n\x05\x04\x15\x02\x05\x08\x12\x04\x85\x03\
+    A_\n\x10\n\x08\x04\x15\x02\x05\x08\xe9\xfb\x03\x12\x04\x85\x03B^\nH\n\
+    \x04\x04\x15\x02\x06\x12\x04\x88\x03\x08a\x1a:\x20IO\x20write\x20rate\
+    \x20limit\x20per\x20cgroup\x20per\x20device,\x20IO\x20per\x20second\n\n\
+    \r\n\x05\x04\x15\x02\x06\x04\x12\x04\x88\x03\x08\x10\n\r\n\x05\x04\x15\
+    \x02\x06\x06\x12\x04\x88\x03\x11$\n\r\n\x05\x04\x15\x02\x06\x01\x12\x04\
+    \x88\x03%<\n\r\n\x05\x04\x15\x02\x06\x03\x12\x04\x88\x03?@\n\r\n\x05\x04\
+    \x15\x02\x06\x08\x12\x04\x88\x03B`\n\x10\n\x08\x04\x15\x02\x06\x08\xe9\
+    \xfb\x03\x12\x04\x88\x03C_\n\x0c\n\x02\x04\x16\x12\x06\x8b\x03\0\x8e\x03\
+    \x01\n\x0b\n\x03\x04\x16\x01\x12\x04\x8b\x03\x08\x11\n>\n\x04\x04\x16\
+    \x02\0\x12\x04\x8d\x03\x08\x18\x1a0\x20Maximum\x20number\x20of\x20PIDs.\
+    \x20Default\x20is\x20\"no\x20limit\".\n\n\x0f\n\x05\x04\x16\x02\0\x04\
+    \x12\x06\x8d\x03\x08\x8b\x03\x13\n\r\n\x05\x04\x16\x02\0\x05\x12\x04\x8d\
+    \x03\x08\r\n\r\n\x05\x04\x16\x02\0\x01\x12\x04\x8d\x03\x0e\x13\n\r\n\x05\
+    \x04\x16\x02\0\x03\x12\x04\x8d\x03\x16\x17\n\x0c\n\x02\x04\x17\x12\x06\
+    \x90\x03\0\x9f\x03\x01\n\x0b\n\x03\x04\x17\x01\x12\x04\x90\x03\x08\x19\n\
+    \x1d\n\x04\x04\x17\x02\0\x12\x04\x92\x03\x08\x17\x1a\x0f\x20Allow\x20or\
+    \x20deny\n\n\x0f\n\x05\x04\x17\x02\0\x04\x12\x06\x92\x03\x08\x90\x03\x1b\
+    \n\r\n\x05\x04\x17\x02\0\x05\x12\x04\x92\x03\x08\x0c\n\r\n\x05\x04\x17\
+    \x02\0\x01\x12\x04\x92\x03\r\x12\n\r\n\x05\x04\x17\x02\0\x03\x12\x04\x92\
+    \x03\x15\x16\n.\n\x04\x04\x17\x02\x01\x12\x04\x95\x03\x08\x18\x1a\x20\
+    \x20Device\x20type,\x20block,\x20char,\x20etc.\n\n\x0f\n\x05\x04\x17\x02\
+    \x01\x04\x12\x06\x95\x03\x08\x92\x03\x17\n\r\n\x05\x04\x17\x02\x01\x05\
+    \x12\x04\x95\x03\x08\x0e\n\r\n\x05\x04\x17\x02\x01\x01\x12\x04\x95\x03\
+    \x0f\x13\n\r\n\x05\x04\x17\x02\x01\x03\x12\x04\x95\x03\x16\x17\n3\n\x04\
+    \x04\x17\x02\x02\x12\x04\x98\x03\x08\x18\x1a%\x20Major\x20is\x20the\x20d\
+    evice's\x20major\x20number.\n\n\x0f\n\x05\x04\x17\x02\x02\x04\x12\x06\
+    \x98\x03\x08\x95\x03\x18\n\r\n\x05\x04\x17\x02\x02\x05\x12\x04\x98\x03\
+    \x08\r\n\r\n\x05\x04\x17\x02\x02\x01\x12\x04\x98\x03\x0e\x13\n\r\n\x05\
+    \x04\x17\x02\x02\x03\x12\x04\x98\x03\x16\x17\n3\n\x04\x04\x17\x02\x03\
+    \x12\x04\x9b\x03\x08\x18\x1a%\x20Minor\x20is\x20the\x20device's\x20minor\
+    \x20number.\n\n\x0f\n\x05\x04\x17\x02\x03\x04\x12\x06\x9b\x03\x08\x98\
+    \x03\x18\n\r\n\x05\x04\x17\x02\x03\x05\x12\x04\x9b\x03\x08\r\n\r\n\x05\
+    \x04\x17\x02\x03\x01\x12\x04\x9b\x03\x0e\x13\n\r\n\x05\x04\x17\x02\x03\
+    \x03\x12\x04\x9b\x03\x16\x17\n6\n\x04\x04\x17\x02\x04\x12\x04\x9e\x03\
+    \x08\x1a\x1a(\x20Cgroup\x20access\x20permissions\x20format,\x20rwm.\n\n\
+    \x0f\n\x05\x04\x17\x02\x04\x04\x12\x06\x9e\x03\x08\x9b\x03\x18\n\r\n\x05\
+    \x04\x17\x02\x04\x05\x12\x04\x9e\x03\x08\x0e\n\r\n\x05\x04\x17\x02\x04\
+    \x01\x12\x04\x9e\x03\x0f\x15\n\r\n\x05\x04\x17\x02\x04\x03\x12\x04\x9e\
+    \x03\x18\x19\n\x0c\n\x02\x04\x18\x12\x06\xa1\x03\0\xa7\x03\x01\n\x0b\n\
+    \x03\x04\x18\x01\x12\x04\xa1\x03\x08\x14\nD\n\x04\x04\x18\x02\0\x12\x04\
+    \xa3\x03\x08\x1b\x1a6\x20Set\x20class\x20identifier\x20for\x20container'\
+    s\x20network\x20packets\n\n\x0f\n\x05\x04\x18\x02\0\x04\x12\x06\xa3\x03\
+    \x08\xa1\x03\x16\n\r\n\x05\x04\x18\x02\0\x05\x12\x04\xa3\x03\x08\x0e\n\r\
+    \n\x05\x04\x18\x02\0\x01\x12\x04\xa3\x03\x0f\x16\n\r\n\x05\x04\x18\x02\0\
+    \x03\x12\x04\xa3\x03\x19\x1a\n=\n\x04\x04\x18\x02\x01\x12\x04\xa6\x03\
+    \x08W\x1a/\x20Set\x20priority\x20of\x20network\x20traffic\x20for\x20cont\
+    ainer\n\n\r\n\x05\x04\x18\x02\x01\x04\x12\x04\xa6\x03\x08\x10\n\r\n\x05\
+    \x04\x18\x02\x01\x06\x12\x04\xa6\x03\x11'\n\r\n\x05\x04\x18\x02\x01\x01\
+    \x12\x04\xa6\x03(2\n\r\n\x05\x04\x18\x02\x01\x03\x12\x04\xa6\x0356\n\r\n\
+    \x05\x04\x18\x02\x01\x08\x12\x04\xa6\x038V\n\x10\n\x08\x04\x18\x02\x01\
+    \x08\xe9\xfb\x03\x12\x04\xa6\x039U\n\x0c\n\x02\x04\x19\x12\x06\xa9\x03\0\
+    \xaf\x03\x01\n\x0b\n\x03\x04\x19\x01\x12\x04\xa9\x03\x08\x1a\n-\n\x04\
+    \x04\x19\x02\0\x12\x04\xab\x03\x08\x1c\x1a\x1f\x20Pagesize\x20is\x20the\
+    \x20hugepage\x20size\n\n\x0f\n\x05\x04\x19\x02\0\x04\x12\x06\xab\x03\x08\
+    \xa9\x03\x1c\n\r\n\x05\x04\x19\x02\0\x05\x12\x04\xab\x03\x08\x0e\n\r\n\
+    \x05\x04\x19\x02\0\x01\x12\x04\xab\x03\x0f\x17\n\r\n\x05\x04\x19\x02\0\
+    \x03\x12\x04\xab\x03\x1a\x1b\nB\n\x04\x04\x19\x02\x01\x12\x04\xae\x03\
+    \x08\x19\x1a4\x20Limit\x20is\x20the\x20limit\x20of\x20\"hugepagesize\"\
+    \x20hugetlb\x20usage\n\n\x0f\n\x05\x04\x19\x02\x01\x04\x12\x06\xae\x03\
+    \x08\xab\x03\x1c\n\r\n\x05\x04\x19\x02\x01\x05\x12\x04\xae\x03\x08\x0e\n\
+    \r\n\x05\x04\x19\x02\x01\x01\x12\x04\xae\x03\x0f\x14\n\r\n\x05\x04\x19\
+    \x02\x01\x03\x12\x04\xae\x03\x17\x18\n\x0c\n\x02\x04\x1a\x12\x06\xb1\x03\
+    \0\xb7\x03\x01\n\x0b\n\x03\x04\x1a\x01\x12\x04\xb1\x03\x08\x1e\n9\n\x04\
+    \x04\x1a\x02\0\x12\x04\xb3\x03\x08\x18\x1a+\x20Name\x20is\x20the\x20name\
+    \x20of\x20the\x20network\x20interface\n\n\x0f\n\x05\x04\x1a\x02\0\x04\
+    \x12\x06\xb3\x03\x08\xb1\x03\x20\n\r\n\x05\x04\x1a\x02\0\x05\x12\x04\xb3\
+    \x03\x08\x0e\n\r\n\x05\x04\x1a\x02\0\x01\x12\x04\xb3\x03\x0f\x13\n\r\n\
+    \x05\x04\x1a\x02\0\x03\x12\x04\xb3\x03\x16\x17\n*\n\x04\x04\x1a\x02\x01\
+    \x12\x04\xb6\x03\x08\x1c\x1a\x1c\x20Priority\x20for\x20the\x20interface\
+    \n\n\x0f\n\x05\x04\x1a\x02\x01\x04\x12\x06\xb6\x03\x08\xb3\x03\x18\n\r\n\
+    \x05\x04\x1a\x02\x01\x05\x12\x04\xb6\x03\x08\x0e\n\r\n\x05\x04\x1a\x02\
+    \x01\x01\x12\x04\xb6\x03\x0f\x17\n\r\n\x05\x04\x1a\x02\x01\x03\x12\x04\
+    \xb6\x03\x1a\x1b\n\x0c\n\x02\x04\x1b\x12\x06\xb9\x03\0\xbd\x03\x01\n\x0b\
+    \n\x03\x04\x1b\x01\x12\x04\xb9\x03\x08\x14\n\x0c\n\x04\x04\x1b\x02\0\x12\
+    \x04\xba\x03\x08!\n\x0f\n\x05\x04\x1b\x02\0\x04\x12\x06\xba\x03\x08\xb9\
+    \x03\x16\n\r\n\x05\x04\x1b\x02\0\x05\x12\x04\xba\x03\x08\x0e\n\r\n\x05\
+    \x04\x1b\x02\0\x01\x12\x04\xba\x03\x0f\x1c\n\r\n\x05\x04\x1b\x02\0\x03\
+    \x12\x04\xba\x03\x1f\x20\n\x0c\n\x04\x04\x1b\x02\x01\x12\x04\xbb\x03\x08\
+    *\n\r\n\x05\x04\x1b\x02\x01\x04\x12\x04\xbb\x03\x08\x10\n\r\n\x05\x04\
+    \x1b\x02\x01\x05\x12\x04\xbb\x03\x11\x17\n\r\n\x05\x04\x1b\x02\x01\x01\
+    \x12\x04\xbb\x03\x18%\n\r\n\x05\x04\x1b\x02\x01\x03\x12\x04\xbb\x03()\n\
+    \x0c\n\x04\x04\x1b\x02\x02\x12\x04\xbc\x03\x08K\n\r\n\x05\x04\x1b\x02\
+    \x02\x04\x12\x04\xbc\x03\x08\x10\n\r\n\x05\x04\x1b\x02\x02\x06\x12\x04\
+    \xbc\x03\x11\x1d\n\r\n\x05\x04\x1b\x02\x02\x01\x12\x04\xbc\x03\x1e&\n\r\
+    \n\x05\x04\x1b\x02\x02\x03\x12\x04\xbc\x03)*\n\r\n\x05\x04\x1b\x02\x02\
+    \x08\x12\x04\xbc\x03,J\n\x10\n\x08\x04\x1b\x02\x02\x08\xe9\xfb\x03\x12\
+    \x04\xbc\x03-I\n\x0c\n\x02\x04\x1c\x12\x06\xbf\x03\0\xc4\x03\x01\n\x0b\n\
+    \x03\x04\x1c\x01\x12\x04\xbf\x03\x08\x17\n\x0c\n\x04\x04\x1c\x02\0\x12\
+    \x04\xc0\x03\x08\x19\n\x0f\n\x05\x04\x1c\x02\0\x04\x12\x06\xc0\x03\x08\
+    \xbf\x03\x19\n\r\n\x05\x04\x1c\x02\0\x05\x12\x04\xc0\x03\x08\x0e\n\r\n\
+    \x05\x04\x1c\x02\0\x01\x12\x04\xc0\x03\x0f\x14\n\r\n\x05\x04\x1c\x02\0\
+    \x03\x12\x04\xc0\x03\x17\x18\n\x0c\n\x04\x04\x1c\x02\x01\x12\x04\xc1\x03\
+    \x08\x19\n\x0f\n\x05\x04\x1c\x02\x01\x04\x12\x06\xc1\x03\x08\xc0\x03\x19\
+    \n\r\n\x05\x04\x1c\x02\x01\x05\x12\x04\xc1\x03\x08\x0e\n\r\n\x05\x04\x1c\
+    \x02\x01\x01\x12\x04\xc1\x03\x0f\x14\n\r\n\x05\x04\x1c\x02\x01\x03\x12\
+    \x04\xc1\x03\x17\x18\n\x0c\n\x04\x04\x1c\x02\x02\x12\x04\xc2\x03\x08\x1c\
+    \n\x0f\n\x05\x04\x1c\x02\x02\x04\x12\x06\xc2\x03\x08\xc1\x03\x19\n\r\n\
+    \x05\x04\x1c\x02\x02\x05\x12\x04\xc2\x03\x08\x0e\n\r\n\x05\x04\x1c\x02\
+    \x02\x01\x12\x04\xc2\x03\x0f\x17\n\r\n\x05\x04\x1c\x02\x02\x03\x12\x04\
+    \xc2\x03\x1a\x1b\n\x0c\n\x04\x04\x1c\x02\x03\x12\x04\xc3\x03\x08\x16\n\
+    \x0f\n\x05\x04\x1c\x02\x03\x04\x12\x06\xc3\x03\x08\xc2\x03\x1c\n\r\n\x05\
+    \x04\x1c\x02\x03\x05\x12\x04\xc3\x03\x08\x0e\n\r\n\x05\x04\x1c\x02\x03\
+    \x01\x12\x04\xc3\x03\x0f\x11\n\r\n\x05\x04\x1c\x02\x03\x03\x12\x04\xc3\
+    \x03\x14\x15\n\x0c\n\x02\x04\x1d\x12\x06\xc6\x03\0\xca\x03\x01\n\x0b\n\
+    \x03\x04\x1d\x01\x12\x04\xc6\x03\x08\x14\n\x0c\n\x04\x04\x1d\x02\0\x12\
+    \x04\xc7\x03\x08\"\n\r\n\x05\x04\x1d\x02\0\x04\x12\x04\xc7\x03\x08\x10\n\
+    \r\n\x05\x04\x1d\x02\0\x05\x12\x04\xc7\x03\x11\x17\n\r\n\x05\x04\x1d\x02\
+    \0\x01\x12\x04\xc7\x03\x18\x1d\n\r\n\x05\x04\x1d\x02\0\x03\x12\x04\xc7\
+    \x03\x20!\n\x0c\n\x04\x04\x1d\x02\x01\x12\x04\xc8\x03\x08\x1a\n\x0f\n\
+    \x05\x04\x1d\x02\x01\x04\x12\x06\xc8\x03\x08\xc7\x03\"\n\r\n\x05\x04\x1d\
+    \x02\x01\x05\x12\x04\xc8\x03\x08\x0e\n\r\n\x05\x04\x1d\x02\x01\x01\x12\
+    \x04\xc8\x03\x0f\x15\n\r\n\x05\x04\x1d\x02\x01\x03\x12\x04\xc8\x03\x18\
+    \x19\n\x0c\n\x04\x04\x1d\x02\x02\x12\x04\xc9\x03\x08J\n\r\n\x05\x04\x1d\
+    \x02\x02\x04\x12\x04\xc9\x03\x08\x10\n\r\n\x05\x04\x1d\x02\x02\x06\x12\
+    \x04\xc9\x03\x11\x20\n\r\n\x05\x04\x1d\x02\x02\x01\x12\x04\xc9\x03!%\n\r\
+    \n\x05\x04\x1d\x02\x02\x03\x12\x04\xc9\x03()\n\r\n\x05\x04\x1d\x02\x02\
+    \x08\x12\x04\xc9\x03+I\n\x10\n\x08\x04\x1d\x02\x02\x08\xe9\xfb\x03\x12\
+    \x04\xc9\x03,H\n\x0c\n\x02\x04\x1e\x12\x06\xcc\x03\0\xd0\x03\x01\n\x0b\n\
+    \x03\x04\x1e\x01\x12\x04\xcc\x03\x08\x15\n}\n\x04\x04\x1e\x02\0\x12\x04\
+    \xcf\x03\x08!\x1ao\x20The\x20schema\x20for\x20L3\x20cache\x20id\x20and\
+    \x20capacity\x20bitmask\x20(CBM)\n\x20Format:\x20\"L3:<cache_id0>=<cbm0>\
+    ;<cache_id1>=<cbm1>;...\"\n\n\x0f\n\x05\x04\x1e\x02\0\x04\x12\x06\xcf\
+    \x03\x08\xcc\x03\x17\n\r\n\x05\x04\x1e\x02\0\x05\x12\x04\xcf\x03\x08\x0e\
+    \n\r\n\x05\x04\x1e\x02\0\x01\x12\x04\xcf\x03\x0f\x1c\n\r\n\x05\x04\x1e\
+    \x02\0\x03\x12\x04\xcf\x03\x1f\x20b\x06proto3\
 ";
 
 static mut file_descriptor_proto_lazy: ::protobuf::lazy::Lazy<::protobuf::descriptor::FileDescriptorProto> = ::protobuf::lazy::Lazy::INIT;
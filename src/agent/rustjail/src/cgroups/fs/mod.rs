@@ -249,6 +249,9 @@ pub const KMEM_LIMIT: &'static str = "memory.kmem.limit_in_bytes";
 pub const KMEM_TCP_LIMIT: &'static str = "memory.kmem.tcp.limit_in_bytes";
 pub const SWAPPINESS: &'static str = "memory.swappiness";
 pub const OOM_CONTROL: &'static str = "memory.oom_control";
+pub const MEMORY_MIN: &'static str = "memory.min";
+pub const MEMORY_LOW: &'static str = "memory.low";
+pub const MEMORY_HIGH: &'static str = "memory.high";
 pub const PIDS_MAX: &'static str = "pids.max";
 pub const BLKIO_WEIGHT: &'static str = "blkio.weight";
 pub const BLKIO_LEAF_WEIGHT: &'static str = "blkio.leaf_weight";
@@ -684,6 +687,8 @@ impl Subsystem for Memory {
     }
 
     fn set(&self, dir: &str, r: &LinuxResources, update: bool) -> Result<()> {
+        set_memory_qos(dir, r)?;
+
         if r.memory.is_none() {
             return Ok(());
         }
@@ -719,6 +724,48 @@ impl Subsystem for Memory {
     }
 }
 
+// set_memory_qos applies the memory QoS of the cgroup v2 hierarchy asked by
+// Kubernetes, as the unified resources memory.min, memory.low and
+// memory.high. On the v1 hierarchy the protected memory is turned into the
+// soft limit, unless the container has a reservation already, and
+// memory.high has no equivalent.
+fn set_memory_qos(dir: &str, r: &LinuxResources) -> Result<()> {
+    let mut protection = 0;
+
+    for file in &[MEMORY_MIN, MEMORY_LOW, MEMORY_HIGH] {
+        let v = match r.unified.get(*file) {
+            Some(v) => v.trim(),
+            None => continue,
+        };
+
+        if Path::new(dir).join(file).exists() {
+            write_file(dir, file, v)?;
+            continue;
+        }
+
+        if *file == MEMORY_HIGH {
+            warn!(
+                sl!(),
+                "{} not supported by the cgroup v1 hierarchy, ignored", file
+            );
+            continue;
+        }
+
+        match v.parse::<i64>() {
+            Ok(bytes) if bytes > protection => protection = bytes,
+            Ok(_) => {}
+            Err(_) => warn!(sl!(), "{} {:?} cannot be a soft limit, ignored", file, v),
+        }
+    }
+
+    let reservation = r.memory.as_ref().and_then(|m| m.reservation).unwrap_or(0);
+    if protection > 0 && reservation == 0 {
+        write_file(dir, MEMORY_SOFT_LIMIT, protection)?;
+    }
+
+    Ok(())
+}
+
 fn get_exist_memory_data(dir: &str, sub: &str) -> Result<Option<MemoryData>> {
     let res = match get_memory_data(dir, sub) {
         Err(Error(ErrorKind::Io(e), _)) => {
@@ -842,9 +889,13 @@ impl Pids {
             c.trim().parse::<u64>()?
         };
 
+        // The zombies are charged to the cgroup until they are reaped.
+        let zombies = count_zombies(dir)?;
+
         Ok(PidsStats {
             current,
             limit,
+            zombies,
             unknown_fields: UnknownFields::default(),
             cached_size: CachedSize::default(),
         })
@@ -1228,6 +1279,34 @@ fn get_all_procs(dir: &str) -> Result<Vec<i32>> {
     Ok(m)
 }
 
+// process_state returns the state of a process, given its /proc/<pid>/stat.
+// The command name between parentheses may contain any character, so the
+// state is the first field after the last parenthesis.
+fn process_state(stat: &str) -> Option<char> {
+    let i = stat.rfind(')')?;
+    stat[i + 1..].trim_start().chars().next()
+}
+
+// count_zombies returns the number of exited processes of the cgroup and
+// its children not reaped yet.
+fn count_zombies(dir: &str) -> Result<u64> {
+    let mut zombies = 0;
+
+    for pid in get_all_procs(dir)? {
+        // The process may have been reaped since listed
+        let stat = match fs::read_to_string(format!("/proc/{}/stat", pid)) {
+            Ok(stat) => stat,
+            Err(_) => continue,
+        };
+
+        if process_state(&stat) == Some('Z') {
+            zombies += 1;
+        }
+    }
+
+    Ok(zombies)
+}
+
 #[derive(Serialize, Deserialize, Debug, Clone)]
 pub struct Manager {
     pub paths: HashMap<String, String>,
@@ -1472,3 +1551,84 @@ pub fn get_guest_cpuset() -> Result<String> {
 
     get_param_string(m.get("cpuset").unwrap(), CPUSET_CPUS)
 }
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_process_state() {
+        assert_eq!(process_state("42 (sh) S 1 42 42 0 -1"), Some('S'));
+        assert_eq!(process_state("42 (a) b) Z 1 42 42 0 -1"), Some('Z'));
+        assert_eq!(process_state("42 (sh"), None);
+        assert_eq!(process_state("42 (sh)"), None);
+    }
+
+    #[test]
+    fn test_pids() {
+        let dir = std::env::temp_dir().join(format!("kata-pids-{}", std::process::id()));
+        fs::create_dir_all(&dir).unwrap();
+        let path = dir.to_str().unwrap();
+
+        fs::write(dir.join(PIDS_CURRENT), "3\n").unwrap();
+        fs::write(dir.join(CGROUP_PROCS), "").unwrap();
+
+        let mut r = LinuxResources::default();
+        r.pids = Some(oci::LinuxPids { limit: 100 });
+        Pids().set(path, &r, false).unwrap();
+        assert_eq!(fs::read_to_string(dir.join(PIDS_MAX)).unwrap(), "100");
+
+        let stats = Pids().get_stats(path).unwrap();
+        assert_eq!(stats.current, 3);
+        assert_eq!(stats.limit, 100);
+
+        // A limit removed is reported as none
+        r.pids = Some(oci::LinuxPids { limit: 0 });
+        Pids().set(path, &r, true).unwrap();
+        assert_eq!(Pids().get_stats(path).unwrap().limit, 0);
+
+        fs::remove_dir_all(&dir).unwrap();
+    }
+
+    #[test]
+    fn test_memory_qos() {
+        let dir = std::env::temp_dir().join(format!("kata-memory-qos-{}", std::process::id()));
+        fs::create_dir_all(&dir).unwrap();
+        let path = dir.to_str().unwrap();
+
+        let mut r = LinuxResources::default();
+        r.unified
+            .insert(MEMORY_MIN.to_string(), "1048576".to_string());
+        r.unified
+            .insert(MEMORY_LOW.to_string(), "2097152".to_string());
+        r.unified.insert(MEMORY_HIGH.to_string(), "max".to_string());
+
+        // cgroup v1, the protection becomes the soft limit
+        set_memory_qos(path, &r).unwrap();
+        assert_eq!(
+            fs::read_to_string(dir.join(MEMORY_SOFT_LIMIT)).unwrap(),
+            "2097152"
+        );
+        assert!(!dir.join(MEMORY_HIGH).exists());
+
+        // unless the container has a reservation
+        fs::remove_file(dir.join(MEMORY_SOFT_LIMIT)).unwrap();
+        r.memory = Some(oci::LinuxMemory {
+            reservation: Some(4096),
+            ..Default::default()
+        });
+        set_memory_qos(path, &r).unwrap();
+        assert!(!dir.join(MEMORY_SOFT_LIMIT).exists());
+
+        // cgroup v2, the files are written as they are
+        for file in &[MEMORY_MIN, MEMORY_LOW, MEMORY_HIGH] {
+            fs::write(dir.join(file), "0").unwrap();
+        }
+        set_memory_qos(path, &r).unwrap();
+        assert_eq!(fs::read_to_string(dir.join(MEMORY_MIN)).unwrap(), "1048576");
+        assert_eq!(fs::read_to_string(dir.join(MEMORY_LOW)).unwrap(), "2097152");
+        assert_eq!(fs::read_to_string(dir.join(MEMORY_HIGH)).unwrap(), "max");
+
+        fs::remove_dir_all(&dir).unwrap();
+    }
+}
@@ -22,6 +22,7 @@ use std::process::{Child, Command};
 // use crate::configs::namespaces::{NamespaceType};
 use crate::cgroups::Manager as CgroupManager;
 use crate::process::Process;
+use crate::reaper;
 // use crate::intelrdt::Manager as RdtManager;
 use crate::errors::*;
 use crate::log_child;
@@ -62,6 +63,7 @@ const PID_NS_PATH: &str = "/proc/self/ns/pid";
 
 const INIT: &str = "INIT";
 const NO_PIVOT: &str = "NO_PIVOT";
+const INIT_REAPER: &str = "INIT_REAPER";
 const CRFD_FD: &str = "CRFD_FD";
 const CWFD_FD: &str = "CWFD_FD";
 const CLOG_FD: &str = "CLOG_FD";
@@ -356,6 +358,7 @@ fn do_init_child(cwfd: RawFd) -> Result<()> {
 
     let init = std::env::var(INIT)?.eq(format!("{}", true).as_str());
     let no_pivot = std::env::var(NO_PIVOT)?.eq(format!("{}", true).as_str());
+    let init_reaper = std::env::var(INIT_REAPER)?.eq(format!("{}", true).as_str());
     let crfd = std::env::var(CRFD_FD)?.parse::<i32>().unwrap();
     let cfd_log = std::env::var(CLOG_FD)?.parse::<i32>().unwrap();
 
@@ -643,6 +646,10 @@ fn do_init_child(cwfd: RawFd) -> Result<()> {
         unistd::read(fd, &mut buf)?;
     }
 
+    if init_reaper {
+        return reaper::run(oci_process.terminal, || do_exec(&args));
+    }
+
     do_exec(&args);
 
     Err(ErrorKind::ErrorCode("fail to create container".to_string()).into())
@@ -845,6 +852,8 @@ impl BaseContainer for LinuxContainer {
         });
 
         let pidns = get_pid_namespace(&self.logger, linux)?;
+        // The container process is the init of its own pid namespace only.
+        let init_reaper = p.init && pidns.is_none() && self.config.init_reaper;
 
         if pidns.is_some() {
             sched::setns(pidns.unwrap(), CloneFlags::CLONE_NEWPID)
@@ -863,6 +872,7 @@ impl BaseContainer for LinuxContainer {
             .stderr(child_stderr)
             .env(INIT, format!("{}", p.init))
             .env(NO_PIVOT, format!("{}", self.config.no_pivot_root))
+            .env(INIT_REAPER, format!("{}", init_reaper))
             .env(CRFD_FD, format!("{}", crfd))
             .env(CWFD_FD, format!("{}", cwfd))
             .env(CLOG_FD, format!("{}", cfd_log));
@@ -1201,6 +1211,19 @@ fn join_namespaces(
             for h in hooks.prestart.iter() {
                 execute_hook(&logger, h, st)?;
             }
+
+            // The rootfs of the container is mounted and not pivoted yet.
+            // The createContainer hooks are run from the agent namespaces
+            // as well, the agent not entering the container ones.
+            info!(logger, "createRuntime hook");
+            for h in hooks.create_runtime.iter() {
+                execute_hook(&logger, h, st)?;
+            }
+
+            info!(logger, "createContainer hook");
+            for h in hooks.create_container.iter() {
+                execute_hook(&logger, h, st)?;
+            }
         }
 
         // notify child run prestart hooks completed
@@ -1214,8 +1237,16 @@ fn join_namespaces(
 
         //run poststart hook
         if spec.hooks.is_some() {
-            info!(logger, "poststart hook");
             let hooks = spec.hooks.as_ref().unwrap();
+
+            // The child waits on the exec fifo, the user process is not
+            // executed yet.
+            info!(logger, "startContainer hook");
+            for h in hooks.start_container.iter() {
+                execute_hook(&logger, h, st)?;
+            }
+
+            info!(logger, "poststart hook");
             for h in hooks.poststart.iter() {
                 execute_hook(&logger, h, st)?;
             }
@@ -1344,6 +1375,49 @@ impl LinuxContainer {
         })
     }
 
+    // replace_init_process makes a process restored from a checkpoint the
+    // init process of a created container, in place of the one waiting to
+    // be started, which is killed.
+    pub fn replace_init_process(&mut self, pid: pid_t) -> Result<()> {
+        if self.status() != Status::CREATED {
+            return Err(ErrorKind::ErrorCode(format!(
+                "container {} is not in created state",
+                self.id
+            ))
+            .into());
+        }
+
+        let created = self.init_process_pid;
+        let mut p = match self.processes.remove(&created) {
+            Some(p) => p,
+            None => {
+                return Err(ErrorKind::ErrorCode(format!(
+                    "container {} has no init process",
+                    self.id
+                ))
+                .into())
+            }
+        };
+
+        if let Some(m) = self.cgroup_manager.as_ref() {
+            m.apply(pid)?;
+        }
+
+        p.pid = pid;
+        self.processes.insert(pid, p);
+        self.init_process_pid = pid;
+        self.init_process_start_time = SystemTime::now()
+            .duration_since(SystemTime::UNIX_EPOCH)
+            .unwrap()
+            .as_secs();
+        self.status.transition(Status::RUNNING);
+
+        let _ = signal::kill(Pid::from_raw(created), Some(Signal::SIGKILL));
+        info!(self.logger, "init process replaced"; "pid" => pid);
+
+        Ok(())
+    }
+
     fn load<T: Into<String>>(_id: T, _base: T) -> Result<Self> {
         Err(ErrorKind::ErrorCode("not supported".to_string()).into())
     }
@@ -48,6 +48,7 @@ pub mod container;
 pub mod errors;
 pub mod mount;
 pub mod process;
+pub mod reaper;
 pub mod specconv;
 pub mod sync;
 pub mod validator;
@@ -191,12 +192,21 @@ fn hook_grpc_to_oci(h: &[grpcHook]) -> Vec<ociHook> {
 fn hooks_grpc_to_oci(h: &grpcHooks) -> ociHooks {
     let prestart = hook_grpc_to_oci(h.Prestart.as_ref());
 
+    let create_runtime = hook_grpc_to_oci(h.CreateRuntime.as_ref());
+
+    let create_container = hook_grpc_to_oci(h.CreateContainer.as_ref());
+
+    let start_container = hook_grpc_to_oci(h.StartContainer.as_ref());
+
     let poststart = hook_grpc_to_oci(h.Poststart.as_ref());
 
     let poststop = hook_grpc_to_oci(h.Poststop.as_ref());
 
     ociHooks {
         prestart,
+        create_runtime,
+        create_container,
+        start_container,
         poststart,
         poststop,
     }
@@ -406,6 +416,7 @@ pub fn resources_grpc_to_oci(res: &grpcLinuxResources) -> ociLinuxResources {
         hugepage_limits,
         network,
         rdma: HashMap::new(),
+        unified: res.Unified.clone(),
     }
 }
 
@@ -430,6 +441,12 @@ fn seccomp_grpc_to_oci(sec: &grpcLinuxSeccomp) -> ociLinuxSeccomp {
             r.push(ociLinuxSyscall {
                 names: sys.Names.clone().into_vec(),
                 action: sys.Action.clone(),
+                // SCMP_ACT_ERRNO returns EPERM unless told otherwise
+                errno_ret: if sys.ErrnoRet == 0 {
+                    None
+                } else {
+                    Some(sys.ErrnoRet)
+                },
                 args,
             });
         }
@@ -439,6 +456,7 @@ fn seccomp_grpc_to_oci(sec: &grpcLinuxSeccomp) -> ociLinuxSeccomp {
     ociLinuxSeccomp {
         default_action: sec.DefaultAction.clone(),
         architectures: sec.Architectures.clone().into_vec(),
+        flags: sec.Flags.clone().into_vec(),
         syscalls,
     }
 }
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// A minimal init for the containers having a pid namespace of their own.
+// The processes orphaned in the namespace are reparented to its first
+// process, which the container process does not always reap, so that their
+// zombies end up exhausting the pids of the container. The init runs the
+// container process as its child instead, reaps all the processes exiting in
+// the namespace, and forwards the signals it receives to the container
+// process.
+
+use nix::sys::signal::{self, SigSet, SigmaskHow, Signal};
+use nix::sys::wait::{self, WaitPidFlag, WaitStatus};
+use nix::unistd::{self, ForkResult, Pid};
+
+use crate::errors::*;
+
+// exit_code returns the code the init exits with for the status of the
+// container process, 128 plus the signal number as shells do when it was
+// killed by a signal.
+fn exit_code(status: WaitStatus) -> Option<i32> {
+    match status {
+        WaitStatus::Exited(_, code) => Some(code),
+        WaitStatus::Signaled(_, sig, _) => Some(128 + sig as i32),
+        _ => None,
+    }
+}
+
+// run forks the container process, which runs exec, and reaps the processes
+// of the namespace until the container process exits. It only returns in the
+// container process, or when forking it failed.
+pub fn run<F>(terminal: bool, exec: F) -> Result<()>
+where
+    F: FnOnce() -> Result<()>,
+{
+    let all = SigSet::all();
+    let mut old = SigSet::empty();
+    signal::sigprocmask(SigmaskHow::SIG_BLOCK, Some(&all), Some(&mut old))?;
+
+    let child = match unistd::fork()? {
+        ForkResult::Child => {
+            signal::sigprocmask(SigmaskHow::SIG_SETMASK, Some(&old), None)?;
+            if terminal {
+                unistd::setpgid(Pid::from_raw(0), Pid::from_raw(0))?;
+            }
+            return exec();
+        }
+        ForkResult::Parent { child } => child,
+    };
+
+    // The container process joins the foreground process group of the
+    // terminal, for it to receive the signals of the terminal. Both sides
+    // set its process group, since either can run first.
+    if terminal {
+        let _ = unistd::setpgid(child, child);
+        let _ = unistd::tcsetpgrp(0, child);
+    }
+
+    loop {
+        // The signals out of the nix Signal type, the real-time ones, are
+        // dropped.
+        let sig = match all.wait() {
+            Ok(sig) => sig,
+            Err(_) => continue,
+        };
+
+        if sig != Signal::SIGCHLD {
+            let _ = signal::kill(child, sig);
+            continue;
+        }
+
+        // Several exits may be notified by a single SIGCHLD.
+        loop {
+            match wait::waitpid(
+                Pid::from_raw(-1),
+                Some(WaitPidFlag::WNOHANG | WaitPidFlag::__WALL),
+            ) {
+                Ok(WaitStatus::StillAlive) | Err(_) => break,
+                Ok(status) => {
+                    if status.pid() != Some(child) {
+                        continue;
+                    }
+                    // The kernel kills the processes left in the namespace
+                    // when its init exits.
+                    if let Some(code) = exit_code(status) {
+                        std::process::exit(code);
+                    }
+                }
+            }
+        }
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_exit_code() {
+        let pid = Pid::from_raw(1);
+
+        assert_eq!(exit_code(WaitStatus::Exited(pid, 0)), Some(0));
+        assert_eq!(exit_code(WaitStatus::Exited(pid, 3)), Some(3));
+        assert_eq!(
+            exit_code(WaitStatus::Signaled(pid, Signal::SIGKILL, false)),
+            Some(137)
+        );
+        assert_eq!(exit_code(WaitStatus::Stopped(pid, Signal::SIGSTOP)), None);
+    }
+}
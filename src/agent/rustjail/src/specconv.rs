@@ -16,6 +16,9 @@ pub struct CreateOpts {
     pub spec: Option<Spec>,
     pub rootless_euid: bool,
     pub rootless_cgroup: bool,
+    // runs a minimal init reaping the orphaned processes of a container
+    // having its own pid namespace
+    pub init_reaper: bool,
 }
 /*
 const WILDCARD: i32 = -1;
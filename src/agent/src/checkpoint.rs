@@ -0,0 +1,304 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use crate::sandbox::Sandbox;
+use nix::fcntl::{self, OFlag};
+use nix::sys::stat::Mode;
+use nix::unistd;
+use oci::Spec;
+use protocols::agent::{CheckpointContainerRequest, RestoreContainerRequest};
+use rustjail::container::{BaseContainer, LinuxContainer, Status};
+use rustjail::errors::*;
+use slog::Logger;
+use std::fs;
+use std::os::unix::io::RawFd;
+use std::path::Path;
+use std::process::Command;
+use std::sync::{Arc, Mutex};
+
+// The pid files of the restored processes, named after the container.
+const PIDFILES_DIR: &str = "/run/kata-containers/checkpoint";
+
+const CRIU_PATH: &str = "/usr/sbin/criu";
+
+// STDIO_FILE records, with the images, what the standard streams of the
+// checkpointed init process were, for the restored process to use the
+// ones of the container it is restored in.
+const STDIO_FILE: &str = "kata-stdio";
+
+// The namespaces a restored container shares with the sandbox, joined
+// through the ones of the container it is restored in.
+const JOINED_NAMESPACES: &[&str] = &["ipc", "uts"];
+
+// images_dir checks the CRIU images directory of a request.
+fn images_dir(dir: &str) -> Result<&str> {
+    if !Path::new(dir).is_absolute() {
+        return Err(ErrorKind::ErrorCode(format!("invalid images directory {:?}", dir)).into());
+    }
+
+    Ok(dir)
+}
+
+// external_mounts returns the destination and the source of the bind
+// mounts of a container, which CRIU does not checkpoint.
+fn external_mounts(spec: &Spec) -> Vec<(String, String)> {
+    spec.mounts
+        .iter()
+        .filter(|m| m.r#type == "bind" || m.options.iter().any(|o| o == "bind" || o == "rbind"))
+        .map(|m| (m.destination.clone(), m.source.clone()))
+        .collect()
+}
+
+fn criu(args: &[String]) -> Result<()> {
+    let output = Command::new(CRIU_PATH).args(args).output()?;
+    if !output.status.success() {
+        return Err(ErrorKind::ErrorCode(format!(
+            "criu {} failed: {}",
+            args[0],
+            String::from_utf8_lossy(&output.stderr).trim()
+        ))
+        .into());
+    }
+
+    Ok(())
+}
+
+fn rootfs(ctr: &LinuxContainer) -> Result<String> {
+    match ctr.config.spec.as_ref().and_then(|s| s.root.as_ref()) {
+        Some(r) => Ok(r.path.clone()),
+        None => Err(ErrorKind::ErrorCode(format!("container {} has no root", ctr.id)).into()),
+    }
+}
+
+fn checkpoint(logger: &Logger, ctr: &LinuxContainer, dir: &str, leave_running: bool) -> Result<()> {
+    if ctr.status() != Status::RUNNING && ctr.status() != Status::PAUSED {
+        return Err(ErrorKind::ErrorCode(format!("container {} is not running", ctr.id)).into());
+    }
+
+    let pid = ctr.init_process_pid;
+
+    let mut stdio = String::new();
+    for fd in 0..3 {
+        if let Ok(target) = fs::read_link(format!("/proc/{}/fd/{}", pid, fd)) {
+            stdio.push_str(&format!("{} {}\n", fd, target.display()));
+        }
+    }
+    fs::write(Path::new(dir).join(STDIO_FILE), stdio)?;
+
+    let mut args: Vec<String> = vec![
+        "dump".to_string(),
+        "--tree".to_string(),
+        pid.to_string(),
+        "--images-dir".to_string(),
+        dir.to_string(),
+        "--root".to_string(),
+        rootfs(ctr)?,
+        "--manage-cgroups".to_string(),
+        "--tcp-established".to_string(),
+        "--ext-unix-sk".to_string(),
+        "--file-locks".to_string(),
+    ];
+
+    if leave_running {
+        args.push("--leave-running".to_string());
+    }
+
+    if let Some(spec) = ctr.config.spec.as_ref() {
+        for (dest, _) in external_mounts(spec) {
+            args.push("--external".to_string());
+            args.push(format!("mnt[{}]:{}", dest, dest));
+        }
+    }
+
+    criu(&args)?;
+
+    info!(logger, "container checkpointed";
+        "container" => &ctr.id,
+        "dir" => dir,
+        "leave-running" => leave_running);
+
+    Ok(())
+}
+
+// inherited_stdio opens the standard streams of the created init process
+// the ones of the checkpointed one were, for CRIU to hand them over to the
+// restored process. The descriptors are inherited by CRIU.
+fn inherited_stdio(dir: &str, created: i32) -> Result<Vec<(RawFd, String)>> {
+    let stdio = fs::read_to_string(Path::new(dir).join(STDIO_FILE))?;
+    let mut fds = Vec::new();
+
+    for line in stdio.lines() {
+        let fields: Vec<&str> = line.splitn(2, ' ').collect();
+        if fields.len() != 2 || !fields[1].starts_with("pipe:") {
+            continue;
+        }
+
+        let flags = if fields[0] == "0" {
+            OFlag::O_RDONLY
+        } else {
+            OFlag::O_WRONLY
+        };
+
+        let fd = fcntl::open(
+            format!("/proc/{}/fd/{}", created, fields[0]).as_str(),
+            flags,
+            Mode::empty(),
+        )?;
+        fds.push((fd, fields[1].to_string()));
+    }
+
+    Ok(fds)
+}
+
+fn restore(logger: &Logger, ctr: &mut LinuxContainer, dir: &str) -> Result<()> {
+    if ctr.status() != Status::CREATED {
+        return Err(ErrorKind::ErrorCode(format!("container {} is not created", ctr.id)).into());
+    }
+
+    let created = ctr.init_process_pid;
+    fs::create_dir_all(PIDFILES_DIR)?;
+    let pidfile = format!("{}/{}.pid", PIDFILES_DIR, ctr.id);
+
+    let mut args: Vec<String> = vec![
+        "restore".to_string(),
+        "--images-dir".to_string(),
+        dir.to_string(),
+        "--root".to_string(),
+        rootfs(ctr)?,
+        "--restore-detached".to_string(),
+        "--pidfile".to_string(),
+        pidfile.clone(),
+        "--manage-cgroups".to_string(),
+        "--tcp-established".to_string(),
+        "--ext-unix-sk".to_string(),
+        "--file-locks".to_string(),
+    ];
+
+    if let Some(spec) = ctr.config.spec.as_ref() {
+        for (dest, source) in external_mounts(spec) {
+            args.push("--external".to_string());
+            args.push(format!("mnt[{}]:{}", dest, source));
+        }
+    }
+
+    for ns in JOINED_NAMESPACES {
+        args.push("--join-ns".to_string());
+        args.push(format!("{}:/proc/{}/ns/{}", ns, created, ns));
+    }
+
+    let stdio = inherited_stdio(dir, created)?;
+    for (fd, key) in stdio.iter() {
+        args.push("--inherit-fd".to_string());
+        args.push(format!("fd[{}]:{}", fd, key));
+    }
+
+    let result = criu(&args);
+    for (fd, _) in stdio {
+        let _ = unistd::close(fd);
+    }
+    result?;
+
+    let pid = fs::read_to_string(&pidfile)?.trim().parse::<i32>()?;
+    let _ = fs::remove_file(&pidfile);
+
+    ctr.replace_init_process(pid)?;
+
+    info!(logger, "container restored";
+        "container" => &ctr.id,
+        "dir" => dir,
+        "pid" => pid);
+
+    Ok(())
+}
+
+// checkpoint_container checkpoints a running or paused container as
+// requested by the runtime.
+pub fn checkpoint_container(
+    logger: &Logger,
+    sandbox: &Arc<Mutex<Sandbox>>,
+    req: &CheckpointContainerRequest,
+) -> Result<()> {
+    let dir = images_dir(req.get_images_dir())?;
+    let cid = req.get_container_id();
+
+    let mut s = sandbox.lock().unwrap();
+    let ctr = match s.get_container(cid) {
+        Some(c) => c,
+        None => return Err(ErrorKind::ErrorCode(format!("container {} not found", cid)).into()),
+    };
+
+    checkpoint(logger, ctr, dir, req.get_leave_running())
+}
+
+// restore_container restores a checkpointed container in place of a
+// created one, as requested by the runtime.
+pub fn restore_container(
+    logger: &Logger,
+    sandbox: &Arc<Mutex<Sandbox>>,
+    req: &RestoreContainerRequest,
+) -> Result<()> {
+    let dir = images_dir(req.get_images_dir())?;
+    let cid = req.get_container_id();
+
+    let mut s = sandbox.lock().unwrap();
+    let ctr = match s.get_container(cid) {
+        Some(c) => c,
+        None => return Err(ErrorKind::ErrorCode(format!("container {} not found", cid)).into()),
+    };
+
+    restore(logger, ctr, dir)
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use oci::Mount;
+
+    #[test]
+    fn test_images_dir() {
+        assert_eq!(images_dir("/run/images").unwrap(), "/run/images");
+        assert!(images_dir("images").is_err());
+        assert!(images_dir("").is_err());
+    }
+
+    #[test]
+    fn test_external_mounts() {
+        let mut spec = Spec::default();
+        spec.mounts = vec![
+            Mount {
+                destination: "/proc".to_string(),
+                r#type: "proc".to_string(),
+                source: "proc".to_string(),
+                options: vec![],
+            },
+            Mount {
+                destination: "/etc/hosts".to_string(),
+                r#type: "bind".to_string(),
+                source: "/run/kata-containers/shared/containers/ctr-hosts".to_string(),
+                options: vec!["rbind".to_string()],
+            },
+            Mount {
+                destination: "/data".to_string(),
+                r#type: "local".to_string(),
+                source: "/run/kata-containers/sandbox/local/data".to_string(),
+                options: vec!["bind".to_string()],
+            },
+        ];
+
+        assert_eq!(
+            external_mounts(&spec),
+            vec![
+                (
+                    "/etc/hosts".to_string(),
+                    "/run/kata-containers/shared/containers/ctr-hosts".to_string()
+                ),
+                (
+                    "/data".to_string(),
+                    "/run/kata-containers/sandbox/local/data".to_string()
+                ),
+            ]
+        );
+    }
+}
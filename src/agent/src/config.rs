@@ -13,6 +13,25 @@ const HOTPLUG_TIMOUT_OPTION: &str = "agent.hotplug_timeout";
 const DEBUG_CONSOLE_VPORT_OPTION: &str = "agent.debug_console_vport";
 const LOG_VPORT_OPTION: &str = "agent.log_vport";
 const CONTAINER_PIPE_SIZE_OPTION: &str = "agent.container_pipe_size";
+const NTP_SERVERS_OPTION: &str = "agent.ntp_servers";
+const NTP_POOLS_OPTION: &str = "agent.ntp_pools";
+const PTP_KVM_FLAG: &str = "agent.ptp_kvm";
+const LOCALE_OPTION: &str = "agent.locale";
+const KDUMP_FLAG: &str = "agent.kdump";
+const KDUMP_DEVICE_OPTION: &str = "agent.kdump_device";
+const KDUMP_CAPTURE_FLAG: &str = "agent.kdump_capture";
+const KDUMP_REDACT_OPTION: &str = "agent.kdump_redact";
+const KDUMP_REDACT_REGIONS_OPTION: &str = "agent.kdump_redact_regions";
+const PROFILING_OPTION: &str = "agent.profiling";
+const LIVEPATCH_OPTION: &str = "agent.livepatch";
+const DHCP_OPTION: &str = "agent.dhcp";
+const CACHE_DROP_INTERVAL_OPTION: &str = "agent.cache_drop_interval";
+const CACHE_DROP_THRESHOLD_OPTION: &str = "agent.cache_drop_threshold";
+const VFS_CACHE_PRESSURE_OPTION: &str = "agent.vfs_cache_pressure";
+const IDLE_SCAN_INTERVAL_OPTION: &str = "agent.idle_scan_interval";
+const METADATA_PROXY_VPORT_OPTION: &str = "agent.metadata_proxy_vport";
+const NO_CONTAINER_REAPER_FLAG: &str = "agent.no_container_reaper";
+const POWER_OFF_FLAG: &str = "agent.power_off";
 
 const DEFAULT_LOG_LEVEL: slog::Level = slog::Level::Info;
 const DEFAULT_HOTPLUG_TIMEOUT: time::Duration = time::Duration::from_secs(3);
@@ -31,6 +50,25 @@ pub struct agentConfig {
     pub debug_console_vport: i32,
     pub log_vport: i32,
     pub container_pipe_size: i32,
+    pub ntp_servers: Vec<String>,
+    pub ntp_pools: Vec<String>,
+    pub ptp_kvm: bool,
+    pub locale: String,
+    pub kdump: bool,
+    pub kdump_device: String,
+    pub kdump_capture: bool,
+    pub kdump_redact: Vec<String>,
+    pub kdump_redact_regions: Vec<String>,
+    pub profiling_tools: Vec<String>,
+    pub livepatch_modules: Vec<String>,
+    pub dhcp_interfaces: Vec<String>,
+    pub cache_drop_interval: time::Duration,
+    pub cache_drop_threshold_mb: u64,
+    pub vfs_cache_pressure: u64,
+    pub idle_scan_interval: time::Duration,
+    pub metadata_proxy_vport: u32,
+    pub container_reaper: bool,
+    pub power_off: bool,
 }
 
 impl agentConfig {
@@ -43,6 +81,25 @@ impl agentConfig {
             debug_console_vport: 0,
             log_vport: 0,
             container_pipe_size: DEFAULT_CONTAINER_PIPE_SIZE,
+            ntp_servers: Vec::new(),
+            ntp_pools: Vec::new(),
+            ptp_kvm: false,
+            locale: String::new(),
+            kdump: false,
+            kdump_device: String::new(),
+            kdump_capture: false,
+            kdump_redact: Vec::new(),
+            kdump_redact_regions: Vec::new(),
+            profiling_tools: Vec::new(),
+            livepatch_modules: Vec::new(),
+            dhcp_interfaces: Vec::new(),
+            cache_drop_interval: time::Duration::from_secs(0),
+            cache_drop_threshold_mb: 0,
+            vfs_cache_pressure: 0,
+            idle_scan_interval: time::Duration::from_secs(0),
+            metadata_proxy_vport: 0,
+            container_reaper: true,
+            power_off: false,
         }
     }
 
@@ -89,6 +146,95 @@ impl agentConfig {
                 let container_pipe_size = get_container_pipe_size(param)?;
                 self.container_pipe_size = container_pipe_size
             }
+
+            if param.starts_with(format!("{}=", NTP_SERVERS_OPTION).as_str()) {
+                self.ntp_servers = get_string_list(param, NTP_SERVERS_OPTION)?;
+            }
+
+            if param.starts_with(format!("{}=", NTP_POOLS_OPTION).as_str()) {
+                self.ntp_pools = get_string_list(param, NTP_POOLS_OPTION)?;
+            }
+
+            if param.eq(&PTP_KVM_FLAG) {
+                self.ptp_kvm = true;
+            }
+
+            if param.starts_with(format!("{}=", LOCALE_OPTION).as_str()) {
+                self.locale = get_string_value(param, LOCALE_OPTION)?;
+            }
+
+            if param.eq(&KDUMP_FLAG) {
+                self.kdump = true;
+            }
+
+            if param.starts_with(format!("{}=", KDUMP_DEVICE_OPTION).as_str()) {
+                self.kdump_device = get_string_value(param, KDUMP_DEVICE_OPTION)?;
+            }
+
+            if param.eq(&KDUMP_CAPTURE_FLAG) {
+                self.kdump_capture = true;
+            }
+
+            if param.starts_with(format!("{}=", KDUMP_REDACT_OPTION).as_str()) {
+                self.kdump_redact = get_string_list(param, KDUMP_REDACT_OPTION)?;
+            }
+
+            if param.starts_with(format!("{}=", KDUMP_REDACT_REGIONS_OPTION).as_str()) {
+                self.kdump_redact_regions = get_string_list(param, KDUMP_REDACT_REGIONS_OPTION)?;
+            }
+
+            if param.starts_with(format!("{}=", PROFILING_OPTION).as_str()) {
+                self.profiling_tools = get_string_list(param, PROFILING_OPTION)?;
+            }
+
+            if param.starts_with(format!("{}=", LIVEPATCH_OPTION).as_str()) {
+                self.livepatch_modules = get_string_list(param, LIVEPATCH_OPTION)?;
+            }
+
+            if param.starts_with(format!("{}=", DHCP_OPTION).as_str()) {
+                self.dhcp_interfaces = get_string_list(param, DHCP_OPTION)?;
+            }
+
+            if param.starts_with(format!("{}=", CACHE_DROP_INTERVAL_OPTION).as_str()) {
+                let secs = get_number_value(param, CACHE_DROP_INTERVAL_OPTION)?;
+                self.cache_drop_interval = time::Duration::from_secs(secs);
+            }
+
+            if param.starts_with(format!("{}=", CACHE_DROP_THRESHOLD_OPTION).as_str()) {
+                self.cache_drop_threshold_mb =
+                    get_number_value(param, CACHE_DROP_THRESHOLD_OPTION)?;
+            }
+
+            if param.starts_with(format!("{}=", VFS_CACHE_PRESSURE_OPTION).as_str()) {
+                self.vfs_cache_pressure = get_number_value(param, VFS_CACHE_PRESSURE_OPTION)?;
+            }
+
+            if param.starts_with(format!("{}=", IDLE_SCAN_INTERVAL_OPTION).as_str()) {
+                let secs = get_number_value(param, IDLE_SCAN_INTERVAL_OPTION)?;
+                self.idle_scan_interval = time::Duration::from_secs(secs);
+            }
+
+            // The host ports of vhost-vsock are allocated by the kernel,
+            // over the i32 range get_vsock_port parses.
+            if param.starts_with(format!("{}=", METADATA_PROXY_VPORT_OPTION).as_str()) {
+                let port = get_number_value(param, METADATA_PROXY_VPORT_OPTION)?;
+                if port > u32::MAX as u64 {
+                    return Err(ErrorKind::ErrorCode(format!(
+                        "invalid {} value {}",
+                        METADATA_PROXY_VPORT_OPTION, port
+                    ))
+                    .into());
+                }
+                self.metadata_proxy_vport = port as u32;
+            }
+
+            if param.eq(&NO_CONTAINER_REAPER_FLAG) {
+                self.container_reaper = false;
+            }
+
+            if param.eq(&POWER_OFF_FLAG) {
+                self.power_off = true;
+            }
         }
 
         Ok(())
@@ -199,6 +345,48 @@ fn get_container_pipe_size(param: &str) -> Result<i32> {
     Ok(value)
 }
 
+// get_string_list returns the comma separated values of a parameter,
+// skipping the empty ones.
+fn get_string_list(param: &str, option: &str) -> Result<Vec<String>> {
+    let fields: Vec<&str> = param.splitn(2, "=").collect();
+
+    if fields.len() != 2 {
+        return Err(ErrorKind::ErrorCode(String::from("invalid list parameter")).into());
+    }
+
+    if fields[0] != option {
+        return Err(ErrorKind::ErrorCode(String::from("invalid list key name")).into());
+    }
+
+    Ok(fields[1]
+        .split(",")
+        .filter(|v| !v.is_empty())
+        .map(String::from)
+        .collect())
+}
+
+fn get_string_value(param: &str, option: &str) -> Result<String> {
+    let fields: Vec<&str> = param.splitn(2, "=").collect();
+
+    if fields.len() != 2 {
+        return Err(ErrorKind::ErrorCode(String::from("invalid string parameter")).into());
+    }
+
+    if fields[0] != option {
+        return Err(ErrorKind::ErrorCode(String::from("invalid string key name")).into());
+    }
+
+    Ok(fields[1].to_string())
+}
+
+fn get_number_value(param: &str, option: &str) -> Result<u64> {
+    let value = get_string_value(param, option)?;
+
+    value
+        .parse::<u64>()
+        .map_err(|_| ErrorKind::ErrorCode(format!("invalid {} value {}", option, value)).into())
+}
+
 #[cfg(test)]
 mod tests {
     use super::*;
@@ -255,6 +443,8 @@ mod tests {
         assert_eq!(config.dev_mode, false);
         assert_eq!(config.log_level, DEFAULT_LOG_LEVEL);
         assert_eq!(config.hotplug_timeout, DEFAULT_HOTPLUG_TIMEOUT);
+        assert_eq!(config.container_reaper, true);
+        assert_eq!(config.power_off, false);
     }
 
     #[test]
@@ -878,4 +1068,114 @@ mod tests {
             assert_result!(d.result, result, format!("{}", msg));
         }
     }
+
+    #[test]
+    fn test_get_string_list() {
+        #[derive(Debug)]
+        struct TestData<'a> {
+            param: &'a str,
+            result: Result<Vec<String>>,
+        }
+
+        let tests = &[
+            TestData {
+                param: "agent.ntp_servers",
+                result: Err(make_err("invalid list parameter")),
+            },
+            TestData {
+                param: "agent.ntp_pool=pool.ntp.org",
+                result: Err(make_err("invalid list key name")),
+            },
+            TestData {
+                param: "agent.ntp_servers=",
+                result: Ok(vec![]),
+            },
+            TestData {
+                param: "agent.ntp_servers=10.0.0.1",
+                result: Ok(vec!["10.0.0.1".to_string()]),
+            },
+            TestData {
+                param: "agent.ntp_servers=ntp1.example.com,,10.0.0.1",
+                result: Ok(vec!["ntp1.example.com".to_string(), "10.0.0.1".to_string()]),
+            },
+        ];
+
+        for (i, d) in tests.iter().enumerate() {
+            let msg = format!("test[{}]: {:?}", i, d);
+
+            let result = get_string_list(d.param, NTP_SERVERS_OPTION);
+
+            let msg = format!("{}: result: {:?}", msg, result);
+
+            assert_result!(d.result, result, format!("{}", msg));
+        }
+    }
+
+    #[test]
+    fn test_get_string_value() {
+        #[derive(Debug)]
+        struct TestData<'a> {
+            param: &'a str,
+            result: Result<String>,
+        }
+
+        let tests = &[
+            TestData {
+                param: "agent.locale",
+                result: Err(make_err("invalid string parameter")),
+            },
+            TestData {
+                param: "agent.locales=C.UTF-8",
+                result: Err(make_err("invalid string key name")),
+            },
+            TestData {
+                param: "agent.locale=en_US.UTF-8",
+                result: Ok("en_US.UTF-8".to_string()),
+            },
+        ];
+
+        for (i, d) in tests.iter().enumerate() {
+            let msg = format!("test[{}]: {:?}", i, d);
+
+            let result = get_string_value(d.param, LOCALE_OPTION);
+
+            let msg = format!("{}: result: {:?}", msg, result);
+
+            assert_result!(d.result, result, format!("{}", msg));
+        }
+    }
+
+    #[test]
+    fn test_get_number_value() {
+        #[derive(Debug)]
+        struct TestData<'a> {
+            param: &'a str,
+            result: Result<u64>,
+        }
+
+        let tests = &[
+            TestData {
+                param: "agent.vfs_cache_pressure",
+                result: Err(make_err("invalid string parameter")),
+            },
+            TestData {
+                param: "agent.vfs_cache_pressure=-1",
+                result: Err(make_err("invalid agent.vfs_cache_pressure value -1")),
+            },
+            TestData {
+                param: "agent.vfs_cache_pressure=200",
+                result: Ok(200),
+            },
+        ];
+
+        for (i, d) in tests.iter().enumerate() {
+            let msg = format!("test[{}]: {:?}", i, d);
+
+            let result = get_number_value(d.param, VFS_CACHE_PRESSURE_OPTION);
+
+            let msg = format!("{}: result: {:?}", msg, result);
+
+            assert_result!(d.result, result, format!("{}", msg));
+        }
+    }
 }
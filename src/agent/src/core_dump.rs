@@ -0,0 +1,239 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use rustjail::errors::*;
+use std::collections::HashMap;
+use std::env;
+use std::fs::{self, File};
+use std::io::{self, Read};
+use std::path::Path;
+
+// The annotations the runtime sets on the container spec.
+const POLICY_ANNOTATION: &str = "io.katacontainers.agent.coredump.policy";
+const DIR_ANNOTATION: &str = "io.katacontainers.agent.coredump.dir";
+const MAX_SIZE_ANNOTATION: &str = "io.katacontainers.agent.coredump.max_size";
+
+const POLICY_DISCARD: &str = "discard";
+const POLICY_CAPTURE: &str = "capture";
+
+const CORE_PATTERN_PATH: &str = "/proc/sys/kernel/core_pattern";
+
+// POLICIES_DIR holds the core dump policy of each container, read by the
+// core dump handlers run by the kernel.
+const POLICIES_DIR: &str = "/run/kata-containers/coredump";
+
+// CORE_DUMP_ARG is the agent argument running it as the core dump handler.
+pub const CORE_DUMP_ARG: &str = "coredump";
+
+#[derive(Debug, PartialEq)]
+struct Policy {
+    // The mount namespace of the container processes, "mnt:[inode]".
+    mnt_ns: String,
+    discard: bool,
+    dir: String,
+    max_size: u64,
+}
+
+impl Policy {
+    fn from_annotations(
+        mnt_ns: &str,
+        annotations: &HashMap<String, String>,
+    ) -> Result<Option<Policy>> {
+        let discard = match annotations.get(POLICY_ANNOTATION).map(|s| s.as_str()) {
+            None => return Ok(None),
+            Some(POLICY_DISCARD) => true,
+            Some(POLICY_CAPTURE) => false,
+            Some(p) => {
+                return Err(ErrorKind::ErrorCode(format!("invalid core dump policy {}", p)).into())
+            }
+        };
+
+        let dir = annotations.get(DIR_ANNOTATION).cloned().unwrap_or_default();
+        if !discard && dir.is_empty() {
+            return Err(ErrorKind::ErrorCode(String::from("no core dump directory")).into());
+        }
+
+        let max_size = match annotations.get(MAX_SIZE_ANNOTATION) {
+            Some(s) => s.parse::<u64>()?,
+            None => 0,
+        };
+
+        Ok(Some(Policy {
+            mnt_ns: mnt_ns.to_string(),
+            discard,
+            dir,
+            max_size,
+        }))
+    }
+
+    fn parse(s: &str) -> Option<Policy> {
+        let fields: Vec<&str> = s.trim_end().splitn(4, ' ').collect();
+        if fields.len() != 4 {
+            return None;
+        }
+
+        Some(Policy {
+            mnt_ns: fields[0].to_string(),
+            discard: fields[1] == POLICY_DISCARD,
+            max_size: fields[2].parse::<u64>().ok()?,
+            dir: fields[3].to_string(),
+        })
+    }
+
+    fn serialize(&self) -> String {
+        let policy = if self.discard {
+            POLICY_DISCARD
+        } else {
+            POLICY_CAPTURE
+        };
+
+        // The directory is last, as it may contain spaces.
+        format!(
+            "{} {} {} {}\n",
+            self.mnt_ns, policy, self.max_size, self.dir
+        )
+    }
+}
+
+fn mnt_ns(pid: &str) -> io::Result<String> {
+    Ok(fs::read_link(format!("/proc/{}/ns/mnt", pid))?
+        .to_string_lossy()
+        .to_string())
+}
+
+// register_container records the core dump policy of a container, once its
+// init process is created, and hands the guest core dumps to the agent.
+pub fn register_container(
+    cid: &str,
+    annotations: &HashMap<String, String>,
+    init_pid: i32,
+) -> Result<()> {
+    let ns = mnt_ns(&init_pid.to_string())?;
+    let policy = match Policy::from_annotations(&ns, annotations)? {
+        Some(p) => p,
+        None => return Ok(()),
+    };
+
+    fs::create_dir_all(POLICIES_DIR)?;
+    fs::write(Path::new(POLICIES_DIR).join(cid), policy.serialize())?;
+
+    // The core dumps of the containers without a policy are discarded
+    // from now on.
+    let exe = env::current_exe()?;
+    fs::write(
+        CORE_PATTERN_PATH,
+        format!("|{} {} %P %e %t", exe.display(), CORE_DUMP_ARG),
+    )?;
+
+    Ok(())
+}
+
+pub fn unregister_container(cid: &str) {
+    let _ = fs::remove_file(Path::new(POLICIES_DIR).join(cid));
+}
+
+fn find_policy(dir: &str, mnt_ns: &str) -> Option<Policy> {
+    fs::read_dir(dir)
+        .ok()?
+        .filter_map(|e| e.ok())
+        .filter_map(|e| fs::read_to_string(e.path()).ok())
+        .filter_map(|s| Policy::parse(&s))
+        .find(|p| p.mnt_ns == mnt_ns)
+}
+
+fn write_core_dump(policy: &Policy, name: &str, core: &mut dyn Read) -> io::Result<()> {
+    let mut file = File::create(Path::new(&policy.dir).join(name))?;
+
+    if policy.max_size > 0 {
+        io::copy(&mut core.take(policy.max_size), &mut file)?;
+    } else {
+        io::copy(core, &mut file)?;
+    }
+
+    Ok(())
+}
+
+// handle_core_dump writes the core dump read from the standard input, as
+// the container policy says. It is run by the kernel with the global pid,
+// the executable name and the time of the dumping process.
+pub fn handle_core_dump(args: &[String]) -> Result<()> {
+    if args.len() != 3 {
+        return Err(ErrorKind::ErrorCode(String::from("invalid core dump arguments")).into());
+    }
+
+    let (pid, comm, time) = (&args[0], args[1].replace("/", "_"), &args[2]);
+
+    let stdin = io::stdin();
+    let mut core = stdin.lock();
+
+    let result = match find_policy(POLICIES_DIR, &mnt_ns(pid)?) {
+        Some(ref p) if !p.discard => {
+            write_core_dump(p, &format!("core.{}.{}.{}", comm, pid, time), &mut core)
+        }
+        _ => Ok(()),
+    };
+
+    // Whatever is left, for the dumping process to exit.
+    io::copy(&mut core, &mut io::sink())?;
+
+    Ok(result?)
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use tempfile::tempdir;
+
+    #[test]
+    fn test_policy_from_annotations() {
+        let mut annotations = HashMap::new();
+        assert_eq!(
+            Policy::from_annotations("mnt:[1]", &annotations).unwrap(),
+            None
+        );
+
+        annotations.insert(POLICY_ANNOTATION.to_string(), "capture".to_string());
+        assert!(Policy::from_annotations("mnt:[1]", &annotations).is_err());
+
+        annotations.insert(DIR_ANNOTATION.to_string(), "/run/core dumps".to_string());
+        annotations.insert(MAX_SIZE_ANNOTATION.to_string(), "1024".to_string());
+        let policy = Policy::from_annotations("mnt:[1]", &annotations)
+            .unwrap()
+            .unwrap();
+        assert_eq!(
+            policy,
+            Policy {
+                mnt_ns: "mnt:[1]".to_string(),
+                discard: false,
+                dir: "/run/core dumps".to_string(),
+                max_size: 1024,
+            }
+        );
+        assert_eq!(Policy::parse(&policy.serialize()), Some(policy));
+
+        annotations.insert(POLICY_ANNOTATION.to_string(), "keep".to_string());
+        assert!(Policy::from_annotations("mnt:[1]", &annotations).is_err());
+    }
+
+    #[test]
+    fn test_write_core_dump() {
+        let dir = tempdir().unwrap();
+        let policy = Policy {
+            mnt_ns: "mnt:[1]".to_string(),
+            discard: false,
+            dir: dir.path().to_str().unwrap().to_string(),
+            max_size: 4,
+        };
+
+        fs::write(dir.path().join("policy"), policy.serialize()).unwrap();
+        let found = find_policy(dir.path().to_str().unwrap(), "mnt:[1]").unwrap();
+        assert_eq!(found, policy);
+        assert!(find_policy(dir.path().to_str().unwrap(), "mnt:[2]").is_none());
+
+        let mut core: &[u8] = b"core dump";
+        write_core_dump(&policy, "core", &mut core).unwrap();
+        assert_eq!(fs::read(dir.path().join("core")).unwrap(), b"core");
+    }
+}
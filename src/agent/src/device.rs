@@ -4,19 +4,23 @@
 //
 
 use libc::{c_uint, major, minor};
+use nix::mount;
 use nix::sys::stat;
 use std::collections::HashMap;
 use std::fs;
-use std::os::unix::fs::MetadataExt;
+use std::os::unix::fs::{FileTypeExt, MetadataExt};
 use std::path::Path;
 use std::sync::{mpsc, Arc, Mutex};
+use std::thread;
+use std::time::{Duration, Instant};
 
 use crate::linux_abi::*;
 use crate::mount::{DRIVERBLKTYPE, DRIVERMMIOBLKTYPE, DRIVERNVDIMMTYPE, DRIVERSCSITYPE};
+use crate::rpc::load_kernel_module;
 use crate::sandbox::Sandbox;
 use crate::{AGENT_CONFIG, GLOBAL_DEVICE_WATCHER};
-use oci::{LinuxDeviceCgroup, LinuxResources, Spec};
-use protocols::agent::Device;
+use oci::{Linux, LinuxDevice, LinuxDeviceCgroup, LinuxResources, Spec};
+use protocols::agent::{Device, KernelModule};
 use rustjail::errors::*;
 
 // Convenience macro to obtain the scope logger
@@ -28,6 +32,17 @@ macro_rules! sl {
 
 const VM_ROOTFS: &str = "/";
 
+// An RDMA device passed through with VFIO, which does not appear in the
+// guest as a device node of its own.
+pub const DRIVERVFIORDMATYPE: &str = "vfio-rdma";
+
+// A vGPU passed through with VFIO, which the container uses through the
+// device nodes of its guest driver.
+pub const DRIVERVFIOGPUTYPE: &str = "vfio-gpu";
+
+const RDMA_DEVICE_POLL_INTERVAL: Duration = Duration::from_millis(100);
+const PCI_DRIVER_POLL_INTERVAL: Duration = Duration::from_millis(100);
+
 // DeviceHandler is the type of callback to be defined to handle every type of device driver.
 type DeviceHandler = fn(&Device, &mut Spec, &Arc<Mutex<Sandbox>>) -> Result<()>;
 
@@ -40,6 +55,8 @@ lazy_static! {
         m.insert(DRIVERMMIOBLKTYPE, virtiommio_blk_device_handler);
         m.insert(DRIVERNVDIMMTYPE, virtio_nvdimm_device_handler);
         m.insert(DRIVERSCSITYPE, virtio_scsi_device_handler);
+        m.insert(DRIVERVFIORDMATYPE, vfio_rdma_device_handler);
+        m.insert(DRIVERVFIOGPUTYPE, vfio_gpu_device_handler);
         m
     };
 }
@@ -331,6 +348,176 @@ fn virtio_nvdimm_device_handler(
     update_spec_device_list(device, spec)
 }
 
+// device.options are the kernel modules driving the RDMA device. Once the
+// guest has an RDMA device for it, the character devices of the guest RDMA
+// devices are added to the container.
+fn vfio_rdma_device_handler(
+    device: &Device,
+    spec: &mut Spec,
+    _sandbox: &Arc<Mutex<Sandbox>>,
+) -> Result<()> {
+    for name in device.options.iter() {
+        let mut module = KernelModule::default();
+        module.name = name.clone();
+        load_kernel_module(&module)?;
+    }
+
+    let linux = match spec.linux.as_mut() {
+        None => {
+            return Err(
+                ErrorKind::ErrorCode("Spec didn't container linux field".to_string()).into(),
+            )
+        }
+        Some(l) => l,
+    };
+
+    // The container may be given more than one RDMA device.
+    let wanted = linux
+        .devices
+        .iter()
+        .filter(|d| is_uverbs_device(Path::new(&d.path)))
+        .count()
+        + 1;
+
+    let hotplug_timeout = AGENT_CONFIG.read().unwrap().hotplug_timeout;
+    let start = Instant::now();
+    loop {
+        let found = count_uverbs_devices(Path::new(SYSFS_INFINIBAND_VERBS_PATH));
+        if found >= wanted {
+            break;
+        }
+        if start.elapsed() >= hotplug_timeout {
+            return Err(ErrorKind::ErrorCode(format!(
+                "Timeout reached after {:?} waiting for RDMA device {}, found {} of {}",
+                hotplug_timeout, device.id, found, wanted
+            ))
+            .into());
+        }
+        thread::sleep(RDMA_DEVICE_POLL_INTERVAL);
+    }
+
+    add_rdma_devices(
+        linux,
+        Path::new(INFINIBAND_DEV_PATH),
+        &device.container_path,
+    )
+}
+
+// device.id is the PCI identifier of the vGPU and device.options are the
+// kernel modules driving it. The container is only started once the guest
+// driver is bound to the vGPU, the driver creating the device nodes the
+// container uses.
+fn vfio_gpu_device_handler(
+    device: &Device,
+    _spec: &mut Spec,
+    _sandbox: &Arc<Mutex<Sandbox>>,
+) -> Result<()> {
+    for name in device.options.iter() {
+        let mut module = KernelModule::default();
+        module.name = name.clone();
+        load_kernel_module(&module)?;
+    }
+
+    rescan_pci_bus()?;
+
+    let dev_addr = get_pci_device_address(&device.id)?;
+    let bdf = dev_addr.rsplit('/').next().unwrap_or_default();
+    let dev_path = Path::new(SYSFS_PCI_BUS_PREFIX).join(bdf);
+
+    let hotplug_timeout = AGENT_CONFIG.read().unwrap().hotplug_timeout;
+    wait_pci_driver(&dev_path, hotplug_timeout)
+}
+
+// wait_pci_driver waits for a driver to be bound to the PCI device at
+// dev_path in sysfs.
+fn wait_pci_driver(dev_path: &Path, timeout: Duration) -> Result<()> {
+    let driver = dev_path.join("driver");
+    let start = Instant::now();
+    loop {
+        if let Ok(d) = fs::read_link(&driver) {
+            info!(sl!(), "PCI device bound to its driver";
+                "device" => dev_path.to_string_lossy().to_string(),
+                "driver" => d.file_name().map(|n| n.to_string_lossy().to_string()).unwrap_or_default());
+            return Ok(());
+        }
+        if start.elapsed() >= timeout {
+            return Err(ErrorKind::ErrorCode(format!(
+                "Timeout reached after {:?} waiting for a driver bound to PCI device {}",
+                timeout,
+                dev_path.display()
+            ))
+            .into());
+        }
+        thread::sleep(PCI_DRIVER_POLL_INTERVAL);
+    }
+}
+
+fn is_uverbs_device(path: &Path) -> bool {
+    path.file_name()
+        .and_then(|n| n.to_str())
+        .map(|n| n.starts_with("uverbs"))
+        .unwrap_or(false)
+}
+
+fn count_uverbs_devices(dir: &Path) -> usize {
+    match fs::read_dir(dir) {
+        Ok(entries) => entries
+            .filter_map(|e| e.ok())
+            .filter(|e| is_uverbs_device(&e.path()))
+            .count(),
+        Err(_) => 0,
+    }
+}
+
+// add_rdma_devices adds the character devices in dev_dir, such as the
+// uverbs and rdma_cm ones, to the container under container_dir, and lets
+// it access them, once.
+fn add_rdma_devices(linux: &mut Linux, dev_dir: &Path, container_dir: &str) -> Result<()> {
+    for entry in fs::read_dir(dev_dir)? {
+        let entry = entry?;
+        let meta = fs::metadata(entry.path())?;
+        if !meta.file_type().is_char_device() {
+            continue;
+        }
+
+        let path = Path::new(container_dir)
+            .join(entry.file_name())
+            .to_string_lossy()
+            .to_string();
+        if linux.devices.iter().any(|d| d.path == path) {
+            continue;
+        }
+
+        let rdev = meta.rdev();
+        let major_id = stat::major(rdev) as i64;
+        let minor_id = stat::minor(rdev) as i64;
+
+        info!(sl!(), "adding RDMA device";
+            "path" => &path, "major" => major_id, "minor" => minor_id);
+
+        linux.devices.push(LinuxDevice {
+            path,
+            r#type: String::from("c"),
+            major: major_id,
+            minor: minor_id,
+            file_mode: Some(0o666),
+            uid: Some(0),
+            gid: Some(0),
+        });
+
+        let resources = linux.resources.get_or_insert_with(LinuxResources::default);
+        resources.devices.push(LinuxDeviceCgroup {
+            allow: true,
+            major: Some(major_id),
+            minor: Some(minor_id),
+            r#type: String::from("c"),
+            access: String::from("rwm"),
+        });
+    }
+
+    Ok(())
+}
+
 pub fn add_devices(
     devices: &[Device],
     spec: &mut Spec,
@@ -373,6 +560,101 @@ fn add_device(device: &Device, spec: &mut Spec, sandbox: &Arc<Mutex<Sandbox>>) -
 // update_device_cgroup update the device cgroup for container
 // to not allow access to the guest root partition. This prevents
 // the container from being able to access the VM rootfs.
+// release_device has the guest stop using a block device about to be hot
+// unplugged: the mounts of the device and of its partitions are removed,
+// the later ones first, and its buffers are written out. The other devices
+// need nothing from the guest.
+pub fn release_device(device: &Device, sandbox: &Arc<Mutex<Sandbox>>) -> Result<()> {
+    let vm_path = match device.field_type.as_str() {
+        DRIVERBLKTYPE if device.id != "" => get_pci_device_name(sandbox, &device.id)?,
+        DRIVERSCSITYPE => get_scsi_device_name(sandbox, &device.id)?,
+        DRIVERBLKTYPE | DRIVERMMIOBLKTYPE | DRIVERNVDIMMTYPE => device.vm_path.clone(),
+        _ => return Ok(()),
+    };
+
+    let rdev = fs::metadata(&vm_path)?.rdev();
+    let devs = block_device_numbers(stat::major(rdev), stat::minor(rdev))?;
+    let mounts = block_device_mounts(&fs::read_to_string(PROC_MOUNTINFO)?, &devs);
+
+    for m in mounts.iter().rev() {
+        info!(sl!(), "unmounting {} of released device {}", m, vm_path);
+        mount::umount(m.as_str())?;
+        sandbox.lock().unwrap().storages.remove(m);
+    }
+
+    fs::File::open(&vm_path)?.sync_all()?;
+
+    Ok(())
+}
+
+// block_device_numbers returns the device numbers of a block device and of
+// its partitions.
+fn block_device_numbers(major: u64, minor: u64) -> Result<Vec<(u64, u64)>> {
+    let mut devs = vec![(major, minor)];
+
+    let sysfs_dev = Path::new(SYSFS_DEV_BLOCK_PATH).join(format!("{}:{}", major, minor));
+    for entry in fs::read_dir(&sysfs_dev)? {
+        let path = entry?.path();
+        if !path.join("partition").exists() {
+            continue;
+        }
+
+        let dev = fs::read_to_string(path.join("dev"))?;
+        if let Some(dev) = parse_device_number(dev.trim()) {
+            devs.push(dev);
+        }
+    }
+
+    Ok(devs)
+}
+
+// block_device_mounts returns the mount points of the mounts of devs listed
+// in mountinfo, in the order they were mounted.
+fn block_device_mounts(mountinfo: &str, devs: &[(u64, u64)]) -> Vec<String> {
+    mountinfo
+        .lines()
+        .filter_map(|line| {
+            let fields: Vec<&str> = line.split(' ').collect();
+            if fields.len() < 5 || !devs.contains(&parse_device_number(fields[2])?) {
+                return None;
+            }
+            Some(unescape_mount_path(fields[4]))
+        })
+        .collect()
+}
+
+fn parse_device_number(dev: &str) -> Option<(u64, u64)> {
+    let mut numbers = dev.splitn(2, ':');
+    let major = numbers.next()?.parse().ok()?;
+    let minor = numbers.next()?.parse().ok()?;
+    Some((major, minor))
+}
+
+// unescape_mount_path undoes the octal escaping of the spaces, tabs,
+// newlines and backslashes of the paths of mountinfo.
+fn unescape_mount_path(path: &str) -> String {
+    let bytes = path.as_bytes();
+    let mut unescaped = Vec::with_capacity(bytes.len());
+
+    let mut i = 0;
+    while i < bytes.len() {
+        if bytes[i] == b'\\' {
+            if let Some(octal) = bytes.get(i + 1..i + 4) {
+                if octal.iter().all(|b| (b'0'..=b'7').contains(b)) {
+                    let c = octal.iter().fold(0u32, |c, b| c * 8 + u32::from(b - b'0'));
+                    unescaped.push(c as u8);
+                    i += 4;
+                    continue;
+                }
+            }
+        }
+        unescaped.push(bytes[i]);
+        i += 1;
+    }
+
+    String::from_utf8_lossy(&unescaped).into_owned()
+}
+
 pub fn update_device_cgroup(spec: &mut Spec) -> Result<()> {
     let meta = fs::metadata(VM_ROOTFS)?;
     let rdev = meta.dev();
@@ -408,6 +690,85 @@ pub fn update_device_cgroup(spec: &mut Spec) -> Result<()> {
 mod tests {
     use super::*;
     use oci::Linux;
+    use std::os::unix::fs::symlink;
+    use tempfile::tempdir;
+
+    #[test]
+    fn test_add_rdma_devices() {
+        let dir = tempdir().unwrap();
+        symlink("/dev/null", dir.path().join("uverbs0")).unwrap();
+        fs::write(dir.path().join("not-a-device"), "").unwrap();
+
+        let mut linux = Linux::default();
+        add_rdma_devices(&mut linux, dir.path(), "/dev/infiniband").unwrap();
+        // Devices already in the container are not added again.
+        add_rdma_devices(&mut linux, dir.path(), "/dev/infiniband").unwrap();
+
+        assert_eq!(linux.devices.len(), 1);
+        assert_eq!(linux.devices[0].path, "/dev/infiniband/uverbs0");
+        assert_eq!(linux.devices[0].r#type, "c");
+        assert_eq!((linux.devices[0].major, linux.devices[0].minor), (1, 3));
+
+        let cgroup_devices = linux.resources.unwrap().devices;
+        assert_eq!(cgroup_devices.len(), 1);
+        assert!(cgroup_devices[0].allow);
+        assert_eq!(cgroup_devices[0].major, Some(1));
+        assert_eq!(cgroup_devices[0].minor, Some(3));
+    }
+
+    #[test]
+    fn test_count_uverbs_devices() {
+        let dir = tempdir().unwrap();
+        assert_eq!(count_uverbs_devices(dir.path()), 0);
+
+        fs::create_dir(dir.path().join("uverbs0")).unwrap();
+        fs::create_dir(dir.path().join("uverbs1")).unwrap();
+        fs::create_dir(dir.path().join("abi_version")).unwrap();
+        assert_eq!(count_uverbs_devices(dir.path()), 2);
+
+        assert_eq!(count_uverbs_devices(&dir.path().join("missing")), 0);
+    }
+
+    #[test]
+    fn test_wait_pci_driver() {
+        let dir = tempdir().unwrap();
+        let dev_path = dir.path().join("0000:01:03.0");
+        fs::create_dir(&dev_path).unwrap();
+
+        assert!(wait_pci_driver(&dev_path, Duration::from_millis(0)).is_err());
+
+        fs::create_dir(dir.path().join("nvidia")).unwrap();
+        symlink(dir.path().join("nvidia"), dev_path.join("driver")).unwrap();
+        assert!(wait_pci_driver(&dev_path, Duration::from_millis(0)).is_ok());
+    }
+
+    #[test]
+    fn test_block_device_mounts() {
+        let mountinfo = "\
+22 1 254:0 / / rw,relatime - ext4 /dev/vda rw
+30 22 254:16 / /run/kata-containers/sandbox/storage/a rw - ext4 /dev/vdb rw
+31 22 254:17 / /mnt/with\\040space rw - ext4 /dev/vdb1 rw
+32 30 254:16 /sub /run/kata-containers/shared/b rw - ext4 /dev/vdb rw
+33 22 0:21 / /proc rw - proc proc rw
+";
+
+        assert_eq!(
+            block_device_mounts(mountinfo, &[(254, 16), (254, 17)]),
+            vec![
+                "/run/kata-containers/sandbox/storage/a",
+                "/mnt/with space",
+                "/run/kata-containers/shared/b",
+            ]
+        );
+        assert!(block_device_mounts(mountinfo, &[(254, 32)]).is_empty());
+    }
+
+    #[test]
+    fn test_unescape_mount_path() {
+        assert_eq!(unescape_mount_path("/a\\040b\\011c"), "/a b\tc");
+        assert_eq!(unescape_mount_path("/a\\134b"), "/a\\b");
+        assert_eq!(unescape_mount_path("/a\\9"), "/a\\9");
+    }
 
     #[test]
     fn test_update_device_cgroup() {
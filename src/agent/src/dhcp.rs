@@ -0,0 +1,140 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use rustjail::errors::*;
+use slog::Logger;
+use std::collections::HashSet;
+use std::fs::{self, File};
+use std::path::{Path, PathBuf};
+use std::process::{Command, Stdio};
+use std::sync::Mutex;
+
+// The pid, lease and log files of the DHCP clients, named after the
+// interface.
+const DHCP_RUN_DIR: &str = "/run/kata-containers/dhcp";
+
+const DHCLIENT_PATHS: &[&str] = &["/sbin/dhclient", "/usr/sbin/dhclient"];
+const UDHCPC_PATHS: &[&str] = &["/sbin/udhcpc", "/usr/sbin/udhcpc", "/bin/udhcpc"];
+
+lazy_static! {
+    // The interfaces a DHCP client was started on, as the runtime may
+    // update an interface more than once.
+    static ref STARTED: Mutex<HashSet<String>> = Mutex::new(HashSet::new());
+}
+
+#[derive(Debug, PartialEq)]
+enum Client {
+    Dhclient(PathBuf),
+    Udhcpc(PathBuf),
+}
+
+fn find_client() -> Option<Client> {
+    if let Some(p) = DHCLIENT_PATHS.iter().find(|p| Path::new(p).exists()) {
+        return Some(Client::Dhclient(PathBuf::from(p)));
+    }
+
+    UDHCPC_PATHS
+        .iter()
+        .find(|p| Path::new(p).exists())
+        .map(|p| Client::Udhcpc(PathBuf::from(p)))
+}
+
+// client_command returns the command running the client in the foreground
+// on an interface, until the agent exits.
+fn client_command(client: &Client, iface: &str) -> Command {
+    let run_dir = Path::new(DHCP_RUN_DIR);
+
+    match client {
+        Client::Dhclient(path) => {
+            let mut cmd = Command::new(path);
+            cmd.arg("-d")
+                .arg("-pf")
+                .arg(run_dir.join(format!("{}.pid", iface)))
+                .arg("-lf")
+                .arg(run_dir.join(format!("{}.leases", iface)))
+                .arg(iface);
+            cmd
+        }
+        Client::Udhcpc(path) => {
+            let mut cmd = Command::new(path);
+            cmd.arg("-f")
+                .arg("-i")
+                .arg(iface)
+                .arg("-p")
+                .arg(run_dir.join(format!("{}.pid", iface)));
+            cmd
+        }
+    }
+}
+
+// start_client starts a DHCP client on an interface set up by the runtime,
+// once, if it is one of the interfaces passed on the kernel command line.
+pub fn start_client(logger: &Logger, interfaces: &[String], iface: &str) -> Result<()> {
+    if !interfaces.iter().any(|i| i == iface) {
+        return Ok(());
+    }
+
+    let mut started = STARTED.lock().unwrap();
+    if started.contains(iface) {
+        return Ok(());
+    }
+
+    let client = match find_client() {
+        Some(c) => c,
+        None => {
+            return Err(
+                ErrorKind::ErrorCode(format!("no DHCP client in the guest for {}", iface)).into(),
+            )
+        }
+    };
+
+    fs::create_dir_all(DHCP_RUN_DIR)?;
+    let log = File::create(Path::new(DHCP_RUN_DIR).join(format!("{}.log", iface)))?;
+
+    // The client is not waited for, the reaper collects it.
+    let child = client_command(&client, iface)
+        .stdin(Stdio::null())
+        .stdout(log.try_clone()?)
+        .stderr(log)
+        .spawn()?;
+
+    started.insert(iface.to_string());
+
+    info!(logger, "started the DHCP client";
+        "interface" => iface,
+        "client" => format!("{:?}", client),
+        "pid" => child.id());
+
+    Ok(())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_client_command() {
+        let cmd = client_command(&Client::Dhclient(PathBuf::from("/sbin/dhclient")), "eth0");
+        assert_eq!(
+            format!("{:?}", cmd),
+            r#""/sbin/dhclient" "-d" "-pf" "/run/kata-containers/dhcp/eth0.pid" "-lf" "/run/kata-containers/dhcp/eth0.leases" "eth0""#
+        );
+
+        let cmd = client_command(&Client::Udhcpc(PathBuf::from("/sbin/udhcpc")), "eth1");
+        assert_eq!(
+            format!("{:?}", cmd),
+            r#""/sbin/udhcpc" "-f" "-i" "eth1" "-p" "/run/kata-containers/dhcp/eth1.pid""#
+        );
+    }
+
+    #[test]
+    fn test_start_client_not_listed() {
+        let logger = slog_scope::logger();
+        let interfaces = vec!["eth1".to_string()];
+
+        assert!(start_client(&logger, &interfaces, "eth0").is_ok());
+        assert!(!STARTED.lock().unwrap().contains("eth0"));
+    }
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use crate::config::agentConfig;
+use nix::unistd::{self, SysconfVar};
+use rustjail::errors::*;
+use slog::Logger;
+use std::collections::HashMap;
+use std::fs::{self, File, OpenOptions};
+use std::os::unix::fs::{FileExt, MetadataExt};
+use std::path::Path;
+use std::sync::Mutex;
+use std::thread;
+use std::time::Duration;
+
+const PAGE_IDLE_BITMAP_PATH: &str = "/sys/kernel/mm/page_idle/bitmap";
+const KPAGEFLAGS_PATH: &str = "/proc/kpageflags";
+const KPAGECGROUP_PATH: &str = "/proc/kpagecgroup";
+
+// The page is on an LRU list, the only pages idle page tracking tracks.
+const KPF_LRU: u64 = 1 << 5;
+
+// Number of pages scanned at once, a multiple of the 64 pages of a bitmap
+// entry.
+const SCAN_CHUNK_PAGES: usize = 64 * 1024;
+
+lazy_static! {
+    // The idle memory of the last scan, in bytes, by inode of the memory
+    // cgroup the pages are charged to.
+    static ref IDLE_BYTES: Mutex<HashMap<u64, u64>> = Mutex::new(HashMap::new());
+}
+
+fn to_u64s(buf: &[u8]) -> Vec<u64> {
+    buf.chunks_exact(8)
+        .map(|c| {
+            let mut b = [0u8; 8];
+            b.copy_from_slice(c);
+            u64::from_ne_bytes(b)
+        })
+        .collect()
+}
+
+// count_idle adds the size of the idle LRU pages of a chunk to the memory
+// cgroups they are charged to, the pages being described by their entries
+// in the idle bitmap, kpageflags and kpagecgroup.
+fn count_idle(
+    bitmap: &[u64],
+    flags: &[u64],
+    cgroups: &[u64],
+    page_size: u64,
+    idle: &mut HashMap<u64, u64>,
+) {
+    let pages = flags.len().min(cgroups.len()).min(bitmap.len() * 64);
+
+    for i in 0..pages {
+        if bitmap[i / 64] & (1 << (i % 64)) == 0 || flags[i] & KPF_LRU == 0 {
+            continue;
+        }
+
+        *idle.entry(cgroups[i]).or_insert(0) += page_size;
+    }
+}
+
+// scan counts the pages which stayed idle since the previous scan, then
+// marks all the pages idle again for the next one.
+fn scan(page_size: u64) -> Result<HashMap<u64, u64>> {
+    let bitmap_file = OpenOptions::new()
+        .read(true)
+        .write(true)
+        .open(PAGE_IDLE_BITMAP_PATH)?;
+    let flags_file = File::open(KPAGEFLAGS_PATH)?;
+    let cgroups_file = File::open(KPAGECGROUP_PATH)?;
+
+    let mut idle = HashMap::new();
+    let mut bitmap = vec![0u8; SCAN_CHUNK_PAGES / 8];
+    let mut flags = vec![0u8; SCAN_CHUNK_PAGES * 8];
+    let mut cgroups = vec![0u8; SCAN_CHUNK_PAGES * 8];
+    let mut pfn: u64 = 0;
+
+    loop {
+        // Reading the bitmap clears the idle flag of the accessed pages,
+        // it ends at the last page frame.
+        let n = bitmap_file.read_at(&mut bitmap, pfn / 8)?;
+        if n == 0 {
+            break;
+        }
+
+        let pages = n * 8;
+        let nf = flags_file.read_at(&mut flags[..pages * 8], pfn * 8)?;
+        let nc = cgroups_file.read_at(&mut cgroups[..pages * 8], pfn * 8)?;
+
+        count_idle(
+            &to_u64s(&bitmap[..n]),
+            &to_u64s(&flags[..nf]),
+            &to_u64s(&cgroups[..nc]),
+            page_size,
+            &mut idle,
+        );
+
+        // Only the LRU pages are marked, the others are skipped.
+        bitmap_file.write_at(&vec![0xff; n], pfn / 8)?;
+
+        pfn += pages as u64;
+    }
+
+    Ok(idle)
+}
+
+// cgroup_idle_bytes returns the memory charged to the memory cgroup of the
+// path which was idle during the last scan, in bytes, zero until the
+// pages were scanned twice.
+pub fn cgroup_idle_bytes(path: &str) -> u64 {
+    let ino = match fs::metadata(path) {
+        Ok(m) => m.ino(),
+        Err(_) => return 0,
+    };
+
+    *IDLE_BYTES.lock().unwrap().get(&ino).unwrap_or(&0)
+}
+
+// setup_idle_memory starts tracking the idle memory of the guest, once
+// enabled on the kernel command line: every interval, the pages not
+// accessed since the previous scan are accounted to their memory cgroup,
+// which is the memory the containers could give back to the host.
+pub fn setup_idle_memory(logger: &Logger, config: &agentConfig) -> Result<()> {
+    let interval = config.idle_scan_interval;
+    if interval == Duration::from_secs(0) {
+        return Ok(());
+    }
+
+    if !Path::new(PAGE_IDLE_BITMAP_PATH).exists() {
+        return Err(ErrorKind::ErrorCode(String::from(
+            "idle page tracking not supported by the guest kernel",
+        ))
+        .into());
+    }
+
+    let page_size = match unistd::sysconf(SysconfVar::PAGE_SIZE)? {
+        Some(s) => s as u64,
+        None => 4096,
+    };
+
+    let logger = logger.new(o!("subsystem" => "idle-memory"));
+
+    thread::spawn(move || {
+        // The first scan only marks the pages idle.
+        let mut marked = false;
+
+        loop {
+            match scan(page_size) {
+                Ok(idle) => {
+                    if marked {
+                        *IDLE_BYTES.lock().unwrap() = idle;
+                    }
+                    marked = true;
+                }
+                Err(e) => {
+                    warn!(logger, "failed to scan the idle memory"; "error" => format!("{}", e))
+                }
+            }
+
+            thread::sleep(interval);
+        }
+    });
+
+    Ok(())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_count_idle() {
+        let mut idle = HashMap::new();
+
+        // Pages 0, 1 and 65 idle, page 1 not on an LRU list.
+        let bitmap = vec![0b11, 0b10];
+        let mut flags = vec![KPF_LRU; 66];
+        flags[1] = 0;
+        let mut cgroups = vec![100; 66];
+        cgroups[65] = 200;
+
+        count_idle(&bitmap, &flags, &cgroups, 4096, &mut idle);
+        assert_eq!(idle.get(&100), Some(&4096));
+        assert_eq!(idle.get(&200), Some(&4096));
+
+        // The page frames past the end of kpageflags are skipped.
+        count_idle(&bitmap, &flags[..64], &cgroups, 4096, &mut idle);
+        assert_eq!(idle.get(&100), Some(&8192));
+        assert_eq!(idle.get(&200), Some(&4096));
+    }
+
+    #[test]
+    fn test_to_u64s() {
+        let buf: Vec<u8> = [1u64, 2u64]
+            .iter()
+            .flat_map(|v| v.to_ne_bytes().to_vec())
+            .collect();
+        assert_eq!(to_u64s(&buf), vec![1, 2]);
+    }
+}
@@ -0,0 +1,595 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use crate::config::agentConfig;
+use crate::mount::BareMount;
+use nix::mount::MsFlags;
+use nix::sys::mman::{mmap, munmap, MapFlags, ProtFlags};
+use nix::sys::reboot::{reboot, RebootMode};
+use nix::unistd::{self, SysconfVar};
+use rustjail::errors::*;
+use slog::Logger;
+use std::env;
+use std::fs::{self, File, OpenOptions};
+use std::io::{self, Read, Write};
+use std::os::unix::fs::FileExt;
+use std::os::unix::io::AsRawFd;
+use std::path::{Path, PathBuf};
+use std::process::Command;
+use std::sync::atomic::{AtomicBool, Ordering};
+use std::thread;
+use std::time::{Duration, SystemTime, UNIX_EPOCH};
+
+const KEXEC_PATH: &str = "/sbin/kexec";
+const CMDLINE_PATH: &str = "/proc/cmdline";
+const VMCORE_PATH: &str = "/proc/vmcore";
+const PAGEMAP_PATH: &str = "/proc/self/pagemap";
+
+// The guest memory caching the secrets to redact, passed to the crash
+// kernel, is looked up again at this interval, the page cache changing.
+const REDACT_REFRESH_INTERVAL: Duration = Duration::from_secs(30);
+
+// REDACT_ALL stands for regions too many for the crash kernel command line,
+// no vmcore being captured then.
+const REDACT_ALL: &str = "all";
+const MAX_REDACT_PARAM_LEN: usize = 1024;
+
+static REDACT_REFRESH: AtomicBool = AtomicBool::new(false);
+
+// A guest physical memory range, end excluded.
+type Region = (u64, u64);
+
+// The shared filesystem the runtime mounts the vmcores host directory in.
+const SHARED_FS_TAG: &str = "kataShared";
+const SHARED_DIR: &str = "/run/kata-containers/shared/containers";
+const KDUMP_DIR: &str = "kdump";
+
+// crash_cmdline returns the crash kernel command line, running the agent as
+// init to capture the vmcore, less the memory regions to redact.
+fn crash_cmdline(cmdline: &str, agent: &str, redact: &str) -> String {
+    let mut params: Vec<String> = cmdline
+        .split_ascii_whitespace()
+        .filter(|p| {
+            !(p.starts_with("crashkernel=")
+                || p.starts_with("init=")
+                || p.starts_with("rdinit=")
+                || p.starts_with("systemd.")
+                || p.starts_with("agent.kdump_redact=")
+                || *p == "agent.kdump")
+        })
+        .map(String::from)
+        .collect();
+
+    params.push(format!("init={}", agent));
+    params.push(format!("rdinit={}", agent));
+    params.push("agent.kdump_capture".to_string());
+
+    if !redact.is_empty() {
+        params.push(format!("agent.kdump_redact_regions={}", redact));
+    }
+
+    // The usual crash kernel parameters, for the devices left in use by
+    // the crashed kernel.
+    params.push("irqpoll".to_string());
+    params.push("nr_cpus=1".to_string());
+    params.push("reset_devices".to_string());
+
+    params.join(" ")
+}
+
+// file_regions returns the guest physical memory caching a file, mapping it
+// for its pages to be in the page cache.
+fn file_regions(path: &Path, page_size: u64, pagemap: &File) -> Result<Vec<Region>> {
+    let file = File::open(path)?;
+    let len = file.metadata()?.len() as usize;
+    if len == 0 {
+        return Ok(Vec::new());
+    }
+
+    let addr = unsafe {
+        mmap(
+            std::ptr::null_mut(),
+            len,
+            ProtFlags::PROT_READ,
+            MapFlags::MAP_SHARED,
+            file.as_raw_fd(),
+            0,
+        )?
+    };
+
+    let mut regions = Vec::new();
+    let mut result = Ok(());
+    let mut off = 0;
+    while off < len {
+        let page = addr as usize + off;
+        unsafe { std::ptr::read_volatile(page as *const u8) };
+
+        let mut entry = [0u8; 8];
+        if let Err(e) = pagemap.read_exact_at(&mut entry, (page as u64 / page_size) * 8) {
+            result = Err(e.into());
+            break;
+        }
+
+        // Bit 63: page present, bits 0-54: page frame number.
+        let entry = u64::from_le_bytes(entry);
+        let pfn = entry & ((1 << 55) - 1);
+        if entry & (1 << 63) != 0 && pfn != 0 {
+            regions.push((pfn * page_size, (pfn + 1) * page_size));
+        }
+
+        off += page_size as usize;
+    }
+
+    let _ = unsafe { munmap(addr, len) };
+    result?;
+
+    Ok(regions)
+}
+
+fn collect_regions(path: &Path, page_size: u64, pagemap: &File, regions: &mut Vec<Region>) {
+    let meta = match fs::symlink_metadata(path) {
+        Ok(m) => m,
+        Err(_) => return,
+    };
+
+    if meta.is_dir() {
+        if let Ok(entries) = fs::read_dir(path) {
+            for entry in entries.flatten() {
+                collect_regions(&entry.path(), page_size, pagemap, regions);
+            }
+        }
+    } else if meta.is_file() {
+        if let Ok(r) = file_regions(path, page_size, pagemap) {
+            regions.extend(r);
+        }
+    }
+}
+
+// merge_regions sorts the regions and merges the overlapping or adjacent
+// ones.
+fn merge_regions(mut regions: Vec<Region>) -> Vec<Region> {
+    regions.sort();
+
+    let mut merged: Vec<Region> = Vec::new();
+    for r in regions {
+        match merged.last_mut() {
+            Some(last) if r.0 <= last.1 => last.1 = last.1.max(r.1),
+            _ => merged.push(r),
+        }
+    }
+
+    merged
+}
+
+// redact_param returns the crash kernel parameter value of the regions,
+// REDACT_ALL if they do not fit.
+fn redact_param(regions: &[Region]) -> String {
+    let param = regions
+        .iter()
+        .map(|(start, end)| format!("{:x}-{:x}", start, end))
+        .collect::<Vec<String>>()
+        .join(",");
+
+    if param.len() > MAX_REDACT_PARAM_LEN {
+        return REDACT_ALL.to_string();
+    }
+
+    param
+}
+
+// redact_regions returns the crash kernel parameter value of the guest
+// memory caching the files, or the files of the directories, to redact.
+fn redact_regions(paths: &[String]) -> Result<String> {
+    if paths.is_empty() {
+        return Ok(String::new());
+    }
+
+    let page_size = match unistd::sysconf(SysconfVar::PAGE_SIZE)? {
+        Some(s) => s as u64,
+        None => 4096,
+    };
+    let pagemap = File::open(PAGEMAP_PATH)?;
+
+    let mut regions = Vec::new();
+    for p in paths {
+        collect_regions(Path::new(p), page_size, &pagemap, &mut regions);
+    }
+
+    Ok(redact_param(&merge_regions(regions)))
+}
+
+// load_crash_kernel loads the crash kernel copied by the runtime, and its
+// initrd if any, booted by the guest kernel on panic. The crash kernel is
+// loaded again when the memory to redact from the vmcore changes.
+pub fn load_crash_kernel(
+    logger: &Logger,
+    redact: &[String],
+    kernel: &Path,
+    initrd: Option<&Path>,
+) -> Result<()> {
+    let regions = redact_regions(redact)?;
+    if regions == REDACT_ALL {
+        warn!(
+            logger,
+            "too much memory to redact, no vmcore will be captured"
+        );
+    }
+    load_kexec(logger, kernel, initrd, &regions)?;
+
+    if !redact.is_empty() && !REDACT_REFRESH.swap(true, Ordering::SeqCst) {
+        let logger = logger.new(o!("subsystem" => "kdump"));
+        let redact = redact.to_vec();
+        let kernel = kernel.to_path_buf();
+        let initrd = initrd.map(PathBuf::from);
+        thread::spawn(move || {
+            let initrd = initrd.as_ref().map(|p| p.as_path());
+            let mut loaded = regions;
+            loop {
+                thread::sleep(REDACT_REFRESH_INTERVAL);
+
+                match redact_regions(&redact) {
+                    Ok(r) if r != loaded => match load_kexec(&logger, &kernel, initrd, &r) {
+                        Ok(()) => loaded = r,
+                        Err(e) => {
+                            error!(logger, "failed to reload the crash kernel"; "error" => format!("{}", e))
+                        }
+                    },
+                    Ok(_) => (),
+                    Err(e) => {
+                        error!(logger, "failed to look up the memory to redact"; "error" => format!("{}", e))
+                    }
+                }
+            }
+        });
+    }
+
+    Ok(())
+}
+
+fn load_kexec(logger: &Logger, kernel: &Path, initrd: Option<&Path>, redact: &str) -> Result<()> {
+    let agent = env::current_exe()?;
+    let cmdline = crash_cmdline(
+        &fs::read_to_string(CMDLINE_PATH)?,
+        &agent.to_string_lossy(),
+        redact,
+    );
+
+    let mut cmd = Command::new(KEXEC_PATH);
+    cmd.arg("-p")
+        .arg(kernel)
+        .arg(format!("--append={}", cmdline));
+    if let Some(initrd) = initrd {
+        cmd.arg(format!("--initrd={}", initrd.display()));
+    }
+
+    let output = cmd.output()?;
+    if !output.status.success() {
+        return Err(ErrorKind::ErrorCode(format!(
+            "failed to load the crash kernel: {}",
+            String::from_utf8_lossy(&output.stderr)
+        ))
+        .into());
+    }
+
+    info!(logger, "loaded the crash kernel"; "cmdline" => cmdline);
+
+    Ok(())
+}
+
+fn mount_shared_fs(logger: &Logger) -> Result<()> {
+    fs::create_dir_all(SHARED_DIR)?;
+
+    let virtiofs = BareMount::new(
+        SHARED_FS_TAG,
+        SHARED_DIR,
+        "virtiofs",
+        MsFlags::empty(),
+        "",
+        logger,
+    );
+    if virtiofs.mount().is_ok() {
+        return Ok(());
+    }
+
+    BareMount::new(
+        SHARED_FS_TAG,
+        SHARED_DIR,
+        "9p",
+        MsFlags::MS_NODEV,
+        "trans=virtio,version=9p2000.L",
+        logger,
+    )
+    .mount()
+}
+
+fn parse_regions(params: &[String]) -> Result<Vec<Region>> {
+    let mut regions = Vec::new();
+    for p in params {
+        let fields: Vec<&str> = p.splitn(2, '-').collect();
+        if fields.len() != 2 {
+            return Err(ErrorKind::ErrorCode(format!("invalid redaction region {}", p)).into());
+        }
+
+        regions.push((
+            u64::from_str_radix(fields[0], 16)?,
+            u64::from_str_radix(fields[1], 16)?,
+        ));
+    }
+
+    Ok(regions)
+}
+
+fn le_u16(b: &[u8], off: usize) -> u64 {
+    u16::from_le_bytes([b[off], b[off + 1]]) as u64
+}
+
+fn le_u32(b: &[u8], off: usize) -> u32 {
+    let mut v = [0u8; 4];
+    v.copy_from_slice(&b[off..off + 4]);
+    u32::from_le_bytes(v)
+}
+
+fn le_u64(b: &[u8], off: usize) -> u64 {
+    let mut v = [0u8; 8];
+    v.copy_from_slice(&b[off..off + 8]);
+    u64::from_le_bytes(v)
+}
+
+const ELF_HEADER_SIZE: usize = 64;
+const ELF_PHDR_SIZE: u64 = 56;
+const PT_LOAD: u32 = 1;
+
+// redacted_ranges returns the ranges of the vmcore, an ELF64 file, holding
+// the memory regions, from its header and its program headers.
+fn redacted_ranges(header: &[u8], phdrs: &[u8], regions: &[Region]) -> Result<Vec<Region>> {
+    if header.len() < ELF_HEADER_SIZE || &header[0..4] != b"\x7fELF" || header[4] != 2 {
+        return Err(ErrorKind::ErrorCode(String::from("vmcore is not an ELF64 file")).into());
+    }
+
+    let phentsize = le_u16(header, 54);
+    if phentsize < ELF_PHDR_SIZE {
+        return Err(ErrorKind::ErrorCode(String::from("invalid vmcore program headers")).into());
+    }
+
+    let mut ranges = Vec::new();
+    for ph in phdrs.chunks(phentsize as usize) {
+        if ph.len() < ELF_PHDR_SIZE as usize || le_u32(ph, 0) != PT_LOAD {
+            continue;
+        }
+
+        let offset = le_u64(ph, 8);
+        let paddr = le_u64(ph, 24);
+        let filesz = le_u64(ph, 32);
+
+        for (start, end) in regions {
+            let s = (*start).max(paddr);
+            let e = (*end).min(paddr + filesz);
+            if s < e {
+                ranges.push((offset + s - paddr, offset + e - paddr));
+            }
+        }
+    }
+
+    Ok(merge_regions(ranges))
+}
+
+// copy_redacted copies the vmcore, the ranges zeroed.
+fn copy_redacted<R: Read, W: Write>(r: &mut R, w: &mut W, ranges: &[Region]) -> Result<u64> {
+    let mut buf = vec![0u8; 1 << 20];
+    let mut pos: u64 = 0;
+
+    loop {
+        let n = match r.read(&mut buf) {
+            Ok(0) => break,
+            Ok(n) => n,
+            Err(ref e) if e.kind() == io::ErrorKind::Interrupted => continue,
+            Err(e) => return Err(e.into()),
+        };
+
+        let end = pos + n as u64;
+        for (start, stop) in ranges {
+            let s = (*start).max(pos);
+            let e = (*stop).min(end);
+            if s < e {
+                for b in &mut buf[(s - pos) as usize..(e - pos) as usize] {
+                    *b = 0;
+                }
+            }
+        }
+
+        w.write_all(&buf[..n])?;
+        pos = end;
+    }
+
+    Ok(pos)
+}
+
+// write_vmcore writes the vmcore, less the memory to redact.
+fn write_vmcore<W: Write>(config: &agentConfig, w: &mut W) -> Result<u64> {
+    let mut vmcore = File::open(VMCORE_PATH)?;
+
+    if config.kdump_redact_regions.is_empty() {
+        return Ok(io::copy(&mut vmcore, w)?);
+    }
+
+    if config.kdump_redact_regions.iter().any(|r| r == REDACT_ALL) {
+        return Err(ErrorKind::ErrorCode(String::from(
+            "the memory to redact is unknown, vmcore not captured",
+        ))
+        .into());
+    }
+
+    let regions = parse_regions(&config.kdump_redact_regions)?;
+
+    let mut header = [0u8; ELF_HEADER_SIZE];
+    vmcore.read_exact_at(&mut header, 0)?;
+    let mut phdrs = vec![0u8; (le_u16(&header, 54) * le_u16(&header, 56)) as usize];
+    vmcore.read_exact_at(&mut phdrs, le_u64(&header, 32))?;
+
+    let ranges = redacted_ranges(&header, &phdrs, &regions)?;
+    copy_redacted(&mut vmcore, w, &ranges)
+}
+
+// capture_vmcore writes the vmcore of the crashed kernel, run by the agent
+// started as init by the crash kernel.
+pub fn capture_vmcore(logger: &Logger, config: &agentConfig) -> Result<()> {
+    if !config.kdump_device.is_empty() {
+        let mut device = OpenOptions::new().write(true).open(&config.kdump_device)?;
+        let size = write_vmcore(config, &mut device)?;
+        device.sync_all()?;
+
+        info!(logger, "captured the vmcore";
+            "device" => &config.kdump_device,
+            "size" => size);
+
+        return Ok(());
+    }
+
+    mount_shared_fs(logger)?;
+
+    let now = SystemTime::now()
+        .duration_since(UNIX_EPOCH)
+        .map(|d| d.as_secs())
+        .unwrap_or(0);
+    let path = Path::new(SHARED_DIR)
+        .join(KDUMP_DIR)
+        .join(format!("vmcore.{}", now));
+
+    let mut file = File::create(&path)?;
+    let size = match write_vmcore(config, &mut file) {
+        Ok(s) => s,
+        Err(e) => {
+            let _ = fs::remove_file(&path);
+            return Err(e);
+        }
+    };
+    file.sync_all()?;
+
+    info!(logger, "captured the vmcore";
+        "path" => path.display().to_string(),
+        "size" => size);
+
+    Ok(())
+}
+
+// power_off stops the VM, once the vmcore is captured or the sandbox is
+// destroyed.
+pub fn power_off(logger: &Logger) {
+    unistd::sync();
+
+    if let Err(e) = reboot(RebootMode::RB_POWER_OFF) {
+        error!(logger, "failed to power off"; "error" => format!("{}", e));
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_crash_cmdline() {
+        let cmdline = "tsc=reliable root=/dev/pmem0p1 crashkernel=256M init=/usr/lib/systemd/systemd systemd.unit=kata-containers.target agent.kdump agent.kdump_device=/dev/vdb\n";
+
+        assert_eq!(
+            crash_cmdline(cmdline, "/usr/bin/kata-agent", ""),
+            "tsc=reliable root=/dev/pmem0p1 agent.kdump_device=/dev/vdb \
+             init=/usr/bin/kata-agent rdinit=/usr/bin/kata-agent agent.kdump_capture \
+             irqpoll nr_cpus=1 reset_devices"
+        );
+
+        let cmdline =
+            "root=/dev/pmem0p1 crashkernel=256M agent.kdump agent.kdump_redact=/run/secrets\n";
+        assert_eq!(
+            crash_cmdline(cmdline, "/usr/bin/kata-agent", "1000-3000"),
+            "root=/dev/pmem0p1 init=/usr/bin/kata-agent rdinit=/usr/bin/kata-agent \
+             agent.kdump_capture agent.kdump_redact_regions=1000-3000 \
+             irqpoll nr_cpus=1 reset_devices"
+        );
+    }
+
+    #[test]
+    fn test_merge_regions() {
+        assert_eq!(
+            merge_regions(vec![
+                (0x3000, 0x4000),
+                (0x1000, 0x2000),
+                (0x2000, 0x3000),
+                (0x8000, 0x9000)
+            ]),
+            vec![(0x1000, 0x4000), (0x8000, 0x9000)]
+        );
+    }
+
+    #[test]
+    fn test_redact_param() {
+        assert_eq!(
+            redact_param(&[(0x1000, 0x4000), (0x8000, 0x9000)]),
+            "1000-4000,8000-9000"
+        );
+        assert_eq!(redact_param(&[]), "");
+
+        let many: Vec<Region> = (0..200)
+            .map(|i| (i * 0x2000, i * 0x2000 + 0x1000))
+            .collect();
+        assert_eq!(redact_param(&many), REDACT_ALL);
+
+        assert_eq!(
+            parse_regions(&["1000-4000".to_string(), "8000-9000".to_string()]).unwrap(),
+            vec![(0x1000, 0x4000), (0x8000, 0x9000)]
+        );
+        assert!(parse_regions(&["1000".to_string()]).is_err());
+    }
+
+    fn elf_header(phnum: u16) -> Vec<u8> {
+        let mut h = vec![0u8; ELF_HEADER_SIZE];
+        h[0..4].copy_from_slice(b"\x7fELF");
+        h[4] = 2;
+        h[32..40].copy_from_slice(&(ELF_HEADER_SIZE as u64).to_le_bytes());
+        h[54..56].copy_from_slice(&(ELF_PHDR_SIZE as u16).to_le_bytes());
+        h[56..58].copy_from_slice(&phnum.to_le_bytes());
+        h
+    }
+
+    fn load_phdr(offset: u64, paddr: u64, filesz: u64) -> Vec<u8> {
+        let mut ph = vec![0u8; ELF_PHDR_SIZE as usize];
+        ph[0..4].copy_from_slice(&PT_LOAD.to_le_bytes());
+        ph[8..16].copy_from_slice(&offset.to_le_bytes());
+        ph[24..32].copy_from_slice(&paddr.to_le_bytes());
+        ph[32..40].copy_from_slice(&filesz.to_le_bytes());
+        ph
+    }
+
+    #[test]
+    fn test_redacted_ranges() {
+        let header = elf_header(2);
+        let mut phdrs = load_phdr(0x1000, 0x0, 0x10000);
+        phdrs.extend(load_phdr(0x11000, 0x100000, 0x10000));
+
+        assert_eq!(
+            redacted_ranges(&header, &phdrs, &[(0x2000, 0x3000), (0x10f000, 0x120000)]).unwrap(),
+            vec![(0x3000, 0x4000), (0x20000, 0x21000)]
+        );
+
+        // Memory out of the vmcore
+        assert!(redacted_ranges(&header, &phdrs, &[(0x200000, 0x201000)])
+            .unwrap()
+            .is_empty());
+
+        assert!(redacted_ranges(&[0u8; ELF_HEADER_SIZE], &phdrs, &[]).is_err());
+    }
+
+    #[test]
+    fn test_copy_redacted() {
+        let data = vec![0xffu8; 16];
+        let mut out = Vec::new();
+
+        let size = copy_redacted(&mut data.as_slice(), &mut out, &[(2, 4), (10, 20)]).unwrap();
+        assert_eq!(size, 16);
+        assert_eq!(
+            out,
+            vec![0xff, 0xff, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0, 0, 0]
+        );
+    }
+}
@@ -20,26 +20,36 @@ pub const PCI_ROOT_BUS_PATH: &str = "/devices/pci0000:00";
 pub const PCI_ROOT_BUS_PATH: &str = "/devices/platform/4010000000.pcie/pci0000:00";
 
 pub const SYSFS_CPU_ONLINE_PATH: &str = "/sys/devices/system/cpu";
+pub const SYSFS_CPU_ONLINE_LIST_PATH: &str = "/sys/devices/system/cpu/online";
 
 pub const SYSFS_MEMORY_BLOCK_SIZE_PATH: &str = "/sys/devices/system/memory/block_size_bytes";
 pub const SYSFS_MEMORY_HOTPLUG_PROBE_PATH: &str = "/sys/devices/system/memory/probe";
 pub const SYSFS_MEMORY_ONLINE_PATH: &str = "/sys/devices/system/memory";
 
+pub const SYSFS_POWER_STATE_PATH: &str = "/sys/power/state";
+
 // Here in "0:0", the first number is the SCSI host number because
 // only one SCSI controller has been plugged, while the second number
 // is always 0.
 pub const SCSI_HOST_CHANNEL: &str = "0:0:";
 pub const SCSI_BLOCK_SUFFIX: &str = "block";
 pub const SYSFS_SCSI_HOST_PATH: &str = "/sys/class/scsi_host";
+pub const SYSFS_DEV_BLOCK_PATH: &str = "/sys/dev/block";
 
 pub const SYSFS_CGROUPPATH: &str = "/sys/fs/cgroup";
 pub const SYSFS_ONLINE_FILE: &str = "online";
 
 pub const PROC_MOUNTSTATS: &str = "/proc/self/mountstats";
+pub const PROC_MOUNTINFO: &str = "/proc/self/mountinfo";
 pub const PROC_CGROUPS: &str = "/proc/cgroups";
 
 pub const SYSTEM_DEV_PATH: &str = "/dev";
 
+// The character devices of the RDMA devices, and the class of their user
+// space verbs devices.
+pub const INFINIBAND_DEV_PATH: &str = "/dev/infiniband";
+pub const SYSFS_INFINIBAND_VERBS_PATH: &str = "/sys/class/infiniband_verbs";
+
 // Linux UEvent related consts.
 pub const U_EVENT_ACTION: &str = "ACTION";
 pub const U_EVENT_ACTION_ADD: &str = "add";
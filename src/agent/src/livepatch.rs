@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use nix::kmod::{self, ModuleInitFlags};
+use rustjail::errors::*;
+use slog::Logger;
+use std::ffi::CString;
+use std::fs::{self, File};
+use std::path::Path;
+use std::thread;
+use std::time::{Duration, Instant};
+
+const SIG_ENFORCE_PATH: &str = "/sys/module/module/parameters/sig_enforce";
+const LIVEPATCH_SYSFS_DIR: &str = "/sys/kernel/livepatch";
+
+const POLL_INTERVAL: Duration = Duration::from_millis(100);
+
+// TRANSITION_TIMEOUT is how long the tasks are waited for to switch to the
+// patched functions, the transition going on past it.
+const TRANSITION_TIMEOUT: Duration = Duration::from_secs(30);
+
+// kernel_name returns the name the kernel knows a module by.
+fn kernel_name(module: &str) -> String {
+    module.replace('-', "_")
+}
+
+fn sig_enforced(param: &str) -> bool {
+    param.trim() == "Y"
+}
+
+// wait_transition waits for the patch to be enabled and for the tasks to
+// run the patched functions.
+fn wait_transition(logger: &Logger, dir: &Path) -> Result<()> {
+    let deadline = Instant::now() + TRANSITION_TIMEOUT;
+
+    loop {
+        if fs::read_to_string(dir.join("enabled"))?.trim() != "1" {
+            return Err(ErrorKind::ErrorCode(String::from("livepatch not enabled")).into());
+        }
+
+        if fs::read_to_string(dir.join("transition"))?.trim() == "0" {
+            return Ok(());
+        }
+
+        if Instant::now() >= deadline {
+            warn!(logger, "livepatch transition still in progress";
+                "patch" => dir.to_string_lossy().to_string());
+            return Ok(());
+        }
+
+        thread::sleep(POLL_INTERVAL);
+    }
+}
+
+fn load(logger: &Logger, allowed: &[String], path: &Path, module: &str) -> Result<()> {
+    if !allowed.iter().any(|m| m == module) {
+        return Err(
+            ErrorKind::ErrorCode(format!("livepatch module {} not allowed", module)).into(),
+        );
+    }
+
+    // The modules are only trusted once their signature is verified.
+    if !sig_enforced(&fs::read_to_string(SIG_ENFORCE_PATH)?) {
+        return Err(ErrorKind::ErrorCode(String::from(
+            "module signatures not enforced, livepatching disabled",
+        ))
+        .into());
+    }
+
+    let dir = Path::new(LIVEPATCH_SYSFS_DIR).join(kernel_name(module));
+    if dir.exists() {
+        info!(logger, "livepatch module already loaded"; "module" => module);
+        return Ok(());
+    }
+
+    let file = File::open(path)?;
+    kmod::finit_module(&file, &CString::default(), ModuleInitFlags::empty())?;
+
+    wait_transition(logger, &dir)?;
+
+    info!(logger, "livepatch module loaded"; "module" => module);
+
+    Ok(())
+}
+
+// load_module loads the livepatch module copied by the runtime, provided it
+// is allowed, and removes the copy.
+pub fn load_module(logger: &Logger, allowed: &[String], path: &Path, module: &str) -> Result<()> {
+    let result = load(logger, allowed, path, module);
+    fs::remove_file(path)?;
+
+    result
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_kernel_name() {
+        assert_eq!(kernel_name("kpatch-cve-fix"), "kpatch_cve_fix");
+        assert_eq!(kernel_name("livepatch_sched"), "livepatch_sched");
+    }
+
+    #[test]
+    fn test_sig_enforced() {
+        assert!(sig_enforced("Y\n"));
+        assert!(!sig_enforced("N\n"));
+        assert!(!sig_enforced(""));
+    }
+}
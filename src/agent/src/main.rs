@@ -49,17 +49,31 @@ use std::sync::{Arc, Mutex, RwLock};
 use std::{io, thread};
 use unistd::Pid;
 
+mod checkpoint;
 mod config;
+mod core_dump;
 mod device;
+mod dhcp;
+mod idle_memory;
+mod kdump;
 mod linux_abi;
+mod livepatch;
+mod memory_reclaim;
+mod metadata_proxy;
 mod metrics;
 mod mount;
 mod namespace;
+mod net_queues;
 mod network;
+mod profiling;
+mod provisioning;
 pub mod random;
 mod sandbox;
+mod scratch;
+mod tdx;
 #[cfg(test)]
 mod test_utils;
+mod time_sync;
 mod uevent;
 mod version;
 
@@ -109,6 +123,15 @@ fn main() -> Result<()> {
         exit(0);
     }
 
+    // Run by the kernel, for each core dump of the guest processes.
+    if args.len() > 1 && args[1] == core_dump::CORE_DUMP_ARG {
+        if let Err(e) = core_dump::handle_core_dump(&args[2..]) {
+            eprintln!("failed to handle the core dump: {}", e);
+            exit(1);
+        }
+        exit(0);
+    }
+
     env::set_var("RUST_BACKTRACE", "full");
 
     lazy_static::initialize(&SHELLS);
@@ -175,6 +198,16 @@ fn main() -> Result<()> {
 
     announce(&logger);
 
+    // Run as init by the crash kernel.
+    if config.kdump_capture {
+        if let Err(e) = kdump::capture_vmcore(&logger, &config) {
+            error!(logger, "failed to capture the vmcore"; "error" => format!("{}", e));
+        }
+        kdump::power_off(&logger);
+
+        exit(1);
+    }
+
     if args.len() == 2 && args[1] == "--version" {
         // force logger to flush
         drop(logger);
@@ -213,6 +246,25 @@ fn main() -> Result<()> {
 
     let sandbox = Arc::new(Mutex::new(s));
 
+    provisioning::setup_locale(&logger, &config);
+
+    // Before the reaper is set up, which would race with the commands run.
+    if let Err(e) = time_sync::setup_time_sync(&logger, &config) {
+        warn!(logger, "failed to setup the guest time sources"; "error" => format!("{}", e));
+    }
+
+    if let Err(e) = memory_reclaim::setup_memory_reclaim(&logger, &config) {
+        warn!(logger, "failed to setup the guest memory reclaim"; "error" => format!("{}", e));
+    }
+
+    if let Err(e) = idle_memory::setup_idle_memory(&logger, &config) {
+        warn!(logger, "failed to setup the idle memory tracking"; "error" => format!("{}", e));
+    }
+
+    if let Err(e) = metadata_proxy::setup_metadata_proxy(&logger, &config) {
+        warn!(logger, "failed to setup the metadata proxy"; "error" => format!("{}", e));
+    }
+
     setup_signal_handler(&logger, sandbox.clone()).unwrap();
     watch_uevents(sandbox.clone());
 
@@ -246,6 +298,13 @@ fn main() -> Result<()> {
 
     server.shutdown();
 
+    // The runtime waits for the guest to power off once the sandbox is
+    // destroyed, before stopping the VM itself.
+    if config.power_off && unistd::getpid() == Pid::from_raw(1) {
+        info!(logger, "powering off the guest");
+        kdump::power_off(&logger);
+    }
+
     let _ = log_handle.join();
 
     if config.debug_console {
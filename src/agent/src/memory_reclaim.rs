@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use crate::config::agentConfig;
+use rustjail::errors::*;
+use slog::Logger;
+use std::fs;
+use std::thread;
+use std::time::Duration;
+
+const MEMINFO_PATH: &str = "/proc/meminfo";
+const DROP_CACHES_PATH: &str = "/proc/sys/vm/drop_caches";
+const VFS_CACHE_PRESSURE_PATH: &str = "/proc/sys/vm/vfs_cache_pressure";
+
+// Dropping the clean page cache only: the dirty pages are written back as
+// usual, and the dentries and inodes are left to vfs_cache_pressure.
+const DROP_PAGE_CACHE: &str = "1";
+
+// droppable_cache_kb returns the size of the page cache that can be
+// dropped, the shared memory being part of the page cache.
+fn droppable_cache_kb(meminfo: &str) -> Result<u64> {
+    let mut cached = None;
+    let mut shmem = 0;
+
+    for line in meminfo.lines() {
+        let fields: Vec<&str> = line.split_whitespace().collect();
+        if fields.len() < 2 {
+            continue;
+        }
+
+        match fields[0] {
+            "Cached:" => cached = Some(fields[1].parse::<u64>()?),
+            "Shmem:" => shmem = fields[1].parse::<u64>()?,
+            _ => {}
+        }
+    }
+
+    match cached {
+        Some(c) => Ok(c.saturating_sub(shmem)),
+        None => Err(ErrorKind::ErrorCode(String::from("no page cache size in meminfo")).into()),
+    }
+}
+
+fn drop_cache(logger: &Logger, threshold_mb: u64) -> Result<()> {
+    let cached_kb = droppable_cache_kb(&fs::read_to_string(MEMINFO_PATH)?)?;
+    if cached_kb < threshold_mb * 1024 {
+        return Ok(());
+    }
+
+    fs::write(DROP_CACHES_PATH, DROP_PAGE_CACHE)?;
+
+    debug!(logger, "page cache dropped"; "cached-kb" => cached_kb);
+
+    Ok(())
+}
+
+// setup_memory_reclaim applies the cache pressure passed on the kernel
+// command line, and starts dropping the page cache periodically, once it
+// exceeds the threshold, so that the guest reports the memory it caches as
+// free and the memory of the sandbox is its working set.
+pub fn setup_memory_reclaim(logger: &Logger, config: &agentConfig) -> Result<()> {
+    let logger = logger.new(o!("subsystem" => "memory-reclaim"));
+
+    if config.vfs_cache_pressure > 0 {
+        fs::write(
+            VFS_CACHE_PRESSURE_PATH,
+            config.vfs_cache_pressure.to_string(),
+        )?;
+    }
+
+    let interval = config.cache_drop_interval;
+    if interval == Duration::from_secs(0) {
+        return Ok(());
+    }
+
+    let threshold_mb = config.cache_drop_threshold_mb;
+    thread::spawn(move || loop {
+        thread::sleep(interval);
+
+        if let Err(e) = drop_cache(&logger, threshold_mb) {
+            warn!(logger, "failed to drop the page cache"; "error" => format!("{}", e));
+        }
+    });
+
+    Ok(())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_droppable_cache_kb() {
+        let meminfo = "MemTotal:        2041536 kB\n\
+                       MemFree:         1652220 kB\n\
+                       Cached:           262144 kB\n\
+                       Shmem:             65536 kB\n";
+        assert_eq!(droppable_cache_kb(meminfo).unwrap(), 196608);
+
+        assert_eq!(
+            droppable_cache_kb("Cached: 1024 kB\nShmem: 2048 kB\n").unwrap(),
+            0
+        );
+        assert!(droppable_cache_kb("MemTotal: 2041536 kB\n").is_err());
+        assert!(droppable_cache_kb("Cached: many kB\n").is_err());
+    }
+}
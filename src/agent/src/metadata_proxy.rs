@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use crate::config::agentConfig;
+use netlink::{RtIPAddr, RtnlHandle, NETLINK_ROUTE};
+use nix::errno::Errno;
+use nix::sys::socket::{self, AddressFamily, SockAddr, SockFlag, SockType};
+use rustjail::errors::*;
+use slog::Logger;
+use std::fs::File;
+use std::io;
+use std::net::{Ipv4Addr, Shutdown, TcpListener, TcpStream};
+use std::os::unix::io::{AsRawFd, FromRawFd};
+use std::thread;
+
+// The link-local address of the instance metadata service, the same on all
+// the clouds.
+const METADATA_ADDRESS: Ipv4Addr = Ipv4Addr::new(169, 254, 169, 254);
+const METADATA_PORT: u16 = 80;
+
+fn metadata_address() -> RtIPAddr {
+    RtIPAddr {
+        ip_family: libc::AF_INET as u8,
+        ip_mask: 32,
+        addr: METADATA_ADDRESS.octets().to_vec(),
+    }
+}
+
+// add_metadata_address adds the metadata service address to the loopback
+// interface, so that the workloads reach the proxy as they would reach the
+// metadata service on the host.
+fn add_metadata_address() -> Result<()> {
+    let mut rtnl = RtnlHandle::new(NETLINK_ROUTE, 0)?;
+    let lo = rtnl.find_link_by_name("lo")?;
+    rtnl.set_link_status(&lo, true)?;
+
+    match rtnl.add_one_address(&lo, &metadata_address()) {
+        Err(nix::Error::Sys(Errno::EEXIST)) => Ok(()),
+        r => r.map_err(|e| e.into()),
+    }
+}
+
+// connect_host connects to the proxy of the runtime, on the host.
+fn connect_host(port: u32) -> Result<File> {
+    let fd = socket::socket(
+        AddressFamily::Vsock,
+        SockType::Stream,
+        SockFlag::SOCK_CLOEXEC,
+        None,
+    )?;
+
+    // The file owns fd from now on, and closes it.
+    let host = unsafe { File::from_raw_fd(fd) };
+    socket::connect(fd, &SockAddr::new_vsock(libc::VMADDR_CID_HOST, port))?;
+
+    Ok(host)
+}
+
+// forward copies a connection of the guest to the proxy of the runtime, and
+// the responses back, until both sides are done.
+fn forward(stream: TcpStream, port: u32) -> Result<()> {
+    let host = connect_host(port)?;
+
+    let mut to_host = host.try_clone()?;
+    let mut from_guest = stream.try_clone()?;
+    let requests = thread::spawn(move || {
+        let _ = io::copy(&mut from_guest, &mut to_host);
+        let _ = socket::shutdown(to_host.as_raw_fd(), socket::Shutdown::Write);
+    });
+
+    let mut from_host = host;
+    let mut to_guest = stream;
+    let result = io::copy(&mut from_host, &mut to_guest);
+    let _ = to_guest.shutdown(Shutdown::Write);
+    let _ = requests.join();
+
+    result?;
+    Ok(())
+}
+
+// setup_metadata_proxy serves the metadata service address in the guest,
+// forwarding the connections over vsock to the proxy of the runtime, on the
+// port passed on the kernel command line. The proxy of the runtime filters
+// the requests.
+pub fn setup_metadata_proxy(logger: &Logger, config: &agentConfig) -> Result<()> {
+    let port = config.metadata_proxy_vport;
+    if port == 0 {
+        return Ok(());
+    }
+
+    let logger = logger.new(o!("subsystem" => "metadata-proxy"));
+
+    add_metadata_address()?;
+    let listener = TcpListener::bind((METADATA_ADDRESS, METADATA_PORT))?;
+
+    thread::spawn(move || {
+        for stream in listener.incoming() {
+            let stream = match stream {
+                Ok(s) => s,
+                Err(e) => {
+                    warn!(logger, "failed to accept a metadata connection"; "error" => format!("{}", e));
+                    continue;
+                }
+            };
+
+            let logger = logger.clone();
+            thread::spawn(move || {
+                if let Err(e) = forward(stream, port) {
+                    warn!(logger, "failed to forward a metadata connection"; "error" => format!("{}", e));
+                }
+            });
+        }
+    });
+
+    Ok(())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_metadata_address() {
+        let addr = metadata_address();
+
+        assert_eq!(addr.ip_family, libc::AF_INET as u8);
+        assert_eq!(addr.ip_mask, 32);
+        assert_eq!(addr.addr, vec![169, 254, 169, 254]);
+    }
+}
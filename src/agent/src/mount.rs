@@ -25,6 +25,7 @@ use std::io::{BufRead, BufReader};
 use crate::device::{get_pci_device_name, get_scsi_device_name, online_device};
 use crate::linux_abi::*;
 use crate::protocols::agent::Storage;
+use crate::scratch;
 use crate::Sandbox;
 use slog::Logger;
 
@@ -36,6 +37,7 @@ pub const DRIVERSCSITYPE: &str = "scsi";
 pub const DRIVERNVDIMMTYPE: &str = "nvdimm";
 pub const DRIVEREPHEMERALTYPE: &str = "ephemeral";
 pub const DRIVERLOCALTYPE: &str = "local";
+pub const DRIVEROVERLAYFSTYPE: &str = "overlayfs";
 
 pub const TYPEROOTFS: &str = "rootfs";
 
@@ -144,6 +146,8 @@ lazy_static! {
         m.insert(DRIVERLOCALTYPE, local);
     let scsi: StorageHandler = virtio_scsi_storage_handler;
         m.insert(DRIVERSCSITYPE, scsi);
+    let overlayfs: StorageHandler = overlayfs_storage_handler;
+        m.insert(DRIVEROVERLAYFSTYPE, overlayfs);
         m
     };
 }
@@ -303,10 +307,12 @@ fn virtio9p_storage_handler(
 fn virtiommio_blk_storage_handler(
     logger: &Logger,
     storage: &Storage,
-    _sandbox: Arc<Mutex<Sandbox>>,
+    sandbox: Arc<Mutex<Sandbox>>,
 ) -> Result<String> {
     //The source path is VmPath
-    common_storage_handler(logger, storage)
+    let storage = open_encrypted_storage(logger, storage.clone(), &sandbox)?;
+
+    common_storage_handler(logger, &storage)
 }
 
 // virtiofs_storage_handler handles the storage for virtio-fs.
@@ -339,6 +345,8 @@ fn virtio_blk_storage_handler(
         storage.source = dev_path;
     }
 
+    let storage = open_encrypted_storage(logger, storage, &sandbox)?;
+
     common_storage_handler(logger, &storage)
 }
 
@@ -354,9 +362,46 @@ fn virtio_scsi_storage_handler(
     let dev_path = get_scsi_device_name(&sandbox, &storage.source)?;
     storage.source = dev_path;
 
+    let storage = open_encrypted_storage(logger, storage, &sandbox)?;
+
     common_storage_handler(logger, &storage)
 }
 
+// open_encrypted_storage sets an encrypted scratch device up on the device
+// of a block storage, when its driver options ask for one, and returns the
+// storage mounting the opened device.
+fn open_encrypted_storage(
+    logger: &Logger,
+    mut storage: Storage,
+    sandbox: &Arc<Mutex<Sandbox>>,
+) -> Result<Storage> {
+    if !scratch::is_encrypted(&storage.driver_options) {
+        return Ok(storage);
+    }
+
+    storage.source = scratch::setup(logger, &storage.source, &storage.mount_point, sandbox)?;
+    storage.fstype = scratch::FSTYPE.to_string();
+
+    Ok(storage)
+}
+
+// overlayfs_storage_handler handles the storage for an overlay filesystem,
+// creating its upper and work directories.
+fn overlayfs_storage_handler(
+    logger: &Logger,
+    storage: &Storage,
+    _sandbox: Arc<Mutex<Sandbox>>,
+) -> Result<String> {
+    for opt in storage.options.iter() {
+        let fields: Vec<&str> = opt.splitn(2, '=').collect();
+        if fields.len() == 2 && (fields[0] == "upperdir" || fields[0] == "workdir") {
+            fs::create_dir_all(fields[1])?;
+        }
+    }
+
+    common_storage_handler(logger, storage)
+}
+
 fn common_storage_handler(logger: &Logger, storage: &Storage) -> Result<String> {
     // Mount the storage device.
     let mount_point = storage.mount_point.to_string();
@@ -629,9 +674,12 @@ pub fn cgroups_mount(logger: &Logger) -> Result<()> {
     Ok(())
 }
 
+// remove_mounts unmounts the mounts in the reverse order they were mounted,
+// the later ones possibly being stacked on the earlier ones.
 pub fn remove_mounts(mounts: &Vec<String>) -> Result<()> {
-    for m in mounts.iter() {
+    for m in mounts.iter().rev() {
         mount::umount(m.as_str())?;
+        scratch::close(m)?;
     }
     Ok(())
 }
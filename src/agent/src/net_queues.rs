@@ -0,0 +1,192 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use crate::random::IoctlRequestType;
+use nix::errno::Errno;
+use nix::sys::socket::{self, AddressFamily, SockFlag, SockType};
+use rustjail::errors::*;
+use slog::Logger;
+use std::cmp;
+use std::fs::{self, File};
+use std::os::unix::io::{AsRawFd, FromRawFd};
+use std::path::Path;
+
+const SYSFS_NET_PATH: &str = "/sys/class/net";
+const VIRTIO_NET_DRIVER: &str = "virtio_net";
+
+const SIOCETHTOOL: libc::c_ulong = 0x8946;
+const ETHTOOL_GCHANNELS: u32 = 0x3c;
+const ETHTOOL_SCHANNELS: u32 = 0x3d;
+
+// struct ethtool_channels of linux/ethtool.h
+#[repr(C)]
+#[derive(Debug, Default)]
+struct EthtoolChannels {
+    cmd: u32,
+    max_rx: u32,
+    max_tx: u32,
+    max_other: u32,
+    max_combined: u32,
+    rx_count: u32,
+    tx_count: u32,
+    other_count: u32,
+    combined_count: u32,
+}
+
+// struct ifreq of linux/if.h, with ifr_data as its union member.
+#[repr(C)]
+struct IfReq {
+    ifr_name: [libc::c_char; libc::IFNAMSIZ],
+    ifr_data: *mut libc::c_void,
+    _pad: [u8; 16],
+}
+
+// wanted_queues returns the queue pairs a network device should use, one
+// for each online CPU, within the queue pairs of the device.
+fn wanted_queues(online_cpus: u32, max_queues: u32) -> u32 {
+    cmp::max(cmp::min(online_cpus, max_queues), 1)
+}
+
+fn virtio_net_interfaces(sysfs_net: &Path) -> Result<Vec<String>> {
+    let mut ifaces = Vec::new();
+
+    for e in fs::read_dir(sysfs_net)? {
+        let entry = e?;
+        let driver = match fs::read_link(entry.path().join("device/driver")) {
+            Ok(d) => d,
+            Err(_) => continue,
+        };
+
+        if driver.file_name().and_then(|n| n.to_str()) == Some(VIRTIO_NET_DRIVER) {
+            if let Some(name) = entry.file_name().to_str() {
+                ifaces.push(name.to_string());
+            }
+        }
+    }
+
+    ifaces.sort();
+    Ok(ifaces)
+}
+
+fn ethtool_channels(sock: &File, iface: &str, channels: &mut EthtoolChannels) -> Result<()> {
+    let mut req = IfReq {
+        ifr_name: [0; libc::IFNAMSIZ],
+        ifr_data: channels as *mut EthtoolChannels as *mut libc::c_void,
+        _pad: [0; 16],
+    };
+
+    if iface.len() >= libc::IFNAMSIZ {
+        return Err(ErrorKind::ErrorCode(format!("invalid interface name {}", iface)).into());
+    }
+    for (i, b) in iface.bytes().enumerate() {
+        req.ifr_name[i] = b as libc::c_char;
+    }
+
+    let ret = unsafe {
+        libc::ioctl(
+            sock.as_raw_fd(),
+            SIOCETHTOOL as IoctlRequestType,
+            &mut req as *mut IfReq,
+        )
+    };
+    Errno::result(ret).map(drop)?;
+
+    Ok(())
+}
+
+// tune_interface sets the combined queues of a multi-queue virtio-net
+// interface to the online CPUs, which the guest kernel only does when the
+// device is probed.
+fn tune_interface(logger: &Logger, sock: &File, iface: &str, online_cpus: u32) -> Result<()> {
+    let mut channels = EthtoolChannels {
+        cmd: ETHTOOL_GCHANNELS,
+        ..Default::default()
+    };
+    ethtool_channels(sock, iface, &mut channels)?;
+
+    let queues = wanted_queues(online_cpus, channels.max_combined);
+    if channels.max_combined <= 1 || channels.combined_count == queues {
+        return Ok(());
+    }
+
+    info!(logger, "tuning network queues";
+        "interface" => iface,
+        "queues" => queues,
+        "previous" => channels.combined_count);
+
+    channels.cmd = ETHTOOL_SCHANNELS;
+    channels.combined_count = queues;
+    ethtool_channels(sock, iface, &mut channels)
+}
+
+// tune_network_queues makes the virtio-net interfaces use a queue pair for
+// each online CPU, such as after CPUs were hotplugged, as far as their
+// devices have enough queue pairs.
+pub fn tune_network_queues(logger: &Logger) -> Result<()> {
+    let online_cpus = unsafe { libc::sysconf(libc::_SC_NPROCESSORS_ONLN) };
+    if online_cpus < 1 {
+        return Err(ErrorKind::ErrorCode("failed to get the online CPUs".to_string()).into());
+    }
+
+    let fd = socket::socket(
+        AddressFamily::Inet,
+        SockType::Datagram,
+        SockFlag::SOCK_CLOEXEC,
+        None,
+    )?;
+    // The file owns fd from now on, and closes it.
+    let sock = unsafe { File::from_raw_fd(fd) };
+
+    for iface in virtio_net_interfaces(Path::new(SYSFS_NET_PATH))? {
+        if let Err(e) = tune_interface(logger, &sock, &iface, online_cpus as u32) {
+            warn!(logger, "failed to tune network queues";
+                "interface" => iface.as_str(),
+                "error" => format!("{}", e));
+        }
+    }
+
+    Ok(())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use std::os::unix::fs::symlink;
+    use tempfile::tempdir;
+
+    #[test]
+    fn test_wanted_queues() {
+        assert_eq!(wanted_queues(1, 8), 1);
+        assert_eq!(wanted_queues(4, 8), 4);
+        assert_eq!(wanted_queues(16, 8), 8);
+        assert_eq!(wanted_queues(4, 0), 1);
+    }
+
+    #[test]
+    fn test_virtio_net_interfaces() {
+        let dir = tempdir().unwrap();
+        let drivers = dir.path().join("drivers");
+
+        for (iface, driver) in &[
+            ("eth1", "virtio_net"),
+            ("eth0", "virtio_net"),
+            ("eth2", "e1000"),
+        ] {
+            fs::create_dir_all(drivers.join(driver)).unwrap();
+            let device = dir.path().join("devices").join(iface);
+            fs::create_dir_all(&device).unwrap();
+            symlink(drivers.join(driver), device.join("driver")).unwrap();
+
+            let net = dir.path().join("net").join(iface);
+            fs::create_dir_all(&net).unwrap();
+            symlink(&device, net.join("device")).unwrap();
+        }
+        // Virtual interfaces have no device.
+        fs::create_dir_all(dir.path().join("net/lo")).unwrap();
+
+        let ifaces = virtio_net_interfaces(&dir.path().join("net")).unwrap();
+        assert_eq!(ifaces, vec!["eth0".to_string(), "eth1".to_string()]);
+    }
+}
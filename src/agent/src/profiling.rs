@@ -0,0 +1,282 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use nix::sys::signal::{self, Signal};
+use nix::unistd::Pid;
+use protocols::agent::StartProfilingRequest;
+use rustjail::errors::*;
+use slog::Logger;
+use std::fs::{self, File};
+use std::path::{Path, PathBuf};
+use std::process::{Child, Command, ExitStatus};
+use std::thread;
+use std::time::{Duration, Instant};
+
+// The error logs of the tools, named after the session.
+const LOGS_DIR: &str = "/run/kata-containers/profiling";
+
+// The output and the status of the sessions, in the directory shared with
+// the host by the runtime.
+const SESSIONS_DIR: &str = "/run/kata-containers/shared/containers/profiling";
+const OUTPUT_FILE: &str = "output";
+const STATUS_FILE: &str = "status";
+
+const TOOL_PERF: &str = "perf";
+const TOOL_BPFTRACE: &str = "bpftrace";
+
+const PERF_PATH: &str = "/usr/bin/perf";
+const BPFTRACE_PATH: &str = "/usr/bin/bpftrace";
+
+// The bpftrace scripts shipped with the guest image, the only ones a
+// session can run.
+const BPFTRACE_SCRIPTS_DIR: &str = "/usr/share/kata-containers/bpftrace";
+
+const POLL_INTERVAL: Duration = Duration::from_millis(100);
+
+// STOP_TIMEOUT is how long an interrupted tool has to write its results.
+const STOP_TIMEOUT: Duration = Duration::from_secs(5);
+
+#[derive(Debug, Default, PartialEq)]
+struct Request {
+    tool: String,
+    duration: Duration,
+    frequency: u32,
+    script: String,
+}
+
+impl Request {
+    fn new(req: &StartProfilingRequest) -> Result<Request> {
+        if req.get_duration() == 0 {
+            return Err(ErrorKind::ErrorCode(String::from("no profiling duration")).into());
+        }
+
+        Ok(Request {
+            tool: req.get_tool().to_string(),
+            duration: Duration::from_secs(u64::from(req.get_duration())),
+            frequency: req.get_frequency(),
+            script: req.get_script().to_string(),
+        })
+    }
+
+    fn command(&self) -> Result<Command> {
+        match self.tool.as_str() {
+            TOOL_PERF => {
+                // The samples of the whole guest, in the perf pipe format.
+                let mut cmd = Command::new(PERF_PATH);
+                cmd.args(&["record", "-a", "-g", "-o", "-"]);
+                if self.frequency > 0 {
+                    cmd.arg("-F").arg(self.frequency.to_string());
+                }
+                cmd.arg("--")
+                    .arg("sleep")
+                    .arg(self.duration.as_secs().to_string());
+
+                Ok(cmd)
+            }
+            TOOL_BPFTRACE => {
+                if self.script.is_empty()
+                    || self.script.contains('/')
+                    || self.script.starts_with('.')
+                {
+                    return Err(ErrorKind::ErrorCode(format!(
+                        "invalid bpftrace script {:?}",
+                        self.script
+                    ))
+                    .into());
+                }
+
+                let mut cmd = Command::new(BPFTRACE_PATH);
+                cmd.arg(Path::new(BPFTRACE_SCRIPTS_DIR).join(format!("{}.bt", self.script)));
+
+                Ok(cmd)
+            }
+            t => Err(ErrorKind::ErrorCode(format!("unknown profiling tool {}", t)).into()),
+        }
+    }
+}
+
+// valid_session tells whether a session name is a plain file name.
+fn valid_session(session: &str) -> bool {
+    !session.is_empty() && !session.contains('/') && !session.starts_with('.')
+}
+
+// wait_session waits for the tool to exit, interrupting it at the end of
+// the session, and killing it if it does not stop.
+fn wait_session(child: &mut Child, duration: Duration) -> Result<ExitStatus> {
+    let deadline = Instant::now() + duration;
+    let mut interrupted = false;
+
+    loop {
+        if let Some(status) = child.try_wait()? {
+            return Ok(status);
+        }
+
+        let now = Instant::now();
+        if now >= deadline + STOP_TIMEOUT {
+            child.kill()?;
+            return Ok(child.wait()?);
+        }
+
+        if now >= deadline && !interrupted {
+            signal::kill(Pid::from_raw(child.id() as i32), Some(Signal::SIGINT))?;
+            interrupted = true;
+        }
+
+        thread::sleep(POLL_INTERVAL);
+    }
+}
+
+// write_status writes the status of a session, "0" if it succeeded, the
+// exit code and the error of the tool otherwise. The runtime streams the
+// output of the session until the status is written.
+fn write_status(dir: &Path, result: Result<ExitStatus>, log: &Path) -> Result<()> {
+    let status = match result {
+        Ok(s) if s.success() => String::from("0\n"),
+        Ok(s) => format!(
+            "{}\n{}",
+            s.code().unwrap_or(-1),
+            fs::read_to_string(log).unwrap_or_default()
+        ),
+        Err(e) => format!("-1\n{}", e),
+    };
+
+    let tmp = dir.join(format!("{}.tmp", STATUS_FILE));
+    fs::write(&tmp, status)?;
+    fs::rename(&tmp, dir.join(STATUS_FILE))?;
+
+    Ok(())
+}
+
+// start_session starts the profiling session requested by the runtime,
+// provided its tool is allowed, and returns once the tool runs.
+pub fn start_session(
+    logger: &Logger,
+    allowed: &[String],
+    request: &StartProfilingRequest,
+) -> Result<()> {
+    let session = request.get_session();
+    if !valid_session(session) {
+        return Err(
+            ErrorKind::ErrorCode(format!("invalid profiling session {:?}", session)).into(),
+        );
+    }
+
+    let req = Request::new(request)?;
+    if !allowed.contains(&req.tool) {
+        return Err(
+            ErrorKind::ErrorCode(format!("profiling tool {} not allowed", req.tool)).into(),
+        );
+    }
+
+    let dir = Path::new(SESSIONS_DIR).join(session);
+    fs::create_dir_all(LOGS_DIR)?;
+    let log = PathBuf::from(format!("{}/{}.log", LOGS_DIR, session));
+
+    let mut cmd = req.command()?;
+    cmd.stdout(File::create(dir.join(OUTPUT_FILE))?)
+        .stderr(File::create(&log)?);
+    let mut child = cmd.spawn()?;
+
+    info!(logger, "profiling session started";
+        "session" => session,
+        "tool" => &req.tool,
+        "duration" => req.duration.as_secs());
+
+    let logger = logger.new(o!("session" => session.to_string()));
+    thread::spawn(move || {
+        let result = wait_session(&mut child, req.duration);
+        if let Err(e) = write_status(&dir, result, &log) {
+            error!(logger, "failed to write the profiling session status"; "error" => format!("{}", e));
+        }
+        let _ = fs::remove_file(&log);
+
+        info!(logger, "profiling session ended");
+    });
+
+    Ok(())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use tempfile::tempdir;
+
+    #[test]
+    fn test_request_new() {
+        let mut req = StartProfilingRequest::new();
+        req.set_tool(TOOL_PERF.to_string());
+        req.set_duration(10);
+        req.set_frequency(99);
+        assert_eq!(
+            Request::new(&req).unwrap(),
+            Request {
+                tool: TOOL_PERF.to_string(),
+                duration: Duration::from_secs(10),
+                frequency: 99,
+                script: String::new(),
+            }
+        );
+
+        req.set_duration(0);
+        assert!(Request::new(&req).is_err());
+    }
+
+    #[test]
+    fn test_request_command() {
+        let mut req = Request {
+            tool: TOOL_PERF.to_string(),
+            duration: Duration::from_secs(10),
+            frequency: 99,
+            script: String::new(),
+        };
+        assert_eq!(
+            format!("{:?}", req.command().unwrap()),
+            r#""/usr/bin/perf" "record" "-a" "-g" "-o" "-" "-F" "99" "--" "sleep" "10""#
+        );
+
+        req.tool = TOOL_BPFTRACE.to_string();
+        assert!(req.command().is_err());
+
+        req.script = "../../tmp/script".to_string();
+        assert!(req.command().is_err());
+
+        req.script = "runqlat".to_string();
+        assert_eq!(
+            format!("{:?}", req.command().unwrap()),
+            r#""/usr/bin/bpftrace" "/usr/share/kata-containers/bpftrace/runqlat.bt""#
+        );
+
+        req.tool = "gdb".to_string();
+        assert!(req.command().is_err());
+    }
+
+    #[test]
+    fn test_valid_session() {
+        assert!(valid_session("1234"));
+        assert!(!valid_session(""));
+        assert!(!valid_session(".."));
+        assert!(!valid_session("../../etc"));
+    }
+
+    #[test]
+    fn test_wait_session() {
+        let dir = tempdir().unwrap();
+        let log = dir.path().join("log");
+
+        let mut child = Command::new("sleep").arg("10").spawn().unwrap();
+        let result = wait_session(&mut child, Duration::from_millis(200));
+        write_status(dir.path(), result, &log).unwrap();
+
+        let status = fs::read_to_string(dir.path().join(STATUS_FILE)).unwrap();
+        assert_ne!(status, "0\n");
+
+        let mut child = Command::new("true").spawn().unwrap();
+        let result = wait_session(&mut child, Duration::from_secs(10));
+        write_status(dir.path(), result, &log).unwrap();
+
+        let status = fs::read_to_string(dir.path().join(STATUS_FILE)).unwrap();
+        assert_eq!(status, "0\n");
+    }
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use crate::config::agentConfig;
+use crate::mount::BareMount;
+use nix::mount::MsFlags;
+use rustjail::errors::*;
+use slog::Logger;
+use std::env;
+use std::fs;
+use std::io;
+use std::path::{Component, Path};
+
+const LOCALE_CONF_PATH: &str = "/etc/locale.conf";
+
+// install_provisioned_file installs a file copied by the runtime at its guest
+// path. The file is bind mounted over the existing one when the guest root
+// filesystem is read-only, the copy being kept as the mount source.
+pub fn install_provisioned_file(logger: &Logger, staged: &Path, target: &Path) -> Result<()> {
+    if !target.is_absolute() || target.components().any(|c| c == Component::ParentDir) {
+        return Err(
+            ErrorKind::ErrorCode(format!("invalid provisioned file path {:?}", target)).into(),
+        );
+    }
+
+    let err = match copy_file(staged, target) {
+        Ok(()) => {
+            info!(logger, "installed provisioned file"; "path" => target.display().to_string());
+            return Ok(());
+        }
+        Err(e) => e,
+    };
+
+    if !target.exists() {
+        return Err(err.into());
+    }
+
+    let src = staged.to_str().unwrap();
+    let dst = target.to_str().unwrap();
+    BareMount::new(src, dst, "bind", MsFlags::MS_BIND, "", logger).mount()?;
+
+    info!(logger, "mounted provisioned file";
+        "path" => dst,
+        "copy-error" => format!("{}", err));
+
+    Ok(())
+}
+
+fn copy_file(staged: &Path, target: &Path) -> io::Result<()> {
+    if let Some(parent) = target.parent() {
+        fs::create_dir_all(parent)?;
+    }
+
+    // Replace the symlinks, such as /etc/localtime, rather than the file
+    // they point to.
+    if let Ok(m) = fs::symlink_metadata(target) {
+        if m.file_type().is_symlink() {
+            fs::remove_file(target)?;
+        }
+    }
+
+    fs::copy(staged, target)?;
+
+    Ok(())
+}
+
+// setup_locale applies the guest locale passed on the kernel command line to
+// the agent, inherited by the processes it starts, and to the guest
+// configuration when writable.
+pub fn setup_locale(logger: &Logger, config: &agentConfig) {
+    if config.locale.is_empty() {
+        return;
+    }
+
+    env::set_var("LANG", &config.locale);
+
+    if let Err(e) = fs::write(LOCALE_CONF_PATH, format!("LANG={}\n", config.locale)) {
+        warn!(logger, "failed to write the guest locale configuration";
+            "path" => LOCALE_CONF_PATH,
+            "error" => format!("{}", e));
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use tempfile::tempdir;
+
+    #[test]
+    fn test_install_provisioned_file() {
+        let logger = slog::Logger::root(slog::Discard, o!());
+        let dir = tempdir().unwrap();
+
+        let staged = dir.path().join("staged");
+        fs::write(&staged, "bundle").unwrap();
+
+        let target = dir.path().join("etc/ssl/certs/ca-certificates.crt");
+        install_provisioned_file(&logger, &staged, &target).unwrap();
+        assert_eq!(fs::read_to_string(&target).unwrap(), "bundle");
+
+        // Symlinks are replaced
+        let link = dir.path().join("localtime");
+        std::os::unix::fs::symlink(dir.path().join("UTC"), &link).unwrap();
+        install_provisioned_file(&logger, &staged, &link).unwrap();
+        assert!(!fs::symlink_metadata(&link)
+            .unwrap()
+            .file_type()
+            .is_symlink());
+        assert!(!dir.path().join("UTC").exists());
+
+        let invalid = dir.path().join("etc/../../escape");
+        assert!(install_provisioned_file(&logger, &staged, &invalid).is_err());
+        let relative = Path::new("etc/localtime");
+        assert!(install_provisioned_file(&logger, &staged, &relative).is_err());
+    }
+}
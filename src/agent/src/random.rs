@@ -16,9 +16,9 @@ pub const RNDRESEEDRNG: libc::c_int = 0x5207;
 
 // Handle the differing ioctl(2) request types for different targets
 #[cfg(target_env = "musl")]
-type IoctlRequestType = libc::c_int;
+pub type IoctlRequestType = libc::c_int;
 #[cfg(target_env = "gnu")]
-type IoctlRequestType = libc::c_ulong;
+pub type IoctlRequestType = libc::c_ulong;
 
 pub fn reseed_rng(data: &[u8]) -> Result<()> {
     let len = data.len() as libc::c_long;
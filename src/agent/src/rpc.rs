@@ -9,9 +9,9 @@ use ttrpc;
 use oci::{LinuxNamespace, Spec};
 use protobuf::{RepeatedField, SingularPtrField};
 use protocols::agent::{
-    AgentDetails, CopyFileRequest, GuestDetailsResponse, Interfaces, ListProcessesResponse,
-    Metrics, ReadStreamResponse, Routes, StatsContainerResponse, WaitProcessResponse,
-    WriteStreamResponse,
+    AgentDetails, CopyFileRequest, GetTDReportResponse, GuestDetailsResponse, Interfaces,
+    ListProcessesResponse, Metrics, ReadFileRequest, ReadFileResponse, ReadStreamResponse, Routes,
+    StatsContainerResponse, WaitProcessResponse, WriteStreamResponse,
 };
 use protocols::empty::Empty;
 use protocols::health::{
@@ -25,18 +25,28 @@ use rustjail::process::Process;
 use rustjail::specconv::CreateOpts;
 
 use nix::errno::Errno;
+use nix::fcntl::{self, OFlag};
 use nix::sys::signal::Signal;
-use nix::sys::stat;
+use nix::sys::stat::{self, Mode};
 use nix::unistd::{self, Pid};
 use rustjail::process::ProcessOperations;
 
-use crate::device::{add_devices, rescan_pci_bus, update_device_cgroup};
+use crate::checkpoint;
+use crate::core_dump;
+use crate::device::{add_devices, release_device, rescan_pci_bus, update_device_cgroup};
+use crate::dhcp;
+use crate::idle_memory;
+use crate::kdump;
 use crate::linux_abi::*;
+use crate::livepatch;
 use crate::metrics::get_metrics;
 use crate::mount::{add_storages, remove_mounts, STORAGEHANDLERLIST};
 use crate::namespace::{NSTYPEIPC, NSTYPEPID, NSTYPEUTS};
+use crate::profiling;
+use crate::provisioning::install_provisioned_file;
 use crate::random;
-use crate::sandbox::Sandbox;
+use crate::sandbox::{online_cpu_memory, Sandbox};
+use crate::tdx;
 use crate::version::{AGENT_VERSION, API_VERSION};
 use crate::AGENT_CONFIG;
 use netlink::{RtnlHandle, NETLINK_ROUTE};
@@ -44,23 +54,34 @@ use netlink::{RtnlHandle, NETLINK_ROUTE};
 use libc::{self, c_ushort, pid_t, winsize, TIOCSWINSZ};
 use serde_json;
 use std::convert::TryFrom;
+use std::ffi::OsString;
 use std::fs;
-use std::os::unix::io::RawFd;
+use std::os::unix::io::{AsRawFd, FromRawFd, RawFd};
 use std::os::unix::prelude::PermissionsExt;
 use std::process::{Command, Stdio};
 use std::sync::mpsc;
 use std::thread;
-use std::time::Duration;
+use std::time::{Duration, Instant};
 
 use nix::unistd::{Gid, Uid};
-use std::fs::{File, OpenOptions};
+use std::fs::File;
 use std::io::{BufRead, BufReader};
-use std::os::unix::fs::FileExt;
-use std::path::PathBuf;
+use std::os::unix::fs::{FileExt, MetadataExt};
+use std::path::{Component, Path, PathBuf};
 
 const CONTAINER_BASE: &str = "/run/kata-containers";
+// Maximum size of the data of a ReadFile response, the runtime reading the
+// large files by parts.
+const READ_FILE_MAX_SIZE: i64 = 1024 * 1024;
 const MODPROBE_PATH: &str = "/sbin/modprobe";
 
+// Delay between replying to SuspendGuest and suspending the guest, for
+// the reply to reach the runtime before the vCPUs stop.
+const SUSPEND_GUEST_DELAY: Duration = Duration::from_millis(100);
+
+// Interval between two lookups of the link of a hotplugged network device.
+const LINK_POLL_INTERVAL: Duration = Duration::from_millis(100);
+
 // Convenience macro to obtain the scope logger
 macro_rules! sl {
     () => {
@@ -141,6 +162,7 @@ impl agentService {
             spec: Some(oci.clone()),
             rootless_euid: false,
             rootless_cgroup: false,
+            init_reaper: AGENT_CONFIG.read().unwrap().container_reaper,
         };
 
         let mut ctr: LinuxContainer =
@@ -163,6 +185,8 @@ impl agentService {
 
         ctr.start(p)?;
 
+        core_dump::register_container(&cid, &oci.annotations, ctr.init_process_pid)?;
+
         s.update_shared_pidns(&ctr)?;
         s.add_container(ctr);
         info!(sl!(), "created container!");
@@ -224,6 +248,7 @@ impl agentService {
 
             sandbox.container_mounts.remove(cid.as_str());
             sandbox.containers.remove(cid.as_str());
+            core_dump::unregister_container(&cid);
 
             return Ok(());
         }
@@ -279,6 +304,7 @@ impl agentService {
 
         sandbox.container_mounts.remove(&cid);
         sandbox.containers.remove(cid.as_str());
+        core_dump::unregister_container(&cid);
 
         Ok(())
     }
@@ -747,7 +773,7 @@ impl protocols::agent_ttrpc::AgentService for agentService {
             Some(cr) => cr,
             None => {
                 return Err(ttrpc::Error::RpcStatus(ttrpc::get_status(
-                    ttrpc::Code::INTERNAL,
+                    ttrpc::Code::NOT_FOUND,
                     "invalid container id".to_string(),
                 )));
             }
@@ -758,7 +784,20 @@ impl protocols::agent_ttrpc::AgentService for agentService {
                 ttrpc::Code::INTERNAL,
                 e.to_string(),
             ))),
-            Ok(resp) => Ok(resp),
+            Ok(mut resp) => {
+                // The idle memory of the container, as of the last scan.
+                let memory_path = ctr
+                    .cgroup_manager
+                    .as_ref()
+                    .and_then(|cm| cm.paths.get("memory"));
+                if let (Some(path), Some(stats)) = (memory_path, resp.cgroup_stats.as_mut()) {
+                    stats
+                        .mut_memory_stats()
+                        .set_idle(idle_memory::cgroup_idle_bytes(path));
+                }
+
+                Ok(resp)
+            }
         }
     }
 
@@ -948,6 +987,15 @@ impl protocols::agent_ttrpc::AgentService for agentService {
 
         let rtnl = sandbox.rtnl.as_mut().unwrap();
 
+        // The link of a hotplugged device, such as an SR-IOV virtual
+        // function, appears once the guest driver probed the device.
+        let hw_addr = interface.as_ref().unwrap().hwAddr.clone();
+        let hotplug_timeout = AGENT_CONFIG.read().unwrap().hotplug_timeout;
+        let start = Instant::now();
+        while rtnl.find_link_by_hwaddr(&hw_addr).is_err() && start.elapsed() < hotplug_timeout {
+            thread::sleep(LINK_POLL_INTERVAL);
+        }
+
         let iface = match rtnl.update_interface(interface.as_ref().unwrap()) {
             Ok(v) => v,
             Err(_) => {
@@ -958,6 +1006,14 @@ impl protocols::agent_ttrpc::AgentService for agentService {
             }
         };
 
+        let interfaces = AGENT_CONFIG.read().unwrap().dhcp_interfaces.clone();
+        if let Err(e) = dhcp::start_client(&sl!(), &interfaces, &iface.name) {
+            return Err(ttrpc::Error::RpcStatus(ttrpc::get_status(
+                ttrpc::Code::INTERNAL,
+                format!("start DHCP client: {}", e),
+            )));
+        }
+
         Ok(iface)
     }
     fn update_routes(
@@ -1174,9 +1230,19 @@ impl protocols::agent_ttrpc::AgentService for agentService {
         // sleep 5 seconds for debug
         // thread::sleep(Duration::new(5, 0));
         let s = Arc::clone(&self.sandbox);
-        let sandbox = s.lock().unwrap();
+        let logger = s.lock().unwrap().logger.clone();
+
+        // Waiting for the resources to come online must not block the
+        // other requests.
+        if let Err(e) = online_cpu_memory(&logger, &req) {
+            return Err(ttrpc::Error::RpcStatus(ttrpc::get_status(
+                ttrpc::Code::INTERNAL,
+                e.to_string(),
+            )));
+        }
 
-        if let Err(e) = sandbox.online_cpu_memory(&req) {
+        let sandbox = s.lock().unwrap();
+        if let Err(e) = sandbox.update_cpusets() {
             return Err(ttrpc::Error::RpcStatus(ttrpc::get_status(
                 ttrpc::Code::INTERNAL,
                 e.to_string(),
@@ -1270,6 +1336,137 @@ impl protocols::agent_ttrpc::AgentService for agentService {
         Ok(Empty::new())
     }
 
+    fn read_file(
+        &self,
+        _ctx: &ttrpc::TtrpcContext,
+        req: protocols::agent::ReadFileRequest,
+    ) -> ttrpc::Result<ReadFileResponse> {
+        match do_read_file(&req) {
+            Ok(resp) => Ok(resp),
+            Err(e) => Err(ttrpc::Error::RpcStatus(ttrpc::get_status(
+                ttrpc::Code::INTERNAL,
+                e.to_string(),
+            ))),
+        }
+    }
+
+    fn release_device(
+        &self,
+        _ctx: &ttrpc::TtrpcContext,
+        req: protocols::agent::ReleaseDeviceRequest,
+    ) -> ttrpc::Result<Empty> {
+        if let Err(e) = release_device(req.get_device(), &self.sandbox) {
+            return Err(ttrpc::Error::RpcStatus(ttrpc::get_status(
+                ttrpc::Code::INTERNAL,
+                e.to_string(),
+            )));
+        }
+
+        Ok(Empty::new())
+    }
+
+    fn install_file(
+        &self,
+        _ctx: &ttrpc::TtrpcContext,
+        req: protocols::agent::InstallFileRequest,
+    ) -> ttrpc::Result<Empty> {
+        if let Err(e) = do_install_file(&req) {
+            return Err(ttrpc::Error::RpcStatus(ttrpc::get_status(
+                ttrpc::Code::INTERNAL,
+                e.to_string(),
+            )));
+        }
+
+        Ok(Empty::new())
+    }
+
+    fn load_crash_kernel(
+        &self,
+        _ctx: &ttrpc::TtrpcContext,
+        req: protocols::agent::LoadCrashKernelRequest,
+    ) -> ttrpc::Result<Empty> {
+        if let Err(e) = do_load_crash_kernel(&req) {
+            return Err(ttrpc::Error::RpcStatus(ttrpc::get_status(
+                ttrpc::Code::INTERNAL,
+                e.to_string(),
+            )));
+        }
+
+        Ok(Empty::new())
+    }
+
+    fn start_profiling(
+        &self,
+        _ctx: &ttrpc::TtrpcContext,
+        req: protocols::agent::StartProfilingRequest,
+    ) -> ttrpc::Result<Empty> {
+        let tools = AGENT_CONFIG.read().unwrap().profiling_tools.clone();
+        if let Err(e) = profiling::start_session(&sl!(), &tools, &req) {
+            return Err(ttrpc::Error::RpcStatus(ttrpc::get_status(
+                ttrpc::Code::INTERNAL,
+                e.to_string(),
+            )));
+        }
+
+        Ok(Empty::new())
+    }
+
+    fn load_livepatch(
+        &self,
+        _ctx: &ttrpc::TtrpcContext,
+        req: protocols::agent::LoadLivepatchRequest,
+    ) -> ttrpc::Result<Empty> {
+        if let Err(e) = do_load_livepatch(&req) {
+            return Err(ttrpc::Error::RpcStatus(ttrpc::get_status(
+                ttrpc::Code::INTERNAL,
+                e.to_string(),
+            )));
+        }
+
+        Ok(Empty::new())
+    }
+
+    fn checkpoint_container(
+        &self,
+        _ctx: &ttrpc::TtrpcContext,
+        req: protocols::agent::CheckpointContainerRequest,
+    ) -> ttrpc::Result<Empty> {
+        if let Err(e) = checkpoint::checkpoint_container(&sl!(), &self.sandbox, &req) {
+            return Err(ttrpc::Error::RpcStatus(ttrpc::get_status(
+                ttrpc::Code::INTERNAL,
+                e.to_string(),
+            )));
+        }
+
+        Ok(Empty::new())
+    }
+
+    fn restore_container(
+        &self,
+        _ctx: &ttrpc::TtrpcContext,
+        req: protocols::agent::RestoreContainerRequest,
+    ) -> ttrpc::Result<Empty> {
+        if let Err(e) = checkpoint::restore_container(&sl!(), &self.sandbox, &req) {
+            return Err(ttrpc::Error::RpcStatus(ttrpc::get_status(
+                ttrpc::Code::INTERNAL,
+                e.to_string(),
+            )));
+        }
+
+        Ok(Empty::new())
+    }
+
+    fn suspend_guest(&self, _ctx: &ttrpc::TtrpcContext, _req: Empty) -> ttrpc::Result<Empty> {
+        if let Err(e) = do_suspend_guest() {
+            return Err(ttrpc::Error::RpcStatus(ttrpc::get_status(
+                ttrpc::Code::INTERNAL,
+                e.to_string(),
+            )));
+        }
+
+        Ok(Empty::new())
+    }
+
     fn get_metrics(
         &self,
         _ctx: &ttrpc::TtrpcContext,
@@ -1287,6 +1484,24 @@ impl protocols::agent_ttrpc::AgentService for agentService {
             }
         }
     }
+
+    fn get_td_report(
+        &self,
+        _ctx: &ttrpc::TtrpcContext,
+        req: protocols::agent::GetTDReportRequest,
+    ) -> ttrpc::Result<GetTDReportResponse> {
+        match tdx::get_td_report(req.get_report_data()) {
+            Err(e) => Err(ttrpc::Error::RpcStatus(ttrpc::get_status(
+                ttrpc::Code::INTERNAL,
+                e.to_string(),
+            ))),
+            Ok(report) => {
+                let mut resp = GetTDReportResponse::new();
+                resp.set_report(report);
+                Ok(resp)
+            }
+        }
+    }
 }
 
 #[derive(Clone)]
@@ -1589,57 +1804,222 @@ fn do_set_guest_date_time(sec: i64, usec: i64) -> Result<()> {
     Ok(())
 }
 
-fn do_copy_file(req: &CopyFileRequest) -> Result<()> {
-    let path = PathBuf::from(req.path.as_str());
+// open_parent_dir opens the parent directory of the file path, and returns
+// it with the name of the file. The path is below CONTAINER_BASE or, when
+// root is set, relative to root, a container rootfs below CONTAINER_BASE.
+// The path is walked down without following symlinks, the container
+// processes owning the rootfs and the symlinks in it, which could point out
+// of the rootfs. The missing directories are created with dir_mode when it
+// is set.
+fn open_parent_dir(root: &str, path: &str, dir_mode: Option<u32>) -> Result<(File, OsString)> {
+    let (base, path) = if root.is_empty() {
+        let rel = Path::new(path)
+            .strip_prefix(CONTAINER_BASE)
+            .map_err(|_| nix::Error::Sys(Errno::EINVAL))?;
+        (PathBuf::from(CONTAINER_BASE), rel.to_path_buf())
+    } else {
+        (copied_file_path(root)?, PathBuf::from(path))
+    };
 
-    if !path.starts_with(CONTAINER_BASE) {
-        return Err(nix::Error::Sys(Errno::EINVAL).into());
+    let mut names = Vec::new();
+    for c in path.components() {
+        match c {
+            Component::RootDir | Component::CurDir => {}
+            Component::Normal(name) => names.push(name.to_os_string()),
+            _ => return Err(nix::Error::Sys(Errno::EINVAL).into()),
+        }
     }
 
-    let parent = path.parent();
-
-    let dir = if parent.is_some() {
-        parent.unwrap().to_path_buf()
-    } else {
-        PathBuf::from("/")
+    let name = match names.pop() {
+        Some(name) => name,
+        None => return Err(nix::Error::Sys(Errno::EINVAL).into()),
     };
 
-    if let Err(e) = fs::create_dir_all(dir.to_str().unwrap()) {
-        if e.kind() != std::io::ErrorKind::AlreadyExists {
-            return Err(e.into());
-        }
+    let flags = OFlag::O_RDONLY | OFlag::O_DIRECTORY | OFlag::O_CLOEXEC;
+    let fd = fcntl::open(&base, flags, Mode::empty())?;
+    let mut dir = unsafe { File::from_raw_fd(fd) };
+
+    for n in names {
+        let fd = match fcntl::openat(
+            dir.as_raw_fd(),
+            n.as_os_str(),
+            flags | OFlag::O_NOFOLLOW,
+            Mode::empty(),
+        ) {
+            Err(nix::Error::Sys(Errno::ENOENT)) if dir_mode.is_some() => {
+                let mode = Mode::from_bits_truncate(dir_mode.unwrap());
+                stat::mkdirat(dir.as_raw_fd(), n.as_os_str(), mode)?;
+
+                let fd = fcntl::openat(
+                    dir.as_raw_fd(),
+                    n.as_os_str(),
+                    flags | OFlag::O_NOFOLLOW,
+                    Mode::empty(),
+                )?;
+                // mkdirat applies the umask
+                if let Err(e) = stat::fchmod(fd, mode) {
+                    let _ = unistd::close(fd);
+                    return Err(e.into());
+                }
+                fd
+            }
+            r => r?,
+        };
+        dir = unsafe { File::from_raw_fd(fd) };
     }
 
-    std::fs::set_permissions(
-        dir.to_str().unwrap(),
-        std::fs::Permissions::from_mode(req.dir_mode),
-    )?;
+    Ok((dir, name))
+}
+
+fn do_copy_file(req: &CopyFileRequest) -> Result<()> {
+    let (dir, name) = open_parent_dir(req.get_root(), req.get_path(), Some(req.dir_mode))?;
 
-    let mut tmpfile = path.clone();
-    tmpfile.set_extension("tmp");
+    // The directories of a container rootfs are left as they are.
+    if req.get_root().is_empty() {
+        stat::fchmod(dir.as_raw_fd(), Mode::from_bits_truncate(req.dir_mode))?;
+    }
 
-    let file = OpenOptions::new()
-        .write(true)
-        .create(true)
-        .truncate(false)
-        .open(tmpfile.to_str().unwrap())?;
+    let mut tmpname = name.clone();
+    tmpname.push(".tmp");
+
+    let fd = fcntl::openat(
+        dir.as_raw_fd(),
+        tmpname.as_os_str(),
+        OFlag::O_WRONLY | OFlag::O_CREAT | OFlag::O_NOFOLLOW | OFlag::O_CLOEXEC,
+        Mode::from_bits_truncate(0o644),
+    )?;
+    let file = unsafe { File::from_raw_fd(fd) };
 
     file.write_all_at(req.data.as_slice(), req.offset as u64)?;
-    let st = stat::stat(tmpfile.to_str().unwrap())?;
 
-    if st.st_size != req.file_size {
+    if file.metadata()?.len() as i64 != req.file_size {
         return Ok(());
     }
 
     file.set_permissions(std::fs::Permissions::from_mode(req.file_mode))?;
 
-    unistd::chown(
-        tmpfile.to_str().unwrap(),
+    unistd::fchownat(
+        Some(dir.as_raw_fd()),
+        tmpname.as_os_str(),
         Some(Uid::from_raw(req.uid as u32)),
         Some(Gid::from_raw(req.gid as u32)),
+        unistd::FchownatFlags::NoFollowSymlink,
     )?;
 
-    fs::rename(tmpfile, path)?;
+    fcntl::renameat(
+        Some(dir.as_raw_fd()),
+        tmpname.as_os_str(),
+        Some(dir.as_raw_fd()),
+        name.as_os_str(),
+    )?;
+
+    Ok(())
+}
+
+// copied_file_path returns the path of a file copied by the runtime with
+// CopyFile, which it passes to the requests using the file.
+fn copied_file_path(path: &str) -> Result<PathBuf> {
+    let path = PathBuf::from(path);
+
+    if !path.starts_with(CONTAINER_BASE) || path.components().any(|c| c == Component::ParentDir) {
+        return Err(nix::Error::Sys(Errno::EINVAL).into());
+    }
+
+    Ok(path)
+}
+
+fn do_install_file(req: &protocols::agent::InstallFileRequest) -> Result<()> {
+    let source = copied_file_path(req.get_source())?;
+
+    install_provisioned_file(&sl!(), &source, Path::new(req.get_path()))
+}
+
+fn do_load_crash_kernel(req: &protocols::agent::LoadCrashKernelRequest) -> Result<()> {
+    let kernel = copied_file_path(req.get_kernel())?;
+    let initrd = if req.get_initrd().is_empty() {
+        None
+    } else {
+        Some(copied_file_path(req.get_initrd())?)
+    };
+
+    let redact = AGENT_CONFIG.read().unwrap().kdump_redact.clone();
+    kdump::load_crash_kernel(
+        &sl!(),
+        &redact,
+        &kernel,
+        initrd.as_ref().map(|p| p.as_path()),
+    )
+}
+
+fn do_load_livepatch(req: &protocols::agent::LoadLivepatchRequest) -> Result<()> {
+    let path = copied_file_path(req.get_path())?;
+
+    let allowed = AGENT_CONFIG.read().unwrap().livepatch_modules.clone();
+    livepatch::load_module(&sl!(), &allowed, &path, req.get_module())
+}
+
+// do_read_file reads a part of a regular file below CONTAINER_BASE, or of a
+// container rootfs, at most length bytes of it at offset, and returns it
+// with the attributes of the file.
+fn do_read_file(req: &ReadFileRequest) -> Result<ReadFileResponse> {
+    if req.offset < 0 || req.length < 0 {
+        return Err(nix::Error::Sys(Errno::EINVAL).into());
+    }
+
+    let (dir, name) = open_parent_dir(req.get_root(), req.get_path(), None)?;
+
+    // A fifo is not waited for
+    let fd = fcntl::openat(
+        dir.as_raw_fd(),
+        name.as_os_str(),
+        OFlag::O_RDONLY | OFlag::O_NOFOLLOW | OFlag::O_NONBLOCK | OFlag::O_CLOEXEC,
+        Mode::empty(),
+    )?;
+    let file = unsafe { File::from_raw_fd(fd) };
+
+    let metadata = file.metadata()?;
+    if !metadata.is_file() {
+        return Err(nix::Error::Sys(Errno::EINVAL).into());
+    }
+
+    let size = metadata.len() as i64;
+    let offset = req.offset.min(size);
+    let count = req.length.min(READ_FILE_MAX_SIZE).min(size - offset);
+
+    let mut data = vec![0; count as usize];
+    let n = file.read_at(&mut data, offset as u64)?;
+    data.truncate(n);
+
+    let mut resp = ReadFileResponse::new();
+    resp.set_file_size(size);
+    resp.set_file_mode(metadata.mode());
+    resp.set_uid(metadata.uid() as i32);
+    resp.set_gid(metadata.gid() as i32);
+    resp.set_offset(offset);
+    resp.set_data(data);
+
+    Ok(resp)
+}
+
+fn do_suspend_guest() -> Result<()> {
+    let states = fs::read_to_string(SYSFS_POWER_STATE_PATH)?;
+    if !states.split_whitespace().any(|s| s == "mem") {
+        return Err(ErrorKind::Nix(nix::Error::from_errno(Errno::EOPNOTSUPP)).into());
+    }
+
+    // The write to the power state returns once the guest is woken up
+    // by the hypervisor.
+    thread::spawn(move || {
+        thread::sleep(SUSPEND_GUEST_DELAY);
+        unistd::sync();
+
+        info!(sl!(), "suspending the guest to RAM");
+        if let Err(e) = fs::write(SYSFS_POWER_STATE_PATH, "mem") {
+            error!(sl!(), "failed to suspend the guest: {:?}", e);
+            return;
+        }
+        info!(sl!(), "guest resumed from RAM");
+    });
 
     Ok(())
 }
@@ -1668,7 +2048,7 @@ fn setup_bundle(spec: &Spec) -> Result<PathBuf> {
     Ok(olddir)
 }
 
-fn load_kernel_module(module: &protocols::agent::KernelModule) -> Result<()> {
+pub fn load_kernel_module(module: &protocols::agent::KernelModule) -> Result<()> {
     if module.name == "" {
         return Err(ErrorKind::ErrorCode("Kernel module name is empty".to_string()).into());
     }
@@ -1734,4 +2114,29 @@ mod tests {
         let result = load_kernel_module(&m);
         assert!(result.is_ok(), "load module should success");
     }
+
+    #[test]
+    fn test_do_read_file() {
+        let mut req = ReadFileRequest::new();
+
+        // case 1: path outside of the container base
+        req.path = "/etc/hostname".to_string();
+        req.length = READ_FILE_MAX_SIZE;
+        assert!(do_read_file(&req).is_err(), "read file should fail");
+
+        // case 2: path going up the container base
+        req.path = format!("{}/../../etc/hostname", CONTAINER_BASE);
+        assert!(do_read_file(&req).is_err(), "read file should fail");
+
+        // case 3: negative offset
+        req.path = format!("{}/file", CONTAINER_BASE);
+        req.offset = -1;
+        assert!(do_read_file(&req).is_err(), "read file should fail");
+
+        // case 4: path going up the container rootfs
+        req.root = format!("{}/shared/containers/ctr/rootfs", CONTAINER_BASE);
+        req.path = "../../etc/hostname".to_string();
+        req.offset = 0;
+        assert!(do_read_file(&req).is_err(), "read file should fail");
+    }
 }
@@ -8,6 +8,7 @@ use crate::linux_abi::*;
 use crate::mount::{get_mount_fs_type, remove_mounts, TYPEROOTFS};
 use crate::namespace::Namespace;
 use crate::namespace::NSTYPEPID;
+use crate::net_queues::tune_network_queues;
 use crate::network::Network;
 use libc::pid_t;
 use netlink::{RtnlHandle, NETLINK_ROUTE};
@@ -23,6 +24,13 @@ use slog::Logger;
 use std::collections::HashMap;
 use std::fs;
 use std::sync::mpsc::Sender;
+use std::thread;
+use std::time::Duration;
+
+// Number of sysfs scans done, and delay between them, while waiting for
+// hotplugged CPUs and memory to come online in the guest.
+const ONLINE_MAX_TRIES: u32 = 50;
+const ONLINE_RETRY_DELAY: Duration = Duration::from_millis(100);
 
 #[derive(Debug)]
 pub struct Sandbox {
@@ -42,6 +50,8 @@ pub struct Sandbox {
     pub no_pivot_root: bool,
     pub sender: Option<Sender<i32>>,
     pub rtnl: Option<RtnlHandle>,
+    // key of the encrypted scratch devices, generated on first use
+    pub scratch_key: Vec<u8>,
 }
 
 impl Sandbox {
@@ -66,6 +76,7 @@ impl Sandbox {
             no_pivot_root: fs_type.eq(TYPEROOTFS),
             sender: None,
             rtnl: Some(RtnlHandle::new(NETLINK_ROUTE, 0).unwrap()),
+            scratch_key: Vec::new(),
         })
     }
 
@@ -238,17 +249,9 @@ impl Sandbox {
         Ok(())
     }
 
-    pub fn online_cpu_memory(&self, req: &OnlineCPUMemRequest) -> Result<()> {
-        if req.nb_cpus > 0 {
-            // online cpus
-            online_cpus(&self.logger, req.nb_cpus as i32)?;
-        }
-
-        if !req.cpu_only {
-            // online memory
-            online_memory(&self.logger)?;
-        }
-
+    // update_cpusets has the containers run on the CPUs online in the
+    // guest, once CPUs were hotplugged.
+    pub fn update_cpusets(&self) -> Result<()> {
         let cpuset = cgroups::fs::get_guest_cpuset()?;
 
         for (_, ctr) in self.containers.iter() {
@@ -263,6 +266,36 @@ impl Sandbox {
     }
 }
 
+// online_cpu_memory onlines the CPUs and memory hotplugged in the guest.
+// It does not use the sandbox, so that waiting for the resources to come
+// online is done without holding the sandbox lock.
+pub fn online_cpu_memory(logger: &Logger, req: &OnlineCPUMemRequest) -> Result<()> {
+    if req.nb_cpus > 0 {
+        // online cpus
+        if req.wait {
+            wait_online_cpus(logger, req.nb_cpus as i32)?;
+        } else {
+            online_cpus(logger, req.nb_cpus as i32)?;
+        }
+
+        // The network devices keep the queues they had at boot.
+        if let Err(e) = tune_network_queues(logger) {
+            warn!(logger, "failed to tune network queues: {}", e);
+        }
+    }
+
+    if !req.cpu_only {
+        // online memory
+        if req.wait {
+            wait_online_memory(logger)?;
+        } else {
+            online_memory(logger)?;
+        }
+    }
+
+    Ok(())
+}
+
 fn online_resources(logger: &Logger, path: &str, pattern: &str, num: i32) -> Result<i32> {
     let mut count = 0;
     let re = Regex::new(pattern)?;
@@ -300,15 +333,119 @@ fn online_cpus(logger: &Logger, num: i32) -> Result<i32> {
     online_resources(logger, SYSFS_CPU_ONLINE_PATH, r"cpu[0-9]+", num)
 }
 
+// wait_online_cpus onlines the num CPUs hotplugged, rescanning sysfs until
+// the online set of the guest grew by num CPUs or the retries are
+// exhausted, as hotplugged CPUs may show up in the guest some time after
+// the hypervisor added them. The online set is counted, rather than the
+// CPUs onlined here, as the guest may online hotplugged CPUs on its own.
+fn wait_online_cpus(logger: &Logger, num: i32) -> Result<i32> {
+    let before = online_cpu_count()?;
+    let mut onlined = 0;
+
+    for _ in 0..ONLINE_MAX_TRIES {
+        online_cpus(logger, -1)?;
+
+        onlined = online_cpu_count()? - before;
+        if onlined >= num {
+            return Ok(onlined);
+        }
+
+        thread::sleep(ONLINE_RETRY_DELAY);
+    }
+
+    Err(ErrorKind::ErrorCode(format!(
+        "only {} of {} hotplugged CPUs came online",
+        onlined, num
+    ))
+    .into())
+}
+
+// online_cpu_count returns the number of CPUs in the online set of the
+// guest.
+fn online_cpu_count() -> Result<i32> {
+    let list = fs::read_to_string(SYSFS_CPU_ONLINE_LIST_PATH)?;
+    cpu_list_count(list.trim())
+}
+
+// cpu_list_count returns the number of CPUs of a kernel CPU list, such as
+// "0-3,6".
+fn cpu_list_count(list: &str) -> Result<i32> {
+    let mut count = 0;
+
+    for range in list.split(',').filter(|r| !r.is_empty()) {
+        let bounds: Vec<&str> = range.splitn(2, '-').collect();
+        let first = bounds[0].parse::<i32>()?;
+        let last = match bounds.get(1) {
+            Some(last) => last.parse::<i32>()?,
+            None => first,
+        };
+
+        if last < first {
+            return Err(ErrorKind::ErrorCode(format!("invalid CPU list {}", list)).into());
+        }
+
+        count += last - first + 1;
+    }
+
+    Ok(count)
+}
+
 fn online_memory(logger: &Logger) -> Result<()> {
     online_resources(logger, SYSFS_MEMORY_ONLINE_PATH, r"memory[0-9]+", -1)?;
     Ok(())
 }
 
+// wait_online_memory onlines the memory blocks hotplugged, rescanning sysfs
+// until no block of the guest is offline or the retries are exhausted, as
+// onlining a block fails while the guest is still adding it.
+fn wait_online_memory(logger: &Logger) -> Result<()> {
+    let mut offline = 0;
+
+    for _ in 0..ONLINE_MAX_TRIES {
+        if let Err(e) = online_memory(logger) {
+            warn!(logger, "failed to online memory: {}", e);
+        }
+
+        offline = offline_memory_blocks()?;
+        if offline == 0 {
+            return Ok(());
+        }
+
+        thread::sleep(ONLINE_RETRY_DELAY);
+    }
+
+    Err(ErrorKind::ErrorCode(format!(
+        "{} hotplugged memory blocks did not come online",
+        offline
+    ))
+    .into())
+}
+
+// offline_memory_blocks returns the number of memory blocks of the guest
+// which are offline.
+fn offline_memory_blocks() -> Result<i32> {
+    let re = Regex::new(r"memory[0-9]+")?;
+    let mut count = 0;
+
+    for e in fs::read_dir(SYSFS_MEMORY_ONLINE_PATH)? {
+        let entry = e?;
+        if !re.is_match(&entry.file_name().to_string_lossy()) {
+            continue;
+        }
+
+        let online = fs::read_to_string(entry.path().join(SYSFS_ONLINE_FILE))?;
+        if online.trim() == "0" {
+            count += 1;
+        }
+    }
+
+    Ok(count)
+}
+
 #[cfg(test)]
 mod tests {
     //use rustjail::Error;
-    use super::Sandbox;
+    use super::{cpu_list_count, Sandbox};
     use crate::{mount::BareMount, skip_if_not_root};
     use nix::mount::MsFlags;
     use oci::{Linux, Root, Spec};
@@ -534,6 +671,7 @@ mod tests {
             spec: Some(spec),
             rootless_euid: false,
             rootless_cgroup: false,
+            init_reaper: false,
         }
     }
 
@@ -596,4 +734,14 @@ mod tests {
         let ns_path = format!("/proc/{}/ns/pid", test_pid);
         assert_eq!(s.sandbox_pidns.unwrap().path, ns_path);
     }
+
+    #[test]
+    fn test_cpu_list_count() {
+        assert_eq!(cpu_list_count("").unwrap(), 0);
+        assert_eq!(cpu_list_count("0").unwrap(), 1);
+        assert_eq!(cpu_list_count("0-3").unwrap(), 4);
+        assert_eq!(cpu_list_count("0-3,6,8-9").unwrap(), 7);
+        assert!(cpu_list_count("3-0").is_err());
+        assert!(cpu_list_count("a-b").is_err());
+    }
 }
@@ -0,0 +1,171 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Ephemeral encrypted scratch devices back the writable layer of the
+// containers asking for one. The runtime hotplugs a blank block device,
+// which the agent formats with LUKS and a key generated for the sandbox.
+// The key never leaves the guest memory, so that what the container writes
+// never reaches the host in cleartext, and cannot be read back once the
+// sandbox is gone.
+
+use rustjail::errors::*;
+use slog::Logger;
+use std::fs::File;
+use std::io::{Read, Write};
+use std::path::Path;
+use std::process::{Command, Stdio};
+use std::sync::{Arc, Mutex};
+
+use crate::sandbox::Sandbox;
+
+// ENCRYPTION_OPTION is the driver option of the storages of the scratch
+// devices.
+pub const ENCRYPTION_OPTION: &str = "encryption=ephemeral";
+
+// FSTYPE is the filesystem created on the opened scratch devices.
+pub const FSTYPE: &str = "ext4";
+
+const CRYPTSETUP_PATH: &str = "/sbin/cryptsetup";
+const MKFS_PATH: &str = "/sbin/mkfs.ext4";
+const MAPPER_DIR: &str = "/dev/mapper";
+const RANDOM_PATH: &str = "/dev/urandom";
+
+const KEY_SIZE: usize = 64;
+
+// is_encrypted returns true if the driver options of a storage ask for an
+// encrypted scratch device.
+pub fn is_encrypted(driver_options: &[String]) -> bool {
+    driver_options.iter().any(|o| o == ENCRYPTION_OPTION)
+}
+
+// mapper_name returns the name of the opened scratch device mounted on a
+// mount point.
+fn mapper_name(mount_point: &str) -> String {
+    format!("kata-{}", mount_point.trim_matches('/').replace('/', "-"))
+}
+
+// sandbox_key returns the key of the scratch devices of the sandbox,
+// generated on first use.
+fn sandbox_key(sandbox: &Arc<Mutex<Sandbox>>) -> Result<Vec<u8>> {
+    let mut sb = sandbox.lock().unwrap();
+
+    if sb.scratch_key.is_empty() {
+        let mut key = vec![0; KEY_SIZE];
+        File::open(RANDOM_PATH)?.read_exact(&mut key)?;
+        sb.scratch_key = key;
+    }
+
+    Ok(sb.scratch_key.clone())
+}
+
+// run runs a command, writing key to its standard input if any.
+fn run(path: &str, args: &[&str], key: Option<&[u8]>) -> Result<()> {
+    let mut child = Command::new(path)
+        .args(args)
+        .stdin(Stdio::piped())
+        .stdout(Stdio::null())
+        .stderr(Stdio::piped())
+        .spawn()?;
+
+    if let Some(key) = key {
+        // Dropped once written, for the command to read the end of the key
+        child.stdin.take().unwrap().write_all(key)?;
+    }
+
+    let output = child.wait_with_output()?;
+    if !output.status.success() {
+        return Err(ErrorKind::ErrorCode(format!(
+            "{} {} failed: {}",
+            path,
+            args[0],
+            String::from_utf8_lossy(&output.stderr).trim()
+        ))
+        .into());
+    }
+
+    Ok(())
+}
+
+// setup formats a scratch device with the sandbox key, opens it and
+// creates its filesystem, returning the path of the opened device.
+pub fn setup(
+    logger: &Logger,
+    device: &str,
+    mount_point: &str,
+    sandbox: &Arc<Mutex<Sandbox>>,
+) -> Result<String> {
+    let key = sandbox_key(sandbox)?;
+    let name = mapper_name(mount_point);
+
+    // The key is random, the slow key derivations only waste the guest
+    // memory and time.
+    run(
+        CRYPTSETUP_PATH,
+        &[
+            "luksFormat",
+            "--batch-mode",
+            "--type",
+            "luks2",
+            "--pbkdf",
+            "pbkdf2",
+            "--pbkdf-force-iterations",
+            "1000",
+            "--key-file",
+            "-",
+            device,
+        ],
+        Some(&key),
+    )?;
+    run(
+        CRYPTSETUP_PATH,
+        &["open", "--key-file", "-", device, &name],
+        Some(&key),
+    )?;
+
+    let path = format!("{}/{}", MAPPER_DIR, name);
+    if let Err(e) = run(MKFS_PATH, &["-q", "-F", &path], None) {
+        let _ = run(CRYPTSETUP_PATH, &["close", &name], None);
+        return Err(e);
+    }
+
+    info!(logger, "opened encrypted scratch device";
+        "device" => device,
+        "mapper" => &path,
+    );
+
+    Ok(path)
+}
+
+// close closes the scratch device mounted on a mount point, if any, once
+// unmounted.
+pub fn close(mount_point: &str) -> Result<()> {
+    let name = mapper_name(mount_point);
+    if !Path::new(MAPPER_DIR).join(&name).exists() {
+        return Ok(());
+    }
+
+    run(CRYPTSETUP_PATH, &["close", &name], None)
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_is_encrypted() {
+        assert!(is_encrypted(&[ENCRYPTION_OPTION.to_string()]));
+        assert!(!is_encrypted(&[]));
+        assert!(!is_encrypted(&["encryption=none".to_string()]));
+    }
+
+    #[test]
+    fn test_mapper_name() {
+        assert_eq!(
+            mapper_name("/run/kata-containers/sandbox/scratch/abc"),
+            "kata-run-kata-containers-sandbox-scratch-abc"
+        );
+        assert_eq!(mapper_name("/scratch/"), "kata-scratch");
+    }
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// The TD report of a TDX guest is requested from the TDX module by the
+// guest kernel, through its tdx_guest device. The report binds the report
+// data of the caller, typically a nonce of the verifier or the hash of a
+// key, to the measurements of the guest: the firmware, the kernel and its
+// command line measured at the direct boot.
+
+use libc;
+use nix::errno::Errno;
+use nix::fcntl::{self, OFlag};
+use nix::sys::stat::Mode;
+use nix::unistd;
+use rustjail::errors::*;
+
+use crate::random::IoctlRequestType;
+
+pub const TDX_GUEST_DEV: &str = "/dev/tdx_guest";
+
+pub const REPORT_DATA_SIZE: usize = 64;
+pub const TDREPORT_SIZE: usize = 1024;
+
+// TDX_CMD_GET_REPORT0 is _IOWR('T', 1, struct tdx_report_req)
+const TDX_CMD_GET_REPORT0: u32 = 0xc440_5401;
+
+#[repr(C)]
+struct TdxReportReq {
+    report_data: [u8; REPORT_DATA_SIZE],
+    td_report: [u8; TDREPORT_SIZE],
+}
+
+// get_td_report returns the TD report of the guest, the report data being
+// padded with zeroes.
+pub fn get_td_report(report_data: &[u8]) -> Result<Vec<u8>> {
+    if report_data.len() > REPORT_DATA_SIZE {
+        return Err(ErrorKind::ErrorCode(format!(
+            "report data longer than {} bytes",
+            REPORT_DATA_SIZE
+        ))
+        .into());
+    }
+
+    let mut req = TdxReportReq {
+        report_data: [0; REPORT_DATA_SIZE],
+        td_report: [0; TDREPORT_SIZE],
+    };
+    req.report_data[..report_data.len()].copy_from_slice(report_data);
+
+    let fd = fcntl::open(
+        TDX_GUEST_DEV,
+        OFlag::O_RDWR | OFlag::O_CLOEXEC,
+        Mode::empty(),
+    )?;
+
+    let ret = unsafe {
+        libc::ioctl(
+            fd,
+            TDX_CMD_GET_REPORT0 as IoctlRequestType,
+            &mut req as *mut TdxReportReq,
+        )
+    };
+    let _ = unistd::close(fd);
+    let _ = Errno::result(ret).map(drop)?;
+
+    Ok(req.td_report.to_vec())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_report_req_size() {
+        // The size is part of the ioctl request
+        assert_eq!(std::mem::size_of::<TdxReportReq>(), 0x440);
+        assert_eq!((TDX_CMD_GET_REPORT0 >> 16) & 0x3fff, 0x440);
+    }
+
+    #[test]
+    fn test_get_td_report_data_too_long() {
+        assert!(get_td_report(&[0; REPORT_DATA_SIZE + 1]).is_err());
+    }
+}
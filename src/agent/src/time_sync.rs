@@ -0,0 +1,170 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use crate::config::agentConfig;
+use rustjail::errors::*;
+use slog::Logger;
+use std::fs;
+use std::path::Path;
+use std::process::Command;
+
+const MODPROBE_PATH: &str = "/sbin/modprobe";
+const SYSTEMCTL_PATH: &str = "/bin/systemctl";
+
+// SYSTEMD_RUNTIME_DIR exists when systemd is the init of the guest.
+const SYSTEMD_RUNTIME_DIR: &str = "/run/systemd/system";
+const TIMESYNCD_DROPIN_DIR: &str = "/run/systemd/timesyncd.conf.d";
+const TIMESYNCD_DROPIN: &str = "kata-containers.conf";
+
+const CHRONY_RUN_DIR: &str = "/run/kata-containers/chrony";
+const CHRONYD_PATHS: &[&str] = &["/usr/sbin/chronyd", "/usr/bin/chronyd", "/sbin/chronyd"];
+
+// PTP_KVM_DEVICE is the clock of the host exposed by the ptp_kvm driver.
+const PTP_KVM_DEVICE: &str = "/dev/ptp0";
+
+// setup_time_sync configures the time sources of the guest passed on the
+// kernel command line: systemd-timesyncd when systemd is the init, chronyd
+// otherwise, started by the agent.
+pub fn setup_time_sync(logger: &Logger, config: &agentConfig) -> Result<()> {
+    if config.ntp_servers.is_empty() && config.ntp_pools.is_empty() && !config.ptp_kvm {
+        return Ok(());
+    }
+
+    let logger = logger.new(o!("subsystem" => "time-sync"));
+
+    if config.ptp_kvm {
+        let status = Command::new(MODPROBE_PATH).arg("ptp_kvm").status()?;
+        if !status.success() {
+            warn!(logger, "failed to load the ptp_kvm module"; "status" => format!("{}", status));
+        }
+    }
+
+    if Path::new(SYSTEMD_RUNTIME_DIR).exists() {
+        return setup_timesyncd(&logger, config);
+    }
+
+    setup_chronyd(&logger, config)
+}
+
+fn timesyncd_config(config: &agentConfig) -> String {
+    let mut servers = config.ntp_servers.clone();
+    servers.extend(config.ntp_pools.iter().cloned());
+
+    format!("[Time]\nNTP={}\n", servers.join(" "))
+}
+
+fn setup_timesyncd(logger: &Logger, config: &agentConfig) -> Result<()> {
+    if config.ptp_kvm {
+        warn!(
+            logger,
+            "systemd-timesyncd cannot use the ptp_kvm clock, ignored"
+        );
+    }
+
+    if config.ntp_servers.is_empty() && config.ntp_pools.is_empty() {
+        return Ok(());
+    }
+
+    fs::create_dir_all(TIMESYNCD_DROPIN_DIR)?;
+    fs::write(
+        Path::new(TIMESYNCD_DROPIN_DIR).join(TIMESYNCD_DROPIN),
+        timesyncd_config(config),
+    )?;
+
+    // Applies the drop-in if the service already started, no-op otherwise.
+    let status = Command::new(SYSTEMCTL_PATH)
+        .args(&["--no-block", "try-restart", "systemd-timesyncd.service"])
+        .status()?;
+    if !status.success() {
+        warn!(logger, "failed to restart systemd-timesyncd"; "status" => format!("{}", status));
+    }
+
+    info!(logger, "configured systemd-timesyncd";
+        "servers" => format!("{:?}", config.ntp_servers),
+        "pools" => format!("{:?}", config.ntp_pools));
+
+    Ok(())
+}
+
+fn chrony_config(config: &agentConfig) -> String {
+    let mut lines = Vec::new();
+
+    for server in config.ntp_servers.iter() {
+        lines.push(format!("server {} iburst", server));
+    }
+
+    for pool in config.ntp_pools.iter() {
+        lines.push(format!("pool {} iburst", pool));
+    }
+
+    if config.ptp_kvm {
+        lines.push(format!("refclock PHC {} poll 2", PTP_KVM_DEVICE));
+    }
+
+    // Step the clock whenever it is off by more than a second, as after
+    // a VM pause or a live migration.
+    lines.push("makestep 1.0 -1".to_string());
+    lines.push(format!("driftfile {}/drift", CHRONY_RUN_DIR));
+    lines.push(format!("pidfile {}/chronyd.pid", CHRONY_RUN_DIR));
+
+    lines.join("\n") + "\n"
+}
+
+fn setup_chronyd(logger: &Logger, config: &agentConfig) -> Result<()> {
+    let chronyd = match CHRONYD_PATHS.iter().find(|p| Path::new(p).exists()) {
+        Some(p) => p,
+        None => {
+            warn!(logger, "no chronyd in the guest, time sources ignored");
+            return Ok(());
+        }
+    };
+
+    fs::create_dir_all(CHRONY_RUN_DIR)?;
+    let conf = Path::new(CHRONY_RUN_DIR).join("chrony.conf");
+    fs::write(&conf, chrony_config(config))?;
+
+    // chronyd forks into the background once started.
+    let status = Command::new(chronyd).arg("-f").arg(&conf).status()?;
+    if !status.success() {
+        return Err(ErrorKind::ErrorCode(format!("failed to start chronyd: {}", status)).into());
+    }
+
+    info!(logger, "started chronyd";
+        "servers" => format!("{:?}", config.ntp_servers),
+        "pools" => format!("{:?}", config.ntp_pools),
+        "ptp-kvm" => config.ptp_kvm);
+
+    Ok(())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_timesyncd_config() {
+        let mut config = agentConfig::new();
+        config.ntp_servers = vec!["10.0.0.1".to_string()];
+        config.ntp_pools = vec!["pool.ntp.org".to_string()];
+
+        assert_eq!(
+            timesyncd_config(&config),
+            "[Time]\nNTP=10.0.0.1 pool.ntp.org\n"
+        );
+    }
+
+    #[test]
+    fn test_chrony_config() {
+        let mut config = agentConfig::new();
+        config.ntp_servers = vec!["10.0.0.1".to_string(), "10.0.0.2".to_string()];
+        config.ptp_kvm = true;
+
+        let conf = chrony_config(&config);
+        assert!(conf.contains("server 10.0.0.1 iburst\nserver 10.0.0.2 iburst\n"));
+        assert!(conf.contains("refclock PHC /dev/ptp0 poll 2\n"));
+        assert!(!conf.contains("pool "));
+        assert!(conf.contains("makestep 1.0 -1\n"));
+    }
+}
@@ -4,4 +4,4 @@
 //
 
 pub const AGENT_VERSION: &str = "1.4.5";
-pub const API_VERSION: &str = "0.0.1";
+pub const API_VERSION: &str = "0.1.0";
@@ -12,6 +12,7 @@ import (
 	"github.com/containerd/containerd/runtime/v2/shim"
 	containerdshim "github.com/kata-containers/kata-containers/src/runtime/containerd-shim-v2"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/types"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 )
 
 func shimConfig(config *shim.Config) {
@@ -20,6 +21,7 @@ func shimConfig(config *shim.Config) {
 }
 
 func main() {
+	vc.VMMIsolationInit()
 
 	if len(os.Args) == 2 && os.Args[1] == "--version" {
 		fmt.Printf("%s containerd shim: id: %q, version: %s, commit: %v\n", project, types.KataRuntimeName, version, commit)
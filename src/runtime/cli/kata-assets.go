@@ -0,0 +1,236 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/assetstore"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/oci"
+	"github.com/urfave/cli"
+)
+
+var assetsSubCmds = []cli.Command{
+	importAssetCommand,
+	fetchAssetCommand,
+	tagAssetCommand,
+	untagAssetCommand,
+	listAssetsCommand,
+	gcAssetsCommand,
+}
+
+var assetsCLICommand = cli.Command{
+	Name:        "assets",
+	Usage:       "manage the asset store of the guest kernels, initrds, images and firmwares",
+	Subcommands: assetsSubCmds,
+	Action: func(context *cli.Context) {
+		cli.ShowSubcommandHelp(context)
+	},
+}
+
+// runtimeConfigAssetStore returns the runtime configuration, which must
+// have an asset store.
+func runtimeConfigAssetStore(c *cli.Context) (oci.RuntimeConfig, error) {
+	runtimeConfig, ok := c.App.Metadata["runtimeConfig"].(oci.RuntimeConfig)
+	if !ok {
+		return runtimeConfig, errors.New("invalid runtime config")
+	}
+
+	if runtimeConfig.AssetStore == "" {
+		return runtimeConfig, errors.New("no asset store configured")
+	}
+
+	return runtimeConfig, nil
+}
+
+// assetStore returns the asset store of the runtime configuration.
+func assetStore(c *cli.Context) (*assetstore.Store, error) {
+	runtimeConfig, err := runtimeConfigAssetStore(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return assetstore.New(runtimeConfig.AssetStore)
+}
+
+var importAssetCommand = cli.Command{
+	Name:      "import",
+	Usage:     "store a new version of an asset and point a name to it",
+	ArgsUsage: "<name> <file>",
+	Action: func(c *cli.Context) error {
+		name, path := c.Args().Get(0), c.Args().Get(1)
+		if name == "" || path == "" {
+			return errors.New("missing name or file")
+		}
+
+		store, err := assetStore(c)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		digest, err := store.Import(name, f)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(defaultOutputFile, "%s %s\n", digest, store.RefPath(name))
+		return nil
+	},
+}
+
+var fetchAssetCommand = cli.Command{
+	Name:      "fetch",
+	Usage:     "fetch a remote asset, ahead of the first sandbox using it",
+	ArgsUsage: "<https://... | oci://...>",
+	Action: func(c *cli.Context) error {
+		ref := c.Args().First()
+		if !assetstore.IsRemote(ref) {
+			return fmt.Errorf("invalid remote asset %q", ref)
+		}
+
+		ctx, err := cliContextToContext(c)
+		if err != nil {
+			return err
+		}
+
+		runtimeConfig, err := runtimeConfigAssetStore(c)
+		if err != nil {
+			return err
+		}
+
+		store, err := assetstore.New(runtimeConfig.AssetStore)
+		if err != nil {
+			return err
+		}
+
+		var publicKey ed25519.PublicKey
+		if runtimeConfig.AssetSigningKey != "" {
+			if publicKey, err = assetstore.LoadPublicKey(runtimeConfig.AssetSigningKey); err != nil {
+				return err
+			}
+		}
+
+		path, err := assetstore.NewFetcher(store, publicKey).Fetch(ctx, ref)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(defaultOutputFile, path)
+		return nil
+	},
+}
+
+var tagAssetCommand = cli.Command{
+	Name:      "tag",
+	Usage:     "point a name to a stored version of an asset, to roll back to it",
+	ArgsUsage: "<name> <digest>",
+	Action: func(c *cli.Context) error {
+		name, digest := c.Args().Get(0), c.Args().Get(1)
+		if name == "" || digest == "" {
+			return errors.New("missing name or digest")
+		}
+
+		store, err := assetStore(c)
+		if err != nil {
+			return err
+		}
+
+		return store.Tag(name, digest)
+	},
+}
+
+var untagAssetCommand = cli.Command{
+	Name:      "untag",
+	Usage:     "remove a name",
+	ArgsUsage: "<name>",
+	Action: func(c *cli.Context) error {
+		name := c.Args().First()
+		if name == "" {
+			return errors.New("missing name")
+		}
+
+		store, err := assetStore(c)
+		if err != nil {
+			return err
+		}
+
+		return store.Untag(name)
+	},
+}
+
+var listAssetsCommand = cli.Command{
+	Name:  "list",
+	Usage: "list the names and the versions they point to",
+	Action: func(c *cli.Context) error {
+		store, err := assetStore(c)
+		if err != nil {
+			return err
+		}
+
+		refs, err := store.Refs()
+		if err != nil {
+			return err
+		}
+
+		var names []string
+		for name := range refs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		w := tabwriter.NewWriter(defaultOutputFile, 8, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tDIGEST\tPATH")
+		for _, name := range names {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", name, refs[name], store.RefPath(name))
+		}
+
+		return w.Flush()
+	},
+}
+
+var gcAssetsCommand = cli.Command{
+	Name:  "gc",
+	Usage: "remove the versions no name points to and no sandbox pinned",
+	Action: func(c *cli.Context) error {
+		ctx, err := cliContextToContext(c)
+		if err != nil {
+			return err
+		}
+
+		store, err := assetStore(c)
+		if err != nil {
+			return err
+		}
+
+		sandboxes, err := vci.ListSandbox(ctx)
+		if err != nil {
+			return err
+		}
+
+		active := make(map[string]bool)
+		for _, s := range sandboxes {
+			active[s.ID] = true
+		}
+
+		removed, err := store.GC(func(holder string) bool { return active[holder] })
+		for _, digest := range removed {
+			fmt.Fprintln(defaultOutputFile, digest)
+		}
+
+		return err
+	},
+}
@@ -25,7 +25,7 @@ import (
 //
 // XXX: Increment for every change to the output format
 // (meaning any change to the EnvInfo type).
-const formatVersion = "1.0.24"
+const formatVersion = "1.0.27"
 
 // MetaInfo stores information on the format of the output itself
 type MetaInfo struct {
@@ -100,6 +100,9 @@ type HypervisorInfo struct {
 	MemorySlots          uint32
 	PCIeRootPort         uint32
 	HotplugVFIOOnRootBus bool
+	VFIOPeerToPeer       bool
+	GuestNUMA            bool
+	VCPUPinning          bool
 	Debug                bool
 	UseVSock             bool
 }
@@ -329,6 +332,9 @@ func getHypervisorInfo(config oci.RuntimeConfig) HypervisorInfo {
 
 		HotplugVFIOOnRootBus: config.HypervisorConfig.HotplugVFIOOnRootBus,
 		PCIeRootPort:         config.HypervisorConfig.PCIeRootPort,
+		VFIOPeerToPeer:       config.HypervisorConfig.VFIOPeerToPeer,
+		GuestNUMA:            config.HypervisorConfig.GuestNUMA,
+		VCPUPinning:          config.HypervisorConfig.VCPUPinning,
 	}
 }
 
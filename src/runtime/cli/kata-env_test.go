@@ -308,6 +308,9 @@ func getExpectedHypervisor(config oci.RuntimeConfig) HypervisorInfo {
 
 		HotplugVFIOOnRootBus: config.HypervisorConfig.HotplugVFIOOnRootBus,
 		PCIeRootPort:         config.HypervisorConfig.PCIeRootPort,
+		VFIOPeerToPeer:       config.HypervisorConfig.VFIOPeerToPeer,
+		GuestNUMA:            config.HypervisorConfig.GuestNUMA,
+		VCPUPinning:          config.HypervisorConfig.VCPUPinning,
 	}
 }
 
@@ -0,0 +1,635 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/containerd/console"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/urfave/cli"
+)
+
+var sandboxSubCmds = []cli.Command{
+	listSandboxCommand,
+	cleanupSandboxCommand,
+	consoleSandboxCommand,
+	dumpSandboxCommand,
+	metricsSandboxCommand,
+	networkStatsSandboxCommand,
+	networkDiagnosticsSandboxCommand,
+	exportSandboxCommand,
+	profileSandboxCommand,
+	livepatchSandboxCommand,
+	captureSandboxCommand,
+	upgradeVirtiofsdSandboxCommand,
+	liveUpdateSandboxCommand,
+}
+
+var sandboxCLICommand = cli.Command{
+	Name:        "sandbox",
+	Usage:       "operate the sandboxes running on this node",
+	Subcommands: sandboxSubCmds,
+	Action: func(context *cli.Context) {
+		cli.ShowSubcommandHelp(context)
+	},
+}
+
+// sandboxStatus returns the status of the sandbox whose ID is the first
+// argument of the command.
+func sandboxStatus(ctx context.Context, c *cli.Context) (vc.SandboxStatus, error) {
+	sandboxID := c.Args().First()
+	if sandboxID == "" {
+		return vc.SandboxStatus{}, errors.New("missing sandbox ID")
+	}
+
+	sandboxes, err := vci.ListSandbox(ctx)
+	if err != nil {
+		return vc.SandboxStatus{}, err
+	}
+
+	for _, s := range sandboxes {
+		if s.ID == sandboxID {
+			return s, nil
+		}
+	}
+
+	return vc.SandboxStatus{}, fmt.Errorf("sandbox %s not found", sandboxID)
+}
+
+// shimGet copies the response of the shim of a sandbox to a GET request
+// on path to the default output.
+func shimGet(c *cli.Context, path string) error {
+	ctx, err := cliContextToContext(c)
+	if err != nil {
+		return err
+	}
+
+	status, err := sandboxStatus(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	address, err := katautils.ShimMonitorAddress(status)
+	if err != nil {
+		return err
+	}
+
+	resp, err := katautils.ShimRequest(address, http.MethodGet, path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(defaultOutputFile, resp.Body)
+	return err
+}
+
+var listSandboxCommand = cli.Command{
+	Name:  "list",
+	Usage: "list the sandboxes and the state of their containers",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "hypervisor-version",
+			Usage: "only list the sandboxes started with this hypervisor version, as listed",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx, err := cliContextToContext(c)
+		if err != nil {
+			return err
+		}
+
+		var sandboxes []vc.SandboxStatus
+		if c.IsSet("hypervisor-version") {
+			sandboxes, err = vci.ListSandboxesByHypervisorVersion(ctx, c.String("hypervisor-version"))
+		} else {
+			sandboxes, err = vci.ListSandbox(ctx)
+		}
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(defaultOutputFile, 8, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tSTATE\tHYPERVISOR\tCONTAINERS\tRUNNING\tHYPERVISOR VERSION")
+		for _, s := range sandboxes {
+			running := 0
+			for _, cs := range s.ContainersStatus {
+				if cs.State.State == types.StateRunning {
+					running++
+				}
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s\n", s.ID, s.State.State, s.Hypervisor, len(s.ContainersStatus), running, s.State.HypervisorVersion)
+		}
+
+		return w.Flush()
+	},
+}
+
+var cleanupSandboxCommand = cli.Command{
+	Name:      "cleanup",
+	Usage:     "forcibly stop and delete a sandbox",
+	ArgsUsage: "<sandbox-id>",
+	Action: func(c *cli.Context) error {
+		ctx, err := cliContextToContext(c)
+		if err != nil {
+			return err
+		}
+
+		status, err := sandboxStatus(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		address, err := katautils.ShimMonitorAddress(status)
+		if err == nil {
+			var resp *http.Response
+			if resp, err = katautils.ShimRequest(address, http.MethodPost, "/cleanup"); err == nil {
+				resp.Body.Close()
+				return nil
+			}
+		}
+
+		// The shim is gone, clean the sandbox up from here, its
+		// containers first since deleting the last one deletes the
+		// sandbox.
+		kataLog.WithError(err).WithField("sandbox", status.ID).Warn("shim unreachable, cleaning up the sandbox directly")
+		for _, cs := range status.ContainersStatus {
+			if cs.ID == status.ID {
+				continue
+			}
+			if err := vci.CleanupContainer(ctx, status.ID, cs.ID, true); err != nil {
+				return err
+			}
+		}
+
+		return vci.CleanupContainer(ctx, status.ID, status.ID, true)
+	},
+}
+
+var consoleSandboxCommand = cli.Command{
+	Name:      "console",
+	Usage:     "connect to the debug console of a sandbox",
+	ArgsUsage: "<sandbox-id>",
+	Action: func(c *cli.Context) error {
+		ctx, err := cliContextToContext(c)
+		if err != nil {
+			return err
+		}
+
+		status, err := sandboxStatus(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		address, err := katautils.ShimMonitorAddress(status)
+		if err != nil {
+			return err
+		}
+
+		conn, err := katautils.ShimDebugConsole(address)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		current := console.Current()
+		if err := current.SetRaw(); err == nil {
+			defer current.Reset()
+		}
+
+		go io.Copy(conn, os.Stdin)
+		_, err = io.Copy(os.Stdout, conn)
+		return err
+	},
+}
+
+var dumpSandboxCommand = cli.Command{
+	Name:      "dump",
+	Usage:     "collect the state of a sandbox, in JSON, for troubleshooting",
+	ArgsUsage: "<sandbox-id>",
+	Action: func(c *cli.Context) error {
+		return shimGet(c, "/dump")
+	},
+}
+
+var metricsSandboxCommand = cli.Command{
+	Name:      "metrics",
+	Usage:     "show the metrics of a sandbox, in the Prometheus text format",
+	ArgsUsage: "<sandbox-id>",
+	Action: func(c *cli.Context) error {
+		return shimGet(c, "/metrics")
+	},
+}
+
+var networkStatsSandboxCommand = cli.Command{
+	Name:      "network-stats",
+	Usage:     "show the network endpoints of a sandbox, their attach and detach history and host counters, in JSON",
+	ArgsUsage: "<sandbox-id>",
+	Action: func(c *cli.Context) error {
+		return shimGet(c, "/network-stats")
+	},
+}
+
+var networkDiagnosticsSandboxCommand = cli.Command{
+	Name:      "network-diagnostics",
+	Usage:     "report, in JSON, the network setup of a sandbox its endpoints do not work with",
+	ArgsUsage: "<sandbox-id>",
+	Action: func(c *cli.Context) error {
+		return shimGet(c, "/network-diagnostics")
+	},
+}
+
+var exportSandboxCommand = cli.Command{
+	Name:      "export",
+	Usage:     "export the state of a sandbox, with its secrets redacted, as a tarball for bug reports",
+	ArgsUsage: "<sandbox-id>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "write the tarball to this file instead of the standard output",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx, err := cliContextToContext(c)
+		if err != nil {
+			return err
+		}
+
+		sandboxID := c.Args().First()
+		if sandboxID == "" {
+			return errors.New("missing sandbox ID")
+		}
+
+		output := c.String("output")
+		if output == "" {
+			return vci.ExportSandboxState(ctx, sandboxID, defaultOutputFile)
+		}
+
+		f, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			return err
+		}
+
+		if err := vci.ExportSandboxState(ctx, sandboxID, f); err != nil {
+			f.Close()
+			os.Remove(output)
+			return err
+		}
+
+		return f.Close()
+	},
+}
+
+var livepatchSandboxCommand = cli.Command{
+	Name:      "livepatch",
+	Usage:     "apply a kernel livepatch module, among the ones the configuration allows, to the guest of a running sandbox",
+	ArgsUsage: "<sandbox-id> <module> | --all <module>",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "all",
+			Usage: "apply the module to all the running sandboxes allowing it",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx, err := cliContextToContext(c)
+		if err != nil {
+			return err
+		}
+
+		if !c.Bool("all") {
+			sandboxID, module := c.Args().Get(0), c.Args().Get(1)
+			if sandboxID == "" || module == "" {
+				return errors.New("missing sandbox ID or livepatch module")
+			}
+
+			return vci.LivepatchSandbox(ctx, sandboxID, module)
+		}
+
+		module := c.Args().First()
+		if module == "" {
+			return errors.New("missing livepatch module")
+		}
+
+		sandboxes, err := vci.ListSandbox(ctx)
+		if err != nil {
+			return err
+		}
+
+		// A sandbox failing to be patched does not stop the others
+		// from being patched.
+		failed := 0
+		w := tabwriter.NewWriter(defaultOutputFile, 8, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tRESULT")
+		for _, s := range sandboxes {
+			if s.State.State != types.StateRunning {
+				continue
+			}
+
+			result := "applied"
+			if err := vci.LivepatchSandbox(ctx, s.ID, module); err != nil {
+				result = err.Error()
+				failed++
+			}
+			fmt.Fprintf(w, "%s\t%s\n", s.ID, result)
+		}
+
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("livepatch module %s not applied to %d sandboxes", module, failed)
+		}
+
+		return nil
+	},
+}
+
+var captureSandboxCommand = cli.Command{
+	Name:      "capture",
+	Usage:     "capture the traffic of a network interface of a sandbox, as its configuration allows, in the pcap format",
+	ArgsUsage: "<sandbox-id> <interface>",
+	Flags: []cli.Flag{
+		cli.DurationFlag{
+			Name:  "duration",
+			Value: 10 * time.Second,
+			Usage: "duration of the capture",
+		},
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "write the capture to this file instead of the standard output",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx, err := cliContextToContext(c)
+		if err != nil {
+			return err
+		}
+
+		sandboxID, iface := c.Args().Get(0), c.Args().Get(1)
+		if sandboxID == "" || iface == "" {
+			return errors.New("missing sandbox ID or network interface")
+		}
+
+		capture, err := vci.CaptureSandboxTraffic(ctx, sandboxID, iface, c.Duration("duration"))
+		if err != nil {
+			return err
+		}
+		defer capture.Close()
+
+		output := c.String("output")
+		if output == "" {
+			_, err := io.Copy(defaultOutputFile, capture)
+			return err
+		}
+
+		f, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(f, capture); err != nil {
+			f.Close()
+			os.Remove(output)
+			return err
+		}
+
+		return f.Close()
+	},
+}
+
+var profileSandboxCommand = cli.Command{
+	Name:      "profile",
+	Usage:     "run a profiling session in the guest of a sandbox, as its configuration allows, and write its output",
+	ArgsUsage: "<sandbox-id>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "tool",
+			Value: string(vc.ProfilePerf),
+			Usage: "profiling tool, perf (writing perf record samples in the pipe format) or bpftrace",
+		},
+		cli.DurationFlag{
+			Name:  "duration",
+			Value: 10 * time.Second,
+			Usage: "duration of the session",
+		},
+		cli.UintFlag{
+			Name:  "frequency",
+			Usage: "perf sampling frequency, in Hz",
+		},
+		cli.StringFlag{
+			Name:  "script",
+			Usage: "name of the bpftrace script shipped with the guest image",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx, err := cliContextToContext(c)
+		if err != nil {
+			return err
+		}
+
+		status, err := sandboxStatus(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		address, err := katautils.ShimMonitorAddress(status)
+		if err != nil {
+			return err
+		}
+
+		query := url.Values{}
+		query.Set("tool", c.String("tool"))
+		query.Set("duration", c.Duration("duration").String())
+		if f := c.Uint("frequency"); f > 0 {
+			query.Set("frequency", strconv.FormatUint(uint64(f), 10))
+		}
+		if s := c.String("script"); s != "" {
+			query.Set("script", s)
+		}
+
+		resp, err := katautils.ShimRequest(address, http.MethodGet, "/profile?"+query.Encode())
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if _, err := io.Copy(defaultOutputFile, resp.Body); err != nil {
+			return err
+		}
+
+		// The session failing once streamed is reported in a trailer.
+		if msg := resp.Trailer.Get(katautils.ShimProfileErrorTrailer); msg != "" {
+			return errors.New(msg)
+		}
+
+		return nil
+	},
+}
+
+// upgradeVirtiofsd asks the shim of a sandbox, which supervises the
+// virtio-fs daemons of the sandbox, to replace them.
+func upgradeVirtiofsd(status vc.SandboxStatus) error {
+	address, err := katautils.ShimMonitorAddress(status)
+	if err != nil {
+		return err
+	}
+
+	resp, err := katautils.ShimRequest(address, http.MethodPost, "/upgrade-virtiofsd")
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+var upgradeVirtiofsdSandboxCommand = cli.Command{
+	Name:      "upgrade-virtiofsd",
+	Usage:     "hand the virtio-fs shares of a running sandbox over to new daemons, started from the virtiofsd binary as it is now",
+	ArgsUsage: "<sandbox-id> | --all",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "all",
+			Usage: "upgrade the virtiofsd of all the running sandboxes",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx, err := cliContextToContext(c)
+		if err != nil {
+			return err
+		}
+
+		if !c.Bool("all") {
+			status, err := sandboxStatus(ctx, c)
+			if err != nil {
+				return err
+			}
+
+			return upgradeVirtiofsd(status)
+		}
+
+		sandboxes, err := vci.ListSandbox(ctx)
+		if err != nil {
+			return err
+		}
+
+		// A sandbox failing to be upgraded does not stop the others
+		// from being upgraded.
+		failed := 0
+		w := tabwriter.NewWriter(defaultOutputFile, 8, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tRESULT")
+		for _, s := range sandboxes {
+			if s.State.State != types.StateRunning {
+				continue
+			}
+
+			result := "upgraded"
+			if err := upgradeVirtiofsd(s); err != nil {
+				result = err.Error()
+				failed++
+			}
+			fmt.Fprintf(w, "%s\t%s\n", s.ID, result)
+		}
+
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("virtiofsd not upgraded in %d sandboxes", failed)
+		}
+
+		return nil
+	},
+}
+
+// liveUpdate asks the shim of a sandbox, which runs the hypervisor of the
+// sandbox, to move the VM to a new process of the hypervisor.
+func liveUpdate(status vc.SandboxStatus) error {
+	address, err := katautils.ShimMonitorAddress(status)
+	if err != nil {
+		return err
+	}
+
+	resp, err := katautils.ShimRequest(address, http.MethodPost, "/live-update")
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+var liveUpdateSandboxCommand = cli.Command{
+	Name:      "live-update",
+	Usage:     "move the VM of a running sandbox to a new hypervisor process, started from the hypervisor binary as it is now",
+	ArgsUsage: "<sandbox-id> | --all",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "all",
+			Usage: "live update all the running sandboxes",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx, err := cliContextToContext(c)
+		if err != nil {
+			return err
+		}
+
+		if !c.Bool("all") {
+			status, err := sandboxStatus(ctx, c)
+			if err != nil {
+				return err
+			}
+
+			return liveUpdate(status)
+		}
+
+		sandboxes, err := vci.ListSandbox(ctx)
+		if err != nil {
+			return err
+		}
+
+		// A sandbox failing to be updated does not stop the others from
+		// being updated, it runs on with the former hypervisor.
+		failed := 0
+		w := tabwriter.NewWriter(defaultOutputFile, 8, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tRESULT")
+		for _, s := range sandboxes {
+			if s.State.State != types.StateRunning {
+				continue
+			}
+
+			result := "updated"
+			if err := liveUpdate(s); err != nil {
+				result = err.Error()
+				failed++
+			}
+			fmt.Fprintf(w, "%s\t%s\n", s.ID, result)
+		}
+
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d sandboxes not live updated", failed)
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+func TestSandboxCLIList(t *testing.T) {
+	assert := assert.New(t)
+
+	testingImpl.ListSandboxFunc = func(ctx context.Context) ([]vc.SandboxStatus, error) {
+		return []vc.SandboxStatus{
+			{
+				ID:         testSandboxID,
+				State:      types.SandboxState{State: types.StateRunning, HypervisorVersion: "QEMU emulator version 5.0.0"},
+				Hypervisor: vc.QemuHypervisor,
+				ContainersStatus: []vc.ContainerStatus{
+					{ID: testSandboxID, State: types.ContainerState{State: types.StateRunning}},
+					{ID: testContainerID, State: types.ContainerState{State: types.StateStopped}},
+				},
+			},
+		}, nil
+	}
+	defer func() {
+		testingImpl.ListSandboxFunc = nil
+	}()
+
+	output, err := ioutil.TempFile("", "")
+	assert.NoError(err)
+	defer os.Remove(output.Name())
+
+	savedOutputFile := defaultOutputFile
+	defaultOutputFile = output
+	defer func() {
+		defaultOutputFile = savedOutputFile
+	}()
+
+	fn, ok := listSandboxCommand.Action.(func(context *cli.Context) error)
+	assert.True(ok)
+	assert.NoError(fn(createCLIContext(nil)))
+
+	data, err := ioutil.ReadFile(output.Name())
+	assert.NoError(err)
+	assert.Contains(string(data), testSandboxID)
+	assert.Regexp(`running\s+qemu\s+2\s+1\s+QEMU emulator version 5.0.0`, string(data))
+}
+
+func TestSandboxCLIListHypervisorVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	var version string
+	testingImpl.ListSandboxesByHypervisorVersionFunc = func(ctx context.Context, v string) ([]vc.SandboxStatus, error) {
+		version = v
+		return []vc.SandboxStatus{}, nil
+	}
+	defer func() {
+		testingImpl.ListSandboxesByHypervisorVersionFunc = nil
+	}()
+
+	output, err := ioutil.TempFile("", "")
+	assert.NoError(err)
+	defer os.Remove(output.Name())
+
+	savedOutputFile := defaultOutputFile
+	defaultOutputFile = output
+	defer func() {
+		defaultOutputFile = savedOutputFile
+	}()
+
+	set := flag.NewFlagSet("", 0)
+	set.String("hypervisor-version", "", "")
+	assert.NoError(set.Parse([]string{"--hypervisor-version", "QEMU emulator version 4.2.0"}))
+
+	fn, ok := listSandboxCommand.Action.(func(context *cli.Context) error)
+	assert.True(ok)
+	assert.NoError(fn(createCLIContext(set)))
+	assert.Equal("QEMU emulator version 4.2.0", version)
+}
+
+func TestSandboxCLIUnknownSandbox(t *testing.T) {
+	assert := assert.New(t)
+
+	testingImpl.ListSandboxFunc = func(ctx context.Context) ([]vc.SandboxStatus, error) {
+		return []vc.SandboxStatus{}, nil
+	}
+	defer func() {
+		testingImpl.ListSandboxFunc = nil
+	}()
+
+	set := flag.NewFlagSet("", 0)
+	ctx := createCLIContext(set)
+
+	// Missing sandbox ID
+	_, err := sandboxStatus(context.Background(), ctx)
+	assert.Error(err)
+
+	assert.NoError(set.Parse([]string{testSandboxID}))
+	_, err = sandboxStatus(context.Background(), ctx)
+	assert.Error(err)
+}
+
+func TestSandboxCLILivepatchAll(t *testing.T) {
+	assert := assert.New(t)
+
+	testingImpl.ListSandboxFunc = func(ctx context.Context) ([]vc.SandboxStatus, error) {
+		return []vc.SandboxStatus{
+			{ID: "running", State: types.SandboxState{State: types.StateRunning}},
+			{ID: "paused", State: types.SandboxState{State: types.StatePaused}},
+			{ID: "unpatched", State: types.SandboxState{State: types.StateRunning}},
+		}, nil
+	}
+
+	var patched []string
+	testingImpl.LivepatchSandboxFunc = func(ctx context.Context, sandboxID, module string) error {
+		if sandboxID == "unpatched" {
+			return errors.New("Livepatch module \"kpatch-fix\" is not allowed")
+		}
+		patched = append(patched, sandboxID+":"+module)
+		return nil
+	}
+	defer func() {
+		testingImpl.ListSandboxFunc = nil
+		testingImpl.LivepatchSandboxFunc = nil
+	}()
+
+	output, err := ioutil.TempFile("", "")
+	assert.NoError(err)
+	defer os.Remove(output.Name())
+
+	savedOutputFile := defaultOutputFile
+	defaultOutputFile = output
+	defer func() {
+		defaultOutputFile = savedOutputFile
+	}()
+
+	set := flag.NewFlagSet("", 0)
+	set.Bool("all", false, "")
+	assert.NoError(set.Parse([]string{"--all", "kpatch-fix"}))
+
+	fn, ok := livepatchSandboxCommand.Action.(func(context *cli.Context) error)
+	assert.True(ok)
+
+	// The sandboxes not allowing the module are reported, the others
+	// patched.
+	assert.Error(fn(createCLIContext(set)))
+	assert.Equal([]string{"running:kpatch-fix"}, patched)
+
+	data, err := ioutil.ReadFile(output.Name())
+	assert.NoError(err)
+	assert.Regexp(`running\s+applied`, string(data))
+	assert.Regexp(`unpatched\s+Livepatch module "kpatch-fix" is not allowed`, string(data))
+	assert.NotContains(string(data), "paused")
+
+	// Missing module
+	set = flag.NewFlagSet("", 0)
+	set.Bool("all", false, "")
+	assert.NoError(set.Parse([]string{testSandboxID}))
+	assert.Error(fn(createCLIContext(set)))
+}
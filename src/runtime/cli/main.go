@@ -126,6 +126,9 @@ var runtimeCommands = []cli.Command{
 	kataCheckCLICommand,
 	kataEnvCLICommand,
 	factoryCLICommand,
+	sandboxCLICommand,
+	warmupCLICommand,
+	assetsCLICommand,
 }
 
 // runtimeBeforeSubcommands is the function to run before command-line
@@ -577,6 +580,8 @@ func cliContextToContext(c *cli.Context) (context.Context, error) {
 }
 
 func main() {
+	vc.VMMIsolationInit()
+
 	// create a new empty context
 	ctx := context.Background()
 
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/oci"
+	"github.com/urfave/cli"
+)
+
+var warmupCLICommand = cli.Command{
+	Name:  "warmup",
+	Usage: "load the guest kernel, initrd, image and firmware into the host page cache",
+	Description: `Reads the assets the VMs boot from, as configured, into the host page
+   cache, for the sandboxes created next not to wait for the disk. Run it
+   before bursts of pod creations, e.g. from a timer or after a node boots.
+
+   With --netns, also creates the TAP interfaces the VM of the lightweight
+   sandbox created next in the network namespace is connected through.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "netns",
+			Usage: "pre-allocate the TAP interfaces of a lightweight sandbox in this network namespace",
+		},
+		cli.IntFlag{
+			Name:  "taps",
+			Value: 1,
+			Usage: "number of TAP interfaces to pre-allocate, one per network interface of the sandbox",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx, err := cliContextToContext(c)
+		if err != nil {
+			return err
+		}
+
+		runtimeConfig, ok := c.App.Metadata["runtimeConfig"].(oci.RuntimeConfig)
+		if !ok {
+			return errors.New("invalid runtime config")
+		}
+
+		assets, err := vci.PrefetchAssets(ctx, runtimeConfig.HypervisorConfig)
+		if err != nil {
+			return err
+		}
+
+		var taps []string
+		if netNSPath := c.String("netns"); netNSPath != "" {
+			taps, err = vci.PreallocateTaps(ctx, netNSPath, runtimeConfig.HypervisorType, runtimeConfig.HypervisorConfig, c.Int("taps"))
+			if err != nil {
+				return err
+			}
+		}
+
+		w := tabwriter.NewWriter(defaultOutputFile, 8, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "ASSET\tPATH\tSIZE\tDURATION")
+		for _, a := range assets {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%v\n", a.Type, a.Path, a.Size, a.Duration)
+		}
+		for _, tap := range taps {
+			fmt.Fprintf(w, "tap\t%s\t-\t-\n", tap)
+		}
+
+		return w.Flush()
+	},
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/oci"
+	"github.com/sirupsen/logrus"
+)
+
+// attachWriteTimeout bounds the writes of the process output to an attach,
+// a stalled attach being dropped rather than holding the output back.
+var attachWriteTimeout = 10 * time.Second
+
+var (
+	errAttachBusy   = errors.New("the process is attached already")
+	errAttachClosed = errors.New("the process I/O streams are closed")
+)
+
+// attachMux multiplexes the I/O streams of a process between the containerd
+// fifos and the attaches of the management socket, according to the attach
+// policy.
+type attachMux struct {
+	sync.Mutex
+
+	policy oci.AttachPolicy
+
+	// stdinMu serializes the writes of the fifo and of the attaches to the
+	// process stdin.
+	stdinMu sync.Mutex
+	stdin   io.Writer
+
+	// attaches are the connections of the attaches, oldest first.
+	attaches []net.Conn
+	closed   bool
+}
+
+func newAttachMux(policy oci.AttachPolicy, stdin io.Writer) *attachMux {
+	if policy == "" {
+		policy = oci.AttachPolicyShared
+	}
+
+	return &attachMux{
+		policy: policy,
+		stdin:  stdin,
+	}
+}
+
+// add adds an attach, writing the response of the attach request to it
+// before any process output.
+func (m *attachMux) add(conn net.Conn, response string) error {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.closed {
+		return errAttachClosed
+	}
+
+	if m.policy == oci.AttachPolicyExclusive && len(m.attaches) > 0 {
+		return errAttachBusy
+	}
+
+	if _, err := io.WriteString(conn, response); err != nil {
+		return err
+	}
+
+	m.attaches = append(m.attaches, conn)
+	return nil
+}
+
+func (m *attachMux) remove(conn net.Conn) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.removeLocked(conn)
+}
+
+func (m *attachMux) removeLocked(conn net.Conn) {
+	for i, c := range m.attaches {
+		if c == conn {
+			m.attaches = append(m.attaches[:i], m.attaches[i+1:]...)
+			conn.Close()
+			return
+		}
+	}
+}
+
+// Write writes the process output to all the attaches. It never fails, the
+// attaches failing to take the output being dropped.
+func (m *attachMux) Write(p []byte) (int, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, conn := range append([]net.Conn(nil), m.attaches...) {
+		conn.SetWriteDeadline(time.Now().Add(attachWriteTimeout))
+		if _, err := conn.Write(p); err != nil {
+			logrus.WithError(err).Warn("dropping attach failing to take the process output")
+			m.removeLocked(conn)
+		}
+	}
+
+	return len(p), nil
+}
+
+// writeInput writes the input of an attach to the process, if the policy
+// lets it.
+func (m *attachMux) writeInput(conn net.Conn, p []byte) error {
+	m.Lock()
+	writer := m.policy != oci.AttachPolicySingleWriter || (len(m.attaches) > 0 && m.attaches[0] == conn)
+	m.Unlock()
+
+	if !writer {
+		return nil
+	}
+
+	return m.writeStdin(p)
+}
+
+func (m *attachMux) writeStdin(p []byte) error {
+	m.stdinMu.Lock()
+	defer m.stdinMu.Unlock()
+
+	if m.stdin == nil {
+		return nil
+	}
+
+	_, err := m.stdin.Write(p)
+	return err
+}
+
+// stdinWriter returns the writer of the stdin fifo to the process, the
+// input of the fifo being always written.
+func (m *attachMux) stdinWriter() io.Writer {
+	return stdinWriterFunc(func(p []byte) (int, error) {
+		if err := m.writeStdin(p); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	})
+}
+
+type stdinWriterFunc func([]byte) (int, error)
+
+func (f stdinWriterFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
+
+// serve writes the input read from an attach to the process, until the
+// attach or the process I/O streams are closed.
+func (m *attachMux) serve(conn net.Conn, input io.Reader) {
+	defer m.remove(conn)
+
+	buf := make([]byte, bufSize)
+	for {
+		n, err := input.Read(buf)
+		if n > 0 {
+			if err := m.writeInput(conn, buf[:n]); err != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// close closes the attaches once the process I/O streams are closed, and
+// rejects the new ones.
+func (m *attachMux) close() {
+	m.Lock()
+	defer m.Unlock()
+
+	m.closed = true
+	for _, conn := range m.attaches {
+		conn.Close()
+	}
+	m.attaches = nil
+}
+
+func (s *service) attachPolicy() oci.AttachPolicy {
+	if s.config == nil {
+		return ""
+	}
+	return s.config.AttachPolicy
+}
+
+// getAttachMux returns the attach multiplexer of the container process, or
+// of one of its exec processes.
+func (s *service) getAttachMux(containerID, execID string) (*attachMux, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.getContainer(containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := c.attaches
+	if execID != "" {
+		execs, err := c.getExec(execID)
+		if err != nil {
+			return nil, err
+		}
+		mux = execs.attaches
+	}
+
+	if mux == nil {
+		return nil, errAttachClosed
+	}
+
+	return mux, nil
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/api/types/task"
+	taskAPI "github.com/containerd/containerd/runtime/v2/task"
+	"github.com/stretchr/testify/assert"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/oci"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+)
+
+const (
+	testAttachResponse = "HTTP/1.1 200 OK\r\n\r\n"
+	testAttachTimeout  = 5 * time.Second
+	testAttachTick     = 10 * time.Millisecond
+)
+
+type testStdin struct {
+	sync.Mutex
+	bytes.Buffer
+}
+
+func (s *testStdin) Write(p []byte) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.Buffer.Write(p)
+}
+
+func (s *testStdin) String() string {
+	s.Lock()
+	defer s.Unlock()
+	return s.Buffer.String()
+}
+
+// testAttach attaches to mux over a pipe, returning the client end of the
+// attach and the output read from it.
+func testAttach(t *testing.T, mux *attachMux) (net.Conn, <-chan string, error) {
+	server, client := net.Pipe()
+
+	output := make(chan string, 16)
+	go func() {
+		defer close(output)
+		buf := make([]byte, bufSize)
+		for {
+			n, err := client.Read(buf)
+			if n > 0 {
+				output <- string(buf[:n])
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	if err := mux.add(server, testAttachResponse); err != nil {
+		server.Close()
+		client.Close()
+		return nil, nil, err
+	}
+	assert.Equal(t, testAttachResponse, <-output)
+
+	go mux.serve(server, server)
+
+	return client, output, nil
+}
+
+func TestAttachMuxShared(t *testing.T) {
+	assert := assert.New(t)
+
+	stdin := &testStdin{}
+	mux := newAttachMux("", stdin)
+	assert.Equal(oci.AttachPolicyShared, mux.policy)
+
+	c1, out1, err := testAttach(t, mux)
+	assert.NoError(err)
+	c2, out2, err := testAttach(t, mux)
+	assert.NoError(err)
+
+	// All the attaches get the output
+	n, err := mux.Write([]byte("output"))
+	assert.NoError(err)
+	assert.Equal(6, n)
+	assert.Equal("output", <-out1)
+	assert.Equal("output", <-out2)
+
+	// All the attaches write the input
+	_, err = c1.Write([]byte("in1"))
+	assert.NoError(err)
+	_, err = c2.Write([]byte("in2"))
+	assert.NoError(err)
+	assert.Eventually(func() bool { return stdin.String() == "in1in2" }, testAttachTimeout, testAttachTick)
+
+	// The fifo input is written as well
+	_, err = io.WriteString(mux.stdinWriter(), "fifo")
+	assert.NoError(err)
+	assert.Equal("in1in2fifo", stdin.String())
+
+	// A detached attach is dropped
+	c1.Close()
+	assert.Eventually(func() bool {
+		mux.Lock()
+		defer mux.Unlock()
+		return len(mux.attaches) == 1
+	}, testAttachTimeout, testAttachTick)
+
+	_, err = mux.Write([]byte("more"))
+	assert.NoError(err)
+	assert.Equal("more", <-out2)
+
+	c2.Close()
+}
+
+func TestAttachMuxSingleWriter(t *testing.T) {
+	assert := assert.New(t)
+
+	stdin := &testStdin{}
+	mux := newAttachMux(oci.AttachPolicySingleWriter, stdin)
+
+	c1, _, err := testAttach(t, mux)
+	assert.NoError(err)
+	c2, _, err := testAttach(t, mux)
+	assert.NoError(err)
+
+	// Only the oldest attach writes the input
+	_, err = c2.Write([]byte("ignored"))
+	assert.NoError(err)
+	_, err = c1.Write([]byte("in1"))
+	assert.NoError(err)
+	assert.Eventually(func() bool { return stdin.String() == "in1" }, testAttachTimeout, testAttachTick)
+
+	// The next attach writes once the oldest one detaches
+	c1.Close()
+	assert.Eventually(func() bool {
+		mux.Lock()
+		defer mux.Unlock()
+		return len(mux.attaches) == 1
+	}, testAttachTimeout, testAttachTick)
+
+	_, err = c2.Write([]byte("in2"))
+	assert.NoError(err)
+	assert.Eventually(func() bool { return stdin.String() == "in1in2" }, testAttachTimeout, testAttachTick)
+
+	c2.Close()
+}
+
+func TestAttachMuxExclusive(t *testing.T) {
+	assert := assert.New(t)
+
+	mux := newAttachMux(oci.AttachPolicyExclusive, &testStdin{})
+
+	c1, _, err := testAttach(t, mux)
+	assert.NoError(err)
+
+	_, _, err = testAttach(t, mux)
+	assert.Equal(errAttachBusy, err)
+
+	c1.Close()
+	assert.Eventually(func() bool {
+		mux.Lock()
+		defer mux.Unlock()
+		return len(mux.attaches) == 0
+	}, testAttachTimeout, testAttachTick)
+
+	c2, _, err := testAttach(t, mux)
+	assert.NoError(err)
+	c2.Close()
+}
+
+func TestAttachMuxClose(t *testing.T) {
+	assert := assert.New(t)
+
+	mux := newAttachMux(oci.AttachPolicyShared, &testStdin{})
+
+	_, out, err := testAttach(t, mux)
+	assert.NoError(err)
+
+	// The attaches are closed with the process I/O streams
+	mux.close()
+	_, ok := <-out
+	assert.False(ok)
+
+	_, _, err = testAttach(t, mux)
+	assert.Equal(errAttachClosed, err)
+}
+
+func TestResizePtyBeforeStart(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	var err error
+	s.containers[testContainerID], err = newContainer(s, &taskAPI.CreateTaskRequest{ID: testContainerID}, vc.PodContainer, nil, false)
+	assert.NoError(err)
+	c := s.containers[testContainerID]
+	c.execs[testContainerID] = &exec{
+		id:     testContainerID,
+		status: task.StatusCreated,
+		tty:    &tty{},
+	}
+
+	ctx := context.Background()
+
+	// The resizes of the container process are queued until it starts
+	_, err = s.ResizePty(ctx, &taskAPI.ResizePtyRequest{ID: testContainerID, Height: 24, Width: 80})
+	assert.NoError(err)
+	assert.Equal(uint32(24), c.height)
+	assert.Equal(uint32(80), c.width)
+
+	// And so are the resizes of an exec process
+	_, err = s.ResizePty(ctx, &taskAPI.ResizePtyRequest{ID: testContainerID, ExecID: testContainerID, Height: 50, Width: 120})
+	assert.NoError(err)
+	assert.Equal(uint32(50), c.execs[testContainerID].tty.height)
+	assert.Equal(uint32(120), c.execs[testContainerID].tty.width)
+
+	_, err = s.ResizePty(ctx, &taskAPI.ResizePtyRequest{ID: testContainerID, ExecID: "missing", Height: 50, Width: 120})
+	assert.Error(err)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/runtime/v2/runc/options"
+	taskAPI "github.com/containerd/containerd/runtime/v2/task"
+	"github.com/containerd/typeurl"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpointContainer(t *testing.T) {
+	assert := assert.New(t)
+	var err error
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+	}
+
+	var opts vc.CheckpointOptions
+	sandbox.CheckpointContainerFunc = func(contID string, o vc.CheckpointOptions) error {
+		opts = o
+		return nil
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	s.containers[testContainerID], err = newContainer(s, &taskAPI.CreateTaskRequest{ID: testContainerID}, vc.PodContainer, nil, true)
+	assert.NoError(err)
+
+	ctx := namespaces.WithNamespace(context.Background(), "UnitTest")
+
+	req := &taskAPI.CheckpointTaskRequest{
+		ID:   testContainerID,
+		Path: "/var/lib/checkpoints/ctr",
+	}
+	_, err = s.Checkpoint(ctx, req)
+	assert.NoError(err)
+	assert.Equal(vc.CheckpointOptions{ImagesDir: "/var/lib/checkpoints/ctr", LeaveRunning: true}, opts)
+
+	req.Options, err = typeurl.MarshalAny(&options.CheckpointOptions{Exit: true})
+	assert.NoError(err)
+	_, err = s.Checkpoint(ctx, req)
+	assert.NoError(err)
+	assert.False(opts.LeaveRunning)
+
+	// The sandbox container cannot be checkpointed
+	s.containers[testContainerID].cType = vc.PodSandbox
+	_, err = s.Checkpoint(ctx, req)
+	assert.Error(err)
+}
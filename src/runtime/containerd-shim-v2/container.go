@@ -34,6 +34,19 @@ type container struct {
 	status   task.Status
 	terminal bool
 	mounted  bool
+
+	// height and width are the last size of the terminal of the
+	// container process, applied when it starts.
+	height uint32
+	width  uint32
+
+	// attaches multiplexes the I/O streams of the container process
+	// between the containerd fifos and the attaches, once started.
+	attaches *attachMux
+
+	// checkpoint is the directory of the images the container is
+	// restored from, instead of being started, if any.
+	checkpoint string
 }
 
 func newContainer(s *service, r *taskAPI.CreateTaskRequest, containerType vc.ContainerType, spec *specs.Spec, mounted bool) (*container, error) {
@@ -61,6 +74,14 @@ func newContainer(s *service, r *taskAPI.CreateTaskRequest, containerType vc.Con
 		exitIOch: make(chan struct{}),
 		exitCh:   make(chan uint32, 1),
 		mounted:  mounted,
+
+		checkpoint: r.Checkpoint,
 	}
+
+	if spec.Process != nil && spec.Process.ConsoleSize != nil {
+		c.height = uint32(spec.Process.ConsoleSize.Height)
+		c.width = uint32(spec.Process.ConsoleSize.Width)
+	}
+
 	return c, nil
 }
@@ -23,6 +23,7 @@ type exec struct {
 	cmds      *types.Cmd
 	tty       *tty
 	ttyio     *ttyIO
+	attaches  *attachMux
 	id        string
 
 	exitCode int32
@@ -0,0 +1,181 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+)
+
+// The pod stats types below follow the layout of the kubelet summary API
+// (k8s.io/kubelet/pkg/apis/stats/v1alpha1), so that the usage of a Kata pod
+// can be consumed the same way as the usage of a pod level cgroup.
+
+type podCPUStats struct {
+	Time                 time.Time `json:"time"`
+	UsageCoreNanoSeconds uint64    `json:"usageCoreNanoSeconds"`
+}
+
+type podMemoryStats struct {
+	Time            time.Time `json:"time"`
+	UsageBytes      uint64    `json:"usageBytes"`
+	WorkingSetBytes uint64    `json:"workingSetBytes"`
+	RSSBytes        uint64    `json:"rssBytes"`
+	PageFaults      uint64    `json:"pageFaults"`
+	MajorPageFaults uint64    `json:"majorPageFaults"`
+
+	// ReclaimableBytes is not part of the summary API: the memory the
+	// guest did not access during its last idle memory scan, which the pod
+	// could give back, zero when the idle memory is not tracked.
+	ReclaimableBytes uint64 `json:"reclaimableBytes,omitempty"`
+}
+
+type podContainerStats struct {
+	Name   string          `json:"name"`
+	CPU    *podCPUStats    `json:"cpu,omitempty"`
+	Memory *podMemoryStats `json:"memory,omitempty"`
+}
+
+// podStats is the usage of a pod: the usage of its containers measured in
+// the guest, plus the overhead of the VM measured on the host, which is what
+// the host sandbox cgroup uses beyond the containers.
+type podStats struct {
+	SandboxID  string              `json:"sandboxID"`
+	CPU        *podCPUStats        `json:"cpu,omitempty"`
+	Memory     *podMemoryStats     `json:"memory,omitempty"`
+	Overhead   podContainerStats   `json:"overhead"`
+	Containers []podContainerStats `json:"containers"`
+}
+
+// subOrZero returns a - b, or zero if b is greater than a.
+func subOrZero(a, b uint64) uint64 {
+	if b > a {
+		return 0
+	}
+	return a - b
+}
+
+func toPodContainerStats(name string, stats vc.ContainerStats, now time.Time) podContainerStats {
+	cs := podContainerStats{Name: name}
+
+	if stats.CgroupStats == nil {
+		return cs
+	}
+
+	cs.CPU = &podCPUStats{
+		Time:                 now,
+		UsageCoreNanoSeconds: stats.CgroupStats.CPUStats.CPUUsage.TotalUsage,
+	}
+
+	mem := stats.CgroupStats.MemoryStats
+	cs.Memory = &podMemoryStats{
+		Time:       now,
+		UsageBytes: mem.Usage.Usage,
+		// Same working set definition as cAdvisor.
+		WorkingSetBytes:  subOrZero(mem.Usage.Usage, mem.Stats["total_inactive_file"]),
+		RSSBytes:         mem.Stats["total_rss"],
+		PageFaults:       mem.Stats["total_pgfault"],
+		MajorPageFaults:  mem.Stats["total_pgmajfault"],
+		ReclaimableBytes: mem.Idle,
+	}
+
+	return cs
+}
+
+// calcPodStats combines the guest usage of the containers with the host
+// usage of the sandbox.
+func calcPodStats(sandboxID string, sandboxStats vc.SandboxStats, containers []podContainerStats, now time.Time) podStats {
+	stats := podStats{
+		SandboxID:  sandboxID,
+		CPU:        &podCPUStats{Time: now},
+		Memory:     &podMemoryStats{Time: now},
+		Containers: containers,
+	}
+
+	for _, c := range containers {
+		if c.CPU != nil {
+			stats.CPU.UsageCoreNanoSeconds += c.CPU.UsageCoreNanoSeconds
+		}
+		if c.Memory != nil {
+			stats.Memory.UsageBytes += c.Memory.UsageBytes
+			stats.Memory.WorkingSetBytes += c.Memory.WorkingSetBytes
+			stats.Memory.RSSBytes += c.Memory.RSSBytes
+			stats.Memory.PageFaults += c.Memory.PageFaults
+			stats.Memory.MajorPageFaults += c.Memory.MajorPageFaults
+			stats.Memory.ReclaimableBytes += c.Memory.ReclaimableBytes
+		}
+	}
+
+	host := sandboxStats.CgroupStats
+	overheadCPU := subOrZero(host.CPUStats.CPUUsage.TotalUsage, stats.CPU.UsageCoreNanoSeconds)
+	overheadMemory := subOrZero(host.MemoryStats.Usage.Usage, stats.Memory.UsageBytes)
+
+	// The host does not tell the VM memory which is reclaimable, the
+	// whole overhead is accounted in the working set.
+	stats.Overhead = podContainerStats{
+		Name: "overhead",
+		CPU: &podCPUStats{
+			Time:                 now,
+			UsageCoreNanoSeconds: overheadCPU,
+		},
+		Memory: &podMemoryStats{
+			Time:            now,
+			UsageBytes:      overheadMemory,
+			WorkingSetBytes: overheadMemory,
+			RSSBytes:        overheadMemory,
+		},
+	}
+
+	stats.CPU.UsageCoreNanoSeconds += overheadCPU
+	stats.Memory.UsageBytes += overheadMemory
+	stats.Memory.WorkingSetBytes += overheadMemory
+	stats.Memory.RSSBytes += overheadMemory
+
+	return stats
+}
+
+// getPodStats must be called with s.mu held.
+func (s *service) getPodStats() (podStats, error) {
+	sandboxStats, err := s.sandbox.Stats()
+	if err != nil {
+		return podStats{}, err
+	}
+
+	now := time.Now()
+
+	var containers []podContainerStats
+	for _, c := range s.sandbox.GetAllContainers() {
+		cstats, err := s.sandbox.StatsContainer(c.ID())
+		if err != nil {
+			return podStats{}, err
+		}
+		containers = append(containers, toPodContainerStats(c.ID(), cstats, now))
+	}
+
+	return calcPodStats(s.sandbox.ID(), sandboxStats, containers, now), nil
+}
+
+// servePodStats handles /pod-stats requests
+func (s *service) servePodStats(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	stats, err := s.getPodStats()
+	s.mu.Unlock()
+	if err != nil {
+		logrus.WithError(err).Error("failed to get pod stats")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		logrus.WithError(err).Error("failed to encode pod stats")
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+)
+
+func newTestContainerStats(cpu, usage, inactive uint64) vc.ContainerStats {
+	stats := vc.ContainerStats{CgroupStats: &vc.CgroupStats{}}
+	stats.CgroupStats.CPUStats.CPUUsage.TotalUsage = cpu
+	stats.CgroupStats.MemoryStats.Usage.Usage = usage
+	stats.CgroupStats.MemoryStats.Stats = map[string]uint64{
+		"total_inactive_file": inactive,
+		"total_rss":           usage - inactive,
+	}
+	return stats
+}
+
+func TestToPodContainerStats(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Now()
+
+	cs := toPodContainerStats("foo", vc.ContainerStats{}, now)
+	assert.Equal("foo", cs.Name)
+	assert.Nil(cs.CPU)
+	assert.Nil(cs.Memory)
+
+	cs = toPodContainerStats("foo", newTestContainerStats(100, 1000, 400), now)
+	assert.Equal(uint64(100), cs.CPU.UsageCoreNanoSeconds)
+	assert.Equal(uint64(1000), cs.Memory.UsageBytes)
+	assert.Equal(uint64(600), cs.Memory.WorkingSetBytes)
+	assert.Equal(uint64(600), cs.Memory.RSSBytes)
+	assert.Zero(cs.Memory.ReclaimableBytes)
+
+	stats := newTestContainerStats(100, 1000, 400)
+	stats.CgroupStats.MemoryStats.Idle = 300
+	cs = toPodContainerStats("foo", stats, now)
+	assert.Equal(uint64(300), cs.Memory.ReclaimableBytes)
+}
+
+func TestCalcPodStats(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Now()
+
+	containers := []podContainerStats{
+		toPodContainerStats("c1", newTestContainerStats(100, 1000, 400), now),
+		toPodContainerStats("c2", newTestContainerStats(200, 2000, 0), now),
+	}
+
+	sandboxStats := vc.SandboxStats{}
+	sandboxStats.CgroupStats.CPUStats.CPUUsage.TotalUsage = 500
+	sandboxStats.CgroupStats.MemoryStats.Usage.Usage = 10000
+
+	stats := calcPodStats(testSandboxID, sandboxStats, containers, now)
+	assert.Equal(testSandboxID, stats.SandboxID)
+	assert.Len(stats.Containers, 2)
+
+	assert.Equal(uint64(200), stats.Overhead.CPU.UsageCoreNanoSeconds)
+	assert.Equal(uint64(7000), stats.Overhead.Memory.UsageBytes)
+
+	assert.Equal(uint64(500), stats.CPU.UsageCoreNanoSeconds)
+	assert.Equal(uint64(10000), stats.Memory.UsageBytes)
+	assert.Equal(uint64(9600), stats.Memory.WorkingSetBytes)
+	assert.Zero(stats.Memory.ReclaimableBytes)
+
+	containers[1].Memory.ReclaimableBytes = 500
+	stats = calcPodStats(testSandboxID, sandboxStats, containers, now)
+	assert.Equal(uint64(500), stats.Memory.ReclaimableBytes)
+	assert.Zero(stats.Overhead.Memory.ReclaimableBytes)
+
+	// The guest usage can be ahead of the host one, the overhead is
+	// never negative.
+	sandboxStats.CgroupStats.CPUStats.CPUUsage.TotalUsage = 100
+	stats = calcPodStats(testSandboxID, sandboxStats, containers, now)
+	assert.Equal(uint64(0), stats.Overhead.CPU.UsageCoreNanoSeconds)
+	assert.Equal(uint64(300), stats.CPU.UsageCoreNanoSeconds)
+}
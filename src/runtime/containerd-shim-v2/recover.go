@@ -0,0 +1,195 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/containerd/containerd/api/types/task"
+	taskAPI "github.com/containerd/containerd/runtime/v2/task"
+	"github.com/sirupsen/logrus"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+)
+
+// recoveryFile is written in the bundle of every container created by the
+// shim. It records what the persisted sandbox state does not know about,
+// and that a shim taking over the sandbox needs to manage the container.
+const recoveryFile = "kata-shim-recovery.json"
+
+type recoveryRecord struct {
+	Stdin    string
+	Stdout   string
+	Stderr   string
+	Terminal bool
+	Mounted  bool
+
+	// Height and Width are the last size of the container terminal.
+	Height uint32
+	Width  uint32
+}
+
+func saveRecoveryRecord(c *container) error {
+	data, err := json.Marshal(recoveryRecord{
+		Stdin:    c.stdin,
+		Stdout:   c.stdout,
+		Stderr:   c.stderr,
+		Terminal: c.terminal,
+		Mounted:  c.mounted,
+		Height:   c.height,
+		Width:    c.width,
+	})
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(c.bundle, recoveryFile), data, 0600)
+}
+
+func loadRecoveryRecord(bundle string) (recoveryRecord, error) {
+	var record recoveryRecord
+
+	data, err := ioutil.ReadFile(filepath.Join(bundle, recoveryFile))
+	if err != nil {
+		return record, err
+	}
+
+	err = json.Unmarshal(data, &record)
+	return record, err
+}
+
+// recoverContainer rebuilds the shim view of a container from its
+// virtcontainers status and its recovery record.
+func recoverContainer(s *service, status vc.ContainerStatus) (*container, error) {
+	bundle := status.Annotations[vcAnnotations.BundlePathKey]
+	record, err := loadRecoveryRecord(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &taskAPI.CreateTaskRequest{
+		ID:       status.ID,
+		Bundle:   bundle,
+		Stdin:    record.Stdin,
+		Stdout:   record.Stdout,
+		Stderr:   record.Stderr,
+		Terminal: record.Terminal,
+	}
+
+	containerType := vc.ContainerType(status.Annotations[vcAnnotations.ContainerTypeKey])
+	c, err := newContainer(s, r, containerType, status.Spec, record.Mounted)
+	if err != nil {
+		return nil, err
+	}
+
+	c.height = record.Height
+	c.width = record.Width
+
+	c.status = taskStatus(status.State.State)
+	if c.status == task.StatusStopped {
+		// The exit code of a process which was not waited for is lost.
+		c.exit = exitCode255
+		c.exitTime = time.Now()
+		close(c.exitIOch)
+		c.exitCh <- c.exit
+	}
+
+	return c, nil
+}
+
+// recoverSandbox takes over the sandbox this shim is serving, if it was
+// created by a previous shim. It is called once, when the shim starts.
+func (s *service) recoverSandbox() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.recover(); err != nil {
+		logrus.WithError(err).WithField("sandbox", s.id).Debug("no sandbox to recover")
+	}
+}
+
+// recover takes over the sandbox this shim is serving after the shim which
+// created it crashed: it reconnects to the VM, reconciles the containers
+// with the guest, and restarts the I/O streams, the terminals and the waits
+// of the running containers. Exec'ed processes are not recovered.
+//
+// It must be called with s.mu held.
+func (s *service) recover() (err error) {
+	sandbox, err := vci.FetchSandbox(s.ctx, s.id)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			sandbox.Release()
+		}
+	}()
+
+	if err = sandbox.Reconcile(); err != nil {
+		return err
+	}
+
+	containers := make(map[string]*container)
+	for _, cs := range sandbox.Status().ContainersStatus {
+		c, err := recoverContainer(s, cs)
+		if err != nil {
+			return fmt.Errorf("cannot recover container %s: %v", cs.ID, err)
+		}
+		containers[c.id] = c
+	}
+
+	sc, ok := containers[s.id]
+	if !ok || !sc.cType.IsSandbox() {
+		return fmt.Errorf("sandbox container %s not found", s.id)
+	}
+
+	if _, err = loadRuntimeConfig(s, &taskAPI.CreateTaskRequest{}, sc.spec.Annotations); err != nil {
+		return err
+	}
+
+	monitor, err := sandbox.Monitor()
+	if err != nil {
+		return err
+	}
+
+	s.sandbox = sandbox
+	s.sandbox.EnableDeferredShrinks(&s.mu)
+	s.containers = containers
+	s.monitor = monitor
+
+	go watchSandbox(s)
+	go watchOOMEvents(s.ctx, s)
+	go s.startManagementServer(s.ctx, sc.spec)
+
+	for _, c := range containers {
+		if c.status != task.StatusRunning && c.status != task.StatusPaused {
+			continue
+		}
+
+		if err := attachIO(s.ctx, s, c); err != nil {
+			logrus.WithError(err).WithField("container", c.id).Warn("failed to reattach container I/O")
+			continue
+		}
+
+		// The guest terminal kept running, but its size may have changed
+		// while no shim was there to forward the resizes.
+		if c.terminal && c.height != 0 && c.width != 0 {
+			if err := sandbox.WinsizeProcess(c.id, c.id, c.height, c.width); err != nil {
+				logrus.WithError(err).WithField("container", c.id).Warn("failed to restore container terminal size")
+			}
+		}
+	}
+
+	logrus.WithField("sandbox", s.id).Info("sandbox recovered")
+
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/containerd/containerd/api/types/task"
+	"github.com/containerd/containerd/errdefs"
+	taskAPI "github.com/containerd/containerd/runtime/v2/task"
+	"github.com/stretchr/testify/assert"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+func TestRecoverContainer(t *testing.T) {
+	assert := assert.New(t)
+
+	bundle, err := ioutil.TempDir("", "recover")
+	assert.NoError(err)
+	defer os.RemoveAll(bundle)
+
+	s := &service{
+		id:         testSandboxID,
+		containers: make(map[string]*container),
+	}
+
+	c, err := newContainer(s, &taskAPI.CreateTaskRequest{
+		ID:       testContainerID,
+		Bundle:   bundle,
+		Stdout:   "/run/stdout",
+		Terminal: true,
+	}, vc.PodContainer, nil, true)
+	assert.NoError(err)
+	c.height = 24
+	c.width = 80
+	assert.NoError(saveRecoveryRecord(c))
+
+	status := vc.ContainerStatus{
+		ID:    testContainerID,
+		State: types.ContainerState{State: types.StateRunning},
+		Annotations: map[string]string{
+			vcAnnotations.BundlePathKey:    bundle,
+			vcAnnotations.ContainerTypeKey: string(vc.PodContainer),
+		},
+	}
+
+	rc, err := recoverContainer(s, status)
+	assert.NoError(err)
+	assert.Equal(task.StatusRunning, rc.status)
+	assert.Equal(vc.PodContainer, rc.cType)
+	assert.Equal("/run/stdout", rc.stdout)
+	assert.True(rc.terminal)
+	assert.True(rc.mounted)
+	assert.Equal(uint32(24), rc.height)
+	assert.Equal(uint32(80), rc.width)
+
+	// A stopped container can be waited for right away.
+	status.State.State = types.StateStopped
+	rc, err = recoverContainer(s, status)
+	assert.NoError(err)
+	assert.Equal(task.StatusStopped, rc.status)
+	assert.Equal(uint32(exitCode255), <-rc.exitCh)
+
+	// No recovery record
+	status.Annotations[vcAnnotations.BundlePathKey] = "/does/not/exist"
+	_, err = recoverContainer(s, status)
+	assert.Error(err)
+}
+
+func TestRecoverSandbox(t *testing.T) {
+	assert := assert.New(t)
+
+	fetched := 0
+	testingImpl.FetchSandboxFunc = func(ctx context.Context, sandboxID string) (vc.VCSandbox, error) {
+		fetched++
+		return &vcmock.Sandbox{MockID: sandboxID}, nil
+	}
+	defer func() {
+		testingImpl.FetchSandboxFunc = nil
+	}()
+
+	s := &service{
+		id:         testSandboxID,
+		containers: make(map[string]*container),
+		ctx:        context.Background(),
+	}
+
+	// The mock sandbox has no sandbox container, recovery fails.
+	s.recoverSandbox()
+	assert.Equal(1, fetched)
+	assert.Nil(s.sandbox)
+
+	// Looking a container up does not attempt a recovery.
+	_, err := s.getContainer(testContainerID)
+	assert.True(errdefs.IsNotFound(errdefs.FromGRPC(err)))
+	assert.Equal(1, fetched)
+}
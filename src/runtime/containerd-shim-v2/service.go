@@ -7,6 +7,7 @@ package containerdshim
 
 import (
 	"context"
+	"flag"
 	"io/ioutil"
 	"os"
 	sysexec "os/exec"
@@ -20,6 +21,7 @@ import (
 	"github.com/containerd/containerd/events"
 	"github.com/containerd/containerd/namespaces"
 	cdruntime "github.com/containerd/containerd/runtime"
+	"github.com/containerd/containerd/runtime/v2/runc/options"
 	cdshim "github.com/containerd/containerd/runtime/v2/shim"
 	taskAPI "github.com/containerd/containerd/runtime/v2/task"
 	"github.com/containerd/typeurl"
@@ -88,6 +90,12 @@ func New(ctx context.Context, id string, publisher events.Publisher) (cdshim.Shi
 
 	go s.forward(publisher)
 
+	// Only the shim serving the sandbox takes it over, not the ones
+	// containerd runs to start or delete a shim.
+	if flag.Arg(0) == "" {
+		s.recoverSandbox()
+	}
+
 	return s, nil
 }
 
@@ -350,6 +358,10 @@ func (s *service) Create(ctx context.Context, r *taskAPI.CreateTaskRequest) (_ *
 
 	s.containers[r.ID] = c
 
+	if err := saveRecoveryRecord(c); err != nil {
+		logrus.WithError(err).WithField("container", r.ID).Warn("failed to save container recovery record")
+	}
+
 	s.send(&eventstypes.TaskCreate{
 		ContainerID: r.ID,
 		Bundle:      r.Bundle,
@@ -517,6 +529,8 @@ func (s *service) ResizePty(ctx context.Context, r *taskAPI.ResizePtyRequest) (_
 		return nil, err
 	}
 
+	// The resizes of a process not started yet, as web terminals send
+	// when they open, are applied once it starts.
 	processID := c.id
 	if r.ExecID != "" {
 		execs, err := c.getExec(r.ExecID)
@@ -526,14 +540,32 @@ func (s *service) ResizePty(ctx context.Context, r *taskAPI.ResizePtyRequest) (_
 		execs.tty.height = r.Height
 		execs.tty.width = r.Width
 
+		if execs.status == task.StatusCreated {
+			return empty, nil
+		}
+
 		processID = execs.id
+	} else {
+		c.height = r.Height
+		c.width = r.Width
 
+		if c.status == task.StatusCreated {
+			return empty, nil
+		}
 	}
+
 	err = s.sandbox.WinsizeProcess(c.id, processID, r.Height, r.Width)
 	if err != nil {
 		return nil, err
 	}
 
+	// A shim taking over the sandbox restores the terminal size.
+	if r.ExecID == "" {
+		if err := saveRecoveryRecord(c); err != nil {
+			logrus.WithError(err).WithField("container", c.id).Warn("failed to save container recovery record")
+		}
+	}
+
 	return empty, err
 }
 
@@ -773,7 +805,41 @@ func (s *service) Checkpoint(ctx context.Context, r *taskAPI.CheckpointTaskReque
 		rpcDurationsHistogram.WithLabelValues("checkpoint").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
 	}()
 
-	return nil, errdefs.ToGRPCf(errdefs.ErrNotImplemented, "service Checkpoint")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.getContainer(r.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.cType.IsSandbox() {
+		return nil, errdefs.ToGRPCf(errdefs.ErrNotImplemented, "sandbox checkpoint")
+	}
+
+	opts := vc.CheckpointOptions{
+		ImagesDir:    r.Path,
+		LeaveRunning: true,
+	}
+	if r.Options != nil {
+		v, err := typeurl.UnmarshalAny(r.Options)
+		if err != nil {
+			return nil, err
+		}
+		if o, ok := v.(*options.CheckpointOptions); ok {
+			opts.LeaveRunning = !o.Exit
+		}
+	}
+
+	if err := s.sandbox.CheckpointContainer(c.id, opts); err != nil {
+		return nil, err
+	}
+
+	s.send(&eventstypes.TaskCheckpointed{
+		ContainerID: c.id,
+	})
+
+	return empty, nil
 }
 
 // Connect returns shim information such as the shim's pid
@@ -954,17 +1020,22 @@ func (s *service) getContainerStatus(containerID string) (task.Status, error) {
 		return task.StatusUnknown, err
 	}
 
+	return taskStatus(cStatus.State.State), nil
+}
+
+// taskStatus converts a virtcontainers container state to a task status.
+func taskStatus(state types.StateString) task.Status {
 	var status task.Status
-	switch cStatus.State.State {
+	switch state {
 	case types.StateReady:
 		status = task.StatusCreated
 	case types.StateRunning:
 		status = task.StatusRunning
-	case types.StatePaused:
+	case types.StatePaused, types.StateSuspended:
 		status = task.StatusPaused
 	case types.StateStopped:
 		status = task.StatusStopped
 	}
 
-	return status, nil
+	return status
 }
@@ -9,6 +9,7 @@ import (
 	"context"
 	"expvar"
 	"io"
+	"net"
 	"net/http"
 	"net/http/pprof"
 	"path/filepath"
@@ -26,6 +27,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"golang.org/x/sys/unix"
 	"google.golang.org/grpc/codes"
 
 	mutils "github.com/kata-containers/kata-containers/src/runtime/pkg/utils"
@@ -140,6 +142,17 @@ func (s *service) startManagementServer(ctx context.Context, ociSpec *specs.Spec
 	// bind hanlder
 	m := http.NewServeMux()
 	m.Handle("/metrics", http.HandlerFunc(s.serveMetrics))
+	m.Handle("/pod-stats", http.HandlerFunc(s.servePodStats))
+	m.Handle("/network-stats", http.HandlerFunc(s.serveNetworkStats))
+	m.Handle("/network-diagnostics", http.HandlerFunc(s.serveNetworkDiagnostics))
+	m.Handle("/status", http.HandlerFunc(s.serveStatus))
+	m.Handle("/dump", http.HandlerFunc(s.serveDump))
+	m.Handle("/cleanup", rootOnly(s.serveCleanup))
+	m.Handle("/debug-console", rootOnly(s.serveDebugConsole))
+	m.Handle("/attach", rootOnly(s.serveAttach))
+	m.Handle("/profile", rootOnly(s.serveProfile))
+	m.Handle("/upgrade-virtiofsd", rootOnly(s.serveUpgradeVirtiofsd))
+	m.Handle("/live-update", rootOnly(s.serveLiveUpdate))
 	s.mountPprofHandle(m, ociSpec)
 
 	// register shim metrics
@@ -147,12 +160,55 @@ func (s *service) startManagementServer(ctx context.Context, ociSpec *specs.Spec
 
 	// register sandbox metrics
 	vc.RegisterMetrics()
+	prometheus.MustRegister(s.sandbox.MetricsCollector())
 
 	// start serve
-	svr := &http.Server{Handler: m}
+	svr := &http.Server{Handler: m, ConnContext: peerCredContext}
 	svr.Serve(listener)
 }
 
+type peerCredKey struct{}
+
+// peerCredContext records the credentials of the process at the other end
+// of the management socket connection c in the context of its requests.
+func peerCredContext(ctx context.Context, c net.Conn) context.Context {
+	uc, ok := c.(*net.UnixConn)
+	if !ok {
+		return ctx
+	}
+
+	raw, err := uc.SyscallConn()
+	if err != nil {
+		return ctx
+	}
+
+	var cred *unix.Ucred
+	raw.Control(func(fd uintptr) {
+		cred, err = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	})
+	if err != nil {
+		logrus.WithError(err).Warn("failed to get the credentials of a management socket client")
+		return ctx
+	}
+
+	return context.WithValue(ctx, peerCredKey{}, cred)
+}
+
+// rootOnly restricts the handler h, which can disrupt the sandbox or reach
+// into it, to clients running as root. The management socket itself may be
+// reachable by other users.
+func rootOnly(h http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cred, ok := r.Context().Value(peerCredKey{}).(*unix.Ucred)
+		if !ok || cred.Uid != 0 {
+			http.Error(w, "operation restricted to root", http.StatusForbidden)
+			return
+		}
+
+		h(w, r)
+	})
+}
+
 // mountServeDebug provides a debug endpoint
 func (s *service) mountPprofHandle(m *http.ServeMux, ociSpec *specs.Spec) {
 
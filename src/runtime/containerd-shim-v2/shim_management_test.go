@@ -6,9 +6,14 @@
 package containerdshim
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -61,3 +66,46 @@ go_threads 23
 	body = rr.Body.String()
 	assert.Equal(true, len(strings.Split(body, "\n")) > 0)
 }
+
+func TestRootOnly(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := rootOnly(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// Without the credentials of the client
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/cleanup", nil))
+	assert.Equal(http.StatusForbidden, rr.Code)
+
+	dir, err := ioutil.TempDir("", "shim-management")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "shim-monitor.sock")
+	listener, err := net.Listen("unix", socket)
+	assert.NoError(err)
+
+	svr := &http.Server{Handler: handler, ConnContext: peerCredContext}
+	go svr.Serve(listener)
+	defer svr.Close()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return net.Dial("unix", socket)
+			},
+		},
+	}
+
+	resp, err := client.Post("http://shim/cleanup", "", nil)
+	assert.NoError(err)
+	resp.Body.Close()
+
+	if os.Geteuid() == 0 {
+		assert.Equal(http.StatusNoContent, resp.StatusCode)
+	} else {
+		assert.Equal(http.StatusForbidden, resp.StatusCode)
+	}
+}
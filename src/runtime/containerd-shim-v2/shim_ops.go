@@ -0,0 +1,312 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+)
+
+// The handlers below expose operational commands on the shim management
+// socket, so that they can be driven from the node by a thin client such as
+// the "kata-runtime sandbox" command.
+
+// sandboxDump is the state of a sandbox collected for troubleshooting.
+type sandboxDump struct {
+	Time         time.Time        `json:"time"`
+	Status       vc.SandboxStatus `json:"status"`
+	PodStats     *podStats        `json:"podStats,omitempty"`
+	AgentMetrics string           `json:"agentMetrics,omitempty"`
+	Errors       []string         `json:"errors,omitempty"`
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logrus.WithError(err).Error("failed to encode response")
+	}
+}
+
+// serveStatus handles /status requests
+func (s *service) serveStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	status := s.sandbox.Status()
+	s.mu.Unlock()
+
+	writeJSON(w, status)
+}
+
+// serveDump handles /dump requests. Failing to collect a part of the dump
+// is reported in the dump itself, since a dump is mostly useful when the
+// sandbox is malfunctioning.
+func (s *service) serveDump(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	dump := sandboxDump{
+		Time:   time.Now(),
+		Status: s.sandbox.Status(),
+	}
+
+	if stats, err := s.getPodStats(); err != nil {
+		dump.Errors = append(dump.Errors, "pod stats: "+err.Error())
+	} else {
+		dump.PodStats = &stats
+	}
+
+	if metrics, err := s.sandbox.GetAgentMetrics(); err != nil {
+		dump.Errors = append(dump.Errors, "agent metrics: "+err.Error())
+	} else {
+		dump.AgentMetrics = metrics
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, dump)
+}
+
+// serveNetworkStats handles /network-stats requests
+func (s *service) serveNetworkStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := s.sandbox.EndpointStats()
+	if err != nil {
+		logrus.WithError(err).Error("failed to get network stats")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, stats)
+}
+
+// serveNetworkDiagnostics handles /network-diagnostics requests
+func (s *service) serveNetworkDiagnostics(w http.ResponseWriter, r *http.Request) {
+	found, err := s.sandbox.NetworkIncompatibilities()
+	if err != nil {
+		logrus.WithError(err).Error("failed to diagnose the network")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, found)
+}
+
+// serveCleanup handles /cleanup requests, forcibly stopping and deleting
+// the sandbox whatever the state of its containers.
+func (s *service) serveCleanup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "cleanup must be requested with POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// cancel watcher
+	if s.monitor != nil {
+		s.monitor <- nil
+		s.monitor = nil
+	}
+
+	logrus.WithField("sandbox", s.sandbox.ID()).Warn("forced sandbox cleanup requested")
+	cleanupSandbox(s)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serveDebugConsole handles /debug-console requests. The HTTP connection is
+// taken over and spliced to the sandbox console, on which the agent debug
+// console runs when enabled.
+//
+// The console is shared with its other clients, for instance the runtime
+// reading the guest logs.
+func (s *service) serveDebugConsole(w http.ResponseWriter, r *http.Request) {
+	console, err := s.sandbox.AttachConsole()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer console.Close()
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "connection cannot be taken over", http.StatusInternalServerError)
+		return
+	}
+
+	conn, buf, err := hijacker.Hijack()
+	if err != nil {
+		logrus.WithError(err).Error("failed to take over debug console connection")
+		return
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("HTTP/1.1 200 OK\r\n\r\n")); err != nil {
+		return
+	}
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(console, buf)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(conn, console)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+// serveAttach handles /attach requests, such as
+// /attach?container=<id>&exec=<id>. The HTTP connection is taken over and
+// attached to the I/O streams of the process, along with the containerd
+// fifos and the other attaches, according to the attach policy.
+func (s *service) serveAttach(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	mux, err := s.getAttachMux(query.Get("container"), query.Get("exec"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "connection cannot be taken over", http.StatusInternalServerError)
+		return
+	}
+
+	conn, buf, err := hijacker.Hijack()
+	if err != nil {
+		logrus.WithError(err).Error("failed to take over attach connection")
+		return
+	}
+
+	if err := mux.add(conn, "HTTP/1.1 200 OK\r\n\r\n"); err != nil {
+		if err == errAttachBusy || err == errAttachClosed {
+			fmt.Fprintf(conn, "HTTP/1.1 409 Conflict\r\nContent-Length: %d\r\n\r\n%s\n", len(err.Error())+1, err)
+		}
+		conn.Close()
+		return
+	}
+
+	mux.serve(conn, buf)
+}
+
+func parseProfileRequest(query url.Values) (vc.ProfileRequest, error) {
+	req := vc.ProfileRequest{
+		Tool:   vc.ProfileTool(query.Get("tool")),
+		Script: query.Get("script"),
+	}
+
+	duration, err := time.ParseDuration(query.Get("duration"))
+	if err != nil {
+		return req, fmt.Errorf("invalid profiling duration: %v", err)
+	}
+	req.Duration = duration
+
+	if f := query.Get("frequency"); f != "" {
+		frequency, err := strconv.ParseUint(f, 10, 32)
+		if err != nil {
+			return req, fmt.Errorf("invalid profiling frequency: %v", err)
+		}
+		req.Frequency = uint32(frequency)
+	}
+
+	return req, nil
+}
+
+// serveProfile handles /profile requests, such as
+// /profile?tool=perf&duration=10s, running a profiling session in the
+// guest and streaming its output as it comes.
+func (s *service) serveProfile(w http.ResponseWriter, r *http.Request) {
+	req, err := parseProfileRequest(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	output, err := s.sandbox.ProfileGuest(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer output.Close()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Trailer", katautils.ShimProfileErrorTrailer)
+
+	flusher, _ := w.(http.Flusher)
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := output.Read(buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			logrus.WithError(err).Error("guest profiling session failed")
+			w.Header().Set(katautils.ShimProfileErrorTrailer, strings.Join(strings.Fields(err.Error()), " "))
+			return
+		}
+	}
+}
+
+// serveUpgradeVirtiofsd handles /upgrade-virtiofsd requests, handing the
+// virtio-fs shares of the sandbox over to daemons started from the virtiofsd
+// binary as it is now, after a security update of the binary for instance.
+// The daemons run in the shim, which supervises them.
+func (s *service) serveUpgradeVirtiofsd(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "virtiofsd upgrade must be requested with POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.sandbox.UpgradeVirtiofsd(); err != nil {
+		logrus.WithError(err).Error("failed to upgrade virtiofsd")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serveLiveUpdate handles /live-update requests, moving the VM of the
+// sandbox to a new process of the hypervisor, started from the hypervisor
+// binary as it is now, after a security update of the binary for instance.
+func (s *service) serveLiveUpdate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "live update must be requested with POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.sandbox.LiveUpdate(); err != nil {
+		logrus.WithError(err).Error("failed to live update the sandbox")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
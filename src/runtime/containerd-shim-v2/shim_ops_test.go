@@ -0,0 +1,297 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeDump(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	// A failure to collect a part of the dump is reported in the dump
+	sandbox.GetAgentMetricsFunc = func() (string, error) {
+		return "", fmt.Errorf("agent unreachable")
+	}
+	defer func() {
+		sandbox.GetAgentMetricsFunc = nil
+	}()
+
+	rr := httptest.NewRecorder()
+	s.serveDump(rr, httptest.NewRequest(http.MethodGet, "/dump", nil))
+	assert.Equal(http.StatusOK, rr.Code)
+
+	var dump sandboxDump
+	assert.NoError(json.Unmarshal(rr.Body.Bytes(), &dump))
+	assert.False(dump.Time.IsZero())
+	assert.NotNil(dump.PodStats)
+	assert.Empty(dump.AgentMetrics)
+	assert.Equal([]string{"agent metrics: agent unreachable"}, dump.Errors)
+}
+
+func TestServeCleanupMethod(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    &vcmock.Sandbox{MockID: testSandboxID},
+		containers: make(map[string]*container),
+	}
+
+	rr := httptest.NewRecorder()
+	s.serveCleanup(rr, httptest.NewRequest(http.MethodGet, "/cleanup", nil))
+	assert.Equal(http.StatusMethodNotAllowed, rr.Code)
+}
+
+func TestServeDebugConsoleUnavailable(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    &vcmock.Sandbox{MockID: testSandboxID},
+		containers: make(map[string]*container),
+	}
+
+	rr := httptest.NewRecorder()
+	s.serveDebugConsole(rr, httptest.NewRequest(http.MethodGet, "/debug-console", nil))
+	assert.Equal(http.StatusServiceUnavailable, rr.Code)
+}
+
+func TestServeDebugConsoleAttach(t *testing.T) {
+	assert := assert.New(t)
+
+	// The console of the sandbox the shim holds is attached to.
+	console, guest := net.Pipe()
+	defer guest.Close()
+
+	attached := false
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+		AttachConsoleFunc: func() (io.ReadWriteCloser, error) {
+			attached = true
+			return console, nil
+		},
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	// The recorder cannot be taken over, and the console is detached.
+	rr := httptest.NewRecorder()
+	s.serveDebugConsole(rr, httptest.NewRequest(http.MethodGet, "/debug-console", nil))
+	assert.True(attached)
+	assert.Equal(http.StatusInternalServerError, rr.Code)
+
+	_, err := console.Write([]byte("x"))
+	assert.Error(err)
+}
+
+func TestServeProfile(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	rr := httptest.NewRecorder()
+	s.serveProfile(rr, httptest.NewRequest(http.MethodGet, "/profile?tool=perf", nil))
+	assert.Equal(http.StatusBadRequest, rr.Code)
+
+	var req vc.ProfileRequest
+	sandbox.ProfileGuestFunc = func(r vc.ProfileRequest) (io.ReadCloser, error) {
+		req = r
+		return ioutil.NopCloser(strings.NewReader("samples")), nil
+	}
+	defer func() {
+		sandbox.ProfileGuestFunc = nil
+	}()
+
+	rr = httptest.NewRecorder()
+	s.serveProfile(rr, httptest.NewRequest(http.MethodGet, "/profile?tool=perf&duration=10s&frequency=99", nil))
+	assert.Equal(http.StatusOK, rr.Code)
+	assert.Equal("samples", rr.Body.String())
+	assert.Equal(vc.ProfileRequest{Tool: vc.ProfilePerf, Duration: 10 * time.Second, Frequency: 99}, req)
+	assert.Empty(rr.Result().Trailer.Get(katautils.ShimProfileErrorTrailer))
+}
+
+func TestServeNetworkStats(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	sandbox.EndpointStatsFunc = func() ([]vc.EndpointStats, error) {
+		return []vc.EndpointStats{
+			{
+				Name: "eth0",
+				Type: vc.VethEndpointType,
+				Links: []vc.EndpointLinkStats{
+					{Name: "tap0_kata", RxPackets: 10, TxErrors: 2},
+				},
+			},
+		}, nil
+	}
+
+	rr := httptest.NewRecorder()
+	s.serveNetworkStats(rr, httptest.NewRequest(http.MethodGet, "/network-stats", nil))
+	assert.Equal(http.StatusOK, rr.Code)
+
+	var stats []vc.EndpointStats
+	assert.NoError(json.Unmarshal(rr.Body.Bytes(), &stats))
+	assert.Len(stats, 1)
+	assert.Equal(vc.VethEndpointType, stats[0].Type)
+	assert.Equal(uint64(2), stats[0].Links[0].TxErrors)
+
+	sandbox.EndpointStatsFunc = func() ([]vc.EndpointStats, error) {
+		return nil, fmt.Errorf("netns gone")
+	}
+
+	rr = httptest.NewRecorder()
+	s.serveNetworkStats(rr, httptest.NewRequest(http.MethodGet, "/network-stats", nil))
+	assert.Equal(http.StatusInternalServerError, rr.Code)
+}
+
+func TestServeNetworkDiagnostics(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	sandbox.NetworkIncompatibilitiesFunc = func() ([]vc.NetworkIncompatibility, error) {
+		return []vc.NetworkIncompatibility{
+			{Problem: "iptables table nat is in use, the traffic redirected with tc filters bypasses it"},
+		}, nil
+	}
+
+	rr := httptest.NewRecorder()
+	s.serveNetworkDiagnostics(rr, httptest.NewRequest(http.MethodGet, "/network-diagnostics", nil))
+	assert.Equal(http.StatusOK, rr.Code)
+
+	var found []vc.NetworkIncompatibility
+	assert.NoError(json.Unmarshal(rr.Body.Bytes(), &found))
+	assert.Len(found, 1)
+	assert.Empty(found[0].Endpoint)
+}
+
+func TestServeUpgradeVirtiofsd(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	rr := httptest.NewRecorder()
+	s.serveUpgradeVirtiofsd(rr, httptest.NewRequest(http.MethodGet, "/upgrade-virtiofsd", nil))
+	assert.Equal(http.StatusMethodNotAllowed, rr.Code)
+
+	rr = httptest.NewRecorder()
+	s.serveUpgradeVirtiofsd(rr, httptest.NewRequest(http.MethodPost, "/upgrade-virtiofsd", nil))
+	assert.Equal(http.StatusInternalServerError, rr.Code)
+
+	upgraded := false
+	sandbox.UpgradeVirtiofsdFunc = func() error {
+		upgraded = true
+		return nil
+	}
+	defer func() {
+		sandbox.UpgradeVirtiofsdFunc = nil
+	}()
+
+	rr = httptest.NewRecorder()
+	s.serveUpgradeVirtiofsd(rr, httptest.NewRequest(http.MethodPost, "/upgrade-virtiofsd", nil))
+	assert.Equal(http.StatusNoContent, rr.Code)
+	assert.True(upgraded)
+}
+
+func TestServeLiveUpdate(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	rr := httptest.NewRecorder()
+	s.serveLiveUpdate(rr, httptest.NewRequest(http.MethodGet, "/live-update", nil))
+	assert.Equal(http.StatusMethodNotAllowed, rr.Code)
+
+	rr = httptest.NewRecorder()
+	s.serveLiveUpdate(rr, httptest.NewRequest(http.MethodPost, "/live-update", nil))
+	assert.Equal(http.StatusInternalServerError, rr.Code)
+
+	updated := false
+	sandbox.LiveUpdateFunc = func() error {
+		updated = true
+		return nil
+	}
+	defer func() {
+		sandbox.LiveUpdateFunc = nil
+	}()
+
+	rr = httptest.NewRecorder()
+	s.serveLiveUpdate(rr, httptest.NewRequest(http.MethodPost, "/live-update", nil))
+	assert.Equal(http.StatusNoContent, rr.Code)
+	assert.True(updated)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/containerd/containerd/api/types/task"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 )
 
 func startContainer(ctx context.Context, s *service, c *container) error {
@@ -40,6 +41,11 @@ func startContainer(ctx context.Context, s *service, c *container) error {
 		// We don't rely on the context passed to startContainer as it can be cancelled after
 		// this rpc call.
 		go watchOOMEvents(s.ctx, s)
+	} else if c.checkpoint != "" {
+		err := s.sandbox.RestoreContainer(c.id, vc.CheckpointOptions{ImagesDir: c.checkpoint})
+		if err != nil {
+			return err
+		}
 	} else {
 		_, err := s.sandbox.StartContainer(c.id)
 		if err != nil {
@@ -47,9 +53,16 @@ func startContainer(ctx context.Context, s *service, c *container) error {
 		}
 	}
 
+	// Apply the terminal size resized to before the process started
+	if c.terminal && c.height != 0 && c.width != 0 {
+		if err := s.sandbox.WinsizeProcess(c.id, c.id, c.height, c.width); err != nil {
+			return err
+		}
+	}
+
 	// Run post-start OCI hooks.
 	err := katautils.EnterNetNS(s.sandbox.GetNetNs(), func() error {
-		return katautils.PostStartHooks(ctx, *c.spec, s.sandbox.ID(), c.bundle)
+		return katautils.PostStartHooks(ctx, katautils.HostHooks(*c.spec, s.guestHooks()), s.sandbox.ID(), c.bundle)
 	})
 	if err != nil {
 		return err
@@ -57,6 +70,12 @@ func startContainer(ctx context.Context, s *service, c *container) error {
 
 	c.status = task.StatusRunning
 
+	return attachIO(ctx, s, c)
+}
+
+// attachIO connects the container process I/O streams to the fifos provided
+// by containerd, and waits for the process in the background.
+func attachIO(ctx context.Context, s *service, c *container) error {
 	stdin, stdout, stderr, err := s.sandbox.IOStream(c.id, c.id)
 	if err != nil {
 		return err
@@ -68,7 +87,8 @@ func startContainer(ctx context.Context, s *service, c *container) error {
 			return err
 		}
 		c.ttyio = tty
-		go ioCopy(c.exitIOch, tty, stdin, stdout, stderr)
+		c.attaches = newAttachMux(s.attachPolicy(), stdin)
+		go ioCopy(c.exitIOch, tty, c.attaches, stdout, stderr)
 	} else {
 		//close the io exit channel, since there is no io for this container,
 		//otherwise the following wait goroutine will hang on this channel.
@@ -100,6 +120,9 @@ func startExec(ctx context.Context, s *service, containerID, execID string) (*ex
 	execs.id = proc.Token
 
 	execs.status = task.StatusRunning
+
+	// Apply the terminal size of the exec spec, or resized to before the
+	// process started
 	if execs.tty.height != 0 && execs.tty.width != 0 {
 		err = s.sandbox.WinsizeProcess(c.id, execs.id, execs.tty.height, execs.tty.width)
 		if err != nil {
@@ -116,10 +139,20 @@ func startExec(ctx context.Context, s *service, containerID, execID string) (*ex
 		return nil, err
 	}
 	execs.ttyio = tty
+	execs.attaches = newAttachMux(s.attachPolicy(), stdin)
 
-	go ioCopy(execs.exitIOch, tty, stdin, stdout, stderr)
+	go ioCopy(execs.exitIOch, tty, execs.attaches, stdout, stderr)
 
 	go wait(s, c, execID)
 
 	return execs, nil
 }
+
+// guestHooks returns the types of OCI hooks the agent runs in the guest,
+// which the shim leaves out when running the hooks on the host.
+func (s *service) guestHooks() []string {
+	if s.config == nil {
+		return nil
+	}
+	return s.config.GuestHooks
+}
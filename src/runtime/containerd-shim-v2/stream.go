@@ -85,7 +85,10 @@ func newTtyIO(ctx context.Context, stdin, stdout, stderr string, console bool) (
 	return ttyIO, nil
 }
 
-func ioCopy(exitch chan struct{}, tty *ttyIO, stdinPipe io.WriteCloser, stdoutPipe, stderrPipe io.Reader) {
+// ioCopy copies the I/O streams of a process from and to the containerd
+// fifos, the output going to the attaches of mux too, and the input of the
+// attaches being written to the process along with the stdin fifo one.
+func ioCopy(exitch chan struct{}, tty *ttyIO, mux *attachMux, stdoutPipe, stderrPipe io.Reader) {
 	var wg sync.WaitGroup
 	var closeOnce sync.Once
 
@@ -94,7 +97,7 @@ func ioCopy(exitch chan struct{}, tty *ttyIO, stdinPipe io.WriteCloser, stdoutPi
 		go func() {
 			p := bufPool.Get().(*[]byte)
 			defer bufPool.Put(p)
-			io.CopyBuffer(stdinPipe, tty.Stdin, *p)
+			io.CopyBuffer(mux.stdinWriter(), tty.Stdin, *p)
 			wg.Done()
 		}()
 	}
@@ -105,7 +108,7 @@ func ioCopy(exitch chan struct{}, tty *ttyIO, stdinPipe io.WriteCloser, stdoutPi
 		go func() {
 			p := bufPool.Get().(*[]byte)
 			defer bufPool.Put(p)
-			io.CopyBuffer(tty.Stdout, stdoutPipe, *p)
+			io.CopyBuffer(io.MultiWriter(tty.Stdout, mux), stdoutPipe, *p)
 			wg.Done()
 			closeOnce.Do(tty.close)
 		}()
@@ -116,12 +119,13 @@ func ioCopy(exitch chan struct{}, tty *ttyIO, stdinPipe io.WriteCloser, stdoutPi
 		go func() {
 			p := bufPool.Get().(*[]byte)
 			defer bufPool.Put(p)
-			io.CopyBuffer(tty.Stderr, stderrPipe, *p)
+			io.CopyBuffer(io.MultiWriter(tty.Stderr, mux), stderrPipe, *p)
 			wg.Done()
 		}()
 	}
 
 	wg.Wait()
 	closeOnce.Do(tty.close)
+	mux.close()
 	close(exitch)
 }
@@ -60,7 +60,7 @@ func wait(s *service, c *container, execID string) (int32, error) {
 			if s.monitor != nil {
 				s.monitor <- nil
 			}
-			if err = s.sandbox.Stop(true); err != nil {
+			if err = stopSandbox(s); err != nil {
 				logrus.WithField("sandbox", s.sandbox.ID()).Error("failed to stop sandbox")
 			}
 
@@ -92,6 +92,18 @@ func wait(s *service, c *container, execID string) (int32, error) {
 	return ret, nil
 }
 
+// stopSandbox stops the sandbox through the graceful shutdown of its VM,
+// and forcibly when the graceful stop fails.
+func stopSandbox(s *service) error {
+	err := s.sandbox.Stop(false)
+	if err == nil {
+		return nil
+	}
+
+	logrus.WithError(err).WithField("sandbox", s.sandbox.ID()).Warn("failed to stop sandbox gracefully, forcing it")
+	return s.sandbox.Stop(true)
+}
+
 func watchSandbox(s *service) {
 	if s.monitor == nil {
 		return
@@ -106,7 +118,13 @@ func watchSandbox(s *service) {
 	defer s.mu.Unlock()
 	// sandbox malfunctioning, cleanup as much as we can
 	logrus.WithError(err).Warn("sandbox stopped unexpectedly")
-	err = s.sandbox.Stop(true)
+	cleanupSandbox(s)
+}
+
+// cleanupSandbox forcibly stops and deletes the sandbox, and unmounts the
+// container root filesystems. It must be called with s.mu held.
+func cleanupSandbox(s *service) {
+	err := s.sandbox.Stop(true)
 	if err != nil {
 		logrus.WithError(err).Warn("stop sandbox failed")
 	}
@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStopSandbox(t *testing.T) {
+	assert := assert.New(t)
+
+	var stops []bool
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+		StopFunc: func(force bool) error {
+			stops = append(stops, force)
+			return nil
+		},
+	}
+
+	s := &service{
+		id:      testSandboxID,
+		sandbox: sandbox,
+	}
+
+	// The VM is shut down gracefully
+	assert.NoError(stopSandbox(s))
+	assert.Equal([]bool{false}, stops)
+
+	// The sandbox is forcibly stopped when the graceful stop fails
+	stops = nil
+	sandbox.StopFunc = func(force bool) error {
+		stops = append(stops, force)
+		if !force {
+			return errors.New("agent not reachable")
+		}
+		return nil
+	}
+	assert.NoError(stopSandbox(s))
+	assert.Equal([]bool{false, true}, stops)
+}
@@ -5,7 +5,7 @@ go 1.14
 require (
 	github.com/BurntSushi/toml v0.3.1
 	github.com/blang/semver v0.0.0-20190414102917-ba2c2ddd8906
-	github.com/cilium/ebpf v0.0.0-20200421083123-d05ecd062fb1 // indirect
+	github.com/cilium/ebpf v0.0.0-20200421083123-d05ecd062fb1
 	github.com/codahale/hdrhistogram v0.0.0-20161010025455-3a0bb77429bd // indirect
 	github.com/containerd/cgroups v0.0.0-20190717030353-c4b9ac5c7601
 	github.com/containerd/console v0.0.0-20191206165004-02ecf6a7291e
@@ -39,7 +39,7 @@ require (
 	github.com/mitchellh/mapstructure v1.1.2
 	github.com/opencontainers/image-spec v1.0.1 // indirect
 	github.com/opencontainers/runc v1.0.0-rc9.0.20200102164712-2b52db75279c
-	github.com/opencontainers/runtime-spec v1.0.2-0.20190408193819-a1b50f621a48
+	github.com/opencontainers/runtime-spec v1.0.3-0.20220909204839-494a5a6aca78
 	github.com/opencontainers/selinux v1.4.0
 	github.com/opentracing/opentracing-go v1.1.0
 	github.com/pkg/errors v0.8.1
@@ -10,14 +10,18 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"path/filepath"
 	goruntime "runtime"
 	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	govmmQemu "github.com/intel/govmm/qemu"
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	exp "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/experimental"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/assetstore"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/oci"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
 	"github.com/sirupsen/logrus"
@@ -55,6 +59,10 @@ const (
 	// supported proxy component types
 	kataProxyTableType = "kata"
 
+	// default agent component type, the other types select the agents
+	// registered in virtcontainers
+	kataAgentTableType = "kata"
+
 	// the maximum amount of PCI bridges that can be cold plugged in a VM
 	maxPCIBridges uint32 = 5
 )
@@ -83,6 +91,7 @@ type hypervisor struct {
 	Initrd                  string   `toml:"initrd"`
 	Image                   string   `toml:"image"`
 	Firmware                string   `toml:"firmware"`
+	FirmwareVars            string   `toml:"firmware_vars"`
 	MachineAccelerators     string   `toml:"machine_accelerators"`
 	CPUFeatures             string   `toml:"cpu_features"`
 	KernelParams            string   `toml:"kernel_params"`
@@ -94,11 +103,14 @@ type hypervisor struct {
 	VirtioFSCache           string   `toml:"virtio_fs_cache"`
 	VirtioFSExtraArgs       []string `toml:"virtio_fs_extra_args"`
 	VirtioFSCacheSize       uint32   `toml:"virtio_fs_cache_size"`
+	VirtioFSDaemonRestarts  uint32   `toml:"virtio_fs_daemon_restarts"`
 	BlockDeviceCacheSet     bool     `toml:"block_device_cache_set"`
 	BlockDeviceCacheDirect  bool     `toml:"block_device_cache_direct"`
 	BlockDeviceCacheNoflush bool     `toml:"block_device_cache_noflush"`
+	PRHelperSocket          string   `toml:"pr_helper_socket"`
 	EnableVhostUserStore    bool     `toml:"enable_vhost_user_store"`
 	VhostUserStorePath      string   `toml:"vhost_user_store_path"`
+	VhostUserReconnect      uint32   `toml:"vhost_user_reconnect"`
 	NumVCPUs                int32    `toml:"default_vcpus"`
 	DefaultMaxVCPUs         uint32   `toml:"default_maxvcpus"`
 	MemorySize              uint32   `toml:"default_memory"`
@@ -111,6 +123,7 @@ type hypervisor struct {
 	MemPrealloc             bool     `toml:"enable_mem_prealloc"`
 	HugePages               bool     `toml:"enable_hugepages"`
 	VirtioMem               bool     `toml:"enable_virtio_mem"`
+	FreePageReporting       bool     `toml:"enable_free_page_reporting"`
 	IOMMU                   bool     `toml:"enable_iommu"`
 	FileBackedMemRootDir    string   `toml:"file_mem_backend"`
 	Swap                    bool     `toml:"enable_swap"`
@@ -120,10 +133,29 @@ type hypervisor struct {
 	UseVSock                bool     `toml:"use_vsock"`
 	DisableImageNvdimm      bool     `toml:"disable_image_nvdimm"`
 	HotplugVFIOOnRootBus    bool     `toml:"hotplug_vfio_on_root_bus"`
+	VFIOAutoBindDrivers     []string `toml:"vfio_auto_bind_drivers"`
+	VFIOPeerToPeer          bool     `toml:"enable_vfio_p2p"`
+	GuestNUMA               bool     `toml:"enable_guest_numa"`
+	VCPUPinning             bool     `toml:"enable_vcpu_pinning"`
 	DisableVhostNet         bool     `toml:"disable_vhost_net"`
+	NetworkQueues           uint32   `toml:"network_queues"`
+	NetworkMaxQueues        uint32   `toml:"network_max_queues"`
+	EnableGuestSuspend      bool     `toml:"enable_guest_suspend"`
 	GuestHookPath           string   `toml:"guest_hook_path"`
 	RxRateLimiterMaxRate    uint64   `toml:"rx_rate_limiter_max_rate"`
 	TxRateLimiterMaxRate    uint64   `toml:"tx_rate_limiter_max_rate"`
+	EnableVMMIsolation      bool     `toml:"enable_vmm_isolation"`
+	VMMSeccomp              string   `toml:"vmm_seccomp"`
+	MemoryEncryption        string   `toml:"memory_encryption"`
+	SEVPolicy               uint64   `toml:"sev_policy"`
+	SEVCBitPos              uint32   `toml:"sev_cbitpos"`
+	SEVReducedPhysBits      uint32   `toml:"sev_reduced_phys_bits"`
+	SEVDHCertPath           string   `toml:"sev_dh_cert"`
+	SEVSessionPath          string   `toml:"sev_session"`
+
+	HypervisorProfiles map[string]string `toml:"hypervisor_profiles"`
+
+	KernelParamsProfiles map[string]string `toml:"kernel_params_profiles"`
 }
 
 type proxy struct {
@@ -136,10 +168,67 @@ type runtime struct {
 	Tracing             bool     `toml:"enable_tracing"`
 	DisableNewNetNs     bool     `toml:"disable_new_netns"`
 	DisableGuestSeccomp bool     `toml:"disable_guest_seccomp"`
+	GuestHooks          []string `toml:"guest_hooks"`
+	SandboxRegistrars   []string `toml:"sandbox_registrars"`
 	SandboxCgroupOnly   bool     `toml:"sandbox_cgroup_only"`
 	Experimental        []string `toml:"experimental"`
 	InterNetworkModel   string   `toml:"internetworking_model"`
 	EnablePprof         bool     `toml:"enable_pprof"`
+	AttachPolicy        string   `toml:"attach_policy"`
+	IntegrityManifest   string   `toml:"integrity_manifest"`
+	IntegrityMode       string   `toml:"integrity_mode"`
+	MaxMemory           uint32   `toml:"sandbox_max_memory"`
+	MaxVCPUs            uint32   `toml:"sandbox_max_vcpus"`
+	MaxHotplugDevices   uint32   `toml:"sandbox_max_hotplug_devices"`
+	MaxInterfaces       uint32   `toml:"sandbox_max_interfaces"`
+	NodeMaxMemory       uint64   `toml:"node_max_memory"`
+	NodeMaxHotplugDevs  uint32   `toml:"node_max_hotplug_devices"`
+	NodeMaxSandboxes    uint32   `toml:"node_max_sandboxes"`
+	ShrinkCooldown      uint32   `toml:"sandbox_shrink_cooldown"`
+	VCPUShrinkThreshold uint32   `toml:"sandbox_vcpu_shrink_threshold"`
+	MemShrinkThreshold  uint32   `toml:"sandbox_memory_shrink_threshold"`
+	ShutdownGuest       uint32   `toml:"shutdown_guest_timeout"`
+	ShutdownACPI        uint32   `toml:"shutdown_acpi_timeout"`
+	ShutdownQuit        uint32   `toml:"shutdown_quit_timeout"`
+	HotplugPlanning     bool     `toml:"enable_hotplug_planning"`
+	HotplugVCPUs        uint32   `toml:"hotplug_headroom_vcpus"`
+	HotplugMemSlots     uint32   `toml:"hotplug_headroom_memory_slots"`
+	HotplugDevices      uint32   `toml:"hotplug_headroom_devices"`
+	AdmissionMode       string   `toml:"sandbox_admission_policy"`
+	OvercommitRatio     float64  `toml:"sandbox_overcommit_ratio"`
+	AdmissionTimeout    uint32   `toml:"sandbox_admission_timeout"`
+	CPUSharesAggregate  string   `toml:"sandbox_cpu_shares"`
+	GuestCPUSharesScale float64  `toml:"guest_cpu_shares_scale"`
+	VCPUNice            bool     `toml:"enable_vcpu_nice"`
+	GuestOS             string   `toml:"guest_os"`
+	RootfsDiskFstype    string   `toml:"rootfs_disk_fstype"`
+	RootfsDiskConverter string   `toml:"rootfs_disk_converter"`
+	CoreDumpDir         string   `toml:"core_dump_dir"`
+	AssetStore          string   `toml:"asset_store"`
+	AssetSigningKey     string   `toml:"asset_signing_key"`
+	PersistDriver       string   `toml:"persist_driver"`
+
+	Lightweight             bool     `toml:"enable_lightweight_sandbox"`
+	LightweightInterfaces   []string `toml:"lightweight_interfaces"`
+	LightweightMemBlockSize uint32   `toml:"lightweight_guest_memory_block_size"`
+	LightweightMemProbe     bool     `toml:"lightweight_guest_memory_hotplug_probe"`
+	LightweightSeccomp      bool     `toml:"lightweight_guest_seccomp"`
+
+	TCFilterKeepOffloads bool   `toml:"tcfilter_keep_offloads"`
+	TCFilterFixupProg    string `toml:"tcfilter_fixup_prog"`
+
+	MACAllocation string   `toml:"mac_allocation"`
+	MACPool       []string `toml:"mac_pool"`
+
+	DHCPInterfaces []string `toml:"dhcp_interfaces"`
+
+	InterfaceEndpoints []string `toml:"interface_endpoints"`
+
+	NetworkProvider string `toml:"network_provider"`
+
+	TrafficCapture            bool   `toml:"enable_traffic_capture"`
+	TrafficCaptureMaxDuration uint32 `toml:"traffic_capture_max_duration"`
+	TrafficCaptureSnapLen     uint32 `toml:"traffic_capture_snaplen"`
 }
 
 type agent struct {
@@ -148,6 +237,31 @@ type agent struct {
 	TraceMode     string   `toml:"trace_mode"`
 	TraceType     string   `toml:"trace_type"`
 	KernelModules []string `toml:"kernel_modules"`
+	NTPServers    []string `toml:"ntp_servers"`
+	NTPPools      []string `toml:"ntp_pools"`
+	PTPKVM        bool     `toml:"ptp_kvm"`
+	Timezone      string   `toml:"timezone"`
+	CABundle      string   `toml:"ca_bundle"`
+	Locale        string   `toml:"locale"`
+	GuestFiles    []string `toml:"guest_files"`
+	KdumpMemory   uint32   `toml:"kdump_memory"`
+	KdumpDir      string   `toml:"kdump_dir"`
+	KdumpDevice   string   `toml:"kdump_device"`
+
+	KdumpRedactPaths []string `toml:"kdump_redact_paths"`
+
+	ProfilingTools       []string `toml:"profiling_tools"`
+	ProfilingMaxDuration uint32   `toml:"profiling_max_duration"`
+
+	LivepatchModules []string `toml:"livepatch_modules"`
+
+	CacheDropInterval  uint32 `toml:"cache_drop_interval"`
+	CacheDropThreshold uint32 `toml:"cache_drop_threshold"`
+	VFSCachePressure   uint32 `toml:"vfs_cache_pressure"`
+	IdleScanInterval   uint32 `toml:"idle_scan_interval"`
+
+	MetadataAllowedPaths []string `toml:"metadata_allowed_paths"`
+	MetadataURL          string   `toml:"metadata_url"`
 }
 
 type netmon struct {
@@ -166,6 +280,29 @@ func (h hypervisor) path() (string, error) {
 	return ResolvePath(p)
 }
 
+// hypervisorProfiles returns the resolved hypervisor paths of the profiles.
+func (h hypervisor) hypervisorProfiles() (map[string]string, error) {
+	if len(h.HypervisorProfiles) == 0 {
+		return nil, nil
+	}
+
+	profiles := make(map[string]string, len(h.HypervisorProfiles))
+	for name, p := range h.HypervisorProfiles {
+		if name == "" {
+			return nil, errors.New("hypervisor profile without name")
+		}
+
+		path, err := ResolvePath(p)
+		if err != nil {
+			return nil, fmt.Errorf("hypervisor profile %q: %v", name, err)
+		}
+
+		profiles[name] = path
+	}
+
+	return profiles, nil
+}
+
 func (h hypervisor) ctlpath() (string, error) {
 	p := h.CtlPath
 
@@ -186,6 +323,16 @@ func (h hypervisor) jailerPath() (string, error) {
 	return ResolvePath(p)
 }
 
+// resolveAssetPath resolves the path of a guest asset, unless it is the
+// reference of a remote asset, fetched into the asset store.
+func resolveAssetPath(path string) (string, error) {
+	if assetstore.IsRemote(path) {
+		return path, nil
+	}
+
+	return ResolvePath(path)
+}
+
 func (h hypervisor) kernel() (string, error) {
 	p := h.Kernel
 
@@ -193,7 +340,7 @@ func (h hypervisor) kernel() (string, error) {
 		p = defaultKernelPath
 	}
 
-	return ResolvePath(p)
+	return resolveAssetPath(p)
 }
 
 func (h hypervisor) initrd() (string, error) {
@@ -203,7 +350,7 @@ func (h hypervisor) initrd() (string, error) {
 		return "", errors.New("initrd is not set")
 	}
 
-	return ResolvePath(p)
+	return resolveAssetPath(p)
 }
 
 func (h hypervisor) image() (string, error) {
@@ -213,7 +360,7 @@ func (h hypervisor) image() (string, error) {
 		return "", errors.New("image is not set")
 	}
 
-	return ResolvePath(p)
+	return resolveAssetPath(p)
 }
 
 func (h hypervisor) firmware() (string, error) {
@@ -226,7 +373,15 @@ func (h hypervisor) firmware() (string, error) {
 		p = defaultFirmwarePath
 	}
 
-	return ResolvePath(p)
+	return resolveAssetPath(p)
+}
+
+func (h hypervisor) firmwareVars() (string, error) {
+	if h.FirmwareVars == "" {
+		return "", nil
+	}
+
+	return resolveAssetPath(h.FirmwareVars)
 }
 
 func (h hypervisor) machineAccelerators() string {
@@ -263,6 +418,34 @@ func (h hypervisor) kernelParams() string {
 	return h.KernelParams
 }
 
+// kernelParamsProfiles returns the kernel parameters of the profiles.
+func (h hypervisor) kernelParamsProfiles() (map[string][]vc.Param, error) {
+	if len(h.KernelParamsProfiles) == 0 {
+		return nil, nil
+	}
+
+	profiles := make(map[string][]vc.Param, len(h.KernelParamsProfiles))
+	for name, params := range h.KernelParamsProfiles {
+		if name == "" {
+			return nil, errors.New("kernel parameters profile without name")
+		}
+
+		profile := vc.DeserializeParams(strings.Fields(params))
+		if len(profile) == 0 {
+			return nil, fmt.Errorf("kernel parameters profile %q: no parameters", name)
+		}
+		for _, p := range profile {
+			if p.Key == "" {
+				return nil, fmt.Errorf("kernel parameters profile %q: parameter %q without name", name, "="+p.Value)
+			}
+		}
+
+		profiles[name] = profile
+	}
+
+	return profiles, nil
+}
+
 func (h hypervisor) machineType() string {
 	if h.MachineType == "" {
 		return defaultMachineType
@@ -374,7 +557,7 @@ func (h hypervisor) blockDeviceDriver() (string, error) {
 }
 
 func (h hypervisor) sharedFS() (string, error) {
-	supportedSharedFS := []string{config.Virtio9P, config.VirtioFS}
+	supportedSharedFS := []string{config.Virtio9P, config.VirtioFS, config.NoSharedFS}
 
 	if h.SharedFS == "" {
 		return config.Virtio9P, nil
@@ -480,6 +663,70 @@ func (a agent) kernelModules() []string {
 	return a.KernelModules
 }
 
+func (a agent) timeSync() vc.TimeSync {
+	return vc.TimeSync{
+		Servers: a.NTPServers,
+		Pools:   a.NTPPools,
+		PTPKVM:  a.PTPKVM,
+	}
+}
+
+func (a agent) kdump() vc.Kdump {
+	return vc.Kdump{
+		CrashKernelMB: a.KdumpMemory,
+		HostDir:       a.KdumpDir,
+		GuestDevice:   a.KdumpDevice,
+		RedactPaths:   a.KdumpRedactPaths,
+	}
+}
+
+func (a agent) guestProfiling() vc.GuestProfiling {
+	return vc.GuestProfiling{
+		Tools:       a.ProfilingTools,
+		MaxDuration: time.Duration(a.ProfilingMaxDuration) * time.Second,
+	}
+}
+
+func (a agent) guestMemoryReclaim() vc.GuestMemoryReclaim {
+	return vc.GuestMemoryReclaim{
+		CacheDropInterval:    time.Duration(a.CacheDropInterval) * time.Second,
+		CacheDropThresholdMB: a.CacheDropThreshold,
+		VFSCachePressure:     a.VFSCachePressure,
+		IdleScanInterval:     time.Duration(a.IdleScanInterval) * time.Second,
+	}
+}
+
+func (a agent) metadataProxy() vc.MetadataProxy {
+	return vc.MetadataProxy{
+		AllowedPaths: a.MetadataAllowedPaths,
+		URL:          a.MetadataURL,
+	}
+}
+
+func (a agent) guestLivepatch() vc.GuestLivepatch {
+	return vc.GuestLivepatch{
+		Modules: a.LivepatchModules,
+	}
+}
+
+func (a agent) guestProvisioning() (vc.GuestProvisioning, error) {
+	p := vc.GuestProvisioning{
+		Timezone: a.Timezone,
+		CABundle: a.CABundle,
+		Locale:   a.Locale,
+	}
+
+	for _, f := range a.GuestFiles {
+		paths := strings.SplitN(f, ":", 2)
+		if len(paths) != 2 {
+			return vc.GuestProvisioning{}, fmt.Errorf("Invalid guest file %q, expected host-path:guest-path", f)
+		}
+		p.Files = append(p.Files, vc.GuestFile{HostPath: paths[0], GuestPath: paths[1]})
+	}
+
+	return p, nil
+}
+
 func (n netmon) enable() bool {
 	return n.Enable
 }
@@ -523,6 +770,10 @@ func newFirecrackerHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 	}
 
 	kernelParams := h.kernelParams()
+	kernelParamsProfiles, err := h.kernelParamsProfiles()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
 
 	blockDriver, err := h.blockDeviceDriver()
 	if err != nil {
@@ -547,6 +798,7 @@ func newFirecrackerHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		ImagePath:             image,
 		FirmwarePath:          firmware,
 		KernelParams:          vc.DeserializeParams(strings.Fields(kernelParams)),
+		KernelParamsProfiles:  kernelParamsProfiles,
 		NumVCPUs:              h.defaultVCPUs(),
 		DefaultMaxVCPUs:       h.defaultMaxVCPUs(),
 		MemorySize:            h.defaultMemSz(),
@@ -565,6 +817,7 @@ func newFirecrackerHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		GuestHookPath:         h.guestHookPath(),
 		RxRateLimiterMaxRate:  rxRateLimiterMaxRate,
 		TxRateLimiterMaxRate:  txRateLimiterMaxRate,
+		VMMSeccomp:            h.VMMSeccomp,
 	}, nil
 }
 
@@ -574,6 +827,11 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		return vc.HypervisorConfig{}, err
 	}
 
+	profiles, err := h.hypervisorProfiles()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
 	kernel, err := h.kernel()
 	if err != nil {
 		return vc.HypervisorConfig{}, err
@@ -599,9 +857,18 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		return vc.HypervisorConfig{}, err
 	}
 
+	firmwareVars, err := h.firmwareVars()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
 	machineAccelerators := h.machineAccelerators()
 	cpuFeatures := h.cpuFeatures()
 	kernelParams := h.kernelParams()
+	kernelParamsProfiles, err := h.kernelParamsProfiles()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
 	machineType := h.machineType()
 
 	// The "microvm" machine type doesn't support NVDIMM so override the
@@ -627,6 +894,15 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 			errors.New("cannot enable virtio-fs without daemon path in configuration file")
 	}
 
+	if h.PRHelperSocket != "" {
+		if !filepath.IsAbs(h.PRHelperSocket) {
+			return vc.HypervisorConfig{}, fmt.Errorf("pr_helper_socket %q must be an absolute path", h.PRHelperSocket)
+		}
+		if blockDriver != config.VirtioSCSI {
+			return vc.HypervisorConfig{}, fmt.Errorf("pr_helper_socket requires the %s block device driver", config.VirtioSCSI)
+		}
+	}
+
 	useVSock := false
 	if h.useVSock() {
 		if utils.SupportsVsocks() {
@@ -653,9 +929,11 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		InitrdPath:              initrd,
 		ImagePath:               image,
 		FirmwarePath:            firmware,
+		FirmwareVarsTemplate:    firmwareVars,
 		MachineAccelerators:     machineAccelerators,
 		CPUFeatures:             cpuFeatures,
 		KernelParams:            vc.DeserializeParams(strings.Fields(kernelParams)),
+		KernelParamsProfiles:    kernelParamsProfiles,
 		HypervisorMachineType:   machineType,
 		NumVCPUs:                h.defaultVCPUs(),
 		DefaultMaxVCPUs:         h.defaultMaxVCPUs(),
@@ -663,6 +941,7 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		MemSlots:                h.defaultMemSlots(),
 		MemOffset:               h.defaultMemOffset(),
 		VirtioMem:               h.VirtioMem,
+		FreePageReporting:       h.FreePageReporting,
 		EntropySource:           h.GetEntropySource(),
 		DefaultBridges:          h.defaultBridges(),
 		DisableBlockDeviceUse:   h.DisableBlockDeviceUse,
@@ -671,6 +950,7 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		VirtioFSCacheSize:       h.VirtioFSCacheSize,
 		VirtioFSCache:           h.defaultVirtioFSCache(),
 		VirtioFSExtraArgs:       h.VirtioFSExtraArgs,
+		VirtioFSDaemonRestarts:  h.VirtioFSDaemonRestarts,
 		MemPrealloc:             h.MemPrealloc,
 		HugePages:               h.HugePages,
 		IOMMU:                   h.IOMMU,
@@ -682,18 +962,36 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		BlockDeviceCacheSet:     h.BlockDeviceCacheSet,
 		BlockDeviceCacheDirect:  h.BlockDeviceCacheDirect,
 		BlockDeviceCacheNoflush: h.BlockDeviceCacheNoflush,
+		PRHelperSocket:          h.PRHelperSocket,
 		EnableIOThreads:         h.EnableIOThreads,
 		Msize9p:                 h.msize9p(),
 		UseVSock:                useVSock,
 		DisableImageNvdimm:      h.DisableImageNvdimm,
 		HotplugVFIOOnRootBus:    h.HotplugVFIOOnRootBus,
 		PCIeRootPort:            h.PCIeRootPort,
+		VFIOAutoBindDrivers:     h.VFIOAutoBindDrivers,
+		VFIOPeerToPeer:          h.VFIOPeerToPeer,
+		GuestNUMA:               h.GuestNUMA,
+		VCPUPinning:             h.VCPUPinning,
 		DisableVhostNet:         h.DisableVhostNet,
+		NetworkQueues:           h.NetworkQueues,
+		NetworkMaxQueues:        h.NetworkMaxQueues,
+		EnableGuestSuspend:      h.EnableGuestSuspend,
 		EnableVhostUserStore:    h.EnableVhostUserStore,
 		VhostUserStorePath:      h.vhostUserStorePath(),
+		VhostUserReconnect:      h.VhostUserReconnect,
 		GuestHookPath:           h.guestHookPath(),
 		RxRateLimiterMaxRate:    rxRateLimiterMaxRate,
 		TxRateLimiterMaxRate:    txRateLimiterMaxRate,
+		VMMSeccomp:              h.VMMSeccomp,
+		MemoryEncryption:        h.MemoryEncryption,
+		SEVPolicy:               h.SEVPolicy,
+		SEVCBitPos:              h.SEVCBitPos,
+		SEVReducedPhysBits:      h.SEVReducedPhysBits,
+		SEVDHCertPath:           h.SEVDHCertPath,
+		SEVSessionPath:          h.SEVSessionPath,
+
+		HypervisorProfiles: profiles,
 	}, nil
 }
 
@@ -729,6 +1027,10 @@ func newAcrnHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 	}
 
 	kernelParams := h.kernelParams()
+	kernelParamsProfiles, err := h.kernelParamsProfiles()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
 
 	blockDriver, err := h.blockDeviceDriver()
 	if err != nil {
@@ -742,6 +1044,7 @@ func newAcrnHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		HypervisorCtlPath:    hypervisorctl,
 		FirmwarePath:         firmware,
 		KernelParams:         vc.DeserializeParams(strings.Fields(kernelParams)),
+		KernelParamsProfiles: kernelParamsProfiles,
 		NumVCPUs:             h.defaultVCPUs(),
 		DefaultMaxVCPUs:      h.defaultMaxVCPUs(),
 		MemorySize:           h.defaultMemSz(),
@@ -791,6 +1094,10 @@ func newClhHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 
 	machineAccelerators := h.machineAccelerators()
 	kernelParams := h.kernelParams()
+	kernelParamsProfiles, err := h.kernelParamsProfiles()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
 	machineType := h.machineType()
 
 	blockDriver, err := h.blockDeviceDriver()
@@ -813,6 +1120,7 @@ func newClhHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		FirmwarePath:            firmware,
 		MachineAccelerators:     machineAccelerators,
 		KernelParams:            vc.DeserializeParams(strings.Fields(kernelParams)),
+		KernelParamsProfiles:    kernelParamsProfiles,
 		HypervisorMachineType:   machineType,
 		NumVCPUs:                h.defaultVCPUs(),
 		DefaultMaxVCPUs:         h.defaultMaxVCPUs(),
@@ -841,9 +1149,13 @@ func newClhHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		Msize9p:                 h.msize9p(),
 		HotplugVFIOOnRootBus:    h.HotplugVFIOOnRootBus,
 		PCIeRootPort:            h.PCIeRootPort,
+		VFIOAutoBindDrivers:     h.VFIOAutoBindDrivers,
 		DisableVhostNet:         true,
 		UseVSock:                true,
 		VirtioFSExtraArgs:       h.VirtioFSExtraArgs,
+		EnableVMMIsolation:      h.EnableVMMIsolation,
+		VMMSeccomp:              h.VMMSeccomp,
+		MemoryEncryption:        h.MemoryEncryption,
 	}, nil
 }
 
@@ -867,6 +1179,10 @@ func updateRuntimeConfigHypervisor(configPath string, tomlConf tomlConfig, confi
 		var err error
 		var hConfig vc.HypervisorConfig
 
+		if hypervisor.EnableVMMIsolation && k != clhHypervisorTableType {
+			return fmt.Errorf("%v: VMM isolation is not supported by the %s hypervisor", configPath, k)
+		}
+
 		switch k {
 		case firecrackerHypervisorTableType:
 			config.HypervisorType = vc.FirecrackerHypervisor
@@ -935,7 +1251,14 @@ func updateRuntimeConfigAgent(configPath string, tomlConf tomlConfig, config *oc
 		return nil
 	}
 
-	for _, agent := range tomlConf.Agent {
+	for k, agent := range tomlConf.Agent {
+		if k != kataAgentTableType {
+			if !agentTypeSupported(vc.AgentType(k)) {
+				return fmt.Errorf("%s agent type not supported, the runtime is built with %v", k, vc.AgentTypes())
+			}
+			config.AgentType = vc.AgentType(k)
+		}
+
 		config.AgentConfig = vc.KataAgentConfig{
 			UseVSock:      config.HypervisorConfig.UseVSock,
 			Debug:         agent.debug(),
@@ -944,11 +1267,32 @@ func updateRuntimeConfigAgent(configPath string, tomlConf tomlConfig, config *oc
 			TraceType:     agent.traceType(),
 			KernelModules: agent.kernelModules(),
 		}
+		config.TimeSync = agent.timeSync()
+
+		provisioning, err := agent.guestProvisioning()
+		if err != nil {
+			return err
+		}
+		config.GuestProvisioning = provisioning
+		config.Kdump = agent.kdump()
+		config.GuestProfiling = agent.guestProfiling()
+		config.GuestLivepatch = agent.guestLivepatch()
+		config.GuestMemoryReclaim = agent.guestMemoryReclaim()
+		config.MetadataProxy = agent.metadataProxy()
 	}
 
 	return nil
 }
 
+func agentTypeSupported(agentType vc.AgentType) bool {
+	for _, t := range vc.AgentTypes() {
+		if t == agentType {
+			return true
+		}
+	}
+	return false
+}
+
 // SetKernelParams adds the user-specified kernel parameters (from the
 // configuration file) to the defaults so that the former take priority.
 func SetKernelParams(runtimeConfig *oci.RuntimeConfig) error {
@@ -1156,6 +1500,8 @@ func LoadConfiguration(configPath string, ignoreLogging, builtIn bool) (resolved
 	}
 
 	config.DisableGuestSeccomp = tomlConf.Runtime.DisableGuestSeccomp
+	config.GuestHooks = tomlConf.Runtime.GuestHooks
+	config.SandboxRegistrars = tomlConf.Runtime.SandboxRegistrars
 
 	// use no proxy if HypervisorConfig.UseVSock is true
 	if config.HypervisorConfig.UseVSock {
@@ -1166,7 +1512,102 @@ func LoadConfiguration(configPath string, ignoreLogging, builtIn bool) (resolved
 
 	config.SandboxCgroupOnly = tomlConf.Runtime.SandboxCgroupOnly
 	config.DisableNewNetNs = tomlConf.Runtime.DisableNewNetNs
+	config.TCFilterCompat = vc.TCFilterCompat{
+		KeepOffloads: tomlConf.Runtime.TCFilterKeepOffloads,
+		FixupProg:    tomlConf.Runtime.TCFilterFixupProg,
+	}
+	config.MACAllocation = vc.MACAllocation{
+		Strategy: vc.MACAllocationStrategy(tomlConf.Runtime.MACAllocation),
+		Pool:     tomlConf.Runtime.MACPool,
+	}
+	config.DHCPInterfaces = vc.DHCPInterfaces(tomlConf.Runtime.DHCPInterfaces)
+	if config.InterfaceEndpoints, err = vc.ParseInterfaceEndpoints(tomlConf.Runtime.InterfaceEndpoints); err != nil {
+		return "", config, fmt.Errorf("Invalid interface_endpoints: %v", err)
+	}
+	config.NetworkProvider = tomlConf.Runtime.NetworkProvider
+	config.TrafficCapture = vc.TrafficCapture{
+		Enabled:     tomlConf.Runtime.TrafficCapture,
+		MaxDuration: time.Duration(tomlConf.Runtime.TrafficCaptureMaxDuration) * time.Second,
+		SnapLen:     tomlConf.Runtime.TrafficCaptureSnapLen,
+	}
 	config.EnablePprof = tomlConf.Runtime.EnablePprof
+	config.AttachPolicy = oci.AttachPolicy(tomlConf.Runtime.AttachPolicy)
+
+	config.ResourceCeilings = vc.ResourceCeilings{
+		MaxMemoryMB:       tomlConf.Runtime.MaxMemory,
+		MaxVCPUs:          tomlConf.Runtime.MaxVCPUs,
+		MaxHotplugDevices: tomlConf.Runtime.MaxHotplugDevices,
+		MaxInterfaces:     tomlConf.Runtime.MaxInterfaces,
+	}
+
+	config.NodeLimits = vc.NodeLimits{
+		MaxMemoryMB:       tomlConf.Runtime.NodeMaxMemory,
+		MaxHotplugDevices: tomlConf.Runtime.NodeMaxHotplugDevs,
+		MaxSandboxes:      tomlConf.Runtime.NodeMaxSandboxes,
+	}
+
+	config.ResizePolicy = vc.ResizePolicy{
+		ShrinkCooldown:          time.Duration(tomlConf.Runtime.ShrinkCooldown) * time.Second,
+		VCPUShrinkThreshold:     tomlConf.Runtime.VCPUShrinkThreshold,
+		MemoryShrinkThresholdMB: tomlConf.Runtime.MemShrinkThreshold,
+	}
+
+	config.ShutdownTimeouts = vc.ShutdownTimeouts{
+		Guest: time.Duration(tomlConf.Runtime.ShutdownGuest) * time.Second,
+		ACPI:  time.Duration(tomlConf.Runtime.ShutdownACPI) * time.Second,
+		Quit:  time.Duration(tomlConf.Runtime.ShutdownQuit) * time.Second,
+	}
+
+	config.Lightweight = vc.LightweightSandbox{
+		Enable:     tomlConf.Runtime.Lightweight,
+		Interfaces: tomlConf.Runtime.LightweightInterfaces,
+	}
+	if tomlConf.Runtime.LightweightMemBlockSize > 0 {
+		config.Lightweight.GuestDetails = &vc.LightweightGuestDetails{
+			MemoryBlockSizeMB:  tomlConf.Runtime.LightweightMemBlockSize,
+			MemoryHotplugProbe: tomlConf.Runtime.LightweightMemProbe,
+			SeccompSupported:   tomlConf.Runtime.LightweightSeccomp,
+		}
+	}
+
+	config.HotplugPlanning = vc.HotplugPlanning{
+		Enable:      tomlConf.Runtime.HotplugPlanning,
+		VCPUs:       tomlConf.Runtime.HotplugVCPUs,
+		MemorySlots: tomlConf.Runtime.HotplugMemSlots,
+		Devices:     tomlConf.Runtime.HotplugDevices,
+	}
+
+	config.AdmissionPolicy = vc.AdmissionPolicy{
+		Mode:            tomlConf.Runtime.AdmissionMode,
+		OvercommitRatio: tomlConf.Runtime.OvercommitRatio,
+		QueueTimeout:    time.Duration(tomlConf.Runtime.AdmissionTimeout) * time.Second,
+	}
+
+	config.CPUShares = vc.CPUSharesTranslation{
+		Aggregation: tomlConf.Runtime.CPUSharesAggregate,
+		GuestScale:  tomlConf.Runtime.GuestCPUSharesScale,
+		VCPUNice:    tomlConf.Runtime.VCPUNice,
+	}
+
+	config.GuestOS = tomlConf.Runtime.GuestOS
+
+	config.RootfsDisk = vc.RootfsDisk{
+		Fstype:    tomlConf.Runtime.RootfsDiskFstype,
+		Converter: tomlConf.Runtime.RootfsDiskConverter,
+	}
+
+	config.CoreDumpDir = tomlConf.Runtime.CoreDumpDir
+	config.AssetStore = tomlConf.Runtime.AssetStore
+	config.AssetSigningKey = tomlConf.Runtime.AssetSigningKey
+	config.PersistDriver = tomlConf.Runtime.PersistDriver
+
+	config.IntegrityManifest = tomlConf.Runtime.IntegrityManifest
+	config.IntegrityMode = tomlConf.Runtime.IntegrityMode
+	if config.IntegrityManifest != "" && config.IntegrityMode == "" {
+		config.IntegrityMode = integrityModeEnforce
+	}
+	config.ConfigPath = resolved
+
 	for _, f := range tomlConf.Runtime.Experimental {
 		feature := exp.Get(f)
 		if feature == nil {
@@ -1179,6 +1620,14 @@ func LoadConfiguration(configPath string, ignoreLogging, builtIn bool) (resolved
 		return "", config, err
 	}
 
+	// The persist driver is used by all the virtcontainers API calls,
+	// which do not take the runtime configuration.
+	if config.PersistDriver != "" {
+		if err := persist.SetDriver(config.PersistDriver); err != nil {
+			return "", config, err
+		}
+	}
+
 	return resolved, config, nil
 }
 
@@ -1222,15 +1671,28 @@ func checkConfig(config oci.RuntimeConfig) error {
 		return err
 	}
 
+	if err := vc.CheckArch(config.HypervisorType, &config.HypervisorConfig); err != nil {
+		return err
+	}
+
 	if err := checkFactoryConfig(config); err != nil {
 		return err
 	}
 
+	if !config.AttachPolicy.Valid() {
+		return fmt.Errorf("Unknown attach policy %q", config.AttachPolicy)
+	}
+
+	if err := checkIntegrityMode(config); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // checkNetNsConfig performs sanity checks on disable_new_netns config.
 // Because it is an expert option and conflicts with some other common configs.
+// It also checks the tc filter options are only set with tcfilter.
 func checkNetNsConfig(config oci.RuntimeConfig) error {
 	if config.DisableNewNetNs {
 		if config.NetmonConfig.Enable {
@@ -1241,6 +1703,10 @@ func checkNetNsConfig(config oci.RuntimeConfig) error {
 		}
 	}
 
+	if config.TCFilterCompat.FixupProg != "" && config.InterNetworkModel != vc.NetXConnectTCFilterModel {
+		return fmt.Errorf("config tcfilter_fixup_prog only works with 'tcfilter' internetworking_model")
+	}
+
 	return nil
 }
 
@@ -1258,6 +1724,17 @@ func checkFactoryConfig(config oci.RuntimeConfig) error {
 		}
 	}
 
+	// The factory VMs boot outside of any sandbox, which fetches the
+	// remote assets.
+	if config.FactoryConfig.Template || config.FactoryConfig.VMCacheNumber > 0 {
+		h := config.HypervisorConfig
+		for _, path := range []string{h.KernelPath, h.InitrdPath, h.ImagePath, h.FirmwarePath} {
+			if assetstore.IsRemote(path) {
+				return fmt.Errorf("Factory does not support the remote asset %s", path)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -1289,7 +1766,8 @@ func checkHypervisorConfig(config vc.HypervisorConfig) error {
 	mb := int64(1024 * 1024)
 
 	for _, image := range images {
-		if image.path == "" {
+		// A remote image is only fetched by the first sandbox using it
+		if image.path == "" || assetstore.IsRemote(image.path) {
 			continue
 		}
 
@@ -956,6 +956,43 @@ func TestNewQemuHypervisorConfigImageAndInitrd(t *testing.T) {
 	assert.Error(err)
 }
 
+func TestNewQemuHypervisorConfigPRHelperSocket(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir(testDir, "")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	hypervisorPath := path.Join(dir, "hypervisor")
+	kernelPath := path.Join(dir, "kernel")
+	imagePath := path.Join(dir, "image")
+	for _, file := range []string{hypervisorPath, kernelPath, imagePath} {
+		assert.NoError(createEmptyFile(file))
+	}
+
+	hypervisor := hypervisor{
+		Path:              hypervisorPath,
+		Kernel:            kernelPath,
+		Image:             imagePath,
+		BlockDeviceDriver: "virtio-scsi",
+		PRHelperSocket:    "/run/qemu-pr-helper.sock",
+	}
+
+	hConfig, err := newQemuHypervisorConfig(hypervisor)
+	assert.NoError(err)
+	assert.Equal(hypervisor.PRHelperSocket, hConfig.PRHelperSocket)
+
+	hypervisor.PRHelperSocket = "qemu-pr-helper.sock"
+	_, err = newQemuHypervisorConfig(hypervisor)
+	assert.Error(err)
+
+	// Only SCSI devices are passed through
+	hypervisor.PRHelperSocket = "/run/qemu-pr-helper.sock"
+	hypervisor.BlockDeviceDriver = "virtio-blk"
+	_, err = newQemuHypervisorConfig(hypervisor)
+	assert.Error(err)
+}
+
 func TestNewClhHypervisorConfig(t *testing.T) {
 
 	assert := assert.New(t)
@@ -1092,6 +1129,65 @@ func TestHypervisorDefaultsHypervisor(t *testing.T) {
 	assert.Equal(p, testHypervisorPath)
 }
 
+func TestHypervisorProfiles(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpdir, err := ioutil.TempDir(testDir, "")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpdir)
+
+	canaryPath := filepath.Join(tmpdir, "qemu-canary")
+	canaryLinkPath := filepath.Join(tmpdir, "qemu-canary-link")
+
+	assert.NoError(createEmptyFile(canaryPath))
+	assert.NoError(syscall.Symlink(canaryPath, canaryLinkPath))
+
+	profiles, err := hypervisor{}.hypervisorProfiles()
+	assert.NoError(err)
+	assert.Empty(profiles)
+
+	h := hypervisor{HypervisorProfiles: map[string]string{"canary": canaryLinkPath}}
+	profiles, err = h.hypervisorProfiles()
+	assert.NoError(err)
+	assert.Equal(map[string]string{"canary": canaryPath}, profiles)
+
+	h = hypervisor{HypervisorProfiles: map[string]string{"canary": filepath.Join(tmpdir, "missing")}}
+	_, err = h.hypervisorProfiles()
+	assert.Error(err)
+
+	h = hypervisor{HypervisorProfiles: map[string]string{"": canaryPath}}
+	_, err = h.hypervisorProfiles()
+	assert.Error(err)
+}
+
+func TestKernelParamsProfiles(t *testing.T) {
+	assert := assert.New(t)
+
+	profiles, err := hypervisor{}.kernelParamsProfiles()
+	assert.NoError(err)
+	assert.Empty(profiles)
+
+	h := hypervisor{KernelParamsProfiles: map[string]string{"debug": "agent.log=debug  systemd.show_status=true debug"}}
+	profiles, err = h.kernelParamsProfiles()
+	assert.NoError(err)
+	assert.Equal(map[string][]vc.Param{
+		"debug": {
+			{Key: "agent.log", Value: "debug"},
+			{Key: "systemd.show_status", Value: "true"},
+			{Key: "debug", Value: ""},
+		},
+	}, profiles)
+
+	for _, p := range []map[string]string{
+		{"": "debug"},
+		{"debug": " "},
+		{"debug": "agent.log=debug =1"},
+	} {
+		_, err = hypervisor{KernelParamsProfiles: p}.kernelParamsProfiles()
+		assert.Error(err, "%v", p)
+	}
+}
+
 func TestHypervisorDefaultsKernel(t *testing.T) {
 	assert := assert.New(t)
 
@@ -1297,6 +1393,26 @@ func TestAgentDefaults(t *testing.T) {
 	assert.Equal(a.traceType(), a.TraceType)
 }
 
+func TestAgentGuestProvisioning(t *testing.T) {
+	assert := assert.New(t)
+
+	a := agent{
+		Timezone:   "Europe/Paris",
+		GuestFiles: []string{"/etc/pki/proxy.pem:/usr/local/share/ca-certificates/proxy.crt"},
+	}
+
+	p, err := a.guestProvisioning()
+	assert.NoError(err)
+	assert.Equal(vc.GuestProvisioning{
+		Files:    []vc.GuestFile{{HostPath: "/etc/pki/proxy.pem", GuestPath: "/usr/local/share/ca-certificates/proxy.crt"}},
+		Timezone: "Europe/Paris",
+	}, p)
+
+	a.GuestFiles = []string{"/etc/pki/proxy.pem"}
+	_, err = a.guestProvisioning()
+	assert.Error(err)
+}
+
 func TestGetDefaultConfigFilePaths(t *testing.T) {
 	assert := assert.New(t)
 
@@ -1545,6 +1661,47 @@ func TestUpdateRuntimeConfigurationVMConfig(t *testing.T) {
 	assert.Equal(expectedVMConfig, config.HypervisorConfig.MemorySize)
 }
 
+func TestUpdateRuntimeConfigurationVMMIsolation(t *testing.T) {
+	assert := assert.New(t)
+
+	config := oci.RuntimeConfig{}
+
+	tomlConf := tomlConfig{
+		Hypervisor: map[string]hypervisor{
+			qemuHypervisorTableType: {
+				Path:               "/",
+				Kernel:             "/",
+				Image:              "/",
+				Firmware:           "/",
+				EnableVMMIsolation: true,
+			},
+		},
+	}
+
+	err := updateRuntimeConfig("", tomlConf, &config, false)
+	assert.Error(err)
+}
+
+func TestUpdateRuntimeConfigAgentType(t *testing.T) {
+	assert := assert.New(t)
+
+	config := oci.RuntimeConfig{}
+	tomlConf := tomlConfig{Agent: map[string]agent{kataAgentTableType: {}}}
+
+	err := updateRuntimeConfigAgent("", tomlConf, &config, false)
+	assert.NoError(err)
+	assert.Empty(config.AgentType)
+
+	tomlConf.Agent = map[string]agent{string(vc.ConsoleAgentType): {}}
+	err = updateRuntimeConfigAgent("", tomlConf, &config, false)
+	assert.NoError(err)
+	assert.Equal(vc.ConsoleAgentType, config.AgentType)
+
+	tomlConf.Agent = map[string]agent{"unknown": {}}
+	err = updateRuntimeConfigAgent("", tomlConf, &config, false)
+	assert.Error(err)
+}
+
 func TestUpdateRuntimeConfigurationFactoryConfig(t *testing.T) {
 	assert := assert.New(t)
 
@@ -1710,6 +1867,17 @@ func TestCheckNetNsConfig(t *testing.T) {
 	}
 	err = checkNetNsConfig(config)
 	assert.Error(err)
+
+	config = oci.RuntimeConfig{
+		InterNetworkModel: vc.NetXConnectMacVtapModel,
+		TCFilterCompat:    vc.TCFilterCompat{FixupProg: "/sys/fs/bpf/tc/kata-fixup"},
+	}
+	err = checkNetNsConfig(config)
+	assert.Error(err)
+
+	config.InterNetworkModel = vc.NetXConnectTCFilterModel
+	err = checkNetNsConfig(config)
+	assert.NoError(err)
 }
 
 func TestCheckFactoryConfig(t *testing.T) {
@@ -1729,6 +1897,10 @@ func TestCheckFactoryConfig(t *testing.T) {
 
 		{true, false, "", "initrd"},
 		{true, true, "image", ""},
+
+		// Remote assets are fetched by the sandboxes only
+		{false, false, "", "https://example.com/initrd#sha512=00"},
+		{true, true, "", "https://example.com/initrd#sha512=00"},
 	}
 
 	for i, d := range data {
@@ -16,6 +16,7 @@ import (
 	"syscall"
 	"time"
 
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/sirupsen/logrus"
@@ -109,6 +110,28 @@ func runHooks(ctx context.Context, hooks []specs.Hook, cid, bundlePath, hookType
 	return nil
 }
 
+// HostHooks returns the spec with the hooks the runtime runs on the host,
+// leaving out the types of hooks the agent runs in the guest.
+func HostHooks(spec specs.Spec, guestHooks []string) specs.Spec {
+	if spec.Hooks == nil {
+		return spec
+	}
+
+	hooks := *spec.Hooks
+	if vc.IsGuestHook(guestHooks, vc.GuestHookPrestart) {
+		hooks.Prestart = nil
+	}
+	if vc.IsGuestHook(guestHooks, vc.GuestHookPoststart) {
+		hooks.Poststart = nil
+	}
+	if vc.IsGuestHook(guestHooks, vc.GuestHookPoststop) {
+		hooks.Poststop = nil
+	}
+	spec.Hooks = &hooks
+
+	return spec
+}
+
 // PreStartHooks run the hooks before start container
 func PreStartHooks(ctx context.Context, spec specs.Spec, cid, bundlePath string) error {
 	// If no hook available, nothing needs to be done.
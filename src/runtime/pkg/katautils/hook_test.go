@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	ktu "github.com/kata-containers/kata-containers/src/runtime/pkg/katatestutils"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 	. "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/mock"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/assert"
@@ -210,3 +211,33 @@ func TestPostStopHooks(t *testing.T) {
 	err = PostStopHooks(ctx, spec, testSandboxID, testBundlePath)
 	assert.Error(err)
 }
+
+func TestHostHooks(t *testing.T) {
+	assert := assert.New(t)
+
+	// Hooks field is nil
+	spec := HostHooks(specs.Spec{}, []string{vc.GuestHookPrestart})
+	assert.Nil(spec.Hooks)
+
+	hook := createHook(0)
+	spec = specs.Spec{
+		Hooks: &specs.Hooks{
+			Prestart:  []specs.Hook{hook},
+			Poststart: []specs.Hook{hook},
+			Poststop:  []specs.Hook{hook},
+		},
+	}
+
+	// The host runs all of them
+	host := HostHooks(spec, nil)
+	assert.Equal(spec.Hooks, host.Hooks)
+
+	host = HostHooks(spec, []string{vc.GuestHookPrestart, vc.GuestHookPoststop})
+	assert.Empty(host.Hooks.Prestart)
+	assert.Len(host.Hooks.Poststart, 1)
+	assert.Empty(host.Hooks.Poststop)
+
+	// The spec of the container is left alone
+	assert.Len(spec.Hooks.Prestart, 1)
+	assert.Len(spec.Hooks.Poststop, 1)
+}
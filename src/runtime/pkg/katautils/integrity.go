@@ -0,0 +1,241 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katautils
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/assetstore"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/oci"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// integrityModeEnforce refuses to load a configuration whose assets
+	// do not match the integrity manifest.
+	integrityModeEnforce = "enforce"
+
+	// integrityModeWarn only logs integrity mismatches.
+	integrityModeWarn = "warn"
+)
+
+// integrityManifest maps a resolved file path to its expected hex encoded
+// SHA256 digest.
+type integrityManifest map[string]string
+
+// parseIntegrityManifest reads a manifest in the sha256sum(1) output
+// format: one "<digest>  <path>" entry per line, the path being everything
+// after the two separator characters, spaces included. Blank lines and
+// lines starting with '#' are ignored.
+func parseIntegrityManifest(r io.Reader) (integrityManifest, error) {
+	manifest := integrityManifest{}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if trimmed := strings.TrimSpace(line); trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		digest, path, err := parseIntegrityManifestEntry(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integrity manifest entry at line %d: %v", n, err)
+		}
+
+		// Configured asset paths are resolved, so resolve the manifest
+		// paths too in order to compare them.
+		if resolved, err := ResolvePath(path); err == nil {
+			path = resolved
+		}
+
+		manifest[filepath.Clean(path)] = digest
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// parseIntegrityManifestEntry parses a sha256sum(1) output line. The path
+// is separated from the digest by a space and a ' ' (text mode) or '*'
+// (binary mode) character. sha256sum prefixes the line with '\' when it
+// escaped backslashes and newlines in the path.
+func parseIntegrityManifestEntry(line string) (digest, path string, err error) {
+	escaped := strings.HasPrefix(line, "\\")
+	if escaped {
+		line = line[1:]
+	}
+
+	hexSize := hex.EncodedLen(sha256.Size)
+	if len(line) < hexSize+3 || line[hexSize] != ' ' || (line[hexSize+1] != ' ' && line[hexSize+1] != '*') {
+		return "", "", fmt.Errorf("expecting \"<digest>  <path>\"")
+	}
+
+	digest, path = strings.ToLower(line[:hexSize]), line[hexSize+2:]
+
+	if _, err := hex.DecodeString(digest); err != nil {
+		return "", "", fmt.Errorf("invalid SHA256 digest")
+	}
+
+	if escaped {
+		path = strings.NewReplacer("\\\\", "\\", "\\n", "\n").Replace(path)
+	}
+
+	if !filepath.IsAbs(path) {
+		return "", "", fmt.Errorf("path %q is not absolute", path)
+	}
+
+	return digest, path, nil
+}
+
+// fileSHA256 returns the hex encoded SHA256 digest of a file.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// integrityAssets returns the configuration file and every host file the
+// sandbox makes the runtime execute or hand to the guest, with the asset
+// overrides of the sandbox annotations applied.
+func integrityAssets(configPath string, sandboxConfig vc.SandboxConfig) []string {
+	hConfig := sandboxConfig.HypervisorConfig
+
+	kernel, image, initrd := hConfig.KernelPath, hConfig.ImagePath, hConfig.InitrdPath
+	for annotation, path := range map[string]*string{
+		vcAnnotations.KernelPath: &kernel,
+		vcAnnotations.ImagePath:  &image,
+		vcAnnotations.InitrdPath: &initrd,
+	} {
+		if value := sandboxConfig.Annotations[annotation]; value != "" {
+			*path = value
+		}
+	}
+
+	candidates := []string{
+		configPath,
+		hConfig.HypervisorPath,
+		hConfig.JailerPath,
+		hConfig.VirtioFSDaemon,
+		kernel,
+		image,
+		initrd,
+		hConfig.FirmwarePath,
+	}
+
+	// The remote assets are verified when fetched into the asset store.
+	var assets []string
+	for _, c := range candidates {
+		if c != "" && !assetstore.IsRemote(c) {
+			assets = append(assets, c)
+		}
+	}
+
+	return assets
+}
+
+// verifyIntegrity checks the assets against the manifest and returns one
+// error per asset that is missing from the manifest or does not match it.
+func verifyIntegrity(manifest integrityManifest, assets []string) []error {
+	var errs []error
+
+	for _, asset := range assets {
+		expected, ok := manifest[filepath.Clean(asset)]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s is not listed in the integrity manifest", asset))
+			continue
+		}
+
+		computed, err := fileSHA256(asset)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if computed != expected {
+			errs = append(errs, fmt.Errorf("Invalid SHA256 for %s: computed %s, expecting %s", asset, computed, expected))
+		}
+	}
+
+	return errs
+}
+
+// checkIntegrityMode verifies the integrity mode of the configuration.
+func checkIntegrityMode(config oci.RuntimeConfig) error {
+	if config.IntegrityManifest == "" {
+		return nil
+	}
+
+	switch config.IntegrityMode {
+	case integrityModeEnforce, integrityModeWarn:
+		return nil
+	default:
+		return fmt.Errorf("Unknown integrity mode %q", config.IntegrityMode)
+	}
+}
+
+// checkIntegrity verifies the configuration file and the assets of a new
+// sandbox against the integrity manifest, if one is configured.
+//
+// In enforce mode the first mismatch is returned as an error, which makes
+// the sandbox creation fail. In warn mode mismatches are only logged.
+func checkIntegrity(runtimeConfig oci.RuntimeConfig, sandboxConfig vc.SandboxConfig) error {
+	if runtimeConfig.IntegrityManifest == "" {
+		return nil
+	}
+
+	if err := checkIntegrityMode(runtimeConfig); err != nil {
+		return err
+	}
+
+	f, err := os.Open(runtimeConfig.IntegrityManifest)
+	if err != nil {
+		return fmt.Errorf("Cannot open integrity manifest: %v", err)
+	}
+	defer f.Close()
+
+	manifest, err := parseIntegrityManifest(f)
+	if err != nil {
+		return err
+	}
+
+	errs := verifyIntegrity(manifest, integrityAssets(runtimeConfig.ConfigPath, sandboxConfig))
+	if len(errs) == 0 {
+		return nil
+	}
+
+	if runtimeConfig.IntegrityMode == integrityModeEnforce {
+		return errs[0]
+	}
+
+	for _, e := range errs {
+		kataUtilsLogger.WithFields(logrus.Fields{
+			"manifest": runtimeConfig.IntegrityManifest,
+		}).WithError(e).Warn("integrity check failed")
+	}
+
+	return nil
+}
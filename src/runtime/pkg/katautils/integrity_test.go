@@ -0,0 +1,146 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katautils
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/oci"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBadSHA256 is a valid SHA256 digest that matches none of the test files.
+const testBadSHA256 = "f8ad85de8abc95ed2b1d0e2bc8d1c8a0b5d1ec5b2bf2e3f9e85c8ad1f8c5b1e2"
+
+func TestParseIntegrityManifest(t *testing.T) {
+	assert := assert.New(t)
+
+	digest := strings.Repeat("a", 64)
+
+	manifest, err := parseIntegrityManifest(strings.NewReader(fmt.Sprintf("# comment\n\n%s  /foo\n%s *//bar/\n%s  /foo bar/ baz\n\\%s  /back\\\\slash\\nnewline\n",
+		digest, strings.ToUpper(digest), digest, digest)))
+	assert.NoError(err)
+	assert.Equal(integrityManifest{"/foo": digest, "/bar": digest, "/foo bar/ baz": digest, "/back\\slash\nnewline": digest}, manifest)
+
+	for _, data := range []string{
+		"foo",
+		digest + " /foo",
+		digest + "  ",
+		"abcd  /foo",
+		strings.Repeat("z", 64) + "  /foo",
+		digest + "  relative/path",
+	} {
+		_, err := parseIntegrityManifest(strings.NewReader(data))
+		assert.Error(err, data)
+	}
+}
+
+func TestVerifyIntegrity(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpdir, err := ioutil.TempDir(testDir, "integrity-")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpdir)
+
+	kernel := filepath.Join(tmpdir, "kernel")
+	err = ioutil.WriteFile(kernel, []byte("kernel"), testFileMode)
+	assert.NoError(err)
+
+	digest, err := fileSHA256(kernel)
+	assert.NoError(err)
+
+	assert.Empty(verifyIntegrity(integrityManifest{kernel: digest}, []string{kernel}))
+	assert.Len(verifyIntegrity(integrityManifest{kernel: testBadSHA256}, []string{kernel}), 1)
+	assert.Len(verifyIntegrity(integrityManifest{}, []string{kernel}), 1)
+}
+
+func TestIntegrityAssets(t *testing.T) {
+	assert := assert.New(t)
+
+	sandboxConfig := vc.SandboxConfig{
+		HypervisorConfig: vc.HypervisorConfig{
+			HypervisorPath: "/usr/bin/qemu",
+			KernelPath:     "/usr/share/kernel",
+			ImagePath:      "/usr/share/image",
+			FirmwarePath:   "https://example.com/firmware",
+		},
+		Annotations: map[string]string{},
+	}
+
+	assert.Equal([]string{"/etc/configuration.toml", "/usr/bin/qemu", "/usr/share/kernel", "/usr/share/image"},
+		integrityAssets("/etc/configuration.toml", sandboxConfig))
+
+	// The assets overridden by annotation are verified instead of the
+	// configured ones.
+	sandboxConfig.Annotations[vcAnnotations.KernelPath] = "/opt/kernel"
+	sandboxConfig.Annotations[vcAnnotations.InitrdPath] = "/opt/initrd"
+	assert.Equal([]string{"/usr/bin/qemu", "/opt/kernel", "/usr/share/image", "/opt/initrd"},
+		integrityAssets("", sandboxConfig))
+}
+
+func TestCheckIntegrity(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpdir, err := ioutil.TempDir(testDir, "integrity-")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpdir)
+
+	kernel := filepath.Join(tmpdir, "kernel")
+	err = ioutil.WriteFile(kernel, []byte("kernel"), testFileMode)
+	assert.NoError(err)
+
+	manifestPath := filepath.Join(tmpdir, "integrity.sha256")
+	err = ioutil.WriteFile(manifestPath, []byte(testBadSHA256+"  "+kernel+"\n"), testFileMode)
+	assert.NoError(err)
+
+	config := oci.RuntimeConfig{}
+	sandboxConfig := vc.SandboxConfig{
+		HypervisorConfig: vc.HypervisorConfig{
+			KernelPath: kernel,
+		},
+	}
+
+	// No manifest, nothing is checked.
+	assert.NoError(checkIntegrity(config, sandboxConfig))
+
+	config.IntegrityManifest = manifestPath
+	config.IntegrityMode = "foo"
+	assert.Error(checkIntegrityMode(config))
+	assert.Error(checkIntegrity(config, sandboxConfig))
+
+	config.IntegrityMode = integrityModeEnforce
+	assert.NoError(checkIntegrityMode(config))
+	assert.Error(checkIntegrity(config, sandboxConfig))
+
+	config.IntegrityMode = integrityModeWarn
+	assert.NoError(checkIntegrity(config, sandboxConfig))
+
+	digest, err := fileSHA256(kernel)
+	assert.NoError(err)
+	err = ioutil.WriteFile(manifestPath, []byte(digest+"  "+kernel+"\n"), testFileMode)
+	assert.NoError(err)
+
+	config.IntegrityMode = integrityModeEnforce
+	assert.NoError(checkIntegrity(config, sandboxConfig))
+
+	// An asset overridden by annotation has to be listed as well.
+	initrd := filepath.Join(tmpdir, "initrd")
+	err = ioutil.WriteFile(initrd, []byte("initrd"), testFileMode)
+	assert.NoError(err)
+	sandboxConfig.Annotations = map[string]string{vcAnnotations.InitrdPath: initrd}
+	assert.Error(checkIntegrity(config, sandboxConfig))
+	sandboxConfig.Annotations = nil
+
+	config.IntegrityManifest = filepath.Join(tmpdir, "missing")
+	assert.Error(checkIntegrity(config, sandboxConfig))
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katautils
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+)
+
+// shimMonitorAddressFile is the file, in the bundle of the sandbox, the
+// shim writes the address of its management socket to.
+const shimMonitorAddressFile = "monitor_address"
+
+// ShimProfileErrorTrailer is the trailer of the /profile responses the
+// shim reports a guest profiling session failing in, once its output is
+// streamed.
+const ShimProfileErrorTrailer = "Kata-Profile-Error"
+
+// ShimMonitorAddress returns the address of the management socket of the
+// shim serving a sandbox.
+func ShimMonitorAddress(status vc.SandboxStatus) (string, error) {
+	for _, c := range status.ContainersStatus {
+		if c.ID != status.ID {
+			continue
+		}
+
+		bundle := c.Annotations[vcAnnotations.BundlePathKey]
+		if bundle == "" {
+			return "", fmt.Errorf("sandbox %s has no bundle path", status.ID)
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(bundle, shimMonitorAddressFile))
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	return "", fmt.Errorf("sandbox container %s not found", status.ID)
+}
+
+// dialShim connects to the abstract unix socket the shim listens on.
+func dialShim(address string) (net.Conn, error) {
+	return net.Dial("unix", "\x00"+address)
+}
+
+// ShimRequest sends a request to the management socket of a shim. The
+// caller must close the response body.
+func ShimRequest(address, method, path string) (*http.Response, error) {
+	client := http.Client{
+		Transport: &http.Transport{
+			DisableKeepAlives: true,
+			Dial: func(proto, addr string) (net.Conn, error) {
+				return dialShim(address)
+			},
+		},
+	}
+
+	req, err := http.NewRequest(method, "http://shim"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("shim request %s %s failed: %s: %s", method, path, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return resp, nil
+}
+
+// ShimDebugConsole connects to the debug console of the sandbox served by
+// a shim, and returns the connection, which carries the raw console data.
+func ShimDebugConsole(address string) (net.Conn, error) {
+	conn, err := dialShim(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := fmt.Fprint(conn, "GET /debug-console HTTP/1.1\r\nHost: shim\r\n\r\n"); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	// The reader may buffer console data following the response header,
+	// so the console must be read through it.
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		conn.Close()
+		return nil, fmt.Errorf("debug console unavailable: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return &bufferedConn{Conn: conn, reader: reader}, nil
+}
+
+// bufferedConn is a connection whose first bytes may have been buffered
+// while reading a response header.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katautils
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+)
+
+func TestShimMonitorAddress(t *testing.T) {
+	assert := assert.New(t)
+
+	bundle, err := ioutil.TempDir("", "bundle")
+	assert.NoError(err)
+	defer os.RemoveAll(bundle)
+
+	status := vc.SandboxStatus{
+		ID: "sandbox",
+		ContainersStatus: []vc.ContainerStatus{
+			{ID: "container"},
+			{ID: "sandbox"},
+		},
+	}
+
+	// No bundle annotation
+	_, err = ShimMonitorAddress(status)
+	assert.Error(err)
+
+	// No address file
+	status.ContainersStatus[1].Annotations = map[string]string{vcAnnotations.BundlePathKey: bundle}
+	_, err = ShimMonitorAddress(status)
+	assert.Error(err)
+
+	err = ioutil.WriteFile(filepath.Join(bundle, shimMonitorAddressFile), []byte("/containerd-shim/k8s.io/sandbox/shim-monitor.sock\n"), 0644)
+	assert.NoError(err)
+
+	address, err := ShimMonitorAddress(status)
+	assert.NoError(err)
+	assert.Equal("/containerd-shim/k8s.io/sandbox/shim-monitor.sock", address)
+
+	// No sandbox container
+	status.ContainersStatus = status.ContainersStatus[:1]
+	_, err = ShimMonitorAddress(status)
+	assert.Error(err)
+}
+
+func TestShimRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	address := fmt.Sprintf("kata-test-shim-%d.sock", os.Getpid())
+	l, err := net.Listen("unix", "\x00"+address)
+	assert.NoError(err)
+	defer l.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.Method)
+	})
+	mux.HandleFunc("/debug-console", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no console", http.StatusNotFound)
+	})
+	go http.Serve(l, mux)
+
+	resp, err := ShimRequest(address, http.MethodPost, "/status")
+	assert.NoError(err)
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.NoError(err)
+	assert.Equal(http.MethodPost, string(body))
+
+	_, err = ShimRequest(address, http.MethodGet, "/unknown")
+	assert.Error(err)
+
+	_, err = ShimDebugConsole(address)
+	assert.Error(err)
+	assert.Contains(err.Error(), "no console")
+
+	_, err = ShimRequest("kata-test-no-shim.sock", http.MethodGet, "/status")
+	assert.Error(err)
+}
+
+func TestShimDebugConsole(t *testing.T) {
+	assert := assert.New(t)
+
+	address := fmt.Sprintf("kata-test-shim-console-%d.sock", os.Getpid())
+	l, err := net.Listen("unix", "\x00"+address)
+	assert.NoError(err)
+	defer l.Close()
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// Console data sent along with the response header must not
+		// be lost.
+		buf := make([]byte, 512)
+		conn.Read(buf)
+		fmt.Fprint(conn, "HTTP/1.1 200 OK\r\n\r\n/ # ")
+		n, _ := conn.Read(buf)
+		conn.Write(buf[:n])
+	}()
+
+	conn, err := ShimDebugConsole(address)
+	assert.NoError(err)
+	defer conn.Close()
+
+	prompt := make([]byte, 4)
+	_, err = io.ReadFull(conn, prompt)
+	assert.NoError(err)
+	assert.Equal("/ # ", string(prompt))
+
+	_, err = conn.Write([]byte("ls"))
+	assert.NoError(err)
+	echo := make([]byte, 2)
+	_, err = io.ReadFull(conn, echo)
+	assert.NoError(err)
+	assert.Equal("ls", string(echo))
+}
@@ -139,6 +139,8 @@ func (a *Acrn) kernelParameters() string {
 	// params are added here, they will take priority over the defaults.
 	params = append(params, a.config.KernelParams...)
 
+	params = a.config.appendPayloadParams(params)
+
 	paramsStr := SerializeParams(params, "=")
 
 	return strings.Join(paramsStr, " ")
@@ -608,6 +610,42 @@ func (a *Acrn) resumeSandbox() error {
 	return nil
 }
 
+func (a *Acrn) snapshotSandbox(dir string) error {
+	return errors.New("sandbox snapshots are not supported for acrn")
+}
+
+func (a *Acrn) migrateSandbox(uri string) error {
+	return errors.New("sandbox migration is not supported for acrn")
+}
+
+func (a *Acrn) finishIncomingMigration() error {
+	return errors.New("sandbox migration is not supported for acrn")
+}
+
+func (a *Acrn) waitGuestSuspended(timeout time.Duration) error {
+	return errors.New("guest suspend is not supported for acrn")
+}
+
+func (a *Acrn) wakeupSandbox() error {
+	return errors.New("guest suspend is not supported for acrn")
+}
+
+func (a *Acrn) upgradeVirtiofsd() error {
+	return errors.New("virtiofsd upgrade is not supported for acrn")
+}
+
+func (a *Acrn) liveUpdate(vmSocket interface{}, endpoints []Endpoint, drives []*config.BlockDrive) error {
+	return errors.New("sandbox live update is not supported for acrn")
+}
+
+func (a *Acrn) powerdownSandbox() error {
+	return errors.New("ACPI power down is not supported for acrn")
+}
+
+func (a *Acrn) getLaunchMeasurement() (LaunchMeasurement, error) {
+	return LaunchMeasurement{}, errors.New("memory encryption is not supported for acrn")
+}
+
 // addDevice will add extra devices to acrn command line.
 func (a *Acrn) addDevice(devInfo interface{}, devType deviceType) error {
 	var err error
@@ -0,0 +1,234 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// AdmissionStrict only creates a sandbox if the host has the free
+	// memory and CPUs it requests.
+	AdmissionStrict = "strict"
+
+	// AdmissionOvercommit creates a sandbox if the memory and CPUs it
+	// requests are within the free ones times the overcommit ratio.
+	AdmissionOvercommit = "overcommit"
+
+	// AdmissionBestEffort always creates a sandbox, warning when the host
+	// does not have the free memory and CPUs it requests.
+	AdmissionBestEffort = "best-effort"
+
+	procLoadAvg = "/proc/loadavg"
+)
+
+// admissionRetryInterval is how often a queued sandbox creation checks the
+// host resources again.
+var admissionRetryInterval = time.Second
+
+// getHostFreeResources returns the free memory of the host, in MiB, and its
+// idle CPUs.
+var getHostFreeResources = hostFreeResources
+
+// AdmissionPolicy configures the check of the host free resources against
+// the resources a sandbox requests, before its VM is created. Without it, a
+// host silently overcommits until the hypervisors get OOM-killed.
+type AdmissionPolicy struct {
+	// Mode is AdmissionStrict, AdmissionOvercommit or AdmissionBestEffort.
+	// Empty disables the admission check.
+	Mode string
+
+	// OvercommitRatio multiplies the free host resources in the
+	// AdmissionOvercommit mode. It cannot be lower than 1.
+	OvercommitRatio float64
+
+	// QueueTimeout is how long the creation of a sandbox the host has no
+	// room for waits for resources to be freed, before failing. Zero fails
+	// the creation at once.
+	QueueTimeout time.Duration
+}
+
+func (p AdmissionPolicy) validate() error {
+	switch p.Mode {
+	case "", AdmissionStrict, AdmissionBestEffort:
+	case AdmissionOvercommit:
+		if p.OvercommitRatio < 1 {
+			return newConfigFieldError("OvercommitRatio", fmt.Sprintf("Invalid overcommit ratio %v, lower than 1", p.OvercommitRatio))
+		}
+	default:
+		return newConfigFieldError("Mode", fmt.Sprintf("Invalid admission mode %q", p.Mode))
+	}
+
+	if p.QueueTimeout < 0 {
+		return newConfigFieldError("QueueTimeout", fmt.Sprintf("Invalid admission queue timeout %v", p.QueueTimeout))
+	}
+
+	return nil
+}
+
+// admissionRequest are the resources a sandbox requests from the host.
+type admissionRequest struct {
+	memoryMB uint64
+	vcpus    uint32
+}
+
+// sandboxAdmissionRequest returns the resources the sandbox requests: the
+// default memory and vCPUs of the VM, plus the memory and vCPUs hotplugged
+// for the declared container limits and the hotplug headroom.
+func sandboxAdmissionRequest(sandboxConfig *SandboxConfig) admissionRequest {
+	memoryMB := uint64(sandboxConfig.HypervisorConfig.MemorySize)
+	for _, c := range sandboxConfig.Containers {
+		if m := c.Resources.Memory; m != nil && m.Limit != nil && *m.Limit > 0 {
+			memoryMB += uint64(*m.Limit) >> utils.MibToBytesShift
+		}
+	}
+
+	// Plan on a copy, not to reserve the hotplug capacity.
+	config := *sandboxConfig
+
+	return admissionRequest{
+		memoryMB: memoryMB,
+		vcpus:    planHotplug(&config).VCPUs,
+	}
+}
+
+// admit checks the request against the free host resources, returning why
+// the host has no room for it.
+func (p AdmissionPolicy) admit(req admissionRequest, freeMemoryMB uint64, freeCPUs float64) error {
+	ratio := 1.0
+	if p.Mode == AdmissionOvercommit {
+		ratio = p.OvercommitRatio
+	}
+
+	if memoryMB := float64(freeMemoryMB) * ratio; float64(req.memoryMB) > memoryMB {
+		return fmt.Errorf("%d MiB of memory requested, the host admits %.0f MiB", req.memoryMB, memoryMB)
+	}
+
+	if cpus := freeCPUs * ratio; float64(req.vcpus) > cpus {
+		return fmt.Errorf("%d vCPUs requested, the host admits %.1f CPUs", req.vcpus, cpus)
+	}
+
+	return nil
+}
+
+// admitSandbox checks that the host has room for the sandbox, according to
+// the admission policy, waiting up to the queue timeout for resources to be
+// freed.
+func (s *Sandbox) admitSandbox(ctx context.Context) error {
+	policy := s.config.AdmissionPolicy
+	if policy.Mode == "" {
+		return nil
+	}
+
+	span, _ := s.trace("admitSandbox")
+	defer span.Finish()
+
+	req := sandboxAdmissionRequest(s.config)
+	deadline := time.Now().Add(policy.QueueTimeout)
+
+	for {
+		freeMemoryMB, freeCPUs, err := getHostFreeResources()
+		if err != nil {
+			return fmt.Errorf("Could not get the host free resources: %v", err)
+		}
+
+		err = policy.admit(req, freeMemoryMB, freeCPUs)
+		if err == nil {
+			return nil
+		}
+
+		logger := s.Logger().WithError(err).WithFields(logrus.Fields{
+			"free-memory-mb": freeMemoryMB,
+			"free-cpus":      freeCPUs,
+		})
+
+		if policy.Mode == AdmissionBestEffort {
+			logger.Warn("Host overcommitted by the sandbox")
+			return nil
+		}
+
+		if !time.Now().Add(admissionRetryInterval).Before(deadline) {
+			return fmt.Errorf("Not enough host resources for sandbox %s: %v", s.id, err)
+		}
+
+		logger.Info("Sandbox creation queued until the host has room for it")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(admissionRetryInterval):
+		}
+	}
+}
+
+// hostFreeResources returns the memory available on the host, in MiB, and
+// the CPUs left idle by the load average.
+func hostFreeResources() (uint64, float64, error) {
+	memoryKb, err := getHostMemoryAvailableKb(procMemInfo)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	data, err := ioutil.ReadFile(procLoadAvg)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, 0, fmt.Errorf("Invalid %s content %q", procLoadAvg, data)
+	}
+
+	load, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	freeCPUs := float64(runtime.NumCPU()) - load
+	if freeCPUs < 0 {
+		freeCPUs = 0
+	}
+
+	return memoryKb >> 10, freeCPUs, nil
+}
+
+// getHostMemoryAvailableKb returns the memory the host can give without
+// swapping, page cache included.
+func getHostMemoryAvailableKb(memInfoPath string) (uint64, error) {
+	f, err := os.Open(memInfoPath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Expected format: ["MemAvailable:", "1234", "kB"]
+		parts := strings.Fields(scanner.Text())
+		if len(parts) < 3 || parts[0] != "MemAvailable:" || parts[2] != "kB" {
+			continue
+		}
+
+		return strconv.ParseUint(parts[1], 0, 64)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	return 0, fmt.Errorf("unable get MemAvailable from %s", memInfoPath)
+}
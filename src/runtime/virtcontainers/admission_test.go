@@ -0,0 +1,149 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdmissionPolicyValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(AdmissionPolicy{}.validate())
+	assert.NoError(AdmissionPolicy{Mode: AdmissionStrict, QueueTimeout: time.Minute}.validate())
+	assert.NoError(AdmissionPolicy{Mode: AdmissionOvercommit, OvercommitRatio: 1.5}.validate())
+	assert.NoError(AdmissionPolicy{Mode: AdmissionBestEffort}.validate())
+
+	assert.Error(AdmissionPolicy{Mode: "lenient"}.validate())
+	assert.Error(AdmissionPolicy{Mode: AdmissionOvercommit}.validate())
+	assert.Error(AdmissionPolicy{Mode: AdmissionOvercommit, OvercommitRatio: 0.5}.validate())
+	assert.Error(AdmissionPolicy{Mode: AdmissionStrict, QueueTimeout: -time.Second}.validate())
+}
+
+func TestSandboxAdmissionRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	sconfig := newHotplugPlanTestConfig()
+	sconfig.HypervisorConfig.MemorySize = 2048
+
+	// 2 GiB of default memory plus 2 containers limited to 512 MiB, and 1
+	// default vCPU plus 2 for the quota of a container.
+	assert.Equal(admissionRequest{memoryMB: 3072, vcpus: 3}, sandboxAdmissionRequest(sconfig))
+
+	// The hotplug headroom is requested too, without being reserved.
+	sconfig.HotplugPlanning = HotplugPlanning{Enable: true, VCPUs: 2, MemorySlots: 10}
+	assert.Equal(admissionRequest{memoryMB: 3072, vcpus: 5}, sandboxAdmissionRequest(sconfig))
+	assert.Equal(uint32(1), sconfig.HypervisorConfig.MemSlots)
+}
+
+func TestAdmissionPolicyAdmit(t *testing.T) {
+	assert := assert.New(t)
+
+	req := admissionRequest{memoryMB: 3072, vcpus: 3}
+
+	strict := AdmissionPolicy{Mode: AdmissionStrict}
+	assert.NoError(strict.admit(req, 4096, 4))
+	assert.NoError(strict.admit(req, 3072, 3))
+	assert.Error(strict.admit(req, 2048, 4))
+	assert.Error(strict.admit(req, 4096, 2.5))
+
+	overcommit := AdmissionPolicy{Mode: AdmissionOvercommit, OvercommitRatio: 1.5}
+	assert.NoError(overcommit.admit(req, 2048, 2))
+	assert.Error(overcommit.admit(req, 2000, 2))
+	assert.Error(overcommit.admit(req, 2048, 1.5))
+}
+
+func TestAdmitSandbox(t *testing.T) {
+	assert := assert.New(t)
+
+	savedGetHostFreeResources := getHostFreeResources
+	savedRetryInterval := admissionRetryInterval
+	defer func() {
+		getHostFreeResources = savedGetHostFreeResources
+		admissionRetryInterval = savedRetryInterval
+	}()
+
+	admissionRetryInterval = time.Millisecond
+
+	freeMemoryMB := uint64(1024)
+	checks := 0
+	getHostFreeResources = func() (uint64, float64, error) {
+		checks++
+		return freeMemoryMB, 8, nil
+	}
+
+	sconfig := newHotplugPlanTestConfig()
+	sconfig.HypervisorConfig.MemorySize = 2048
+
+	s := &Sandbox{
+		id:     "sandbox",
+		ctx:    context.Background(),
+		config: sconfig,
+	}
+
+	// Without a policy, the host is not checked
+	assert.NoError(s.admitSandbox(context.Background()))
+	assert.Equal(0, checks)
+
+	sconfig.AdmissionPolicy = AdmissionPolicy{Mode: AdmissionBestEffort}
+	assert.NoError(s.admitSandbox(context.Background()))
+	assert.Equal(1, checks)
+
+	sconfig.AdmissionPolicy = AdmissionPolicy{Mode: AdmissionStrict}
+	assert.Error(s.admitSandbox(context.Background()))
+	assert.Equal(2, checks)
+
+	// A queued creation goes on once the host has room for the sandbox
+	sconfig.AdmissionPolicy.QueueTimeout = time.Minute
+	checks = 0
+	getHostFreeResources = func() (uint64, float64, error) {
+		checks++
+		if checks == 3 {
+			freeMemoryMB = 4096
+		}
+		return freeMemoryMB, 8, nil
+	}
+	assert.NoError(s.admitSandbox(context.Background()))
+	assert.Equal(3, checks)
+
+	// Or fails when its context is done
+	freeMemoryMB = 1024
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(context.DeadlineExceeded, s.admitSandbox(ctx))
+}
+
+func TestGetHostMemoryAvailableKb(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "meminfo")
+	_, err = getHostMemoryAvailableKb(file)
+	assert.Error(err)
+
+	err = ioutil.WriteFile(file, []byte("MemTotal:       16323728 kB\nMemFree:         1069316 kB\nMemAvailable:    9876543 kB\n"), 0640)
+	assert.NoError(err)
+
+	kb, err := getHostMemoryAvailableKb(file)
+	assert.NoError(err)
+	assert.Equal(uint64(9876543), kb)
+
+	err = ioutil.WriteFile(file, []byte("MemTotal:       16323728 kB\n"), 0640)
+	assert.NoError(err)
+
+	_, err = getHostMemoryAvailableKb(file)
+	assert.Error(err)
+}
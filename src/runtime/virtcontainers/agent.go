@@ -9,6 +9,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
 	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
 	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
@@ -181,6 +182,10 @@ type agent interface {
 	// reseedRNG will reseed the guest random number generator
 	reseedRNG(data []byte) error
 
+	// reconnect will connect to the agent of a sandbox restored from a
+	// snapshot, which runs the sandbox and its containers already.
+	reconnect(sandbox *Sandbox) error
+
 	// updateInterface will tell the agent to update a nic for an existed Sandbox.
 	updateInterface(inf *vcTypes.Interface) (*vcTypes.Interface, error)
 
@@ -199,9 +204,49 @@ type agent interface {
 	// setGuestDateTime asks the agent to set guest time to the provided one
 	setGuestDateTime(time.Time) error
 
+	// releaseDevice asks the agent to stop using a device of the sandbox
+	// before it is hot unplugged.
+	releaseDevice(sandbox *Sandbox, device api.Device) error
+
+	// suspendGuest asks the agent to suspend the guest to RAM. The agent
+	// replies before suspending it.
+	suspendGuest() error
+
+	// installFile asks the agent to install a file copied in the guest at
+	// its guest path.
+	installFile(src, path string) error
+
+	// loadCrashKernel asks the agent to load the kernel and the initrd,
+	// if any, copied in the guest as the kernel booted on panic.
+	loadCrashKernel(kernel, initrd string) error
+
+	// startProfiling asks the agent to start a profiling session, whose
+	// output the guest writes to the session directory.
+	startProfiling(session string, req ProfileRequest) error
+
+	// loadLivepatch asks the agent to apply a livepatch module copied in
+	// the guest.
+	loadLivepatch(module, path string) error
+
+	// checkpointContainer asks the agent to checkpoint a container to the
+	// guest images directory.
+	checkpointContainer(c *Container, imagesDir string, leaveRunning bool) error
+
+	// restoreContainer asks the agent to restore a checkpointed container
+	// from the guest images directory, in place of the created one.
+	restoreContainer(c *Container, imagesDir string) error
+
 	// copyFile copies file from host to container's rootfs
 	copyFile(src, dst string) error
 
+	// copyFileToRootfs copies file from host to the path dst of the
+	// container rootfs directory rootfs of the guest
+	copyFileToRootfs(src, rootfs, dst string) error
+
+	// copyFileFromRootfs copies the path src of the container rootfs
+	// directory rootfs of the guest to the host file dst
+	copyFileFromRootfs(rootfs, src, dst string) error
+
 	// markDead tell agent that the guest is dead
 	markDead()
 
@@ -220,4 +265,14 @@ type agent interface {
 
 	// getAgentMetrics get metrics of agent and guest through agent
 	getAgentMetrics(*grpc.GetMetricsRequest) (*grpc.Metrics, error)
+
+	// getTDReport returns the TDX report of the guest, binding reportData
+	getTDReport(reportData []byte) ([]byte, error)
+
+	// beginVMSwitch holds the requests to the agent back while the guest
+	// moves to another process of the hypervisor, until endVMSwitch is
+	// called. The agent is reached through vmSocket once the guest moved,
+	// vmSocket being nil when the guest did not move.
+	beginVMSwitch() error
+	endVMSwitch(sandbox *Sandbox, vmSocket interface{}) error
 }
@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"golang.org/x/net/context"
+)
+
+// AgentType is the guest agent implementation the runtime talks to.
+type AgentType string
+
+const (
+	// KataAgentType is the kata agent, the default agent of the sandboxes.
+	KataAgentType AgentType = "kata"
+
+	// ConsoleAgentType drives the foreign guests, which do not run the
+	// kata agent, through their console.
+	ConsoleAgentType AgentType = "console"
+)
+
+var (
+	agentImplementations     = map[AgentType]newAgentFuncType{}
+	agentImplementationsLock sync.RWMutex
+)
+
+func init() {
+	registerAgent(KataAgentType, newKataAgent)
+	registerAgent(ConsoleAgentType, newConsoleAgent)
+}
+
+// registerAgent makes an agent implementation selectable by its type.
+//
+// Alternative agents, such as agents speaking another revision of the agent
+// protocol or experimental ones, register themselves from the init function
+// of a file only built with their build tag, so that the runtime binary only
+// offers the agents it was built with.
+func registerAgent(agentType AgentType, newAgent newAgentFuncType) {
+	if agentType == "" || newAgent == nil {
+		panic("invalid agent registration")
+	}
+
+	agentImplementationsLock.Lock()
+	defer agentImplementationsLock.Unlock()
+
+	if _, ok := agentImplementations[agentType]; ok {
+		panic(fmt.Sprintf("agent type %q registered twice", agentType))
+	}
+	agentImplementations[agentType] = newAgent
+}
+
+func lookupAgent(agentType AgentType) (newAgentFuncType, bool) {
+	agentImplementationsLock.RLock()
+	defer agentImplementationsLock.RUnlock()
+
+	newAgent, ok := agentImplementations[agentType]
+	return newAgent, ok
+}
+
+// AgentTypes returns the agent types the runtime was built with.
+func AgentTypes() []AgentType {
+	agentImplementationsLock.RLock()
+	defer agentImplementationsLock.RUnlock()
+
+	types := make([]AgentType, 0, len(agentImplementations))
+	for t := range agentImplementations {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	return types
+}
+
+// agentType returns the agent type of the sandbox, the console agent for the
+// foreign guests, or an empty type if the sandbox uses the default agent.
+func (sandboxConfig *SandboxConfig) agentType() AgentType {
+	if sandboxConfig.isForeignGuest() {
+		return ConsoleAgentType
+	}
+	return sandboxConfig.AgentType
+}
+
+// checkAgentType checks that the agent type of the sandbox is registered,
+// and that it can drive the guest of the sandbox.
+func checkAgentType(sandboxConfig *SandboxConfig, factory Factory) error {
+	agentType := sandboxConfig.AgentType
+	if agentType == "" {
+		return nil
+	}
+
+	if _, ok := lookupAgent(agentType); !ok {
+		return fmt.Errorf("Unknown agent type %q, the runtime is built with %v", agentType, AgentTypes())
+	}
+
+	if sandboxConfig.isForeignGuest() != (agentType == ConsoleAgentType) {
+		if agentType == ConsoleAgentType {
+			return fmt.Errorf("The %q agent only drives foreign guests", agentType)
+		}
+		return fmt.Errorf("Guest OS %q cannot run the %q agent", sandboxConfig.GuestOS, agentType)
+	}
+
+	// The VMs of the factory are booted with the default agent.
+	if factory != nil && agentType != KataAgentType {
+		return fmt.Errorf("Agent type %q cannot be used with the VM factory", agentType)
+	}
+
+	return nil
+}
+
+// newSandboxAgent creates the agent of the sandbox. Unless the sandbox
+// selects an agent type, the agent is created by the function passed in
+// ctx, the kata agent by default.
+func newSandboxAgent(ctx context.Context, sandboxConfig *SandboxConfig) (agent, error) {
+	agentType := sandboxConfig.agentType()
+	if agentType == "" {
+		return getNewAgentFunc(ctx)(), nil
+	}
+
+	newAgent, ok := lookupAgent(agentType)
+	if !ok {
+		return nil, fmt.Errorf("Unknown agent type %q", agentType)
+	}
+
+	return newAgent(), nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterAgent(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]AgentType{ConsoleAgentType, KataAgentType}, AgentTypes())
+
+	assert.Panics(func() { registerAgent(KataAgentType, NewMockAgent) })
+	assert.Panics(func() { registerAgent("", NewMockAgent) })
+	assert.Panics(func() { registerAgent("test", nil) })
+
+	registerAgent("test", NewMockAgent)
+	defer func() {
+		agentImplementationsLock.Lock()
+		delete(agentImplementations, "test")
+		agentImplementationsLock.Unlock()
+	}()
+
+	assert.Equal([]AgentType{ConsoleAgentType, KataAgentType, "test"}, AgentTypes())
+	assert.NoError(checkAgentType(&SandboxConfig{AgentType: "test"}, nil))
+
+	a, err := newSandboxAgent(context.Background(), &SandboxConfig{AgentType: "test"})
+	assert.NoError(err)
+	assert.IsType(&mockAgent{}, a)
+}
+
+func TestCheckAgentType(t *testing.T) {
+	assert := assert.New(t)
+
+	config := &SandboxConfig{}
+	assert.NoError(checkAgentType(config, nil))
+	assert.NoError(checkAgentType(config, &noopFactory{}))
+
+	config.AgentType = KataAgentType
+	assert.NoError(checkAgentType(config, nil))
+	assert.NoError(checkAgentType(config, &noopFactory{}))
+
+	config.AgentType = "unknown"
+	assert.Error(checkAgentType(config, nil))
+
+	// The console agent only drives foreign guests
+	config.AgentType = ConsoleAgentType
+	assert.Error(checkAgentType(config, nil))
+
+	config.GuestOS = GuestOSOther
+	assert.NoError(checkAgentType(config, nil))
+
+	config.AgentType = KataAgentType
+	assert.Error(checkAgentType(config, nil))
+}
+
+func TestNewSandboxAgent(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := WithNewAgentFunc(context.Background(), NewMockAgent)
+
+	a, err := newSandboxAgent(context.Background(), &SandboxConfig{})
+	assert.NoError(err)
+	assert.IsType(&kataAgent{}, a)
+
+	a, err = newSandboxAgent(ctx, &SandboxConfig{})
+	assert.NoError(err)
+	assert.IsType(&mockAgent{}, a)
+
+	a, err = newSandboxAgent(ctx, &SandboxConfig{AgentType: KataAgentType})
+	assert.NoError(err)
+	assert.IsType(&kataAgent{}, a)
+
+	a, err = newSandboxAgent(ctx, &SandboxConfig{GuestOS: GuestOSOther})
+	assert.NoError(err)
+	assert.IsType(&consoleAgent{}, a)
+
+	_, err = newSandboxAgent(ctx, &SandboxConfig{AgentType: "unknown"})
+	assert.Error(err)
+}
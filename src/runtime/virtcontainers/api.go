@@ -7,12 +7,15 @@ package virtcontainers
 
 import (
 	"context"
-	"os"
+	"io"
 	"runtime"
+	"sort"
 	"syscall"
+	"time"
 
 	deviceApi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
 	deviceConfig "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	deviceManager "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/cgroups"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/compatoci"
@@ -83,6 +86,11 @@ func createSandboxFromConfig(ctx context.Context, sandboxConfig SandboxConfig, f
 		}
 	}()
 
+	// Check the host has room for the sandbox before creating its VM
+	if err = s.admitSandbox(ctx); err != nil {
+		return nil, err
+	}
+
 	// Create the sandbox network
 	if err = s.createNetwork(); err != nil {
 		return nil, err
@@ -114,7 +122,7 @@ func createSandboxFromConfig(ctx context.Context, sandboxConfig SandboxConfig, f
 	// rollback to stop VM if error occurs
 	defer func() {
 		if err != nil {
-			s.stopVM()
+			s.stopVM(false)
 		}
 	}()
 
@@ -137,6 +145,162 @@ func createSandboxFromConfig(ctx context.Context, sandboxConfig SandboxConfig, f
 	return s, nil
 }
 
+// CloneSandbox is the virtcontainers sandbox cloning entry point.
+// CloneSandbox creates and starts a sandbox running a copy of the guest of
+// a running cloneable sandbox, paused while its memory is copied. The clone
+// boots from the copy, mapped privately, instead of the guest kernel.
+func CloneSandbox(ctx context.Context, sourceID string, sandboxConfig SandboxConfig) (VCSandbox, error) {
+	span, ctx := trace(ctx, "CloneSandbox")
+	defer span.Finish()
+
+	if sourceID == "" {
+		return nil, vcTypes.ErrNeedSandboxID
+	}
+
+	unlock, err := rwLockSandbox(sourceID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	source, err := fetchSandbox(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return source.clone(ctx, sandboxConfig)
+}
+
+// CloneSandboxSnapshot creates and starts a sandbox running the guest of a
+// cloneable sandbox as it was when one of its periodic snapshots was taken.
+func CloneSandboxSnapshot(ctx context.Context, sourceID, snapshot string, sandboxConfig SandboxConfig) (VCSandbox, error) {
+	span, ctx := trace(ctx, "CloneSandboxSnapshot")
+	defer span.Finish()
+
+	if sourceID == "" {
+		return nil, vcTypes.ErrNeedSandboxID
+	}
+
+	unlock, err := rwLockSandbox(sourceID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	source, err := fetchSandbox(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return source.cloneSnapshot(ctx, snapshot, sandboxConfig)
+}
+
+// SnapshotSandbox is the virtcontainers entry point to save a running
+// sandbox, its guest memory included, to destPath, for the hypervisors
+// supporting it. The sandbox keeps running once it is saved.
+func SnapshotSandbox(ctx context.Context, sandboxID, destPath string) error {
+	span, ctx := trace(ctx, "SnapshotSandbox")
+	defer span.Finish()
+
+	if sandboxID == "" {
+		return vcTypes.ErrNeedSandboxID
+	}
+
+	unlock, err := rwLockSandbox(sandboxID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s, err := fetchSandbox(ctx, sandboxID)
+	if err != nil {
+		return err
+	}
+
+	return s.Snapshot(destPath)
+}
+
+// RestoreSandbox is the virtcontainers entry point to restore a sandbox
+// saved by SnapshotSandbox, such as after a host reboot. The restored VM
+// runs the guest as it was saved, and the sandbox reconnects to its agent
+// and to its containers, which are running.
+func RestoreSandbox(ctx context.Context, snapshotPath string) (VCSandbox, error) {
+	span, ctx := trace(ctx, "RestoreSandbox")
+	defer span.Finish()
+
+	s, err := restoreSandbox(ctx, snapshotPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// MigrateSandbox is the virtcontainers entry point to live migrate a
+// running sandbox to the host receiving it with ReceiveSandbox on
+// destination, for the hypervisors supporting it. The sandbox is deleted
+// on this host once it runs on the destination host.
+func MigrateSandbox(ctx context.Context, sandboxID, destination string) error {
+	span, ctx := trace(ctx, "MigrateSandbox")
+	defer span.Finish()
+
+	if sandboxID == "" {
+		return vcTypes.ErrNeedSandboxID
+	}
+
+	unlock, err := rwLockSandbox(sandboxID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s, err := fetchSandbox(ctx, sandboxID)
+	if err != nil {
+		return err
+	}
+
+	return s.Migrate(destination)
+}
+
+// ReceiveSandbox is the virtcontainers entry point to receive a sandbox
+// live migrated from another host with MigrateSandbox, on the TCP address
+// listenAddr. It returns once the sandbox runs on this host.
+func ReceiveSandbox(ctx context.Context, listenAddr string) (VCSandbox, error) {
+	span, ctx := trace(ctx, "ReceiveSandbox")
+	defer span.Finish()
+
+	s, err := receiveSandbox(ctx, listenAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// ListSandboxSnapshots returns the stored periodic snapshots of a sandbox,
+// the oldest first.
+func ListSandboxSnapshots(ctx context.Context, sandboxID string) ([]SnapshotInfo, error) {
+	span, ctx := trace(ctx, "ListSandboxSnapshots")
+	defer span.Finish()
+
+	if sandboxID == "" {
+		return nil, vcTypes.ErrNeedSandboxID
+	}
+
+	unlock, err := rLockSandbox(sandboxID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	s, err := fetchSandbox(ctx, sandboxID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.snapshotStore().list()
+}
+
 // DeleteSandbox is the virtcontainers sandbox deletion entry point.
 // DeleteSandbox will stop an already running container and then delete it.
 func DeleteSandbox(ctx context.Context, sandboxID string) (VCSandbox, error) {
@@ -313,18 +477,7 @@ func ListSandbox(ctx context.Context) ([]SandboxStatus, error) {
 		return []SandboxStatus{}, err
 	}
 
-	dir, err := os.Open(store.RunStoragePath())
-	if err != nil {
-		if os.IsNotExist(err) {
-			// No sandbox directory is not an error
-			return []SandboxStatus{}, nil
-		}
-		return []SandboxStatus{}, err
-	}
-
-	defer dir.Close()
-
-	sandboxesID, err := dir.Readdirnames(0)
+	sandboxesID, err := persist.ListSandboxes(store)
 	if err != nil {
 		return []SandboxStatus{}, err
 	}
@@ -343,6 +496,70 @@ func ListSandbox(ctx context.Context) ([]SandboxStatus, error) {
 	return sandboxStatusList, nil
 }
 
+// ListSandboxesByHypervisorVersion returns the status of the sandboxes whose
+// VM was started with the hypervisor version, such as the sandboxes still
+// running an older hypervisor.
+func ListSandboxesByHypervisorVersion(ctx context.Context, version string) ([]SandboxStatus, error) {
+	span, ctx := trace(ctx, "ListSandboxesByHypervisorVersion")
+	defer span.Finish()
+
+	sandboxes, err := ListSandbox(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []SandboxStatus
+	for _, s := range sandboxes {
+		if s.State.HypervisorVersion == version {
+			matching = append(matching, s)
+		}
+	}
+
+	return matching, nil
+}
+
+// ListSandboxesWithFilter returns the summaries of the sandboxes the filter
+// selects, by ID. They are read from the saved sandbox states, without
+// fetching the sandboxes, unlike ListSandbox.
+func ListSandboxesWithFilter(ctx context.Context, filter ListFilter) ([]SandboxSummary, error) {
+	span, _ := trace(ctx, "ListSandboxesWithFilter")
+	defer span.Finish()
+
+	store, err := persist.GetDriver()
+	if err != nil {
+		return nil, err
+	}
+
+	sandboxesID, err := persist.ListSandboxes(store)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(sandboxesID)
+
+	var summaries []SandboxSummary
+	for _, sandboxID := range sandboxesID {
+		if filter.Limit > 0 && len(summaries) == filter.Limit {
+			break
+		}
+
+		if sandboxID <= filter.After {
+			continue
+		}
+
+		// The sandboxes being created or deleted have no saved state
+		summary, err := fetchSandboxSummary(sandboxID)
+		if err != nil {
+			continue
+		}
+
+		if filter.matches(summary) {
+			summaries = append(summaries, summary)
+		}
+	}
+
+	return summaries, nil
+}
+
 // StatusSandbox is the virtcontainers sandbox status entry point.
 func StatusSandbox(ctx context.Context, sandboxID string) (SandboxStatus, error) {
 	span, ctx := trace(ctx, "StatusSandbox")
@@ -373,12 +590,14 @@ func StatusSandbox(ctx context.Context, sandboxID string) (SandboxStatus, error)
 		contStatusList = append(contStatusList, contStatus)
 	}
 
+	state, _ := s.reportedStates()
 	sandboxStatus := SandboxStatus{
 		ID:               s.id,
-		State:            s.state,
+		State:            state,
 		Hypervisor:       s.config.HypervisorType,
 		HypervisorConfig: s.config.HypervisorConfig,
 		ContainersStatus: contStatusList,
+		Arch:             hypervisorArch(s.config.HypervisorType, &s.config.HypervisorConfig),
 		Annotations:      s.config.Annotations,
 	}
 
@@ -503,6 +722,36 @@ func StopContainer(ctx context.Context, sandboxID, containerID string) (VCContai
 	return s.StopContainer(containerID, false)
 }
 
+// RestartContainer recreates a running container within its running VM,
+// reusing its devices, mounts and network, and starts it again. The VM
+// pays nothing for the restart: no device is hot unplugged and plugged
+// again.
+func RestartContainer(ctx context.Context, sandboxID, containerID string) (VCContainer, error) {
+	span, ctx := trace(ctx, "RestartContainer")
+	defer span.Finish()
+
+	if sandboxID == "" {
+		return nil, vcTypes.ErrNeedSandboxID
+	}
+
+	if containerID == "" {
+		return nil, vcTypes.ErrNeedContainerID
+	}
+
+	unlock, err := rwLockSandbox(sandboxID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	s, err := fetchSandbox(ctx, sandboxID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.restartContainer(containerID)
+}
+
 // EnterContainer is the virtcontainers container command execution entry point.
 // EnterContainer enters an already running container and runs a given command.
 func EnterContainer(ctx context.Context, sandboxID, containerID string, cmd types.Cmd) (VCSandbox, VCContainer, *Process, error) {
@@ -566,14 +815,16 @@ func StatusContainer(ctx context.Context, sandboxID, containerID string) (Contai
 
 func statusContainer(sandbox *Sandbox, containerID string) (ContainerStatus, error) {
 	if container, ok := sandbox.containers[containerID]; ok {
+		_, states := sandbox.reportedStates()
 		return ContainerStatus{
-			ID:          container.id,
-			State:       container.state,
-			PID:         container.process.Pid,
-			StartTime:   container.process.StartTime,
-			RootFs:      container.config.RootFs.Target,
-			Spec:        container.GetPatchedOCISpec(),
-			Annotations: container.config.Annotations,
+			ID:           container.id,
+			State:        states[containerID],
+			PID:          container.process.Pid,
+			StartTime:    container.process.StartTime,
+			RootFs:       container.config.RootFs.Target,
+			Spec:         container.GetPatchedOCISpec(),
+			Annotations:  container.config.Annotations,
+			BlockDevices: container.blockDeviceStatuses(),
 		}, nil
 	}
 
@@ -732,6 +983,381 @@ func StatsSandbox(ctx context.Context, sandboxID string) (SandboxStats, []Contai
 	return sandboxStats, containerStats, nil
 }
 
+func toggleSuspendSandbox(ctx context.Context, sandboxID string, suspend bool) error {
+	if sandboxID == "" {
+		return vcTypes.ErrNeedSandboxID
+	}
+
+	unlock, err := rwLockSandbox(sandboxID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s, err := fetchSandbox(ctx, sandboxID)
+	if err != nil {
+		return err
+	}
+
+	if suspend {
+		return s.SuspendToRAM()
+	}
+
+	return s.ResumeFromRAM()
+}
+
+// LivepatchSandbox applies a kernel livepatch module to the guest of a
+// running sandbox, among the modules its configuration allows, without
+// rebooting it. The guest kernel must trust the key the module is signed
+// with.
+func LivepatchSandbox(ctx context.Context, sandboxID, module string) error {
+	span, ctx := trace(ctx, "LivepatchSandbox")
+	defer span.Finish()
+
+	if sandboxID == "" {
+		return vcTypes.ErrNeedSandboxID
+	}
+
+	unlock, err := rwLockSandbox(sandboxID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s, err := fetchSandbox(ctx, sandboxID)
+	if err != nil {
+		return err
+	}
+
+	return s.livepatch(module)
+}
+
+// CopyFileToContainer copies the host file hostPath to guestPath in the
+// rootfs of a container, through the agent, without any shared filesystem.
+// A large file is copied by parts.
+func CopyFileToContainer(ctx context.Context, sandboxID, containerID, hostPath, guestPath string) error {
+	span, ctx := trace(ctx, "CopyFileToContainer")
+	defer span.Finish()
+
+	if sandboxID == "" {
+		return vcTypes.ErrNeedSandboxID
+	}
+
+	if containerID == "" {
+		return vcTypes.ErrNeedContainerID
+	}
+
+	unlock, err := rLockSandbox(sandboxID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s, err := fetchSandbox(ctx, sandboxID)
+	if err != nil {
+		return err
+	}
+
+	return s.copyFileToContainer(containerID, hostPath, guestPath)
+}
+
+// CopyFileFromContainer copies the file guestPath of the rootfs of a
+// container to the host file hostPath, through the agent. hostPath is only
+// replaced once the whole file is read.
+func CopyFileFromContainer(ctx context.Context, sandboxID, containerID, guestPath, hostPath string) error {
+	span, ctx := trace(ctx, "CopyFileFromContainer")
+	defer span.Finish()
+
+	if sandboxID == "" {
+		return vcTypes.ErrNeedSandboxID
+	}
+
+	if containerID == "" {
+		return vcTypes.ErrNeedContainerID
+	}
+
+	unlock, err := rLockSandbox(sandboxID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s, err := fetchSandbox(ctx, sandboxID)
+	if err != nil {
+		return err
+	}
+
+	return s.copyFileFromContainer(containerID, guestPath, hostPath)
+}
+
+// CheckpointContainer is the virtcontainers entry point to checkpoint a
+// running or paused container, with CRIU in the guest, to the host
+// directory opts.ImagesDir, shared with the guest for the checkpoint. The
+// other containers and the VM keep running.
+func CheckpointContainer(ctx context.Context, sandboxID, containerID string, opts CheckpointOptions) error {
+	span, ctx := trace(ctx, "CheckpointContainer")
+	defer span.Finish()
+
+	if sandboxID == "" {
+		return vcTypes.ErrNeedSandboxID
+	}
+
+	if containerID == "" {
+		return vcTypes.ErrNeedContainerID
+	}
+
+	unlock, err := rwLockSandbox(sandboxID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s, err := fetchSandbox(ctx, sandboxID)
+	if err != nil {
+		return err
+	}
+
+	return s.CheckpointContainer(containerID, opts)
+}
+
+// RestoreContainer is the virtcontainers entry point to restore the
+// processes of a container checkpointed to opts.ImagesDir, in place of a
+// created container of the same sandbox or of another one.
+func RestoreContainer(ctx context.Context, sandboxID, containerID string, opts CheckpointOptions) error {
+	span, ctx := trace(ctx, "RestoreContainer")
+	defer span.Finish()
+
+	if sandboxID == "" {
+		return vcTypes.ErrNeedSandboxID
+	}
+
+	if containerID == "" {
+		return vcTypes.ErrNeedContainerID
+	}
+
+	unlock, err := rwLockSandbox(sandboxID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s, err := fetchSandbox(ctx, sandboxID)
+	if err != nil {
+		return err
+	}
+
+	return s.RestoreContainer(containerID, opts)
+}
+
+// ResizeSandboxMemory resizes the memory of the VM of a running sandbox to
+// targetMB, beyond what its containers request. The VM is shrunk only if
+// the hypervisor can unplug memory, and never below its boot memory nor
+// the memory limits of its containers.
+func ResizeSandboxMemory(ctx context.Context, sandboxID string, targetMB uint32) error {
+	span, ctx := trace(ctx, "ResizeSandboxMemory")
+	defer span.Finish()
+
+	if sandboxID == "" {
+		return vcTypes.ErrNeedSandboxID
+	}
+
+	unlock, err := rwLockSandbox(sandboxID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s, err := fetchSandbox(ctx, sandboxID)
+	if err != nil {
+		return err
+	}
+
+	return s.ResizeMemory(targetMB)
+}
+
+// SandboxHostResources returns the host resources a sandbox claims: the
+// taps its VM is connected to, its cgroups, the huge pages backing the VM
+// memory, the host devices attached to the VM, and its vsock address.
+func SandboxHostResources(ctx context.Context, sandboxID string) (HostResources, error) {
+	span, ctx := trace(ctx, "SandboxHostResources")
+	defer span.Finish()
+
+	if sandboxID == "" {
+		return HostResources{}, vcTypes.ErrNeedSandboxID
+	}
+
+	unlock, err := rLockSandbox(sandboxID)
+	if err != nil {
+		return HostResources{}, err
+	}
+	defer unlock()
+
+	s, err := fetchSandbox(ctx, sandboxID)
+	if err != nil {
+		return HostResources{}, err
+	}
+
+	return s.HostResources()
+}
+
+// WatchSandboxEvents returns a channel receiving the events of a sandbox:
+// its lifecycle transitions, the OOMs of its containers, the crash of its
+// hypervisor and the results of the hotplugs of devices. The channel is
+// closed when ctx is done or the sandbox is released. Only the process
+// running the sandbox sees its events.
+func WatchSandboxEvents(ctx context.Context, sandboxID string) (<-chan SandboxEvent, error) {
+	span, _ := trace(ctx, "WatchSandboxEvents")
+	defer span.Finish()
+
+	if sandboxID == "" {
+		return nil, vcTypes.ErrNeedSandboxID
+	}
+
+	s, err := globalSandboxList.lookupSandbox(sandboxID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.watchEvents(ctx)
+}
+
+// GetLaunchMeasurement returns the launch measurement of the memory
+// encrypted VM of a sandbox, which the guest owner verifies before
+// provisioning secrets to the guest.
+func GetLaunchMeasurement(ctx context.Context, sandboxID string) (LaunchMeasurement, error) {
+	span, ctx := trace(ctx, "GetLaunchMeasurement")
+	defer span.Finish()
+
+	if sandboxID == "" {
+		return LaunchMeasurement{}, vcTypes.ErrNeedSandboxID
+	}
+
+	unlock, err := rLockSandbox(sandboxID)
+	if err != nil {
+		return LaunchMeasurement{}, err
+	}
+	defer unlock()
+
+	s, err := fetchSandbox(ctx, sandboxID)
+	if err != nil {
+		return LaunchMeasurement{}, err
+	}
+
+	return s.GetLaunchMeasurement()
+}
+
+// GetTDReport returns a TD report of the TDX guest of a sandbox, binding
+// reportData, which the guest owner has quoted and verifies before
+// provisioning secrets to the guest.
+func GetTDReport(ctx context.Context, sandboxID string, reportData []byte) ([]byte, error) {
+	span, ctx := trace(ctx, "GetTDReport")
+	defer span.Finish()
+
+	if sandboxID == "" {
+		return nil, vcTypes.ErrNeedSandboxID
+	}
+
+	unlock, err := rLockSandbox(sandboxID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	s, err := fetchSandbox(ctx, sandboxID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTDReport(reportData)
+}
+
+// CaptureSandboxTraffic captures, on the host, the traffic of a network
+// interface of a sandbox for a while, as its configuration allows, and
+// streams it back in the pcap format while the capture is taken. Closing
+// the capture stops it.
+func CaptureSandboxTraffic(ctx context.Context, sandboxID, iface string, duration time.Duration) (io.ReadCloser, error) {
+	span, ctx := trace(ctx, "CaptureSandboxTraffic")
+	defer span.Finish()
+
+	if sandboxID == "" {
+		return nil, vcTypes.ErrNeedSandboxID
+	}
+
+	unlock, err := rLockSandbox(sandboxID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	s, err := fetchSandbox(ctx, sandboxID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CaptureTraffic(iface, duration)
+}
+
+// SuspendSandboxToRAM is the virtcontainers entry point to suspend a
+// sandbox guest to RAM, for the hypervisors and machine types supporting
+// it.
+func SuspendSandboxToRAM(ctx context.Context, sandboxID string) error {
+	span, ctx := trace(ctx, "SuspendSandboxToRAM")
+	defer span.Finish()
+
+	return toggleSuspendSandbox(ctx, sandboxID, true)
+}
+
+// ResumeSandboxFromRAM is the virtcontainers entry point to wake up a
+// sandbox guest suspended to RAM.
+func ResumeSandboxFromRAM(ctx context.Context, sandboxID string) error {
+	span, ctx := trace(ctx, "ResumeSandboxFromRAM")
+	defer span.Finish()
+
+	return toggleSuspendSandbox(ctx, sandboxID, false)
+}
+
+func togglePauseSandbox(ctx context.Context, sandboxID string, pause bool) error {
+	if sandboxID == "" {
+		return vcTypes.ErrNeedSandboxID
+	}
+
+	unlock, err := rwLockSandbox(sandboxID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s, err := fetchSandbox(ctx, sandboxID)
+	if err != nil {
+		return err
+	}
+
+	if pause {
+		return s.Pause()
+	}
+
+	return s.Resume()
+}
+
+// PauseSandbox is the virtcontainers entry point to pause the VM of a
+// running sandbox, for the hypervisors supporting it, such as during host
+// maintenance.
+func PauseSandbox(ctx context.Context, sandboxID string) error {
+	span, ctx := trace(ctx, "PauseSandbox")
+	defer span.Finish()
+
+	return togglePauseSandbox(ctx, sandboxID, true)
+}
+
+// ResumeSandbox is the virtcontainers entry point to resume the VM of a
+// sandbox paused by PauseSandbox.
+func ResumeSandbox(ctx context.Context, sandboxID string) error {
+	span, ctx := trace(ctx, "ResumeSandbox")
+	defer span.Finish()
+
+	return togglePauseSandbox(ctx, sandboxID, false)
+}
+
 func togglePauseContainer(ctx context.Context, sandboxID, containerID string, pause bool) error {
 	if sandboxID == "" {
 		return vcTypes.ErrNeedSandboxID
@@ -798,6 +1424,30 @@ func AddDevice(ctx context.Context, sandboxID string, info deviceConfig.DeviceIn
 	return s.AddDevice(info)
 }
 
+// RemoveDevice will hot unplug a device added with AddDevice from the
+// sandbox and release it.
+func RemoveDevice(ctx context.Context, sandboxID, deviceID string) error {
+	span, ctx := trace(ctx, "RemoveDevice")
+	defer span.Finish()
+
+	if sandboxID == "" {
+		return vcTypes.ErrNeedSandboxID
+	}
+
+	unlock, err := rwLockSandbox(sandboxID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s, err := fetchSandbox(ctx, sandboxID)
+	if err != nil {
+		return err
+	}
+
+	return s.RemoveDevice(deviceID)
+}
+
 func toggleInterface(ctx context.Context, sandboxID string, inf *vcTypes.Interface, add bool) (*vcTypes.Interface, error) {
 	if sandboxID == "" {
 		return nil, vcTypes.ErrNeedSandboxID
@@ -958,3 +1608,63 @@ func CleanupContainer(ctx context.Context, sandboxID, containerID string, force
 
 	return nil
 }
+
+// GetSandboxConsole attaches to the console of a sandbox, where the agent
+// debug console runs when enabled. The console is shared by all the
+// clients attached to it: each of them reads the console output written
+// since it attached, and what they write is written to the console.
+// Closing the returned console detaches the client. The console is only
+// shared within the process attached to it, such as the shim serving the
+// sandbox, whose /debug-console endpoint the other processes go through.
+func GetSandboxConsole(ctx context.Context, sandboxID string) (io.ReadWriteCloser, error) {
+	span, ctx := trace(ctx, "GetSandboxConsole")
+	defer span.Finish()
+
+	if sandboxID == "" {
+		return nil, vcTypes.ErrNeedSandboxID
+	}
+
+	unlock, err := rLockSandbox(sandboxID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	s, err := fetchSandbox(ctx, sandboxID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.AttachConsole()
+}
+
+// CheckDeviceTopology reports the host NUMA locality of the devices and the
+// IOMMU group conflicts between them, without any sandbox, so that the
+// devices of a sandbox can be picked before creating it.
+func CheckDeviceTopology(ctx context.Context, devices []deviceConfig.DeviceInfo) (deviceConfig.DeviceTopology, error) {
+	span, _ := trace(ctx, "CheckDeviceTopology")
+	defer span.Finish()
+
+	return deviceManager.GetDeviceTopology(devices)
+}
+
+// PrefetchAssets loads the kernel, initrd, image and firmware of the VMs a
+// hypervisor configuration boots into the host page cache, to warm the
+// host up before bursts of sandbox creations. It returns the assets loaded.
+func PrefetchAssets(ctx context.Context, hypervisorConfig HypervisorConfig) ([]PrefetchedAsset, error) {
+	span, _ := trace(ctx, "PrefetchAssets")
+	defer span.Finish()
+
+	return prefetchAssets(&hypervisorConfig)
+}
+
+// PreallocateTaps creates in a network namespace the TAP interfaces the VM
+// of a hypervisor configuration is connected through, for the lightweight
+// sandbox created next in the namespace to attach to them rather than
+// creating them. It returns the names of the TAP interfaces created.
+func PreallocateTaps(ctx context.Context, netNSPath string, hypervisorType HypervisorType, hypervisorConfig HypervisorConfig, count int) ([]string, error) {
+	span, _ := trace(ctx, "PreallocateTaps")
+	defer span.Finish()
+
+	return preallocateTaps(netNSPath, count, tapQueues(hypervisorType, hypervisorConfig))
+}
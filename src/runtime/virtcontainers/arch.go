@@ -0,0 +1,300 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/assetstore"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+// qemuArchs maps the targets of the QEMU system emulators to the GOARCH
+// names.
+var qemuArchs = map[string]string{
+	"x86_64":  "amd64",
+	"aarch64": "arm64",
+	"ppc64":   "ppc64le",
+	"s390x":   "s390x",
+}
+
+// gptRootTypes maps the GPT partition types of the root partitions, from
+// the Discoverable Partitions Specification, to the GOARCH names.
+var gptRootTypes = map[string]string{
+	"4f68bce3-e8cd-4db1-96e7-fbcaf984b709": "amd64",
+	"b921b045-1df0-41c3-af44-4c6f280d3fae": "arm64",
+	"c31c45e6-3f39-412e-80fb-4809c4980599": "ppc64le",
+	"5eead9a9-fe09-4a1e-a1d7-520d00531306": "s390x",
+}
+
+// peMachines maps the PE and TE machine types to the GOARCH names.
+var peMachines = map[uint16]string{
+	0x8664: "amd64",
+	0xaa64: "arm64",
+}
+
+// ArchMismatchError is returned when the hypervisor, the kernel, the image
+// or the firmware of a sandbox is not for the architecture of the sandbox,
+// the one of the host.
+type ArchMismatchError struct {
+	// Asset is the mismatching asset.
+	Asset types.AssetType
+
+	// Path is the path of the asset.
+	Path string
+
+	// Arch is the architecture of the asset, and Expected the one of the
+	// sandbox, as GOARCH names.
+	Arch     string
+	Expected string
+}
+
+func (e *ArchMismatchError) Error() string {
+	return fmt.Sprintf("%s %q is for the %s architecture, not %s", e.Asset, e.Path, e.Arch, e.Expected)
+}
+
+// hypervisorArch returns the architecture of the VMs of the hypervisor, the
+// target of a QEMU system emulator and the host architecture otherwise.
+func hypervisorArch(hType HypervisorType, conf *HypervisorConfig) string {
+	if hType == QemuHypervisor {
+		path, _ := conf.HypervisorAssetPath()
+		target := strings.TrimPrefix(filepath.Base(path), "qemu-system-")
+		if arch, ok := qemuArchs[target]; ok {
+			return arch
+		}
+	}
+
+	return runtime.GOARCH
+}
+
+// CheckArch checks that the hypervisor targets the host architecture, and
+// that the kernel, image and firmware are built for it. The architecture of
+// an asset is read from its headers, an asset in an unknown format, or
+// missing, is not checked.
+func CheckArch(hType HypervisorType, conf *HypervisorConfig) error {
+	expected := runtime.GOARCH
+
+	if arch := hypervisorArch(hType, conf); arch != expected {
+		path, _ := conf.HypervisorAssetPath()
+		return &ArchMismatchError{
+			Asset:    types.HypervisorAsset,
+			Path:     path,
+			Arch:     arch,
+			Expected: expected,
+		}
+	}
+
+	assets := []struct {
+		asset  types.AssetType
+		path   func() (string, error)
+		detect func(*os.File) (string, error)
+	}{
+		{types.KernelAsset, conf.KernelAssetPath, kernelArch},
+		{types.ImageAsset, conf.ImageAssetPath, imageArch},
+		{types.FirmwareAsset, conf.FirmwareAssetPath, firmwareArch},
+	}
+
+	for _, a := range assets {
+		path, err := a.path()
+		if err != nil {
+			return err
+		}
+
+		// A remote asset is checked once fetched
+		if path == "" || assetstore.IsRemote(path) {
+			continue
+		}
+
+		arch, err := fileArch(path, a.detect)
+		if err != nil {
+			return fmt.Errorf("failed to read the architecture of %s %q: %v", a.asset, path, err)
+		}
+
+		if arch != "" && arch != expected {
+			return &ArchMismatchError{
+				Asset:    a.asset,
+				Path:     path,
+				Arch:     arch,
+				Expected: expected,
+			}
+		}
+	}
+
+	return nil
+}
+
+func fileArch(path string, detect func(*os.File) (string, error)) (string, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	return detect(f)
+}
+
+// readAt reads size bytes at offset of f, and returns nil past the end of
+// f.
+func readAt(f *os.File, offset int64, size int) ([]byte, error) {
+	data := make([]byte, size)
+	if _, err := f.ReadAt(data, offset); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// elfArch returns the architecture of an ELF file, "" if f is not one.
+func elfArch(f *os.File) (string, error) {
+	magic, err := readAt(f, 0, len(elf.ELFMAG))
+	if err != nil || string(magic) != elf.ELFMAG {
+		return "", err
+	}
+
+	e, err := elf.NewFile(f)
+	if err != nil {
+		return "", err
+	}
+
+	switch e.Machine {
+	case elf.EM_X86_64:
+		return "amd64", nil
+	case elf.EM_AARCH64:
+		return "arm64", nil
+	case elf.EM_PPC64:
+		if e.Data == elf.ELFDATA2LSB {
+			return "ppc64le", nil
+		}
+		return "ppc64", nil
+	case elf.EM_S390:
+		return "s390x", nil
+	default:
+		return strings.ToLower(strings.TrimPrefix(e.Machine.String(), "EM_")), nil
+	}
+}
+
+// peArch returns the architecture of a PE file at offset of f, "" if there
+// is none.
+func peArch(f *os.File, offset int64) (string, error) {
+	dos, err := readAt(f, offset, 0x40)
+	if err != nil || dos == nil || string(dos[:2]) != "MZ" {
+		return "", err
+	}
+
+	header, err := readAt(f, offset+int64(binary.LittleEndian.Uint32(dos[0x3c:])), 6)
+	if err != nil || header == nil || string(header[:4]) != "PE\x00\x00" {
+		return "", err
+	}
+
+	return peMachines[binary.LittleEndian.Uint16(header[4:])], nil
+}
+
+// kernelArch returns the architecture of a vmlinux, bzImage or arm64 Image
+// kernel.
+func kernelArch(f *os.File) (string, error) {
+	if arch, err := elfArch(f); arch != "" || err != nil {
+		return arch, err
+	}
+
+	if magic, err := readAt(f, 0x202, 4); err != nil || string(magic) == "HdrS" {
+		return "amd64", err
+	}
+
+	if magic, err := readAt(f, 0x38, 4); err != nil || string(magic) == "ARM\x64" {
+		return "arm64", err
+	}
+
+	return "", nil
+}
+
+// imageArch returns the architecture of the root partition of a GPT image.
+func imageArch(f *os.File) (string, error) {
+	const sectorSize = 512
+
+	header, err := readAt(f, sectorSize, 92)
+	if err != nil || header == nil || string(header[:8]) != "EFI PART" {
+		return "", err
+	}
+
+	lba := int64(binary.LittleEndian.Uint64(header[72:]))
+	count := int(binary.LittleEndian.Uint32(header[80:]))
+	size := int(binary.LittleEndian.Uint32(header[84:]))
+	if size < 16 || count > 1024 {
+		return "", nil
+	}
+
+	entries, err := readAt(f, lba*sectorSize, count*size)
+	if err != nil || entries == nil {
+		return "", err
+	}
+
+	for i := 0; i < count; i++ {
+		if arch, ok := gptRootTypes[gptGUID(entries[i*size:])]; ok {
+			return arch, nil
+		}
+	}
+
+	return "", nil
+}
+
+// gptGUID formats a GUID stored in the mixed endianness of GPT.
+func gptGUID(b []byte) string {
+	return fmt.Sprintf("%08x-%04x-%04x-%x-%x",
+		binary.LittleEndian.Uint32(b[0:4]),
+		binary.LittleEndian.Uint16(b[4:6]),
+		binary.LittleEndian.Uint16(b[6:8]),
+		b[8:10], b[10:16])
+}
+
+// firmwareArch returns the architecture of an ELF or PE firmware, or of the
+// first PE or TE image of a UEFI firmware volume.
+func firmwareArch(f *os.File) (string, error) {
+	if arch, err := elfArch(f); arch != "" || err != nil {
+		return arch, err
+	}
+
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+
+	// The images of the firmware volumes are 4 bytes aligned
+	for offset := 0; offset+8 <= len(data); offset += 4 {
+		switch {
+		case bytes.HasPrefix(data[offset:], []byte("MZ")):
+			if arch, err := peArch(f, int64(offset)); arch != "" || err != nil {
+				return arch, err
+			}
+		case bytes.HasPrefix(data[offset:], []byte("VZ")):
+			// The TE images of the SEC and PEI phases, of the EFI
+			// subsystems only.
+			subsystem := data[offset+5]
+			if subsystem < 10 || subsystem > 12 {
+				continue
+			}
+			if arch, ok := peMachines[binary.LittleEndian.Uint16(data[offset+2:])]; ok {
+				return arch, nil
+			}
+		}
+	}
+
+	return "", nil
+}
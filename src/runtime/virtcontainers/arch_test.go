@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeArchAsset(t *testing.T, dir, name string, size int, patches map[int][]byte) string {
+	data := make([]byte, size)
+	for offset, patch := range patches {
+		copy(data[offset:], patch)
+	}
+
+	path := filepath.Join(dir, name)
+	assert.NoError(t, ioutil.WriteFile(path, data, 0600))
+	return path
+}
+
+func TestAssetArch(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "arch")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	// An ELF file, the test binary
+	self, err := os.Executable()
+	assert.NoError(err)
+	arch, err := fileArch(self, kernelArch)
+	assert.NoError(err)
+	assert.Equal(runtime.GOARCH, arch)
+
+	bzImage := writeArchAsset(t, dir, "bzImage", 1024, map[int][]byte{0x202: []byte("HdrS")})
+	arch, err = fileArch(bzImage, kernelArch)
+	assert.NoError(err)
+	assert.Equal("amd64", arch)
+
+	image := writeArchAsset(t, dir, "Image", 1024, map[int][]byte{0x38: []byte("ARM\x64")})
+	arch, err = fileArch(image, kernelArch)
+	assert.NoError(err)
+	assert.Equal("arm64", arch)
+
+	// A GPT image with an arm64 root partition, second entry
+	header := make([]byte, 92)
+	copy(header, "EFI PART")
+	binary.LittleEndian.PutUint64(header[72:], 2)
+	binary.LittleEndian.PutUint32(header[80:], 4)
+	binary.LittleEndian.PutUint32(header[84:], 128)
+	root := []byte{0x45, 0xb0, 0x21, 0xb9, 0xf0, 0x1d, 0xc3, 0x41, 0xaf, 0x44, 0x4c, 0x6f, 0x28, 0x0d, 0x3f, 0xae}
+	gpt := writeArchAsset(t, dir, "gpt.img", 4096, map[int][]byte{512: header, 2*512 + 128: root})
+	arch, err = fileArch(gpt, imageArch)
+	assert.NoError(err)
+	assert.Equal("arm64", arch)
+
+	// A firmware volume with a TE image, of the EFI application subsystem
+	fv := writeArchAsset(t, dir, "OVMF.fd", 4096, map[int][]byte{0x100: {'V', 'Z', 0x64, 0x86, 1, 11}})
+	arch, err = fileArch(fv, firmwareArch)
+	assert.NoError(err)
+	assert.Equal("amd64", arch)
+
+	// Unknown formats and missing assets are not checked
+	unknown := writeArchAsset(t, dir, "unknown", 4096, nil)
+	for _, detect := range []func(*os.File) (string, error){kernelArch, imageArch, firmwareArch} {
+		arch, err = fileArch(unknown, detect)
+		assert.NoError(err)
+		assert.Empty(arch)
+
+		arch, err = fileArch(filepath.Join(dir, "missing"), detect)
+		assert.NoError(err)
+		assert.Empty(arch)
+	}
+}
+
+func TestCheckArch(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "arch")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	other, qemuTarget := "arm64", "aarch64"
+	if runtime.GOARCH == "arm64" {
+		other, qemuTarget = "amd64", "x86_64"
+	}
+
+	self, err := os.Executable()
+	assert.NoError(err)
+
+	conf := HypervisorConfig{
+		HypervisorPath: filepath.Join(dir, "qemu-system-"+qemuTarget),
+		KernelPath:     self,
+		ImagePath:      filepath.Join(dir, "missing"),
+	}
+
+	var archErr *ArchMismatchError
+	err = CheckArch(QemuHypervisor, &conf)
+	assert.True(errors.As(err, &archErr))
+	assert.Equal(types.HypervisorAsset, archErr.Asset)
+	assert.Equal(other, archErr.Arch)
+	assert.Equal(runtime.GOARCH, archErr.Expected)
+	assert.Equal(other, hypervisorArch(QemuHypervisor, &conf))
+
+	// Only the QEMU system emulators have a target
+	assert.NoError(CheckArch(ClhHypervisor, &conf))
+	assert.Equal(runtime.GOARCH, hypervisorArch(ClhHypervisor, &conf))
+
+	conf.HypervisorPath = "/usr/bin/qemu-kvm"
+	assert.NoError(CheckArch(QemuHypervisor, &conf))
+
+	patches := map[int][]byte{0x38: []byte("ARM\x64")}
+	if other == "amd64" {
+		patches = map[int][]byte{0x202: []byte("HdrS")}
+	}
+	conf.KernelPath = writeArchAsset(t, dir, "vmlinuz", 1024, patches)
+
+	err = CheckArch(QemuHypervisor, &conf)
+	assert.True(errors.As(err, &archErr))
+	assert.Equal(types.KernelAsset, archErr.Asset)
+	assert.Equal(conf.KernelPath, archErr.Path)
+	assert.Equal(other, archErr.Arch)
+}
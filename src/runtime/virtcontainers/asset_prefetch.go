@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/assetstore"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"golang.org/x/sys/unix"
+)
+
+// prefetchAssetTypes are the assets of the VM read from the host disk when
+// it boots.
+var prefetchAssetTypes = []types.AssetType{
+	types.KernelAsset,
+	types.InitrdAsset,
+	types.ImageAsset,
+	types.FirmwareAsset,
+}
+
+// PrefetchedAsset is an asset of the VM loaded into the host page cache.
+type PrefetchedAsset struct {
+	Type types.AssetType
+	Path string
+
+	// Size is the size, in bytes, of the asset.
+	Size int64
+
+	// Duration is how long loading the asset took, close to zero when it
+	// was cached already.
+	Duration time.Duration
+}
+
+// prefetchAsset loads a file into the host page cache, and returns its
+// size.
+func prefetchAsset(path string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	// Have the kernel read the whole file ahead in large requests, then
+	// read it through for it to be cached once returning.
+	if err := unix.Fadvise(int(f.Fd()), 0, 0, unix.FADV_WILLNEED); err != nil {
+		virtLog.WithError(err).WithField("asset", path).Debug("Could not advise the kernel to read the asset ahead")
+	}
+
+	return io.Copy(ioutil.Discard, f)
+}
+
+// prefetchAssets loads the kernel, initrd, image and firmware the
+// hypervisor configuration boots the VM with into the host page cache, so
+// that the sandboxes created next do not wait for a slow disk. The assets
+// which are not configured are skipped.
+func prefetchAssets(hypervisorConfig *HypervisorConfig) ([]PrefetchedAsset, error) {
+	var assets []PrefetchedAsset
+
+	for _, t := range prefetchAssetTypes {
+		path, err := hypervisorConfig.assetPath(t)
+		if err != nil {
+			return assets, err
+		}
+
+		// The remote assets are fetched by the first sandbox using them
+		if path == "" || assetstore.IsRemote(path) {
+			continue
+		}
+
+		start := time.Now()
+		size, err := prefetchAsset(path)
+		if err != nil {
+			return assets, fmt.Errorf("Could not prefetch the %s asset %s: %v", t, path, err)
+		}
+
+		assets = append(assets, PrefetchedAsset{
+			Type:     t,
+			Path:     path,
+			Size:     size,
+			Duration: time.Since(start),
+		})
+	}
+
+	return assets, nil
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefetchAssets(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "prefetch")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	kernel := filepath.Join(dir, "vmlinuz")
+	image := filepath.Join(dir, "kata-containers.img")
+	assert.NoError(ioutil.WriteFile(kernel, make([]byte, 4096), 0600))
+	assert.NoError(ioutil.WriteFile(image, make([]byte, 8192), 0600))
+
+	config := &HypervisorConfig{
+		KernelPath: kernel,
+		ImagePath:  image,
+	}
+
+	// The initrd and the firmware are not configured
+	assets, err := prefetchAssets(config)
+	assert.NoError(err)
+	assert.Len(assets, 2)
+	assert.Equal(types.KernelAsset, assets[0].Type)
+	assert.Equal(kernel, assets[0].Path)
+	assert.Equal(int64(4096), assets[0].Size)
+	assert.Equal(types.ImageAsset, assets[1].Type)
+	assert.Equal(image, assets[1].Path)
+	assert.Equal(int64(8192), assets[1].Size)
+
+	config.FirmwarePath = filepath.Join(dir, "OVMF.fd")
+	_, err = prefetchAssets(config)
+	assert.Error(err)
+}
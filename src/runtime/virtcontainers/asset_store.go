@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"crypto/ed25519"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/assetstore"
+)
+
+// pinStoredAssets replaces the paths of the kernel, initrd, image and
+// firmware of a new sandbox taken from the asset store with the paths of
+// the versions their names point to, and pins these versions for the
+// sandbox until it is deleted. The remote assets are fetched into the store
+// first, on their first use.
+func pinStoredAssets(ctx context.Context, sandboxConfig *SandboxConfig) error {
+	store, err := assetstore.New(sandboxConfig.AssetStore)
+	if err != nil {
+		return err
+	}
+
+	conf := &sandboxConfig.HypervisorConfig
+	assets := []*string{&conf.KernelPath, &conf.InitrdPath, &conf.ImagePath, &conf.FirmwarePath}
+
+	var fetcher *assetstore.Fetcher
+	paths := make([]string, len(assets))
+	for i, a := range assets {
+		paths[i] = *a
+		if !assetstore.IsRemote(*a) {
+			continue
+		}
+
+		if fetcher == nil {
+			if fetcher, err = newAssetFetcher(store, sandboxConfig.AssetSigningKey); err != nil {
+				return err
+			}
+		}
+
+		if paths[i], err = fetcher.Fetch(ctx, *a); err != nil {
+			return err
+		}
+	}
+
+	if paths, err = store.Pin(sandboxConfig.ID, paths); err != nil {
+		return err
+	}
+
+	for i, a := range assets {
+		*a = paths[i]
+	}
+
+	return nil
+}
+
+// newAssetFetcher returns the fetcher of the remote assets into store,
+// which must be signed with the key of the signingKey file, if set.
+func newAssetFetcher(store *assetstore.Store, signingKey string) (*assetstore.Fetcher, error) {
+	var publicKey ed25519.PublicKey
+
+	if signingKey != "" {
+		var err error
+		if publicKey, err = assetstore.LoadPublicKey(signingKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return assetstore.NewFetcher(store, publicKey), nil
+}
+
+// releaseStoredAssets releases the versions of the assets the sandbox
+// pinned, for the garbage collection of the asset store to remove them
+// once no name points to them.
+func (s *Sandbox) releaseStoredAssets() {
+	store, err := assetstore.New(s.config.AssetStore)
+	if err == nil {
+		err = store.Release(s.id)
+	}
+
+	if err != nil {
+		s.Logger().WithError(err).Error("failed to release the assets pinned in the asset store")
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/assetstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPinStoredAssets(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "asset-store")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	store, err := assetstore.New(dir)
+	assert.NoError(err)
+
+	v1, err := store.Import("vmlinuz", strings.NewReader("kernel v1"))
+	assert.NoError(err)
+
+	sandboxConfig := &SandboxConfig{
+		ID:         "test-assets",
+		AssetStore: dir,
+		HypervisorConfig: HypervisorConfig{
+			KernelPath: store.RefPath("vmlinuz"),
+			ImagePath:  "/usr/share/kata-containers/kata-containers.img",
+		},
+	}
+
+	assert.NoError(pinStoredAssets(context.Background(), sandboxConfig))
+	assert.Equal(v1, filepath.Base(sandboxConfig.HypervisorConfig.KernelPath))
+	assert.Equal("/usr/share/kata-containers/kata-containers.img", sandboxConfig.HypervisorConfig.ImagePath)
+	assert.Empty(sandboxConfig.HypervisorConfig.InitrdPath)
+
+	// The pinned version outlives the swap of its name until the sandbox
+	// releases it
+	_, err = store.Import("vmlinuz", strings.NewReader("kernel v2"))
+	assert.NoError(err)
+
+	noneActive := func(string) bool { return false }
+	removed, err := store.GC(noneActive)
+	assert.NoError(err)
+	assert.Empty(removed)
+
+	s := &Sandbox{id: sandboxConfig.ID, config: sandboxConfig}
+	s.releaseStoredAssets()
+
+	removed, err = store.GC(noneActive)
+	assert.NoError(err)
+	assert.Equal([]string{v1}, removed)
+
+	sandboxConfig.HypervisorConfig.KernelPath = "https://example.com/vmlinuz"
+	sandboxConfig.AssetStore = ""
+	assert.Error(sandboxConfig.validate(), "remote assets require an asset store")
+
+	sandboxConfig.AssetStore = dir
+	sandboxConfig.HypervisorConfig.KernelPath = store.RefPath("missing")
+	assert.Error(pinStoredAssets(context.Background(), sandboxConfig))
+}
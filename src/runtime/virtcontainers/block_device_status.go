@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+)
+
+// blockDriveSerialLen is the length of the serials set on the block
+// devices, the most a virtio-blk device ID holds.
+const blockDriveSerialLen = 20
+
+// BlockDeviceStatus describes a block device attached to a container, and
+// where the guest finds it.
+type BlockDeviceStatus struct {
+	// ContainerPath is the path of the device, or the mount point of the
+	// volume, in the container. It is "/" for the container rootfs.
+	ContainerPath string
+
+	// HostPath is the host block device or file backing the device.
+	HostPath string
+
+	// Serial is the serial number of the device in the guest, derived
+	// from HostPath. It is empty if the hypervisor sets none.
+	Serial string
+
+	// GuestPath is the stable path of the device in the guest, under
+	// /dev/disk/by-id, which does not depend on the hotplug order.
+	// It is empty if the device has no serial.
+	GuestPath string
+
+	// VirtPath is the path of the device in the guest at hotplug time.
+	VirtPath string
+
+	// PCIAddr is the PCI address of a virtio-blk device.
+	PCIAddr string
+
+	// SCSIAddr is the SCSI address of a virtio-scsi device.
+	SCSIAddr string
+}
+
+// blockDriveSerial returns the serial of the block device backed by
+// hostPath, the same for a volume across sandboxes.
+func blockDriveSerial(hostPath string) string {
+	sum := sha256.Sum256([]byte(hostPath))
+	return hex.EncodeToString(sum[:])[:blockDriveSerialLen]
+}
+
+// blockDriveGuestPath returns the path udev links the block device to in
+// the guest, from its serial.
+func blockDriveGuestPath(drive *config.BlockDrive) string {
+	switch {
+	case drive.Serial == "":
+		return ""
+	case drive.SCSIAddr != "":
+		return "/dev/disk/by-id/scsi-0QEMU_QEMU_HARDDISK_" + drive.Serial
+	case drive.PCIAddr != "":
+		return "/dev/disk/by-id/virtio-" + drive.Serial
+	}
+	return ""
+}
+
+// blockDeviceStatuses returns the status of the block devices attached to
+// the container: its rootfs, its volumes and its devices.
+func (c *Container) blockDeviceStatuses() []BlockDeviceStatus {
+	if c.sandbox == nil || c.sandbox.devManager == nil {
+		return nil
+	}
+
+	var statuses []BlockDeviceStatus
+	add := func(containerPath, devID string) {
+		device := c.sandbox.devManager.GetDeviceByID(devID)
+		if device == nil || device.DeviceType() != config.DeviceBlock {
+			return
+		}
+
+		drive, ok := device.GetDeviceInfo().(*config.BlockDrive)
+		if !ok || drive == nil {
+			return
+		}
+
+		statuses = append(statuses, BlockDeviceStatus{
+			ContainerPath: containerPath,
+			HostPath:      drive.File,
+			Serial:        drive.Serial,
+			GuestPath:     blockDriveGuestPath(drive),
+			VirtPath:      drive.VirtPath,
+			PCIAddr:       drive.PCIAddr,
+			SCSIAddr:      drive.SCSIAddr,
+		})
+	}
+
+	if c.state.BlockDeviceID != "" {
+		add("/", c.state.BlockDeviceID)
+	}
+
+	for _, m := range c.mounts {
+		if m.BlockDeviceID != "" {
+			add(m.Destination, m.BlockDeviceID)
+		}
+	}
+
+	for _, dev := range c.devices {
+		add(dev.ContainerPath, dev.ID)
+	}
+
+	return statuses
+}
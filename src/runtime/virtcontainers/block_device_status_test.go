@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/drivers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockDriveSerial(t *testing.T) {
+	assert := assert.New(t)
+
+	serial := blockDriveSerial("/dev/mapper/volume-1")
+	assert.Len(serial, blockDriveSerialLen)
+	assert.Equal(serial, blockDriveSerial("/dev/mapper/volume-1"))
+	assert.NotEqual(serial, blockDriveSerial("/dev/mapper/volume-2"))
+}
+
+func TestBlockDriveGuestPath(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(blockDriveGuestPath(&config.BlockDrive{PCIAddr: "02/01"}))
+	assert.Equal("/dev/disk/by-id/virtio-0123456789abcdef0123",
+		blockDriveGuestPath(&config.BlockDrive{Serial: "0123456789abcdef0123", PCIAddr: "02/01"}))
+	assert.Equal("/dev/disk/by-id/scsi-0QEMU_QEMU_HARDDISK_0123456789abcdef0123",
+		blockDriveGuestPath(&config.BlockDrive{Serial: "0123456789abcdef0123", SCSIAddr: "0:1"}))
+	assert.Empty(blockDriveGuestPath(&config.BlockDrive{Serial: "0123456789abcdef0123", NvdimmID: "0"}))
+}
+
+func TestContainerBlockDeviceStatuses(t *testing.T) {
+	assert := assert.New(t)
+
+	rootfs := drivers.NewBlockDevice(&config.DeviceInfo{ID: "rootfs"})
+	rootfs.BlockDrive = &config.BlockDrive{File: "/dev/sdb", SCSIAddr: "0:1", Serial: blockDriveSerial("/dev/sdb")}
+	volume := drivers.NewBlockDevice(&config.DeviceInfo{ID: "volume"})
+	volume.BlockDrive = &config.BlockDrive{File: "/dev/sdc", PCIAddr: "02/01", VirtPath: "/dev/vdb", Serial: blockDriveSerial("/dev/sdc")}
+	generic := drivers.NewGenericDevice(&config.DeviceInfo{ID: "generic"})
+
+	devManager := manager.NewDeviceManager(config.VirtioSCSI, false, "", []api.Device{rootfs, volume, generic})
+
+	c := &Container{
+		sandbox: &Sandbox{devManager: devManager},
+		state:   types.ContainerState{BlockDeviceID: "rootfs"},
+		mounts: []Mount{
+			{Destination: "/data", BlockDeviceID: "volume"},
+			{Destination: "/tmp"},
+		},
+		devices: []ContainerDevice{
+			{ID: "generic", ContainerPath: "/dev/null"},
+		},
+	}
+
+	statuses := c.blockDeviceStatuses()
+	assert.Len(statuses, 2)
+
+	assert.Equal("/", statuses[0].ContainerPath)
+	assert.Equal("/dev/sdb", statuses[0].HostPath)
+	assert.Equal("/dev/disk/by-id/scsi-0QEMU_QEMU_HARDDISK_"+blockDriveSerial("/dev/sdb"), statuses[0].GuestPath)
+
+	assert.Equal("/data", statuses[1].ContainerPath)
+	assert.Equal("/dev/sdc", statuses[1].HostPath)
+	assert.Equal("/dev/vdb", statuses[1].VirtPath)
+	assert.Equal("02/01", statuses[1].PCIAddr)
+	assert.Equal("/dev/disk/by-id/virtio-"+blockDriveSerial("/dev/sdc"), statuses[1].GuestPath)
+
+	// No device manager
+	assert.Nil((&Container{sandbox: &Sandbox{}}).blockDeviceStatuses())
+}
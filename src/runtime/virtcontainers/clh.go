@@ -47,6 +47,7 @@ const (
 const (
 	clhStateCreated = "Created"
 	clhStateRunning = "Running"
+	clhStatePaused  = "Paused"
 )
 
 const (
@@ -58,6 +59,8 @@ const (
 	// Use longer time timeout for it.
 	clhHotPlugAPITimeout  = 5
 	clhStopSandboxTimeout = 3
+	// Saving or restoring the whole guest memory takes longer too.
+	clhSnapshotAPITimeout = 300
 	clhSocket             = "clh.sock"
 	clhAPISocket          = "clh-api.sock"
 	virtioFsSocket        = "virtiofsd.sock"
@@ -65,6 +68,9 @@ const (
 	supportedMinorVersion = 5
 	defaultClhPath        = "/usr/local/bin/cloud-hypervisor"
 	virtioFsCacheAlways   = "always"
+	// Memory hotplug method resizing the guest memory with virtio-mem,
+	// which, unlike ACPI, also unplugs memory.
+	clhHotplugMethodVirtioMem = "VirtioMem"
 )
 
 // Interface that hides the implementation of openAPI client
@@ -89,6 +95,16 @@ type clhClient interface {
 	VmAddDevicePut(ctx context.Context, vmAddDevice chclient.VmAddDevice) (*http.Response, error)
 	// Add a new disk device to the VM
 	VmAddDiskPut(ctx context.Context, diskConfig chclient.DiskConfig) (*http.Response, error)
+	// Remove a disk or VFIO PCI device from the VM
+	VmRemoveDevicePut(ctx context.Context, vmRemoveDevice chclient.VmRemoveDevice) (*http.Response, error)
+	// Pause the VM
+	PauseVM(ctx context.Context) (*http.Response, error)
+	// Resume the paused VM
+	ResumeVM(ctx context.Context) (*http.Response, error)
+	// Save the state of the paused VM
+	VmSnapshotPut(ctx context.Context, vmSnapshotConfig chclient.VmSnapshotConfig) (*http.Response, error)
+	// Restore the VM from a snapshot
+	VmRestorePut(ctx context.Context, restoreConfig chclient.RestoreConfig) (*http.Response, error)
 }
 
 type CloudHypervisorVersion struct {
@@ -123,6 +139,7 @@ type cloudHypervisor struct {
 	vmconfig  chclient.VmConfig
 	virtiofsd Virtiofsd
 	store     persistapi.PersistDriver
+	isolation *vmmIsolation
 }
 
 var clhKernelParams = []Param{
@@ -135,6 +152,19 @@ var clhKernelParams = []Param{
 	{"rootfstype", "ext4"},
 }
 
+// clhTDXKernelParams are the default parameters of the TDX guests, booting
+// from the image attached as their first disk.
+func clhTDXKernelParams() []Param {
+	params := make([]Param, 0, len(clhKernelParams))
+	for _, p := range clhKernelParams {
+		if p.Key == "root" {
+			p.Value = "/dev/vda1"
+		}
+		params = append(params, p)
+	}
+	return params
+}
+
 var clhDebugKernelParams = []Param{
 
 	{"console", "ttyS0,115200n8"},     // enable serial console
@@ -205,6 +235,9 @@ func (clh *cloudHypervisor) createSandbox(ctx context.Context, id string, networ
 
 	if clh.state.PID > 0 {
 		clh.Logger().WithField("function", "createSandbox").Info("Sandbox already exist, loading from state")
+		if clh.isolation != nil {
+			clh.isolation.root = vmmIsolationRoot(clh.id)
+		}
 		clh.virtiofsd = &virtiofsd{
 			PID:        clh.state.VirtiofsdPID,
 			sourcePath: filepath.Join(getSharePath(clh.id)),
@@ -218,12 +251,26 @@ func (clh *cloudHypervisor) createSandbox(ctx context.Context, id string, networ
 	// to fetch if this is the first time the hypervisor is created.
 	clh.Logger().WithField("function", "createSandbox").WithError(err).Info("Sandbox not found creating ")
 
+	if clh.config.MemoryEncryption == MemoryEncryptionTDX {
+		if err := checkHostMemoryEncryption(clh.config.MemoryEncryption); err != nil {
+			return err
+		}
+
+		// The TDX firmware measures the kernel and boots it
+		clh.vmconfig.Tdx = &chclient.TdxConfig{
+			Firmware: clh.config.FirmwarePath,
+		}
+	}
+
 	// Set initial memomory size of the virtual machine
 	// Convert to int64 openApiClient only support int64
 	clh.vmconfig.Memory.Size = int64((utils.MemUnit(clh.config.MemorySize) * utils.MiB).ToBytes())
 	clh.vmconfig.Memory.File = "/dev/shm"
 	// shared memory should be enabled if using vhost-user(kata uses virtiofsd)
 	clh.vmconfig.Memory.Shared = true
+	if clh.config.VirtioMem {
+		clh.vmconfig.Memory.HotplugMethod = clhHotplugMethodVirtioMem
+	}
 	hostMemKb, err := getHostMemorySizeKb(procMemInfo)
 	if err != nil {
 		return nil
@@ -249,6 +296,9 @@ func (clh *cloudHypervisor) createSandbox(ctx context.Context, id string, networ
 
 	// First take the default parameters defined by this driver
 	params := clhKernelParams
+	if clh.config.MemoryEncryption == MemoryEncryptionTDX {
+		params = clhTDXKernelParams()
+	}
 
 	// Followed by extra debug parameters if debug enabled in configuration file
 	if clh.config.Debug {
@@ -258,6 +308,8 @@ func (clh *cloudHypervisor) createSandbox(ctx context.Context, id string, networ
 	// Followed by extra debug parameters defined in the configuration file
 	params = append(params, clh.config.KernelParams...)
 
+	params = clh.config.appendPayloadParams(params)
+
 	clh.vmconfig.Cmdline.Args = kernelParamsToString(params)
 
 	// set random device generator to hypervisor
@@ -275,11 +327,20 @@ func (clh *cloudHypervisor) createSandbox(ctx context.Context, id string, networ
 		return errors.New("image path is empty")
 	}
 
-	pmem := chclient.PmemConfig{
-		File:          imagePath,
-		DiscardWrites: true,
+	if clh.config.MemoryEncryption == MemoryEncryptionTDX {
+		// The private memory of the guest cannot map the image
+		disk := chclient.DiskConfig{
+			Path:     imagePath,
+			Readonly: true,
+		}
+		clh.vmconfig.Disks = append(clh.vmconfig.Disks, disk)
+	} else {
+		pmem := chclient.PmemConfig{
+			File:          imagePath,
+			DiscardWrites: true,
+		}
+		clh.vmconfig.Pmem = append(clh.vmconfig.Pmem, pmem)
 	}
-	clh.vmconfig.Pmem = append(clh.vmconfig.Pmem, pmem)
 
 	// set the serial console to the cloud hypervisor
 	if clh.config.Debug {
@@ -357,17 +418,31 @@ func (clh *cloudHypervisor) startSandbox(timeout int) error {
 		return errors.New("cloud-hypervisor only supports virtio based file sharing")
 	}
 
+	if clh.config.EnableVMMIsolation {
+		if err := clh.setupVMMIsolation(vmPath); err != nil {
+			if shutdownErr := clh.virtiofsd.Stop(); shutdownErr != nil {
+				clh.Logger().WithField("error", shutdownErr).Warn("error shutting down Virtiofsd")
+			}
+			return err
+		}
+	}
+
 	var strErr string
 	strErr, pid, err := clh.LaunchClh()
 	if err != nil {
 		if shutdownErr := clh.virtiofsd.Stop(); shutdownErr != nil {
 			clh.Logger().WithField("error", shutdownErr).Warn("error shutting down Virtiofsd")
 		}
+		clh.teardownVMMIsolation()
 		return fmt.Errorf("failed to launch cloud-hypervisor: %q, hypervisor output:\n%s", err, strErr)
 	}
 	clh.state.PID = pid
 
-	if err := clh.bootVM(ctx); err != nil {
+	if clh.config.SnapshotPath != "" {
+		if err := clh.restoreVM(); err != nil {
+			return err
+		}
+	} else if err := clh.bootVM(ctx); err != nil {
 		return err
 	}
 
@@ -375,6 +450,69 @@ func (clh *cloudHypervisor) startSandbox(timeout int) error {
 	return nil
 }
 
+// setupVMMIsolation builds the root the isolated VMM runs in, and hands the
+// VM directory, the virtiofsd socket and the tap devices over to the VMM
+// host ID.
+func (clh *cloudHypervisor) setupVMMIsolation(vmPath string) (err error) {
+	clhPath, err := clh.clhPath()
+	if err != nil {
+		return err
+	}
+
+	roPaths := []string{clhPath, clh.vmconfig.Kernel.Path}
+	for _, pmem := range clh.vmconfig.Pmem {
+		roPaths = append(roPaths, pmem.File)
+	}
+	for _, disk := range clh.vmconfig.Disks {
+		roPaths = append(roPaths, disk.Path)
+	}
+	for _, path := range []string{clh.config.InitrdPath, clh.config.FirmwarePath, clh.config.SnapshotPath, clh.config.EntropySource} {
+		if path != "" {
+			roPaths = append(roPaths, path)
+		}
+	}
+
+	clh.isolation, err = newVMMIsolation(clh.id, roPaths, []string{vmPath})
+	if err != nil {
+		clh.isolation = nil
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			clh.teardownVMMIsolation()
+		}
+	}()
+
+	virtiofsdSocketPath, err := clh.virtioFsSocketPath(clh.id)
+	if err != nil {
+		return err
+	}
+	if err := clh.isolation.handOver(virtiofsdSocketPath); err != nil {
+		return err
+	}
+
+	for _, net := range clh.vmconfig.Net {
+		if err := clh.isolation.setTapOwner(net.Tap); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (clh *cloudHypervisor) teardownVMMIsolation() {
+	if clh.isolation == nil {
+		return
+	}
+
+	if err := clh.isolation.teardown(); err != nil {
+		clh.Logger().WithError(err).WithField("root", clh.isolation.root).Warn("failed to tear down the VMM isolation")
+		return
+	}
+	clh.isolation = nil
+}
+
 // getSandboxConsole builds the path of the console where we can read
 // logs coming from the sandbox.
 func (clh *cloudHypervisor) getSandboxConsole(id string) (string, error) {
@@ -415,10 +553,22 @@ func (clh *cloudHypervisor) hotplugBlockDevice(drive *config.BlockDrive) error {
 	//Explicitly set PCIAddr to NULL, so that VirtPath can be used
 	drive.PCIAddr = ""
 
+	if clh.isolation != nil && !drive.Pmem {
+		if err := clh.isolation.addFile(drive.File); err != nil {
+			return err
+		}
+		defer func() {
+			if err != nil {
+				clh.isolation.removeFile(drive.File)
+			}
+		}()
+	}
+
 	if drive.Pmem {
 		err = fmt.Errorf("pmem device hotplug not supported")
 	} else {
 		blkDevice := chclient.DiskConfig{
+			Id:        drive.ID,
 			Path:      drive.File,
 			Readonly:  drive.ReadOnly,
 			VhostUser: false,
@@ -442,7 +592,13 @@ func (clh *cloudHypervisor) hotPlugVFIODevice(device config.VFIODev) error {
 		return openAPIClientError(err)
 	}
 
-	_, err = cl.VmAddDevicePut(ctx, chclient.VmAddDevice{Path: device.SysfsDev})
+	if clh.isolation != nil {
+		if err := clh.isolation.addVFIOGroup(device.SysfsDev); err != nil {
+			return err
+		}
+	}
+
+	_, err = cl.VmAddDevicePut(ctx, chclient.VmAddDevice{Path: device.SysfsDev, Id: device.ID})
 	if err != nil {
 		err = fmt.Errorf("Failed to hotplug device %+v %s", device, openAPIClientError(err))
 	}
@@ -467,8 +623,37 @@ func (clh *cloudHypervisor) hotplugAddDevice(devInfo interface{}, devType device
 }
 
 func (clh *cloudHypervisor) hotplugRemoveDevice(devInfo interface{}, devType deviceType) (interface{}, error) {
-	clh.Logger().WithField("function", "hotplugRemoveDevice").Warn("hotplug remove device not supported")
-	return nil, nil
+	span, _ := clh.trace("hotplugRemoveDevice")
+	defer span.Finish()
+
+	var deviceID string
+
+	switch devType {
+	case blockDev:
+		deviceID = devInfo.(*config.BlockDrive).ID
+	case vfioDev:
+		deviceID = devInfo.(*config.VFIODev).ID
+	default:
+		clh.Logger().WithFields(log.Fields{"devInfo": devInfo,
+			"deviceType": devType}).Error("hotplugRemoveDevice: unsupported device")
+		return nil, fmt.Errorf("Could not hot remove device: unsupported device: %v, type: %v",
+			devInfo, devType)
+	}
+
+	cl := clh.client()
+	ctx, cancel := context.WithTimeout(context.Background(), clhHotPlugAPITimeout*time.Second)
+	defer cancel()
+
+	_, err := cl.VmRemoveDevicePut(ctx, chclient.VmRemoveDevice{Id: deviceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to hotplug remove (unplug) device %+v: %s", devInfo, openAPIClientError(err))
+	}
+
+	if clh.isolation != nil && devType == blockDev {
+		err = clh.isolation.removeFile(devInfo.(*config.BlockDrive).File)
+	}
+
+	return nil, err
 }
 
 func (clh *cloudHypervisor) hypervisorConfig() HypervisorConfig {
@@ -477,8 +662,6 @@ func (clh *cloudHypervisor) hypervisorConfig() HypervisorConfig {
 
 func (clh *cloudHypervisor) resizeMemory(reqMemMB uint32, memoryBlockSizeMB uint32, probe bool) (uint32, memoryDevice, error) {
 
-	// TODO: Add support for virtio-mem
-
 	if probe {
 		return 0, memoryDevice{}, errors.New("probe memory is not supported for cloud-hypervisor")
 	}
@@ -502,6 +685,12 @@ func (clh *cloudHypervisor) resizeMemory(reqMemMB uint32, memoryBlockSizeMB uint
 		return uint32(currentMem.ToMiB()), memoryDevice{}, nil
 	}
 
+	// virtio-mem plugs and unplugs the memory in the guest, the VM is
+	// resized to the requested size as it is.
+	if clh.config.VirtioMem {
+		return clh.resizeVirtioMem(currentMem, newMem)
+	}
+
 	if currentMem > newMem {
 		clh.Logger().Warn("Remove memory is not supported, nothing to do")
 		return uint32(currentMem.ToMiB()), memoryDevice{}, nil
@@ -540,6 +729,21 @@ func (clh *cloudHypervisor) resizeMemory(reqMemMB uint32, memoryBlockSizeMB uint
 	return uint32(newMem.ToMiB()), memoryDevice{sizeMB: int(hotplugSize.ToMiB())}, nil
 }
 
+func (clh *cloudHypervisor) resizeVirtioMem(currentMem, newMem utils.MemUnit) (uint32, memoryDevice, error) {
+	cl := clh.client()
+	ctx, cancelResize := context.WithTimeout(context.Background(), clhAPITimeout*time.Second)
+	defer cancelResize()
+
+	resize := chclient.VmResize{DesiredRam: int64(newMem.ToBytes())}
+	clh.Logger().WithFields(log.Fields{"current-memory": currentMem, "new-memory": newMem}).Debug("updating VM memory with virtio-mem")
+	if _, err := cl.VmResizePut(ctx, resize); err != nil {
+		err = fmt.Errorf("Failed to resize memory from %d to %d: %s", currentMem, newMem, openAPIClientError(err))
+		return uint32(currentMem.ToMiB()), memoryDevice{}, err
+	}
+
+	return uint32(newMem.ToMiB()), memoryDevice{}, nil
+}
+
 func (clh *cloudHypervisor) resizeVCPUs(reqVCPUs uint32) (currentVCPUs uint32, newVCPUs uint32, err error) {
 	cl := clh.client()
 
@@ -600,6 +804,91 @@ func (clh *cloudHypervisor) resumeSandbox() error {
 	return nil
 }
 
+// snapshotSandbox pauses the VM, which pauseSandbox does not, to save its
+// state, and resumes it.
+func (clh *cloudHypervisor) snapshotSandbox(dir string) (err error) {
+	span, _ := clh.trace("snapshotSandbox")
+	defer span.Finish()
+
+	ctx, cancel := context.WithTimeout(context.Background(), clhSnapshotAPITimeout*time.Second)
+	defer cancel()
+
+	cl := clh.client()
+	if _, err = cl.PauseVM(ctx); err != nil {
+		return openAPIClientError(err)
+	}
+	defer func() {
+		if _, resumeErr := cl.ResumeVM(ctx); resumeErr != nil && err == nil {
+			err = openAPIClientError(resumeErr)
+		}
+	}()
+
+	if _, err = cl.VmSnapshotPut(ctx, chclient.VmSnapshotConfig{DestinationUrl: "file://" + dir}); err != nil {
+		return openAPIClientError(err)
+	}
+
+	return nil
+}
+
+// restoreVM restores the VM from the snapshot it is configured with, its
+// devices being the saved ones, and resumes it.
+func (clh *cloudHypervisor) restoreVM() error {
+	ctx, cancel := context.WithTimeout(context.Background(), clhSnapshotAPITimeout*time.Second)
+	defer cancel()
+
+	cl := clh.client()
+	if _, err := cl.VmRestorePut(ctx, chclient.RestoreConfig{SourceUrl: "file://" + clh.config.SnapshotPath}); err != nil {
+		return openAPIClientError(err)
+	}
+
+	info, err := clh.vmInfo()
+	if err != nil {
+		return err
+	}
+
+	if info.State != clhStatePaused {
+		return fmt.Errorf("VM state is not 'Paused' after 'VmRestorePut'")
+	}
+
+	if _, err := cl.ResumeVM(ctx); err != nil {
+		return openAPIClientError(err)
+	}
+
+	return nil
+}
+
+func (clh *cloudHypervisor) migrateSandbox(uri string) error {
+	return errors.New("sandbox migration is not supported for cloud-hypervisor")
+}
+
+func (clh *cloudHypervisor) finishIncomingMigration() error {
+	return errors.New("sandbox migration is not supported for cloud-hypervisor")
+}
+
+func (clh *cloudHypervisor) waitGuestSuspended(timeout time.Duration) error {
+	return errors.New("guest suspend is not supported for cloud-hypervisor")
+}
+
+func (clh *cloudHypervisor) wakeupSandbox() error {
+	return errors.New("guest suspend is not supported for cloud-hypervisor")
+}
+
+func (clh *cloudHypervisor) upgradeVirtiofsd() error {
+	return errors.New("virtiofsd upgrade is not supported for cloud-hypervisor")
+}
+
+func (clh *cloudHypervisor) liveUpdate(vmSocket interface{}, endpoints []Endpoint, drives []*config.BlockDrive) error {
+	return errors.New("sandbox live update is not supported for cloud-hypervisor")
+}
+
+func (clh *cloudHypervisor) powerdownSandbox() error {
+	return errors.New("ACPI power down is not supported for cloud-hypervisor")
+}
+
+func (clh *cloudHypervisor) getLaunchMeasurement() (LaunchMeasurement, error) {
+	return LaunchMeasurement{}, errors.New("memory encryption is not supported for cloud-hypervisor")
+}
+
 // stopSandbox will stop the Sandbox's VM.
 func (clh *cloudHypervisor) stopSandbox() (err error) {
 	span, _ := clh.trace("stopSandbox")
@@ -621,6 +910,10 @@ func (clh *cloudHypervisor) save() (s persistapi.HypervisorState) {
 	s.Type = string(ClhHypervisor)
 	s.VirtiofsdPid = clh.state.VirtiofsdPID
 	s.APISocket = clh.state.apiSocket
+	if clh.isolation != nil {
+		s.VMMIsolationID = clh.isolation.hostID
+		s.VMMIsolationOwners = clh.isolation.owners
+	}
 	return
 }
 
@@ -628,6 +921,7 @@ func (clh *cloudHypervisor) load(s persistapi.HypervisorState) {
 	clh.state.PID = s.Pid
 	clh.state.VirtiofsdPID = s.VirtiofsdPid
 	clh.state.apiSocket = s.APISocket
+	clh.isolation = loadVMMIsolation(s.VMMIsolationID, s.VMMIsolationOwners)
 }
 
 func (clh *cloudHypervisor) check() error {
@@ -689,6 +983,12 @@ func (clh *cloudHypervisor) capabilities() types.Capabilities {
 	var caps types.Capabilities
 	caps.SetFsSharingSupport()
 	caps.SetBlockDeviceHotplugSupport()
+	caps.SetBlockDeviceHotUnplugSupport()
+	caps.SetVFIOHotplugSupport()
+	caps.SetSandboxSnapshotSupport()
+	if clh.config.VirtioMem {
+		caps.SetMemoryHotUnplugSupport()
+	}
 	return caps
 }
 
@@ -913,10 +1213,17 @@ func (clh *cloudHypervisor) LaunchClh() (string, int, error) {
 		args = append(args, "-vv")
 	}
 
+	args = append(args, clhSeccompArgs(clh.config.VMMSeccomp)...)
+
 	clh.Logger().WithField("path", clhPath).Info()
 	clh.Logger().WithField("args", strings.Join(args, " ")).Info()
 
-	cmdHypervisor := exec.Command(clhPath, args...)
+	var cmdHypervisor *exec.Cmd
+	if clh.isolation != nil {
+		cmdHypervisor = clh.isolation.command(clhPath, args...)
+	} else {
+		cmdHypervisor = exec.Command(clhPath, args...)
+	}
 	var hypervisorOutput io.ReadCloser
 	if clh.config.Debug {
 		cmdHypervisor.Env = os.Environ()
@@ -1172,23 +1479,17 @@ func (clh *cloudHypervisor) addVolume(volume types.Volume) error {
 		return err
 	}
 
-	if clh.config.VirtioFSCache == virtioFsCacheAlways {
-		clh.vmconfig.Fs = []chclient.FsConfig{
-			{
-				Tag:       volume.MountTag,
-				CacheSize: int64(clh.config.VirtioFSCacheSize << 20),
-				Socket:    vfsdSockPath,
-			},
-		}
-	} else {
-		clh.vmconfig.Fs = []chclient.FsConfig{
-			{
-				Tag:    volume.MountTag,
-				Socket: vfsdSockPath,
-			},
-		}
-
+	// As with QEMU, the DAX window is only mapped when it has a size,
+	// cloud-hypervisor enables it by default otherwise.
+	fs := chclient.FsConfig{
+		Tag:    volume.MountTag,
+		Socket: vfsdSockPath,
+		Dax:    clh.config.VirtioFSCacheSize > 0,
 	}
+	if fs.Dax {
+		fs.CacheSize = int64(clh.config.VirtioFSCacheSize << 20)
+	}
+	clh.vmconfig.Fs = []chclient.FsConfig{fs}
 
 	clh.Logger().Debug("Adding share volume to hypervisor: ", volume.MountTag)
 	return nil
@@ -1239,6 +1540,8 @@ func (clh *cloudHypervisor) cleanupVM(force bool) error {
 		}
 	}
 
+	clh.teardownVMMIsolation()
+
 	if clh.config.VMid != "" {
 		dir = filepath.Join(clh.store.RunStoragePath(), clh.config.VMid)
 		if err := os.RemoveAll(dir); err != nil {
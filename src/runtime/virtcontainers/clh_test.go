@@ -17,6 +17,7 @@ import (
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
 	chclient "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/cloud-hypervisor/client"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -63,7 +64,8 @@ func newClhConfig() (HypervisorConfig, error) {
 }
 
 type clhClientMock struct {
-	vmInfo chclient.VmInfo
+	vmInfo         chclient.VmInfo
+	removedDevices []string
 }
 
 func (c *clhClientMock) VmmPingGet(ctx context.Context) (chclient.VmmPingResponse, *http.Response, error) {
@@ -104,6 +106,33 @@ func (c *clhClientMock) VmAddDiskPut(ctx context.Context, diskConfig chclient.Di
 	return nil, nil
 }
 
+//nolint:golint
+func (c *clhClientMock) VmRemoveDevicePut(ctx context.Context, vmRemoveDevice chclient.VmRemoveDevice) (*http.Response, error) {
+	c.removedDevices = append(c.removedDevices, vmRemoveDevice.Id)
+	return nil, nil
+}
+
+func (c *clhClientMock) PauseVM(ctx context.Context) (*http.Response, error) {
+	c.vmInfo.State = clhStatePaused
+	return nil, nil
+}
+
+func (c *clhClientMock) ResumeVM(ctx context.Context) (*http.Response, error) {
+	c.vmInfo.State = clhStateRunning
+	return nil, nil
+}
+
+//nolint:golint
+func (c *clhClientMock) VmSnapshotPut(ctx context.Context, vmSnapshotConfig chclient.VmSnapshotConfig) (*http.Response, error) {
+	return nil, nil
+}
+
+//nolint:golint
+func (c *clhClientMock) VmRestorePut(ctx context.Context, restoreConfig chclient.RestoreConfig) (*http.Response, error) {
+	c.vmInfo.State = clhStatePaused
+	return nil, nil
+}
+
 func TestCloudHypervisorAddVSock(t *testing.T) {
 	assert := assert.New(t)
 	clh := cloudHypervisor{}
@@ -246,6 +275,17 @@ func TestClhCreateSandbox(t *testing.T) {
 	assert.Exactly(clhConfig, clh.config)
 }
 
+func TestClhTDXKernelParams(t *testing.T) {
+	assert := assert.New(t)
+
+	params := clhTDXKernelParams()
+	assert.Len(params, len(clhKernelParams))
+	assert.Contains(params, Param{"root", "/dev/vda1"})
+
+	// The defaults are left alone
+	assert.Contains(clhKernelParams, Param{"root", "/dev/pmem0p1"})
+}
+
 func TestClooudHypervisorStartSandbox(t *testing.T) {
 	assert := assert.New(t)
 	clhConfig, err := newClhConfig()
@@ -384,3 +424,106 @@ func TestCloudHypervisorHotplugBlockDevice(t *testing.T) {
 	err = clh.hotplugBlockDevice(&config.BlockDrive{Pmem: false})
 	assert.Error(err, "Hotplug block device not using 'virtio-blk' expected error")
 }
+
+func TestCloudHypervisorHotplugRemoveDevice(t *testing.T) {
+	assert := assert.New(t)
+
+	clhConfig, err := newClhConfig()
+	assert.NoError(err)
+
+	mockClient := &clhClientMock{}
+	clh := &cloudHypervisor{}
+	clh.config = clhConfig
+	clh.APIClient = mockClient
+
+	_, err = clh.hotplugRemoveDevice(&config.BlockDrive{ID: "drive-1"}, blockDev)
+	assert.NoError(err, "Hotplug remove block device expected no error")
+
+	_, err = clh.hotplugRemoveDevice(&config.VFIODev{ID: "vfio-1"}, vfioDev)
+	assert.NoError(err, "Hotplug remove vfio device expected no error")
+
+	_, err = clh.hotplugRemoveDevice(nil, netDev)
+	assert.Error(err, "Hotplug remove network device expected error")
+
+	assert.Equal([]string{"drive-1", "vfio-1"}, mockClient.removedDevices)
+}
+
+func TestCloudHypervisorResizeVirtioMem(t *testing.T) {
+	assert := assert.New(t)
+
+	clhConfig, err := newClhConfig()
+	assert.NoError(err)
+	clhConfig.VirtioMem = true
+
+	mockClient := &clhClientMock{}
+	mockClient.vmInfo.Config.Memory.Size = int64(utils.MemUnit(clhConfig.MemorySize) * utils.MiB)
+
+	clh := &cloudHypervisor{}
+	clh.config = clhConfig
+	clh.APIClient = mockClient
+
+	// virtio-mem removes memory too, and needs no memory device in the guest
+	newMem, memDev, err := clh.resizeMemory(clhConfig.MemorySize/2, 128, false)
+	assert.NoError(err)
+	assert.Equal(clhConfig.MemorySize/2, newMem)
+	assert.Equal(memoryDevice{}, memDev)
+
+	caps := clh.capabilities()
+	assert.True(caps.IsMemoryHotUnplugSupported())
+}
+
+func TestCloudHypervisorCapabilities(t *testing.T) {
+	assert := assert.New(t)
+	clh := &cloudHypervisor{}
+
+	caps := clh.capabilities()
+	assert.True(caps.IsBlockDeviceHotplugSupported())
+	assert.True(caps.IsBlockDeviceHotUnplugSupported())
+	assert.True(caps.IsVFIOHotplugSupported())
+	assert.False(caps.IsMemoryHotUnplugSupported())
+}
+
+func TestCloudHypervisorAddVolumeDax(t *testing.T) {
+	assert := assert.New(t)
+
+	clhConfig, err := newClhConfig()
+	assert.NoError(err)
+
+	store, err := persist.GetDriver()
+	assert.NoError(err)
+
+	clh := &cloudHypervisor{}
+	clh.id = "testSandbox"
+	clh.store = store
+	clh.config = clhConfig
+
+	// No DAX window without a cache size, as with QEMU
+	clh.config.VirtioFSCacheSize = 0
+	assert.NoError(clh.addVolume(types.Volume{MountTag: "kataShared"}))
+	assert.False(clh.vmconfig.Fs[0].Dax)
+	assert.Zero(clh.vmconfig.Fs[0].CacheSize)
+
+	clh.config.VirtioFSCacheSize = 1024
+	assert.NoError(clh.addVolume(types.Volume{MountTag: "kataShared"}))
+	assert.True(clh.vmconfig.Fs[0].Dax)
+	assert.Equal(int64(1024<<20), clh.vmconfig.Fs[0].CacheSize)
+}
+
+func TestCloudHypervisorSnapshotRestore(t *testing.T) {
+	assert := assert.New(t)
+
+	clhConfig, err := newClhConfig()
+	assert.NoError(err)
+
+	mock := &clhClientMock{vmInfo: chclient.VmInfo{State: clhStateRunning}}
+	clh := &cloudHypervisor{}
+	clh.config = clhConfig
+	clh.APIClient = mock
+
+	assert.NoError(clh.snapshotSandbox("/run/snapshot"))
+	assert.Equal(clhStateRunning, mock.vmInfo.State)
+
+	clh.config.SnapshotPath = "/run/snapshot"
+	assert.NoError(clh.restoreVM())
+	assert.Equal(clhStateRunning, mock.vmInfo.State)
+}
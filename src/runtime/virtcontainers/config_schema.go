@@ -0,0 +1,298 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"encoding"
+	"encoding/json"
+	"math"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+)
+
+// configSchemaDraft is the JSON schema version of the configuration
+// schemas.
+const configSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// ConfigSchema is a JSON schema describing the JSON encoding of a
+// configuration, with the fields types, their constraints and defaults.
+type ConfigSchema struct {
+	Schema               string                   `json:"$schema,omitempty"`
+	Ref                  string                   `json:"$ref,omitempty"`
+	Title                string                   `json:"title,omitempty"`
+	Type                 string                   `json:"type,omitempty"`
+	Properties           map[string]*ConfigSchema `json:"properties,omitempty"`
+	Required             []string                 `json:"required,omitempty"`
+	Items                *ConfigSchema            `json:"items,omitempty"`
+	AdditionalProperties *ConfigSchema            `json:"additionalProperties,omitempty"`
+	Enum                 []interface{}            `json:"enum,omitempty"`
+	Default              interface{}              `json:"default,omitempty"`
+	Minimum              *float64                 `json:"minimum,omitempty"`
+	Maximum              *float64                 `json:"maximum,omitempty"`
+	MinLength            *int                     `json:"minLength,omitempty"`
+	Definitions          map[string]*ConfigSchema `json:"definitions,omitempty"`
+}
+
+// configFieldHint describes what the Go type of a configuration field
+// cannot tell: whether it is required, the values it accepts and the
+// default it gets when unset.
+type configFieldHint struct {
+	required bool
+	enum     []interface{}
+	def      interface{}
+	minimum  *float64
+}
+
+func schemaBound(v float64) *float64 {
+	return &v
+}
+
+// configFieldHints are the hints of the configuration fields, by type
+// name and field name. They must be kept in sync with the validation of
+// the configuration.
+var configFieldHints = map[string]configFieldHint{
+	"SandboxConfig.ID": {required: true},
+	"SandboxConfig.HypervisorType": {
+		enum: []interface{}{"", FirecrackerHypervisor, QemuHypervisor, AcrnHypervisor, ClhHypervisor, MockHypervisor},
+		def:  QemuHypervisor,
+	},
+	"SandboxConfig.GuestOS": {
+		enum: []interface{}{"", GuestOSLinux, GuestOSOther},
+		def:  GuestOSLinux,
+	},
+
+	"HypervisorConfig.KernelPath":     {required: true},
+	"HypervisorConfig.NumVCPUs":       {def: defaultVCPUs},
+	"HypervisorConfig.MemorySize":     {def: defaultMemSzMiB},
+	"HypervisorConfig.DefaultBridges": {def: defaultBridges},
+	"HypervisorConfig.BlockDeviceDriver": {
+		enum: []interface{}{"", config.VirtioSCSI, config.VirtioBlock, config.VirtioBlockCCW, config.VirtioMmio, config.Nvdimm},
+		def:  defaultBlockDriver,
+	},
+	"HypervisorConfig.DefaultMaxVCPUs": {def: defaultMaxQemuVCPUs},
+	"HypervisorConfig.Msize9p":         {def: defaultMsize9p},
+	"HypervisorConfig.SharedFS": {
+		enum: []interface{}{"", config.Virtio9P, config.VirtioFS, config.NoSharedFS},
+	},
+
+	"CPUSharesTranslation.Aggregation": {
+		enum: []interface{}{"", CPUSharesMax, CPUSharesSum},
+		def:  CPUSharesMax,
+	},
+	"CPUSharesTranslation.GuestScale": {minimum: schemaBound(0)},
+
+	"RootfsDisk.Fstype": {
+		enum: []interface{}{"", RootfsDiskExt4, RootfsDiskErofs},
+	},
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// configSchemaBuilder builds the schema of a type, with one definition per
+// struct type, referenced by the fields of that type.
+type configSchemaBuilder struct {
+	definitions map[string]*ConfigSchema
+	names       map[reflect.Type]string
+}
+
+// SandboxConfigSchema returns the JSON schema of the JSON encoding of
+// SandboxConfig. The nested configurations, such as HypervisorConfig, are
+// in its definitions.
+func SandboxConfigSchema() *ConfigSchema {
+	b := &configSchemaBuilder{
+		definitions: make(map[string]*ConfigSchema),
+		names:       make(map[reflect.Type]string),
+	}
+
+	t := reflect.TypeOf(SandboxConfig{})
+	b.schema(t)
+
+	// The root is a copy of the SandboxConfig definition rather than a
+	// reference to it, as the siblings of a $ref are ignored.
+	root := *b.definitions[b.names[t]]
+	root.Schema = configSchemaDraft
+	root.Title = t.Name()
+	root.Definitions = b.definitions
+
+	return &root
+}
+
+// definitionName returns the definition name of a struct type, its name,
+// qualified by its package on conflict.
+func (b *configSchemaBuilder) definitionName(t reflect.Type) string {
+	name := t.Name()
+	for other, otherName := range b.names {
+		if otherName == name && other != t {
+			return path.Base(t.PkgPath()) + "." + name
+		}
+	}
+	return name
+}
+
+func (b *configSchemaBuilder) schema(t reflect.Type) *ConfigSchema {
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		// Anything goes
+		return &ConfigSchema{}
+	}
+
+	if t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+		return &ConfigSchema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return b.schema(t.Elem())
+	case reflect.Bool:
+		return &ConfigSchema{Type: "boolean"}
+	case reflect.String:
+		return &ConfigSchema{Type: "string"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return integerSchema(t)
+	case reflect.Float32, reflect.Float64:
+		return &ConfigSchema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Base64 encoded
+			return &ConfigSchema{Type: "string"}
+		}
+		return &ConfigSchema{Type: "array", Items: b.schema(t.Elem())}
+	case reflect.Map:
+		return &ConfigSchema{Type: "object", AdditionalProperties: b.schema(t.Elem())}
+	case reflect.Struct:
+		return b.structSchema(t)
+	}
+
+	// Interfaces
+	return &ConfigSchema{}
+}
+
+func integerSchema(t reflect.Type) *ConfigSchema {
+	s := &ConfigSchema{Type: "integer"}
+
+	switch t.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		bits := uint(t.Bits())
+		s.Minimum = schemaBound(-math.Pow(2, float64(bits-1)))
+		s.Maximum = schemaBound(math.Pow(2, float64(bits-1)) - 1)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		s.Minimum = schemaBound(0)
+		s.Maximum = schemaBound(math.Pow(2, float64(t.Bits())) - 1)
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		s.Minimum = schemaBound(0)
+	}
+
+	return s
+}
+
+// structSchema returns a reference to the definition of a struct type,
+// added on first use.
+func (b *configSchemaBuilder) structSchema(t reflect.Type) *ConfigSchema {
+	if t.Name() == "" {
+		return b.objectSchema(t)
+	}
+
+	name, ok := b.names[t]
+	if !ok {
+		name = b.definitionName(t)
+		b.names[t] = name
+
+		// Added before its fields for the recursive types to
+		// reference it.
+		def := &ConfigSchema{}
+		b.definitions[name] = def
+		*def = *b.objectSchema(t)
+	}
+
+	return &ConfigSchema{Ref: "#/definitions/" + name}
+}
+
+func (b *configSchemaBuilder) objectSchema(t reflect.Type) *ConfigSchema {
+	s := &ConfigSchema{
+		Type:       "object",
+		Properties: make(map[string]*ConfigSchema),
+	}
+	b.addFields(s, t)
+
+	return s
+}
+
+// addFields adds the properties of the fields of a struct type, following
+// the encoding/json rules.
+func (b *configSchemaBuilder) addFields(s *ConfigSchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("json"); ok {
+			tagName := strings.Split(tag, ",")[0]
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		// The fields of the untagged embedded structs are promoted.
+		if f.Anonymous && name == f.Name && ft.Kind() == reflect.Struct {
+			b.addFields(s, ft)
+			continue
+		}
+
+		if f.PkgPath != "" {
+			// Unexported
+			continue
+		}
+
+		switch ft.Kind() {
+		case reflect.Func, reflect.Chan, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+			continue
+		}
+
+		fs := b.schema(f.Type)
+
+		if hint, ok := configFieldHints[t.Name()+"."+f.Name]; ok {
+			fs = hint.apply(fs)
+			if hint.required {
+				s.Required = append(s.Required, name)
+			}
+		}
+
+		s.Properties[name] = fs
+	}
+}
+
+func (h configFieldHint) apply(s *ConfigSchema) *ConfigSchema {
+	hinted := *s
+
+	if h.required && hinted.Type == "string" {
+		minLength := 1
+		hinted.MinLength = &minLength
+	}
+	if h.enum != nil {
+		hinted.Enum = h.enum
+	}
+	if h.def != nil {
+		hinted.Default = h.def
+	}
+	if h.minimum != nil {
+		hinted.Minimum = h.minimum
+	}
+
+	return &hinted
+}
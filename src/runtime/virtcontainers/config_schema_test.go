@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxConfigSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	schema := SandboxConfigSchema()
+	assert.Equal(configSchemaDraft, schema.Schema)
+	assert.Equal("SandboxConfig", schema.Title)
+	assert.Equal("object", schema.Type)
+	assert.Equal([]string{"ID"}, schema.Required)
+
+	assert.Equal("#/definitions/HypervisorConfig", schema.Properties["HypervisorConfig"].Ref)
+	assert.Equal("qemu", string(schema.Properties["HypervisorType"].Default.(HypervisorType)))
+
+	hypervisorConfig := schema.Definitions["HypervisorConfig"]
+	assert.NotNil(hypervisorConfig)
+	assert.Contains(hypervisorConfig.Required, "KernelPath")
+	assert.Equal(uint32(defaultVCPUs), uint32(hypervisorConfig.Properties["NumVCPUs"].Default.(int)))
+	assert.Equal(float64(0), *hypervisorConfig.Properties["NumVCPUs"].Minimum)
+	assert.Equal(float64(1<<32-1), *hypervisorConfig.Properties["NumVCPUs"].Maximum)
+	assert.Equal("array", hypervisorConfig.Properties["KernelParams"].Type)
+
+	// Every reference has its definition.
+	data, err := json.Marshal(schema)
+	assert.NoError(err)
+
+	var refs func(s *ConfigSchema)
+	refs = func(s *ConfigSchema) {
+		if s == nil {
+			return
+		}
+		if s.Ref != "" {
+			assert.Contains(schema.Definitions, s.Ref[len("#/definitions/"):])
+		}
+		for _, p := range s.Properties {
+			refs(p)
+		}
+		refs(s.Items)
+		refs(s.AdditionalProperties)
+	}
+	for _, d := range schema.Definitions {
+		refs(d)
+	}
+
+	// Round trips through JSON
+	var decoded ConfigSchema
+	assert.NoError(json.Unmarshal(data, &decoded))
+	assert.Equal(len(schema.Definitions), len(decoded.Definitions))
+}
+
+func TestConfigSchemaFields(t *testing.T) {
+	assert := assert.New(t)
+
+	type Embedded struct {
+		Promoted string
+	}
+
+	type Recursive struct {
+		Embedded
+		Tagged   int8 `json:"tagged,omitempty"`
+		Skipped  bool `json:"-"`
+		Data     []byte
+		Children []*Recursive
+		Callback func()
+		private  string
+	}
+
+	b := &configSchemaBuilder{
+		definitions: make(map[string]*ConfigSchema),
+		names:       make(map[reflect.Type]string),
+	}
+
+	s := b.schema(reflect.TypeOf(&Recursive{}))
+	assert.Equal("#/definitions/Recursive", s.Ref)
+
+	def := b.definitions["Recursive"]
+	assert.Len(def.Properties, 4)
+	assert.Equal("string", def.Properties["Promoted"].Type)
+	assert.Equal(float64(-128), *def.Properties["tagged"].Minimum)
+	assert.Equal(float64(127), *def.Properties["tagged"].Maximum)
+	assert.Equal("string", def.Properties["Data"].Type)
+	assert.Equal("#/definitions/Recursive", def.Properties["Children"].Items.Ref)
+}
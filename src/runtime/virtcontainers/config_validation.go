@@ -0,0 +1,169 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"errors"
+)
+
+// ConfigFieldError is a sandbox configuration validation error, tied to
+// the field it is about so that it can be reported next to it.
+type ConfigFieldError struct {
+	// Field is the path of the invalid field from the SandboxConfig, made
+	// of the dot separated Go field names, with the slice indexes in
+	// brackets, e.g. "HypervisorConfig.KernelPath" or
+	// "Experimental[0].Name".
+	Field string `json:"field"`
+
+	// Reason tells why the field is invalid.
+	Reason string `json:"reason"`
+}
+
+func newConfigFieldError(field, reason string) *ConfigFieldError {
+	return &ConfigFieldError{
+		Field:  field,
+		Reason: reason,
+	}
+}
+
+// Error returns the reason only, the field being available to callers
+// unwrapping the error.
+func (e *ConfigFieldError) Error() string {
+	return e.Reason
+}
+
+// configFieldError ties err to the field path, under which the fields of
+// an already tied error are nested.
+func configFieldError(path string, err error) *ConfigFieldError {
+	var fieldErr *ConfigFieldError
+	if !errors.As(err, &fieldErr) {
+		return newConfigFieldError(path, err.Error())
+	}
+
+	if path == "" {
+		return fieldErr
+	}
+
+	if fieldErr.Field == "" {
+		return newConfigFieldError(path, fieldErr.Reason)
+	}
+
+	return newConfigFieldError(path+"."+fieldErr.Field, fieldErr.Reason)
+}
+
+// ValidateSandboxConfig checks a sandbox configuration without creating
+// the sandbox, and returns an error for each invalid field found, none if
+// the configuration is valid. Only the checks depending on the
+// configuration alone are run, the ones depending on the host are left to
+// the sandbox creation.
+func ValidateSandboxConfig(sandboxConfig SandboxConfig) []*ConfigFieldError {
+	var errs []*ConfigFieldError
+
+	// The checks fill in some defaults, on the copy only.
+	conf := sandboxConfig
+
+	if err := conf.validate(); err != nil {
+		errs = append(errs, configFieldError("", err))
+	}
+
+	if err := conf.HypervisorConfig.valid(); err != nil {
+		errs = append(errs, configFieldError("HypervisorConfig", err))
+	}
+
+	if err := checkResourceCeilings(conf.ResourceCeilings, &conf.HypervisorConfig); err != nil {
+		errs = append(errs, configFieldError("ResourceCeilings", err))
+	}
+
+	if err := conf.CPUShares.validate(); err != nil {
+		errs = append(errs, configFieldError("CPUShares", err))
+	}
+
+	if err := conf.RootfsDisk.validate(); err != nil {
+		errs = append(errs, configFieldError("RootfsDisk", err))
+	}
+
+	if err := conf.TimeSync.validate(); err != nil {
+		errs = append(errs, configFieldError("TimeSync", err))
+	}
+
+	if err := conf.GuestProvisioning.validate(); err != nil {
+		errs = append(errs, configFieldError("GuestProvisioning", err))
+	}
+
+	if err := conf.Kdump.validate(); err != nil {
+		errs = append(errs, configFieldError("Kdump", err))
+	}
+
+	if err := conf.GuestProfiling.validate(); err != nil {
+		errs = append(errs, configFieldError("GuestProfiling", err))
+	}
+
+	if err := conf.GuestLivepatch.validate(); err != nil {
+		errs = append(errs, configFieldError("GuestLivepatch", err))
+	}
+
+	if err := conf.GuestMemoryReclaim.validate(); err != nil {
+		errs = append(errs, configFieldError("GuestMemoryReclaim", err))
+	}
+
+	if err := conf.MetadataProxy.validate(); err != nil {
+		errs = append(errs, configFieldError("MetadataProxy", err))
+	}
+
+	if err := conf.NetworkConfig.TCFilterCompat.validate(); err != nil {
+		errs = append(errs, configFieldError("NetworkConfig.TCFilterCompat", err))
+	}
+
+	if err := conf.NetworkConfig.MACAllocation.validate(); err != nil {
+		errs = append(errs, configFieldError("NetworkConfig.MACAllocation", err))
+	}
+
+	if err := conf.NetworkConfig.DHCPInterfaces.validate(); err != nil {
+		errs = append(errs, configFieldError("NetworkConfig.DHCPInterfaces", err))
+	}
+
+	if err := conf.NetworkConfig.InterfaceEndpoints.validate(); err != nil {
+		errs = append(errs, configFieldError("NetworkConfig.InterfaceEndpoints", err))
+	}
+
+	if err := checkNetworkProvider(conf.NetworkConfig.Provider); err != nil {
+		errs = append(errs, configFieldError("NetworkConfig.Provider", err))
+	}
+
+	if err := conf.TrafficCapture.validate(); err != nil {
+		errs = append(errs, configFieldError("TrafficCapture", err))
+	}
+
+	if err := checkCloneable(&conf, nil); err != nil {
+		errs = append(errs, configFieldError("Cloneable", err))
+	}
+
+	if err := conf.PeriodicSnapshots.validate(); err != nil {
+		errs = append(errs, configFieldError("PeriodicSnapshots", err))
+	}
+
+	if err := conf.ShutdownTimeouts.validate(); err != nil {
+		errs = append(errs, configFieldError("ShutdownTimeouts", err))
+	}
+
+	if err := conf.Lightweight.validate(&conf); err != nil {
+		errs = append(errs, configFieldError("Lightweight", err))
+	}
+
+	if err := checkGuestOS(&conf, nil); err != nil {
+		errs = append(errs, configFieldError("GuestOS", err))
+	}
+
+	if err := checkAgentType(&conf, nil); err != nil {
+		errs = append(errs, configFieldError("AgentType", err))
+	}
+
+	if err := checkRegistrars(conf.Registrars); err != nil {
+		errs = append(errs, configFieldError("Registrars", err))
+	}
+
+	return errs
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	exp "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/experimental"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigFieldError(t *testing.T) {
+	assert := assert.New(t)
+
+	err := configFieldError("CPUShares", fmt.Errorf("Invalid"))
+	assert.Equal("CPUShares", err.Field)
+	assert.Equal("Invalid", err.Error())
+
+	err = configFieldError("HypervisorConfig", newConfigFieldError("KernelPath", "Missing kernel path"))
+	assert.Equal("HypervisorConfig.KernelPath", err.Field)
+	assert.Equal("Missing kernel path", err.Error())
+
+	err = configFieldError("", newConfigFieldError("ID", "Missing sandbox ID"))
+	assert.Equal("ID", err.Field)
+
+	// Wrapped errors are unwrapped
+	var fieldErr *ConfigFieldError
+	wrapped := fmt.Errorf("Invalid sandbox configuration: %w", newConfigFieldError("ID", "Missing sandbox ID"))
+	assert.True(errors.As(wrapped, &fieldErr))
+	assert.Equal("ID", configFieldError("", wrapped).Field)
+}
+
+func TestValidateSandboxConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	sandboxConfig := SandboxConfig{
+		ID: "test",
+		HypervisorConfig: HypervisorConfig{
+			KernelPath: "/kernel",
+			ImagePath:  "/image",
+		},
+	}
+	assert.Empty(ValidateSandboxConfig(sandboxConfig))
+
+	// The defaults are not filled in the caller configuration.
+	assert.Zero(sandboxConfig.HypervisorConfig.NumVCPUs)
+
+	sandboxConfig = SandboxConfig{
+		HypervisorConfig: HypervisorConfig{
+			NumVCPUs: 4,
+		},
+		Experimental:     []exp.Feature{{Name: "no-such-feature"}},
+		ResourceCeilings: ResourceCeilings{MaxVCPUs: 2},
+		CPUShares:        CPUSharesTranslation{Aggregation: "avg"},
+		RootfsDisk:       RootfsDisk{Converter: "relative/path"},
+		GuestOS:          "plan9",
+	}
+
+	var fields []string
+	for _, err := range ValidateSandboxConfig(sandboxConfig) {
+		assert.NotEmpty(err.Reason)
+		fields = append(fields, err.Field)
+	}
+	assert.Equal([]string{
+		"ID",
+		"HypervisorConfig.KernelPath",
+		"ResourceCeilings.MaxVCPUs",
+		"CPUShares.Aggregation",
+		"RootfsDisk.Converter",
+		"GuestOS",
+	}, fields)
+
+	sandboxConfig.ID = "test"
+	errs := ValidateSandboxConfig(sandboxConfig)
+	assert.NotEmpty(errs)
+	assert.Equal("Experimental[0].Name", errs[0].Field)
+}
@@ -0,0 +1,426 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	grpcCodes "google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+// consoleAgentCheckInterval is how often the hypervisor is checked while
+// waiting for the payload to exit.
+var consoleAgentCheckInterval = time.Second
+
+// consoleAgent stands in for the kata agent in the sandboxes whose guest
+// does not run it. The guest is the payload of the sandbox container, the
+// only container such a sandbox can have: it starts and stops with the VM,
+// and its standard input and output are the guest serial console.
+type consoleAgent struct {
+	sync.Mutex
+
+	sandbox  *Sandbox
+	console  net.Conn
+	exited   chan struct{}
+	exitCode int32
+}
+
+func newConsoleAgent() agent {
+	return &consoleAgent{
+		exited: make(chan struct{}),
+	}
+}
+
+func (a *consoleAgent) Logger() *logrus.Entry {
+	return virtLog.WithField("subsystem", "console_agent")
+}
+
+func errConsoleAgentUnsupported(op string) error {
+	return fmt.Errorf("%s is not supported by guests not running the kata agent", op)
+}
+
+func (a *consoleAgent) init(ctx context.Context, sandbox *Sandbox, config KataAgentConfig) (bool, error) {
+	a.sandbox = sandbox
+	return false, nil
+}
+
+// markExited records the payload exit, the first exit code recorded wins.
+func (a *consoleAgent) markExited(code int32) {
+	a.Lock()
+	defer a.Unlock()
+
+	select {
+	case <-a.exited:
+	default:
+		a.exitCode = code
+		close(a.exited)
+	}
+}
+
+func (a *consoleAgent) closeConsole() {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.console != nil {
+		a.console.Close()
+		a.console = nil
+	}
+}
+
+func (a *consoleAgent) getConsole() (net.Conn, error) {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.console == nil {
+		return nil, fmt.Errorf("the serial console of the guest is not connected")
+	}
+	return a.console, nil
+}
+
+func (a *consoleAgent) startProxy(sandbox *Sandbox) error {
+	return nil
+}
+
+func (a *consoleAgent) longLiveConn() bool {
+	return false
+}
+
+func (a *consoleAgent) createSandbox(sandbox *Sandbox) error {
+	return nil
+}
+
+func (a *consoleAgent) capabilities() types.Capabilities {
+	return types.Capabilities{}
+}
+
+func (a *consoleAgent) disconnect() error {
+	return nil
+}
+
+func (a *consoleAgent) check() error {
+	return nil
+}
+
+func (a *consoleAgent) exec(sandbox *Sandbox, c Container, cmd types.Cmd) (*Process, error) {
+	return nil, errConsoleAgentUnsupported("exec")
+}
+
+// startSandbox connects to the guest serial console once the VM runs.
+func (a *consoleAgent) startSandbox(sandbox *Sandbox) error {
+	path, err := sandbox.hypervisor.getSandboxConsole(sandbox.id)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return fmt.Errorf("the hypervisor provides no serial console for the guest")
+	}
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		return err
+	}
+
+	a.Lock()
+	a.console = conn
+	a.Unlock()
+
+	return nil
+}
+
+func (a *consoleAgent) stopSandbox(sandbox *Sandbox) error {
+	a.markExited(0)
+	a.closeConsole()
+	return nil
+}
+
+func (a *consoleAgent) createContainer(sandbox *Sandbox, c *Container) (*Process, error) {
+	if c.id != sandbox.id {
+		return nil, fmt.Errorf("Guest OS %q sandboxes cannot have other containers than the sandbox one", sandbox.config.GuestOS)
+	}
+
+	process := &Process{
+		Token:     c.id,
+		StartTime: time.Now().UTC(),
+	}
+	if pids := sandbox.hypervisor.getPids(); len(pids) > 0 {
+		process.Pid = pids[0]
+	}
+
+	return process, nil
+}
+
+func (a *consoleAgent) startContainer(sandbox *Sandbox, c *Container) error {
+	return nil
+}
+
+func (a *consoleAgent) stopContainer(sandbox *Sandbox, c Container) error {
+	return nil
+}
+
+// signalProcess stops the payload on any signal but 0, which only checks
+// it is still running. The VM itself is stopped along with the sandbox.
+func (a *consoleAgent) signalProcess(c *Container, processID string, signal syscall.Signal, all bool) error {
+	if signal == syscall.Signal(0) {
+		select {
+		case <-a.exited:
+			return fmt.Errorf("the guest of sandbox %s has exited", c.sandboxID)
+		default:
+			return nil
+		}
+	}
+
+	a.markExited(128 + int32(signal))
+	return nil
+}
+
+func (a *consoleAgent) winsizeProcess(c *Container, processID string, height, width uint32) error {
+	return nil
+}
+
+func (a *consoleAgent) writeProcessStdin(c *Container, processID string, data []byte) (int, error) {
+	console, err := a.getConsole()
+	if err != nil {
+		return 0, err
+	}
+	return console.Write(data)
+}
+
+func (a *consoleAgent) closeProcessStdin(c *Container, processID string) error {
+	return nil
+}
+
+// readProcessStdout reads the guest serial console, whose end means the
+// guest has gone.
+func (a *consoleAgent) readProcessStdout(c *Container, processID string, data []byte) (int, error) {
+	console, err := a.getConsole()
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := console.Read(data)
+	if err == io.EOF {
+		a.markExited(0)
+	}
+	return n, err
+}
+
+// readProcessStderr returns io.EOF, the serial console carries all the
+// guest output.
+func (a *consoleAgent) readProcessStderr(c *Container, processID string, data []byte) (int, error) {
+	return 0, io.EOF
+}
+
+// waitProcess waits for the payload to be stopped, or for the VM to exit.
+func (a *consoleAgent) waitProcess(c *Container, processID string) (int32, error) {
+	if processID != c.id {
+		return 0, errConsoleAgentUnsupported("exec")
+	}
+
+	ticker := time.NewTicker(consoleAgentCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.exited:
+			a.Lock()
+			defer a.Unlock()
+			return a.exitCode, nil
+		case <-ticker.C:
+			if err := c.sandbox.hypervisor.check(); err != nil {
+				a.Logger().WithError(err).Info("guest VM is gone")
+				a.markExited(0)
+			}
+		}
+	}
+}
+
+func (a *consoleAgent) processListContainer(sandbox *Sandbox, c Container, options ProcessListOptions) (ProcessList, error) {
+	return nil, errConsoleAgentUnsupported("ps")
+}
+
+func (a *consoleAgent) updateContainer(sandbox *Sandbox, c Container, resources specs.LinuxResources) error {
+	return errConsoleAgentUnsupported("update")
+}
+
+// onlineCPUMem does nothing, the guest onlines the hotplugged vCPUs and
+// memory itself, if it can.
+func (a *consoleAgent) onlineCPUMem(cpus uint32, cpuOnly bool) error {
+	return nil
+}
+
+func (a *consoleAgent) memHotplugByProbe(addr uint64, sizeMB uint32, memorySectionSizeMB uint32) error {
+	return nil
+}
+
+// statsContainer returns empty stats, only the VM can be measured, from
+// the host.
+func (a *consoleAgent) statsContainer(sandbox *Sandbox, c Container) (*ContainerStats, error) {
+	return &ContainerStats{}, nil
+}
+
+func (a *consoleAgent) pauseContainer(sandbox *Sandbox, c Container) error {
+	return errConsoleAgentUnsupported("pause")
+}
+
+func (a *consoleAgent) resumeContainer(sandbox *Sandbox, c Container) error {
+	return errConsoleAgentUnsupported("resume")
+}
+
+func (a *consoleAgent) configure(h hypervisor, id, sharePath string, builtin bool, config interface{}) error {
+	return nil
+}
+
+func (a *consoleAgent) configureFromGrpc(h hypervisor, id string, builtin bool, config interface{}) error {
+	return nil
+}
+
+func (a *consoleAgent) reseedRNG(data []byte) error {
+	return errConsoleAgentUnsupported("RNG reseeding")
+}
+
+func (a *consoleAgent) reconnect(sandbox *Sandbox) error {
+	return errConsoleAgentUnsupported("reconnection")
+}
+
+func (a *consoleAgent) updateInterface(inf *vcTypes.Interface) (*vcTypes.Interface, error) {
+	return nil, errConsoleAgentUnsupported("interface update")
+}
+
+func (a *consoleAgent) listInterfaces() ([]*vcTypes.Interface, error) {
+	return nil, nil
+}
+
+func (a *consoleAgent) updateRoutes(routes []*vcTypes.Route) ([]*vcTypes.Route, error) {
+	return nil, errConsoleAgentUnsupported("route update")
+}
+
+func (a *consoleAgent) listRoutes() ([]*vcTypes.Route, error) {
+	return nil, nil
+}
+
+func (a *consoleAgent) getGuestDetails(*grpc.GuestDetailsRequest) (*grpc.GuestDetailsResponse, error) {
+	return nil, nil
+}
+
+func (a *consoleAgent) setGuestDateTime(time.Time) error {
+	return errConsoleAgentUnsupported("guest time setting")
+}
+
+// releaseDevice does nothing, the guest not running the kata agent is not
+// told about the hot unplug beforehand.
+func (a *consoleAgent) releaseDevice(sandbox *Sandbox, device api.Device) error {
+	return nil
+}
+
+func (a *consoleAgent) suspendGuest() error {
+	return errConsoleAgentUnsupported("guest suspend")
+}
+
+// installFile does nothing, as copyFile.
+func (a *consoleAgent) installFile(src, path string) error {
+	return nil
+}
+
+// loadCrashKernel does nothing, nothing in the guest would capture the
+// vmcore.
+func (a *consoleAgent) loadCrashKernel(kernel, initrd string) error {
+	return nil
+}
+
+func (a *consoleAgent) startProfiling(session string, req ProfileRequest) error {
+	return errConsoleAgentUnsupported("guest profiling")
+}
+
+func (a *consoleAgent) loadLivepatch(module, path string) error {
+	return errConsoleAgentUnsupported("livepatch")
+}
+
+func (a *consoleAgent) checkpointContainer(c *Container, imagesDir string, leaveRunning bool) error {
+	return errConsoleAgentUnsupported("checkpoint")
+}
+
+func (a *consoleAgent) restoreContainer(c *Container, imagesDir string) error {
+	return errConsoleAgentUnsupported("restore")
+}
+
+// copyFile does nothing, nothing in the guest would pick the file up.
+func (a *consoleAgent) copyFile(src, dst string) error {
+	a.Logger().WithField("source", src).Debug("not copying file to a guest not running the kata agent")
+	return nil
+}
+
+func (a *consoleAgent) copyFileToRootfs(src, rootfs, dst string) error {
+	return errConsoleAgentUnsupported("guest file copy")
+}
+
+func (a *consoleAgent) copyFileFromRootfs(rootfs, src, dst string) error {
+	return errConsoleAgentUnsupported("guest file copy")
+}
+
+func (a *consoleAgent) reuseAgent(agent agent) error {
+	return errConsoleAgentUnsupported("VM factory")
+}
+
+func (a *consoleAgent) getAgentURL() (string, error) {
+	return "", nil
+}
+
+func (a *consoleAgent) setProxy(sandbox *Sandbox, proxy proxy, pid int, url string) error {
+	return nil
+}
+
+func (a *consoleAgent) setProxyFromGrpc(proxy proxy, pid int, url string) {
+}
+
+func (a *consoleAgent) markDead() {
+}
+
+func (a *consoleAgent) cleanup(s *Sandbox) {
+	a.closeConsole()
+}
+
+func (a *consoleAgent) save() (s persistapi.AgentState) {
+	return
+}
+
+func (a *consoleAgent) load(s persistapi.AgentState) {}
+
+// getOOMEvent returns a not found error, so that the OOM events are not
+// polled for.
+func (a *consoleAgent) getOOMEvent() (string, error) {
+	return "", grpcStatus.Error(grpcCodes.NotFound, errConsoleAgentUnsupported("OOM events").Error())
+}
+
+func (a *consoleAgent) getAgentMetrics(req *grpc.GetMetricsRequest) (*grpc.Metrics, error) {
+	return nil, errConsoleAgentUnsupported("agent metrics")
+}
+
+func (a *consoleAgent) getTDReport(reportData []byte) ([]byte, error) {
+	return nil, errConsoleAgentUnsupported("TD report")
+}
+
+// beginVMSwitch fails, the payload exiting with the hypervisor process.
+func (a *consoleAgent) beginVMSwitch() error {
+	return errConsoleAgentUnsupported("live update")
+}
+
+func (a *consoleAgent) endVMSwitch(sandbox *Sandbox, vmSocket interface{}) error {
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+func newTestConsoleAgent() (*consoleAgent, *Sandbox, *Container) {
+	s := &Sandbox{
+		id:         "sandbox",
+		hypervisor: &mockHypervisor{mockPid: 42},
+		config:     &SandboxConfig{GuestOS: GuestOSOther},
+	}
+	c := &Container{
+		id:        "sandbox",
+		sandboxID: "sandbox",
+		sandbox:   s,
+	}
+
+	a := newConsoleAgent().(*consoleAgent)
+	a.init(context.Background(), s, KataAgentConfig{})
+
+	return a, s, c
+}
+
+func TestConsoleAgentCreateContainer(t *testing.T) {
+	assert := assert.New(t)
+
+	a, s, c := newTestConsoleAgent()
+
+	process, err := a.createContainer(s, c)
+	assert.NoError(err)
+	assert.Equal(42, process.Pid)
+	assert.Equal(c.id, process.Token)
+
+	// Only the sandbox container is supported
+	_, err = a.createContainer(s, &Container{id: "other", sandbox: s})
+	assert.Error(err)
+
+	_, err = a.exec(s, *c, types.Cmd{})
+	assert.Error(err)
+}
+
+func TestConsoleAgentWaitProcess(t *testing.T) {
+	assert := assert.New(t)
+
+	a, _, c := newTestConsoleAgent()
+
+	// Signal 0 checks the payload is running
+	assert.NoError(a.signalProcess(c, c.id, syscall.Signal(0), false))
+
+	_, err := a.waitProcess(c, "exec")
+	assert.Error(err)
+
+	go a.signalProcess(c, c.id, syscall.SIGKILL, true)
+	code, err := a.waitProcess(c, c.id)
+	assert.NoError(err)
+	assert.Equal(int32(128+syscall.SIGKILL), code)
+
+	// The first exit wins
+	assert.NoError(a.stopSandbox(nil))
+	code, err = a.waitProcess(c, c.id)
+	assert.NoError(err)
+	assert.Equal(int32(128+syscall.SIGKILL), code)
+
+	assert.Error(a.signalProcess(c, c.id, syscall.Signal(0), false))
+}
+
+func TestConsoleAgentWaitProcessVMGone(t *testing.T) {
+	assert := assert.New(t)
+
+	savedInterval := consoleAgentCheckInterval
+	consoleAgentCheckInterval = 10 * time.Millisecond
+	defer func() {
+		consoleAgentCheckInterval = savedInterval
+	}()
+
+	a, s, c := newTestConsoleAgent()
+	s.hypervisor = &goneHypervisor{}
+
+	code, err := a.waitProcess(c, c.id)
+	assert.NoError(err)
+	assert.Equal(int32(0), code)
+}
+
+// goneHypervisor is a hypervisor whose VM has exited.
+type goneHypervisor struct {
+	mockHypervisor
+}
+
+func (h *goneHypervisor) check() error {
+	return io.ErrUnexpectedEOF
+}
+
+func TestConsoleAgentIO(t *testing.T) {
+	assert := assert.New(t)
+
+	a, _, c := newTestConsoleAgent()
+
+	// Not connected
+	_, err := a.writeProcessStdin(c, c.id, []byte("x"))
+	assert.Error(err)
+	_, err = a.readProcessStdout(c, c.id, make([]byte, 1))
+	assert.Error(err)
+
+	guest, console := net.Pipe()
+	a.console = console
+
+	go guest.Write([]byte("boot"))
+	buf := make([]byte, 4)
+	n, err := a.readProcessStdout(c, c.id, buf)
+	assert.NoError(err)
+	assert.Equal("boot", string(buf[:n]))
+
+	go a.writeProcessStdin(c, c.id, []byte("ok"))
+	n, err = guest.Read(buf)
+	assert.NoError(err)
+	assert.Equal("ok", string(buf[:n]))
+
+	_, err = a.readProcessStderr(c, c.id, buf)
+	assert.Equal(io.EOF, err)
+
+	// The end of the console means the guest has gone
+	guest.Close()
+	_, err = a.readProcessStdout(c, c.id, buf)
+	assert.Equal(io.EOF, err)
+	code, err := a.waitProcess(c, c.id)
+	assert.NoError(err)
+	assert.Equal(int32(0), code)
+
+	a.cleanup(nil)
+	assert.Nil(a.console)
+}
+
+func TestConsoleAgentOOMEvent(t *testing.T) {
+	a, _, _ := newTestConsoleAgent()
+
+	_, err := a.getOOMEvent()
+	assert.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"io"
+	"net"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// consoleHistorySize is how much of the console output is kept at
+	// least for the clients reading it slower than the guest writes it. A
+	// client lagging further behind loses the output it did not read.
+	consoleHistorySize = 64 * 1024
+
+	consoleReadSize = 4096
+)
+
+var (
+	consoleMuxes     = map[string]*consoleMux{}
+	consoleMuxesLock sync.Mutex
+)
+
+// consoleMux shares the console of a sandbox, which accepts a single
+// connection, between several clients. The console output is read once
+// and each client reads it from its own cursor, while the input of all the
+// clients is written to the console. The multiplexers live in the process
+// attached to the console, which is the shim for the sandboxes it serves:
+// the clients of other processes attach through the /debug-console
+// endpoint of the shim.
+type consoleMux struct {
+	sync.Mutex
+	sandboxID string
+	conn      net.Conn
+	readable  *sync.Cond
+
+	// history is the end of the console output, up to end.
+	history []byte
+	end     int64
+	err     error
+	clients int
+
+	// dropped is how much of the console output the clients lost.
+	dropped int64
+
+	writeLock sync.Mutex
+}
+
+// consoleClient is a client of a console multiplexer. It reads the
+// console output written since it attached.
+type consoleClient struct {
+	mux     *consoleMux
+	cursor  int64
+	closed  bool
+	dropped int64
+}
+
+// attachConsole attaches a client to the console of a sandbox, connecting
+// to its console socket when no other client is attached.
+func attachConsole(sandboxID, path string) (io.ReadWriteCloser, error) {
+	consoleMuxesLock.Lock()
+	defer consoleMuxesLock.Unlock()
+
+	m, ok := consoleMuxes[sandboxID]
+	if ok {
+		m.Lock()
+		ok = m.err == nil
+		m.Unlock()
+	}
+
+	// The clients of a console which went away read its output until
+	// they detach, and a new connection is made for the next ones.
+	if !ok {
+		conn, err := net.Dial("unix", path)
+		if err != nil {
+			return nil, err
+		}
+
+		m = &consoleMux{
+			sandboxID: sandboxID,
+			conn:      conn,
+		}
+		m.readable = sync.NewCond(m)
+		consoleMuxes[sandboxID] = m
+
+		go m.run()
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.clients++
+	return &consoleClient{mux: m, cursor: m.end}, nil
+}
+
+// run reads the console output until the console goes away or the last
+// client detaches.
+func (m *consoleMux) run() {
+	buf := make([]byte, consoleReadSize)
+	for {
+		n, err := m.conn.Read(buf)
+
+		m.Lock()
+		m.history = append(m.history, buf[:n]...)
+		if len(m.history) > 2*consoleHistorySize {
+			m.history = append([]byte{}, m.history[len(m.history)-consoleHistorySize:]...)
+		}
+		m.end += int64(n)
+		if err != nil {
+			m.err = err
+		}
+		m.readable.Broadcast()
+		m.Unlock()
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (c *consoleClient) Read(p []byte) (int, error) {
+	m := c.mux
+
+	m.Lock()
+	defer m.Unlock()
+
+	for !c.closed && c.cursor == m.end && m.err == nil {
+		m.readable.Wait()
+	}
+
+	if c.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	if c.cursor == m.end {
+		return 0, m.err
+	}
+
+	start := m.end - int64(len(m.history))
+	if c.cursor < start {
+		lost := start - c.cursor
+		c.dropped += lost
+		m.dropped += lost
+		c.cursor = start
+
+		virtLog.WithFields(logrus.Fields{
+			"sandbox":        m.sandboxID,
+			"dropped":        lost,
+			"client-dropped": c.dropped,
+			"total-dropped":  m.dropped,
+		}).Warn("console client lagged behind, console output dropped")
+	}
+
+	n := copy(p, m.history[c.cursor-start:])
+	c.cursor += int64(n)
+
+	return n, nil
+}
+
+func (c *consoleClient) Write(p []byte) (int, error) {
+	m := c.mux
+
+	m.Lock()
+	closed := c.closed
+	m.Unlock()
+
+	if closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	m.writeLock.Lock()
+	defer m.writeLock.Unlock()
+
+	return m.conn.Write(p)
+}
+
+// Close detaches the client, the console connection being closed once the
+// last client detaches.
+func (c *consoleClient) Close() error {
+	consoleMuxesLock.Lock()
+	defer consoleMuxesLock.Unlock()
+
+	m := c.mux
+
+	m.Lock()
+	if c.closed {
+		m.Unlock()
+		return nil
+	}
+	c.closed = true
+	m.clients--
+	last := m.clients == 0
+	m.readable.Broadcast()
+	m.Unlock()
+
+	if !last {
+		return nil
+	}
+
+	if consoleMuxes[m.sandboxID] == m {
+		delete(consoleMuxes, m.sandboxID)
+	}
+
+	return m.conn.Close()
+}
+
+// AttachConsole attaches a client to the console of the sandbox, shared
+// with the other clients attached to it in this process.
+func (s *Sandbox) AttachConsole() (io.ReadWriteCloser, error) {
+	if err := s.checkNotConfidential("attach to the console"); err != nil {
+		return nil, err
+	}
+
+	path, err := s.GetConsoleSocket()
+	if err != nil {
+		return nil, err
+	}
+
+	return attachConsole(s.id, path)
+}
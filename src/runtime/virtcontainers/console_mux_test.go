@@ -0,0 +1,193 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// testConsole is a console socket accepting a single connection at a time,
+// as the hypervisors do.
+type testConsole struct {
+	listener net.Listener
+	conns    chan net.Conn
+}
+
+func newTestConsole(t *testing.T, dir string) *testConsole {
+	listener, err := net.Listen("unix", filepath.Join(dir, "console.sock"))
+	assert.NoError(t, err)
+
+	c := &testConsole{
+		listener: listener,
+		conns:    make(chan net.Conn),
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			c.conns <- conn
+		}
+	}()
+
+	return c
+}
+
+func (c *testConsole) path() string {
+	return c.listener.Addr().String()
+}
+
+func (c *testConsole) accept(t *testing.T) net.Conn {
+	select {
+	case conn := <-c.conns:
+		return conn
+	case <-time.After(time.Second):
+		t.Fatal("no connection to the console")
+		return nil
+	}
+}
+
+func readConsole(t *testing.T, r io.Reader, size int) string {
+	buf := make([]byte, size)
+	_, err := io.ReadFull(r, buf)
+	assert.NoError(t, err)
+	return string(buf)
+}
+
+func TestConsoleMux(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "console-mux")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpDir)
+
+	console := newTestConsole(t, tmpDir)
+	defer console.listener.Close()
+
+	logs, err := attachConsole("sandbox", console.path())
+	assert.NoError(err)
+	guest := console.accept(t)
+	defer guest.Close()
+
+	debug, err := attachConsole("sandbox", console.path())
+	assert.NoError(err)
+
+	// The clients share the single console connection, and read the
+	// output from their own cursor.
+	_, err = guest.Write([]byte("login: "))
+	assert.NoError(err)
+	assert.Equal("login: ", readConsole(t, debug, 7))
+
+	_, err = guest.Write([]byte("root\n"))
+	assert.NoError(err)
+	assert.Equal("login: root\n", readConsole(t, logs, 12))
+	assert.Equal("root\n", readConsole(t, debug, 5))
+
+	// The input of the clients is written to the console.
+	_, err = debug.Write([]byte("ls\n"))
+	assert.NoError(err)
+	assert.Equal("ls\n", readConsole(t, guest, 3))
+
+	// A client attaching later reads the output written since.
+	late, err := attachConsole("sandbox", console.path())
+	assert.NoError(err)
+	_, err = guest.Write([]byte("bin\n"))
+	assert.NoError(err)
+	assert.Equal("bin\n", readConsole(t, late, 4))
+
+	// A detached client does not read anymore, and the console stays
+	// connected for the others.
+	assert.NoError(debug.Close())
+	_, err = debug.Read(make([]byte, 1))
+	assert.Equal(io.ErrClosedPipe, err)
+	assert.Equal("bin\n", readConsole(t, logs, 4))
+
+	// The console connection is closed once the last client detaches.
+	assert.NoError(late.Close())
+	assert.NoError(logs.Close())
+	_, err = guest.Read(make([]byte, 1))
+	assert.Equal(io.EOF, err)
+
+	consoleMuxesLock.Lock()
+	assert.Empty(consoleMuxes)
+	consoleMuxesLock.Unlock()
+
+	// The console is connected again for the next client.
+	logs, err = attachConsole("sandbox", console.path())
+	assert.NoError(err)
+	defer logs.Close()
+	guest = console.accept(t)
+
+	// The clients read the end of the console once it goes away.
+	_, err = guest.Write([]byte("reboot: Power down\n"))
+	assert.NoError(err)
+	assert.NoError(guest.Close())
+	assert.Equal("reboot: Power down\n", readConsole(t, logs, 19))
+	_, err = logs.Read(make([]byte, 1))
+	assert.Equal(io.EOF, err)
+}
+
+func TestConsoleMuxLaggingClient(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "console-mux")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpDir)
+
+	console := newTestConsole(t, tmpDir)
+	defer console.listener.Close()
+
+	slow, err := attachConsole("lagging", console.path())
+	assert.NoError(err)
+	defer slow.Close()
+	guest := console.accept(t)
+	defer guest.Close()
+
+	fast, err := attachConsole("lagging", console.path())
+	assert.NoError(err)
+	defer fast.Close()
+
+	// The output the slow client lagged behind is lost, while the fast
+	// one reads it all.
+	chunk := make([]byte, consoleReadSize)
+	for i := range chunk {
+		chunk[i] = 'a' + byte(i%26)
+	}
+	written := 0
+	for written < 3*consoleHistorySize {
+		_, err = guest.Write(chunk)
+		assert.NoError(err)
+		assert.Equal(string(chunk), readConsole(t, fast, len(chunk)))
+		written += len(chunk)
+	}
+
+	read, err := slow.Read(make([]byte, written))
+	assert.NoError(err)
+	assert.True(read >= consoleHistorySize && read < written, "read %d bytes", read)
+
+	// The output lost is counted.
+	client := slow.(*consoleClient)
+	assert.Equal(int64(written-read), client.dropped)
+	assert.Equal(int64(0), fast.(*consoleClient).dropped)
+	assert.Equal(client.dropped, client.mux.dropped)
+}
+
+func TestGetSandboxConsoleMissingID(t *testing.T) {
+	_, err := GetSandboxConsole(context.Background(), "")
+	assert.Equal(t, vcTypes.ErrNeedSandboxID, err)
+}
@@ -85,6 +85,9 @@ type ContainerStatus struct {
 	// for example to add additional status values required
 	// to support particular specifications.
 	Annotations map[string]string
+
+	// BlockDevices are the block devices attached to the container.
+	BlockDevices []BlockDeviceStatus
 }
 
 // ThrottlingData gather the date related to container cpu throttling.
@@ -144,6 +147,9 @@ type MemoryStats struct {
 	UseHierarchy bool `json:"use_hierarchy"`
 
 	Stats map[string]uint64 `json:"stats,omitempty"`
+
+	// memory the guest did not access during the last idle memory scan
+	Idle uint64 `json:"idle,omitempty"`
 }
 
 // PidsStats describes the pids stats
@@ -152,6 +158,8 @@ type PidsStats struct {
 	Current uint64 `json:"current,omitempty"`
 	// active pids hard limit
 	Limit uint64 `json:"limit,omitempty"`
+	// number of exited processes of the cgroup not reaped yet
+	Zombies uint64 `json:"zombies,omitempty"`
 }
 
 // BlkioStatEntry gather date related to a block device
@@ -253,6 +261,14 @@ type ContainerConfig struct {
 
 	// Raw OCI specification, it won't be saved to disk.
 	CustomSpec *specs.Spec `json:"-"`
+
+	// CoreDump is what is done with the core dumps of the container
+	// processes.
+	CoreDump CoreDump
+
+	// EncryptedScratchMB is the size in MiB of the ephemeral encrypted
+	// block device backing the writable layer of the rootfs, none if 0.
+	EncryptedScratchMB uint64
 }
 
 // valid checks that the container configuration is valid.
@@ -455,6 +471,13 @@ func (c *Container) shareFiles(m Mount, idx int, hostSharedDir, guestSharedDir s
 			return "", false, err
 		}
 
+		// The k8s volumes the kubelet updates, such as the projected
+		// service account tokens, are copied again when they change.
+		if fileInfo.IsDir() && isWatchableMount(m.Source) {
+			ignore, err := c.copyWatchedMount(m.Source, guestDest)
+			return guestDest, ignore, err
+		}
+
 		// Ignore the mount if this is not a regular file (excludes
 		// directory, socket, device, ...) as it cannot be handled by
 		// a simple copy. But this should not be treated as an error,
@@ -489,11 +512,15 @@ func (c *Container) mountSharedDirMounts(hostSharedDir, guestSharedDir string) (
 	sharedDirMounts = make(map[string]Mount)
 	ignoredMounts = make(map[string]Mount)
 	var devicesToDetach []string
+	var sharesToRemove []int
 	defer func() {
 		if err != nil {
 			for _, id := range devicesToDetach {
 				c.sandbox.devManager.DetachDevice(id, c.sandbox)
 			}
+			for _, idx := range sharesToRemove {
+				c.removeVirtioFSShare(&c.mounts[idx])
+			}
 		}
 	}()
 	for idx, m := range c.mounts {
@@ -526,6 +553,21 @@ func (c *Container) mountSharedDirMounts(hostSharedDir, guestSharedDir string) (
 			continue
 		}
 
+		// Share the mount through a virtio-fs device of its own if it has
+		// virtio-fs options, the agent mounting it as a storage.
+		if m.VirtioFS != nil {
+			shared := m.VirtioFSDeviceID != ""
+			if err = c.addVirtioFSShare(&c.mounts[idx]); err != nil {
+				return nil, nil, err
+			}
+			if c.mounts[idx].VirtioFSDeviceID != "" {
+				if !shared {
+					sharesToRemove = append(sharesToRemove, idx)
+				}
+				continue
+			}
+		}
+
 		// Ignore /dev, directories and all other device files. We handle
 		// only regular files in /dev. It does not make sense to pass the host
 		// device nodes to the guest.
@@ -637,6 +679,8 @@ func (c *Container) createBlockDevices() error {
 		return nil
 	}
 
+	caps := c.sandbox.hypervisor.capabilities()
+
 	// iterate all mounts and create block device if it's block based.
 	for i, m := range c.mounts {
 		if len(m.BlockDeviceID) > 0 || m.Type != "bind" {
@@ -663,6 +707,10 @@ func (c *Container) createBlockDevices() error {
 				DevType:       "b",
 				Major:         int64(unix.Major(stat.Rdev)),
 				Minor:         int64(unix.Minor(stat.Rdev)),
+				BlockCache:    m.BlockCache,
+			}
+			if di.BlockCache != "" && !caps.IsBlockDeviceCacheSupported() {
+				return fmt.Errorf("Cache mode of block device %s not supported by the hypervisor", m.Source)
 			}
 			// check whether source can be used as a pmem device
 		} else if di, err = config.PmemDeviceInfo(m.Source, m.Destination); err != nil {
@@ -671,6 +719,10 @@ func (c *Container) createBlockDevices() error {
 				Debug("no loop device")
 		}
 
+		if m.BlockCache != "" && (di == nil || di.Pmem) {
+			c.Logger().WithField("mount-source", m.Source).Warn("Not a block device, cache mode ignored")
+		}
+
 		if err == nil && di != nil {
 			b, err := c.sandbox.devManager.NewDevice(*di)
 
@@ -699,6 +751,20 @@ func newContainer(sandbox *Sandbox, contConfig *ContainerConfig) (*Container, er
 		return &Container{}, fmt.Errorf("Invalid container configuration")
 	}
 
+	if err := contConfig.CoreDump.validate(); err != nil {
+		return &Container{}, configFieldError("CoreDump", err)
+	}
+
+	for _, m := range contConfig.Mounts {
+		if err := config.CheckBlockCache(m.BlockCache); err != nil {
+			return &Container{}, configFieldError("Mounts", err)
+		}
+	}
+
+	if contConfig.EncryptedScratchMB != 0 && contConfig.ReadonlyRootfs {
+		return &Container{}, newConfigFieldError("EncryptedScratchMB", "A read-only rootfs has no writable layer to encrypt")
+	}
+
 	c := &Container{
 		id:            contConfig.ID,
 		sandboxID:     sandbox.id,
@@ -776,15 +842,25 @@ func (c *Container) rollbackFailingContainerCreation() {
 	if err := c.detachDevices(); err != nil {
 		c.Logger().WithError(err).Error("rollback failed detachDevices()")
 	}
+	if err := c.removeVirtioFSShares(); err != nil {
+		c.Logger().WithError(err).Error("rollback failed removeVirtioFSShares()")
+	}
 	if err := c.removeDrive(); err != nil {
 		c.Logger().WithError(err).Error("rollback failed removeDrive()")
 	}
+	if err := c.removeEncryptedScratch(); err != nil {
+		c.Logger().WithError(err).Error("rollback failed removeEncryptedScratch()")
+	}
 	if err := c.unmountHostMounts(); err != nil {
 		c.Logger().WithError(err).Error("rollback failed unmountHostMounts()")
 	}
+	c.sandbox.unwatchMounts(c.id)
 	if err := bindUnmountContainerRootfs(c.ctx, getMountPath(c.sandbox.id), c.id); err != nil {
 		c.Logger().WithError(err).Error("rollback failed bindUnmountContainerRootfs()")
 	}
+	if err := bindUnmountCoreDumpDir(c.ctx, getMountPath(c.sandbox.id), c.id); err != nil {
+		c.Logger().WithError(err).Error("rollback failed bindUnmountCoreDumpDir()")
+	}
 }
 
 func (c *Container) checkBlockDeviceSupport() bool {
@@ -812,12 +888,22 @@ func (c *Container) create() (err error) {
 		}
 	}()
 
-	if c.checkBlockDeviceSupport() {
+	if c.useRootfsDisk() {
+		if err = c.plugRootfsDisk(); err != nil {
+			return
+		}
+	} else if c.checkBlockDeviceSupport() {
 		if err = c.hotplugDrive(); err != nil {
 			return
 		}
 	}
 
+	if c.config.EncryptedScratchMB != 0 {
+		if err = c.plugEncryptedScratch(); err != nil {
+			return
+		}
+	}
+
 	var (
 		machineType        = c.sandbox.config.HypervisorConfig.HypervisorMachineType
 		normalAttachedDevs []ContainerDevice //for q35: normally attached devices
@@ -1010,18 +1096,32 @@ func (c *Container) stop(force bool) error {
 		return err
 	}
 
+	c.sandbox.unwatchMounts(c.id)
+
 	if err := bindUnmountContainerRootfs(c.ctx, getMountPath(c.sandbox.id), c.id); err != nil && !force {
 		return err
 	}
 
+	if err := bindUnmountCoreDumpDir(c.ctx, getMountPath(c.sandbox.id), c.id); err != nil && !force {
+		return err
+	}
+
 	if err := c.detachDevices(); err != nil && !force {
 		return err
 	}
 
+	if err := c.removeVirtioFSShares(); err != nil && !force {
+		return err
+	}
+
 	if err := c.removeDrive(); err != nil && !force {
 		return err
 	}
 
+	if err := c.removeEncryptedScratch(); err != nil && !force {
+		return err
+	}
+
 	shareDir := filepath.Join(kataHostSharedDir(), c.sandbox.id, c.id)
 	if err := syscall.Rmdir(shareDir); err != nil {
 		c.Logger().WithError(err).WithField("share-dir", shareDir).Warn("Could not remove container share dir")
@@ -1149,6 +1249,14 @@ func (c *Container) update(resources specs.LinuxResources) error {
 		c.config.Resources.Memory.Limit = mem.Limit
 	}
 
+	if pids := resources.Pids; pids != nil {
+		c.config.Resources.Pids = &specs.LinuxPids{Limit: pids.Limit}
+	}
+
+	if len(resources.Unified) > 0 {
+		c.config.Resources.Unified = resources.Unified
+	}
+
 	if err := c.sandbox.updateResources(); err != nil {
 		return err
 	}
@@ -1267,27 +1375,35 @@ func (c *Container) plugDevice(devicePath string) error {
 	}
 
 	if c.checkBlockDeviceSupport() && stat.Mode&unix.S_IFBLK == unix.S_IFBLK {
-		b, err := c.sandbox.devManager.NewDevice(config.DeviceInfo{
-			HostPath:      devicePath,
-			ContainerPath: filepath.Join(kataGuestSharedDir(), c.id),
-			DevType:       "b",
-			Major:         int64(unix.Major(stat.Rdev)),
-			Minor:         int64(unix.Minor(stat.Rdev)),
-		})
-		if err != nil {
-			return fmt.Errorf("device manager failed to create rootfs device for %q: %v", devicePath, err)
+		id, err := c.attachBlockDevice(devicePath, filepath.Join(kataGuestSharedDir(), c.id), stat)
+		if id != "" {
+			c.state.BlockDeviceID = id
 		}
-
-		c.state.BlockDeviceID = b.DeviceID()
-
-		// attach rootfs device
-		if err := c.sandbox.devManager.AttachDevice(b.DeviceID(), c.sandbox); err != nil {
+		if err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// attachBlockDevice creates the device of a host block device, and attaches
+// it to the sandbox. The ID of the device is returned once created, for the
+// caller to remove it even if attaching it failed.
+func (c *Container) attachBlockDevice(devicePath, containerPath string, stat unix.Stat_t) (string, error) {
+	b, err := c.sandbox.devManager.NewDevice(config.DeviceInfo{
+		HostPath:      devicePath,
+		ContainerPath: containerPath,
+		DevType:       "b",
+		Major:         int64(unix.Major(stat.Rdev)),
+		Minor:         int64(unix.Minor(stat.Rdev)),
+	})
+	if err != nil {
+		return "", fmt.Errorf("device manager failed to create device for %q: %v", devicePath, err)
+	}
+
+	return b.DeviceID(), c.sandbox.devManager.AttachDevice(b.DeviceID(), c.sandbox)
+}
+
 // isDriveUsed checks if a drive has been used for container rootfs
 func (c *Container) isDriveUsed() bool {
 	return !(c.state.Fstype == "")
@@ -1332,14 +1448,27 @@ func (c *Container) attachDevices(devices []ContainerDevice) error {
 			return err
 		}
 	}
-	return nil
+
+	return c.sandbox.checkVFIOPeerToPeer()
 }
 
 func (c *Container) detachDevices() error {
+	var detachErr error
+
+	// Keep going on errors, so that a device failing to detach does not
+	// leak all the devices after it.
 	for _, dev := range c.devices {
 		err := c.sandbox.devManager.DetachDevice(dev.ID, c.sandbox)
 		if err != nil && err != manager.ErrDeviceNotAttached {
-			return err
+			c.Logger().WithFields(logrus.Fields{
+				"container": c.id,
+				"device-id": dev.ID,
+			}).WithError(err).Error("detach device failed")
+
+			if detachErr == nil {
+				detachErr = err
+			}
+			continue
 		}
 
 		if err = c.sandbox.devManager.RemoveDevice(dev.ID); err != nil {
@@ -1349,12 +1478,12 @@ func (c *Container) detachDevices() error {
 			}).WithError(err).Error("remove device failed")
 
 			// ignore the device not exist error
-			if err != manager.ErrDeviceNotExist {
-				return err
+			if err != manager.ErrDeviceNotExist && detachErr == nil {
+				detachErr = err
 			}
 		}
 	}
-	return nil
+	return detachErr
 }
 
 // cgroupsCreate creates cgroups on the host for the associated container
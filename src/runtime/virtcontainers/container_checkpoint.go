@@ -0,0 +1,161 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// checkpointDir is the directory of the checkpoint images of a
+	// container in the shared directory.
+	checkpointDir = "checkpoint"
+)
+
+// CheckpointOptions are the options of a container checkpoint or restore,
+// done by CRIU in the guest.
+type CheckpointOptions struct {
+	// ImagesDir is the host directory of the CRIU images of the
+	// container.
+	ImagesDir string
+
+	// LeaveRunning keeps a checkpointed container running, its processes
+	// exit otherwise.
+	LeaveRunning bool
+}
+
+func (o CheckpointOptions) validate() error {
+	if o.ImagesDir == "" || !filepath.IsAbs(o.ImagesDir) {
+		return fmt.Errorf("Checkpoint images directory %q is not absolute", o.ImagesDir)
+	}
+	return nil
+}
+
+// shareCheckpointDir shares the checkpoint images directory with the
+// guest, and returns its guest path.
+func (c *Container) shareCheckpointDir(imagesDir string) (string, error) {
+	caps := c.sandbox.hypervisor.capabilities()
+	if !caps.IsFsSharingSupported() {
+		return "", fmt.Errorf("Container %s cannot be checkpointed or restored without filesystem sharing", c.id)
+	}
+
+	if err := os.MkdirAll(imagesDir, DirMode); err != nil {
+		return "", err
+	}
+
+	if err := bindMount(c.ctx, imagesDir, filepath.Join(getMountPath(c.sandbox.id), c.id, checkpointDir), false, "private"); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(kataGuestSharedDir(), c.id, checkpointDir), nil
+}
+
+func (c *Container) checkpoint(opts CheckpointOptions) error {
+	if err := c.checkSandboxRunning("checkpoint"); err != nil {
+		return err
+	}
+
+	if c.state.State != types.StateRunning && c.state.State != types.StatePaused {
+		return fmt.Errorf("Container not running or paused, impossible to checkpoint")
+	}
+
+	if err := c.sandbox.checkNotConfidential("checkpoint a container"); err != nil {
+		return err
+	}
+
+	guestDir, err := c.shareCheckpointDir(opts.ImagesDir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := bindUnmountCheckpointDir(c.ctx, getMountPath(c.sandbox.id), c.id); err != nil {
+			c.Logger().WithError(err).Warn("Could not unmount the checkpoint images directory")
+		}
+	}()
+
+	if err := c.sandbox.agent.checkpointContainer(c, guestDir, opts.LeaveRunning); err != nil {
+		return fmt.Errorf("Could not checkpoint container %s: %v", c.id, err)
+	}
+
+	// The exit of the container processes, unless left running, is
+	// handled as any other.
+	c.Logger().WithField("images", opts.ImagesDir).Info("Container checkpointed")
+
+	return nil
+}
+
+// restore restores a checkpointed container in place of a created one,
+// which is then running.
+func (c *Container) restore(opts CheckpointOptions) error {
+	if err := c.checkSandboxRunning("restore"); err != nil {
+		return err
+	}
+
+	if c.state.State != types.StateReady {
+		return fmt.Errorf("Container not ready, impossible to restore")
+	}
+
+	if err := c.sandbox.checkNotConfidential("restore a container"); err != nil {
+		return err
+	}
+
+	if err := c.state.ValidTransition(c.state.State, types.StateRunning); err != nil {
+		return err
+	}
+
+	guestDir, err := c.shareCheckpointDir(opts.ImagesDir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := bindUnmountCheckpointDir(c.ctx, getMountPath(c.sandbox.id), c.id); err != nil {
+			c.Logger().WithError(err).Warn("Could not unmount the checkpoint images directory")
+		}
+	}()
+
+	if err := c.sandbox.agent.restoreContainer(c, guestDir); err != nil {
+		c.Logger().WithError(err).Error("Failed to restore container")
+
+		if err := c.stop(true); err != nil {
+			c.Logger().WithError(err).Warn("Failed to stop container")
+		}
+		return fmt.Errorf("Could not restore container %s: %v", c.id, err)
+	}
+
+	c.Logger().WithField("images", opts.ImagesDir).Info("Container restored")
+
+	return c.setContainerState(types.StateRunning)
+}
+
+// bindUnmountCheckpointDir unmounts the checkpoint images directory of a
+// container from the shared directory, if shared.
+func bindUnmountCheckpointDir(ctx context.Context, sharedDir, cID string) error {
+	span, _ := trace(ctx, "bindUnmountCheckpointDir")
+	defer span.Finish()
+
+	dest := filepath.Join(sharedDir, cID, checkpointDir)
+	if isSymlink(filepath.Join(sharedDir, cID)) || isSymlink(dest) {
+		logrus.Warnf("container dir %s is a symlink, malicious guest?", cID)
+		return nil
+	}
+
+	if _, err := os.Stat(dest); os.IsNotExist(err) {
+		return nil
+	}
+
+	if err := syscall.Unmount(dest, syscall.MNT_DETACH|UmountNoFollow); err != nil && err != syscall.EINVAL {
+		return err
+	}
+
+	return os.Remove(dest)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpointOptionsValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(CheckpointOptions{ImagesDir: "/var/lib/checkpoints"}.validate())
+	assert.Error(CheckpointOptions{}.validate())
+	assert.Error(CheckpointOptions{ImagesDir: "checkpoints"}.validate())
+}
+
+func TestCheckpointContainerMissingIDs(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	opts := CheckpointOptions{ImagesDir: "/var/lib/checkpoints"}
+
+	for _, fn := range []func(context.Context, string, string, CheckpointOptions) error{CheckpointContainer, RestoreContainer} {
+		assert.Equal(vcTypes.ErrNeedSandboxID, fn(ctx, "", "ctr", opts))
+		assert.Equal(vcTypes.ErrNeedContainerID, fn(ctx, "sandbox", "", opts))
+	}
+}
+
+func TestContainerCheckpointState(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &Container{
+		id:      "ctr",
+		sandbox: &Sandbox{id: "sandbox", state: types.SandboxState{State: types.StateRunning}},
+		state:   types.ContainerState{State: types.StateReady},
+	}
+
+	opts := CheckpointOptions{ImagesDir: "/var/lib/checkpoints"}
+
+	// A created container can be restored, not checkpointed
+	assert.Error(c.checkpoint(opts))
+
+	c.state.State = types.StateRunning
+	assert.Error(c.restore(opts))
+
+	c.sandbox.state.State = types.StatePaused
+	assert.Error(c.checkpoint(opts))
+}
+
+func TestBindUnmountCheckpointDir(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "checkpoint")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	// Nothing to unmount
+	assert.NoError(bindUnmountCheckpointDir(context.Background(), dir, "ctr"))
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/sirupsen/logrus"
+)
+
+// rootfsGuestDir returns the guest directory of the container rootfs.
+func (c *Container) rootfsGuestDir() string {
+	return filepath.Join(kataGuestSharedDir(), c.id, c.rootfsSuffix)
+}
+
+// rootfsRelPath returns the path p of a container rootfs relative to the
+// rootfs. p is cleaned as an absolute path first, not to go up the rootfs,
+// and the agent resolves it without following the symlinks of the rootfs.
+func rootfsRelPath(p string) string {
+	return strings.TrimPrefix(filepath.Clean("/"+p), "/")
+}
+
+// checkFileCopy checks that the rootfs of the container is mounted in the
+// guest, from its creation until it stops.
+func (c *Container) checkFileCopy() error {
+	if err := c.checkSandboxRunning("copy a file of"); err != nil {
+		return err
+	}
+
+	switch c.state.State {
+	case types.StateReady, types.StateRunning, types.StatePaused:
+		return nil
+	}
+
+	return fmt.Errorf("Container %s not created, impossible to copy a file", c.id)
+}
+
+// copyFileTo copies the host file hostPath to guestPath in the container
+// rootfs, by parts for a large file. The agent creates the missing parent
+// directories.
+func (c *Container) copyFileTo(hostPath, guestPath string) error {
+	if err := c.checkFileCopy(); err != nil {
+		return err
+	}
+
+	if err := c.sandbox.agent.copyFileToRootfs(hostPath, c.rootfsGuestDir(), rootfsRelPath(guestPath)); err != nil {
+		return fmt.Errorf("Could not copy %s to container %s: %v", hostPath, c.id, err)
+	}
+
+	c.Logger().WithFields(logrus.Fields{
+		"source": hostPath,
+		"dest":   guestPath,
+	}).Info("File copied to container")
+
+	return nil
+}
+
+// copyFileFrom copies the file guestPath of the container rootfs to the
+// host file hostPath, by parts for a large file.
+func (c *Container) copyFileFrom(guestPath, hostPath string) error {
+	if err := c.checkFileCopy(); err != nil {
+		return err
+	}
+
+	if err := c.sandbox.agent.copyFileFromRootfs(c.rootfsGuestDir(), rootfsRelPath(guestPath), hostPath); err != nil {
+		return fmt.Errorf("Could not copy %s from container %s: %v", guestPath, c.id, err)
+	}
+
+	c.Logger().WithFields(logrus.Fields{
+		"source": guestPath,
+		"dest":   hostPath,
+	}).Info("File copied from container")
+
+	return nil
+}
+
+func (s *Sandbox) copyFileToContainer(containerID, hostPath, guestPath string) error {
+	span, _ := s.trace("copyFileToContainer")
+	defer span.Finish()
+
+	if hostPath == "" || guestPath == "" {
+		return fmt.Errorf("Both the host and the guest paths are needed to copy a file")
+	}
+
+	c, err := s.findContainer(containerID)
+	if err != nil {
+		return err
+	}
+
+	return c.copyFileTo(hostPath, guestPath)
+}
+
+func (s *Sandbox) copyFileFromContainer(containerID, guestPath, hostPath string) error {
+	span, _ := s.trace("copyFileFromContainer")
+	defer span.Finish()
+
+	if hostPath == "" || guestPath == "" {
+		return fmt.Errorf("Both the host and the guest paths are needed to copy a file")
+	}
+
+	if err := s.checkNotConfidential("copy a file from a container"); err != nil {
+		return err
+	}
+
+	c, err := s.findContainer(containerID)
+	if err != nil {
+		return err
+	}
+
+	return c.copyFileFrom(guestPath, hostPath)
+}
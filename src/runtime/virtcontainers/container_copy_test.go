@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerRootfsGuestDir(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &Container{id: "ctr", rootfsSuffix: "rootfs"}
+	assert.Equal(filepath.Join(kataGuestSharedDir(), "ctr", "rootfs"), c.rootfsGuestDir())
+
+	// A block device rootfs is mounted as is
+	c.rootfsSuffix = ""
+	assert.Equal(filepath.Join(kataGuestSharedDir(), "ctr"), c.rootfsGuestDir())
+}
+
+func TestRootfsRelPath(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("etc/hosts", rootfsRelPath("/etc/hosts"))
+	assert.Equal("etc/hosts", rootfsRelPath("etc/hosts"))
+	assert.Equal("etc/passwd", rootfsRelPath("/../../etc/passwd"))
+	assert.Equal("secret", rootfsRelPath("/tmp/../../secret"))
+}
+
+func TestSandboxCopyFileContainer(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id:     "sandbox",
+		ctx:    context.Background(),
+		config: &SandboxConfig{},
+		agent:  &mockAgent{},
+		state:  types.SandboxState{State: types.StateRunning},
+	}
+
+	c := &Container{
+		id:      "ctr",
+		sandbox: s,
+		state:   types.ContainerState{State: types.StateRunning},
+	}
+	s.containers = map[string]*Container{c.id: c}
+
+	assert.NoError(s.copyFileToContainer("ctr", "/etc/resolv.conf", "/etc/resolv.conf"))
+	assert.NoError(s.copyFileFromContainer("ctr", "/var/log/app.log", "/tmp/app.log"))
+
+	assert.Error(s.copyFileToContainer("ctr", "", "/etc/resolv.conf"))
+	assert.Error(s.copyFileFromContainer("ctr", "/var/log/app.log", ""))
+	assert.Error(s.copyFileToContainer("missing", "/etc/resolv.conf", "/etc/resolv.conf"))
+
+	// The rootfs is only mounted until the container stops
+	c.state.State = types.StateStopped
+	assert.Error(s.copyFileToContainer("ctr", "/etc/resolv.conf", "/etc/resolv.conf"))
+
+	c.state.State = types.StatePaused
+	assert.NoError(s.copyFileFromContainer("ctr", "/var/log/app.log", "/tmp/app.log"))
+
+	s.state.State = types.StatePaused
+	assert.Error(s.copyFileFromContainer("ctr", "/var/log/app.log", "/tmp/app.log"))
+}
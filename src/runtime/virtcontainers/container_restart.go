@@ -0,0 +1,166 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"path/filepath"
+	"syscall"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// blockVolumeIDs returns the IDs of the devices of the block device mounts
+// of the container, added to its devices by the agent when it creates the
+// container.
+func (c *Container) blockVolumeIDs() map[string]bool {
+	ids := make(map[string]bool)
+	for _, m := range c.mounts {
+		if m.BlockDeviceID != "" {
+			ids[m.BlockDeviceID] = true
+		}
+	}
+	return ids
+}
+
+// restoreEphemeralMounts turns back the ephemeral mounts of the spec,
+// rewritten to bind mounts of the guest tmpfs when the container was
+// created, into ephemeral mounts, for the agent to mount the tmpfs again.
+func restoreEphemeralMounts(spec *specs.Spec) {
+	for i, m := range spec.Mounts {
+		if m.Type == "bind" && filepath.Dir(m.Source) == ephemeralPath() {
+			spec.Mounts[i].Type = KataEphemeralDevType
+		}
+	}
+}
+
+// teardownInGuest removes the container from the guest, and undoes the
+// host mounts the agent shares with it, leaving its devices attached to
+// the VM.
+func (c *Container) teardownInGuest() error {
+	// The container is killed the same way as when it is stopped, its
+	// process possibly being gone already.
+	c.kill(syscall.SIGKILL, true)
+	c.sandbox.agent.waitProcess(c, c.id)
+
+	if err := c.sandbox.agent.stopContainer(c.sandbox, *c); err != nil {
+		return err
+	}
+
+	if err := c.unmountHostMounts(); err != nil {
+		return err
+	}
+
+	c.sandbox.unwatchMounts(c.id)
+
+	if err := bindUnmountContainerRootfs(c.ctx, getMountPath(c.sandbox.id), c.id); err != nil {
+		return err
+	}
+
+	return bindUnmountCoreDumpDir(c.ctx, getMountPath(c.sandbox.id), c.id)
+}
+
+// recreateInGuest creates the container in the guest again, with the
+// devices still attached to the VM.
+func (c *Container) recreateInGuest() error {
+	// The agent adds the block device mounts to the devices, and takes a
+	// reference on them, again.
+	volumes := c.blockVolumeIDs()
+	var devices []ContainerDevice
+	for _, d := range c.devices {
+		if !volumes[d.ID] {
+			devices = append(devices, d)
+		}
+	}
+	c.devices = devices
+
+	if spec := c.GetPatchedOCISpec(); spec != nil {
+		restoreEphemeralMounts(spec)
+	}
+
+	c.getSystemMountInfo()
+
+	process, err := c.sandbox.agent.createContainer(c.sandbox, c)
+	if err != nil {
+		return err
+	}
+	c.process = *process
+
+	// Only one reference is held on the block device mounts, so that
+	// stopping the container unplugs them.
+	for id := range volumes {
+		if err := c.sandbox.devManager.DetachDevice(id, c.sandbox); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restart recreates the container in the running VM, reusing its devices,
+// mounts and network, and starts it. Neither the devices are hot unplugged
+// and plugged again, nor the VM is resized. The container is stopped if it
+// cannot be recreated.
+func (c *Container) restart() (err error) {
+	span, _ := c.trace("restart")
+	defer span.Finish()
+
+	if err := c.checkSandboxRunning("restart"); err != nil {
+		return err
+	}
+
+	if c.state.State != types.StateReady && c.state.State != types.StateRunning {
+		return fmt.Errorf("Container not ready or running, impossible to restart")
+	}
+
+	defer func() {
+		if err != nil && c.state.State != types.StateStopped {
+			c.Logger().WithError(err).Error("Container restart failed")
+			c.rollbackFailingContainerCreation()
+			if err := c.setContainerState(types.StateStopped); err != nil {
+				c.Logger().WithError(err).Warn("Could not set the state of the container")
+			}
+		}
+	}()
+
+	if err = c.teardownInGuest(); err != nil {
+		return err
+	}
+
+	if err = c.recreateInGuest(); err != nil {
+		return err
+	}
+
+	if err = c.setContainerState(types.StateReady); err != nil {
+		return err
+	}
+
+	// A container failing to start is stopped.
+	return c.start()
+}
+
+func (s *Sandbox) restartContainer(containerID string) (VCContainer, error) {
+	span, _ := s.trace("restartContainer")
+	defer span.Finish()
+
+	c, err := s.findContainer(containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.restart(); err != nil {
+		return nil, err
+	}
+
+	if err := s.storeSandbox(); err != nil {
+		return nil, err
+	}
+
+	s.Logger().WithField("container", containerID).Info("Container is restarted")
+
+	return c, nil
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestoreEphemeralMounts(t *testing.T) {
+	assert := assert.New(t)
+
+	spec := &specs.Spec{
+		Mounts: []specs.Mount{
+			{Destination: "/cache", Source: filepath.Join(ephemeralPath(), "cache"), Type: "bind"},
+			{Destination: "/data", Source: "/run/kata-containers/shared/containers/data", Type: "bind"},
+			{Destination: "/tmp", Source: "tmpfs", Type: "tmpfs"},
+		},
+	}
+
+	restoreEphemeralMounts(spec)
+	assert.Equal(KataEphemeralDevType, spec.Mounts[0].Type)
+	assert.Equal("bind", spec.Mounts[1].Type)
+	assert.Equal("tmpfs", spec.Mounts[2].Type)
+
+	// The agent handles them as when the container was first created
+	storages := (&kataAgent{}).handleEphemeralStorage(spec.Mounts)
+	assert.Len(storages, 1)
+	assert.Equal(filepath.Join(ephemeralPath(), "cache"), storages[0].MountPoint)
+	assert.Equal("bind", spec.Mounts[0].Type)
+}
+
+func TestRestartContainer(t *testing.T) {
+	defer cleanUp()
+	assert := assert.New(t)
+
+	ctx := WithNewAgentFunc(context.Background(), newMockAgent)
+
+	_, err := RestartContainer(ctx, "", "100")
+	assert.Error(err)
+	_, err = RestartContainer(ctx, testSandboxID, "")
+	assert.Error(err)
+
+	p, _, err := createAndStartSandbox(ctx, newTestSandboxConfigNoop())
+	assert.NoError(err)
+
+	c, err := p.CreateContainer(newTestContainerConfigNoop("100"))
+	assert.NoError(err)
+	_, err = p.StartContainer(c.ID())
+	assert.NoError(err)
+
+	c, err = RestartContainer(ctx, p.ID(), "100")
+	assert.NoError(err)
+	assert.Equal(types.StateRunning, c.(*Container).state.State)
+
+	_, err = RestartContainer(ctx, p.ID(), "missing")
+	assert.Error(err)
+
+	// A stopped container has released its devices, and is created again
+	// instead.
+	_, err = p.StopContainer("100", false)
+	assert.NoError(err)
+	_, err = RestartContainer(ctx, p.ID(), "100")
+	assert.Error(err)
+}
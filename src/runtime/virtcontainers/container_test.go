@@ -7,6 +7,7 @@ package virtcontainers
 
 import (
 	"context"
+	"errors"
 	"io/ioutil"
 	"os"
 	"os/exec"
@@ -539,3 +540,21 @@ func TestProcessIOStream(t *testing.T) {
 	_, _, _, err = c.ioStream(processID)
 	assert.Error(err)
 }
+
+func TestNewContainerBlockCache(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id:     testSandboxID,
+		config: &SandboxConfig{},
+		ctx:    context.Background(),
+	}
+
+	_, err := newContainer(s, &ContainerConfig{
+		ID:     "100",
+		Mounts: []Mount{{Source: "/dev/sdb", Destination: "/data", Type: "bind", BlockCache: "unsafe"}},
+	})
+	var fieldErr *ConfigFieldError
+	assert.True(errors.As(err, &fieldErr))
+	assert.Equal("Mounts", fieldErr.Field)
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/sirupsen/logrus"
+)
+
+// CoreDumpPolicy is what the agent does with the core dumps of the
+// container processes.
+type CoreDumpPolicy string
+
+const (
+	// CoreDumpGuestDefault leaves the core dumps to the guest
+	// configuration.
+	CoreDumpGuestDefault CoreDumpPolicy = ""
+
+	// CoreDumpDiscard drops the core dumps.
+	CoreDumpDiscard CoreDumpPolicy = "discard"
+
+	// CoreDumpCapture writes the core dumps to a host directory.
+	CoreDumpCapture CoreDumpPolicy = "capture"
+)
+
+// coreDumpDir is the directory of the container core dumps in the shared
+// directory.
+const coreDumpDir = "coredumps"
+
+// The annotations of the container spec passed to the agent, overriding
+// the ones of the user spec.
+const (
+	agentCoreDumpPolicyKey  = "io.katacontainers.agent.coredump.policy"
+	agentCoreDumpDirKey     = "io.katacontainers.agent.coredump.dir"
+	agentCoreDumpMaxSizeKey = "io.katacontainers.agent.coredump.max_size"
+)
+
+// CoreDump is the core dump capture configuration of a container. The
+// guest hands the core dumps to the agent, which writes them to a host
+// directory shared with the guest, or drops them.
+type CoreDump struct {
+	Policy CoreDumpPolicy
+
+	// HostDir is the host directory the core dumps are written to, by
+	// default the container directory under the sandbox CoreDumpDir.
+	HostDir string
+
+	// MaxSize is the size in bytes the core dumps are truncated to, none
+	// if 0.
+	MaxSize uint64
+}
+
+func (d CoreDump) validate() error {
+	switch d.Policy {
+	case CoreDumpGuestDefault, CoreDumpDiscard, CoreDumpCapture:
+	default:
+		return newConfigFieldError("Policy", fmt.Sprintf("Invalid core dump policy %q", d.Policy))
+	}
+
+	if d.HostDir != "" && !filepath.IsAbs(d.HostDir) {
+		return newConfigFieldError("HostDir", fmt.Sprintf("Host directory %q is not absolute", d.HostDir))
+	}
+
+	return nil
+}
+
+// coreDumpHostDir returns the host directory the core dumps of the
+// container are written to.
+func (c *Container) coreDumpHostDir() (string, error) {
+	if c.config.CoreDump.HostDir != "" {
+		return c.config.CoreDump.HostDir, nil
+	}
+
+	if c.sandbox.config.CoreDumpDir == "" {
+		return "", fmt.Errorf("Container %s captures its core dumps without a host directory", c.id)
+	}
+
+	return filepath.Join(c.sandbox.config.CoreDumpDir, c.sandbox.id, c.id), nil
+}
+
+// shareCoreDumpDir shares the host directory of the container core dumps
+// with the guest, and sets the annotations telling the agent what to do
+// with them.
+func (c *Container) shareCoreDumpDir(spec *specs.Spec, hostSharedDir, guestSharedDir string) error {
+	delete(spec.Annotations, agentCoreDumpPolicyKey)
+	delete(spec.Annotations, agentCoreDumpDirKey)
+	delete(spec.Annotations, agentCoreDumpMaxSizeKey)
+
+	d := c.config.CoreDump
+	if d.Policy == CoreDumpGuestDefault {
+		return nil
+	}
+
+	if spec.Annotations == nil {
+		spec.Annotations = make(map[string]string)
+	}
+	spec.Annotations[agentCoreDumpPolicyKey] = string(d.Policy)
+
+	if d.Policy == CoreDumpDiscard {
+		return nil
+	}
+
+	caps := c.sandbox.hypervisor.capabilities()
+	if !caps.IsFsSharingSupported() {
+		return fmt.Errorf("Container %s core dumps cannot be captured without filesystem sharing", c.id)
+	}
+
+	hostDir, err := c.coreDumpHostDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(hostDir, DirMode); err != nil {
+		return err
+	}
+
+	if err := bindMount(c.ctx, hostDir, filepath.Join(hostSharedDir, c.id, coreDumpDir), false, "private"); err != nil {
+		return err
+	}
+
+	spec.Annotations[agentCoreDumpDirKey] = filepath.Join(guestSharedDir, c.id, coreDumpDir)
+	if d.MaxSize > 0 {
+		spec.Annotations[agentCoreDumpMaxSizeKey] = strconv.FormatUint(d.MaxSize, 10)
+	}
+
+	return nil
+}
+
+// bindUnmountCoreDumpDir unmounts the core dumps directory of a container
+// from the shared directory, if shared.
+func bindUnmountCoreDumpDir(ctx context.Context, sharedDir, cID string) error {
+	span, _ := trace(ctx, "bindUnmountCoreDumpDir")
+	defer span.Finish()
+
+	dest := filepath.Join(sharedDir, cID, coreDumpDir)
+	if isSymlink(filepath.Join(sharedDir, cID)) || isSymlink(dest) {
+		logrus.Warnf("container dir %s is a symlink, malicious guest?", cID)
+		return nil
+	}
+
+	if _, err := os.Stat(dest); os.IsNotExist(err) {
+		return nil
+	}
+
+	if err := syscall.Unmount(dest, syscall.MNT_DETACH|UmountNoFollow); err != nil && err != syscall.EINVAL {
+		return err
+	}
+
+	return os.Remove(dest)
+}
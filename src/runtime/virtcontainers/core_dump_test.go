@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoreDumpValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(CoreDump{}.validate())
+	assert.NoError(CoreDump{Policy: CoreDumpCapture, HostDir: "/var/crash", MaxSize: 1 << 20}.validate())
+
+	err := CoreDump{Policy: "keep"}.validate()
+	assert.Error(err)
+	assert.Equal("Policy", configFieldError("", err).Field)
+
+	err = CoreDump{Policy: CoreDumpCapture, HostDir: "crash"}.validate()
+	assert.Error(err)
+	assert.Equal("HostDir", configFieldError("", err).Field)
+}
+
+func TestCoreDumpHostDir(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &Container{
+		id:     "ctr",
+		config: &ContainerConfig{CoreDump: CoreDump{Policy: CoreDumpCapture}},
+		sandbox: &Sandbox{
+			id:     "sandbox",
+			config: &SandboxConfig{},
+		},
+	}
+
+	_, err := c.coreDumpHostDir()
+	assert.Error(err)
+
+	c.sandbox.config.CoreDumpDir = "/var/lib/kata-containers/coredumps"
+	dir, err := c.coreDumpHostDir()
+	assert.NoError(err)
+	assert.Equal("/var/lib/kata-containers/coredumps/sandbox/ctr", dir)
+
+	c.config.CoreDump.HostDir = "/var/crash"
+	dir, err = c.coreDumpHostDir()
+	assert.NoError(err)
+	assert.Equal("/var/crash", dir)
+}
+
+func TestShareCoreDumpDirAnnotations(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &Container{
+		id:      "ctr",
+		config:  &ContainerConfig{},
+		sandbox: &Sandbox{id: "sandbox", config: &SandboxConfig{}},
+	}
+
+	// The annotations of the user spec are dropped.
+	spec := &specs.Spec{
+		Annotations: map[string]string{agentCoreDumpPolicyKey: string(CoreDumpCapture), agentCoreDumpDirKey: "/"},
+	}
+	assert.NoError(c.shareCoreDumpDir(spec, "/host", "/guest"))
+	assert.Empty(spec.Annotations)
+
+	c.config.CoreDump.Policy = CoreDumpDiscard
+	assert.NoError(c.shareCoreDumpDir(spec, "/host", "/guest"))
+	assert.Equal(map[string]string{agentCoreDumpPolicyKey: "discard"}, spec.Annotations)
+}
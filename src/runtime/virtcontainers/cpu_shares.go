@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"math"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// CPUSharesMax gives the sandbox cgroup on the host the CPU shares of
+	// the container having the most shares.
+	CPUSharesMax = "max"
+
+	// CPUSharesSum gives the sandbox cgroup on the host the sum of the CPU
+	// shares of its containers, the same weight runc would give the pod.
+	CPUSharesSum = "sum"
+
+	// Bounds of the cgroup v1 cpu.shares value.
+	minCPUShares = 2
+	maxCPUShares = 262144
+
+	// defaultCPUShares is the weight of a nice 0 task.
+	defaultCPUShares = 1024
+
+	// niceWeightRatio is the ratio between the scheduler weights of two
+	// consecutive nice levels.
+	niceWeightRatio = 1.25
+)
+
+// CPUSharesTranslation configures how the OCI CPU shares of the containers
+// are translated to scheduling weights in the guest and on the host.
+type CPUSharesTranslation struct {
+	// Aggregation is how the shares of the sandbox cgroup on the host
+	// are derived from the shares of its containers: CPUSharesMax or
+	// CPUSharesSum. Defaults to CPUSharesMax.
+	Aggregation string
+
+	// GuestScale multiplies the container shares applied in the guest,
+	// changing the weight of the containers relative to the guest system
+	// processes. Zero means no scaling.
+	GuestScale float64
+
+	// VCPUNice sets the nice value of the vCPU threads from the shares of
+	// the sandbox, so that sandboxes get proportional CPU on the host
+	// even when they are not in weighted cgroups.
+	VCPUNice bool
+}
+
+func (t CPUSharesTranslation) validate() error {
+	switch t.Aggregation {
+	case "", CPUSharesMax, CPUSharesSum:
+	default:
+		return newConfigFieldError("Aggregation", fmt.Sprintf("Invalid CPU shares aggregation %q", t.Aggregation))
+	}
+
+	if t.GuestScale < 0 {
+		return newConfigFieldError("GuestScale", fmt.Sprintf("Invalid CPU shares guest scale %v", t.GuestScale))
+	}
+
+	return nil
+}
+
+// aggregate merges the shares of a container into the sandbox shares.
+func (t CPUSharesTranslation) aggregate(sandboxShares, shares uint64) uint64 {
+	if t.Aggregation == CPUSharesSum {
+		return clampCPUShares(sandboxShares + shares)
+	}
+
+	if shares > sandboxShares {
+		return shares
+	}
+	return sandboxShares
+}
+
+// guestShares returns the shares a container having shares gets in the
+// guest. Zero shares are left unset.
+func (t CPUSharesTranslation) guestShares(shares uint64) uint64 {
+	if shares == 0 || t.GuestScale == 0 {
+		return shares
+	}
+
+	return clampCPUShares(uint64(math.Round(float64(shares) * t.GuestScale)))
+}
+
+func clampCPUShares(shares uint64) uint64 {
+	if shares < minCPUShares {
+		return minCPUShares
+	}
+	if shares > maxCPUShares {
+		return maxCPUShares
+	}
+	return shares
+}
+
+// cpuSharesToNice returns the nice value whose scheduler weight is the
+// closest to shares, each nice level weighing 1.25 times less than the
+// previous one, and nice 0 weighing 1024.
+func cpuSharesToNice(shares uint64) int {
+	if shares == 0 {
+		return 0
+	}
+
+	nice := -int(math.Round(math.Log(float64(shares)/defaultCPUShares) / math.Log(niceWeightRatio)))
+	if nice < -20 {
+		return -20
+	}
+	if nice > 19 {
+		return 19
+	}
+	return nice
+}
+
+// setVCPUsNice applies the nice value matching the sandbox shares to the
+// vCPU threads.
+func (s *Sandbox) setVCPUsNice() error {
+	if !s.config.CPUShares.VCPUNice {
+		return nil
+	}
+
+	tids, err := s.hypervisor.getThreadIDs()
+	if err != nil {
+		return fmt.Errorf("failed to get thread ids from hypervisor: %v", err)
+	}
+
+	nice := cpuSharesToNice(*s.cpuResources().Shares)
+	for _, tid := range tids.vcpus {
+		if err := unix.Setpriority(unix.PRIO_PROCESS, tid, nice); err != nil {
+			return fmt.Errorf("Could not set vCPU thread %d nice value to %d: %v", tid, nice, err)
+		}
+	}
+
+	return nil
+}
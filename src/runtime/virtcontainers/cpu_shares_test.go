@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCPUSharesTranslationValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(CPUSharesTranslation{}.validate())
+	assert.NoError(CPUSharesTranslation{Aggregation: CPUSharesSum, GuestScale: 0.5}.validate())
+	assert.Error(CPUSharesTranslation{Aggregation: "avg"}.validate())
+	assert.Error(CPUSharesTranslation{GuestScale: -1}.validate())
+}
+
+func TestCPUSharesTranslationAggregate(t *testing.T) {
+	assert := assert.New(t)
+
+	var max CPUSharesTranslation
+	assert.Equal(uint64(1024), max.aggregate(512, 1024))
+	assert.Equal(uint64(1024), max.aggregate(1024, 512))
+
+	sum := CPUSharesTranslation{Aggregation: CPUSharesSum}
+	assert.Equal(uint64(1536), sum.aggregate(512, 1024))
+	assert.Equal(uint64(maxCPUShares), sum.aggregate(maxCPUShares, 1024))
+}
+
+func TestCPUSharesTranslationGuestShares(t *testing.T) {
+	assert := assert.New(t)
+
+	var none CPUSharesTranslation
+	assert.Equal(uint64(512), none.guestShares(512))
+
+	half := CPUSharesTranslation{GuestScale: 0.5}
+	assert.Equal(uint64(0), half.guestShares(0))
+	assert.Equal(uint64(256), half.guestShares(512))
+	assert.Equal(uint64(minCPUShares), half.guestShares(2))
+
+	big := CPUSharesTranslation{GuestScale: 100}
+	assert.Equal(uint64(maxCPUShares), big.guestShares(10240))
+}
+
+func TestCPUSharesToNice(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, cpuSharesToNice(0))
+	assert.Equal(0, cpuSharesToNice(1024))
+	assert.Equal(-1, cpuSharesToNice(1280))
+	assert.Equal(3, cpuSharesToNice(512))
+	assert.Equal(-20, cpuSharesToNice(maxCPUShares))
+	assert.Equal(19, cpuSharesToNice(minCPUShares))
+}
+
+func TestSandboxCPUResourcesSharesSum(t *testing.T) {
+	assert := assert.New(t)
+
+	shares1 := uint64(512)
+	shares2 := uint64(1024)
+
+	s := &Sandbox{
+		config: &SandboxConfig{
+			CPUShares: CPUSharesTranslation{Aggregation: CPUSharesSum},
+		},
+		containers: map[string]*Container{
+			"c1": {config: &ContainerConfig{Resources: specs.LinuxResources{CPU: &specs.LinuxCPU{Shares: &shares1}}}},
+			"c2": {config: &ContainerConfig{Resources: specs.LinuxResources{CPU: &specs.LinuxCPU{Shares: &shares2}}}},
+		},
+	}
+
+	assert.Equal(uint64(1536), *s.cpuResources().Shares)
+
+	s.config.CPUShares.Aggregation = CPUSharesMax
+	assert.Equal(uint64(1024), *s.cpuResources().Shares)
+}
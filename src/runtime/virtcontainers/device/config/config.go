@@ -67,8 +67,43 @@ const (
 
 	// VirtioFS means use virtio-fs for the shared file system
 	VirtioFS = "virtio-fs"
+
+	// NoSharedFS means no file system is shared with the guest, the root
+	// file systems of the containers being block devices and their other
+	// files being copied to the guest
+	NoSharedFS = "none"
 )
 
+// Block device cache modes, how the host page cache is used for the writes
+// of the guest to a block device.
+const (
+	// BlockCacheNone bypasses the host page cache, the flushes of the
+	// guest reaching the host device.
+	BlockCacheNone = "none"
+
+	// BlockCacheWriteback caches the writes in the host page cache.
+	BlockCacheWriteback = "writeback"
+
+	// BlockCacheWritethrough caches the writes in the host page cache,
+	// each write being flushed to the host device before completing.
+	BlockCacheWritethrough = "writethrough"
+
+	// BlockCacheDirectSync bypasses the host page cache, each write being
+	// flushed to the host device before completing.
+	BlockCacheDirectSync = "directsync"
+)
+
+// CheckBlockCache verifies the block device cache mode is supported, empty
+// leaving the cache mode of the hypervisor configuration.
+func CheckBlockCache(mode string) error {
+	switch mode {
+	case "", BlockCacheNone, BlockCacheWriteback, BlockCacheWritethrough, BlockCacheDirectSync:
+		return nil
+	}
+
+	return fmt.Errorf("Invalid block device cache mode %q", mode)
+}
+
 const (
 	// The OCI spec requires the major-minor number to be provided for a
 	// device. We have chosen the below major numbers to represent
@@ -92,6 +127,12 @@ var SysBusPciDevicesPath = "/sys/bus/pci/devices"
 // SysBusPciSlotsPath is static string of /sys/bus/pci/slots
 var SysBusPciSlotsPath = "/sys/bus/pci/slots"
 
+// SysBusMdevDevicesPath is static string of /sys/bus/mdev/devices
+var SysBusMdevDevicesPath = "/sys/bus/mdev/devices"
+
+// VFIOGroupsPath is static string of /dev/vfio
+var VFIOGroupsPath = "/dev/vfio"
+
 var getSysDevPath = getSysDevPathImpl
 
 // DeviceInfo is an embedded type that contains device data common to all types of devices.
@@ -136,6 +177,10 @@ type DeviceInfo struct {
 	// DriverOptions is specific options for each device driver
 	// for example, for BlockDevice, we can set DriverOptions["blockDriver"]="virtio-blk"
 	DriverOptions map[string]string
+
+	// BlockCache is the cache mode of a block device, empty for the cache
+	// options of the hypervisor configuration.
+	BlockCache string
 }
 
 // BlockDrive represents a block storage drive which may be used in case the storage
@@ -163,6 +208,10 @@ type BlockDrive struct {
 	// SCSI address is in the format SCSI-Id:LUN
 	SCSIAddr string
 
+	// Serial is the serial number of the block device in the guest, if
+	// the hypervisor sets one.
+	Serial string
+
 	// NvdimmID is the nvdimm id inside the VM
 	NvdimmID string
 
@@ -181,6 +230,15 @@ type BlockDrive struct {
 	// Pmem enables persistent memory. Use File as backing file
 	// for a nvdimm device in the guest
 	Pmem bool
+
+	// SCSIPassthrough is set when File is a SCSI disk, or a multipath
+	// device over SCSI disks, the SCSI commands of the guest, such as the
+	// persistent reservations, can be passed through to.
+	SCSIPassthrough bool
+
+	// Cache is the cache mode of the drive, empty for the cache options of
+	// the hypervisor configuration.
+	Cache string
 }
 
 // VFIODeviceType indicates VFIO device type
@@ -223,6 +281,18 @@ type VFIODev struct {
 	// PCI Class Code
 	Class string
 
+	// PCI Vendor ID of the host device, unlike VendorID which overrides
+	// the one the guest sees
+	Vendor string
+
+	// GPUDirectClique is the NVIDIA GPUDirect clique ID of the device in
+	// the guest, set by the hypervisor when peer-to-peer DMA is enabled
+	GPUDirectClique string
+
+	// GuestPCIPath is the PCI path of the device in the guest, as
+	// bridge-addr/device-addr, when it is hotplugged on a PCI bridge
+	GuestPCIPath string
+
 	// Bus of VFIO PCIe device
 	Bus string
 }
@@ -249,6 +319,11 @@ type VhostUserDeviceAttrs struct {
 	CacheSize uint32
 	Cache     string
 
+	// Reconnect is the number of seconds between the attempts of the
+	// hypervisor to connect again to a restarted vhost user fs daemon or
+	// block backend, zero not reconnecting.
+	Reconnect uint32
+
 	// PCIAddr is the PCI address used to identify the slot at which the drive is attached.
 	// It is only meaningful for vhost user block devices
 	PCIAddr string
@@ -71,3 +71,14 @@ func TestGetSysDevPathImpl(t *testing.T) {
 	assert.Contains(path, expectedFormat)
 	assert.Contains(path, "block")
 }
+
+func TestCheckBlockCache(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, mode := range []string{"", BlockCacheNone, BlockCacheWriteback, BlockCacheWritethrough, BlockCacheDirectSync} {
+		assert.NoError(CheckBlockCache(mode), mode)
+	}
+
+	assert.Error(CheckBlockCache("unsafe"))
+	assert.Error(CheckBlockCache("direct"))
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+var mdevUUIDRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// MdevDeviceInfo returns the DeviceInfo of the VFIO group of a mediated
+// device, such as an NVIDIA vGPU, given by the UUID it was created with on
+// the host.
+func MdevDeviceInfo(uuid string) (*DeviceInfo, error) {
+	if !mdevUUIDRegexp.MatchString(uuid) {
+		return nil, fmt.Errorf("Invalid mediated device UUID %q", uuid)
+	}
+
+	groupPath, err := os.Readlink(filepath.Join(SysBusMdevDevicesPath, uuid, "iommu_group"))
+	if err != nil {
+		return nil, fmt.Errorf("Mediated device %s not found: %v", uuid, err)
+	}
+	group := filepath.Base(groupPath)
+
+	// The whole VFIO group is passed to the VM, it must not hold any
+	// other device.
+	devices, err := ioutil.ReadDir(filepath.Join(SysIOMMUPath, group, "devices"))
+	if err != nil {
+		return nil, err
+	}
+	if len(devices) != 1 || devices[0].Name() != uuid {
+		return nil, fmt.Errorf("IOMMU group %s of mediated device %s holds %d devices", group, uuid, len(devices))
+	}
+
+	hostPath := filepath.Join(VFIOGroupsPath, group)
+	stat := syscall.Stat_t{}
+	if err := syscall.Stat(hostPath, &stat); err != nil {
+		return nil, err
+	}
+
+	return &DeviceInfo{
+		HostPath:      hostPath,
+		ContainerPath: hostPath,
+		DevType:       "c",
+		Major:         int64(unix.Major(stat.Rdev)),
+		Minor:         int64(unix.Minor(stat.Rdev)),
+	}, nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMdevDeviceInfo(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "mdev")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	savedMdevPath, savedIOMMUPath, savedVFIOPath := SysBusMdevDevicesPath, SysIOMMUPath, VFIOGroupsPath
+	defer func() {
+		SysBusMdevDevicesPath, SysIOMMUPath, VFIOGroupsPath = savedMdevPath, savedIOMMUPath, savedVFIOPath
+	}()
+	SysBusMdevDevicesPath = filepath.Join(dir, "mdev")
+	SysIOMMUPath = filepath.Join(dir, "iommu_groups")
+	VFIOGroupsPath = filepath.Join(dir, "vfio")
+
+	uuid := "f79944e4-5a3d-11e8-99ce-479cbab002e4"
+	mdevPath := filepath.Join(SysBusMdevDevicesPath, uuid)
+	groupPath := filepath.Join(SysIOMMUPath, "12")
+	assert.NoError(os.MkdirAll(mdevPath, 0755))
+	assert.NoError(os.MkdirAll(filepath.Join(groupPath, "devices"), 0755))
+	assert.NoError(os.MkdirAll(VFIOGroupsPath, 0755))
+	assert.NoError(os.Symlink(groupPath, filepath.Join(mdevPath, "iommu_group")))
+	assert.NoError(os.Symlink(mdevPath, filepath.Join(groupPath, "devices", uuid)))
+	assert.NoError(ioutil.WriteFile(filepath.Join(VFIOGroupsPath, "12"), nil, 0600))
+
+	_, err = MdevDeviceInfo("not-a-uuid")
+	assert.Error(err)
+
+	_, err = MdevDeviceInfo("00000000-0000-0000-0000-000000000000")
+	assert.Error(err)
+
+	info, err := MdevDeviceInfo(uuid)
+	assert.NoError(err)
+	assert.Equal(filepath.Join(VFIOGroupsPath, "12"), info.HostPath)
+	assert.Equal(info.HostPath, info.ContainerPath)
+	assert.Equal("c", info.DevType)
+
+	// The group cannot be passed with another device
+	assert.NoError(os.Symlink(mdevPath, filepath.Join(groupPath, "devices", "0000:00:02.0")))
+	_, err = MdevDeviceInfo(uuid)
+	assert.Error(err)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package config
+
+// NoNUMANode is the NUMA node of the devices the host does not know the
+// NUMA locality of.
+const NoNUMANode = -1
+
+// DeviceLocality is where a device sits on the host.
+type DeviceLocality struct {
+	// HostPath is the host path of the device, as in its DeviceInfo.
+	HostPath string
+
+	// PCIDevices are the host PCI devices backing the device, all the
+	// members of its IOMMU group for a VFIO group.
+	PCIDevices []string
+
+	// IOMMUGroup is the IOMMU group of the device, empty if it has none.
+	IOMMUGroup string
+
+	// NUMANodes are the NUMA nodes the PCI devices are attached to,
+	// without NoNUMANode.
+	NUMANodes []int
+}
+
+// DeviceTopology is the host topology of a set of devices assigned to the
+// same sandbox.
+type DeviceTopology struct {
+	// Devices are the localities of the devices, in the order the
+	// devices were given.
+	Devices []DeviceLocality
+
+	// NUMANodes are all the NUMA nodes the devices are attached to.
+	NUMANodes []int
+
+	// Conflicts describe the IOMMU group conflicts between the devices,
+	// which prevent assigning them together.
+	Conflicts []string
+}
+
+// CrossNUMA returns true if the devices are attached to more than one
+// NUMA node.
+func (t DeviceTopology) CrossNUMA() bool {
+	return len(t.NUMANodes) > 1
+}
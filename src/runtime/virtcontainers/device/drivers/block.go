@@ -7,7 +7,11 @@
 package drivers
 
 import (
+	"io/ioutil"
+	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
@@ -66,6 +70,7 @@ func (device *BlockDevice) Attach(devReceiver api.DeviceReceiver) (err error) {
 		ID:     utils.MakeNameID("drive", device.DeviceInfo.ID, maxDevIDSize),
 		Index:  index,
 		Pmem:   device.DeviceInfo.Pmem,
+		Cache:  device.DeviceInfo.BlockCache,
 	}
 
 	if fs, ok := device.DeviceInfo.DriverOptions["fstype"]; ok {
@@ -83,6 +88,7 @@ func (device *BlockDevice) Attach(devReceiver api.DeviceReceiver) (err error) {
 		}
 
 		drive.SCSIAddr = scsiAddr
+		drive.SCSIPassthrough = isSCSIPassthroughCapable(device.DeviceInfo)
 	} else if customOptions["block-driver"] != "nvdimm" {
 		var globalIdx int
 
@@ -171,10 +177,16 @@ func (device *BlockDevice) Save() persistapi.DeviceState {
 			MmioAddr: drive.MmioAddr,
 			PCIAddr:  drive.PCIAddr,
 			SCSIAddr: drive.SCSIAddr,
+			Serial:   drive.Serial,
 			NvdimmID: drive.NvdimmID,
 			VirtPath: drive.VirtPath,
 			DevNo:    drive.DevNo,
 			Pmem:     drive.Pmem,
+			ShareRW:  drive.ShareRW,
+			ReadOnly: drive.ReadOnly,
+
+			SCSIPassthrough: drive.SCSIPassthrough,
+			Cache:           drive.Cache,
 		}
 	}
 	return ds
@@ -197,12 +209,39 @@ func (device *BlockDevice) Load(ds persistapi.DeviceState) {
 		MmioAddr: bd.MmioAddr,
 		PCIAddr:  bd.PCIAddr,
 		SCSIAddr: bd.SCSIAddr,
+		Serial:   bd.Serial,
 		NvdimmID: bd.NvdimmID,
 		VirtPath: bd.VirtPath,
 		DevNo:    bd.DevNo,
 		Pmem:     bd.Pmem,
+		ShareRW:  bd.ShareRW,
+		ReadOnly: bd.ReadOnly,
+
+		SCSIPassthrough: bd.SCSIPassthrough,
+		Cache:           bd.Cache,
 	}
 }
 
 // It should implement GetAttachCount() and DeviceID() as api.Device implementation
 // here it shares function from *GenericDevice so we don't need duplicate codes
+
+// isSCSIPassthroughCapable returns true if the SCSI commands sent to the
+// block device reach a SCSI disk: the device is a whole SCSI disk, or a
+// dm-multipath device whose paths are SCSI disks, which forwards the
+// commands, the persistent reservations included, to its active path.
+func isSCSIPassthroughCapable(devInfo *config.DeviceInfo) bool {
+	sysDevPath := filepath.Join(config.SysDevPrefix, "block",
+		strconv.FormatInt(devInfo.Major, 10)+":"+strconv.FormatInt(devInfo.Minor, 10))
+
+	if _, err := os.Stat(filepath.Join(sysDevPath, "device", "scsi_disk")); err == nil {
+		return true
+	}
+
+	uuid, err := ioutil.ReadFile(filepath.Join(sysDevPath, "dm", "uuid"))
+	if err != nil || !strings.HasPrefix(string(uuid), "mpath-") {
+		return false
+	}
+
+	deviceLogger().WithField("device", devInfo.HostPath).Info("Multipath device detected")
+	return true
+}
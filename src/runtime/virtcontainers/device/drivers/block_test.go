@@ -0,0 +1,70 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package drivers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSCSIPassthroughCapable(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpDir)
+
+	savedSysDevPrefix := config.SysDevPrefix
+	config.SysDevPrefix = tmpDir
+	defer func() {
+		config.SysDevPrefix = savedSysDevPrefix
+	}()
+
+	// SCSI disk
+	assert.NoError(os.MkdirAll(filepath.Join(tmpDir, "block", "8:0", "device", "scsi_disk"), 0750))
+	// SCSI disk partition
+	assert.NoError(os.MkdirAll(filepath.Join(tmpDir, "block", "8:1"), 0750))
+	// Multipath device
+	assert.NoError(os.MkdirAll(filepath.Join(tmpDir, "block", "253:0", "dm"), 0750))
+	assert.NoError(ioutil.WriteFile(filepath.Join(tmpDir, "block", "253:0", "dm", "uuid"), []byte("mpath-3600a0b80001234\n"), 0640))
+	// LVM volume
+	assert.NoError(os.MkdirAll(filepath.Join(tmpDir, "block", "253:1", "dm"), 0750))
+	assert.NoError(ioutil.WriteFile(filepath.Join(tmpDir, "block", "253:1", "dm", "uuid"), []byte("LVM-abcdef\n"), 0640))
+
+	assert.True(isSCSIPassthroughCapable(&config.DeviceInfo{Major: 8, Minor: 0}))
+	assert.False(isSCSIPassthroughCapable(&config.DeviceInfo{Major: 8, Minor: 1}))
+	assert.True(isSCSIPassthroughCapable(&config.DeviceInfo{Major: 253, Minor: 0}))
+	assert.False(isSCSIPassthroughCapable(&config.DeviceInfo{Major: 253, Minor: 1}))
+	assert.False(isSCSIPassthroughCapable(&config.DeviceInfo{Major: 7, Minor: 0}))
+}
+
+func TestBlockDeviceSaveLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	dev := NewBlockDevice(&config.DeviceInfo{ID: "block", HostPath: "/dev/dm-0", BlockCache: config.BlockCacheNone})
+	dev.BlockDrive = &config.BlockDrive{
+		File:            "/dev/dm-0",
+		ID:              "drive-block",
+		SCSIAddr:        "0:1",
+		SCSIPassthrough: true,
+		Cache:           config.BlockCacheNone,
+	}
+
+	ds := dev.Save()
+	assert.Equal(&persistapi.BlockDrive{File: "/dev/dm-0", ID: "drive-block", SCSIAddr: "0:1", SCSIPassthrough: true, Cache: "none"}, ds.BlockDrive)
+	assert.Equal("none", ds.BlockCache)
+
+	loaded := &BlockDevice{}
+	loaded.Load(ds)
+	assert.Equal(dev.BlockDrive, loaded.BlockDrive)
+	assert.Equal(config.BlockCacheNone, loaded.DeviceInfo.BlockCache)
+}
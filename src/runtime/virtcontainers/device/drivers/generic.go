@@ -8,6 +8,7 @@ package drivers
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
@@ -137,10 +138,17 @@ func (device *GenericDevice) Save() persistapi.DeviceState {
 	info := device.DeviceInfo
 	if info != nil {
 		dss.DevType = info.DevType
+		dss.HostPath = info.HostPath
+		dss.ContainerPath = info.ContainerPath
 		dss.Major = info.Major
 		dss.Minor = info.Minor
+		dss.FileMode = uint32(info.FileMode)
+		dss.UID = info.UID
+		dss.GID = info.GID
+		dss.Pmem = info.Pmem
 		dss.DriverOptions = info.DriverOptions
 		dss.ColdPlug = info.ColdPlug
+		dss.BlockCache = info.BlockCache
 	}
 	return dss
 }
@@ -152,10 +160,18 @@ func (device *GenericDevice) Load(ds persistapi.DeviceState) {
 	device.AttachCount = ds.AttachCount
 
 	device.DeviceInfo = &config.DeviceInfo{
+		ID:            ds.ID,
 		DevType:       ds.DevType,
+		HostPath:      ds.HostPath,
+		ContainerPath: ds.ContainerPath,
 		Major:         ds.Major,
 		Minor:         ds.Minor,
+		FileMode:      os.FileMode(ds.FileMode),
+		UID:           ds.UID,
+		GID:           ds.GID,
+		Pmem:          ds.Pmem,
 		DriverOptions: ds.DriverOptions,
 		ColdPlug:      ds.ColdPlug,
+		BlockCache:    ds.BlockCache,
 	}
 }
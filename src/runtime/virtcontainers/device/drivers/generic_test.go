@@ -55,3 +55,29 @@ func TestGetHostPath(t *testing.T) {
 	}
 	assert.Equal(expectedHostPath, dev.GetHostPath())
 }
+
+func TestGenericDeviceSaveLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	dev := &GenericDevice{
+		ID:          "foo",
+		RefCount:    2,
+		AttachCount: 1,
+		DeviceInfo: &config.DeviceInfo{
+			ID:            "foo",
+			HostPath:      "/dev/sda",
+			ContainerPath: "/dev/xvda",
+			DevType:       "b",
+			Major:         8,
+			Minor:         0,
+			FileMode:      0660,
+			UID:           1,
+			GID:           2,
+			DriverOptions: map[string]string{"block-driver": "virtio-blk"},
+		},
+	}
+
+	loaded := &GenericDevice{}
+	loaded.Load(dev.Save())
+	assert.Equal(dev, loaded)
+}
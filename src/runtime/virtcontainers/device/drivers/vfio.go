@@ -42,6 +42,10 @@ var (
 type VFIODevice struct {
 	*GenericDevice
 	VfioDevs []*config.VFIODev
+
+	// HostDrivers are the host drivers of the devices of the IOMMU group
+	// bound to vfio-pci when the device was attached, by address.
+	HostDrivers map[string]string
 }
 
 // NewVFIODevice create a new VFIO device
@@ -72,6 +76,16 @@ func (device *VFIODevice) Attach(devReceiver api.DeviceReceiver) (retErr error)
 	}()
 
 	vfioGroup := filepath.Base(device.DeviceInfo.HostPath)
+	err = device.prepareIOMMUGroup(vfioGroup)
+	defer func() {
+		if retErr != nil {
+			device.RestoreHostDrivers()
+		}
+	}()
+	if err != nil {
+		return err
+	}
+
 	iommuDevicesPath := filepath.Join(config.SysIOMMUPath, vfioGroup, "devices")
 
 	deviceFiles, err := ioutil.ReadDir(iommuDevicesPath)
@@ -92,7 +106,17 @@ func (device *VFIODevice) Attach(devReceiver api.DeviceReceiver) (retErr error)
 			BDF:      deviceBDF,
 			SysfsDev: deviceSysfsDev,
 			IsPCIe:   isPCIeDevice(deviceBDF),
-			Class:    getPCIDeviceProperty(deviceBDF, PCISysFsDevicesClass),
+		}
+		switch vfioDeviceType {
+		case config.VFIODeviceNormalType:
+			vfio.Class = getPCIDeviceProperty(deviceBDF, PCISysFsDevicesClass)
+			vfio.Vendor = getPCIDeviceProperty(deviceBDF, PCISysFsDevicesVendor)
+		case config.VFIODeviceMediatedType:
+			// A mediated device has the class and vendor of its parent.
+			if parent := mdevParentBDF(deviceSysfsDev); parent != "" {
+				vfio.Class = getPCIDeviceProperty(parent, PCISysFsDevicesClass)
+				vfio.Vendor = getPCIDeviceProperty(parent, PCISysFsDevicesVendor)
+			}
 		}
 		device.VfioDevs = append(device.VfioDevs, vfio)
 		if vfio.IsPCIe {
@@ -156,6 +180,12 @@ func (device *VFIODevice) Detach(devReceiver api.DeviceReceiver) (retErr error)
 		return err
 	}
 
+	// The device is detached even if the host is left with some devices
+	// bound to vfio-pci.
+	if err := device.RestoreHostDrivers(); err != nil {
+		deviceLogger().WithError(err).Warn("Failed to restore host drivers")
+	}
+
 	deviceLogger().WithFields(logrus.Fields{
 		"device-group": device.DeviceInfo.HostPath,
 		"device-type":  "vfio-passthrough",
@@ -186,9 +216,16 @@ func (device *VFIODevice) Save() persistapi.DeviceState {
 				Type:     uint32(dev.Type),
 				BDF:      dev.BDF,
 				SysfsDev: dev.SysfsDev,
+				IsPCIe:   dev.IsPCIe,
+				Class:    dev.Class,
+				Vendor:   dev.Vendor,
+				Bus:      dev.Bus,
+
+				GuestPCIPath: dev.GuestPCIPath,
 			})
 		}
 	}
+	ds.VFIOHostDrivers = device.HostDrivers
 	return ds
 }
 
@@ -196,6 +233,7 @@ func (device *VFIODevice) Save() persistapi.DeviceState {
 func (device *VFIODevice) Load(ds persistapi.DeviceState) {
 	device.GenericDevice = &GenericDevice{}
 	device.GenericDevice.Load(ds)
+	device.HostDrivers = ds.VFIOHostDrivers
 
 	for _, dev := range ds.VFIODevs {
 		device.VfioDevs = append(device.VfioDevs, &config.VFIODev{
@@ -203,7 +241,19 @@ func (device *VFIODevice) Load(ds persistapi.DeviceState) {
 			Type:     config.VFIODeviceType(dev.Type),
 			BDF:      dev.BDF,
 			SysfsDev: dev.SysfsDev,
+			IsPCIe:   dev.IsPCIe,
+			Class:    dev.Class,
+			Vendor:   dev.Vendor,
+			Bus:      dev.Bus,
+
+			GuestPCIPath: dev.GuestPCIPath,
 		})
+
+		// Keep the root ports already in use so that devices hotplugged
+		// after a restart do not get a conflicting bus.
+		if dev.IsPCIe {
+			AllPCIeDevs[dev.BDF] = true
+		}
 	}
 }
 
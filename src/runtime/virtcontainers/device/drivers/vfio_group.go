@@ -0,0 +1,237 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package drivers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+)
+
+const (
+	// VFIOAutoBindOption is the VFIO device driver option listing, comma
+	// separated, the host drivers whose devices are bound to vfio-pci
+	// when they share the IOMMU group of the device.
+	VFIOAutoBindOption = "vfio-auto-bind"
+
+	vfioPCIDriver        = "vfio-pci"
+	pciBridgeClassPrefix = "0x0604"
+)
+
+// vfioViableDrivers are the drivers, or none, the devices of an IOMMU group
+// can be bound to for the kernel to let the group be used through VFIO.
+var vfioViableDrivers = map[string]bool{
+	"":            true,
+	vfioPCIDriver: true,
+	"pci-stub":    true,
+}
+
+// IOMMUGroupError is returned when a VFIO group cannot be assigned because
+// some devices of its IOMMU group are still used by the host.
+type IOMMUGroupError struct {
+	Group string
+
+	// HostDevices are the addresses of the devices bound to a host
+	// driver, mapped to the driver.
+	HostDevices map[string]string
+}
+
+func (e *IOMMUGroupError) Error() string {
+	var devices []string
+	for bdf, driver := range e.HostDevices {
+		devices = append(devices, fmt.Sprintf("%s (bound to %s)", bdf, driver))
+	}
+	sort.Strings(devices)
+
+	return fmt.Sprintf("IOMMU group %s cannot be assigned while the host uses %s: "+
+		"the devices of an IOMMU group are assigned together, bind them to vfio-pci or unbind them from their driver",
+		e.Group, strings.Join(devices, ", "))
+}
+
+// IOMMUGroupHostDevices returns the PCI devices of an IOMMU group bound to
+// a host driver, mapped to the driver. The group cannot be assigned to a VM
+// until they are bound to vfio-pci or unbound.
+func IOMMUGroupHostDevices(group string) (map[string]string, error) {
+	iommuDevicesPath := filepath.Join(config.SysIOMMUPath, group, "devices")
+	deviceFiles, err := ioutil.ReadDir(iommuDevicesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	hostDevices := make(map[string]string)
+	for _, deviceFile := range deviceFiles {
+		bdf := deviceFile.Name()
+		if GetVFIODeviceType(bdf) != config.VFIODeviceNormalType {
+			// Mediated devices belong to their parent driver
+			continue
+		}
+
+		devPath := filepath.Join(iommuDevicesPath, bdf)
+		driver := pciDriver(devPath)
+		if vfioViableDrivers[driver] {
+			continue
+		}
+
+		// Bridges do not prevent the group from being used
+		if class, err := readPCIProperty(filepath.Join(devPath, string(PCISysFsDevicesClass))); err == nil &&
+			strings.HasPrefix(class, pciBridgeClassPrefix) {
+			continue
+		}
+
+		hostDevices[bdf] = driver
+	}
+
+	return hostDevices, nil
+}
+
+// pciDriver returns the driver a PCI device is bound to, empty if none.
+func pciDriver(devPath string) string {
+	driver, err := os.Readlink(filepath.Join(devPath, "driver"))
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(driver)
+}
+
+// prepareIOMMUGroup checks that all the devices of the IOMMU group can be
+// assigned along with the device, first binding to vfio-pci those bound to
+// a host driver listed by VFIOAutoBindOption. The host drivers of the
+// devices bound to vfio-pci are recorded for restoreHostDrivers.
+func (device *VFIODevice) prepareIOMMUGroup(group string) error {
+	hostDevices, err := IOMMUGroupHostDevices(group)
+	if err != nil {
+		return err
+	}
+
+	autoBind := make(map[string]bool)
+	if device.DeviceInfo.DriverOptions != nil {
+		for _, driver := range strings.Split(device.DeviceInfo.DriverOptions[VFIOAutoBindOption], ",") {
+			if driver != "" {
+				autoBind[driver] = true
+			}
+		}
+	}
+
+	for bdf, driver := range hostDevices {
+		if !autoBind[driver] {
+			continue
+		}
+
+		logger := deviceLogger().WithFields(logrus.Fields{
+			"device-group": group,
+			"device-bdf":   bdf,
+			"host-driver":  driver,
+		})
+
+		devPath := filepath.Join(config.SysIOMMUPath, group, "devices", bdf)
+		if err := bindToVFIO(devPath, bdf); err != nil {
+			logger.WithError(err).Warn("Failed to bind IOMMU group sibling to vfio-pci")
+			if err := restoreHostDriver(devPath, bdf, driver); err != nil {
+				logger.WithError(err).Warn("Failed to restore IOMMU group sibling host driver")
+			}
+			continue
+		}
+
+		logger.Info("IOMMU group sibling bound to vfio-pci")
+		delete(hostDevices, bdf)
+
+		if device.HostDrivers == nil {
+			device.HostDrivers = make(map[string]string)
+		}
+		device.HostDrivers[bdf] = driver
+	}
+
+	if len(hostDevices) > 0 {
+		return &IOMMUGroupError{
+			Group:       group,
+			HostDevices: hostDevices,
+		}
+	}
+
+	return nil
+}
+
+// bindToVFIO binds a PCI device to vfio-pci through its driver override,
+// which unlike the vfio-pci new_id does not catch the other devices with
+// the same vendor and device IDs.
+func bindToVFIO(devPath, bdf string) error {
+	if err := utils.WriteToFile(filepath.Join(devPath, "driver_override"), []byte(vfioPCIDriver)); err != nil {
+		return err
+	}
+
+	if err := utils.WriteToFile(filepath.Join(devPath, "driver", "unbind"), []byte(bdf)); err != nil {
+		return err
+	}
+
+	driversProbePath := filepath.Join(filepath.Dir(config.SysBusPciDevicesPath), "drivers_probe")
+	if err := utils.WriteToFile(driversProbePath, []byte(bdf)); err != nil {
+		return err
+	}
+
+	if driver := pciDriver(devPath); driver != vfioPCIDriver {
+		return fmt.Errorf("%s is bound to %q after probing", bdf, driver)
+	}
+
+	return nil
+}
+
+// restoreHostDriver binds back to its host driver a PCI device bindToVFIO
+// bound to vfio-pci.
+func restoreHostDriver(devPath, bdf, driver string) error {
+	// A new line clears the driver override.
+	if err := utils.WriteToFile(filepath.Join(devPath, "driver_override"), []byte("\n")); err != nil {
+		return err
+	}
+
+	if pciDriver(devPath) == vfioPCIDriver {
+		if err := utils.WriteToFile(filepath.Join(devPath, "driver", "unbind"), []byte(bdf)); err != nil {
+			return err
+		}
+	}
+
+	// Unlike drivers_probe, binding fails when the driver does not take
+	// the device.
+	driverBindPath := filepath.Join(filepath.Dir(config.SysBusPciDevicesPath), "drivers", driver, "bind")
+	return utils.WriteToFile(driverBindPath, []byte(bdf))
+}
+
+// RestoreHostDrivers binds the devices of the IOMMU group bound to vfio-pci
+// when the device was attached back to their host drivers. It must only be
+// called once the group is released by the hypervisor.
+func (device *VFIODevice) RestoreHostDrivers() error {
+	group := filepath.Base(device.DeviceInfo.HostPath)
+
+	var failed []string
+	for bdf, driver := range device.HostDrivers {
+		devPath := filepath.Join(config.SysIOMMUPath, group, "devices", bdf)
+		if err := restoreHostDriver(devPath, bdf, driver); err != nil {
+			deviceLogger().WithError(err).WithFields(logrus.Fields{
+				"device-group": group,
+				"device-bdf":   bdf,
+				"host-driver":  driver,
+			}).Warn("Failed to restore IOMMU group sibling host driver")
+			failed = append(failed, bdf)
+			continue
+		}
+
+		delete(device.HostDrivers, bdf)
+	}
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return fmt.Errorf("Could not restore the host drivers of %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
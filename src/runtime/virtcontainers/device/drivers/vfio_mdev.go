@@ -0,0 +1,35 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package drivers
+
+import (
+	"path/filepath"
+	"regexp"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+)
+
+var pciBDFRegexp = regexp.MustCompile(`^[0-9a-fA-F]{4}:[0-9a-fA-F]{2}:[0-9a-fA-F]{2}\.[0-7]$`)
+
+// NVIDIAVGPUModules are the guest kernel modules driving the NVIDIA vGPUs.
+var NVIDIAVGPUModules = []string{"nvidia"}
+
+// mdevParentBDF returns the BDF of the PCI device a mediated device was
+// created on, from the sysfs path of the mediated device, such as
+// /sys/devices/pci0000:00/0000:00:02.0/f79944e4-5a3d-11e8-99ce-479cbab002e4.
+func mdevParentBDF(sysfsDev string) string {
+	parent := filepath.Base(filepath.Dir(sysfsDev))
+	if !pciBDFRegexp.MatchString(parent) {
+		return ""
+	}
+	return parent
+}
+
+// IsNVIDIAVGPU tells if a VFIO device is an NVIDIA vGPU, a mediated device
+// of an NVIDIA GPU, which the guest drives with NVIDIAVGPUModules.
+func IsNVIDIAVGPU(dev *config.VFIODev) bool {
+	return dev.Type == config.VFIODeviceMediatedType && IsNVIDIAGPU(dev)
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package drivers
+
+import (
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMdevParentBDF(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("0000:3b:00.0", mdevParentBDF("/sys/devices/pci0000:3a/0000:3a:00.0/0000:3b:00.0/f79944e4-5a3d-11e8-99ce-479cbab002e4"))
+	assert.Equal("", mdevParentBDF("/sys/devices/virtual/mdev/f79944e4-5a3d-11e8-99ce-479cbab002e4"))
+	assert.Equal("", mdevParentBDF(""))
+}
+
+func TestIsNVIDIAVGPU(t *testing.T) {
+	assert := assert.New(t)
+
+	// A vGPU of a Tesla T4
+	assert.True(IsNVIDIAVGPU(&config.VFIODev{Type: config.VFIODeviceMediatedType, Vendor: "0x10de", Class: "0x030200"}))
+
+	// The GPU itself, and a mediated device of an Intel GPU
+	assert.False(IsNVIDIAVGPU(&config.VFIODev{Type: config.VFIODeviceNormalType, Vendor: "0x10de", Class: "0x030200"}))
+	assert.False(IsNVIDIAVGPU(&config.VFIODev{Type: config.VFIODeviceMediatedType, Vendor: "0x8086", Class: "0x030000"}))
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package drivers
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+)
+
+// The PCI Express extended capabilities follow the PCI compatible
+// configuration space, which is all an unprivileged user can read.
+const (
+	pciExtCapOffset  = 0x100
+	pciConfigSpaceSz = 0x1000
+	pciExtCapIDACS   = 0x000d
+
+	// pciACSCtrlOffset is the offset of the ACS control register in the
+	// ACS capability.
+	pciACSCtrlOffset = 6
+
+	// pciACSCtrlRedirect is the P2P request and completion redirect bits,
+	// which send the traffic between the devices below a port up to the
+	// root complex.
+	pciACSCtrlRedirect = 0x0004 | 0x0008
+
+	// pciGPUClassPrefix is the PCI class of the display controllers.
+	pciGPUClassPrefix = "0x03"
+
+	// NVIDIAVendorID is the PCI vendor ID of NVIDIA.
+	NVIDIAVendorID = "0x10de"
+)
+
+// IsNVIDIAGPU tells if a VFIO device is an NVIDIA GPU, for which the
+// peer-to-peer DMA is enabled in the guest with the GPUDirect clique of the
+// device.
+func IsNVIDIAGPU(dev *config.VFIODev) bool {
+	return dev.Vendor == NVIDIAVendorID && strings.HasPrefix(dev.Class, pciGPUClassPrefix)
+}
+
+// pciDevicePath returns the ports, from the host bridge, a PCI device is
+// below, and the device itself, as sysfs lists them, such as
+// ["pci0000:00", "0000:00:01.0", "0000:01:00.0"].
+func pciDevicePath(bdf string) ([]string, error) {
+	if len(strings.Split(bdf, ":")) == 2 {
+		bdf = PCIDomain + ":" + bdf
+	}
+
+	path, err := filepath.EvalSymlinks(filepath.Join(config.SysBusPciDevicesPath, bdf))
+	if err != nil {
+		return nil, err
+	}
+
+	elems := strings.Split(path, string(filepath.Separator))
+	for i, e := range elems {
+		if strings.HasPrefix(e, "pci") {
+			return elems[i:], nil
+		}
+	}
+
+	return nil, fmt.Errorf("Could not find the host bridge of PCI device %s in %s", bdf, path)
+}
+
+// pciACSRedirects tells if a PCI port redirects the peer-to-peer traffic
+// of the devices below it to the root complex with its ACS capability.
+func pciACSRedirects(bdf string) (bool, error) {
+	cfg, err := ioutil.ReadFile(filepath.Join(config.SysBusPciDevicesPath, bdf, "config"))
+	if err != nil {
+		return false, err
+	}
+	if len(cfg) < pciConfigSpaceSz {
+		return false, fmt.Errorf("Could not read the extended configuration space of PCI device %s", bdf)
+	}
+
+	for off, n := pciExtCapOffset, 0; off >= pciExtCapOffset && off+8 <= len(cfg) && n < pciConfigSpaceSz/8; n++ {
+		header := binary.LittleEndian.Uint32(cfg[off:])
+		if header == 0 || header == 0xffffffff {
+			break
+		}
+		if header&0xffff == pciExtCapIDACS {
+			ctrl := binary.LittleEndian.Uint16(cfg[off+pciACSCtrlOffset:])
+			return ctrl&pciACSCtrlRedirect != 0, nil
+		}
+		off = int(header>>20) & 0xffc
+	}
+
+	return false, nil
+}
+
+// CheckPeerToPeer returns an error if the host topology does not let the
+// VFIO devices DMA to each other: they must be PCIe devices under the same
+// host bridge. It warns when their traffic goes through the root complex,
+// because they are not below the same switch or the ports in between
+// redirect it with ACS, since not all root complexes forward it.
+func CheckPeerToPeer(devs []*config.VFIODev) error {
+	var paths [][]string
+
+	for _, dev := range devs {
+		if dev.Type != config.VFIODeviceNormalType {
+			return fmt.Errorf("Peer-to-peer DMA is not supported with the mediated device %s", dev.SysfsDev)
+		}
+		if !dev.IsPCIe {
+			return fmt.Errorf("Peer-to-peer DMA is not supported with the PCI device %s, expected a PCIe device", dev.BDF)
+		}
+
+		path, err := pciDevicePath(dev.BDF)
+		if err != nil {
+			return err
+		}
+		if len(paths) > 0 && paths[0][0] != path[0] {
+			return fmt.Errorf("Peer-to-peer DMA between PCI devices %s and %s is not supported, they are under different host bridges", devs[0].BDF, dev.BDF)
+		}
+		paths = append(paths, path)
+	}
+
+	for i := range paths {
+		for j := i + 1; j < len(paths); j++ {
+			checkPeerToPeerRoute(devs[i].BDF, devs[j].BDF, paths[i], paths[j])
+		}
+	}
+
+	return nil
+}
+
+func checkPeerToPeerRoute(bdf1, bdf2 string, path1, path2 []string) {
+	logger := deviceLogger().WithField("devices", bdf1+","+bdf2)
+
+	common := 0
+	for common < len(path1)-1 && common < len(path2)-1 && path1[common] == path2[common] {
+		common++
+	}
+
+	// Only the host bridge is shared
+	if common == 1 {
+		logger.Warn("Peer-to-peer DMA goes through the root complex, the devices are not below the same switch")
+		return
+	}
+
+	// The ports below the common one, up to the devices
+	ports := append(append([]string{}, path1[common:len(path1)-1]...), path2[common:len(path2)-1]...)
+	for _, port := range ports {
+		redirects, err := pciACSRedirects(port)
+		if err != nil {
+			logger.WithError(err).Warn("Could not check the ACS of the PCI ports between the devices")
+			return
+		}
+		if redirects {
+			logger.WithField("port", port).Warn("Peer-to-peer DMA goes through the root complex, ACS redirects it")
+			return
+		}
+	}
+}
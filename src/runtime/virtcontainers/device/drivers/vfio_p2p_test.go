@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package drivers
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePCIDevice creates a device in a fake sysfs, below the given path of
+// ports, with an ACS capability after another extended capability if acs
+// is not nil.
+func fakePCIDevice(t *testing.T, root string, path []string, acs *uint16) {
+	assert := assert.New(t)
+
+	devPath := filepath.Join(append([]string{root, "devices"}, path...)...)
+	assert.NoError(os.MkdirAll(devPath, 0750))
+
+	cfg := make([]byte, pciConfigSpaceSz)
+	if acs != nil {
+		// An AER capability, pointing to the ACS one
+		binary.LittleEndian.PutUint32(cfg[0x100:], 0x0001|0x1<<16|0x140<<20)
+		binary.LittleEndian.PutUint32(cfg[0x140:], pciExtCapIDACS|0x1<<16)
+		binary.LittleEndian.PutUint16(cfg[0x140+pciACSCtrlOffset:], *acs)
+	}
+	assert.NoError(ioutil.WriteFile(filepath.Join(devPath, "config"), cfg, 0640))
+
+	link := filepath.Join(root, "bus", "devices", path[len(path)-1])
+	assert.NoError(os.MkdirAll(filepath.Dir(link), 0750))
+	assert.NoError(os.Symlink(devPath, link))
+}
+
+func TestCheckPeerToPeer(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpDir)
+
+	savedSysBusPciDevicesPath := config.SysBusPciDevicesPath
+	config.SysBusPciDevicesPath = filepath.Join(tmpDir, "bus", "devices")
+	defer func() {
+		config.SysBusPciDevicesPath = savedSysBusPciDevicesPath
+	}()
+
+	redirect := uint16(pciACSCtrlRedirect)
+	noRedirect := uint16(0x0001)
+
+	// A GPU and a NIC below a switch, and a NIC under another host bridge
+	fakePCIDevice(t, tmpDir, []string{"pci0000:00", "0000:00:01.0"}, nil)
+	fakePCIDevice(t, tmpDir, []string{"pci0000:00", "0000:00:01.0", "0000:01:00.0"}, nil)
+	fakePCIDevice(t, tmpDir, []string{"pci0000:00", "0000:00:01.0", "0000:01:00.0", "0000:02:08.0"}, &noRedirect)
+	fakePCIDevice(t, tmpDir, []string{"pci0000:00", "0000:00:01.0", "0000:01:00.0", "0000:02:10.0"}, &redirect)
+	fakePCIDevice(t, tmpDir, []string{"pci0000:00", "0000:00:01.0", "0000:01:00.0", "0000:02:08.0", "0000:03:00.0"}, nil)
+	fakePCIDevice(t, tmpDir, []string{"pci0000:00", "0000:00:01.0", "0000:01:00.0", "0000:02:10.0", "0000:04:00.0"}, nil)
+	fakePCIDevice(t, tmpDir, []string{"pci0000:80", "0000:80:01.0"}, nil)
+	fakePCIDevice(t, tmpDir, []string{"pci0000:80", "0000:80:01.0", "0000:81:00.0"}, nil)
+
+	path, err := pciDevicePath("03:00.0")
+	assert.NoError(err)
+	assert.Equal([]string{"pci0000:00", "0000:00:01.0", "0000:01:00.0", "0000:02:08.0", "0000:03:00.0"}, path)
+
+	redirects, err := pciACSRedirects("0000:02:08.0")
+	assert.NoError(err)
+	assert.False(redirects)
+	redirects, err = pciACSRedirects("0000:02:10.0")
+	assert.NoError(err)
+	assert.True(redirects)
+	redirects, err = pciACSRedirects("0000:01:00.0")
+	assert.NoError(err)
+	assert.False(redirects)
+
+	gpu := &config.VFIODev{Type: config.VFIODeviceNormalType, BDF: "03:00.0", IsPCIe: true}
+	nic := &config.VFIODev{Type: config.VFIODeviceNormalType, BDF: "04:00.0", IsPCIe: true}
+	remoteNIC := &config.VFIODev{Type: config.VFIODeviceNormalType, BDF: "81:00.0", IsPCIe: true}
+
+	assert.NoError(CheckPeerToPeer([]*config.VFIODev{gpu, nic}))
+	assert.Error(CheckPeerToPeer([]*config.VFIODev{gpu, remoteNIC}))
+
+	pci := &config.VFIODev{Type: config.VFIODeviceNormalType, BDF: "04:00.0"}
+	assert.Error(CheckPeerToPeer([]*config.VFIODev{gpu, pci}))
+
+	mdev := &config.VFIODev{Type: config.VFIODeviceMediatedType, SysfsDev: "/sys/devices/pci0000:00/0000:00:02.0/f79944e4-5a3d-11e8-99ce-479cbab002e4"}
+	assert.Error(CheckPeerToPeer([]*config.VFIODev{gpu, mdev}))
+}
+
+func TestIsNVIDIAGPU(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(IsNVIDIAGPU(&config.VFIODev{Vendor: "0x10de", Class: "0x030200"}))
+	assert.False(IsNVIDIAGPU(&config.VFIODev{Vendor: "0x10de", Class: "0x040300"}))
+	assert.False(IsNVIDIAGPU(&config.VFIODev{Vendor: "0x15b3", Class: "0x020700"}))
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package drivers
+
+import (
+	"strings"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+)
+
+const (
+	// MellanoxVendorID is the PCI vendor ID of Mellanox.
+	MellanoxVendorID = "0x15b3"
+
+	// The PCI classes of the Ethernet controllers, driven in RoCE mode,
+	// and of the InfiniBand controllers.
+	pciEthernetClassPrefix   = "0x0200"
+	pciInfiniBandClassPrefix = "0x0207"
+)
+
+// MellanoxRDMAModules are the guest kernel modules driving the Mellanox
+// RDMA devices and giving access to them through /dev/infiniband. The
+// RDMA core, ib_core, is loaded as a dependency.
+var MellanoxRDMAModules = []string{"mlx5_core", "mlx5_ib", "ib_uverbs", "rdma_ucm"}
+
+// IsRDMADevice tells if a VFIO device is a Mellanox RDMA device, which
+// the guest drives with MellanoxRDMAModules.
+func IsRDMADevice(dev *config.VFIODev) bool {
+	if dev.Type != config.VFIODeviceNormalType || dev.Vendor != MellanoxVendorID {
+		return false
+	}
+
+	return strings.HasPrefix(dev.Class, pciEthernetClassPrefix) || strings.HasPrefix(dev.Class, pciInfiniBandClassPrefix)
+}
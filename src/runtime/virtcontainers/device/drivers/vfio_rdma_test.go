@@ -0,0 +1,26 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package drivers
+
+import (
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRDMADevice(t *testing.T) {
+	assert := assert.New(t)
+
+	// ConnectX-5, in Ethernet and InfiniBand modes
+	assert.True(IsRDMADevice(&config.VFIODev{Type: config.VFIODeviceNormalType, Vendor: "0x15b3", Class: "0x020000"}))
+	assert.True(IsRDMADevice(&config.VFIODev{Type: config.VFIODeviceNormalType, Vendor: "0x15b3", Class: "0x020700"}))
+
+	// A Mellanox switch, an Intel NIC and a mediated device
+	assert.False(IsRDMADevice(&config.VFIODev{Type: config.VFIODeviceNormalType, Vendor: "0x15b3", Class: "0x058000"}))
+	assert.False(IsRDMADevice(&config.VFIODev{Type: config.VFIODeviceNormalType, Vendor: "0x8086", Class: "0x020000"}))
+	assert.False(IsRDMADevice(&config.VFIODev{Type: config.VFIODeviceMediatedType, Class: "0x020000"}))
+}
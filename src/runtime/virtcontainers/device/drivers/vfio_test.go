@@ -7,6 +7,10 @@
 package drivers
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
@@ -47,3 +51,196 @@ func TestGetVFIODetails(t *testing.T) {
 		}
 	}
 }
+
+func TestVFIODeviceSaveLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	AllPCIeDevs = map[string]bool{}
+	defer func() { AllPCIeDevs = map[string]bool{} }()
+
+	dev := NewVFIODevice(&config.DeviceInfo{ID: "vfio", HostPath: "/dev/vfio/1"})
+	dev.AttachCount = 1
+	dev.VfioDevs = []*config.VFIODev{
+		{
+			ID:     "vfio0",
+			Type:   config.VFIODeviceNormalType,
+			BDF:    "02:10.0",
+			IsPCIe: true,
+			Class:  "0x030000",
+			Bus:    "rp0",
+		},
+	}
+
+	loaded := &VFIODevice{}
+	loaded.Load(dev.Save())
+	assert.Equal(dev.VfioDevs, loaded.VfioDevs)
+	assert.Equal("/dev/vfio/1", loaded.GetHostPath())
+	assert.Equal(uint(1), loaded.GetAttachCount())
+
+	// The root port of the restored device is still taken.
+	assert.Equal(map[string]bool{"02:10.0": true}, AllPCIeDevs)
+}
+
+// fakeIOMMUGroup creates IOMMU group 1 with a GPU bound to vfio-pci, its
+// audio function bound to snd_hda_intel, an unbound device, a bridge and a
+// mediated device.
+func fakeIOMMUGroup(t *testing.T, root string) {
+	assert := assert.New(t)
+
+	devices := []struct {
+		bdf    string
+		driver string
+		class  string
+	}{
+		{"0000:01:00.0", "vfio-pci", "0x030000"},
+		{"0000:01:00.1", "snd_hda_intel", "0x040300"},
+		{"0000:01:00.2", "", "0x0c0330"},
+		{"0000:00:01.0", "pcieport", "0x060400"},
+		{"f79944e4-5a3d-11e8-99ce-479cbab002e4", "", ""},
+	}
+
+	assert.NoError(os.MkdirAll(filepath.Join(root, "bus", "devices"), 0750))
+	assert.NoError(ioutil.WriteFile(filepath.Join(root, "bus", "drivers_probe"), nil, 0640))
+
+	for _, d := range devices {
+		devPath := filepath.Join(root, "iommu_groups", "1", "devices", d.bdf)
+		assert.NoError(os.MkdirAll(devPath, 0750))
+		if d.class != "" {
+			assert.NoError(ioutil.WriteFile(filepath.Join(devPath, "class"), []byte(d.class+"\n"), 0640))
+		}
+		if d.driver != "" {
+			driverPath := filepath.Join(root, "bus", "drivers", d.driver)
+			assert.NoError(os.MkdirAll(driverPath, 0750))
+			assert.NoError(ioutil.WriteFile(filepath.Join(driverPath, "unbind"), nil, 0640))
+			assert.NoError(ioutil.WriteFile(filepath.Join(driverPath, "bind"), nil, 0640))
+			assert.NoError(os.Symlink(driverPath, filepath.Join(devPath, "driver")))
+		}
+		assert.NoError(ioutil.WriteFile(filepath.Join(devPath, "driver_override"), nil, 0640))
+	}
+}
+
+func TestIOMMUGroupHostDevices(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpDir)
+
+	fakeIOMMUGroup(t, tmpDir)
+
+	savedIOMMUPath := config.SysIOMMUPath
+	config.SysIOMMUPath = filepath.Join(tmpDir, "iommu_groups")
+	defer func() {
+		config.SysIOMMUPath = savedIOMMUPath
+	}()
+
+	hostDevices, err := IOMMUGroupHostDevices("1")
+	assert.NoError(err)
+	assert.Equal(map[string]string{"0000:01:00.1": "snd_hda_intel"}, hostDevices)
+
+	_, err = IOMMUGroupHostDevices("2")
+	assert.Error(err)
+}
+
+func TestVFIODevicePrepareIOMMUGroup(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpDir)
+
+	fakeIOMMUGroup(t, tmpDir)
+
+	savedIOMMUPath := config.SysIOMMUPath
+	savedSysBusPciDevicesPath := config.SysBusPciDevicesPath
+	config.SysIOMMUPath = filepath.Join(tmpDir, "iommu_groups")
+	config.SysBusPciDevicesPath = filepath.Join(tmpDir, "bus", "devices")
+	defer func() {
+		config.SysIOMMUPath = savedIOMMUPath
+		config.SysBusPciDevicesPath = savedSysBusPciDevicesPath
+	}()
+
+	// The audio function is in the way
+	dev := NewVFIODevice(&config.DeviceInfo{ID: "vfio", HostPath: "/dev/vfio/1"})
+	err = dev.prepareIOMMUGroup("1")
+	assert.Error(err)
+	groupErr, ok := err.(*IOMMUGroupError)
+	assert.True(ok)
+	assert.Equal("1", groupErr.Group)
+	assert.Equal(map[string]string{"0000:01:00.1": "snd_hda_intel"}, groupErr.HostDevices)
+	assert.Contains(err.Error(), "0000:01:00.1 (bound to snd_hda_intel)")
+
+	// Allowed to be bound to vfio-pci, which the fake sysfs does not do:
+	// the device is bound back to its driver.
+	dev.DeviceInfo.DriverOptions = map[string]string{VFIOAutoBindOption: "nvme,snd_hda_intel"}
+	err = dev.prepareIOMMUGroup("1")
+	assert.Error(err)
+	assert.Empty(dev.HostDrivers)
+
+	devPath := filepath.Join(config.SysIOMMUPath, "1", "devices", "0000:01:00.1")
+	// The override is cleared by a new line, written over the fake file
+	override, err := ioutil.ReadFile(filepath.Join(devPath, "driver_override"))
+	assert.NoError(err)
+	assert.True(strings.HasPrefix(string(override), "\n"))
+	probed, err := ioutil.ReadFile(filepath.Join(tmpDir, "bus", "drivers_probe"))
+	assert.NoError(err)
+	assert.Equal("0000:01:00.1", string(probed))
+	bound, err := ioutil.ReadFile(filepath.Join(tmpDir, "bus", "drivers", "snd_hda_intel", "bind"))
+	assert.NoError(err)
+	assert.Equal("0000:01:00.1", string(bound))
+
+	// Once bound to vfio-pci, the group can be assigned
+	assert.NoError(os.Remove(filepath.Join(devPath, "driver")))
+	assert.NoError(os.Symlink(filepath.Join(tmpDir, "bus", "drivers", "vfio-pci"), filepath.Join(devPath, "driver")))
+	assert.NoError(dev.prepareIOMMUGroup("1"))
+}
+
+func TestVFIODeviceRestoreHostDrivers(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpDir)
+
+	fakeIOMMUGroup(t, tmpDir)
+
+	savedIOMMUPath := config.SysIOMMUPath
+	savedSysBusPciDevicesPath := config.SysBusPciDevicesPath
+	config.SysIOMMUPath = filepath.Join(tmpDir, "iommu_groups")
+	config.SysBusPciDevicesPath = filepath.Join(tmpDir, "bus", "devices")
+	defer func() {
+		config.SysIOMMUPath = savedIOMMUPath
+		config.SysBusPciDevicesPath = savedSysBusPciDevicesPath
+	}()
+
+	// The audio function was bound to vfio-pci on attach
+	devPath := filepath.Join(config.SysIOMMUPath, "1", "devices", "0000:01:00.1")
+	assert.NoError(os.Remove(filepath.Join(devPath, "driver")))
+	assert.NoError(os.Symlink(filepath.Join(tmpDir, "bus", "drivers", "vfio-pci"), filepath.Join(devPath, "driver")))
+
+	dev := NewVFIODevice(&config.DeviceInfo{ID: "vfio", HostPath: "/dev/vfio/1"})
+	dev.HostDrivers = map[string]string{"0000:01:00.1": "snd_hda_intel"}
+
+	// The host drivers are saved along with the device
+	loaded := &VFIODevice{}
+	loaded.Load(dev.Save())
+	assert.Equal(dev.HostDrivers, loaded.HostDrivers)
+
+	assert.NoError(loaded.RestoreHostDrivers())
+	assert.Empty(loaded.HostDrivers)
+
+	override, err := ioutil.ReadFile(filepath.Join(devPath, "driver_override"))
+	assert.NoError(err)
+	assert.Equal("\n", string(override))
+	unbound, err := ioutil.ReadFile(filepath.Join(tmpDir, "bus", "drivers", "vfio-pci", "unbind"))
+	assert.NoError(err)
+	assert.Equal("0000:01:00.1", string(unbound))
+	bound, err := ioutil.ReadFile(filepath.Join(tmpDir, "bus", "drivers", "snd_hda_intel", "bind"))
+	assert.NoError(err)
+	assert.Equal("0000:01:00.1", string(bound))
+
+	// A driver that does not take the device back is reported
+	loaded.HostDrivers = map[string]string{"0000:01:00.1": "nvme"}
+	assert.Error(loaded.RestoreHostDrivers())
+	assert.Equal(map[string]string{"0000:01:00.1": "nvme"}, loaded.HostDrivers)
+}
@@ -9,6 +9,7 @@ package manager
 import (
 	"encoding/hex"
 	"errors"
+	"strings"
 	"sync"
 
 	"github.com/sirupsen/logrus"
@@ -50,6 +51,7 @@ type deviceManager struct {
 	blockDriver           string
 	vhostUserStoreEnabled bool
 	vhostUserStorePath    string
+	vfioAutoBindDrivers   []string
 
 	devices map[string]api.Device
 	sync.RWMutex
@@ -59,13 +61,27 @@ func deviceLogger() *logrus.Entry {
 	return api.DeviceLogger().WithField("subsystem", "device")
 }
 
+// Option is an optional setting of a deviceManager.
+type Option func(*deviceManager)
+
+// WithVFIOAutoBindDrivers sets the host drivers whose devices are bound to
+// vfio-pci when they share the IOMMU group of a VFIO device.
+func WithVFIOAutoBindDrivers(drivers []string) Option {
+	return func(dm *deviceManager) {
+		dm.vfioAutoBindDrivers = drivers
+	}
+}
+
 // NewDeviceManager creates a deviceManager object behaved as api.DeviceManager
-func NewDeviceManager(blockDriver string, vhostUserStoreEnabled bool, vhostUserStorePath string, devices []api.Device) api.DeviceManager {
+func NewDeviceManager(blockDriver string, vhostUserStoreEnabled bool, vhostUserStorePath string, devices []api.Device, opts ...Option) api.DeviceManager {
 	dm := &deviceManager{
 		vhostUserStoreEnabled: vhostUserStoreEnabled,
 		vhostUserStorePath:    vhostUserStorePath,
 		devices:               make(map[string]api.Device),
 	}
+	for _, opt := range opts {
+		opt(dm)
+	}
 	if blockDriver == VirtioMmio {
 		dm.blockDriver = VirtioMmio
 	} else if blockDriver == VirtioBlock {
@@ -123,7 +139,13 @@ func (dm *deviceManager) createDevice(devInfo config.DeviceInfo) (dev api.Device
 	if devInfo.ID, err = dm.newDeviceID(); err != nil {
 		return nil, err
 	}
-	if isVFIO(devInfo.HostPath) {
+	if IsVFIO(devInfo.HostPath) {
+		if len(dm.vfioAutoBindDrivers) > 0 {
+			if devInfo.DriverOptions == nil {
+				devInfo.DriverOptions = make(map[string]string)
+			}
+			devInfo.DriverOptions[drivers.VFIOAutoBindOption] = strings.Join(dm.vfioAutoBindDrivers, ",")
+		}
 		return drivers.NewVFIODevice(&devInfo), nil
 	} else if isVhostUserBlk(devInfo) {
 		if devInfo.DriverOptions == nil {
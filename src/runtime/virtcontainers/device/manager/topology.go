@@ -0,0 +1,230 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package manager
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/drivers"
+)
+
+var pciAddressRegexp = regexp.MustCompile(`^[[:xdigit:]]{4}:[[:xdigit:]]{2}:[[:xdigit:]]{2}\.[0-7]$`)
+
+// GetDeviceTopology returns the host topology of the devices: the NUMA
+// nodes they are attached to, and the IOMMU group conflicts preventing
+// them from being assigned to the same sandbox. It only reads the host
+// sysfs, so it can be used before creating the sandbox, to pick devices
+// local to each other.
+func GetDeviceTopology(devices []config.DeviceInfo) (config.DeviceTopology, error) {
+	var topology config.DeviceTopology
+
+	nodes := make(map[int]bool)
+	for _, devInfo := range devices {
+		locality, err := getDeviceLocality(devInfo)
+		if err != nil {
+			return config.DeviceTopology{}, err
+		}
+
+		for _, node := range locality.NUMANodes {
+			nodes[node] = true
+		}
+		topology.Devices = append(topology.Devices, locality)
+	}
+
+	for node := range nodes {
+		topology.NUMANodes = append(topology.NUMANodes, node)
+	}
+	sort.Ints(topology.NUMANodes)
+
+	topology.Conflicts = iommuGroupConflicts(topology.Devices)
+
+	for _, l := range topology.Devices {
+		if !IsVFIO(l.HostPath) {
+			continue
+		}
+
+		hostDevices, err := drivers.IOMMUGroupHostDevices(l.IOMMUGroup)
+		if err != nil {
+			return config.DeviceTopology{}, err
+		}
+		if len(hostDevices) > 0 {
+			groupErr := &drivers.IOMMUGroupError{Group: l.IOMMUGroup, HostDevices: hostDevices}
+			topology.Conflicts = append(topology.Conflicts, groupErr.Error())
+		}
+	}
+
+	return topology, nil
+}
+
+// iommuGroupConflicts returns the IOMMU groups assigned more than once, and
+// the devices left on the host while their IOMMU group is assigned.
+func iommuGroupConflicts(localities []config.DeviceLocality) []string {
+	var conflicts []string
+
+	assigned := make(map[string][]string)
+	for _, l := range localities {
+		if IsVFIO(l.HostPath) {
+			assigned[l.IOMMUGroup] = append(assigned[l.IOMMUGroup], l.HostPath)
+		}
+	}
+
+	reported := make(map[string]bool)
+	for _, l := range localities {
+		if l.IOMMUGroup == "" {
+			continue
+		}
+
+		paths := assigned[l.IOMMUGroup]
+		if IsVFIO(l.HostPath) {
+			if len(paths) > 1 && !reported[l.IOMMUGroup] {
+				reported[l.IOMMUGroup] = true
+				conflicts = append(conflicts, fmt.Sprintf("IOMMU group %s is assigned %d times", l.IOMMUGroup, len(paths)))
+			}
+		} else if len(paths) > 0 {
+			conflicts = append(conflicts, fmt.Sprintf("%s cannot stay on the host, its IOMMU group %s is assigned with %s",
+				l.HostPath, l.IOMMUGroup, paths[0]))
+		}
+	}
+
+	return conflicts
+}
+
+func getDeviceLocality(devInfo config.DeviceInfo) (config.DeviceLocality, error) {
+	locality := config.DeviceLocality{
+		HostPath: devInfo.HostPath,
+	}
+
+	if IsVFIO(devInfo.HostPath) {
+		locality.IOMMUGroup = filepath.Base(devInfo.HostPath)
+
+		iommuDevicesPath := filepath.Join(config.SysIOMMUPath, locality.IOMMUGroup, "devices")
+		deviceFiles, err := ioutil.ReadDir(iommuDevicesPath)
+		if err != nil {
+			return locality, err
+		}
+
+		for _, deviceFile := range deviceFiles {
+			bdf := deviceFile.Name()
+			if drivers.GetVFIODeviceType(bdf) == config.VFIODeviceMediatedType {
+				// Mediated devices are located by their parent
+				sysfsDev, err := filepath.EvalSymlinks(filepath.Join(iommuDevicesPath, bdf))
+				if err != nil {
+					return locality, err
+				}
+				if bdf = pciAncestor(sysfsDev); bdf == "" {
+					continue
+				}
+			}
+			locality.PCIDevices = append(locality.PCIDevices, bdf)
+		}
+	} else {
+		bdf, err := hostPCIDevice(devInfo)
+		if err != nil {
+			return locality, err
+		}
+		if bdf != "" {
+			locality.PCIDevices = []string{bdf}
+			locality.IOMMUGroup = pciIOMMUGroup(bdf)
+		}
+	}
+
+	seen := make(map[int]bool)
+	for _, bdf := range locality.PCIDevices {
+		node := pciNUMANode(bdf)
+		if node != config.NoNUMANode && !seen[node] {
+			seen[node] = true
+			locality.NUMANodes = append(locality.NUMANodes, node)
+		}
+	}
+	sort.Ints(locality.NUMANodes)
+
+	return locality, nil
+}
+
+// hostPCIDevice returns the address of the PCI device behind a block or
+// character device, empty if it is not backed by one.
+func hostPCIDevice(devInfo config.DeviceInfo) (string, error) {
+	if devInfo.DevType == "" {
+		var stat unix.Stat_t
+		if err := unix.Stat(devInfo.HostPath, &stat); err != nil {
+			return "", err
+		}
+
+		switch stat.Mode & unix.S_IFMT {
+		case unix.S_IFBLK:
+			devInfo.DevType = "b"
+		case unix.S_IFCHR:
+			devInfo.DevType = "c"
+		default:
+			return "", fmt.Errorf("%s is not a device", devInfo.HostPath)
+		}
+		devInfo.Major = int64(unix.Major(stat.Rdev))
+		devInfo.Minor = int64(unix.Minor(stat.Rdev))
+	}
+
+	var pathComp string
+	switch devInfo.DevType {
+	case "b":
+		pathComp = "block"
+	case "c", "u":
+		pathComp = "char"
+	default:
+		return "", nil
+	}
+
+	format := strconv.FormatInt(devInfo.Major, 10) + ":" + strconv.FormatInt(devInfo.Minor, 10)
+	sysfsDev, err := filepath.EvalSymlinks(filepath.Join(config.SysDevPrefix, pathComp, format, "device"))
+	if os.IsNotExist(err) {
+		// Virtual devices have no backing device
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return pciAncestor(sysfsDev), nil
+}
+
+// pciAncestor returns the address of the closest PCI device on a sysfs
+// device path, empty if there is none.
+func pciAncestor(sysfsDev string) string {
+	for dir := sysfsDev; dir != "/" && dir != "."; dir = filepath.Dir(dir) {
+		if pciAddressRegexp.MatchString(filepath.Base(dir)) {
+			return filepath.Base(dir)
+		}
+	}
+	return ""
+}
+
+func pciIOMMUGroup(bdf string) string {
+	group, err := os.Readlink(filepath.Join(config.SysBusPciDevicesPath, bdf, "iommu_group"))
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(group)
+}
+
+func pciNUMANode(bdf string) int {
+	data, err := ioutil.ReadFile(filepath.Join(config.SysBusPciDevicesPath, bdf, "numa_node"))
+	if err != nil {
+		return config.NoNUMANode
+	}
+
+	node, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || node < 0 {
+		return config.NoNUMANode
+	}
+	return node
+}
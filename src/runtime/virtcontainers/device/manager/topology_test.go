@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package manager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTopologySysfs creates a sysfs with a GPU on NUMA node 0, alone in
+// IOMMU group 5, and a NIC and an NVMe disk on NUMA node 1, sharing IOMMU
+// group 6.
+func fakeTopologySysfs(t *testing.T, root string) {
+	assert := assert.New(t)
+
+	pciDevices := []struct {
+		path  string
+		group string
+		node  string
+	}{
+		{"pci0000:00/0000:00:01.0/0000:01:00.0", "5", "0"},
+		{"pci0000:00/0000:00:02.0", "6", "1"},
+		{"pci0000:00/0000:00:02.1", "6", "1"},
+	}
+
+	for _, d := range pciDevices {
+		dir := filepath.Join(root, "devices", d.path)
+		bdf := filepath.Base(dir)
+		groupDir := filepath.Join(root, "iommu_groups", d.group)
+
+		assert.NoError(os.MkdirAll(dir, dirMode))
+		assert.NoError(ioutil.WriteFile(filepath.Join(dir, "numa_node"), []byte(d.node+"\n"), fileMode0640))
+		assert.NoError(os.MkdirAll(filepath.Join(groupDir, "devices"), dirMode))
+		assert.NoError(os.Symlink(groupDir, filepath.Join(dir, "iommu_group")))
+		assert.NoError(os.Symlink(dir, filepath.Join(groupDir, "devices", bdf)))
+
+		assert.NoError(os.MkdirAll(filepath.Join(root, "bus"), dirMode))
+		assert.NoError(os.Symlink(dir, filepath.Join(root, "bus", bdf)))
+	}
+
+	nvme := filepath.Join(root, "devices", "pci0000:00/0000:00:02.1/nvme/nvme0")
+	assert.NoError(os.MkdirAll(nvme, dirMode))
+	assert.NoError(os.MkdirAll(filepath.Join(root, "dev", "block", "259:0"), dirMode))
+	assert.NoError(os.Symlink(nvme, filepath.Join(root, "dev", "block", "259:0", "device")))
+}
+
+func TestGetDeviceTopology(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpDir)
+
+	fakeTopologySysfs(t, tmpDir)
+
+	savedSysDevPrefix := config.SysDevPrefix
+	savedIOMMUPath := config.SysIOMMUPath
+	savedSysBusPciDevicesPath := config.SysBusPciDevicesPath
+	config.SysDevPrefix = filepath.Join(tmpDir, "dev")
+	config.SysIOMMUPath = filepath.Join(tmpDir, "iommu_groups")
+	config.SysBusPciDevicesPath = filepath.Join(tmpDir, "bus")
+	defer func() {
+		config.SysDevPrefix = savedSysDevPrefix
+		config.SysIOMMUPath = savedIOMMUPath
+		config.SysBusPciDevicesPath = savedSysBusPciDevicesPath
+	}()
+
+	gpu := config.DeviceInfo{HostPath: "/dev/vfio/5", DevType: "c"}
+	nic := config.DeviceInfo{HostPath: "/dev/vfio/6", DevType: "c"}
+	disk := config.DeviceInfo{HostPath: "/dev/nvme0n1", DevType: "b", Major: 259, Minor: 0}
+	null := config.DeviceInfo{HostPath: "/dev/null", DevType: "c", Major: 1, Minor: 3}
+
+	// Same NUMA node, no conflict
+	topology, err := GetDeviceTopology([]config.DeviceInfo{nic, null})
+	assert.NoError(err)
+	assert.Len(topology.Devices, 2)
+	assert.Equal("6", topology.Devices[0].IOMMUGroup)
+	assert.Equal([]string{"0000:00:02.0", "0000:00:02.1"}, topology.Devices[0].PCIDevices)
+	assert.Equal([]int{1}, topology.Devices[0].NUMANodes)
+	assert.Empty(topology.Devices[1].PCIDevices)
+	assert.Empty(topology.Devices[1].NUMANodes)
+	assert.Equal([]int{1}, topology.NUMANodes)
+	assert.False(topology.CrossNUMA())
+	assert.Empty(topology.Conflicts)
+
+	// GPU and NIC on different NUMA nodes
+	topology, err = GetDeviceTopology([]config.DeviceInfo{gpu, nic})
+	assert.NoError(err)
+	assert.Equal([]int{0, 1}, topology.NUMANodes)
+	assert.True(topology.CrossNUMA())
+	assert.Empty(topology.Conflicts)
+
+	// The disk is in the NIC IOMMU group, which is also assigned twice
+	topology, err = GetDeviceTopology([]config.DeviceInfo{nic, disk, nic})
+	assert.NoError(err)
+	assert.Equal([]string{"0000:00:02.1"}, topology.Devices[1].PCIDevices)
+	assert.Equal("6", topology.Devices[1].IOMMUGroup)
+	assert.Equal([]int{1}, topology.Devices[1].NUMANodes)
+	assert.Len(topology.Conflicts, 2)
+	assert.Contains(topology.Conflicts[0], "assigned 2 times")
+	assert.Contains(topology.Conflicts[1], "/dev/nvme0n1 cannot stay on the host")
+
+	// The disk is bound to its host driver
+	nvmePath := filepath.Join(tmpDir, "devices", "pci0000:00/0000:00:02.1")
+	assert.NoError(os.MkdirAll(filepath.Join(tmpDir, "drivers", "nvme"), dirMode))
+	assert.NoError(os.Symlink(filepath.Join(tmpDir, "drivers", "nvme"), filepath.Join(nvmePath, "driver")))
+	topology, err = GetDeviceTopology([]config.DeviceInfo{nic})
+	assert.NoError(err)
+	assert.Len(topology.Conflicts, 1)
+	assert.Contains(topology.Conflicts[0], "0000:00:02.1 (bound to nvme)")
+
+	// Unknown IOMMU group
+	_, err = GetDeviceTopology([]config.DeviceInfo{{HostPath: "/dev/vfio/7", DevType: "c"}})
+	assert.Error(err)
+}
+
+func TestPCIAncestor(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("0000:00:02.1", pciAncestor("/sys/devices/pci0000:00/0000:00:02.1/nvme/nvme0"))
+	assert.Equal("0000:03:00.0", pciAncestor("/sys/devices/pci0000:00/0000:00:01.0/0000:03:00.0"))
+	assert.Equal("", pciAncestor("/sys/devices/virtual/mem/null"))
+	assert.Equal("", pciAncestor("relative/path"))
+}
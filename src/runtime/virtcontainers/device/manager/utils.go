@@ -23,8 +23,8 @@ const (
 	vfioPath = "/dev/vfio/"
 )
 
-// isVFIO checks if the device provided is a vfio group.
-func isVFIO(hostPath string) bool {
+// IsVFIO checks if the device provided is a vfio group.
+func IsVFIO(hostPath string) bool {
 	// Ignore /dev/vfio/vfio character device
 	if strings.HasPrefix(hostPath, filepath.Join(vfioPath, "vfio")) {
 		return false
@@ -44,7 +44,7 @@ func isBlock(devInfo config.DeviceInfo) bool {
 
 // IsVFIOLargeBarSpaceDevice checks if the device is a large bar space device.
 func IsVFIOLargeBarSpaceDevice(hostPath string) (bool, error) {
-	if !isVFIO(hostPath) {
+	if !IsVFIO(hostPath) {
 		return false, nil
 	}
 
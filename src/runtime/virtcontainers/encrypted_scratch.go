@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// scratchEncryptionOption is the driver option asking the agent to
+	// encrypt a scratch device with the key of the sandbox, which never
+	// leaves the guest.
+	scratchEncryptionOption = "encryption=ephemeral"
+
+	// scratchFstype is the filesystem the agent creates on the opened
+	// scratch devices.
+	scratchFstype = "ext4"
+
+	kataOverlayFSDevType = "overlayfs"
+)
+
+// scratchGuestPath returns the guest directory the encrypted scratch device
+// of a container is mounted on.
+func scratchGuestPath(cID string) string {
+	return filepath.Join(defaultKataGuestSandboxDir, "scratch", cID)
+}
+
+// plugEncryptedScratch allocates a sparse image of the size of the scratch
+// device of the container, and hotplugs it. The image is only referenced by
+// the loop device backing the drive, so that both go away with the drive
+// and nothing written to it outlives the container.
+func (c *Container) plugEncryptedScratch() error {
+	if !c.checkBlockDeviceSupport() {
+		return fmt.Errorf("Container %s encrypted scratch needs block devices support", c.id)
+	}
+
+	image := filepath.Join(c.sandbox.newStore.RunVMStoragePath(), c.sandbox.id, "scratch-"+c.id+".img")
+	if err := os.MkdirAll(filepath.Dir(image), DirMode); err != nil {
+		return err
+	}
+	defer os.Remove(image)
+
+	f, err := os.OpenFile(image, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	err = f.Truncate(int64(c.config.EncryptedScratchMB) << 20)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	loopPath, loop, err := attachLoopDevice(image, false)
+	if err != nil {
+		return err
+	}
+	// Closing after the hypervisor has opened the device
+	defer loop.Close()
+
+	var stat unix.Stat_t
+	if err := unix.Stat(loopPath, &stat); err != nil {
+		return fmt.Errorf("stat %q failed: %v", loopPath, err)
+	}
+
+	id, err := c.attachBlockDevice(loopPath, scratchGuestPath(c.id), stat)
+	c.state.ScratchDeviceID = id
+	if err != nil {
+		return err
+	}
+
+	c.Logger().WithFields(logrus.Fields{
+		"device":  id,
+		"size-mb": c.config.EncryptedScratchMB,
+	}).Info("Encrypted scratch device plugged")
+
+	return nil
+}
+
+// removeEncryptedScratch unplugs the scratch device of the container, if
+// any, destroying its image.
+func (c *Container) removeEncryptedScratch() error {
+	id := c.state.ScratchDeviceID
+	if id == "" {
+		return nil
+	}
+
+	// A device gone already is forgotten
+	err := c.sandbox.devManager.DetachDevice(id, c.sandbox)
+	if err != nil && err != manager.ErrDeviceNotAttached && err != manager.ErrDeviceNotExist {
+		return err
+	}
+
+	if err := c.sandbox.devManager.RemoveDevice(id); err != nil && err != manager.ErrDeviceNotExist {
+		return err
+	}
+
+	c.state.ScratchDeviceID = ""
+
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/drivers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
+	pb "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleEncryptedScratch(t *testing.T) {
+	assert := assert.New(t)
+
+	dev := drivers.NewBlockDevice(&config.DeviceInfo{ID: "scratch"})
+	dev.BlockDrive = &config.BlockDrive{PCIAddr: "0002:01"}
+
+	sConfig := SandboxConfig{}
+	sConfig.HypervisorConfig.BlockDeviceDriver = manager.VirtioBlock
+	c := &Container{
+		id: "100",
+		sandbox: &Sandbox{
+			id:         "100",
+			devManager: manager.NewDeviceManager(manager.VirtioBlock, false, "", []api.Device{dev}),
+			ctx:        context.Background(),
+			config:     &sConfig,
+		},
+	}
+
+	k := &kataAgent{}
+
+	// No scratch device, no storage
+	storages, err := k.handleEncryptedScratch(c, "/rootfs")
+	assert.NoError(err)
+	assert.Empty(storages)
+
+	c.state.ScratchDeviceID = "scratch"
+	storages, err = k.handleEncryptedScratch(c, "/rootfs")
+	assert.NoError(err)
+	assert.Equal([]*pb.Storage{
+		{
+			Driver:        kataBlkDevType,
+			DriverOptions: []string{scratchEncryptionOption},
+			Source:        "0002:01",
+			Fstype:        scratchFstype,
+			MountPoint:    scratchGuestPath("100"),
+		},
+		{
+			Driver: kataOverlayFSDevType,
+			Source: "overlay",
+			Fstype: "overlay",
+			Options: []string{
+				"lowerdir=/rootfs",
+				"upperdir=" + scratchGuestPath("100") + "/upper",
+				"workdir=" + scratchGuestPath("100") + "/work",
+			},
+			MountPoint: "/rootfs",
+		},
+	}, storages)
+
+	c.state.ScratchDeviceID = "missing"
+	_, err = k.handleEncryptedScratch(c, "/rootfs")
+	assert.Error(err)
+}
+
+func TestNewContainerEncryptedScratch(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id:     testSandboxID,
+		config: &SandboxConfig{},
+		ctx:    context.Background(),
+	}
+
+	_, err := newContainer(s, &ContainerConfig{
+		ID:                 "100",
+		ReadonlyRootfs:     true,
+		EncryptedScratchMB: 1024,
+	})
+	var fieldErr *ConfigFieldError
+	assert.True(errors.As(err, &fieldErr))
+	assert.Equal("EncryptedScratchMB", fieldErr.Field)
+}
+
+func TestRemoveEncryptedScratch(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &Container{
+		id: "100",
+		sandbox: &Sandbox{
+			devManager: manager.NewDeviceManager(manager.VirtioBlock, false, "", nil),
+		},
+	}
+
+	// Nothing to remove
+	assert.NoError(c.removeEncryptedScratch())
+
+	// A device already gone is forgotten
+	c.state = types.ContainerState{ScratchDeviceID: "missing"}
+	assert.NoError(c.removeEncryptedScratch())
+	assert.Empty(c.state.ScratchDeviceID)
+}
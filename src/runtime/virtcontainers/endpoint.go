@@ -24,8 +24,8 @@ type Endpoint interface {
 	SetPciAddr(string)
 	Attach(*Sandbox) error
 	Detach(netNsCreated bool, netNsPath string) error
-	HotAttach(h hypervisor) error
-	HotDetach(h hypervisor, netNsCreated bool, netNsPath string) error
+	HotAttach(*Sandbox) error
+	HotDetach(s *Sandbox, netNsCreated bool, netNsPath string) error
 
 	save() persistapi.NetworkEndpoint
 	load(persistapi.NetworkEndpoint)
@@ -0,0 +1,267 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/vishvananda/netlink"
+)
+
+// The endpoint operations, as labelled in the metrics.
+const (
+	endpointOpAttach    = "attach"
+	endpointOpDetach    = "detach"
+	endpointOpHotAttach = "hot_attach"
+	endpointOpHotDetach = "hot_detach"
+)
+
+var (
+	endpointOpDurationsHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespaceKatashim,
+		Name:      "endpoint_op_durations_histogram_milliseconds",
+		Help:      "Network endpoint attach and detach latency distributions.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+	},
+		[]string{"type", "op"},
+	)
+
+	endpointOpErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespaceKatashim,
+		Name:      "endpoint_op_errors_total",
+		Help:      "Network endpoint attach and detach failures.",
+	},
+		[]string{"type", "op"},
+	)
+)
+
+// EndpointOpStats is the last run of an operation on a network endpoint.
+type EndpointOpStats struct {
+	Time     time.Time
+	Duration time.Duration
+	// Error is why the last run failed, if it did.
+	Error string
+	// Errors is how many runs failed.
+	Errors uint64
+}
+
+// EndpointLinkStats are the counters of a host network interface carrying
+// the traffic of an endpoint.
+type EndpointLinkStats struct {
+	Name      string
+	RxPackets uint64
+	RxBytes   uint64
+	RxErrors  uint64
+	RxDropped uint64
+	TxPackets uint64
+	TxBytes   uint64
+	TxErrors  uint64
+	TxDropped uint64
+	// Error is why the counters could not be read.
+	Error string `json:",omitempty"`
+}
+
+// EndpointConfig is the configuration of a network endpoint, as attached.
+type EndpointConfig struct {
+	InterworkingModel string `json:",omitempty"`
+	MTU               int
+	Addrs             []string
+	RxRateLimiter     bool
+	TxRateLimiter     bool
+	SocketPath        string `json:",omitempty"`
+}
+
+// EndpointStats describes a network endpoint of a sandbox, for
+// troubleshooting the sandbox networking.
+type EndpointStats struct {
+	Name         string
+	Type         EndpointType
+	HardwareAddr string
+	PciAddr      string
+	Config       EndpointConfig
+	// Ops are the last runs of the operations on the endpoint, by
+	// operation.
+	Ops map[string]EndpointOpStats
+	// Links are the host interfaces carrying the traffic, none if the
+	// traffic does not go through the host network stack, such as for
+	// the physical and vhost-user endpoints.
+	Links []EndpointLinkStats
+}
+
+// endpointOps records the operations run on the endpoints of a sandbox, by
+// endpoint name.
+type endpointOps struct {
+	sync.Mutex
+	ops map[string]map[string]EndpointOpStats
+}
+
+func (e *endpointOps) record(name, op string, start time.Time, err error) {
+	e.Lock()
+	defer e.Unlock()
+
+	if e.ops == nil {
+		e.ops = make(map[string]map[string]EndpointOpStats)
+	}
+	if e.ops[name] == nil {
+		e.ops[name] = make(map[string]EndpointOpStats)
+	}
+
+	stats := e.ops[name][op]
+	stats.Time = start
+	stats.Duration = time.Since(start)
+	stats.Error = ""
+	if err != nil {
+		stats.Error = err.Error()
+		stats.Errors++
+	}
+	e.ops[name][op] = stats
+}
+
+// forget drops the records of a removed endpoint, its detach latency being
+// in the metrics only.
+func (e *endpointOps) forget(name string) {
+	e.Lock()
+	defer e.Unlock()
+
+	delete(e.ops, name)
+}
+
+func (e *endpointOps) get(name string) map[string]EndpointOpStats {
+	e.Lock()
+	defer e.Unlock()
+
+	ops := make(map[string]EndpointOpStats)
+	for op, stats := range e.ops[name] {
+		ops[op] = stats
+	}
+
+	return ops
+}
+
+// runEndpointOp runs an operation on an endpoint, and records its latency
+// and failure in the metrics, and in ops if not nil.
+func runEndpointOp(ops *endpointOps, endpoint Endpoint, op string, f func() error) error {
+	start := time.Now()
+	err := f()
+
+	labels := prometheus.Labels{"type": string(endpoint.Type()), "op": op}
+	endpointOpDurationsHistogram.With(labels).Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+	if err != nil {
+		endpointOpErrors.With(labels).Inc()
+	}
+
+	if ops != nil {
+		ops.record(endpoint.Name(), op, start, err)
+	}
+
+	return err
+}
+
+// endpointHostLinks returns the host interfaces, in the network namespace
+// of the sandbox, carrying the traffic of an endpoint.
+func endpointHostLinks(endpoint Endpoint) []string {
+	if pair := endpoint.NetworkPair(); pair != nil {
+		return []string{pair.VirtIface.Name, pair.TAPIface.Name}
+	}
+
+	switch e := endpoint.(type) {
+	case *MacvtapEndpoint:
+		return []string{e.EndpointProperties.Iface.Name}
+	case *TapEndpoint:
+		return []string{e.TapInterface.TAPIface.Name}
+	}
+
+	return nil
+}
+
+func endpointConfig(endpoint Endpoint) EndpointConfig {
+	props := endpoint.Properties()
+
+	config := EndpointConfig{
+		MTU:           props.Iface.MTU,
+		RxRateLimiter: endpoint.GetRxRateLimiter(),
+		TxRateLimiter: endpoint.GetTxRateLimiter(),
+	}
+
+	for _, addr := range props.Addrs {
+		if addr.IPNet != nil {
+			config.Addrs = append(config.Addrs, addr.IPNet.String())
+		}
+	}
+
+	if pair := endpoint.NetworkPair(); pair != nil {
+		config.InterworkingModel = pair.NetInterworkingModel.name()
+	}
+
+	if e, ok := endpoint.(*VhostUserEndpoint); ok {
+		config.SocketPath = e.SocketPath
+	}
+
+	return config
+}
+
+func linkStats(name string) EndpointLinkStats {
+	stats := EndpointLinkStats{Name: name}
+
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		stats.Error = err.Error()
+		return stats
+	}
+
+	s := link.Attrs().Statistics
+	if s == nil {
+		stats.Error = "no statistics"
+		return stats
+	}
+
+	stats.RxPackets = s.RxPackets
+	stats.RxBytes = s.RxBytes
+	stats.RxErrors = s.RxErrors
+	stats.RxDropped = s.RxDropped
+	stats.TxPackets = s.TxPackets
+	stats.TxBytes = s.TxBytes
+	stats.TxErrors = s.TxErrors
+	stats.TxDropped = s.TxDropped
+
+	return stats
+}
+
+// EndpointStats returns the configuration, the last attach and detach
+// operations and the host interface counters of the network endpoints of
+// the sandbox.
+func (s *Sandbox) EndpointStats() ([]EndpointStats, error) {
+	var stats []EndpointStats
+
+	for _, endpoint := range s.networkNS.Endpoints {
+		stats = append(stats, EndpointStats{
+			Name:         endpoint.Name(),
+			Type:         endpoint.Type(),
+			HardwareAddr: endpoint.HardwareAddr(),
+			PciAddr:      endpoint.PciAddr(),
+			Config:       endpointConfig(endpoint),
+			Ops:          s.endpointOps.get(endpoint.Name()),
+		})
+	}
+
+	if s.networkNS.NetNsPath == "" {
+		return stats, nil
+	}
+
+	err := doNetNS(s.networkNS.NetNsPath, func(_ ns.NetNS) error {
+		for i, endpoint := range s.networkNS.Endpoints {
+			for _, name := range endpointHostLinks(endpoint) {
+				stats[i].Links = append(stats[i].Links, linkStats(name))
+			}
+		}
+		return nil
+	})
+
+	return stats, err
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vishvananda/netlink"
+)
+
+func TestRunEndpointOp(t *testing.T) {
+	assert := assert.New(t)
+
+	endpoint, err := createVethNetworkEndpoint(0, "eth0", NetXConnectTCFilterModel)
+	assert.NoError(err)
+
+	var ops endpointOps
+	assert.NoError(runEndpointOp(&ops, endpoint, endpointOpAttach, func() error { return nil }))
+
+	stats := ops.get(endpoint.Name())
+	assert.Contains(stats, endpointOpAttach)
+	assert.False(stats[endpointOpAttach].Time.IsZero())
+	assert.Empty(stats[endpointOpAttach].Error)
+
+	// The failures are counted, the last error reported
+	attachErr := errors.New("tap creation failed")
+	for i := 0; i < 2; i++ {
+		assert.Equal(attachErr, runEndpointOp(&ops, endpoint, endpointOpAttach, func() error { return attachErr }))
+	}
+	stats = ops.get(endpoint.Name())
+	assert.Equal("tap creation failed", stats[endpointOpAttach].Error)
+	assert.Equal(uint64(2), stats[endpointOpAttach].Errors)
+
+	assert.NoError(runEndpointOp(&ops, endpoint, endpointOpAttach, func() error { return nil }))
+	assert.Empty(ops.get(endpoint.Name())[endpointOpAttach].Error)
+	assert.Equal(uint64(2), ops.get(endpoint.Name())[endpointOpAttach].Errors)
+
+	ops.forget(endpoint.Name())
+	assert.Empty(ops.get(endpoint.Name()))
+
+	// Without records, only the metrics are updated
+	assert.NoError(runEndpointOp(nil, endpoint, endpointOpDetach, func() error { return nil }))
+}
+
+func TestEndpointHostLinks(t *testing.T) {
+	assert := assert.New(t)
+
+	veth, err := createVethNetworkEndpoint(0, "eth0", NetXConnectTCFilterModel)
+	assert.NoError(err)
+	assert.Equal([]string{veth.NetPair.VirtIface.Name, veth.NetPair.TAPIface.Name}, endpointHostLinks(veth))
+
+	macvtap := &MacvtapEndpoint{EndpointProperties: NetworkInfo{Iface: NetlinkIface{LinkAttrs: netlink.LinkAttrs{Name: "macvtap0"}}}}
+	assert.Equal([]string{"macvtap0"}, endpointHostLinks(macvtap))
+
+	assert.Empty(endpointHostLinks(&VhostUserEndpoint{SocketPath: "/tmp/vhost-user.sock"}))
+	assert.Empty(endpointHostLinks(&PhysicalEndpoint{IfaceName: "eth0"}))
+}
+
+func TestEndpointConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	veth, err := createVethNetworkEndpoint(0, "eth0", NetXConnectTCFilterModel)
+	assert.NoError(err)
+
+	ip, ipnet, err := net.ParseCIDR("172.17.0.2/16")
+	assert.NoError(err)
+	veth.SetProperties(NetworkInfo{
+		Iface: NetlinkIface{LinkAttrs: netlink.LinkAttrs{Name: "eth0", MTU: 1450}},
+		Addrs: []netlink.Addr{{IPNet: &net.IPNet{IP: ip, Mask: ipnet.Mask}}},
+	})
+	veth.RxRateLimiter = true
+
+	assert.Equal(EndpointConfig{
+		InterworkingModel: "tcfilter",
+		MTU:               1450,
+		Addrs:             []string{"172.17.0.2/16"},
+		RxRateLimiter:     true,
+	}, endpointConfig(veth))
+
+	assert.Equal(EndpointConfig{SocketPath: "/tmp/vhost-user.sock"}, endpointConfig(&VhostUserEndpoint{SocketPath: "/tmp/vhost-user.sock"}))
+}
+
+func TestSandboxEndpointStats(t *testing.T) {
+	assert := assert.New(t)
+
+	endpoint := &VhostUserEndpoint{
+		SocketPath:   "/tmp/vhost-user.sock",
+		HardAddr:     "02:00:ca:fe:00:01",
+		IfaceName:    "eth0",
+		EndpointType: VhostUserEndpointType,
+	}
+
+	s := &Sandbox{networkNS: NetworkNamespace{Endpoints: []Endpoint{endpoint}}}
+	assert.NoError(runEndpointOp(&s.endpointOps, endpoint, endpointOpHotAttach, func() error { return nil }))
+
+	stats, err := s.EndpointStats()
+	assert.NoError(err)
+	assert.Len(stats, 1)
+	assert.Equal(endpoint.Name(), stats[0].Name)
+	assert.Equal(VhostUserEndpointType, stats[0].Type)
+	assert.Equal("02:00:ca:fe:00:01", stats[0].HardwareAddr)
+	assert.Equal("/tmp/vhost-user.sock", stats[0].Config.SocketPath)
+	assert.Contains(stats[0].Ops, endpointOpHotAttach)
+	assert.Empty(stats[0].Links)
+}
@@ -387,10 +387,13 @@ func (fc *firecracker) fcInit(timeout int) error {
 		if fc.netNSPath != "" {
 			args = append(args, "--netns", fc.netNSPath)
 		}
-		args = append(args, "--", "--config-file", fc.fcConfigPath)
+		args = append(args, "--")
+		args = append(args, fcSeccompArgs(fc.config.VMMSeccomp)...)
+		args = append(args, "--config-file", fc.fcConfigPath)
 
 		cmd = exec.Command(fc.config.JailerPath, args...)
 	} else {
+		args = append(args, fcSeccompArgs(fc.config.VMMSeccomp)...)
 		args = append(args,
 			"--api-sock", fc.socketPath,
 			"--config-file", fc.fcConfigPath)
@@ -706,6 +709,7 @@ func (fc *firecracker) fcInitConfiguration() error {
 	}
 
 	kernelParams := append(fc.config.KernelParams, fcKernelParams...)
+	kernelParams = fc.config.appendPayloadParams(kernelParams)
 	strParams := SerializeParams(kernelParams, "=")
 	formattedParams := strings.Join(strParams, " ")
 	if err := fc.fcSetBootSource(kernelPath, formattedParams); err != nil {
@@ -881,6 +885,42 @@ func (fc *firecracker) resumeSandbox() error {
 	return nil
 }
 
+func (fc *firecracker) snapshotSandbox(dir string) error {
+	return errors.New("sandbox snapshots are not supported for firecracker")
+}
+
+func (fc *firecracker) migrateSandbox(uri string) error {
+	return errors.New("sandbox migration is not supported for firecracker")
+}
+
+func (fc *firecracker) finishIncomingMigration() error {
+	return errors.New("sandbox migration is not supported for firecracker")
+}
+
+func (fc *firecracker) waitGuestSuspended(timeout time.Duration) error {
+	return errors.New("guest suspend is not supported for firecracker")
+}
+
+func (fc *firecracker) wakeupSandbox() error {
+	return errors.New("guest suspend is not supported for firecracker")
+}
+
+func (fc *firecracker) upgradeVirtiofsd() error {
+	return errors.New("virtiofsd upgrade is not supported for firecracker")
+}
+
+func (fc *firecracker) liveUpdate(vmSocket interface{}, endpoints []Endpoint, drives []*config.BlockDrive) error {
+	return errors.New("sandbox live update is not supported for firecracker")
+}
+
+func (fc *firecracker) powerdownSandbox() error {
+	return errors.New("ACPI power down is not supported for firecracker")
+}
+
+func (fc *firecracker) getLaunchMeasurement() (LaunchMeasurement, error) {
+	return LaunchMeasurement{}, errors.New("memory encryption is not supported for firecracker")
+}
+
 func (fc *firecracker) fcAddVsock(hvs types.HybridVSock) {
 	span, _ := fc.trace("fcAddVsock")
 	defer span.Finish()
@@ -1115,6 +1155,7 @@ func (fc *firecracker) capabilities() types.Capabilities {
 	defer span.Finish()
 	var caps types.Capabilities
 	caps.SetBlockDeviceHotplugSupport()
+	caps.SetBlockDeviceHotUnplugSupport()
 
 	return caps
 }
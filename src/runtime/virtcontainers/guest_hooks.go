@@ -0,0 +1,83 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+)
+
+// Supported values for SandboxConfig.GuestHooks, the types of the OCI hooks
+// the runtime leaves to the agent.
+const (
+	// GuestHookPrestart runs the prestart hooks in the guest.
+	GuestHookPrestart = "prestart"
+
+	// GuestHookPoststart runs the poststart hooks in the guest.
+	GuestHookPoststart = "poststart"
+
+	// GuestHookPoststop runs the poststop hooks in the guest.
+	GuestHookPoststop = "poststop"
+)
+
+// checkGuestHooks verifies the hook types run in the guest are known.
+func checkGuestHooks(hookTypes []string) error {
+	for _, t := range hookTypes {
+		switch t {
+		case GuestHookPrestart, GuestHookPoststart, GuestHookPoststop:
+		default:
+			return newConfigFieldError("GuestHooks", fmt.Sprintf("Unknown hook type %q", t))
+		}
+	}
+
+	return nil
+}
+
+// IsGuestHook tells whether the OCI hooks of the type are run by the agent
+// in the guest, rather than by the runtime on the host.
+func IsGuestHook(hookTypes []string, hookType string) bool {
+	for _, t := range hookTypes {
+		if t == hookType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// guestHooks returns the hooks of the container the agent runs in the guest.
+// The createRuntime, createContainer and startContainer hooks run where the
+// container is created, which is the guest, the other hooks only when their
+// type is run in the guest. It returns nil when the agent has no hook to run.
+func guestHooks(hooks *grpc.Hooks, hookTypes []string) *grpc.Hooks {
+	if hooks == nil {
+		return nil
+	}
+
+	g := &grpc.Hooks{
+		CreateRuntime:   hooks.CreateRuntime,
+		CreateContainer: hooks.CreateContainer,
+		StartContainer:  hooks.StartContainer,
+	}
+
+	if IsGuestHook(hookTypes, GuestHookPrestart) {
+		g.Prestart = hooks.Prestart
+	}
+	if IsGuestHook(hookTypes, GuestHookPoststart) {
+		g.Poststart = hooks.Poststart
+	}
+	if IsGuestHook(hookTypes, GuestHookPoststop) {
+		g.Poststop = hooks.Poststop
+	}
+
+	if len(g.Prestart) == 0 && len(g.CreateRuntime) == 0 && len(g.CreateContainer) == 0 &&
+		len(g.StartContainer) == 0 && len(g.Poststart) == 0 && len(g.Poststop) == 0 {
+		return nil
+	}
+
+	return g
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	pb "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckGuestHooks(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(checkGuestHooks(nil))
+	assert.NoError(checkGuestHooks([]string{GuestHookPrestart, GuestHookPoststart, GuestHookPoststop}))
+	assert.Error(checkGuestHooks([]string{GuestHookPrestart, "createRuntime"}))
+}
+
+func TestGuestHooks(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(guestHooks(nil, []string{GuestHookPrestart}))
+
+	hooks := &pb.Hooks{
+		Prestart:  []pb.Hook{{Path: "/usr/bin/prestart"}},
+		Poststart: []pb.Hook{{Path: "/usr/bin/poststart"}},
+		Poststop:  []pb.Hook{{Path: "/usr/bin/poststop"}},
+	}
+
+	// The host runs all of them
+	assert.Nil(guestHooks(hooks, nil))
+
+	g := guestHooks(hooks, []string{GuestHookPoststop})
+	assert.NotNil(g)
+	assert.Empty(g.Prestart)
+	assert.Empty(g.Poststart)
+	assert.Equal(hooks.Poststop, g.Poststop)
+
+	// The container creation hooks always run in the guest
+	hooks.CreateRuntime = []pb.Hook{{Path: "/usr/bin/create-runtime"}}
+	hooks.CreateContainer = []pb.Hook{{Path: "/usr/bin/create-container"}}
+	hooks.StartContainer = []pb.Hook{{Path: "/usr/bin/start-container"}}
+	g = guestHooks(hooks, nil)
+	assert.NotNil(g)
+	assert.Empty(g.Prestart)
+	assert.Equal(hooks.CreateRuntime, g.CreateRuntime)
+	assert.Equal(hooks.CreateContainer, g.CreateContainer)
+	assert.Equal(hooks.StartContainer, g.StartContainer)
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+const (
+	// guestLivepatchDir is where the livepatch modules are copied, before
+	// the agent loads them.
+	guestLivepatchDir = "/run/kata-containers/livepatch"
+
+	livepatchModuleExt = ".ko"
+
+	agentLivepatchParam = "agent.livepatch"
+
+	// The guest kernel only loads the modules signed with a key it trusts.
+	moduleSigEnforceParam = "module.sig_enforce"
+)
+
+var livepatchModuleName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// GuestLivepatch is the list of the kernel livepatch modules, kpatch or
+// livepatch, that can be applied to the running guests. Livepatching is
+// disabled unless modules are allowed.
+type GuestLivepatch struct {
+	// Modules are the host paths of the modules, signed with a key the
+	// guest kernel trusts. A module is named after its file.
+	Modules []string
+}
+
+func (l GuestLivepatch) enabled() bool {
+	return len(l.Modules) > 0
+}
+
+func livepatchModule(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), livepatchModuleExt)
+}
+
+func (l GuestLivepatch) validate() error {
+	names := make(map[string]bool)
+
+	for _, m := range l.Modules {
+		if !filepath.IsAbs(m) || !strings.HasSuffix(m, livepatchModuleExt) {
+			return newConfigFieldError("Modules", fmt.Sprintf("Invalid livepatch module %q, expected an absolute path to a .ko file", m))
+		}
+
+		// The names are passed to the agent as a kernel parameter list.
+		name := livepatchModule(m)
+		if !livepatchModuleName.MatchString(name) {
+			return newConfigFieldError("Modules", fmt.Sprintf("Invalid livepatch module name %q", name))
+		}
+
+		if names[name] {
+			return newConfigFieldError("Modules", fmt.Sprintf("Duplicate livepatch module %q", name))
+		}
+		names[name] = true
+	}
+
+	return nil
+}
+
+// kernelParams returns the modules the agent accepts, which the guest
+// cannot change once booted.
+func (l GuestLivepatch) kernelParams() []Param {
+	if !l.enabled() {
+		return nil
+	}
+
+	var names []string
+	for _, m := range l.Modules {
+		names = append(names, livepatchModule(m))
+	}
+
+	return []Param{
+		{Key: agentLivepatchParam, Value: strings.Join(names, ",")},
+		{Key: moduleSigEnforceParam, Value: "1"},
+	}
+}
+
+// modulePath returns the host path of an allowed module.
+func (l GuestLivepatch) modulePath(name string) (string, error) {
+	for _, m := range l.Modules {
+		if livepatchModule(m) == name {
+			return m, nil
+		}
+	}
+
+	return "", fmt.Errorf("Livepatch module %q is not allowed", name)
+}
+
+// livepatch applies an allowed livepatch module to the running guest.
+// Applying a module the guest runs already does nothing.
+func (s *Sandbox) livepatch(module string) error {
+	span, _ := s.trace("livepatch")
+	defer span.Finish()
+
+	path, err := s.config.GuestLivepatch.modulePath(module)
+	if err != nil {
+		return err
+	}
+
+	if s.state.State != types.StateRunning {
+		return fmt.Errorf("Sandbox %s not running, impossible to livepatch", s.id)
+	}
+
+	guestPath := filepath.Join(guestLivepatchDir, module+livepatchModuleExt)
+	if err := s.agent.copyFile(path, guestPath); err != nil {
+		return fmt.Errorf("Could not copy livepatch module %s: %v", module, err)
+	}
+
+	if err := s.agent.loadLivepatch(module, guestPath); err != nil {
+		return fmt.Errorf("Could not apply livepatch module %s: %v", module, err)
+	}
+
+	s.Logger().WithField("module", module).Info("Guest livepatch applied")
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuestLivepatchValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(GuestLivepatch{}.validate())
+	assert.NoError(GuestLivepatch{Modules: []string{"/opt/livepatch/kpatch-cve-fix.ko", "/opt/livepatch/livepatch_sched.ko"}}.validate())
+
+	for _, modules := range [][]string{
+		{"kpatch-cve-fix.ko"},
+		{"/opt/livepatch/kpatch-cve-fix"},
+		{"/opt/livepatch/kpatch,fix.ko"},
+		{"/opt/livepatch/.ko"},
+		{"/opt/livepatch/kpatch-cve-fix.ko", "/usr/share/livepatch/kpatch-cve-fix.ko"},
+	} {
+		err := GuestLivepatch{Modules: modules}.validate()
+		assert.Error(err, "%v", modules)
+		assert.Equal("Modules", configFieldError("", err).Field)
+	}
+}
+
+func TestSandboxConfigLivepatchKernelModules(t *testing.T) {
+	assert := assert.New(t)
+
+	sandboxConfig := &SandboxConfig{
+		ID:             "test",
+		GuestLivepatch: GuestLivepatch{Modules: []string{"/opt/livepatch/kpatch-cve-fix.ko"}},
+	}
+	assert.NoError(sandboxConfig.validate())
+
+	// The agent kernel modules may not be signed
+	sandboxConfig.AgentConfig.KernelModules = []string{"e1000e InterruptThrottleRate=3000,3000,3000 EEE=1"}
+	err := sandboxConfig.validate()
+	assert.Error(err)
+	assert.Equal("GuestLivepatch", configFieldError("", err).Field)
+}
+
+func TestGuestLivepatchKernelParams(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(GuestLivepatch{}.kernelParams())
+	assert.Equal([]Param{
+		{Key: "agent.livepatch", Value: "kpatch-cve-fix,livepatch_sched"},
+		{Key: "module.sig_enforce", Value: "1"},
+	}, GuestLivepatch{Modules: []string{"/opt/livepatch/kpatch-cve-fix.ko", "/opt/livepatch/livepatch_sched.ko"}}.kernelParams())
+}
+
+func TestSandboxLivepatch(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id:    "sandbox",
+		ctx:   context.Background(),
+		agent: &mockAgent{},
+		config: &SandboxConfig{
+			GuestLivepatch: GuestLivepatch{Modules: []string{"/opt/livepatch/kpatch-cve-fix.ko"}},
+		},
+		state: types.SandboxState{State: types.StateRunning},
+	}
+
+	assert.NoError(s.livepatch("kpatch-cve-fix"))
+
+	// Only the allowed modules are applied
+	assert.Error(s.livepatch("kpatch-other"))
+	assert.Error(s.livepatch("/opt/livepatch/kpatch-cve-fix.ko"))
+
+	s.state.State = types.StatePaused
+	assert.Error(s.livepatch("kpatch-cve-fix"))
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	agentCacheDropIntervalParam  = "agent.cache_drop_interval"
+	agentCacheDropThresholdParam = "agent.cache_drop_threshold"
+	agentVFSCachePressureParam   = "agent.vfs_cache_pressure"
+	agentIdleScanIntervalParam   = "agent.idle_scan_interval"
+)
+
+// GuestMemoryReclaim is how the guest gives back the memory it only uses as
+// cache, so that the memory of the sandbox, as the host sees it, is close
+// to its working set. The guest reports the pages it frees when the
+// hypervisor has free page reporting enabled.
+type GuestMemoryReclaim struct {
+	// CacheDropInterval is how often the agent drops the clean page
+	// cache of the guest, never if 0.
+	CacheDropInterval time.Duration
+
+	// CacheDropThresholdMB is the size of the page cache, in MiB, below
+	// which it is not dropped.
+	CacheDropThresholdMB uint32
+
+	// VFSCachePressure is the vm.vfs_cache_pressure of the guest, how
+	// much the kernel reclaims the dentry and inode caches, the kernel
+	// default (100) if 0.
+	VFSCachePressure uint32
+
+	// IdleScanInterval is how often the agent scans the guest memory for
+	// the pages the containers did not access since the previous scan,
+	// reported as their idle memory, never if 0.
+	IdleScanInterval time.Duration
+}
+
+func (r GuestMemoryReclaim) enabled() bool {
+	return r.CacheDropInterval > 0 || r.VFSCachePressure > 0 || r.IdleScanInterval > 0
+}
+
+func (r GuestMemoryReclaim) validate() error {
+	if r.CacheDropInterval < 0 {
+		return newConfigFieldError("CacheDropInterval", "Cache drop interval cannot be negative")
+	}
+
+	// The agent is passed whole seconds.
+	if r.CacheDropInterval > 0 && r.CacheDropInterval < time.Second {
+		return newConfigFieldError("CacheDropInterval", fmt.Sprintf("Cache drop interval %v is shorter than a second", r.CacheDropInterval))
+	}
+
+	if r.IdleScanInterval < 0 {
+		return newConfigFieldError("IdleScanInterval", "Idle scan interval cannot be negative")
+	}
+
+	if r.IdleScanInterval > 0 && r.IdleScanInterval < time.Second {
+		return newConfigFieldError("IdleScanInterval", fmt.Sprintf("Idle scan interval %v is shorter than a second", r.IdleScanInterval))
+	}
+
+	if r.CacheDropThresholdMB != 0 && r.CacheDropInterval == 0 {
+		return newConfigFieldError("CacheDropThresholdMB", "A cache drop threshold requires a cache drop interval")
+	}
+
+	return nil
+}
+
+func (r GuestMemoryReclaim) kernelParams() []Param {
+	var params []Param
+
+	if r.CacheDropInterval > 0 {
+		params = append(params,
+			Param{Key: agentCacheDropIntervalParam, Value: strconv.FormatInt(int64(r.CacheDropInterval/time.Second), 10)},
+			Param{Key: agentCacheDropThresholdParam, Value: strconv.FormatUint(uint64(r.CacheDropThresholdMB), 10)})
+	}
+
+	if r.VFSCachePressure > 0 {
+		params = append(params, Param{Key: agentVFSCachePressureParam, Value: strconv.FormatUint(uint64(r.VFSCachePressure), 10)})
+	}
+
+	if r.IdleScanInterval > 0 {
+		params = append(params, Param{Key: agentIdleScanIntervalParam, Value: strconv.FormatInt(int64(r.IdleScanInterval/time.Second), 10)})
+	}
+
+	return params
+}
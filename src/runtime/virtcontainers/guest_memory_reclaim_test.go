@@ -0,0 +1,47 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuestMemoryReclaimValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(GuestMemoryReclaim{}.validate())
+	assert.NoError(GuestMemoryReclaim{VFSCachePressure: 200}.validate())
+	assert.NoError(GuestMemoryReclaim{CacheDropInterval: time.Minute, CacheDropThresholdMB: 128}.validate())
+	assert.NoError(GuestMemoryReclaim{IdleScanInterval: 2 * time.Minute}.validate())
+
+	for _, r := range []GuestMemoryReclaim{
+		{CacheDropInterval: -time.Second},
+		{CacheDropInterval: 500 * time.Millisecond},
+		{CacheDropThresholdMB: 128},
+		{IdleScanInterval: -time.Second},
+		{IdleScanInterval: 500 * time.Millisecond},
+	} {
+		assert.Error(r.validate(), "%+v", r)
+	}
+}
+
+func TestGuestMemoryReclaimKernelParams(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(GuestMemoryReclaim{}.kernelParams())
+	assert.Equal([]Param{
+		{Key: "agent.cache_drop_interval", Value: "90"},
+		{Key: "agent.cache_drop_threshold", Value: "128"},
+		{Key: "agent.vfs_cache_pressure", Value: "200"},
+	}, GuestMemoryReclaim{CacheDropInterval: 90 * time.Second, CacheDropThresholdMB: 128, VFSCachePressure: 200}.kernelParams())
+
+	reclaim := GuestMemoryReclaim{IdleScanInterval: 2 * time.Minute}
+	assert.True(reclaim.enabled())
+	assert.Equal([]Param{{Key: "agent.idle_scan_interval", Value: "120"}}, reclaim.kernelParams())
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	deviceManager "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
+)
+
+// sysNodePath is where the host NUMA nodes are described.
+var sysNodePath = "/sys/devices/system/node"
+
+// setupGuestNUMA sets the NUMA nodes of the VM of a new sandbox from the
+// host NUMA locality of the VFIO devices its containers are created with,
+// and of their cpusets when the vCPUs are pinned. The VM keeps a single
+// NUMA node when the host does not know the locality of any of them.
+func setupGuestNUMA(sandboxConfig *SandboxConfig) error {
+	if sandboxConfig.HypervisorConfig.GuestNUMANodes != nil {
+		return nil
+	}
+
+	var devices []config.DeviceInfo
+	for _, c := range sandboxConfig.Containers {
+		for _, d := range c.DeviceInfos {
+			if deviceManager.IsVFIO(d.HostPath) {
+				devices = append(devices, d)
+			}
+		}
+	}
+
+	var nodes []GuestNUMANode
+	if len(devices) > 0 {
+		topology, err := deviceManager.GetDeviceTopology(devices)
+		if err != nil {
+			return err
+		}
+		nodes = guestNUMANodes(topology)
+	}
+
+	if sandboxConfig.HypervisorConfig.VCPUPinning {
+		var err error
+		if nodes, err = appendCPUSetNUMANodes(nodes, sandboxConfig.Containers); err != nil {
+			return err
+		}
+	}
+
+	sandboxConfig.HypervisorConfig.GuestNUMANodes = nodes
+
+	return nil
+}
+
+// guestNUMANodes returns a guest NUMA node for each host NUMA node of the
+// devices, with the PCI devices attached to it. A device attached to
+// several host nodes, or to none the host knows of, is not attached to any
+// guest node.
+func guestNUMANodes(topology config.DeviceTopology) []GuestNUMANode {
+	var nodes []GuestNUMANode
+
+	index := make(map[int]int)
+	for _, node := range topology.NUMANodes {
+		index[node] = len(nodes)
+		nodes = append(nodes, GuestNUMANode{HostNode: node})
+	}
+
+	for _, l := range topology.Devices {
+		if len(l.NUMANodes) != 1 {
+			continue
+		}
+
+		node := &nodes[index[l.NUMANodes[0]]]
+		for _, bdf := range l.PCIDevices {
+			// The BDF of a VFIODev has no PCI domain, such as
+			// 3b:00.2 for 0000:3b:00.2.
+			if tokens := strings.SplitN(bdf, ":", 2); len(tokens) == 2 {
+				node.PCIDevices = append(node.PCIDevices, tokens[1])
+			}
+		}
+	}
+
+	return nodes
+}
+
+// guestNUMANodeOfVCPU returns the index of the guest NUMA node of a vCPU
+// among nodes. The boot vCPUs are spread evenly across the nodes, and so
+// are the vCPUs that can be hotplugged, each node getting a contiguous
+// range of either.
+func guestNUMANodeOfVCPU(vcpu, numVCPUs, maxVCPUs uint32, nodes int) int {
+	if vcpu < numVCPUs {
+		return int(uint64(vcpu) * uint64(nodes) / uint64(numVCPUs))
+	}
+
+	return int(uint64(vcpu-numVCPUs) * uint64(nodes) / uint64(maxVCPUs-numVCPUs))
+}
+
+// hostNUMANodeCPUs returns the CPUs of a host NUMA node.
+func hostNUMANodeCPUs(node int) ([]int, error) {
+	data, err := ioutil.ReadFile(filepath.Join(sysNodePath, fmt.Sprintf("node%d", node), "cpulist"))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCPUList(strings.TrimSpace(string(data)))
+}
+
+// parseCPUList parses a list of CPUs in the kernel format, such as
+// "0-3,8,10-11".
+func parseCPUList(list string) ([]int, error) {
+	var cpus []int
+
+	if list == "" {
+		return cpus, nil
+	}
+
+	for _, r := range strings.Split(list, ",") {
+		bounds := strings.SplitN(r, "-", 2)
+
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid CPU list %q: %v", list, err)
+		}
+
+		last := first
+		if len(bounds) == 2 {
+			if last, err = strconv.Atoi(bounds[1]); err != nil {
+				return nil, fmt.Errorf("Invalid CPU list %q: %v", list, err)
+			}
+		}
+
+		if last < first {
+			return nil, fmt.Errorf("Invalid CPU list %q: range %s is reversed", list, r)
+		}
+
+		for cpu := first; cpu <= last; cpu++ {
+			cpus = append(cpus, cpu)
+		}
+	}
+
+	return cpus, nil
+}
+
+// setVCPUsAffinity pins the vCPU threads of a VM with guest NUMA nodes to
+// the CPUs of the host NUMA nodes their guest nodes mirror, or each one to
+// a single CPU with VCPUPinning. A vCPU thread the cpuset of the sandbox
+// does not let run there is left as it is.
+func (s *Sandbox) setVCPUsAffinity() error {
+	hConfig := s.config.HypervisorConfig
+
+	if hConfig.VCPUPinning {
+		return s.pinVCPUs()
+	}
+
+	nodes := hConfig.GuestNUMANodes
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	tids, err := s.hypervisor.getThreadIDs()
+	if err != nil {
+		return fmt.Errorf("failed to get thread ids from hypervisor: %v", err)
+	}
+
+	sets := make(map[int]*unix.CPUSet)
+	for vcpu, tid := range tids.vcpus {
+		node := nodes[guestNUMANodeOfVCPU(uint32(vcpu), hConfig.NumVCPUs, hConfig.DefaultMaxVCPUs, len(nodes))]
+
+		set, ok := sets[node.HostNode]
+		if !ok {
+			cpus, err := hostNUMANodeCPUs(node.HostNode)
+			if err != nil {
+				return err
+			}
+
+			set = &unix.CPUSet{}
+			for _, cpu := range cpus {
+				set.Set(cpu)
+			}
+			sets[node.HostNode] = set
+		}
+
+		if err := unix.SchedSetaffinity(tid, set); err != nil {
+			s.Logger().WithError(err).WithFields(logrus.Fields{
+				"vcpu":      vcpu,
+				"host-node": node.HostNode,
+			}).Warn("Could not pin vCPU thread to its host NUMA node")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuestNUMANodes(t *testing.T) {
+	assert := assert.New(t)
+
+	topology := config.DeviceTopology{
+		Devices: []config.DeviceLocality{
+			{HostPath: "/dev/vfio/1", PCIDevices: []string{"0000:3b:00.0", "0000:3b:00.1"}, NUMANodes: []int{1}},
+			{HostPath: "/dev/vfio/2", PCIDevices: []string{"0000:d8:00.0"}, NUMANodes: []int{3}},
+			{HostPath: "/dev/vfio/3", PCIDevices: []string{"0000:05:00.0"}},
+			{HostPath: "/dev/vfio/4", PCIDevices: []string{"0000:af:00.0"}, NUMANodes: []int{1}},
+		},
+		NUMANodes: []int{1, 3},
+	}
+
+	assert.Equal([]GuestNUMANode{
+		{HostNode: 1, PCIDevices: []string{"3b:00.0", "3b:00.1", "af:00.0"}},
+		{HostNode: 3, PCIDevices: []string{"d8:00.0"}},
+	}, guestNUMANodes(topology))
+
+	assert.Empty(guestNUMANodes(config.DeviceTopology{}))
+}
+
+func TestGuestNUMANodeOfVCPU(t *testing.T) {
+	assert := assert.New(t)
+
+	// 4 boot vCPUs and 4 hotpluggable ones on 2 nodes
+	var nodes []int
+	for vcpu := uint32(0); vcpu < 8; vcpu++ {
+		nodes = append(nodes, guestNUMANodeOfVCPU(vcpu, 4, 8, 2))
+	}
+	assert.Equal([]int{0, 0, 1, 1, 0, 0, 1, 1}, nodes)
+
+	// 3 boot vCPUs on 2 nodes
+	assert.Equal(0, guestNUMANodeOfVCPU(1, 3, 3, 2))
+	assert.Equal(1, guestNUMANodeOfVCPU(2, 3, 3, 2))
+}
+
+func TestParseCPUList(t *testing.T) {
+	assert := assert.New(t)
+
+	cpus, err := parseCPUList("0-3,8,10-11")
+	assert.NoError(err)
+	assert.Equal([]int{0, 1, 2, 3, 8, 10, 11}, cpus)
+
+	cpus, err = parseCPUList("")
+	assert.NoError(err)
+	assert.Empty(cpus)
+
+	for _, list := range []string{"a", "0-b", "3-1", "0,,1"} {
+		_, err = parseCPUList(list)
+		assert.Error(err, list)
+	}
+}
+
+func TestHostNUMANodeCPUs(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "node")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpDir)
+
+	savedSysNodePath := sysNodePath
+	sysNodePath = tmpDir
+	defer func() {
+		sysNodePath = savedSysNodePath
+	}()
+
+	assert.NoError(os.MkdirAll(filepath.Join(tmpDir, "node1"), DirMode))
+	assert.NoError(ioutil.WriteFile(filepath.Join(tmpDir, "node1", "cpulist"), []byte("4-5,12\n"), 0644))
+
+	cpus, err := hostNUMANodeCPUs(1)
+	assert.NoError(err)
+	assert.Equal([]int{4, 5, 12}, cpus)
+
+	_, err = hostNUMANodeCPUs(0)
+	assert.Error(err)
+}
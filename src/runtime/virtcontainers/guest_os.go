@@ -0,0 +1,153 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"strings"
+
+	exp "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/experimental"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+const (
+	// GuestOSLinux is a Linux guest running the kata agent, the default.
+	GuestOSLinux = "linux"
+
+	// GuestOSOther is any other guest not running the kata agent, such as
+	// a unikernel. It boots from the configured kernel, like Linux guests,
+	// which rules out the guests booting from firmware only, as Windows.
+	GuestOSOther = "other"
+
+	// payloadArgsSeparator separates the kernel parameters from the
+	// arguments of the payload on the kernel command line.
+	payloadArgsSeparator = "--"
+)
+
+var (
+	// ForeignGuestFeature enables the sandboxes whose guest is not a Linux
+	// guest running the kata agent.
+	ForeignGuestFeature = exp.Feature{
+		Name:        "foreign_guest",
+		Description: "Boot guests not running the kata agent, with a start/stop/status only lifecycle and the I/O going through the serial console.",
+		ExpRelease:  "3.0",
+	}
+
+	foreignGuestErr = exp.Register(ForeignGuestFeature)
+)
+
+// isForeignGuest returns true if the sandbox guest does not run the kata
+// agent.
+func (sandboxConfig *SandboxConfig) isForeignGuest() bool {
+	return sandboxConfig.GuestOS != "" && sandboxConfig.GuestOS != GuestOSLinux
+}
+
+// checkGuestOS checks that the guest OS of the sandbox is supported, and
+// that the foreign guests are only used when experimenting.
+func checkGuestOS(sandboxConfig *SandboxConfig, factory Factory) error {
+	switch sandboxConfig.GuestOS {
+	case "", GuestOSLinux:
+		return nil
+	case GuestOSOther:
+	default:
+		return fmt.Errorf("Invalid guest OS %q, the supported guest OSes are %q and %q", sandboxConfig.GuestOS, GuestOSLinux, GuestOSOther)
+	}
+
+	if foreignGuestErr != nil {
+		return foreignGuestErr
+	}
+
+	enabled := false
+	for _, f := range sandboxConfig.Experimental {
+		if f.Name == ForeignGuestFeature.Name {
+			enabled = true
+			break
+		}
+	}
+	if !enabled {
+		return fmt.Errorf("Guest OS %q requires the %q experimental feature", sandboxConfig.GuestOS, ForeignGuestFeature.Name)
+	}
+
+	if factory != nil {
+		return fmt.Errorf("Guest OS %q cannot be used with the VM factory", sandboxConfig.GuestOS)
+	}
+
+	return nil
+}
+
+// payloadArgs returns the arguments of the sandbox container, which are
+// passed on the kernel command line of a GuestOSOther guest, after "--",
+// where unikernels and most launchers read them from.
+func payloadArgs(spec *specs.Spec) ([]string, error) {
+	if spec == nil || spec.Process == nil {
+		return nil, nil
+	}
+
+	for _, arg := range spec.Process.Args {
+		if arg == "" || strings.ContainsAny(arg, "\"\n") {
+			return nil, fmt.Errorf("Payload argument %q cannot be passed on the kernel command line", arg)
+		}
+	}
+
+	return spec.Process.Args, nil
+}
+
+// appendPayloadParams appends the payload arguments to the kernel
+// parameters params. The hypervisors call it last when building the kernel
+// command line, since the kernel hands every parameter after "--" over to
+// init.
+func (conf *HypervisorConfig) appendPayloadParams(params []Param) []Param {
+	if len(conf.PayloadArgs) == 0 {
+		return params
+	}
+
+	params = append(params, Param{Key: payloadArgsSeparator})
+	for _, arg := range conf.PayloadArgs {
+		if strings.ContainsAny(arg, " \t") {
+			arg = `"` + arg + `"`
+		}
+		params = append(params, Param{Key: arg})
+	}
+
+	return params
+}
+
+// reportedStates returns the states of the sandbox and of its containers.
+// No agent reports the states of a foreign guest, they follow its VM: once
+// the VM is gone, the sandbox and its containers are stopped, whatever the
+// recorded states.
+func (s *Sandbox) reportedStates() (types.SandboxState, map[string]types.ContainerState) {
+	sandboxState := s.state
+	containerStates := make(map[string]types.ContainerState, len(s.containers))
+	for id, c := range s.containers {
+		containerStates[id] = c.state
+	}
+
+	if !s.config.isForeignGuest() {
+		return sandboxState, containerStates
+	}
+
+	switch sandboxState.State {
+	case types.StateRunning, types.StatePaused, types.StateSuspended:
+	default:
+		return sandboxState, containerStates
+	}
+
+	if err := s.hypervisor.check(); err == nil {
+		return sandboxState, containerStates
+	}
+
+	sandboxState.State = types.StateStopped
+	for id, state := range containerStates {
+		if state.State != types.StateReady {
+			state.State = types.StateStopped
+			containerStates[id] = state
+		}
+	}
+
+	return sandboxState, containerStates
+}
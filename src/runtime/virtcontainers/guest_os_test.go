@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	exp "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/experimental"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// noopFactory is a Factory whose methods are never called.
+type noopFactory struct {
+	Factory
+}
+
+func TestCheckGuestOS(t *testing.T) {
+	assert := assert.New(t)
+
+	config := &SandboxConfig{}
+	assert.NoError(checkGuestOS(config, nil))
+	assert.False(config.isForeignGuest())
+
+	config.GuestOS = GuestOSLinux
+	assert.NoError(checkGuestOS(config, nil))
+	assert.False(config.isForeignGuest())
+
+	config.GuestOS = "plan9"
+	assert.Error(checkGuestOS(config, nil))
+
+	// The experimental feature is required
+	config.GuestOS = GuestOSOther
+	assert.True(config.isForeignGuest())
+	assert.Error(checkGuestOS(config, nil))
+
+	config.Experimental = []exp.Feature{ForeignGuestFeature}
+	assert.NoError(checkGuestOS(config, nil))
+
+	// Guests booting from firmware only are not supported
+	config.GuestOS = "windows"
+	assert.Error(checkGuestOS(config, nil))
+
+	config.GuestOS = GuestOSOther
+
+	// The VM factory cannot be used
+	assert.Error(checkGuestOS(config, &noopFactory{}))
+}
+
+func TestPayloadArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	args, err := payloadArgs(nil)
+	assert.NoError(err)
+	assert.Empty(args)
+
+	spec := &specs.Spec{Process: &specs.Process{}}
+	args, err = payloadArgs(spec)
+	assert.NoError(err)
+	assert.Empty(args)
+
+	spec.Process.Args = []string{"/app", "--name", "hello world"}
+	args, err = payloadArgs(spec)
+	assert.NoError(err)
+	assert.Equal(spec.Process.Args, args)
+
+	spec.Process.Args = []string{"/app", "say \"hi\""}
+	_, err = payloadArgs(spec)
+	assert.Error(err)
+
+	spec.Process.Args = []string{"/app", ""}
+	_, err = payloadArgs(spec)
+	assert.Error(err)
+}
+
+func TestAppendPayloadParams(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := &HypervisorConfig{}
+	params := []Param{{Key: "console", Value: "ttyS0"}}
+	assert.Equal(params, conf.appendPayloadParams(params))
+
+	conf.PayloadArgs = []string{"/app", "--name", "hello world"}
+	params = conf.appendPayloadParams(params)
+	assert.Equal("console=ttyS0 -- /app --name \"hello world\"", strings.Join(SerializeParams(params, "="), " "))
+}
+
+func TestSandboxReportedStates(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		hypervisor: &goneHypervisor{},
+		config:     &SandboxConfig{},
+		state:      types.SandboxState{State: types.StateRunning},
+		containers: map[string]*Container{
+			"running": {config: &ContainerConfig{}, state: types.ContainerState{State: types.StateRunning}},
+			"ready":   {config: &ContainerConfig{}, state: types.ContainerState{State: types.StateReady}},
+		},
+	}
+
+	// The agent reports the states of Linux guests
+	state, containerStates := s.reportedStates()
+	assert.Equal(types.StateRunning, state.State)
+	assert.Equal(types.StateRunning, containerStates["running"].State)
+
+	// Foreign guests follow their VM
+	s.config.GuestOS = GuestOSOther
+	state, containerStates = s.reportedStates()
+	assert.Equal(types.StateStopped, state.State)
+	assert.Equal(types.StateStopped, containerStates["running"].State)
+	assert.Equal(types.StateReady, containerStates["ready"].State)
+	assert.Equal(types.StateStopped, s.Status().State.State)
+
+	// The recorded states are left untouched
+	assert.Equal(types.StateRunning, s.state.State)
+	assert.Equal(types.StateRunning, s.containers["running"].state.State)
+
+	s.hypervisor = &mockHypervisor{}
+	state, containerStates = s.reportedStates()
+	assert.Equal(types.StateRunning, state.State)
+	assert.Equal(types.StateRunning, containerStates["running"].State)
+}
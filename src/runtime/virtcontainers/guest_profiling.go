@@ -0,0 +1,280 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ProfileTool is a profiling tool shipped with the guest image.
+type ProfileTool string
+
+const (
+	// ProfilePerf samples the whole guest with perf record, and streams
+	// the samples in the perf pipe format.
+	ProfilePerf ProfileTool = "perf"
+
+	// ProfileBpftrace runs a bpftrace script shipped with the guest image,
+	// and streams its output.
+	ProfileBpftrace ProfileTool = "bpftrace"
+)
+
+const (
+	// guestProfilingDir is the directory of the profiling sessions in the
+	// sandbox directory and in the shared directory.
+	guestProfilingDir = "profiling"
+
+	profileOutputFile = "output"
+	profileStatusFile = "status"
+
+	agentProfilingParam = "agent.profiling"
+
+	defaultGuestProfilingMaxDuration = time.Minute
+
+	profilePollInterval = 100 * time.Millisecond
+
+	// profileStopTimeout is how long the session output is waited for
+	// past the session duration, the agent interrupting the tool.
+	profileStopTimeout = 10 * time.Second
+)
+
+// GuestProfiling is the policy of the profiling sessions run in the guest.
+// Profiling is disabled unless tools are allowed.
+type GuestProfiling struct {
+	// Tools are the profiling tools the sessions can run.
+	Tools []string
+
+	// MaxDuration is the longest session allowed, a minute if 0.
+	MaxDuration time.Duration
+}
+
+// ProfileRequest is a profiling session run in the guest.
+type ProfileRequest struct {
+	Tool     ProfileTool
+	Duration time.Duration
+
+	// Frequency is the perf sampling frequency, in Hz, the perf default
+	// if 0.
+	Frequency uint32
+
+	// Script is the name of the bpftrace script, shipped with the guest
+	// image.
+	Script string
+}
+
+func (p GuestProfiling) enabled() bool {
+	return len(p.Tools) > 0
+}
+
+func (p GuestProfiling) maxDuration() time.Duration {
+	if p.MaxDuration == 0 {
+		return defaultGuestProfilingMaxDuration
+	}
+	return p.MaxDuration
+}
+
+func (p GuestProfiling) validate() error {
+	for _, t := range p.Tools {
+		switch ProfileTool(t) {
+		case ProfilePerf, ProfileBpftrace:
+		default:
+			return newConfigFieldError("Tools", fmt.Sprintf("Unknown profiling tool %q", t))
+		}
+	}
+
+	if p.MaxDuration < 0 {
+		return newConfigFieldError("MaxDuration", "Profiling duration cannot be negative")
+	}
+
+	return nil
+}
+
+func (p GuestProfiling) kernelParams() []Param {
+	if !p.enabled() {
+		return nil
+	}
+
+	return []Param{{Key: agentProfilingParam, Value: strings.Join(p.Tools, ",")}}
+}
+
+// check returns an error if the policy does not allow a session.
+func (p GuestProfiling) check(req ProfileRequest) error {
+	allowed := false
+	for _, t := range p.Tools {
+		allowed = allowed || ProfileTool(t) == req.Tool
+	}
+	if !allowed {
+		return fmt.Errorf("Profiling tool %q is not allowed", req.Tool)
+	}
+
+	if req.Duration < time.Second || req.Duration > p.maxDuration() {
+		return fmt.Errorf("Profiling duration must be between 1s and %v", p.maxDuration())
+	}
+
+	if req.Tool == ProfileBpftrace && (req.Script == "" || strings.ContainsAny(req.Script, "/\n=")) {
+		return fmt.Errorf("Invalid bpftrace script %q", req.Script)
+	}
+
+	return nil
+}
+
+// setupGuestProfiling shares the directory the guest writes the output of
+// the profiling sessions to.
+func (s *Sandbox) setupGuestProfiling() error {
+	if !s.config.GuestProfiling.enabled() {
+		return nil
+	}
+
+	caps := s.hypervisor.capabilities()
+	if !caps.IsFsSharingSupported() {
+		s.Logger().Warn("Guest profiling disabled without filesystem sharing")
+		return nil
+	}
+
+	dir := filepath.Join(getSandboxPath(s.id), guestProfilingDir)
+	if err := os.MkdirAll(dir, DirMode); err != nil {
+		return err
+	}
+
+	return bindMount(s.ctx, dir, filepath.Join(getMountPath(s.id), guestProfilingDir), false, "private")
+}
+
+// ProfileGuest runs a profiling session in the guest, as the sandbox policy
+// allows, and returns its output, streamed while the session runs. Closing
+// the output discards the session.
+func (s *Sandbox) ProfileGuest(req ProfileRequest) (io.ReadCloser, error) {
+	if err := s.config.GuestProfiling.check(req); err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(getSandboxPath(s.id), guestProfilingDir)
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("Guest profiling is not set up: %v", err)
+	}
+
+	session := strconv.FormatInt(time.Now().UnixNano(), 10)
+	sessionDir := filepath.Join(dir, session)
+	if err := os.Mkdir(sessionDir, DirMode); err != nil {
+		return nil, err
+	}
+
+	if err := s.agent.startProfiling(session, req); err != nil {
+		os.RemoveAll(sessionDir)
+		return nil, fmt.Errorf("Could not start the profiling session: %v", err)
+	}
+
+	s.Logger().WithField("session", session).WithField("tool", req.Tool).Info("Guest profiling session started")
+
+	return &profileReader{
+		dir:      sessionDir,
+		deadline: time.Now().Add(req.Duration + profileStopTimeout),
+	}, nil
+}
+
+// profileReader reads the output of a profiling session as the guest
+// writes it, until the guest writes the session status.
+type profileReader struct {
+	dir      string
+	deadline time.Time
+	output   *os.File
+	done     bool
+	err      error
+}
+
+// status reads the session status, if written, which is "0" when the
+// session succeeded, the exit code and the error of the tool otherwise.
+func (r *profileReader) status() (bool, error) {
+	data, err := ioutil.ReadFile(filepath.Join(r.dir, profileStatusFile))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return true, err
+	}
+
+	lines := strings.SplitN(string(data), "\n", 2)
+	if lines[0] == "0" {
+		return true, nil
+	}
+
+	msg := ""
+	if len(lines) == 2 {
+		msg = strings.TrimSpace(lines[1])
+	}
+	return true, fmt.Errorf("Profiling session failed with status %s: %s", lines[0], msg)
+}
+
+func (r *profileReader) Read(b []byte) (int, error) {
+	for {
+		if r.output == nil {
+			f, err := os.Open(filepath.Join(r.dir, profileOutputFile))
+			if err != nil && !os.IsNotExist(err) {
+				return 0, err
+			}
+			r.output = f
+		}
+
+		if r.output != nil {
+			n, err := r.output.Read(b)
+			if n > 0 || err != io.EOF {
+				return n, err
+			}
+		}
+
+		// The output is read to its end once the status is written.
+		if r.done {
+			if r.err == nil {
+				return 0, io.EOF
+			}
+			return 0, r.err
+		}
+
+		if r.done, r.err = r.status(); r.done {
+			continue
+		}
+
+		if time.Now().After(r.deadline) {
+			return 0, fmt.Errorf("Profiling session timed out")
+		}
+
+		time.Sleep(profilePollInterval)
+	}
+}
+
+func (r *profileReader) Close() error {
+	if r.output != nil {
+		r.output.Close()
+	}
+
+	return os.RemoveAll(r.dir)
+}
+
+// bindUnmountGuestProfilingDir unmounts the profiling sessions directory
+// from the shared directory, if shared.
+func bindUnmountGuestProfilingDir(ctx context.Context, sharedDir string) error {
+	span, _ := trace(ctx, "bindUnmountGuestProfilingDir")
+	defer span.Finish()
+
+	dest := filepath.Join(sharedDir, guestProfilingDir)
+	if _, err := os.Lstat(dest); os.IsNotExist(err) {
+		return nil
+	}
+
+	if err := syscall.Unmount(dest, syscall.MNT_DETACH|UmountNoFollow); err != nil && err != syscall.EINVAL {
+		return err
+	}
+
+	return os.Remove(dest)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuestProfilingValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(GuestProfiling{}.validate())
+	assert.NoError(GuestProfiling{Tools: []string{"perf", "bpftrace"}, MaxDuration: time.Minute}.validate())
+
+	err := GuestProfiling{Tools: []string{"gdb"}}.validate()
+	assert.Error(err)
+	assert.Equal("Tools", configFieldError("", err).Field)
+
+	err = GuestProfiling{Tools: []string{"perf"}, MaxDuration: -time.Second}.validate()
+	assert.Error(err)
+	assert.Equal("MaxDuration", configFieldError("", err).Field)
+}
+
+func TestGuestProfilingKernelParams(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(GuestProfiling{}.kernelParams())
+	assert.Equal([]Param{{Key: "agent.profiling", Value: "perf,bpftrace"}},
+		GuestProfiling{Tools: []string{"perf", "bpftrace"}}.kernelParams())
+}
+
+func TestGuestProfilingCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	p := GuestProfiling{Tools: []string{"bpftrace"}}
+
+	assert.NoError(p.check(ProfileRequest{Tool: ProfileBpftrace, Duration: time.Minute, Script: "runqlat"}))
+
+	// Profiling is disabled by default
+	assert.Error(GuestProfiling{}.check(ProfileRequest{Tool: ProfilePerf, Duration: time.Second}))
+
+	for _, req := range []ProfileRequest{
+		{Tool: ProfilePerf, Duration: time.Second},
+		{Tool: ProfileBpftrace, Duration: time.Millisecond, Script: "runqlat"},
+		{Tool: ProfileBpftrace, Duration: 2 * time.Minute, Script: "runqlat"},
+		{Tool: ProfileBpftrace, Duration: time.Second},
+		{Tool: ProfileBpftrace, Duration: time.Second, Script: "../runqlat"},
+		{Tool: ProfileBpftrace, Duration: time.Second, Script: "runqlat\ntool=perf"},
+	} {
+		assert.Error(p.check(req), "%+v", req)
+	}
+}
+
+func TestProfileReader(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "profiling")
+	assert.NoError(err)
+
+	output := filepath.Join(dir, profileOutputFile)
+	status := filepath.Join(dir, profileStatusFile)
+
+	r := &profileReader{dir: dir, deadline: time.Now().Add(time.Minute)}
+
+	assert.NoError(ioutil.WriteFile(output, []byte("samples"), 0600))
+	assert.NoError(ioutil.WriteFile(status, []byte("0\n"), 0600))
+
+	data, err := ioutil.ReadAll(r)
+	assert.NoError(err)
+	assert.Equal("samples", string(data))
+
+	assert.NoError(r.Close())
+	_, err = os.Stat(dir)
+	assert.True(os.IsNotExist(err))
+
+	// The error of a failed session is returned once its output is read.
+	dir, err = ioutil.TempDir("", "profiling")
+	assert.NoError(err)
+
+	r = &profileReader{dir: dir, deadline: time.Now().Add(time.Minute)}
+	defer r.Close()
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, profileStatusFile), []byte("1\nno such script\n"), 0600))
+
+	_, err = ioutil.ReadAll(r)
+	assert.EqualError(err, "Profiling session failed with status 1: no such script")
+
+	// A session writing no status times out.
+	assert.NoError(os.Remove(filepath.Join(dir, profileStatusFile)))
+	_, err = ioutil.ReadAll(&profileReader{dir: dir, deadline: time.Now()})
+	assert.EqualError(err, "Profiling session timed out")
+}
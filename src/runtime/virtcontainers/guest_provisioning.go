@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// guestProvisioningDir is where the files are copied in the guest,
+	// under their guest path, before the agent installs them.
+	guestProvisioningDir = "/run/kata-containers/provisioning"
+
+	hostZoneinfoDir    = "/usr/share/zoneinfo"
+	guestLocaltimePath = "/etc/localtime"
+	guestCABundlePath  = "/etc/ssl/certs/ca-certificates.crt"
+
+	agentLocaleParam = "agent.locale"
+)
+
+// GuestFile is a host file installed in the guest at boot.
+type GuestFile struct {
+	// HostPath is the path of the file on the host.
+	HostPath string
+
+	// GuestPath is the absolute path of the file in the guest, replaced
+	// if it exists.
+	GuestPath string
+}
+
+// GuestProvisioning are the host files and settings installed in the guest
+// at boot by the agent, to avoid building guest images for them. They apply
+// to the guest itself, not to the container root filesystems.
+type GuestProvisioning struct {
+	// Files are the host files installed in the guest.
+	Files []GuestFile
+
+	// Timezone is the guest timezone, a name of the host timezone
+	// database such as "Europe/Paris", installed as /etc/localtime.
+	Timezone string
+
+	// CABundle is the host path of the certificate authorities bundle of
+	// the guest, installed as /etc/ssl/certs/ca-certificates.crt.
+	CABundle string
+
+	// Locale is the guest locale, such as "en_US.UTF-8".
+	Locale string
+}
+
+func (p GuestProvisioning) validate() error {
+	for i, f := range p.Files {
+		if !filepath.IsAbs(f.HostPath) {
+			return newConfigFieldError(fmt.Sprintf("Files[%d].HostPath", i), fmt.Sprintf("Host path %q is not absolute", f.HostPath))
+		}
+
+		if !filepath.IsAbs(f.GuestPath) || filepath.Clean(f.GuestPath) != f.GuestPath || f.GuestPath == "/" {
+			return newConfigFieldError(fmt.Sprintf("Files[%d].GuestPath", i), fmt.Sprintf("Invalid guest path %q", f.GuestPath))
+		}
+	}
+
+	if p.Timezone != "" && (filepath.IsAbs(p.Timezone) || filepath.Clean(p.Timezone) != p.Timezone || strings.HasPrefix(p.Timezone, "..")) {
+		return newConfigFieldError("Timezone", fmt.Sprintf("Invalid timezone %q", p.Timezone))
+	}
+
+	if p.CABundle != "" && !filepath.IsAbs(p.CABundle) {
+		return newConfigFieldError("CABundle", fmt.Sprintf("Host path %q is not absolute", p.CABundle))
+	}
+
+	// The locale is passed on the kernel command line.
+	if strings.ContainsAny(p.Locale, " \t\n\"") {
+		return newConfigFieldError("Locale", fmt.Sprintf("Invalid locale %q", p.Locale))
+	}
+
+	return nil
+}
+
+// files returns all the files to install in the guest.
+func (p GuestProvisioning) files() []GuestFile {
+	var files []GuestFile
+
+	if p.Timezone != "" {
+		files = append(files, GuestFile{
+			HostPath:  filepath.Join(hostZoneinfoDir, p.Timezone),
+			GuestPath: guestLocaltimePath,
+		})
+	}
+
+	if p.CABundle != "" {
+		files = append(files, GuestFile{
+			HostPath:  p.CABundle,
+			GuestPath: guestCABundlePath,
+		})
+	}
+
+	// Last, to override the others.
+	return append(files, p.Files...)
+}
+
+func (p GuestProvisioning) kernelParams() []Param {
+	if p.Locale == "" {
+		return nil
+	}
+
+	return []Param{{Key: agentLocaleParam, Value: p.Locale}}
+}
+
+// provisionGuest copies the files to install in the guest, and has the
+// agent install them, once it is up.
+func (s *Sandbox) provisionGuest() error {
+	for _, f := range s.config.GuestProvisioning.files() {
+		staged := filepath.Join(guestProvisioningDir, f.GuestPath)
+		if err := s.agent.copyFile(f.HostPath, staged); err != nil {
+			return fmt.Errorf("Could not copy %s to the guest: %v", f.GuestPath, err)
+		}
+
+		if err := s.agent.installFile(staged, f.GuestPath); err != nil {
+			return fmt.Errorf("Could not install %s in the guest: %v", f.GuestPath, err)
+		}
+	}
+
+	return nil
+}
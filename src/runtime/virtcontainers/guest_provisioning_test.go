@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuestProvisioningValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(GuestProvisioning{}.validate())
+	assert.NoError(GuestProvisioning{
+		Files:    []GuestFile{{HostPath: "/etc/pki/proxy.pem", GuestPath: "/usr/local/share/ca-certificates/proxy.crt"}},
+		Timezone: "Europe/Paris",
+		CABundle: "/etc/ssl/certs/ca-certificates.crt",
+		Locale:   "en_US.UTF-8",
+	}.validate())
+
+	for _, p := range []struct {
+		provisioning GuestProvisioning
+		field        string
+	}{
+		{GuestProvisioning{Files: []GuestFile{{HostPath: "proxy.pem", GuestPath: "/etc/proxy.pem"}}}, "Files[0].HostPath"},
+		{GuestProvisioning{Files: []GuestFile{{HostPath: "/proxy.pem", GuestPath: "etc/proxy.pem"}}}, "Files[0].GuestPath"},
+		{GuestProvisioning{Files: []GuestFile{{HostPath: "/proxy.pem", GuestPath: "/etc/../proxy.pem"}}}, "Files[0].GuestPath"},
+		{GuestProvisioning{Files: []GuestFile{{HostPath: "/proxy.pem", GuestPath: "/"}}}, "Files[0].GuestPath"},
+		{GuestProvisioning{Timezone: "../../../etc/shadow"}, "Timezone"},
+		{GuestProvisioning{Timezone: "/etc/localtime"}, "Timezone"},
+		{GuestProvisioning{CABundle: "ca.crt"}, "CABundle"},
+		{GuestProvisioning{Locale: "en_US.UTF-8 init=/bin/sh"}, "Locale"},
+	} {
+		err := p.provisioning.validate()
+		assert.Error(err)
+		assert.Equal(p.field, configFieldError("", err).Field)
+	}
+}
+
+func TestGuestProvisioningFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(GuestProvisioning{}.files())
+	assert.Empty(GuestProvisioning{}.kernelParams())
+
+	p := GuestProvisioning{
+		Files:    []GuestFile{{HostPath: "/etc/pki/proxy.pem", GuestPath: "/usr/local/share/ca-certificates/proxy.crt"}},
+		Timezone: "Europe/Paris",
+		CABundle: "/etc/pki/bundle.crt",
+		Locale:   "en_US.UTF-8",
+	}
+	assert.Equal([]GuestFile{
+		{HostPath: "/usr/share/zoneinfo/Europe/Paris", GuestPath: "/etc/localtime"},
+		{HostPath: "/etc/pki/bundle.crt", GuestPath: "/etc/ssl/certs/ca-certificates.crt"},
+		{HostPath: "/etc/pki/proxy.pem", GuestPath: "/usr/local/share/ca-certificates/proxy.crt"},
+	}, p.files())
+	assert.Equal([]string{"agent.locale=en_US.UTF-8"}, SerializeParams(p.kernelParams(), "="))
+}
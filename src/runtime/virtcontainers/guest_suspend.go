@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+// guestSuspendTimeout is how long the guest has to suspend itself to RAM
+// once the agent accepted to suspend it.
+const guestSuspendTimeout = 10 * time.Second
+
+// SuspendToRAM suspends the guest to RAM (ACPI S3). The guest memory stays
+// allocated but its vCPUs stop, until ResumeFromRAM wakes it up. Unlike a
+// snapshot, nothing is written out, so it suits short idle periods.
+func (s *Sandbox) SuspendToRAM() error {
+	span, _ := s.trace("SuspendToRAM")
+	defer span.Finish()
+
+	if s.state.State != types.StateRunning {
+		return fmt.Errorf("Sandbox not running, impossible to suspend it to RAM")
+	}
+
+	if caps := s.hypervisor.capabilities(); !caps.IsGuestSuspendSupported() {
+		return fmt.Errorf("Guest suspend to RAM is not enabled for the hypervisor")
+	}
+
+	if err := s.agent.suspendGuest(); err != nil {
+		return err
+	}
+
+	if err := s.hypervisor.waitGuestSuspended(guestSuspendTimeout); err != nil {
+		return err
+	}
+
+	if err := s.setSandboxState(types.StateSuspended); err != nil {
+		return err
+	}
+
+	return s.storeSandbox()
+}
+
+// ResumeFromRAM wakes up a sandbox suspended to RAM by SuspendToRAM.
+func (s *Sandbox) ResumeFromRAM() error {
+	span, _ := s.trace("ResumeFromRAM")
+	defer span.Finish()
+
+	if s.state.State != types.StateSuspended {
+		return fmt.Errorf("Sandbox not suspended, impossible to resume it from RAM")
+	}
+
+	if err := s.hypervisor.wakeupSandbox(); err != nil {
+		return err
+	}
+
+	if err := s.agent.check(); err != nil {
+		return err
+	}
+
+	// The guest clock may have drifted while the guest was asleep
+	if err := s.agent.setGuestDateTime(time.Now()); err != nil {
+		s.Logger().WithError(err).Warn("Failed to sync the guest time after resuming from RAM")
+	}
+
+	if err := s.setSandboxState(types.StateRunning); err != nil {
+		return err
+	}
+
+	return s.storeSandbox()
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// suspendHypervisor is a mock hypervisor able to suspend the guest to RAM.
+type suspendHypervisor struct {
+	mockHypervisor
+}
+
+func (h *suspendHypervisor) capabilities() types.Capabilities {
+	var caps types.Capabilities
+	caps.SetGuestSuspendSupport()
+	return caps
+}
+
+func TestSandboxSuspendToRAM(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id:         "test-suspend",
+		containers: map[string]*Container{},
+		devManager: manager.NewDeviceManager(manager.VirtioSCSI, false, "", nil),
+		hypervisor: &mockHypervisor{},
+		agent:      &mockAgent{},
+		ctx:        context.Background(),
+		config:     &SandboxConfig{ID: "test-suspend"},
+		state:      types.SandboxState{State: types.StateRunning},
+	}
+
+	var err error
+	s.newStore, err = persist.GetDriver()
+	assert.NoError(err)
+	defer os.RemoveAll(filepath.Join(s.newStore.RunStoragePath(), s.id))
+
+	// Suspend is not enabled
+	assert.Error(s.SuspendToRAM())
+	assert.Equal(types.StateRunning, s.state.State)
+
+	// Not suspended
+	assert.Error(s.ResumeFromRAM())
+
+	s.hypervisor = &suspendHypervisor{}
+	assert.NoError(s.SuspendToRAM())
+	assert.Equal(types.StateSuspended, s.state.State)
+
+	// Already suspended
+	assert.Error(s.SuspendToRAM())
+
+	assert.NoError(s.ResumeFromRAM())
+	assert.Equal(types.StateRunning, s.state.State)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+// HostResources lists the host resources a sandbox claims, for the node
+// agents to detect conflicts between sandboxes and to enforce quotas.
+type HostResources struct {
+	SandboxID string `json:"sandbox_id"`
+
+	// Taps are the host network interfaces, in the network namespace of
+	// the sandbox, the VM is connected to.
+	Taps []string `json:"taps,omitempty"`
+
+	// CgroupPaths are the cgroups of the sandbox, by subsystem.
+	CgroupPaths map[string]string `json:"cgroup_paths,omitempty"`
+
+	// HugePagesMB is the memory of the VM backed by huge pages.
+	HugePagesMB uint32 `json:"hugepages_mb,omitempty"`
+
+	// Devices are the host devices attached to the VM.
+	Devices []HostDevice `json:"devices,omitempty"`
+
+	// VSockCID is the vsock context ID of the VM, 0 when the agent is not
+	// reached over vsock.
+	VSockCID uint64 `json:"vsock_cid,omitempty"`
+
+	// VSockPort is the vsock port the agent is reached on.
+	VSockPort uint32 `json:"vsock_port,omitempty"`
+}
+
+// HostDevice is a host device attached to the VM of a sandbox.
+type HostDevice struct {
+	Type     config.DeviceType `json:"type"`
+	HostPath string            `json:"host_path"`
+
+	// PCIDevices are the addresses of the host PCI devices bound to
+	// vfio-pci for a VFIO device.
+	PCIDevices []string `json:"pci_devices,omitempty"`
+}
+
+// parseAgentVSock returns the vsock context ID and port of an agent URL,
+// the context ID being 0 for a hybrid vsock.
+func parseAgentVSock(url string) (uint64, uint32, bool) {
+	var cid uint64
+
+	switch {
+	case strings.HasPrefix(url, types.VSockScheme+"://"):
+		tokens := strings.Split(strings.TrimPrefix(url, types.VSockScheme+"://"), ":")
+		if len(tokens) != 2 {
+			return 0, 0, false
+		}
+
+		var err error
+		if cid, err = strconv.ParseUint(tokens[0], 10, 64); err != nil {
+			return 0, 0, false
+		}
+	case !strings.HasPrefix(url, types.HybridVSockScheme+"://"):
+		return 0, 0, false
+	}
+
+	i := strings.LastIndex(url, ":")
+	port, err := strconv.ParseUint(url[i+1:], 10, 32)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return cid, uint32(port), true
+}
+
+// HostResources returns the host resources the sandbox claims.
+func (s *Sandbox) HostResources() (HostResources, error) {
+	res := HostResources{
+		SandboxID:   s.id,
+		CgroupPaths: s.state.CgroupPaths,
+	}
+
+	for _, endpoint := range s.networkNS.Endpoints {
+		// The endpoints passed through to the VM have no tap
+		if tap, err := endpointCaptureLink(endpoint); err == nil {
+			res.Taps = append(res.Taps, tap)
+		}
+	}
+
+	hConfig := s.hypervisor.hypervisorConfig()
+	if hConfig.HugePages {
+		res.HugePagesMB = hConfig.MemorySize + uint32(s.hypervisor.save().HotpluggedMemory)
+	}
+
+	for _, d := range s.devManager.GetAllDevices() {
+		if d.GetAttachCount() == 0 {
+			continue
+		}
+
+		dev := HostDevice{
+			Type:     d.DeviceType(),
+			HostPath: d.GetHostPath(),
+		}
+
+		if vfioDevs, ok := d.GetDeviceInfo().([]*config.VFIODev); ok {
+			for _, v := range vfioDevs {
+				if v.BDF != "" {
+					dev.PCIDevices = append(dev.PCIDevices, v.BDF)
+				}
+			}
+		}
+
+		res.Devices = append(res.Devices, dev)
+	}
+
+	url, err := s.agent.getAgentURL()
+	if err != nil {
+		return HostResources{}, fmt.Errorf("Could not get the agent URL: %v", err)
+	}
+
+	if cid, port, ok := parseAgentVSock(url); ok {
+		res.VSockCID = cid
+		res.VSockPort = port
+	}
+
+	return res, nil
+}
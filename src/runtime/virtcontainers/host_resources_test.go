@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAgentVSock(t *testing.T) {
+	assert := assert.New(t)
+
+	type testData struct {
+		url  string
+		cid  uint64
+		port uint32
+		ok   bool
+	}
+
+	data := []testData{
+		{"vsock://3:1024", 3, 1024, true},
+		{"hvsock:///run/vc/firecracker/123/root/kata.hvsock:1024", 0, 1024, true},
+		{"vsock://3", 0, 0, false},
+		{"vsock://cid:1024", 0, 0, false},
+		{"/run/vc/sbs/123/kata.sock", 0, 0, false},
+		{"", 0, 0, false},
+	}
+
+	for _, d := range data {
+		cid, port, ok := parseAgentVSock(d.url)
+		assert.Equal(d.ok, ok, d.url)
+		assert.Equal(d.cid, cid, d.url)
+		assert.Equal(d.port, port, d.url)
+	}
+}
+
+// hugePagesHypervisor is a mock hypervisor backing the VM memory with huge
+// pages.
+type hugePagesHypervisor struct {
+	mockHypervisor
+}
+
+func (h *hugePagesHypervisor) hypervisorConfig() HypervisorConfig {
+	return HypervisorConfig{MemorySize: 2048, HugePages: true}
+}
+
+// vsockAgent is a mock agent reached over vsock.
+type vsockAgent struct {
+	mockAgent
+}
+
+func (n *vsockAgent) getAgentURL() (string, error) {
+	return "vsock://3:1024", nil
+}
+
+func TestSandboxHostResources(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id:         "test-host-resources",
+		devManager: manager.NewDeviceManager(manager.VirtioSCSI, false, "", nil),
+		hypervisor: &mockHypervisor{},
+		agent:      &mockAgent{},
+		ctx:        context.Background(),
+		config:     &SandboxConfig{ID: "test-host-resources"},
+		networkNS: NetworkNamespace{
+			Endpoints: []Endpoint{
+				&VethEndpoint{
+					NetPair: NetworkInterfacePair{
+						TapInterface: TapInterface{
+							TAPIface: NetworkInterface{Name: "tap0_kata"},
+						},
+					},
+				},
+				&PhysicalEndpoint{BDF: "0000:3b:00.2"},
+			},
+		},
+	}
+	s.state.CgroupPaths = map[string]string{"memory": "/sys/fs/cgroup/memory/kata_test-host-resources"}
+
+	res, err := s.HostResources()
+	assert.NoError(err)
+	assert.Equal("test-host-resources", res.SandboxID)
+	assert.Equal([]string{"tap0_kata"}, res.Taps)
+	assert.Equal(s.state.CgroupPaths, res.CgroupPaths)
+	assert.Zero(res.HugePagesMB)
+	assert.Empty(res.Devices)
+	assert.Zero(res.VSockCID)
+
+	s.hypervisor = &hugePagesHypervisor{}
+	s.agent = &vsockAgent{}
+
+	res, err = s.HostResources()
+	assert.NoError(err)
+	assert.Equal(uint32(2048), res.HugePagesMB)
+	assert.Equal(uint64(3), res.VSockCID)
+	assert.Equal(uint32(1024), res.VSockPort)
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	deviceManager "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+)
+
+const (
+	// hotplugPlanMaxBridges is the maximum number of PCI bridges a plan
+	// can reserve.
+	hotplugPlanMaxBridges = 5
+
+	// hotplugPlanMaxMemorySlots is the maximum number of DIMM slots a
+	// plan can reserve.
+	hotplugPlanMaxMemorySlots = 255
+)
+
+// HotplugPlanning configures the reservation of hotplug capacity when the
+// sandbox VM is created. The headroom is added on top of what the declared
+// container limits require.
+type HotplugPlanning struct {
+	// Enable turns hotplug capacity reservation on.
+	Enable bool
+
+	// VCPUs is the number of extra vCPUs the VM must be able to hotplug.
+	VCPUs uint32
+
+	// MemorySlots is the number of extra DIMM slots to reserve.
+	MemorySlots uint32
+
+	// Devices is the number of extra PCI slots to reserve.
+	Devices uint32
+}
+
+// HotplugPlan describes the hotplug capacity a sandbox needs, and the
+// capacity its VM has been created with.
+type HotplugPlan struct {
+	// VCPUs is the number of vCPUs the sandbox is expected to peak at.
+	VCPUs uint32
+
+	// MaxVCPUs is the number of vCPUs the VM can grow to.
+	MaxVCPUs uint32
+
+	// MemorySlots is the number of DIMM slots reserved for memory
+	// hotplug, each memory resize using one slot.
+	MemorySlots uint32
+
+	// Devices is the number of devices expected to be hotplugged on a
+	// PCI slot.
+	Devices uint32
+
+	// Bridges is the number of PCI bridges reserved for device hotplug.
+	Bridges uint32
+}
+
+// planHotplug computes the hotplug capacity needed by the declared
+// container limits plus the configured headroom. When planning is enabled,
+// the VM maximum vCPUs, memory slots and bridges are raised to fit the
+// plan, within the limits of the hypervisor and of the sandbox resource
+// ceilings.
+func planHotplug(sandboxConfig *SandboxConfig) HotplugPlan {
+	conf := &sandboxConfig.HypervisorConfig
+	headroom := sandboxConfig.HotplugPlanning
+
+	var mCPU, memorySlots, devices uint32
+	for _, c := range sandboxConfig.Containers {
+		if cpu := c.Resources.CPU; cpu != nil && cpu.Period != nil && cpu.Quota != nil {
+			mCPU += utils.CalculateMilliCPUs(*cpu.Quota, *cpu.Period)
+		}
+
+		// Every container growing the sandbox memory uses a DIMM.
+		if m := c.Resources.Memory; m != nil && m.Limit != nil && *m.Limit > 0 {
+			memorySlots++
+		}
+
+		for _, d := range c.DeviceInfos {
+			if hotplugUsesPCISlot(d, conf.BlockDeviceDriver) {
+				devices++
+			}
+		}
+	}
+
+	plan := HotplugPlan{
+		VCPUs:       conf.NumVCPUs + utils.CalculateVCpusFromMilliCpus(mCPU),
+		MaxVCPUs:    conf.DefaultMaxVCPUs,
+		MemorySlots: conf.MemSlots,
+		Devices:     devices,
+		Bridges:     conf.DefaultBridges,
+	}
+
+	if !headroom.Enable {
+		return plan
+	}
+
+	plan.VCPUs += headroom.VCPUs
+	plan.Devices += headroom.Devices
+
+	if plan.VCPUs > plan.MaxVCPUs {
+		maxVCPUs := MaxQemuVCPUs()
+		if ceiling := sandboxConfig.ResourceCeilings.MaxVCPUs; ceiling > 0 && ceiling < maxVCPUs {
+			maxVCPUs = ceiling
+		}
+
+		plan.MaxVCPUs = plan.VCPUs
+		if plan.MaxVCPUs > maxVCPUs {
+			plan.MaxVCPUs = maxVCPUs
+		}
+	}
+
+	if slots := memorySlots + headroom.MemorySlots; slots > plan.MemorySlots {
+		if slots > hotplugPlanMaxMemorySlots {
+			slots = hotplugPlanMaxMemorySlots
+		}
+		plan.MemorySlots = slots
+	}
+
+	if bridges := (plan.Devices + types.PCIBridgeMaxCapacity - 1) / types.PCIBridgeMaxCapacity; bridges > plan.Bridges {
+		if bridges > hotplugPlanMaxBridges {
+			bridges = hotplugPlanMaxBridges
+		}
+		plan.Bridges = bridges
+	}
+
+	conf.DefaultMaxVCPUs = plan.MaxVCPUs
+	conf.MemSlots = plan.MemorySlots
+	conf.DefaultBridges = plan.Bridges
+
+	return plan
+}
+
+// hotplugUsesPCISlot returns true if hotplugging the device takes a PCI
+// slot. VFIO and vhost-user-blk devices always do, the other block devices
+// only with the virtio-blk driver: with virtio-scsi, they are LUNs of the
+// SCSI controller, which pods with many volumes should rather use.
+func hotplugUsesPCISlot(d config.DeviceInfo, blockDriver string) bool {
+	switch {
+	case deviceManager.IsVFIO(d.HostPath):
+		return true
+	case d.DevType != "b":
+		return false
+	case d.Major == config.VhostUserBlkMajor:
+		return true
+	}
+
+	return blockDriver == config.VirtioBlock
+}
+
+// check returns an error if the capacity reserved by the plan cannot
+// accommodate what it expects the sandbox to need, so that sandbox
+// creation fails instead of a later container update.
+func (p HotplugPlan) check() error {
+	if p.VCPUs > p.MaxVCPUs {
+		return fmt.Errorf("Sandbox may need %d vCPUs but the VM can only have %d", p.VCPUs, p.MaxVCPUs)
+	}
+
+	if p.Devices > p.Bridges*types.PCIBridgeMaxCapacity {
+		return fmt.Errorf("Sandbox may hotplug %d devices but only %d PCI slots can be reserved", p.Devices, p.Bridges*types.PCIBridgeMaxCapacity)
+	}
+
+	return nil
+}
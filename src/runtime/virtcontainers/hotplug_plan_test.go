@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func newHotplugPlanTestConfig() *SandboxConfig {
+	quota := int64(200000)
+	period := uint64(100000)
+	limit := int64(512 << 20)
+
+	return &SandboxConfig{
+		HypervisorConfig: HypervisorConfig{
+			NumVCPUs:          1,
+			DefaultMaxVCPUs:   4,
+			MemSlots:          1,
+			DefaultBridges:    1,
+			BlockDeviceDriver: config.VirtioBlock,
+		},
+		Containers: []ContainerConfig{
+			{
+				Resources: specs.LinuxResources{
+					CPU:    &specs.LinuxCPU{Quota: &quota, Period: &period},
+					Memory: &specs.LinuxMemory{Limit: &limit},
+				},
+				DeviceInfos: []config.DeviceInfo{
+					{HostPath: "/dev/sda", DevType: "b"},
+					{HostPath: "/dev/vfio/1", DevType: "c"},
+					{HostPath: "/dev/null", DevType: "c"},
+				},
+			},
+			{
+				Resources: specs.LinuxResources{
+					Memory: &specs.LinuxMemory{Limit: &limit},
+				},
+			},
+		},
+	}
+}
+
+func TestPlanHotplugDisabled(t *testing.T) {
+	assert := assert.New(t)
+
+	sconfig := newHotplugPlanTestConfig()
+	sconfig.HotplugPlanning.VCPUs = 10
+
+	plan := planHotplug(sconfig)
+	assert.Equal(HotplugPlan{
+		VCPUs:       3,
+		MaxVCPUs:    4,
+		MemorySlots: 1,
+		Devices:     2,
+		Bridges:     1,
+	}, plan)
+
+	// The hypervisor configuration is left untouched.
+	assert.Equal(uint32(1), sconfig.HypervisorConfig.MemSlots)
+	assert.NoError(plan.check())
+}
+
+func TestPlanHotplug(t *testing.T) {
+	assert := assert.New(t)
+
+	sconfig := newHotplugPlanTestConfig()
+	sconfig.HotplugPlanning = HotplugPlanning{
+		Enable:      true,
+		VCPUs:       1,
+		MemorySlots: 2,
+		Devices:     30,
+	}
+
+	plan := planHotplug(sconfig)
+	assert.Equal(HotplugPlan{
+		VCPUs:       4,
+		MaxVCPUs:    4,
+		MemorySlots: 4,
+		Devices:     32,
+		Bridges:     2,
+	}, plan)
+	assert.NoError(plan.check())
+
+	assert.Equal(uint32(4), sconfig.HypervisorConfig.MemSlots)
+	assert.Equal(uint32(2), sconfig.HypervisorConfig.DefaultBridges)
+
+	// The maximum vCPUs are raised to fit the plan
+	sconfig.HotplugPlanning.VCPUs = 2
+	plan = planHotplug(sconfig)
+	assert.Equal(uint32(5), plan.MaxVCPUs)
+	assert.Equal(uint32(5), sconfig.HypervisorConfig.DefaultMaxVCPUs)
+	assert.NoError(plan.check())
+
+	// Too many vCPUs for the ceiling
+	sconfig.HypervisorConfig.DefaultMaxVCPUs = 4
+	sconfig.ResourceCeilings.MaxVCPUs = 4
+	plan = planHotplug(sconfig)
+	assert.Equal(uint32(4), plan.MaxVCPUs)
+	assert.Error(plan.check())
+
+	// Too many devices
+	sconfig.ResourceCeilings.MaxVCPUs = 0
+	sconfig.HotplugPlanning.VCPUs = 0
+	sconfig.HotplugPlanning.Devices = 1000
+	plan = planHotplug(sconfig)
+	assert.Equal(uint32(hotplugPlanMaxBridges), plan.Bridges)
+	assert.Error(plan.check())
+}
+
+func TestPlanHotplugSCSI(t *testing.T) {
+	assert := assert.New(t)
+
+	sconfig := newHotplugPlanTestConfig()
+	sconfig.HypervisorConfig.BlockDeviceDriver = config.VirtioSCSI
+	for i := 0; i < 40; i++ {
+		sconfig.Containers[1].DeviceInfos = append(sconfig.Containers[1].DeviceInfos,
+			config.DeviceInfo{HostPath: fmt.Sprintf("/dev/sd%d", i), DevType: "b"})
+	}
+	sconfig.HotplugPlanning.Enable = true
+
+	// The SCSI disks are LUNs, only the VFIO device takes a PCI slot
+	plan := planHotplug(sconfig)
+	assert.Equal(uint32(1), plan.Devices)
+	assert.Equal(uint32(1), plan.Bridges)
+	assert.NoError(plan.check())
+
+	// One PCI slot per disk with virtio-blk
+	sconfig.HypervisorConfig.BlockDeviceDriver = config.VirtioBlock
+	plan = planHotplug(sconfig)
+	assert.Equal(uint32(42), plan.Devices)
+	assert.Equal(uint32(2), plan.Bridges)
+}
+
+func TestHotplugUsesPCISlot(t *testing.T) {
+	assert := assert.New(t)
+
+	disk := config.DeviceInfo{HostPath: "/dev/sda", DevType: "b"}
+	assert.True(hotplugUsesPCISlot(disk, config.VirtioBlock))
+	assert.False(hotplugUsesPCISlot(disk, config.VirtioSCSI))
+	assert.False(hotplugUsesPCISlot(disk, config.Nvdimm))
+
+	vhostUserBlk := config.DeviceInfo{HostPath: "/dev/vhost-blk0", DevType: "b", Major: config.VhostUserBlkMajor}
+	assert.True(hotplugUsesPCISlot(vhostUserBlk, config.VirtioSCSI))
+
+	assert.True(hotplugUsesPCISlot(config.DeviceInfo{HostPath: "/dev/vfio/1", DevType: "c"}, config.VirtioSCSI))
+	assert.False(hotplugUsesPCISlot(config.DeviceInfo{HostPath: "/dev/null", DevType: "c"}, config.VirtioBlock))
+}
@@ -14,6 +14,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
@@ -141,6 +142,10 @@ const (
 	// hybridVirtioVsockDev is a hybrid virtio-vsock device supported
 	// only on certain hypervisors, like firecracker.
 	hybridVirtioVsockDev
+
+	// virtioFSShareDev is a host directory shared through a virtio-fs
+	// device and daemon of its own.
+	virtioFSShareDev
 )
 
 type memoryDevice struct {
@@ -237,6 +242,11 @@ type HypervisorConfig struct {
 	// DefaultMem specifies default memory size in MiB for the VM.
 	MemorySize uint32
 
+	// MemoryAdjustmentMB is the memory, in MiB, ResizeSandboxMemory added
+	// to the VM on top of MemorySize and of the memory limits of the
+	// containers.
+	MemoryAdjustmentMB uint32
+
 	// DefaultBridges specifies default number of bridges for the VM.
 	// Bridges can be used to hot plug devices
 	DefaultBridges uint32
@@ -256,6 +266,19 @@ type HypervisorConfig struct {
 	// KernelParams are additional guest kernel parameters.
 	KernelParams []Param
 
+	// KernelParamsProfiles are sets of guest kernel parameters, such as
+	// debug or hardening settings, a sandbox can merge with KernelParams,
+	// by profile name.
+	KernelParamsProfiles map[string][]Param
+
+	// KernelParamsProfile is the profile merged with KernelParams, empty
+	// when none was selected.
+	KernelParamsProfile string
+
+	// PayloadArgs are the arguments of the guest payload, passed at the
+	// end of the kernel command line, after "--".
+	PayloadArgs []string
+
 	// HypervisorParams are additional hypervisor parameters.
 	HypervisorParams []Param
 
@@ -272,6 +295,12 @@ type HypervisorConfig struct {
 	// FirmwarePath is the bios host path
 	FirmwarePath string
 
+	// FirmwareVarsTemplate is the host path of the template of the
+	// variable store of the firmware, such as OVMF_VARS.fd. When set, the
+	// firmware is loaded as a read-only flash image, and each sandbox gets
+	// a writable copy of the template, kept until the sandbox is deleted.
+	FirmwareVarsTemplate string
+
 	// MachineAccelerators are machine specific accelerators
 	MachineAccelerators string
 
@@ -281,6 +310,15 @@ type HypervisorConfig struct {
 	// HypervisorPath is the hypervisor executable host path.
 	HypervisorPath string
 
+	// HypervisorProfiles are the hypervisor executable host paths, such as
+	// the builds of a canary rollout, a sandbox can select in place of
+	// HypervisorPath, by profile name.
+	HypervisorProfiles map[string]string
+
+	// HypervisorProfile is the profile HypervisorPath was selected from,
+	// empty for the default hypervisor.
+	HypervisorProfile string
+
 	// HypervisorCtlPath is the hypervisor ctl executable host path.
 	HypervisorCtlPath string
 
@@ -303,6 +341,14 @@ type HypervisorConfig struct {
 	// BootFromTemplate is true.
 	DevicesStatePath string
 
+	// SnapshotPath is the directory of the VM state saved by snapshotSandbox
+	// the VM is restored from, instead of booting the guest kernel.
+	SnapshotPath string
+
+	// IncomingMigrationURI is the URI the VM receives the state of a VM
+	// migrated from another host on, instead of booting the guest kernel.
+	IncomingMigrationURI string
+
 	// EntropySource is the path to a host source of
 	// entropy (/dev/random, /dev/urandom or real hardware RNG device)
 	EntropySource string
@@ -310,6 +356,7 @@ type HypervisorConfig struct {
 	// Shared file system type:
 	//   - virtio-9p (default)
 	//   - virtio-fs
+	//   - none
 	SharedFS string
 
 	// VirtioFSDaemon is the virtio-fs vhost-user daemon path
@@ -321,6 +368,11 @@ type HypervisorConfig struct {
 	// VirtioFSExtraArgs passes options to virtiofsd daemon
 	VirtioFSExtraArgs []string
 
+	// VirtioFSDaemonRestarts is how many times in a row a crashed
+	// virtio-fs daemon is restarted, for the hypervisor to reconnect to it,
+	// before the sandbox is stopped.
+	VirtioFSDaemonRestarts uint32
+
 	// File based memory backend root directory
 	FileBackedMemRootDir string
 
@@ -341,6 +393,12 @@ type HypervisorConfig struct {
 	// Denotes whether flush requests for the device are ignored.
 	BlockDeviceCacheNoflush bool
 
+	// PRHelperSocket is the socket of the qemu-pr-helper daemon the
+	// persistent reservation commands of the guest are forwarded to. When
+	// set, the SCSI disks and the multipath devices over SCSI disks are
+	// passed through to the guest, with the virtio-scsi block driver.
+	PRHelperSocket string
+
 	// DisableBlockDeviceUse disallows a block device from being used.
 	DisableBlockDeviceUse bool
 
@@ -361,6 +419,10 @@ type HypervisorConfig struct {
 	// VirtioMem is used to enable/disable virtio-mem
 	VirtioMem bool
 
+	// FreePageReporting adds a balloon the guest reports its free pages
+	// to, which the hypervisor returns to the host.
+	FreePageReporting bool
+
 	// IOMMU specifies if the VM should have a vIOMMU
 	IOMMU bool
 
@@ -390,6 +452,29 @@ type HypervisorConfig struct {
 	// The PCIe Root Port device is used to hot-plug the PCIe device
 	PCIeRootPort uint32
 
+	// VFIOAutoBindDrivers are the host drivers whose devices are bound to
+	// vfio-pci when they share the IOMMU group of an assigned VFIO device.
+	VFIOAutoBindDrivers []string
+
+	// VFIOPeerToPeer lets the VFIO devices of the VM DMA to each other,
+	// such as a GPU and an RDMA NIC for GPUDirect RDMA.
+	VFIOPeerToPeer bool
+
+	// GuestNUMA gives the VM a NUMA node for each host NUMA node its VFIO
+	// devices are attached to, with the devices attached to it, and its
+	// memory and vCPUs on the host node.
+	GuestNUMA bool
+
+	// GuestNUMANodes are the NUMA nodes of the VM, set from the devices
+	// of the sandbox when it is created with GuestNUMA.
+	GuestNUMANodes []GuestNUMANode
+
+	// VCPUPinning pins each vCPU thread to a host CPU of the cpusets of
+	// the containers, such as the exclusive CPUs of the kubelet static CPU
+	// manager. With GuestNUMA, the VM also gets a NUMA node for each host
+	// NUMA node of these CPUs.
+	VCPUPinning bool
+
 	// BootToBeTemplate used to indicate if the VM is created to be a template VM
 	BootToBeTemplate bool
 
@@ -399,6 +484,18 @@ type HypervisorConfig struct {
 	// DisableVhostNet is used to indicate if host supports vhost_net
 	DisableVhostNet bool
 
+	// NetworkQueues is the number of queue pairs of the multi-queue
+	// network devices. When zero, they are sized from the vCPUs of the VM.
+	NetworkQueues uint32
+
+	// NetworkMaxQueues caps the queue pairs of the network devices sized
+	// from the vCPUs of the VM.
+	NetworkMaxQueues uint32
+
+	// EnableGuestSuspend lets the guest be suspended to RAM (ACPI S3) and
+	// woken up, when the machine type supports it.
+	EnableGuestSuspend bool
+
 	// EnableVhostUserStore is used to indicate if host supports vhost-user-blk/scsi
 	EnableVhostUserStore bool
 
@@ -406,6 +503,11 @@ type HypervisorConfig struct {
 	// related folders, sockets and device nodes should be.
 	VhostUserStorePath string
 
+	// VhostUserReconnect is the number of seconds between the attempts of
+	// the hypervisor to connect again to a restarted vhost-user storage
+	// backend, zero not reconnecting.
+	VhostUserReconnect uint32
+
 	// GuestHookPath is the path within the VM that will be used for 'drop-in' hooks
 	GuestHookPath string
 
@@ -416,6 +518,37 @@ type HypervisorConfig struct {
 	// SELinux label for the VM
 	SELinuxProcessLabel string
 
+	// EnableVMMIsolation starts the hypervisor process in its own mount,
+	// PID and user namespaces.
+	EnableVMMIsolation bool
+
+	// VMMSeccomp is the seccomp mode applied to the hypervisor process:
+	// "enforce", "log", "disabled" or empty to keep the hypervisor default.
+	VMMSeccomp string
+
+	// MemoryEncryption encrypts the VM memory, hiding it from the host:
+	// "sev", "sev-snp", "tdx" or empty to leave it unencrypted.
+	MemoryEncryption string
+
+	// SEVPolicy is the guest policy of the memory encrypted VM, as
+	// defined by the AMD SEV API.
+	SEVPolicy uint64
+
+	// SEVCBitPos is the encryption bit of the guest page table entries.
+	SEVCBitPos uint32
+
+	// SEVReducedPhysBits is the number of physical address bits lost by
+	// the guest with the memory encryption.
+	SEVReducedPhysBits uint32
+
+	// SEVDHCertPath is the host path of the guest owner Diffie-Hellman
+	// certificate, which the launch session is established with.
+	SEVDHCertPath string
+
+	// SEVSessionPath is the host path of the guest owner launch session
+	// parameters.
+	SEVSessionPath string
+
 	// RxRateLimiterMaxRate is used to control network I/O inbound bandwidth on VM level.
 	RxRateLimiterMaxRate uint64
 
@@ -423,6 +556,17 @@ type HypervisorConfig struct {
 	TxRateLimiterMaxRate uint64
 }
 
+// GuestNUMANode is a NUMA node of the VM, mirroring a host NUMA node.
+type GuestNUMANode struct {
+	// HostNode is the host NUMA node the memory and the vCPUs of the
+	// guest node are on.
+	HostNode int
+
+	// PCIDevices are the addresses of the host PCI devices attached to
+	// the guest node, as in the BDF of their VFIODev.
+	PCIDevices []string
+}
+
 // vcpu mapping from vcpu number to thread number
 type vcpuThreadIDs struct {
 	vcpus map[int]int
@@ -430,35 +574,127 @@ type vcpuThreadIDs struct {
 
 func (conf *HypervisorConfig) checkTemplateConfig() error {
 	if conf.BootToBeTemplate && conf.BootFromTemplate {
-		return fmt.Errorf("Cannot set both 'to be' and 'from' vm tempate")
+		return newConfigFieldError("BootFromTemplate", "Cannot set both 'to be' and 'from' vm tempate")
 	}
 
 	if conf.BootToBeTemplate || conf.BootFromTemplate {
 		if conf.MemoryPath == "" {
-			return fmt.Errorf("Missing MemoryPath for vm template")
+			return newConfigFieldError("MemoryPath", "Missing MemoryPath for vm template")
 		}
 
 		if conf.BootFromTemplate && conf.DevicesStatePath == "" {
-			return fmt.Errorf("Missing DevicesStatePath to load from vm template")
+			return newConfigFieldError("DevicesStatePath", "Missing DevicesStatePath to load from vm template")
+		}
+
+		if conf.SnapshotPath != "" {
+			return newConfigFieldError("SnapshotPath", "Cannot restore a vm template from a snapshot")
+		}
+
+		if conf.IncomingMigrationURI != "" {
+			return newConfigFieldError("IncomingMigrationURI", "Cannot migrate a vm template")
 		}
 	}
 
 	return nil
 }
 
+// SelectHypervisorProfile selects the hypervisor executable of the profile,
+// which must be one of HypervisorProfiles.
+func (conf *HypervisorConfig) SelectHypervisorProfile(profile string) error {
+	path, ok := conf.HypervisorProfiles[profile]
+	if !ok {
+		return newConfigFieldError("HypervisorProfile", fmt.Sprintf("Hypervisor profile %q is not allowed", profile))
+	}
+
+	conf.HypervisorPath = path
+	conf.HypervisorProfile = profile
+
+	return nil
+}
+
+// SelectKernelParamsProfile merges the kernel parameters of the profile,
+// which must be one of KernelParamsProfiles, with KernelParams. A parameter
+// of the profile replaces the parameters with the same key.
+func (conf *HypervisorConfig) SelectKernelParamsProfile(profile string) error {
+	params, ok := conf.KernelParamsProfiles[profile]
+	if !ok {
+		return newConfigFieldError("KernelParamsProfile", fmt.Sprintf("Kernel parameters profile %q is not allowed", profile))
+	}
+
+	if conf.KernelParamsProfile != "" {
+		return newConfigFieldError("KernelParamsProfile", fmt.Sprintf("Kernel parameters profile %q is already selected", conf.KernelParamsProfile))
+	}
+
+	replaced := make(map[string]bool, len(params))
+	for _, p := range params {
+		replaced[p.Key] = true
+	}
+
+	var merged []Param
+	for _, p := range conf.KernelParams {
+		if !replaced[p.Key] {
+			merged = append(merged, p)
+		}
+	}
+
+	conf.KernelParams = append(merged, params...)
+	conf.KernelParamsProfile = profile
+
+	return nil
+}
+
 func (conf *HypervisorConfig) valid() error {
 	if conf.KernelPath == "" {
-		return fmt.Errorf("Missing kernel path")
+		return newConfigFieldError("KernelPath", "Missing kernel path")
 	}
 
 	if conf.ImagePath == "" && conf.InitrdPath == "" {
-		return fmt.Errorf("Missing image and initrd path")
+		return newConfigFieldError("ImagePath", "Missing image and initrd path")
 	}
 
 	if err := conf.checkTemplateConfig(); err != nil {
 		return err
 	}
 
+	// The vIOMMU gives each device its own address space, in which the
+	// other devices are not mapped.
+	if conf.VFIOPeerToPeer && conf.IOMMU {
+		return newConfigFieldError("VFIOPeerToPeer", "Peer-to-peer DMA between VFIO devices is not supported with a vIOMMU")
+	}
+
+	// The memory of the template VM is a single file, shared by the VMs
+	// created from it.
+	if conf.GuestNUMA && (conf.BootToBeTemplate || conf.BootFromTemplate) {
+		return newConfigFieldError("GuestNUMA", "Guest NUMA nodes are not supported with VM templating")
+	}
+
+	if conf.FirmwareVarsTemplate != "" {
+		if conf.FirmwarePath == "" {
+			return newConfigFieldError("FirmwareVarsTemplate", "Firmware variables need a firmware path")
+		}
+		// The host can read and modify the variables of the guest.
+		if conf.MemoryEncryption != MemoryEncryptionNone {
+			return newConfigFieldError("FirmwareVarsTemplate", "Firmware variables are not supported with memory encryption")
+		}
+		if conf.BootToBeTemplate || conf.BootFromTemplate {
+			return newConfigFieldError("FirmwareVarsTemplate", "Firmware variables are not supported with VM templating")
+		}
+	}
+
+	// Without a shared file system, the root file systems of the
+	// containers are block devices.
+	if conf.SharedFS == config.NoSharedFS && conf.DisableBlockDeviceUse {
+		return newConfigFieldError("SharedFS", "A sandbox without a shared file system needs the block devices")
+	}
+
+	if err := validateNetworkQueues(conf); err != nil {
+		return err
+	}
+
+	if err := validateVhostUserStore(conf); err != nil {
+		return err
+	}
+
 	if conf.NumVCPUs == 0 {
 		conf.NumVCPUs = defaultVCPUs
 	}
@@ -483,6 +719,21 @@ func (conf *HypervisorConfig) valid() error {
 		conf.Msize9p = defaultMsize9p
 	}
 
+	if conf.MemoryEncryption == MemoryEncryptionSEV || conf.MemoryEncryption == MemoryEncryptionSNP {
+		if conf.SEVCBitPos == 0 {
+			conf.SEVCBitPos = defaultSEVCBitPos
+		}
+
+		if conf.SEVReducedPhysBits == 0 {
+			conf.SEVReducedPhysBits = defaultSEVReducedPhysBits
+		}
+	}
+
+	if conf.MemoryEncryption != MemoryEncryptionNone {
+		// The guest would read the image through its encryption
+		conf.DisableImageNvdimm = true
+	}
+
 	return nil
 }
 
@@ -782,6 +1033,37 @@ type hypervisor interface {
 	pauseSandbox() error
 	saveSandbox() error
 	resumeSandbox() error
+	// snapshotSandbox saves the state of the paused VM, its memory
+	// included, to the directory dir, which a VM configured with it as
+	// SnapshotPath is restored from.
+	snapshotSandbox(dir string) error
+	// migrateSandbox streams the state of the running VM to the VM of
+	// another host receiving it on uri, and waits for it to be sent. The
+	// VM is paused once its state is sent.
+	migrateSandbox(uri string) error
+	// finishIncomingMigration waits for a VM configured with an
+	// IncomingMigrationURI to receive the state of the migrated VM, and
+	// resumes it.
+	finishIncomingMigration() error
+	// waitGuestSuspended waits for the guest to suspend itself to RAM.
+	waitGuestSuspended(timeout time.Duration) error
+	// wakeupSandbox wakes up a guest suspended to RAM.
+	wakeupSandbox() error
+	// upgradeVirtiofsd hands the virtio-fs shares of the guest over to
+	// daemons started anew, from the virtiofsd binary as it is now.
+	upgradeVirtiofsd() error
+	// liveUpdate moves the running VM to a new process of the hypervisor,
+	// started from the hypervisor binary as it is now. The agent of the
+	// guest is reached through vmSocket in the new process, the endpoints
+	// are attached to it with their reopened file descriptors, and the
+	// drives hotplugged to the VM are added to it again.
+	liveUpdate(vmSocket interface{}, endpoints []Endpoint, drives []*config.BlockDrive) error
+	// powerdownSandbox asks the guest to power off, with an ACPI power
+	// button event.
+	powerdownSandbox() error
+	// getLaunchMeasurement returns the launch measurement of a memory
+	// encrypted VM.
+	getLaunchMeasurement() (LaunchMeasurement, error)
 	addDevice(devInfo interface{}, devType deviceType) error
 	hotplugAddDevice(devInfo interface{}, devType deviceType) (interface{}, error)
 	hotplugRemoveDevice(devInfo interface{}, devType deviceType) (interface{}, error)
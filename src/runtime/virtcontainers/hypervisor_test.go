@@ -13,6 +13,7 @@ import (
 	"testing"
 
 	ktu "github.com/kata-containers/kata-containers/src/runtime/pkg/katatestutils"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -154,10 +155,73 @@ func TestHypervisorConfigValidTemplateConfig(t *testing.T) {
 	hypervisorConfig.BootFromTemplate = false
 	hypervisorConfig.BootToBeTemplate = true
 	testHypervisorConfigValid(t, hypervisorConfig, true)
+	hypervisorConfig.SnapshotPath = "foobar"
+	testHypervisorConfigValid(t, hypervisorConfig, false)
+	hypervisorConfig.SnapshotPath = ""
+	hypervisorConfig.IncomingMigrationURI = "tcp:192.168.0.1:4444"
+	testHypervisorConfigValid(t, hypervisorConfig, false)
+	hypervisorConfig.IncomingMigrationURI = ""
 	hypervisorConfig.MemoryPath = ""
 	testHypervisorConfigValid(t, hypervisorConfig, false)
 }
 
+func TestHypervisorConfigValidVFIOPeerToPeer(t *testing.T) {
+	hypervisorConfig := &HypervisorConfig{
+		KernelPath:     fmt.Sprintf("%s/%s", testDir, testKernel),
+		ImagePath:      fmt.Sprintf("%s/%s", testDir, testImage),
+		HypervisorPath: fmt.Sprintf("%s/%s", testDir, testHypervisor),
+		VFIOPeerToPeer: true,
+	}
+	testHypervisorConfigValid(t, hypervisorConfig, true)
+
+	hypervisorConfig.IOMMU = true
+	testHypervisorConfigValid(t, hypervisorConfig, false)
+}
+
+func TestHypervisorConfigValidGuestNUMA(t *testing.T) {
+	hypervisorConfig := &HypervisorConfig{
+		KernelPath:     fmt.Sprintf("%s/%s", testDir, testKernel),
+		ImagePath:      fmt.Sprintf("%s/%s", testDir, testImage),
+		HypervisorPath: fmt.Sprintf("%s/%s", testDir, testHypervisor),
+		GuestNUMA:      true,
+	}
+	testHypervisorConfigValid(t, hypervisorConfig, true)
+
+	hypervisorConfig.BootFromTemplate = true
+	hypervisorConfig.MemoryPath = "/run/template/memory"
+	hypervisorConfig.DevicesStatePath = "/run/template/state"
+	testHypervisorConfigValid(t, hypervisorConfig, false)
+}
+
+func TestHypervisorConfigValidFirmwareVars(t *testing.T) {
+	hypervisorConfig := &HypervisorConfig{
+		KernelPath:           fmt.Sprintf("%s/%s", testDir, testKernel),
+		ImagePath:            fmt.Sprintf("%s/%s", testDir, testImage),
+		HypervisorPath:       fmt.Sprintf("%s/%s", testDir, testHypervisor),
+		FirmwareVarsTemplate: "/usr/share/OVMF/OVMF_VARS.fd",
+	}
+	testHypervisorConfigValid(t, hypervisorConfig, false)
+
+	hypervisorConfig.FirmwarePath = "/usr/share/OVMF/OVMF_CODE.fd"
+	testHypervisorConfigValid(t, hypervisorConfig, true)
+
+	hypervisorConfig.MemoryEncryption = MemoryEncryptionSEV
+	testHypervisorConfigValid(t, hypervisorConfig, false)
+}
+
+func TestHypervisorConfigValidNoSharedFS(t *testing.T) {
+	hypervisorConfig := &HypervisorConfig{
+		KernelPath:     fmt.Sprintf("%s/%s", testDir, testKernel),
+		ImagePath:      fmt.Sprintf("%s/%s", testDir, testImage),
+		HypervisorPath: fmt.Sprintf("%s/%s", testDir, testHypervisor),
+		SharedFS:       config.NoSharedFS,
+	}
+	testHypervisorConfigValid(t, hypervisorConfig, true)
+
+	hypervisorConfig.DisableBlockDeviceUse = true
+	testHypervisorConfigValid(t, hypervisorConfig, false)
+}
+
 func TestHypervisorConfigDefaults(t *testing.T) {
 	assert := assert.New(t)
 	hypervisorConfig := &HypervisorConfig{
@@ -350,6 +414,57 @@ func TestAddKernelParamInvalid(t *testing.T) {
 	assert.Error(err)
 }
 
+func TestSelectKernelParamsProfile(t *testing.T) {
+	assert := assert.New(t)
+
+	config := HypervisorConfig{
+		KernelParams: []Param{{"quiet", ""}, {"systemd.show_status", "false"}, {"console", "hvc0"}},
+		KernelParamsProfiles: map[string][]Param{
+			"debug": {{"systemd.show_status", "true"}, {"agent.log", "debug"}},
+		},
+	}
+
+	err := config.SelectKernelParamsProfile("perf")
+	assert.Error(err)
+	assert.Equal("KernelParamsProfile", configFieldError("", err).Field)
+	assert.Len(config.KernelParams, 3)
+
+	assert.NoError(config.SelectKernelParamsProfile("debug"))
+	assert.Equal("debug", config.KernelParamsProfile)
+	assert.Equal([]Param{
+		{"quiet", ""},
+		{"console", "hvc0"},
+		{"systemd.show_status", "true"},
+		{"agent.log", "debug"},
+	}, config.KernelParams)
+
+	// The parameters of a single profile are merged.
+	assert.Error(config.SelectKernelParamsProfile("debug"))
+}
+
+func TestSelectHypervisorProfile(t *testing.T) {
+	assert := assert.New(t)
+
+	config := HypervisorConfig{
+		HypervisorPath: "/usr/bin/qemu-system-x86_64",
+		HypervisorProfiles: map[string]string{
+			"kata":   "/usr/bin/qemu-kata",
+			"canary": "/opt/qemu-canary/bin/qemu-system-x86_64",
+		},
+	}
+
+	assert.NoError(config.SelectHypervisorProfile("kata"))
+	assert.Equal("/usr/bin/qemu-kata", config.HypervisorPath)
+	assert.Equal("kata", config.HypervisorProfile)
+
+	err := config.SelectHypervisorProfile("experimental")
+	assert.Error(err)
+	assert.Equal("HypervisorProfile", configFieldError("", err).Field)
+	assert.Equal("/usr/bin/qemu-kata", config.HypervisorPath)
+
+	assert.Error((&HypervisorConfig{}).SelectHypervisorProfile(""))
+}
+
 func TestGetHostMemorySizeKb(t *testing.T) {
 	assert := assert.New(t)
 	type testData struct {
@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os/exec"
+	"strings"
+)
+
+// hypervisorVersion returns the first line of the version the hypervisor
+// binary reports, such as "QEMU emulator version 5.0.0".
+func hypervisorVersion(path string) (string, error) {
+	out, err := exec.Command(path, "--version").Output()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0]), nil
+}
+
+// hypervisorConfigDigest returns the hex encoded SHA256 digest of the
+// hypervisor configuration, less the fields specific to each sandbox: the
+// sandboxes created from the same configuration have the same digest.
+func hypervisorConfigDigest(conf HypervisorConfig) (string, error) {
+	conf.MemoryPath = ""
+	conf.DevicesStatePath = ""
+	conf.BootToBeTemplate = false
+	conf.BootFromTemplate = false
+	conf.VMid = ""
+
+	data, err := json.Marshal(conf)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// recordHypervisorVersion records the version and the configuration digest
+// of the hypervisor the VM of the sandbox is started with, for the sandboxes
+// still running an older hypervisor to be found during upgrades.
+func (s *Sandbox) recordHypervisorVersion() {
+	version, err := hypervisorVersion(s.config.HypervisorConfig.HypervisorPath)
+	if err != nil {
+		s.Logger().WithError(err).Warn("Could not get the hypervisor version")
+	}
+
+	digest, err := hypervisorConfigDigest(s.config.HypervisorConfig)
+	if err != nil {
+		s.Logger().WithError(err).Warn("Could not digest the hypervisor configuration")
+	}
+
+	s.state.HypervisorVersion = version
+	s.state.HypervisorConfigDigest = digest
+
+	s.Logger().WithField("hypervisor-version", version).WithField("hypervisor-config-digest", digest).Info("Hypervisor version")
+}
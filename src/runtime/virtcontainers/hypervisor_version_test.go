@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHypervisorVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "hypervisor")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "qemu")
+	script := "#!/bin/sh\necho 'QEMU emulator version 5.0.0 (kata-static)'\necho 'Copyright (c) 2003-2020 Fabrice Bellard'\n"
+	assert.NoError(ioutil.WriteFile(path, []byte(script), 0700))
+
+	version, err := hypervisorVersion(path)
+	assert.NoError(err)
+	assert.Equal("QEMU emulator version 5.0.0 (kata-static)", version)
+
+	_, err = hypervisorVersion(filepath.Join(dir, "missing"))
+	assert.Error(err)
+}
+
+func TestHypervisorConfigDigest(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := HypervisorConfig{
+		HypervisorPath: "/usr/bin/qemu-system-x86_64",
+		KernelPath:     "/usr/share/kata-containers/vmlinuz",
+		MemorySize:     2048,
+	}
+
+	digest, err := hypervisorConfigDigest(conf)
+	assert.NoError(err)
+	assert.Len(digest, 64)
+
+	// The sandbox specific fields are left out
+	clone := conf
+	clone.BootFromTemplate = true
+	clone.MemoryPath = "/run/vc/sbs/clone/clone/memory"
+	cloneDigest, err := hypervisorConfigDigest(clone)
+	assert.NoError(err)
+	assert.Equal(digest, cloneDigest)
+
+	conf.HypervisorPath = "/opt/qemu-canary/bin/qemu-system-x86_64"
+	canaryDigest, err := hypervisorConfigDigest(conf)
+	assert.NoError(err)
+	assert.NotEqual(digest, canaryDigest)
+}
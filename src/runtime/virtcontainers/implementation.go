@@ -11,7 +11,11 @@ package virtcontainers
 
 import (
 	"context"
+	"io"
+	"time"
 
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	"github.com/sirupsen/logrus"
 )
 
@@ -35,9 +39,194 @@ func (impl *VCImpl) CreateSandbox(ctx context.Context, sandboxConfig SandboxConf
 	return CreateSandbox(ctx, sandboxConfig, impl.factory)
 }
 
+// CloneSandbox implements the VC function of the same name.
+func (impl *VCImpl) CloneSandbox(ctx context.Context, sourceID string, sandboxConfig SandboxConfig) (VCSandbox, error) {
+	return CloneSandbox(ctx, sourceID, sandboxConfig)
+}
+
+// CloneSandboxSnapshot implements the VC function of the same name.
+func (impl *VCImpl) CloneSandboxSnapshot(ctx context.Context, sourceID, snapshot string, sandboxConfig SandboxConfig) (VCSandbox, error) {
+	return CloneSandboxSnapshot(ctx, sourceID, snapshot, sandboxConfig)
+}
+
+// ListSandboxSnapshots implements the VC function of the same name.
+func (impl *VCImpl) ListSandboxSnapshots(ctx context.Context, sandboxID string) ([]SnapshotInfo, error) {
+	return ListSandboxSnapshots(ctx, sandboxID)
+}
+
+// SnapshotSandbox implements the VC function of the same name.
+func (impl *VCImpl) SnapshotSandbox(ctx context.Context, sandboxID, destPath string) error {
+	return SnapshotSandbox(ctx, sandboxID, destPath)
+}
+
+// RestoreSandbox implements the VC function of the same name.
+func (impl *VCImpl) RestoreSandbox(ctx context.Context, snapshotPath string) (VCSandbox, error) {
+	return RestoreSandbox(ctx, snapshotPath)
+}
+
+// MigrateSandbox implements the VC function of the same name.
+func (impl *VCImpl) MigrateSandbox(ctx context.Context, sandboxID, destination string) error {
+	return MigrateSandbox(ctx, sandboxID, destination)
+}
+
+// ReceiveSandbox implements the VC function of the same name.
+func (impl *VCImpl) ReceiveSandbox(ctx context.Context, listenAddr string) (VCSandbox, error) {
+	return ReceiveSandbox(ctx, listenAddr)
+}
+
+// FetchSandbox implements the VC function of the same name.
+func (impl *VCImpl) FetchSandbox(ctx context.Context, sandboxID string) (VCSandbox, error) {
+	return FetchSandbox(ctx, sandboxID)
+}
+
+// ListSandbox implements the VC function of the same name.
+func (impl *VCImpl) ListSandbox(ctx context.Context) ([]SandboxStatus, error) {
+	return ListSandbox(ctx)
+}
+
+// ListSandboxesByHypervisorVersion implements the VC function of the same name.
+func (impl *VCImpl) ListSandboxesByHypervisorVersion(ctx context.Context, version string) ([]SandboxStatus, error) {
+	return ListSandboxesByHypervisorVersion(ctx, version)
+}
+
+// ListSandboxesWithFilter implements the VC function of the same name.
+func (impl *VCImpl) ListSandboxesWithFilter(ctx context.Context, filter ListFilter) ([]SandboxSummary, error) {
+	return ListSandboxesWithFilter(ctx, filter)
+}
+
+// LivepatchSandbox implements the VC function of the same name.
+func (impl *VCImpl) LivepatchSandbox(ctx context.Context, sandboxID, module string) error {
+	return LivepatchSandbox(ctx, sandboxID, module)
+}
+
+// PauseSandbox implements the VC function of the same name.
+func (impl *VCImpl) PauseSandbox(ctx context.Context, sandboxID string) error {
+	return PauseSandbox(ctx, sandboxID)
+}
+
+// ResumeSandbox implements the VC function of the same name.
+func (impl *VCImpl) ResumeSandbox(ctx context.Context, sandboxID string) error {
+	return ResumeSandbox(ctx, sandboxID)
+}
+
+// SuspendSandboxToRAM implements the VC function of the same name.
+func (impl *VCImpl) SuspendSandboxToRAM(ctx context.Context, sandboxID string) error {
+	return SuspendSandboxToRAM(ctx, sandboxID)
+}
+
+// ResumeSandboxFromRAM implements the VC function of the same name.
+func (impl *VCImpl) ResumeSandboxFromRAM(ctx context.Context, sandboxID string) error {
+	return ResumeSandboxFromRAM(ctx, sandboxID)
+}
+
+// AddDevice implements the VC function of the same name.
+func (impl *VCImpl) AddDevice(ctx context.Context, sandboxID string, info config.DeviceInfo) (api.Device, error) {
+	return AddDevice(ctx, sandboxID, info)
+}
+
+// RemoveDevice implements the VC function of the same name.
+func (impl *VCImpl) RemoveDevice(ctx context.Context, sandboxID, deviceID string) error {
+	return RemoveDevice(ctx, sandboxID, deviceID)
+}
+
+// CopyFileToContainer implements the VC function of the same name.
+func (impl *VCImpl) CopyFileToContainer(ctx context.Context, sandboxID, containerID, hostPath, guestPath string) error {
+	return CopyFileToContainer(ctx, sandboxID, containerID, hostPath, guestPath)
+}
+
+// CopyFileFromContainer implements the VC function of the same name.
+func (impl *VCImpl) CopyFileFromContainer(ctx context.Context, sandboxID, containerID, guestPath, hostPath string) error {
+	return CopyFileFromContainer(ctx, sandboxID, containerID, guestPath, hostPath)
+}
+
+// CheckpointContainer implements the VC function of the same name.
+func (impl *VCImpl) CheckpointContainer(ctx context.Context, sandboxID, containerID string, opts CheckpointOptions) error {
+	return CheckpointContainer(ctx, sandboxID, containerID, opts)
+}
+
+// RestoreContainer implements the VC function of the same name.
+func (impl *VCImpl) RestoreContainer(ctx context.Context, sandboxID, containerID string, opts CheckpointOptions) error {
+	return RestoreContainer(ctx, sandboxID, containerID, opts)
+}
+
+// RestartContainer implements the VC function of the same name.
+func (impl *VCImpl) RestartContainer(ctx context.Context, sandboxID, containerID string) (VCContainer, error) {
+	return RestartContainer(ctx, sandboxID, containerID)
+}
+
+// ResizeSandboxMemory implements the VC function of the same name.
+func (impl *VCImpl) ResizeSandboxMemory(ctx context.Context, sandboxID string, targetMB uint32) error {
+	return ResizeSandboxMemory(ctx, sandboxID, targetMB)
+}
+
+// SandboxHostResources implements the VC function of the same name.
+func (impl *VCImpl) SandboxHostResources(ctx context.Context, sandboxID string) (HostResources, error) {
+	return SandboxHostResources(ctx, sandboxID)
+}
+
+// WatchSandboxEvents implements the VC function of the same name.
+func (impl *VCImpl) WatchSandboxEvents(ctx context.Context, sandboxID string) (<-chan SandboxEvent, error) {
+	return WatchSandboxEvents(ctx, sandboxID)
+}
+
+// CaptureSandboxTraffic implements the VC function of the same name.
+func (impl *VCImpl) CaptureSandboxTraffic(ctx context.Context, sandboxID, iface string, duration time.Duration) (io.ReadCloser, error) {
+	return CaptureSandboxTraffic(ctx, sandboxID, iface, duration)
+}
+
+// GetLaunchMeasurement implements the VC function of the same name.
+func (impl *VCImpl) GetLaunchMeasurement(ctx context.Context, sandboxID string) (LaunchMeasurement, error) {
+	return GetLaunchMeasurement(ctx, sandboxID)
+}
+
+// GetTDReport implements the VC function of the same name.
+func (impl *VCImpl) GetTDReport(ctx context.Context, sandboxID string, reportData []byte) ([]byte, error) {
+	return GetTDReport(ctx, sandboxID, reportData)
+}
+
+// GetSandboxConsole implements the VC function of the same name.
+func (impl *VCImpl) GetSandboxConsole(ctx context.Context, sandboxID string) (io.ReadWriteCloser, error) {
+	return GetSandboxConsole(ctx, sandboxID)
+}
+
 // CleanupContaienr is used by shimv2 to stop and delete a container exclusively, once there is no container
 // in the sandbox left, do stop the sandbox and delete it. Those serial operations will be done exclusively by
 // locking the sandbox.
 func (impl *VCImpl) CleanupContainer(ctx context.Context, sandboxID, containerID string, force bool) error {
 	return CleanupContainer(ctx, sandboxID, containerID, force)
 }
+
+// ExportSandboxState implements the VC function of the same name.
+func (impl *VCImpl) ExportSandboxState(ctx context.Context, sandboxID string, w io.Writer) error {
+	return ExportSandboxState(ctx, sandboxID, w)
+}
+
+// CheckDeviceTopology implements the VC function of the same name.
+func (impl *VCImpl) CheckDeviceTopology(ctx context.Context, devices []config.DeviceInfo) (config.DeviceTopology, error) {
+	return CheckDeviceTopology(ctx, devices)
+}
+
+// PrefetchAssets implements the VC function of the same name.
+func (impl *VCImpl) PrefetchAssets(ctx context.Context, hypervisorConfig HypervisorConfig) ([]PrefetchedAsset, error) {
+	return PrefetchAssets(ctx, hypervisorConfig)
+}
+
+// PreallocateTaps implements the VC function of the same name.
+func (impl *VCImpl) PreallocateTaps(ctx context.Context, netNSPath string, hypervisorType HypervisorType, hypervisorConfig HypervisorConfig, count int) ([]string, error) {
+	return PreallocateTaps(ctx, netNSPath, hypervisorType, hypervisorConfig, count)
+}
+
+// DrainAllSandboxes implements the VC function of the same name.
+func (impl *VCImpl) DrainAllSandboxes(ctx context.Context, deadline time.Time, policy DrainPolicy) ([]DrainResult, error) {
+	return DrainAllSandboxes(ctx, deadline, policy)
+}
+
+// SandboxConfigSchema implements the VC function of the same name.
+func (impl *VCImpl) SandboxConfigSchema(ctx context.Context) *ConfigSchema {
+	return SandboxConfigSchema()
+}
+
+// ValidateSandboxConfig implements the VC function of the same name.
+func (impl *VCImpl) ValidateSandboxConfig(ctx context.Context, sandboxConfig SandboxConfig) []*ConfigFieldError {
+	return ValidateSandboxConfig(sandboxConfig)
+}
@@ -8,13 +8,16 @@ package virtcontainers
 import (
 	"context"
 	"io"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 )
 
@@ -24,7 +27,45 @@ type VC interface {
 	SetFactory(ctx context.Context, factory Factory)
 
 	CreateSandbox(ctx context.Context, sandboxConfig SandboxConfig) (VCSandbox, error)
+	CloneSandbox(ctx context.Context, sourceID string, sandboxConfig SandboxConfig) (VCSandbox, error)
+	CloneSandboxSnapshot(ctx context.Context, sourceID, snapshot string, sandboxConfig SandboxConfig) (VCSandbox, error)
+	ListSandboxSnapshots(ctx context.Context, sandboxID string) ([]SnapshotInfo, error)
+	SnapshotSandbox(ctx context.Context, sandboxID, destPath string) error
+	RestoreSandbox(ctx context.Context, snapshotPath string) (VCSandbox, error)
+	MigrateSandbox(ctx context.Context, sandboxID, destination string) error
+	ReceiveSandbox(ctx context.Context, listenAddr string) (VCSandbox, error)
+	FetchSandbox(ctx context.Context, sandboxID string) (VCSandbox, error)
+	ListSandbox(ctx context.Context) ([]SandboxStatus, error)
+	ListSandboxesByHypervisorVersion(ctx context.Context, version string) ([]SandboxStatus, error)
+	ListSandboxesWithFilter(ctx context.Context, filter ListFilter) ([]SandboxSummary, error)
 	CleanupContainer(ctx context.Context, sandboxID, containerID string, force bool) error
+	ExportSandboxState(ctx context.Context, sandboxID string, w io.Writer) error
+	LivepatchSandbox(ctx context.Context, sandboxID, module string) error
+	PauseSandbox(ctx context.Context, sandboxID string) error
+	ResumeSandbox(ctx context.Context, sandboxID string) error
+	SuspendSandboxToRAM(ctx context.Context, sandboxID string) error
+	ResumeSandboxFromRAM(ctx context.Context, sandboxID string) error
+	AddDevice(ctx context.Context, sandboxID string, info config.DeviceInfo) (api.Device, error)
+	RemoveDevice(ctx context.Context, sandboxID, deviceID string) error
+	CopyFileToContainer(ctx context.Context, sandboxID, containerID, hostPath, guestPath string) error
+	CopyFileFromContainer(ctx context.Context, sandboxID, containerID, guestPath, hostPath string) error
+	CheckpointContainer(ctx context.Context, sandboxID, containerID string, opts CheckpointOptions) error
+	RestoreContainer(ctx context.Context, sandboxID, containerID string, opts CheckpointOptions) error
+	RestartContainer(ctx context.Context, sandboxID, containerID string) (VCContainer, error)
+	ResizeSandboxMemory(ctx context.Context, sandboxID string, targetMB uint32) error
+	SandboxHostResources(ctx context.Context, sandboxID string) (HostResources, error)
+	WatchSandboxEvents(ctx context.Context, sandboxID string) (<-chan SandboxEvent, error)
+	CaptureSandboxTraffic(ctx context.Context, sandboxID, iface string, duration time.Duration) (io.ReadCloser, error)
+	GetLaunchMeasurement(ctx context.Context, sandboxID string) (LaunchMeasurement, error)
+	GetTDReport(ctx context.Context, sandboxID string, reportData []byte) ([]byte, error)
+	GetSandboxConsole(ctx context.Context, sandboxID string) (io.ReadWriteCloser, error)
+	CheckDeviceTopology(ctx context.Context, devices []config.DeviceInfo) (config.DeviceTopology, error)
+	PrefetchAssets(ctx context.Context, hypervisorConfig HypervisorConfig) ([]PrefetchedAsset, error)
+	PreallocateTaps(ctx context.Context, netNSPath string, hypervisorType HypervisorType, hypervisorConfig HypervisorConfig, count int) ([]string, error)
+	DrainAllSandboxes(ctx context.Context, deadline time.Time, policy DrainPolicy) ([]DrainResult, error)
+
+	SandboxConfigSchema(ctx context.Context) *ConfigSchema
+	ValidateSandboxConfig(ctx context.Context, sandboxConfig SandboxConfig) []*ConfigFieldError
 }
 
 // VCSandbox is the Sandbox interface
@@ -32,6 +73,7 @@ type VC interface {
 type VCSandbox interface {
 	Annotations(key string) (string, error)
 	GetNetNs() string
+	GetConsoleSocket() (string, error)
 	GetAllContainers() []VCContainer
 	GetAnnotations() map[string]string
 	GetContainer(containerID string) VCContainer
@@ -44,6 +86,7 @@ type VCSandbox interface {
 	Stop(force bool) error
 	Release() error
 	Monitor() (chan error, error)
+	Reconcile() error
 	Delete() error
 	Status() SandboxStatus
 	CreateContainer(contConfig ContainerConfig) (VCContainer, error)
@@ -55,6 +98,12 @@ type VCSandbox interface {
 	StatsContainer(containerID string) (ContainerStats, error)
 	PauseContainer(containerID string) error
 	ResumeContainer(containerID string) error
+	CheckpointContainer(containerID string, opts CheckpointOptions) error
+	RestoreContainer(containerID string, opts CheckpointOptions) error
+	SuspendToRAM() error
+	ResumeFromRAM() error
+	Pause() error
+	Resume() error
 	EnterContainer(containerID string, cmd types.Cmd) (VCContainer, *Process, error)
 	UpdateContainer(containerID string, resources specs.LinuxResources) error
 	ProcessListContainer(containerID string, options ProcessListOptions) (ProcessList, error)
@@ -64,17 +113,28 @@ type VCSandbox interface {
 	IOStream(containerID, processID string) (io.WriteCloser, io.Reader, io.Reader, error)
 
 	AddDevice(info config.DeviceInfo) (api.Device, error)
+	RemoveDevice(deviceID string) error
 
 	AddInterface(inf *vcTypes.Interface) (*vcTypes.Interface, error)
 	RemoveInterface(inf *vcTypes.Interface) (*vcTypes.Interface, error)
 	ListInterfaces() ([]*vcTypes.Interface, error)
 	UpdateRoutes(routes []*vcTypes.Route) ([]*vcTypes.Route, error)
 	ListRoutes() ([]*vcTypes.Route, error)
+	EndpointStats() ([]EndpointStats, error)
+	NetworkIncompatibilities() ([]NetworkIncompatibility, error)
 
 	GetOOMEvent() (string, error)
 
 	UpdateRuntimeMetrics() error
 	GetAgentMetrics() (string, error)
+	MetricsCollector() prometheus.Collector
+
+	ProfileGuest(req ProfileRequest) (io.ReadCloser, error)
+	UpgradeVirtiofsd() error
+	LiveUpdate() error
+	AttachConsole() (io.ReadWriteCloser, error)
+
+	EnableDeferredShrinks(lock sync.Locker)
 }
 
 // VCContainer is the Container interface
@@ -114,12 +114,12 @@ func (endpoint *IPVlanEndpoint) Detach(netNsCreated bool, netNsPath string) erro
 }
 
 // HotAttach for physical endpoint not supported yet
-func (endpoint *IPVlanEndpoint) HotAttach(h hypervisor) error {
+func (endpoint *IPVlanEndpoint) HotAttach(s *Sandbox) error {
 	return fmt.Errorf("IPVlanEndpoint does not support Hot attach")
 }
 
 // HotDetach for physical endpoint not supported yet
-func (endpoint *IPVlanEndpoint) HotDetach(h hypervisor, netNsCreated bool, netNsPath string) error {
+func (endpoint *IPVlanEndpoint) HotDetach(s *Sandbox, netNsCreated bool, netNsPath string) error {
 	return fmt.Errorf("IPVlanEndpoint does not support Hot detach")
 }
 
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/drivers"
 	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
 	aTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols"
 	kataclient "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/client"
@@ -33,6 +35,7 @@ import (
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 
 	"github.com/gogo/protobuf/proto"
+	gpb "github.com/gogo/protobuf/types"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
@@ -73,6 +76,9 @@ var (
 	kataBlkCCWDevType           = "blk-ccw"
 	kataSCSIDevType             = "scsi"
 	kataNvdimmDevType           = "nvdimm"
+	kataVFIORDMADevType         = "vfio-rdma"
+	kataVFIOGPUDevType          = "vfio-gpu"
+	rdmaDevicesPath             = "/dev/infiniband"
 	kataVirtioFSDevType         = "virtio-fs"
 	sharedDir9pOptions          = []string{"trans=virtio,version=9p2000.L,cache=mmap", "nodev"}
 	sharedDirVirtioFSOptions    = []string{}
@@ -97,38 +103,47 @@ const (
 )
 
 const (
-	grpcCheckRequest             = "grpc.CheckRequest"
-	grpcExecProcessRequest       = "grpc.ExecProcessRequest"
-	grpcCreateSandboxRequest     = "grpc.CreateSandboxRequest"
-	grpcDestroySandboxRequest    = "grpc.DestroySandboxRequest"
-	grpcCreateContainerRequest   = "grpc.CreateContainerRequest"
-	grpcStartContainerRequest    = "grpc.StartContainerRequest"
-	grpcRemoveContainerRequest   = "grpc.RemoveContainerRequest"
-	grpcSignalProcessRequest     = "grpc.SignalProcessRequest"
-	grpcUpdateRoutesRequest      = "grpc.UpdateRoutesRequest"
-	grpcUpdateInterfaceRequest   = "grpc.UpdateInterfaceRequest"
-	grpcListInterfacesRequest    = "grpc.ListInterfacesRequest"
-	grpcListRoutesRequest        = "grpc.ListRoutesRequest"
-	grpcAddARPNeighborsRequest   = "grpc.AddARPNeighborsRequest"
-	grpcOnlineCPUMemRequest      = "grpc.OnlineCPUMemRequest"
-	grpcListProcessesRequest     = "grpc.ListProcessesRequest"
-	grpcUpdateContainerRequest   = "grpc.UpdateContainerRequest"
-	grpcWaitProcessRequest       = "grpc.WaitProcessRequest"
-	grpcTtyWinResizeRequest      = "grpc.TtyWinResizeRequest"
-	grpcWriteStreamRequest       = "grpc.WriteStreamRequest"
-	grpcCloseStdinRequest        = "grpc.CloseStdinRequest"
-	grpcStatsContainerRequest    = "grpc.StatsContainerRequest"
-	grpcPauseContainerRequest    = "grpc.PauseContainerRequest"
-	grpcResumeContainerRequest   = "grpc.ResumeContainerRequest"
-	grpcReseedRandomDevRequest   = "grpc.ReseedRandomDevRequest"
-	grpcGuestDetailsRequest      = "grpc.GuestDetailsRequest"
-	grpcMemHotplugByProbeRequest = "grpc.MemHotplugByProbeRequest"
-	grpcCopyFileRequest          = "grpc.CopyFileRequest"
-	grpcSetGuestDateTimeRequest  = "grpc.SetGuestDateTimeRequest"
-	grpcStartTracingRequest      = "grpc.StartTracingRequest"
-	grpcStopTracingRequest       = "grpc.StopTracingRequest"
-	grpcGetOOMEventRequest       = "grpc.GetOOMEventRequest"
-	grpcGetMetricsRequest        = "grpc.GetMetricsRequest"
+	grpcCheckRequest               = "grpc.CheckRequest"
+	grpcExecProcessRequest         = "grpc.ExecProcessRequest"
+	grpcCreateSandboxRequest       = "grpc.CreateSandboxRequest"
+	grpcDestroySandboxRequest      = "grpc.DestroySandboxRequest"
+	grpcCreateContainerRequest     = "grpc.CreateContainerRequest"
+	grpcStartContainerRequest      = "grpc.StartContainerRequest"
+	grpcRemoveContainerRequest     = "grpc.RemoveContainerRequest"
+	grpcSignalProcessRequest       = "grpc.SignalProcessRequest"
+	grpcUpdateRoutesRequest        = "grpc.UpdateRoutesRequest"
+	grpcUpdateInterfaceRequest     = "grpc.UpdateInterfaceRequest"
+	grpcListInterfacesRequest      = "grpc.ListInterfacesRequest"
+	grpcListRoutesRequest          = "grpc.ListRoutesRequest"
+	grpcAddARPNeighborsRequest     = "grpc.AddARPNeighborsRequest"
+	grpcOnlineCPUMemRequest        = "grpc.OnlineCPUMemRequest"
+	grpcListProcessesRequest       = "grpc.ListProcessesRequest"
+	grpcUpdateContainerRequest     = "grpc.UpdateContainerRequest"
+	grpcWaitProcessRequest         = "grpc.WaitProcessRequest"
+	grpcTtyWinResizeRequest        = "grpc.TtyWinResizeRequest"
+	grpcWriteStreamRequest         = "grpc.WriteStreamRequest"
+	grpcCloseStdinRequest          = "grpc.CloseStdinRequest"
+	grpcStatsContainerRequest      = "grpc.StatsContainerRequest"
+	grpcPauseContainerRequest      = "grpc.PauseContainerRequest"
+	grpcResumeContainerRequest     = "grpc.ResumeContainerRequest"
+	grpcReseedRandomDevRequest     = "grpc.ReseedRandomDevRequest"
+	grpcGuestDetailsRequest        = "grpc.GuestDetailsRequest"
+	grpcMemHotplugByProbeRequest   = "grpc.MemHotplugByProbeRequest"
+	grpcCopyFileRequest            = "grpc.CopyFileRequest"
+	grpcReadFileRequest            = "grpc.ReadFileRequest"
+	grpcSetGuestDateTimeRequest    = "grpc.SetGuestDateTimeRequest"
+	grpcStartTracingRequest        = "grpc.StartTracingRequest"
+	grpcStopTracingRequest         = "grpc.StopTracingRequest"
+	grpcGetOOMEventRequest         = "grpc.GetOOMEventRequest"
+	grpcGetMetricsRequest          = "grpc.GetMetricsRequest"
+	grpcGetTDReportRequest         = "grpc.GetTDReportRequest"
+	grpcReleaseDeviceRequest       = "grpc.ReleaseDeviceRequest"
+	grpcInstallFileRequest         = "grpc.InstallFileRequest"
+	grpcLoadCrashKernelRequest     = "grpc.LoadCrashKernelRequest"
+	grpcStartProfilingRequest      = "grpc.StartProfilingRequest"
+	grpcLoadLivepatchRequest       = "grpc.LoadLivepatchRequest"
+	grpcCheckpointContainerRequest = "grpc.CheckpointContainerRequest"
+	grpcRestoreContainerRequest    = "grpc.RestoreContainerRequest"
 )
 
 // newKataAgent returns an agent from an agent type.
@@ -212,6 +227,9 @@ type KataAgentConfig struct {
 type KataAgentState struct {
 	ProxyPid int
 	URL      string
+
+	// APIVersion is the API version the agent reported
+	APIVersion string
 }
 
 type kataAgent struct {
@@ -231,6 +249,16 @@ type kataAgent struct {
 
 	vmSocket interface{}
 	ctx      context.Context
+
+	// apiCompat describes how to talk to the agent, from its API version.
+	apiCompat *agentAPICompat
+
+	// vmSwitch is read locked by the requests to the agent, and locked
+	// while the guest moves to another process of the hypervisor.
+	vmSwitch sync.RWMutex
+	// vmSwitches counts the moves of the guest to another process of the
+	// hypervisor.
+	vmSwitches uint64
 }
 
 func (k *kataAgent) trace(name string) (opentracing.Span, context.Context) {
@@ -848,6 +876,7 @@ func (k *kataAgent) startSandbox(sandbox *Sandbox) error {
 	if err != nil {
 		return err
 	}
+	interfaces, routes, neighs = sandbox.config.NetworkConfig.DHCPInterfaces.strip(interfaces, routes, neighs)
 	if err = k.updateInterfaces(interfaces); err != nil {
 		return err
 	}
@@ -1063,11 +1092,10 @@ func (k *kataAgent) replaceOCIMountsForStorages(spec *specs.Spec, volumeStorages
 	return nil
 }
 
-func (k *kataAgent) constraintGRPCSpec(grpcSpec *grpc.Spec, passSeccomp bool) {
-	// Disable Hooks since they have been handled on the host and there is
-	// no reason to send them to the agent. It would make no sense to try
-	// to apply them on the guest.
-	grpcSpec.Hooks = nil
+func (k *kataAgent) constraintGRPCSpec(grpcSpec *grpc.Spec, passSeccomp bool, guestHookTypes []string) {
+	// Only pass the hooks run in the guest, the others have been handled
+	// on the host and there is no reason to send them to the agent.
+	grpcSpec.Hooks = guestHooks(grpcSpec.Hooks, guestHookTypes)
 
 	// Pass seccomp only if disable_guest_seccomp is set to false in
 	// configuration.toml and guest image is seccomp capable.
@@ -1082,11 +1110,11 @@ func (k *kataAgent) constraintGRPCSpec(grpcSpec *grpc.Spec, passSeccomp bool) {
 		grpcSpec.Process.SelinuxLabel = ""
 	}
 
-	// By now only CPU constraints are supported
+	// By now only CPU, memory and pids constraints are supported. The pids
+	// limit is enforced in the guest, where the container processes run.
 	// Issue: https://github.com/kata-containers/runtime/issues/158
 	// Issue: https://github.com/kata-containers/runtime/issues/204
 	grpcSpec.Linux.Resources.Devices = nil
-	grpcSpec.Linux.Resources.Pids = nil
 	grpcSpec.Linux.Resources.BlockIO = nil
 	grpcSpec.Linux.Resources.HugepageLimits = nil
 	grpcSpec.Linux.Resources.Network = nil
@@ -1113,8 +1141,8 @@ func (k *kataAgent) constraintGRPCSpec(grpcSpec *grpc.Spec, passSeccomp bool) {
 	var tmpNamespaces []grpc.LinuxNamespace
 	for _, ns := range grpcSpec.Linux.Namespaces {
 		switch ns.Type {
-		case specs.CgroupNamespace:
-		case specs.NetworkNamespace:
+		case string(specs.CgroupNamespace):
+		case string(specs.NetworkNamespace):
 		default:
 			ns.Path = ""
 			tmpNamespaces = append(tmpNamespaces, ns)
@@ -1182,11 +1210,18 @@ func (k *kataAgent) appendBlockDevice(dev ContainerDevice, c *Container) *grpc.D
 		return nil
 	}
 
-	kataDevice := &grpc.Device{
-		ContainerPath: dev.ContainerPath,
-	}
+	kataDevice := blockDriveDevice(d, c.sandbox.config.HypervisorConfig.BlockDeviceDriver)
+	kataDevice.ContainerPath = dev.ContainerPath
+
+	return kataDevice
+}
+
+// blockDriveDevice describes a block drive attached with the block device
+// driver to the agent.
+func blockDriveDevice(d *config.BlockDrive, blockDeviceDriver string) *grpc.Device {
+	kataDevice := &grpc.Device{}
 
-	switch c.sandbox.config.HypervisorConfig.BlockDeviceDriver {
+	switch blockDeviceDriver {
 	case config.VirtioMmio:
 		kataDevice.Type = kataMmioBlkDevType
 		kataDevice.Id = d.VirtPath
@@ -1227,6 +1262,71 @@ func (k *kataAgent) appendVhostUserBlkDevice(dev ContainerDevice, c *Container)
 	return kataDevice
 }
 
+// appendVFIORDMADevices describes the RDMA devices of a VFIO group to the
+// agent, the VFIO group not appearing in the guest, for it to load their
+// drivers and to add the guest RDMA character devices to the container.
+func (k *kataAgent) appendVFIORDMADevices(dev ContainerDevice, c *Container) []*grpc.Device {
+	device := c.sandbox.devManager.GetDeviceByID(dev.ID)
+
+	vfioDevs, ok := device.GetDeviceInfo().([]*config.VFIODev)
+	if !ok {
+		k.Logger().WithField("device", device).Error("malformed vfio device")
+		return nil
+	}
+
+	var kataDevices []*grpc.Device
+	for _, d := range vfioDevs {
+		if d == nil || !drivers.IsRDMADevice(d) {
+			continue
+		}
+
+		kataDevices = append(kataDevices, &grpc.Device{
+			Id:            d.ID,
+			Type:          kataVFIORDMADevType,
+			ContainerPath: rdmaDevicesPath,
+			Options:       drivers.MellanoxRDMAModules,
+		})
+	}
+
+	return kataDevices
+}
+
+// appendVFIOGPUDevices describes the vGPUs of a VFIO group to the agent, by
+// their PCI path in the guest, for it to load their guest driver and to wait
+// for the driver to drive them before starting the container.
+func (k *kataAgent) appendVFIOGPUDevices(dev ContainerDevice, c *Container) []*grpc.Device {
+	device := c.sandbox.devManager.GetDeviceByID(dev.ID)
+
+	vfioDevs, ok := device.GetDeviceInfo().([]*config.VFIODev)
+	if !ok {
+		k.Logger().WithField("device", device).Error("malformed vfio device")
+		return nil
+	}
+
+	var kataDevices []*grpc.Device
+	for _, d := range vfioDevs {
+		if d == nil || !drivers.IsNVIDIAVGPU(d) {
+			continue
+		}
+
+		// The devices hotplugged on a root port or on the root bus
+		// cannot be located in the guest.
+		if d.GuestPCIPath == "" {
+			k.Logger().WithField("device", d.ID).Warn("Not waiting for the guest driver of a vGPU of unknown PCI path")
+			continue
+		}
+
+		kataDevices = append(kataDevices, &grpc.Device{
+			Id:            d.GuestPCIPath,
+			Type:          kataVFIOGPUDevType,
+			ContainerPath: dev.ContainerPath,
+			Options:       drivers.NVIDIAVGPUModules,
+		})
+	}
+
+	return kataDevices
+}
+
 func (k *kataAgent) appendDevices(deviceList []*grpc.Device, c *Container) []*grpc.Device {
 	var kataDevice *grpc.Device
 
@@ -1242,6 +1342,10 @@ func (k *kataAgent) appendDevices(deviceList []*grpc.Device, c *Container) []*gr
 			kataDevice = k.appendBlockDevice(dev, c)
 		case config.VhostUserBlk:
 			kataDevice = k.appendVhostUserBlkDevice(dev, c)
+		case config.DeviceVFIO:
+			deviceList = append(deviceList, k.appendVFIORDMADevices(dev, c)...)
+			deviceList = append(deviceList, k.appendVFIOGPUDevices(dev, c)...)
+			continue
 		}
 
 		if kataDevice == nil {
@@ -1267,6 +1371,10 @@ func (k *kataAgent) rollbackFailingContainerCreation(c *Container) {
 		if err2 := bindUnmountContainerRootfs(k.ctx, getMountPath(c.sandbox.id), c.id); err2 != nil {
 			k.Logger().WithError(err2).Error("rollback failed bindUnmountContainerRootfs()")
 		}
+
+		if err2 := bindUnmountCoreDumpDir(k.ctx, getMountPath(c.sandbox.id), c.id); err2 != nil {
+			k.Logger().WithError(err2).Error("rollback failed bindUnmountCoreDumpDir()")
+		}
 	}
 }
 
@@ -1331,6 +1439,10 @@ func (k *kataAgent) buildContainerRootfs(sandbox *Sandbox, c *Container, rootPat
 		return rootfs, nil
 	}
 
+	if sandbox.config.HypervisorConfig.SharedFS == config.NoSharedFS {
+		return nil, fmt.Errorf("The rootfs of container %s is not a block device, and the sandbox does not share files with the guest", c.id)
+	}
+
 	// This is not a block based device rootfs.
 	// We are going to bind mount it into the 9pfs
 	// shared drive between the host and the guest.
@@ -1387,6 +1499,12 @@ func (k *kataAgent) createContainer(sandbox *Sandbox, c *Container) (p *Process,
 		ctrStorages = append(ctrStorages, rootfs)
 	}
 
+	scratchStorages, err := k.handleEncryptedScratch(c, rootPath)
+	if err != nil {
+		return nil, err
+	}
+	ctrStorages = append(ctrStorages, scratchStorages...)
+
 	ociSpec := c.GetPatchedOCISpec()
 	if ociSpec == nil {
 		return nil, errorMissingOCISpec
@@ -1398,6 +1516,10 @@ func (k *kataAgent) createContainer(sandbox *Sandbox, c *Container) (p *Process,
 		return nil, err
 	}
 
+	if err = c.shareCoreDumpDir(ociSpec, getMountPath(sandbox.id), kataGuestSharedDir()); err != nil {
+		return nil, err
+	}
+
 	k.handleShm(ociSpec.Mounts, sandbox)
 
 	epheStorages := k.handleEphemeralStorage(ociSpec.Mounts)
@@ -1434,6 +1556,14 @@ func (k *kataAgent) createContainer(sandbox *Sandbox, c *Container) (p *Process,
 
 	ctrStorages = append(ctrStorages, volumeStorages...)
 
+	// Handle the volumes shared through virtio-fs devices of their own.
+	virtioFSStorages := k.handleVirtioFSVolumes(c)
+	if err := k.replaceOCIMountsForStorages(ociSpec, virtioFSStorages); err != nil {
+		return nil, err
+	}
+
+	ctrStorages = append(ctrStorages, virtioFSStorages...)
+
 	grpcSpec, err := grpc.OCItoGRPC(ociSpec)
 	if err != nil {
 		return nil, err
@@ -1444,11 +1574,15 @@ func (k *kataAgent) createContainer(sandbox *Sandbox, c *Container) (p *Process,
 
 	sharedPidNs := k.handlePidNamespace(grpcSpec, sandbox)
 
-	passSeccomp := !sandbox.config.DisableGuestSeccomp && sandbox.seccompSupported
+	passSeccomp := !sandbox.config.DisableGuestSeccomp && sandbox.state.GuestSeccompSupported
 
 	// We need to constraint the spec to make sure we're not passing
 	// irrelevant information to the agent.
-	k.constraintGRPCSpec(grpcSpec, passSeccomp)
+	k.constraintGRPCSpec(grpcSpec, passSeccomp, sandbox.config.GuestHooks)
+
+	if cpu := grpcSpec.Linux.Resources.CPU; cpu != nil {
+		cpu.Shares = sandbox.config.CPUShares.guestShares(cpu.Shares)
+	}
 
 	req := &grpc.CreateContainerRequest{
 		ContainerId:  c.id,
@@ -1646,6 +1780,74 @@ func (k *kataAgent) handleBlockVolumes(c *Container) ([]*grpc.Storage, error) {
 	return volumeStorages, nil
 }
 
+// handleEncryptedScratch returns the storages of the encrypted scratch
+// device of the container, if any, and of the overlay stacking the writable
+// layer it holds on the rootfs.
+func (k *kataAgent) handleEncryptedScratch(c *Container, rootPath string) ([]*grpc.Storage, error) {
+	id := c.state.ScratchDeviceID
+	if id == "" {
+		return nil, nil
+	}
+
+	device := c.sandbox.devManager.GetDeviceByID(id)
+	if device == nil {
+		k.Logger().WithField("device", id).Error("failed to find device by id")
+		return nil, fmt.Errorf("Failed to find device by id (id=%s)", id)
+	}
+
+	scratch, err := k.handleDeviceBlockVolume(c, device)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := scratchGuestPath(c.id)
+	scratch.DriverOptions = []string{scratchEncryptionOption}
+	scratch.Fstype = scratchFstype
+	scratch.MountPoint = dir
+
+	overlay := &grpc.Storage{
+		Driver: kataOverlayFSDevType,
+		Source: "overlay",
+		Fstype: "overlay",
+		Options: []string{
+			"lowerdir=" + rootPath,
+			"upperdir=" + filepath.Join(dir, "upper"),
+			"workdir=" + filepath.Join(dir, "work"),
+		},
+		MountPoint: rootPath,
+	}
+
+	return []*grpc.Storage{scratch, overlay}, nil
+}
+
+// handleVirtioFSVolumes returns the storages of the volumes shared through
+// virtio-fs devices of their own, mounted with their tags.
+func (k *kataAgent) handleVirtioFSVolumes(c *Container) []*grpc.Storage {
+	var volumeStorages []*grpc.Storage
+
+	for _, m := range c.mounts {
+		if m.VirtioFSDeviceID == "" {
+			continue
+		}
+
+		// As for the shared directory, DAX needs the guest to cache
+		var options []string
+		if m.VirtioFS.Cache != typeVirtioFSNoCache && m.VirtioFS.DAXWindowMB != 0 {
+			options = append(options, sharedDirVirtioFSDaxOptions)
+		}
+
+		volumeStorages = append(volumeStorages, &grpc.Storage{
+			Driver:     kataVirtioFSDevType,
+			Source:     virtioFSShareTag(m.VirtioFSDeviceID),
+			MountPoint: m.Destination,
+			Fstype:     typeVirtioFS,
+			Options:    options,
+		})
+	}
+
+	return volumeStorages
+}
+
 // handlePidNamespace checks if Pid namespace for a container needs to be shared with its sandbox
 // pid namespace. This function also modifies the grpc spec to remove the pid namespace
 // from the list of namespaces passed to the agent.
@@ -1749,6 +1951,10 @@ func (k *kataAgent) updateContainer(sandbox *Sandbox, c Container, resources spe
 		return err
 	}
 
+	if grpcResources.CPU != nil {
+		grpcResources.CPU.Shares = sandbox.config.CPUShares.guestShares(grpcResources.CPU.Shares)
+	}
+
 	req := &grpc.UpdateContainerRequest{
 		ContainerId: c.id,
 		Resources:   grpcResources,
@@ -1801,8 +2007,11 @@ func (k *kataAgent) memHotplugByProbe(addr uint64, sizeMB uint32, memorySectionS
 }
 
 func (k *kataAgent) onlineCPUMem(cpus uint32, cpuOnly bool) error {
+	// Wait for the guest to online everything, so that a resource that
+	// never comes online is reported instead of silently mis-sizing the
+	// container cgroups in the guest.
 	req := &grpc.OnlineCPUMemRequest{
-		Wait:    false,
+		Wait:    true,
 		NbCpus:  cpus,
 		CpuOnly: cpuOnly,
 	}
@@ -1843,6 +2052,15 @@ func (k *kataAgent) statsContainer(sandbox *Sandbox, c Container) (*ContainerSta
 	return containerStats, nil
 }
 
+// isContainerNotFound tells whether err is the agent reporting it does not
+// know about the container of a request. Agents older than the NotFound
+// code report it as an internal error.
+func isContainerNotFound(err error) bool {
+	st := grpcStatus.Convert(err)
+	return st.Code() == codes.NotFound ||
+		(st.Code() == codes.Internal && st.Message() == "invalid container id")
+}
+
 func (k *kataAgent) connect() error {
 	if k.dead {
 		return errors.New("Dead agent")
@@ -1903,16 +2121,67 @@ func (k *kataAgent) disconnect() error {
 	return nil
 }
 
-// check grpc server is serving
+// check grpc server is serving, and that the runtime can talk to the agent
 func (k *kataAgent) check() error {
 	span, _ := k.trace("check")
 	defer span.Finish()
 
 	_, err := k.sendReq(&grpc.CheckRequest{})
 	if err != nil {
-		err = fmt.Errorf("Failed to check if grpc server is working: %s", err)
+		return fmt.Errorf("Failed to check if grpc server is working: %s", err)
 	}
-	return err
+
+	return k.checkAPIVersion()
+}
+
+// checkAPIVersion does not go through sendReq, the CheckRequest of Version
+// is the request of Check.
+func (k *kataAgent) checkAPIVersion() error {
+	k.vmSwitch.RLock()
+	defer k.vmSwitch.RUnlock()
+
+	if err := k.connect(); err != nil {
+		return err
+	}
+	if !k.keepConn {
+		defer k.disconnect()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), checkRequestTimeout)
+	defer cancel()
+
+	resp, err := k.client.HealthClient.Version(ctx, &grpc.CheckRequest{})
+	if err != nil {
+		return fmt.Errorf("Failed to get the agent API version: %v", err)
+	}
+
+	k.Logger().WithFields(logrus.Fields{
+		"agent-version":     resp.AgentVersion,
+		"agent-api-version": resp.GrpcVersion,
+	}).Info("Agent API version")
+
+	return k.setAPIVersion(resp.GrpcVersion)
+}
+
+func (k *kataAgent) setAPIVersion(version string) error {
+	compat, err := lookupAgentAPICompat(version)
+	if err != nil {
+		return err
+	}
+
+	k.state.APIVersion = version
+	k.apiCompat = compat
+
+	return nil
+}
+
+// shimRequest adapts a request to the API version of the agent, known once
+// the agent is checked.
+func (k *kataAgent) shimRequest(name string, req interface{}) error {
+	if k.apiCompat == nil {
+		return nil
+	}
+	return k.apiCompat.shimRequest(k, name, req)
 }
 
 func (k *kataAgent) waitProcess(c *Container, processID string) (int32, error) {
@@ -1961,6 +2230,51 @@ func (k *kataAgent) reseedRNG(data []byte) error {
 	return err
 }
 
+func (k *kataAgent) reconnect(sandbox *Sandbox) error {
+	span, _ := k.trace("reconnect")
+	defer span.Finish()
+
+	if err := k.startProxy(sandbox); err != nil {
+		return err
+	}
+
+	return k.check()
+}
+
+func (k *kataAgent) beginVMSwitch() error {
+	k.vmSwitch.Lock()
+	k.vmSwitches++
+
+	return nil
+}
+
+func (k *kataAgent) endVMSwitch(sandbox *Sandbox, vmSocket interface{}) error {
+	defer k.vmSwitch.Unlock()
+
+	if vmSocket == nil {
+		return nil
+	}
+
+	// The connections to the agent went away with the previous process
+	// of the hypervisor.
+	if err := k.disconnect(); err != nil {
+		k.Logger().WithError(err).Warn("Could not close the connection to the agent")
+	}
+
+	if k.proxy != nil {
+		if err := k.proxy.stop(k.state.ProxyPid); err != nil {
+			k.Logger().WithError(err).Warn("Could not stop the proxy")
+		}
+	}
+
+	// The proxy is started again with the new agent URL, watching the
+	// console of the new process of the hypervisor.
+	k.vmSocket = vmSocket
+	k.state.URL = ""
+
+	return k.startProxy(sandbox)
+}
+
 type reqFunc func(context.Context, interface{}) (interface{}, error)
 
 func (k *kataAgent) installReqFunc(c *kataclient.AgentClient) {
@@ -2046,6 +2360,9 @@ func (k *kataAgent) installReqFunc(c *kataclient.AgentClient) {
 	k.reqHandlers[grpcCopyFileRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
 		return k.client.AgentServiceClient.CopyFile(ctx, req.(*grpc.CopyFileRequest))
 	}
+	k.reqHandlers[grpcReadFileRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.ReadFile(ctx, req.(*grpc.ReadFileRequest))
+	}
 	k.reqHandlers[grpcSetGuestDateTimeRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
 		return k.client.AgentServiceClient.SetGuestDateTime(ctx, req.(*grpc.SetGuestDateTimeRequest))
 	}
@@ -2061,14 +2378,43 @@ func (k *kataAgent) installReqFunc(c *kataclient.AgentClient) {
 	k.reqHandlers[grpcGetMetricsRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
 		return k.client.AgentServiceClient.GetMetrics(ctx, req.(*grpc.GetMetricsRequest))
 	}
+	k.reqHandlers[grpcGetTDReportRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.GetTDReport(ctx, req.(*grpc.GetTDReportRequest))
+	}
+	k.reqHandlers[grpcReleaseDeviceRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.ReleaseDevice(ctx, req.(*grpc.ReleaseDeviceRequest))
+	}
+	k.reqHandlers[grpcInstallFileRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.InstallFile(ctx, req.(*grpc.InstallFileRequest))
+	}
+	k.reqHandlers[grpcLoadCrashKernelRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.LoadCrashKernel(ctx, req.(*grpc.LoadCrashKernelRequest))
+	}
+	k.reqHandlers[grpcStartProfilingRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.StartProfiling(ctx, req.(*grpc.StartProfilingRequest))
+	}
+	k.reqHandlers[grpcLoadLivepatchRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.LoadLivepatch(ctx, req.(*grpc.LoadLivepatchRequest))
+	}
+	k.reqHandlers[grpcCheckpointContainerRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.CheckpointContainer(ctx, req.(*grpc.CheckpointContainerRequest))
+	}
+	k.reqHandlers[grpcRestoreContainerRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.RestoreContainer(ctx, req.(*grpc.RestoreContainerRequest))
+	}
+}
+
+// isWaitRequest tells if a request waits for an event of the guest.
+func isWaitRequest(reqName string) bool {
+	return reqName == grpcWaitProcessRequest || reqName == grpcGetOOMEventRequest
 }
 
 func (k *kataAgent) getReqContext(reqName string) (ctx context.Context, cancel context.CancelFunc) {
 	ctx = context.Background()
-	switch reqName {
-	case grpcWaitProcessRequest, grpcGetOOMEventRequest:
+	switch {
+	case isWaitRequest(reqName):
 		// Wait and GetOOMEvent have no timeout
-	case grpcCheckRequest:
+	case reqName == grpcCheckRequest:
 		ctx, cancel = context.WithTimeout(ctx, checkRequestTimeout)
 	default:
 		ctx, cancel = context.WithTimeout(ctx, defaultRequestTimeout)
@@ -2078,6 +2424,50 @@ func (k *kataAgent) getReqContext(reqName string) (ctx context.Context, cancel c
 }
 
 func (k *kataAgent) sendReq(request interface{}) (interface{}, error) {
+	msgName := proto.MessageName(request.(proto.Message))
+
+	// The requests waiting for an event of the guest do not hold a move
+	// of the guest back, they are sent again when it breaks them.
+	if isWaitRequest(msgName) {
+		var resp interface{}
+		err := k.retryAcrossVMSwitch(msgName, func() (err error) {
+			resp, err = k.sendReqToVM(request)
+			return err
+		})
+		return resp, err
+	}
+
+	k.vmSwitch.RLock()
+	defer k.vmSwitch.RUnlock()
+
+	return k.sendReqToVM(request)
+}
+
+// retryAcrossVMSwitch calls send again when a move of the guest to another
+// process of the hypervisor broke it.
+func (k *kataAgent) retryAcrossVMSwitch(name string, send func() error) error {
+	for {
+		switches := k.vmSwitchCount()
+
+		err := send()
+		if err == nil || k.vmSwitchCount() == switches {
+			return err
+		}
+
+		k.Logger().WithError(err).WithField("name", name).Info("Sending again the request broken by a move of the guest")
+	}
+}
+
+// vmSwitchCount returns the number of moves of the guest to another process
+// of the hypervisor, once the ongoing one completed.
+func (k *kataAgent) vmSwitchCount() uint64 {
+	k.vmSwitch.RLock()
+	defer k.vmSwitch.RUnlock()
+
+	return k.vmSwitches
+}
+
+func (k *kataAgent) sendReqToVM(request interface{}) (interface{}, error) {
 	start := time.Now()
 	span, _ := k.trace("sendReq")
 	span.SetTag("request", request)
@@ -2095,6 +2485,9 @@ func (k *kataAgent) sendReq(request interface{}) (interface{}, error) {
 	if msgName == "" || handler == nil {
 		return nil, errors.New("Invalid request type")
 	}
+	if err := k.shimRequest(msgName, request); err != nil {
+		return nil, err
+	}
 	message := request.(proto.Message)
 	ctx, cancel := k.getReqContext(msgName)
 	if cancel != nil {
@@ -2109,27 +2502,37 @@ func (k *kataAgent) sendReq(request interface{}) (interface{}, error) {
 }
 
 // readStdout and readStderr are special that we cannot differentiate them with the request types...
-func (k *kataAgent) readProcessStdout(c *Container, processID string, data []byte) (int, error) {
-	if err := k.connect(); err != nil {
-		return 0, err
-	}
-	if !k.keepConn {
-		defer k.disconnect()
-	}
+func (k *kataAgent) readProcessStdout(c *Container, processID string, data []byte) (n int, err error) {
+	err = k.retryAcrossVMSwitch("ReadStdout", func() error {
+		if err := k.connect(); err != nil {
+			return err
+		}
+		if !k.keepConn {
+			defer k.disconnect()
+		}
 
-	return k.readProcessStream(c.id, processID, data, k.client.AgentServiceClient.ReadStdout)
+		n, err = k.readProcessStream(c.id, processID, data, k.client.AgentServiceClient.ReadStdout)
+		return err
+	})
+
+	return n, err
 }
 
 // readStdout and readStderr are special that we cannot differentiate them with the request types...
-func (k *kataAgent) readProcessStderr(c *Container, processID string, data []byte) (int, error) {
-	if err := k.connect(); err != nil {
-		return 0, err
-	}
-	if !k.keepConn {
-		defer k.disconnect()
-	}
+func (k *kataAgent) readProcessStderr(c *Container, processID string, data []byte) (n int, err error) {
+	err = k.retryAcrossVMSwitch("ReadStderr", func() error {
+		if err := k.connect(); err != nil {
+			return err
+		}
+		if !k.keepConn {
+			defer k.disconnect()
+		}
+
+		n, err = k.readProcessStream(c.id, processID, data, k.client.AgentServiceClient.ReadStderr)
+		return err
+	})
 
-	return k.readProcessStream(c.id, processID, data, k.client.AgentServiceClient.ReadStderr)
+	return n, err
 }
 
 type readFn func(context.Context, *grpc.ReadStreamRequest) (*grpc.ReadStreamResponse, error)
@@ -2165,6 +2568,122 @@ func (k *kataAgent) setGuestDateTime(tv time.Time) error {
 	return err
 }
 
+// releaseDevice has the agent unmount a block device and write out its
+// buffers before the device is hot unplugged. The other devices need
+// nothing from the guest.
+func (k *kataAgent) releaseDevice(sandbox *Sandbox, device api.Device) error {
+	var kataDevice *grpc.Device
+
+	switch d := device.GetDeviceInfo().(type) {
+	case *config.BlockDrive:
+		if d.Pmem {
+			kataDevice = &grpc.Device{
+				Type:   kataNvdimmDevType,
+				VmPath: fmt.Sprintf("/dev/pmem%s", d.NvdimmID),
+			}
+		} else {
+			kataDevice = blockDriveDevice(d, sandbox.config.HypervisorConfig.BlockDeviceDriver)
+		}
+	case *config.VhostUserDeviceAttrs:
+		if device.DeviceType() != config.VhostUserBlk {
+			return nil
+		}
+		kataDevice = &grpc.Device{
+			Type: kataBlkDevType,
+			Id:   d.PCIAddr,
+		}
+	default:
+		return nil
+	}
+
+	_, err := k.sendReq(&grpc.ReleaseDeviceRequest{Device: kataDevice})
+	return err
+}
+
+// suspendGuest does not go through sendReq, its Empty request does not
+// identify the call.
+func (k *kataAgent) suspendGuest() error {
+	k.vmSwitch.RLock()
+	defer k.vmSwitch.RUnlock()
+
+	if err := k.shimRequest(grpcSuspendGuestCall, nil); err != nil {
+		return err
+	}
+	if err := k.connect(); err != nil {
+		return err
+	}
+	if !k.keepConn {
+		defer k.disconnect()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+	defer cancel()
+
+	_, err := k.client.AgentServiceClient.SuspendGuest(ctx, &gpb.Empty{})
+	return err
+}
+
+func (k *kataAgent) installFile(src, path string) error {
+	_, err := k.sendReq(&grpc.InstallFileRequest{
+		Source: src,
+		Path:   path,
+	})
+
+	return err
+}
+
+func (k *kataAgent) loadCrashKernel(kernel, initrd string) error {
+	_, err := k.sendReq(&grpc.LoadCrashKernelRequest{
+		Kernel: kernel,
+		Initrd: initrd,
+	})
+
+	return err
+}
+
+// startProfiling rounds the session duration up to the second.
+func (k *kataAgent) startProfiling(session string, req ProfileRequest) error {
+	secs := (req.Duration + time.Second - 1) / time.Second
+
+	_, err := k.sendReq(&grpc.StartProfilingRequest{
+		Session:   session,
+		Tool:      string(req.Tool),
+		Duration:  uint32(secs),
+		Frequency: req.Frequency,
+		Script:    req.Script,
+	})
+
+	return err
+}
+
+func (k *kataAgent) loadLivepatch(module, path string) error {
+	_, err := k.sendReq(&grpc.LoadLivepatchRequest{
+		Module: module,
+		Path:   path,
+	})
+
+	return err
+}
+
+func (k *kataAgent) checkpointContainer(c *Container, imagesDir string, leaveRunning bool) error {
+	_, err := k.sendReq(&grpc.CheckpointContainerRequest{
+		ContainerId:  c.id,
+		ImagesDir:    imagesDir,
+		LeaveRunning: leaveRunning,
+	})
+
+	return err
+}
+
+func (k *kataAgent) restoreContainer(c *Container, imagesDir string) error {
+	_, err := k.sendReq(&grpc.RestoreContainerRequest{
+		ContainerId: c.id,
+		ImagesDir:   imagesDir,
+	})
+
+	return err
+}
+
 func (k *kataAgent) convertToKataAgentIPFamily(ipFamily int) aTypes.IPFamily {
 	switch ipFamily {
 	case netlink.FAMILY_V4:
@@ -2329,6 +2848,16 @@ func (k *kataAgent) convertToRoutes(aRoutes []*aTypes.Route) (routes []*vcTypes.
 }
 
 func (k *kataAgent) copyFile(src, dst string) error {
+	return k.copyFileToGuest(src, "", dst)
+}
+
+func (k *kataAgent) copyFileToRootfs(src, rootfs, dst string) error {
+	return k.copyFileToGuest(src, rootfs, dst)
+}
+
+// copyFileToGuest copies the host file src to the guest file dst, relative
+// to the container rootfs directory root when it is set.
+func (k *kataAgent) copyFileToGuest(src, root, dst string) error {
 	var st unix.Stat_t
 
 	err := unix.Stat(src, &st)
@@ -2336,20 +2865,23 @@ func (k *kataAgent) copyFile(src, dst string) error {
 		return fmt.Errorf("Could not get file %s information: %v", src, err)
 	}
 
-	b, err := ioutil.ReadFile(src)
+	f, err := os.Open(src)
 	if err != nil {
 		return fmt.Errorf("Could not read file %s: %v", src, err)
 	}
+	defer f.Close()
 
-	fileSize := int64(len(b))
+	fileSize := st.Size
 
 	k.Logger().WithFields(logrus.Fields{
 		"source": src,
+		"root":   root,
 		"dest":   dst,
 	}).Debugf("Copying file from host to guest")
 
 	cpReq := &grpc.CopyFileRequest{
 		Path:     dst,
+		Root:     root,
 		DirMode:  uint32(DirMode),
 		FileMode: st.Mode,
 		FileSize: fileSize,
@@ -2363,13 +2895,22 @@ func (k *kataAgent) copyFile(src, dst string) error {
 		return err
 	}
 
-	// Copy file by parts if it's needed
-	remainingBytes := fileSize
-	offset := int64(0)
-	for remainingBytes > 0 {
-		bytesToCopy := int64(len(b))
-		if bytesToCopy > grpcMaxDataSize {
-			bytesToCopy = grpcMaxDataSize
+	// Copy file by parts if it's needed, not to read a large file in
+	// memory at once
+	chunkSize := fileSize
+	if chunkSize > grpcMaxDataSize {
+		chunkSize = grpcMaxDataSize
+	}
+	b := make([]byte, chunkSize)
+
+	for offset := int64(0); offset < fileSize; {
+		bytesToCopy := fileSize - offset
+		if bytesToCopy > chunkSize {
+			bytesToCopy = chunkSize
+		}
+
+		if _, err = io.ReadFull(f, b[:bytesToCopy]); err != nil {
+			return fmt.Errorf("Could not read file %s: %v", src, err)
 		}
 
 		cpReq.Data = b[:bytesToCopy]
@@ -2379,14 +2920,71 @@ func (k *kataAgent) copyFile(src, dst string) error {
 			return fmt.Errorf("Could not send CopyFile request: %v", err)
 		}
 
-		b = b[bytesToCopy:]
-		remainingBytes -= bytesToCopy
-		offset += grpcMaxDataSize
+		offset += bytesToCopy
 	}
 
 	return nil
 }
 
+// copyFileFromRootfs copies the file src of the container rootfs directory
+// rootfs of the guest to the host file dst, by parts. The file is written
+// next to dst first, dst being only replaced once the whole file is read.
+func (k *kataAgent) copyFileFromRootfs(rootfs, src, dst string) (err error) {
+	k.Logger().WithFields(logrus.Fields{
+		"root":   rootfs,
+		"source": src,
+		"dest":   dst,
+	}).Debugf("Copying file from guest to host")
+
+	f, err := ioutil.TempFile(filepath.Dir(dst), "."+filepath.Base(dst))
+	if err != nil {
+		return fmt.Errorf("Could not create file %s: %v", dst, err)
+	}
+	defer func() {
+		f.Close()
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+
+	var resp *grpc.ReadFileResponse
+	for offset := int64(0); ; {
+		r, err := k.sendReq(&grpc.ReadFileRequest{
+			Path:   src,
+			Root:   rootfs,
+			Offset: offset,
+			Length: grpcMaxDataSize,
+		})
+		if err != nil {
+			return fmt.Errorf("Could not send ReadFile request: %v", err)
+		}
+		resp = r.(*grpc.ReadFileResponse)
+
+		if _, err = f.Write(resp.Data); err != nil {
+			return fmt.Errorf("Could not write file %s: %v", dst, err)
+		}
+
+		offset += int64(len(resp.Data))
+		if offset >= resp.FileSize {
+			break
+		}
+
+		if len(resp.Data) == 0 {
+			return fmt.Errorf("File %s was truncated while read", src)
+		}
+	}
+
+	if err = f.Chmod(os.FileMode(resp.FileMode).Perm()); err != nil {
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), dst)
+}
+
 func (k *kataAgent) markDead() {
 	k.Logger().Infof("mark agent dead")
 	k.dead = true
@@ -2406,6 +3004,12 @@ func (k *kataAgent) cleanup(s *Sandbox) {
 	if err := bindUnmountAllRootfs(k.ctx, path, s); err != nil {
 		k.Logger().WithError(err).Errorf("failed to unmount vm mount path %s", path)
 	}
+	if err := bindUnmountKdumpDir(k.ctx, path); err != nil {
+		k.Logger().WithError(err).Errorf("failed to unmount the kdump directory of %s", path)
+	}
+	if err := bindUnmountGuestProfilingDir(k.ctx, path); err != nil {
+		k.Logger().WithError(err).Errorf("failed to unmount the profiling directory of %s", path)
+	}
 	if err := os.RemoveAll(getSandboxPath(s.id)); err != nil {
 		k.Logger().WithError(err).Errorf("failed to cleanup vm path %s", getSandboxPath(s.id))
 	}
@@ -2413,14 +3017,23 @@ func (k *kataAgent) cleanup(s *Sandbox) {
 
 func (k *kataAgent) save() persistapi.AgentState {
 	return persistapi.AgentState{
-		ProxyPid: k.state.ProxyPid,
-		URL:      k.state.URL,
+		ProxyPid:   k.state.ProxyPid,
+		URL:        k.state.URL,
+		APIVersion: k.state.APIVersion,
 	}
 }
 
 func (k *kataAgent) load(s persistapi.AgentState) {
 	k.state.ProxyPid = s.ProxyPid
 	k.state.URL = s.URL
+
+	// The sandboxes saved before the agent API version was checked are
+	// checked again when the runtime reconnects to their agent.
+	if s.APIVersion != "" {
+		if err := k.setAPIVersion(s.APIVersion); err != nil {
+			k.Logger().WithError(err).Warn("Unsupported agent API version")
+		}
+	}
 }
 
 func (k *kataAgent) getOOMEvent() (string, error) {
@@ -2443,3 +3056,12 @@ func (k *kataAgent) getAgentMetrics(req *grpc.GetMetricsRequest) (*grpc.Metrics,
 
 	return resp.(*grpc.Metrics), nil
 }
+
+func (k *kataAgent) getTDReport(reportData []byte) ([]byte, error) {
+	resp, err := k.sendReq(&grpc.GetTDReportRequest{ReportData: reportData})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*grpc.GetTDReportResponse).Report, nil
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+
+	"github.com/blang/semver"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// SuspendGuest does not go through sendReq, its request does not
+	// identify the call.
+	grpcSuspendGuestCall = "grpc.AgentService.SuspendGuest"
+)
+
+// agentAPICompat describes how the runtime talks to the agents of an API
+// minor version.
+type agentAPICompat struct {
+	version semver.Version
+
+	// unsupportedRequests are the requests the agents do not implement.
+	unsupportedRequests []string
+
+	// unsupportedDevices are the device types the agents do not handle.
+	// They are not passed to the agents, the containers starting without
+	// them.
+	unsupportedDevices []string
+
+	// unsupportedStorages are the storage drivers the agents do not
+	// handle.
+	unsupportedStorages []string
+
+	// shim adapts the requests to the agents, and fails the requests the
+	// agents would silently run without one of their fields.
+	shim func(compat *agentAPICompat, k *kataAgent, req interface{}) error
+}
+
+// agentAPICompatMatrix lists the agent API versions the runtime talks to,
+// the current one and the previous minor one, so that the runtime can be
+// upgraded before the guest images. The minor version of the API is bumped
+// when requests or fields are added, and its major version when they are
+// changed or removed.
+var agentAPICompatMatrix = []agentAPICompat{
+	{
+		version: semver.MustParse(grpc.APIVersion),
+	},
+	{
+		version: semver.MustParse("0.0.1"),
+		unsupportedRequests: []string{
+			grpcGetTDReportRequest,
+			grpcSuspendGuestCall,
+			grpcReadFileRequest,
+			grpcReleaseDeviceRequest,
+		},
+		unsupportedDevices: []string{
+			kataVFIORDMADevType,
+			kataVFIOGPUDevType,
+		},
+		unsupportedStorages: []string{
+			kataOverlayFSDevType,
+		},
+		shim: shimAgentAPIV0,
+	},
+}
+
+// lookupAgentAPICompat returns how to talk to the agents of an API version.
+// Agents of a newer minor version than the runtime only add requests and
+// fields, the runtime talks to them as to the agents of its version.
+func lookupAgentAPICompat(version string) (*agentAPICompat, error) {
+	v, err := semver.Make(version)
+	if err != nil {
+		return nil, fmt.Errorf("Malformed agent API version %q: %v", version, err)
+	}
+
+	current := &agentAPICompatMatrix[0]
+	if v.Major == current.version.Major && v.Minor >= current.version.Minor {
+		return current, nil
+	}
+
+	for i := range agentAPICompatMatrix {
+		compat := &agentAPICompatMatrix[i]
+		if v.Major == compat.version.Major && v.Minor == compat.version.Minor {
+			return compat, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Agent API version %s is not supported by the runtime, which supports API version %s and the previous minor version, upgrade the guest image", v, current.version)
+}
+
+func (compat *agentAPICompat) checkRequest(name string) error {
+	for _, r := range compat.unsupportedRequests {
+		if r == name {
+			return fmt.Errorf("%s is not supported by the agent API version %s", name, compat.version)
+		}
+	}
+	return nil
+}
+
+func (compat *agentAPICompat) shimRequest(k *kataAgent, name string, req interface{}) error {
+	if err := compat.checkRequest(name); err != nil {
+		return err
+	}
+
+	if r, ok := req.(*grpc.CreateContainerRequest); ok {
+		if err := compat.shimContainerDevices(k, r); err != nil {
+			return err
+		}
+	}
+
+	if compat.shim != nil {
+		return compat.shim(compat, k, req)
+	}
+	return nil
+}
+
+func (compat *agentAPICompat) shimContainerDevices(k *kataAgent, req *grpc.CreateContainerRequest) error {
+	for _, s := range req.Storages {
+		for _, d := range compat.unsupportedStorages {
+			if s.Driver == d {
+				return fmt.Errorf("Storage driver %s of %s is not supported by the agent API version %s", s.Driver, s.MountPoint, compat.version)
+			}
+		}
+	}
+
+	var devices []*grpc.Device
+	for _, dev := range req.Devices {
+		supported := true
+		for _, t := range compat.unsupportedDevices {
+			if dev.Type == t {
+				supported = false
+				break
+			}
+		}
+		if !supported {
+			k.Logger().WithFields(logrus.Fields{
+				"device":            dev.Id,
+				"type":              dev.Type,
+				"agent-api-version": compat.version.String(),
+			}).Warn("Not passing a device the agent does not handle")
+			continue
+		}
+		devices = append(devices, dev)
+	}
+	req.Devices = devices
+
+	return nil
+}
+
+// shimAgentAPIV0 adapts the requests to the agents of API version 0.0,
+// which ignore the fields added since.
+func shimAgentAPIV0(compat *agentAPICompat, k *kataAgent, req interface{}) error {
+	var resources *grpc.LinuxResources
+
+	switch r := req.(type) {
+	case *grpc.CreateContainerRequest:
+		if r.OCI == nil {
+			return nil
+		}
+
+		if h := r.OCI.Hooks; h != nil && (len(h.CreateRuntime) > 0 || len(h.CreateContainer) > 0 || len(h.StartContainer) > 0) {
+			return fmt.Errorf("The createRuntime, createContainer and startContainer hooks are not supported by the agent API version %s", compat.version)
+		}
+
+		if r.OCI.Linux == nil {
+			return nil
+		}
+
+		// The agents would apply less specific seccomp rules.
+		if s := r.OCI.Linux.Seccomp; s != nil {
+			if len(s.Flags) > 0 {
+				return fmt.Errorf("Seccomp flags are not supported by the agent API version %s", compat.version)
+			}
+			for _, syscall := range s.Syscalls {
+				if syscall.ErrnoRet != 0 {
+					return fmt.Errorf("Seccomp errnoRet is not supported by the agent API version %s", compat.version)
+				}
+			}
+		}
+
+		resources = r.OCI.Linux.Resources
+	case *grpc.UpdateContainerRequest:
+		resources = r.Resources
+	case *grpc.CopyFileRequest:
+		// The agents would write the file relative to their working
+		// directory.
+		if r.Root != "" {
+			return fmt.Errorf("Copying a file to a container rootfs is not supported by the agent API version %s", compat.version)
+		}
+	}
+
+	if resources != nil && len(resources.Unified) > 0 {
+		k.Logger().WithField("agent-api-version", compat.version.String()).Warn("Not applying the unified cgroup resources the agent does not handle")
+		resources.Unified = nil
+	}
+
+	return nil
+}
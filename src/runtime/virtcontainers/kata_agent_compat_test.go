@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	pb "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupAgentAPICompat(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, v := range []string{pb.APIVersion, "0.1.7", "0.2.0"} {
+		compat, err := lookupAgentAPICompat(v)
+		assert.NoError(err, v)
+		assert.Equal(&agentAPICompatMatrix[0], compat, v)
+	}
+
+	compat, err := lookupAgentAPICompat("0.0.1")
+	assert.NoError(err)
+	assert.Equal(&agentAPICompatMatrix[1], compat)
+
+	for _, v := range []string{"", "0.1", "1.0.0"} {
+		_, err := lookupAgentAPICompat(v)
+		assert.Error(err, v)
+	}
+}
+
+// newCompatTestContainerRequest returns a request using the fields and
+// device types added to the API since its first version.
+func newCompatTestContainerRequest() *pb.CreateContainerRequest {
+	return &pb.CreateContainerRequest{
+		ContainerId: "foo",
+		Devices: []*pb.Device{
+			{Id: "01/02", Type: kataBlkDevType},
+			{Id: "02/03", Type: kataVFIOGPUDevType},
+			{Id: "02/04", Type: kataVFIORDMADevType},
+		},
+		OCI: &pb.Spec{
+			Linux: &pb.Linux{
+				Resources: &pb.LinuxResources{
+					Unified: map[string]string{"memory.high": "1G"},
+				},
+			},
+		},
+	}
+}
+
+// TestAgentAPICompat checks the runtime against mock agents of the API
+// versions of the compatibility matrix.
+func TestAgentAPICompat(t *testing.T) {
+	assert := assert.New(t)
+
+	sockDir, err := testGenerateKataProxySockDir()
+	assert.NoError(err)
+	defer os.RemoveAll(sockDir)
+	testKataProxyURL := fmt.Sprintf(testKataProxyURLTempl, sockDir)
+
+	startAgent := func(version string) (*gRPCProxy, *kataAgent, func()) {
+		impl := &gRPCProxy{apiVersion: version}
+		proxy := mock.ProxyGRPCMock{
+			GRPCImplementer: impl,
+			GRPCRegister:    gRPCRegister,
+		}
+		assert.NoError(proxy.Start(testKataProxyURL))
+
+		k := &kataAgent{
+			ctx: context.Background(),
+			state: KataAgentState{
+				URL: testKataProxyURL,
+			},
+		}
+		return impl, k, func() { proxy.Stop() }
+	}
+
+	for _, compat := range agentAPICompatMatrix {
+		version := compat.version.String()
+
+		impl, k, stop := startAgent(version)
+		assert.NoError(k.check(), version)
+		assert.Equal(version, k.state.APIVersion)
+		assert.Equal(version, k.save().APIVersion)
+
+		req := newCompatTestContainerRequest()
+		_, err = k.sendReq(req)
+		assert.NoError(err, version)
+		assert.Len(impl.createContainerReq.Devices, 3-len(compat.unsupportedDevices), version)
+		if compat.shim != nil {
+			assert.Empty(impl.createContainerReq.OCI.Linux.Resources.Unified, version)
+		} else {
+			assert.NotEmpty(impl.createContainerReq.OCI.Linux.Resources.Unified, version)
+		}
+
+		_, err = k.sendReq(&pb.GetTDReportRequest{})
+		assert.Equal(compat.checkRequest(grpcGetTDReportRequest) == nil, err == nil, version)
+		err = k.suspendGuest()
+		assert.Equal(compat.checkRequest(grpcSuspendGuestCall) == nil, err == nil, version)
+		_, err = k.sendReq(&pb.ReleaseDeviceRequest{})
+		assert.Equal(compat.checkRequest(grpcReleaseDeviceRequest) == nil, err == nil, version)
+		_, err = k.sendReq(&pb.ReadFileRequest{})
+		assert.Equal(compat.checkRequest(grpcReadFileRequest) == nil, err == nil, version)
+		_, err = k.sendReq(&pb.CopyFileRequest{Root: "/run/kata-containers/shared/containers/ctr/rootfs"})
+		assert.Equal(compat.shim == nil, err == nil, version)
+
+		// A runtime restarted with the sandbox talks to the agent as
+		// the runtime which started the sandbox.
+		restored := &kataAgent{}
+		restored.load(k.save())
+		assert.Equal(k.apiCompat, restored.apiCompat, version)
+
+		stop()
+	}
+
+	// The agents of API version 0.0 would not run the hooks.
+	_, k, stop := startAgent("0.0.1")
+	assert.NoError(k.check())
+	req := newCompatTestContainerRequest()
+	req.OCI.Hooks = &pb.Hooks{CreateContainer: []pb.Hook{{Path: "/bin/true"}}}
+	_, err = k.sendReq(req)
+	assert.Error(err)
+	stop()
+
+	for _, version := range []string{"1.0.0", "unknown"} {
+		_, k, stop := startAgent(version)
+		assert.Error(k.check(), version)
+		assert.Nil(k.apiCompat, version)
+		stop()
+	}
+}
@@ -17,11 +17,14 @@ import (
 	"strings"
 	"syscall"
 	"testing"
+	"time"
 
 	"github.com/containerd/ttrpc"
 	gpb "github.com/gogo/protobuf/types"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
 
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
@@ -113,11 +116,30 @@ func TestKataAgentDisconnect(t *testing.T) {
 	assert.Nil(k.client)
 }
 
-type gRPCProxy struct{}
+type gRPCProxy struct {
+	// apiVersion is the API version the agent reports, the version of
+	// the runtime by default.
+	apiVersion string
+
+	// createContainerReq is the last CreateContainer request received.
+	createContainerReq *pb.CreateContainerRequest
+
+	// releaseDeviceReq is the last ReleaseDevice request received.
+	releaseDeviceReq *pb.ReleaseDeviceRequest
+
+	// copyFileReq and readFileReq are the last CopyFile and ReadFile
+	// requests received.
+	copyFileReq *pb.CopyFileRequest
+	readFileReq *pb.ReadFileRequest
+
+	// startProfilingReq is the last StartProfiling request received.
+	startProfilingReq *pb.StartProfilingRequest
+}
 
 var emptyResp = &gpb.Empty{}
 
 func (p *gRPCProxy) CreateContainer(ctx context.Context, req *pb.CreateContainerRequest) (*gpb.Empty, error) {
+	p.createContainerReq = req
 	return emptyResp, nil
 }
 
@@ -210,8 +232,10 @@ func (p *gRPCProxy) Check(ctx context.Context, req *pb.CheckRequest) (*pb.Health
 }
 
 func (p *gRPCProxy) Version(ctx context.Context, req *pb.CheckRequest) (*pb.VersionCheckResponse, error) {
-	return &pb.VersionCheckResponse{}, nil
-
+	if p.apiVersion == "" {
+		return &pb.VersionCheckResponse{GrpcVersion: pb.APIVersion}, nil
+	}
+	return &pb.VersionCheckResponse{GrpcVersion: p.apiVersion}, nil
 }
 
 func (p *gRPCProxy) PauseContainer(ctx context.Context, req *pb.PauseContainerRequest) (*gpb.Empty, error) {
@@ -235,9 +259,68 @@ func (p *gRPCProxy) SetGuestDateTime(ctx context.Context, req *pb.SetGuestDateTi
 }
 
 func (p *gRPCProxy) CopyFile(ctx context.Context, req *pb.CopyFileRequest) (*gpb.Empty, error) {
+	p.copyFileReq = req
+	return &gpb.Empty{}, nil
+}
+
+func (p *gRPCProxy) ReleaseDevice(ctx context.Context, req *pb.ReleaseDeviceRequest) (*gpb.Empty, error) {
+	p.releaseDeviceReq = req
+	return emptyResp, nil
+}
+
+func (p *gRPCProxy) SuspendGuest(ctx context.Context, req *gpb.Empty) (*gpb.Empty, error) {
 	return &gpb.Empty{}, nil
 }
 
+func (p *gRPCProxy) InstallFile(ctx context.Context, req *pb.InstallFileRequest) (*gpb.Empty, error) {
+	return emptyResp, nil
+}
+
+func (p *gRPCProxy) LoadCrashKernel(ctx context.Context, req *pb.LoadCrashKernelRequest) (*gpb.Empty, error) {
+	return emptyResp, nil
+}
+
+func (p *gRPCProxy) StartProfiling(ctx context.Context, req *pb.StartProfilingRequest) (*gpb.Empty, error) {
+	p.startProfilingReq = req
+	return emptyResp, nil
+}
+
+func (p *gRPCProxy) LoadLivepatch(ctx context.Context, req *pb.LoadLivepatchRequest) (*gpb.Empty, error) {
+	return emptyResp, nil
+}
+
+func (p *gRPCProxy) CheckpointContainer(ctx context.Context, req *pb.CheckpointContainerRequest) (*gpb.Empty, error) {
+	return emptyResp, nil
+}
+
+func (p *gRPCProxy) RestoreContainer(ctx context.Context, req *pb.RestoreContainerRequest) (*gpb.Empty, error) {
+	return emptyResp, nil
+}
+
+// testGuestFileData is the content of the guest files read by ReadFile.
+var testGuestFileData = []byte("abcdefghi123456789")
+
+func (p *gRPCProxy) ReadFile(ctx context.Context, req *pb.ReadFileRequest) (*pb.ReadFileResponse, error) {
+	p.readFileReq = req
+
+	size := int64(len(testGuestFileData))
+	if req.Offset > size {
+		return nil, fmt.Errorf("offset %d past the end of %s", req.Offset, req.Path)
+	}
+
+	end := req.Offset + req.Length
+	if end > size {
+		end = size
+	}
+
+	return &pb.ReadFileResponse{
+		FileMode: 0640,
+		FileSize: size,
+		Offset:   req.Offset,
+		Data:     testGuestFileData[req.Offset:end],
+	}, nil
+}
+
 func (p *gRPCProxy) StartTracing(ctx context.Context, req *pb.StartTracingRequest) (*gpb.Empty, error) {
 	return &gpb.Empty{}, nil
 }
@@ -258,6 +341,10 @@ func (p *gRPCProxy) GetMetrics(ctx context.Context, req *pb.GetMetricsRequest) (
 	return &pb.Metrics{}, nil
 }
 
+func (p *gRPCProxy) GetTDReport(ctx context.Context, req *pb.GetTDReportRequest) (*pb.GetTDReportResponse, error) {
+	return &pb.GetTDReportResponse{}, nil
+}
+
 func gRPCRegister(s *ttrpc.Server, srv interface{}) {
 	switch g := srv.(type) {
 	case *gRPCProxy:
@@ -362,6 +449,26 @@ func TestKataAgentSendReq(t *testing.T) {
 
 	_, err = k.getOOMEvent()
 	assert.Nil(err)
+
+	err = k.installFile("/run/kata-containers/provisioning/etc/localtime", "/etc/localtime")
+	assert.Nil(err)
+
+	err = k.loadCrashKernel(guestKdumpKernelPath, "")
+	assert.Nil(err)
+
+	err = k.loadLivepatch("fix", "/run/kata-containers/livepatch/fix.ko")
+	assert.Nil(err)
+
+	err = k.checkpointContainer(container, "/run/kata-containers/shared/containers/foo/checkpoint", true)
+	assert.Nil(err)
+
+	err = k.restoreContainer(container, "/run/kata-containers/shared/containers/foo/checkpoint")
+	assert.Nil(err)
+
+	// The session duration is rounded up to the second.
+	err = k.startProfiling("1", ProfileRequest{Tool: ProfilePerf, Duration: 1500 * time.Millisecond, Frequency: 99})
+	assert.Nil(err)
+	assert.Equal(&pb.StartProfilingRequest{Session: "1", Tool: "perf", Duration: 2, Frequency: 99}, impl.startProfilingReq)
 }
 
 func TestHandleEphemeralStorage(t *testing.T) {
@@ -652,6 +759,87 @@ func TestAppendDevices(t *testing.T) {
 		updatedDevList, expected)
 }
 
+func TestAppendVFIORDMADevices(t *testing.T) {
+	k := kataAgent{}
+
+	id := "test-append-vfio-rdma"
+	ctrDevices := []api.Device{
+		&drivers.VFIODevice{
+			GenericDevice: &drivers.GenericDevice{
+				ID: id,
+			},
+			VfioDevs: []*config.VFIODev{
+				{ID: "vfio-rdma0", Type: config.VFIODeviceNormalType, Vendor: drivers.MellanoxVendorID, Class: "0x020700"},
+				{ID: "vfio-gpu0", Type: config.VFIODeviceNormalType, Vendor: drivers.NVIDIAVendorID, Class: "0x030200"},
+			},
+		},
+	}
+
+	c := &Container{
+		sandbox: &Sandbox{
+			devManager: manager.NewDeviceManager("virtio-blk", false, "", ctrDevices),
+		},
+	}
+	c.devices = append(c.devices, ContainerDevice{
+		ID:            id,
+		ContainerPath: "/dev/vfio/42",
+	})
+
+	expected := []*pb.Device{
+		{
+			Id:            "vfio-rdma0",
+			Type:          kataVFIORDMADevType,
+			ContainerPath: rdmaDevicesPath,
+			Options:       drivers.MellanoxRDMAModules,
+		},
+	}
+	updatedDevList := k.appendDevices([]*pb.Device{}, c)
+	assert.True(t, reflect.DeepEqual(updatedDevList, expected),
+		"Device lists didn't match: got %+v, expecting %+v",
+		updatedDevList, expected)
+}
+
+func TestAppendVFIOGPUDevices(t *testing.T) {
+	k := kataAgent{}
+
+	id := "test-append-vfio-gpu"
+	ctrDevices := []api.Device{
+		&drivers.VFIODevice{
+			GenericDevice: &drivers.GenericDevice{
+				ID: id,
+			},
+			VfioDevs: []*config.VFIODev{
+				{ID: "vfio-vgpu0", Type: config.VFIODeviceMediatedType, Vendor: drivers.NVIDIAVendorID, Class: "0x030200", GuestPCIPath: "02/03"},
+				{ID: "vfio-vgpu1", Type: config.VFIODeviceMediatedType, Vendor: drivers.NVIDIAVendorID, Class: "0x030200"},
+				{ID: "vfio-gpu0", Type: config.VFIODeviceNormalType, Vendor: drivers.NVIDIAVendorID, Class: "0x030200", GuestPCIPath: "02/04"},
+			},
+		},
+	}
+
+	c := &Container{
+		sandbox: &Sandbox{
+			devManager: manager.NewDeviceManager("virtio-blk", false, "", ctrDevices),
+		},
+	}
+	c.devices = append(c.devices, ContainerDevice{
+		ID:            id,
+		ContainerPath: "/dev/vfio/42",
+	})
+
+	expected := []*pb.Device{
+		{
+			Id:            "02/03",
+			Type:          kataVFIOGPUDevType,
+			ContainerPath: "/dev/vfio/42",
+			Options:       drivers.NVIDIAVGPUModules,
+		},
+	}
+	updatedDevList := k.appendDevices([]*pb.Device{}, c)
+	assert.True(t, reflect.DeepEqual(updatedDevList, expected),
+		"Device lists didn't match: got %+v, expecting %+v",
+		updatedDevList, expected)
+}
+
 func TestAppendVhostUserBlkDevices(t *testing.T) {
 	k := kataAgent{}
 
@@ -713,11 +901,11 @@ func TestConstraintGRPCSpec(t *testing.T) {
 			Seccomp: &pb.LinuxSeccomp{},
 			Namespaces: []pb.LinuxNamespace{
 				{
-					Type: specs.NetworkNamespace,
+					Type: string(specs.NetworkNamespace),
 					Path: "/abc/123",
 				},
 				{
-					Type: specs.MountNamespace,
+					Type: string(specs.MountNamespace),
 					Path: "/abc/123",
 				},
 			},
@@ -748,14 +936,14 @@ func TestConstraintGRPCSpec(t *testing.T) {
 	}
 
 	k := kataAgent{}
-	k.constraintGRPCSpec(g, true)
+	k.constraintGRPCSpec(g, true, nil)
 
 	// check nil fields
 	assert.Nil(g.Hooks)
 	assert.NotNil(g.Linux.Seccomp)
 	assert.Nil(g.Linux.Resources.Devices)
 	assert.NotNil(g.Linux.Resources.Memory)
-	assert.Nil(g.Linux.Resources.Pids)
+	assert.NotNil(g.Linux.Resources.Pids)
 	assert.Nil(g.Linux.Resources.BlockIO)
 	assert.Nil(g.Linux.Resources.HugepageLimits)
 	assert.Nil(g.Linux.Resources.Network)
@@ -846,11 +1034,11 @@ func TestHandlePidNamespace(t *testing.T) {
 		Linux: &pb.Linux{
 			Namespaces: []pb.LinuxNamespace{
 				{
-					Type: specs.NetworkNamespace,
+					Type: string(specs.NetworkNamespace),
 					Path: "/abc/123",
 				},
 				{
-					Type: specs.MountNamespace,
+					Type: string(specs.MountNamespace),
 					Path: "/abc/123",
 				},
 			},
@@ -871,7 +1059,7 @@ func TestHandlePidNamespace(t *testing.T) {
 	}
 
 	utsNs := pb.LinuxNamespace{
-		Type: specs.UTSNamespace,
+		Type: string(specs.UTSNamespace),
 		Path: "",
 	}
 
@@ -1151,6 +1339,133 @@ func TestKataCopyFile(t *testing.T) {
 
 	err = k.copyFile(src.Name(), dst.Name())
 	assert.NoError(err)
+	assert.Empty(impl.copyFileReq.Root)
+
+	// The path is relative to the container rootfs
+	err = k.copyFileToRootfs(src.Name(), "/run/kata-containers/shared/containers/ctr/rootfs", "etc/hosts")
+	assert.NoError(err)
+	assert.Equal("/run/kata-containers/shared/containers/ctr/rootfs", impl.copyFileReq.Root)
+	assert.Equal("etc/hosts", impl.copyFileReq.Path)
+}
+
+func TestKataCopyFileFromRootfs(t *testing.T) {
+	assert := assert.New(t)
+
+	impl := &gRPCProxy{}
+
+	proxy := mock.ProxyGRPCMock{
+		GRPCImplementer: impl,
+		GRPCRegister:    gRPCRegister,
+	}
+
+	sockDir, err := testGenerateKataProxySockDir()
+	assert.NoError(err)
+	defer os.RemoveAll(sockDir)
+
+	testKataProxyURL := fmt.Sprintf(testKataProxyURLTempl, sockDir)
+	err = proxy.Start(testKataProxyURL)
+	assert.NoError(err)
+	defer proxy.Stop()
+
+	k := &kataAgent{
+		ctx: context.Background(),
+		state: KataAgentState{
+			URL: testKataProxyURL,
+		},
+	}
+
+	dir, err := ioutil.TempDir("", "dst")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	rootfs := "/run/kata-containers/shared/containers/ctr/rootfs"
+
+	err = k.copyFileFromRootfs(rootfs, "var/log/app.log", filepath.Join(dir, "missing", "dst"))
+	assert.Error(err)
+
+	orgGrpcMaxDataSize := grpcMaxDataSize
+	grpcMaxDataSize = 4
+	defer func() {
+		grpcMaxDataSize = orgGrpcMaxDataSize
+	}()
+
+	dst := filepath.Join(dir, "dst")
+	err = k.copyFileFromRootfs(rootfs, "var/log/app.log", dst)
+	assert.NoError(err)
+	assert.Equal(rootfs, impl.readFileReq.Root)
+	assert.Equal("var/log/app.log", impl.readFileReq.Path)
+
+	data, err := ioutil.ReadFile(dst)
+	assert.NoError(err)
+	assert.Equal(testGuestFileData, data)
+
+	st, err := os.Stat(dst)
+	assert.NoError(err)
+	assert.Equal(os.FileMode(0640), st.Mode().Perm())
+
+	// Only the copy is left in the directory
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(err)
+	assert.Len(files, 1)
+}
+
+func TestKataReleaseDevice(t *testing.T) {
+	assert := assert.New(t)
+
+	impl := &gRPCProxy{}
+
+	proxy := mock.ProxyGRPCMock{
+		GRPCImplementer: impl,
+		GRPCRegister:    gRPCRegister,
+	}
+
+	sockDir, err := testGenerateKataProxySockDir()
+	assert.NoError(err)
+	defer os.RemoveAll(sockDir)
+
+	testKataProxyURL := fmt.Sprintf(testKataProxyURLTempl, sockDir)
+	err = proxy.Start(testKataProxyURL)
+	assert.NoError(err)
+	defer proxy.Stop()
+
+	k := &kataAgent{
+		ctx: context.Background(),
+		state: KataAgentState{
+			URL: testKataProxyURL,
+		},
+	}
+
+	sandbox := &Sandbox{
+		config: &SandboxConfig{
+			HypervisorConfig: HypervisorConfig{
+				BlockDeviceDriver: config.VirtioBlock,
+			},
+		},
+	}
+
+	err = k.releaseDevice(sandbox, &drivers.BlockDevice{
+		BlockDrive: &config.BlockDrive{
+			PCIAddr:  "02/01",
+			VirtPath: "/dev/vdb",
+		},
+	})
+	assert.NoError(err)
+	assert.Equal(&pb.Device{Type: kataBlkDevType, Id: "02/01", VmPath: "/dev/vdb"}, impl.releaseDeviceReq.Device)
+
+	err = k.releaseDevice(sandbox, &drivers.BlockDevice{
+		BlockDrive: &config.BlockDrive{
+			Pmem:     true,
+			NvdimmID: testNvdimmID,
+		},
+	})
+	assert.NoError(err)
+	assert.Equal(&pb.Device{Type: kataNvdimmDevType, VmPath: fmt.Sprintf("/dev/pmem%s", testNvdimmID)}, impl.releaseDeviceReq.Device)
+
+	// The guest does not use VFIO devices through mounts.
+	impl.releaseDeviceReq = nil
+	err = k.releaseDevice(sandbox, &drivers.VFIODevice{})
+	assert.NoError(err)
+	assert.Nil(impl.releaseDeviceReq)
 }
 
 func TestKataCleanupSandbox(t *testing.T) {
@@ -1409,3 +1724,13 @@ func TestKataAgentDirs(t *testing.T) {
 		assert.Equal(ephemeralPath(), defaultEphemeralPath)
 	}
 }
+
+func TestIsContainerNotFound(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(isContainerNotFound(grpcStatus.Error(codes.NotFound, "invalid container id")))
+	assert.True(isContainerNotFound(grpcStatus.Error(codes.Internal, "invalid container id")))
+	assert.False(isContainerNotFound(grpcStatus.Error(codes.Internal, "failed to read cgroup")))
+	assert.False(isContainerNotFound(grpcStatus.Error(codes.Unavailable, "connection reset")))
+	assert.False(isContainerNotFound(fmt.Errorf("timeout")))
+}
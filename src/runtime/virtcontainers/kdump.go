@@ -0,0 +1,172 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+const (
+	// kdumpDir is the directory of the vmcores in the shared directory.
+	kdumpDir = "kdump"
+
+	// Where the crash kernel the agent loads, and its initrd if any, are
+	// copied.
+	guestKdumpKernelPath = "/run/kata-containers/kdump/kernel"
+	guestKdumpInitrdPath = "/run/kata-containers/kdump/initrd"
+
+	crashKernelParam      = "crashkernel"
+	agentKdumpParam       = "agent.kdump"
+	agentKdumpDeviceParam = "agent.kdump_device"
+	agentKdumpRedactParam = "agent.kdump_redact"
+)
+
+// Kdump captures the vmcore of a crashing guest kernel. The agent loads the
+// sandbox kernel as crash kernel, which runs the agent again to write the
+// vmcore, then powers the VM off.
+type Kdump struct {
+	// CrashKernelMB is the guest memory reserved for the crash kernel,
+	// added to the sandbox memory. Kdump is disabled if 0.
+	CrashKernelMB uint32
+
+	// HostDir is the host directory the vmcores are written to, through
+	// the shared filesystem.
+	HostDir string
+
+	// GuestDevice is the guest block device the vmcores are written to,
+	// raw, in place of HostDir, such as a volume attached to the sandbox.
+	GuestDevice string
+
+	// RedactPaths are the guest files, or directories, whose contents are
+	// zeroed in the vmcores, such as the secrets of the containers. The
+	// agent passes the guest memory caching them to the crash kernel, and
+	// no vmcore is captured if it cannot.
+	RedactPaths []string
+}
+
+func (k Kdump) enabled() bool {
+	return k.CrashKernelMB > 0
+}
+
+func (k Kdump) validate() error {
+	if !k.enabled() {
+		if k.HostDir != "" || k.GuestDevice != "" || len(k.RedactPaths) != 0 {
+			return newConfigFieldError("CrashKernelMB", "Kdump requires crash kernel memory")
+		}
+		return nil
+	}
+
+	if k.HostDir == "" && k.GuestDevice == "" {
+		return newConfigFieldError("HostDir", "Kdump requires a host directory or a guest device")
+	}
+
+	if k.HostDir != "" && k.GuestDevice != "" {
+		return newConfigFieldError("GuestDevice", "Kdump writes to a host directory or a guest device, not both")
+	}
+
+	if k.HostDir != "" && !filepath.IsAbs(k.HostDir) {
+		return newConfigFieldError("HostDir", fmt.Sprintf("Host directory %q is not absolute", k.HostDir))
+	}
+
+	if k.GuestDevice != "" && !filepath.IsAbs(k.GuestDevice) {
+		return newConfigFieldError("GuestDevice", fmt.Sprintf("Guest device %q is not absolute", k.GuestDevice))
+	}
+
+	// The paths are passed to the agent as a kernel parameter list.
+	for _, p := range k.RedactPaths {
+		if !filepath.IsAbs(p) || strings.ContainsAny(p, ", \t") {
+			return newConfigFieldError("RedactPaths", fmt.Sprintf("Invalid path to redact %q", p))
+		}
+	}
+
+	return nil
+}
+
+func (k Kdump) kernelParams() []Param {
+	if !k.enabled() {
+		return nil
+	}
+
+	params := []Param{
+		{Key: crashKernelParam, Value: fmt.Sprintf("%dM", k.CrashKernelMB)},
+		{Key: agentKdumpParam},
+	}
+
+	if k.GuestDevice != "" {
+		params = append(params, Param{Key: agentKdumpDeviceParam, Value: k.GuestDevice})
+	}
+
+	if len(k.RedactPaths) != 0 {
+		params = append(params, Param{Key: agentKdumpRedactParam, Value: strings.Join(k.RedactPaths, ",")})
+	}
+
+	return params
+}
+
+// setupKdump shares the vmcores host directory with the guest, and has the
+// agent load the crash kernel once it is up.
+func (s *Sandbox) setupKdump() error {
+	k := s.config.Kdump
+	if !k.enabled() {
+		return nil
+	}
+
+	if k.HostDir != "" {
+		caps := s.hypervisor.capabilities()
+		if !caps.IsFsSharingSupported() {
+			return fmt.Errorf("Kdump cannot write to a host directory without filesystem sharing")
+		}
+
+		if err := os.MkdirAll(k.HostDir, DirMode); err != nil {
+			return err
+		}
+
+		if err := bindMount(s.ctx, k.HostDir, filepath.Join(getMountPath(s.id), kdumpDir), false, "private"); err != nil {
+			return err
+		}
+	}
+
+	guestInitrd := ""
+	if initrd := s.config.HypervisorConfig.InitrdPath; initrd != "" {
+		if err := s.agent.copyFile(initrd, guestKdumpInitrdPath); err != nil {
+			return fmt.Errorf("Could not copy the crash kernel initrd: %v", err)
+		}
+		guestInitrd = guestKdumpInitrdPath
+	}
+
+	if err := s.agent.copyFile(s.config.HypervisorConfig.KernelPath, guestKdumpKernelPath); err != nil {
+		return fmt.Errorf("Could not copy the crash kernel: %v", err)
+	}
+
+	if err := s.agent.loadCrashKernel(guestKdumpKernelPath, guestInitrd); err != nil {
+		return fmt.Errorf("Could not load the crash kernel: %v", err)
+	}
+
+	return nil
+}
+
+// bindUnmountKdumpDir unmounts the vmcores directory from the shared
+// directory, if shared.
+func bindUnmountKdumpDir(ctx context.Context, sharedDir string) error {
+	span, _ := trace(ctx, "bindUnmountKdumpDir")
+	defer span.Finish()
+
+	dest := filepath.Join(sharedDir, kdumpDir)
+	if _, err := os.Lstat(dest); os.IsNotExist(err) {
+		return nil
+	}
+
+	if err := syscall.Unmount(dest, syscall.MNT_DETACH|UmountNoFollow); err != nil && err != syscall.EINVAL {
+		return err
+	}
+
+	return os.Remove(dest)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKdumpValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(Kdump{}.validate())
+	assert.NoError(Kdump{CrashKernelMB: 256, HostDir: "/var/crash"}.validate())
+	assert.NoError(Kdump{CrashKernelMB: 256, GuestDevice: "/dev/vdb"}.validate())
+	assert.NoError(Kdump{CrashKernelMB: 256, HostDir: "/var/crash", RedactPaths: []string{"/run/secrets"}}.validate())
+
+	for _, d := range []struct {
+		kdump Kdump
+		field string
+	}{
+		{Kdump{HostDir: "/var/crash"}, "CrashKernelMB"},
+		{Kdump{CrashKernelMB: 256}, "HostDir"},
+		{Kdump{CrashKernelMB: 256, HostDir: "/var/crash", GuestDevice: "/dev/vdb"}, "GuestDevice"},
+		{Kdump{CrashKernelMB: 256, HostDir: "crash"}, "HostDir"},
+		{Kdump{CrashKernelMB: 256, GuestDevice: "vdb"}, "GuestDevice"},
+		{Kdump{RedactPaths: []string{"/run/secrets"}}, "CrashKernelMB"},
+		{Kdump{CrashKernelMB: 256, HostDir: "/var/crash", RedactPaths: []string{"secrets"}}, "RedactPaths"},
+		{Kdump{CrashKernelMB: 256, HostDir: "/var/crash", RedactPaths: []string{"/run/a,/run/b"}}, "RedactPaths"},
+	} {
+		err := d.kdump.validate()
+		assert.Error(err)
+		assert.Equal(d.field, configFieldError("", err).Field)
+	}
+}
+
+func TestKdumpKernelParams(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(Kdump{}.kernelParams())
+
+	assert.Equal([]Param{
+		{Key: "crashkernel", Value: "256M"},
+		{Key: "agent.kdump"},
+	}, Kdump{CrashKernelMB: 256, HostDir: "/var/crash"}.kernelParams())
+
+	assert.Equal([]Param{
+		{Key: "crashkernel", Value: "128M"},
+		{Key: "agent.kdump"},
+		{Key: "agent.kdump_device", Value: "/dev/vdb"},
+	}, Kdump{CrashKernelMB: 128, GuestDevice: "/dev/vdb"}.kernelParams())
+
+	assert.Equal([]Param{
+		{Key: "crashkernel", Value: "256M"},
+		{Key: "agent.kdump"},
+		{Key: "agent.kdump_redact", Value: "/run/secrets,/etc/tls"},
+	}, Kdump{CrashKernelMB: 256, HostDir: "/var/crash", RedactPaths: []string{"/run/secrets", "/etc/tls"}}.kernelParams())
+}
@@ -0,0 +1,317 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vishvananda/netlink"
+)
+
+// MACAllocationStrategy is how the MAC addresses of the guest network
+// interfaces are picked.
+type MACAllocationStrategy string
+
+const (
+	// MACAllocationNone keeps the MAC addresses the network plugin set.
+	MACAllocationNone MACAllocationStrategy = ""
+
+	// MACAllocationHash derives the MAC address of an interface from the
+	// sandbox ID and the interface name, so that it is the same every
+	// time the sandbox is created.
+	MACAllocationHash MACAllocationStrategy = "hash"
+
+	// MACAllocationPool leases the MAC addresses from a pool the operator
+	// provides.
+	MACAllocationPool MACAllocationStrategy = "pool"
+)
+
+// macLeasesDir is the directory, next to the sandbox directories, holding
+// a lease file per MAC address allocated to a sandbox.
+const macLeasesDir = "mac-leases"
+
+// macHashAttempts is how many addresses derived from the sandbox ID and the
+// interface name are tried before giving up on collisions.
+const macHashAttempts = 16
+
+// MACAllocation picks the MAC addresses of the guest network interfaces,
+// instead of the ones the network plugin sets, which change every time a
+// sandbox is created. Each address is leased to the sandbox, and released
+// when it is deleted, so that no two sandboxes get the same one.
+type MACAllocation struct {
+	Strategy MACAllocationStrategy
+
+	// Pool lists the MAC addresses leased with the pool strategy, as
+	// addresses or as ranges of addresses, such as
+	// "02:00:00:00:00:10-02:00:00:00:00:1f".
+	Pool []string
+}
+
+func (a MACAllocation) enabled() bool {
+	return a.Strategy != MACAllocationNone
+}
+
+func (a MACAllocation) validate() error {
+	switch a.Strategy {
+	case MACAllocationNone, MACAllocationHash:
+		if len(a.Pool) > 0 {
+			return newConfigFieldError("Pool", "MAC address pool requires the pool strategy")
+		}
+		return nil
+	case MACAllocationPool:
+	default:
+		return newConfigFieldError("Strategy", fmt.Sprintf("Invalid MAC address allocation strategy %q", a.Strategy))
+	}
+
+	if len(a.Pool) == 0 {
+		return newConfigFieldError("Pool", "MAC address pool strategy requires a pool")
+	}
+
+	for _, r := range a.Pool {
+		if _, _, err := parseMACRange(r); err != nil {
+			return newConfigFieldError("Pool", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// parseMACRange parses a MAC address, or a range of unicast MAC addresses,
+// into the first and last addresses of the range.
+func parseMACRange(r string) (uint64, uint64, error) {
+	bounds := strings.SplitN(r, "-", 2)
+	if len(bounds) == 1 {
+		bounds = append(bounds, bounds[0])
+	}
+
+	var addrs [2]uint64
+	for i, b := range bounds {
+		hw, err := net.ParseMAC(strings.TrimSpace(b))
+		if err != nil || len(hw) != 6 {
+			return 0, 0, fmt.Errorf("Invalid MAC address range %q", r)
+		}
+		if hw[0]&1 != 0 {
+			return 0, 0, fmt.Errorf("Invalid MAC address range %q, expected unicast addresses", r)
+		}
+		addrs[i] = macToUint64(hw)
+	}
+
+	if addrs[0] > addrs[1] {
+		return 0, 0, fmt.Errorf("Invalid MAC address range %q, the first address is after the last one", r)
+	}
+
+	return addrs[0], addrs[1], nil
+}
+
+func macToUint64(hw net.HardwareAddr) uint64 {
+	var buf [8]byte
+	copy(buf[2:], hw)
+	return binary.BigEndian.Uint64(buf[:])
+}
+
+func uint64ToMAC(v uint64) net.HardwareAddr {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+	return net.HardwareAddr(buf[2:])
+}
+
+// hashMAC derives a locally administered unicast MAC address from the
+// sandbox ID, the interface name and the attempt.
+func hashMAC(sandboxID, iface string, attempt int) net.HardwareAddr {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%d", sandboxID, iface, attempt)))
+	hw := net.HardwareAddr(sum[:6])
+	hw[0] = (hw[0] | 2) & 0xfe
+	return hw
+}
+
+// macLeases are the MAC addresses leased to the sandboxes, each one a file
+// named after the address and holding the sandbox ID and the interface name.
+type macLeases struct {
+	dir string
+
+	// sandboxesDir holds the sandbox directories, to reclaim the leases of
+	// the sandboxes which are gone.
+	sandboxesDir string
+}
+
+func (s *Sandbox) macLeases() *macLeases {
+	sandboxesDir := s.newStore.RunStoragePath()
+	return &macLeases{
+		dir:          filepath.Join(filepath.Dir(sandboxesDir), macLeasesDir),
+		sandboxesDir: sandboxesDir,
+	}
+}
+
+func leaseOwner(sandboxID, iface string) string {
+	return sandboxID + "\n" + iface + "\n"
+}
+
+func (l *macLeases) path(hw net.HardwareAddr) string {
+	return filepath.Join(l.dir, strings.Replace(hw.String(), ":", "-", -1))
+}
+
+// acquire leases an address to an interface of a sandbox, unless another
+// one holds it. An address the interface holds already is leased again.
+func (l *macLeases) acquire(hw net.HardwareAddr, sandboxID, iface string) (bool, error) {
+	if err := os.MkdirAll(l.dir, DirMode); err != nil {
+		return false, err
+	}
+
+	owner := leaseOwner(sandboxID, iface)
+	path := l.path(hw)
+
+	for {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			_, err = f.WriteString(owner)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				os.Remove(path)
+				return false, err
+			}
+			return true, nil
+		}
+		if !os.IsExist(err) {
+			return false, err
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return false, err
+		}
+		if string(data) == owner {
+			return true, nil
+		}
+
+		// The lease of a sandbox which is gone, for instance because
+		// the runtime was killed, is reclaimed.
+		holder := strings.SplitN(string(data), "\n", 2)[0]
+		if holder == "" || l.sandboxExists(holder) {
+			return false, nil
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return false, err
+		}
+	}
+}
+
+func (l *macLeases) sandboxExists(sandboxID string) bool {
+	_, err := os.Stat(filepath.Join(l.sandboxesDir, sandboxID))
+	return err == nil
+}
+
+// release removes all the leases of a sandbox.
+func (l *macLeases) release(sandboxID string) error {
+	files, err := ioutil.ReadDir(l.dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		path := filepath.Join(l.dir, f.Name())
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if strings.SplitN(string(data), "\n", 2)[0] != sandboxID {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// allocate leases a MAC address to an interface of a sandbox.
+func (a MACAllocation) allocate(l *macLeases, sandboxID, iface string) (net.HardwareAddr, error) {
+	switch a.Strategy {
+	case MACAllocationHash:
+		for attempt := 0; attempt < macHashAttempts; attempt++ {
+			hw := hashMAC(sandboxID, iface, attempt)
+			ok, err := l.acquire(hw, sandboxID, iface)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return hw, nil
+			}
+		}
+		return nil, fmt.Errorf("Could not allocate a MAC address to %s, all the addresses derived from the sandbox ID are in use", iface)
+	case MACAllocationPool:
+		for _, r := range a.Pool {
+			first, last, err := parseMACRange(r)
+			if err != nil {
+				return nil, err
+			}
+			for v := first; v <= last; v++ {
+				hw := uint64ToMAC(v)
+				ok, err := l.acquire(hw, sandboxID, iface)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					return hw, nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("Could not allocate a MAC address to %s, the pool is exhausted", iface)
+	}
+
+	return nil, fmt.Errorf("Invalid MAC address allocation strategy %q", a.Strategy)
+}
+
+// assign sets the MAC address allocated to the network interface of an
+// endpoint, before it is attached, for the guest to get it. It must be
+// called in the network namespace of the sandbox. The endpoints whose
+// address cannot be changed, such as the ipvlan and the physical ones,
+// keep theirs.
+func (a MACAllocation) assign(l *macLeases, sandboxID string, endpoint Endpoint) error {
+	if !a.enabled() {
+		return nil
+	}
+
+	switch endpoint.(type) {
+	case *VethEndpoint, *BridgedMacvlanEndpoint:
+	default:
+		return nil
+	}
+
+	hw, err := a.allocate(l, sandboxID, endpoint.Name())
+	if err != nil {
+		return err
+	}
+
+	link, err := netlink.LinkByName(endpoint.Name())
+	if err != nil {
+		return fmt.Errorf("Could not get link %s: %v", endpoint.Name(), err)
+	}
+
+	if err := netlink.LinkSetHardwareAddr(link, hw); err != nil {
+		return fmt.Errorf("Could not set MAC address %s for interface %s: %v", hw, endpoint.Name(), err)
+	}
+
+	props := endpoint.Properties()
+	props.Iface.HardwareAddr = hw
+	endpoint.SetProperties(props)
+
+	networkLogger().WithField("interface", endpoint.Name()).WithField("mac", hw.String()).Info("MAC address allocated")
+
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMACAllocationValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(MACAllocation{}.validate())
+	assert.NoError(MACAllocation{Strategy: MACAllocationHash}.validate())
+	assert.NoError(MACAllocation{Strategy: MACAllocationPool, Pool: []string{"02:00:00:00:00:01", "02:00:00:00:00:10-02:00:00:00:00:1f"}}.validate())
+
+	for _, a := range []MACAllocation{
+		{Strategy: "random"},
+		{Strategy: MACAllocationHash, Pool: []string{"02:00:00:00:00:01"}},
+		{Strategy: MACAllocationPool},
+		{Strategy: MACAllocationPool, Pool: []string{"02:00:00:00:00"}},
+		{Strategy: MACAllocationPool, Pool: []string{"01:00:5e:00:00:01"}},
+		{Strategy: MACAllocationPool, Pool: []string{"02:00:00:00:00:1f-02:00:00:00:00:10"}},
+	} {
+		assert.Error(a.validate(), "%+v", a)
+	}
+}
+
+func TestHashMAC(t *testing.T) {
+	assert := assert.New(t)
+
+	hw := hashMAC("sandbox", "eth0", 0)
+	assert.Len(hw, 6)
+	assert.Equal(hw, hashMAC("sandbox", "eth0", 0))
+	assert.NotEqual(hw, hashMAC("sandbox", "eth1", 0))
+	assert.NotEqual(hw, hashMAC("sandbox", "eth0", 1))
+
+	// Locally administered unicast addresses
+	assert.Equal(byte(2), hw[0]&3)
+}
+
+func newTestMACLeases(t *testing.T) (*macLeases, func()) {
+	dir, err := ioutil.TempDir("", "mac-leases")
+	assert.NoError(t, err)
+
+	l := &macLeases{
+		dir:          filepath.Join(dir, macLeasesDir),
+		sandboxesDir: filepath.Join(dir, "sbs"),
+	}
+	for _, id := range []string{"sandbox1", "sandbox2"} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(l.sandboxesDir, id), DirMode))
+	}
+
+	return l, func() { os.RemoveAll(dir) }
+}
+
+func TestMACAllocationPool(t *testing.T) {
+	assert := assert.New(t)
+
+	l, cleanup := newTestMACLeases(t)
+	defer cleanup()
+
+	a := MACAllocation{Strategy: MACAllocationPool, Pool: []string{"02:00:00:00:00:fe-02:00:00:00:01:00"}}
+
+	hw, err := a.allocate(l, "sandbox1", "eth0")
+	assert.NoError(err)
+	assert.Equal("02:00:00:00:00:fe", hw.String())
+
+	// The interface holding an address gets it again.
+	hw, err = a.allocate(l, "sandbox1", "eth0")
+	assert.NoError(err)
+	assert.Equal("02:00:00:00:00:fe", hw.String())
+
+	hw, err = a.allocate(l, "sandbox1", "eth1")
+	assert.NoError(err)
+	assert.Equal("02:00:00:00:00:ff", hw.String())
+
+	hw, err = a.allocate(l, "sandbox2", "eth0")
+	assert.NoError(err)
+	assert.Equal("02:00:00:00:01:00", hw.String())
+
+	_, err = a.allocate(l, "sandbox2", "eth1")
+	assert.Error(err)
+
+	assert.NoError(l.release("sandbox1"))
+
+	hw, err = a.allocate(l, "sandbox2", "eth1")
+	assert.NoError(err)
+	assert.Equal("02:00:00:00:00:fe", hw.String())
+
+	// The leases of the sandboxes which are gone are reclaimed.
+	assert.NoError(os.RemoveAll(filepath.Join(l.sandboxesDir, "sandbox2")))
+
+	hw, err = a.allocate(l, "sandbox1", "eth0")
+	assert.NoError(err)
+	assert.Equal("02:00:00:00:00:fe", hw.String())
+}
+
+func TestMACAllocationHash(t *testing.T) {
+	assert := assert.New(t)
+
+	l, cleanup := newTestMACLeases(t)
+	defer cleanup()
+
+	a := MACAllocation{Strategy: MACAllocationHash}
+
+	hw, err := a.allocate(l, "sandbox1", "eth0")
+	assert.NoError(err)
+	assert.Equal(hashMAC("sandbox1", "eth0", 0), hw)
+
+	// An address leased to another sandbox is skipped.
+	assert.NoError(l.release("sandbox1"))
+	ok, err := l.acquire(hashMAC("sandbox1", "eth0", 0), "sandbox2", "eth3")
+	assert.NoError(err)
+	assert.True(ok)
+
+	hw, err = a.allocate(l, "sandbox1", "eth0")
+	assert.NoError(err)
+	assert.Equal(hashMAC("sandbox1", "eth0", 1), hw)
+}
@@ -0,0 +1,349 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	govmmQemu "github.com/intel/govmm/qemu"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+// Supported values for HypervisorConfig.MemoryEncryption.
+const (
+	// MemoryEncryptionNone runs the VM with its memory readable by the
+	// host.
+	MemoryEncryptionNone = ""
+
+	// MemoryEncryptionSEV encrypts the VM memory with AMD SEV, the
+	// secure processor measuring the initial guest memory.
+	MemoryEncryptionSEV = "sev"
+
+	// MemoryEncryptionSNP encrypts and integrity protects the VM memory
+	// with AMD SEV-SNP, the guest requesting its attestation reports
+	// from the secure processor itself.
+	MemoryEncryptionSNP = "sev-snp"
+
+	// MemoryEncryptionTDX runs the VM as an Intel TDX trust domain, its
+	// memory encrypted and integrity protected by the TDX module, the
+	// guest requesting its TD reports from the TDX module itself.
+	MemoryEncryptionTDX = "tdx"
+)
+
+const (
+	// defaultSEVCBitPos is the encryption bit of the page table entries
+	// of the AMD EPYC processors since Rome.
+	defaultSEVCBitPos = 51
+
+	// defaultSEVReducedPhysBits is the number of physical address bits
+	// the AMD EPYC processors lose with the memory encryption.
+	defaultSEVReducedPhysBits = 1
+
+	// sevObjectID is the ID of the QEMU object encrypting the VM memory.
+	sevObjectID = "sev0"
+
+	// tdxObjectID is the ID of the QEMU object of the TDX guest.
+	tdxObjectID = "tdx0"
+
+	// tdReportDataSize is the size of the data bound to a TD report.
+	tdReportDataSize = 64
+)
+
+// kvmAMDParamsDir holds the parameters of the kvm_amd module, telling
+// whether the host enabled SEV and SEV-SNP for its guests.
+var kvmAMDParamsDir = "/sys/module/kvm_amd/parameters"
+
+// kvmIntelParamsDir holds the parameters of the kvm_intel module, telling
+// whether the host enabled TDX for its guests.
+var kvmIntelParamsDir = "/sys/module/kvm_intel/parameters"
+
+// LaunchMeasurement is the measurement of the initial memory of a memory
+// encrypted VM, which the guest owner checks before trusting the guest
+// with secrets.
+type LaunchMeasurement struct {
+	// Measurement is the base64 encoded launch measurement, signed with
+	// the session key of the guest owner.
+	Measurement string
+
+	// APIMajor and APIMinor are the version of the firmware of the
+	// secure processor.
+	APIMajor uint8
+	APIMinor uint8
+
+	// BuildID is the build of the firmware of the secure processor.
+	BuildID uint8
+
+	// Policy is the guest policy the VM was launched with.
+	Policy uint32
+}
+
+// checkMemoryEncryption verifies the memory encryption of the VM is known,
+// supported by the hypervisor type and consistent with the rest of the
+// hypervisor configuration.
+func checkMemoryEncryption(hType HypervisorType, conf *HypervisorConfig) error {
+	switch conf.MemoryEncryption {
+	case MemoryEncryptionNone:
+		return nil
+	case MemoryEncryptionSEV, MemoryEncryptionSNP, MemoryEncryptionTDX:
+	default:
+		return newConfigFieldError("MemoryEncryption", fmt.Sprintf("Unknown memory encryption %q", conf.MemoryEncryption))
+	}
+
+	switch hType {
+	case QemuHypervisor, MockHypervisor:
+	case ClhHypervisor:
+		// Cloud Hypervisor only launches TDX guests
+		if conf.MemoryEncryption != MemoryEncryptionTDX {
+			return newConfigFieldError("MemoryEncryption", fmt.Sprintf("Memory encryption %q is not supported by the %s hypervisor", conf.MemoryEncryption, hType))
+		}
+	default:
+		return newConfigFieldError("MemoryEncryption", fmt.Sprintf("Memory encryption is not supported by the %s hypervisor", hType))
+	}
+
+	// The measured boot starts from the firmware, loading the kernel.
+	if conf.FirmwarePath == "" {
+		return newConfigFieldError("FirmwarePath", "Memory encrypted VMs boot through a firmware")
+	}
+
+	// The session blobs are exchanged with the secure processor at launch
+	// by SEV only, SEV-SNP guests attest themselves once running.
+	if conf.SEVDHCertPath != "" || conf.SEVSessionPath != "" {
+		if conf.MemoryEncryption != MemoryEncryptionSEV {
+			return newConfigFieldError("SEVSessionPath", "Launch session blobs are only supported by SEV")
+		}
+		if conf.SEVDHCertPath == "" || conf.SEVSessionPath == "" {
+			return newConfigFieldError("SEVSessionPath", "Launch session needs both the guest owner certificate and session blobs")
+		}
+	}
+
+	// The encrypted memory cannot be shared with other VMs, nor saved and
+	// restored by the host.
+	if conf.BootToBeTemplate || conf.BootFromTemplate {
+		return newConfigFieldError("MemoryEncryption", "Memory encryption is not supported with VM templating")
+	}
+	if conf.SnapshotPath != "" || conf.IncomingMigrationURI != "" {
+		return newConfigFieldError("MemoryEncryption", "Memory encrypted VMs cannot be restored or migrated")
+	}
+
+	return nil
+}
+
+// GetLaunchMeasurement returns the launch measurement of the memory
+// encrypted VM of the sandbox, for the guest owner to verify the guest
+// before releasing its secrets to the agent.
+func (s *Sandbox) GetLaunchMeasurement() (LaunchMeasurement, error) {
+	span, _ := s.trace("GetLaunchMeasurement")
+	defer span.Finish()
+
+	if s.config.HypervisorConfig.MemoryEncryption == MemoryEncryptionNone {
+		return LaunchMeasurement{}, fmt.Errorf("Sandbox %s memory is not encrypted", s.id)
+	}
+
+	switch s.state.State {
+	case types.StateRunning, types.StatePaused, types.StateSuspended:
+	default:
+		return LaunchMeasurement{}, fmt.Errorf("Sandbox %s not running, no launch measurement", s.id)
+	}
+
+	return s.hypervisor.getLaunchMeasurement()
+}
+
+// GetTDReport returns a TD report of the TDX guest of the sandbox, binding
+// reportData, for the guest owner to verify the guest through a quote of
+// the report.
+func (s *Sandbox) GetTDReport(reportData []byte) ([]byte, error) {
+	span, _ := s.trace("GetTDReport")
+	defer span.Finish()
+
+	if s.config.HypervisorConfig.MemoryEncryption != MemoryEncryptionTDX {
+		return nil, fmt.Errorf("Sandbox %s is not a TDX guest", s.id)
+	}
+
+	if len(reportData) > tdReportDataSize {
+		return nil, fmt.Errorf("Report data of %d bytes, %d bytes at most", len(reportData), tdReportDataSize)
+	}
+
+	if s.state.State != types.StateRunning {
+		return nil, fmt.Errorf("Sandbox %s not running, no TD report", s.id)
+	}
+
+	return s.agent.getTDReport(reportData)
+}
+
+// checkConfidentialGuest refuses the options opening the guest of a memory
+// encrypted sandbox to the host, defeating its confidentiality.
+func checkConfidentialGuest(config *SandboxConfig) error {
+	if config.HypervisorConfig.MemoryEncryption == MemoryEncryptionNone {
+		return nil
+	}
+
+	for _, p := range config.HypervisorConfig.KernelParams {
+		if p.Key == "agent.debug_console" {
+			return newConfigFieldError("KernelParams", "The agent debug console is not supported by confidential guests")
+		}
+	}
+
+	if config.DisableGuestSeccomp {
+		return newConfigFieldError("DisableGuestSeccomp", "Confidential guests cannot disable the guest seccomp")
+	}
+
+	// The vmcore, the profiles and the clones export the guest memory
+	// or data to the host.
+	if config.Kdump.enabled() {
+		return newConfigFieldError("Kdump", "Kdump is not supported by confidential guests")
+	}
+
+	if config.GuestProfiling.enabled() {
+		return newConfigFieldError("GuestProfiling", "Guest profiling is not supported by confidential guests")
+	}
+
+	if config.Cloneable {
+		return newConfigFieldError("Cloneable", "Confidential guests cannot be cloned")
+	}
+
+	return nil
+}
+
+// checkNotConfidential refuses the operation op, which exports the guest
+// memory or data to the host, for a memory encrypted sandbox.
+func (s *Sandbox) checkNotConfidential(op string) error {
+	if mode := s.config.HypervisorConfig.MemoryEncryption; mode != MemoryEncryptionNone {
+		return fmt.Errorf("Sandbox %s is a confidential guest (%s), impossible to %s", s.id, mode, op)
+	}
+	return nil
+}
+
+// checkHostMemoryEncryption verifies the host runs kvm_amd or kvm_intel with
+// the memory encryption enabled.
+func checkHostMemoryEncryption(mode string) error {
+	dir, param := kvmAMDParamsDir, "sev"
+	switch mode {
+	case MemoryEncryptionSNP:
+		param = "sev_snp"
+	case MemoryEncryptionTDX:
+		dir, param = kvmIntelParamsDir, "tdx"
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, param))
+	if err != nil {
+		return fmt.Errorf("Memory encryption %q not supported by the host: %v", mode, err)
+	}
+
+	switch strings.TrimSpace(string(content)) {
+	case "1", "Y", "y":
+		return nil
+	default:
+		return fmt.Errorf("Memory encryption %q not enabled on the host", mode)
+	}
+}
+
+// sevGuest is the QEMU object encrypting the memory of an AMD SEV or SEV-SNP
+// guest, which the machine refers to.
+type sevGuest struct {
+	ID string
+
+	// SNP makes the object a sev-snp-guest one, integrity protecting
+	// the guest memory too.
+	SNP bool
+
+	// CBitPos is the position of the encryption bit in the guest page
+	// table entries.
+	CBitPos uint32
+
+	// ReducedPhysBits is the number of physical address bits lost when
+	// the memory encryption is enabled.
+	ReducedPhysBits uint32
+
+	// Policy is the guest policy.
+	Policy uint64
+
+	// DHCertFile and SessionFile are the paths of the Diffie-Hellman key
+	// and of the session parameters of the guest owner, for SEV guests
+	// only.
+	DHCertFile  string
+	SessionFile string
+}
+
+// Valid returns true if the object has an ID and the encryption bits, and
+// if an SEV-SNP object has no SEV guest owner files.
+func (g sevGuest) Valid() bool {
+	if g.ID == "" || g.CBitPos == 0 || g.ReducedPhysBits == 0 {
+		return false
+	}
+
+	return !g.SNP || (g.DHCertFile == "" && g.SessionFile == "")
+}
+
+// QemuParams returns the qemu parameters adding the object.
+func (g sevGuest) QemuParams(config *govmmQemu.Config) []string {
+	objectType := "sev-guest"
+	if g.SNP {
+		objectType = "sev-snp-guest"
+	}
+
+	object := fmt.Sprintf("%s,id=%s,cbitpos=%d,reduced-phys-bits=%d,policy=%#x", objectType, g.ID, g.CBitPos, g.ReducedPhysBits, g.Policy)
+	if g.DHCertFile != "" {
+		object += ",dh-cert-file=" + g.DHCertFile
+	}
+	if g.SessionFile != "" {
+		object += ",session-file=" + g.SessionFile
+	}
+
+	return []string{"-object", object}
+}
+
+// tdxGuest is the QEMU object of an Intel TDX guest, whose memory is
+// encrypted and integrity protected, which the machine refers to.
+type tdxGuest struct {
+	ID string
+}
+
+// Valid returns true if the object has an ID.
+func (g tdxGuest) Valid() bool {
+	return g.ID != ""
+}
+
+// QemuParams returns the qemu parameters adding the object.
+func (g tdxGuest) QemuParams(config *govmmQemu.Config) []string {
+	return []string{"-object", fmt.Sprintf("tdx-guest,id=%s", g.ID)}
+}
+
+// virtioIOMMUPlatform makes the virtio devices go through the DMA API of
+// the guest, for the guests whose memory the devices cannot access.
+type virtioIOMMUPlatform struct{}
+
+// Valid implements the govmm Device interface.
+func (virtioIOMMUPlatform) Valid() bool {
+	return true
+}
+
+// QemuParams implements the govmm Device interface.
+func (virtioIOMMUPlatform) QemuParams(config *govmmQemu.Config) []string {
+	return []string{"-global", "virtio-device.iommu_platform=on"}
+}
+
+// qemuMemoryEncryption is the govmm device of the object encrypting the VM
+// memory, which the machine refers to.
+func qemuMemoryEncryption(conf *HypervisorConfig) govmmQemu.Device {
+	if conf.MemoryEncryption == MemoryEncryptionTDX {
+		return tdxGuest{
+			ID: tdxObjectID,
+		}
+	}
+
+	return sevGuest{
+		ID:              sevObjectID,
+		SNP:             conf.MemoryEncryption == MemoryEncryptionSNP,
+		CBitPos:         conf.SEVCBitPos,
+		ReducedPhysBits: conf.SEVReducedPhysBits,
+		Policy:          conf.SEVPolicy,
+		DHCertFile:      conf.SEVDHCertPath,
+		SessionFile:     conf.SEVSessionPath,
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	govmmQemu "github.com/intel/govmm/qemu"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckMemoryEncryption(t *testing.T) {
+	assert := assert.New(t)
+
+	type testData struct {
+		hType       HypervisorType
+		conf        HypervisorConfig
+		expectError bool
+	}
+
+	firmware := "/usr/share/ovmf/OVMF.fd"
+
+	data := []testData{
+		{QemuHypervisor, HypervisorConfig{}, false},
+		{FirecrackerHypervisor, HypervisorConfig{}, false},
+		{QemuHypervisor, HypervisorConfig{MemoryEncryption: MemoryEncryptionSEV, FirmwarePath: firmware}, false},
+		{QemuHypervisor, HypervisorConfig{MemoryEncryption: MemoryEncryptionSNP, FirmwarePath: firmware}, false},
+		{QemuHypervisor, HypervisorConfig{MemoryEncryption: MemoryEncryptionTDX, FirmwarePath: firmware}, false},
+		{QemuHypervisor, HypervisorConfig{MemoryEncryption: "sme", FirmwarePath: firmware}, true},
+		{ClhHypervisor, HypervisorConfig{MemoryEncryption: MemoryEncryptionSEV, FirmwarePath: firmware}, true},
+		{ClhHypervisor, HypervisorConfig{MemoryEncryption: MemoryEncryptionTDX, FirmwarePath: firmware}, false},
+		{ClhHypervisor, HypervisorConfig{MemoryEncryption: MemoryEncryptionTDX}, true},
+		{FirecrackerHypervisor, HypervisorConfig{MemoryEncryption: MemoryEncryptionTDX, FirmwarePath: firmware}, true},
+		{QemuHypervisor, HypervisorConfig{MemoryEncryption: MemoryEncryptionTDX, FirmwarePath: firmware,
+			SEVDHCertPath: "godh.b64", SEVSessionPath: "session.b64"}, true},
+		{QemuHypervisor, HypervisorConfig{MemoryEncryption: MemoryEncryptionSEV}, true},
+		{QemuHypervisor, HypervisorConfig{MemoryEncryption: MemoryEncryptionSEV, FirmwarePath: firmware,
+			SEVDHCertPath: "godh.b64", SEVSessionPath: "session.b64"}, false},
+		{QemuHypervisor, HypervisorConfig{MemoryEncryption: MemoryEncryptionSEV, FirmwarePath: firmware,
+			SEVSessionPath: "session.b64"}, true},
+		{QemuHypervisor, HypervisorConfig{MemoryEncryption: MemoryEncryptionSNP, FirmwarePath: firmware,
+			SEVDHCertPath: "godh.b64", SEVSessionPath: "session.b64"}, true},
+		{QemuHypervisor, HypervisorConfig{MemoryEncryption: MemoryEncryptionSEV, FirmwarePath: firmware,
+			BootToBeTemplate: true}, true},
+		{QemuHypervisor, HypervisorConfig{MemoryEncryption: MemoryEncryptionSEV, FirmwarePath: firmware,
+			IncomingMigrationURI: "tcp:0:4444"}, true},
+	}
+
+	for i, d := range data {
+		err := checkMemoryEncryption(d.hType, &d.conf)
+		if d.expectError {
+			assert.Error(err, "test %d (%+v)", i, d)
+		} else {
+			assert.NoError(err, "test %d (%+v)", i, d)
+		}
+	}
+}
+
+func TestCheckHostMemoryEncryption(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "kvm-amd")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	savedDir := kvmAMDParamsDir
+	kvmAMDParamsDir = dir
+	defer func() {
+		kvmAMDParamsDir = savedDir
+	}()
+
+	// kvm_amd not loaded
+	assert.Error(checkHostMemoryEncryption(MemoryEncryptionSEV))
+
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, "sev"), []byte("Y\n"), 0644))
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, "sev_snp"), []byte("N\n"), 0644))
+	assert.NoError(checkHostMemoryEncryption(MemoryEncryptionSEV))
+	assert.Error(checkHostMemoryEncryption(MemoryEncryptionSNP))
+
+	savedIntelDir := kvmIntelParamsDir
+	kvmIntelParamsDir = filepath.Join(dir, "kvm_intel")
+	defer func() {
+		kvmIntelParamsDir = savedIntelDir
+	}()
+
+	// kvm_intel not loaded
+	assert.Error(checkHostMemoryEncryption(MemoryEncryptionTDX))
+
+	assert.NoError(os.Mkdir(kvmIntelParamsDir, 0755))
+	assert.NoError(ioutil.WriteFile(filepath.Join(kvmIntelParamsDir, "tdx"), []byte("Y\n"), 0644))
+	assert.NoError(checkHostMemoryEncryption(MemoryEncryptionTDX))
+}
+
+func TestQemuMemoryEncryption(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := HypervisorConfig{
+		MemoryEncryption:   MemoryEncryptionSEV,
+		SEVPolicy:          3,
+		SEVCBitPos:         47,
+		SEVReducedPhysBits: 1,
+		SEVDHCertPath:      "/run/godh.b64",
+		SEVSessionPath:     "/run/session.b64",
+	}
+
+	object := qemuMemoryEncryption(&conf)
+	assert.True(object.Valid())
+	assert.Equal([]string{"-object", "sev-guest,id=sev0,cbitpos=47,reduced-phys-bits=1,policy=0x3," +
+		"dh-cert-file=/run/godh.b64,session-file=/run/session.b64"},
+		object.QemuParams(&govmmQemu.Config{}))
+
+	conf.MemoryEncryption = MemoryEncryptionSNP
+	conf.SEVDHCertPath = ""
+	conf.SEVSessionPath = ""
+	object = qemuMemoryEncryption(&conf)
+	assert.True(object.Valid())
+	assert.Equal([]string{"-object", "sev-snp-guest,id=sev0,cbitpos=47,reduced-phys-bits=1,policy=0x3"},
+		object.QemuParams(&govmmQemu.Config{}))
+
+	conf.MemoryEncryption = MemoryEncryptionTDX
+	object = qemuMemoryEncryption(&conf)
+	assert.True(object.Valid())
+	assert.Equal([]string{"-object", "tdx-guest,id=tdx0"}, object.QemuParams(&govmmQemu.Config{}))
+
+	assert.Equal([]string{"-global", "virtio-device.iommu_platform=on"}, virtioIOMMUPlatform{}.QemuParams(&govmmQemu.Config{}))
+}
+
+func TestHypervisorConfigMemoryEncryptionDefaults(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := HypervisorConfig{
+		KernelPath:       "/kernel",
+		ImagePath:        "/image",
+		MemoryEncryption: MemoryEncryptionSEV,
+	}
+	assert.NoError(conf.valid())
+	assert.Equal(uint32(defaultSEVCBitPos), conf.SEVCBitPos)
+	assert.Equal(uint32(defaultSEVReducedPhysBits), conf.SEVReducedPhysBits)
+	assert.True(conf.DisableImageNvdimm)
+
+	// The SEV parameters are left alone for TDX
+	conf = HypervisorConfig{
+		KernelPath:       "/kernel",
+		ImagePath:        "/image",
+		MemoryEncryption: MemoryEncryptionTDX,
+	}
+	assert.NoError(conf.valid())
+	assert.Zero(conf.SEVCBitPos)
+	assert.True(conf.DisableImageNvdimm)
+}
+
+func TestCheckConfidentialGuest(t *testing.T) {
+	assert := assert.New(t)
+
+	config := SandboxConfig{
+		DisableGuestSeccomp: true,
+	}
+	config.HypervisorConfig.KernelParams = []Param{{"agent.debug_console", ""}}
+
+	// Unencrypted guests are not confidential
+	assert.NoError(checkConfidentialGuest(&config))
+
+	config.HypervisorConfig.MemoryEncryption = MemoryEncryptionTDX
+	var fieldErr *ConfigFieldError
+	assert.True(errors.As(checkConfidentialGuest(&config), &fieldErr))
+	assert.Equal("KernelParams", fieldErr.Field)
+
+	config.HypervisorConfig.KernelParams = nil
+	assert.True(errors.As(checkConfidentialGuest(&config), &fieldErr))
+	assert.Equal("DisableGuestSeccomp", fieldErr.Field)
+
+	config.DisableGuestSeccomp = false
+	assert.NoError(checkConfidentialGuest(&config))
+
+	// The guest memory or data would be exported to the host
+	config.Kdump.CrashKernelMB = 256
+	assert.True(errors.As(checkConfidentialGuest(&config), &fieldErr))
+	assert.Equal("Kdump", fieldErr.Field)
+	config.Kdump.CrashKernelMB = 0
+
+	config.GuestProfiling.Tools = []string{"perf"}
+	assert.True(errors.As(checkConfidentialGuest(&config), &fieldErr))
+	assert.Equal("GuestProfiling", fieldErr.Field)
+	config.GuestProfiling.Tools = nil
+
+	config.Cloneable = true
+	assert.True(errors.As(checkConfidentialGuest(&config), &fieldErr))
+	assert.Equal("Cloneable", fieldErr.Field)
+}
+
+func TestSandboxConfidentialGuest(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id:         testSandboxID,
+		ctx:        context.Background(),
+		config:     &SandboxConfig{},
+		agent:      &mockAgent{},
+		hypervisor: &mockHypervisor{},
+		devManager: manager.NewDeviceManager(manager.VirtioSCSI, false, "", nil),
+		state:      types.SandboxState{State: types.StateRunning},
+	}
+
+	c := &Container{
+		id:      "ctr",
+		sandbox: s,
+		state:   types.ContainerState{State: types.StateRunning},
+	}
+	s.containers = map[string]*Container{c.id: c}
+
+	assert.NoError(s.checkSaveable("snapshot"))
+	assert.NoError(s.copyFileFromContainer("ctr", "/var/log/app.log", "/tmp/app.log"))
+
+	s.config.HypervisorConfig.MemoryEncryption = MemoryEncryptionSEV
+
+	// Snapshots, migrations and live updates
+	assert.Error(s.checkSaveable("snapshot"))
+
+	assert.Error(s.copyFileFromContainer("ctr", "/var/log/app.log", "/tmp/app.log"))
+	assert.Error(c.checkpoint(CheckpointOptions{ImagesDir: "/tmp/checkpoint"}))
+
+	c.state.State = types.StateReady
+	assert.Error(c.restore(CheckpointOptions{ImagesDir: "/tmp/checkpoint"}))
+
+	_, err := s.AttachConsole()
+	assert.Error(err)
+	assert.Contains(err.Error(), "confidential guest")
+}
+
+func TestSandboxGetLaunchMeasurement(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id:         testSandboxID,
+		config:     &SandboxConfig{},
+		hypervisor: &mockHypervisor{},
+	}
+
+	// Memory not encrypted
+	s.state.State = types.StateRunning
+	_, err := s.GetLaunchMeasurement()
+	assert.Error(err)
+
+	s.config.HypervisorConfig.MemoryEncryption = MemoryEncryptionSEV
+	s.state.State = types.StateReady
+	_, err = s.GetLaunchMeasurement()
+	assert.Error(err)
+
+	s.state.State = types.StateRunning
+	_, err = s.GetLaunchMeasurement()
+	assert.NoError(err)
+}
+
+func TestSandboxGetTDReport(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id:     testSandboxID,
+		config: &SandboxConfig{},
+		agent:  &mockAgent{},
+	}
+
+	// Not a TDX guest
+	s.state.State = types.StateRunning
+	_, err := s.GetTDReport(nil)
+	assert.Error(err)
+
+	s.config.HypervisorConfig.MemoryEncryption = MemoryEncryptionTDX
+	_, err = s.GetTDReport(make([]byte, tdReportDataSize+1))
+	assert.Error(err)
+
+	s.state.State = types.StateReady
+	_, err = s.GetTDReport(make([]byte, tdReportDataSize))
+	assert.Error(err)
+
+	s.state.State = types.StateRunning
+	_, err = s.GetTDReport(make([]byte, tdReportDataSize))
+	assert.NoError(err)
+}
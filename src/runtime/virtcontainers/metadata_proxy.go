@@ -0,0 +1,309 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/mdlayher/vsock"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	agentMetadataProxyPortParam = "agent.metadata_proxy_vport"
+
+	// defaultMetadataURL is the instance metadata service, at the same
+	// link-local address on all the clouds.
+	defaultMetadataURL = "http://169.254.169.254"
+
+	// hybridMetadataProxyPort is the vsock port of the metadata proxy of
+	// the sandboxes with a hybrid vsock, which have a host socket of their
+	// own per port.
+	hybridMetadataProxyPort = 1026
+
+	metadataProxyReadHeaderTimeout = 10 * time.Second
+)
+
+// MetadataProxy exposes the instance metadata service of the cloud the host
+// runs on to the guest, which cannot reach 169.254.169.254 through the pod
+// network. The agent serves 169.254.169.254 in the guest and forwards the
+// connections over vsock to a proxy on the host, which only passes on the
+// requests for the allowed paths.
+type MetadataProxy struct {
+	// AllowedPaths are the path prefixes of the metadata service the
+	// guest can access. The proxy is disabled if empty.
+	AllowedPaths []string
+
+	// URL is the URL of the metadata service, http://169.254.169.254 if
+	// empty.
+	URL string
+}
+
+func (m MetadataProxy) enabled() bool {
+	return len(m.AllowedPaths) > 0
+}
+
+func (m MetadataProxy) validate() error {
+	if m.URL != "" {
+		if !m.enabled() {
+			return newConfigFieldError("URL", "A metadata service URL requires allowed paths")
+		}
+
+		u, err := url.Parse(m.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return newConfigFieldError("URL", fmt.Sprintf("Invalid metadata service URL %q", m.URL))
+		}
+	}
+
+	for _, p := range m.AllowedPaths {
+		if !cleanURLPath(p) {
+			return newConfigFieldError("AllowedPaths", fmt.Sprintf("Invalid path %q", p))
+		}
+	}
+
+	return nil
+}
+
+func (m MetadataProxy) kernelParams(port uint32) []Param {
+	return []Param{{Key: agentMetadataProxyPortParam, Value: strconv.FormatUint(uint64(port), 10)}}
+}
+
+func (m MetadataProxy) metadataURL() string {
+	if m.URL == "" {
+		return defaultMetadataURL
+	}
+	return m.URL
+}
+
+// Allows returns whether the path p is a clean path under an allowed path.
+func (m MetadataProxy) Allows(p string) bool {
+	if !cleanURLPath(p) {
+		return false
+	}
+
+	p = strings.TrimSuffix(p, "/")
+	for _, prefix := range m.AllowedPaths {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if p == prefix || strings.HasPrefix(p, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cleanURLPath returns whether p is an absolute path without any "." or
+// ".." element, a trailing slash aside.
+func cleanURLPath(p string) bool {
+	return strings.HasPrefix(p, "/") && (p == "/" || path.Clean(p) == strings.TrimSuffix(p, "/"))
+}
+
+// metadataProxy is the host side of the metadata proxy of a sandbox, an
+// HTTP reverse proxy listening on vsock.
+type metadataProxy struct {
+	config MetadataProxy
+	port   uint32
+	hybrid bool
+
+	target *url.URL
+	proxy  *httputil.ReverseProxy
+
+	listener net.Listener
+	server   *http.Server
+
+	logger *logrus.Entry
+}
+
+// cidListener only accepts the vsock connections of the guest with the
+// context ID cid, all the guests of the host connecting to the ports of the
+// host context ID.
+type cidListener struct {
+	net.Listener
+	cid uint32
+}
+
+func (l *cidListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		if addr, ok := conn.RemoteAddr().(*vsock.Addr); ok && addr.ContextID == l.cid {
+			return conn, nil
+		}
+
+		conn.Close()
+	}
+}
+
+// newMetadataProxy returns the metadata proxy of a new sandbox. The port of
+// a vhost-vsock proxy is allocated by the kernel, its listener being opened
+// until the proxy starts.
+func newMetadataProxy(config MetadataProxy, hType HypervisorType, logger *logrus.Entry) (*metadataProxy, error) {
+	target, err := url.Parse(config.metadataURL())
+	if err != nil {
+		return nil, err
+	}
+
+	p := &metadataProxy{
+		config: config,
+		port:   hybridMetadataProxyPort,
+		hybrid: hType == FirecrackerHypervisor || hType == ClhHypervisor,
+		target: target,
+		logger: logger.WithField("subsystem", "metadata-proxy"),
+	}
+
+	p.proxy = httputil.NewSingleHostReverseProxy(target)
+	director := p.proxy.Director
+	p.proxy.Director = func(r *http.Request) {
+		director(r)
+		// The metadata services check the host they are queried as
+		r.Host = target.Host
+		r.Header.Del("X-Forwarded-For")
+	}
+
+	if p.hybrid {
+		return p, nil
+	}
+
+	l, err := vsock.Listen(0)
+	if err != nil {
+		return nil, fmt.Errorf("Could not listen on vsock for the metadata proxy: %v", err)
+	}
+
+	p.listener = l
+	p.port = l.Addr().(*vsock.Addr).Port
+
+	return p, nil
+}
+
+// start starts serving the guest of the agent socket agentURL.
+func (p *metadataProxy) start(agentURL string) error {
+	if p.server != nil {
+		return nil
+	}
+
+	cid, _, ok := parseAgentVSock(agentURL)
+	if !ok {
+		return fmt.Errorf("The metadata proxy requires a vsock agent socket, not %q", agentURL)
+	}
+
+	if p.listener == nil {
+		var err error
+		if p.hybrid {
+			udsPath := strings.TrimPrefix(agentURL[:strings.LastIndex(agentURL, ":")], types.HybridVSockScheme+"://")
+			p.listener, err = net.Listen("unix", fmt.Sprintf("%s_%d", udsPath, p.port))
+		} else {
+			p.listener, err = vsock.Listen(p.port)
+		}
+		if err != nil {
+			return fmt.Errorf("Could not listen for the metadata proxy: %v", err)
+		}
+	}
+
+	listener := p.listener
+	if !p.hybrid {
+		listener = &cidListener{Listener: p.listener, cid: uint32(cid)}
+	}
+
+	p.server = &http.Server{
+		Handler:           p,
+		ReadHeaderTimeout: metadataProxyReadHeaderTimeout,
+	}
+
+	go func(server *http.Server) {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			p.logger.WithError(err).Warn("metadata proxy stopped")
+		}
+	}(p.server)
+
+	p.logger.WithFields(logrus.Fields{
+		"port":   p.port,
+		"target": p.target.String(),
+	}).Info("metadata proxy started")
+
+	return nil
+}
+
+func (p *metadataProxy) stop() {
+	if p.server != nil {
+		p.server.Close()
+		p.server = nil
+	}
+
+	if p.listener != nil {
+		p.listener.Close()
+		p.listener = nil
+	}
+}
+
+// ServeHTTP forwards the requests of the guest for the allowed paths to the
+// metadata service. PUT is allowed for the session tokens of IMDSv2.
+func (p *metadataProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := p.logger.WithFields(logrus.Fields{
+		"method": r.Method,
+		"path":   r.URL.Path,
+	})
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut:
+	default:
+		logger.Warn("metadata request method not allowed")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !cleanURLPath(r.URL.Path) {
+		logger.Warn("invalid metadata request path")
+		http.Error(w, "invalid path", http.StatusBadRequest)
+		return
+	}
+
+	if !p.config.Allows(r.URL.Path) {
+		logger.Warn("metadata request path not allowed")
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	logger.Debug("forwarding metadata request")
+
+	// The request is sent as the guest's own, some metadata services
+	// rejecting the ones with an X-Forwarded-For header.
+	r.RemoteAddr = ""
+	r.URL.RawPath = ""
+	p.proxy.ServeHTTP(w, r)
+}
+
+// startMetadataProxy starts the metadata proxy of the sandbox, once the VM
+// started and the agent socket is known.
+func (s *Sandbox) startMetadataProxy() error {
+	if s.metadataProxy == nil {
+		return nil
+	}
+
+	url, err := s.agent.getAgentURL()
+	if err != nil {
+		return err
+	}
+
+	return s.metadataProxy.start(url)
+}
+
+func (s *Sandbox) stopMetadataProxy() {
+	if s.metadataProxy != nil {
+		s.metadataProxy.stop()
+	}
+}
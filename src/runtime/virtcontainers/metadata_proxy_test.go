@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadataProxyValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(MetadataProxy{}.validate())
+	assert.NoError(MetadataProxy{AllowedPaths: []string{"/latest/meta-data/", "/latest/api/token"}}.validate())
+	assert.NoError(MetadataProxy{AllowedPaths: []string{"/"}, URL: "http://127.0.0.1:8080"}.validate())
+
+	for _, m := range []MetadataProxy{
+		{URL: "http://127.0.0.1:8080"},
+		{AllowedPaths: []string{"/"}, URL: "unix:///run/metadata.sock"},
+		{AllowedPaths: []string{"latest/meta-data"}},
+		{AllowedPaths: []string{"/latest/../user-data"}},
+		{AllowedPaths: []string{"/latest//meta-data"}},
+	} {
+		assert.Error(m.validate(), "%+v", m)
+	}
+}
+
+func TestMetadataProxyAllows(t *testing.T) {
+	assert := assert.New(t)
+
+	m := MetadataProxy{AllowedPaths: []string{"/latest/meta-data/placement/", "/latest/api/token"}}
+
+	assert.True(m.Allows("/latest/meta-data/placement"))
+	assert.True(m.Allows("/latest/meta-data/placement/"))
+	assert.True(m.Allows("/latest/meta-data/placement/availability-zone"))
+	assert.True(m.Allows("/latest/api/token"))
+	assert.False(m.Allows("/latest/meta-data/placement-group"))
+	assert.False(m.Allows("/latest/meta-data/placement/../../user-data"))
+	assert.False(m.Allows("/latest/meta-data/iam/security-credentials/"))
+	assert.False(m.Allows("/"))
+
+	assert.True(MetadataProxy{AllowedPaths: []string{"/"}}.Allows("/computeMetadata/v1/"))
+}
+
+func TestMetadataProxyKernelParams(t *testing.T) {
+	assert.Equal(t, []Param{{Key: "agent.metadata_proxy_vport", Value: "1026"}}, MetadataProxy{}.kernelParams(hybridMetadataProxyPort))
+}
+
+func TestMetadataProxyServe(t *testing.T) {
+	assert := assert.New(t)
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(r.Header.Get("X-Forwarded-For"))
+		fmt.Fprintf(w, "%s %s", r.Method, r.URL.Path)
+	}))
+	defer upstream.Close()
+
+	dir, err := ioutil.TempDir("", "metadata-proxy")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	p, err := newMetadataProxy(MetadataProxy{
+		AllowedPaths: []string{"/latest/meta-data/", "/latest/api/token"},
+		URL:          upstream.URL,
+	}, FirecrackerHypervisor, virtLog)
+	assert.NoError(err)
+	assert.Equal(uint32(hybridMetadataProxyPort), p.port)
+
+	udsPath := filepath.Join(dir, "kata.hvsock")
+	assert.NoError(p.start(fmt.Sprintf("hvsock://%s:1024", udsPath)))
+	defer p.stop()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return net.Dial("unix", fmt.Sprintf("%s_%d", udsPath, hybridMetadataProxyPort))
+			},
+		},
+	}
+
+	request := func(method, path string) (int, string) {
+		req, err := http.NewRequest(method, "http://169.254.169.254"+path, nil)
+		assert.NoError(err)
+		req.Header.Set("X-Forwarded-For", "10.0.0.1")
+
+		resp, err := client.Do(req)
+		assert.NoError(err)
+		defer resp.Body.Close()
+
+		body, err := ioutil.ReadAll(resp.Body)
+		assert.NoError(err)
+		return resp.StatusCode, string(body)
+	}
+
+	code, body := request(http.MethodGet, "/latest/meta-data/placement/region")
+	assert.Equal(http.StatusOK, code)
+	assert.Equal("GET /latest/meta-data/placement/region", body)
+
+	code, body = request(http.MethodPut, "/latest/api/token")
+	assert.Equal(http.StatusOK, code)
+	assert.Equal("PUT /latest/api/token", body)
+
+	code, _ = request(http.MethodGet, "/latest/user-data")
+	assert.Equal(http.StatusForbidden, code)
+
+	code, _ = request(http.MethodGet, "/latest/meta-data/../user-data")
+	assert.Equal(http.StatusBadRequest, code)
+
+	code, _ = request(http.MethodPost, "/latest/meta-data/")
+	assert.Equal(http.StatusMethodNotAllowed, code)
+
+	// The proxy can be restarted with the sandbox
+	p.stop()
+	assert.NoError(p.start(fmt.Sprintf("hvsock://%s:1024", udsPath)))
+	code, _ = request(http.MethodGet, "/latest/meta-data/")
+	assert.Equal(http.StatusOK, code)
+
+	p.stop()
+	assert.Error(p.start("unix:///run/kata.sock"))
+}
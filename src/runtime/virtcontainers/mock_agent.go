@@ -9,6 +9,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
 	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
 	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
@@ -194,6 +195,11 @@ func (n *mockAgent) reseedRNG(data []byte) error {
 	return nil
 }
 
+// reconnect is the Noop agent reconnection. It does nothing.
+func (n *mockAgent) reconnect(sandbox *Sandbox) error {
+	return nil
+}
+
 // reuseAgent is the Noop agent reuser. It does nothing.
 func (n *mockAgent) reuseAgent(agent agent) error {
 	return nil
@@ -222,11 +228,61 @@ func (n *mockAgent) setGuestDateTime(time.Time) error {
 	return nil
 }
 
+// releaseDevice is the Noop agent device releaser. It does nothing.
+func (n *mockAgent) releaseDevice(sandbox *Sandbox, device api.Device) error {
+	return nil
+}
+
+// suspendGuest is the Noop agent guest suspender. It does nothing.
+func (n *mockAgent) suspendGuest() error {
+	return nil
+}
+
+// installFile is the Noop agent file installer. It does nothing.
+func (n *mockAgent) installFile(src, path string) error {
+	return nil
+}
+
+// loadCrashKernel is the Noop agent crash kernel loader. It does nothing.
+func (n *mockAgent) loadCrashKernel(kernel, initrd string) error {
+	return nil
+}
+
+// startProfiling is the Noop agent profiling starter. It does nothing.
+func (n *mockAgent) startProfiling(session string, req ProfileRequest) error {
+	return nil
+}
+
+// loadLivepatch is the Noop agent livepatch loader. It does nothing.
+func (n *mockAgent) loadLivepatch(module, path string) error {
+	return nil
+}
+
+// checkpointContainer is the Noop agent container checkpointer. It does nothing.
+func (n *mockAgent) checkpointContainer(c *Container, imagesDir string, leaveRunning bool) error {
+	return nil
+}
+
+// restoreContainer is the Noop agent container restorer. It does nothing.
+func (n *mockAgent) restoreContainer(c *Container, imagesDir string) error {
+	return nil
+}
+
 // copyFile is the Noop agent copy file. It does nothing.
 func (n *mockAgent) copyFile(src, dst string) error {
 	return nil
 }
 
+// copyFileToRootfs is the Noop agent copy file to a container rootfs. It does nothing.
+func (n *mockAgent) copyFileToRootfs(src, rootfs, dst string) error {
+	return nil
+}
+
+// copyFileFromRootfs is the Noop agent copy file from a container rootfs. It does nothing.
+func (n *mockAgent) copyFileFromRootfs(rootfs, src, dst string) error {
+	return nil
+}
+
 func (n *mockAgent) markDead() {
 }
 
@@ -248,3 +304,18 @@ func (n *mockAgent) getOOMEvent() (string, error) {
 func (k *mockAgent) getAgentMetrics(req *grpc.GetMetricsRequest) (*grpc.Metrics, error) {
 	return nil, nil
 }
+
+// getTDReport is the Noop agent TD report getter. It does nothing.
+func (n *mockAgent) getTDReport(reportData []byte) ([]byte, error) {
+	return nil, nil
+}
+
+// beginVMSwitch is the Noop agent VM switch beginning. It does nothing.
+func (n *mockAgent) beginVMSwitch() error {
+	return nil
+}
+
+// endVMSwitch is the Noop agent VM switch end. It does nothing.
+func (n *mockAgent) endVMSwitch(sandbox *Sandbox, vmSocket interface{}) error {
+	return nil
+}
@@ -9,7 +9,9 @@ import (
 	"context"
 	"errors"
 	"os"
+	"time"
 
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 )
@@ -19,7 +21,12 @@ type mockHypervisor struct {
 }
 
 func (m *mockHypervisor) capabilities() types.Capabilities {
-	return types.Capabilities{}
+	var caps types.Capabilities
+	caps.SetBlockDeviceHotUnplugSupport()
+	caps.SetVFIOHotplugSupport()
+	caps.SetVirtioFSHotplugSupport()
+	caps.SetBlockDeviceCacheSupport()
+	return caps
 }
 
 func (m *mockHypervisor) hypervisorConfig() HypervisorConfig {
@@ -51,10 +58,46 @@ func (m *mockHypervisor) resumeSandbox() error {
 	return nil
 }
 
+func (m *mockHypervisor) waitGuestSuspended(timeout time.Duration) error {
+	return nil
+}
+
+func (m *mockHypervisor) wakeupSandbox() error {
+	return nil
+}
+
+func (m *mockHypervisor) upgradeVirtiofsd() error {
+	return nil
+}
+
+func (m *mockHypervisor) liveUpdate(vmSocket interface{}, endpoints []Endpoint, drives []*config.BlockDrive) error {
+	return nil
+}
+
+func (m *mockHypervisor) powerdownSandbox() error {
+	return nil
+}
+
+func (m *mockHypervisor) getLaunchMeasurement() (LaunchMeasurement, error) {
+	return LaunchMeasurement{}, nil
+}
+
 func (m *mockHypervisor) saveSandbox() error {
 	return nil
 }
 
+func (m *mockHypervisor) snapshotSandbox(dir string) error {
+	return nil
+}
+
+func (m *mockHypervisor) migrateSandbox(uri string) error {
+	return nil
+}
+
+func (m *mockHypervisor) finishIncomingMigration() error {
+	return nil
+}
+
 func (m *mockHypervisor) addDevice(devInfo interface{}, devType deviceType) error {
 	return nil
 }
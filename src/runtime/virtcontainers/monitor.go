@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 	"github.com/pkg/errors"
 )
 
@@ -26,6 +27,14 @@ type monitor struct {
 	wg            sync.WaitGroup
 	running       bool
 	stopCh        chan bool
+
+	// hypervisorCrashed is set once the crash of the hypervisor is
+	// published, for the next checks not to publish it again.
+	hypervisorCrashed bool
+
+	// checkLock is held by each round of checks, and by the operations
+	// during which the sandbox cannot answer them.
+	checkLock sync.Mutex
 }
 
 func newMonitor(s *Sandbox) *monitor {
@@ -57,8 +66,14 @@ func (m *monitor) newWatcher() (chan error, error) {
 					m.wg.Done()
 					return
 				case <-tick.C:
+					m.checkLock.Lock()
 					m.watchHypervisor()
-					m.watchAgent()
+					// The agent does not answer while the
+					// sandbox is paused or suspended.
+					if state := m.sandbox.getSandboxState(); state != types.StatePaused && state != types.StateSuspended {
+						m.watchAgent()
+					}
+					m.checkLock.Unlock()
 				}
 			}
 		}()
@@ -127,6 +142,16 @@ func (m *monitor) stop() {
 	}
 }
 
+// holdChecks waits for the ongoing checks to complete, and holds the next
+// ones back until releaseChecks is called.
+func (m *monitor) holdChecks() {
+	m.checkLock.Lock()
+}
+
+func (m *monitor) releaseChecks() {
+	m.checkLock.Unlock()
+}
+
 func (m *monitor) watchAgent() {
 	err := m.sandbox.agent.check()
 	if err != nil {
@@ -137,7 +162,12 @@ func (m *monitor) watchAgent() {
 
 func (m *monitor) watchHypervisor() error {
 	if err := m.sandbox.hypervisor.check(); err != nil {
-		m.notify(errors.Wrapf(err, "failed to ping hypervisor process"))
+		err = errors.Wrapf(err, "failed to ping hypervisor process")
+		if !m.hypervisorCrashed {
+			m.hypervisorCrashed = true
+			m.sandbox.publishEvent(SandboxEvent{Type: SandboxEventHypervisorCrash, Err: err})
+		}
+		m.notify(err)
 		return err
 	}
 	return nil
@@ -8,7 +8,9 @@ package virtcontainers
 import (
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -58,3 +60,32 @@ func TestMonitorClosedChannel(t *testing.T) {
 
 	m.stop()
 }
+
+func TestMonitorSandboxStateChanges(t *testing.T) {
+	contID := "505"
+	contConfig := newTestContainerConfigNoop(contID)
+	hConfig := newHypervisorConfig(nil, nil)
+	assert := assert.New(t)
+
+	// create a sandbox
+	s, err := testCreateSandbox(t, testSandboxID, MockHypervisor, hConfig, NetworkConfig{}, []ContainerConfig{contConfig}, nil)
+	assert.NoError(err)
+	defer cleanUp()
+
+	m := newMonitor(s)
+	m.checkInterval = time.Millisecond
+
+	_, err = m.newWatcher()
+	assert.Nil(err, "newWatcher failed: %v", err)
+
+	// The sandbox is paused and resumed while the monitor checks it
+	for i := 0; i < 10; i++ {
+		assert.NoError(s.setSandboxState(types.StatePaused))
+		time.Sleep(m.checkInterval)
+		assert.NoError(s.setSandboxState(types.StateRunning))
+		time.Sleep(m.checkInterval)
+	}
+	assert.Equal(types.StateRunning, s.getSandboxState())
+
+	m.stop()
+}
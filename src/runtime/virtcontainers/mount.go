@@ -305,6 +305,19 @@ type Mount struct {
 	// VM in case this mount is a block device file or a directory
 	// backed by a block device.
 	BlockDeviceID string
+
+	// VirtioFS shares the mount with the guest through a virtio-fs device
+	// of its own, with these options, instead of the shared directory of
+	// the sandbox.
+	VirtioFS *VirtioFSOptions
+
+	// VirtioFSDeviceID is the virtio-fs device the mount is shared through.
+	VirtioFSDeviceID string
+
+	// BlockCache is the cache mode of the block device the mount source
+	// is attached to the VM as, empty for the cache options of the
+	// hypervisor configuration.
+	BlockCache string
 }
 
 func isSymlink(path string) bool {
@@ -353,6 +366,7 @@ func bindUnmountAllRootfs(ctx context.Context, sharedDir string, sandbox *Sandbo
 			// to be unmounted, and collect all errors
 			errors = merr.Append(errors, bindUnmountContainerRootfs(c.ctx, sharedDir, c.id))
 		}
+		errors = merr.Append(errors, bindUnmountCoreDumpDir(c.ctx, sharedDir, c.id))
 	}
 	return errors.ErrorOrNil()
 }
@@ -422,3 +436,28 @@ func isEmptyDir(path string) bool {
 	}
 	return false
 }
+
+// k8sWatchableVolumes are the k8s volume types the kubelet updates while the
+// pods run, such as the projected service account tokens it rotates.
+var k8sWatchableVolumes = []string{
+	"kubernetes.io~configmap",
+	"kubernetes.io~downward-api",
+	"kubernetes.io~projected",
+	"kubernetes.io~secret",
+}
+
+// isWatchableMount returns whether the given path is a k8s volume whose
+// files the kubelet updates, and which must be copied to the guest again
+// when they change if the filesystem is not shared.
+func isWatchableMount(path string) bool {
+	splitSourceSlice := strings.Split(path, "/")
+	if len(splitSourceSlice) > 1 {
+		storageType := splitSourceSlice[len(splitSourceSlice)-2]
+		for _, t := range k8sWatchableVolumes {
+			if storageType == t {
+				return true
+			}
+		}
+	}
+	return false
+}
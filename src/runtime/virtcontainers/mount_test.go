@@ -415,3 +415,15 @@ func TestBindUnmountContainerRootfsRemoveRootfsDest(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestIsWatchableMount(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(isWatchableMount("/var/lib/kubelet/pods/abc/volumes/kubernetes.io~projected/kube-api-access-x7k2p"))
+	assert.True(isWatchableMount("/var/lib/kubelet/pods/abc/volumes/kubernetes.io~secret/credentials"))
+	assert.True(isWatchableMount("/var/lib/kubelet/pods/abc/volumes/kubernetes.io~configmap/config"))
+	assert.True(isWatchableMount("/var/lib/kubelet/pods/abc/volumes/kubernetes.io~downward-api/podinfo"))
+	assert.False(isWatchableMount("/var/lib/kubelet/pods/abc/volumes/kubernetes.io~empty-dir/cache"))
+	assert.False(isWatchableMount("/var/lib/kubelet/pods/abc/etc-hosts"))
+	assert.False(isWatchableMount("kubernetes.io~secret"))
+}
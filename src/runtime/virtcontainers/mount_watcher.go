@@ -0,0 +1,235 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/sirupsen/logrus"
+)
+
+// watchedMountsCheckInterval is how often the files of the watched mounts
+// are checked for changes. The kubelet rotates the service account tokens
+// well before they expire.
+var watchedMountsCheckInterval = 2 * time.Second
+
+// watchedFile is the version of a file of a watched mount last copied to
+// the guest.
+type watchedFile struct {
+	modTime time.Time
+	size    int64
+}
+
+// watchedMount is a k8s volume copied to the guest, the filesystem not being
+// shared, whose files are copied again when the kubelet updates them.
+type watchedMount struct {
+	source    string
+	guestDest string
+
+	// files are the versions copied of the files, by path relative to
+	// source.
+	files map[string]watchedFile
+}
+
+// mountWatcher copies the files of the watched mounts of the containers of
+// a sandbox to the guest when they change.
+type mountWatcher struct {
+	sync.Mutex
+
+	// mounts are the watched mounts, by container ID.
+	mounts map[string][]*watchedMount
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// scanWatchedDir lists the regular files of a k8s volume directory. The
+// kubelet writes the files in a hidden ..data directory, swapped at once on
+// updates, the files being symlinks to it.
+func scanWatchedDir(dir, rel string, files map[string]watchedFile) error {
+	entries, err := ioutil.ReadDir(filepath.Join(dir, rel))
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), "..") {
+			continue
+		}
+
+		path := filepath.Join(rel, e.Name())
+		fi, err := os.Stat(filepath.Join(dir, path))
+		if os.IsNotExist(err) {
+			// The kubelet is swapping the files
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case fi.IsDir():
+			if err := scanWatchedDir(dir, path, files); err != nil {
+				return err
+			}
+		case fi.Mode().IsRegular():
+			files[path] = watchedFile{modTime: fi.ModTime(), size: fi.Size()}
+		}
+	}
+
+	return nil
+}
+
+// sync copies the files of the mount changed since the last copy to the
+// guest. The files removed on the host are left in the guest, the agent
+// only writing files.
+func (m *watchedMount) sync(a agent, logger *logrus.Entry) error {
+	files := make(map[string]watchedFile)
+	if err := scanWatchedDir(m.source, "", files); err != nil {
+		return err
+	}
+
+	for path, f := range files {
+		if copied, ok := m.files[path]; ok && copied == f {
+			continue
+		}
+
+		if err := a.copyFile(filepath.Join(m.source, path), filepath.Join(m.guestDest, path)); err != nil {
+			return err
+		}
+
+		m.files[path] = f
+		logger.WithFields(logrus.Fields{
+			"source": m.source,
+			"file":   path,
+		}).Debug("Watched mount file copied")
+	}
+
+	for path := range m.files {
+		if _, ok := files[path]; !ok {
+			delete(m.files, path)
+		}
+	}
+
+	return nil
+}
+
+// copyWatchedMount copies a k8s volume directory to the guest, and watches
+// it for the updates of the kubelet until the container stops. The mount is
+// ignored if the volume has no file.
+func (c *Container) copyWatchedMount(source, guestDest string) (bool, error) {
+	m := &watchedMount{
+		source:    source,
+		guestDest: guestDest,
+		files:     make(map[string]watchedFile),
+	}
+
+	if err := m.sync(c.sandbox.agent, c.Logger()); err != nil {
+		return false, err
+	}
+
+	if len(m.files) == 0 {
+		c.Logger().WithField("ignored-dir", source).Debug("Ignoring empty watched mount as FS sharing not supported")
+		return true, nil
+	}
+
+	c.sandbox.watchMount(c.id, m)
+
+	return false, nil
+}
+
+// watchMount adds a watched mount of a container, and starts watching the
+// mounts of the sandbox if not yet.
+func (s *Sandbox) watchMount(containerID string, m *watchedMount) {
+	if s.mountWatcher == nil {
+		s.startMountWatcher()
+	}
+
+	w := s.mountWatcher
+	w.Lock()
+	defer w.Unlock()
+
+	w.mounts[containerID] = append(w.mounts[containerID], m)
+}
+
+// unwatchMounts stops watching the mounts of a container.
+func (s *Sandbox) unwatchMounts(containerID string) {
+	if s.mountWatcher == nil {
+		return
+	}
+
+	w := s.mountWatcher
+	w.Lock()
+	defer w.Unlock()
+
+	delete(w.mounts, containerID)
+}
+
+func (s *Sandbox) startMountWatcher() {
+	w := &mountWatcher{
+		mounts: make(map[string][]*watchedMount),
+		stopCh: make(chan struct{}),
+	}
+	s.mountWatcher = w
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		tick := time.NewTicker(watchedMountsCheckInterval)
+		defer tick.Stop()
+
+		for {
+			select {
+			case <-w.stopCh:
+				return
+			case <-tick.C:
+				s.syncWatchedMounts()
+			}
+		}
+	}()
+}
+
+// syncWatchedMounts copies the changed files of the watched mounts to the
+// guest, while it runs.
+func (s *Sandbox) syncWatchedMounts() {
+	if s.state.State != types.StateRunning {
+		return
+	}
+
+	w := s.mountWatcher
+	w.Lock()
+	defer w.Unlock()
+
+	for id, mounts := range w.mounts {
+		for _, m := range mounts {
+			if err := m.sync(s.agent, s.Logger()); err != nil {
+				s.Logger().WithError(err).WithFields(logrus.Fields{
+					"container": id,
+					"source":    m.source,
+				}).Warn("Could not copy watched mount to the guest")
+			}
+		}
+	}
+}
+
+// stopMountWatcher stops watching the mounts of the sandbox, once the
+// files being copied, if any, are copied.
+func (s *Sandbox) stopMountWatcher() {
+	if s.mountWatcher == nil {
+		return
+	}
+
+	close(s.mountWatcher.stopCh)
+	s.mountWatcher.wg.Wait()
+	s.mountWatcher = nil
+}
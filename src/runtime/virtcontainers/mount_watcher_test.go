@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// copyRecordingAgent is a mock agent recording the guest files copied.
+type copyRecordingAgent struct {
+	mockAgent
+	copied []string
+}
+
+func (n *copyRecordingAgent) copyFile(src, dst string) error {
+	n.copied = append(n.copied, dst)
+	return nil
+}
+
+// writeK8sVolume writes the files of a k8s volume as the kubelet does, in a
+// new hidden directory swapped at once.
+func writeK8sVolume(t *testing.T, dir, version string, files map[string]string) {
+	assert := assert.New(t)
+
+	dataDir := filepath.Join(dir, ".."+version)
+	for name, content := range files {
+		assert.NoError(os.MkdirAll(filepath.Dir(filepath.Join(dataDir, name)), 0755))
+		assert.NoError(ioutil.WriteFile(filepath.Join(dataDir, name), []byte(content), 0644))
+
+		link := filepath.Join(dir, name)
+		if _, err := os.Lstat(link); os.IsNotExist(err) {
+			assert.NoError(os.Symlink(filepath.Join("..data", name), link))
+		}
+	}
+
+	assert.NoError(os.Symlink(".."+version, filepath.Join(dir, "..data_tmp")))
+	assert.NoError(os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+}
+
+func TestWatchedMountSync(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "watched-mount")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	source := filepath.Join(dir, "kubernetes.io~projected", "kube-api-access")
+	assert.NoError(os.MkdirAll(source, 0755))
+	writeK8sVolume(t, source, "2020_06_01_10_00_00.1", map[string]string{
+		"token":     "token-1",
+		"ca.crt":    "ca",
+		"namespace": "default",
+	})
+
+	a := &copyRecordingAgent{}
+	m := &watchedMount{
+		source:    source,
+		guestDest: "/run/kata-containers/shared/containers/ctr-token",
+		files:     make(map[string]watchedFile),
+	}
+
+	assert.NoError(m.sync(a, virtLog))
+	sort.Strings(a.copied)
+	assert.Equal([]string{
+		filepath.Join(m.guestDest, "ca.crt"),
+		filepath.Join(m.guestDest, "namespace"),
+		filepath.Join(m.guestDest, "token"),
+	}, a.copied)
+
+	// Nothing changed
+	a.copied = nil
+	assert.NoError(m.sync(a, virtLog))
+	assert.Empty(a.copied)
+
+	// The token is rotated, the kubelet writing all the files again
+	writeK8sVolume(t, source, "2020_06_01_10_48_00.2", map[string]string{
+		"token":     "token-2-rotated",
+		"ca.crt":    "ca",
+		"namespace": "default",
+	})
+
+	assert.NoError(m.sync(a, virtLog))
+	assert.Len(a.copied, 3)
+	assert.Contains(a.copied, filepath.Join(m.guestDest, "token"))
+
+	// A file removed from the volume is not copied any longer
+	assert.NoError(os.Remove(filepath.Join(source, "namespace")))
+	a.copied = nil
+	assert.NoError(m.sync(a, virtLog))
+	assert.Empty(a.copied)
+}
+
+func TestSandboxMountWatcher(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "watched-mount")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	source := filepath.Join(dir, "kubernetes.io~secret", "credentials")
+	assert.NoError(os.MkdirAll(source, 0755))
+	writeK8sVolume(t, source, "2020_06_01_10_00_00.1", map[string]string{"password": "secret-1"})
+
+	// The mounts are synced by the test only
+	savedInterval := watchedMountsCheckInterval
+	watchedMountsCheckInterval = time.Hour
+	defer func() {
+		watchedMountsCheckInterval = savedInterval
+	}()
+
+	a := &copyRecordingAgent{}
+	s := &Sandbox{
+		id:    "sandbox",
+		ctx:   context.Background(),
+		agent: a,
+		state: types.SandboxState{State: types.StateRunning},
+	}
+
+	c := &Container{id: "ctr", sandbox: s}
+
+	ignore, err := c.copyWatchedMount(source, "/run/kata-containers/shared/containers/ctr-credentials")
+	assert.NoError(err)
+	assert.False(ignore)
+	assert.Len(a.copied, 1)
+	assert.NotNil(s.mountWatcher)
+	defer s.stopMountWatcher()
+
+	writeK8sVolume(t, source, "2020_06_01_11_00_00.2", map[string]string{"password": "secret-2-updated"})
+
+	// Not copied to a paused guest
+	s.state.State = types.StatePaused
+	s.syncWatchedMounts()
+	assert.Len(a.copied, 1)
+
+	s.state.State = types.StateRunning
+	s.syncWatchedMounts()
+	assert.Len(a.copied, 2)
+
+	// Not copied once the container stopped
+	writeK8sVolume(t, source, "2020_06_01_12_00_00.3", map[string]string{"password": "secret-3-updated-again"})
+	s.unwatchMounts(c.id)
+	s.syncWatchedMounts()
+	assert.Len(a.copied, 2)
+
+	// An empty volume is ignored
+	empty := filepath.Join(dir, "kubernetes.io~configmap", "empty")
+	assert.NoError(os.MkdirAll(empty, 0755))
+	ignore, err = c.copyWatchedMount(empty, "/run/kata-containers/shared/containers/ctr-empty")
+	assert.NoError(err)
+	assert.True(ignore)
+}
@@ -16,7 +16,9 @@ import (
 	"os/exec"
 	"runtime"
 	"sort"
+	"syscall"
 	"time"
+	"unsafe"
 
 	"github.com/containernetworking/plugins/pkg/ns"
 	"github.com/containernetworking/plugins/pkg/testutils"
@@ -90,6 +92,20 @@ func (n *NetInterworkingModel) SetModel(modelName string) error {
 	return fmt.Errorf("Unknown type %s", modelName)
 }
 
+// name returns the name SetModel takes for the model.
+func (n NetInterworkingModel) name() string {
+	switch n {
+	case NetXConnectMacVtapModel:
+		return macvtapNetModelStr
+	case NetXConnectTCFilterModel:
+		return tcFilterNetModelStr
+	case NetXConnectNoneModel:
+		return noneNetModelStr
+	default:
+		return defaultNetModelStr
+	}
+}
+
 // DefaultNetInterworkingModel is a package level default
 // that determines how the VM should be connected to the
 // the container network interface
@@ -161,6 +177,28 @@ type NetworkConfig struct {
 	DisableNewNetNs   bool
 	NetmonConfig      NetmonConfig
 	InterworkingModel NetInterworkingModel
+
+	// TCFilterCompat is how the endpoints connected with tc filters are
+	// set up for the redirected traffic.
+	TCFilterCompat TCFilterCompat
+
+	// MACAllocation picks the MAC addresses of the guest network
+	// interfaces.
+	MACAllocation MACAllocation
+
+	// DHCPInterfaces lists the interfaces whose addresses the guest gets
+	// from a DHCP server.
+	DHCPInterfaces DHCPInterfaces
+
+	// InterfaceEndpoints forces the endpoint of some interfaces instead
+	// of the one detected from their type.
+	InterfaceEndpoints InterfaceEndpoints
+
+	// Provider is the network provider setting up the datapath of the
+	// interfaces, a provider built into the runtime or the absolute path
+	// of a provider binary. The interfaces of the network namespace are
+	// used when it is empty.
+	Provider string
 }
 
 func networkLogger() *logrus.Entry {
@@ -350,6 +388,27 @@ func createLink(netHandle *netlink.Handle, name string, expectedLink netlink.Lin
 	return newLink, fds, err
 }
 
+// createTap creates the TAP interface a VM is connected through, or attaches
+// to its queues when it was pre-allocated.
+func createTap(netHandle *netlink.Handle, name string, queues int) (netlink.Link, []*os.File, error) {
+	link, err := getLinkByName(netHandle, name, &netlink.Tuntap{})
+	if err != nil {
+		return createLink(netHandle, name, &netlink.Tuntap{}, queues)
+	}
+
+	// The hypervisor attaches to a single queue TAP interface by name.
+	if queues == 0 {
+		return link, nil, nil
+	}
+
+	fds, err := openTapQueues(name, queues)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return link, fds, nil
+}
+
 func getLinkForEndpoint(endpoint Endpoint, netHandle *netlink.Handle) (netlink.Link, error) {
 	var link netlink.Link
 
@@ -410,7 +469,7 @@ func xConnectVMNetwork(endpoint Endpoint, h hypervisor) error {
 	queues := 0
 	caps := h.capabilities()
 	if caps.IsMultiQueueSupported() {
-		queues = int(h.hypervisorConfig().NumVCPUs)
+		queues = networkQueues(h.hypervisorConfig())
 	}
 
 	var disableVhostNet bool
@@ -492,6 +551,118 @@ const linkRange = 0xFFFF    // This will allow upto 2^16 containers
 const linkRetries = 128     // The numbers of time we try to find a non conflicting index
 const macvtapWorkaround = true
 
+// openTapQueues attaches a number of queues to the name multiqueue tap, as
+// created by createLink.
+func openTapQueues(name string, queues int) ([]*os.File, error) {
+	fds := make([]*os.File, queues)
+
+	for i := 0; i < queues; i++ {
+		f, err := os.OpenFile("/dev/net/tun", os.O_RDWR, defaultFilePerms)
+		if err != nil {
+			utils.CleanupFds(fds, i)
+			return nil, err
+		}
+		fds[i] = f
+
+		req := tapIfReq{
+			Flags: unix.IFF_TAP | unix.IFF_NO_PI | unix.IFF_VNET_HDR | unix.IFF_MULTI_QUEUE,
+		}
+		copy(req.Name[:maxInterfaceNameLen], name)
+
+		if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), unix.TUNSETIFF, uintptr(unsafe.Pointer(&req))); errno != 0 {
+			utils.CleanupFds(fds, i+1)
+			return nil, fmt.Errorf("Could not attach to TAP %s: %v", name, errno)
+		}
+	}
+
+	return fds, nil
+}
+
+// reopenVMFds opens the file descriptors of the network interface of an
+// endpoint again, for another process of the hypervisor to attach to the
+// interface. It is called in the network namespace of the sandbox.
+func reopenVMFds(endpoint Endpoint, h hypervisor) (err error) {
+	queues := 0
+	caps := h.capabilities()
+	if caps.IsMultiQueueSupported() {
+		queues = networkQueues(h.hypervisorConfig())
+	}
+
+	// A single queue tap is attached to a single process.
+	if queues == 0 {
+		return fmt.Errorf("Cannot reopen the single queue network interface of endpoint %s", endpoint.Name())
+	}
+
+	// The queues the runtime still holds would be left unread once the
+	// process of the hypervisor sharing them goes away.
+	closeVMFds(endpoint)
+
+	disableVhostNet := h.hypervisorConfig().DisableVhostNet
+
+	var vmFds []*os.File
+	switch ep := endpoint.(type) {
+	case *MacvtapEndpoint:
+		vmFds, err = createMacvtapFds(ep.EndpointProperties.Iface.Index, queues)
+	case *VethEndpoint, *BridgedMacvlanEndpoint, *IPVlanEndpoint, *TuntapEndpoint:
+		netPair := endpoint.NetworkPair()
+		disableVhostNet = disableVhostNet || rootless.IsRootless()
+
+		switch netPair.NetInterworkingModel {
+		case NetXConnectMacVtapModel:
+			var link netlink.Link
+			if link, err = netlink.LinkByName(netPair.TAPIface.Name); err != nil {
+				return err
+			}
+			vmFds, err = createMacvtapFds(link.Attrs().Index, queues)
+		case NetXConnectTCFilterModel:
+			vmFds, err = openTapQueues(netPair.TAPIface.Name, queues)
+		default:
+			return fmt.Errorf("Invalid internetworking model")
+		}
+	default:
+		return fmt.Errorf("Cannot reopen the network interface of %s endpoint %s", endpoint.Type(), endpoint.Name())
+	}
+	if err != nil {
+		return fmt.Errorf("Could not reopen the network interface of endpoint %s: %v", endpoint.Name(), err)
+	}
+
+	var vhostFds []*os.File
+	if !disableVhostNet {
+		if vhostFds, err = createVhostFds(queues); err != nil {
+			utils.CleanupFds(vmFds, len(vmFds))
+			return fmt.Errorf("Could not setup vhost fds %s: %v", endpoint.Name(), err)
+		}
+	}
+
+	if ep, ok := endpoint.(*MacvtapEndpoint); ok {
+		ep.VMFds = vmFds
+		ep.VhostFds = vhostFds
+	} else {
+		endpoint.NetworkPair().VMFds = vmFds
+		endpoint.NetworkPair().VhostFds = vhostFds
+	}
+
+	return nil
+}
+
+// closeVMFds closes the file descriptors of the network interface of an
+// endpoint, once the hypervisor inherited them.
+func closeVMFds(endpoint Endpoint) {
+	var vmFds, vhostFds *[]*os.File
+	if ep, ok := endpoint.(*MacvtapEndpoint); ok {
+		vmFds, vhostFds = &ep.VMFds, &ep.VhostFds
+	} else if netPair := endpoint.NetworkPair(); netPair != nil {
+		vmFds, vhostFds = &netPair.VMFds, &netPair.VhostFds
+	} else {
+		return
+	}
+
+	utils.CleanupFds(*vmFds, len(*vmFds))
+	utils.CleanupFds(*vhostFds, len(*vhostFds))
+	*vmFds = nil
+	*vhostFds = nil
+}
+
 func createMacVtap(netHandle *netlink.Handle, name string, link netlink.Link, queues int) (taplink netlink.Link, err error) {
 
 	if !macvtapWorkaround {
@@ -634,7 +805,7 @@ func setupTCFiltering(endpoint Endpoint, queues int, disableVhostNet bool) error
 
 	netPair := endpoint.NetworkPair()
 
-	tapLink, fds, err := createLink(netHandle, netPair.TAPIface.Name, &netlink.Tuntap{}, queues)
+	tapLink, fds, err := createTap(netHandle, netPair.TAPIface.Name, queues)
 	if err != nil {
 		return fmt.Errorf("Could not create TAP interface: %s", err)
 	}
@@ -1150,8 +1321,9 @@ func createEndpointsFromScan(networkNSPath string, config *NetworkConfig) ([]End
 		// Ignore unconfigured network interfaces. These are
 		// either base tunnel devices that are not namespaced
 		// like gre0, gretap0, sit0, ipip0, tunl0 or incorrectly
-		// setup interfaces.
-		if len(netInfo.Addrs) == 0 {
+		// setup interfaces. The DHCP interfaces get their addresses
+		// in the guest.
+		if len(netInfo.Addrs) == 0 && !config.DHCPInterfaces.has(netInfo.Iface.Name) {
 			continue
 		}
 
@@ -1161,7 +1333,7 @@ func createEndpointsFromScan(networkNSPath string, config *NetworkConfig) ([]End
 		}
 
 		if err := doNetNS(networkNSPath, func(_ ns.NetNS) error {
-			endpoint, errCreate = createEndpoint(netInfo, idx, config.InterworkingModel, link)
+			endpoint, errCreate = createEndpoint(netInfo, idx, config.InterworkingModel, config.InterfaceEndpoints, link)
 			return errCreate
 		}); err != nil {
 			return []Endpoint{}, err
@@ -1182,8 +1354,13 @@ func createEndpointsFromScan(networkNSPath string, config *NetworkConfig) ([]End
 	return endpoints, nil
 }
 
-func createEndpoint(netInfo NetworkInfo, idx int, model NetInterworkingModel, link netlink.Link) (Endpoint, error) {
-	var endpoint Endpoint
+func createEndpoint(netInfo NetworkInfo, idx int, model NetInterworkingModel, forced InterfaceEndpoints, link netlink.Link) (Endpoint, error) {
+	// An endpoint forced by the configuration wins over the detected one.
+	endpoint, err := forced.createForcedEndpoint(netInfo, idx, model)
+	if endpoint != nil || err != nil {
+		return endpoint, err
+	}
+
 	// TODO: This is the incoming interface
 	// based on the incoming interface we should create
 	// an appropriate EndPoint based on interface type
@@ -1275,24 +1452,50 @@ func (n *Network) Add(ctx context.Context, config *NetworkConfig, s *Sandbox, ho
 	span, _ := n.trace(ctx, "add")
 	defer span.Finish()
 
-	endpoints, err := createEndpointsFromScan(config.NetNSPath, config)
+	var (
+		endpoints []Endpoint
+		err       error
+	)
+	if s.networkProvider != nil {
+		endpoints, err = createEndpointsFromProvider(ctx, s.networkProvider, s.id, config)
+	} else if s.config.Lightweight.Enable && len(s.config.Lightweight.Interfaces) > 0 {
+		endpoints, err = createEndpointsFromNames(config.NetNSPath, config, s.config.Lightweight.Interfaces)
+	} else {
+		endpoints, err = createEndpointsFromScan(config.NetNSPath, config)
+	}
 	if err != nil {
 		return endpoints, err
 	}
 
+	if err := s.config.ResourceCeilings.checkInterfaces(len(endpoints)); err != nil {
+		return []Endpoint{}, err
+	}
+
 	err = doNetNS(config.NetNSPath, func(_ ns.NetNS) error {
 		for _, endpoint := range endpoints {
+			if err := config.MACAllocation.assign(s.macLeases(), s.id, endpoint); err != nil {
+				return err
+			}
+
 			networkLogger().WithField("endpoint-type", endpoint.Type()).WithField("hotplug", hotplug).Info("Attaching endpoint")
 			if hotplug {
-				if err := endpoint.HotAttach(s.hypervisor); err != nil {
+				if err := runEndpointOp(&s.endpointOps, endpoint, endpointOpHotAttach, func() error {
+					return endpoint.HotAttach(s)
+				}); err != nil {
 					return err
 				}
 			} else {
-				if err := endpoint.Attach(s); err != nil {
+				if err := runEndpointOp(&s.endpointOps, endpoint, endpointOpAttach, func() error {
+					return endpoint.Attach(s)
+				}); err != nil {
 					return err
 				}
 			}
 
+			if err := s.config.NetworkConfig.TCFilterCompat.apply(endpoint); err != nil {
+				return err
+			}
+
 			if !s.hypervisor.isRateLimiterBuiltin() {
 				rxRateLimiterMaxRate := s.hypervisor.hypervisorConfig().RxRateLimiterMaxRate
 				if rxRateLimiterMaxRate > 0 {
@@ -1376,7 +1579,9 @@ func (n *Network) Remove(ctx context.Context, ns *NetworkNamespace, hypervisor h
 		// Detach for an endpoint should enter the network namespace
 		// if required.
 		networkLogger().WithField("endpoint-type", endpoint.Type()).Info("Detaching endpoint")
-		if err := endpoint.Detach(ns.NetNsCreated, ns.NetNsPath); err != nil {
+		if err := runEndpointOp(nil, endpoint, endpointOpDetach, func() error {
+			return endpoint.Detach(ns.NetNsCreated, ns.NetNsPath)
+		}); err != nil {
 			return err
 		}
 	}
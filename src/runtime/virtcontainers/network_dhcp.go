@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"strings"
+
+	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
+)
+
+const agentDHCPParam = "agent.dhcp"
+
+// maxInterfaceNameLen is the longest network interface name the kernel
+// accepts, IFNAMSIZ without the trailing NUL.
+const maxInterfaceNameLen = 15
+
+// DHCPInterfaces lists the pod network interfaces whose addresses are
+// assigned by a DHCP server of the pod network, such as SR-IOV VFs on a
+// VLAN, instead of the network plugin. The agent runs a DHCP client on
+// them, and the addresses, routes and neighbors of the network namespace
+// are not replicated in the guest.
+type DHCPInterfaces []string
+
+func (d DHCPInterfaces) enabled() bool {
+	return len(d) > 0
+}
+
+func (d DHCPInterfaces) validate() error {
+	seen := make(map[string]bool)
+	for _, name := range d {
+		if name == "" || len(name) > maxInterfaceNameLen || strings.ContainsAny(name, "/,= \t\n") {
+			return newConfigFieldError("DHCPInterfaces", fmt.Sprintf("Invalid interface name %q", name))
+		}
+		if seen[name] {
+			return newConfigFieldError("DHCPInterfaces", fmt.Sprintf("Interface %q listed twice", name))
+		}
+		seen[name] = true
+	}
+
+	return nil
+}
+
+func (d DHCPInterfaces) kernelParams() []Param {
+	if !d.enabled() {
+		return nil
+	}
+
+	return []Param{{Key: agentDHCPParam, Value: strings.Join(d, ",")}}
+}
+
+func (d DHCPInterfaces) has(name string) bool {
+	for _, n := range d {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// strip removes the addresses, routes and neighbors of the DHCP interfaces,
+// which the guest gets from the DHCP server.
+func (d DHCPInterfaces) strip(ifaces []*vcTypes.Interface, routes []*vcTypes.Route, neighs []*vcTypes.ARPNeighbor) ([]*vcTypes.Interface, []*vcTypes.Route, []*vcTypes.ARPNeighbor) {
+	if !d.enabled() {
+		return ifaces, routes, neighs
+	}
+
+	for _, ifc := range ifaces {
+		if d.has(ifc.Name) {
+			ifc.IPAddresses = nil
+		}
+	}
+
+	var r []*vcTypes.Route
+	for _, route := range routes {
+		if !d.has(route.Device) {
+			r = append(r, route)
+		}
+	}
+
+	var n []*vcTypes.ARPNeighbor
+	for _, neigh := range neighs {
+		if !d.has(neigh.Device) {
+			n = append(n, neigh)
+		}
+	}
+
+	return ifaces, r, n
+}
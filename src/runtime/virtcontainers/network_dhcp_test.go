@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDHCPInterfacesValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(DHCPInterfaces{}.validate())
+	assert.NoError(DHCPInterfaces{"eth0", "net1"}.validate())
+
+	for _, d := range []DHCPInterfaces{
+		{""},
+		{"eth0,eth1"},
+		{"eth0=1"},
+		{"a-very-long-interface"},
+		{"eth0", "eth0"},
+	} {
+		assert.Error(d.validate(), "%v", d)
+	}
+}
+
+func TestDHCPInterfacesKernelParams(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(DHCPInterfaces{}.kernelParams())
+	assert.Equal([]Param{{Key: "agent.dhcp", Value: "eth0,net1"}}, DHCPInterfaces{"eth0", "net1"}.kernelParams())
+}
+
+func TestDHCPInterfacesStrip(t *testing.T) {
+	assert := assert.New(t)
+
+	ifaces := []*vcTypes.Interface{
+		{Name: "eth0", IPAddresses: []*vcTypes.IPAddress{{Address: "10.0.0.2", Mask: "24"}}},
+		{Name: "net1", IPAddresses: []*vcTypes.IPAddress{{Address: "192.168.1.2", Mask: "24"}}},
+	}
+	routes := []*vcTypes.Route{
+		{Dest: "10.1.0.0/16", Device: "eth0"},
+		{Gateway: "192.168.1.1", Device: "net1"},
+	}
+	neighs := []*vcTypes.ARPNeighbor{
+		{Device: "eth0"},
+		{Device: "net1"},
+	}
+
+	ifaces, routes, neighs = DHCPInterfaces{"net1"}.strip(ifaces, routes, neighs)
+
+	assert.Len(ifaces, 2)
+	assert.Len(ifaces[0].IPAddresses, 1)
+	assert.Empty(ifaces[1].IPAddresses)
+	assert.Len(routes, 1)
+	assert.Equal("eth0", routes[0].Device)
+	assert.Len(neighs, 1)
+	assert.Equal("eth0", neighs[0].Device)
+}
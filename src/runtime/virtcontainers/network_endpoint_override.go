@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// InterfaceEndpoint is the endpoint connecting a pod network interface to
+// the VM.
+type InterfaceEndpoint string
+
+const (
+	// InterfaceEndpointVeth connects the interface like a veth, with the
+	// interworking model of the sandbox.
+	InterfaceEndpointVeth InterfaceEndpoint = "veth"
+
+	// InterfaceEndpointTCFilter redirects the traffic of the interface to
+	// a tap device with tc mirred filters.
+	InterfaceEndpointTCFilter InterfaceEndpoint = "tcfilter"
+
+	// InterfaceEndpointMacvtap bridges the interface to the VM with a
+	// macvtap device.
+	InterfaceEndpointMacvtap InterfaceEndpoint = "macvtap"
+
+	// InterfaceEndpointIPVlan connects the interface like an ipvlan, which
+	// always uses tc filters, whatever the interworking model.
+	InterfaceEndpointIPVlan InterfaceEndpoint = "ipvlan"
+)
+
+func (e InterfaceEndpoint) valid() bool {
+	switch e {
+	case InterfaceEndpointVeth, InterfaceEndpointTCFilter, InterfaceEndpointMacvtap, InterfaceEndpointIPVlan:
+		return true
+	}
+	return false
+}
+
+// InterfaceEndpoints forces the endpoint of some pod network interfaces,
+// by interface name, instead of the endpoint detected from the interface
+// type. It lets the dataplane of CNIs the detection does not handle well,
+// such as eBPF based ones, be picked per interface.
+type InterfaceEndpoints map[string]InterfaceEndpoint
+
+// ParseInterfaceEndpoints parses a list of <interface>=<endpoint> entries.
+func ParseInterfaceEndpoints(list []string) (InterfaceEndpoints, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	e := make(InterfaceEndpoints)
+	for _, entry := range list {
+		kv := strings.SplitN(entry, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("Invalid interface endpoint %q, expected <interface>=<endpoint>", entry)
+		}
+
+		name := strings.TrimSpace(kv[0])
+		if _, ok := e[name]; ok {
+			return nil, fmt.Errorf("Interface %q listed twice", name)
+		}
+		e[name] = InterfaceEndpoint(strings.TrimSpace(kv[1]))
+	}
+
+	return e, e.validate()
+}
+
+func (e InterfaceEndpoints) validate() error {
+	for name, endpoint := range e {
+		if name == "" || len(name) > maxInterfaceNameLen || strings.ContainsAny(name, "/,= \t\n") {
+			return newConfigFieldError("InterfaceEndpoints", fmt.Sprintf("Invalid interface name %q", name))
+		}
+		if !endpoint.valid() {
+			return newConfigFieldError("InterfaceEndpoints", fmt.Sprintf("Unknown endpoint %q for interface %q", endpoint, name))
+		}
+	}
+
+	return nil
+}
+
+// createForcedEndpoint creates the endpoint forced for the interface, or
+// returns nil if the endpoint of the interface is not forced.
+func (e InterfaceEndpoints) createForcedEndpoint(netInfo NetworkInfo, idx int, model NetInterworkingModel) (Endpoint, error) {
+	endpoint, ok := e[netInfo.Iface.Name]
+	if !ok {
+		return nil, nil
+	}
+
+	networkLogger().WithFields(logrus.Fields{
+		"interface": netInfo.Iface.Name,
+		"type":      netInfo.Iface.Type,
+		"endpoint":  endpoint,
+	}).Info("Using forced endpoint")
+
+	switch endpoint {
+	case InterfaceEndpointVeth:
+		return createVethNetworkEndpoint(idx, netInfo.Iface.Name, model)
+	case InterfaceEndpointTCFilter:
+		return createVethNetworkEndpoint(idx, netInfo.Iface.Name, NetXConnectTCFilterModel)
+	case InterfaceEndpointMacvtap:
+		return createVethNetworkEndpoint(idx, netInfo.Iface.Name, NetXConnectMacVtapModel)
+	case InterfaceEndpointIPVlan:
+		return createIPVlanNetworkEndpoint(idx, netInfo.Iface.Name)
+	}
+
+	return nil, fmt.Errorf("Unknown endpoint %q for interface %q", endpoint, netInfo.Iface.Name)
+}
+
+func (e InterfaceEndpoints) save() map[string]string {
+	if len(e) == 0 {
+		return nil
+	}
+
+	saved := make(map[string]string, len(e))
+	for name, endpoint := range e {
+		saved[name] = string(endpoint)
+	}
+	return saved
+}
+
+func loadInterfaceEndpoints(saved map[string]string) InterfaceEndpoints {
+	if len(saved) == 0 {
+		return nil
+	}
+
+	e := make(InterfaceEndpoints, len(saved))
+	for name, endpoint := range saved {
+		e[name] = InterfaceEndpoint(endpoint)
+	}
+	return e
+}
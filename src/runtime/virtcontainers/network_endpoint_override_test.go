@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vishvananda/netlink"
+)
+
+func TestParseInterfaceEndpoints(t *testing.T) {
+	assert := assert.New(t)
+
+	e, err := ParseInterfaceEndpoints(nil)
+	assert.NoError(err)
+	assert.Nil(e)
+
+	e, err = ParseInterfaceEndpoints([]string{"eth0=ipvlan", " net1 = macvtap "})
+	assert.NoError(err)
+	assert.Equal(InterfaceEndpoints{"eth0": InterfaceEndpointIPVlan, "net1": InterfaceEndpointMacvtap}, e)
+
+	for _, list := range [][]string{
+		{"eth0"},
+		{"=tcfilter"},
+		{"eth0=bridge"},
+		{"a-very-long-interface=veth"},
+		{"eth0=veth", "eth0=ipvlan"},
+	} {
+		_, err := ParseInterfaceEndpoints(list)
+		assert.Error(err, "%v", list)
+	}
+}
+
+func TestCreateForcedEndpoint(t *testing.T) {
+	assert := assert.New(t)
+
+	forced := InterfaceEndpoints{
+		"eth0": InterfaceEndpointVeth,
+		"eth1": InterfaceEndpointTCFilter,
+		"eth2": InterfaceEndpointMacvtap,
+		"eth3": InterfaceEndpointIPVlan,
+	}
+
+	netInfo := func(name string) NetworkInfo {
+		return NetworkInfo{Iface: NetlinkIface{LinkAttrs: netlink.LinkAttrs{Name: name}, Type: "veth"}}
+	}
+
+	endpoint, err := forced.createForcedEndpoint(netInfo("net1"), 0, NetXConnectMacVtapModel)
+	assert.NoError(err)
+	assert.Nil(endpoint)
+
+	endpoint, err = forced.createForcedEndpoint(netInfo("eth0"), 0, NetXConnectMacVtapModel)
+	assert.NoError(err)
+	assert.Equal(VethEndpointType, endpoint.Type())
+	assert.Equal(NetXConnectMacVtapModel, endpoint.NetworkPair().NetInterworkingModel)
+
+	endpoint, err = forced.createForcedEndpoint(netInfo("eth1"), 1, NetXConnectMacVtapModel)
+	assert.NoError(err)
+	assert.Equal(VethEndpointType, endpoint.Type())
+	assert.Equal(NetXConnectTCFilterModel, endpoint.NetworkPair().NetInterworkingModel)
+
+	endpoint, err = forced.createForcedEndpoint(netInfo("eth2"), 2, NetXConnectTCFilterModel)
+	assert.NoError(err)
+	assert.Equal(VethEndpointType, endpoint.Type())
+	assert.Equal(NetXConnectMacVtapModel, endpoint.NetworkPair().NetInterworkingModel)
+
+	endpoint, err = forced.createForcedEndpoint(netInfo("eth3"), 3, NetXConnectMacVtapModel)
+	assert.NoError(err)
+	assert.Equal(IPVlanEndpointType, endpoint.Type())
+	assert.Equal("eth3", endpoint.Name())
+}
+
+func TestInterfaceEndpointsSaveLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(InterfaceEndpoints{}.save())
+	assert.Nil(loadInterfaceEndpoints(nil))
+
+	e := InterfaceEndpoints{"eth0": InterfaceEndpointTCFilter}
+	assert.Equal(map[string]string{"eth0": "tcfilter"}, e.save())
+	assert.Equal(e, loadInterfaceEndpoints(e.save()))
+}
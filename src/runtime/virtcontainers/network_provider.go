@@ -0,0 +1,391 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+// networkProviderTimeout bounds each call to an external network provider
+// binary.
+const networkProviderTimeout = 30 * time.Second
+
+// NetworkProvider sets up the datapath of the network interfaces of the
+// sandboxes, instead of the runtime connecting the VM to the interfaces it
+// finds in the network namespace. It lets SDN datapaths be integrated
+// without new endpoint implementations: the VM is connected to the
+// interfaces the provider returns with the in-tree endpoints.
+type NetworkProvider interface {
+	// Setup prepares the datapath of the interfaces of a sandbox, before
+	// its VM is connected to them, and returns them.
+	Setup(ctx context.Context, sandboxID, netNSPath string) ([]NetworkProviderInterface, error)
+
+	// Teardown releases the datapath of a sandbox, once its VM is
+	// disconnected from it. A network namespace the runtime created is
+	// deleted already.
+	Teardown(ctx context.Context, sandboxID, netNSPath string) error
+
+	// Hotplug prepares the datapath of an interface hot added to a
+	// sandbox. The guest gets the name, MAC address and IP addresses of
+	// inf, only the datapath of the returned interface is used.
+	Hotplug(ctx context.Context, sandboxID, netNSPath string, inf *vcTypes.Interface) (NetworkProviderInterface, error)
+
+	// Hotunplug releases the datapath of an interface hot removed from a
+	// sandbox, once its VM is disconnected from it.
+	Hotunplug(ctx context.Context, sandboxID, netNSPath, name string) error
+
+	// Stats returns the statistics of the interfaces of a sandbox, as
+	// the datapath counts them.
+	Stats(ctx context.Context, sandboxID string) ([]NetworkInterfaceStats, error)
+}
+
+// NetworkProviderInterface is a network interface of a sandbox, set up by
+// a network provider. Its datapath is either a vhost-user backend, or an
+// interface of the network namespace the VM is connected to as to the
+// interfaces the runtime finds there.
+type NetworkProviderInterface struct {
+	// Name is the name of the interface in the guest.
+	Name string `json:"name"`
+
+	// HardwareAddr is the MAC address of the interface in the guest.
+	HardwareAddr string `json:"mac,omitempty"`
+
+	// MTU is the MTU of the interface in the guest.
+	MTU int `json:"mtu,omitempty"`
+
+	// IPAddresses are the CIDR addresses of the interface in the guest.
+	IPAddresses []string `json:"ips,omitempty"`
+
+	// Routes are the guest routes through the interface.
+	Routes []NetworkProviderRoute `json:"routes,omitempty"`
+
+	// VhostUserSocket is the socket of the vhost-user backend of the
+	// interface.
+	VhostUserSocket string `json:"vhost_user_socket,omitempty"`
+
+	// Link is the interface of the network namespace the VM is connected
+	// to, when there is no vhost-user backend. The guest interface is
+	// named after it, and gets its MAC address, MTU, IP addresses and
+	// routes unless they are set.
+	Link string `json:"link,omitempty"`
+}
+
+// NetworkProviderRoute is a guest route through a network provider
+// interface.
+type NetworkProviderRoute struct {
+	// Dest is the CIDR destination of the route, the default route when
+	// empty.
+	Dest string `json:"dst,omitempty"`
+
+	// Gateway is the gateway of the route, the destination being on link
+	// when empty.
+	Gateway string `json:"gw,omitempty"`
+}
+
+// NetworkInterfaceStats are the statistics of a network interface of a
+// sandbox.
+type NetworkInterfaceStats struct {
+	Name      string `json:"name"`
+	RxBytes   uint64 `json:"rx_bytes"`
+	RxPackets uint64 `json:"rx_packets"`
+	RxErrors  uint64 `json:"rx_errors"`
+	RxDropped uint64 `json:"rx_dropped"`
+	TxBytes   uint64 `json:"tx_bytes"`
+	TxPackets uint64 `json:"tx_packets"`
+	TxErrors  uint64 `json:"tx_errors"`
+	TxDropped uint64 `json:"tx_dropped"`
+}
+
+var (
+	networkProviders     = map[string]NetworkProvider{}
+	networkProvidersLock sync.RWMutex
+)
+
+// RegisterNetworkProvider makes a network provider selectable by name in
+// the Provider of the sandbox network configuration. The providers register
+// themselves from the init function of their package, built into the
+// runtime.
+func RegisterNetworkProvider(name string, provider NetworkProvider) {
+	if name == "" || filepath.IsAbs(name) || provider == nil {
+		panic("invalid network provider registration")
+	}
+
+	networkProvidersLock.Lock()
+	defer networkProvidersLock.Unlock()
+
+	if _, ok := networkProviders[name]; ok {
+		panic(fmt.Sprintf("network provider %q registered twice", name))
+	}
+	networkProviders[name] = provider
+}
+
+// NetworkProviderNames returns the names of the network providers the
+// runtime was built with.
+func NetworkProviderNames() []string {
+	networkProvidersLock.RLock()
+	defer networkProvidersLock.RUnlock()
+
+	names := make([]string, 0, len(networkProviders))
+	for name := range networkProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// newNetworkProvider returns the network provider selected by name: a
+// provider built into the runtime, or an external binary when name is an
+// absolute path. There is no provider when name is empty.
+func newNetworkProvider(name string) (NetworkProvider, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	if filepath.IsAbs(name) {
+		return &execNetworkProvider{path: name}, nil
+	}
+
+	networkProvidersLock.RLock()
+	defer networkProvidersLock.RUnlock()
+
+	provider, ok := networkProviders[name]
+	if !ok {
+		return nil, fmt.Errorf("Unknown network provider %q, the runtime is built with %v", name, NetworkProviderNames())
+	}
+	return provider, nil
+}
+
+// checkNetworkProvider checks that the network provider is known. The
+// binary of an external provider is only looked for when it is called.
+func checkNetworkProvider(name string) error {
+	_, err := newNetworkProvider(name)
+	return err
+}
+
+// execNetworkProvider is a network provider binary. It is run with the
+// operation as argument, "setup", "teardown", "hotplug", "hotunplug" or
+// "stats", gets an execNetworkProviderRequest on its standard input, and
+// writes the JSON result of the operation, if any, on its standard output.
+type execNetworkProvider struct {
+	path string
+}
+
+type execNetworkProviderRequest struct {
+	SandboxID string             `json:"sandbox_id"`
+	NetNSPath string             `json:"netns,omitempty"`
+	Interface *vcTypes.Interface `json:"interface,omitempty"`
+	Name      string             `json:"name,omitempty"`
+}
+
+func (p *execNetworkProvider) run(ctx context.Context, op string, req execNetworkProviderRequest, result interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, networkProviderTimeout)
+	defer cancel()
+
+	input, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.path, op)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("Network provider %s %s failed: %v: %s", p.path, op, err, strings.TrimSpace(stderr.String()))
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(stdout.Bytes(), result); err != nil {
+		return fmt.Errorf("Invalid result of network provider %s %s: %v", p.path, op, err)
+	}
+	return nil
+}
+
+func (p *execNetworkProvider) Setup(ctx context.Context, sandboxID, netNSPath string) ([]NetworkProviderInterface, error) {
+	var interfaces []NetworkProviderInterface
+	err := p.run(ctx, "setup", execNetworkProviderRequest{SandboxID: sandboxID, NetNSPath: netNSPath}, &interfaces)
+	return interfaces, err
+}
+
+func (p *execNetworkProvider) Teardown(ctx context.Context, sandboxID, netNSPath string) error {
+	return p.run(ctx, "teardown", execNetworkProviderRequest{SandboxID: sandboxID, NetNSPath: netNSPath}, nil)
+}
+
+func (p *execNetworkProvider) Hotplug(ctx context.Context, sandboxID, netNSPath string, inf *vcTypes.Interface) (NetworkProviderInterface, error) {
+	var iface NetworkProviderInterface
+	err := p.run(ctx, "hotplug", execNetworkProviderRequest{SandboxID: sandboxID, NetNSPath: netNSPath, Interface: inf}, &iface)
+	return iface, err
+}
+
+func (p *execNetworkProvider) Hotunplug(ctx context.Context, sandboxID, netNSPath, name string) error {
+	return p.run(ctx, "hotunplug", execNetworkProviderRequest{SandboxID: sandboxID, NetNSPath: netNSPath, Name: name}, nil)
+}
+
+func (p *execNetworkProvider) Stats(ctx context.Context, sandboxID string) ([]NetworkInterfaceStats, error) {
+	var stats []NetworkInterfaceStats
+	err := p.run(ctx, "stats", execNetworkProviderRequest{SandboxID: sandboxID}, &stats)
+	return stats, err
+}
+
+// netInfo returns the guest description of the interface, over the one of
+// its link, if any.
+func (pi NetworkProviderInterface) netInfo(netInfo NetworkInfo) (NetworkInfo, error) {
+	if pi.Name != "" && pi.Link == "" {
+		netInfo.Iface.Name = pi.Name
+	}
+
+	if pi.HardwareAddr != "" {
+		hw, err := net.ParseMAC(pi.HardwareAddr)
+		if err != nil {
+			return NetworkInfo{}, err
+		}
+		netInfo.Iface.HardwareAddr = hw
+	}
+
+	if pi.MTU != 0 {
+		netInfo.Iface.MTU = pi.MTU
+	}
+
+	if len(pi.IPAddresses) > 0 {
+		netInfo.Addrs = nil
+		for _, a := range pi.IPAddresses {
+			addr, err := netlink.ParseAddr(a)
+			if err != nil {
+				return NetworkInfo{}, fmt.Errorf("could not parse %q: %v", a, err)
+			}
+			netInfo.Addrs = append(netInfo.Addrs, *addr)
+		}
+	}
+
+	if len(pi.Routes) > 0 {
+		netInfo.Routes = nil
+		for _, r := range pi.Routes {
+			route := netlink.Route{Scope: netlink.SCOPE_LINK}
+			if r.Dest != "" {
+				_, dst, err := net.ParseCIDR(r.Dest)
+				if err != nil {
+					return NetworkInfo{}, err
+				}
+				route.Dst = dst
+			}
+			if r.Gateway != "" {
+				if route.Gw = net.ParseIP(r.Gateway); route.Gw == nil {
+					return NetworkInfo{}, fmt.Errorf("Invalid gateway %q", r.Gateway)
+				}
+				route.Scope = netlink.SCOPE_UNIVERSE
+			}
+			netInfo.Routes = append(netInfo.Routes, route)
+		}
+	}
+
+	return netInfo, nil
+}
+
+// createProviderEndpoint creates the endpoint connecting the VM to the
+// datapath of a network provider interface, netInfo being its guest
+// description.
+func createProviderEndpoint(pi NetworkProviderInterface, netInfo NetworkInfo, idx int, config *NetworkConfig) (Endpoint, error) {
+	var (
+		endpoint Endpoint
+		err      error
+	)
+
+	switch {
+	case pi.VhostUserSocket != "":
+		endpoint, err = createVhostUserEndpoint(netInfo, pi.VhostUserSocket)
+	case pi.Link != "":
+		err = doNetNS(config.NetNSPath, func(_ ns.NetNS) error {
+			link, err := netlink.LinkByName(pi.Link)
+			if err != nil {
+				return fmt.Errorf("Could not find network provider link %s: %v", pi.Link, err)
+			}
+			endpoint, err = createEndpoint(netInfo, idx, config.InterworkingModel, config.InterfaceEndpoints, link)
+			return err
+		})
+	default:
+		err = fmt.Errorf("Network provider interface %q has neither a vhost-user socket nor a link", pi.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint.SetProperties(netInfo)
+	return endpoint, nil
+}
+
+// createEndpointsFromProvider sets up the network of a sandbox with its
+// network provider, and creates the endpoints connecting the VM to it.
+func createEndpointsFromProvider(ctx context.Context, provider NetworkProvider, sandboxID string, config *NetworkConfig) ([]Endpoint, error) {
+	interfaces, err := provider.Setup(ctx, sandboxID, config.NetNSPath)
+	if err != nil {
+		return []Endpoint{}, err
+	}
+
+	var handle *netlink.Handle
+	for _, pi := range interfaces {
+		if pi.Link != "" {
+			netnsHandle, err := netns.GetFromPath(config.NetNSPath)
+			if err != nil {
+				return []Endpoint{}, err
+			}
+			defer netnsHandle.Close()
+
+			if handle, err = netlink.NewHandleAt(netnsHandle); err != nil {
+				return []Endpoint{}, err
+			}
+			defer handle.Delete()
+			break
+		}
+	}
+
+	var endpoints []Endpoint
+	for idx, pi := range interfaces {
+		var netInfo NetworkInfo
+		if pi.Link != "" {
+			link, err := handle.LinkByName(pi.Link)
+			if err != nil {
+				return []Endpoint{}, fmt.Errorf("Could not find network provider link %s: %v", pi.Link, err)
+			}
+			if netInfo, err = networkInfoFromLink(handle, link); err != nil {
+				return []Endpoint{}, err
+			}
+		}
+
+		if netInfo, err = pi.netInfo(netInfo); err != nil {
+			return []Endpoint{}, fmt.Errorf("Invalid network provider interface %q: %v", pi.Name, err)
+		}
+
+		endpoint, err := createProviderEndpoint(pi, netInfo, idx, config)
+		if err != nil {
+			return []Endpoint{}, err
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	networkLogger().WithField("endpoints", endpoints).Info("Endpoints set up by the network provider")
+
+	return endpoints, nil
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/vishvananda/netlink"
+)
+
+const testNetworkProviderScript = `#!/bin/sh
+request=$(cat)
+case "$1" in
+setup)
+	echo '[{"name": "eth0", "mac": "02:00:ca:fe:00:01", "mtu": 1400, "ips": ["10.0.0.2/24"], "routes": [{"gw": "10.0.0.1"}], "vhost_user_socket": "/run/sdn/eth0.sock"}]'
+	;;
+stats)
+	echo '[{"name": "eth0", "rx_bytes": 42}]'
+	;;
+teardown)
+	echo "$request" | grep -q '"sandbox_id":"sandbox"'
+	;;
+*)
+	echo "unsupported operation $1" >&2
+	exit 1
+	;;
+esac
+`
+
+type testNetworkProvider struct{}
+
+func (p *testNetworkProvider) Setup(ctx context.Context, sandboxID, netNSPath string) ([]NetworkProviderInterface, error) {
+	return nil, nil
+}
+
+func (p *testNetworkProvider) Teardown(ctx context.Context, sandboxID, netNSPath string) error {
+	return nil
+}
+
+func (p *testNetworkProvider) Hotplug(ctx context.Context, sandboxID, netNSPath string, inf *vcTypes.Interface) (NetworkProviderInterface, error) {
+	return NetworkProviderInterface{}, nil
+}
+
+func (p *testNetworkProvider) Hotunplug(ctx context.Context, sandboxID, netNSPath, name string) error {
+	return nil
+}
+
+func (p *testNetworkProvider) Stats(ctx context.Context, sandboxID string) ([]NetworkInterfaceStats, error) {
+	return nil, nil
+}
+
+func TestRegisterNetworkProvider(t *testing.T) {
+	assert := assert.New(t)
+
+	p := &testNetworkProvider{}
+	RegisterNetworkProvider("test", p)
+	defer func() {
+		networkProvidersLock.Lock()
+		delete(networkProviders, "test")
+		networkProvidersLock.Unlock()
+	}()
+
+	assert.Panics(func() { RegisterNetworkProvider("test", p) })
+	assert.Panics(func() { RegisterNetworkProvider("", p) })
+	assert.Panics(func() { RegisterNetworkProvider("/usr/bin/test", p) })
+	assert.Panics(func() { RegisterNetworkProvider("other", nil) })
+
+	assert.Contains(NetworkProviderNames(), "test")
+
+	provider, err := newNetworkProvider("test")
+	assert.NoError(err)
+	assert.Equal(p, provider)
+
+	provider, err = newNetworkProvider("")
+	assert.NoError(err)
+	assert.Nil(provider)
+
+	provider, err = newNetworkProvider("/usr/libexec/network-provider")
+	assert.NoError(err)
+	assert.Equal(&execNetworkProvider{path: "/usr/libexec/network-provider"}, provider)
+
+	assert.NoError(checkNetworkProvider("test"))
+	assert.Error(checkNetworkProvider("unknown"))
+}
+
+func TestNetworkProviderInterfaceNetInfo(t *testing.T) {
+	assert := assert.New(t)
+
+	pi := NetworkProviderInterface{
+		Name:         "eth0",
+		HardwareAddr: "02:00:ca:fe:00:01",
+		MTU:          1400,
+		IPAddresses:  []string{"10.0.0.2/24", "fd00::2/64"},
+		Routes: []NetworkProviderRoute{
+			{Gateway: "10.0.0.1"},
+			{Dest: "192.168.0.0/16"},
+		},
+	}
+
+	netInfo, err := pi.netInfo(NetworkInfo{})
+	assert.NoError(err)
+	assert.Equal("eth0", netInfo.Iface.Name)
+	assert.Equal("02:00:ca:fe:00:01", netInfo.Iface.HardwareAddr.String())
+	assert.Equal(1400, netInfo.Iface.MTU)
+	assert.Len(netInfo.Addrs, 2)
+	assert.Len(netInfo.Routes, 2)
+	assert.Nil(netInfo.Routes[0].Dst)
+	assert.Equal(netlink.SCOPE_UNIVERSE, netInfo.Routes[0].Scope)
+	assert.Equal("192.168.0.0/16", netInfo.Routes[1].Dst.String())
+	assert.Equal(netlink.SCOPE_LINK, netInfo.Routes[1].Scope)
+
+	// The guest interface of a link is named after it.
+	linkInfo := NetworkInfo{Iface: NetlinkIface{LinkAttrs: netlink.LinkAttrs{Name: "sdn0", MTU: 1500}}}
+	netInfo, err = NetworkProviderInterface{Name: "eth0", Link: "sdn0"}.netInfo(linkInfo)
+	assert.NoError(err)
+	assert.Equal("sdn0", netInfo.Iface.Name)
+	assert.Equal(1500, netInfo.Iface.MTU)
+
+	for _, pi := range []NetworkProviderInterface{
+		{HardwareAddr: "invalid"},
+		{IPAddresses: []string{"10.0.0.2"}},
+		{Routes: []NetworkProviderRoute{{Gateway: "invalid"}}},
+	} {
+		_, err := pi.netInfo(NetworkInfo{})
+		assert.Error(err, "%+v", pi)
+	}
+}
+
+func TestExecNetworkProvider(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "network-provider")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, "provider")
+	assert.NoError(ioutil.WriteFile(path, []byte(testNetworkProviderScript), 0755))
+
+	provider, err := newNetworkProvider(path)
+	assert.NoError(err)
+
+	ctx := context.Background()
+	config := &NetworkConfig{NetNSPath: "/var/run/netns/test"}
+
+	endpoints, err := createEndpointsFromProvider(ctx, provider, "sandbox", config)
+	assert.NoError(err)
+	assert.Len(endpoints, 1)
+	assert.Equal(VhostUserEndpointType, endpoints[0].Type())
+	assert.Equal("eth0", endpoints[0].Name())
+	assert.Equal("02:00:ca:fe:00:01", endpoints[0].HardwareAddr())
+	assert.Equal("/run/sdn/eth0.sock", endpoints[0].(*VhostUserEndpoint).SocketPath)
+	assert.Len(endpoints[0].Properties().Routes, 1)
+
+	stats, err := provider.Stats(ctx, "sandbox")
+	assert.NoError(err)
+	assert.Equal([]NetworkInterfaceStats{{Name: "eth0", RxBytes: 42}}, stats)
+
+	assert.NoError(provider.Teardown(ctx, "sandbox", config.NetNSPath))
+	assert.Error(provider.Teardown(ctx, "other", config.NetNSPath))
+
+	err = provider.Hotunplug(ctx, "sandbox", config.NetNSPath, "eth0")
+	assert.Error(err)
+	assert.Contains(err.Error(), "unsupported operation hotunplug")
+
+	_, err = newNetworkProvider(filepath.Join(tmpDir, "missing"))
+	assert.NoError(err)
+	_, err = (&execNetworkProvider{path: filepath.Join(tmpDir, "missing")}).Setup(ctx, "sandbox", config.NetNSPath)
+	assert.Error(err)
+}
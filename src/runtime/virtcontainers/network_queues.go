@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+)
+
+const (
+	// defaultNetworkMaxQueues caps the queue pairs of the network devices
+	// sized from the vCPUs.
+	defaultNetworkMaxQueues = 8
+
+	// maxNetworkQueues is the most queues a tap device can have.
+	maxNetworkQueues = 256
+)
+
+// networkQueues returns the queue pairs of the multi-queue network devices
+// of the VM.
+//
+// Unless NetworkQueues fixes them, the devices get a queue pair for each
+// vCPU the VM can have, up to NetworkMaxQueues. The guest only uses as many
+// of them as it has online vCPUs, and the agent enables more when vCPUs
+// are hotplugged, so that the network throughput follows the vCPUs of the
+// sandbox.
+func networkQueues(conf HypervisorConfig) int {
+	if conf.NetworkQueues > 0 {
+		return int(conf.NetworkQueues)
+	}
+
+	maxQueues := conf.NetworkMaxQueues
+	if maxQueues == 0 {
+		maxQueues = defaultNetworkMaxQueues
+	}
+
+	queues := conf.DefaultMaxVCPUs
+	if queues < conf.NumVCPUs {
+		queues = conf.NumVCPUs
+	}
+	if queues > maxQueues {
+		queues = maxQueues
+	}
+	if queues == 0 {
+		queues = 1
+	}
+
+	return int(queues)
+}
+
+func validateNetworkQueues(conf *HypervisorConfig) error {
+	if conf.NetworkQueues > maxNetworkQueues {
+		return newConfigFieldError("NetworkQueues", fmt.Sprintf("At most %d network queues are supported", maxNetworkQueues))
+	}
+
+	if conf.NetworkMaxQueues > maxNetworkQueues {
+		return newConfigFieldError("NetworkMaxQueues", fmt.Sprintf("At most %d network queues are supported", maxNetworkQueues))
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkQueues(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, d := range []struct {
+		conf   HypervisorConfig
+		queues int
+	}{
+		{HypervisorConfig{}, 1},
+		{HypervisorConfig{NumVCPUs: 2}, 2},
+		{HypervisorConfig{NumVCPUs: 1, DefaultMaxVCPUs: 4}, 4},
+		{HypervisorConfig{NumVCPUs: 2, DefaultMaxVCPUs: 32}, defaultNetworkMaxQueues},
+		{HypervisorConfig{NumVCPUs: 2, DefaultMaxVCPUs: 32, NetworkMaxQueues: 16}, 16},
+		{HypervisorConfig{NumVCPUs: 2, DefaultMaxVCPUs: 32, NetworkQueues: 3}, 3},
+	} {
+		assert.Equal(d.queues, networkQueues(d.conf), "%+v", d.conf)
+	}
+}
+
+func TestValidateNetworkQueues(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(validateNetworkQueues(&HypervisorConfig{NetworkQueues: maxNetworkQueues}))
+	assert.Error(validateNetworkQueues(&HypervisorConfig{NetworkQueues: maxNetworkQueues + 1}))
+	assert.Error(validateNetworkQueues(&HypervisorConfig{NetworkMaxQueues: maxNetworkQueues + 1}))
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// nodeLedgerDir is the directory, next to the sandbox directories, holding
+// a claim file per sandbox of the host.
+const nodeLedgerDir = "node-ledger"
+
+// nodeLedgerLock is the file of the ledger directory locked while a claim
+// is checked against the claims of the other sandboxes.
+const nodeLedgerLock = ".lock"
+
+// NodeLimits are upper bounds on the resources all the sandboxes of the
+// host claim together, whichever runtime instance or shim runs them. Each
+// sandbox records its claim in a ledger the runtime instances of the host
+// share, and creating or growing a sandbox beyond the limits fails. They
+// prevent the churn of pods from exhausting the host.
+//
+// A zero value means no limit.
+type NodeLimits struct {
+	// MaxMemoryMB is the maximum memory, in MiB, of all the VMs,
+	// hotplugged memory included.
+	MaxMemoryMB uint64
+
+	// MaxHotplugDevices is the maximum number of devices hotplugged into
+	// all the VMs at the same time.
+	MaxHotplugDevices uint32
+
+	// MaxSandboxes is the maximum number of sandboxes.
+	MaxSandboxes uint32
+}
+
+func (l NodeLimits) enabled() bool {
+	return l.MaxMemoryMB > 0 || l.MaxHotplugDevices > 0 || l.MaxSandboxes > 0
+}
+
+// nodeClaim is the claim of a sandbox on the host resources.
+type nodeClaim struct {
+	// Pid is the runtime process which last updated the claim.
+	Pid int `json:"pid"`
+
+	MemoryMB       uint32 `json:"memory_mb"`
+	HotplugDevices uint32 `json:"hotplug_devices"`
+}
+
+// nodeLedger holds the claims of the sandboxes of the host, each one a file
+// named after the sandbox.
+type nodeLedger struct {
+	dir string
+
+	// sandboxesDir holds the sandbox directories, to reclaim the claims of
+	// the sandboxes which are gone.
+	sandboxesDir string
+}
+
+func (s *Sandbox) nodeLedger() *nodeLedger {
+	sandboxesDir := s.newStore.RunStoragePath()
+	return &nodeLedger{
+		dir:          filepath.Join(filepath.Dir(sandboxesDir), nodeLedgerDir),
+		sandboxesDir: sandboxesDir,
+	}
+}
+
+// lock takes the lock of the ledger, and returns the function releasing
+// it.
+func (l *nodeLedger) lock() (func(), error) {
+	if err := os.MkdirAll(l.dir, DirMode); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(filepath.Join(l.dir, nodeLedgerLock), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_EX); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("Could not lock the node ledger: %v", err)
+	}
+
+	return func() {
+		unix.Flock(int(f.Fd()), unix.LOCK_UN)
+		f.Close()
+	}, nil
+}
+
+// stale tells if the claim of a sandbox is left by a runtime which was
+// killed: the sandbox was never stored, or is gone, and the process which
+// claimed the resources for it is gone too.
+func (l *nodeLedger) stale(sandboxID string, c nodeClaim) bool {
+	if _, err := os.Stat(filepath.Join(l.sandboxesDir, sandboxID)); err == nil {
+		return false
+	}
+
+	return c.Pid <= 0 || syscall.Kill(c.Pid, 0) == syscall.ESRCH
+}
+
+// claims returns the claims of the sandboxes, reclaiming the stale ones.
+// The ledger must be locked.
+func (l *nodeLedger) claims() (map[string]nodeClaim, error) {
+	files, err := ioutil.ReadDir(l.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := make(map[string]nodeClaim)
+	for _, f := range files {
+		if strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(l.dir, f.Name())
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var c nodeClaim
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("Invalid node ledger claim %s: %v", path, err)
+		}
+
+		if l.stale(f.Name(), c) {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+			continue
+		}
+
+		claims[f.Name()] = c
+	}
+
+	return claims, nil
+}
+
+// claim updates the claim of a sandbox with update, unless the claims of
+// all the sandboxes would then exceed the limits. A claim going down is
+// always recorded.
+func (l *nodeLedger) claim(limits NodeLimits, sandboxID string, update func(c *nodeClaim)) error {
+	unlock, err := l.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	claims, err := l.claims()
+	if err != nil {
+		return err
+	}
+
+	old, exists := claims[sandboxID]
+	c := old
+	update(&c)
+	c.Pid = os.Getpid()
+
+	var memoryMB uint64
+	var devices uint32
+	for id, other := range claims {
+		if id != sandboxID {
+			memoryMB += uint64(other.MemoryMB)
+			devices += other.HotplugDevices
+		}
+	}
+
+	if !exists && limits.MaxSandboxes > 0 && uint32(len(claims)) >= limits.MaxSandboxes {
+		return fmt.Errorf("The host runs %d sandboxes already, the node limit", len(claims))
+	}
+
+	if c.MemoryMB > old.MemoryMB && limits.MaxMemoryMB > 0 && memoryMB+uint64(c.MemoryMB) > limits.MaxMemoryMB {
+		return fmt.Errorf("%d MiB of memory for the sandbox would exceed the %d MiB node limit, %d MiB being used by the other sandboxes", c.MemoryMB, limits.MaxMemoryMB, memoryMB)
+	}
+
+	if c.HotplugDevices > old.HotplugDevices && limits.MaxHotplugDevices > 0 && devices+c.HotplugDevices > limits.MaxHotplugDevices {
+		return fmt.Errorf("%d hotplugged devices for the sandbox would exceed the %d devices node limit, %d being hotplugged into the other sandboxes", c.HotplugDevices, limits.MaxHotplugDevices, devices)
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	// The claim is replaced at once, for a killed runtime not to leave a
+	// partial one.
+	tmp := filepath.Join(l.dir, "."+sandboxID)
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, filepath.Join(l.dir, sandboxID))
+}
+
+// release removes the claim of a sandbox.
+func (l *nodeLedger) release(sandboxID string) error {
+	unlock, err := l.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := os.Remove(filepath.Join(l.dir, sandboxID)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// claimNodeResources records in the node ledger the memory and the devices
+// hotplugged the sandbox claims, as update sets them, if the host has node
+// limits.
+func (s *Sandbox) claimNodeResources(update func(c *nodeClaim)) error {
+	if !s.config.NodeLimits.enabled() {
+		return nil
+	}
+
+	return s.nodeLedger().claim(s.config.NodeLimits, s.id, update)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestNodeLedger(t *testing.T) (*nodeLedger, func()) {
+	dir, err := ioutil.TempDir("", "node-ledger")
+	assert.NoError(t, err)
+
+	l := &nodeLedger{
+		dir:          filepath.Join(dir, nodeLedgerDir),
+		sandboxesDir: filepath.Join(dir, "sbs"),
+	}
+
+	return l, func() { os.RemoveAll(dir) }
+}
+
+func setMemory(memoryMB uint32) func(c *nodeClaim) {
+	return func(c *nodeClaim) { c.MemoryMB = memoryMB }
+}
+
+func setDevices(devices uint32) func(c *nodeClaim) {
+	return func(c *nodeClaim) { c.HotplugDevices = devices }
+}
+
+func TestNodeLimitsEnabled(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(NodeLimits{}.enabled())
+	assert.True(NodeLimits{MaxMemoryMB: 1024}.enabled())
+	assert.True(NodeLimits{MaxHotplugDevices: 4}.enabled())
+	assert.True(NodeLimits{MaxSandboxes: 2}.enabled())
+}
+
+func TestNodeLedgerClaim(t *testing.T) {
+	assert := assert.New(t)
+
+	l, cleanup := newTestNodeLedger(t)
+	defer cleanup()
+
+	limits := NodeLimits{MaxMemoryMB: 4096, MaxHotplugDevices: 2, MaxSandboxes: 2}
+
+	assert.NoError(l.claim(limits, "sb1", setMemory(2048)))
+	assert.NoError(l.claim(limits, "sb2", setMemory(1024)))
+
+	// Too many sandboxes
+	assert.Error(l.claim(limits, "sb3", setMemory(128)))
+
+	// Too much memory
+	assert.Error(l.claim(limits, "sb2", setMemory(3072)))
+	assert.NoError(l.claim(limits, "sb2", setMemory(2048)))
+
+	// Too many devices
+	assert.NoError(l.claim(limits, "sb1", setDevices(1)))
+	assert.NoError(l.claim(limits, "sb2", setDevices(1)))
+	assert.Error(l.claim(limits, "sb2", setDevices(2)))
+
+	claims, err := l.claims()
+	assert.NoError(err)
+	assert.Equal(uint32(2048), claims["sb1"].MemoryMB)
+	assert.Equal(uint32(1), claims["sb1"].HotplugDevices)
+	assert.Equal(uint32(2048), claims["sb2"].MemoryMB)
+	assert.Equal(os.Getpid(), claims["sb2"].Pid)
+
+	// Lowered limits do not prevent claims going down
+	limits.MaxMemoryMB = 1024
+	assert.NoError(l.claim(limits, "sb2", setMemory(1536)))
+
+	assert.NoError(l.release("sb2"))
+	assert.NoError(l.release("sb2"))
+	assert.NoError(l.claim(limits, "sb3", setMemory(0)))
+}
+
+func TestNodeLedgerStaleClaims(t *testing.T) {
+	assert := assert.New(t)
+
+	l, cleanup := newTestNodeLedger(t)
+	defer cleanup()
+
+	limits := NodeLimits{MaxSandboxes: 1}
+
+	assert.NoError(os.MkdirAll(l.dir, DirMode))
+	data, err := json.Marshal(nodeClaim{MemoryMB: 1024})
+	assert.NoError(err)
+	assert.NoError(ioutil.WriteFile(filepath.Join(l.dir, "sb1"), data, 0600))
+
+	// The sandbox is stored
+	assert.NoError(os.MkdirAll(filepath.Join(l.sandboxesDir, "sb1"), DirMode))
+	assert.Error(l.claim(limits, "sb2", setMemory(1024)))
+
+	// The sandbox is gone, and so is the runtime which claimed resources
+	// for it
+	assert.NoError(os.RemoveAll(filepath.Join(l.sandboxesDir, "sb1")))
+	assert.NoError(l.claim(limits, "sb2", setMemory(1024)))
+
+	claims, err := l.claims()
+	assert.NoError(err)
+	assert.Len(claims, 1)
+	assert.Contains(claims, "sb2")
+}
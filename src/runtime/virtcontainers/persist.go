@@ -32,9 +32,24 @@ func (s *Sandbox) dumpState(ss *persistapi.SandboxState, cs map[string]persistap
 	ss.SandboxContainer = s.id
 	ss.GuestMemoryBlockSizeMB = s.state.GuestMemoryBlockSizeMB
 	ss.GuestMemoryHotplugProbe = s.state.GuestMemoryHotplugProbe
+	ss.GuestSeccompSupported = s.state.GuestSeccompSupported
 	ss.State = string(s.state.State)
 	ss.CgroupPath = s.state.CgroupPath
 	ss.CgroupPaths = s.state.CgroupPaths
+	ss.HypervisorVersion = s.state.HypervisorVersion
+	ss.HypervisorConfigDigest = s.state.HypervisorConfigDigest
+	ss.Resize = persistapi.ResizeState{
+		VCPUs:      s.resize.vcpus,
+		MemoryMB:   s.resize.memoryMB,
+		LastResize: s.resize.lastResize,
+	}
+	ss.HotplugPlan = persistapi.HotplugPlan{
+		VCPUs:       s.hotplugPlan.VCPUs,
+		MaxVCPUs:    s.hotplugPlan.MaxVCPUs,
+		MemorySlots: s.hotplugPlan.MemorySlots,
+		Devices:     s.hotplugPlan.Devices,
+		Bridges:     s.hotplugPlan.Bridges,
+	}
 
 	for id, cont := range s.containers {
 		state := persistapi.ContainerState{}
@@ -43,8 +58,9 @@ func (s *Sandbox) dumpState(ss *persistapi.SandboxState, cs map[string]persistap
 		}
 		state.State = string(cont.state.State)
 		state.Rootfs = persistapi.RootfsState{
-			BlockDeviceID: cont.state.BlockDeviceID,
-			FsType:        cont.state.Fstype,
+			BlockDeviceID:   cont.state.BlockDeviceID,
+			FsType:          cont.state.Fstype,
+			ScratchDeviceID: cont.state.ScratchDeviceID,
 		}
 		state.CgroupPath = cont.state.CgroupPath
 		cs[id] = state
@@ -60,6 +76,9 @@ func (s *Sandbox) dumpState(ss *persistapi.SandboxState, cs map[string]persistap
 
 func (s *Sandbox) dumpHypervisor(ss *persistapi.SandboxState) {
 	ss.HypervisorState = s.hypervisor.save()
+	if pid := ss.HypervisorState.Pid; pid > 0 {
+		ss.HypervisorState.PidStartTime, _ = processStartTime(pid)
+	}
 	// BlockIndexMap will be moved from sandbox state to hypervisor state later
 	ss.HypervisorState.BlockIndexMap = s.state.BlockIndexMap
 }
@@ -186,14 +205,69 @@ func (s *Sandbox) dumpConfig(ss *persistapi.SandboxState) {
 			NetNsCreated:      sconfig.NetworkConfig.NetNsCreated,
 			DisableNewNetNs:   sconfig.NetworkConfig.DisableNewNetNs,
 			InterworkingModel: int(sconfig.NetworkConfig.InterworkingModel),
+
+			TCFilterCompat: persistapi.TCFilterCompat(sconfig.NetworkConfig.TCFilterCompat),
+			MACAllocation: persistapi.MACAllocation{
+				Strategy: string(sconfig.NetworkConfig.MACAllocation.Strategy),
+				Pool:     sconfig.NetworkConfig.MACAllocation.Pool,
+			},
+			DHCPInterfaces: sconfig.NetworkConfig.DHCPInterfaces,
+
+			InterfaceEndpoints: sconfig.NetworkConfig.InterfaceEndpoints.save(),
+			Provider:           sconfig.NetworkConfig.Provider,
 		},
 
+		Annotations:         sconfig.Annotations,
 		ShmSize:             sconfig.ShmSize,
 		SharePidNs:          sconfig.SharePidNs,
 		SystemdCgroup:       sconfig.SystemdCgroup,
 		SandboxCgroupOnly:   sconfig.SandboxCgroupOnly,
 		DisableGuestSeccomp: sconfig.DisableGuestSeccomp,
+		GuestHooks:          sconfig.GuestHooks,
+		Registrars:          sconfig.Registrars,
 		Cgroups:             sconfig.Cgroups,
+		ResourceCeilings:    persistapi.ResourceCeilings(sconfig.ResourceCeilings),
+		NodeLimits:          persistapi.NodeLimits(sconfig.NodeLimits),
+		ResizePolicy:        persistapi.ResizePolicy(sconfig.ResizePolicy),
+		HotplugPlanning:     persistapi.HotplugPlanning(sconfig.HotplugPlanning),
+		CPUShares:           persistapi.CPUSharesTranslation(sconfig.CPUShares),
+		GuestOS:             sconfig.GuestOS,
+		AgentType:           string(sconfig.AgentType),
+		RootfsDisk:          persistapi.RootfsDisk(sconfig.RootfsDisk),
+		TimeSync:            persistapi.TimeSync(sconfig.TimeSync),
+		GuestProvisioning: persistapi.GuestProvisioning{
+			Timezone: sconfig.GuestProvisioning.Timezone,
+			CABundle: sconfig.GuestProvisioning.CABundle,
+			Locale:   sconfig.GuestProvisioning.Locale,
+		},
+		CoreDumpDir: sconfig.CoreDumpDir,
+		AssetStore:  sconfig.AssetStore,
+		Kdump:       persistapi.Kdump(sconfig.Kdump),
+
+		GuestProfiling: persistapi.GuestProfiling(sconfig.GuestProfiling),
+		GuestLivepatch: persistapi.GuestLivepatch(sconfig.GuestLivepatch),
+		Cloneable:      sconfig.Cloneable,
+
+		GuestMemoryReclaim: persistapi.GuestMemoryReclaim(sconfig.GuestMemoryReclaim),
+		MetadataProxy:      persistapi.MetadataProxy(sconfig.MetadataProxy),
+		TrafficCapture:     persistapi.TrafficCapture(sconfig.TrafficCapture),
+
+		CloneSnapshotMaxAge: sconfig.CloneSnapshotMaxAge,
+		PeriodicSnapshots:   persistapi.SnapshotPolicy(sconfig.PeriodicSnapshots),
+		ShutdownTimeouts:    persistapi.ShutdownTimeouts(sconfig.ShutdownTimeouts),
+		Lightweight: persistapi.LightweightSandbox{
+			Enable:     sconfig.Lightweight.Enable,
+			Interfaces: sconfig.Lightweight.Interfaces,
+		},
+	}
+
+	if d := sconfig.Lightweight.GuestDetails; d != nil {
+		details := persistapi.LightweightGuestDetails(*d)
+		ss.Config.Lightweight.GuestDetails = &details
+	}
+
+	for _, f := range sconfig.GuestProvisioning.Files {
+		ss.Config.GuestProvisioning.Files = append(ss.Config.GuestProvisioning.Files, persistapi.GuestFile(f))
 	}
 
 	for _, e := range sconfig.Experimental {
@@ -204,19 +278,24 @@ func (s *Sandbox) dumpConfig(ss *persistapi.SandboxState) {
 		NumVCPUs:                sconfig.HypervisorConfig.NumVCPUs,
 		DefaultMaxVCPUs:         sconfig.HypervisorConfig.DefaultMaxVCPUs,
 		MemorySize:              sconfig.HypervisorConfig.MemorySize,
+		MemoryAdjustmentMB:      sconfig.HypervisorConfig.MemoryAdjustmentMB,
 		DefaultBridges:          sconfig.HypervisorConfig.DefaultBridges,
 		Msize9p:                 sconfig.HypervisorConfig.Msize9p,
 		MemSlots:                sconfig.HypervisorConfig.MemSlots,
 		MemOffset:               sconfig.HypervisorConfig.MemOffset,
 		VirtioMem:               sconfig.HypervisorConfig.VirtioMem,
+		FreePageReporting:       sconfig.HypervisorConfig.FreePageReporting,
 		VirtioFSCacheSize:       sconfig.HypervisorConfig.VirtioFSCacheSize,
 		KernelPath:              sconfig.HypervisorConfig.KernelPath,
 		ImagePath:               sconfig.HypervisorConfig.ImagePath,
 		InitrdPath:              sconfig.HypervisorConfig.InitrdPath,
 		FirmwarePath:            sconfig.HypervisorConfig.FirmwarePath,
+		FirmwareVarsTemplate:    sconfig.HypervisorConfig.FirmwareVarsTemplate,
 		MachineAccelerators:     sconfig.HypervisorConfig.MachineAccelerators,
 		CPUFeatures:             sconfig.HypervisorConfig.CPUFeatures,
 		HypervisorPath:          sconfig.HypervisorConfig.HypervisorPath,
+		HypervisorProfile:       sconfig.HypervisorConfig.HypervisorProfile,
+		KernelParamsProfile:     sconfig.HypervisorConfig.KernelParamsProfile,
 		HypervisorCtlPath:       sconfig.HypervisorConfig.HypervisorCtlPath,
 		JailerPath:              sconfig.HypervisorConfig.JailerPath,
 		BlockDeviceDriver:       sconfig.HypervisorConfig.BlockDeviceDriver,
@@ -228,9 +307,11 @@ func (s *Sandbox) dumpConfig(ss *persistapi.SandboxState) {
 		VirtioFSDaemon:          sconfig.HypervisorConfig.VirtioFSDaemon,
 		VirtioFSCache:           sconfig.HypervisorConfig.VirtioFSCache,
 		VirtioFSExtraArgs:       sconfig.HypervisorConfig.VirtioFSExtraArgs[:],
+		VirtioFSDaemonRestarts:  sconfig.HypervisorConfig.VirtioFSDaemonRestarts,
 		BlockDeviceCacheSet:     sconfig.HypervisorConfig.BlockDeviceCacheSet,
 		BlockDeviceCacheDirect:  sconfig.HypervisorConfig.BlockDeviceCacheDirect,
 		BlockDeviceCacheNoflush: sconfig.HypervisorConfig.BlockDeviceCacheNoflush,
+		PRHelperSocket:          sconfig.HypervisorConfig.PRHelperSocket,
 		DisableBlockDeviceUse:   sconfig.HypervisorConfig.DisableBlockDeviceUse,
 		EnableIOThreads:         sconfig.HypervisorConfig.EnableIOThreads,
 		Debug:                   sconfig.HypervisorConfig.Debug,
@@ -244,15 +325,35 @@ func (s *Sandbox) dumpConfig(ss *persistapi.SandboxState) {
 		DisableImageNvdimm:      sconfig.HypervisorConfig.DisableImageNvdimm,
 		HotplugVFIOOnRootBus:    sconfig.HypervisorConfig.HotplugVFIOOnRootBus,
 		PCIeRootPort:            sconfig.HypervisorConfig.PCIeRootPort,
+		VFIOAutoBindDrivers:     sconfig.HypervisorConfig.VFIOAutoBindDrivers,
+		VFIOPeerToPeer:          sconfig.HypervisorConfig.VFIOPeerToPeer,
+		GuestNUMA:               sconfig.HypervisorConfig.GuestNUMA,
+		VCPUPinning:             sconfig.HypervisorConfig.VCPUPinning,
 		BootToBeTemplate:        sconfig.HypervisorConfig.BootToBeTemplate,
 		BootFromTemplate:        sconfig.HypervisorConfig.BootFromTemplate,
 		DisableVhostNet:         sconfig.HypervisorConfig.DisableVhostNet,
+		NetworkQueues:           sconfig.HypervisorConfig.NetworkQueues,
+		NetworkMaxQueues:        sconfig.HypervisorConfig.NetworkMaxQueues,
+		EnableGuestSuspend:      sconfig.HypervisorConfig.EnableGuestSuspend,
 		EnableVhostUserStore:    sconfig.HypervisorConfig.EnableVhostUserStore,
 		VhostUserStorePath:      sconfig.HypervisorConfig.VhostUserStorePath,
+		VhostUserReconnect:      sconfig.HypervisorConfig.VhostUserReconnect,
 		GuestHookPath:           sconfig.HypervisorConfig.GuestHookPath,
 		VMid:                    sconfig.HypervisorConfig.VMid,
 		RxRateLimiterMaxRate:    sconfig.HypervisorConfig.RxRateLimiterMaxRate,
 		TxRateLimiterMaxRate:    sconfig.HypervisorConfig.TxRateLimiterMaxRate,
+		EnableVMMIsolation:      sconfig.HypervisorConfig.EnableVMMIsolation,
+		VMMSeccomp:              sconfig.HypervisorConfig.VMMSeccomp,
+		MemoryEncryption:        sconfig.HypervisorConfig.MemoryEncryption,
+		SEVPolicy:               sconfig.HypervisorConfig.SEVPolicy,
+		SEVCBitPos:              sconfig.HypervisorConfig.SEVCBitPos,
+		SEVReducedPhysBits:      sconfig.HypervisorConfig.SEVReducedPhysBits,
+		SEVDHCertPath:           sconfig.HypervisorConfig.SEVDHCertPath,
+		SEVSessionPath:          sconfig.HypervisorConfig.SEVSessionPath,
+	}
+
+	for _, node := range sconfig.HypervisorConfig.GuestNUMANodes {
+		ss.Config.HypervisorConfig.GuestNUMANodes = append(ss.Config.HypervisorConfig.GuestNUMANodes, persistapi.GuestNUMANode(node))
 	}
 
 	ss.Config.KataAgentConfig = &persistapi.KataAgentConfig{
@@ -266,11 +367,29 @@ func (s *Sandbox) dumpConfig(ss *persistapi.SandboxState) {
 			Annotations: contConf.Annotations,
 			RootFs:      contConf.RootFs.Target,
 			Resources:   contConf.Resources,
+			CoreDump: persistapi.CoreDump{
+				Policy:  string(contConf.CoreDump.Policy),
+				HostDir: contConf.CoreDump.HostDir,
+				MaxSize: contConf.CoreDump.MaxSize,
+			},
+			EncryptedScratchMB: contConf.EncryptedScratchMB,
 		})
 	}
 }
 
 func (s *Sandbox) Save() error {
+	ss, cs := s.dump()
+
+	if err := s.newStore.ToDisk(ss, cs); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// dump returns the states of the sandbox and of its containers the store
+// saves.
+func (s *Sandbox) dump() (persistapi.SandboxState, map[string]persistapi.ContainerState) {
 	var (
 		ss = persistapi.SandboxState{}
 		cs = make(map[string]persistapi.ContainerState)
@@ -286,11 +405,7 @@ func (s *Sandbox) Save() error {
 	s.dumpNetwork(&ss)
 	s.dumpConfig(&ss)
 
-	if err := s.newStore.ToDisk(ss, cs); err != nil {
-		return err
-	}
-
-	return nil
+	return ss, cs
 }
 
 func (s *Sandbox) loadState(ss persistapi.SandboxState) {
@@ -301,14 +416,30 @@ func (s *Sandbox) loadState(ss persistapi.SandboxState) {
 	s.state.CgroupPath = ss.CgroupPath
 	s.state.CgroupPaths = ss.CgroupPaths
 	s.state.GuestMemoryHotplugProbe = ss.GuestMemoryHotplugProbe
+	s.state.GuestSeccompSupported = ss.GuestSeccompSupported
+	s.state.HypervisorVersion = ss.HypervisorVersion
+	s.state.HypervisorConfigDigest = ss.HypervisorConfigDigest
+	s.resize = resizeState{
+		vcpus:      ss.Resize.VCPUs,
+		memoryMB:   ss.Resize.MemoryMB,
+		lastResize: ss.Resize.LastResize,
+	}
+	s.hotplugPlan = HotplugPlan{
+		VCPUs:       ss.HotplugPlan.VCPUs,
+		MaxVCPUs:    ss.HotplugPlan.MaxVCPUs,
+		MemorySlots: ss.HotplugPlan.MemorySlots,
+		Devices:     ss.HotplugPlan.Devices,
+		Bridges:     ss.HotplugPlan.Bridges,
+	}
 }
 
 func (c *Container) loadContState(cs persistapi.ContainerState) {
 	c.state = types.ContainerState{
-		State:         types.StateString(cs.State),
-		BlockDeviceID: cs.Rootfs.BlockDeviceID,
-		Fstype:        cs.Rootfs.FsType,
-		CgroupPath:    cs.CgroupPath,
+		State:           types.StateString(cs.State),
+		BlockDeviceID:   cs.Rootfs.BlockDeviceID,
+		Fstype:          cs.Rootfs.FsType,
+		ScratchDeviceID: cs.Rootfs.ScratchDeviceID,
+		CgroupPath:      cs.CgroupPath,
 	}
 }
 
@@ -324,6 +455,7 @@ func (s *Sandbox) loadAgent(as persistapi.AgentState) {
 
 func (s *Sandbox) loadDevices(devStates []persistapi.DeviceState) {
 	s.devManager.LoadDevices(devStates)
+	s.hotpluggedDevices = hotpluggedDeviceIDs(s.devManager.GetAllDevices())
 }
 
 func (c *Container) loadContDevices(cs persistapi.ContainerState) {
@@ -366,9 +498,12 @@ func (s *Sandbox) loadNetwork(netInfo persistapi.NetworkInfo) {
 		NetNsPath:    netInfo.NetNsPath,
 		NetmonPID:    netInfo.NetmonPID,
 		NetNsCreated: netInfo.NetNsCreated,
+		Endpoints:    s.loadEndpoints(netInfo.Endpoints),
 	}
+}
 
-	for _, e := range netInfo.Endpoints {
+func (s *Sandbox) loadEndpoints(states []persistapi.NetworkEndpoint) (endpoints []Endpoint) {
+	for _, e := range states {
 		var ep Endpoint
 		switch EndpointType(e.Type) {
 		case PhysicalEndpointType:
@@ -390,8 +525,10 @@ func (s *Sandbox) loadNetwork(netInfo persistapi.NetworkInfo) {
 			continue
 		}
 		ep.load(e)
-		s.networkNS.Endpoints = append(s.networkNS.Endpoints, ep)
+		endpoints = append(endpoints, ep)
 	}
+
+	return endpoints
 }
 
 // Restore will restore sandbox data from persist file on disk
@@ -439,9 +576,15 @@ func loadSandboxConfig(id string) (*SandboxConfig, error) {
 		return nil, err
 	}
 
+	return sandboxConfigFromState(ss), nil
+}
+
+// sandboxConfigFromState returns the configuration of a sandbox saved in
+// its state.
+func sandboxConfigFromState(ss persistapi.SandboxState) *SandboxConfig {
 	savedConf := ss.Config
 	sconfig := &SandboxConfig{
-		ID:             id,
+		ID:             ss.SandboxContainer,
 		HypervisorType: HypervisorType(savedConf.HypervisorType),
 		ProxyType:      ProxyType(savedConf.ProxyType),
 		ProxyConfig: ProxyConfig{
@@ -453,14 +596,69 @@ func loadSandboxConfig(id string) (*SandboxConfig, error) {
 			NetNsCreated:      savedConf.NetworkConfig.NetNsCreated,
 			DisableNewNetNs:   savedConf.NetworkConfig.DisableNewNetNs,
 			InterworkingModel: NetInterworkingModel(savedConf.NetworkConfig.InterworkingModel),
+
+			TCFilterCompat: TCFilterCompat(savedConf.NetworkConfig.TCFilterCompat),
+			MACAllocation: MACAllocation{
+				Strategy: MACAllocationStrategy(savedConf.NetworkConfig.MACAllocation.Strategy),
+				Pool:     savedConf.NetworkConfig.MACAllocation.Pool,
+			},
+			DHCPInterfaces: savedConf.NetworkConfig.DHCPInterfaces,
+
+			InterfaceEndpoints: loadInterfaceEndpoints(savedConf.NetworkConfig.InterfaceEndpoints),
+			Provider:           savedConf.NetworkConfig.Provider,
 		},
 
+		Annotations:         savedConf.Annotations,
 		ShmSize:             savedConf.ShmSize,
 		SharePidNs:          savedConf.SharePidNs,
 		SystemdCgroup:       savedConf.SystemdCgroup,
 		SandboxCgroupOnly:   savedConf.SandboxCgroupOnly,
 		DisableGuestSeccomp: savedConf.DisableGuestSeccomp,
+		GuestHooks:          savedConf.GuestHooks,
+		Registrars:          savedConf.Registrars,
 		Cgroups:             savedConf.Cgroups,
+		ResourceCeilings:    ResourceCeilings(savedConf.ResourceCeilings),
+		NodeLimits:          NodeLimits(savedConf.NodeLimits),
+		ResizePolicy:        ResizePolicy(savedConf.ResizePolicy),
+		HotplugPlanning:     HotplugPlanning(savedConf.HotplugPlanning),
+		CPUShares:           CPUSharesTranslation(savedConf.CPUShares),
+		GuestOS:             savedConf.GuestOS,
+		AgentType:           AgentType(savedConf.AgentType),
+		RootfsDisk:          RootfsDisk(savedConf.RootfsDisk),
+		TimeSync:            TimeSync(savedConf.TimeSync),
+		GuestProvisioning: GuestProvisioning{
+			Timezone: savedConf.GuestProvisioning.Timezone,
+			CABundle: savedConf.GuestProvisioning.CABundle,
+			Locale:   savedConf.GuestProvisioning.Locale,
+		},
+		CoreDumpDir: savedConf.CoreDumpDir,
+		AssetStore:  savedConf.AssetStore,
+		Kdump:       Kdump(savedConf.Kdump),
+
+		GuestProfiling: GuestProfiling(savedConf.GuestProfiling),
+		GuestLivepatch: GuestLivepatch(savedConf.GuestLivepatch),
+		Cloneable:      savedConf.Cloneable,
+
+		GuestMemoryReclaim: GuestMemoryReclaim(savedConf.GuestMemoryReclaim),
+		MetadataProxy:      MetadataProxy(savedConf.MetadataProxy),
+		TrafficCapture:     TrafficCapture(savedConf.TrafficCapture),
+
+		CloneSnapshotMaxAge: savedConf.CloneSnapshotMaxAge,
+		PeriodicSnapshots:   SnapshotPolicy(savedConf.PeriodicSnapshots),
+		ShutdownTimeouts:    ShutdownTimeouts(savedConf.ShutdownTimeouts),
+		Lightweight: LightweightSandbox{
+			Enable:     savedConf.Lightweight.Enable,
+			Interfaces: savedConf.Lightweight.Interfaces,
+		},
+	}
+
+	if d := savedConf.Lightweight.GuestDetails; d != nil {
+		details := LightweightGuestDetails(*d)
+		sconfig.Lightweight.GuestDetails = &details
+	}
+
+	for _, f := range savedConf.GuestProvisioning.Files {
+		sconfig.GuestProvisioning.Files = append(sconfig.GuestProvisioning.Files, GuestFile(f))
 	}
 
 	for _, name := range savedConf.Experimental {
@@ -472,19 +670,24 @@ func loadSandboxConfig(id string) (*SandboxConfig, error) {
 		NumVCPUs:                hconf.NumVCPUs,
 		DefaultMaxVCPUs:         hconf.DefaultMaxVCPUs,
 		MemorySize:              hconf.MemorySize,
+		MemoryAdjustmentMB:      hconf.MemoryAdjustmentMB,
 		DefaultBridges:          hconf.DefaultBridges,
 		Msize9p:                 hconf.Msize9p,
 		MemSlots:                hconf.MemSlots,
 		MemOffset:               hconf.MemOffset,
 		VirtioMem:               hconf.VirtioMem,
+		FreePageReporting:       hconf.FreePageReporting,
 		VirtioFSCacheSize:       hconf.VirtioFSCacheSize,
 		KernelPath:              hconf.KernelPath,
 		ImagePath:               hconf.ImagePath,
 		InitrdPath:              hconf.InitrdPath,
 		FirmwarePath:            hconf.FirmwarePath,
+		FirmwareVarsTemplate:    hconf.FirmwareVarsTemplate,
 		MachineAccelerators:     hconf.MachineAccelerators,
 		CPUFeatures:             hconf.CPUFeatures,
 		HypervisorPath:          hconf.HypervisorPath,
+		HypervisorProfile:       hconf.HypervisorProfile,
+		KernelParamsProfile:     hconf.KernelParamsProfile,
 		HypervisorCtlPath:       hconf.HypervisorCtlPath,
 		JailerPath:              hconf.JailerPath,
 		BlockDeviceDriver:       hconf.BlockDeviceDriver,
@@ -496,9 +699,11 @@ func loadSandboxConfig(id string) (*SandboxConfig, error) {
 		VirtioFSDaemon:          hconf.VirtioFSDaemon,
 		VirtioFSCache:           hconf.VirtioFSCache,
 		VirtioFSExtraArgs:       hconf.VirtioFSExtraArgs[:],
+		VirtioFSDaemonRestarts:  hconf.VirtioFSDaemonRestarts,
 		BlockDeviceCacheSet:     hconf.BlockDeviceCacheSet,
 		BlockDeviceCacheDirect:  hconf.BlockDeviceCacheDirect,
 		BlockDeviceCacheNoflush: hconf.BlockDeviceCacheNoflush,
+		PRHelperSocket:          hconf.PRHelperSocket,
 		DisableBlockDeviceUse:   hconf.DisableBlockDeviceUse,
 		EnableIOThreads:         hconf.EnableIOThreads,
 		Debug:                   hconf.Debug,
@@ -512,15 +717,35 @@ func loadSandboxConfig(id string) (*SandboxConfig, error) {
 		DisableImageNvdimm:      hconf.DisableImageNvdimm,
 		HotplugVFIOOnRootBus:    hconf.HotplugVFIOOnRootBus,
 		PCIeRootPort:            hconf.PCIeRootPort,
+		VFIOAutoBindDrivers:     hconf.VFIOAutoBindDrivers,
+		VFIOPeerToPeer:          hconf.VFIOPeerToPeer,
+		GuestNUMA:               hconf.GuestNUMA,
+		VCPUPinning:             hconf.VCPUPinning,
 		BootToBeTemplate:        hconf.BootToBeTemplate,
 		BootFromTemplate:        hconf.BootFromTemplate,
 		DisableVhostNet:         hconf.DisableVhostNet,
+		NetworkQueues:           hconf.NetworkQueues,
+		NetworkMaxQueues:        hconf.NetworkMaxQueues,
+		EnableGuestSuspend:      hconf.EnableGuestSuspend,
 		EnableVhostUserStore:    hconf.EnableVhostUserStore,
 		VhostUserStorePath:      hconf.VhostUserStorePath,
+		VhostUserReconnect:      hconf.VhostUserReconnect,
 		GuestHookPath:           hconf.GuestHookPath,
 		VMid:                    hconf.VMid,
 		RxRateLimiterMaxRate:    hconf.RxRateLimiterMaxRate,
 		TxRateLimiterMaxRate:    hconf.TxRateLimiterMaxRate,
+		EnableVMMIsolation:      hconf.EnableVMMIsolation,
+		VMMSeccomp:              hconf.VMMSeccomp,
+		MemoryEncryption:        hconf.MemoryEncryption,
+		SEVPolicy:               hconf.SEVPolicy,
+		SEVCBitPos:              hconf.SEVCBitPos,
+		SEVReducedPhysBits:      hconf.SEVReducedPhysBits,
+		SEVDHCertPath:           hconf.SEVDHCertPath,
+		SEVSessionPath:          hconf.SEVSessionPath,
+	}
+
+	for _, node := range hconf.GuestNUMANodes {
+		sconfig.HypervisorConfig.GuestNUMANodes = append(sconfig.HypervisorConfig.GuestNUMANodes, GuestNUMANode(node))
 	}
 
 	sconfig.AgentConfig = KataAgentConfig{
@@ -536,7 +761,13 @@ func loadSandboxConfig(id string) (*SandboxConfig, error) {
 			RootFs: RootFs{
 				Target: contConf.RootFs,
 			},
+			CoreDump: CoreDump{
+				Policy:  CoreDumpPolicy(contConf.CoreDump.Policy),
+				HostDir: contConf.CoreDump.HostDir,
+				MaxSize: contConf.CoreDump.MaxSize,
+			},
+			EncryptedScratchMB: contConf.EncryptedScratchMB,
 		})
 	}
-	return sconfig, nil
+	return sconfig
 }
@@ -7,10 +7,21 @@
 package persistapi
 
 import (
+	"time"
+
 	"github.com/opencontainers/runc/libcontainer/configs"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 )
 
+// GuestNUMANode saves a NUMA node of the VM.
+type GuestNUMANode struct {
+	// HostNode is the host NUMA node mirrored by the guest node.
+	HostNode int
+
+	// PCIDevices are the host PCI devices attached to the guest node.
+	PCIDevices []string
+}
+
 // HypervisorConfig saves configurations of sandbox hypervisor
 type HypervisorConfig struct {
 	// NumVCPUs specifies default number of vCPUs for the VM.
@@ -22,6 +33,10 @@ type HypervisorConfig struct {
 	// DefaultMem specifies default memory size in MiB for the VM.
 	MemorySize uint32
 
+	// MemoryAdjustmentMB is the memory, in MiB, added to the VM on top of
+	// MemorySize and of the memory limits of the containers.
+	MemoryAdjustmentMB uint32
+
 	// DefaultBridges specifies default number of bridges for the VM.
 	// Bridges can be used to hot plug devices
 	DefaultBridges uint32
@@ -51,6 +66,9 @@ type HypervisorConfig struct {
 	// FirmwarePath is the bios host path
 	FirmwarePath string
 
+	// FirmwareVarsTemplate is the template of the firmware variables
+	FirmwareVarsTemplate string
+
 	// MachineAccelerators are machine specific accelerators
 	MachineAccelerators string
 
@@ -60,6 +78,12 @@ type HypervisorConfig struct {
 	// HypervisorPath is the hypervisor executable host path.
 	HypervisorPath string
 
+	// HypervisorProfile is the profile HypervisorPath was selected from.
+	HypervisorProfile string
+
+	// KernelParamsProfile is the profile merged with the kernel parameters.
+	KernelParamsProfile string
+
 	// HypervisorCtlPath is the hypervisor ctl executable host path.
 	HypervisorCtlPath string
 
@@ -89,6 +113,7 @@ type HypervisorConfig struct {
 	// Shared file system type:
 	//   - virtio-9p (default)
 	//   - virtio-fs
+	//   - none
 	SharedFS string
 
 	// VirtioFSDaemon is the virtio-fs vhost-user daemon path
@@ -100,6 +125,11 @@ type HypervisorConfig struct {
 	// VirtioFSExtraArgs passes options to virtiofsd daemon
 	VirtioFSExtraArgs []string
 
+	// VirtioFSDaemonRestarts is how many times in a row a crashed
+	// virtio-fs daemon is restarted, for the hypervisor to reconnect to it,
+	// before the sandbox is stopped.
+	VirtioFSDaemonRestarts uint32
+
 	// File based memory backend root directory
 	FileBackedMemRootDir string
 
@@ -114,6 +144,12 @@ type HypervisorConfig struct {
 	// Denotes whether flush requests for the device are ignored.
 	BlockDeviceCacheNoflush bool
 
+	// PRHelperSocket is the socket of the qemu-pr-helper daemon the
+	// persistent reservation commands of the guest are forwarded to. When
+	// set, the SCSI disks and the multipath devices over SCSI disks are
+	// passed through to the guest, with the virtio-scsi block driver.
+	PRHelperSocket string
+
 	// DisableBlockDeviceUse disallows a block device from being used.
 	DisableBlockDeviceUse bool
 
@@ -134,6 +170,9 @@ type HypervisorConfig struct {
 	// VirtioMem is used to enable/disable virtio-mem
 	VirtioMem bool
 
+	// FreePageReporting adds a balloon the guest reports its free pages to
+	FreePageReporting bool
+
 	// Realtime Used to enable/disable realtime
 	Realtime bool
 
@@ -160,6 +199,23 @@ type HypervisorConfig struct {
 	// The PCIe Root Port device is used to hot-plug the PCIe device
 	PCIeRootPort uint32
 
+	// VFIOAutoBindDrivers are the host drivers whose devices are bound to
+	// vfio-pci when they share the IOMMU group of an assigned VFIO device.
+	VFIOAutoBindDrivers []string
+
+	// VFIOPeerToPeer lets the VFIO devices of the VM DMA to each other.
+	VFIOPeerToPeer bool
+
+	// GuestNUMA gives the VM a NUMA node for each host NUMA node its VFIO
+	// devices are attached to.
+	GuestNUMA bool
+
+	// GuestNUMANodes are the NUMA nodes of the VM.
+	GuestNUMANodes []GuestNUMANode
+
+	// VCPUPinning pins each vCPU thread to a host CPU.
+	VCPUPinning bool
+
 	// BootToBeTemplate used to indicate if the VM is created to be a template VM
 	BootToBeTemplate bool
 
@@ -169,6 +225,15 @@ type HypervisorConfig struct {
 	// DisableVhostNet is used to indicate if host supports vhost_net
 	DisableVhostNet bool
 
+	// NetworkQueues is the number of queue pairs of the network devices
+	NetworkQueues uint32
+
+	// NetworkMaxQueues caps the queue pairs sized from the vCPUs
+	NetworkMaxQueues uint32
+
+	// EnableGuestSuspend lets the guest be suspended to RAM
+	EnableGuestSuspend bool
+
 	// EnableVhostUserStore is used to indicate if host supports vhost-user-blk/scsi
 	EnableVhostUserStore bool
 
@@ -176,6 +241,11 @@ type HypervisorConfig struct {
 	// related folders, sockets and device nodes should be.
 	VhostUserStorePath string
 
+	// VhostUserReconnect is the number of seconds between the attempts of
+	// the hypervisor to connect again to a restarted vhost-user storage
+	// backend
+	VhostUserReconnect uint32
+
 	// GuestHookPath is the path within the VM that will be used for 'drop-in' hooks
 	GuestHookPath string
 
@@ -188,6 +258,32 @@ type HypervisorConfig struct {
 
 	// TxRateLimiterMaxRate is used to control network I/O outbound bandwidth on VM level.
 	TxRateLimiterMaxRate uint64
+
+	// EnableVMMIsolation starts the hypervisor process in its own mount,
+	// PID and user namespaces.
+	EnableVMMIsolation bool
+
+	// VMMSeccomp is the seccomp mode applied to the hypervisor process.
+	VMMSeccomp string
+
+	// MemoryEncryption is the encryption of the VM memory.
+	MemoryEncryption string
+
+	// SEVPolicy is the guest policy of the memory encrypted VM.
+	SEVPolicy uint64
+
+	// SEVCBitPos is the encryption bit of the guest page table entries.
+	SEVCBitPos uint32
+
+	// SEVReducedPhysBits is the number of physical address bits lost by
+	// the guest with the memory encryption.
+	SEVReducedPhysBits uint32
+
+	// SEVDHCertPath is the path of the guest owner certificate.
+	SEVDHCertPath string
+
+	// SEVSessionPath is the path of the guest owner session parameters.
+	SEVSessionPath string
 }
 
 // KataAgentConfig is a structure storing information needed
@@ -217,6 +313,29 @@ type NetworkConfig struct {
 	NetNsCreated      bool
 	DisableNewNetNs   bool
 	InterworkingModel int
+
+	TCFilterCompat TCFilterCompat
+	MACAllocation  MACAllocation
+	DHCPInterfaces []string
+
+	InterfaceEndpoints map[string]string
+
+	Provider string
+}
+
+// TCFilterCompat is how the endpoints connected with tc filters are set up.
+// Refs: virtcontainers/tcfilter_compat.go:TCFilterCompat
+type TCFilterCompat struct {
+	KeepOffloads bool
+	FixupProg    string
+}
+
+// MACAllocation is how the MAC addresses of the guest network interfaces
+// are picked.
+// Refs: virtcontainers/mac_allocation.go:MACAllocation
+type MACAllocation struct {
+	Strategy string
+	Pool     []string
 }
 
 type ContainerConfig struct {
@@ -224,7 +343,170 @@ type ContainerConfig struct {
 	Annotations map[string]string
 	RootFs      string
 	// Resources for recoding update
-	Resources specs.LinuxResources
+	Resources          specs.LinuxResources
+	CoreDump           CoreDump
+	EncryptedScratchMB uint64
+}
+
+// Kdump is the guest kernel crash dump configuration.
+// Refs: virtcontainers/kdump.go:Kdump
+type Kdump struct {
+	CrashKernelMB uint32
+	HostDir       string
+	GuestDevice   string
+	RedactPaths   []string
+}
+
+// SnapshotPolicy is the policy of the periodic snapshots of a sandbox.
+// Refs: virtcontainers/sandbox_snapshots.go:SnapshotPolicy
+type SnapshotPolicy struct {
+	Interval  time.Duration
+	Retention uint
+}
+
+// ShutdownTimeouts are the timeouts of the graceful shutdown stages of the
+// VM of a sandbox.
+// Refs: virtcontainers/sandbox_shutdown.go:ShutdownTimeouts
+type ShutdownTimeouts struct {
+	Guest time.Duration
+	ACPI  time.Duration
+	Quit  time.Duration
+}
+
+// LightweightSandbox is the fast path of the sandboxes running a single
+// container.
+// Refs: virtcontainers/sandbox_lightweight.go:LightweightSandbox
+type LightweightSandbox struct {
+	Enable       bool
+	Interfaces   []string
+	GuestDetails *LightweightGuestDetails
+}
+
+// LightweightGuestDetails are the details of the guest of a lightweight
+// sandbox known beforehand.
+type LightweightGuestDetails struct {
+	MemoryBlockSizeMB  uint32
+	MemoryHotplugProbe bool
+	SeccompSupported   bool
+}
+
+// GuestProfiling is the policy of the guest profiling sessions.
+// Refs: virtcontainers/guest_profiling.go:GuestProfiling
+type GuestProfiling struct {
+	Tools       []string
+	MaxDuration time.Duration
+}
+
+// GuestLivepatch lists the livepatch modules of the guest.
+// Refs: virtcontainers/guest_livepatch.go:GuestLivepatch
+type GuestLivepatch struct {
+	Modules []string
+}
+
+// TrafficCapture is the policy of the captures of the sandbox traffic.
+// Refs: virtcontainers/traffic_capture.go:TrafficCapture
+type TrafficCapture struct {
+	Enabled     bool
+	MaxDuration time.Duration
+	SnapLen     uint32
+}
+
+// GuestMemoryReclaim is how the guest gives back its cache memory.
+// Refs: virtcontainers/guest_memory_reclaim.go:GuestMemoryReclaim
+type GuestMemoryReclaim struct {
+	CacheDropInterval    time.Duration
+	CacheDropThresholdMB uint32
+	VFSCachePressure     uint32
+	IdleScanInterval     time.Duration
+}
+
+// MetadataProxy is the proxy of the instance metadata service.
+// Refs: virtcontainers/metadata_proxy.go:MetadataProxy
+type MetadataProxy struct {
+	AllowedPaths []string
+	URL          string
+}
+
+// CoreDump is the core dump capture configuration of a container.
+// Refs: virtcontainers/core_dump.go:CoreDump
+type CoreDump struct {
+	Policy  string
+	HostDir string
+	MaxSize uint64
+}
+
+// ResourceCeilings are the host-side sandbox resource limits.
+// Refs: virtcontainers/resource_ceilings.go:ResourceCeilings
+type ResourceCeilings struct {
+	MaxMemoryMB       uint32
+	MaxVCPUs          uint32
+	MaxHotplugDevices uint32
+	MaxInterfaces     uint32
+}
+
+// NodeLimits are the limits of the resources all the sandboxes of the host
+// claim together.
+// Refs: virtcontainers/node_ledger.go:NodeLimits
+type NodeLimits struct {
+	MaxMemoryMB       uint64
+	MaxHotplugDevices uint32
+	MaxSandboxes      uint32
+}
+
+// ResizePolicy is the sandbox shrink debouncing policy.
+// Refs: virtcontainers/resize_debounce.go:ResizePolicy
+type ResizePolicy struct {
+	ShrinkCooldown          time.Duration
+	VCPUShrinkThreshold     uint32
+	MemoryShrinkThresholdMB uint32
+}
+
+// HotplugPlanning is the sandbox hotplug capacity reservation setting.
+// Refs: virtcontainers/hotplug_plan.go:HotplugPlanning
+type HotplugPlanning struct {
+	Enable      bool
+	VCPUs       uint32
+	MemorySlots uint32
+	Devices     uint32
+}
+
+// CPUSharesTranslation is the container CPU shares translation setting.
+// Refs: virtcontainers/cpu_shares.go:CPUSharesTranslation
+type CPUSharesTranslation struct {
+	Aggregation string
+	GuestScale  float64
+	VCPUNice    bool
+}
+
+// RootfsDisk is the sandbox container rootfs conversion setting.
+// Refs: virtcontainers/rootfs_disk.go:RootfsDisk
+type RootfsDisk struct {
+	Fstype    string
+	Converter string
+}
+
+// TimeSync are the time sources of the guest.
+// Refs: virtcontainers/time_sync.go:TimeSync
+type TimeSync struct {
+	Servers []string
+	Pools   []string
+	PTPKVM  bool
+}
+
+// GuestFile is a host file installed in the guest.
+// Refs: virtcontainers/guest_provisioning.go:GuestFile
+type GuestFile struct {
+	HostPath  string
+	GuestPath string
+}
+
+// GuestProvisioning are the host files and settings installed in the guest.
+// Refs: virtcontainers/guest_provisioning.go:GuestProvisioning
+type GuestProvisioning struct {
+	Files    []GuestFile
+	Timezone string
+	CABundle string
+	Locale   string
 }
 
 // SandboxConfig is a sandbox configuration.
@@ -244,6 +526,10 @@ type SandboxConfig struct {
 
 	NetworkConfig NetworkConfig
 
+	// Annotations of the sandbox, to list the sandboxes by annotation
+	// without fetching them.
+	Annotations map[string]string
+
 	ShmSize uint64
 
 	// SharePidNs sets all containers to share the same sandbox level pid namespace.
@@ -261,9 +547,62 @@ type SandboxConfig struct {
 
 	DisableGuestSeccomp bool
 
+	// GuestHooks are the types of the OCI hooks run in the guest
+	GuestHooks []string
+
+	// Registrars are the names of the registrars the interfaces of the
+	// sandbox are registered with
+	Registrars []string
+
 	// Experimental enables experimental features
 	Experimental []string
 
+	ResourceCeilings ResourceCeilings
+
+	NodeLimits NodeLimits
+
+	ResizePolicy ResizePolicy
+
+	HotplugPlanning HotplugPlanning
+
+	CPUShares CPUSharesTranslation
+
+	GuestOS string
+
+	AgentType string
+
+	RootfsDisk RootfsDisk
+
+	TimeSync TimeSync
+
+	GuestProvisioning GuestProvisioning
+
+	CoreDumpDir string
+
+	AssetStore string
+
+	Kdump Kdump
+
+	GuestProfiling GuestProfiling
+
+	GuestLivepatch GuestLivepatch
+
+	GuestMemoryReclaim GuestMemoryReclaim
+
+	MetadataProxy MetadataProxy
+
+	TrafficCapture TrafficCapture
+
+	Cloneable bool
+
+	CloneSnapshotMaxAge time.Duration
+
+	PeriodicSnapshots SnapshotPolicy
+
+	ShutdownTimeouts ShutdownTimeouts
+
+	Lightweight LightweightSandbox
+
 	// Information for fields not saved:
 	// * Annotation: this is kind of casual data, we don't need casual data in persist file,
 	// 				if you know this data needs to persist, please gives it
@@ -64,6 +64,10 @@ type RootfsState struct {
 
 	// RootFStype is file system of the rootfs incase it is block device
 	FsType string
+
+	// ScratchDeviceID represents the encrypted scratch block device ID
+	// backing the writable layer of the rootfs
+	ScratchDeviceID string
 }
 
 // Process gathers data related to a container process.
@@ -33,6 +33,9 @@ type BlockDrive struct {
 	// SCSI address is in the format SCSI-Id:LUN
 	SCSIAddr string
 
+	// Serial is the serial number of the block device in the guest
+	Serial string
+
 	// NvdimmID is the nvdimm id inside the VM
 	NvdimmID string
 
@@ -45,6 +48,20 @@ type BlockDrive struct {
 	// Pmem enabled persistent memory. Use File as backing file
 	// for a nvdimm device in the guest.
 	Pmem bool
+
+	// ShareRW enables multiple qemu instances to share the File
+	ShareRW bool
+
+	// ReadOnly sets the device file readonly
+	ReadOnly bool
+
+	// SCSIPassthrough is set when File is a SCSI disk, or a multipath
+	// device over SCSI disks, the SCSI commands of the guest can be passed
+	// through to.
+	SCSIPassthrough bool
+
+	// Cache is the cache mode of the drive
+	Cache string
 }
 
 // VFIODev represents a VFIO drive used for hotplugging
@@ -60,6 +77,21 @@ type VFIODev struct {
 
 	// Sysfsdev of VFIO mediated device
 	SysfsDev string
+
+	// IsPCIe specifies device is PCIe or PCI
+	IsPCIe bool
+
+	// Class is the PCI class of the device
+	Class string
+
+	// Vendor is the PCI vendor ID of the device
+	Vendor string
+
+	// GuestPCIPath is the PCI path of the device in the guest
+	GuestPCIPath string
+
+	// Bus is the PCIe root port the device is plugged to in the guest
+	Bus string
 }
 
 // VhostUserDeviceAttrs represents data shared by most vhost-user devices
@@ -99,10 +131,29 @@ type DeviceState struct {
 	// More info in mknod(1).
 	DevType string
 
+	// HostPath is device path on the host
+	HostPath string
+
+	// ContainerPath is the device path inside the container
+	ContainerPath string
+
 	// Major, minor numbers for device.
 	Major int64
 	Minor int64
 
+	// FileMode permission bits for the device.
+	FileMode uint32
+
+	// UID is user ID in the container namespace
+	UID uint32
+
+	// GID is group ID in the container namespace
+	GID uint32
+
+	// Pmem enabled persistent memory. Use HostPath as backing file
+	// for a nvdimm device in the guest.
+	Pmem bool
+
 	// ColdPlug specifies whether the device must be cold plugged (true)
 	// or hot plugged (false).
 	ColdPlug bool
@@ -111,6 +162,9 @@ type DeviceState struct {
 	// for example, for BlockDevice, we can set DriverOptions["blockDriver"]="virtio-blk"
 	DriverOptions map[string]string
 
+	// BlockCache is the cache mode of a block device
+	BlockCache string
+
 	// ============ device driver specific data ===========
 	// BlockDrive is specific for block device driver
 	BlockDrive *BlockDrive `json:",omitempty"`
@@ -118,6 +172,10 @@ type DeviceState struct {
 	// VFIODev is specific VFIO device driver
 	VFIODevs []*VFIODev `json:",omitempty"`
 
+	// VFIOHostDrivers are the host drivers of the devices of the IOMMU
+	// group of a VFIO device bound to vfio-pci, by address.
+	VFIOHostDrivers map[string]string `json:",omitempty"`
+
 	// VhostUserDeviceAttrs is specific for vhost-user device driver
 	VhostUserDev *VhostUserDeviceAttrs `json:",omitempty"`
 	// ============ end device driver specific data ===========
@@ -20,6 +20,12 @@ type Bridge struct {
 	Addr int
 }
 
+// FileOwner is the owner of a host file.
+type FileOwner struct {
+	UID int
+	GID int
+}
+
 // CPUDevice represents a CPU device which was hot-added in a running VM
 type CPUDevice struct {
 	// ID is used to identify this CPU in the hypervisor options.
@@ -28,6 +34,10 @@ type CPUDevice struct {
 
 type HypervisorState struct {
 	Pid int
+	// PidStartTime is the start time of the hypervisor process, in clock
+	// ticks since boot, telling it apart from a later process reusing
+	// its pid.
+	PidStartTime uint64
 	// Type of hypervisor, E.g. qemu/firecracker/acrn.
 	Type          string
 	BlockIndexMap map[int]struct{}
@@ -45,4 +55,10 @@ type HypervisorState struct {
 
 	// clh sepcific: refer to 'virtcontainers/clh.go:CloudHypervisorState'
 	APISocket string
+
+	// VMMIsolationID is the host user and group ID of an isolated VMM.
+	VMMIsolationID int
+	// VMMIsolationOwners are the original owners of the host files handed
+	// over to an isolated VMM, indexed by path.
+	VMMIsolationOwners map[string]FileOwner
 }
@@ -34,3 +34,10 @@ type PersistDriver interface {
 	// It will contain all guest vm sockets and shared mountpoints.
 	RunVMStoragePath() string
 }
+
+// SandboxLister is implemented by the persist drivers which can list the
+// sandboxes they store without scanning the RunStoragePath directory.
+type SandboxLister interface {
+	// ListSandboxes returns the IDs of the sandboxes stored.
+	ListSandboxes() ([]string, error)
+}
@@ -43,6 +43,7 @@ type PhysicalEndpoint struct {
 	BDF            string
 	Driver         string
 	VendorDeviceID string
+	DeviceID       string
 }
 
 type MacvtapEndpoint struct {
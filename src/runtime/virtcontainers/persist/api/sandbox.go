@@ -6,6 +6,10 @@
 
 package persistapi
 
+import (
+	"time"
+)
+
 // ============= sandbox level resources =============
 
 // AgentState save agent state data
@@ -15,6 +19,27 @@ type AgentState struct {
 
 	// URL to connect to agent
 	URL string
+
+	// APIVersion is the API version the agent reported
+	APIVersion string
+}
+
+// ResizeState is the size a sandbox was last resized to.
+// Refs: virtcontainers/resize_debounce.go:resizeState
+type ResizeState struct {
+	VCPUs      uint32
+	MemoryMB   uint32
+	LastResize time.Time
+}
+
+// HotplugPlan is the hotplug capacity planned for a sandbox VM.
+// Refs: virtcontainers/hotplug_plan.go:HotplugPlan
+type HotplugPlan struct {
+	VCPUs       uint32
+	MaxVCPUs    uint32
+	MemorySlots uint32
+	Devices     uint32
+	Bridges     uint32
 }
 
 // SandboxState contains state information of sandbox
@@ -32,6 +57,10 @@ type SandboxState struct {
 	// GuestMemoryHotplugProbe determines whether guest kernel supports memory hotplug probe interface
 	GuestMemoryHotplugProbe bool
 
+	// GuestSeccompSupported tells whether the agent applies the seccomp
+	// profiles of the containers
+	GuestSeccompSupported bool
+
 	// SandboxContainer specifies which container is used to start the sandbox/vm
 	SandboxContainer string
 
@@ -50,6 +79,20 @@ type SandboxState struct {
 	// HypervisorState saves hypervisor specific data
 	HypervisorState HypervisorState
 
+	// HypervisorVersion is the version of the hypervisor the VM was
+	// started with.
+	HypervisorVersion string
+
+	// HypervisorConfigDigest is the digest of the hypervisor configuration
+	// the VM was started with.
+	HypervisorConfigDigest string
+
+	// Resize is the size the sandbox was last resized to
+	Resize ResizeState
+
+	// HotplugPlan is the hotplug capacity planned when the VM was created
+	HotplugPlan HotplugPlan
+
 	// AgentState saves state data of agent
 	AgentState AgentState
 
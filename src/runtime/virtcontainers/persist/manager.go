@@ -8,18 +8,23 @@ package persist
 
 import (
 	"fmt"
+	"os"
+	"sync"
 
 	exp "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/experimental"
 	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/fs"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/plugin/db"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/rootless"
 )
 
-type initFunc (func() (persistapi.PersistDriver, error))
+// InitFunc returns a new PersistDriver.
+type InitFunc func() (persistapi.PersistDriver, error)
 
 const (
 	RootFSName     = "fs"
 	RootlessFSName = "rootlessfs"
+	DBName         = "db"
 )
 
 var (
@@ -29,12 +34,18 @@ var (
 		Description: "This is a new storage driver which reorganized disk data structures, it has to be an experimental feature since it breaks backward compatibility.",
 		ExpRelease:  "2.0",
 	}
-	expErr           error
-	supportedDrivers = map[string]initFunc{
+	expErr error
+
+	// driversLock protects supportedDrivers and driverName
+	driversLock      sync.RWMutex
+	supportedDrivers = map[string]InitFunc{
 
 		RootFSName:     fs.Init,
 		RootlessFSName: fs.RootlessInit,
+		DBName:         db.Init,
 	}
+	// driverName is the driver GetDriver returns, the FS one if empty.
+	driverName  string
 	mockTesting = false
 )
 
@@ -46,13 +57,54 @@ func EnableMockTesting() {
 	mockTesting = true
 }
 
+// RegisterDriver registers an external PersistDriver under name, for
+// GetDriverByName and SetDriver to find it. Drivers usually register from
+// the init function of their package.
+func RegisterDriver(name string, f InitFunc) error {
+	if name == "" || f == nil {
+		return fmt.Errorf("storage driver name and init function required")
+	}
+
+	driversLock.Lock()
+	defer driversLock.Unlock()
+
+	if _, ok := supportedDrivers[name]; ok {
+		return fmt.Errorf("storage driver %q already registered", name)
+	}
+
+	supportedDrivers[name] = f
+	return nil
+}
+
+// SetDriver makes GetDriver return the driver registered under name. The
+// FS drivers are returned again for "fs".
+func SetDriver(name string) error {
+	driversLock.Lock()
+	defer driversLock.Unlock()
+
+	if _, ok := supportedDrivers[name]; !ok {
+		return fmt.Errorf("failed to get storage driver %q", name)
+	}
+
+	if name == RootFSName {
+		name = ""
+	}
+
+	driverName = name
+	return nil
+}
+
 // GetDriver returns new PersistDriver according to driver name
 func GetDriverByName(name string) (persistapi.PersistDriver, error) {
 	if expErr != nil {
 		return nil, expErr
 	}
 
-	if f, ok := supportedDrivers[name]; ok {
+	driversLock.RLock()
+	f, ok := supportedDrivers[name]
+	driversLock.RUnlock()
+
+	if ok {
 		return f()
 	}
 
@@ -71,15 +123,37 @@ func GetDriver() (persistapi.PersistDriver, error) {
 		return fs.MockFSInit()
 	}
 
+	driversLock.RLock()
+	name := driverName
+	driversLock.RUnlock()
+
+	if name != "" {
+		return GetDriverByName(name)
+	}
+
 	if rootless.IsRootless() {
-		if f, ok := supportedDrivers[RootlessFSName]; ok {
-			return f()
-		}
+		return GetDriverByName(RootlessFSName)
 	}
 
-	if f, ok := supportedDrivers[RootFSName]; ok {
-		return f()
+	return GetDriverByName(RootFSName)
+}
+
+// ListSandboxes returns the IDs of the sandboxes stored by driver, from the
+// driver itself if it can list them, or from its RunStoragePath directory.
+func ListSandboxes(driver persistapi.PersistDriver) ([]string, error) {
+	if lister, ok := driver.(persistapi.SandboxLister); ok {
+		return lister.ListSandboxes()
+	}
+
+	dir, err := os.Open(driver.RunStoragePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			// No sandbox directory is not an error
+			return nil, nil
+		}
+		return nil, err
 	}
+	defer dir.Close()
 
-	return nil, fmt.Errorf("Could not find a FS driver")
+	return dir.Readdirnames(0)
 }
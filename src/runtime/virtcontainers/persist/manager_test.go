@@ -55,3 +55,48 @@ func TestGetDriver(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(expectedFS, fsd)
 }
+
+func TestRegisterDriver(t *testing.T) {
+	assert := assert.New(t)
+	defer func() {
+		delete(supportedDrivers, "test")
+		driverName = ""
+	}()
+
+	assert.Error(RegisterDriver("", fs.Init))
+	assert.Error(RegisterDriver(RootFSName, fs.Init))
+	assert.Error(SetDriver("test"))
+
+	assert.NoError(RegisterDriver("test", fs.MockFSInit))
+	assert.Error(RegisterDriver("test", fs.MockFSInit))
+
+	driver, err := GetDriverByName("test")
+	assert.NoError(err)
+	assert.Equal(fs.MockRunStoragePath(), driver.RunStoragePath())
+
+	// The selected driver is returned by GetDriver
+	assert.NoError(SetDriver("test"))
+	driver, err = GetDriver()
+	assert.NoError(err)
+	assert.Equal(fs.MockRunStoragePath(), driver.RunStoragePath())
+
+	assert.NoError(SetDriver(RootFSName))
+	assert.Empty(driverName)
+}
+
+func TestListSandboxes(t *testing.T) {
+	assert := assert.New(t)
+
+	driver, err := fs.MockFSInit()
+	assert.NoError(err)
+	defer fs.MockStorageDestroy()
+
+	ids, err := ListSandboxes(driver)
+	assert.NoError(err)
+	assert.Empty(ids)
+
+	assert.NoError(driver.ToDisk(persistapi.SandboxState{SandboxContainer: "sb1"}, nil))
+	ids, err = ListSandboxes(driver)
+	assert.NoError(err)
+	assert.Equal([]string{"sb1"}, ids)
+}
@@ -0,0 +1,439 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package db is a persist driver keeping the state of all the sandboxes of
+// the host in a single database file, instead of a JSON file per sandbox and
+// per container.
+//
+// The database is a log: each write of a sandbox state appends a record,
+// and the index of the last record of each sandbox is rebuilt from the
+// record headers when the database is opened. Deleting a sandbox appends an
+// empty record. The live records are copied to a new database once the
+// stale ones take most of the file. The sandbox directories are still
+// created, for the sandbox locks and the files of the VM.
+package db
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/fs"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/rootless"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// dbFile is the file name of the database, next to the sandbox
+	// directories.
+	dbFile = "persist.db"
+
+	// fileMode is the permission bits used for creating a file
+	fileMode = os.FileMode(0600)
+
+	// dirMode is the permission bits used for creating a directory
+	dirMode = os.FileMode(0700) | os.ModeDir
+
+	// headerSize is the size of a record header: the sizes of the sandbox
+	// ID and of the state, and the checksum of both.
+	headerSize = 12
+
+	// compactMinSize is the size of the database below which it is never
+	// compacted.
+	compactMinSize = 1 << 20
+)
+
+var dbLog = logrus.WithField("source", "virtcontainers/persist/plugin/db")
+
+// DB storage driver implementation. The sandbox directories, the locks and
+// the global data are handled by the FS driver.
+type DB struct {
+	persistapi.PersistDriver
+}
+
+// record is the state of a sandbox, as stored in a record.
+type record struct {
+	Sandbox    persistapi.SandboxState
+	Containers map[string]persistapi.ContainerState
+}
+
+// recordRef locates the last record of a sandbox in the database.
+type recordRef struct {
+	offset int64
+	size   int64
+}
+
+// index locates the last record of each sandbox of the database.
+type index struct {
+	records map[string]recordRef
+
+	// size is the size of the valid records of the database, and live the
+	// size of the last record of each sandbox.
+	size int64
+	live int64
+}
+
+// Init DB persist driver and return abstract PersistDriver
+func Init() (persistapi.PersistDriver, error) {
+	var base persistapi.PersistDriver
+	var err error
+
+	if rootless.IsRootless() {
+		base, err = fs.RootlessInit()
+	} else {
+		base, err = fs.Init()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not create DB driver: %v", err)
+	}
+
+	return newDB(base), nil
+}
+
+func newDB(base persistapi.PersistDriver) *DB {
+	return &DB{base}
+}
+
+// Logger returns a logrus logger appropriate for logging Store messages
+func (db *DB) Logger() *logrus.Entry {
+	return dbLog.WithFields(logrus.Fields{
+		"subsystem": "persist",
+		"driver":    "db",
+	})
+}
+
+func (db *DB) path() string {
+	return filepath.Join(filepath.Dir(db.RunStoragePath()), dbFile)
+}
+
+// lock locks the database, and returns the function unlocking it. The
+// database file is replaced when it is compacted, the lock is taken on a
+// file of its own.
+func (db *DB) lock(exclusive bool) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(db.path()), dirMode); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(db.path()+".lock", os.O_RDWR|os.O_CREATE, fileMode)
+	if err != nil {
+		return nil, err
+	}
+
+	lockType := syscall.LOCK_SH
+	if exclusive {
+		lockType = syscall.LOCK_EX
+	}
+
+	if err := syscall.Flock(int(f.Fd()), lockType); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
+
+// readIndex reads the record headers of the database. A record cut short
+// by a crash ends the database.
+func readIndex(f *os.File) (*index, error) {
+	idx := &index{records: make(map[string]recordRef)}
+
+	r := bufio.NewReader(f)
+	header := make([]byte, headerSize)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return idx, nil
+			}
+			return nil, err
+		}
+
+		idSize := int64(binary.LittleEndian.Uint32(header[0:4]))
+		stateSize := int64(binary.LittleEndian.Uint32(header[4:8]))
+
+		id := make([]byte, idSize)
+		if _, err := io.ReadFull(r, id); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return idx, nil
+			}
+			return nil, err
+		}
+
+		discarded, err := r.Discard(int(stateSize))
+		if int64(discarded) != stateSize {
+			return idx, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		size := headerSize + idSize + stateSize
+		if old, ok := idx.records[string(id)]; ok {
+			idx.live -= old.size
+		}
+
+		if stateSize == 0 {
+			delete(idx.records, string(id))
+		} else {
+			idx.records[string(id)] = recordRef{offset: idx.size, size: size}
+			idx.live += size
+		}
+		idx.size += size
+	}
+}
+
+// open opens the database and reads its index. A missing database is an
+// empty one.
+func (db *DB) open() (*os.File, *index, error) {
+	f, err := os.Open(db.path())
+	if os.IsNotExist(err) {
+		return nil, &index{records: make(map[string]recordRef)}, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	idx, err := readIndex(f)
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	return f, idx, nil
+}
+
+// readRecord reads and checks the record ref points to.
+func readRecord(f *os.File, ref recordRef) (string, []byte, error) {
+	data := make([]byte, ref.size)
+	if _, err := f.ReadAt(data, ref.offset); err != nil {
+		return "", nil, err
+	}
+
+	idSize := binary.LittleEndian.Uint32(data[0:4])
+	sum := binary.LittleEndian.Uint32(data[8:12])
+	if crc32.ChecksumIEEE(data[headerSize:]) != sum {
+		return "", nil, fmt.Errorf("corrupted record at offset %d of %s", ref.offset, f.Name())
+	}
+
+	payload := data[headerSize:]
+	return string(payload[:idSize]), payload[idSize:], nil
+}
+
+func encodeRecord(sid string, state []byte) []byte {
+	data := make([]byte, headerSize+len(sid)+len(state))
+	binary.LittleEndian.PutUint32(data[0:4], uint32(len(sid)))
+	binary.LittleEndian.PutUint32(data[4:8], uint32(len(state)))
+	copy(data[headerSize:], sid)
+	copy(data[headerSize+len(sid):], state)
+	binary.LittleEndian.PutUint32(data[8:12], crc32.ChecksumIEEE(data[headerSize:]))
+	return data
+}
+
+// write appends the record of sandbox sid, an empty state deleting it, and
+// compacts the database if needed.
+func (db *DB) write(sid string, state []byte) error {
+	unlock, err := db.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	f, idx, err := db.open()
+	if err != nil {
+		return err
+	}
+	if f != nil {
+		f.Close()
+	}
+
+	if _, ok := idx.records[sid]; !ok && len(state) == 0 {
+		return nil
+	}
+
+	w, err := os.OpenFile(db.path(), os.O_WRONLY|os.O_CREATE, fileMode)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	// Drop the record a crash cut short, if any
+	if err := w.Truncate(idx.size); err != nil {
+		return err
+	}
+
+	data := encodeRecord(sid, state)
+	if _, err := w.WriteAt(data, idx.size); err != nil {
+		return err
+	}
+
+	size := int64(len(data))
+	if old, ok := idx.records[sid]; ok {
+		idx.live -= old.size
+	}
+	if len(state) == 0 {
+		delete(idx.records, sid)
+	} else {
+		idx.records[sid] = recordRef{offset: idx.size, size: size}
+		idx.live += size
+	}
+	idx.size += size
+
+	if idx.size > compactMinSize && idx.size > 2*idx.live {
+		if err := db.compact(idx); err != nil {
+			// The database is still valid, only larger than needed
+			db.Logger().WithError(err).Warn("failed to compact the database")
+		}
+	}
+
+	return nil
+}
+
+// compact replaces the database with one holding the last record of each
+// sandbox only. The database must be locked.
+func (db *DB) compact(idx *index) (retErr error) {
+	f, err := os.Open(db.path())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tmp := db.path() + ".tmp"
+	w, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fileMode)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		w.Close()
+		if retErr != nil {
+			os.Remove(tmp)
+		}
+	}()
+
+	bw := bufio.NewWriter(w)
+	for _, ref := range idx.records {
+		if _, err := io.Copy(bw, io.NewSectionReader(f, ref.offset, ref.size)); err != nil {
+			return err
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, db.path())
+}
+
+// ToDisk sandboxState and containerState to disk
+func (db *DB) ToDisk(ss persistapi.SandboxState, cs map[string]persistapi.ContainerState) error {
+	id := ss.SandboxContainer
+	if id == "" {
+		return fmt.Errorf("sandbox container id required")
+	}
+
+	// The sandbox directory holds the sandbox lock and the files of the VM
+	if err := os.MkdirAll(filepath.Join(db.RunStoragePath(), id), dirMode); err != nil {
+		return err
+	}
+
+	state, err := json.Marshal(record{Sandbox: ss, Containers: cs})
+	if err != nil {
+		return err
+	}
+
+	return db.write(id, state)
+}
+
+// FromDisk restores state for sandbox with name sid
+func (db *DB) FromDisk(sid string) (persistapi.SandboxState, map[string]persistapi.ContainerState, error) {
+	ss := persistapi.SandboxState{}
+	if sid == "" {
+		return ss, nil, fmt.Errorf("restore requires sandbox id")
+	}
+
+	unlock, err := db.lock(false)
+	if err != nil {
+		return ss, nil, err
+	}
+	defer unlock()
+
+	f, idx, err := db.open()
+	if err != nil {
+		return ss, nil, err
+	}
+
+	ref, ok := idx.records[sid]
+	if !ok {
+		if f != nil {
+			f.Close()
+		}
+		return ss, nil, &os.PathError{Op: "read", Path: filepath.Join(db.path(), sid), Err: os.ErrNotExist}
+	}
+	defer f.Close()
+
+	_, state, err := readRecord(f, ref)
+	if err != nil {
+		return ss, nil, err
+	}
+
+	var r record
+	if err := json.Unmarshal(state, &r); err != nil {
+		return ss, nil, err
+	}
+
+	if r.Containers == nil {
+		r.Containers = make(map[string]persistapi.ContainerState)
+	}
+
+	return r.Sandbox, r.Containers, nil
+}
+
+// Destroy removes everything from disk
+func (db *DB) Destroy(sandboxID string) error {
+	if sandboxID == "" {
+		return fmt.Errorf("sandbox container id required")
+	}
+
+	if err := db.write(sandboxID, nil); err != nil {
+		return err
+	}
+
+	return db.PersistDriver.Destroy(sandboxID)
+}
+
+// ListSandboxes returns the IDs of the sandboxes stored, from the index of
+// the database.
+func (db *DB) ListSandboxes() ([]string, error) {
+	unlock, err := db.lock(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	f, idx, err := db.open()
+	if err != nil {
+		return nil, err
+	}
+	if f != nil {
+		f.Close()
+	}
+
+	ids := make([]string, 0, len(idx.records))
+	for id := range idx.records {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
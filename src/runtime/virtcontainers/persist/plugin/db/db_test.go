@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package db
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDB(t *testing.T) *DB {
+	base, err := fs.MockFSInit()
+	assert.NoError(t, err)
+
+	return newDB(base)
+}
+
+func TestDBToDiskFromDisk(t *testing.T) {
+	assert := assert.New(t)
+
+	db := newTestDB(t)
+	defer fs.MockStorageDestroy()
+
+	_, _, err := db.FromDisk("sb1")
+	assert.True(os.IsNotExist(err))
+
+	ss := persistapi.SandboxState{SandboxContainer: "sb1", State: "running"}
+	cs := map[string]persistapi.ContainerState{"c1": {State: "ready"}}
+	assert.NoError(db.ToDisk(ss, cs))
+	assert.NoError(db.ToDisk(persistapi.SandboxState{SandboxContainer: "sb2"}, nil))
+
+	// The sandbox directory holds the sandbox lock
+	unlock, err := db.Lock("sb1", true)
+	assert.NoError(err)
+	assert.NoError(unlock())
+
+	ss.State = "paused"
+	delete(cs, "c1")
+	cs["c2"] = persistapi.ContainerState{State: "running"}
+	assert.NoError(db.ToDisk(ss, cs))
+
+	rss, rcs, err := db.FromDisk("sb1")
+	assert.NoError(err)
+	assert.Equal(ss, rss)
+	assert.Equal(cs, rcs)
+
+	_, rcs, err = db.FromDisk("sb2")
+	assert.NoError(err)
+	assert.Empty(rcs)
+
+	ids, err := db.ListSandboxes()
+	assert.NoError(err)
+	assert.Equal([]string{"sb1", "sb2"}, ids)
+
+	assert.NoError(db.Destroy("sb1"))
+	assert.NoError(db.Destroy("sb1"))
+	_, _, err = db.FromDisk("sb1")
+	assert.True(os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(db.RunStoragePath(), "sb1"))
+	assert.True(os.IsNotExist(err))
+
+	ids, err = db.ListSandboxes()
+	assert.NoError(err)
+	assert.Equal([]string{"sb2"}, ids)
+
+	assert.Error(db.ToDisk(persistapi.SandboxState{}, nil))
+}
+
+func TestDBTornRecord(t *testing.T) {
+	assert := assert.New(t)
+
+	db := newTestDB(t)
+	defer fs.MockStorageDestroy()
+
+	assert.NoError(db.ToDisk(persistapi.SandboxState{SandboxContainer: "sb1"}, nil))
+
+	info, err := os.Stat(db.path())
+	assert.NoError(err)
+
+	// A record cut short by a crash is ignored, then overwritten
+	f, err := os.OpenFile(db.path(), os.O_WRONLY|os.O_APPEND, fileMode)
+	assert.NoError(err)
+	_, err = f.Write(encodeRecord("sb2", []byte(`{"Sandbox":{}}`))[:20])
+	assert.NoError(err)
+	f.Close()
+
+	ids, err := db.ListSandboxes()
+	assert.NoError(err)
+	assert.Equal([]string{"sb1"}, ids)
+
+	assert.NoError(db.ToDisk(persistapi.SandboxState{SandboxContainer: "sb3"}, nil))
+	ids, err = db.ListSandboxes()
+	assert.NoError(err)
+	assert.Equal([]string{"sb1", "sb3"}, ids)
+
+	_, _, err = db.FromDisk("sb3")
+	assert.NoError(err)
+
+	// Nothing is appended when deleting an unknown sandbox
+	info2, err := os.Stat(db.path())
+	assert.NoError(err)
+	assert.NoError(db.Destroy("sb4"))
+	info3, err := os.Stat(db.path())
+	assert.NoError(err)
+	assert.Equal(info2.Size(), info3.Size())
+	assert.True(info.Size() < info2.Size())
+}
+
+func TestDBCompact(t *testing.T) {
+	assert := assert.New(t)
+
+	db := newTestDB(t)
+	defer fs.MockStorageDestroy()
+
+	ss := persistapi.SandboxState{SandboxContainer: "sb1"}
+	for i := 0; i < 5000; i++ {
+		ss.State = fmt.Sprintf("state-%d", i)
+		assert.NoError(db.ToDisk(ss, nil))
+	}
+
+	// The stale records are dropped
+	info, err := os.Stat(db.path())
+	assert.NoError(err)
+	assert.True(info.Size() < 2*compactMinSize)
+
+	rss, _, err := db.FromDisk("sb1")
+	assert.NoError(err)
+	assert.Equal("state-4999", rss.State)
+}
@@ -61,12 +61,14 @@ func TestSandboxRestore(t *testing.T) {
 	sandbox.state.State = types.StateString("running")
 	sandbox.state.GuestMemoryBlockSizeMB = uint32(1024)
 	sandbox.state.BlockIndexMap[2] = struct{}{}
+	sandbox.hotplugPlan = HotplugPlan{VCPUs: 2, MaxVCPUs: 4, MemorySlots: 3, Devices: 8, Bridges: 1}
 	// flush data to disk
 	err = sandbox.Save()
 	assert.Nil(err)
 
 	// empty the sandbox
 	sandbox.state = types.SandboxState{}
+	sandbox.hotplugPlan = HotplugPlan{}
 	if sandbox.newStore, err = persist.GetDriver(); err != nil || sandbox.newStore == nil {
 		t.Fatal("failed to get persist driver")
 	}
@@ -78,4 +80,5 @@ func TestSandboxRestore(t *testing.T) {
 	assert.Equal(sandbox.state.GuestMemoryBlockSizeMB, uint32(1024))
 	assert.Equal(len(sandbox.state.BlockIndexMap), 1)
 	assert.Equal(sandbox.state.BlockIndexMap[2], struct{}{})
+	assert.Equal(HotplugPlan{VCPUs: 2, MaxVCPUs: 4, MemorySlots: 3, Devices: 8, Bridges: 1}, sandbox.hotplugPlan)
 }
@@ -29,6 +29,10 @@ type PhysicalEndpoint struct {
 	Driver             string
 	VendorDeviceID     string
 	PCIAddr            string
+
+	// DeviceID is the VFIO device of the sandbox the interface is passed
+	// through as.
+	DeviceID string
 }
 
 // Properties returns the properties of the physical interface.
@@ -74,6 +78,56 @@ func (endpoint *PhysicalEndpoint) NetworkPair() *NetworkInterfacePair {
 // Attach for physical endpoint binds the physical network interface to
 // vfio-pci and adds device to the hypervisor with vfio-passthrough.
 func (endpoint *PhysicalEndpoint) Attach(s *Sandbox) error {
+	return endpoint.attachVFIO(s, true)
+}
+
+// Detach for physical endpoint unbinds the physical network interface from vfio-pci
+// and binds it back to the saved host driver.
+func (endpoint *PhysicalEndpoint) Detach(netNsCreated bool, netNsPath string) error {
+	// Bind back the physical network interface to host.
+	// We need to do this even if a new network namespace has not
+	// been created by virtcontainers.
+
+	// We do not need to enter the network namespace to bind back the
+	// physical interface to host driver.
+	return bindNICToHost(endpoint)
+}
+
+// HotAttach for physical endpoint binds the physical network interface, such
+// as an SR-IOV virtual function, to vfio-pci and hot plugs it to the VM with
+// vfio-passthrough.
+func (endpoint *PhysicalEndpoint) HotAttach(s *Sandbox) error {
+	if caps := s.hypervisor.capabilities(); !caps.IsVFIOHotplugSupported() {
+		return fmt.Errorf("PhysicalEndpoint hot attach needs VFIO hotplug support")
+	}
+
+	if err := endpoint.attachVFIO(s, false); err != nil {
+		if bindErr := bindNICToHost(endpoint); bindErr != nil {
+			networkLogger().WithError(bindErr).WithField("device-bdf", endpoint.BDF).
+				Error("Could not bind back physical interface to host driver")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// HotDetach for physical endpoint hot unplugs the VFIO device from the VM,
+// then binds the physical network interface back to its host driver.
+func (endpoint *PhysicalEndpoint) HotDetach(s *Sandbox, netNsCreated bool, netNsPath string) error {
+	if endpoint.DeviceID != "" {
+		if err := s.RemoveDevice(endpoint.DeviceID); err != nil {
+			return err
+		}
+		endpoint.DeviceID = ""
+	}
+
+	return bindNICToHost(endpoint)
+}
+
+// attachVFIO unbinds the physical network interface from its host driver,
+// binds it to vfio-pci and adds it to the sandbox as a VFIO device.
+func (endpoint *PhysicalEndpoint) attachVFIO(s *Sandbox, coldPlug bool) error {
 	// Unbind physical interface from host driver and bind to vfio
 	// so that it can be passed to qemu.
 	vfioPath, err := bindNICToVFIO(endpoint)
@@ -91,33 +145,16 @@ func (endpoint *PhysicalEndpoint) Attach(s *Sandbox) error {
 		DevType:       string(c.Type),
 		Major:         c.Major,
 		Minor:         c.Minor,
-		ColdPlug:      true,
+		ColdPlug:      coldPlug,
 	}
 
-	_, err = s.AddDevice(d)
-	return err
-}
-
-// Detach for physical endpoint unbinds the physical network interface from vfio-pci
-// and binds it back to the saved host driver.
-func (endpoint *PhysicalEndpoint) Detach(netNsCreated bool, netNsPath string) error {
-	// Bind back the physical network interface to host.
-	// We need to do this even if a new network namespace has not
-	// been created by virtcontainers.
-
-	// We do not need to enter the network namespace to bind back the
-	// physical interface to host driver.
-	return bindNICToHost(endpoint)
-}
-
-// HotAttach for physical endpoint not supported yet
-func (endpoint *PhysicalEndpoint) HotAttach(h hypervisor) error {
-	return fmt.Errorf("PhysicalEndpoint does not support Hot attach")
-}
+	dev, err := s.AddDevice(d)
+	if err != nil {
+		return err
+	}
 
-// HotDetach for physical endpoint not supported yet
-func (endpoint *PhysicalEndpoint) HotDetach(h hypervisor, netNsCreated bool, netNsPath string) error {
-	return fmt.Errorf("PhysicalEndpoint does not support Hot detach")
+	endpoint.DeviceID = dev.DeviceID()
+	return nil
 }
 
 // isPhysicalIface checks if an interface is a physical device.
@@ -221,6 +258,7 @@ func (endpoint *PhysicalEndpoint) save() persistapi.NetworkEndpoint {
 			BDF:            endpoint.BDF,
 			Driver:         endpoint.Driver,
 			VendorDeviceID: endpoint.VendorDeviceID,
+			DeviceID:       endpoint.DeviceID,
 		},
 	}
 }
@@ -232,6 +270,7 @@ func (endpoint *PhysicalEndpoint) load(s persistapi.NetworkEndpoint) {
 		endpoint.BDF = s.Physical.BDF
 		endpoint.Driver = s.Physical.Driver
 		endpoint.VendorDeviceID = s.Physical.VendorDeviceID
+		endpoint.DeviceID = s.Physical.DeviceID
 	}
 }
 
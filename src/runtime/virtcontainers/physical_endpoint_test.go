@@ -12,6 +12,7 @@ import (
 	"github.com/containernetworking/plugins/pkg/ns"
 	"github.com/containernetworking/plugins/pkg/testutils"
 	ktu "github.com/kata-containers/kata-containers/src/runtime/pkg/katatestutils"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
 	"github.com/stretchr/testify/assert"
 	"github.com/vishvananda/netlink"
 	"github.com/vishvananda/netns"
@@ -24,10 +25,14 @@ func TestPhysicalEndpoint_HotAttach(t *testing.T) {
 		HardAddr:  net.HardwareAddr{0x02, 0x00, 0xca, 0xfe, 0x00, 0x04}.String(),
 	}
 
-	h := &mockHypervisor{}
+	s := &Sandbox{
+		hypervisor: &mockHypervisor{},
+	}
 
-	err := v.HotAttach(h)
+	// The interface cannot be bound to vfio-pci
+	err := v.HotAttach(s)
 	assert.Error(err)
+	assert.Empty(v.DeviceID)
 }
 
 func TestPhysicalEndpoint_HotDetach(t *testing.T) {
@@ -37,9 +42,20 @@ func TestPhysicalEndpoint_HotDetach(t *testing.T) {
 		HardAddr:  net.HardwareAddr{0x02, 0x00, 0xca, 0xfe, 0x00, 0x04}.String(),
 	}
 
-	h := &mockHypervisor{}
+	s := &Sandbox{
+		hypervisor: &mockHypervisor{},
+		devManager: manager.NewDeviceManager(manager.VirtioSCSI, false, "", nil),
+	}
+
+	// The VFIO device of the interface is unknown
+	v.DeviceID = "vfio-0"
+	err := v.HotDetach(s, true, "")
+	assert.Error(err)
+	assert.Equal("vfio-0", v.DeviceID)
 
-	err := v.HotDetach(h, true, "")
+	// The interface cannot be bound to its host driver
+	v.DeviceID = ""
+	err = v.HotDetach(s, true, "")
 	assert.Error(err)
 }
 
@@ -90,3 +106,22 @@ func TestIsPhysicalIface(t *testing.T) {
 	assert.NoError(err)
 	assert.False(isPhysical)
 }
+
+func TestPhysicalEndpointSaveLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	v := &PhysicalEndpoint{
+		BDF:            "0000:3b:02.1",
+		Driver:         "iavf",
+		VendorDeviceID: "8086 154c",
+		DeviceID:       "vfio-0",
+	}
+
+	loaded := &PhysicalEndpoint{}
+	loaded.load(v.save())
+	assert.Equal(v.BDF, loaded.BDF)
+	assert.Equal(v.Driver, loaded.Driver)
+	assert.Equal(v.VendorDeviceID, loaded.VendorDeviceID)
+	assert.Equal(v.DeviceID, loaded.DeviceID)
+	assert.Equal(PhysicalEndpointType, loaded.Type())
+}
@@ -424,7 +424,13 @@ type Hooks struct {
 	// Poststart is a list of hooks to be run after the container process is started.
 	Poststart []Hook `protobuf:"bytes,2,rep,name=Poststart,proto3" json:"Poststart"`
 	// Poststop is a list of hooks to be run after the container process exits.
-	Poststop             []Hook   `protobuf:"bytes,3,rep,name=Poststop,proto3" json:"Poststop"`
+	Poststop []Hook `protobuf:"bytes,3,rep,name=Poststop,proto3" json:"Poststop"`
+	// CreateRuntime is a list of hooks to be run after the container is created, before pivot_root.
+	CreateRuntime []Hook `protobuf:"bytes,4,rep,name=CreateRuntime,proto3" json:"CreateRuntime"`
+	// CreateContainer is a list of hooks to be run in the container namespaces, before pivot_root.
+	CreateContainer []Hook `protobuf:"bytes,5,rep,name=CreateContainer,proto3" json:"CreateContainer"`
+	// StartContainer is a list of hooks to be run in the container, before the container process is executed.
+	StartContainer       []Hook   `protobuf:"bytes,6,rep,name=StartContainer,proto3" json:"StartContainer"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -805,10 +811,12 @@ type LinuxResources struct {
 	// Hugetlb limit (in bytes)
 	HugepageLimits []LinuxHugepageLimit `protobuf:"bytes,6,rep,name=HugepageLimits,proto3" json:"HugepageLimits"`
 	// Network restriction configuration
-	Network              *LinuxNetwork `protobuf:"bytes,7,opt,name=Network,proto3" json:"Network,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Network *LinuxNetwork `protobuf:"bytes,7,opt,name=Network,proto3" json:"Network,omitempty"`
+	// Unified resources of the cgroup v2 hierarchy, by file name
+	Unified              map[string]string `protobuf:"bytes,8,rep,name=Unified,proto3" json:"Unified,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *LinuxResources) Reset()      { *m = LinuxResources{} }
@@ -1307,6 +1315,7 @@ type LinuxSeccomp struct {
 	DefaultAction        string         `protobuf:"bytes,1,opt,name=DefaultAction,proto3" json:"DefaultAction,omitempty"`
 	Architectures        []string       `protobuf:"bytes,2,rep,name=Architectures,proto3" json:"Architectures,omitempty"`
 	Syscalls             []LinuxSyscall `protobuf:"bytes,3,rep,name=Syscalls,proto3" json:"Syscalls"`
+	Flags                []string       `protobuf:"bytes,4,rep,name=Flags,proto3" json:"Flags,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -1387,12 +1396,14 @@ func (m *LinuxSeccompArg) XXX_DiscardUnknown() {
 var xxx_messageInfo_LinuxSeccompArg proto.InternalMessageInfo
 
 type LinuxSyscall struct {
-	Names                []string          `protobuf:"bytes,1,rep,name=Names,proto3" json:"Names,omitempty"`
-	Action               string            `protobuf:"bytes,2,opt,name=Action,proto3" json:"Action,omitempty"`
-	Args                 []LinuxSeccompArg `protobuf:"bytes,3,rep,name=Args,proto3" json:"Args"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Names  []string          `protobuf:"bytes,1,rep,name=Names,proto3" json:"Names,omitempty"`
+	Action string            `protobuf:"bytes,2,opt,name=Action,proto3" json:"Action,omitempty"`
+	Args   []LinuxSeccompArg `protobuf:"bytes,3,rep,name=Args,proto3" json:"Args"`
+	// ErrnoRet is the errno returned by the SCMP_ACT_ERRNO action, EPERM when zero.
+	ErrnoRet             uint32   `protobuf:"varint,4,opt,name=ErrnoRet,proto3" json:"ErrnoRet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *LinuxSyscall) Reset()      { *m = LinuxSyscall{} }
@@ -1488,6 +1499,7 @@ func init() {
 	proto.RegisterType((*LinuxNamespace)(nil), "grpc.LinuxNamespace")
 	proto.RegisterType((*LinuxDevice)(nil), "grpc.LinuxDevice")
 	proto.RegisterType((*LinuxResources)(nil), "grpc.LinuxResources")
+	proto.RegisterMapType((map[string]string)(nil), "grpc.LinuxResources.UnifiedEntry")
 	proto.RegisterType((*LinuxMemory)(nil), "grpc.LinuxMemory")
 	proto.RegisterType((*LinuxCPU)(nil), "grpc.LinuxCPU")
 	proto.RegisterType((*LinuxWeightDevice)(nil), "grpc.LinuxWeightDevice")
@@ -1509,139 +1521,144 @@ func init() {
 }
 
 var fileDescriptor_e42fef2823778fc8 = []byte{
-	// 2099 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x18, 0x4b, 0x73, 0x1b, 0x49,
-	0x39, 0x23, 0xc9, 0xb2, 0xd4, 0x8a, 0xf3, 0xe8, 0xcd, 0x66, 0x87, 0xb0, 0xa5, 0xf5, 0x0e, 0x29,
-	0x30, 0x90, 0xb5, 0x8b, 0x84, 0xc7, 0xb2, 0x3c, 0xaa, 0x64, 0x3b, 0x89, 0x55, 0x1b, 0x47, 0xa2,
-	0x65, 0x6f, 0x60, 0x0f, 0x5b, 0xd5, 0x1e, 0xb5, 0xa5, 0x5e, 0x8f, 0xa6, 0xa7, 0xba, 0x5b, 0x76,
-	0xcc, 0x09, 0x6e, 0x1c, 0xa9, 0xe2, 0x17, 0x70, 0x02, 0xfe, 0x01, 0xc5, 0x89, 0x1b, 0x29, 0x4e,
-	0x1c, 0xa9, 0xa2, 0x0a, 0x88, 0xef, 0xdc, 0x39, 0x52, 0x5f, 0x3f, 0x46, 0x2d, 0xc9, 0x81, 0x0d,
-	0x7b, 0x52, 0x7f, 0xcf, 0xee, 0xef, 0xfd, 0x8d, 0x50, 0x6f, 0xc4, 0xf5, 0x78, 0x7a, 0xb4, 0x99,
-	0x8a, 0xc9, 0xd6, 0x09, 0xd5, 0xf4, 0xbd, 0x54, 0xe4, 0x9a, 0xf2, 0x9c, 0x49, 0xb5, 0x04, 0x2b,
-	0x99, 0x6e, 0xd1, 0x11, 0xcb, 0xf5, 0x56, 0x21, 0x85, 0x16, 0xa9, 0xc8, 0x94, 0x3d, 0xa9, 0x2d,
-	0x91, 0xf2, 0x4d, 0x73, 0xc4, 0xb5, 0x91, 0x2c, 0xd2, 0x3b, 0xef, 0x05, 0x6a, 0x47, 0x62, 0x24,
-	0x2c, 0xdf, 0xd1, 0xf4, 0xd8, 0x40, 0x06, 0x30, 0x27, 0x2b, 0x74, 0xa7, 0x3d, 0x12, 0x62, 0x94,
-	0xb1, 0x19, 0xd7, 0x99, 0xa4, 0x45, 0xc1, 0xa4, 0xb2, 0xf4, 0xe4, 0x4f, 0x55, 0x54, 0x1b, 0x14,
-	0x2c, 0xc5, 0x31, 0x5a, 0xfd, 0x88, 0x49, 0xc5, 0x45, 0x1e, 0x47, 0xeb, 0xd1, 0x46, 0x93, 0x78,
-	0x10, 0x7f, 0x05, 0xad, 0xf6, 0xa5, 0x48, 0x99, 0x52, 0x71, 0x65, 0x3d, 0xda, 0x68, 0xdd, 0x5f,
-	0xdb, 0x84, 0x97, 0x6c, 0x3a, 0x24, 0xf1, 0x54, 0xdc, 0x46, 0x35, 0x22, 0x84, 0x8e, 0xab, 0x86,
-	0x0b, 0x59, 0x2e, 0xc0, 0x10, 0x83, 0xc7, 0x77, 0x50, 0x63, 0x4f, 0x28, 0x9d, 0xd3, 0x09, 0x8b,
-	0x6b, 0xe6, 0x8e, 0x12, 0xc6, 0x5f, 0x45, 0xf5, 0x7d, 0x31, 0xcd, 0xb5, 0x8a, 0x57, 0xd6, 0xab,
-	0x1b, 0xad, 0xfb, 0x2d, 0x2b, 0x6d, 0x70, 0xdb, 0xb5, 0x17, 0x7f, 0x7f, 0xe7, 0x0a, 0x71, 0x0c,
-	0xf8, 0x5d, 0xb4, 0xb2, 0x27, 0xc4, 0x89, 0x8a, 0xeb, 0xe6, 0x1e, 0xc7, 0x69, 0x50, 0xc4, 0x52,
-	0xf0, 0x0f, 0x50, 0xab, 0x93, 0xe7, 0x42, 0x53, 0xcd, 0x45, 0xae, 0xe2, 0x55, 0xa3, 0xf2, 0x8b,
-	0x96, 0x11, 0xac, 0xdd, 0x0c, 0xa8, 0x0f, 0x73, 0x2d, 0xcf, 0x49, 0xc8, 0x0f, 0x37, 0x3c, 0xe1,
-	0xf9, 0xf4, 0x79, 0xdc, 0x08, 0x6f, 0x30, 0x28, 0x62, 0x29, 0xe0, 0x94, 0x81, 0xc8, 0xa8, 0xe4,
-	0x2a, 0x6e, 0x86, 0x4e, 0x71, 0x48, 0xe2, 0xa9, 0xc0, 0xf8, 0x8c, 0xe7, 0x43, 0x71, 0xa6, 0x62,
-	0x14, 0x32, 0x3a, 0x24, 0xf1, 0xd4, 0x3b, 0x3f, 0x44, 0x37, 0x16, 0x5f, 0x85, 0x6f, 0xa0, 0xea,
-	0x09, 0x3b, 0x77, 0x01, 0x81, 0x23, 0xbe, 0x85, 0x56, 0x4e, 0x69, 0x36, 0x65, 0x26, 0x14, 0x4d,
-	0x62, 0x81, 0x0f, 0x2a, 0xef, 0x47, 0xc9, 0x1f, 0xaa, 0x65, 0x9c, 0xc0, 0xd3, 0x07, 0x4c, 0x4e,
-	0x78, 0x4e, 0x33, 0x23, 0xdc, 0x20, 0x25, 0x8c, 0xbf, 0x8e, 0x5a, 0x3b, 0x22, 0x57, 0x22, 0x63,
-	0x03, 0xfe, 0x53, 0xe6, 0x42, 0xda, 0xb4, 0x8f, 0xda, 0x16, 0xcf, 0x49, 0x48, 0xc5, 0x77, 0x51,
-	0xed, 0x50, 0x31, 0x39, 0x1f, 0x52, 0xc0, 0xb8, 0x98, 0x18, 0x2a, 0xc6, 0xa8, 0xd6, 0x91, 0x23,
-	0x15, 0xd7, 0xd6, 0xab, 0x1b, 0x4d, 0x62, 0xce, 0xf0, 0xf4, 0x87, 0xf9, 0xa9, 0x89, 0x66, 0x93,
-	0xc0, 0x11, 0x30, 0x3b, 0x67, 0x43, 0x13, 0xb5, 0x26, 0x81, 0x23, 0xfe, 0x1e, 0xba, 0xba, 0x43,
-	0x0b, 0x7a, 0xc4, 0x33, 0xae, 0x39, 0x83, 0x38, 0xc1, 0x2d, 0x6f, 0x05, 0xee, 0x0e, 0xc9, 0x64,
-	0x8e, 0x19, 0x7f, 0x03, 0xad, 0x92, 0x8c, 0x4f, 0xb8, 0x56, 0x71, 0xc3, 0xc4, 0xf7, 0xa6, 0x4b,
-	0xcb, 0xde, 0xa0, 0xfb, 0x63, 0x4b, 0x71, 0x8f, 0xf4, 0x7c, 0x78, 0x03, 0x5d, 0x7f, 0x2a, 0x9e,
-	0xb2, 0xb3, 0xbe, 0xe4, 0xa7, 0x3c, 0x63, 0x23, 0x66, 0x83, 0xd7, 0x20, 0x8b, 0x68, 0xe0, 0xec,
-	0x14, 0x05, 0x95, 0x13, 0x21, 0xfb, 0x52, 0x1c, 0xf3, 0x8c, 0x99, 0xe8, 0x35, 0xc9, 0x22, 0x1a,
-	0xaf, 0xa3, 0x56, 0xaf, 0xb7, 0x3f, 0x48, 0x85, 0x64, 0x9d, 0xe1, 0xa7, 0x71, 0x6b, 0x3d, 0xda,
-	0xa8, 0x92, 0x10, 0x85, 0x13, 0x74, 0x75, 0xc0, 0x32, 0xb0, 0xe6, 0x09, 0x3d, 0x62, 0x59, 0x7c,
-	0xd5, 0x28, 0x9a, 0xc3, 0x25, 0x0f, 0x50, 0x75, 0x5b, 0x3c, 0xc7, 0xb7, 0x51, 0x7d, 0x8f, 0xf1,
-	0xd1, 0x58, 0x9b, 0xa8, 0xad, 0x11, 0x07, 0x41, 0xd4, 0x9f, 0xf1, 0xa1, 0x1e, 0x9b, 0x68, 0xad,
-	0x11, 0x0b, 0x24, 0xb9, 0x0d, 0x0e, 0x38, 0xf6, 0xb0, 0xbb, 0xeb, 0x44, 0xe0, 0x08, 0x98, 0xc7,
-	0xdd, 0x5d, 0xc7, 0x0d, 0x47, 0xfc, 0x65, 0x74, 0xad, 0x33, 0x1c, 0x72, 0xc8, 0x2d, 0x9a, 0x3d,
-	0xe6, 0x43, 0x15, 0x57, 0xd7, 0xab, 0x1b, 0x6b, 0x64, 0x01, 0x0b, 0x99, 0x03, 0x3a, 0xc3, 0x1a,
-	0xf5, 0x70, 0xf2, 0x9b, 0x08, 0xdd, 0x5c, 0x8a, 0x0a, 0x48, 0x6c, 0x8b, 0x69, 0x3e, 0xe4, 0xf9,
-	0x28, 0x8e, 0x4c, 0xb4, 0x4b, 0x18, 0xbf, 0x8d, 0x9a, 0x0f, 0x8f, 0x8f, 0x59, 0xaa, 0xf9, 0x29,
-	0x64, 0x1a, 0x10, 0x67, 0x08, 0x70, 0x5d, 0x37, 0x1f, 0x33, 0xc9, 0x35, 0x3d, 0xca, 0x98, 0x79,
-	0x50, 0x93, 0x84, 0x28, 0x90, 0xef, 0x43, 0xde, 0x6a, 0xcd, 0x86, 0x2e, 0xbb, 0x66, 0x08, 0x68,
-	0x59, 0x9d, 0xc9, 0x11, 0x67, 0xb9, 0x76, 0x69, 0xe6, 0xc1, 0xa4, 0x8b, 0x5a, 0x41, 0x1a, 0x40,
-	0x7e, 0x1e, 0x9c, 0x17, 0xcc, 0xd5, 0x91, 0x39, 0x03, 0x6e, 0x8f, 0xca, 0xa1, 0xf1, 0x51, 0x8d,
-	0x98, 0x33, 0xe0, 0x06, 0xe2, 0xd8, 0x36, 0xb0, 0x1a, 0x31, 0xe7, 0x44, 0xa0, 0x15, 0xd3, 0x77,
-	0xe0, 0xb5, 0x43, 0xa6, 0x34, 0xcf, 0x4d, 0x81, 0x3a, 0x5d, 0x21, 0x0a, 0xa2, 0xa7, 0xc4, 0x54,
-	0xa6, 0xbe, 0x38, 0x1d, 0x04, 0x6a, 0x35, 0x5c, 0x5f, 0xb5, 0xd7, 0xc3, 0x19, 0xde, 0x2e, 0x0a,
-	0xdb, 0x9d, 0xac, 0x5d, 0x1e, 0x4c, 0xbe, 0x6d, 0xbb, 0x28, 0x48, 0xf5, 0xa9, 0x1e, 0xfb, 0x47,
-	0xc3, 0x19, 0x7c, 0x4d, 0x18, 0x1d, 0x8a, 0x3c, 0x3b, 0x37, 0x77, 0x34, 0x48, 0x09, 0x27, 0xbf,
-	0x8a, 0x5c, 0x5f, 0xc4, 0xf7, 0x50, 0xa3, 0x2f, 0x99, 0xd2, 0x54, 0x6a, 0x13, 0x91, 0xb2, 0x70,
-	0x81, 0xec, 0x6a, 0xa2, 0xe4, 0xc0, 0x9b, 0xa8, 0xd9, 0x17, 0x4a, 0x5b, 0xf6, 0xca, 0x2b, 0xd8,
-	0x67, 0x2c, 0x46, 0xbb, 0x01, 0x44, 0x61, 0x42, 0x76, 0xb9, 0x76, 0xc7, 0x91, 0x7c, 0x8c, 0x6a,
-	0x80, 0xbf, 0xd4, 0x1a, 0xdf, 0x36, 0x2a, 0xcb, 0x6d, 0xa3, 0x3a, 0x6b, 0x1b, 0x31, 0x5a, 0x3d,
-	0xe0, 0x13, 0x26, 0xa6, 0xda, 0x24, 0x64, 0x95, 0x78, 0x30, 0xf9, 0xdd, 0x8a, 0xeb, 0xd3, 0xf8,
-	0xfb, 0xa8, 0x75, 0xd8, 0xdd, 0xdd, 0xa7, 0x45, 0xc1, 0xf3, 0x91, 0x72, 0x46, 0xdf, 0x0a, 0xfa,
-	0x48, 0x49, 0x74, 0x0f, 0x0c, 0xd9, 0x41, 0xfa, 0x71, 0x20, 0x5d, 0xf9, 0xdf, 0xd2, 0x01, 0x3b,
-	0xde, 0x42, 0xf5, 0xc1, 0xb9, 0x4a, 0x75, 0xe6, 0xbc, 0x11, 0xb6, 0xaf, 0x4d, 0x4b, 0xb1, 0x23,
-	0xc6, 0xb1, 0xe1, 0xfb, 0xa8, 0x49, 0x98, 0x4d, 0x0d, 0x65, 0x4c, 0x9a, 0xbf, 0xac, 0xa4, 0x91,
-	0x19, 0x1b, 0x24, 0xdf, 0xce, 0x48, 0x8a, 0x69, 0xa1, 0x8c, 0x17, 0x57, 0x6c, 0xf2, 0x05, 0x28,
-	0xfc, 0x01, 0x42, 0x4f, 0xe9, 0x84, 0xa9, 0x82, 0x82, 0xda, 0xfa, 0x92, 0x0d, 0x25, 0xd1, 0xd9,
-	0x10, 0x70, 0x43, 0x2b, 0xdd, 0x65, 0xa7, 0x3c, 0x65, 0x7e, 0x54, 0xde, 0x0c, 0x04, 0x2d, 0xc5,
-	0xb7, 0x52, 0xc7, 0x87, 0xef, 0xa1, 0xd5, 0x01, 0x4b, 0x53, 0x31, 0x29, 0xdc, 0x90, 0xc4, 0x81,
-	0x88, 0xa3, 0x10, 0xcf, 0x82, 0xef, 0xa1, 0x9b, 0x90, 0xd3, 0xc7, 0xaa, 0x2f, 0x45, 0x41, 0x47,
-	0xb6, 0x82, 0x9a, 0xc6, 0x88, 0x65, 0x02, 0x18, 0xbb, 0x4f, 0xd5, 0x09, 0x1b, 0x82, 0x61, 0x30,
-	0x36, 0x4d, 0x5f, 0x08, 0x50, 0xf8, 0x2e, 0x5a, 0xf3, 0x79, 0x6f, 0x79, 0x5a, 0x86, 0x67, 0x1e,
-	0x89, 0xdb, 0x08, 0x99, 0xd2, 0x0d, 0xdb, 0x6e, 0x80, 0xc1, 0x5b, 0xa8, 0xd1, 0xcd, 0x35, 0xcb,
-	0xc8, 0x50, 0xc7, 0x6b, 0xc6, 0x88, 0x37, 0xc2, 0xa0, 0x3b, 0x12, 0x29, 0x99, 0xee, 0x7c, 0x17,
-	0xb5, 0x82, 0x80, 0xbe, 0xd6, 0x74, 0x7e, 0xa7, 0x5c, 0x03, 0x80, 0x69, 0x38, 0x9d, 0x4c, 0xbc,
-	0xa0, 0x05, 0x80, 0xc1, 0xaf, 0x0c, 0x97, 0x33, 0x7c, 0x82, 0xae, 0xcd, 0x27, 0xa3, 0x99, 0x16,
-	0x42, 0xe9, 0xb2, 0xf5, 0x3b, 0xc8, 0x24, 0x8b, 0xdf, 0x2d, 0xcb, 0x29, 0x10, 0xa2, 0x4c, 0xa3,
-	0x83, 0xe1, 0x5f, 0x35, 0x24, 0x73, 0x4e, 0xde, 0x77, 0xfa, 0xcb, 0xbc, 0x78, 0x55, 0xdb, 0x34,
-	0x19, 0x58, 0x99, 0xd5, 0x71, 0xf2, 0xeb, 0x08, 0xb5, 0x82, 0x54, 0x79, 0x55, 0xad, 0x1b, 0x5d,
-	0x95, 0x40, 0xd7, 0x2d, 0xb4, 0xb2, 0x4f, 0x3f, 0x15, 0x76, 0xbb, 0xa8, 0x12, 0x0b, 0x18, 0x2c,
-	0xcf, 0x85, 0x74, 0xd5, 0x6e, 0x01, 0xe8, 0x7c, 0x8f, 0x78, 0xc6, 0xf6, 0xc5, 0x90, 0x99, 0xec,
-	0x5f, 0x23, 0x25, 0xec, 0xe7, 0x5f, 0x7d, 0x69, 0xfe, 0xad, 0x96, 0xf3, 0x2f, 0xf9, 0x47, 0xc5,
-	0x99, 0x37, 0xab, 0xa9, 0xef, 0xcc, 0xb2, 0x3e, 0x5a, 0xaa, 0x5c, 0x4b, 0xb1, 0x05, 0xb6, 0x98,
-	0xfb, 0xb0, 0xab, 0xb2, 0x89, 0x90, 0xe7, 0x6e, 0x79, 0x0a, 0xab, 0xc5, 0x12, 0x88, 0x63, 0xc0,
-	0xeb, 0xa8, 0xba, 0xd3, 0x3f, 0x74, 0xeb, 0xd3, 0xb5, 0x70, 0xb1, 0xe9, 0x1f, 0x12, 0x20, 0xe1,
-	0x2f, 0xa1, 0x5a, 0x1f, 0xc6, 0xb1, 0x6d, 0x04, 0xd7, 0x03, 0x16, 0x40, 0x13, 0x43, 0x84, 0x6a,
-	0xdb, 0xce, 0x44, 0x7a, 0xd2, 0xed, 0x19, 0xe3, 0xe7, 0xab, 0xcd, 0x51, 0x88, 0x67, 0xc1, 0x8f,
-	0xd0, 0xb5, 0xbd, 0xe9, 0x88, 0x15, 0x74, 0xc4, 0x9e, 0xd8, 0x05, 0xc9, 0xb6, 0x83, 0x38, 0x10,
-	0x9a, 0x63, 0x70, 0x06, 0x2e, 0x48, 0xc1, 0xad, 0x4f, 0x99, 0x3e, 0x13, 0xf2, 0xc4, 0x6d, 0x66,
-	0xe1, 0xad, 0x8e, 0x42, 0x3c, 0x4b, 0xf2, 0x37, 0x9f, 0x05, 0xce, 0xf4, 0x5b, 0xd0, 0x9c, 0x27,
-	0xdc, 0xae, 0x32, 0x55, 0x62, 0x01, 0xc8, 0x4d, 0xc2, 0x14, 0x93, 0xa7, 0xb6, 0x07, 0x54, 0xec,
-	0xba, 0x14, 0xa0, 0x4c, 0x6e, 0x9e, 0xd1, 0xc2, 0x25, 0x85, 0x39, 0x43, 0xa6, 0x7f, 0xc8, 0x64,
-	0xce, 0x32, 0x97, 0x14, 0x0e, 0x82, 0xfd, 0xc0, 0x9e, 0x0e, 0x76, 0xfa, 0xc6, 0x33, 0x55, 0x32,
-	0x43, 0x40, 0xfd, 0x83, 0x74, 0xc1, 0x73, 0xf8, 0x76, 0xa9, 0x9b, 0xa1, 0x1e, 0x60, 0xf0, 0xd7,
-	0xd0, 0x8d, 0x5d, 0xae, 0x60, 0xd1, 0xe8, 0xf5, 0xf6, 0x3f, 0xe4, 0x59, 0xc6, 0xa4, 0x31, 0xb4,
-	0x41, 0x96, 0xf0, 0xc9, 0x9f, 0x23, 0xd4, 0xf0, 0x81, 0x83, 0xe7, 0x0c, 0xc6, 0x54, 0x9a, 0xc4,
-	0x01, 0xa5, 0x0e, 0x02, 0x93, 0x7f, 0x34, 0x15, 0x9a, 0x3a, 0xb3, 0x2c, 0x00, 0xdc, 0x7d, 0x26,
-	0xb9, 0x18, 0xba, 0xbd, 0xc2, 0x41, 0xb0, 0x63, 0x12, 0x46, 0x33, 0xcd, 0x27, 0x8c, 0x4c, 0x73,
-	0xf8, 0x71, 0xd6, 0x2d, 0xa2, 0x61, 0x79, 0xf3, 0x28, 0xa7, 0x69, 0xc5, 0x68, 0x5a, 0xc0, 0x82,
-	0xeb, 0x76, 0x8a, 0xa9, 0x72, 0x2b, 0xb6, 0x39, 0x03, 0x6e, 0x9f, 0x4d, 0xec, 0x6e, 0xdd, 0x24,
-	0xe6, 0x9c, 0x9c, 0xb9, 0x3d, 0xee, 0x99, 0xd9, 0x2e, 0x5d, 0xd5, 0x96, 0xd5, 0x18, 0x5d, 0x5a,
-	0x8d, 0x95, 0xb0, 0x1a, 0x6f, 0xa3, 0xba, 0x95, 0x75, 0x1d, 0xc4, 0x41, 0xe0, 0xf1, 0x27, 0x8c,
-	0x1e, 0x3b, 0x5a, 0xcd, 0xd0, 0x02, 0x4c, 0x72, 0x88, 0xde, 0x30, 0x17, 0x1f, 0x8c, 0xa5, 0xd0,
-	0x3a, 0x63, 0xff, 0xc7, 0xd5, 0x18, 0xd5, 0x08, 0xd5, 0xcc, 0xef, 0x68, 0x70, 0x4e, 0xfe, 0x55,
-	0x45, 0x57, 0xc3, 0x52, 0x08, 0xde, 0x17, 0xfd, 0x97, 0xf7, 0x55, 0x16, 0xdf, 0x87, 0x3b, 0xe8,
-	0x6a, 0xe8, 0x93, 0x4b, 0x26, 0x7a, 0x48, 0x76, 0x65, 0x33, 0x27, 0x82, 0x0f, 0xd1, 0x9b, 0xde,
-	0x3a, 0x98, 0x46, 0xdb, 0x85, 0x72, 0xba, 0x6a, 0x46, 0xd7, 0x17, 0x02, 0x5d, 0xf3, 0x5e, 0x70,
-	0xda, 0x2e, 0x97, 0xc6, 0xcf, 0xd0, 0x6d, 0x4f, 0x78, 0x26, 0xb9, 0x66, 0x33, 0xbd, 0x2b, 0x9f,
-	0x4d, 0xef, 0x2b, 0xc4, 0x43, 0xc5, 0x70, 0x63, 0xb7, 0xd7, 0x1f, 0x38, 0xc5, 0xf5, 0xd7, 0x54,
-	0x3c, 0x2f, 0x8e, 0x7f, 0x82, 0xde, 0x9a, 0xbb, 0x32, 0xd0, 0xbc, 0xfa, 0xd9, 0x34, 0xbf, 0x4a,
-	0x3e, 0x79, 0x17, 0x35, 0xcb, 0x0e, 0x79, 0x79, 0x9f, 0x49, 0x7e, 0xee, 0xbf, 0x55, 0xc2, 0x46,
-	0x0e, 0xbc, 0x9d, 0x2c, 0x13, 0x67, 0xee, 0xa3, 0xd8, 0x02, 0x9f, 0x7b, 0x36, 0xdd, 0x46, 0xf5,
-	0x4e, 0x6a, 0xfe, 0x1f, 0xb1, 0x7b, 0x99, 0x83, 0x92, 0xcc, 0x65, 0xa5, 0xeb, 0x90, 0xb0, 0xc9,
-	0xee, 0x64, 0x54, 0xa9, 0x72, 0x60, 0x7b, 0x10, 0x6f, 0x23, 0xd4, 0x97, 0x5c, 0x48, 0xfb, 0x19,
-	0x6c, 0x17, 0xd0, 0xb7, 0x17, 0x76, 0x11, 0x79, 0x4c, 0x53, 0xe6, 0xb8, 0xce, 0xfd, 0x12, 0x37,
-	0x93, 0x4a, 0x1e, 0x21, 0xbc, 0xdc, 0xd9, 0x61, 0x6e, 0xf6, 0xe9, 0x88, 0x29, 0x98, 0xf6, 0x76,
-	0x1e, 0x97, 0xf0, 0xcc, 0x73, 0xf6, 0x1b, 0xc8, 0x79, 0x6e, 0x0f, 0xdd, 0xbe, 0xfc, 0x4e, 0xf0,
-	0x13, 0x2c, 0x07, 0x7e, 0xae, 0xc3, 0xd9, 0xe8, 0x77, 0x74, 0x57, 0x4f, 0x25, 0x9c, 0xfc, 0x32,
-	0x72, 0x0e, 0xf0, 0x6b, 0xe0, 0x5d, 0xb4, 0xb6, 0xcb, 0x8e, 0xe9, 0x34, 0xd3, 0x9d, 0x34, 0xf8,
-	0x88, 0x9a, 0x47, 0x02, 0x57, 0x47, 0xa6, 0x63, 0xae, 0x59, 0xaa, 0xa7, 0x92, 0xf9, 0xef, 0x83,
-	0x79, 0x24, 0xfe, 0x26, 0x6a, 0xc0, 0x2e, 0x46, 0xb3, 0x4c, 0xb9, 0x32, 0x9d, 0xdb, 0x40, 0x2d,
-	0xc9, 0x7f, 0x8e, 0x78, 0xce, 0x84, 0xa3, 0xeb, 0xe1, 0x8b, 0x3a, 0x72, 0x04, 0x5e, 0xe8, 0xe6,
-	0x43, 0xf6, 0xdc, 0xf5, 0x72, 0x0b, 0x00, 0xf6, 0xa3, 0x72, 0x93, 0xab, 0x11, 0x0b, 0x80, 0xb5,
-	0xe6, 0x70, 0x70, 0x26, 0x5c, 0x03, 0x2a, 0x61, 0x7c, 0x0d, 0x55, 0x7a, 0x85, 0xfb, 0x66, 0xae,
-	0xf4, 0x8a, 0x64, 0xe2, 0x8d, 0xb7, 0x77, 0x83, 0x46, 0xb3, 0x5a, 0xb9, 0x8f, 0x64, 0x0b, 0xd8,
-	0xdc, 0x29, 0x47, 0xa1, 0xc9, 0x1d, 0xe3, 0x84, 0x2d, 0xf7, 0x6d, 0x64, 0x4d, 0x7b, 0x73, 0x79,
-	0xb9, 0xee, 0x48, 0xff, 0x35, 0x62, 0x18, 0x93, 0x6f, 0xa1, 0xb5, 0xb9, 0xb5, 0x15, 0xdc, 0xf8,
-	0xe4, 0xc1, 0x0e, 0x4d, 0xc7, 0x6c, 0x90, 0x8e, 0xd9, 0x84, 0x7a, 0x67, 0xcf, 0x21, 0xb7, 0x7f,
-	0x11, 0xbd, 0x78, 0xd9, 0xbe, 0xf2, 0xd7, 0x97, 0xed, 0x2b, 0xff, 0x7e, 0xd9, 0x8e, 0x7e, 0x76,
-	0xd1, 0x8e, 0x7e, 0x7b, 0xd1, 0x8e, 0x7e, 0x7f, 0xd1, 0x8e, 0xfe, 0x78, 0xd1, 0x8e, 0x5e, 0x5c,
-	0xb4, 0xa3, 0xbf, 0x5c, 0xb4, 0xa3, 0x7f, 0x5e, 0xb4, 0xa3, 0x8f, 0x3f, 0x79, 0xcd, 0x3f, 0x34,
-	0xa5, 0x9d, 0x5e, 0x5b, 0xa7, 0x5c, 0xea, 0x80, 0x54, 0x9c, 0x8c, 0x96, 0xfe, 0xeb, 0x04, 0xeb,
-	0x8e, 0xea, 0x06, 0x7e, 0xf0, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x9b, 0x19, 0xc3, 0xc2, 0x39,
-	0x15, 0x00, 0x00,
+	// 2192 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0x4b, 0x73, 0x1c, 0x49,
+	0xf1, 0x77, 0xcf, 0x7b, 0x6a, 0x34, 0xb2, 0x5d, 0xeb, 0xf5, 0xf6, 0x5f, 0xff, 0x8d, 0x59, 0xb9,
+	0x71, 0x80, 0x00, 0xaf, 0x14, 0xd8, 0xb0, 0x18, 0x2f, 0x10, 0x31, 0x1a, 0xd9, 0x96, 0x62, 0x2d,
+	0x6b, 0xa8, 0x91, 0xd6, 0xb0, 0x87, 0x8d, 0x28, 0xf5, 0x94, 0x46, 0xb5, 0xea, 0xe9, 0xea, 0xa8,
+	0xae, 0x91, 0xac, 0x3d, 0xc1, 0x0d, 0x3e, 0x06, 0x17, 0x1e, 0xdf, 0x80, 0xe0, 0x04, 0x27, 0x1c,
+	0x9c, 0x38, 0x6e, 0x04, 0x11, 0x04, 0xd6, 0x9d, 0x3b, 0x47, 0x22, 0xeb, 0xd1, 0x53, 0x33, 0x23,
+	0xc1, 0x1a, 0x6e, 0x95, 0x99, 0xbf, 0xcc, 0xaa, 0xca, 0x57, 0x65, 0x37, 0xda, 0x1b, 0x71, 0x75,
+	0x3c, 0x39, 0x5c, 0x8f, 0xc5, 0x78, 0xe3, 0x84, 0x2a, 0xfa, 0x7e, 0x2c, 0x52, 0x45, 0x79, 0xca,
+	0x64, 0xbe, 0x40, 0xe7, 0x32, 0xde, 0xa0, 0x23, 0x96, 0xaa, 0x8d, 0x4c, 0x0a, 0x25, 0x62, 0x91,
+	0xe4, 0x66, 0x95, 0x6f, 0x88, 0x98, 0xaf, 0xeb, 0x25, 0xae, 0x8c, 0x64, 0x16, 0xaf, 0xbc, 0xef,
+	0x99, 0x1d, 0x89, 0x91, 0x30, 0xb8, 0xc3, 0xc9, 0x91, 0xa6, 0x34, 0xa1, 0x57, 0x46, 0x69, 0xa5,
+	0x33, 0x12, 0x62, 0x94, 0xb0, 0x29, 0xea, 0x4c, 0xd2, 0x2c, 0x63, 0x32, 0x37, 0xf2, 0xe8, 0x4f,
+	0x65, 0x54, 0x19, 0x64, 0x2c, 0xc6, 0x21, 0xaa, 0x7f, 0xcc, 0x64, 0xce, 0x45, 0x1a, 0x06, 0xab,
+	0xc1, 0x5a, 0x93, 0x38, 0x12, 0x7f, 0x0d, 0xd5, 0xfb, 0x52, 0xc4, 0x2c, 0xcf, 0xc3, 0xd2, 0x6a,
+	0xb0, 0xd6, 0xba, 0xdf, 0x5e, 0x87, 0x93, 0xac, 0x5b, 0x26, 0x71, 0x52, 0xdc, 0x41, 0x15, 0x22,
+	0x84, 0x0a, 0xcb, 0x1a, 0x85, 0x0c, 0x0a, 0x38, 0x44, 0xf3, 0xf1, 0x0a, 0x6a, 0x6c, 0x8b, 0x5c,
+	0xa5, 0x74, 0xcc, 0xc2, 0x8a, 0xde, 0xa3, 0xa0, 0xf1, 0xd7, 0x51, 0x6d, 0x57, 0x4c, 0x52, 0x95,
+	0x87, 0xd5, 0xd5, 0xf2, 0x5a, 0xeb, 0x7e, 0xcb, 0x68, 0x6b, 0xde, 0x66, 0xe5, 0xd5, 0xdf, 0xde,
+	0xbb, 0x46, 0x2c, 0x00, 0xdf, 0x41, 0xd5, 0x6d, 0x21, 0x4e, 0xf2, 0xb0, 0xb6, 0x1a, 0x4c, 0x91,
+	0x9a, 0x45, 0x8c, 0x04, 0xff, 0x00, 0xb5, 0xba, 0x69, 0x2a, 0x14, 0x55, 0x5c, 0xa4, 0x79, 0x58,
+	0xd7, 0x26, 0xff, 0xdf, 0x00, 0xe1, 0xb6, 0xeb, 0x9e, 0xf4, 0x71, 0xaa, 0xe4, 0x39, 0xf1, 0xf1,
+	0xb0, 0xc3, 0x33, 0x9e, 0x4e, 0x5e, 0x86, 0x0d, 0x7f, 0x07, 0xcd, 0x22, 0x46, 0x02, 0x4e, 0x19,
+	0x88, 0x84, 0x4a, 0x9e, 0x87, 0x4d, 0xdf, 0x29, 0x96, 0x49, 0x9c, 0x14, 0x80, 0x2f, 0x78, 0x3a,
+	0x14, 0x67, 0x79, 0x88, 0x7c, 0xa0, 0x65, 0x12, 0x27, 0x5d, 0xf9, 0x21, 0xba, 0x31, 0x7f, 0x2a,
+	0x7c, 0x03, 0x95, 0x4f, 0xd8, 0xb9, 0x0d, 0x08, 0x2c, 0xf1, 0x2d, 0x54, 0x3d, 0xa5, 0xc9, 0x84,
+	0xe9, 0x50, 0x34, 0x89, 0x21, 0x1e, 0x95, 0x1e, 0x06, 0xd1, 0xef, 0xcb, 0x45, 0x9c, 0xc0, 0xd3,
+	0xfb, 0x4c, 0x8e, 0x79, 0x4a, 0x13, 0xad, 0xdc, 0x20, 0x05, 0x8d, 0xbf, 0x89, 0x5a, 0x3d, 0x91,
+	0xe6, 0x22, 0x61, 0x03, 0xfe, 0x39, 0xb3, 0x21, 0x6d, 0x9a, 0x43, 0x6d, 0x8a, 0x97, 0xc4, 0x97,
+	0xe2, 0xbb, 0xa8, 0x72, 0x90, 0x33, 0x39, 0x1b, 0x52, 0xe0, 0xd8, 0x98, 0x68, 0x29, 0xc6, 0xa8,
+	0xd2, 0x95, 0xa3, 0x3c, 0xac, 0xac, 0x96, 0xd7, 0x9a, 0x44, 0xaf, 0xe1, 0xe8, 0x8f, 0xd3, 0x53,
+	0x1d, 0xcd, 0x26, 0x81, 0x25, 0x70, 0x7a, 0x67, 0x43, 0x1d, 0xb5, 0x26, 0x81, 0x25, 0xfe, 0x10,
+	0x2d, 0xf5, 0x68, 0x46, 0x0f, 0x79, 0xc2, 0x15, 0x67, 0x10, 0x27, 0xd8, 0xe5, 0x1d, 0xcf, 0xdd,
+	0xbe, 0x98, 0xcc, 0x80, 0xf1, 0xb7, 0x50, 0x9d, 0x24, 0x7c, 0xcc, 0x55, 0x1e, 0x36, 0x74, 0x7c,
+	0x6f, 0xda, 0xb4, 0xdc, 0x1b, 0xec, 0xfc, 0xd8, 0x48, 0xec, 0x21, 0x1d, 0x0e, 0xaf, 0xa1, 0xeb,
+	0xcf, 0xc5, 0x73, 0x76, 0xd6, 0x97, 0xfc, 0x94, 0x27, 0x6c, 0xc4, 0x4c, 0xf0, 0x1a, 0x64, 0x9e,
+	0x0d, 0xc8, 0x6e, 0x96, 0x51, 0x39, 0x16, 0xb2, 0x2f, 0xc5, 0x11, 0x4f, 0x98, 0x8e, 0x5e, 0x93,
+	0xcc, 0xb3, 0xf1, 0x2a, 0x6a, 0xed, 0xed, 0xed, 0x0e, 0x62, 0x21, 0x59, 0x77, 0xf8, 0x59, 0xd8,
+	0x5a, 0x0d, 0xd6, 0xca, 0xc4, 0x67, 0xe1, 0x08, 0x2d, 0x0d, 0x58, 0x02, 0xb7, 0x79, 0x46, 0x0f,
+	0x59, 0x12, 0x2e, 0x69, 0x43, 0x33, 0xbc, 0xe8, 0x01, 0x2a, 0x6f, 0x8a, 0x97, 0xf8, 0x36, 0xaa,
+	0x6d, 0x33, 0x3e, 0x3a, 0x56, 0x3a, 0x6a, 0x6d, 0x62, 0x29, 0x88, 0xfa, 0x0b, 0x3e, 0x54, 0xc7,
+	0x3a, 0x5a, 0x6d, 0x62, 0x88, 0x28, 0x35, 0xc1, 0x01, 0xc7, 0x1e, 0xec, 0x6c, 0x59, 0x15, 0x58,
+	0x02, 0xe7, 0xe9, 0xce, 0x96, 0x45, 0xc3, 0x12, 0x7f, 0x15, 0x2d, 0x77, 0x87, 0x43, 0x0e, 0xb9,
+	0x45, 0x93, 0xa7, 0x7c, 0x98, 0x87, 0xe5, 0xd5, 0xf2, 0x5a, 0x9b, 0xcc, 0x71, 0x21, 0x73, 0xc0,
+	0xa6, 0x5f, 0xa3, 0x8e, 0x8e, 0x7e, 0x1d, 0xa0, 0x9b, 0x0b, 0x51, 0x01, 0x8d, 0x4d, 0x31, 0x49,
+	0x87, 0x3c, 0x1d, 0x85, 0x81, 0x8e, 0x76, 0x41, 0xe3, 0x77, 0x51, 0xf3, 0xf1, 0xd1, 0x11, 0x8b,
+	0x15, 0x3f, 0x85, 0x4c, 0x03, 0xe1, 0x94, 0x01, 0xae, 0xdb, 0x49, 0x8f, 0x99, 0xe4, 0x8a, 0x1e,
+	0x26, 0x4c, 0x1f, 0xa8, 0x49, 0x7c, 0x16, 0xe8, 0xf7, 0x21, 0x6f, 0x95, 0x62, 0x43, 0x9b, 0x5d,
+	0x53, 0x06, 0xb4, 0xac, 0xee, 0xf8, 0x90, 0xb3, 0x54, 0xd9, 0x34, 0x73, 0x64, 0xb4, 0x83, 0x5a,
+	0x5e, 0x1a, 0x40, 0x7e, 0xee, 0x9f, 0x67, 0xcc, 0xd6, 0x91, 0x5e, 0x03, 0x6f, 0x9b, 0xca, 0xa1,
+	0xf6, 0x51, 0x85, 0xe8, 0x35, 0xf0, 0x06, 0xe2, 0xc8, 0x34, 0xb0, 0x0a, 0xd1, 0xeb, 0x48, 0xa0,
+	0xaa, 0xee, 0x3b, 0x70, 0xda, 0x21, 0xcb, 0x15, 0x4f, 0x75, 0x81, 0x5a, 0x5b, 0x3e, 0x0b, 0xa2,
+	0x97, 0x8b, 0x89, 0x8c, 0x5d, 0x71, 0x5a, 0x0a, 0xcc, 0x2a, 0xd8, 0xbe, 0x6c, 0xb6, 0x87, 0x35,
+	0x9c, 0x5d, 0x64, 0xa6, 0x3b, 0x99, 0x7b, 0x39, 0x32, 0xfa, 0xc0, 0x74, 0x51, 0xd0, 0xea, 0x53,
+	0x75, 0xec, 0x0e, 0x0d, 0x6b, 0xf0, 0x35, 0x61, 0x74, 0x28, 0xd2, 0xe4, 0x5c, 0xef, 0xd1, 0x20,
+	0x05, 0x1d, 0xfd, 0xb1, 0x64, 0xfb, 0x22, 0xbe, 0x87, 0x1a, 0x7d, 0xc9, 0x72, 0x45, 0xa5, 0xd2,
+	0x11, 0x29, 0x0a, 0x17, 0xc4, 0xb6, 0x26, 0x0a, 0x04, 0x5e, 0x47, 0xcd, 0xbe, 0xc8, 0x95, 0x81,
+	0x97, 0xae, 0x80, 0x4f, 0x21, 0xda, 0xba, 0x26, 0x44, 0x16, 0x96, 0xaf, 0x80, 0x17, 0x08, 0xfc,
+	0x01, 0x6a, 0xf7, 0x24, 0xa3, 0x8a, 0x91, 0x49, 0xaa, 0xb8, 0x4e, 0xaa, 0xcb, 0x55, 0x66, 0x61,
+	0xf8, 0x11, 0xba, 0x6e, 0x18, 0x3d, 0xf7, 0x4a, 0x86, 0xd5, 0x2b, 0x34, 0xe7, 0x81, 0xf8, 0x21,
+	0x5a, 0x1e, 0xc0, 0x51, 0xa7, 0xaa, 0xb5, 0x2b, 0x54, 0xe7, 0x70, 0xd1, 0x27, 0xa8, 0x02, 0xd2,
+	0x4b, 0x7d, 0xef, 0x9a, 0x5c, 0x69, 0xb1, 0xc9, 0x95, 0xa7, 0x4d, 0x2e, 0x44, 0xf5, 0x7d, 0x3e,
+	0x66, 0x62, 0xa2, 0x74, 0xf9, 0x94, 0x89, 0x23, 0xa3, 0xdf, 0x56, 0xed, 0xab, 0x82, 0xbf, 0x8f,
+	0x5a, 0x07, 0x3b, 0x5b, 0xbb, 0x34, 0xcb, 0x78, 0x3a, 0xca, 0x6d, 0x88, 0x6e, 0x79, 0x5d, 0xaf,
+	0x10, 0xda, 0x63, 0xfa, 0x70, 0xd0, 0x7e, 0xea, 0x69, 0x97, 0xfe, 0xb3, 0xb6, 0x07, 0xc7, 0x1b,
+	0xa8, 0x36, 0x38, 0xcf, 0x63, 0x95, 0xd8, 0xd8, 0xf9, 0xcd, 0x76, 0xdd, 0x48, 0xcc, 0x83, 0x68,
+	0x61, 0xf8, 0x3e, 0x6a, 0x12, 0x66, 0x12, 0x39, 0xd7, 0x57, 0x9a, 0xdd, 0xac, 0x90, 0x91, 0x29,
+	0x0c, 0x4a, 0xa5, 0x37, 0x92, 0x62, 0x92, 0xe5, 0xda, 0x8b, 0x55, 0x53, 0x2a, 0x1e, 0x0b, 0x3f,
+	0x42, 0xe8, 0x39, 0x1d, 0xb3, 0x3c, 0xa3, 0x60, 0xb6, 0xb6, 0x70, 0x87, 0x42, 0x68, 0xef, 0xe0,
+	0xa1, 0xa1, 0xf1, 0x6f, 0xb1, 0x53, 0x1e, 0x33, 0xf7, 0xb0, 0xdf, 0xf4, 0x14, 0x8d, 0xc4, 0x35,
+	0x7e, 0x8b, 0xc3, 0xf7, 0x50, 0x7d, 0xc0, 0xe2, 0x58, 0x8c, 0x33, 0xfb, 0xa4, 0x63, 0x4f, 0xc5,
+	0x4a, 0x88, 0x83, 0xe0, 0x7b, 0xe8, 0x26, 0x54, 0xe0, 0x51, 0xde, 0x97, 0x22, 0xa3, 0x23, 0x53,
+	0xef, 0x4d, 0x7d, 0x89, 0x45, 0x01, 0x5c, 0x76, 0x97, 0xe6, 0x27, 0x6c, 0x08, 0x17, 0x83, 0x47,
+	0x5e, 0x77, 0x31, 0x8f, 0x85, 0xef, 0xa2, 0xb6, 0xab, 0x52, 0x83, 0x69, 0x69, 0xcc, 0x2c, 0x13,
+	0x77, 0x10, 0xd2, 0x8d, 0xc6, 0x7f, 0x24, 0x3c, 0x0e, 0xde, 0x40, 0x8d, 0x9d, 0x54, 0xb1, 0x84,
+	0x0c, 0x55, 0xd8, 0xd6, 0x97, 0x78, 0xcb, 0x0f, 0xba, 0x15, 0x91, 0x02, 0xb4, 0xf2, 0x3d, 0xd4,
+	0xf2, 0x02, 0xfa, 0x46, 0xb3, 0xc4, 0x7b, 0xc5, 0xd0, 0x02, 0xa0, 0xe1, 0x64, 0x3c, 0x76, 0x8a,
+	0x86, 0x00, 0x80, 0x1b, 0x70, 0x2e, 0x07, 0x7c, 0x8a, 0x96, 0x67, 0x93, 0x51, 0xbf, 0x6d, 0x22,
+	0x57, 0xc5, 0x43, 0x65, 0x29, 0x9d, 0x2c, 0xae, 0x00, 0x8b, 0x37, 0xcb, 0x67, 0xe9, 0xb6, 0xcc,
+	0x3f, 0x37, 0xfd, 0xb3, 0x4d, 0xf4, 0x3a, 0x7a, 0x68, 0xed, 0x17, 0x79, 0x71, 0x55, 0x93, 0xd7,
+	0x19, 0x58, 0x9a, 0xd6, 0x71, 0xf4, 0xcb, 0x00, 0xb5, 0xbc, 0x54, 0xb9, 0xaa, 0xd6, 0xb5, 0xad,
+	0x92, 0x67, 0xeb, 0x16, 0xaa, 0xee, 0xd2, 0xcf, 0x84, 0x99, 0x85, 0xca, 0xc4, 0x10, 0x9a, 0xcb,
+	0x53, 0x21, 0x6d, 0xb5, 0x1b, 0x02, 0xfa, 0xf4, 0x13, 0x9e, 0xb0, 0x5d, 0x31, 0x64, 0x3a, 0xfb,
+	0xdb, 0xa4, 0xa0, 0xdd, 0x6b, 0x5d, 0x5b, 0x78, 0xad, 0xeb, 0xc5, 0x6b, 0x1d, 0x7d, 0x51, 0xb6,
+	0xd7, 0x9b, 0xd6, 0xd4, 0x77, 0xa7, 0x59, 0x1f, 0x2c, 0x54, 0xae, 0x91, 0x98, 0x02, 0x9b, 0xcf,
+	0x7d, 0x98, 0xac, 0xd9, 0x58, 0xc8, 0x73, 0x3b, 0xea, 0xf9, 0xd5, 0x62, 0x04, 0xc4, 0x02, 0xf0,
+	0x2a, 0x2a, 0xf7, 0xfa, 0x07, 0x76, 0xd8, 0x5b, 0xf6, 0xc7, 0xb0, 0xfe, 0x01, 0x01, 0x11, 0xfe,
+	0x0a, 0xaa, 0xf4, 0x61, 0x78, 0x30, 0x8d, 0xe0, 0xba, 0x07, 0x01, 0x36, 0xd1, 0x42, 0xa8, 0xb6,
+	0xcd, 0x44, 0xc4, 0x27, 0x3b, 0x7b, 0x61, 0x75, 0xa1, 0xda, 0xac, 0x84, 0x38, 0x08, 0x7e, 0x82,
+	0x96, 0xb7, 0x27, 0x23, 0x96, 0xd1, 0x11, 0x7b, 0x66, 0xc6, 0x39, 0xd3, 0x0e, 0x42, 0x4f, 0x69,
+	0x06, 0xe0, 0x7a, 0xf7, 0xac, 0x16, 0xec, 0xfa, 0x9c, 0xa9, 0x33, 0x21, 0x4f, 0xc2, 0xfa, 0xc2,
+	0xae, 0x56, 0x42, 0x1c, 0x04, 0x7f, 0x88, 0xea, 0x07, 0x29, 0x3f, 0xe2, 0x6c, 0x68, 0xa7, 0xc7,
+	0x3b, 0x97, 0x35, 0xb5, 0x75, 0x8b, 0x31, 0x2d, 0xd1, 0x69, 0xac, 0x3c, 0x42, 0x4b, 0xbe, 0xe0,
+	0x8d, 0x4a, 0xeb, 0xaf, 0x2e, 0xfd, 0xac, 0xcf, 0x6f, 0xc1, 0xab, 0x30, 0xe6, 0x66, 0xe2, 0x2b,
+	0x13, 0x43, 0x40, 0x51, 0x10, 0x96, 0x33, 0x79, 0x6a, 0x9a, 0x4f, 0x49, 0xcb, 0x7c, 0x96, 0x2e,
+	0x8a, 0x33, 0x9a, 0xd9, 0x6c, 0xd4, 0x6b, 0x28, 0xb1, 0x8f, 0x98, 0x4c, 0x59, 0x62, 0xb3, 0xd1,
+	0x52, 0x30, 0x46, 0x99, 0xd5, 0x7e, 0xaf, 0xaf, 0x43, 0x52, 0x26, 0x53, 0x06, 0x34, 0x1e, 0xd0,
+	0xce, 0x78, 0x0a, 0x9f, 0x78, 0x35, 0x3d, 0xfb, 0x78, 0x1c, 0xfc, 0x0d, 0x74, 0x63, 0x8b, 0xe7,
+	0x30, 0x8f, 0xed, 0xed, 0xed, 0x7e, 0xc4, 0x93, 0x84, 0x49, 0xed, 0xe1, 0x06, 0x59, 0xe0, 0x47,
+	0x7f, 0x0e, 0x50, 0xc3, 0x65, 0x0c, 0x1c, 0x67, 0x70, 0x4c, 0xa5, 0xce, 0x58, 0x30, 0x6a, 0x29,
+	0xb8, 0xf2, 0x8f, 0x26, 0x42, 0x51, 0x7b, 0x2d, 0x43, 0x00, 0xba, 0xcf, 0x24, 0x17, 0x43, 0x3b,
+	0x7e, 0x59, 0x0a, 0x46, 0x71, 0xc2, 0x68, 0x02, 0x53, 0xc1, 0x74, 0x86, 0x00, 0xbd, 0x79, 0x36,
+	0xcc, 0xb8, 0x8e, 0x65, 0x2d, 0x55, 0xb5, 0xa5, 0x39, 0x2e, 0xb8, 0xae, 0x97, 0x4d, 0x72, 0xfb,
+	0x25, 0xa2, 0xd7, 0xc0, 0xdb, 0x65, 0x63, 0xf3, 0x09, 0xd2, 0x24, 0x7a, 0x1d, 0x9d, 0xd9, 0x71,
+	0xf7, 0x85, 0x1e, 0xc2, 0x6d, 0xbb, 0x28, 0xda, 0x40, 0x70, 0x69, 0x1b, 0x28, 0xf9, 0x6d, 0xe0,
+	0x36, 0xaa, 0x19, 0x5d, 0xdb, 0xba, 0x2c, 0x05, 0x1e, 0x7f, 0xc6, 0xe8, 0x91, 0x95, 0x55, 0xb4,
+	0xcc, 0xe3, 0x44, 0x07, 0xe8, 0x2d, 0xbd, 0xf1, 0xfe, 0xb1, 0x14, 0x4a, 0x25, 0xec, 0xbf, 0xd8,
+	0x1a, 0xa3, 0x0a, 0xa1, 0x8a, 0xb9, 0x51, 0x16, 0xd6, 0xd1, 0x3f, 0xca, 0x68, 0xc9, 0xaf, 0x41,
+	0xef, 0x7c, 0xc1, 0xbf, 0x39, 0x5f, 0x69, 0xfe, 0x7c, 0xb8, 0x8b, 0x96, 0x7c, 0x9f, 0x5c, 0x32,
+	0x4a, 0xf8, 0x62, 0x5b, 0xaf, 0x33, 0x2a, 0xf8, 0x00, 0xbd, 0xed, 0x6e, 0x07, 0xcf, 0xe0, 0x66,
+	0x96, 0x5b, 0x5b, 0x66, 0x3e, 0xfc, 0x3f, 0xcf, 0xd6, 0xac, 0x17, 0xac, 0xb5, 0xcb, 0xb5, 0xf1,
+	0x0b, 0x74, 0xdb, 0x09, 0x5e, 0x48, 0xae, 0xd8, 0xd4, 0x6e, 0xf5, 0xcb, 0xd9, 0xbd, 0x42, 0xdd,
+	0x37, 0x0c, 0x3b, 0xee, 0xec, 0xf5, 0x07, 0xd6, 0x70, 0xed, 0x0d, 0x0d, 0xcf, 0xaa, 0xe3, 0x9f,
+	0xa0, 0x77, 0x66, 0xb6, 0xf4, 0x2c, 0xd7, 0xbf, 0x9c, 0xe5, 0xab, 0xf4, 0xa3, 0x3b, 0xa8, 0x59,
+	0xb4, 0xe6, 0xcb, 0xfb, 0x4c, 0xf4, 0x33, 0xf7, 0x49, 0xe7, 0xbf, 0x20, 0x80, 0xed, 0x26, 0x89,
+	0x38, 0xb3, 0xff, 0x0e, 0x0c, 0xf1, 0x3f, 0x3f, 0x8a, 0xb7, 0x51, 0xad, 0x1b, 0xeb, 0xdf, 0x48,
+	0x66, 0x20, 0xb4, 0x54, 0x94, 0xd8, 0xac, 0x74, 0xad, 0x39, 0x44, 0xf5, 0x5e, 0x42, 0xf3, 0xbc,
+	0x98, 0x14, 0x1c, 0x89, 0x37, 0x11, 0xea, 0x4b, 0x2e, 0xa4, 0xf9, 0x5b, 0x60, 0x26, 0xdf, 0x77,
+	0xe7, 0x86, 0x20, 0x79, 0x44, 0x63, 0x66, 0x51, 0xe7, 0x6e, 0x7a, 0x9c, 0x6a, 0x45, 0x4f, 0x10,
+	0x5e, 0x7c, 0x52, 0xe0, 0xc1, 0xee, 0xd3, 0x11, 0xcb, 0x61, 0xcc, 0x30, 0x6d, 0xbc, 0xa0, 0xa7,
+	0x9e, 0x33, 0x9f, 0x8a, 0xd6, 0x73, 0xdb, 0xe8, 0xf6, 0xe5, 0x7b, 0x82, 0x9f, 0x60, 0x2a, 0x71,
+	0x03, 0x05, 0xac, 0xb5, 0x7d, 0x2b, 0xb7, 0xf5, 0x54, 0xd0, 0xd1, 0xaf, 0x02, 0xeb, 0x00, 0x37,
+	0x7f, 0xde, 0x45, 0xed, 0x2d, 0x76, 0x44, 0x27, 0x89, 0xea, 0xc6, 0xde, 0xb7, 0xe6, 0x2c, 0x13,
+	0x50, 0x5d, 0x19, 0x1f, 0x73, 0xc5, 0x62, 0x35, 0x91, 0xcc, 0x7d, 0x98, 0xcc, 0x32, 0xf1, 0xb7,
+	0x51, 0x03, 0x86, 0x40, 0x9a, 0x24, 0xb9, 0x2d, 0xd3, 0x99, 0xd1, 0xd7, 0x88, 0xdc, 0x57, 0x9b,
+	0x43, 0xc2, 0x95, 0x9f, 0x24, 0xb4, 0xf8, 0xa3, 0x63, 0x88, 0x88, 0xa3, 0xeb, 0xfe, 0x39, 0xbb,
+	0x72, 0x04, 0xc0, 0x9d, 0x74, 0xc8, 0x5e, 0xda, 0x0e, 0x6f, 0x08, 0xe0, 0x7e, 0x5c, 0xbc, 0x7e,
+	0x15, 0x62, 0x08, 0xf0, 0x81, 0x5e, 0xec, 0x9f, 0x09, 0xdb, 0x96, 0x0a, 0x1a, 0x2f, 0xa3, 0xd2,
+	0x5e, 0x66, 0x7f, 0x38, 0x94, 0xf6, 0xb2, 0xe8, 0x17, 0x85, 0x4f, 0xcc, 0x91, 0xc0, 0xa4, 0x1e,
+	0xf5, 0xec, 0x2f, 0x06, 0x43, 0x98, 0x94, 0x2a, 0x5e, 0xc8, 0x26, 0xb1, 0x14, 0xde, 0xb0, 0xdf,
+	0x6a, 0xe6, 0xc6, 0x6f, 0x2f, 0x0e, 0xfb, 0x5d, 0xe9, 0xbe, 0x8e, 0x34, 0x10, 0xce, 0xf6, 0x58,
+	0xca, 0x54, 0x10, 0xe6, 0xfa, 0x71, 0x41, 0x47, 0xdf, 0x41, 0xed, 0x99, 0x11, 0x1b, 0x3c, 0xff,
+	0xec, 0x41, 0x8f, 0xc6, 0xc7, 0x6c, 0x10, 0x1f, 0xb3, 0x31, 0x75, 0xf1, 0x99, 0x61, 0x6e, 0xfe,
+	0x3c, 0x78, 0xf5, 0xba, 0x73, 0xed, 0x8b, 0xd7, 0x9d, 0x6b, 0xff, 0x7c, 0xdd, 0x09, 0x7e, 0x7a,
+	0xd1, 0x09, 0x7e, 0x73, 0xd1, 0x09, 0x7e, 0x77, 0xd1, 0x09, 0xfe, 0x70, 0xd1, 0x09, 0x5e, 0x5d,
+	0x74, 0x82, 0xbf, 0x5c, 0x74, 0x82, 0xbf, 0x5f, 0x74, 0x82, 0x4f, 0x3e, 0x7d, 0xc3, 0x5f, 0xc5,
+	0xd2, 0x3c, 0x78, 0x1b, 0xa7, 0x5c, 0x2a, 0x4f, 0x94, 0x9d, 0x8c, 0x16, 0xfe, 0x22, 0xc3, 0xcd,
+	0x0f, 0x6b, 0x9a, 0x7e, 0xf0, 0xaf, 0x01, 0x00, 0x01, 0x32, 0x7b, 0xdb, 0x93, 0x16, 0x00, 0x00,
 }
 
 func (this *Spec) Equal(that interface{}) bool {
@@ -2065,6 +2082,30 @@ func (this *Hooks) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if len(this.CreateRuntime) != len(that1.CreateRuntime) {
+		return false
+	}
+	for i := range this.CreateRuntime {
+		if !this.CreateRuntime[i].Equal(&that1.CreateRuntime[i]) {
+			return false
+		}
+	}
+	if len(this.CreateContainer) != len(that1.CreateContainer) {
+		return false
+	}
+	for i := range this.CreateContainer {
+		if !this.CreateContainer[i].Equal(&that1.CreateContainer[i]) {
+			return false
+		}
+	}
+	if len(this.StartContainer) != len(that1.StartContainer) {
+		return false
+	}
+	for i := range this.StartContainer {
+		if !this.StartContainer[i].Equal(&that1.StartContainer[i]) {
+			return false
+		}
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -2426,6 +2467,14 @@ func (this *LinuxResources) Equal(that interface{}) bool {
 	if !this.Network.Equal(that1.Network) {
 		return false
 	}
+	if len(this.Unified) != len(that1.Unified) {
+		return false
+	}
+	for i := range this.Unified {
+		if this.Unified[i] != that1.Unified[i] {
+			return false
+		}
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -2859,6 +2908,14 @@ func (this *LinuxSeccomp) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if len(this.Flags) != len(that1.Flags) {
+		return false
+	}
+	for i := range this.Flags {
+		if this.Flags[i] != that1.Flags[i] {
+			return false
+		}
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -2938,6 +2995,9 @@ func (this *LinuxSyscall) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.ErrnoRet != that1.ErrnoRet {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -3595,6 +3655,48 @@ func (m *Hooks) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.StartContainer) > 0 {
+		for iNdEx := len(m.StartContainer) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.StartContainer[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOci(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.CreateContainer) > 0 {
+		for iNdEx := len(m.CreateContainer) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.CreateContainer[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOci(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.CreateRuntime) > 0 {
+		for iNdEx := len(m.CreateRuntime) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.CreateRuntime[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOci(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
 	if len(m.Poststop) > 0 {
 		for iNdEx := len(m.Poststop) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -4115,6 +4217,25 @@ func (m *LinuxResources) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Unified) > 0 {
+		for k := range m.Unified {
+			v := m.Unified[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintOci(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintOci(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintOci(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
 	if m.Network != nil {
 		{
 			size, err := m.Network.MarshalToSizedBuffer(dAtA[:i])
@@ -4776,6 +4897,15 @@ func (m *LinuxSeccomp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Flags) > 0 {
+		for iNdEx := len(m.Flags) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Flags[iNdEx])
+			copy(dAtA[i:], m.Flags[iNdEx])
+			i = encodeVarintOci(dAtA, i, uint64(len(m.Flags[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
 	if len(m.Syscalls) > 0 {
 		for iNdEx := len(m.Syscalls) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -4882,6 +5012,11 @@ func (m *LinuxSyscall) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.ErrnoRet != 0 {
+		i = encodeVarintOci(dAtA, i, uint64(m.ErrnoRet))
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.Args) > 0 {
 		for iNdEx := len(m.Args) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -5168,8 +5303,32 @@ func NewPopulatedHooks(r randyOci, easy bool) *Hooks {
 			this.Poststop[i] = *v21
 		}
 	}
+	if r.Intn(5) != 0 {
+		v22 := r.Intn(5)
+		this.CreateRuntime = make([]Hook, v22)
+		for i := 0; i < v22; i++ {
+			v23 := NewPopulatedHook(r, easy)
+			this.CreateRuntime[i] = *v23
+		}
+	}
+	if r.Intn(5) != 0 {
+		v24 := r.Intn(5)
+		this.CreateContainer = make([]Hook, v24)
+		for i := 0; i < v24; i++ {
+			v25 := NewPopulatedHook(r, easy)
+			this.CreateContainer[i] = *v25
+		}
+	}
+	if r.Intn(5) != 0 {
+		v26 := r.Intn(5)
+		this.StartContainer = make([]Hook, v26)
+		for i := 0; i < v26; i++ {
+			v27 := NewPopulatedHook(r, easy)
+			this.StartContainer[i] = *v27
+		}
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedOci(r, 4)
+		this.XXX_unrecognized = randUnrecognizedOci(r, 7)
 	}
 	return this
 }
@@ -5177,14 +5336,14 @@ func NewPopulatedHooks(r randyOci, easy bool) *Hooks {
 func NewPopulatedHook(r randyOci, easy bool) *Hook {
 	this := &Hook{}
 	this.Path = string(randStringOci(r))
-	v22 := r.Intn(10)
-	this.Args = make([]string, v22)
-	for i := 0; i < v22; i++ {
+	v28 := r.Intn(10)
+	this.Args = make([]string, v28)
+	for i := 0; i < v28; i++ {
 		this.Args[i] = string(randStringOci(r))
 	}
-	v23 := r.Intn(10)
-	this.Env = make([]string, v23)
-	for i := 0; i < v23; i++ {
+	v29 := r.Intn(10)
+	this.Env = make([]string, v29)
+	for i := 0; i < v29; i++ {
 		this.Env[i] = string(randStringOci(r))
 	}
 	this.Timeout = int64(r.Int63())
@@ -5200,25 +5359,25 @@ func NewPopulatedHook(r randyOci, easy bool) *Hook {
 func NewPopulatedLinux(r randyOci, easy bool) *Linux {
 	this := &Linux{}
 	if r.Intn(5) != 0 {
-		v24 := r.Intn(5)
-		this.UIDMappings = make([]LinuxIDMapping, v24)
-		for i := 0; i < v24; i++ {
-			v25 := NewPopulatedLinuxIDMapping(r, easy)
-			this.UIDMappings[i] = *v25
+		v30 := r.Intn(5)
+		this.UIDMappings = make([]LinuxIDMapping, v30)
+		for i := 0; i < v30; i++ {
+			v31 := NewPopulatedLinuxIDMapping(r, easy)
+			this.UIDMappings[i] = *v31
 		}
 	}
 	if r.Intn(5) != 0 {
-		v26 := r.Intn(5)
-		this.GIDMappings = make([]LinuxIDMapping, v26)
-		for i := 0; i < v26; i++ {
-			v27 := NewPopulatedLinuxIDMapping(r, easy)
-			this.GIDMappings[i] = *v27
+		v32 := r.Intn(5)
+		this.GIDMappings = make([]LinuxIDMapping, v32)
+		for i := 0; i < v32; i++ {
+			v33 := NewPopulatedLinuxIDMapping(r, easy)
+			this.GIDMappings[i] = *v33
 		}
 	}
 	if r.Intn(5) != 0 {
-		v28 := r.Intn(10)
+		v34 := r.Intn(10)
 		this.Sysctl = make(map[string]string)
-		for i := 0; i < v28; i++ {
+		for i := 0; i < v34; i++ {
 			this.Sysctl[randStringOci(r)] = randStringOci(r)
 		}
 	}
@@ -5227,33 +5386,33 @@ func NewPopulatedLinux(r randyOci, easy bool) *Linux {
 	}
 	this.CgroupsPath = string(randStringOci(r))
 	if r.Intn(5) != 0 {
-		v29 := r.Intn(5)
-		this.Namespaces = make([]LinuxNamespace, v29)
-		for i := 0; i < v29; i++ {
-			v30 := NewPopulatedLinuxNamespace(r, easy)
-			this.Namespaces[i] = *v30
+		v35 := r.Intn(5)
+		this.Namespaces = make([]LinuxNamespace, v35)
+		for i := 0; i < v35; i++ {
+			v36 := NewPopulatedLinuxNamespace(r, easy)
+			this.Namespaces[i] = *v36
 		}
 	}
 	if r.Intn(5) != 0 {
-		v31 := r.Intn(5)
-		this.Devices = make([]LinuxDevice, v31)
-		for i := 0; i < v31; i++ {
-			v32 := NewPopulatedLinuxDevice(r, easy)
-			this.Devices[i] = *v32
+		v37 := r.Intn(5)
+		this.Devices = make([]LinuxDevice, v37)
+		for i := 0; i < v37; i++ {
+			v38 := NewPopulatedLinuxDevice(r, easy)
+			this.Devices[i] = *v38
 		}
 	}
 	if r.Intn(5) != 0 {
 		this.Seccomp = NewPopulatedLinuxSeccomp(r, easy)
 	}
 	this.RootfsPropagation = string(randStringOci(r))
-	v33 := r.Intn(10)
-	this.MaskedPaths = make([]string, v33)
-	for i := 0; i < v33; i++ {
+	v39 := r.Intn(10)
+	this.MaskedPaths = make([]string, v39)
+	for i := 0; i < v39; i++ {
 		this.MaskedPaths[i] = string(randStringOci(r))
 	}
-	v34 := r.Intn(10)
-	this.ReadonlyPaths = make([]string, v34)
-	for i := 0; i < v34; i++ {
+	v40 := r.Intn(10)
+	this.ReadonlyPaths = make([]string, v40)
+	for i := 0; i < v40; i++ {
 		this.ReadonlyPaths[i] = string(randStringOci(r))
 	}
 	this.MountLabel = string(randStringOci(r))
@@ -5329,11 +5488,11 @@ func NewPopulatedLinuxDevice(r randyOci, easy bool) *LinuxDevice {
 func NewPopulatedLinuxResources(r randyOci, easy bool) *LinuxResources {
 	this := &LinuxResources{}
 	if r.Intn(5) != 0 {
-		v35 := r.Intn(5)
-		this.Devices = make([]LinuxDeviceCgroup, v35)
-		for i := 0; i < v35; i++ {
-			v36 := NewPopulatedLinuxDeviceCgroup(r, easy)
-			this.Devices[i] = *v36
+		v41 := r.Intn(5)
+		this.Devices = make([]LinuxDeviceCgroup, v41)
+		for i := 0; i < v41; i++ {
+			v42 := NewPopulatedLinuxDeviceCgroup(r, easy)
+			this.Devices[i] = *v42
 		}
 	}
 	if r.Intn(5) != 0 {
@@ -5349,18 +5508,25 @@ func NewPopulatedLinuxResources(r randyOci, easy bool) *LinuxResources {
 		this.BlockIO = NewPopulatedLinuxBlockIO(r, easy)
 	}
 	if r.Intn(5) != 0 {
-		v37 := r.Intn(5)
-		this.HugepageLimits = make([]LinuxHugepageLimit, v37)
-		for i := 0; i < v37; i++ {
-			v38 := NewPopulatedLinuxHugepageLimit(r, easy)
-			this.HugepageLimits[i] = *v38
+		v43 := r.Intn(5)
+		this.HugepageLimits = make([]LinuxHugepageLimit, v43)
+		for i := 0; i < v43; i++ {
+			v44 := NewPopulatedLinuxHugepageLimit(r, easy)
+			this.HugepageLimits[i] = *v44
 		}
 	}
 	if r.Intn(5) != 0 {
 		this.Network = NewPopulatedLinuxNetwork(r, easy)
 	}
+	if r.Intn(5) != 0 {
+		v45 := r.Intn(10)
+		this.Unified = make(map[string]string)
+		for i := 0; i < v45; i++ {
+			this.Unified[randStringOci(r)] = randStringOci(r)
+		}
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedOci(r, 8)
+		this.XXX_unrecognized = randUnrecognizedOci(r, 9)
 	}
 	return this
 }
@@ -5456,43 +5622,43 @@ func NewPopulatedLinuxBlockIO(r randyOci, easy bool) *LinuxBlockIO {
 	this.Weight = uint32(r.Uint32())
 	this.LeafWeight = uint32(r.Uint32())
 	if r.Intn(5) != 0 {
-		v39 := r.Intn(5)
-		this.WeightDevice = make([]LinuxWeightDevice, v39)
-		for i := 0; i < v39; i++ {
-			v40 := NewPopulatedLinuxWeightDevice(r, easy)
-			this.WeightDevice[i] = *v40
+		v46 := r.Intn(5)
+		this.WeightDevice = make([]LinuxWeightDevice, v46)
+		for i := 0; i < v46; i++ {
+			v47 := NewPopulatedLinuxWeightDevice(r, easy)
+			this.WeightDevice[i] = *v47
 		}
 	}
 	if r.Intn(5) != 0 {
-		v41 := r.Intn(5)
-		this.ThrottleReadBpsDevice = make([]LinuxThrottleDevice, v41)
-		for i := 0; i < v41; i++ {
-			v42 := NewPopulatedLinuxThrottleDevice(r, easy)
-			this.ThrottleReadBpsDevice[i] = *v42
+		v48 := r.Intn(5)
+		this.ThrottleReadBpsDevice = make([]LinuxThrottleDevice, v48)
+		for i := 0; i < v48; i++ {
+			v49 := NewPopulatedLinuxThrottleDevice(r, easy)
+			this.ThrottleReadBpsDevice[i] = *v49
 		}
 	}
 	if r.Intn(5) != 0 {
-		v43 := r.Intn(5)
-		this.ThrottleWriteBpsDevice = make([]LinuxThrottleDevice, v43)
-		for i := 0; i < v43; i++ {
-			v44 := NewPopulatedLinuxThrottleDevice(r, easy)
-			this.ThrottleWriteBpsDevice[i] = *v44
+		v50 := r.Intn(5)
+		this.ThrottleWriteBpsDevice = make([]LinuxThrottleDevice, v50)
+		for i := 0; i < v50; i++ {
+			v51 := NewPopulatedLinuxThrottleDevice(r, easy)
+			this.ThrottleWriteBpsDevice[i] = *v51
 		}
 	}
 	if r.Intn(5) != 0 {
-		v45 := r.Intn(5)
-		this.ThrottleReadIOPSDevice = make([]LinuxThrottleDevice, v45)
-		for i := 0; i < v45; i++ {
-			v46 := NewPopulatedLinuxThrottleDevice(r, easy)
-			this.ThrottleReadIOPSDevice[i] = *v46
+		v52 := r.Intn(5)
+		this.ThrottleReadIOPSDevice = make([]LinuxThrottleDevice, v52)
+		for i := 0; i < v52; i++ {
+			v53 := NewPopulatedLinuxThrottleDevice(r, easy)
+			this.ThrottleReadIOPSDevice[i] = *v53
 		}
 	}
 	if r.Intn(5) != 0 {
-		v47 := r.Intn(5)
-		this.ThrottleWriteIOPSDevice = make([]LinuxThrottleDevice, v47)
-		for i := 0; i < v47; i++ {
-			v48 := NewPopulatedLinuxThrottleDevice(r, easy)
-			this.ThrottleWriteIOPSDevice[i] = *v48
+		v54 := r.Intn(5)
+		this.ThrottleWriteIOPSDevice = make([]LinuxThrottleDevice, v54)
+		for i := 0; i < v54; i++ {
+			v55 := NewPopulatedLinuxThrottleDevice(r, easy)
+			this.ThrottleWriteIOPSDevice[i] = *v55
 		}
 	}
 	if !easy && r.Intn(10) != 0 {
@@ -5536,11 +5702,11 @@ func NewPopulatedLinuxNetwork(r randyOci, easy bool) *LinuxNetwork {
 	this := &LinuxNetwork{}
 	this.ClassID = uint32(r.Uint32())
 	if r.Intn(5) != 0 {
-		v49 := r.Intn(5)
-		this.Priorities = make([]LinuxInterfacePriority, v49)
-		for i := 0; i < v49; i++ {
-			v50 := NewPopulatedLinuxInterfacePriority(r, easy)
-			this.Priorities[i] = *v50
+		v56 := r.Intn(5)
+		this.Priorities = make([]LinuxInterfacePriority, v56)
+		for i := 0; i < v56; i++ {
+			v57 := NewPopulatedLinuxInterfacePriority(r, easy)
+			this.Priorities[i] = *v57
 		}
 	}
 	if !easy && r.Intn(10) != 0 {
@@ -5572,21 +5738,26 @@ func NewPopulatedLinuxInterfacePriority(r randyOci, easy bool) *LinuxInterfacePr
 func NewPopulatedLinuxSeccomp(r randyOci, easy bool) *LinuxSeccomp {
 	this := &LinuxSeccomp{}
 	this.DefaultAction = string(randStringOci(r))
-	v51 := r.Intn(10)
-	this.Architectures = make([]string, v51)
-	for i := 0; i < v51; i++ {
+	v58 := r.Intn(10)
+	this.Architectures = make([]string, v58)
+	for i := 0; i < v58; i++ {
 		this.Architectures[i] = string(randStringOci(r))
 	}
 	if r.Intn(5) != 0 {
-		v52 := r.Intn(5)
-		this.Syscalls = make([]LinuxSyscall, v52)
-		for i := 0; i < v52; i++ {
-			v53 := NewPopulatedLinuxSyscall(r, easy)
-			this.Syscalls[i] = *v53
+		v59 := r.Intn(5)
+		this.Syscalls = make([]LinuxSyscall, v59)
+		for i := 0; i < v59; i++ {
+			v60 := NewPopulatedLinuxSyscall(r, easy)
+			this.Syscalls[i] = *v60
 		}
 	}
+	v61 := r.Intn(10)
+	this.Flags = make([]string, v61)
+	for i := 0; i < v61; i++ {
+		this.Flags[i] = string(randStringOci(r))
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedOci(r, 4)
+		this.XXX_unrecognized = randUnrecognizedOci(r, 5)
 	}
 	return this
 }
@@ -5605,22 +5776,23 @@ func NewPopulatedLinuxSeccompArg(r randyOci, easy bool) *LinuxSeccompArg {
 
 func NewPopulatedLinuxSyscall(r randyOci, easy bool) *LinuxSyscall {
 	this := &LinuxSyscall{}
-	v54 := r.Intn(10)
-	this.Names = make([]string, v54)
-	for i := 0; i < v54; i++ {
+	v62 := r.Intn(10)
+	this.Names = make([]string, v62)
+	for i := 0; i < v62; i++ {
 		this.Names[i] = string(randStringOci(r))
 	}
 	this.Action = string(randStringOci(r))
 	if r.Intn(5) != 0 {
-		v55 := r.Intn(5)
-		this.Args = make([]LinuxSeccompArg, v55)
-		for i := 0; i < v55; i++ {
-			v56 := NewPopulatedLinuxSeccompArg(r, easy)
-			this.Args[i] = *v56
+		v63 := r.Intn(5)
+		this.Args = make([]LinuxSeccompArg, v63)
+		for i := 0; i < v63; i++ {
+			v64 := NewPopulatedLinuxSeccompArg(r, easy)
+			this.Args[i] = *v64
 		}
 	}
+	this.ErrnoRet = uint32(r.Uint32())
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedOci(r, 4)
+		this.XXX_unrecognized = randUnrecognizedOci(r, 5)
 	}
 	return this
 }
@@ -5653,9 +5825,9 @@ func randUTF8RuneOci(r randyOci) rune {
 	return rune(ru + 61)
 }
 func randStringOci(r randyOci) string {
-	v57 := r.Intn(100)
-	tmps := make([]rune, v57)
-	for i := 0; i < v57; i++ {
+	v65 := r.Intn(100)
+	tmps := make([]rune, v65)
+	for i := 0; i < v65; i++ {
 		tmps[i] = randUTF8RuneOci(r)
 	}
 	return string(tmps)
@@ -5677,11 +5849,11 @@ func randFieldOci(dAtA []byte, r randyOci, fieldNumber int, wire int) []byte {
 	switch wire {
 	case 0:
 		dAtA = encodeVarintPopulateOci(dAtA, uint64(key))
-		v58 := r.Int63()
+		v66 := r.Int63()
 		if r.Intn(2) == 0 {
-			v58 *= -1
+			v66 *= -1
 		}
-		dAtA = encodeVarintPopulateOci(dAtA, uint64(v58))
+		dAtA = encodeVarintPopulateOci(dAtA, uint64(v66))
 	case 1:
 		dAtA = encodeVarintPopulateOci(dAtA, uint64(key))
 		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
@@ -6009,6 +6181,24 @@ func (m *Hooks) Size() (n int) {
 			n += 1 + l + sovOci(uint64(l))
 		}
 	}
+	if len(m.CreateRuntime) > 0 {
+		for _, e := range m.CreateRuntime {
+			l = e.Size()
+			n += 1 + l + sovOci(uint64(l))
+		}
+	}
+	if len(m.CreateContainer) > 0 {
+		for _, e := range m.CreateContainer {
+			l = e.Size()
+			n += 1 + l + sovOci(uint64(l))
+		}
+	}
+	if len(m.StartContainer) > 0 {
+		for _, e := range m.StartContainer {
+			l = e.Size()
+			n += 1 + l + sovOci(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -6272,6 +6462,14 @@ func (m *LinuxResources) Size() (n int) {
 		l = m.Network.Size()
 		n += 1 + l + sovOci(uint64(l))
 	}
+	if len(m.Unified) > 0 {
+		for k, v := range m.Unified {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovOci(uint64(len(k))) + 1 + len(v) + sovOci(uint64(len(v)))
+			n += mapEntrySize + 1 + sovOci(uint64(mapEntrySize))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -6564,6 +6762,12 @@ func (m *LinuxSeccomp) Size() (n int) {
 			n += 1 + l + sovOci(uint64(l))
 		}
 	}
+	if len(m.Flags) > 0 {
+		for _, s := range m.Flags {
+			l = len(s)
+			n += 1 + l + sovOci(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -6617,6 +6821,9 @@ func (m *LinuxSyscall) Size() (n int) {
 			n += 1 + l + sovOci(uint64(l))
 		}
 	}
+	if m.ErrnoRet != 0 {
+		n += 1 + sovOci(uint64(m.ErrnoRet))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -6806,10 +7013,28 @@ func (this *Hooks) String() string {
 		repeatedStringForPoststop += strings.Replace(strings.Replace(f.String(), "Hook", "Hook", 1), `&`, ``, 1) + ","
 	}
 	repeatedStringForPoststop += "}"
+	repeatedStringForCreateRuntime := "[]Hook{"
+	for _, f := range this.CreateRuntime {
+		repeatedStringForCreateRuntime += strings.Replace(strings.Replace(f.String(), "Hook", "Hook", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForCreateRuntime += "}"
+	repeatedStringForCreateContainer := "[]Hook{"
+	for _, f := range this.CreateContainer {
+		repeatedStringForCreateContainer += strings.Replace(strings.Replace(f.String(), "Hook", "Hook", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForCreateContainer += "}"
+	repeatedStringForStartContainer := "[]Hook{"
+	for _, f := range this.StartContainer {
+		repeatedStringForStartContainer += strings.Replace(strings.Replace(f.String(), "Hook", "Hook", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForStartContainer += "}"
 	s := strings.Join([]string{`&Hooks{`,
 		`Prestart:` + repeatedStringForPrestart + `,`,
 		`Poststart:` + repeatedStringForPoststart + `,`,
 		`Poststop:` + repeatedStringForPoststop + `,`,
+		`CreateRuntime:` + repeatedStringForCreateRuntime + `,`,
+		`CreateContainer:` + repeatedStringForCreateContainer + `,`,
+		`StartContainer:` + repeatedStringForStartContainer + `,`,
 		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
 		`}`,
 	}, "")
@@ -6960,6 +7185,16 @@ func (this *LinuxResources) String() string {
 		repeatedStringForHugepageLimits += strings.Replace(strings.Replace(f.String(), "LinuxHugepageLimit", "LinuxHugepageLimit", 1), `&`, ``, 1) + ","
 	}
 	repeatedStringForHugepageLimits += "}"
+	keysForUnified := make([]string, 0, len(this.Unified))
+	for k, _ := range this.Unified {
+		keysForUnified = append(keysForUnified, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForUnified)
+	mapStringForUnified := "map[string]string{"
+	for _, k := range keysForUnified {
+		mapStringForUnified += fmt.Sprintf("%v: %v,", k, this.Unified[k])
+	}
+	mapStringForUnified += "}"
 	s := strings.Join([]string{`&LinuxResources{`,
 		`Devices:` + repeatedStringForDevices + `,`,
 		`Memory:` + strings.Replace(this.Memory.String(), "LinuxMemory", "LinuxMemory", 1) + `,`,
@@ -6968,6 +7203,7 @@ func (this *LinuxResources) String() string {
 		`BlockIO:` + strings.Replace(this.BlockIO.String(), "LinuxBlockIO", "LinuxBlockIO", 1) + `,`,
 		`HugepageLimits:` + repeatedStringForHugepageLimits + `,`,
 		`Network:` + strings.Replace(this.Network.String(), "LinuxNetwork", "LinuxNetwork", 1) + `,`,
+		`Unified:` + mapStringForUnified + `,`,
 		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
 		`}`,
 	}, "")
@@ -7156,6 +7392,7 @@ func (this *LinuxSeccomp) String() string {
 		`DefaultAction:` + fmt.Sprintf("%v", this.DefaultAction) + `,`,
 		`Architectures:` + fmt.Sprintf("%v", this.Architectures) + `,`,
 		`Syscalls:` + repeatedStringForSyscalls + `,`,
+		`Flags:` + fmt.Sprintf("%v", this.Flags) + `,`,
 		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
 		`}`,
 	}, "")
@@ -7188,6 +7425,7 @@ func (this *LinuxSyscall) String() string {
 		`Names:` + fmt.Sprintf("%v", this.Names) + `,`,
 		`Action:` + fmt.Sprintf("%v", this.Action) + `,`,
 		`Args:` + repeatedStringForArgs + `,`,
+		`ErrnoRet:` + fmt.Sprintf("%v", this.ErrnoRet) + `,`,
 		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
 		`}`,
 	}, "")
@@ -9168,6 +9406,108 @@ func (m *Hooks) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateRuntime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOci
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOci
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOci
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CreateRuntime = append(m.CreateRuntime, Hook{})
+			if err := m.CreateRuntime[len(m.CreateRuntime)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateContainer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOci
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOci
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOci
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CreateContainer = append(m.CreateContainer, Hook{})
+			if err := m.CreateContainer[len(m.CreateContainer)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartContainer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOci
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOci
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOci
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StartContainer = append(m.StartContainer, Hook{})
+			if err := m.StartContainer[len(m.StartContainer)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOci(dAtA[iNdEx:])
@@ -10838,6 +11178,133 @@ func (m *LinuxResources) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unified", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOci
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOci
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOci
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Unified == nil {
+				m.Unified = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOci
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOci
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthOci
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthOci
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOci
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthOci
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthOci
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipOci(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthOci
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Unified[mapkey] = mapvalue
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOci(dAtA[iNdEx:])
@@ -12460,6 +12927,38 @@ func (m *LinuxSeccomp) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOci
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOci
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOci
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Flags = append(m.Flags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOci(dAtA[iNdEx:])
@@ -12755,6 +13254,25 @@ func (m *LinuxSyscall) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrnoRet", wireType)
+			}
+			m.ErrnoRet = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOci
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ErrnoRet |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOci(dAtA[iNdEx:])
@@ -148,6 +148,79 @@ func TestProcessGRPCtoOCI(t *testing.T) {
 	assertProcessIsEqual(t, newOciProcess, grpcProcess)
 }
 
+func TestResourcesUnified(t *testing.T) {
+	assert := assert.New(t)
+
+	ociResources := &specs.LinuxResources{
+		Unified: map[string]string{
+			"memory.min":  "1048576",
+			"memory.high": "max",
+		},
+	}
+
+	grpcResources, err := ResourcesOCItoGRPC(ociResources)
+	assert.NoError(err)
+	assert.Equal(ociResources.Unified, grpcResources.Unified)
+
+	data, err := grpcResources.Marshal()
+	assert.NoError(err)
+	decoded := &LinuxResources{}
+	assert.NoError(decoded.Unmarshal(data))
+	assert.Equal(ociResources.Unified, decoded.Unified)
+
+	newOciResources, err := ResourcesGRPCtoOCI(decoded)
+	assert.NoError(err)
+	assert.Equal(ociResources.Unified, newOciResources.Unified)
+}
+
+func TestHooksAndSeccomp(t *testing.T) {
+	assert := assert.New(t)
+
+	errnoRet := uint(1)
+	ociSpec := &specs.Spec{
+		Hooks: &specs.Hooks{
+			CreateRuntime:   []specs.Hook{{Path: "/usr/bin/create-runtime"}},
+			CreateContainer: []specs.Hook{{Path: "/usr/bin/create-container", Args: []string{"create-container"}}},
+			StartContainer:  []specs.Hook{{Path: "/usr/bin/start-container"}},
+		},
+		Linux: &specs.Linux{
+			Seccomp: &specs.LinuxSeccomp{
+				DefaultAction: specs.ActErrno,
+				Flags:         []specs.LinuxSeccompFlag{"SECCOMP_FILTER_FLAG_LOG"},
+				Syscalls: []specs.LinuxSyscall{
+					{
+						Names:    []string{"mkdir"},
+						Action:   specs.ActErrno,
+						ErrnoRet: &errnoRet,
+					},
+				},
+			},
+		},
+	}
+
+	grpcSpec, err := OCItoGRPC(ociSpec)
+	assert.NoError(err)
+
+	data, err := grpcSpec.Marshal()
+	assert.NoError(err)
+	decoded := &Spec{}
+	assert.NoError(decoded.Unmarshal(data))
+
+	assert.Len(decoded.Hooks.CreateRuntime, 1)
+	assert.Equal(ociSpec.Hooks.CreateContainer[0].Args, decoded.Hooks.CreateContainer[0].Args)
+	assert.Equal(ociSpec.Hooks.StartContainer[0].Path, decoded.Hooks.StartContainer[0].Path)
+	assert.Equal([]string{"SECCOMP_FILTER_FLAG_LOG"}, decoded.Linux.Seccomp.Flags)
+	assert.EqualValues(errnoRet, decoded.Linux.Seccomp.Syscalls[0].ErrnoRet)
+
+	newOciSpec, err := GRPCtoOCI(decoded)
+	assert.NoError(err)
+	assert.Equal(ociSpec.Hooks.CreateRuntime[0].Path, newOciSpec.Hooks.CreateRuntime[0].Path)
+	assert.Equal(ociSpec.Hooks.CreateContainer[0].Args, newOciSpec.Hooks.CreateContainer[0].Args)
+	assert.Equal(ociSpec.Hooks.StartContainer[0].Path, newOciSpec.Hooks.StartContainer[0].Path)
+	assert.Equal(ociSpec.Linux.Seccomp.Flags, newOciSpec.Linux.Seccomp.Flags)
+	assert.Equal(errnoRet, *newOciSpec.Linux.Seccomp.Syscalls[0].ErrnoRet)
+}
+
 func testCopyValue(t *testing.T, to, from interface{}) {
 	assert := assert.New(t)
 
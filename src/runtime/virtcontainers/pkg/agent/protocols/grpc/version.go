@@ -7,5 +7,6 @@
 package grpc
 
 // APIVersion specifies the version of the gRPC communications protocol used
-// by Kata Containers.
-const APIVersion = "0.0.1"
+// by Kata Containers. Its minor version is bumped when requests or fields
+// are added, its major version when they are changed or removed.
+const APIVersion = "0.1.0"
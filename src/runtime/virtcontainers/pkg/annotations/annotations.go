@@ -41,6 +41,13 @@ const (
 	// HypervisorPath is a sandbox annotation for passing a per container path pointing at the hypervisor that will run the container VM.
 	HypervisorPath = kataAnnotHypervisorPrefix + "path"
 
+	// HypervisorProfile is a sandbox annotation selecting the hypervisor of a profile of the runtime configuration.
+	HypervisorProfile = kataAnnotHypervisorPrefix + "profile"
+
+	// KernelParamsProfile is a sandbox annotation selecting the kernel parameters of a profile of the
+	// runtime configuration, merged with the kernel parameters.
+	KernelParamsProfile = kataAnnotHypervisorPrefix + "kernel_params_profile"
+
 	// JailerPath is a sandbox annotation for passing a per container path pointing at the jailer that will constrain the container VM.
 	JailerPath = kataAnnotHypervisorPrefix + "jailer_path"
 
@@ -87,6 +94,14 @@ const (
 	// DisableVhostNet is a sandbox annotation to specify if vhost-net is not available on the host.
 	DisableVhostNet = kataAnnotHypervisorPrefix + "disable_vhost_net"
 
+	// NetworkQueues is a sandbox annotation to specify the number of queue pairs of the
+	// network devices, sized from the vCPUs when zero.
+	NetworkQueues = kataAnnotHypervisorPrefix + "network_queues"
+
+	// NetworkMaxQueues is a sandbox annotation to cap the queue pairs of the network devices
+	// sized from the vCPUs.
+	NetworkMaxQueues = kataAnnotHypervisorPrefix + "network_max_queues"
+
 	// EnableVhostUserStore is a sandbox annotation to specify if vhost-user-blk/scsi is abailable on the host
 	EnableVhostUserStore = kataAnnotHypervisorPrefix + "enable_vhost_user_store"
 
@@ -94,6 +109,10 @@ const (
 	// related folders, sockets and device nodes should be.
 	VhostUserStorePath = kataAnnotHypervisorPrefix + "vhost_user_store_path"
 
+	// VhostUserReconnect is a sandbox annotation to specify the number of seconds between
+	// the attempts to connect again to a restarted vhost-user storage backend.
+	VhostUserReconnect = kataAnnotHypervisorPrefix + "vhost_user_reconnect"
+
 	// GuestHookPath is a sandbox annotation to specify the path within the VM that will be used for 'drop-in' hooks.
 	GuestHookPath = kataAnnotHypervisorPrefix + "guest_hook_path"
 
@@ -154,6 +173,10 @@ const (
 	// Iommu is a sandbox annotation to specify if the VM should have a vIOMMU device
 	IOMMU = kataAnnotHypervisorPrefix + "enable_iommu"
 
+	// FreePageReporting is a sandbox annotation to specify if the guest reports
+	// its free pages to the hypervisor, which returns them to the host.
+	FreePageReporting = kataAnnotHypervisorPrefix + "enable_free_page_reporting"
+
 	// FileBackedMemRootDir is a sandbox annotation to soecify file based memory backend root directory
 	FileBackedMemRootDir = kataAnnotHypervisorPrefix + "file_mem_backend"
 
@@ -164,7 +187,7 @@ const (
 	// Msize9p is a sandbox annotation to specify as the msize for 9p shares
 	Msize9p = kataAnnotHypervisorPrefix + "msize_9p"
 
-	// SharedFs is a sandbox annotation to specify the shared file system type, either virtio-9p or virtio-fs.
+	// SharedFs is a sandbox annotation to specify the shared file system type, either virtio-9p, virtio-fs or none.
 	SharedFS = kataAnnotHypervisorPrefix + "shared_fs"
 
 	// VirtioFSDaemon is a sandbox annotations to specify virtio-fs vhost-user daemon path
@@ -231,6 +254,10 @@ const (
 	//the container network interface.
 	InterNetworkModel = kataAnnotRuntimePrefix + "internetworking_model"
 
+	// InterfaceEndpoints is a sandbox annotation that forces the endpoint of some network
+	// interfaces, as a comma separated list of <interface>=<endpoint> entries.
+	InterfaceEndpoints = kataAnnotRuntimePrefix + "interface_endpoints"
+
 	// DisableNewNetNs is a sandbox annotation that determines if create a netns for hypervisor process.
 	DisableNewNetNs = kataAnnotRuntimePrefix + "disable_new_netns"
 )
@@ -251,6 +278,10 @@ const (
 	//
 	KernelModules = kataAnnotAgentPrefix + "kernel_modules"
 
+	// AgentType is a sandbox annotation to select the agent implementation,
+	// among the ones the runtime is built with.
+	AgentType = kataAnnotAgentPrefix + "type"
+
 	// AgentTrace is a sandbox annotation to enable tracing for the agent.
 	AgentTrace = kataAnnotAgentPrefix + "enable_tracing"
 
@@ -264,6 +295,75 @@ const (
 	AgentContainerPipeSize       = kataAnnotAgentPrefix + ContainerPipeSizeOption
 	ContainerPipeSizeOption      = "container_pipe_size"
 	ContainerPipeSizeKernelParam = "agent." + ContainerPipeSizeOption
+
+	// AgentNTPServers is a sandbox annotation to specify the comma separated
+	// NTP servers the guest clock is synchronized with.
+	AgentNTPServers = kataAnnotAgentPrefix + "ntp_servers"
+
+	// AgentNTPPools is a sandbox annotation to specify the comma separated
+	// NTP server pools the guest clock is synchronized with.
+	AgentNTPPools = kataAnnotAgentPrefix + "ntp_pools"
+
+	// AgentPTPKVM is a sandbox annotation to synchronize the guest clock with
+	// the host clock through the ptp_kvm guest driver.
+	AgentPTPKVM = kataAnnotAgentPrefix + "ptp_kvm"
+
+	// AgentTimezone is a sandbox annotation to specify the guest timezone,
+	// a name of the host timezone database such as "Europe/Paris".
+	AgentTimezone = kataAnnotAgentPrefix + "timezone"
+
+	// AgentLocale is a sandbox annotation to specify the guest locale.
+	AgentLocale = kataAnnotAgentPrefix + "locale"
+
+	// AgentCacheDropInterval is a sandbox annotation to specify how often, in
+	// seconds, the agent drops the clean guest page cache.
+	AgentCacheDropInterval = kataAnnotAgentPrefix + "cache_drop_interval"
+
+	// AgentCacheDropThreshold is a sandbox annotation to specify the size of
+	// the guest page cache, in MiB, below which it is not dropped.
+	AgentCacheDropThreshold = kataAnnotAgentPrefix + "cache_drop_threshold"
+
+	// AgentVFSCachePressure is a sandbox annotation to specify the
+	// vm.vfs_cache_pressure of the guest.
+	AgentVFSCachePressure = kataAnnotAgentPrefix + "vfs_cache_pressure"
+
+	// AgentIdleScanInterval is a sandbox annotation to specify how often, in
+	// seconds, the agent scans the guest memory for the idle pages.
+	AgentIdleScanInterval = kataAnnotAgentPrefix + "idle_scan_interval"
+
+	// AgentMetadataAllowedPaths is a sandbox annotation to specify the paths
+	// of the instance metadata service the guest can access, among the ones
+	// the runtime allows.
+	AgentMetadataAllowedPaths = kataAnnotAgentPrefix + "metadata_allowed_paths"
+)
+
+const (
+	kataAnnotContainerPrefix = kataAnnotationsPrefix + "container."
+
+	// ContainerCoreDumpPolicy is a container annotation to specify what is
+	// done with the core dumps of the container processes, "discard" or
+	// "capture" to the runtime core dumps directory.
+	ContainerCoreDumpPolicy = kataAnnotContainerPrefix + "coredump_policy"
+
+	// ContainerCoreDumpMaxSize is a container annotation to specify the size
+	// in bytes the captured core dumps are truncated to.
+	ContainerCoreDumpMaxSize = kataAnnotContainerPrefix + "coredump_max_size"
+
+	// ContainerEncryptedScratchSize is a container annotation to specify the
+	// size in MiB of the ephemeral encrypted block device backing the
+	// writable layer of the rootfs.
+	ContainerEncryptedScratchSize = kataAnnotContainerPrefix + "encrypted_scratch_size"
+
+	// ContainerVolumeBlockCache is a container annotation to specify the
+	// cache modes of the volumes attached to the VM as block devices, as a
+	// comma separated list of <destination>=<mode>, mode being "none",
+	// "writeback", "writethrough" or "directsync".
+	ContainerVolumeBlockCache = kataAnnotContainerPrefix + "volume_block_cache"
+
+	// ContainerMdevDevices is a container annotation to pass mediated
+	// devices, such as vGPUs, to the container, as a comma separated list of
+	// the UUIDs of the devices on the host.
+	ContainerMdevDevices = kataAnnotContainerPrefix + "mdev_devices"
 )
 
 const (
@@ -0,0 +1,437 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package annotations
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Type is the type of the value of an annotation.
+type Type string
+
+const (
+	// TypeString is any string.
+	TypeString Type = "string"
+
+	// TypeBool is a boolean, as parsed by strconv.ParseBool.
+	TypeBool Type = "bool"
+
+	// TypeUint is a base 10 unsigned integer.
+	TypeUint Type = "uint"
+
+	// TypeList is a list of strings, separated by Annotation.Separator.
+	TypeList Type = "list"
+)
+
+// Alias is a deprecated key of an annotation, still accepted in place of
+// the annotation key.
+type Alias struct {
+	Key string
+
+	// Since is the release deprecating the alias.
+	Since string
+}
+
+// Annotation describes a supported annotation.
+type Annotation struct {
+	Key         string
+	Type        Type
+	Description string
+
+	// Default is the value used when the annotation is not set, empty if
+	// it comes from the runtime configuration.
+	Default string
+
+	// Min and Max bound the TypeUint values, when not zero.
+	Min uint64
+	Max uint64
+
+	// Values are the accepted values, any if empty.
+	Values []string
+
+	// Separator separates the TypeList items.
+	Separator string
+
+	// Since is the release the annotation was introduced in, empty for
+	// the annotations predating the registry.
+	Since string
+
+	// Aliases are the deprecated keys of the annotation.
+	Aliases []Alias
+}
+
+const maxUint32 = math.MaxUint32
+
+// registry are the supported annotations, grouped as their keys.
+var registry = []Annotation{
+	// OCI
+	{Key: BundlePathKey, Type: TypeString, Description: "OCI bundle path, set by the runtime"},
+	{Key: ContainerTypeKey, Type: TypeString, Description: "Container type, set by the runtime",
+		Values: []string{"pod_sandbox", "pod_container"}},
+	{Key: SandboxConfigPathKey, Type: TypeString, Description: "Runtime configuration file path"},
+
+	// Assets
+	{Key: KernelPath, Type: TypeString, Description: "Guest kernel path"},
+	{Key: ImagePath, Type: TypeString, Description: "Guest image path"},
+	{Key: InitrdPath, Type: TypeString, Description: "Guest initrd path"},
+	{Key: HypervisorPath, Type: TypeString, Description: "Hypervisor binary path"},
+	{Key: HypervisorProfile, Type: TypeString, Description: "Hypervisor profile, among the hypervisor_profiles of the runtime configuration"},
+	{Key: KernelParamsProfile, Type: TypeString, Description: "Kernel parameters profile, among the kernel_params_profiles of the runtime configuration"},
+	{Key: JailerPath, Type: TypeString, Description: "Jailer binary path"},
+	{Key: FirmwarePath, Type: TypeString, Description: "Guest firmware path"},
+	{Key: KernelHash, Type: TypeString, Description: "Guest kernel hash"},
+	{Key: ImageHash, Type: TypeString, Description: "Guest image hash"},
+	{Key: InitrdHash, Type: TypeString, Description: "Guest initrd hash"},
+	{Key: HypervisorHash, Type: TypeString, Description: "Hypervisor binary hash"},
+	{Key: JailerHash, Type: TypeString, Description: "Jailer binary hash"},
+	{Key: FirmwareHash, Type: TypeString, Description: "Guest firmware hash"},
+	{Key: AssetHashType, Type: TypeString, Description: "Hash algorithm of the asset hashes", Default: SHA512},
+
+	// Hypervisor
+	{Key: KernelParams, Type: TypeString, Description: "Additional guest kernel parameters, space separated"},
+	{Key: MachineType, Type: TypeString, Description: "Machine type emulated by the hypervisor"},
+	{Key: MachineAccelerators, Type: TypeString, Description: "Machine accelerators"},
+	{Key: CPUFeatures, Type: TypeString, Description: "Guest CPU features"},
+	{Key: DisableVhostNet, Type: TypeBool, Description: "Do not use vhost-net for the network"},
+	{Key: NetworkQueues, Type: TypeUint, Description: "Queue pairs of the network devices, sized from the vCPUs when 0", Max: 256},
+	{Key: NetworkMaxQueues, Type: TypeUint, Description: "Most queue pairs of the network devices sized from the vCPUs", Max: 256},
+	{Key: EnableVhostUserStore, Type: TypeBool, Description: "Enable the vhost-user storage devices"},
+	{Key: VhostUserStorePath, Type: TypeString, Description: "Directory of the vhost-user devices sockets and nodes"},
+	{Key: VhostUserReconnect, Type: TypeUint, Description: "Seconds between the reconnections to a restarted vhost-user storage backend", Max: maxUint32},
+	{Key: GuestHookPath, Type: TypeString, Description: "Guest directory of the drop-in OCI hooks"},
+	{Key: UseVSock, Type: TypeBool, Description: "Talk to the agent over vsock"},
+	{Key: DisableImageNvdimm, Type: TypeBool, Description: "Do not attach the guest image as an nvdimm"},
+	{Key: HotplugVFIOOnRootBus, Type: TypeBool, Description: "Hotplug the VFIO devices on the root bus rather than a bridge"},
+	{Key: PCIeRootPort, Type: TypeUint, Description: "Number of PCIe root ports for hotplug", Max: maxUint32},
+	{Key: EntropySource, Type: TypeString, Description: "Host source of entropy"},
+
+	// CPU
+	{Key: DefaultVCPUs, Type: TypeUint, Description: "Number of vCPUs at boot, at most the host CPUs", Max: maxUint32},
+	{Key: DefaultMaxVCPUs, Type: TypeUint, Description: "Maximum number of vCPUs, at most the host CPUs", Max: maxUint32,
+		Aliases: []Alias{{Key: kataAnnotHypervisorPrefix + "default_maxvcpus", Since: "2.0.0"}}},
+
+	// Memory
+	{Key: DefaultMemory, Type: TypeUint, Description: "Memory at boot in MiB", Max: maxUint32},
+	{Key: MemSlots, Type: TypeUint, Description: "Number of memory slots for hotplug", Max: maxUint32},
+	{Key: MemOffset, Type: TypeUint, Description: "Memory space of the nvdimm devices in MiB", Max: maxUint32},
+	{Key: VirtioMem, Type: TypeBool, Description: "Resize the memory with virtio-mem"},
+	{Key: MemPrealloc, Type: TypeBool, Description: "Preallocate the guest memory"},
+	{Key: EnableSwap, Type: TypeBool, Description: "Let the guest memory be swapped out"},
+	{Key: HugePages, Type: TypeBool, Description: "Back the guest memory with huge pages"},
+	{Key: IOMMU, Type: TypeBool, Description: "Add a vIOMMU to the VM",
+		Aliases: []Alias{{Key: kataAnnotHypervisorPrefix + "iommu", Since: "2.0.0"}}},
+	{Key: FileBackedMemRootDir, Type: TypeString, Description: "Directory of the file backed guest memory"},
+	{Key: FreePageReporting, Type: TypeBool, Description: "Return the free guest pages the guest reports to the host"},
+
+	// Shared file system
+	{Key: Msize9p, Type: TypeUint, Description: "msize of the 9p shares", Min: 1, Max: maxUint32},
+	{Key: SharedFS, Type: TypeString, Description: "Shared file system",
+		Values: []string{"virtio-9p", "virtio-fs", "none"}},
+	{Key: VirtioFSDaemon, Type: TypeString, Description: "virtio-fs daemon path"},
+	{Key: VirtioFSCache, Type: TypeString, Description: "virtio-fs cache mode"},
+	{Key: VirtioFSCacheSize, Type: TypeUint, Description: "virtio-fs DAX cache size in MiB", Max: maxUint32},
+	{Key: VirtioFSExtraArgs, Type: TypeString, Description: "Extra arguments of the virtio-fs daemon"},
+
+	// Block devices
+	{Key: BlockDeviceDriver, Type: TypeString, Description: "Block device driver",
+		Values: []string{"virtio-scsi", "virtio-blk", "virtio-mmio", "nvdimm", "virtio-blk-ccw"}},
+	{Key: DisableBlockDeviceUse, Type: TypeBool, Description: "Do not use block devices for the container rootfs"},
+	{Key: EnableIOThreads, Type: TypeBool, Description: "Process the block I/O in separate threads"},
+	{Key: BlockDeviceCacheSet, Type: TypeBool, Description: "Set the cache options of the block devices"},
+	{Key: BlockDeviceCacheDirect, Type: TypeBool, Description: "Bypass the host page cache for the block devices"},
+	{Key: BlockDeviceCacheNoflush, Type: TypeBool, Description: "Ignore the flush requests of the block devices"},
+
+	// Network
+	{Key: RxRateLimiterMaxRate, Type: TypeUint, Description: "Inbound network bandwidth limit in bits per second"},
+	{Key: TxRateLimiterMaxRate, Type: TypeUint, Description: "Outbound network bandwidth limit in bits per second"},
+
+	// Runtime
+	{Key: DisableGuestSeccomp, Type: TypeBool, Description: "Do not apply seccomp in the guest"},
+	{Key: SandboxCgroupOnly, Type: TypeBool, Description: "Only use the sandbox cgroup on the host"},
+	{Key: EnablePprof, Type: TypeBool, Description: "Enable pprof in the shim"},
+	{Key: Experimental, Type: TypeList, Description: "Experimental features", Separator: " "},
+	{Key: InterNetworkModel, Type: TypeString, Description: "How the VM is connected to the container network",
+		Values: []string{"default", "macvtap", "tcfilter", "none"}},
+	{Key: InterfaceEndpoints, Type: TypeList, Description: "Endpoints forced for network interfaces, as <interface>=<endpoint>", Separator: ","},
+	{Key: DisableNewNetNs, Type: TypeBool, Description: "Do not create a network namespace for the hypervisor"},
+
+	// Agent
+	{Key: AgentType, Type: TypeString, Description: "Agent implementation of the sandbox"},
+	{Key: KernelModules, Type: TypeList, Description: "Guest kernel modules to load, with their parameters", Separator: ";",
+		Aliases: []Alias{{Key: "io.kata-containers.config.agent.kernel_modules", Since: "2.0.0"}}},
+	{Key: AgentTrace, Type: TypeBool, Description: "Enable the agent tracing"},
+	{Key: AgentTraceMode, Type: TypeString, Description: "Agent trace mode"},
+	{Key: AgentTraceType, Type: TypeString, Description: "Agent trace type"},
+	{Key: AgentContainerPipeSize, Type: TypeUint, Description: "Size of the container pipes in the guest", Max: maxUint32},
+	{Key: AgentNTPServers, Type: TypeList, Description: "NTP servers of the guest", Separator: ","},
+	{Key: AgentNTPPools, Type: TypeList, Description: "NTP server pools of the guest", Separator: ","},
+	{Key: AgentPTPKVM, Type: TypeBool, Description: "Synchronize the guest clock with the host clock through ptp_kvm"},
+	{Key: AgentTimezone, Type: TypeString, Description: "Guest timezone, from the host timezone database"},
+	{Key: AgentLocale, Type: TypeString, Description: "Guest locale"},
+	{Key: AgentCacheDropInterval, Type: TypeUint, Description: "Seconds between two drops of the clean guest page cache", Max: maxUint32},
+	{Key: AgentCacheDropThreshold, Type: TypeUint, Description: "Guest page cache size in MiB below which it is not dropped", Max: maxUint32},
+	{Key: AgentVFSCachePressure, Type: TypeUint, Description: "Guest vm.vfs_cache_pressure", Max: maxUint32},
+	{Key: AgentIdleScanInterval, Type: TypeUint, Description: "Seconds between two scans of the idle guest memory", Max: maxUint32},
+	{Key: AgentMetadataAllowedPaths, Type: TypeList, Description: "Paths of the instance metadata service the guest can access", Separator: ","},
+
+	// Container
+	{Key: ContainerCoreDumpPolicy, Type: TypeString, Description: "What is done with the core dumps of the container processes",
+		Values: []string{"discard", "capture"}},
+	{Key: ContainerCoreDumpMaxSize, Type: TypeUint, Description: "Size in bytes the captured core dumps are truncated to"},
+	{Key: ContainerEncryptedScratchSize, Type: TypeUint, Description: "Size in MiB of the encrypted block device backing the writable layer of the rootfs"},
+	{Key: ContainerVolumeBlockCache, Type: TypeList, Description: "Cache modes of the block device volumes, as <destination>=<mode>", Separator: ","},
+	{Key: ContainerMdevDevices, Type: TypeList, Description: "UUIDs of the mediated devices passed to the container", Separator: ","},
+}
+
+var (
+	registryKeys    = make(map[string]*Annotation)
+	registryAliases = make(map[string]*Annotation)
+)
+
+func init() {
+	for i := range registry {
+		a := &registry[i]
+		registryKeys[a.Key] = a
+		for _, alias := range a.Aliases {
+			registryAliases[alias.Key] = a
+		}
+	}
+}
+
+// ListSupportedAnnotations returns the supported annotations, sorted by
+// key.
+func ListSupportedAnnotations() []Annotation {
+	annotations := make([]Annotation, len(registry))
+	copy(annotations, registry)
+
+	sort.Slice(annotations, func(i, j int) bool {
+		return annotations[i].Key < annotations[j].Key
+	})
+
+	return annotations
+}
+
+// Lookup returns the annotation of a key, or of a deprecated alias.
+func Lookup(key string) (Annotation, bool) {
+	if a, ok := registryKeys[key]; ok {
+		return *a, true
+	}
+
+	if a, ok := registryAliases[key]; ok {
+		return *a, true
+	}
+
+	return Annotation{}, false
+}
+
+// Validate checks the value of the annotation has its type, and is in its
+// range or accepted values.
+func (a Annotation) Validate(value string) error {
+	switch a.Type {
+	case TypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("Invalid value %q for annotation %s: please specify boolean value 'true|false'", value, a.Key)
+		}
+	case TypeUint:
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("Invalid value %q for annotation %s: please specify positive numeric value", value, a.Key)
+		}
+		if n < a.Min || (a.Max > 0 && n > a.Max) {
+			return fmt.Errorf("Invalid value %q for annotation %s: please specify a value between %d and %d", value, a.Key, a.Min, a.max())
+		}
+	}
+
+	if len(a.Values) > 0 {
+		values := []string{value}
+		if a.Type == TypeList {
+			values = strings.Split(value, a.Separator)
+		}
+		for _, v := range values {
+			if !a.accepts(v) {
+				return fmt.Errorf("Invalid value %q for annotation %s (supported values: %v)", v, a.Key, a.Values)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (a Annotation) max() uint64 {
+	if a.Max > 0 {
+		return a.Max
+	}
+	return math.MaxUint64
+}
+
+func (a Annotation) accepts(value string) bool {
+	for _, v := range a.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// WarningKind is the kind of an annotation warning.
+type WarningKind string
+
+const (
+	// WarningUnknown is an unknown key in the kata containers namespace,
+	// ignored.
+	WarningUnknown WarningKind = "unknown"
+
+	// WarningDeprecated is a deprecated alias, used in place of its
+	// annotation key.
+	WarningDeprecated WarningKind = "deprecated"
+
+	// WarningShadowed is a deprecated alias set along its annotation key,
+	// ignored.
+	WarningShadowed WarningKind = "shadowed"
+)
+
+// Warning is an annotation not used as is.
+type Warning struct {
+	Key  string
+	Kind WarningKind
+
+	// Replacement is the key to use instead, the closest supported key
+	// for the unknown keys, if any.
+	Replacement string
+
+	// Since is the release deprecating the alias.
+	Since string
+}
+
+func (w Warning) String() string {
+	switch w.Kind {
+	case WarningDeprecated:
+		return fmt.Sprintf("Annotation %s is deprecated since %s, use %s", w.Key, w.Since, w.Replacement)
+	case WarningShadowed:
+		return fmt.Sprintf("Annotation %s is ignored as %s is set", w.Key, w.Replacement)
+	}
+
+	if w.Replacement != "" {
+		return fmt.Sprintf("Unknown annotation %s is ignored, did you mean %s?", w.Key, w.Replacement)
+	}
+	return fmt.Sprintf("Unknown annotation %s is ignored", w.Key)
+}
+
+// namespacePrefixes are the key prefixes of the annotations checked, the
+// second one being a frequent misspelling.
+var namespacePrefixes = []string{kataAnnotationsPrefix, "io.kata-containers."}
+
+func inNamespace(key string) bool {
+	for _, prefix := range namespacePrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve returns a copy of the annotations with the deprecated aliases
+// replaced by their annotation key, and the warnings about the deprecated
+// and unknown annotations of the kata containers namespace, sorted by key.
+// The annotations outside of the namespace are left alone.
+func Resolve(annotations map[string]string) (map[string]string, []Warning) {
+	resolved := make(map[string]string, len(annotations))
+	var warnings []Warning
+
+	for key, value := range annotations {
+		if _, ok := registryKeys[key]; ok || !inNamespace(key) {
+			resolved[key] = value
+			continue
+		}
+
+		a, ok := registryAliases[key]
+		if !ok {
+			warnings = append(warnings, Warning{
+				Key:         key,
+				Kind:        WarningUnknown,
+				Replacement: closestKey(key),
+			})
+			continue
+		}
+
+		w := Warning{
+			Key:         key,
+			Kind:        WarningDeprecated,
+			Replacement: a.Key,
+		}
+		for _, alias := range a.Aliases {
+			if alias.Key == key {
+				w.Since = alias.Since
+			}
+		}
+
+		if _, ok := annotations[a.Key]; ok {
+			w.Kind = WarningShadowed
+		} else {
+			resolved[a.Key] = value
+		}
+		warnings = append(warnings, w)
+	}
+
+	sort.Slice(warnings, func(i, j int) bool {
+		return warnings[i].Key < warnings[j].Key
+	})
+
+	return resolved, warnings
+}
+
+// closestKey returns the supported key the closest to an unknown key, if
+// close enough to be a typo.
+func closestKey(key string) string {
+	closest := ""
+	best := len(key)/4 + 1
+
+	for k := range registryKeys {
+		if d := editDistance(key, k); d <= best && (closest == "" || d < best || k < closest) {
+			closest = k
+			best = d
+		}
+	}
+
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package annotations
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListSupportedAnnotations(t *testing.T) {
+	assert := assert.New(t)
+
+	annotations := ListSupportedAnnotations()
+	assert.Len(annotations, len(registry))
+	assert.True(sort.SliceIsSorted(annotations, func(i, j int) bool {
+		return annotations[i].Key < annotations[j].Key
+	}))
+
+	keys := make(map[string]bool)
+	for _, a := range annotations {
+		assert.False(keys[a.Key], "duplicate annotation %s", a.Key)
+		keys[a.Key] = true
+
+		assert.True(strings.HasPrefix(a.Key, kataAnnotationsPrefix), a.Key)
+		assert.NotEmpty(a.Type, a.Key)
+		assert.NotEmpty(a.Description, a.Key)
+		if a.Type == TypeList {
+			assert.NotEmpty(a.Separator, a.Key)
+		}
+	}
+
+	// The returned annotations are copies.
+	annotations[0].Key = "modified"
+	assert.NotEqual("modified", ListSupportedAnnotations()[0].Key)
+}
+
+func TestLookup(t *testing.T) {
+	assert := assert.New(t)
+
+	a, ok := Lookup(DefaultMaxVCPUs)
+	assert.True(ok)
+	assert.Equal(DefaultMaxVCPUs, a.Key)
+
+	a, ok = Lookup(kataAnnotHypervisorPrefix + "default_maxvcpus")
+	assert.True(ok)
+	assert.Equal(DefaultMaxVCPUs, a.Key)
+
+	_, ok = Lookup("io.katacontainers.no_such_annotation")
+	assert.False(ok)
+}
+
+func TestAnnotationValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, d := range []struct {
+		key   string
+		value string
+		valid bool
+	}{
+		{UseVSock, "true", true},
+		{UseVSock, "0", true},
+		{UseVSock, "yes", false},
+		{DefaultMemory, "2048", true},
+		{DefaultMemory, "-1", false},
+		{DefaultMemory, "4294967296", false},
+		{Msize9p, "0", false},
+		{RxRateLimiterMaxRate, "4294967296", true},
+		{SharedFS, "virtio-fs", true},
+		{SharedFS, "nfs", false},
+		{InterNetworkModel, "tcfilter", true},
+		{InterNetworkModel, "bridged", false},
+		{KernelModules, "e1000e EEE=1; i915", true},
+		{MachineType, "", true},
+	} {
+		a, ok := Lookup(d.key)
+		assert.True(ok, d.key)
+
+		err := a.Validate(d.value)
+		if d.valid {
+			assert.NoError(err, "%s=%s", d.key, d.value)
+		} else {
+			assert.Error(err, "%s=%s", d.key, d.value)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	assert := assert.New(t)
+
+	annotations := map[string]string{
+		"io.kubernetes.cri.sandbox-name":                   "pod",
+		DefaultVCPUs:                                       "2",
+		kataAnnotHypervisorPrefix + "default_maxvcpus":     "4",
+		kataAnnotHypervisorPrefix + "iommu":                "true",
+		IOMMU:                                              "false",
+		kataAnnotHypervisorPrefix + "default_vpcus":        "2",
+		kataAnnotationsPrefix + "completely.unrelated.key": "x",
+	}
+
+	resolved, warnings := Resolve(annotations)
+	assert.Equal(map[string]string{
+		"io.kubernetes.cri.sandbox-name": "pod",
+		DefaultVCPUs:                     "2",
+		DefaultMaxVCPUs:                  "4",
+		IOMMU:                            "false",
+	}, resolved)
+
+	assert.Equal([]Warning{
+		{Key: kataAnnotationsPrefix + "completely.unrelated.key", Kind: WarningUnknown},
+		{Key: kataAnnotHypervisorPrefix + "default_maxvcpus", Kind: WarningDeprecated, Replacement: DefaultMaxVCPUs, Since: "2.0.0"},
+		{Key: kataAnnotHypervisorPrefix + "default_vpcus", Kind: WarningUnknown, Replacement: DefaultVCPUs},
+		{Key: kataAnnotHypervisorPrefix + "iommu", Kind: WarningShadowed, Replacement: IOMMU, Since: "2.0.0"},
+	}, warnings)
+
+	for _, w := range warnings {
+		assert.Contains(w.String(), w.Key)
+	}
+
+	// The misspelled namespace is checked too.
+	_, warnings = Resolve(map[string]string{"io.kata-containers.config.hypervisor.default_vcpus": "1"})
+	assert.Len(warnings, 1)
+	assert.Equal(DefaultVCPUs, warnings[0].Replacement)
+}
+
+func TestEditDistance(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, editDistance("kata", "kata"))
+	assert.Equal(1, editDistance("kata", "kat"))
+	assert.Equal(2, editDistance("vcpus", "vpcus"))
+	assert.Equal(4, editDistance("", "kata"))
+}
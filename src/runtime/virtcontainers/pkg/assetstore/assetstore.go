@@ -0,0 +1,493 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package assetstore manages the guest kernels, initrds, images and
+// firmwares of the host by digest.
+//
+// Each version of an asset is stored once, read-only, as a blob named after
+// the SHA-512 digest of its content, and the names the configuration refers
+// to are symbolic links to blobs, swapped atomically to move to a new
+// version or to roll back to an older one. A sandbox pins the versions its
+// VM boots from by resolving the names to blobs and leasing these, so that
+// swapping a name never changes the files a sandbox being created reads,
+// and the garbage collection never removes them.
+//
+// The remote assets, HTTPS URLs or OCI artifacts, are fetched into the store
+// on their first use and verified by a Fetcher.
+package assetstore
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	blobsDir  = "blobs/sha512"
+	refsDir   = "refs"
+	leasesDir = "leases"
+	tmpDir    = "tmp"
+	lockFile  = ".lock"
+
+	// tmpMaxAge is the age past which the garbage collection removes the
+	// files of the imports which never completed.
+	tmpMaxAge = time.Hour
+)
+
+// Digests are the digests of the content of an asset.
+type Digests struct {
+	SHA512 []byte
+	SHA256 []byte
+}
+
+// Store is an asset store rooted at a host directory.
+type Store struct {
+	root string
+}
+
+// lease is the set of blobs pinned by a holder, a sandbox.
+type lease struct {
+	// Pid is the process which last pinned blobs for the holder.
+	Pid     int      `json:"pid"`
+	Digests []string `json:"digests"`
+}
+
+// New returns the asset store rooted at root, creating it if needed.
+func New(root string) (*Store, error) {
+	if root == "" {
+		return nil, fmt.Errorf("Missing asset store directory")
+	}
+
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dir := range []string{blobsDir, refsDir, leasesDir, tmpDir} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			return nil, err
+		}
+	}
+
+	// The configured asset paths may have their symbolic links resolved
+	// already, the root of the store included.
+	if root, err = filepath.EvalSymlinks(root); err != nil {
+		return nil, err
+	}
+
+	return &Store{root: root}, nil
+}
+
+// Root returns the directory of the store.
+func (s *Store) Root() string {
+	return s.root
+}
+
+// RefPath returns the path of the symbolic link of a name, which the
+// configuration refers to.
+func (s *Store) RefPath(name string) string {
+	return filepath.Join(s.root, refsDir, name)
+}
+
+func (s *Store) blobPath(digest string) string {
+	return filepath.Join(s.root, blobsDir, digest)
+}
+
+func (s *Store) leasePath(holder string) string {
+	return filepath.Join(s.root, leasesDir, holder)
+}
+
+// validName checks that a name, of a ref or of a lease holder, is a plain
+// file name.
+func validName(name string) error {
+	if name == "" || strings.HasPrefix(name, ".") || strings.ContainsRune(name, filepath.Separator) {
+		return fmt.Errorf("Invalid asset store name %q", name)
+	}
+
+	return nil
+}
+
+// lock takes the lock of the store, and returns the function releasing it.
+func (s *Store) lock() (func(), error) {
+	f, err := os.OpenFile(filepath.Join(s.root, lockFile), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_EX); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("Could not lock the asset store: %v", err)
+	}
+
+	return func() {
+		unix.Flock(int(f.Fd()), unix.LOCK_UN)
+		f.Close()
+	}, nil
+}
+
+// add stores the content read from r in a temporary file, and returns its
+// path and digests. It becomes a blob once renamed, the store must be
+// locked from the rename until a name or a lease refers to the blob.
+func (s *Store) add(r io.Reader) (string, Digests, error) {
+	var digests Digests
+
+	f, err := ioutil.TempFile(filepath.Join(s.root, tmpDir), "import-")
+	if err != nil {
+		return "", digests, err
+	}
+	defer f.Close()
+
+	h512 := sha512.New()
+	h256 := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(f, h512, h256), r); err != nil {
+		os.Remove(f.Name())
+		return "", digests, err
+	}
+
+	// The blob must be whole on disk before any name refers to it.
+	if err := f.Sync(); err != nil {
+		os.Remove(f.Name())
+		return "", digests, err
+	}
+
+	if err := f.Chmod(0444); err != nil {
+		os.Remove(f.Name())
+		return "", digests, err
+	}
+
+	digests.SHA512 = h512.Sum(nil)
+	digests.SHA256 = h256.Sum(nil)
+
+	return f.Name(), digests, nil
+}
+
+// Import stores a new version of an asset read from r, unless the store
+// has it already, and points name to it. It returns the digest of the
+// version.
+func (s *Store) Import(name string, r io.Reader) (string, error) {
+	return s.ImportVerified(name, r, nil)
+}
+
+// ImportVerified is Import, only storing the version if verify, when set,
+// accepts the digests of its content.
+func (s *Store) ImportVerified(name string, r io.Reader, verify func(Digests) error) (string, error) {
+	if err := validName(name); err != nil {
+		return "", err
+	}
+
+	// The content is copied without the lock, which is only taken to
+	// swap it in.
+	tmp, digests, err := s.add(r)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp)
+
+	if verify != nil {
+		if err := verify(digests); err != nil {
+			return "", err
+		}
+	}
+
+	digest := hex.EncodeToString(digests.SHA512)
+
+	unlock, err := s.lock()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	if _, err := os.Stat(s.blobPath(digest)); os.IsNotExist(err) {
+		if err := os.Rename(tmp, s.blobPath(digest)); err != nil {
+			return "", err
+		}
+	}
+
+	return digest, s.tag(name, digest)
+}
+
+// Tag points name to the version of an asset with digest, to roll back to
+// it for example. The store must have the version.
+func (s *Store) Tag(name, digest string) error {
+	if err := validName(name); err != nil {
+		return err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return s.tag(name, digest)
+}
+
+// tag swaps the symbolic link of name at once, for the sandboxes being
+// created to resolve either the old version or the new one. The store must
+// be locked.
+func (s *Store) tag(name, digest string) error {
+	if err := validName(digest); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(s.blobPath(digest)); err != nil {
+		return fmt.Errorf("Unknown asset %s: %v", digest, err)
+	}
+
+	tmp := filepath.Join(s.root, refsDir, "."+name)
+	os.Remove(tmp)
+	if err := os.Symlink(filepath.Join("..", blobsDir, digest), tmp); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, s.RefPath(name))
+}
+
+// Untag removes name. The versions it pointed to are removed by the next
+// garbage collection, unless other names point to them or they are pinned.
+func (s *Store) Untag(name string) error {
+	if err := validName(name); err != nil {
+		return err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return os.Remove(s.RefPath(name))
+}
+
+// Refs returns the digest of the version each name points to.
+func (s *Store) Refs() (map[string]string, error) {
+	files, err := ioutil.ReadDir(filepath.Join(s.root, refsDir))
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make(map[string]string)
+	for _, f := range files {
+		if strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+
+		target, err := os.Readlink(s.RefPath(f.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		refs[f.Name()] = filepath.Base(target)
+	}
+
+	return refs, nil
+}
+
+// resolve returns the blob a path of the store refers to, either through a
+// name or directly, and its digest. The paths outside of the store are not
+// resolved.
+func (s *Store) resolve(path string) (string, string, bool, error) {
+	path = filepath.Clean(path)
+	if !strings.HasPrefix(path, filepath.Join(s.root, refsDir)+"/") && !strings.HasPrefix(path, filepath.Join(s.root, blobsDir)+"/") {
+		return path, "", false, nil
+	}
+
+	blob, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", "", false, err
+	}
+
+	if filepath.Dir(blob) != filepath.Join(s.root, blobsDir) {
+		return "", "", false, fmt.Errorf("%s does not point to an asset of the store", path)
+	}
+
+	return blob, filepath.Base(blob), true, nil
+}
+
+func (s *Store) readLease(holder string) (lease, error) {
+	var l lease
+
+	data, err := ioutil.ReadFile(s.leasePath(holder))
+	if err != nil {
+		return l, err
+	}
+
+	if err := json.Unmarshal(data, &l); err != nil {
+		return l, fmt.Errorf("Invalid asset store lease %s: %v", s.leasePath(holder), err)
+	}
+
+	return l, nil
+}
+
+// Pin resolves the paths of the store to the blobs of the versions the
+// names point to, and leases these for holder until it releases them. It
+// returns the paths, resolved, in order. The paths outside of the store, and
+// the empty ones, are returned as they are.
+func (s *Store) Pin(holder string, paths []string) ([]string, error) {
+	if err := validName(holder); err != nil {
+		return nil, err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	l, err := s.readLease(holder)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	leased := make(map[string]bool)
+	for _, d := range l.Digests {
+		leased[d] = true
+	}
+
+	resolved := make([]string, len(paths))
+	for i, path := range paths {
+		if path == "" {
+			continue
+		}
+
+		blob, digest, ok, err := s.resolve(path)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved[i] = blob
+		if ok && !leased[digest] {
+			leased[digest] = true
+			l.Digests = append(l.Digests, digest)
+		}
+	}
+
+	l.Pid = os.Getpid()
+	data, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp := filepath.Join(s.root, leasesDir, "."+holder)
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return nil, err
+	}
+
+	if err := os.Rename(tmp, s.leasePath(holder)); err != nil {
+		return nil, err
+	}
+
+	return resolved, nil
+}
+
+// Release releases the blobs pinned by holder.
+func (s *Store) Release(holder string) error {
+	if err := validName(holder); err != nil {
+		return err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := os.Remove(s.leasePath(holder)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// GC removes the blobs which no name points to and no holder pins, and
+// returns their digests. The leases of the holders which are no longer
+// active, as active tells, and whose process is gone are removed first.
+func (s *Store) GC(active func(holder string) bool) ([]string, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	refs, err := s.Refs()
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool)
+	for _, digest := range refs {
+		used[digest] = true
+	}
+
+	leases, err := ioutil.ReadDir(filepath.Join(s.root, leasesDir))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range leases {
+		if strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+
+		l, err := s.readLease(f.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		if !active(f.Name()) && (l.Pid <= 0 || syscall.Kill(l.Pid, 0) == syscall.ESRCH) {
+			if err := os.Remove(s.leasePath(f.Name())); err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+			continue
+		}
+
+		for _, d := range l.Digests {
+			used[d] = true
+		}
+	}
+
+	blobs, err := ioutil.ReadDir(filepath.Join(s.root, blobsDir))
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for _, f := range blobs {
+		if used[f.Name()] {
+			continue
+		}
+
+		if err := os.Remove(s.blobPath(f.Name())); err != nil {
+			return removed, err
+		}
+		removed = append(removed, f.Name())
+	}
+
+	tmps, err := ioutil.ReadDir(filepath.Join(s.root, tmpDir))
+	if err != nil {
+		return removed, err
+	}
+
+	for _, f := range tmps {
+		if time.Since(f.ModTime()) > tmpMaxAge {
+			os.Remove(filepath.Join(s.root, tmpDir, f.Name()))
+		}
+	}
+
+	sort.Strings(removed)
+	return removed, nil
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package assetstore
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStore(t *testing.T) (*Store, func()) {
+	dir, err := ioutil.TempDir("", "assetstore")
+	assert.NoError(t, err)
+
+	s, err := New(dir)
+	assert.NoError(t, err)
+
+	return s, func() { os.RemoveAll(dir) }
+}
+
+func noneActive(holder string) bool {
+	return false
+}
+
+func TestStoreImport(t *testing.T) {
+	assert := assert.New(t)
+
+	s, cleanup := newTestStore(t)
+	defer cleanup()
+
+	v1, err := s.Import("vmlinuz", strings.NewReader("kernel v1"))
+	assert.NoError(err)
+	assert.Len(v1, 128)
+
+	data, err := ioutil.ReadFile(s.RefPath("vmlinuz"))
+	assert.NoError(err)
+	assert.Equal("kernel v1", string(data))
+
+	// Importing the same content again stores it once
+	again, err := s.Import("vmlinuz-lts", strings.NewReader("kernel v1"))
+	assert.NoError(err)
+	assert.Equal(v1, again)
+
+	v2, err := s.Import("vmlinuz", strings.NewReader("kernel v2"))
+	assert.NoError(err)
+	assert.NotEqual(v1, v2)
+
+	refs, err := s.Refs()
+	assert.NoError(err)
+	assert.Equal(map[string]string{"vmlinuz": v2, "vmlinuz-lts": v1}, refs)
+
+	// Rolling back
+	assert.NoError(s.Tag("vmlinuz", v1))
+	data, err = ioutil.ReadFile(s.RefPath("vmlinuz"))
+	assert.NoError(err)
+	assert.Equal("kernel v1", string(data))
+
+	assert.Error(s.Tag("vmlinuz", "0123"))
+	assert.Error(s.Tag("../vmlinuz", v1))
+	_, err = s.Import(".vmlinuz", strings.NewReader("kernel v3"))
+	assert.Error(err)
+}
+
+func TestStorePin(t *testing.T) {
+	assert := assert.New(t)
+
+	s, cleanup := newTestStore(t)
+	defer cleanup()
+
+	v1, err := s.Import("vmlinuz", strings.NewReader("kernel v1"))
+	assert.NoError(err)
+	image, err := s.Import("image", strings.NewReader("image"))
+	assert.NoError(err)
+
+	paths, err := s.Pin("sb1", []string{s.RefPath("vmlinuz"), "", "/usr/share/kata-containers/OVMF.fd", s.RefPath("image")})
+	assert.NoError(err)
+	assert.Equal([]string{s.blobPath(v1), "", "/usr/share/kata-containers/OVMF.fd", s.blobPath(image)}, paths)
+
+	// Swapping in a new version does not change the version pinned
+	v2, err := s.Import("vmlinuz", strings.NewReader("kernel v2"))
+	assert.NoError(err)
+	data, err := ioutil.ReadFile(paths[0])
+	assert.NoError(err)
+	assert.Equal("kernel v1", string(data))
+
+	// Pinning the resolved paths again is a no-op
+	again, err := s.Pin("sb1", paths)
+	assert.NoError(err)
+	assert.Equal(paths, again)
+
+	l, err := s.readLease("sb1")
+	assert.NoError(err)
+	assert.Equal([]string{v1, image}, l.Digests)
+
+	_, err = s.Pin("sb2", []string{s.RefPath("initrd")})
+	assert.Error(err)
+
+	// The pinned version is kept while its holder runs
+	removed, err := s.GC(noneActive)
+	assert.NoError(err)
+	assert.Empty(removed)
+
+	assert.NoError(s.Release("sb1"))
+	assert.NoError(s.Release("sb1"))
+
+	removed, err = s.GC(noneActive)
+	assert.NoError(err)
+	assert.Equal([]string{v1}, removed)
+
+	refs, err := s.Refs()
+	assert.NoError(err)
+	assert.Equal(map[string]string{"vmlinuz": v2, "image": image}, refs)
+}
+
+func TestStoreGCStaleLeases(t *testing.T) {
+	assert := assert.New(t)
+
+	s, cleanup := newTestStore(t)
+	defer cleanup()
+
+	v1, err := s.Import("vmlinuz", strings.NewReader("kernel v1"))
+	assert.NoError(err)
+	assert.NoError(s.Untag("vmlinuz"))
+
+	// A lease left by a killed runtime
+	data, err := json.Marshal(lease{Digests: []string{v1}})
+	assert.NoError(err)
+	assert.NoError(ioutil.WriteFile(s.leasePath("sb1"), data, 0600))
+
+	active := func(holder string) bool { return holder == "sb1" }
+	removed, err := s.GC(active)
+	assert.NoError(err)
+	assert.Empty(removed)
+
+	removed, err = s.GC(noneActive)
+	assert.NoError(err)
+	assert.Equal([]string{v1}, removed)
+
+	_, err = os.Stat(s.leasePath("sb1"))
+	assert.True(os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(s.root, blobsDir, v1))
+	assert.True(os.IsNotExist(err))
+}
@@ -0,0 +1,480 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package assetstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const (
+	httpsScheme = "https://"
+	ociScheme   = "oci://"
+
+	// remoteRefPrefix starts the names of the remote assets fetched, the
+	// rest of the name being derived from their reference.
+	remoteRefPrefix = "remote-"
+
+	// signatureSuffix is appended to the URL of an HTTPS asset to fetch
+	// its signature.
+	signatureSuffix = ".sig"
+
+	// SignatureAnnotation is the annotation of the layer of an OCI
+	// artifact holding the signature of the asset.
+	SignatureAnnotation = "org.kata-containers.asset.signature"
+
+	ociManifestMediaType    = "application/vnd.oci.image.manifest.v1+json"
+	dockerManifestMediaType = "application/vnd.docker.distribution.manifest.v2+json"
+
+	// maxManifestSize bounds the size of the OCI manifests read.
+	maxManifestSize = 4 << 20
+)
+
+// IsRemote tells if an asset path is the reference of a remote asset,
+// an HTTPS URL or an OCI artifact.
+func IsRemote(path string) bool {
+	return strings.HasPrefix(path, httpsScheme) || strings.HasPrefix(path, ociScheme)
+}
+
+// Fetcher fetches the remote assets into a store on their first use, and
+// verifies them.
+//
+// An HTTPS asset is referred to by its URL, which can end with the digest of
+// the asset as "#sha512=<hex>" or "#sha256=<hex>". An OCI asset is referred
+// to as "oci://<registry>/<repository>[:<tag>|@<digest>]", the artifact
+// having a single layer, the asset, whose digest is verified.
+//
+// With a public key, the assets must be signed: the signature is the
+// base64-encoded Ed25519 signature of the raw SHA-512 digest of the asset,
+// found at the URL of an HTTPS asset suffixed with ".sig", or in the
+// "org.kata-containers.asset.signature" annotation of the layer of an OCI
+// asset. Without a public key, the HTTPS assets must have a digest.
+type Fetcher struct {
+	store     *Store
+	client    *http.Client
+	publicKey ed25519.PublicKey
+}
+
+// NewFetcher returns a fetcher of the remote assets into store. publicKey
+// may be nil, for the assets not to be signed.
+func NewFetcher(store *Store, publicKey ed25519.PublicKey) *Fetcher {
+	return &Fetcher{
+		store:     store,
+		client:    http.DefaultClient,
+		publicKey: publicKey,
+	}
+}
+
+// LoadPublicKey reads the Ed25519 public key the assets are signed with
+// from a PEM file, as written by "openssl pkey -pubout".
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("No PEM data in %s", path)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid public key %s: %v", path, err)
+	}
+
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an Ed25519 public key", path)
+	}
+
+	return pub, nil
+}
+
+// RemoteRefName returns the name of the store a remote asset is fetched
+// to.
+func RemoteRefName(ref string) string {
+	sum := sha256.Sum256([]byte(ref))
+	return remoteRefPrefix + hex.EncodeToString(sum[:])
+}
+
+// Fetch returns the path of the name of the store a remote asset is fetched
+// to, fetching it first unless it was already. A name is fetched once, the
+// version of a tag or a URL without digest is only fetched again once the
+// name is removed.
+func (f *Fetcher) Fetch(ctx context.Context, ref string) (string, error) {
+	name := RemoteRefName(ref)
+	path := f.store.RefPath(name)
+
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	var err error
+	switch {
+	case strings.HasPrefix(ref, httpsScheme):
+		err = f.fetchHTTPS(ctx, name, ref)
+	case strings.HasPrefix(ref, ociScheme):
+		err = f.fetchOCI(ctx, name, ref)
+	default:
+		err = fmt.Errorf("Unsupported remote asset %q", ref)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("Could not fetch %s: %v", ref, err)
+	}
+
+	return path, nil
+}
+
+// parseDigest parses a digest written as "<algorithm>:<hex>" or
+// "<algorithm>=<hex>".
+func parseDigest(s string) (string, []byte, error) {
+	i := strings.IndexAny(s, ":=")
+	if i < 0 {
+		return "", nil, fmt.Errorf("Invalid digest %q", s)
+	}
+
+	algorithm := s[:i]
+	switch algorithm {
+	case "sha256", "sha512":
+	default:
+		return "", nil, fmt.Errorf("Unsupported digest algorithm %q", algorithm)
+	}
+
+	sum, err := hex.DecodeString(s[i+1:])
+	if err != nil {
+		return "", nil, fmt.Errorf("Invalid digest %q: %v", s, err)
+	}
+
+	return algorithm, sum, nil
+}
+
+// verifier returns the function verifying the digests of an asset against
+// its expected digest, if known, and its signature, if signed.
+func (f *Fetcher) verifier(algorithm string, expected []byte, signature []byte) func(Digests) error {
+	return func(d Digests) error {
+		if expected != nil {
+			computed := d.SHA512
+			if algorithm == "sha256" {
+				computed = d.SHA256
+			}
+
+			if !bytes.Equal(computed, expected) {
+				return fmt.Errorf("Invalid %s digest: computed %x, expecting %x", algorithm, computed, expected)
+			}
+		}
+
+		if f.publicKey != nil && !ed25519.Verify(f.publicKey, d.SHA512, signature) {
+			return fmt.Errorf("Invalid signature")
+		}
+
+		return nil
+	}
+}
+
+// get sends a GET request. The response of a failed request is returned
+// along with the error, its body closed.
+func (f *Fetcher) get(ctx context.Context, url string, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return resp, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+
+	return resp, nil
+}
+
+// decodeSignature decodes a base64-encoded signature.
+func decodeSignature(s string) ([]byte, error) {
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid signature: %v", err)
+	}
+
+	return sig, nil
+}
+
+func (f *Fetcher) fetchHTTPS(ctx context.Context, name, ref string) error {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return err
+	}
+
+	var algorithm string
+	var expected []byte
+	if u.Fragment != "" {
+		if algorithm, expected, err = parseDigest(u.Fragment); err != nil {
+			return err
+		}
+	}
+	u.Fragment = ""
+
+	if expected == nil && f.publicKey == nil {
+		return fmt.Errorf("An HTTPS asset requires a digest when the assets are not signed")
+	}
+
+	// The store has the version already, and no signature to check
+	if algorithm == "sha512" && f.publicKey == nil {
+		if _, err := os.Stat(f.store.blobPath(hex.EncodeToString(expected))); err == nil {
+			return f.store.Tag(name, hex.EncodeToString(expected))
+		}
+	}
+
+	var signature []byte
+	if f.publicKey != nil {
+		resp, err := f.get(ctx, u.String()+signatureSuffix, nil)
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		if signature, err = decodeSignature(string(data)); err != nil {
+			return err
+		}
+	}
+
+	resp, err := f.get(ctx, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, err = f.store.ImportVerified(name, resp.Body, f.verifier(algorithm, expected, signature))
+	return err
+}
+
+// ociReference is a parsed OCI artifact reference.
+type ociReference struct {
+	registry   string
+	repository string
+	// reference is the tag or the digest of the manifest.
+	reference string
+}
+
+func parseOCIReference(ref string) (ociReference, error) {
+	var r ociReference
+
+	s := strings.TrimPrefix(ref, ociScheme)
+	i := strings.Index(s, "/")
+	if i <= 0 || i == len(s)-1 {
+		return r, fmt.Errorf("Invalid OCI reference %q", ref)
+	}
+	r.registry, s = s[:i], s[i+1:]
+
+	if i := strings.Index(s, "@"); i >= 0 {
+		r.repository, r.reference = s[:i], s[i+1:]
+		if _, _, err := parseDigest(r.reference); err != nil {
+			return r, err
+		}
+	} else if i := strings.LastIndex(s, ":"); i >= 0 && !strings.Contains(s[i:], "/") {
+		r.repository, r.reference = s[:i], s[i+1:]
+	} else {
+		r.repository, r.reference = s, "latest"
+	}
+
+	if r.repository == "" || r.reference == "" {
+		return r, fmt.Errorf("Invalid OCI reference %q", ref)
+	}
+
+	return r, nil
+}
+
+// ociDescriptor is the descriptor of an OCI content.
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ociManifest is an OCI image manifest, listing the layers of the
+// artifact.
+type ociManifest struct {
+	Layers []ociDescriptor `json:"layers"`
+}
+
+// parseChallenge parses the parameters of a Bearer WWW-Authenticate
+// challenge.
+func parseChallenge(challenge string) map[string]string {
+	params := make(map[string]string)
+
+	if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+		return params
+	}
+
+	for _, p := range strings.Split(challenge[len("bearer "):], ",") {
+		kv := strings.SplitN(strings.TrimSpace(p), "=", 2)
+		if len(kv) == 2 {
+			params[strings.ToLower(kv[0])] = strings.Trim(kv[1], `"`)
+		}
+	}
+
+	return params
+}
+
+// ociToken requests an anonymous token from the authorization service a
+// registry challenged the client with.
+func (f *Fetcher) ociToken(ctx context.Context, challenge string) (string, error) {
+	params := parseChallenge(challenge)
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("Unsupported registry authentication %q", challenge)
+	}
+
+	u, err := url.Parse(realm)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	for _, k := range []string{"service", "scope"} {
+		if params[k] != "" {
+			q.Set(k, params[k])
+		}
+	}
+	u.RawQuery = q.Encode()
+
+	resp, err := f.get(ctx, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+
+	if token.Token != "" {
+		return token.Token, nil
+	}
+
+	return token.AccessToken, nil
+}
+
+// ociGet sends a GET request to a registry, authenticating with an
+// anonymous token if the registry asks for one.
+func (f *Fetcher) ociGet(ctx context.Context, url string, header http.Header, token *string) (*http.Response, error) {
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	if *token != "" {
+		header.Set("Authorization", "Bearer "+*token)
+	}
+
+	resp, err := f.get(ctx, url, header)
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized || *token != "" {
+		return resp, err
+	}
+
+	if *token, err = f.ociToken(ctx, resp.Header.Get("WWW-Authenticate")); err != nil {
+		return nil, err
+	}
+	header.Set("Authorization", "Bearer "+*token)
+
+	return f.get(ctx, url, header)
+}
+
+func (f *Fetcher) fetchOCI(ctx context.Context, name, ref string) error {
+	r, err := parseOCIReference(ref)
+	if err != nil {
+		return err
+	}
+
+	base := fmt.Sprintf("%s%s/v2/%s", httpsScheme, r.registry, r.repository)
+	var token string
+
+	header := make(http.Header)
+	header.Set("Accept", ociManifestMediaType+", "+dockerManifestMediaType)
+	resp, err := f.ociGet(ctx, base+"/manifests/"+r.reference, header, &token)
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxManifestSize))
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	// The manifest of a digest reference is pinned
+	if algorithm, expected, err := parseDigest(r.reference); err == nil {
+		if algorithm != "sha256" {
+			return fmt.Errorf("Unsupported manifest digest algorithm %q", algorithm)
+		}
+
+		if computed := sha256.Sum256(data); !bytes.Equal(computed[:], expected) {
+			return fmt.Errorf("Invalid manifest digest: computed %x, expecting %x", computed, expected)
+		}
+	}
+
+	var manifest ociManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return fmt.Errorf("Invalid manifest: %v", err)
+	}
+
+	if len(manifest.Layers) != 1 {
+		return fmt.Errorf("An asset artifact has a single layer, found %d", len(manifest.Layers))
+	}
+	layer := manifest.Layers[0]
+
+	algorithm, expected, err := parseDigest(layer.Digest)
+	if err != nil {
+		return err
+	}
+
+	var signature []byte
+	if f.publicKey != nil {
+		if signature, err = decodeSignature(layer.Annotations[SignatureAnnotation]); err != nil {
+			return err
+		}
+	}
+
+	resp, err = f.ociGet(ctx, base+"/blobs/"+layer.Digest, nil, &token)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, err = f.store.ImportVerified(name, resp.Body, f.verifier(algorithm, expected, signature))
+	return err
+}
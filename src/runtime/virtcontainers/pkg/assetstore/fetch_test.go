@@ -0,0 +1,244 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package assetstore
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testKernel = "remote kernel"
+
+func sign(key ed25519.PrivateKey, content string) string {
+	sum := sha512.Sum512([]byte(content))
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(key, sum[:]))
+}
+
+func newTestFetcher(t *testing.T, server *httptest.Server, publicKey ed25519.PublicKey) (*Fetcher, func()) {
+	s, cleanup := newTestStore(t)
+
+	f := NewFetcher(s, publicKey)
+	f.client = server.Client()
+
+	return f, cleanup
+}
+
+func readRef(t *testing.T, path string) string {
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	return string(data)
+}
+
+func TestIsRemote(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(IsRemote("https://example.com/vmlinuz"))
+	assert.True(IsRemote("oci://registry.example.com/kata/kernel:5.4"))
+	assert.False(IsRemote("http://example.com/vmlinuz"))
+	assert.False(IsRemote("/usr/share/kata-containers/vmlinuz"))
+}
+
+func TestFetchHTTPS(t *testing.T) {
+	assert := assert.New(t)
+
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(err)
+
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/vmlinuz":
+			requests++
+			fmt.Fprint(w, testKernel)
+		case "/vmlinuz.sig":
+			fmt.Fprint(w, sign(key, testKernel))
+		case "/bad.sig":
+			fmt.Fprint(w, sign(key, "another kernel"))
+		case "/bad":
+			fmt.Fprint(w, testKernel)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	f, cleanup := newTestFetcher(t, server, nil)
+	defer cleanup()
+
+	sum := sha512.Sum512([]byte(testKernel))
+	ref := fmt.Sprintf("%s/vmlinuz#sha512=%x", server.URL, sum)
+
+	path, err := f.Fetch(context.Background(), ref)
+	assert.NoError(err)
+	assert.Equal(f.store.RefPath(RemoteRefName(ref)), path)
+	assert.Equal(testKernel, readRef(t, path))
+
+	// The asset is fetched on first use only
+	_, err = f.Fetch(context.Background(), ref)
+	assert.NoError(err)
+	assert.Equal(1, requests)
+
+	// Another reference of a version of the store is not fetched
+	sum256 := sha256.Sum256([]byte(testKernel))
+	_, err = f.Fetch(context.Background(), fmt.Sprintf("%s/vmlinuz?v=2#sha512=%x", server.URL, sum))
+	assert.NoError(err)
+	assert.Equal(1, requests)
+
+	_, err = f.Fetch(context.Background(), fmt.Sprintf("%s/vmlinuz#sha256=%x", server.URL, sum256))
+	assert.NoError(err)
+	assert.Equal(2, requests)
+
+	_, err = f.Fetch(context.Background(), fmt.Sprintf("%s/vmlinuz#sha256=%x", server.URL, sum))
+	assert.Error(err)
+	_, err = f.Fetch(context.Background(), server.URL+"/vmlinuz")
+	assert.Error(err, "an unsigned asset without digest is refused")
+	_, err = f.Fetch(context.Background(), server.URL+"/missing#sha512=00")
+	assert.Error(err)
+
+	// Signed assets
+	f.publicKey = pub
+	path, err = f.Fetch(context.Background(), server.URL+"/vmlinuz")
+	assert.NoError(err)
+	assert.Equal(testKernel, readRef(t, path))
+
+	_, err = f.Fetch(context.Background(), server.URL+"/bad")
+	assert.Error(err)
+	_, err = f.Fetch(context.Background(), server.URL+"/missing")
+	assert.Error(err)
+
+	refs, err := f.store.Refs()
+	assert.NoError(err)
+	assert.Len(refs, 4)
+}
+
+func TestFetchOCI(t *testing.T) {
+	assert := assert.New(t)
+
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(err)
+
+	layerDigest := fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(testKernel)))
+	manifest, err := json.Marshal(ociManifest{Layers: []ociDescriptor{{
+		MediaType:   "application/octet-stream",
+		Digest:      layerDigest,
+		Size:        int64(len(testKernel)),
+		Annotations: map[string]string{SignatureAnnotation: sign(key, testKernel)},
+	}}})
+	assert.NoError(err)
+	manifestDigest := fmt.Sprintf("sha256:%x", sha256.Sum256(manifest))
+
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			assert.Equal("repository:kata/kernel:pull", r.URL.Query().Get("scope"))
+			fmt.Fprint(w, `{"token": "secret"}`)
+			return
+		}
+
+		// Anonymous token authentication
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:kata/kernel:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v2/kata/kernel/manifests/5.4", "/v2/kata/kernel/manifests/" + manifestDigest:
+			assert.Contains(r.Header.Get("Accept"), ociManifestMediaType)
+			w.Write(manifest)
+		case "/v2/kata/kernel/blobs/" + layerDigest:
+			fmt.Fprint(w, testKernel)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	f, cleanup := newTestFetcher(t, server, pub)
+	defer cleanup()
+
+	registry := strings.TrimPrefix(server.URL, "https://")
+
+	path, err := f.Fetch(context.Background(), fmt.Sprintf("oci://%s/kata/kernel:5.4", registry))
+	assert.NoError(err)
+	assert.Equal(testKernel, readRef(t, path))
+
+	path, err = f.Fetch(context.Background(), fmt.Sprintf("oci://%s/kata/kernel@%s", registry, manifestDigest))
+	assert.NoError(err)
+	assert.Equal(testKernel, readRef(t, path))
+
+	_, err = f.Fetch(context.Background(), fmt.Sprintf("oci://%s/kata/kernel@sha256:%x", registry, sha256.Sum256(nil)))
+	assert.Error(err)
+	_, err = f.Fetch(context.Background(), fmt.Sprintf("oci://%s/kata/kernel:missing", registry))
+	assert.Error(err)
+
+	// The layer must be signed by the key
+	other, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(err)
+	f.publicKey = other
+	_, err = f.Fetch(context.Background(), fmt.Sprintf("oci://%s/kata/kernel", registry))
+	assert.Error(err)
+}
+
+func TestParseOCIReference(t *testing.T) {
+	assert := assert.New(t)
+
+	for ref, expected := range map[string]ociReference{
+		"oci://registry.example.com/kata/kernel":          {"registry.example.com", "kata/kernel", "latest"},
+		"oci://registry.example.com:5000/kata/kernel:5.4": {"registry.example.com:5000", "kata/kernel", "5.4"},
+		"oci://registry.example.com/kernel@sha256:00":     {"registry.example.com", "kernel", "sha256:00"},
+	} {
+		r, err := parseOCIReference(ref)
+		assert.NoError(err, ref)
+		assert.Equal(expected, r, ref)
+	}
+
+	for _, ref := range []string{"oci://registry.example.com", "oci://registry.example.com/", "oci://registry.example.com/kernel@md5:00"} {
+		_, err := parseOCIReference(ref)
+		assert.Error(err, ref)
+	}
+}
+
+func TestLoadPublicKey(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "assetstore-key")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(err)
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	assert.NoError(err)
+
+	path := filepath.Join(dir, "key.pem")
+	assert.NoError(ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+
+	loaded, err := LoadPublicKey(path)
+	assert.NoError(err)
+	assert.Equal(pub, loaded)
+
+	assert.NoError(ioutil.WriteFile(path, []byte("not a key"), 0600))
+	_, err = LoadPublicKey(path)
+	assert.Error(err)
+}
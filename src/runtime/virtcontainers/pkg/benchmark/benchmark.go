@@ -0,0 +1,280 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package benchmark measures the latency of the sandbox operations of
+// virtcontainers, against a mock or a real hypervisor, so that the users of
+// a configuration can run regression benchmarks of it and report comparable
+// numbers.
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// Operation is a sandbox operation whose latency is measured.
+type Operation string
+
+const (
+	// OpCreate creates the sandbox, its VM being booted, and its
+	// containers.
+	OpCreate Operation = "create"
+
+	// OpStart starts the sandbox and its containers.
+	OpStart Operation = "start"
+
+	// OpHotplug updates the resources of the first container, so that
+	// vCPUs and memory are hot added to the VM.
+	OpHotplug Operation = "hotplug"
+
+	// OpUnplug restores the resources of the first container, so that
+	// the hot added vCPUs and memory are removed from the VM.
+	OpUnplug Operation = "unplug"
+
+	// OpStop stops the sandbox, its VM included.
+	OpStop Operation = "stop"
+
+	// OpDelete deletes the sandbox.
+	OpDelete Operation = "delete"
+)
+
+// Operations are the operations measured, in the order they are run in each
+// iteration.
+var Operations = []Operation{OpCreate, OpStart, OpHotplug, OpUnplug, OpStop, OpDelete}
+
+const defaultSandboxID = "benchmark"
+
+// Config is the configuration of a benchmark.
+type Config struct {
+	// SandboxConfig is the configuration of the sandboxes created, such
+	// as converted from an OCI bundle. Each iteration creates a sandbox,
+	// whose ID is suffixed with the iteration.
+	SandboxConfig vc.SandboxConfig
+
+	// Iterations is how many sandboxes are created, one at a time.
+	Iterations int
+
+	// Hotplug are the resources the first container is updated to once
+	// the sandbox is started, the hotplug operations being skipped when
+	// nil.
+	Hotplug *specs.LinuxResources
+
+	// Mock runs the sandboxes with the mock hypervisor and agent, which
+	// measures the overhead of virtcontainers alone.
+	Mock bool
+
+	// Factory is the VM factory the sandboxes are created with, if any.
+	Factory vc.Factory
+}
+
+// Sample is the latency of an operation in an iteration.
+type Sample struct {
+	Iteration int           `json:"iteration"`
+	Operation Operation     `json:"operation"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// Stats are the statistics of the latency of an operation over the
+// iterations.
+type Stats struct {
+	Operation Operation     `json:"operation"`
+	Count     int           `json:"count"`
+	Min       time.Duration `json:"min"`
+	Max       time.Duration `json:"max"`
+	Mean      time.Duration `json:"mean"`
+	Median    time.Duration `json:"median"`
+	P95       time.Duration `json:"p95"`
+}
+
+// Result is the result of a benchmark.
+type Result struct {
+	// Hypervisor is the hypervisor the sandboxes ran with.
+	Hypervisor vc.HypervisorType `json:"hypervisor"`
+
+	// Arch is the architecture of the host, as a GOARCH name.
+	Arch string `json:"arch"`
+
+	// Iterations is how many sandboxes were created.
+	Iterations int `json:"iterations"`
+
+	// Samples are the latencies of the operations of each iteration.
+	Samples []Sample `json:"samples"`
+}
+
+// Run runs a benchmark. It stops at the first failing operation, and
+// returns the samples measured until then along with the error.
+func Run(ctx context.Context, config Config) (*Result, error) {
+	if config.Iterations <= 0 {
+		return nil, fmt.Errorf("Invalid number of iterations %d", config.Iterations)
+	}
+
+	sandboxConfig := config.SandboxConfig
+	if sandboxConfig.ID == "" {
+		sandboxConfig.ID = defaultSandboxID
+	}
+	if len(sandboxConfig.Containers) == 0 {
+		return nil, errors.New("The sandboxes run no container")
+	}
+	if config.Mock {
+		ctx = vc.WithNewAgentFunc(ctx, vc.NewMockAgent)
+		sandboxConfig.HypervisorType = vc.MockHypervisor
+	}
+
+	result := &Result{
+		Hypervisor: sandboxConfig.HypervisorType,
+		Arch:       runtime.GOARCH,
+		Iterations: config.Iterations,
+	}
+
+	for i := 0; i < config.Iterations; i++ {
+		if err := result.runIteration(ctx, config, sandboxConfig, i); err != nil {
+			return result, fmt.Errorf("Iteration %d: %v", i, err)
+		}
+	}
+
+	return result, nil
+}
+
+func (r *Result) measure(iteration int, op Operation, fn func() error) error {
+	start := time.Now()
+	if err := fn(); err != nil {
+		return fmt.Errorf("%s: %v", op, err)
+	}
+
+	r.Samples = append(r.Samples, Sample{
+		Iteration: iteration,
+		Operation: op,
+		Duration:  time.Since(start),
+	})
+	return nil
+}
+
+func (r *Result) runIteration(ctx context.Context, config Config, sandboxConfig vc.SandboxConfig, iteration int) (err error) {
+	sandboxConfig.ID = fmt.Sprintf("%s-%d", sandboxConfig.ID, iteration)
+	sandboxConfig.Containers = append([]vc.ContainerConfig{}, sandboxConfig.Containers...)
+
+	var s vc.VCSandbox
+	if err := r.measure(iteration, OpCreate, func() error {
+		s, err = vc.CreateSandbox(ctx, sandboxConfig, config.Factory)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	// The sandbox is cleaned up after a failing operation, without
+	// measuring it.
+	stopped, deleted := false, false
+	defer func() {
+		if !stopped {
+			s.Stop(true)
+		}
+		if !deleted {
+			s.Delete()
+		}
+	}()
+
+	if err := r.measure(iteration, OpStart, s.Start); err != nil {
+		return err
+	}
+
+	if config.Hotplug != nil {
+		container := sandboxConfig.Containers[0]
+
+		if err := r.measure(iteration, OpHotplug, func() error {
+			return s.UpdateContainer(container.ID, *config.Hotplug)
+		}); err != nil {
+			return err
+		}
+
+		if err := r.measure(iteration, OpUnplug, func() error {
+			return s.UpdateContainer(container.ID, container.Resources)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := r.measure(iteration, OpStop, func() error {
+		return s.Stop(false)
+	}); err != nil {
+		return err
+	}
+	stopped = true
+
+	if err := r.measure(iteration, OpDelete, s.Delete); err != nil {
+		return err
+	}
+	deleted = true
+
+	return nil
+}
+
+// Stats returns the statistics of the latency of an operation.
+func (r *Result) Stats(op Operation) (Stats, error) {
+	var durations []time.Duration
+	for _, sample := range r.Samples {
+		if sample.Operation == op {
+			durations = append(durations, sample.Duration)
+		}
+	}
+
+	if len(durations) == 0 {
+		return Stats{}, errors.New("No sample of the operation")
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+
+	return Stats{
+		Operation: op,
+		Count:     len(durations),
+		Min:       durations[0],
+		Max:       durations[len(durations)-1],
+		Mean:      total / time.Duration(len(durations)),
+		Median:    percentile(durations, 50),
+		P95:       percentile(durations, 95),
+	}, nil
+}
+
+// percentile returns the nearest rank percentile of sorted durations.
+func percentile(durations []time.Duration, p int) time.Duration {
+	rank := (p*len(durations) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return durations[rank-1]
+}
+
+// Report writes the statistics of the operations measured as a table, to be
+// reported along with the configuration benchmarked.
+func (r *Result) Report(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "hypervisor: %s, arch: %s, iterations: %d\n", r.Hypervisor, r.Arch, r.Iterations)
+	fmt.Fprintln(tw, "OPERATION\tCOUNT\tMIN\tMEDIAN\tMEAN\tP95\tMAX")
+
+	for _, op := range Operations {
+		stats, err := r.Stats(op)
+		if err != nil {
+			continue
+		}
+
+		fmt.Fprintf(tw, "%s\t%d\t%v\t%v\t%v\t%v\t%v\n", op, stats.Count, stats.Min, stats.Median, stats.Mean, stats.P95, stats.Max)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package benchmark
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ktu "github.com/kata-containers/kata-containers/src/runtime/pkg/katatestutils"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/fs"
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+var tc ktu.TestConstraint
+
+func init() {
+	tc = ktu.NewTestConstraint(false)
+}
+
+func TestMain(m *testing.M) {
+	persist.EnableMockTesting()
+
+	ret := m.Run()
+
+	fs.MockStorageDestroy()
+	os.Exit(ret)
+}
+
+func TestRunInvalidConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := Run(context.Background(), Config{Mock: true})
+	assert.Error(err)
+
+	_, err = Run(context.Background(), Config{Iterations: 1, Mock: true})
+	assert.Error(err)
+}
+
+// mockConfig returns the configuration of a benchmark of the sandboxes
+// running a single container with the mock hypervisor, whose assets are
+// created in dir.
+func mockConfig(dir string) (Config, error) {
+	for _, name := range []string{"kernel", "image", "hypervisor"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			return Config{}, err
+		}
+	}
+
+	return Config{
+		SandboxConfig: vc.SandboxConfig{
+			HypervisorConfig: vc.HypervisorConfig{
+				KernelPath:     filepath.Join(dir, "kernel"),
+				ImagePath:      filepath.Join(dir, "image"),
+				HypervisorPath: filepath.Join(dir, "hypervisor"),
+			},
+			Containers: []vc.ContainerConfig{
+				{
+					ID:     "function",
+					RootFs: vc.RootFs{Target: dir, Mounted: true},
+					Annotations: map[string]string{
+						vcAnnotations.ContainerTypeKey: string(vc.PodSandbox),
+					},
+					CustomSpec: &specs.Spec{
+						Linux: &specs.Linux{
+							Resources: &specs.LinuxResources{},
+						},
+						Process: &specs.Process{
+							Capabilities: &specs.LinuxCapabilities{},
+						},
+					},
+				},
+			},
+			ProxyType: vc.NoopProxyType,
+		},
+		Iterations: 1,
+		Mock:       true,
+	}, nil
+}
+
+func TestRunMock(t *testing.T) {
+	if tc.NotValid(ktu.NeedRoot()) {
+		t.Skip("Test disabled as requires root user")
+	}
+
+	assert := assert.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "benchmark")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpDir)
+
+	config, err := mockConfig(tmpDir)
+	assert.NoError(err)
+
+	period := uint64(100000)
+	quota := int64(200000)
+	config.Iterations = 3
+	config.Hotplug = &specs.LinuxResources{
+		CPU: &specs.LinuxCPU{Period: &period, Quota: &quota},
+	}
+
+	result, err := Run(context.Background(), config)
+	assert.NoError(err)
+	assert.Equal(vc.MockHypervisor, result.Hypervisor)
+	assert.Len(result.Samples, 3*len(Operations))
+
+	for _, op := range Operations {
+		stats, err := result.Stats(op)
+		assert.NoError(err)
+		assert.Equal(3, stats.Count)
+	}
+
+	var report bytes.Buffer
+	assert.NoError(result.Report(&report))
+	assert.Contains(report.String(), "hypervisor: mock")
+	assert.Contains(report.String(), "hotplug")
+}
+
+func TestResultStats(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &Result{}
+	for i := 1; i <= 20; i++ {
+		r.Samples = append(r.Samples, Sample{
+			Iteration: i,
+			Operation: OpCreate,
+			Duration:  time.Duration(i) * time.Millisecond,
+		})
+	}
+
+	stats, err := r.Stats(OpCreate)
+	assert.NoError(err)
+	assert.Equal(Stats{
+		Operation: OpCreate,
+		Count:     20,
+		Min:       time.Millisecond,
+		Max:       20 * time.Millisecond,
+		Mean:      10500 * time.Microsecond,
+		Median:    10 * time.Millisecond,
+		P95:       19 * time.Millisecond,
+	}, stats)
+
+	_, err = r.Stats(OpStart)
+	assert.Error(err)
+
+	// The operations without samples are not reported.
+	var report bytes.Buffer
+	assert.NoError(r.Report(&report))
+	assert.Contains(report.String(), "create")
+	assert.NotContains(report.String(), "start")
+}
+
+// BenchmarkLightweightSandbox compares the latency of the creation and the
+// start of the lightweight sandboxes to the one of the default sandboxes,
+// both running in the sandbox cgroup only.
+func BenchmarkLightweightSandbox(b *testing.B) {
+	if tc.NotValid(ktu.NeedRoot()) {
+		b.Skip("Benchmark disabled as requires root user")
+	}
+
+	tmpDir, err := ioutil.TempDir("", "benchmark")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for _, lightweight := range []bool{false, true} {
+		name := "default"
+		if lightweight {
+			name = "lightweight"
+		}
+
+		b.Run(name, func(b *testing.B) {
+			config, err := mockConfig(tmpDir)
+			if err != nil {
+				b.Fatal(err)
+			}
+			config.Iterations = b.N
+			config.SandboxConfig.SandboxCgroupOnly = true
+
+			if lightweight {
+				config.SandboxConfig.Lightweight = vc.LightweightSandbox{
+					Enable:       true,
+					GuestDetails: &vc.LightweightGuestDetails{MemoryBlockSizeMB: 128},
+				}
+			}
+
+			result, err := Run(context.Background(), config)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			// The latency the lightweight sandboxes target is the one
+			// of the creation and the start, below 100ms.
+			for _, op := range []Operation{OpCreate, OpStart} {
+				stats, err := result.Stats(op)
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.ReportMetric(float64(stats.Median)/float64(time.Millisecond), string(op)+"-ms")
+			}
+		})
+	}
+}
@@ -14,7 +14,7 @@ type FsConfig struct {
 	Socket string `json:"socket"`
 	NumQueues int32 `json:"num_queues,omitempty"`
 	QueueSize int32 `json:"queue_size,omitempty"`
-	Dax bool `json:"dax,omitempty"`
+	Dax bool `json:"dax"`
 	CacheSize int64 `json:"cache_size,omitempty"`
 	Id string `json:"id,omitempty"`
 }
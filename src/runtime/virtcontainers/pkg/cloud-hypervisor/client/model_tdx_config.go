@@ -0,0 +1,15 @@
+/*
+ * Cloud Hypervisor API
+ *
+ * Local HTTP based API for managing and inspecting a cloud-hypervisor virtual machine.
+ *
+ * API version: 0.3.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package openapi
+// TdxConfig struct for TdxConfig
+type TdxConfig struct {
+	// Path to the TDX firmware, measuring the direct boot.
+	Firmware string `json:"firmware"`
+}
@@ -11,4 +11,5 @@ package openapi
 // VmAddDevice struct for VmAddDevice
 type VmAddDevice struct {
 	Path string `json:"path,omitempty"`
+	Id string `json:"id,omitempty"`
 }
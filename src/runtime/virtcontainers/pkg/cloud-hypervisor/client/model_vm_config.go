@@ -25,4 +25,5 @@ type VmConfig struct {
 	Devices []DeviceConfig `json:"devices,omitempty"`
 	Vsock VsockConfig `json:"vsock,omitempty"`
 	Iommu bool `json:"iommu,omitempty"`
+	Tdx *TdxConfig `json:"tdx,omitempty"`
 }
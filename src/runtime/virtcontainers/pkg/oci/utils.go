@@ -11,9 +11,11 @@ import (
 	"fmt"
 	"path/filepath"
 	goruntime "runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	criContainerdAnnotations "github.com/containerd/cri-containerd/pkg/annotations"
 	crioAnnotations "github.com/cri-o/cri-o/pkg/annotations"
@@ -74,6 +76,33 @@ const (
 
 const KernelModulesSeparator = ";"
 
+// AttachPolicy determines how the attaches to a process of the shim share
+// its I/O streams. All the attaches get the process output.
+type AttachPolicy string
+
+const (
+	// AttachPolicyShared writes the input of all the attaches to the
+	// process.
+	AttachPolicyShared AttachPolicy = "shared"
+
+	// AttachPolicySingleWriter only writes the input of the oldest
+	// attach to the process, the input of the other ones being dropped.
+	AttachPolicySingleWriter AttachPolicy = "single-writer"
+
+	// AttachPolicyExclusive only accepts one attach at a time.
+	AttachPolicyExclusive AttachPolicy = "exclusive"
+)
+
+// Valid returns whether the policy is a known one, the empty policy being
+// the shared one.
+func (p AttachPolicy) Valid() bool {
+	switch p {
+	case "", AttachPolicyShared, AttachPolicySingleWriter, AttachPolicyExclusive:
+		return true
+	}
+	return false
+}
+
 // FactoryConfig is a structure to set the VM factory configuration.
 type FactoryConfig struct {
 	// Template enables VM templating support in VM factory.
@@ -96,6 +125,7 @@ type RuntimeConfig struct {
 
 	NetmonConfig vc.NetmonConfig
 
+	AgentType   vc.AgentType
 	AgentConfig vc.KataAgentConfig
 
 	ProxyType   vc.ProxyType
@@ -113,9 +143,30 @@ type RuntimeConfig struct {
 	//Determines if seccomp should be applied inside guest
 	DisableGuestSeccomp bool
 
+	//Determines the types of OCI hooks run inside guest
+	GuestHooks []string
+
+	//Determines the registrars the interfaces of the sandboxes are registered with
+	SandboxRegistrars []string
+
 	//Determines if create a netns for hypervisor process
 	DisableNewNetNs bool
 
+	//Determines how the endpoints connected with tc filters are set up
+	TCFilterCompat vc.TCFilterCompat
+
+	//Determines how the MAC addresses of the guest network interfaces are picked
+	MACAllocation vc.MACAllocation
+
+	//Determines the interfaces whose addresses the guest gets from a DHCP server
+	DHCPInterfaces vc.DHCPInterfaces
+
+	//Determines the endpoints forced for some network interfaces
+	InterfaceEndpoints vc.InterfaceEndpoints
+
+	//Determines the network provider setting up the network interfaces
+	NetworkProvider string
+
 	//Determines kata processes are managed only in sandbox cgroup
 	SandboxCgroupOnly bool
 
@@ -124,6 +175,86 @@ type RuntimeConfig struct {
 
 	// Determines if enable pprof
 	EnablePprof bool
+
+	//Determines how the attaches to a process share its I/O streams
+	AttachPolicy AttachPolicy
+
+	// Path of the configuration file the runtime configuration was loaded from
+	ConfigPath string
+
+	// Path of the SHA256 manifest the runtime assets are verified against
+	IntegrityManifest string
+
+	// Determines if an integrity mismatch is fatal ("enforce") or only
+	// logged ("warn")
+	IntegrityMode string
+
+	//Host-side resource limits applied to every sandbox
+	ResourceCeilings vc.ResourceCeilings
+
+	//Limits of the resources all the sandboxes of the host claim together
+	NodeLimits vc.NodeLimits
+
+	//Determines how eagerly sandboxes are shrunk
+	ResizePolicy vc.ResizePolicy
+
+	//Determines how long each graceful shutdown stage of the VM may take
+	ShutdownTimeouts vc.ShutdownTimeouts
+
+	//Determines if the sandboxes run on the fast path of single containers
+	Lightweight vc.LightweightSandbox
+
+	//Determines the hotplug capacity reserved at VM creation
+	HotplugPlanning vc.HotplugPlanning
+
+	//Determines if the host has room for a sandbox before its creation
+	AdmissionPolicy vc.AdmissionPolicy
+
+	//Determines how container CPU shares are translated
+	CPUShares vc.CPUSharesTranslation
+
+	//Determines the guest operating system
+	GuestOS string
+
+	//Determines if the sandbox container rootfs is converted to a disk
+	RootfsDisk vc.RootfsDisk
+
+	//Determines the time sources of the guest
+	TimeSync vc.TimeSync
+
+	//Determines the host files and settings installed in the guest
+	GuestProvisioning vc.GuestProvisioning
+
+	//Determines the host directory of the captured container core dumps
+	CoreDumpDir string
+
+	//Determines the directory of the asset store the guest assets are
+	//pinned from
+	AssetStore string
+
+	//Determines the public key the remote assets must be signed with
+	AssetSigningKey string
+
+	//Determines the persist driver storing the state of the sandboxes
+	PersistDriver string
+
+	//Determines how the guest kernel crash dumps are captured
+	Kdump vc.Kdump
+
+	//Determines the profiling sessions allowed in the guest
+	GuestProfiling vc.GuestProfiling
+
+	//Determines the livepatch modules that can be applied to the guest
+	GuestLivepatch vc.GuestLivepatch
+
+	//Determines how the guest gives back the memory it uses as cache
+	GuestMemoryReclaim vc.GuestMemoryReclaim
+
+	//Determines the paths of the instance metadata service the guest can access
+	MetadataProxy vc.MetadataProxy
+
+	//Determines the captures allowed of the sandbox traffic
+	TrafficCapture vc.TrafficCapture
 }
 
 // AddKernelParam allows the addition of new kernel parameters to an existing
@@ -227,8 +358,9 @@ func newLinuxDeviceInfo(d specs.LinuxDevice) (*config.DeviceInfo, error) {
 
 func containerDeviceInfos(spec specs.Spec) ([]config.DeviceInfo, error) {
 	ociLinuxDevices := spec.Linux.Devices
+	mdevDevices := spec.Annotations[vcAnnotations.ContainerMdevDevices]
 
-	if ociLinuxDevices == nil {
+	if ociLinuxDevices == nil && mdevDevices == "" {
 		return []config.DeviceInfo{}, nil
 	}
 
@@ -242,6 +374,22 @@ func containerDeviceInfos(spec specs.Spec) ([]config.DeviceInfo, error) {
 		devices = append(devices, *linuxDeviceInfo)
 	}
 
+	// The mediated devices are passed by UUID, as the VFIO group of a
+	// mediated device is only known once the device is created on the host.
+	for _, uuid := range strings.Split(mdevDevices, ",") {
+		uuid = strings.TrimSpace(uuid)
+		if uuid == "" {
+			continue
+		}
+
+		mdevDeviceInfo, err := config.MdevDeviceInfo(uuid)
+		if err != nil {
+			return []config.DeviceInfo{}, fmt.Errorf("Error parsing annotation for %s: %v", vcAnnotations.ContainerMdevDevices, err)
+		}
+
+		devices = append(devices, *mdevDeviceInfo)
+	}
+
 	return devices, nil
 }
 
@@ -264,6 +412,11 @@ func networkConfig(ocispec specs.Spec, config RuntimeConfig) (vc.NetworkConfig,
 	}
 	netConf.InterworkingModel = config.InterNetworkModel
 	netConf.DisableNewNetNs = config.DisableNewNetNs
+	netConf.TCFilterCompat = config.TCFilterCompat
+	netConf.MACAllocation = config.MACAllocation
+	netConf.DHCPInterfaces = config.DHCPInterfaces
+	netConf.InterfaceEndpoints = config.InterfaceEndpoints
+	netConf.Provider = config.NetworkProvider
 
 	netConf.NetmonConfig = vc.NetmonConfig{
 		Path:   config.NetmonConfig.Path,
@@ -325,7 +478,46 @@ func SandboxID(spec specs.Spec) (string, error) {
 	return "", fmt.Errorf("Could not find sandbox ID")
 }
 
+// checkAnnotations replaces the deprecated annotations of the spec by their
+// current key, logs the deprecated and unknown ones, and validates the
+// values of the supported ones.
+func checkAnnotations(ocispec *specs.Spec) error {
+	annotations, warnings := vcAnnotations.Resolve(ocispec.Annotations)
+	for _, w := range warnings {
+		ociLog.WithFields(logrus.Fields{
+			"annotation":  w.Key,
+			"kind":        w.Kind,
+			"replacement": w.Replacement,
+		}).Warn(w.String())
+	}
+
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		a, ok := vcAnnotations.Lookup(key)
+		if !ok {
+			continue
+		}
+
+		if err := a.Validate(annotations[key]); err != nil {
+			return err
+		}
+	}
+
+	ocispec.Annotations = annotations
+
+	return nil
+}
+
 func addAnnotations(ocispec specs.Spec, config *vc.SandboxConfig) error {
+	if err := checkAnnotations(&ocispec); err != nil {
+		return err
+	}
+
 	addAssetAnnotations(ocispec, config)
 	if err := addHypervisorConfigOverrides(ocispec, config); err != nil {
 		return err
@@ -385,6 +577,14 @@ func addHypervisorConfigOverrides(ocispec specs.Spec, config *vc.SandboxConfig)
 		return err
 	}
 
+	// The kernel parameters of the annotation come after the ones of the
+	// profile, and win over them.
+	if value, ok := ocispec.Annotations[vcAnnotations.KernelParamsProfile]; ok {
+		if err := config.HypervisorConfig.SelectKernelParamsProfile(value); err != nil {
+			return err
+		}
+	}
+
 	if value, ok := ocispec.Annotations[vcAnnotations.KernelParams]; ok {
 		if value != "" {
 			params := vc.DeserializeParams(strings.Fields(value))
@@ -396,6 +596,12 @@ func addHypervisorConfigOverrides(ocispec specs.Spec, config *vc.SandboxConfig)
 		}
 	}
 
+	if value, ok := ocispec.Annotations[vcAnnotations.HypervisorProfile]; ok {
+		if err := config.HypervisorConfig.SelectHypervisorProfile(value); err != nil {
+			return err
+		}
+	}
+
 	if value, ok := ocispec.Annotations[vcAnnotations.MachineType]; ok {
 		if value != "" {
 			config.HypervisorConfig.HypervisorMachineType = value
@@ -534,6 +740,15 @@ func addHypervisorMemoryOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig
 		sbConfig.HypervisorConfig.HugePages = hugePages
 	}
 
+	if value, ok := ocispec.Annotations[vcAnnotations.FreePageReporting]; ok {
+		freePageReporting, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("Error parsing annotation for enable_free_page_reporting: Please specify boolean value 'true|false'")
+		}
+
+		sbConfig.HypervisorConfig.FreePageReporting = freePageReporting
+	}
+
 	if value, ok := ocispec.Annotations[vcAnnotations.IOMMU]; ok {
 		iommu, err := strconv.ParseBool(value)
 		if err != nil {
@@ -610,6 +825,24 @@ func addHypervisorBlockOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig)
 		sbConfig.HypervisorConfig.DisableBlockDeviceUse = disableBlockDeviceUse
 	}
 
+	if value, ok := ocispec.Annotations[vcAnnotations.EnableVhostUserStore]; ok {
+		enableVhostUserStore, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("Error parsing annotation for enable_vhost_user_store: Please specify boolean value 'true|false'")
+		}
+
+		sbConfig.HypervisorConfig.EnableVhostUserStore = enableVhostUserStore
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.VhostUserReconnect]; ok {
+		reconnect, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("Error parsing annotation for vhost_user_reconnect: %v, Please specify an integer greater than or equal to 0", err)
+		}
+
+		sbConfig.HypervisorConfig.VhostUserReconnect = uint32(reconnect)
+	}
+
 	if value, ok := ocispec.Annotations[vcAnnotations.EnableIOThreads]; ok {
 		enableIOThreads, err := strconv.ParseBool(value)
 		if err != nil {
@@ -651,7 +884,7 @@ func addHypervisorBlockOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig)
 
 func addHypervisporVirtioFsOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig) error {
 	if value, ok := ocispec.Annotations[vcAnnotations.SharedFS]; ok {
-		supportedSharedFS := []string{config.Virtio9P, config.VirtioFS}
+		supportedSharedFS := []string{config.Virtio9P, config.VirtioFS, config.NoSharedFS}
 		valid := false
 		for _, fs := range supportedSharedFS {
 			if fs == value {
@@ -714,6 +947,22 @@ func addHypervisporNetworkOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConf
 		sbConfig.HypervisorConfig.DisableVhostNet = disableVhostNet
 	}
 
+	if value, ok := ocispec.Annotations[vcAnnotations.NetworkQueues]; ok {
+		queues, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("Error parsing annotation for network_queues: %v, Please specify an integer greater than or equal to 0", err)
+		}
+		sbConfig.HypervisorConfig.NetworkQueues = uint32(queues)
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.NetworkMaxQueues]; ok {
+		maxQueues, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("Error parsing annotation for network_max_queues: %v, Please specify an integer greater than or equal to 0", err)
+		}
+		sbConfig.HypervisorConfig.NetworkMaxQueues = uint32(maxQueues)
+	}
+
 	if value, ok := ocispec.Annotations[vcAnnotations.RxRateLimiterMaxRate]; ok {
 		rxRateLimiterMaxRate, err := strconv.ParseUint(value, 10, 64)
 		if err != nil || rxRateLimiterMaxRate < 0 {
@@ -782,12 +1031,25 @@ func addRuntimeConfigOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig) e
 		sbConfig.NetworkConfig.InterworkingModel = runtimeConfig.InterNetworkModel
 	}
 
+	if value, ok := ocispec.Annotations[vcAnnotations.InterfaceEndpoints]; ok {
+		endpoints, err := vc.ParseInterfaceEndpoints(strings.Split(value, ","))
+		if err != nil {
+			return fmt.Errorf("Error parsing annotation %s: %v", vcAnnotations.InterfaceEndpoints, err)
+		}
+
+		sbConfig.NetworkConfig.InterfaceEndpoints = endpoints
+	}
+
 	return nil
 }
 
 func addAgentConfigOverrides(ocispec specs.Spec, config *vc.SandboxConfig) error {
 	c := config.AgentConfig
 
+	if value, ok := ocispec.Annotations[vcAnnotations.AgentType]; ok {
+		config.AgentType = vc.AgentType(value)
+	}
+
 	if value, ok := ocispec.Annotations[vcAnnotations.KernelModules]; ok {
 		modules := strings.Split(value, KernelModulesSeparator)
 		c.KernelModules = modules
@@ -820,6 +1082,74 @@ func addAgentConfigOverrides(ocispec specs.Spec, config *vc.SandboxConfig) error
 
 	config.AgentConfig = c
 
+	if value, ok := ocispec.Annotations[vcAnnotations.AgentNTPServers]; ok {
+		config.TimeSync.Servers = strings.Split(value, ",")
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.AgentNTPPools]; ok {
+		config.TimeSync.Pools = strings.Split(value, ",")
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.AgentPTPKVM]; ok {
+		ptpKVM, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("Error parsing annotation for %s: Please specify boolean value 'true|false'", vcAnnotations.AgentPTPKVM)
+		}
+		config.TimeSync.PTPKVM = ptpKVM
+	}
+
+	// The host paths are only set by the configuration file.
+	if value, ok := ocispec.Annotations[vcAnnotations.AgentTimezone]; ok {
+		config.GuestProvisioning.Timezone = value
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.AgentLocale]; ok {
+		config.GuestProvisioning.Locale = value
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.AgentCacheDropInterval]; ok {
+		interval, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("Error parsing annotation for %s: Please specify uint32 value", vcAnnotations.AgentCacheDropInterval)
+		}
+		config.GuestMemoryReclaim.CacheDropInterval = time.Duration(interval) * time.Second
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.AgentCacheDropThreshold]; ok {
+		threshold, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("Error parsing annotation for %s: Please specify uint32 value", vcAnnotations.AgentCacheDropThreshold)
+		}
+		config.GuestMemoryReclaim.CacheDropThresholdMB = uint32(threshold)
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.AgentVFSCachePressure]; ok {
+		pressure, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("Error parsing annotation for %s: Please specify uint32 value", vcAnnotations.AgentVFSCachePressure)
+		}
+		config.GuestMemoryReclaim.VFSCachePressure = uint32(pressure)
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.AgentIdleScanInterval]; ok {
+		interval, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("Error parsing annotation for %s: Please specify uint32 value", vcAnnotations.AgentIdleScanInterval)
+		}
+		config.GuestMemoryReclaim.IdleScanInterval = time.Duration(interval) * time.Second
+	}
+
+	// A sandbox can only restrict the metadata paths the runtime allows
+	if value, ok := ocispec.Annotations[vcAnnotations.AgentMetadataAllowedPaths]; ok {
+		paths := strings.Split(value, ",")
+		for _, p := range paths {
+			if !config.MetadataProxy.Allows(p) {
+				return fmt.Errorf("Error parsing annotation for %s: metadata path %q is not allowed", vcAnnotations.AgentMetadataAllowedPaths, p)
+			}
+		}
+		config.MetadataProxy.AllowedPaths = paths
+	}
+
 	return nil
 }
 
@@ -870,10 +1200,57 @@ func SandboxConfig(ocispec specs.Spec, runtime RuntimeConfig, bundlePath, cid, c
 
 		DisableGuestSeccomp: runtime.DisableGuestSeccomp,
 
+		GuestHooks: runtime.GuestHooks,
+
+		Registrars: runtime.SandboxRegistrars,
+
 		// Q: Is this really necessary? @weizhang555
 		// Spec: &ocispec,
 
 		Experimental: runtime.Experimental,
+
+		ResourceCeilings: runtime.ResourceCeilings,
+
+		NodeLimits: runtime.NodeLimits,
+
+		ResizePolicy: runtime.ResizePolicy,
+
+		ShutdownTimeouts: runtime.ShutdownTimeouts,
+
+		Lightweight: runtime.Lightweight,
+
+		HotplugPlanning: runtime.HotplugPlanning,
+
+		AdmissionPolicy: runtime.AdmissionPolicy,
+
+		CPUShares: runtime.CPUShares,
+
+		GuestOS: runtime.GuestOS,
+
+		AgentType: runtime.AgentType,
+
+		RootfsDisk: runtime.RootfsDisk,
+
+		TimeSync: runtime.TimeSync,
+
+		GuestProvisioning: runtime.GuestProvisioning,
+
+		CoreDumpDir: runtime.CoreDumpDir,
+
+		AssetStore:      runtime.AssetStore,
+		AssetSigningKey: runtime.AssetSigningKey,
+
+		Kdump: runtime.Kdump,
+
+		GuestProfiling: runtime.GuestProfiling,
+
+		GuestLivepatch: runtime.GuestLivepatch,
+
+		GuestMemoryReclaim: runtime.GuestMemoryReclaim,
+
+		MetadataProxy: runtime.MetadataProxy,
+
+		TrafficCapture: runtime.TrafficCapture,
 	}
 
 	if err := addAnnotations(ocispec, &sandboxConfig); err != nil {
@@ -943,9 +1320,86 @@ func ContainerConfig(ocispec specs.Spec, bundlePath, cid, console string, detach
 
 	containerConfig.Annotations[vcAnnotations.ContainerTypeKey] = string(cType)
 
+	if containerConfig.CoreDump, err = containerCoreDump(ocispec); err != nil {
+		return vc.ContainerConfig{}, err
+	}
+
+	if containerConfig.EncryptedScratchMB, err = containerEncryptedScratch(ocispec); err != nil {
+		return vc.ContainerConfig{}, err
+	}
+
+	if err = containerVolumeBlockCache(ocispec, containerConfig.Mounts); err != nil {
+		return vc.ContainerConfig{}, err
+	}
+
 	return containerConfig, nil
 }
 
+func containerEncryptedScratch(ocispec specs.Spec) (uint64, error) {
+	value, ok := ocispec.Annotations[vcAnnotations.ContainerEncryptedScratchSize]
+	if !ok {
+		return 0, nil
+	}
+
+	size, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Error parsing annotation for %s: Please specify uint64 value", vcAnnotations.ContainerEncryptedScratchSize)
+	}
+
+	return size, nil
+}
+
+// containerVolumeBlockCache sets the block device cache mode of the mounts
+// of the volumes listed in the annotation.
+func containerVolumeBlockCache(ocispec specs.Spec, mounts []vc.Mount) error {
+	value, ok := ocispec.Annotations[vcAnnotations.ContainerVolumeBlockCache]
+	if !ok {
+		return nil
+	}
+
+	for _, v := range strings.Split(value, ",") {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return fmt.Errorf("Error parsing annotation for %s: Please specify <destination>=<mode> values", vcAnnotations.ContainerVolumeBlockCache)
+		}
+
+		if err := config.CheckBlockCache(kv[1]); err != nil {
+			return fmt.Errorf("Error parsing annotation for %s: %v", vcAnnotations.ContainerVolumeBlockCache, err)
+		}
+
+		found := false
+		for i := range mounts {
+			if mounts[i].Destination == kv[0] {
+				mounts[i].BlockCache = kv[1]
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("Error parsing annotation for %s: no volume mounted at %s", vcAnnotations.ContainerVolumeBlockCache, kv[0])
+		}
+	}
+
+	return nil
+}
+
+func containerCoreDump(ocispec specs.Spec) (vc.CoreDump, error) {
+	var d vc.CoreDump
+
+	if value, ok := ocispec.Annotations[vcAnnotations.ContainerCoreDumpPolicy]; ok {
+		d.Policy = vc.CoreDumpPolicy(value)
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.ContainerCoreDumpMaxSize]; ok {
+		maxSize, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return vc.CoreDump{}, fmt.Errorf("Error parsing annotation for %s: Please specify uint64 value", vcAnnotations.ContainerCoreDumpMaxSize)
+		}
+		d.MaxSize = maxSize
+	}
+
+	return d, nil
+}
+
 func getShmSize(c vc.ContainerConfig) (uint64, error) {
 	var shmSize uint64
 
@@ -977,7 +1431,7 @@ func StatusToOCIState(status vc.ContainerStatus) specs.State {
 	return specs.State{
 		Version:     specs.Version,
 		ID:          status.ID,
-		Status:      StateToOCIState(status.State.State),
+		Status:      specs.ContainerState(StateToOCIState(status.State.State)),
 		Pid:         status.PID,
 		Bundle:      status.Annotations[vcAnnotations.BundlePathKey],
 		Annotations: status.Annotations,
@@ -993,7 +1447,7 @@ func StateToOCIState(state types.StateString) string {
 		return StateRunning
 	case types.StateStopped:
 		return StateStopped
-	case types.StatePaused:
+	case types.StatePaused, types.StateSuspended:
 		return StatePaused
 	default:
 		return ""
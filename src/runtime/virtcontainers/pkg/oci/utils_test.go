@@ -15,6 +15,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cri-o/cri-o/pkg/annotations"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
@@ -565,6 +566,23 @@ func TestDevicePathEmpty(t *testing.T) {
 	assert.NotNil(t, err, "This test should fail as path cannot be empty for device")
 }
 
+func TestContainerMdevDevices(t *testing.T) {
+	var ociSpec specs.Spec
+
+	ociSpec.Linux = &specs.Linux{}
+	ociSpec.Annotations = map[string]string{
+		vcAnnotations.ContainerMdevDevices: " , ",
+	}
+
+	devices, err := containerDeviceInfos(ociSpec)
+	assert.NoError(t, err)
+	assert.Empty(t, devices)
+
+	ociSpec.Annotations[vcAnnotations.ContainerMdevDevices] = "not-a-uuid"
+	_, err = containerDeviceInfos(ociSpec)
+	assert.Error(t, err)
+}
+
 func TestGetShmSize(t *testing.T) {
 	containerConfig := vc.ContainerConfig{
 		Mounts: []vc.Mount{},
@@ -729,6 +747,195 @@ func TestContainerPipeSizeAnnotation(t *testing.T) {
 	assert.Exactly(expectedAgentConfig, config.AgentConfig)
 }
 
+func TestAgentTypeAnnotation(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.SandboxConfig{
+		Annotations: make(map[string]string),
+	}
+
+	ocispec := specs.Spec{
+		Annotations: map[string]string{
+			vcAnnotations.AgentType: "kata",
+		},
+	}
+
+	err := addAnnotations(ocispec, &config)
+	assert.NoError(err)
+	assert.Equal(vc.KataAgentType, config.AgentType)
+}
+
+func TestTimeSyncAnnotations(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.SandboxConfig{
+		Annotations: make(map[string]string),
+	}
+
+	ocispec := specs.Spec{
+		Annotations: make(map[string]string),
+	}
+
+	ocispec.Annotations[vcAnnotations.AgentNTPServers] = "10.0.0.1,10.0.0.2"
+	ocispec.Annotations[vcAnnotations.AgentNTPPools] = "pool.ntp.org"
+	ocispec.Annotations[vcAnnotations.AgentPTPKVM] = "true"
+	err := addAnnotations(ocispec, &config)
+	assert.NoError(err)
+	assert.Exactly(vc.TimeSync{
+		Servers: []string{"10.0.0.1", "10.0.0.2"},
+		Pools:   []string{"pool.ntp.org"},
+		PTPKVM:  true,
+	}, config.TimeSync)
+
+	ocispec.Annotations[vcAnnotations.AgentPTPKVM] = "foo"
+	err = addAnnotations(ocispec, &config)
+	assert.Error(err)
+}
+
+func TestContainerCoreDump(t *testing.T) {
+	assert := assert.New(t)
+
+	ocispec := specs.Spec{
+		Annotations: map[string]string{
+			vcAnnotations.ContainerCoreDumpPolicy:  "capture",
+			vcAnnotations.ContainerCoreDumpMaxSize: "1048576",
+		},
+	}
+
+	d, err := containerCoreDump(ocispec)
+	assert.NoError(err)
+	assert.Equal(vc.CoreDump{Policy: vc.CoreDumpCapture, MaxSize: 1048576}, d)
+
+	ocispec.Annotations[vcAnnotations.ContainerCoreDumpMaxSize] = "1M"
+	_, err = containerCoreDump(ocispec)
+	assert.Error(err)
+}
+
+func TestContainerEncryptedScratch(t *testing.T) {
+	assert := assert.New(t)
+
+	ocispec := specs.Spec{
+		Annotations: map[string]string{},
+	}
+
+	size, err := containerEncryptedScratch(ocispec)
+	assert.NoError(err)
+	assert.Zero(size)
+
+	ocispec.Annotations[vcAnnotations.ContainerEncryptedScratchSize] = "1024"
+	size, err = containerEncryptedScratch(ocispec)
+	assert.NoError(err)
+	assert.Equal(uint64(1024), size)
+
+	ocispec.Annotations[vcAnnotations.ContainerEncryptedScratchSize] = "1G"
+	_, err = containerEncryptedScratch(ocispec)
+	assert.Error(err)
+}
+
+func TestContainerVolumeBlockCache(t *testing.T) {
+	assert := assert.New(t)
+
+	ocispec := specs.Spec{
+		Annotations: map[string]string{
+			vcAnnotations.ContainerVolumeBlockCache: "/var/lib/mysql=none,/logs=writethrough",
+		},
+	}
+	mounts := []vc.Mount{
+		{Source: "/dev/sdb", Destination: "/var/lib/mysql", Type: "bind"},
+		{Source: "/dev/sdc", Destination: "/logs", Type: "bind"},
+		{Source: "/tmp", Destination: "/tmp", Type: "bind"},
+	}
+
+	assert.NoError(containerVolumeBlockCache(ocispec, mounts))
+	assert.Equal("none", mounts[0].BlockCache)
+	assert.Equal("writethrough", mounts[1].BlockCache)
+	assert.Empty(mounts[2].BlockCache)
+
+	for _, value := range []string{"none", "/var/lib/mysql=unsafe", "/data=none", "=none"} {
+		ocispec.Annotations[vcAnnotations.ContainerVolumeBlockCache] = value
+		assert.Error(containerVolumeBlockCache(ocispec, mounts), value)
+	}
+}
+
+func TestGuestProvisioningAnnotations(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.SandboxConfig{
+		Annotations: make(map[string]string),
+	}
+
+	ocispec := specs.Spec{
+		Annotations: make(map[string]string),
+	}
+
+	ocispec.Annotations[vcAnnotations.AgentTimezone] = "Europe/Paris"
+	ocispec.Annotations[vcAnnotations.AgentLocale] = "en_US.UTF-8"
+	err := addAnnotations(ocispec, &config)
+	assert.NoError(err)
+	assert.Exactly(vc.GuestProvisioning{
+		Timezone: "Europe/Paris",
+		Locale:   "en_US.UTF-8",
+	}, config.GuestProvisioning)
+}
+
+func TestGuestMemoryReclaimAnnotations(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.SandboxConfig{
+		Annotations: make(map[string]string),
+	}
+
+	ocispec := specs.Spec{
+		Annotations: make(map[string]string),
+	}
+
+	ocispec.Annotations[vcAnnotations.AgentCacheDropInterval] = "60"
+	ocispec.Annotations[vcAnnotations.AgentCacheDropThreshold] = "128"
+	ocispec.Annotations[vcAnnotations.AgentVFSCachePressure] = "200"
+	ocispec.Annotations[vcAnnotations.AgentIdleScanInterval] = "120"
+	err := addAnnotations(ocispec, &config)
+	assert.NoError(err)
+	assert.Exactly(vc.GuestMemoryReclaim{
+		CacheDropInterval:    time.Minute,
+		CacheDropThresholdMB: 128,
+		VFSCachePressure:     200,
+		IdleScanInterval:     2 * time.Minute,
+	}, config.GuestMemoryReclaim)
+
+	ocispec.Annotations[vcAnnotations.AgentCacheDropInterval] = "1m"
+	err = addAnnotations(ocispec, &config)
+	assert.Error(err)
+}
+
+func TestMetadataProxyAnnotations(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.SandboxConfig{
+		Annotations: make(map[string]string),
+		MetadataProxy: vc.MetadataProxy{
+			AllowedPaths: []string{"/latest/meta-data/", "/latest/api/token"},
+		},
+	}
+
+	ocispec := specs.Spec{
+		Annotations: make(map[string]string),
+	}
+
+	ocispec.Annotations[vcAnnotations.AgentMetadataAllowedPaths] = "/latest/meta-data/placement/,/latest/api/token"
+	err := addAnnotations(ocispec, &config)
+	assert.NoError(err)
+	assert.Equal([]string{"/latest/meta-data/placement/", "/latest/api/token"}, config.MetadataProxy.AllowedPaths)
+
+	// A sandbox cannot access more paths than the runtime allows
+	ocispec.Annotations[vcAnnotations.AgentMetadataAllowedPaths] = "/latest/user-data"
+	err = addAnnotations(ocispec, &config)
+	assert.Error(err)
+
+	ocispec.Annotations[vcAnnotations.AgentMetadataAllowedPaths] = "/latest/meta-data/../user-data"
+	err = addAnnotations(ocispec, &config)
+	assert.Error(err)
+}
+
 func TestAddHypervisorAnnotations(t *testing.T) {
 	assert := assert.New(t)
 
@@ -768,9 +975,12 @@ func TestAddHypervisorAnnotations(t *testing.T) {
 	ocispec.Annotations[vcAnnotations.FileBackedMemRootDir] = "/dev/shm"
 	ocispec.Annotations[vcAnnotations.HugePages] = "true"
 	ocispec.Annotations[vcAnnotations.IOMMU] = "true"
+	ocispec.Annotations[vcAnnotations.FreePageReporting] = "true"
 	ocispec.Annotations[vcAnnotations.BlockDeviceDriver] = "virtio-scsi"
 	ocispec.Annotations[vcAnnotations.DisableBlockDeviceUse] = "true"
 	ocispec.Annotations[vcAnnotations.EnableIOThreads] = "true"
+	ocispec.Annotations[vcAnnotations.EnableVhostUserStore] = "true"
+	ocispec.Annotations[vcAnnotations.VhostUserReconnect] = "2"
 	ocispec.Annotations[vcAnnotations.BlockDeviceCacheSet] = "true"
 	ocispec.Annotations[vcAnnotations.BlockDeviceCacheDirect] = "true"
 	ocispec.Annotations[vcAnnotations.BlockDeviceCacheNoflush] = "true"
@@ -782,6 +992,8 @@ func TestAddHypervisorAnnotations(t *testing.T) {
 	ocispec.Annotations[vcAnnotations.MachineAccelerators] = "nofw"
 	ocispec.Annotations[vcAnnotations.CPUFeatures] = "pmu=off"
 	ocispec.Annotations[vcAnnotations.DisableVhostNet] = "true"
+	ocispec.Annotations[vcAnnotations.NetworkQueues] = "4"
+	ocispec.Annotations[vcAnnotations.NetworkMaxQueues] = "16"
 	ocispec.Annotations[vcAnnotations.GuestHookPath] = "/usr/bin/"
 	ocispec.Annotations[vcAnnotations.UseVSock] = "true"
 	ocispec.Annotations[vcAnnotations.DisableImageNvdimm] = "true"
@@ -804,9 +1016,12 @@ func TestAddHypervisorAnnotations(t *testing.T) {
 	assert.Equal(config.HypervisorConfig.FileBackedMemRootDir, "/dev/shm")
 	assert.Equal(config.HypervisorConfig.HugePages, true)
 	assert.Equal(config.HypervisorConfig.IOMMU, true)
+	assert.Equal(config.HypervisorConfig.FreePageReporting, true)
 	assert.Equal(config.HypervisorConfig.BlockDeviceDriver, "virtio-scsi")
 	assert.Equal(config.HypervisorConfig.DisableBlockDeviceUse, true)
 	assert.Equal(config.HypervisorConfig.EnableIOThreads, true)
+	assert.Equal(config.HypervisorConfig.EnableVhostUserStore, true)
+	assert.Equal(config.HypervisorConfig.VhostUserReconnect, uint32(2))
 	assert.Equal(config.HypervisorConfig.BlockDeviceCacheSet, true)
 	assert.Equal(config.HypervisorConfig.BlockDeviceCacheDirect, true)
 	assert.Equal(config.HypervisorConfig.BlockDeviceCacheNoflush, true)
@@ -818,6 +1033,8 @@ func TestAddHypervisorAnnotations(t *testing.T) {
 	assert.Equal(config.HypervisorConfig.MachineAccelerators, "nofw")
 	assert.Equal(config.HypervisorConfig.CPUFeatures, "pmu=off")
 	assert.Equal(config.HypervisorConfig.DisableVhostNet, true)
+	assert.Equal(config.HypervisorConfig.NetworkQueues, uint32(4))
+	assert.Equal(config.HypervisorConfig.NetworkMaxQueues, uint32(16))
 	assert.Equal(config.HypervisorConfig.GuestHookPath, "/usr/bin/")
 	assert.Equal(config.HypervisorConfig.UseVSock, true)
 	assert.Equal(config.HypervisorConfig.DisableImageNvdimm, true)
@@ -846,6 +1063,64 @@ func TestAddHypervisorAnnotations(t *testing.T) {
 	assert.Error(err)
 }
 
+func TestAddHypervisorProfileAnnotation(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.SandboxConfig{
+		Annotations: make(map[string]string),
+		HypervisorConfig: vc.HypervisorConfig{
+			HypervisorPath:     "/usr/bin/qemu-system-x86_64",
+			HypervisorProfiles: map[string]string{"canary": "/opt/qemu-canary/bin/qemu-system-x86_64"},
+		},
+	}
+
+	ocispec := specs.Spec{
+		Annotations: map[string]string{vcAnnotations.HypervisorProfile: "canary"},
+	}
+
+	assert.NoError(addAnnotations(ocispec, &config))
+	assert.Equal("/opt/qemu-canary/bin/qemu-system-x86_64", config.HypervisorConfig.HypervisorPath)
+	assert.Equal("canary", config.HypervisorConfig.HypervisorProfile)
+
+	// Only the configured binaries can be selected
+	ocispec.Annotations[vcAnnotations.HypervisorProfile] = "/tmp/qemu"
+	assert.Error(addAnnotations(ocispec, &config))
+}
+
+func TestAddKernelParamsProfileAnnotation(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.SandboxConfig{
+		Annotations: make(map[string]string),
+		HypervisorConfig: vc.HypervisorConfig{
+			KernelParams: []vc.Param{{Key: "quiet"}, {Key: "agent.log", Value: "info"}},
+			KernelParamsProfiles: map[string][]vc.Param{
+				"debug": {{Key: "agent.log", Value: "debug"}, {Key: "debug"}},
+			},
+		},
+	}
+
+	ocispec := specs.Spec{
+		Annotations: map[string]string{
+			vcAnnotations.KernelParamsProfile: "debug",
+			vcAnnotations.KernelParams:        "agent.debug_console",
+		},
+	}
+
+	assert.NoError(addAnnotations(ocispec, &config))
+	assert.Equal("debug", config.HypervisorConfig.KernelParamsProfile)
+	assert.Equal([]vc.Param{
+		{Key: "quiet"},
+		{Key: "agent.log", Value: "debug"},
+		{Key: "debug"},
+		{Key: "agent.debug_console"},
+	}, config.HypervisorConfig.KernelParams)
+
+	config.HypervisorConfig.KernelParamsProfile = ""
+	ocispec.Annotations = map[string]string{vcAnnotations.KernelParamsProfile: "perf"}
+	assert.Error(addAnnotations(ocispec, &config))
+}
+
 func TestAddRuntimeAnnotations(t *testing.T) {
 	assert := assert.New(t)
 
@@ -861,10 +1136,61 @@ func TestAddRuntimeAnnotations(t *testing.T) {
 	ocispec.Annotations[vcAnnotations.SandboxCgroupOnly] = "true"
 	ocispec.Annotations[vcAnnotations.DisableNewNetNs] = "true"
 	ocispec.Annotations[vcAnnotations.InterNetworkModel] = "macvtap"
+	ocispec.Annotations[vcAnnotations.InterfaceEndpoints] = "eth0=ipvlan,net1=tcfilter"
 
 	addAnnotations(ocispec, &config)
 	assert.Equal(config.DisableGuestSeccomp, true)
 	assert.Equal(config.SandboxCgroupOnly, true)
 	assert.Equal(config.NetworkConfig.DisableNewNetNs, true)
 	assert.Equal(config.NetworkConfig.InterworkingModel, vc.NetXConnectMacVtapModel)
+	assert.Equal(config.NetworkConfig.InterfaceEndpoints, vc.InterfaceEndpoints{
+		"eth0": vc.InterfaceEndpointIPVlan,
+		"net1": vc.InterfaceEndpointTCFilter,
+	})
+
+	ocispec.Annotations[vcAnnotations.InterfaceEndpoints] = "eth0=bridge"
+	assert.Error(addAnnotations(ocispec, &config))
+}
+
+func TestCheckAnnotations(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.SandboxConfig{
+		Annotations: make(map[string]string),
+	}
+
+	ocispec := specs.Spec{
+		Annotations: map[string]string{
+			"io.katacontainers.config.hypervisor.default_maxvcpus": "1",
+			"io.katacontainers.config.hypervisor.iommu":            "true",
+			"io.katacontainers.config.hypervisor.enable_hugepage":  "true",
+		},
+	}
+
+	// The deprecated keys are used, the unknown ones ignored.
+	assert.NoError(addAnnotations(ocispec, &config))
+	assert.Equal(uint32(1), config.HypervisorConfig.DefaultMaxVCPUs)
+	assert.True(config.HypervisorConfig.IOMMU)
+	assert.False(config.HypervisorConfig.HugePages)
+
+	// The spec of the caller is left alone.
+	assert.Len(ocispec.Annotations, 3)
+	assert.NotContains(ocispec.Annotations, vcAnnotations.DefaultMaxVCPUs)
+
+	ocispec.Annotations[vcAnnotations.BlockDeviceCacheSet] = "maybe"
+	assert.Error(addAnnotations(ocispec, &config))
+
+	ocispec.Annotations[vcAnnotations.BlockDeviceCacheSet] = "true"
+	ocispec.Annotations[vcAnnotations.PCIeRootPort] = "4294967296"
+	assert.Error(addAnnotations(ocispec, &config))
+}
+
+func TestAttachPolicyValid(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(AttachPolicy("").Valid())
+	assert.True(AttachPolicyShared.Valid())
+	assert.True(AttachPolicySingleWriter.Valid())
+	assert.True(AttachPolicyExclusive.Valid())
+	assert.False(AttachPolicy("broadcast").Valid())
 }
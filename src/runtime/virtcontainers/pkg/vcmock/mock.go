@@ -18,7 +18,9 @@ package vcmock
 import (
 	"context"
 	"fmt"
+	"io"
 	"syscall"
+	"time"
 
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
@@ -56,6 +58,69 @@ func (m *VCMock) CreateSandbox(ctx context.Context, sandboxConfig vc.SandboxConf
 	return nil, fmt.Errorf("%s: %s (%+v): sandboxConfig: %v", mockErrorPrefix, getSelf(), m, sandboxConfig)
 }
 
+// CloneSandbox implements the VC function of the same name.
+func (m *VCMock) CloneSandbox(ctx context.Context, sourceID string, sandboxConfig vc.SandboxConfig) (vc.VCSandbox, error) {
+	if m.CloneSandboxFunc != nil {
+		return m.CloneSandboxFunc(ctx, sourceID, sandboxConfig)
+	}
+
+	return nil, fmt.Errorf("%s: %s (%+v): sourceID: %v, sandboxConfig: %v", mockErrorPrefix, getSelf(), m, sourceID, sandboxConfig)
+}
+
+// CloneSandboxSnapshot implements the VC function of the same name.
+func (m *VCMock) CloneSandboxSnapshot(ctx context.Context, sourceID, snapshot string, sandboxConfig vc.SandboxConfig) (vc.VCSandbox, error) {
+	if m.CloneSandboxSnapshotFunc != nil {
+		return m.CloneSandboxSnapshotFunc(ctx, sourceID, snapshot, sandboxConfig)
+	}
+
+	return nil, fmt.Errorf("%s: %s (%+v): sourceID: %v, snapshot: %v, sandboxConfig: %v", mockErrorPrefix, getSelf(), m, sourceID, snapshot, sandboxConfig)
+}
+
+// ListSandboxSnapshots implements the VC function of the same name.
+func (m *VCMock) ListSandboxSnapshots(ctx context.Context, sandboxID string) ([]vc.SnapshotInfo, error) {
+	if m.ListSandboxSnapshotsFunc != nil {
+		return m.ListSandboxSnapshotsFunc(ctx, sandboxID)
+	}
+
+	return nil, fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), m, sandboxID)
+}
+
+// SnapshotSandbox implements the VC function of the same name.
+func (m *VCMock) SnapshotSandbox(ctx context.Context, sandboxID, destPath string) error {
+	if m.SnapshotSandboxFunc != nil {
+		return m.SnapshotSandboxFunc(ctx, sandboxID, destPath)
+	}
+
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v, destPath: %v", mockErrorPrefix, getSelf(), m, sandboxID, destPath)
+}
+
+// RestoreSandbox implements the VC function of the same name.
+func (m *VCMock) RestoreSandbox(ctx context.Context, snapshotPath string) (vc.VCSandbox, error) {
+	if m.RestoreSandboxFunc != nil {
+		return m.RestoreSandboxFunc(ctx, snapshotPath)
+	}
+
+	return nil, fmt.Errorf("%s: %s (%+v): snapshotPath: %v", mockErrorPrefix, getSelf(), m, snapshotPath)
+}
+
+// MigrateSandbox implements the VC function of the same name.
+func (m *VCMock) MigrateSandbox(ctx context.Context, sandboxID, destination string) error {
+	if m.MigrateSandboxFunc != nil {
+		return m.MigrateSandboxFunc(ctx, sandboxID, destination)
+	}
+
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v, destination: %v", mockErrorPrefix, getSelf(), m, sandboxID, destination)
+}
+
+// ReceiveSandbox implements the VC function of the same name.
+func (m *VCMock) ReceiveSandbox(ctx context.Context, listenAddr string) (vc.VCSandbox, error) {
+	if m.ReceiveSandboxFunc != nil {
+		return m.ReceiveSandboxFunc(ctx, listenAddr)
+	}
+
+	return nil, fmt.Errorf("%s: %s (%+v): listenAddr: %v", mockErrorPrefix, getSelf(), m, listenAddr)
+}
+
 // DeleteSandbox implements the VC function of the same name.
 func (m *VCMock) DeleteSandbox(ctx context.Context, sandboxID string) (vc.VCSandbox, error) {
 	if m.DeleteSandboxFunc != nil {
@@ -110,6 +175,177 @@ func (m *VCMock) ListSandbox(ctx context.Context) ([]vc.SandboxStatus, error) {
 	return nil, fmt.Errorf("%s: %s", mockErrorPrefix, getSelf())
 }
 
+// ListSandboxesByHypervisorVersion implements the VC function of the same name.
+func (m *VCMock) ListSandboxesByHypervisorVersion(ctx context.Context, version string) ([]vc.SandboxStatus, error) {
+	if m.ListSandboxesByHypervisorVersionFunc != nil {
+		return m.ListSandboxesByHypervisorVersionFunc(ctx, version)
+	}
+
+	return nil, fmt.Errorf("%s: %s (%+v): version: %v", mockErrorPrefix, getSelf(), m, version)
+}
+
+// ListSandboxesWithFilter implements the VC function of the same name.
+func (m *VCMock) ListSandboxesWithFilter(ctx context.Context, filter vc.ListFilter) ([]vc.SandboxSummary, error) {
+	if m.ListSandboxesWithFilterFunc != nil {
+		return m.ListSandboxesWithFilterFunc(ctx, filter)
+	}
+
+	return nil, fmt.Errorf("%s: %s (%+v): filter: %+v", mockErrorPrefix, getSelf(), m, filter)
+}
+
+// LivepatchSandbox implements the VC function of the same name.
+func (m *VCMock) LivepatchSandbox(ctx context.Context, sandboxID, module string) error {
+	if m.LivepatchSandboxFunc != nil {
+		return m.LivepatchSandboxFunc(ctx, sandboxID, module)
+	}
+
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v, module: %v", mockErrorPrefix, getSelf(), m, sandboxID, module)
+}
+
+// PauseSandbox implements the VC function of the same name.
+func (m *VCMock) PauseSandbox(ctx context.Context, sandboxID string) error {
+	if m.PauseSandboxFunc != nil {
+		return m.PauseSandboxFunc(ctx, sandboxID)
+	}
+
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), m, sandboxID)
+}
+
+// ResumeSandbox implements the VC function of the same name.
+func (m *VCMock) ResumeSandbox(ctx context.Context, sandboxID string) error {
+	if m.ResumeSandboxFunc != nil {
+		return m.ResumeSandboxFunc(ctx, sandboxID)
+	}
+
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), m, sandboxID)
+}
+
+// SuspendSandboxToRAM implements the VC function of the same name.
+func (m *VCMock) SuspendSandboxToRAM(ctx context.Context, sandboxID string) error {
+	if m.SuspendSandboxToRAMFunc != nil {
+		return m.SuspendSandboxToRAMFunc(ctx, sandboxID)
+	}
+
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), m, sandboxID)
+}
+
+// ResumeSandboxFromRAM implements the VC function of the same name.
+func (m *VCMock) ResumeSandboxFromRAM(ctx context.Context, sandboxID string) error {
+	if m.ResumeSandboxFromRAMFunc != nil {
+		return m.ResumeSandboxFromRAMFunc(ctx, sandboxID)
+	}
+
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), m, sandboxID)
+}
+
+// CopyFileToContainer implements the VC function of the same name.
+func (m *VCMock) CopyFileToContainer(ctx context.Context, sandboxID, containerID, hostPath, guestPath string) error {
+	if m.CopyFileToContainerFunc != nil {
+		return m.CopyFileToContainerFunc(ctx, sandboxID, containerID, hostPath, guestPath)
+	}
+
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v, containerID: %v", mockErrorPrefix, getSelf(), m, sandboxID, containerID)
+}
+
+// CopyFileFromContainer implements the VC function of the same name.
+func (m *VCMock) CopyFileFromContainer(ctx context.Context, sandboxID, containerID, guestPath, hostPath string) error {
+	if m.CopyFileFromContainerFunc != nil {
+		return m.CopyFileFromContainerFunc(ctx, sandboxID, containerID, guestPath, hostPath)
+	}
+
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v, containerID: %v", mockErrorPrefix, getSelf(), m, sandboxID, containerID)
+}
+
+// CheckpointContainer implements the VC function of the same name.
+func (m *VCMock) CheckpointContainer(ctx context.Context, sandboxID, containerID string, opts vc.CheckpointOptions) error {
+	if m.CheckpointContainerFunc != nil {
+		return m.CheckpointContainerFunc(ctx, sandboxID, containerID, opts)
+	}
+
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v, containerID: %v", mockErrorPrefix, getSelf(), m, sandboxID, containerID)
+}
+
+// RestoreContainer implements the VC function of the same name.
+func (m *VCMock) RestoreContainer(ctx context.Context, sandboxID, containerID string, opts vc.CheckpointOptions) error {
+	if m.RestoreContainerFunc != nil {
+		return m.RestoreContainerFunc(ctx, sandboxID, containerID, opts)
+	}
+
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v, containerID: %v", mockErrorPrefix, getSelf(), m, sandboxID, containerID)
+}
+
+// RestartContainer implements the VC function of the same name.
+func (m *VCMock) RestartContainer(ctx context.Context, sandboxID, containerID string) (vc.VCContainer, error) {
+	if m.RestartContainerFunc != nil {
+		return m.RestartContainerFunc(ctx, sandboxID, containerID)
+	}
+
+	return nil, fmt.Errorf("%s: %s (%+v): sandboxID: %v, containerID: %v", mockErrorPrefix, getSelf(), m, sandboxID, containerID)
+}
+
+// ResizeSandboxMemory implements the VC function of the same name.
+func (m *VCMock) ResizeSandboxMemory(ctx context.Context, sandboxID string, targetMB uint32) error {
+	if m.ResizeSandboxMemoryFunc != nil {
+		return m.ResizeSandboxMemoryFunc(ctx, sandboxID, targetMB)
+	}
+
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v, targetMB: %v", mockErrorPrefix, getSelf(), m, sandboxID, targetMB)
+}
+
+// SandboxHostResources implements the VC function of the same name.
+func (m *VCMock) SandboxHostResources(ctx context.Context, sandboxID string) (vc.HostResources, error) {
+	if m.SandboxHostResourcesFunc != nil {
+		return m.SandboxHostResourcesFunc(ctx, sandboxID)
+	}
+
+	return vc.HostResources{}, fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), m, sandboxID)
+}
+
+// WatchSandboxEvents implements the VC function of the same name.
+func (m *VCMock) WatchSandboxEvents(ctx context.Context, sandboxID string) (<-chan vc.SandboxEvent, error) {
+	if m.WatchSandboxEventsFunc != nil {
+		return m.WatchSandboxEventsFunc(ctx, sandboxID)
+	}
+
+	return nil, fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), m, sandboxID)
+}
+
+// CaptureSandboxTraffic implements the VC function of the same name.
+func (m *VCMock) CaptureSandboxTraffic(ctx context.Context, sandboxID, iface string, duration time.Duration) (io.ReadCloser, error) {
+	if m.CaptureSandboxTrafficFunc != nil {
+		return m.CaptureSandboxTrafficFunc(ctx, sandboxID, iface, duration)
+	}
+
+	return nil, fmt.Errorf("%s: %s (%+v): sandboxID: %v, interface: %v", mockErrorPrefix, getSelf(), m, sandboxID, iface)
+}
+
+// GetLaunchMeasurement implements the VC function of the same name.
+func (m *VCMock) GetLaunchMeasurement(ctx context.Context, sandboxID string) (vc.LaunchMeasurement, error) {
+	if m.GetLaunchMeasurementFunc != nil {
+		return m.GetLaunchMeasurementFunc(ctx, sandboxID)
+	}
+
+	return vc.LaunchMeasurement{}, fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), m, sandboxID)
+}
+
+// GetTDReport implements the VC function of the same name.
+func (m *VCMock) GetTDReport(ctx context.Context, sandboxID string, reportData []byte) ([]byte, error) {
+	if m.GetTDReportFunc != nil {
+		return m.GetTDReportFunc(ctx, sandboxID, reportData)
+	}
+
+	return nil, fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), m, sandboxID)
+}
+
+// GetSandboxConsole implements the VC function of the same name.
+func (m *VCMock) GetSandboxConsole(ctx context.Context, sandboxID string) (io.ReadWriteCloser, error) {
+	if m.GetSandboxConsoleFunc != nil {
+		return m.GetSandboxConsoleFunc(ctx, sandboxID)
+	}
+
+	return nil, fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), m, sandboxID)
+}
+
 // StatusSandbox implements the VC function of the same name.
 func (m *VCMock) StatusSandbox(ctx context.Context, sandboxID string) (vc.SandboxStatus, error) {
 	if m.StatusSandboxFunc != nil {
@@ -245,6 +481,15 @@ func (m *VCMock) AddDevice(ctx context.Context, sandboxID string, info config.De
 	return nil, fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), m, sandboxID)
 }
 
+// RemoveDevice implements the VC function of the same name.
+func (m *VCMock) RemoveDevice(ctx context.Context, sandboxID, deviceID string) error {
+	if m.RemoveDeviceFunc != nil {
+		return m.RemoveDeviceFunc(ctx, sandboxID, deviceID)
+	}
+
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), m, sandboxID)
+}
+
 // AddInterface implements the VC function of the same name.
 func (m *VCMock) AddInterface(ctx context.Context, sandboxID string, inf *vcTypes.Interface) (*vcTypes.Interface, error) {
 	if m.AddInterfaceFunc != nil {
@@ -296,3 +541,59 @@ func (m *VCMock) CleanupContainer(ctx context.Context, sandboxID, containerID st
 	}
 	return fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), m, sandboxID)
 }
+
+// ExportSandboxState implements the VC function of the same name.
+func (m *VCMock) ExportSandboxState(ctx context.Context, sandboxID string, w io.Writer) error {
+	if m.ExportSandboxStateFunc != nil {
+		return m.ExportSandboxStateFunc(ctx, sandboxID, w)
+	}
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), m, sandboxID)
+}
+
+// CheckDeviceTopology implements the VC function of the same name.
+func (m *VCMock) CheckDeviceTopology(ctx context.Context, devices []config.DeviceInfo) (config.DeviceTopology, error) {
+	if m.CheckDeviceTopologyFunc != nil {
+		return m.CheckDeviceTopologyFunc(ctx, devices)
+	}
+	return config.DeviceTopology{}, fmt.Errorf("%s: %s (%+v): devices: %v", mockErrorPrefix, getSelf(), m, devices)
+}
+
+// PrefetchAssets implements the VC function of the same name.
+func (m *VCMock) PrefetchAssets(ctx context.Context, hypervisorConfig vc.HypervisorConfig) ([]vc.PrefetchedAsset, error) {
+	if m.PrefetchAssetsFunc != nil {
+		return m.PrefetchAssetsFunc(ctx, hypervisorConfig)
+	}
+	return nil, fmt.Errorf("%s: %s (%+v): hypervisorConfig: %+v", mockErrorPrefix, getSelf(), m, hypervisorConfig)
+}
+
+// PreallocateTaps implements the VC function of the same name.
+func (m *VCMock) PreallocateTaps(ctx context.Context, netNSPath string, hypervisorType vc.HypervisorType, hypervisorConfig vc.HypervisorConfig, count int) ([]string, error) {
+	if m.PreallocateTapsFunc != nil {
+		return m.PreallocateTapsFunc(ctx, netNSPath, hypervisorType, hypervisorConfig, count)
+	}
+	return nil, fmt.Errorf("%s: %s (%+v): netNSPath: %s, hypervisorType: %s, count: %d", mockErrorPrefix, getSelf(), m, netNSPath, hypervisorType, count)
+}
+
+// DrainAllSandboxes implements the VC function of the same name.
+func (m *VCMock) DrainAllSandboxes(ctx context.Context, deadline time.Time, policy vc.DrainPolicy) ([]vc.DrainResult, error) {
+	if m.DrainAllSandboxesFunc != nil {
+		return m.DrainAllSandboxesFunc(ctx, deadline, policy)
+	}
+	return nil, fmt.Errorf("%s: %s (%+v): deadline: %v, policy: %+v", mockErrorPrefix, getSelf(), m, deadline, policy)
+}
+
+// SandboxConfigSchema implements the VC function of the same name.
+func (m *VCMock) SandboxConfigSchema(ctx context.Context) *vc.ConfigSchema {
+	if m.SandboxConfigSchemaFunc != nil {
+		return m.SandboxConfigSchemaFunc(ctx)
+	}
+	return nil
+}
+
+// ValidateSandboxConfig implements the VC function of the same name.
+func (m *VCMock) ValidateSandboxConfig(ctx context.Context, sandboxConfig vc.SandboxConfig) []*vc.ConfigFieldError {
+	if m.ValidateSandboxConfigFunc != nil {
+		return m.ValidateSandboxConfigFunc(ctx, sandboxConfig)
+	}
+	return nil
+}
@@ -7,11 +7,18 @@ package vcmock
 
 import (
 	"context"
+	"io"
+	"io/ioutil"
+	"net"
 	"reflect"
+	"strings"
 	"syscall"
 	"testing"
+	"time"
 
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
+	deviceConfig "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/factory"
 	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
@@ -143,56 +150,758 @@ func TestVCMockCreateSandbox(t *testing.T) {
 	assert.True(IsMockError(err))
 }
 
+func TestVCMockCloneSandbox(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.CloneSandboxFunc)
+
+	ctx := context.Background()
+	_, err := m.CloneSandbox(ctx, testSandboxID, vc.SandboxConfig{})
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.CloneSandboxFunc = func(ctx context.Context, sourceID string, sandboxConfig vc.SandboxConfig) (vc.VCSandbox, error) {
+		return &Sandbox{MockID: sandboxConfig.ID}, nil
+	}
+
+	sandbox, err := m.CloneSandbox(ctx, testSandboxID, vc.SandboxConfig{ID: "clone"})
+	assert.NoError(err)
+	assert.Equal("clone", sandbox.ID())
+
+	// reset
+	m.CloneSandboxFunc = nil
+
+	_, err = m.CloneSandbox(ctx, testSandboxID, vc.SandboxConfig{})
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockSnapshotSandbox(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.SnapshotSandboxFunc)
+
+	ctx := context.Background()
+	err := m.SnapshotSandbox(ctx, testSandboxID, "/var/lib/snapshots/pod")
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.SnapshotSandboxFunc = func(ctx context.Context, sandboxID, destPath string) error {
+		return nil
+	}
+
+	err = m.SnapshotSandbox(ctx, testSandboxID, "/var/lib/snapshots/pod")
+	assert.NoError(err)
+
+	// reset
+	m.SnapshotSandboxFunc = nil
+
+	err = m.SnapshotSandbox(ctx, testSandboxID, "/var/lib/snapshots/pod")
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockRestoreSandbox(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.RestoreSandboxFunc)
+
+	ctx := context.Background()
+	_, err := m.RestoreSandbox(ctx, "/var/lib/snapshots/pod")
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.RestoreSandboxFunc = func(ctx context.Context, snapshotPath string) (vc.VCSandbox, error) {
+		return &Sandbox{MockID: testSandboxID}, nil
+	}
+
+	sandbox, err := m.RestoreSandbox(ctx, "/var/lib/snapshots/pod")
+	assert.NoError(err)
+	assert.Equal(testSandboxID, sandbox.ID())
+
+	// reset
+	m.RestoreSandboxFunc = nil
+
+	_, err = m.RestoreSandbox(ctx, "/var/lib/snapshots/pod")
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockMigrateSandbox(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.MigrateSandboxFunc)
+
+	ctx := context.Background()
+	err := m.MigrateSandbox(ctx, testSandboxID, "192.168.0.2:4444")
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.MigrateSandboxFunc = func(ctx context.Context, sandboxID, destination string) error {
+		return nil
+	}
+
+	err = m.MigrateSandbox(ctx, testSandboxID, "192.168.0.2:4444")
+	assert.NoError(err)
+
+	// reset
+	m.MigrateSandboxFunc = nil
+
+	err = m.MigrateSandbox(ctx, testSandboxID, "192.168.0.2:4444")
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockReceiveSandbox(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.ReceiveSandboxFunc)
+
+	ctx := context.Background()
+	_, err := m.ReceiveSandbox(ctx, ":4444")
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.ReceiveSandboxFunc = func(ctx context.Context, listenAddr string) (vc.VCSandbox, error) {
+		return &Sandbox{MockID: testSandboxID}, nil
+	}
+
+	sandbox, err := m.ReceiveSandbox(ctx, ":4444")
+	assert.NoError(err)
+	assert.Equal(testSandboxID, sandbox.ID())
+
+	// reset
+	m.ReceiveSandboxFunc = nil
+
+	_, err = m.ReceiveSandbox(ctx, ":4444")
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockCloneSandboxSnapshot(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.CloneSandboxSnapshotFunc)
+
+	ctx := context.Background()
+	_, err := m.CloneSandboxSnapshot(ctx, testSandboxID, "20200601-120000.000000000", vc.SandboxConfig{})
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.CloneSandboxSnapshotFunc = func(ctx context.Context, sourceID, snapshot string, sandboxConfig vc.SandboxConfig) (vc.VCSandbox, error) {
+		return &Sandbox{MockID: sandboxConfig.ID}, nil
+	}
+
+	sandbox, err := m.CloneSandboxSnapshot(ctx, testSandboxID, "20200601-120000.000000000", vc.SandboxConfig{ID: "clone"})
+	assert.NoError(err)
+	assert.Equal("clone", sandbox.ID())
+
+	// reset
+	m.CloneSandboxSnapshotFunc = nil
+
+	_, err = m.CloneSandboxSnapshot(ctx, testSandboxID, "20200601-120000.000000000", vc.SandboxConfig{})
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockListSandboxSnapshots(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.ListSandboxSnapshotsFunc)
+
+	ctx := context.Background()
+	_, err := m.ListSandboxSnapshots(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.ListSandboxSnapshotsFunc = func(ctx context.Context, sandboxID string) ([]vc.SnapshotInfo, error) {
+		return []vc.SnapshotInfo{{Name: "20200601-120000.000000000"}}, nil
+	}
+
+	snapshots, err := m.ListSandboxSnapshots(ctx, testSandboxID)
+	assert.NoError(err)
+	assert.Len(snapshots, 1)
+
+	// reset
+	m.ListSandboxSnapshotsFunc = nil
+
+	_, err = m.ListSandboxSnapshots(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
 func TestVCMockDeleteSandbox(t *testing.T) {
 	assert := assert.New(t)
 
 	m := &VCMock{}
-	assert.Nil(m.DeleteSandboxFunc)
+	assert.Nil(m.DeleteSandboxFunc)
+
+	ctx := context.Background()
+	_, err := m.DeleteSandbox(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.DeleteSandboxFunc = func(ctx context.Context, sandboxID string) (vc.VCSandbox, error) {
+		return &Sandbox{}, nil
+	}
+
+	sandbox, err := m.DeleteSandbox(ctx, testSandboxID)
+	assert.NoError(err)
+	assert.Equal(sandbox, &Sandbox{})
+
+	// reset
+	m.DeleteSandboxFunc = nil
+
+	_, err = m.DeleteSandbox(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockListSandbox(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.ListSandboxFunc)
+
+	ctx := context.Background()
+	_, err := m.ListSandbox(ctx)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.ListSandboxFunc = func(ctx context.Context) ([]vc.SandboxStatus, error) {
+		return []vc.SandboxStatus{}, nil
+	}
+
+	sandboxes, err := m.ListSandbox(ctx)
+	assert.NoError(err)
+	assert.Equal(sandboxes, []vc.SandboxStatus{})
+
+	// reset
+	m.ListSandboxFunc = nil
+
+	_, err = m.ListSandbox(ctx)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockListSandboxesByHypervisorVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.ListSandboxesByHypervisorVersionFunc)
+
+	ctx := context.Background()
+	_, err := m.ListSandboxesByHypervisorVersion(ctx, "QEMU emulator version 5.0.0")
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.ListSandboxesByHypervisorVersionFunc = func(ctx context.Context, version string) ([]vc.SandboxStatus, error) {
+		return []vc.SandboxStatus{{ID: testSandboxID}}, nil
+	}
+
+	sandboxes, err := m.ListSandboxesByHypervisorVersion(ctx, "QEMU emulator version 5.0.0")
+	assert.NoError(err)
+	assert.Len(sandboxes, 1)
+
+	// reset
+	m.ListSandboxesByHypervisorVersionFunc = nil
+
+	_, err = m.ListSandboxesByHypervisorVersion(ctx, "QEMU emulator version 5.0.0")
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockListSandboxesWithFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.ListSandboxesWithFilterFunc)
+
+	ctx := context.Background()
+	filter := vc.ListFilter{States: []types.StateString{types.StateRunning}}
+	_, err := m.ListSandboxesWithFilter(ctx, filter)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.ListSandboxesWithFilterFunc = func(ctx context.Context, filter vc.ListFilter) ([]vc.SandboxSummary, error) {
+		return []vc.SandboxSummary{{ID: testSandboxID}}, nil
+	}
+
+	sandboxes, err := m.ListSandboxesWithFilter(ctx, filter)
+	assert.NoError(err)
+	assert.Len(sandboxes, 1)
+
+	// reset
+	m.ListSandboxesWithFilterFunc = nil
+
+	_, err = m.ListSandboxesWithFilter(ctx, filter)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockLivepatchSandbox(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.LivepatchSandboxFunc)
+
+	ctx := context.Background()
+	err := m.LivepatchSandbox(ctx, testSandboxID, "kpatch-fix")
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.LivepatchSandboxFunc = func(ctx context.Context, sandboxID, module string) error {
+		return nil
+	}
+
+	err = m.LivepatchSandbox(ctx, testSandboxID, "kpatch-fix")
+	assert.NoError(err)
+
+	// reset
+	m.LivepatchSandboxFunc = nil
+
+	err = m.LivepatchSandbox(ctx, testSandboxID, "kpatch-fix")
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockPauseSandbox(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.PauseSandboxFunc)
+
+	ctx := context.Background()
+	err := m.PauseSandbox(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.PauseSandboxFunc = func(ctx context.Context, sandboxID string) error {
+		return nil
+	}
+
+	err = m.PauseSandbox(ctx, testSandboxID)
+	assert.NoError(err)
+
+	// reset
+	m.PauseSandboxFunc = nil
+
+	err = m.PauseSandbox(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockResumeSandbox(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.ResumeSandboxFunc)
+
+	ctx := context.Background()
+	err := m.ResumeSandbox(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.ResumeSandboxFunc = func(ctx context.Context, sandboxID string) error {
+		return nil
+	}
+
+	err = m.ResumeSandbox(ctx, testSandboxID)
+	assert.NoError(err)
+
+	// reset
+	m.ResumeSandboxFunc = nil
+
+	err = m.ResumeSandbox(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockSuspendSandboxToRAM(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.SuspendSandboxToRAMFunc)
+
+	ctx := context.Background()
+	err := m.SuspendSandboxToRAM(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.SuspendSandboxToRAMFunc = func(ctx context.Context, sandboxID string) error {
+		return nil
+	}
+
+	err = m.SuspendSandboxToRAM(ctx, testSandboxID)
+	assert.NoError(err)
+
+	// reset
+	m.SuspendSandboxToRAMFunc = nil
+
+	err = m.SuspendSandboxToRAM(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockResumeSandboxFromRAM(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.ResumeSandboxFromRAMFunc)
+
+	ctx := context.Background()
+	err := m.ResumeSandboxFromRAM(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.ResumeSandboxFromRAMFunc = func(ctx context.Context, sandboxID string) error {
+		return nil
+	}
+
+	err = m.ResumeSandboxFromRAM(ctx, testSandboxID)
+	assert.NoError(err)
+
+	// reset
+	m.ResumeSandboxFromRAMFunc = nil
+
+	err = m.ResumeSandboxFromRAM(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockCopyFileToContainer(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.CopyFileToContainerFunc)
+
+	ctx := context.Background()
+	err := m.CopyFileToContainer(ctx, testSandboxID, testContainerID, "/etc/resolv.conf", "/etc/resolv.conf")
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.CopyFileToContainerFunc = func(ctx context.Context, sandboxID, containerID, hostPath, guestPath string) error {
+		return nil
+	}
+
+	err = m.CopyFileToContainer(ctx, testSandboxID, testContainerID, "/etc/resolv.conf", "/etc/resolv.conf")
+	assert.NoError(err)
+
+	// reset
+	m.CopyFileToContainerFunc = nil
+
+	err = m.CopyFileToContainer(ctx, testSandboxID, testContainerID, "/etc/resolv.conf", "/etc/resolv.conf")
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockCopyFileFromContainer(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.CopyFileFromContainerFunc)
+
+	ctx := context.Background()
+	err := m.CopyFileFromContainer(ctx, testSandboxID, testContainerID, "/var/log/app.log", "/tmp/app.log")
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.CopyFileFromContainerFunc = func(ctx context.Context, sandboxID, containerID, guestPath, hostPath string) error {
+		return nil
+	}
+
+	err = m.CopyFileFromContainer(ctx, testSandboxID, testContainerID, "/var/log/app.log", "/tmp/app.log")
+	assert.NoError(err)
+
+	// reset
+	m.CopyFileFromContainerFunc = nil
+
+	err = m.CopyFileFromContainer(ctx, testSandboxID, testContainerID, "/var/log/app.log", "/tmp/app.log")
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockCheckpointContainer(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.CheckpointContainerFunc)
+
+	ctx := context.Background()
+	opts := vc.CheckpointOptions{ImagesDir: "/var/lib/checkpoints"}
+	err := m.CheckpointContainer(ctx, testSandboxID, testContainerID, opts)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.CheckpointContainerFunc = func(ctx context.Context, sandboxID, containerID string, opts vc.CheckpointOptions) error {
+		return nil
+	}
+
+	err = m.CheckpointContainer(ctx, testSandboxID, testContainerID, opts)
+	assert.NoError(err)
+
+	// reset
+	m.CheckpointContainerFunc = nil
+
+	err = m.CheckpointContainer(ctx, testSandboxID, testContainerID, opts)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockRestoreContainer(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.RestoreContainerFunc)
+
+	ctx := context.Background()
+	opts := vc.CheckpointOptions{ImagesDir: "/var/lib/checkpoints"}
+	err := m.RestoreContainer(ctx, testSandboxID, testContainerID, opts)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.RestoreContainerFunc = func(ctx context.Context, sandboxID, containerID string, opts vc.CheckpointOptions) error {
+		return nil
+	}
+
+	err = m.RestoreContainer(ctx, testSandboxID, testContainerID, opts)
+	assert.NoError(err)
+
+	// reset
+	m.RestoreContainerFunc = nil
+
+	err = m.RestoreContainer(ctx, testSandboxID, testContainerID, opts)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockRestartContainer(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.RestartContainerFunc)
 
 	ctx := context.Background()
-	_, err := m.DeleteSandbox(ctx, testSandboxID)
+	_, err := m.RestartContainer(ctx, testSandboxID, testContainerID)
 	assert.Error(err)
 	assert.True(IsMockError(err))
 
-	m.DeleteSandboxFunc = func(ctx context.Context, sandboxID string) (vc.VCSandbox, error) {
-		return &Sandbox{}, nil
+	m.RestartContainerFunc = func(ctx context.Context, sandboxID, containerID string) (vc.VCContainer, error) {
+		return &Container{}, nil
 	}
 
-	sandbox, err := m.DeleteSandbox(ctx, testSandboxID)
+	container, err := m.RestartContainer(ctx, testSandboxID, testContainerID)
 	assert.NoError(err)
-	assert.Equal(sandbox, &Sandbox{})
+	assert.Equal(container, &Container{})
 
 	// reset
-	m.DeleteSandboxFunc = nil
+	m.RestartContainerFunc = nil
 
-	_, err = m.DeleteSandbox(ctx, testSandboxID)
+	_, err = m.RestartContainer(ctx, testSandboxID, testContainerID)
 	assert.Error(err)
 	assert.True(IsMockError(err))
 }
 
-func TestVCMockListSandbox(t *testing.T) {
+func TestVCMockResizeSandboxMemory(t *testing.T) {
 	assert := assert.New(t)
 
 	m := &VCMock{}
-	assert.Nil(m.ListSandboxFunc)
+	assert.Nil(m.ResizeSandboxMemoryFunc)
 
 	ctx := context.Background()
-	_, err := m.ListSandbox(ctx)
+	err := m.ResizeSandboxMemory(ctx, testSandboxID, 4096)
 	assert.Error(err)
 	assert.True(IsMockError(err))
 
-	m.ListSandboxFunc = func(ctx context.Context) ([]vc.SandboxStatus, error) {
-		return []vc.SandboxStatus{}, nil
+	m.ResizeSandboxMemoryFunc = func(ctx context.Context, sandboxID string, targetMB uint32) error {
+		return nil
 	}
 
-	sandboxes, err := m.ListSandbox(ctx)
+	err = m.ResizeSandboxMemory(ctx, testSandboxID, 4096)
 	assert.NoError(err)
-	assert.Equal(sandboxes, []vc.SandboxStatus{})
 
 	// reset
-	m.ListSandboxFunc = nil
+	m.ResizeSandboxMemoryFunc = nil
 
-	_, err = m.ListSandbox(ctx)
+	err = m.ResizeSandboxMemory(ctx, testSandboxID, 4096)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockSandboxHostResources(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.SandboxHostResourcesFunc)
+
+	ctx := context.Background()
+	_, err := m.SandboxHostResources(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.SandboxHostResourcesFunc = func(ctx context.Context, sandboxID string) (vc.HostResources, error) {
+		return vc.HostResources{SandboxID: sandboxID, Taps: []string{"tap0_kata"}}, nil
+	}
+
+	res, err := m.SandboxHostResources(ctx, testSandboxID)
+	assert.NoError(err)
+	assert.Equal(testSandboxID, res.SandboxID)
+	assert.Equal([]string{"tap0_kata"}, res.Taps)
+
+	// reset
+	m.SandboxHostResourcesFunc = nil
+
+	_, err = m.SandboxHostResources(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockWatchSandboxEvents(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.WatchSandboxEventsFunc)
+
+	ctx := context.Background()
+	_, err := m.WatchSandboxEvents(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.WatchSandboxEventsFunc = func(ctx context.Context, sandboxID string) (<-chan vc.SandboxEvent, error) {
+		events := make(chan vc.SandboxEvent, 1)
+		events <- vc.SandboxEvent{Type: vc.SandboxEventOOM, SandboxID: sandboxID, ContainerID: testContainerID}
+		close(events)
+		return events, nil
+	}
+
+	events, err := m.WatchSandboxEvents(ctx, testSandboxID)
+	assert.NoError(err)
+	event := <-events
+	assert.Equal(vc.SandboxEventOOM, event.Type)
+	assert.Equal(testSandboxID, event.SandboxID)
+	assert.Equal(testContainerID, event.ContainerID)
+
+	// reset
+	m.WatchSandboxEventsFunc = nil
+
+	_, err = m.WatchSandboxEvents(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockCaptureSandboxTraffic(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.CaptureSandboxTrafficFunc)
+
+	ctx := context.Background()
+	_, err := m.CaptureSandboxTraffic(ctx, testSandboxID, "eth0", time.Second)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.CaptureSandboxTrafficFunc = func(ctx context.Context, sandboxID, iface string, duration time.Duration) (io.ReadCloser, error) {
+		return ioutil.NopCloser(strings.NewReader("pcap")), nil
+	}
+
+	capture, err := m.CaptureSandboxTraffic(ctx, testSandboxID, "eth0", time.Second)
+	assert.NoError(err)
+	assert.NotNil(capture)
+
+	// reset
+	m.CaptureSandboxTrafficFunc = nil
+
+	_, err = m.CaptureSandboxTraffic(ctx, testSandboxID, "eth0", time.Second)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockGetLaunchMeasurement(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.GetLaunchMeasurementFunc)
+
+	ctx := context.Background()
+	_, err := m.GetLaunchMeasurement(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.GetLaunchMeasurementFunc = func(ctx context.Context, sandboxID string) (vc.LaunchMeasurement, error) {
+		return vc.LaunchMeasurement{Measurement: "measurement"}, nil
+	}
+
+	measurement, err := m.GetLaunchMeasurement(ctx, testSandboxID)
+	assert.NoError(err)
+	assert.Equal("measurement", measurement.Measurement)
+
+	// reset
+	m.GetLaunchMeasurementFunc = nil
+
+	_, err = m.GetLaunchMeasurement(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockGetTDReport(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.GetTDReportFunc)
+
+	ctx := context.Background()
+	_, err := m.GetTDReport(ctx, testSandboxID, []byte("nonce"))
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.GetTDReportFunc = func(ctx context.Context, sandboxID string, reportData []byte) ([]byte, error) {
+		return append([]byte("report:"), reportData...), nil
+	}
+
+	report, err := m.GetTDReport(ctx, testSandboxID, []byte("nonce"))
+	assert.NoError(err)
+	assert.Equal([]byte("report:nonce"), report)
+
+	// reset
+	m.GetTDReportFunc = nil
+
+	_, err = m.GetTDReport(ctx, testSandboxID, []byte("nonce"))
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockGetSandboxConsole(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.GetSandboxConsoleFunc)
+
+	ctx := context.Background()
+	_, err := m.GetSandboxConsole(ctx, testSandboxID)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	console, guest := net.Pipe()
+	defer guest.Close()
+	m.GetSandboxConsoleFunc = func(ctx context.Context, sandboxID string) (io.ReadWriteCloser, error) {
+		return console, nil
+	}
+
+	c, err := m.GetSandboxConsole(ctx, testSandboxID)
+	assert.NoError(err)
+	assert.Equal(console, c)
+
+	// reset
+	m.GetSandboxConsoleFunc = nil
+
+	_, err = m.GetSandboxConsole(ctx, testSandboxID)
 	assert.Error(err)
 	assert.True(IsMockError(err))
 }
@@ -709,6 +1418,60 @@ func TestVCMockAddInterface(t *testing.T) {
 	assert.True(IsMockError(err))
 }
 
+func TestVCMockAddDevice(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	config := &vc.SandboxConfig{}
+	assert.Nil(m.AddDeviceFunc)
+
+	ctx := context.Background()
+	_, err := m.AddDevice(ctx, config.ID, deviceConfig.DeviceInfo{})
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.AddDeviceFunc = func(ctx context.Context, sid string, info deviceConfig.DeviceInfo) (api.Device, error) {
+		return nil, nil
+	}
+
+	_, err = m.AddDevice(ctx, config.ID, deviceConfig.DeviceInfo{})
+	assert.NoError(err)
+
+	// reset
+	m.AddDeviceFunc = nil
+
+	_, err = m.AddDevice(ctx, config.ID, deviceConfig.DeviceInfo{})
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockRemoveDevice(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	config := &vc.SandboxConfig{}
+	assert.Nil(m.RemoveDeviceFunc)
+
+	ctx := context.Background()
+	err := m.RemoveDevice(ctx, config.ID, "foo")
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.RemoveDeviceFunc = func(ctx context.Context, sid, deviceID string) error {
+		return nil
+	}
+
+	err = m.RemoveDevice(ctx, config.ID, "foo")
+	assert.NoError(err)
+
+	// reset
+	m.RemoveDeviceFunc = nil
+
+	err = m.RemoveDevice(ctx, config.ID, "foo")
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
 func TestVCMockRemoveInterface(t *testing.T) {
 	assert := assert.New(t)
 
@@ -816,3 +1579,120 @@ func TestVCMockListRoutes(t *testing.T) {
 	assert.Error(err)
 	assert.True(IsMockError(err))
 }
+
+func TestVCMockDrainAllSandboxes(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.DrainAllSandboxesFunc)
+
+	ctx := context.Background()
+	_, err := m.DrainAllSandboxes(ctx, time.Time{}, vc.DrainPolicy{})
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.DrainAllSandboxesFunc = func(ctx context.Context, deadline time.Time, policy vc.DrainPolicy) ([]vc.DrainResult, error) {
+		return []vc.DrainResult{{SandboxID: testSandboxID, Outcome: vc.DrainStopped}}, nil
+	}
+
+	results, err := m.DrainAllSandboxes(ctx, time.Time{}, vc.DrainPolicy{})
+	assert.NoError(err)
+	assert.Len(results, 1)
+
+	// reset
+	m.DrainAllSandboxesFunc = nil
+
+	_, err = m.DrainAllSandboxes(ctx, time.Time{}, vc.DrainPolicy{})
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockPrefetchAssets(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.PrefetchAssetsFunc)
+
+	ctx := context.Background()
+	_, err := m.PrefetchAssets(ctx, vc.HypervisorConfig{})
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.PrefetchAssetsFunc = func(ctx context.Context, hypervisorConfig vc.HypervisorConfig) ([]vc.PrefetchedAsset, error) {
+		return []vc.PrefetchedAsset{{Path: hypervisorConfig.KernelPath}}, nil
+	}
+
+	assets, err := m.PrefetchAssets(ctx, vc.HypervisorConfig{KernelPath: "/usr/share/kata-containers/vmlinuz"})
+	assert.NoError(err)
+	assert.Equal([]vc.PrefetchedAsset{{Path: "/usr/share/kata-containers/vmlinuz"}}, assets)
+
+	// reset
+	m.PrefetchAssetsFunc = nil
+
+	_, err = m.PrefetchAssets(ctx, vc.HypervisorConfig{})
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockPreallocateTaps(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.PreallocateTapsFunc)
+
+	ctx := context.Background()
+	_, err := m.PreallocateTaps(ctx, "/var/run/netns/function", vc.QemuHypervisor, vc.HypervisorConfig{}, 1)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+
+	m.PreallocateTapsFunc = func(ctx context.Context, netNSPath string, hypervisorType vc.HypervisorType, hypervisorConfig vc.HypervisorConfig, count int) ([]string, error) {
+		return []string{"tap0_kata"}, nil
+	}
+
+	taps, err := m.PreallocateTaps(ctx, "/var/run/netns/function", vc.QemuHypervisor, vc.HypervisorConfig{}, 1)
+	assert.NoError(err)
+	assert.Equal([]string{"tap0_kata"}, taps)
+
+	// reset
+	m.PreallocateTapsFunc = nil
+
+	_, err = m.PreallocateTaps(ctx, "/var/run/netns/function", vc.QemuHypervisor, vc.HypervisorConfig{}, 1)
+	assert.Error(err)
+	assert.True(IsMockError(err))
+}
+
+func TestVCMockSandboxConfigSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.SandboxConfigSchemaFunc)
+
+	ctx := context.Background()
+	assert.Nil(m.SandboxConfigSchema(ctx))
+
+	m.SandboxConfigSchemaFunc = func(ctx context.Context) *vc.ConfigSchema {
+		return &vc.ConfigSchema{Title: "SandboxConfig"}
+	}
+
+	schema := m.SandboxConfigSchema(ctx)
+	assert.NotNil(schema)
+	assert.Equal("SandboxConfig", schema.Title)
+}
+
+func TestVCMockValidateSandboxConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &VCMock{}
+	assert.Nil(m.ValidateSandboxConfigFunc)
+
+	ctx := context.Background()
+	assert.Empty(m.ValidateSandboxConfig(ctx, vc.SandboxConfig{}))
+
+	m.ValidateSandboxConfigFunc = func(ctx context.Context, sandboxConfig vc.SandboxConfig) []*vc.ConfigFieldError {
+		return []*vc.ConfigFieldError{{Field: "ID", Reason: "Missing sandbox ID"}}
+	}
+
+	errs := m.ValidateSandboxConfig(ctx, vc.SandboxConfig{})
+	assert.Len(errs, 1)
+	assert.Equal("ID", errs[0].Field)
+}
@@ -8,6 +8,7 @@ package vcmock
 import (
 	"fmt"
 	"io"
+	"sync"
 	"syscall"
 
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
@@ -16,6 +17,7 @@ import (
 	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // ID implements the VCSandbox function of the same name.
@@ -64,6 +66,11 @@ func (s *Sandbox) GetContainer(containerID string) vc.VCContainer {
 	return &Container{}
 }
 
+// GetConsoleSocket implements the VCSandbox function of the same name.
+func (s *Sandbox) GetConsoleSocket() (string, error) {
+	return "", nil
+}
+
 // Release implements the VCSandbox function of the same name.
 func (s *Sandbox) Release() error {
 	return nil
@@ -76,16 +83,41 @@ func (s *Sandbox) Start() error {
 
 // Stop implements the VCSandbox function of the same name.
 func (s *Sandbox) Stop(force bool) error {
+	if s.StopFunc != nil {
+		return s.StopFunc(force)
+	}
 	return nil
 }
 
 // Pause implements the VCSandbox function of the same name.
 func (s *Sandbox) Pause() error {
+	if s.PauseFunc != nil {
+		return s.PauseFunc()
+	}
 	return nil
 }
 
 // Resume implements the VCSandbox function of the same name.
 func (s *Sandbox) Resume() error {
+	if s.ResumeFunc != nil {
+		return s.ResumeFunc()
+	}
+	return nil
+}
+
+// SuspendToRAM implements the VCSandbox function of the same name.
+func (s *Sandbox) SuspendToRAM() error {
+	if s.SuspendToRAMFunc != nil {
+		return s.SuspendToRAMFunc()
+	}
+	return nil
+}
+
+// ResumeFromRAM implements the VCSandbox function of the same name.
+func (s *Sandbox) ResumeFromRAM() error {
+	if s.ResumeFromRAMFunc != nil {
+		return s.ResumeFromRAMFunc()
+	}
 	return nil
 }
 
@@ -145,6 +177,22 @@ func (s *Sandbox) ResumeContainer(contID string) error {
 	return nil
 }
 
+// CheckpointContainer implements the VCSandbox function of the same name.
+func (s *Sandbox) CheckpointContainer(contID string, opts vc.CheckpointOptions) error {
+	if s.CheckpointContainerFunc != nil {
+		return s.CheckpointContainerFunc(contID, opts)
+	}
+	return nil
+}
+
+// RestoreContainer implements the VCSandbox function of the same name.
+func (s *Sandbox) RestoreContainer(contID string, opts vc.CheckpointOptions) error {
+	if s.RestoreContainerFunc != nil {
+		return s.RestoreContainerFunc(contID, opts)
+	}
+	return nil
+}
+
 // Status implements the VCSandbox function of the same name.
 func (s *Sandbox) Status() vc.SandboxStatus {
 	return vc.SandboxStatus{}
@@ -160,6 +208,11 @@ func (s *Sandbox) Monitor() (chan error, error) {
 	return nil, nil
 }
 
+// Reconcile implements the VCSandbox function of the same name.
+func (s *Sandbox) Reconcile() error {
+	return nil
+}
+
 // UpdateContainer implements the VCSandbox function of the same name.
 func (s *Sandbox) UpdateContainer(containerID string, resources specs.LinuxResources) error {
 	return nil
@@ -195,6 +248,11 @@ func (s *Sandbox) AddDevice(info config.DeviceInfo) (api.Device, error) {
 	return nil, nil
 }
 
+// RemoveDevice removes a device from sandbox
+func (s *Sandbox) RemoveDevice(deviceID string) error {
+	return nil
+}
+
 // AddInterface implements the VCSandbox function of the same name.
 func (s *Sandbox) AddInterface(inf *vcTypes.Interface) (*vcTypes.Interface, error) {
 	return nil, nil
@@ -240,6 +298,14 @@ func (s *Sandbox) GetAgentMetrics() (string, error) {
 	return "", nil
 }
 
+// MetricsCollector implements the VCSandbox function of the same name.
+func (s *Sandbox) MetricsCollector() prometheus.Collector {
+	if s.MetricsCollectorFunc != nil {
+		return s.MetricsCollectorFunc()
+	}
+	return nil
+}
+
 // Stats implements the VCSandbox function of the same name.
 func (s *Sandbox) Stats() (vc.SandboxStats, error) {
 	if s.StatsFunc != nil {
@@ -247,3 +313,55 @@ func (s *Sandbox) Stats() (vc.SandboxStats, error) {
 	}
 	return vc.SandboxStats{}, nil
 }
+
+// EndpointStats implements the VCSandbox function of the same name.
+func (s *Sandbox) EndpointStats() ([]vc.EndpointStats, error) {
+	if s.EndpointStatsFunc != nil {
+		return s.EndpointStatsFunc()
+	}
+	return nil, nil
+}
+
+// NetworkIncompatibilities implements the VCSandbox function of the same name.
+func (s *Sandbox) NetworkIncompatibilities() ([]vc.NetworkIncompatibility, error) {
+	if s.NetworkIncompatibilitiesFunc != nil {
+		return s.NetworkIncompatibilitiesFunc()
+	}
+	return nil, nil
+}
+
+// ProfileGuest implements the VCSandbox function of the same name.
+func (s *Sandbox) ProfileGuest(req vc.ProfileRequest) (io.ReadCloser, error) {
+	if s.ProfileGuestFunc != nil {
+		return s.ProfileGuestFunc(req)
+	}
+	return nil, fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
+// UpgradeVirtiofsd implements the VCSandbox function of the same name.
+func (s *Sandbox) UpgradeVirtiofsd() error {
+	if s.UpgradeVirtiofsdFunc != nil {
+		return s.UpgradeVirtiofsdFunc()
+	}
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
+// LiveUpdate implements the VCSandbox function of the same name.
+func (s *Sandbox) LiveUpdate() error {
+	if s.LiveUpdateFunc != nil {
+		return s.LiveUpdateFunc()
+	}
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
+// AttachConsole implements the VCSandbox function of the same name.
+func (s *Sandbox) AttachConsole() (io.ReadWriteCloser, error) {
+	if s.AttachConsoleFunc != nil {
+		return s.AttachConsoleFunc()
+	}
+	return nil, fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
+// EnableDeferredShrinks implements the VCSandbox function of the same name.
+func (s *Sandbox) EnableDeferredShrinks(lock sync.Locker) {
+}
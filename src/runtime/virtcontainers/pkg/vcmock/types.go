@@ -9,6 +9,7 @@ import (
 	"context"
 	"io"
 	"syscall"
+	"time"
 
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
@@ -16,6 +17,7 @@ import (
 	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 )
 
@@ -49,6 +51,8 @@ type Sandbox struct {
 	StatsContainerFunc       func(contID string) (vc.ContainerStats, error)
 	PauseContainerFunc       func(contID string) error
 	ResumeContainerFunc      func(contID string) error
+	SuspendToRAMFunc         func() error
+	ResumeFromRAMFunc        func() error
 	StatusFunc               func() vc.SandboxStatus
 	EnterContainerFunc       func(containerID string, cmd types.Cmd) (vc.VCContainer, *vc.Process, error)
 	MonitorFunc              func() (chan error, error)
@@ -66,7 +70,17 @@ type Sandbox struct {
 	ListRoutesFunc           func() ([]*vcTypes.Route, error)
 	UpdateRuntimeMetricsFunc func() error
 	GetAgentMetricsFunc      func() (string, error)
+	MetricsCollectorFunc     func() prometheus.Collector
 	StatsFunc                func() (vc.SandboxStats, error)
+	ProfileGuestFunc         func(req vc.ProfileRequest) (io.ReadCloser, error)
+	CheckpointContainerFunc  func(contID string, opts vc.CheckpointOptions) error
+	RestoreContainerFunc     func(contID string, opts vc.CheckpointOptions) error
+	EndpointStatsFunc        func() ([]vc.EndpointStats, error)
+	UpgradeVirtiofsdFunc     func() error
+	LiveUpdateFunc           func() error
+	AttachConsoleFunc        func() (io.ReadWriteCloser, error)
+
+	NetworkIncompatibilitiesFunc func() ([]vc.NetworkIncompatibility, error)
 }
 
 // Container is a fake Container type used for testing
@@ -109,7 +123,8 @@ type VCMock struct {
 	PauseContainerFunc       func(ctx context.Context, sandboxID, containerID string) error
 	ResumeContainerFunc      func(ctx context.Context, sandboxID, containerID string) error
 
-	AddDeviceFunc func(ctx context.Context, sandboxID string, info config.DeviceInfo) (api.Device, error)
+	AddDeviceFunc    func(ctx context.Context, sandboxID string, info config.DeviceInfo) (api.Device, error)
+	RemoveDeviceFunc func(ctx context.Context, sandboxID, deviceID string) error
 
 	AddInterfaceFunc     func(ctx context.Context, sandboxID string, inf *vcTypes.Interface) (*vcTypes.Interface, error)
 	RemoveInterfaceFunc  func(ctx context.Context, sandboxID string, inf *vcTypes.Interface) (*vcTypes.Interface, error)
@@ -117,4 +132,49 @@ type VCMock struct {
 	UpdateRoutesFunc     func(ctx context.Context, sandboxID string, routes []*vcTypes.Route) ([]*vcTypes.Route, error)
 	ListRoutesFunc       func(ctx context.Context, sandboxID string) ([]*vcTypes.Route, error)
 	CleanupContainerFunc func(ctx context.Context, sandboxID, containerID string, force bool) error
+
+	ExportSandboxStateFunc  func(ctx context.Context, sandboxID string, w io.Writer) error
+	CheckDeviceTopologyFunc func(ctx context.Context, devices []config.DeviceInfo) (config.DeviceTopology, error)
+	PrefetchAssetsFunc      func(ctx context.Context, hypervisorConfig vc.HypervisorConfig) ([]vc.PrefetchedAsset, error)
+	PreallocateTapsFunc     func(ctx context.Context, netNSPath string, hypervisorType vc.HypervisorType, hypervisorConfig vc.HypervisorConfig, count int) ([]string, error)
+	DrainAllSandboxesFunc   func(ctx context.Context, deadline time.Time, policy vc.DrainPolicy) ([]vc.DrainResult, error)
+
+	SandboxConfigSchemaFunc   func(ctx context.Context) *vc.ConfigSchema
+	ValidateSandboxConfigFunc func(ctx context.Context, sandboxConfig vc.SandboxConfig) []*vc.ConfigFieldError
+
+	CloneSandboxFunc         func(ctx context.Context, sourceID string, sandboxConfig vc.SandboxConfig) (vc.VCSandbox, error)
+	CloneSandboxSnapshotFunc func(ctx context.Context, sourceID, snapshot string, sandboxConfig vc.SandboxConfig) (vc.VCSandbox, error)
+	ListSandboxSnapshotsFunc func(ctx context.Context, sandboxID string) ([]vc.SnapshotInfo, error)
+	SnapshotSandboxFunc      func(ctx context.Context, sandboxID, destPath string) error
+	RestoreSandboxFunc       func(ctx context.Context, snapshotPath string) (vc.VCSandbox, error)
+	MigrateSandboxFunc       func(ctx context.Context, sandboxID, destination string) error
+	ReceiveSandboxFunc       func(ctx context.Context, listenAddr string) (vc.VCSandbox, error)
+
+	ListSandboxesByHypervisorVersionFunc func(ctx context.Context, version string) ([]vc.SandboxStatus, error)
+	ListSandboxesWithFilterFunc          func(ctx context.Context, filter vc.ListFilter) ([]vc.SandboxSummary, error)
+
+	LivepatchSandboxFunc func(ctx context.Context, sandboxID, module string) error
+
+	PauseSandboxFunc         func(ctx context.Context, sandboxID string) error
+	ResumeSandboxFunc        func(ctx context.Context, sandboxID string) error
+	SuspendSandboxToRAMFunc  func(ctx context.Context, sandboxID string) error
+	ResumeSandboxFromRAMFunc func(ctx context.Context, sandboxID string) error
+
+	CopyFileToContainerFunc   func(ctx context.Context, sandboxID, containerID, hostPath, guestPath string) error
+	CopyFileFromContainerFunc func(ctx context.Context, sandboxID, containerID, guestPath, hostPath string) error
+
+	CheckpointContainerFunc func(ctx context.Context, sandboxID, containerID string, opts vc.CheckpointOptions) error
+	RestoreContainerFunc    func(ctx context.Context, sandboxID, containerID string, opts vc.CheckpointOptions) error
+
+	RestartContainerFunc func(ctx context.Context, sandboxID, containerID string) (vc.VCContainer, error)
+
+	ResizeSandboxMemoryFunc  func(ctx context.Context, sandboxID string, targetMB uint32) error
+	SandboxHostResourcesFunc func(ctx context.Context, sandboxID string) (vc.HostResources, error)
+	WatchSandboxEventsFunc   func(ctx context.Context, sandboxID string) (<-chan vc.SandboxEvent, error)
+
+	CaptureSandboxTrafficFunc func(ctx context.Context, sandboxID, iface string, duration time.Duration) (io.ReadCloser, error)
+
+	GetLaunchMeasurementFunc func(ctx context.Context, sandboxID string) (vc.LaunchMeasurement, error)
+	GetTDReportFunc          func(ctx context.Context, sandboxID string, reportData []byte) ([]byte, error)
+	GetSandboxConsoleFunc    func(ctx context.Context, sandboxID string) (io.ReadWriteCloser, error)
 }
@@ -9,7 +9,6 @@ import (
 	"bufio"
 	"fmt"
 	"io"
-	"net"
 	"path/filepath"
 	"strings"
 
@@ -22,7 +21,7 @@ var buildinProxyConsoleProto = consoleProtoUnix
 
 type proxyBuiltin struct {
 	sandboxID string
-	conn      net.Conn
+	console   io.ReadWriteCloser
 }
 
 // ProxyConfig is a structure storing information needed from any
@@ -181,12 +180,14 @@ type proxy interface {
 func (p *proxyBuiltin) watchConsole(proto, console string, logger *logrus.Entry) (err error) {
 	var (
 		scanner *bufio.Scanner
-		conn    net.Conn
+		conn    io.ReadWriteCloser
 	)
 
 	switch proto {
 	case consoleProtoUnix:
-		conn, err = net.Dial("unix", console)
+		// The console is shared with the other clients of the
+		// sandbox console.
+		conn, err = attachConsole(p.sandboxID, console)
 		if err != nil {
 			return err
 		}
@@ -198,7 +199,7 @@ func (p *proxyBuiltin) watchConsole(proto, console string, logger *logrus.Entry)
 		return fmt.Errorf("unknown console proto %s", proto)
 	}
 
-	p.conn = conn
+	p.console = conn
 
 	go func() {
 		scanner = bufio.NewScanner(conn)
@@ -210,7 +211,7 @@ func (p *proxyBuiltin) watchConsole(proto, console string, logger *logrus.Entry)
 		}
 
 		if err := scanner.Err(); err != nil {
-			if err == io.EOF {
+			if err == io.EOF || err == io.ErrClosedPipe {
 				logger.Info("console watcher quits")
 			} else {
 				logger.WithError(err).WithFields(logrus.Fields{
@@ -226,7 +227,7 @@ func (p *proxyBuiltin) watchConsole(proto, console string, logger *logrus.Entry)
 
 // check if the proxy has watched the vm console.
 func (p *proxyBuiltin) consoleWatched() bool {
-	return p.conn != nil
+	return p.console != nil
 }
 
 // start is the proxy start implementation for builtin proxy.
@@ -261,9 +262,9 @@ func (p *proxyBuiltin) start(params proxyParams) (int, string, error) {
 
 // stop is the proxy stop implementation for builtin proxy.
 func (p *proxyBuiltin) stop(pid int) error {
-	if p.conn != nil {
-		p.conn.Close()
-		p.conn = nil
+	if p.console != nil {
+		p.console.Close()
+		p.console = nil
 		p.sandboxID = ""
 	}
 	return nil
@@ -13,9 +13,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math"
-	"net"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -32,6 +30,7 @@ import (
 	"golang.org/x/sys/unix"
 
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/drivers"
 	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/uuid"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
@@ -48,6 +47,13 @@ const romFile = ""
 // Default value is false.
 const defaultDisableModern = false
 
+// gpuDirectClique is the NVIDIA GPUDirect clique of all the GPUs of the VM.
+const gpuDirectClique = "0"
+
+// firmwareVarsFile is the file name of the firmware variables of a sandbox,
+// in the sandbox storage directory.
+const firmwareVarsFile = "firmware-vars.fd"
+
 type qmpChannel struct {
 	sync.Mutex
 	ctx     context.Context
@@ -74,6 +80,128 @@ type QemuState struct {
 	PCIeRootPort         int
 }
 
+// prManagerHelper is the QEMU object forwarding the persistent reservation
+// commands of the passthrough SCSI devices to the qemu-pr-helper daemon,
+// which has the privileges to send them to the host devices.
+type prManagerHelper struct {
+	ID   string
+	Path string
+}
+
+// Valid returns true if the object has an ID and a socket path.
+func (p prManagerHelper) Valid() bool {
+	return p.ID != "" && p.Path != ""
+}
+
+// QemuParams returns the qemu parameters adding the object.
+func (p prManagerHelper) QemuParams(config *govmmQemu.Config) []string {
+	return []string{"-object", fmt.Sprintf("pr-manager-helper,id=%s,path=%s", p.ID, p.Path)}
+}
+
+// reportingBalloon is a memory balloon the guest reports its free pages
+// to, QEMU discarding them (QEMU 5.1 and later).
+type reportingBalloon struct {
+	govmmQemu.BalloonDevice
+}
+
+// QemuParams returns the qemu parameters of the balloon, with free page
+// reporting enabled.
+func (b reportingBalloon) QemuParams(config *govmmQemu.Config) []string {
+	params := b.BalloonDevice.QemuParams(config)
+	params[len(params)-1] += ",free-page-reporting=on"
+
+	return params
+}
+
+// gpuDirectVFIODevice is an NVIDIA GPU passed through in a GPUDirect
+// clique, the GPUs of a clique being able to DMA to each other.
+type gpuDirectVFIODevice struct {
+	govmmQemu.VFIODevice
+	Clique string
+}
+
+// QemuParams returns the qemu parameters of the device, in its clique.
+func (d gpuDirectVFIODevice) QemuParams(config *govmmQemu.Config) []string {
+	params := d.VFIODevice.QemuParams(config)
+	params[len(params)-1] += ",x-nv-gpudirect-clique=" + d.Clique
+
+	return params
+}
+
+// reconnectingVhostUserDevice is a vhost-user device connecting again to
+// its backend when the backend restarts.
+type reconnectingVhostUserDevice struct {
+	govmmQemu.VhostUserDevice
+
+	// Reconnect is the number of seconds between the attempts to
+	// connect again to the backend.
+	Reconnect uint32
+}
+
+// QemuParams returns the qemu parameters of the device, its character
+// device connecting again to the backend.
+func (d reconnectingVhostUserDevice) QemuParams(config *govmmQemu.Config) []string {
+	params := d.VhostUserDevice.QemuParams(config)
+	for i := range params {
+		if params[i] == "-chardev" && i+1 < len(params) {
+			params[i+1] += fmt.Sprintf(",reconnect=%d", d.Reconnect)
+			break
+		}
+	}
+
+	return params
+}
+
+// cachedBlockDevice is a block device whose drive has a cache mode other
+// than the QEMU default one.
+type cachedBlockDevice struct {
+	govmmQemu.BlockDevice
+
+	// Cache is the cache mode of the drive, e.g. none or writethrough.
+	Cache string
+}
+
+// QemuParams returns the qemu parameters of the device, its drive having
+// the cache mode.
+func (d cachedBlockDevice) QemuParams(config *govmmQemu.Config) []string {
+	params := d.BlockDevice.QemuParams(config)
+	for i := range params {
+		if params[i] == "-drive" && i+1 < len(params) {
+			params[i+1] += fmt.Sprintf(",cache=%s", d.Cache)
+			break
+		}
+	}
+
+	return params
+}
+
+// pflashDrive is a flash image of the firmware, used instead of the BIOS.
+// The firmware code is on the first unit, its variables on the next one.
+type pflashDrive struct {
+	Unit int
+
+	// File is the path of the image.
+	File string
+
+	// ReadOnly prevents the guest from writing to the image.
+	ReadOnly bool
+}
+
+// Valid returns true if the drive has an image.
+func (d pflashDrive) Valid() bool {
+	return d.File != "" && d.Unit >= 0
+}
+
+// QemuParams returns the qemu parameters adding the drive.
+func (d pflashDrive) QemuParams(config *govmmQemu.Config) []string {
+	drive := fmt.Sprintf("if=pflash,format=raw,unit=%d,file=%s", d.Unit, d.File)
+	if d.ReadOnly {
+		drive += ",readonly=on"
+	}
+
+	return []string{"-drive", drive}
+}
+
 // qemu is an Hypervisor interface implementation for the Linux qemu hypervisor.
 type qemu struct {
 	id string
@@ -99,6 +227,13 @@ type qemu struct {
 	stopped bool
 
 	store persistapi.PersistDriver
+
+	// virtiofsd supervises the daemon of the sandbox shared directory
+	virtiofsd *virtiofsdSupervisor
+
+	// virtiofsShares supervises the daemons of the mounts shared through
+	// virtio-fs devices of their own, by device ID.
+	virtiofsShares map[string]*virtiofsdSupervisor
 }
 
 const (
@@ -106,10 +241,29 @@ const (
 	qmpSocket     = "qmp.sock"
 	vhostFSSocket = "vhost-fs.sock"
 
+	// virtiofsdReconnect is the number of seconds between the attempts of
+	// QEMU to connect again to a restarted virtio-fs daemon.
+	virtiofsdReconnect = 1
+
 	qmpCapErrMsg  = "Failed to negoatiate QMP capabilities"
 	qmpExecCatCmd = "exec:cat"
 
+	// snapshotStateFile is the file, in the snapshot directory, the VM
+	// state is migrated to.
+	snapshotStateFile = "vmstate"
+
+	// qmpSnapshotWaitTimeout is how long the migration of the whole
+	// memory of a VM to or from a snapshot may take.
+	qmpSnapshotWaitTimeout = 5 * time.Minute
+
+	// qmpLiveMigrationWaitTimeout is how long the live migration of a VM
+	// to another host may take, its memory being sent again as long as
+	// the guest dirties it.
+	qmpLiveMigrationWaitTimeout = 10 * time.Minute
+
 	scsiControllerID         = "scsi0"
+	prManagerHelperID        = "pr-helper0"
+	balloonID                = "balloon0"
 	rngID                    = "rng0"
 	vsockKernelOption        = "agent.use_vsock"
 	fallbackFileBackedMemDir = "/dev/shm"
@@ -174,6 +328,8 @@ func (q *qemu) kernelParameters() string {
 	// params are added here, they will take priority over the defaults.
 	params = append(params, q.config.KernelParams...)
 
+	params = q.config.appendPayloadParams(params)
+
 	paramsStr := SerializeParams(params, "=")
 
 	return strings.Join(paramsStr, " ")
@@ -184,7 +340,34 @@ func (q *qemu) capabilities() types.Capabilities {
 	span, _ := q.trace("capabilities")
 	defer span.Finish()
 
-	return q.arch.capabilities()
+	caps := q.arch.capabilities()
+	if caps.IsBlockDeviceHotplugSupported() {
+		caps.SetBlockDeviceHotUnplugSupport()
+	}
+	caps.SetVFIOHotplugSupport()
+	caps.SetBlockDeviceCacheSupport()
+	caps.SetSandboxPauseSupport()
+	caps.SetVFIOPeerToPeerSupport()
+	caps.SetSandboxSnapshotSupport()
+	caps.SetSandboxMigrationSupport()
+	caps.SetSandboxLiveUpdateSupport()
+	if q.config.SharedFS == config.NoSharedFS {
+		caps.UnsetFsSharingSupport()
+	}
+	if q.config.VirtioMem {
+		// The guest gives back the memory a virtio-mem device unplugs
+		caps.SetMemoryHotUnplugSupport()
+	}
+	if q.config.EnableGuestSuspend {
+		// The VM does not start if the machine type cannot suspend
+		caps.SetGuestSuspendSupport()
+	}
+	if q.config.SharedFS == config.VirtioFS {
+		// The memory of the VM is shared with the virtio-fs daemons
+		caps.SetVirtioFSHotplugSupport()
+	}
+
+	return caps
 }
 
 func (q *qemu) hypervisorConfig() HypervisorConfig {
@@ -342,6 +525,14 @@ func (q *qemu) getQemuMachine() (govmmQemu.Machine, error) {
 		machine.Options += accelerators
 	}
 
+	switch q.config.MemoryEncryption {
+	case MemoryEncryptionSEV, MemoryEncryptionSNP:
+		machine.Options += ",memory-encryption=" + sevObjectID
+	case MemoryEncryptionTDX:
+		// The TDX module emulates the interrupt controller of the guest
+		machine.Options += ",kvm-type=tdx,confidential-guest-support=" + tdxObjectID + ",kernel_irqchip=split"
+	}
+
 	return machine, nil
 }
 
@@ -372,6 +563,11 @@ func (q *qemu) createQmpSocket() ([]govmmQemu.QMPSocket, error) {
 		path: monitorSockPath,
 	}
 
+	commandSockPath, err := q.qmpCommandSocketPath()
+	if err != nil {
+		return nil, err
+	}
+
 	return []govmmQemu.QMPSocket{
 		{
 			Type:   "unix",
@@ -379,6 +575,12 @@ func (q *qemu) createQmpSocket() ([]govmmQemu.QMPSocket, error) {
 			Server: true,
 			NoWait: true,
 		},
+		{
+			Type:   "unix",
+			Name:   commandSockPath,
+			Server: true,
+			NoWait: true,
+		},
 	}, nil
 }
 
@@ -413,6 +615,29 @@ func (q *qemu) buildDevices(initrdPath string) ([]govmmQemu.Device, *govmmQemu.I
 		}
 	}
 
+	if q.config.EnableGuestSuspend {
+		devices, err = q.arch.appendGuestSuspend(devices)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// The balloon is never inflated, the guest memory being resized
+	// with hotplug.
+	if q.config.FreePageReporting {
+		devices = append(devices, reportingBalloon{govmmQemu.BalloonDevice{
+			ID:           balloonID,
+			DeflateOnOOM: true,
+		}})
+	}
+
+	if q.config.PRHelperSocket != "" {
+		devices = append(devices, prManagerHelper{
+			ID:   prManagerHelperID,
+			Path: q.config.PRHelperSocket,
+		})
+	}
+
 	var ioThread *govmmQemu.IOThread
 	if q.config.BlockDeviceDriver == config.VirtioSCSI {
 		return q.arch.appendSCSIController(devices, q.config.EnableIOThreads)
@@ -470,6 +695,12 @@ func (q *qemu) createSandbox(ctx context.Context, id string, networkNS NetworkNa
 		return err
 	}
 
+	if q.config.MemoryEncryption != MemoryEncryptionNone {
+		if err := checkHostMemoryEncryption(q.config.MemoryEncryption); err != nil {
+			return err
+		}
+	}
+
 	machine, err := q.getQemuMachine()
 	if err != nil {
 		return err
@@ -482,6 +713,17 @@ func (q *qemu) createSandbox(ctx context.Context, id string, networkNS NetworkNa
 		return err
 	}
 
+	// The memory of a VM with NUMA nodes is given by its nodes, rather
+	// than by govmm.
+	var numaMemory qemuGuestNUMAMemory
+	if len(q.config.GuestNUMANodes) > 0 {
+		numaMemory.Size = memory.Size
+		if numaMemory.Nodes, err = q.guestNUMANodes(machine, smp); err != nil {
+			return err
+		}
+		memory.Size = ""
+	}
+
 	knobs := govmmQemu.Knobs{
 		NoUserConfig: true,
 		NoDefaults:   true,
@@ -511,6 +753,12 @@ func (q *qemu) createSandbox(ctx context.Context, id string, networkNS NetworkNa
 
 	incoming := q.setupTemplate(&knobs, &memory)
 
+	// The state of a restored or migrated VM is loaded once QEMU is
+	// started.
+	if q.config.SnapshotPath != "" || q.config.IncomingMigrationURI != "" {
+		incoming.MigrationType = govmmQemu.MigrationDefer
+	}
+
 	// With the current implementations, VM templating will not work with file
 	// based memory (stand-alone) or virtiofs. This is because VM templating
 	// builds the first VM with file-backed memory and shared=on and the
@@ -590,6 +838,18 @@ func (q *qemu) createSandbox(ctx context.Context, id string, networkNS NetworkNa
 		PidFile:     filepath.Join(q.store.RunVMStoragePath(), q.id, "pid"),
 	}
 
+	if q.config.FirmwareVarsTemplate != "" {
+		varsPath, err := q.firmwareVars()
+		if err != nil {
+			return err
+		}
+
+		qemuConfig.Bios = ""
+		qemuConfig.Devices = append(qemuConfig.Devices,
+			pflashDrive{Unit: 0, File: firmwarePath, ReadOnly: true},
+			pflashDrive{Unit: 1, File: varsPath})
+	}
+
 	if ioThread != nil {
 		qemuConfig.IOThreads = []govmmQemu.IOThread{*ioThread}
 	}
@@ -610,25 +870,75 @@ func (q *qemu) createSandbox(ctx context.Context, id string, networkNS NetworkNa
 		qemuConfig.Devices = q.arch.appendPCIeRootPortDevice(qemuConfig.Devices, hypervisorConfig.PCIeRootPort)
 	}
 
+	if numaMemory.Valid() {
+		qemuConfig.Devices = append(qemuConfig.Devices, numaMemory)
+	}
+	qemuConfig.Devices = appendGuestNUMABridges(qemuConfig.Devices, q.config.GuestNUMANodes)
+
+	qemuConfig.Devices = appendQemuSandbox(qemuConfig.Devices, q.config.VMMSeccomp)
+
+	if q.config.MemoryEncryption != MemoryEncryptionNone {
+		// The devices cannot access the encrypted guest memory, the guest
+		// bounces their buffers through memory it shares with the host.
+		qemuConfig.Devices = append(qemuConfig.Devices, qemuMemoryEncryption(&q.config), virtioIOMMUPlatform{})
+	}
+
 	q.qemuConfig = qemuConfig
 
 	return nil
 }
 
+// firmwareVars returns the path of the firmware variables of the sandbox,
+// copied from their template the first time the VM of the sandbox boots.
+// They live in the sandbox storage directory rather than in the VM one, so
+// that the changes of the guest, such as secure boot enrollments or the
+// boot order, outlive the VM until the sandbox is deleted.
+func (q *qemu) firmwareVars() (string, error) {
+	path := filepath.Join(q.store.RunStoragePath(), q.id, firmwareVarsFile)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), DirMode); err != nil {
+		return "", err
+	}
+
+	// Copy then rename, so that an interrupted copy is not taken for the
+	// variables of the sandbox.
+	tmpPath := path + ".tmp"
+	if err := utils.FileCopy(q.config.FirmwareVarsTemplate, tmpPath); err != nil {
+		return "", fmt.Errorf("failed to copy the firmware variables template %s: %v", q.config.FirmwareVarsTemplate, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return "", err
+	}
+
+	q.Logger().WithField("path", path).Info("Created the firmware variables of the sandbox")
+
+	return path, nil
+}
+
 func (q *qemu) vhostFSSocketPath(id string) (string, error) {
 	return utils.BuildSocketPath(q.store.RunVMStoragePath(), id, vhostFSSocket)
 }
 
 func (q *qemu) virtiofsdArgs(fd uintptr) []string {
-	// The daemon will terminate when the vhost-user socket
-	// connection with QEMU closes.  Therefore we do not keep track
-	// of this child process after returning from this function.
 	sourcePath := filepath.Join(getSharePath(q.id))
+	return q.virtiofsdShareArgs(fd, sourcePath, q.config.VirtioFSCache, false)
+}
+
+func (q *qemu) virtiofsdShareArgs(fd uintptr, sourcePath, cache string, xattr bool) []string {
 	args := []string{
 		fmt.Sprintf("--fd=%v", fd),
 		"-o", "source=" + sourcePath,
-		"-o", "cache=" + q.config.VirtioFSCache,
+		"-o", "cache=" + cache,
 		"--syslog", "-o", "no_posix_lock"}
+	if xattr {
+		args = append(args, "-o", "xattr")
+	}
 	if q.config.Debug {
 		args = append(args, "-d")
 	} else {
@@ -641,58 +951,82 @@ func (q *qemu) virtiofsdArgs(fd uintptr) []string {
 	return args
 }
 
-func (q *qemu) setupVirtiofsd() (err error) {
-	var listener *net.UnixListener
-	var fd *os.File
+// newVirtiofsd returns the supervisor of a virtio-fs daemon serving QEMU on
+// sockPath. The daemon terminates when the vhost-user socket connection with
+// QEMU closes, and is only restarted on its socket when QEMU reconnects to it.
+func (q *qemu) newVirtiofsd(sockPath string, args func(fd uintptr) []string) *virtiofsdSupervisor {
+	return &virtiofsdSupervisor{
+		path:        q.config.VirtioFSDaemon,
+		socketPath:  sockPath,
+		args:        args,
+		maxRestarts: q.config.VirtioFSDaemonRestarts,
+		logger:      q.Logger().WithField("socket", sockPath),
+	}
+}
 
+func (q *qemu) setupVirtiofsd() error {
 	sockPath, err := q.vhostFSSocketPath(q.id)
 	if err != nil {
 		return err
 	}
 
-	listener, err = net.ListenUnix("unix", &net.UnixAddr{
-		Name: sockPath,
-		Net:  "unix",
-	})
-	if err != nil {
-		return err
+	q.virtiofsd = q.newVirtiofsd(sockPath, q.virtiofsdArgs)
+	q.virtiofsd.onStart = func(pid int) {
+		q.state.VirtiofsdPid = pid
+	}
+	// Stop the sandbox if virtiofsd quits for good
+	q.virtiofsd.onExit = func() {
+		q.stopSandbox()
 	}
-	listener.SetUnlinkOnClose(false)
 
-	fd, err = listener.File()
-	listener.Close() // no longer needed since fd is a dup
-	listener = nil
-	if err != nil {
-		return err
+	return q.virtiofsd.start()
+}
+
+// upgradeVirtiofsd hands the shared directory and the virtio-fs shares over
+// to daemons started anew, one at a time. QEMU only reconnects to them if
+// the daemons are restarted on crash.
+func (q *qemu) upgradeVirtiofsd() error {
+	span, _ := q.trace("upgradeVirtiofsd")
+	defer span.Finish()
+
+	if q.virtiofsd == nil && len(q.virtiofsShares) == 0 {
+		return errors.New("No virtiofsd to upgrade")
 	}
-	defer fd.Close()
 
-	const sockFd = 3 // Cmd.ExtraFiles[] fds are numbered starting from 3
-	cmd := exec.Command(q.config.VirtioFSDaemon, q.virtiofsdArgs(sockFd)...)
-	cmd.ExtraFiles = append(cmd.ExtraFiles, fd)
-	stderr, err := cmd.StderrPipe()
-	if err != nil {
-		return err
+	if q.config.VirtioFSDaemonRestarts == 0 {
+		return errors.New("virtiofsd cannot be upgraded without virtio_fs_daemon_restarts, QEMU does not reconnect to it")
 	}
 
-	err = cmd.Start()
-	if err != nil {
-		return fmt.Errorf("virtiofs daemon %v returned with error: %v", q.config.VirtioFSDaemon, err)
+	if q.virtiofsd != nil {
+		if err := q.virtiofsd.upgrade(); err != nil {
+			return err
+		}
 	}
-	q.state.VirtiofsdPid = cmd.Process.Pid
 
-	// Monitor virtiofsd's stderr and stop sandbox if virtiofsd quits
-	go func() {
-		scanner := bufio.NewScanner(stderr)
-		for scanner.Scan() {
-			q.Logger().WithField("source", "virtiofsd").Info(scanner.Text())
+	for id, daemon := range q.virtiofsShares {
+		if err := daemon.upgrade(); err != nil {
+			return fmt.Errorf("Could not upgrade the virtiofsd of device %s: %v", id, err)
 		}
-		q.Logger().Info("virtiofsd quits")
-		// Wait to release resources of virtiofsd process
-		cmd.Process.Wait()
-		q.stopSandbox()
-	}()
-	return err
+	}
+
+	return nil
+}
+
+// stopVirtiofsd stops the virtio-fs daemons, not to restart them when QEMU
+// quits.
+func (q *qemu) stopVirtiofsd() {
+	if q.virtiofsd != nil {
+		if err := q.virtiofsd.stop(); err != nil {
+			q.Logger().WithError(err).Warn("Could not stop virtiofsd")
+		}
+	}
+
+	for id, daemon := range q.virtiofsShares {
+		if err := daemon.stop(); err != nil {
+			q.Logger().WithError(err).WithField("device", id).Warn("Could not stop virtiofsd")
+		}
+		delete(q.virtiofsShares, id)
+	}
 }
 
 func (q *qemu) getMemArgs() (bool, string, string, error) {
@@ -843,6 +1177,18 @@ func (q *qemu) startSandbox(timeout int) error {
 		}
 	}
 
+	if q.config.SnapshotPath != "" {
+		if err = q.restoreSandbox(); err != nil {
+			return err
+		}
+	}
+
+	if q.config.IncomingMigrationURI != "" {
+		if err = q.startIncomingMigration(); err != nil {
+			return err
+		}
+	}
+
 	if q.config.VirtioMem {
 		err = q.setupVirtioMem()
 	}
@@ -870,6 +1216,53 @@ func (q *qemu) bootFromTemplate() error {
 	return q.waitMigration()
 }
 
+// restoreSandbox loads the state of the VM saved by snapshotSandbox, and
+// resumes it.
+func (q *qemu) restoreSandbox() error {
+	err := q.qmpSetup()
+	if err != nil {
+		return err
+	}
+
+	uri := fmt.Sprintf("%s %s", qmpExecCatCmd, filepath.Join(q.config.SnapshotPath, snapshotStateFile))
+	if err = q.qmpMonitorCh.qmp.ExecuteMigrationIncoming(q.qmpMonitorCh.ctx, uri); err != nil {
+		return err
+	}
+
+	if err = q.waitMigrationTimeout(qmpSnapshotWaitTimeout); err != nil {
+		return err
+	}
+
+	// The VM was paused when it was saved.
+	return q.togglePauseSandbox(false)
+}
+
+// startIncomingMigration has QEMU wait for the state of the migrated VM,
+// which finishIncomingMigration waits for.
+func (q *qemu) startIncomingMigration() error {
+	if err := q.qmpSetup(); err != nil {
+		return err
+	}
+
+	return q.qmpMonitorCh.qmp.ExecuteMigrationIncoming(q.qmpMonitorCh.ctx, q.config.IncomingMigrationURI)
+}
+
+func (q *qemu) finishIncomingMigration() error {
+	span, _ := q.trace("finishIncomingMigration")
+	defer span.Finish()
+
+	if err := q.qmpSetup(); err != nil {
+		return err
+	}
+
+	if err := q.waitMigrationTimeout(qmpLiveMigrationWaitTimeout); err != nil {
+		return err
+	}
+
+	// The source VM stops once its state is sent.
+	return q.togglePauseSandbox(false)
+}
+
 // waitSandbox will wait for the Sandbox's VM to be up and running.
 func (q *qemu) waitSandbox(timeout int) error {
 	span, _ := q.trace("waitSandbox")
@@ -940,6 +1333,8 @@ func (q *qemu) stopSandbox() error {
 		q.stopped = true
 	}()
 
+	q.stopVirtiofsd()
+
 	if q.config.Debug && q.qemuConfig.LogFile != "" {
 		f, err := os.OpenFile(q.qemuConfig.LogFile, os.O_RDONLY, 0)
 		if err == nil {
@@ -1067,6 +1462,47 @@ func (q *qemu) qmpShutdown() {
 	}
 }
 
+// blockCacheOptions returns whether the host page cache is bypassed, and
+// whether the guest sees a volatile write cache, for a block device cache
+// mode. Without a volatile write cache, each write of the guest is flushed
+// before completing.
+func blockCacheOptions(mode string) (direct, writeCache bool) {
+	switch mode {
+	case config.BlockCacheNone:
+		return true, true
+	case config.BlockCacheWritethrough:
+		return false, false
+	case config.BlockCacheDirectSync:
+		return true, false
+	default:
+		return false, true
+	}
+}
+
+// scsiPassthrough returns whether the SCSI disk of drive is passed through,
+// for the guest to manage its persistent reservations through
+// qemu-pr-helper.
+func (q *qemu) scsiPassthrough(drive *config.BlockDrive) bool {
+	return q.config.PRHelperSocket != "" && q.config.BlockDeviceDriver == config.VirtioSCSI && drive.SCSIPassthrough
+}
+
+// blockdevAdd adds the host block device backing drive. The cache mode of
+// the drive overrides the cache options of the hypervisor configuration.
+func (q *qemu) blockdevAdd(drive *config.BlockDrive, scsiPassthrough bool) error {
+	direct, _ := blockCacheOptions(drive.Cache)
+
+	switch {
+	case scsiPassthrough:
+		return q.executeBlockdevAddWithPRManager(drive.File, drive.ID, prManagerHelperID)
+	case drive.Cache != "":
+		return q.qmpMonitorCh.qmp.ExecuteBlockdevAddWithCache(q.qmpMonitorCh.ctx, drive.File, drive.ID, direct, false)
+	case q.config.BlockDeviceCacheSet:
+		return q.qmpMonitorCh.qmp.ExecuteBlockdevAddWithCache(q.qmpMonitorCh.ctx, drive.File, drive.ID, q.config.BlockDeviceCacheDirect, q.config.BlockDeviceCacheNoflush)
+	default:
+		return q.qmpMonitorCh.qmp.ExecuteBlockdevAdd(q.qmpMonitorCh.ctx, drive.File, drive.ID)
+	}
+}
+
 func (q *qemu) hotplugAddBlockDevice(drive *config.BlockDrive, op operation, devID string) (err error) {
 	// drive can be a pmem device, in which case it's used as backing file for a nvdimm device
 	if q.config.BlockDeviceDriver == config.Nvdimm || drive.Pmem {
@@ -1098,12 +1534,10 @@ func (q *qemu) hotplugAddBlockDevice(drive *config.BlockDrive, op operation, dev
 		return nil
 	}
 
-	if q.config.BlockDeviceCacheSet {
-		err = q.qmpMonitorCh.qmp.ExecuteBlockdevAddWithCache(q.qmpMonitorCh.ctx, drive.File, drive.ID, q.config.BlockDeviceCacheDirect, q.config.BlockDeviceCacheNoflush)
-	} else {
-		err = q.qmpMonitorCh.qmp.ExecuteBlockdevAdd(q.qmpMonitorCh.ctx, drive.File, drive.ID)
-	}
-	if err != nil {
+	scsiPassthrough := q.scsiPassthrough(drive)
+	_, writeCache := blockCacheOptions(drive.Cache)
+
+	if err = q.blockdevAdd(drive, scsiPassthrough); err != nil {
 		return err
 	}
 
@@ -1116,6 +1550,9 @@ func (q *qemu) hotplugAddBlockDevice(drive *config.BlockDrive, op operation, dev
 	switch {
 	case q.config.BlockDeviceDriver == config.VirtioBlockCCW:
 		driver := "virtio-blk-ccw"
+		if !writeCache {
+			return fmt.Errorf("Cache mode %s not supported by %s", drive.Cache, driver)
+		}
 
 		addr, bridge, err := q.arch.addDeviceToBridge(drive.ID, types.CCW)
 		if err != nil {
@@ -1149,11 +1586,15 @@ func (q *qemu) hotplugAddBlockDevice(drive *config.BlockDrive, op operation, dev
 		// PCI address is in the format bridge-addr/device-addr eg. "03/02"
 		drive.PCIAddr = fmt.Sprintf("%02x", bridge.Addr) + "/" + addr
 
-		if err = q.qmpMonitorCh.qmp.ExecutePCIDeviceAdd(q.qmpMonitorCh.ctx, drive.ID, devID, driver, addr, bridge.ID, romFile, 0, true, defaultDisableModern); err != nil {
+		drive.Serial = blockDriveSerial(drive.File)
+		if err = q.executePCIDeviceAdd(drive.ID, devID, driver, addr, bridge.ID, drive.Serial, writeCache); err != nil {
 			return err
 		}
 	case q.config.BlockDeviceDriver == config.VirtioSCSI:
 		driver := "scsi-hd"
+		if scsiPassthrough {
+			driver = "scsi-block"
+		}
 
 		// Bus exposed by the SCSI Controller
 		bus := scsiControllerID + ".0"
@@ -1164,7 +1605,12 @@ func (q *qemu) hotplugAddBlockDevice(drive *config.BlockDrive, op operation, dev
 			return err
 		}
 
-		if err = q.qmpMonitorCh.qmp.ExecuteSCSIDeviceAdd(q.qmpMonitorCh.ctx, drive.ID, devID, driver, bus, romFile, scsiID, lun, true, defaultDisableModern); err != nil {
+		// scsi-block passes the serial of the host disk through
+		if !scsiPassthrough {
+			drive.Serial = blockDriveSerial(drive.File)
+		}
+
+		if err = q.executeSCSIDeviceAdd(drive.ID, devID, driver, bus, drive.Serial, writeCache, scsiID, lun); err != nil {
 			return err
 		}
 	default:
@@ -1175,7 +1621,16 @@ func (q *qemu) hotplugAddBlockDevice(drive *config.BlockDrive, op operation, dev
 }
 
 func (q *qemu) hotplugAddVhostUserBlkDevice(vAttr *config.VhostUserDeviceAttrs, op operation, devID string) (err error) {
-	err = q.qmpMonitorCh.qmp.ExecuteCharDevUnixSocketAdd(q.qmpMonitorCh.ctx, vAttr.DevID, vAttr.SocketPath, false, false)
+	if err = waitVhostUserSocket(vAttr.SocketPath, vhostUserSocketTimeout); err != nil {
+		return err
+	}
+
+	if q.config.VhostUserReconnect != 0 {
+		vAttr.Reconnect = q.config.VhostUserReconnect
+		err = q.executeCharDevUnixSocketReconnectAdd(vAttr.DevID, vAttr.SocketPath, vAttr.Reconnect)
+	} else {
+		err = q.qmpMonitorCh.qmp.ExecuteCharDevUnixSocketAdd(q.qmpMonitorCh.ctx, vAttr.DevID, vAttr.SocketPath, false, false)
+	}
 	if err != nil {
 		return err
 	}
@@ -1208,6 +1663,97 @@ func (q *qemu) hotplugAddVhostUserBlkDevice(vAttr *config.VhostUserDeviceAttrs,
 	return nil
 }
 
+func (q *qemu) hotplugAddVhostUserFSDevice(vAttr *config.VhostUserDeviceAttrs, devID string) (err error) {
+	err = q.executeCharDevUnixSocketReconnectAdd(vAttr.DevID, vAttr.SocketPath, vAttr.Reconnect)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			q.qmpMonitorCh.qmp.ExecuteChardevDel(q.qmpMonitorCh.ctx, vAttr.DevID)
+		}
+	}()
+
+	addr, bridge, err := q.arch.addDeviceToBridge(vAttr.DevID, types.PCI)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			q.arch.removeDeviceFromBridge(vAttr.DevID)
+		}
+	}()
+
+	// PCI address is in the format bridge-addr/device-addr eg. "03/02"
+	vAttr.PCIAddr = fmt.Sprintf("%02x", bridge.Addr) + "/" + addr
+
+	return q.executePCIVhostUserFSDevAdd(devID, vAttr.DevID, vAttr.Tag, vAttr.CacheSize, addr, bridge.ID)
+}
+
+// hotplugVirtioFSShare shares a host directory with the guest through a
+// virtio-fs device and daemon of its own, started with the options of the
+// share.
+func (q *qemu) hotplugVirtioFSShare(share *virtioFSShare, op operation) (err error) {
+	vAttr := &config.VhostUserDeviceAttrs{
+		DevID:     share.ID,
+		Type:      config.VhostUserFS,
+		Tag:       share.Tag,
+		CacheSize: share.Options.DAXWindowMB,
+		Cache:     share.Options.Cache,
+	}
+	if q.config.VirtioFSDaemonRestarts != 0 {
+		vAttr.Reconnect = virtiofsdReconnect
+	}
+
+	if op == removeDevice {
+		daemon, ok := q.virtiofsShares[share.ID]
+		if !ok {
+			return fmt.Errorf("Unknown virtio-fs share %s", share.ID)
+		}
+
+		if err := q.hotplugVhostUserDevice(vAttr, removeDevice); err != nil {
+			return err
+		}
+
+		delete(q.virtiofsShares, share.ID)
+		return daemon.stop()
+	}
+
+	if vAttr.SocketPath, err = utils.BuildSocketPath(q.store.RunVMStoragePath(), q.id, "fs-"+share.ID+".sock"); err != nil {
+		return err
+	}
+
+	daemon := q.newVirtiofsd(vAttr.SocketPath, func(fd uintptr) []string {
+		return q.virtiofsdShareArgs(fd, share.Source, share.Options.Cache, share.Options.Xattr)
+	})
+	// The guest only loses the share if its daemon quits for good
+	daemon.onExit = func() {
+		q.Logger().WithField("device", share.ID).Error("virtio-fs share lost")
+	}
+	if err := daemon.start(); err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			daemon.stop()
+		}
+	}()
+
+	if err = q.hotplugVhostUserDevice(vAttr, addDevice); err != nil {
+		return err
+	}
+
+	if q.virtiofsShares == nil {
+		q.virtiofsShares = make(map[string]*virtiofsdSupervisor)
+	}
+	q.virtiofsShares[share.ID] = daemon
+
+	return nil
+}
+
 func (q *qemu) hotplugBlockDevice(drive *config.BlockDrive, op operation) error {
 	err := q.qmpSetup()
 	if err != nil {
@@ -1249,6 +1795,8 @@ func (q *qemu) hotplugVhostUserDevice(vAttr *config.VhostUserDeviceAttrs, op ope
 		switch vAttr.Type {
 		case config.VhostUserBlk:
 			return q.hotplugAddVhostUserBlkDevice(vAttr, op, devID)
+		case config.VhostUserFS:
+			return q.hotplugAddVhostUserFSDevice(vAttr, devID)
 		default:
 			return fmt.Errorf("Incorrect vhost-user device type found")
 		}
@@ -1269,6 +1817,16 @@ func (q *qemu) hotplugVhostUserDevice(vAttr *config.VhostUserDeviceAttrs, op ope
 	return nil
 }
 
+// setGPUDirectClique puts the NVIDIA GPUs in the same GPUDirect clique when
+// peer-to-peer DMA is enabled, for the guest driver to let them DMA to each
+// other. The VFIO devices share the address space of the VM, in which QEMU
+// maps the BARs of the other devices. The vGPUs do not support it.
+func (q *qemu) setGPUDirectClique(device *config.VFIODev) {
+	if q.config.VFIOPeerToPeer && device.Type != config.VFIODeviceMediatedType && drivers.IsNVIDIAGPU(device) {
+		device.GPUDirectClique = gpuDirectClique
+	}
+}
+
 func (q *qemu) hotplugVFIODevice(device *config.VFIODev, op operation) (err error) {
 	err = q.qmpSetup()
 	if err != nil {
@@ -1279,6 +1837,7 @@ func (q *qemu) hotplugVFIODevice(device *config.VFIODev, op operation) (err erro
 	machinneType := q.hypervisorConfig().HypervisorMachineType
 
 	if op == addDevice {
+		q.setGPUDirectClique(device)
 
 		buf, _ := json.Marshal(device)
 		q.Logger().WithFields(logrus.Fields{
@@ -1288,14 +1847,22 @@ func (q *qemu) hotplugVFIODevice(device *config.VFIODev, op operation) (err erro
 			"device-info":              string(buf),
 		}).Info("Start hot-plug VFIO device")
 
+		// A device attached to a guest NUMA node is hotplugged on a root
+		// port of the node.
+		numaRootPort := q.guestNUMARootPort(device)
+		if numaRootPort != "" {
+			device.Bus = numaRootPort
+		}
+
 		// In case HotplugVFIOOnRootBus is true, devices are hotplugged on the root bus
 		// for pc machine type instead of bridge. This is useful for devices that require
 		// a large PCI BAR which is a currently a limitation with PCI bridges.
-		if q.state.HotplugVFIOOnRootBus {
+		if q.state.HotplugVFIOOnRootBus || numaRootPort != "" {
 
 			// In case MachineType is q35, a PCIe device is hotplugged on a PCIe Root Port.
-			switch machinneType {
-			case QemuQ35:
+			switch {
+			case numaRootPort != "":
+			case machinneType == QemuQ35:
 				if device.IsPCIe && q.state.PCIeRootPort <= 0 {
 					q.Logger().WithField("dev-id", device.ID).Warn("VFIO device is a PCIe device. It's recommended to add the PCIe Root Port by setting the pcie_root_port parameter in the configuration for q35")
 					device.Bus = ""
@@ -1306,6 +1873,9 @@ func (q *qemu) hotplugVFIODevice(device *config.VFIODev, op operation) (err erro
 
 			switch device.Type {
 			case config.VFIODeviceNormalType:
+				if device.GPUDirectClique != "" {
+					return q.executePCIVFIODeviceAddWithGPUDirectClique(devID, device.BDF, "", device.Bus, device.GPUDirectClique)
+				}
 				return q.qmpMonitorCh.qmp.ExecuteVFIODeviceAdd(q.qmpMonitorCh.ctx, devID, device.BDF, device.Bus, romFile)
 			case config.VFIODeviceMediatedType:
 				return q.qmpMonitorCh.qmp.ExecutePCIVFIOMediatedDeviceAdd(q.qmpMonitorCh.ctx, devID, device.SysfsDev, "", device.Bus, romFile)
@@ -1325,8 +1895,14 @@ func (q *qemu) hotplugVFIODevice(device *config.VFIODev, op operation) (err erro
 			}
 		}()
 
+		// PCI address is in the format bridge-addr/device-addr eg. "03/02"
+		device.GuestPCIPath = fmt.Sprintf("%02x", bridge.Addr) + "/" + addr
+
 		switch device.Type {
 		case config.VFIODeviceNormalType:
+			if device.GPUDirectClique != "" {
+				return q.executePCIVFIODeviceAddWithGPUDirectClique(devID, device.BDF, addr, bridge.ID, device.GPUDirectClique)
+			}
 			return q.qmpMonitorCh.qmp.ExecutePCIVFIODeviceAdd(q.qmpMonitorCh.ctx, devID, device.BDF, addr, bridge.ID, romFile)
 		case config.VFIODeviceMediatedType:
 			return q.qmpMonitorCh.qmp.ExecutePCIVFIOMediatedDeviceAdd(q.qmpMonitorCh.ctx, devID, device.SysfsDev, addr, bridge.ID, romFile)
@@ -1336,7 +1912,7 @@ func (q *qemu) hotplugVFIODevice(device *config.VFIODev, op operation) (err erro
 	} else {
 		q.Logger().WithField("dev-id", devID).Info("Start hot-unplug VFIO device")
 
-		if !q.state.HotplugVFIOOnRootBus {
+		if !q.state.HotplugVFIOOnRootBus && q.guestNUMARootPort(device) == "" {
 			if err := q.arch.removeDeviceFromBridge(devID); err != nil {
 				return err
 			}
@@ -1424,9 +2000,9 @@ func (q *qemu) hotplugNetDevice(endpoint Endpoint, op operation) (err error) {
 		}
 		if machine.Type == QemuCCWVirtio {
 			devNoHotplug := fmt.Sprintf("fe.%x.%x", bridge.Addr, addr)
-			return q.qmpMonitorCh.qmp.ExecuteNetCCWDeviceAdd(q.qmpMonitorCh.ctx, tap.Name, devID, endpoint.HardwareAddr(), devNoHotplug, int(q.config.NumVCPUs))
+			return q.qmpMonitorCh.qmp.ExecuteNetCCWDeviceAdd(q.qmpMonitorCh.ctx, tap.Name, devID, endpoint.HardwareAddr(), devNoHotplug, networkQueues(q.config))
 		}
-		return q.qmpMonitorCh.qmp.ExecuteNetPCIDeviceAdd(q.qmpMonitorCh.ctx, tap.Name, devID, endpoint.HardwareAddr(), addr, bridge.ID, romFile, int(q.config.NumVCPUs), defaultDisableModern)
+		return q.qmpMonitorCh.qmp.ExecuteNetPCIDeviceAdd(q.qmpMonitorCh.ctx, tap.Name, devID, endpoint.HardwareAddr(), addr, bridge.ID, romFile, networkQueues(q.config), defaultDisableModern)
 
 	}
 
@@ -1464,6 +2040,9 @@ func (q *qemu) hotplugDevice(devInfo interface{}, devType deviceType, op operati
 	case vhostuserDev:
 		vAttr := devInfo.(*config.VhostUserDeviceAttrs)
 		return nil, q.hotplugVhostUserDevice(vAttr, op)
+	case virtioFSShareDev:
+		share := devInfo.(*virtioFSShare)
+		return nil, q.hotplugVirtioFSShare(share, op)
 	default:
 		return nil, fmt.Errorf("cannot hotplug device: unsupported device type '%v'", devType)
 	}
@@ -1535,8 +2114,6 @@ func (q *qemu) hotplugAddCPUs(amount uint32) (uint32, error) {
 		return 0, fmt.Errorf("failed to query hotpluggable CPUs: %v", err)
 	}
 
-	machine := q.arch.machine()
-
 	var hotpluggedVCPUs uint32
 	for _, hc := range hotpluggableVCPUs {
 		// qom-path is the path to the CPU, non-empty means that this CPU is already in use
@@ -1544,22 +2121,8 @@ func (q *qemu) hotplugAddCPUs(amount uint32) (uint32, error) {
 			continue
 		}
 
-		// CPU type, i.e host-x86_64-cpu
-		driver := hc.Type
 		cpuID := fmt.Sprintf("cpu-%d", len(q.state.HotpluggedVCPUs))
-		socketID := fmt.Sprintf("%d", hc.Properties.Socket)
-		dieID := fmt.Sprintf("%d", hc.Properties.Die)
-		coreID := fmt.Sprintf("%d", hc.Properties.Core)
-		threadID := fmt.Sprintf("%d", hc.Properties.Thread)
-
-		// If CPU type is IBM pSeries or Z, we do not set socketID and threadID
-		if machine.Type == "pseries" || machine.Type == "s390-ccw-virtio" {
-			socketID = ""
-			threadID = ""
-			dieID = ""
-		}
-
-		if err := q.qmpMonitorCh.qmp.ExecuteCPUDeviceAdd(q.qmpMonitorCh.ctx, driver, cpuID, socketID, dieID, coreID, threadID, romFile); err != nil {
+		if err := q.addCPUDevice(hc, cpuID); err != nil {
 			// don't fail, let's try with other CPU
 			continue
 		}
@@ -1576,6 +2139,25 @@ func (q *qemu) hotplugAddCPUs(amount uint32) (uint32, error) {
 	return hotpluggedVCPUs, fmt.Errorf("failed to hot add vCPUs: only %d vCPUs of %d were added", hotpluggedVCPUs, amount)
 }
 
+// addCPUDevice adds the vCPU cpuID in the slot of the hc hotpluggable CPU.
+func (q *qemu) addCPUDevice(hc govmmQemu.HotpluggableCPU, cpuID string) error {
+	// CPU type, i.e host-x86_64-cpu
+	driver := hc.Type
+	socketID := fmt.Sprintf("%d", hc.Properties.Socket)
+	dieID := fmt.Sprintf("%d", hc.Properties.Die)
+	coreID := fmt.Sprintf("%d", hc.Properties.Core)
+	threadID := fmt.Sprintf("%d", hc.Properties.Thread)
+
+	// If CPU type is IBM pSeries or Z, we do not set socketID and threadID
+	if machine := q.arch.machine(); machine.Type == "pseries" || machine.Type == "s390-ccw-virtio" {
+		socketID = ""
+		threadID = ""
+		dieID = ""
+	}
+
+	return q.qmpMonitorCh.qmp.ExecuteCPUDeviceAdd(q.qmpMonitorCh.ctx, driver, cpuID, socketID, dieID, coreID, threadID, romFile)
+}
+
 // try to  hot remove an amount of vCPUs, returns the number of vCPUs removed
 func (q *qemu) hotplugRemoveCPUs(amount uint32) (uint32, error) {
 	hotpluggedVCPUs := uint32(len(q.state.HotpluggedVCPUs))
@@ -1739,6 +2321,9 @@ func (q *qemu) addDevice(devInfo interface{}, devType deviceType) error {
 				CacheSize: q.config.VirtioFSCacheSize,
 				Cache:     q.config.VirtioFSCache,
 			}
+			if q.config.VirtioFSDaemonRestarts != 0 {
+				vhostDev.Reconnect = virtiofsdReconnect
+			}
 			vhostDev.SocketPath = sockPath
 			vhostDev.DevID = id
 
@@ -1759,6 +2344,10 @@ func (q *qemu) addDevice(devInfo interface{}, devType deviceType) error {
 	case config.VhostUserDeviceAttrs:
 		q.qemuConfig.Devices, err = q.arch.appendVhostUserDevice(q.qemuConfig.Devices, v)
 	case config.VFIODev:
+		q.setGPUDirectClique(&v)
+		if bus := q.guestNUMARootPort(&v); bus != "" {
+			v.Bus = bus
+		}
 		q.qemuConfig.Devices = q.arch.appendVFIODevice(q.qemuConfig.Devices, v)
 	default:
 		q.Logger().WithField("dev-type", v).Warn("Could not append device: unsupported device type")
@@ -1803,8 +2392,70 @@ func (q *qemu) saveSandbox() error {
 	return q.waitMigration()
 }
 
+func (q *qemu) snapshotSandbox(dir string) error {
+	span, _ := q.trace("snapshotSandbox")
+	defer span.Finish()
+
+	if err := q.checkNotHotplugged(); err != nil {
+		return err
+	}
+
+	if err := q.qmpSetup(); err != nil {
+		return err
+	}
+
+	err := q.qmpMonitorCh.qmp.ExecSetMigrateArguments(q.qmpMonitorCh.ctx, fmt.Sprintf("%s>%s", qmpExecCatCmd, filepath.Join(dir, snapshotStateFile)))
+	if err != nil {
+		q.Logger().WithError(err).Error("exec migration")
+		return err
+	}
+
+	return q.waitMigrationTimeout(qmpSnapshotWaitTimeout)
+}
+
+func (q *qemu) migrateSandbox(uri string) error {
+	span, _ := q.trace("migrateSandbox")
+	defer span.Finish()
+
+	if err := q.checkNotHotplugged(); err != nil {
+		return err
+	}
+
+	if err := q.qmpSetup(); err != nil {
+		return err
+	}
+
+	if err := q.qmpMonitorCh.qmp.ExecSetMigrateArguments(q.qmpMonitorCh.ctx, uri); err != nil {
+		q.Logger().WithError(err).Error("live migration")
+		return err
+	}
+
+	return q.waitMigrationTimeout(qmpLiveMigrationWaitTimeout)
+}
+
+// checkNotHotplugged returns an error if devices, memory or vCPUs were
+// hotplugged to the VM. A restored or migrated VM is started with the
+// ones the sandbox is configured with, its command line not having the
+// ones hotplugged since.
+func (q *qemu) checkNotHotplugged() error {
+	for _, b := range q.arch.getBridges() {
+		if len(b.Devices) != 0 {
+			return errors.New("Cannot save the state of a VM with hotplugged devices")
+		}
+	}
+	if q.state.HotpluggedMemory != 0 || len(q.state.HotpluggedVCPUs) != 0 {
+		return errors.New("Cannot save the state of a VM with hotplugged memory or vCPUs")
+	}
+
+	return nil
+}
+
 func (q *qemu) waitMigration() error {
-	t := time.NewTimer(qmpMigrationWaitTimeout)
+	return q.waitMigrationTimeout(qmpMigrationWaitTimeout)
+}
+
+func (q *qemu) waitMigrationTimeout(timeout time.Duration) error {
+	t := time.NewTimer(timeout)
 	defer t.Stop()
 	for {
 		status, err := q.qmpMonitorCh.qmp.ExecuteQueryMigration(q.qmpMonitorCh.ctx)
@@ -1815,11 +2466,15 @@ func (q *qemu) waitMigration() error {
 		if status.Status == "completed" {
 			break
 		}
+		if status.Status == "failed" || status.Status == "cancelled" {
+			q.Logger().WithField("migration-status", status).Error("qemu migration failed")
+			return fmt.Errorf("qemu migration %s", status.Status)
+		}
 
 		select {
 		case <-t.C:
 			q.Logger().WithField("migration-status", status).Error("timeout waiting for qemu migration")
-			return fmt.Errorf("timed out after %d seconds waiting for qemu migration", qmpMigrationWaitTimeout)
+			return fmt.Errorf("timed out after %v waiting for qemu migration", timeout)
 		default:
 			// migration in progress
 			q.Logger().WithField("migration-status", status).Debug("migration in progress")
@@ -1830,6 +2485,89 @@ func (q *qemu) waitMigration() error {
 	return nil
 }
 
+func (q *qemu) waitGuestSuspended(timeout time.Duration) error {
+	span, _ := q.trace("waitGuestSuspended")
+	defer span.Finish()
+
+	if err := q.qmpSetup(); err != nil {
+		return err
+	}
+
+	t := time.NewTimer(timeout)
+	defer t.Stop()
+	for {
+		status, err := q.qmpMonitorCh.qmp.ExecuteQueryStatus(q.qmpMonitorCh.ctx)
+		if err != nil {
+			return err
+		}
+		if status.Status == "suspended" {
+			return nil
+		}
+
+		select {
+		case <-t.C:
+			return fmt.Errorf("guest not suspended after %v, status %q", timeout, status.Status)
+		default:
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+}
+
+func (q *qemu) wakeupSandbox() error {
+	span, _ := q.trace("wakeupSandbox")
+	defer span.Finish()
+
+	return q.qmpExecute("system_wakeup", nil, nil)
+}
+
+func (q *qemu) powerdownSandbox() error {
+	span, _ := q.trace("powerdownSandbox")
+	defer span.Finish()
+
+	if err := q.qmpSetup(); err != nil {
+		return err
+	}
+
+	return q.qmpMonitorCh.qmp.ExecuteSystemPowerdown(q.qmpMonitorCh.ctx)
+}
+
+func (q *qemu) getLaunchMeasurement() (LaunchMeasurement, error) {
+	span, _ := q.trace("getLaunchMeasurement")
+	defer span.Finish()
+
+	switch q.config.MemoryEncryption {
+	case MemoryEncryptionSEV:
+	case MemoryEncryptionSNP:
+		return LaunchMeasurement{}, errors.New("SEV-SNP guests request their attestation reports from the secure processor")
+	case MemoryEncryptionTDX:
+		return LaunchMeasurement{}, errors.New("TDX guests are attested through their TD reports")
+	default:
+		return LaunchMeasurement{}, errors.New("the VM memory is not encrypted")
+	}
+
+	var measurement qmpSEVLaunchMeasurement
+	if err := q.qmpExecute("query-sev-launch-measure", nil, &measurement); err != nil {
+		return LaunchMeasurement{}, err
+	}
+
+	var info qmpSEVInfo
+	if err := q.qmpExecute("query-sev", nil, &info); err != nil {
+		return LaunchMeasurement{}, err
+	}
+
+	if !info.Enabled {
+		return LaunchMeasurement{}, errors.New("SEV is not enabled for the VM")
+	}
+
+	return LaunchMeasurement{
+		Measurement: measurement.Data,
+		APIMajor:    info.APIMajor,
+		APIMinor:    info.APIMinor,
+		BuildID:     info.BuildID,
+		Policy:      info.Policy,
+	}, nil
+}
+
 func (q *qemu) disconnect() {
 	span, _ := q.trace("disconnect")
 	defer span.Finish()
@@ -1858,8 +2596,8 @@ func (q *qemu) resizeMemory(reqMemMB uint32, memoryBlockSizeMB uint32, probe boo
 	var addMemDevice memoryDevice
 	if q.config.VirtioMem && currentMemory != reqMemMB {
 		q.Logger().WithField("hotplug", "memory").Debugf("resize memory from %dMB to %dMB", currentMemory, reqMemMB)
-		sizeByte := (reqMemMB - q.config.MemorySize) * 1024 * 1024
-		err = q.qmpMonitorCh.qmp.ExecQomSet(q.qmpMonitorCh.ctx, "virtiomem0", "requested-size", uint64(sizeByte))
+		sizeByte := uint64(reqMemMB-q.config.MemorySize) * 1024 * 1024
+		err = q.qmpMonitorCh.qmp.ExecQomSet(q.qmpMonitorCh.ctx, "virtiomem0", "requested-size", sizeByte)
 		if err != nil {
 			return 0, memoryDevice{}, err
 		}
@@ -2036,6 +2774,233 @@ func genericAppendPCIeRootPort(devices []govmmQemu.Device, number uint32, machin
 	return devices
 }
 
+const (
+	// guestNUMABusNr is the first bus number of the PCIe expander bridges
+	// of the guest NUMA nodes, each node having guestNUMABuses of them.
+	guestNUMABusNr = 128
+	guestNUMABuses = 16
+
+	// guestNUMAMaxNodes is the maximum number of guest NUMA nodes with
+	// their buses below 256.
+	guestNUMAMaxNodes = 8
+
+	// guestNUMAChassis is the first chassis number of the root ports of
+	// the guest NUMA nodes, each node having its own.
+	guestNUMAChassis = 128
+)
+
+// guestNUMANodes returns the NUMA nodes of the VM, sharing the memory and
+// the vCPUs evenly. The memory of a node is allocated on the host node it
+// mirrors.
+func (q *qemu) guestNUMANodes(machine govmmQemu.Machine, smp govmmQemu.SMP) ([]qemuNUMANode, error) {
+	nodes := q.config.GuestNUMANodes
+
+	// The PCIe expander bridges attaching the devices to the nodes are
+	// PCIe host bridges.
+	if machine.Type != QemuQ35 {
+		return nil, fmt.Errorf("Guest NUMA nodes are not supported with the %s machine type", machine.Type)
+	}
+
+	if len(nodes) > guestNUMAMaxNodes {
+		return nil, fmt.Errorf("%d guest NUMA nodes requested, at most %d are supported", len(nodes), guestNUMAMaxNodes)
+	}
+
+	if smp.CPUs < uint32(len(nodes)) {
+		return nil, fmt.Errorf("%d vCPUs cannot be shared by %d guest NUMA nodes", smp.CPUs, len(nodes))
+	}
+
+	memory := uint64(q.config.MemorySize)
+	if memory < uint64(len(nodes)) {
+		return nil, fmt.Errorf("%d MiB of memory cannot be shared by %d guest NUMA nodes", memory, len(nodes))
+	}
+
+	var numaNodes []qemuNUMANode
+	for i, node := range nodes {
+		if len(node.PCIDevices) >= guestNUMABuses {
+			return nil, fmt.Errorf("%d devices attached to guest NUMA node %d, at most %d are supported", len(node.PCIDevices), i, guestNUMABuses-1)
+		}
+
+		// The first node gets the rest of the memory.
+		size := memory / uint64(len(nodes))
+		if i == 0 {
+			size += memory % uint64(len(nodes))
+		}
+
+		numaNodes = append(numaNodes, qemuNUMANode{
+			Size:      fmt.Sprintf("%dM", size),
+			HostNodes: strconv.Itoa(node.HostNode),
+		})
+	}
+
+	// Each node gets contiguous ranges of the boot vCPUs and of the vCPUs
+	// that can be hotplugged.
+	for first := uint32(0); first < smp.MaxCPUs; {
+		node := guestNUMANodeOfVCPU(first, smp.CPUs, smp.MaxCPUs, len(nodes))
+
+		last := first
+		for last+1 < smp.MaxCPUs && last+1 != smp.CPUs && guestNUMANodeOfVCPU(last+1, smp.CPUs, smp.MaxCPUs, len(nodes)) == node {
+			last++
+		}
+
+		cpus := strconv.FormatUint(uint64(first), 10)
+		if last > first {
+			cpus += "-" + strconv.FormatUint(uint64(last), 10)
+		}
+		numaNodes[node].CPUs = append(numaNodes[node].CPUs, cpus)
+
+		first = last + 1
+	}
+
+	return numaNodes, nil
+}
+
+// qemuNUMANode is a NUMA node of the guest.
+type qemuNUMANode struct {
+	// Size is the amount of memory of the node, suffixed as the memory
+	// size of QEMU.
+	Size string
+
+	// CPUs are the ranges of the vCPUs of the node, such as "0-3".
+	CPUs []string
+
+	// HostNodes are the host NUMA nodes the memory of the node is
+	// allocated on, such as "0" or "0-1".
+	HostNodes string
+}
+
+// qemuGuestNUMAMemory is the memory of a VM shared by its NUMA nodes,
+// in place of the memory govmm gives a VM with a single node.
+type qemuGuestNUMAMemory struct {
+	// Size is the memory of the VM, the sizes of the nodes adding up to
+	// it.
+	Size string
+
+	Nodes []qemuNUMANode
+}
+
+// Valid returns true if the VM has memory and NUMA nodes.
+func (m qemuGuestNUMAMemory) Valid() bool {
+	return m.Size != "" && len(m.Nodes) > 0
+}
+
+// QemuParams returns the qemu parameters adding the memory of the VM and a
+// memory backend for each node, allocated on the host nodes of the node.
+// The backends are set up as govmm sets up the single one of a VM without
+// NUMA nodes.
+func (m qemuGuestNUMAMemory) QemuParams(config *govmmQemu.Config) []string {
+	memory := m.Size
+	if config.Memory.Slots > 0 {
+		memory += fmt.Sprintf(",slots=%d", config.Memory.Slots)
+	}
+	if config.Memory.MaxMem != "" {
+		memory += ",maxmem=" + config.Memory.MaxMem
+	}
+
+	params := []string{"-m", memory}
+
+	for i, node := range m.Nodes {
+		id := fmt.Sprintf("dimm%d", i+1)
+
+		var backend string
+		switch {
+		case config.Knobs.HugePages:
+			backend = fmt.Sprintf("memory-backend-file,id=%s,size=%s,mem-path=/dev/hugepages", id, node.Size)
+		case config.Knobs.FileBackedMem && config.Memory.Path != "":
+			backend = fmt.Sprintf("memory-backend-file,id=%s,size=%s,mem-path=%s", id, node.Size, config.Memory.Path)
+		default:
+			backend = fmt.Sprintf("memory-backend-ram,id=%s,size=%s", id, node.Size)
+		}
+
+		if config.Knobs.MemShared {
+			backend += ",share=on"
+		}
+		if config.Knobs.MemPrealloc {
+			backend += ",prealloc=on"
+		}
+		if node.HostNodes != "" {
+			backend += ",host-nodes=" + node.HostNodes + ",policy=bind"
+		}
+
+		numa := fmt.Sprintf("node,nodeid=%d", i)
+		for _, cpus := range node.CPUs {
+			numa += ",cpus=" + cpus
+		}
+		numa += ",memdev=" + id
+
+		params = append(params, "-object", backend, "-numa", numa)
+	}
+
+	return params
+}
+
+// guestNUMARootPortID returns the ID of the root port a device attached to
+// a guest NUMA node is plugged in.
+func guestNUMARootPortID(node, device int) string {
+	return fmt.Sprintf("numa%d%s%d", node, pcieRootPortPrefix, device)
+}
+
+// guestNUMARootPort returns the root port of the guest NUMA node a VFIO
+// device is attached to, empty if it is not attached to any.
+func (q *qemu) guestNUMARootPort(device *config.VFIODev) string {
+	if device.Type != config.VFIODeviceNormalType {
+		return ""
+	}
+
+	for i, node := range q.config.GuestNUMANodes {
+		for j, bdf := range node.PCIDevices {
+			if bdf == device.BDF {
+				return guestNUMARootPortID(i, j)
+			}
+		}
+	}
+
+	return ""
+}
+
+// appendGuestNUMABridges appends a PCIe expander bridge for each guest NUMA
+// node, with a root port for each device attached to the node.
+func appendGuestNUMABridges(devices []govmmQemu.Device, nodes []GuestNUMANode) []govmmQemu.Device {
+	for i, node := range nodes {
+		bridge := qemuPCIeExpanderBridge{
+			ID:       fmt.Sprintf("pxb%d", i),
+			BusNr:    guestNUMABusNr + i*guestNUMABuses,
+			NUMANode: i,
+		}
+		devices = append(devices, bridge)
+
+		for j := range node.PCIDevices {
+			devices = append(devices,
+				govmmQemu.PCIeRootPortDevice{
+					ID:      guestNUMARootPortID(i, j),
+					Bus:     bridge.ID,
+					Chassis: strconv.Itoa(guestNUMAChassis + i),
+					Slot:    strconv.Itoa(j),
+				},
+			)
+		}
+	}
+
+	return devices
+}
+
+// qemuPCIeExpanderBridge is a PCIe host bridge attached to a guest NUMA
+// node, the devices behind it being local to the node.
+type qemuPCIeExpanderBridge struct {
+	ID       string
+	BusNr    int
+	NUMANode int
+}
+
+// Valid returns true if the bridge has an ID.
+func (b qemuPCIeExpanderBridge) Valid() bool {
+	return b.ID != ""
+}
+
+// QemuParams returns the qemu parameters adding the bridge.
+func (b qemuPCIeExpanderBridge) QemuParams(config *govmmQemu.Config) []string {
+	return []string{"-device", fmt.Sprintf("pxb-pcie,id=%s,bus=%s,bus_nr=%d,numa_node=%d", b.ID, defaultBridgeBus, b.BusNr, b.NUMANode)}
+}
+
 func (q *qemu) getThreadIDs() (vcpuThreadIDs, error) {
 	span, _ := q.trace("getThreadIDs")
 	defer span.Finish()
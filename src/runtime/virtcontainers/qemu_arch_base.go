@@ -133,6 +133,9 @@ type qemuArch interface {
 
 	// append vIOMMU device
 	appendIOMMU(devices []govmmQemu.Device) ([]govmmQemu.Device, error)
+
+	// appendGuestSuspend enables the guest suspend to RAM
+	appendGuestSuspend(devices []govmmQemu.Device) ([]govmmQemu.Device, error)
 }
 
 type qemuArchBase struct {
@@ -608,12 +611,16 @@ func (q *qemuArchBase) appendBlockDevice(devices []govmmQemu.Device, drive confi
 	if err != nil {
 		return devices, fmt.Errorf("Failed to append block device %v", err)
 	}
+	if drive.Cache != "" {
+		return append(devices, cachedBlockDevice{d, drive.Cache}), nil
+	}
 	devices = append(devices, d)
 	return devices, nil
 }
 
 func (q *qemuArchBase) appendVhostUserDevice(devices []govmmQemu.Device, attr config.VhostUserDeviceAttrs) ([]govmmQemu.Device, error) {
 	qemuVhostUserDevice := govmmQemu.VhostUserDevice{}
+	var reconnect uint32
 
 	switch attr.Type {
 	case config.VhostUserNet:
@@ -624,17 +631,23 @@ func (q *qemuArchBase) appendVhostUserDevice(devices []govmmQemu.Device, attr co
 		qemuVhostUserDevice.TypeDevID = utils.MakeNameID("scsi", attr.DevID, maxDevIDSize)
 		qemuVhostUserDevice.VhostUserType = govmmQemu.VhostUserSCSI
 	case config.VhostUserBlk:
+		reconnect = attr.Reconnect
 		qemuVhostUserDevice.VhostUserType = govmmQemu.VhostUserBlk
 	case config.VhostUserFS:
 		qemuVhostUserDevice.TypeDevID = utils.MakeNameID("fs", attr.DevID, maxDevIDSize)
 		qemuVhostUserDevice.Tag = attr.Tag
 		qemuVhostUserDevice.CacheSize = attr.CacheSize
+		reconnect = attr.Reconnect
 		qemuVhostUserDevice.VhostUserType = govmmQemu.VhostUserFS
 	}
 
 	qemuVhostUserDevice.SocketPath = attr.SocketPath
 	qemuVhostUserDevice.CharDevID = utils.MakeNameID("char", attr.DevID, maxDevIDSize)
 
+	if reconnect != 0 {
+		return append(devices, reconnectingVhostUserDevice{qemuVhostUserDevice, reconnect}), nil
+	}
+
 	devices = append(devices, qemuVhostUserDevice)
 
 	return devices, nil
@@ -645,16 +658,18 @@ func (q *qemuArchBase) appendVFIODevice(devices []govmmQemu.Device, vfioDev conf
 		return devices
 	}
 
-	devices = append(devices,
-		govmmQemu.VFIODevice{
-			BDF:      vfioDev.BDF,
-			VendorID: vfioDev.VendorID,
-			DeviceID: vfioDev.DeviceID,
-			Bus:      vfioDev.Bus,
-		},
-	)
+	device := govmmQemu.VFIODevice{
+		BDF:      vfioDev.BDF,
+		VendorID: vfioDev.VendorID,
+		DeviceID: vfioDev.DeviceID,
+		Bus:      vfioDev.Bus,
+	}
 
-	return devices
+	if vfioDev.GPUDirectClique != "" {
+		return append(devices, gpuDirectVFIODevice{device, vfioDev.GPUDirectClique})
+	}
+
+	return append(devices, device)
 }
 
 func (q *qemuArchBase) appendRNGDevice(devices []govmmQemu.Device, rngDev config.RNGDev) ([]govmmQemu.Device, error) {
@@ -774,3 +789,41 @@ func (q *qemuArchBase) appendIOMMU(devices []govmmQemu.Device) ([]govmmQemu.Devi
 		return devices, fmt.Errorf("Machine Type %s does not support vIOMMU", q.qemuMachine.Type)
 	}
 }
+
+// qemuGlobalProperty sets the default value of a device property.
+type qemuGlobalProperty struct {
+	Driver   string
+	Property string
+	Value    string
+}
+
+// Valid returns true if the driver and the property are set.
+func (g qemuGlobalProperty) Valid() bool {
+	return g.Driver != "" && g.Property != ""
+}
+
+// QemuParams returns the qemu parameters setting the property.
+func (g qemuGlobalProperty) QemuParams(config *govmmQemu.Config) []string {
+	return []string{"-global", fmt.Sprintf("%s.%s=%s", g.Driver, g.Property, g.Value)}
+}
+
+// appendGuestSuspend enables ACPI S3 on the power management device of the
+// machine, which QEMU disables by default.
+func (q *qemuArchBase) appendGuestSuspend(devices []govmmQemu.Device) ([]govmmQemu.Device, error) {
+	var driver string
+	switch q.qemuMachine.Type {
+	case QemuPC:
+		driver = "PIIX4_PM"
+	case QemuQ35:
+		driver = "ICH9-LPC"
+	default:
+		return devices, fmt.Errorf("Machine Type %s does not support guest suspend", q.qemuMachine.Type)
+	}
+
+	devices = append(devices, qemuGlobalProperty{
+		Driver:   driver,
+		Property: "disable_s3",
+		Value:    "0",
+	})
+	return devices, nil
+}
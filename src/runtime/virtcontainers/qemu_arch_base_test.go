@@ -392,6 +392,36 @@ func TestQemuArchBaseAppendBlockDevice(t *testing.T) {
 	testQemuArchBaseAppend(t, drive, expectedOut)
 }
 
+func TestQemuArchBaseAppendBlockDeviceCache(t *testing.T) {
+	id := "blockDevTest"
+
+	expectedOut := []govmmQemu.Device{
+		cachedBlockDevice{
+			BlockDevice: govmmQemu.BlockDevice{
+				Driver:    govmmQemu.VirtioBlock,
+				ID:        id,
+				File:      "/root",
+				AIO:       govmmQemu.Threads,
+				Format:    "raw",
+				Interface: "none",
+			},
+			Cache: config.BlockCacheDirectSync,
+		},
+	}
+
+	drive := config.BlockDrive{
+		File:   "/root",
+		Format: "raw",
+		ID:     id,
+		Cache:  config.BlockCacheDirectSync,
+	}
+
+	testQemuArchBaseAppend(t, drive, expectedOut)
+
+	params := expectedOut[0].QemuParams(&govmmQemu.Config{})
+	assert.Contains(t, params, "id=blockDevTest,file=/root,aio=threads,format=raw,if=none,cache="+config.BlockCacheDirectSync)
+}
+
 func TestQemuArchBaseAppendVhostUserDevice(t *testing.T) {
 	socketPath := "nonexistentpath.sock"
 	macAddress := "00:11:22:33:44:55:66"
@@ -417,6 +447,27 @@ func TestQemuArchBaseAppendVhostUserDevice(t *testing.T) {
 	testQemuArchBaseAppend(t, vhostUserDevice, expectedOut)
 }
 
+func TestQemuArchBaseAppendReconnectingVhostUserDevice(t *testing.T) {
+	assert := assert.New(t)
+	qemuArchBase := newQemuArchBase()
+
+	vhostUserDevice := config.VhostUserDeviceAttrs{
+		Type:      config.VhostUserFS,
+		Tag:       "kataShared",
+		Reconnect: 1,
+	}
+	vhostUserDevice.DevID = "deadbeef"
+	vhostUserDevice.SocketPath = "vhost-fs.sock"
+
+	devices, err := qemuArchBase.appendVhostUserDevice(nil, vhostUserDevice)
+	assert.NoError(err)
+	assert.Len(devices, 1)
+
+	params := devices[0].QemuParams(&govmmQemu.Config{})
+	assert.Equal("-chardev", params[0])
+	assert.Equal("socket,id=char-deadbeef,path=vhost-fs.sock,reconnect=1", params[1])
+}
+
 func TestQemuArchBaseAppendVFIODevice(t *testing.T) {
 	bdf := "02:10.1"
 
@@ -566,3 +617,23 @@ func TestQemuArchBaseAppendIOMMU(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(expectedOut, devices)
 }
+
+func TestQemuArchBaseAppendGuestSuspend(t *testing.T) {
+	assert := assert.New(t)
+	qemuArchBase := newQemuArchBase()
+
+	qemuArchBase.qemuMachine.Type = QemuPC
+	devices, err := qemuArchBase.appendGuestSuspend(nil)
+	assert.NoError(err)
+	assert.Equal([]govmmQemu.Device{qemuGlobalProperty{Driver: "PIIX4_PM", Property: "disable_s3", Value: "0"}}, devices)
+	assert.Equal([]string{"-global", "PIIX4_PM.disable_s3=0"}, devices[0].QemuParams(nil))
+
+	qemuArchBase.qemuMachine.Type = QemuQ35
+	devices, err = qemuArchBase.appendGuestSuspend(nil)
+	assert.NoError(err)
+	assert.Equal([]string{"-global", "ICH9-LPC.disable_s3=0"}, devices[0].QemuParams(nil))
+
+	qemuArchBase.qemuMachine.Type = QemuMicrovm
+	_, err = qemuArchBase.appendGuestSuspend(nil)
+	assert.Error(err)
+}
@@ -0,0 +1,361 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	govmmQemu "github.com/intel/govmm/qemu"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+)
+
+const (
+	// liveUpdateOldVMSuffix is appended to the directory of a VM moved to
+	// a new QEMU process, for the new process to be started in the
+	// directory while the former one runs on.
+	liveUpdateOldVMSuffix = ".old"
+
+	// liveUpdateMigrationSocket is the socket, in the directory of the VM,
+	// the new QEMU process receives the state of the VM on.
+	liveUpdateMigrationSocket = "migrate.sock"
+)
+
+// checkLiveUpdate checks the VM can be moved to a new QEMU process. The new
+// process is started with the command line of the VM, the vCPUs, the
+// memory and the drives hotplugged since being added to it again, which
+// other hotplugged devices cannot be.
+func (q *qemu) checkLiveUpdate(drives []*config.BlockDrive) error {
+	if q.config.BootToBeTemplate || q.config.BootFromTemplate || q.config.VMid != "" {
+		return errors.New("Cannot live update a VM template, a VM created from a template or by a factory")
+	}
+
+	if q.config.SharedFS != config.NoSharedFS {
+		return fmt.Errorf("Cannot live update a VM sharing files with %s", q.config.SharedFS)
+	}
+
+	if q.config.MemoryEncryption != MemoryEncryptionNone {
+		return errors.New("Cannot live update a VM with encrypted memory")
+	}
+
+	hotplugged := make(map[string]bool)
+	for _, drive := range drives {
+		if drive.Pmem || q.config.BlockDeviceDriver == config.Nvdimm {
+			return fmt.Errorf("Cannot live update a VM with the NVDIMM drive %s", drive.ID)
+		}
+		if q.config.BlockDeviceDriver != config.VirtioBlock && q.config.BlockDeviceDriver != config.VirtioSCSI {
+			return fmt.Errorf("Cannot live update a VM with %s drives", q.config.BlockDeviceDriver)
+		}
+		hotplugged[drive.ID] = true
+	}
+
+	for _, b := range q.arch.getBridges() {
+		for _, id := range b.Devices {
+			if !hotplugged[id] {
+				return fmt.Errorf("Cannot live update a VM with the hotplugged device %s", id)
+			}
+		}
+	}
+
+	return nil
+}
+
+// liveUpdate starts a new QEMU process from the QEMU binary as it is now,
+// with the command line of the VM, adds the vCPUs, the memory and the
+// drives hotplugged to the VM to it, and migrates the VM to it through a
+// unix socket. The new process takes the directory of the VM over, and the
+// former one quits. The VM runs on in the former process if the update
+// fails.
+func (q *qemu) liveUpdate(vmSocket interface{}, endpoints []Endpoint, drives []*config.BlockDrive) (err error) {
+	span, _ := q.trace("liveUpdate")
+	defer span.Finish()
+
+	if err := q.checkLiveUpdate(drives); err != nil {
+		return err
+	}
+
+	if err := q.qmpSetup(); err != nil {
+		return err
+	}
+
+	cpus, err := q.qmpMonitorCh.qmp.ExecuteQueryHotpluggableCPUs(q.qmpMonitorCh.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query hotpluggable CPUs: %v", err)
+	}
+
+	memoryDevices, err := q.qmpMonitorCh.qmp.ExecQueryMemoryDevices(q.qmpMonitorCh.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query memory devices: %v", err)
+	}
+
+	// The former process is reached in the renamed directory of the VM
+	// until it quits.
+	vmPath := filepath.Join(q.store.RunVMStoragePath(), q.id)
+	oldVMPath := vmPath + liveUpdateOldVMSuffix
+	monitorPath := q.qmpMonitorCh.path
+
+	q.qmpShutdown()
+	if err := os.Rename(vmPath, oldVMPath); err != nil {
+		return err
+	}
+	q.qmpMonitorCh.path = filepath.Join(oldVMPath, qmpSocket)
+
+	var next *qemu
+	migrated := false
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		if next != nil {
+			if err := next.stopSandbox(); err != nil {
+				q.Logger().WithError(err).Warn("Could not stop the new QEMU process after the live update failed")
+			}
+			next.qmpShutdown()
+		}
+
+		q.qmpShutdown()
+		if err := os.Rename(oldVMPath, vmPath); err != nil {
+			q.Logger().WithError(err).Errorf("Could not move the VM directory %s back", oldVMPath)
+		}
+		q.qmpMonitorCh.path = monitorPath
+
+		// The former process stops the VM once its state is sent.
+		if migrated {
+			if err := q.togglePauseSandbox(false); err != nil {
+				q.Logger().WithError(err).Error("Could not resume the VM after the live update failed")
+			}
+		}
+	}()
+
+	hypervisorConfig := q.config
+	hypervisorConfig.SnapshotPath = ""
+	hypervisorConfig.IncomingMigrationURI = ""
+
+	state := q.state
+	state.Bridges = q.arch.getBridges()
+
+	next = &qemu{
+		store: q.store,
+		state: state,
+	}
+
+	if err = next.createSandbox(q.ctx, q.id, NetworkNamespace{Endpoints: endpoints}, &hypervisorConfig); err != nil {
+		return err
+	}
+
+	// The migrated state is loaded once the devices hotplugged to the VM
+	// are added again.
+	next.qemuConfig.Incoming.MigrationType = govmmQemu.MigrationDefer
+
+	// The devices are added in the order the sandbox added them to the
+	// former process.
+	if err = next.addDevice(vmSocket, vSockPCIDev); err != nil {
+		return err
+	}
+	for _, endpoint := range endpoints {
+		if err = next.addDevice(endpoint, netDev); err != nil {
+			return err
+		}
+	}
+
+	if err = next.startSandbox(vmStartTimeout); err != nil {
+		return err
+	}
+
+	if err = next.qmpSetup(); err != nil {
+		return err
+	}
+
+	if err = next.addMovedVCPUs(cpus); err != nil {
+		return err
+	}
+
+	if err = next.addMovedMemory(memoryDevices); err != nil {
+		return err
+	}
+
+	if err = next.addMovedDrives(drives); err != nil {
+		return err
+	}
+
+	migrationSocket, err := utils.BuildSocketPath(vmPath, liveUpdateMigrationSocket)
+	if err != nil {
+		return err
+	}
+	uri := "unix:" + migrationSocket
+
+	if err = next.qmpMonitorCh.qmp.ExecuteMigrationIncoming(next.qmpMonitorCh.ctx, uri); err != nil {
+		return err
+	}
+
+	if err = q.qmpSetup(); err != nil {
+		return err
+	}
+
+	if err = q.qmpMonitorCh.qmp.ExecSetMigrateArguments(q.qmpMonitorCh.ctx, uri); err != nil {
+		q.Logger().WithError(err).Error("live update migration")
+		return err
+	}
+
+	if err = q.waitMigrationTimeout(qmpLiveMigrationWaitTimeout); err != nil {
+		if err := q.qmpExecute("migrate_cancel", nil, nil); err != nil {
+			q.Logger().WithError(err).Warn("Could not cancel the live update migration")
+		}
+		return err
+	}
+	migrated = true
+
+	if err = next.finishIncomingMigration(); err != nil {
+		return err
+	}
+
+	// The VM runs in the new process from now on.
+	if err := q.qmpMonitorCh.qmp.ExecuteQuit(q.qmpMonitorCh.ctx); err != nil {
+		q.Logger().WithError(err).Warn("Could not quit the former QEMU process")
+	}
+	q.qmpShutdown()
+	next.qmpShutdown()
+
+	if err := os.RemoveAll(oldVMPath); err != nil {
+		q.Logger().WithError(err).Warnf("failed to remove vm path %s", oldVMPath)
+	}
+
+	q.qmpMonitorCh.path = monitorPath
+	q.qemuConfig = next.qemuConfig
+	q.fds = nil
+
+	q.Logger().Info("VM moved to a new QEMU process")
+
+	return nil
+}
+
+// addMovedVCPUs adds the vCPUs hotplugged to the VM to its new QEMU process,
+// in the slots they have in the former one, whose hotpluggable CPUs are
+// source.
+func (q *qemu) addMovedVCPUs(source []govmmQemu.HotpluggableCPU) error {
+	slots := make(map[string]govmmQemu.HotpluggableCPU)
+	for _, hc := range source {
+		if hc.QOMPath != "" {
+			slots[strings.TrimPrefix(hc.QOMPath, "/machine/peripheral/")] = hc
+		}
+	}
+
+	for _, cpu := range q.state.HotpluggedVCPUs {
+		hc, ok := slots[cpu.ID]
+		if !ok {
+			return fmt.Errorf("vCPU %s not found in the VM", cpu.ID)
+		}
+
+		if err := q.addCPUDevice(hc, cpu.ID); err != nil {
+			return fmt.Errorf("failed to add vCPU %s: %v", cpu.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// addMovedMemory adds the memory hotplugged to the VM to its new QEMU
+// process, in the slots it has in the former one, whose memory devices are
+// source.
+func (q *qemu) addMovedMemory(source []govmmQemu.MemoryDevices) error {
+	var dimms []govmmQemu.MemoryDevicesData
+	for _, device := range source {
+		if device.Type == "dimm" && device.Data.Hotplugged {
+			dimms = append(dimms, device.Data)
+		}
+	}
+
+	// The slots are taken in order.
+	sort.Slice(dimms, func(i, j int) bool {
+		return dimms[i].Slot < dimms[j].Slot
+	})
+
+	share, target, memoryBack, err := q.getMemArgs()
+	if err != nil {
+		return err
+	}
+
+	for _, dimm := range dimms {
+		id := strings.TrimPrefix(dimm.ID, "dimm")
+		if err := q.qmpMonitorCh.qmp.ExecHotplugMemory(q.qmpMonitorCh.ctx, memoryBack, id, target, int(dimm.Size>>20), share); err != nil {
+			return fmt.Errorf("failed to add memory %s: %v", id, err)
+		}
+	}
+
+	return nil
+}
+
+// addMovedDrives adds the drives hotplugged to the VM to its new QEMU
+// process, at the addresses they have in the former one.
+func (q *qemu) addMovedDrives(drives []*config.BlockDrive) error {
+	bridges := q.arch.getBridges()
+
+	for _, drive := range drives {
+		scsiPassthrough := q.scsiPassthrough(drive)
+		_, writeCache := blockCacheOptions(drive.Cache)
+		devID := "virtio-" + drive.ID
+
+		if err := q.blockdevAdd(drive, scsiPassthrough); err != nil {
+			return err
+		}
+
+		switch q.config.BlockDeviceDriver {
+		case config.VirtioBlock:
+			bridge, addr, err := bridgeOfDevice(bridges, drive.ID)
+			if err != nil {
+				return err
+			}
+
+			if err := q.executePCIDeviceAdd(drive.ID, devID, "virtio-blk-pci", addr, bridge.ID, drive.Serial, writeCache); err != nil {
+				return err
+			}
+		case config.VirtioSCSI:
+			driver := "scsi-hd"
+			if scsiPassthrough {
+				driver = "scsi-block"
+			}
+
+			scsiID, lun, err := utils.GetSCSIIdLun(drive.Index)
+			if err != nil {
+				return err
+			}
+
+			if err := q.executeSCSIDeviceAdd(drive.ID, devID, driver, scsiControllerID+".0", drive.Serial, writeCache, scsiID, lun); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("Block device %s not recognized", q.config.BlockDeviceDriver)
+		}
+	}
+
+	return nil
+}
+
+// bridgeOfDevice returns the PCI bridge the device id was added to, and the
+// address of the device on the bridge.
+func bridgeOfDevice(bridges []types.Bridge, id string) (types.Bridge, string, error) {
+	for _, b := range bridges {
+		if b.Type != types.PCI && b.Type != types.PCIE {
+			continue
+		}
+
+		for addr, devID := range b.Devices {
+			if devID == id {
+				return b, fmt.Sprintf("%02x", addr), nil
+			}
+		}
+	}
+
+	return types.Bridge{}, "", fmt.Errorf("Device %s not found on the PCI bridges", id)
+}
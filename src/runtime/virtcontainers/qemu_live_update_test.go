@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQemuCheckLiveUpdate(t *testing.T) {
+	assert := assert.New(t)
+
+	bridges := []types.Bridge{
+		types.NewBridge(types.PCI, "pci-bridge-0", map[uint32]string{2: "drive-0"}, 2),
+	}
+
+	q := &qemu{
+		config: HypervisorConfig{
+			SharedFS:          config.NoSharedFS,
+			BlockDeviceDriver: config.VirtioBlock,
+		},
+		arch: &qemuArchBase{Bridges: bridges},
+	}
+
+	drives := []*config.BlockDrive{{ID: "drive-0"}}
+	assert.NoError(q.checkLiveUpdate(drives))
+
+	// A device other than the drives was hotplugged
+	assert.Error(q.checkLiveUpdate(nil))
+
+	q.config.SharedFS = config.Virtio9P
+	assert.Error(q.checkLiveUpdate(drives))
+	q.config.SharedFS = config.NoSharedFS
+
+	q.config.BlockDeviceDriver = config.VirtioBlockCCW
+	assert.Error(q.checkLiveUpdate(drives))
+	q.config.BlockDeviceDriver = config.VirtioBlock
+
+	drives[0].Pmem = true
+	assert.Error(q.checkLiveUpdate(drives))
+	drives[0].Pmem = false
+
+	q.config.BootFromTemplate = true
+	assert.Error(q.checkLiveUpdate(drives))
+}
+
+func TestBridgeOfDevice(t *testing.T) {
+	assert := assert.New(t)
+
+	bridges := []types.Bridge{
+		types.NewBridge(types.CCW, "ccw-bridge-0", map[uint32]string{1: "drive-ccw"}, 0),
+		types.NewBridge(types.PCI, "pci-bridge-0", map[uint32]string{1: "drive-0"}, 2),
+		types.NewBridge(types.PCI, "pci-bridge-1", map[uint32]string{10: "drive-1"}, 3),
+	}
+
+	bridge, addr, err := bridgeOfDevice(bridges, "drive-1")
+	assert.NoError(err)
+	assert.Equal("pci-bridge-1", bridge.ID)
+	assert.Equal("0a", addr)
+
+	_, _, err = bridgeOfDevice(bridges, "drive-ccw")
+	assert.Error(err)
+
+	_, _, err = bridgeOfDevice(bridges, "drive-2")
+	assert.Error(err)
+}
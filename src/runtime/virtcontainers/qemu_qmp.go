@@ -0,0 +1,303 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"path/filepath"
+	"time"
+
+	govmmQemu "github.com/intel/govmm/qemu"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+)
+
+// The QMP commands, or the command arguments, that govmm does not support
+// are sent on a second QMP monitor of QEMU, as govmm keeps the connection
+// to the first one to itself.
+
+// qmpCommandSocket is the socket of the second QMP monitor, next to the
+// socket of the monitor govmm connects to.
+const qmpCommandSocket = "qmp-cmd.sock"
+
+// qmpMessage is a message QEMU sends on a QMP monitor: its greeting, the
+// response to a command, or an event.
+type qmpMessage struct {
+	Return json.RawMessage `json:"return"`
+	Error  *struct {
+		Class string `json:"class"`
+		Desc  string `json:"desc"`
+	} `json:"error"`
+	Event string `json:"event"`
+}
+
+// qmpSEVInfo is the state of the memory encryption of an AMD SEV guest,
+// returned by query-sev.
+type qmpSEVInfo struct {
+	Enabled  bool   `json:"enabled"`
+	APIMajor uint8  `json:"api-major"`
+	APIMinor uint8  `json:"api-minor"`
+	BuildID  uint8  `json:"build-id"`
+	Policy   uint32 `json:"policy"`
+	State    string `json:"state"`
+	Handle   uint32 `json:"handle"`
+}
+
+// qmpSEVLaunchMeasurement is the launch measurement of an AMD SEV guest,
+// returned by query-sev-launch-measure.
+type qmpSEVLaunchMeasurement struct {
+	// Data is the base64 encoded measurement.
+	Data string `json:"data"`
+}
+
+// qmpCommandSocketPath returns the path of the second QMP monitor of QEMU.
+func (q *qemu) qmpCommandSocketPath() (string, error) {
+	return utils.BuildSocketPath(filepath.Dir(q.qmpMonitorCh.path), qmpCommandSocket)
+}
+
+// qmpExecute sends a QMP command to QEMU on its second monitor, and decodes
+// the value QEMU returns into result, unless result is nil.
+func (q *qemu) qmpExecute(command string, args map[string]interface{}, result interface{}) error {
+	path, err := q.qmpCommandSocketPath()
+	if err != nil {
+		return err
+	}
+
+	ret, err := qmpExecute(q.qmpMonitorCh.ctx, path, command, args)
+	if err != nil {
+		return err
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(ret, result); err != nil {
+		return fmt.Errorf("Invalid result of QMP command %s: %v", command, err)
+	}
+
+	return nil
+}
+
+// qmpExecute connects to the QMP monitor listening on path, negotiates the
+// capabilities and sends command, returning the value QEMU returns. The
+// monitor serves one client at a time, the next ones waiting for it to
+// disconnect.
+func qmpExecute(ctx context.Context, path, command string, args map[string]interface{}) (json.RawMessage, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", path)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Unblock the reads and writes once the context is done.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.SetDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	decoder := json.NewDecoder(conn)
+
+	var greeting map[string]json.RawMessage
+	if err := decoder.Decode(&greeting); err != nil {
+		return nil, fmt.Errorf("Failed to read QMP greeting: %v", err)
+	}
+	if _, ok := greeting["QMP"]; !ok {
+		return nil, fmt.Errorf("Invalid QMP greeting from %s", path)
+	}
+
+	if _, err := qmpRoundTrip(conn, decoder, "qmp_capabilities", nil); err != nil {
+		return nil, err
+	}
+
+	return qmpRoundTrip(conn, decoder, command, args)
+}
+
+// qmpRoundTrip sends a QMP command and waits for its response, skipping
+// the events QEMU sends meanwhile.
+func qmpRoundTrip(conn net.Conn, decoder *json.Decoder, command string, args map[string]interface{}) (json.RawMessage, error) {
+	request := map[string]interface{}{
+		"execute": command,
+	}
+	if args != nil {
+		request["arguments"] = args
+	}
+
+	if err := json.NewEncoder(conn).Encode(request); err != nil {
+		return nil, fmt.Errorf("Failed to send QMP command %s: %v", command, err)
+	}
+
+	for {
+		var msg qmpMessage
+		if err := decoder.Decode(&msg); err != nil {
+			return nil, fmt.Errorf("Failed to read the response to QMP command %s: %v", command, err)
+		}
+
+		switch {
+		case msg.Event != "":
+			continue
+		case msg.Error != nil:
+			return nil, fmt.Errorf("QMP command %s failed: %s: %s", command, msg.Error.Class, msg.Error.Desc)
+		default:
+			return msg.Return, nil
+		}
+	}
+}
+
+// executeBlockdevAddWithPRManager adds a host block device forwarding the
+// persistent reservation commands to the prManager pr-manager-helper
+// object, for the device to be passed through with scsi-block.
+func (q *qemu) executeBlockdevAddWithPRManager(device, blockdevID, prManager string) error {
+	args := map[string]interface{}{
+		"driver":    "raw",
+		"node-name": blockdevID,
+		"file": map[string]interface{}{
+			"driver":     "host_device",
+			"filename":   device,
+			"pr-manager": prManager,
+		},
+	}
+
+	return q.qmpExecute("blockdev-add", args, nil)
+}
+
+// executeSCSIDeviceAdd adds a SCSI disk backed by the blockdevID block
+// device, on the bus of a SCSI controller. driver is scsi-hd, or scsi-block
+// to pass the SCSI commands through to a host block device. serial is the
+// serial number the guest reads from the disk, not set when empty, and
+// writeCache denotes whether the guest sees a volatile write cache, each
+// write being flushed before completing otherwise.
+func (q *qemu) executeSCSIDeviceAdd(blockdevID, devID, driver, bus, serial string, writeCache bool, scsiID, lun int) error {
+	args := map[string]interface{}{
+		"id":       devID,
+		"driver":   driver,
+		"drive":    blockdevID,
+		"bus":      bus,
+		"scsi-id":  scsiID,
+		"lun":      lun,
+		"share-rw": "on",
+	}
+
+	if serial != "" {
+		args["serial"] = serial
+	}
+	if !writeCache {
+		args["write-cache"] = "off"
+	}
+
+	return q.qmpExecute("device_add", args, nil)
+}
+
+// executePCIDeviceAdd adds a virtio-blk-pci device backed by the blockdevID
+// block device, at the addr address of the bus PCI bridge. serial is the
+// serial number the guest reads from the device, not set when empty, and
+// writeCache denotes whether the guest sees a volatile write cache, each
+// write being flushed before completing otherwise.
+func (q *qemu) executePCIDeviceAdd(blockdevID, devID, driver, addr, bus, serial string, writeCache bool) error {
+	args := map[string]interface{}{
+		"id":       devID,
+		"driver":   driver,
+		"drive":    blockdevID,
+		"addr":     addr,
+		"share-rw": "on",
+		"romfile":  romFile,
+	}
+
+	if bus != "" {
+		args["bus"] = bus
+	}
+	if serial != "" {
+		args["serial"] = serial
+	}
+	if !writeCache {
+		args["write-cache"] = "off"
+	}
+
+	return q.qmpExecute("device_add", args, nil)
+}
+
+// executePCIVFIODeviceAddWithGPUDirectClique adds the bdf host PCI device
+// through VFIO, in the clique NVIDIA GPUDirect clique. addr is the address
+// of the device on the bus PCI bridge, both being optional.
+func (q *qemu) executePCIVFIODeviceAddWithGPUDirectClique(devID, bdf, addr, bus, clique string) error {
+	args := map[string]interface{}{
+		"id":                    devID,
+		"driver":                govmmQemu.VfioPCI,
+		"host":                  bdf,
+		"romfile":               romFile,
+		"x-nv-gpudirect-clique": clique,
+	}
+
+	if addr != "" {
+		args["addr"] = addr
+	}
+	if bus != "" {
+		args["bus"] = bus
+	}
+
+	return q.qmpExecute("device_add", args, nil)
+}
+
+// executeCharDevUnixSocketReconnectAdd adds a character device connecting
+// to the path unix socket, reconnect being the number of seconds between
+// the attempts to connect again to the socket when its server goes away.
+func (q *qemu) executeCharDevUnixSocketReconnectAdd(id, path string, reconnect uint32) error {
+	args := map[string]interface{}{
+		"id": id,
+		"backend": map[string]interface{}{
+			"type": "socket",
+			"data": map[string]interface{}{
+				"server":    false,
+				"reconnect": reconnect,
+				"addr": map[string]interface{}{
+					"type": "unix",
+					"data": map[string]interface{}{
+						"path": path,
+					},
+				},
+			},
+		},
+	}
+
+	return q.qmpExecute("chardev-add", args, nil)
+}
+
+// executePCIVhostUserFSDevAdd adds a vhost-user-fs device connected to the
+// virtio-fs daemon through the chardevID character device, the guest
+// mounting the file system with tag. cacheSize is the size of the DAX
+// window in MiB, zero disabling it. addr is the address of the device on
+// the bus PCI bridge, bus being optional.
+func (q *qemu) executePCIVhostUserFSDevAdd(devID, chardevID, tag string, cacheSize uint32, addr, bus string) error {
+	args := map[string]interface{}{
+		"driver":  "vhost-user-fs-pci",
+		"id":      devID,
+		"chardev": chardevID,
+		"tag":     tag,
+		"addr":    addr,
+	}
+
+	if cacheSize != 0 {
+		args["cache-size"] = fmt.Sprintf("%dM", cacheSize)
+	}
+	if bus != "" {
+		args["bus"] = bus
+	}
+
+	return q.qmpExecute("device_add", args, nil)
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type qmpTestCommand struct {
+	Execute   string                 `json:"execute"`
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+// startQMPTestServer serves a QMP monitor on the command socket of q,
+// replying to the commands other than qmp_capabilities with reply, and
+// sending the commands it receives on the returned channel.
+func startQMPTestServer(t *testing.T, q *qemu, reply string) <-chan qmpTestCommand {
+	path, err := q.qmpCommandSocketPath()
+	assert.NoError(t, err)
+
+	l, err := net.Listen("unix", path)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	commands := make(chan qmpTestCommand, 1)
+	go func() {
+		// Serve a single client, as QEMU does.
+		conn, err := l.Accept()
+		l.Close()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		conn.Write([]byte(`{"QMP": {"version": {"qemu": {"micro": 0, "minor": 2, "major": 5}}, "capabilities": []}}` + "\n"))
+
+		decoder := json.NewDecoder(conn)
+		for {
+			var cmd qmpTestCommand
+			if err := decoder.Decode(&cmd); err != nil {
+				return
+			}
+
+			if cmd.Execute == "qmp_capabilities" {
+				conn.Write([]byte(`{"return": {}}` + "\n"))
+				continue
+			}
+
+			commands <- cmd
+			conn.Write([]byte(`{"event": "RESUME", "timestamp": {"seconds": 0, "microseconds": 0}}` + "\n"))
+			conn.Write([]byte(reply + "\n"))
+		}
+	}()
+
+	return commands
+}
+
+func newQMPTestQemu(t *testing.T) (*qemu, string) {
+	dir, err := ioutil.TempDir("", "qmp")
+	assert.NoError(t, err)
+
+	q := &qemu{
+		qmpMonitorCh: qmpChannel{
+			ctx:  context.Background(),
+			path: filepath.Join(dir, qmpSocket),
+		},
+	}
+
+	return q, dir
+}
+
+func TestQemuQMPExecute(t *testing.T) {
+	assert := assert.New(t)
+
+	q, dir := newQMPTestQemu(t)
+	defer os.RemoveAll(dir)
+
+	commands := startQMPTestServer(t, q, `{"return": {"status": "running"}}`)
+
+	var status struct {
+		Status string `json:"status"`
+	}
+	err := q.qmpExecute("query-status", nil, &status)
+	assert.NoError(err)
+	assert.Equal("running", status.Status)
+	assert.Equal(qmpTestCommand{Execute: "query-status"}, <-commands)
+
+	commands = startQMPTestServer(t, q, `{"error": {"class": "GenericError", "desc": "Device not found"}}`)
+
+	err = q.qmpExecute("device_del", map[string]interface{}{"id": "drive0"}, nil)
+	assert.Error(err)
+	assert.Contains(err.Error(), "Device not found")
+	assert.Equal(qmpTestCommand{Execute: "device_del", Arguments: map[string]interface{}{"id": "drive0"}}, <-commands)
+}
+
+func TestQemuSCSIDeviceAdd(t *testing.T) {
+	assert := assert.New(t)
+
+	q, dir := newQMPTestQemu(t)
+	defer os.RemoveAll(dir)
+
+	commands := startQMPTestServer(t, q, `{"return": {}}`)
+
+	err := q.executeSCSIDeviceAdd("drive0", "virtio-drive0", "scsi-block", "scsi0.0", "", false, 0, 1)
+	assert.NoError(err)
+	assert.Equal(qmpTestCommand{
+		Execute: "device_add",
+		Arguments: map[string]interface{}{
+			"id":          "virtio-drive0",
+			"driver":      "scsi-block",
+			"drive":       "drive0",
+			"bus":         "scsi0.0",
+			"scsi-id":     float64(0),
+			"lun":         float64(1),
+			"share-rw":    "on",
+			"write-cache": "off",
+		},
+	}, <-commands)
+}
+
+func TestQemuPCIDeviceAdd(t *testing.T) {
+	assert := assert.New(t)
+
+	q, dir := newQMPTestQemu(t)
+	defer os.RemoveAll(dir)
+
+	commands := startQMPTestServer(t, q, `{"return": {}}`)
+
+	err := q.executePCIDeviceAdd("drive0", "virtio-drive0", "virtio-blk-pci", "02", "pci-bridge-0", "d5f0c5a3", true)
+	assert.NoError(err)
+	assert.Equal(qmpTestCommand{
+		Execute: "device_add",
+		Arguments: map[string]interface{}{
+			"id":       "virtio-drive0",
+			"driver":   "virtio-blk-pci",
+			"drive":    "drive0",
+			"addr":     "02",
+			"bus":      "pci-bridge-0",
+			"share-rw": "on",
+			"romfile":  "",
+			"serial":   "d5f0c5a3",
+		},
+	}, <-commands)
+}
+
+func TestQemuPCIVFIODeviceAddWithGPUDirectClique(t *testing.T) {
+	assert := assert.New(t)
+
+	q, dir := newQMPTestQemu(t)
+	defer os.RemoveAll(dir)
+
+	commands := startQMPTestServer(t, q, `{"return": {}}`)
+
+	err := q.executePCIVFIODeviceAddWithGPUDirectClique("vfio-gpu0", "03:00.0", "", "rp0", "0")
+	assert.NoError(err)
+	assert.Equal(qmpTestCommand{
+		Execute: "device_add",
+		Arguments: map[string]interface{}{
+			"id":                    "vfio-gpu0",
+			"driver":                "vfio-pci",
+			"host":                  "03:00.0",
+			"romfile":               "",
+			"bus":                   "rp0",
+			"x-nv-gpudirect-clique": "0",
+		},
+	}, <-commands)
+}
+
+func TestQemuCharDevUnixSocketReconnectAdd(t *testing.T) {
+	assert := assert.New(t)
+
+	q, dir := newQMPTestQemu(t)
+	defer os.RemoveAll(dir)
+
+	commands := startQMPTestServer(t, q, `{"return": {}}`)
+
+	err := q.executeCharDevUnixSocketReconnectAdd("char-fs0", "/run/vhost-fs.sock", 1)
+	assert.NoError(err)
+	assert.Equal(qmpTestCommand{
+		Execute: "chardev-add",
+		Arguments: map[string]interface{}{
+			"id": "char-fs0",
+			"backend": map[string]interface{}{
+				"type": "socket",
+				"data": map[string]interface{}{
+					"server":    false,
+					"reconnect": float64(1),
+					"addr": map[string]interface{}{
+						"type": "unix",
+						"data": map[string]interface{}{
+							"path": "/run/vhost-fs.sock",
+						},
+					},
+				},
+			},
+		},
+	}, <-commands)
+}
@@ -147,6 +147,9 @@ func (q *qemuS390x) appendCCWBlockDevice(devices []govmmQemu.Device, drive confi
 	if err != nil {
 		return devices, fmt.Errorf("Failed to append blk-dev %v", err)
 	}
+	if drive.Cache != "" {
+		return append(devices, cachedBlockDevice{d, drive.Cache}), nil
+	}
 	devices = append(devices, d)
 	return devices, nil
 }
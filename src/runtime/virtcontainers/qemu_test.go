@@ -73,6 +73,23 @@ func TestQemuKernelParameters(t *testing.T) {
 	testQemuKernelParameters(t, params, expectedOut, false)
 }
 
+func TestQemuKernelParametersPayloadArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	qemuConfig := newQemuConfig()
+	qemuConfig.KernelParams = []Param{{Key: "foo", Value: "foo"}}
+	qemuConfig.PayloadArgs = []string{"/app", "hello world"}
+
+	q := &qemu{
+		config: qemuConfig,
+		arch:   &qemuArchBase{},
+	}
+
+	// The payload arguments come last, after the kernel parameters
+	expected := fmt.Sprintf("panic=1 nr_cpus=%d agent.use_vsock=false foo=foo -- /app \"hello world\"", MaxQemuVCPUs())
+	assert.Equal(expected, q.kernelParameters())
+}
+
 func TestQemuCreateSandbox(t *testing.T) {
 	qemuConfig := newQemuConfig()
 	assert := assert.New(t)
@@ -105,6 +122,62 @@ func TestQemuCreateSandbox(t *testing.T) {
 	assert.Exactly(qemuConfig, q.config)
 }
 
+func TestQemuCreateSandboxFirmwareVars(t *testing.T) {
+	qemuConfig := newQemuConfig()
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "firmware")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	qemuConfig.FirmwarePath = filepath.Join(dir, "OVMF_CODE.fd")
+	qemuConfig.FirmwareVarsTemplate = filepath.Join(dir, "OVMF_VARS.fd")
+	assert.NoError(ioutil.WriteFile(qemuConfig.FirmwarePath, []byte("code"), 0644))
+	assert.NoError(ioutil.WriteFile(qemuConfig.FirmwareVarsTemplate, []byte("template"), 0644))
+
+	store, err := persist.GetDriver()
+	assert.NoError(err)
+	q := &qemu{
+		store: store,
+	}
+	sandboxID := "testSandbox"
+
+	testQemuPath := filepath.Join(testDir, testHypervisor)
+	_, err = os.Create(testQemuPath)
+	assert.NoError(err)
+
+	parentDir := filepath.Join(q.store.RunStoragePath(), sandboxID)
+	defer os.RemoveAll(parentDir)
+
+	err = q.createSandbox(context.Background(), sandboxID, NetworkNamespace{}, &qemuConfig)
+	assert.NoError(err)
+
+	varsPath := filepath.Join(parentDir, firmwareVarsFile)
+	assert.Empty(q.qemuConfig.Bios)
+	code := pflashDrive{Unit: 0, File: qemuConfig.FirmwarePath, ReadOnly: true}
+	vars := pflashDrive{Unit: 1, File: varsPath}
+	assert.Contains(q.qemuConfig.Devices, code)
+	assert.Contains(q.qemuConfig.Devices, vars)
+	assert.Equal([]string{"-drive", "if=pflash,format=raw,unit=0,file=" + qemuConfig.FirmwarePath + ",readonly=on"},
+		code.QemuParams(&govmmQemu.Config{}))
+	assert.Equal([]string{"-drive", "if=pflash,format=raw,unit=1,file=" + varsPath},
+		vars.QemuParams(&govmmQemu.Config{}))
+
+	content, err := ioutil.ReadFile(varsPath)
+	assert.NoError(err)
+	assert.Equal("template", string(content))
+
+	// The variables the guest changed are kept when the VM is created again.
+	assert.NoError(ioutil.WriteFile(varsPath, []byte("enrolled"), 0644))
+	path, err := q.firmwareVars()
+	assert.NoError(err)
+	assert.Equal(varsPath, path)
+
+	content, err = ioutil.ReadFile(varsPath)
+	assert.NoError(err)
+	assert.Equal("enrolled", string(content))
+}
+
 func TestQemuCreateSandboxMissingParentDirFail(t *testing.T) {
 	qemuConfig := newQemuConfig()
 	assert := assert.New(t)
@@ -328,6 +401,18 @@ func TestQemuCapabilities(t *testing.T) {
 
 	caps := q.capabilities()
 	assert.True(caps.IsBlockDeviceHotplugSupported())
+	assert.True(caps.IsBlockDeviceHotUnplugSupported())
+	assert.True(caps.IsVFIOHotplugSupported())
+	assert.False(caps.IsMemoryHotUnplugSupported())
+
+	q.config.VirtioMem = true
+	caps = q.capabilities()
+	assert.True(caps.IsMemoryHotUnplugSupported())
+	assert.False(caps.IsVirtioFSHotplugSupported())
+
+	q.config.SharedFS = config.VirtioFS
+	caps = q.capabilities()
+	assert.True(caps.IsVirtioFSHotplugSupported())
 }
 
 func TestQemuQemuPath(t *testing.T) {
@@ -557,6 +642,11 @@ func TestQemuVirtiofsdArgs(t *testing.T) {
 	result = "--fd=123 -o source=test-share-dir/foo/shared -o cache=none --syslog -o no_posix_lock -f"
 	args = q.virtiofsdArgs(123)
 	assert.Equal(strings.Join(args, " "), result)
+
+	// The daemons of the virtio-fs shares get their own options
+	result = "--fd=123 -o source=/data -o cache=always --syslog -o no_posix_lock -o xattr -f"
+	args = q.virtiofsdShareArgs(123, "/data", "always", true)
+	assert.Equal(strings.Join(args, " "), result)
 }
 
 func TestQemuGetpids(t *testing.T) {
@@ -595,3 +685,166 @@ func TestQemuGetpids(t *testing.T) {
 	assert.True(pids[0] == 100)
 	assert.True(pids[1] == 200)
 }
+
+func TestQemuPRManagerHelper(t *testing.T) {
+	assert := assert.New(t)
+
+	qemuConfig := newQemuConfig()
+	qemuConfig.PRHelperSocket = "/run/qemu-pr-helper.sock"
+
+	store, err := persist.GetDriver()
+	assert.NoError(err)
+	q := &qemu{
+		store: store,
+	}
+
+	testQemuPath := filepath.Join(testDir, testHypervisor)
+	_, err = os.Create(testQemuPath)
+	assert.NoError(err)
+
+	parentDir := filepath.Join(q.store.RunStoragePath(), "testSandbox")
+	assert.NoError(os.MkdirAll(parentDir, DirMode))
+	defer os.RemoveAll(parentDir)
+
+	err = q.createSandbox(context.Background(), "testSandbox", NetworkNamespace{}, &qemuConfig)
+	assert.NoError(err)
+
+	helper := prManagerHelper{ID: prManagerHelperID, Path: qemuConfig.PRHelperSocket}
+	assert.Contains(q.qemuConfig.Devices, helper)
+	assert.Equal([]string{"-object", "pr-manager-helper,id=pr-helper0,path=/run/qemu-pr-helper.sock"}, helper.QemuParams(nil))
+
+	assert.False(prManagerHelper{ID: prManagerHelperID}.Valid())
+}
+
+func TestQemuFreePageReporting(t *testing.T) {
+	assert := assert.New(t)
+
+	qemuConfig := newQemuConfig()
+	qemuConfig.FreePageReporting = true
+
+	store, err := persist.GetDriver()
+	assert.NoError(err)
+	q := &qemu{
+		store: store,
+	}
+
+	testQemuPath := filepath.Join(testDir, testHypervisor)
+	_, err = os.Create(testQemuPath)
+	assert.NoError(err)
+
+	parentDir := filepath.Join(q.store.RunStoragePath(), "testSandbox")
+	assert.NoError(os.MkdirAll(parentDir, DirMode))
+	defer os.RemoveAll(parentDir)
+
+	err = q.createSandbox(context.Background(), "testSandbox", NetworkNamespace{}, &qemuConfig)
+	assert.NoError(err)
+
+	balloon := reportingBalloon{govmmQemu.BalloonDevice{ID: balloonID, DeflateOnOOM: true}}
+	assert.Contains(q.qemuConfig.Devices, balloon)
+
+	params := balloon.QemuParams(&q.qemuConfig)
+	assert.Equal("-device", params[0])
+	assert.Regexp(`^virtio-balloon-\w+,id=balloon0,.*deflate-on-oom=on.*,free-page-reporting=on$`, params[1])
+}
+
+func TestQemuGPUDirectClique(t *testing.T) {
+	assert := assert.New(t)
+
+	q := &qemu{
+		config: newQemuConfig(),
+	}
+
+	gpu := config.VFIODev{BDF: "03:00.0", Vendor: "0x10de", Class: "0x030200"}
+	nic := config.VFIODev{BDF: "04:00.0", Vendor: "0x15b3", Class: "0x020700"}
+
+	q.setGPUDirectClique(&gpu)
+	assert.Empty(gpu.GPUDirectClique)
+
+	q.config.VFIOPeerToPeer = true
+	q.setGPUDirectClique(&gpu)
+	q.setGPUDirectClique(&nic)
+	assert.Equal("0", gpu.GPUDirectClique)
+	assert.Empty(nic.GPUDirectClique)
+
+	devices := (&qemuArchBase{}).appendVFIODevice(nil, gpu)
+	assert.Equal([]string{"-device", "vfio-pci,host=03:00.0,romfile=,x-nv-gpudirect-clique=0"}, devices[0].QemuParams(&govmmQemu.Config{}))
+}
+
+func TestQemuGuestNUMANodes(t *testing.T) {
+	assert := assert.New(t)
+
+	q := &qemu{
+		config: newQemuConfig(),
+	}
+	q.config.MemorySize = 2049
+	q.config.GuestNUMANodes = []GuestNUMANode{
+		{HostNode: 1, PCIDevices: []string{"3b:00.0"}},
+		{HostNode: 3, PCIDevices: []string{"d8:00.0", "d8:00.1"}},
+	}
+
+	q35 := govmmQemu.Machine{Type: QemuQ35}
+	smp := govmmQemu.SMP{CPUs: 4, MaxCPUs: 7}
+
+	nodes, err := q.guestNUMANodes(q35, smp)
+	assert.NoError(err)
+	assert.Equal([]qemuNUMANode{
+		{Size: "1025M", CPUs: []string{"0-1", "4-5"}, HostNodes: "1"},
+		{Size: "1024M", CPUs: []string{"2-3", "6"}, HostNodes: "3"},
+	}, nodes)
+
+	memory := qemuGuestNUMAMemory{Size: "2049M", Nodes: nodes}
+	assert.True(memory.Valid())
+	assert.Equal([]string{
+		"-m", "2049M,slots=10,maxmem=8G",
+		"-object", "memory-backend-file,id=dimm1,size=1025M,mem-path=/dev/hugepages,prealloc=on,host-nodes=1,policy=bind",
+		"-numa", "node,nodeid=0,cpus=0-1,cpus=4-5,memdev=dimm1",
+		"-object", "memory-backend-file,id=dimm2,size=1024M,mem-path=/dev/hugepages,prealloc=on,host-nodes=3,policy=bind",
+		"-numa", "node,nodeid=1,cpus=2-3,cpus=6,memdev=dimm2",
+	}, memory.QemuParams(&govmmQemu.Config{
+		Memory: govmmQemu.Memory{Slots: 10, MaxMem: "8G"},
+		Knobs:  govmmQemu.Knobs{HugePages: true, MemPrealloc: true},
+	}))
+
+	_, err = q.guestNUMANodes(govmmQemu.Machine{Type: QemuPC}, smp)
+	assert.Error(err)
+
+	_, err = q.guestNUMANodes(q35, govmmQemu.SMP{CPUs: 1, MaxCPUs: 4})
+	assert.Error(err)
+
+	assert.Equal("numa1rp1", q.guestNUMARootPort(&config.VFIODev{Type: config.VFIODeviceNormalType, BDF: "d8:00.1"}))
+	assert.Empty(q.guestNUMARootPort(&config.VFIODev{Type: config.VFIODeviceNormalType, BDF: "05:00.0"}))
+
+	var params []string
+	for _, d := range appendGuestNUMABridges(nil, q.config.GuestNUMANodes) {
+		params = append(params, d.QemuParams(&govmmQemu.Config{})...)
+	}
+	assert.Equal([]string{
+		"-device", "pxb-pcie,id=pxb0,bus=pcie.0,bus_nr=128,numa_node=0",
+		"-device", "pcie-root-port,id=numa0rp0,bus=pxb0,chassis=128,slot=0,multifunction=off",
+		"-device", "pxb-pcie,id=pxb1,bus=pcie.0,bus_nr=144,numa_node=1",
+		"-device", "pcie-root-port,id=numa1rp0,bus=pxb1,chassis=129,slot=0,multifunction=off",
+		"-device", "pcie-root-port,id=numa1rp1,bus=pxb1,chassis=129,slot=1,multifunction=off",
+	}, params)
+}
+
+func TestQemuBlockCacheOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	data := []struct {
+		mode       string
+		direct     bool
+		writeCache bool
+	}{
+		{"", false, true},
+		{config.BlockCacheNone, true, true},
+		{config.BlockCacheWriteback, false, true},
+		{config.BlockCacheWritethrough, false, false},
+		{config.BlockCacheDirectSync, true, false},
+	}
+
+	for _, d := range data {
+		direct, writeCache := blockCacheOptions(d.mode)
+		assert.Equal(d.direct, direct, d.mode)
+		assert.Equal(d.writeCache, writeCache, d.mode)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+// ResizePolicy controls how eagerly a sandbox is shrunk when the resources
+// requested by its containers go down. Growing a sandbox is never delayed.
+//
+// Debouncing shrinks prevents bursts of container updates from causing
+// repeated CPU and memory hotplug/unplug cycles in the guest.
+type ResizePolicy struct {
+	// ShrinkCooldown is the minimum time between the last resize of the
+	// sandbox and a shrink.
+	ShrinkCooldown time.Duration
+
+	// VCPUShrinkThreshold is the minimum number of vCPUs a shrink must
+	// remove for it to be applied.
+	VCPUShrinkThreshold uint32
+
+	// MemoryShrinkThresholdMB is the minimum amount of memory, in MiB, a
+	// shrink must remove for it to be applied.
+	MemoryShrinkThresholdMB uint32
+}
+
+// resizeState records the last sandbox size applied by updateResources.
+type resizeState struct {
+	vcpus      uint32
+	memoryMB   uint32
+	lastResize time.Time
+}
+
+// debounce returns the vCPUs and memory the sandbox should be resized to
+// for a request of vcpus and memoryMB at time now. It also returns when the
+// shrinks held back by the cooldown can be applied, the zero time if none
+// is.
+//
+// A shrink held back by the thresholds is applied along with the next
+// resize request that passes the policy.
+func (r *resizeState) debounce(policy ResizePolicy, vcpus, memoryMB uint32, now time.Time) (uint32, uint32, time.Time) {
+	var retry time.Time
+
+	cooling := !r.lastResize.IsZero() && now.Sub(r.lastResize) < policy.ShrinkCooldown
+
+	if vcpus < r.vcpus {
+		if r.vcpus-vcpus < policy.VCPUShrinkThreshold {
+			vcpus = r.vcpus
+		} else if cooling {
+			vcpus = r.vcpus
+			retry = r.lastResize.Add(policy.ShrinkCooldown)
+		}
+	}
+
+	if memoryMB < r.memoryMB {
+		if r.memoryMB-memoryMB < policy.MemoryShrinkThresholdMB {
+			memoryMB = r.memoryMB
+		} else if cooling {
+			memoryMB = r.memoryMB
+			retry = r.lastResize.Add(policy.ShrinkCooldown)
+		}
+	}
+
+	return vcpus, memoryMB, retry
+}
+
+// recordVCPUs records the sandbox vCPUs were resized to vcpus at time now.
+func (r *resizeState) recordVCPUs(vcpus uint32, now time.Time) {
+	if vcpus != r.vcpus {
+		r.vcpus = vcpus
+		r.lastResize = now
+	}
+}
+
+// recordMemory records the sandbox memory was resized to memoryMB at time
+// now.
+func (r *resizeState) recordMemory(memoryMB uint32, now time.Time) {
+	if memoryMB != r.memoryMB {
+		r.memoryMB = memoryMB
+		r.lastResize = now
+	}
+}
+
+// EnableDeferredShrinks has the shrinks held back by the cooldown of the
+// sandbox resize policy applied as soon as the cooldown elapses, rather
+// than along with the next resize. The deferred shrinks are applied with
+// lock held, which the caller must hold as well whenever it calls the
+// other methods of the sandbox.
+func (s *Sandbox) EnableDeferredShrinks(lock sync.Locker) {
+	s.shrinkLock = lock
+
+	// A shrink may have been held back by the process the sandbox was
+	// fetched from.
+	if !s.resize.lastResize.IsZero() && s.config.ResizePolicy.ShrinkCooldown > 0 {
+		s.scheduleShrink(s.resize.lastResize.Add(s.config.ResizePolicy.ShrinkCooldown))
+	}
+}
+
+// scheduleShrink arms the timer applying the shrinks held back until at,
+// when deferred shrinks are enabled, or disarms it when at is zero.
+func (s *Sandbox) scheduleShrink(at time.Time) {
+	if s.shrinkTimer != nil {
+		s.shrinkTimer.Stop()
+		s.shrinkTimer = nil
+	}
+
+	if s.shrinkLock == nil || at.IsZero() {
+		return
+	}
+
+	s.shrinkTimer = time.AfterFunc(time.Until(at), s.applyDeferredShrink)
+}
+
+// applyDeferredShrink resizes the sandbox to the resources of its
+// containers, now that the cooldown of the shrinks held back elapsed.
+func (s *Sandbox) applyDeferredShrink() {
+	s.shrinkLock.Lock()
+	defer s.shrinkLock.Unlock()
+
+	// The sandbox may have been stopped while the lock was waited for.
+	if s.state.State != types.StateRunning {
+		return
+	}
+
+	if err := s.updateResources(); err != nil {
+		s.Logger().WithError(err).Warn("Could not apply the deferred sandbox shrink")
+		return
+	}
+
+	if err := s.Save(); err != nil {
+		s.Logger().WithError(err).Warn("Could not save the sandbox state after a deferred shrink")
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResizeStateDebounce(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	r := resizeState{}
+
+	// No policy: follow every request.
+	vcpus, mem, retry := r.debounce(ResizePolicy{}, 4, 4096, now)
+	assert.Equal(uint32(4), vcpus)
+	assert.Equal(uint32(4096), mem)
+	assert.True(retry.IsZero())
+	r.recordVCPUs(vcpus, now)
+	r.recordMemory(mem, now)
+
+	vcpus, mem, retry = r.debounce(ResizePolicy{}, 2, 2048, now)
+	assert.Equal(uint32(2), vcpus)
+	assert.Equal(uint32(2048), mem)
+	assert.True(retry.IsZero())
+	r.recordVCPUs(vcpus, now)
+	r.recordMemory(mem, now)
+
+	policy := ResizePolicy{
+		ShrinkCooldown:          time.Minute,
+		VCPUShrinkThreshold:     2,
+		MemoryShrinkThresholdMB: 1024,
+	}
+
+	// Growing is immediate.
+	vcpus, mem, retry = r.debounce(policy, 8, 8192, now)
+	assert.Equal(uint32(8), vcpus)
+	assert.Equal(uint32(8192), mem)
+	assert.True(retry.IsZero())
+
+	// Nothing is recorded until the resize succeeded.
+	assert.Equal(uint32(2), r.vcpus)
+	assert.Equal(uint32(2048), r.memoryMB)
+	r.recordVCPUs(vcpus, now)
+	r.recordMemory(mem, now)
+
+	// Shrinking within the cooldown is held back until the cooldown
+	// elapses.
+	vcpus, mem, retry = r.debounce(policy, 2, 2048, now.Add(30*time.Second))
+	assert.Equal(uint32(8), vcpus)
+	assert.Equal(uint32(8192), mem)
+	assert.Equal(now.Add(time.Minute), retry)
+
+	// Shrinking below the thresholds is held back, without retry.
+	later := now.Add(2 * time.Minute)
+	vcpus, mem, retry = r.debounce(policy, 7, 7680, later)
+	assert.Equal(uint32(8), vcpus)
+	assert.Equal(uint32(8192), mem)
+	assert.True(retry.IsZero())
+
+	// Shrinks are applied independently once past the cooldown.
+	vcpus, mem, retry = r.debounce(policy, 6, 7680, later)
+	assert.Equal(uint32(6), vcpus)
+	assert.Equal(uint32(8192), mem)
+	assert.True(retry.IsZero())
+	r.recordVCPUs(vcpus, later)
+	r.recordMemory(mem, later)
+
+	// The vCPUs shrink restarted the cooldown.
+	vcpus, mem, retry = r.debounce(policy, 6, 4096, later.Add(time.Second))
+	assert.Equal(uint32(6), vcpus)
+	assert.Equal(uint32(8192), mem)
+	assert.Equal(later.Add(time.Minute), retry)
+
+	vcpus, mem, retry = r.debounce(policy, 6, 4096, later.Add(2*time.Minute))
+	assert.Equal(uint32(6), vcpus)
+	assert.Equal(uint32(4096), mem)
+	assert.True(retry.IsZero())
+}
+
+func TestSandboxDeferredShrink(t *testing.T) {
+	assert := assert.New(t)
+
+	hConfig := newHypervisorConfig(nil, nil)
+
+	defer cleanUp()
+	s, err := testCreateSandbox(t,
+		testSandboxID,
+		MockHypervisor,
+		hConfig,
+		NetworkConfig{},
+		[]ContainerConfig{newTestContainerConfigNoop("cont-00001")},
+		nil)
+	assert.NoError(err)
+
+	s.state.State = types.StateRunning
+	s.config.ResizePolicy.ShrinkCooldown = 50 * time.Millisecond
+
+	var lock sync.Mutex
+	s.EnableDeferredShrinks(&lock)
+
+	lock.Lock()
+	err = s.updateResources()
+	assert.NoError(err)
+	vcpus := s.resize.vcpus
+
+	// Pretend the sandbox was resized larger than needed right before.
+	s.resize.recordVCPUs(vcpus+1, time.Now())
+	err = s.updateResources()
+	assert.NoError(err)
+	assert.Equal(vcpus+1, s.resize.vcpus)
+	assert.NotNil(s.shrinkTimer)
+	lock.Unlock()
+
+	assert.Eventually(func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return s.resize.vcpus == vcpus
+	}, time.Second, 10*time.Millisecond)
+
+	s.scheduleShrink(time.Time{})
+	assert.Nil(s.shrinkTimer)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+)
+
+// ResourceCeilings are host-side upper bounds on the resources a sandbox
+// can ever be given. They are set by the administrator through the runtime
+// configuration only, and are enforced on every operation growing the
+// sandbox whatever the OCI annotations or container resources request.
+//
+// A zero value means no ceiling.
+type ResourceCeilings struct {
+	// MaxMemoryMB is the maximum guest memory, hotplugged memory
+	// included, in MiB.
+	MaxMemoryMB uint32
+
+	// MaxVCPUs is the maximum number of guest vCPUs, hotplugged vCPUs
+	// included.
+	MaxVCPUs uint32
+
+	// MaxHotplugDevices is the maximum number of devices that can be
+	// hotplugged into the guest at the same time.
+	MaxHotplugDevices uint32
+
+	// MaxInterfaces is the maximum number of network interfaces the
+	// sandbox can have.
+	MaxInterfaces uint32
+}
+
+// checkResourceCeilings verifies the initial sandbox sizing is within the
+// ceilings, and lowers the maximum vCPUs the hypervisor is configured with
+// so that vCPU hotplug can never exceed the ceiling.
+func checkResourceCeilings(ceilings ResourceCeilings, conf *HypervisorConfig) error {
+	if ceilings.MaxVCPUs > 0 {
+		if conf.NumVCPUs > ceilings.MaxVCPUs {
+			return newConfigFieldError("MaxVCPUs", fmt.Sprintf("Sandbox vCPUs %d exceed the %d vCPUs ceiling", conf.NumVCPUs, ceilings.MaxVCPUs))
+		}
+
+		if conf.DefaultMaxVCPUs == 0 || conf.DefaultMaxVCPUs > ceilings.MaxVCPUs {
+			conf.DefaultMaxVCPUs = ceilings.MaxVCPUs
+		}
+	}
+
+	if ceilings.MaxMemoryMB > 0 && conf.MemorySize > ceilings.MaxMemoryMB {
+		return newConfigFieldError("MaxMemoryMB", fmt.Sprintf("Sandbox memory %d MiB exceeds the %d MiB ceiling", conf.MemorySize, ceilings.MaxMemoryMB))
+	}
+
+	return nil
+}
+
+// checkResize verifies a sandbox resize request is within the
+// ceilings.
+func (c ResourceCeilings) checkResize(vcpus, memoryMB uint32) error {
+	if c.MaxVCPUs > 0 && vcpus > c.MaxVCPUs {
+		return fmt.Errorf("Requested %d vCPUs exceed the %d vCPUs ceiling", vcpus, c.MaxVCPUs)
+	}
+
+	if c.MaxMemoryMB > 0 && memoryMB > c.MaxMemoryMB {
+		return fmt.Errorf("Requested %d MiB of memory exceed the %d MiB ceiling", memoryMB, c.MaxMemoryMB)
+	}
+
+	return nil
+}
+
+// checkInterfaces verifies a sandbox having count network interfaces is
+// within the ceilings.
+func (c ResourceCeilings) checkInterfaces(count int) error {
+	if c.MaxInterfaces > 0 && count > int(c.MaxInterfaces) {
+		return fmt.Errorf("%d network interfaces exceed the %d interfaces ceiling", count, c.MaxInterfaces)
+	}
+
+	return nil
+}
+
+// isHotplugDeviceType returns true for the device types that are
+// hotplugged into the guest when attached.
+func isHotplugDeviceType(devType config.DeviceType) bool {
+	switch devType {
+	case config.DeviceVFIO, config.DeviceBlock, config.VhostUserBlk:
+		return true
+	default:
+		return false
+	}
+}
+
+// checkHotplugDevices verifies hotplugging the device id on top of the
+// already hotplugged devices is within the ceilings.
+func (c ResourceCeilings) checkHotplugDevices(hotplugged map[string]struct{}, id string) error {
+	if c.MaxHotplugDevices == 0 {
+		return nil
+	}
+
+	count := len(hotplugged)
+	if _, ok := hotplugged[id]; !ok {
+		count++
+	}
+
+	if count > int(c.MaxHotplugDevices) {
+		return fmt.Errorf("Hotplugging device %s exceeds the %d hotplugged devices ceiling", id, c.MaxHotplugDevices)
+	}
+
+	return nil
+}
+
+// hotpluggedDeviceIDs returns the set of attached devices that have been
+// hotplugged into the guest.
+func hotpluggedDeviceIDs(devices []api.Device) map[string]struct{} {
+	hotplugged := make(map[string]struct{})
+
+	for _, d := range devices {
+		if d.GetAttachCount() > 0 && isHotplugDeviceType(d.DeviceType()) {
+			hotplugged[d.DeviceID()] = struct{}{}
+		}
+	}
+
+	return hotplugged
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/drivers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckResourceCeilings(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := &HypervisorConfig{
+		NumVCPUs:        2,
+		DefaultMaxVCPUs: 8,
+		MemorySize:      2048,
+	}
+
+	// No ceiling
+	assert.NoError(checkResourceCeilings(ResourceCeilings{}, conf))
+	assert.Equal(uint32(8), conf.DefaultMaxVCPUs)
+
+	assert.NoError(checkResourceCeilings(ResourceCeilings{MaxVCPUs: 4, MaxMemoryMB: 4096}, conf))
+	assert.Equal(uint32(4), conf.DefaultMaxVCPUs)
+
+	assert.Error(checkResourceCeilings(ResourceCeilings{MaxVCPUs: 1}, conf))
+	assert.Error(checkResourceCeilings(ResourceCeilings{MaxMemoryMB: 1024}, conf))
+}
+
+func TestResourceCeilingsCheckResize(t *testing.T) {
+	assert := assert.New(t)
+
+	c := ResourceCeilings{}
+	assert.NoError(c.checkResize(100, 1<<20))
+
+	c = ResourceCeilings{MaxVCPUs: 4, MaxMemoryMB: 4096}
+	assert.NoError(c.checkResize(4, 4096))
+	assert.Error(c.checkResize(5, 4096))
+	assert.Error(c.checkResize(4, 4097))
+}
+
+func TestResourceCeilingsCheckInterfaces(t *testing.T) {
+	assert := assert.New(t)
+
+	c := ResourceCeilings{}
+	assert.NoError(c.checkInterfaces(100))
+
+	c.MaxInterfaces = 2
+	assert.NoError(c.checkInterfaces(2))
+	assert.Error(c.checkInterfaces(3))
+}
+
+func TestResourceCeilingsCheckHotplugDevices(t *testing.T) {
+	assert := assert.New(t)
+
+	hotplugged := map[string]struct{}{"block1": {}}
+
+	c := ResourceCeilings{}
+	assert.NoError(c.checkHotplugDevices(hotplugged, "vfio"))
+
+	c.MaxHotplugDevices = 2
+	assert.NoError(c.checkHotplugDevices(hotplugged, "vfio"))
+
+	c.MaxHotplugDevices = 1
+	assert.Error(c.checkHotplugDevices(hotplugged, "vfio"))
+
+	// The device being hotplugged is not counted twice.
+	assert.NoError(c.checkHotplugDevices(hotplugged, "block1"))
+}
+
+func TestHotpluggedDeviceIDs(t *testing.T) {
+	assert := assert.New(t)
+
+	block1 := drivers.NewBlockDevice(&config.DeviceInfo{ID: "block1"})
+	block1.AttachCount = 1
+	block2 := drivers.NewBlockDevice(&config.DeviceInfo{ID: "block2"})
+	generic := drivers.NewGenericDevice(&config.DeviceInfo{ID: "generic"})
+	generic.AttachCount = 1
+	vfio := drivers.NewVFIODevice(&config.DeviceInfo{ID: "vfio"})
+	vfio.AttachCount = 2
+
+	// Detached and generic devices are not hotplugged.
+	hotplugged := hotpluggedDeviceIDs([]api.Device{block1, block2, generic, vfio})
+	assert.Equal(map[string]struct{}{"block1": {}, "vfio": {}}, hotplugged)
+}
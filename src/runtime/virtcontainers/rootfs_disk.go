@@ -0,0 +1,245 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"unsafe"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// RootfsDiskExt4 converts the rootfs to a writable ext4 disk.
+	RootfsDiskExt4 = "ext4"
+
+	// RootfsDiskErofs converts the rootfs to a read-only erofs disk.
+	RootfsDiskErofs = "erofs"
+
+	// ext4 disks are sized after the rootfs, with some headroom for the
+	// filesystem metadata and for the container to write to.
+	rootfsDiskSlackRatio = 1.5
+	rootfsDiskMinSize    = 64 * 1024 * 1024
+
+	loopControlPath = "/dev/loop-control"
+)
+
+// RootfsDisk configures the conversion of the rootfs of the sandbox
+// container to a disk image, attached to the VM as a block device in place
+// of sharing the rootfs directory with the guest.
+type RootfsDisk struct {
+	// Fstype is the filesystem of the disk, RootfsDiskExt4 or
+	// RootfsDiskErofs. The conversion is disabled when empty.
+	Fstype string
+
+	// Converter is the command converting the rootfs, run with the rootfs
+	// directory, the disk image path and the filesystem type as arguments.
+	// The mkfs tool of the filesystem is used when empty.
+	Converter string
+}
+
+func (d RootfsDisk) enabled() bool {
+	return d.Fstype != ""
+}
+
+func (d RootfsDisk) validate() error {
+	switch d.Fstype {
+	case "", RootfsDiskExt4, RootfsDiskErofs:
+	default:
+		return newConfigFieldError("Fstype", fmt.Sprintf("Invalid rootfs disk filesystem %q", d.Fstype))
+	}
+
+	if d.Converter != "" && !filepath.IsAbs(d.Converter) {
+		return newConfigFieldError("Converter", fmt.Sprintf("Rootfs disk converter %q must be an absolute path", d.Converter))
+	}
+
+	return nil
+}
+
+// rootfsConverter converts a rootfs directory to a disk image.
+type rootfsConverter interface {
+	convert(rootfs, image, fstype string) error
+}
+
+func (d RootfsDisk) converter() rootfsConverter {
+	if d.Converter != "" {
+		return commandConverter{path: d.Converter}
+	}
+	return mkfsConverter{}
+}
+
+// commandConverter runs an external converter.
+type commandConverter struct {
+	path string
+}
+
+func (cc commandConverter) convert(rootfs, image, fstype string) error {
+	return runConverter(cc.path, rootfs, image, fstype)
+}
+
+// mkfsConverter creates the filesystem from the rootfs with its mkfs tool.
+type mkfsConverter struct{}
+
+func (mkfsConverter) convert(rootfs, image, fstype string) error {
+	switch fstype {
+	case RootfsDiskExt4:
+		size, err := dirSize(rootfs)
+		if err != nil {
+			return err
+		}
+
+		size = int64(float64(size) * rootfsDiskSlackRatio)
+		if size < rootfsDiskMinSize {
+			size = rootfsDiskMinSize
+		}
+
+		f, err := os.OpenFile(image, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		err = f.Truncate(size)
+		f.Close()
+		if err != nil {
+			return err
+		}
+
+		return runConverter("mkfs.ext4", "-q", "-F", "-d", rootfs, image)
+	case RootfsDiskErofs:
+		return runConverter("mkfs.erofs", image, rootfs)
+	}
+
+	return fmt.Errorf("Invalid rootfs disk filesystem %q", fstype)
+}
+
+func runConverter(path string, args ...string) error {
+	out, err := exec.Command(path, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("rootfs conversion with %s failed: %v: %s", path, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// dirSize returns the size of the regular files under a directory.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// attachLoopDevice attaches a file to a free loop device, read-only if
+// readOnly is true. The loop device is detached as soon as the returned
+// file, and any later opener of the device, closes it.
+func attachLoopDevice(path string, readOnly bool) (string, *os.File, error) {
+	flags := os.O_RDWR
+	if readOnly {
+		flags = os.O_RDONLY
+	}
+
+	backing, err := os.OpenFile(path, flags, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	defer backing.Close()
+
+	control, err := os.OpenFile(loopControlPath, os.O_RDWR, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	defer control.Close()
+
+	index, err := unix.IoctlRetInt(int(control.Fd()), unix.LOOP_CTL_GET_FREE)
+	if err != nil {
+		return "", nil, fmt.Errorf("could not find a free loop device: %v", err)
+	}
+
+	loopPath := fmt.Sprintf("/dev/loop%d", index)
+	loop, err := os.OpenFile(loopPath, flags, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := unix.IoctlSetInt(int(loop.Fd()), unix.LOOP_SET_FD, int(backing.Fd())); err != nil {
+		loop.Close()
+		return "", nil, fmt.Errorf("could not attach %s to %s: %v", path, loopPath, err)
+	}
+
+	info := unix.LoopInfo64{Flags: unix.LO_FLAGS_AUTOCLEAR}
+	copy(info.File_name[:unix.LO_NAME_SIZE-1], path)
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, loop.Fd(), unix.LOOP_SET_STATUS64, uintptr(unsafe.Pointer(&info))); errno != 0 {
+		unix.IoctlSetInt(int(loop.Fd()), unix.LOOP_CLR_FD, 0)
+		loop.Close()
+		return "", nil, fmt.Errorf("could not set %s status: %v", loopPath, errno)
+	}
+
+	return loopPath, loop, nil
+}
+
+// plugRootfsDisk converts the rootfs of the container to a disk image and
+// hotplugs it as the container rootfs. The image is only referenced by the
+// loop device backing the drive, so that both go away with the drive.
+func (c *Container) plugRootfsDisk() error {
+	disk := c.sandbox.config.RootfsDisk
+
+	image := filepath.Join(c.sandbox.newStore.RunVMStoragePath(), c.sandbox.id, "rootfs-"+c.id+".img")
+	if err := os.MkdirAll(filepath.Dir(image), DirMode); err != nil {
+		return err
+	}
+	defer os.Remove(image)
+
+	c.Logger().WithFields(logrus.Fields{
+		"rootfs": c.rootFs.Target,
+		"fstype": disk.Fstype,
+	}).Info("converting rootfs to a disk")
+
+	if err := disk.converter().convert(c.rootFs.Target, image, disk.Fstype); err != nil {
+		return err
+	}
+
+	loopPath, loop, err := attachLoopDevice(image, disk.Fstype == RootfsDiskErofs)
+	if err != nil {
+		return err
+	}
+	// Closing after the hypervisor has opened the device
+	defer loop.Close()
+
+	if err := c.plugDevice(loopPath); err != nil {
+		return err
+	}
+
+	// there is no "rootfs" dir on block device backed rootfs
+	c.rootfsSuffix = ""
+
+	return c.setStateFstype(disk.Fstype)
+}
+
+// useRootfsDisk returns true if the rootfs of the container is to be
+// converted to a disk: only the rootfs directory of the sandbox container
+// is, if the guest can mount block devices.
+func (c *Container) useRootfsDisk() bool {
+	if !c.sandbox.config.RootfsDisk.enabled() || c.id != c.sandbox.id || !c.rootFs.Mounted {
+		return false
+	}
+
+	if !c.checkBlockDeviceSupport() {
+		c.Logger().Warn("Block devices not supported, sharing the rootfs in place of converting it to a disk")
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	ktu "github.com/kata-containers/kata-containers/src/runtime/pkg/katatestutils"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+func TestRootfsDiskValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(RootfsDisk{}.validate())
+	assert.NoError(RootfsDisk{Fstype: RootfsDiskExt4}.validate())
+	assert.NoError(RootfsDisk{Fstype: RootfsDiskErofs, Converter: "/usr/bin/convert"}.validate())
+	assert.Error(RootfsDisk{Fstype: "btrfs"}.validate())
+	assert.Error(RootfsDisk{Fstype: RootfsDiskExt4, Converter: "convert"}.validate())
+
+	assert.False(RootfsDisk{}.enabled())
+	assert.True(RootfsDisk{Fstype: RootfsDiskExt4}.enabled())
+
+	assert.Equal(mkfsConverter{}, RootfsDisk{Fstype: RootfsDiskExt4}.converter())
+	assert.Equal(commandConverter{path: "/bin/convert"}, RootfsDisk{Fstype: RootfsDiskExt4, Converter: "/bin/convert"}.converter())
+}
+
+func TestDirSize(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "rootfs")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(os.MkdirAll(filepath.Join(dir, "bin"), 0700))
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, "bin", "sh"), make([]byte, 1000), 0700))
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, "hostname"), make([]byte, 24), 0600))
+	assert.NoError(os.Symlink("bin/sh", filepath.Join(dir, "sh")))
+
+	size, err := dirSize(dir)
+	assert.NoError(err)
+	assert.Equal(int64(1024), size)
+
+	_, err = dirSize(filepath.Join(dir, "missing"))
+	assert.Error(err)
+}
+
+func TestCommandConverter(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "converter")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	converter := filepath.Join(dir, "convert")
+	script := "#!/bin/sh\n[ \"$3\" = erofs ] || { echo bad fstype >&2; exit 1; }\necho \"$1\" > \"$2\"\n"
+	assert.NoError(ioutil.WriteFile(converter, []byte(script), 0700))
+
+	image := filepath.Join(dir, "rootfs.img")
+	cc := commandConverter{path: converter}
+	assert.NoError(cc.convert("/rootfs", image, RootfsDiskErofs))
+
+	data, err := ioutil.ReadFile(image)
+	assert.NoError(err)
+	assert.Equal("/rootfs\n", string(data))
+
+	err = cc.convert("/rootfs", image, RootfsDiskExt4)
+	assert.Error(err)
+	assert.Contains(err.Error(), "bad fstype")
+}
+
+func TestMkfsConverterExt4(t *testing.T) {
+	assert := assert.New(t)
+
+	if _, err := exec.LookPath("mkfs.ext4"); err != nil {
+		t.Skip("mkfs.ext4 not found")
+	}
+
+	dir, err := ioutil.TempDir("", "converter")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	rootfs := filepath.Join(dir, "rootfs")
+	assert.NoError(os.MkdirAll(filepath.Join(rootfs, "etc"), 0700))
+	assert.NoError(ioutil.WriteFile(filepath.Join(rootfs, "etc", "hostname"), []byte("kata"), 0600))
+
+	image := filepath.Join(dir, "rootfs.img")
+	assert.NoError(mkfsConverter{}.convert(rootfs, image, RootfsDiskExt4))
+
+	fi, err := os.Stat(image)
+	assert.NoError(err)
+	assert.Equal(int64(rootfsDiskMinSize), fi.Size())
+
+	assert.Error(mkfsConverter{}.convert(rootfs, image, "btrfs"))
+}
+
+func TestAttachLoopDevice(t *testing.T) {
+	assert := assert.New(t)
+	if tc.NotValid(ktu.NeedRoot()) {
+		t.Skip(testDisabledAsNonRoot)
+	}
+
+	if _, err := os.Stat(loopControlPath); err != nil {
+		t.Skip("loop devices not supported")
+	}
+
+	f, err := ioutil.TempFile("", "disk")
+	assert.NoError(err)
+	defer os.Remove(f.Name())
+	assert.NoError(f.Truncate(1024 * 1024))
+	f.Close()
+
+	loopPath, loop, err := attachLoopDevice(f.Name(), true)
+	assert.NoError(err)
+
+	var stat unix.Stat_t
+	assert.NoError(unix.Stat(loopPath, &stat))
+	assert.Equal(uint32(unix.S_IFBLK), stat.Mode&unix.S_IFMT)
+
+	// The backing file can go, the loop device keeps it
+	assert.NoError(os.Remove(f.Name()))
+	data, err := ioutil.ReadFile(filepath.Join("/sys/block", filepath.Base(loopPath), "loop", "autoclear"))
+	assert.NoError(err)
+	assert.Equal("1\n", string(data))
+
+	assert.NoError(loop.Close())
+
+	_, _, err = attachLoopDevice(f.Name(), true)
+	assert.Error(err)
+}
+
+func TestContainerUseRootfsDisk(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id:         "sandbox",
+		agent:      &mockAgent{},
+		hypervisor: &mockHypervisor{},
+		config:     &SandboxConfig{},
+	}
+	c := &Container{
+		id:      "sandbox",
+		sandbox: s,
+		rootFs:  RootFs{Target: "/rootfs", Mounted: true},
+	}
+
+	// Disabled
+	assert.False(c.useRootfsDisk())
+
+	// The mock agent does not support block devices
+	s.config.RootfsDisk.Fstype = RootfsDiskExt4
+	assert.False(c.useRootfsDisk())
+}
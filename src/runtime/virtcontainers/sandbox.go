@@ -12,9 +12,12 @@ import (
 	"math"
 	"net"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/containerd/cgroups"
 	"github.com/containernetworking/plugins/pkg/ns"
@@ -34,6 +37,7 @@ import (
 	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/assetstore"
 	vccgroups "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/cgroups"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/compatoci"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/rootless"
@@ -59,6 +63,15 @@ type SandboxStatus struct {
 	HypervisorConfig HypervisorConfig
 	ContainersStatus []ContainerStatus
 
+	// Arch is the architecture of the VM, as a GOARCH name.
+	Arch string
+
+	// HotplugPlan is the hotplug capacity the sandbox needs and has.
+	HotplugPlan HotplugPlan
+
+	// VCPUs is how the vCPUs of the VM are allocated to the containers.
+	VCPUs VCPUAllocation
+
 	// Annotations allow clients to store arbitrary values,
 	// for example to add additional status values required
 	// to support particular specifications.
@@ -69,6 +82,10 @@ type SandboxStatus struct {
 type SandboxStats struct {
 	CgroupStats CgroupStats
 	Cpus        int
+
+	// NetworkStats are the statistics of the network interfaces, when a
+	// network provider sets them up.
+	NetworkStats []NetworkInterfaceStats
 }
 
 // SandboxConfig is a Sandbox configuration.
@@ -80,6 +97,9 @@ type SandboxConfig struct {
 	HypervisorType   HypervisorType
 	HypervisorConfig HypervisorConfig
 
+	// AgentType selects the agent implementation of the sandbox, among the
+	// ones the runtime is built with. The kata agent is used by default.
+	AgentType   AgentType
 	AgentConfig KataAgentConfig
 
 	ProxyType   ProxyType
@@ -113,12 +133,114 @@ type SandboxConfig struct {
 
 	DisableGuestSeccomp bool
 
+	// GuestHooks are the types of the OCI hooks of the containers run by
+	// the agent in the guest instead of by the runtime on the host.
+	GuestHooks []string
+
+	// Registrars are the names of the registrars the interfaces of the
+	// sandbox are registered with, once it is started.
+	Registrars []string
+
 	// Experimental features enabled
 	Experimental []exp.Feature
 
 	// Cgroups specifies specific cgroup settings for the various subsystems that the container is
 	// placed into to limit the resources the container has available
 	Cgroups *configs.Cgroup
+
+	// ResourceCeilings are the host-side resource limits the sandbox can
+	// never grow beyond.
+	ResourceCeilings ResourceCeilings
+
+	// NodeLimits are the limits of the resources all the sandboxes of the
+	// host claim together.
+	NodeLimits NodeLimits
+
+	// ResizePolicy debounces the sandbox shrinks.
+	ResizePolicy ResizePolicy
+
+	// HotplugPlanning reserves hotplug capacity at VM creation.
+	HotplugPlanning HotplugPlanning
+
+	// AdmissionPolicy checks the host has room for the sandbox before
+	// creating its VM.
+	AdmissionPolicy AdmissionPolicy
+
+	// CPUShares configures the translation of the container CPU shares.
+	CPUShares CPUSharesTranslation
+
+	// GuestOS is the guest operating system, GuestOSLinux by default.
+	// Other guests do not run the kata agent, and require the
+	// ForeignGuestFeature experimental feature.
+	GuestOS string
+
+	// RootfsDisk converts the sandbox container rootfs to a disk.
+	RootfsDisk RootfsDisk
+
+	// TimeSync are the time sources of the guest, configured by the agent.
+	TimeSync TimeSync
+
+	// GuestProvisioning are the host files and settings installed in the
+	// guest by the agent.
+	GuestProvisioning GuestProvisioning
+
+	// CoreDumpDir is the host directory the captured core dumps of the
+	// containers are written to, under the sandbox and container IDs,
+	// unless the container sets its own.
+	CoreDumpDir string
+
+	// AssetStore is the directory of the asset store the guest kernel,
+	// initrd, image and firmware are pinned from, when they are taken
+	// from it.
+	AssetStore string
+
+	// AssetSigningKey is the PEM file of the Ed25519 public key the remote
+	// assets fetched into the asset store must be signed with.
+	AssetSigningKey string
+
+	// Kdump captures the vmcore of a crashing guest kernel.
+	Kdump Kdump
+
+	// GuestProfiling is the policy of the profiling sessions run in the
+	// guest.
+	GuestProfiling GuestProfiling
+
+	// GuestLivepatch lists the kernel livepatch modules that can be
+	// applied to the running guest, see LivepatchSandbox.
+	GuestLivepatch GuestLivepatch
+
+	// GuestMemoryReclaim is how the guest gives back the memory it only
+	// uses as cache.
+	GuestMemoryReclaim GuestMemoryReclaim
+
+	// MetadataProxy exposes the instance metadata service of the cloud
+	// to the guest.
+	MetadataProxy MetadataProxy
+
+	// TrafficCapture is the policy of the captures of the sandbox
+	// traffic, see CaptureSandboxTraffic.
+	TrafficCapture TrafficCapture
+
+	// Cloneable backs the guest memory with a file the sandbox is cloned
+	// from, see CloneSandbox.
+	Cloneable bool
+
+	// CloneSnapshotMaxAge is how long a snapshot of a cloneable sandbox
+	// is cloned again, its clones sharing the guest pages none of them
+	// wrote. Each clone takes its own snapshot if 0.
+	CloneSnapshotMaxAge time.Duration
+
+	// PeriodicSnapshots stores snapshots of a cloneable sandbox, to be
+	// cloned as it was when they were taken.
+	PeriodicSnapshots SnapshotPolicy
+
+	// ShutdownTimeouts are the timeouts of the graceful shutdown stages
+	// of the VM, when the sandbox is stopped without force.
+	ShutdownTimeouts ShutdownTimeouts
+
+	// Lightweight runs the sandbox on the fast path of the sandboxes
+	// running a single container.
+	Lightweight LightweightSandbox
 }
 
 func (s *Sandbox) trace(name string) (opentracing.Span, context.Context) {
@@ -145,23 +267,72 @@ func (s *Sandbox) startProxy() error {
 	return s.agent.startProxy(s)
 }
 
+// kernelParamsSource is a sandbox setting passed to the guest on the kernel
+// command line. Its parameters are empty when the setting is disabled.
+type kernelParamsSource interface {
+	kernelParams() []Param
+}
+
+// guestKernelParams returns the kernel parameters of the sandbox settings
+// passed to the guest on the kernel command line.
+func (sandboxConfig *SandboxConfig) guestKernelParams() []Param {
+	var params []Param
+
+	for _, src := range []kernelParamsSource{
+		sandboxConfig.TimeSync,
+		sandboxConfig.GuestProvisioning,
+		sandboxConfig.Kdump,
+		sandboxConfig.GuestProfiling,
+		sandboxConfig.GuestLivepatch,
+		sandboxConfig.NetworkConfig.DHCPInterfaces,
+		sandboxConfig.GuestMemoryReclaim,
+		sandboxConfig.ShutdownTimeouts,
+	} {
+		params = append(params, src.kernelParams()...)
+	}
+
+	return params
+}
+
 // valid checks that the sandbox configuration is valid.
 func (sandboxConfig *SandboxConfig) valid() bool {
+	return sandboxConfig.validate() == nil
+}
+
+// validate checks that the sandbox configuration is valid, and returns a
+// ConfigFieldError for the first invalid field.
+func (sandboxConfig *SandboxConfig) validate() error {
 	if sandboxConfig.ID == "" {
-		return false
+		return newConfigFieldError("ID", "Missing sandbox ID")
 	}
 
 	if _, err := newHypervisor(sandboxConfig.HypervisorType); err != nil {
 		sandboxConfig.HypervisorType = QemuHypervisor
 	}
 
+	// The remote assets are fetched into the asset store
+	if sandboxConfig.AssetStore == "" {
+		conf := &sandboxConfig.HypervisorConfig
+		for _, path := range []string{conf.KernelPath, conf.InitrdPath, conf.ImagePath, conf.FirmwarePath} {
+			if assetstore.IsRemote(path) {
+				return newConfigFieldError("AssetStore", fmt.Sprintf("Remote asset %s requires an asset store", path))
+			}
+		}
+	}
+
+	// The guest kernel of a livepatched sandbox only loads signed modules
+	if sandboxConfig.GuestLivepatch.enabled() && len(sandboxConfig.AgentConfig.KernelModules) > 0 {
+		return newConfigFieldError("GuestLivepatch", "Livepatching enforces the signatures of the guest kernel modules, it cannot be combined with the agent kernel modules")
+	}
+
 	// validate experimental features
-	for _, f := range sandboxConfig.Experimental {
+	for i, f := range sandboxConfig.Experimental {
 		if exp.Get(f.Name) == nil {
-			return false
+			return newConfigFieldError(fmt.Sprintf("Experimental[%d].Name", i),
+				fmt.Sprintf("Unknown experimental feature %q", f.Name))
 		}
 	}
-	return true
+	return nil
 }
 
 // Sandbox is composed of a set of containers and a runtime environment.
@@ -175,8 +346,16 @@ type Sandbox struct {
 	agent      agent
 	newStore   persistapi.PersistDriver
 
-	network Network
-	monitor *monitor
+	network         Network
+	networkProvider NetworkProvider
+	monitor         *monitor
+	events          *sandboxEvents
+
+	snapshotter *snapshotter
+
+	registration *sandboxRegistration
+
+	mountWatcher *mountWatcher
 
 	config *SandboxConfig
 
@@ -190,15 +369,35 @@ type Sandbox struct {
 
 	networkNS NetworkNamespace
 
+	// endpointOps are the last operations on the network endpoints.
+	endpointOps endpointOps
+
+	metadataProxy *metadataProxy
+
 	annotationsLock *sync.RWMutex
 
 	wg *sync.WaitGroup
 
 	shmSize           uint64
 	sharePidNs        bool
-	seccompSupported  bool
 	disableVMShutdown bool
 
+	// hotpluggedDevices tracks the IDs of the devices currently hotplugged
+	// into the guest, to enforce the hotplugged devices ceiling.
+	hotpluggedDevices map[string]struct{}
+
+	// hotplugPlan is the hotplug capacity planned when the VM was
+	// created.
+	hotplugPlan HotplugPlan
+
+	// resize is the last size updateResources applied to the sandbox.
+	resize resizeState
+
+	// shrinkLock is held while the shrinks deferred by shrinkTimer are
+	// applied, nil when shrinks are not deferred.
+	shrinkLock  sync.Locker
+	shrinkTimer *time.Timer
+
 	cgroupMgr *vccgroups.Manager
 
 	ctx context.Context
@@ -254,6 +453,21 @@ func (s *Sandbox) GetNetNs() string {
 	return s.networkNS.NetNsPath
 }
 
+// GetConsoleSocket returns the path of the unix socket the sandbox console,
+// where the agent debug console runs when enabled, can be accessed through.
+func (s *Sandbox) GetConsoleSocket() (string, error) {
+	path, err := s.hypervisor.getSandboxConsole(s.id)
+	if err != nil {
+		return "", err
+	}
+
+	if path == "" {
+		return "", fmt.Errorf("Hypervisor %s does not provide a console socket", s.config.HypervisorType)
+	}
+
+	return path, nil
+}
+
 // GetAllContainers returns all containers.
 func (s *Sandbox) GetAllContainers() []VCContainer {
 	ifa := make([]VCContainer, len(s.containers))
@@ -282,6 +496,10 @@ func (s *Sandbox) Release() error {
 	if s.monitor != nil {
 		s.monitor.stop()
 	}
+	s.releaseEvents()
+	s.stopPeriodicSnapshots()
+	s.stopMountWatcher()
+	s.scheduleShrink(time.Time{})
 	s.hypervisor.disconnect()
 	return s.agent.disconnect()
 }
@@ -289,6 +507,8 @@ func (s *Sandbox) Release() error {
 // Status gets the status of the sandbox
 // TODO: update container status properly, see kata-containers/runtime#253
 func (s *Sandbox) Status() SandboxStatus {
+	state, containerStates := s.reportedStates()
+
 	var contStatusList []ContainerStatus
 	for _, c := range s.containers {
 		rootfs := c.config.RootFs.Source
@@ -297,21 +517,25 @@ func (s *Sandbox) Status() SandboxStatus {
 		}
 
 		contStatusList = append(contStatusList, ContainerStatus{
-			ID:          c.id,
-			State:       c.state,
-			PID:         c.process.Pid,
-			StartTime:   c.process.StartTime,
-			RootFs:      rootfs,
-			Annotations: c.config.Annotations,
+			ID:           c.id,
+			State:        containerStates[c.id],
+			PID:          c.process.Pid,
+			StartTime:    c.process.StartTime,
+			RootFs:       rootfs,
+			Annotations:  c.config.Annotations,
+			BlockDevices: c.blockDeviceStatuses(),
 		})
 	}
 
 	return SandboxStatus{
 		ID:               s.id,
-		State:            s.state,
+		State:            state,
 		Hypervisor:       s.config.HypervisorType,
 		HypervisorConfig: s.config.HypervisorConfig,
 		ContainersStatus: contStatusList,
+		Arch:             hypervisorArch(s.config.HypervisorType, &s.config.HypervisorConfig),
+		HotplugPlan:      s.hotplugPlan,
+		VCPUs:            s.vcpuAllocation(),
 		Annotations:      s.config.Annotations,
 	}
 }
@@ -331,6 +555,47 @@ func (s *Sandbox) Monitor() (chan error, error) {
 	return s.monitor.newWatcher()
 }
 
+// Reconcile reconnects to the agent of a running sandbox fetched from
+// storage and aligns the state of its containers with the guest. It is
+// meant to be called by a process taking over the management of a sandbox,
+// for instance a shim restarted after a crash.
+//
+// A container the agent does not know about is marked as stopped, since
+// its processes cannot be waited for nor signaled anymore. A container the
+// agent fails to report on for any other reason keeps its state.
+func (s *Sandbox) Reconcile() error {
+	if s.state.State != types.StateRunning {
+		return fmt.Errorf("Sandbox %s is not running", s.id)
+	}
+
+	if err := s.agent.check(); err != nil {
+		return fmt.Errorf("Cannot reach the agent of sandbox %s: %v", s.id, err)
+	}
+
+	for _, c := range s.containers {
+		if c.state.State == types.StateStopped {
+			continue
+		}
+
+		_, err := s.agent.statsContainer(s, *c)
+		if err == nil {
+			continue
+		}
+
+		if !isContainerNotFound(err) {
+			c.Logger().WithError(err).Warn("could not check the container in the guest, keeping its state")
+			continue
+		}
+
+		c.Logger().WithError(err).Warn("container not found in the guest, marking it stopped")
+		if err := c.setContainerState(types.StateStopped); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // WaitProcess waits on a container process and return its exit code
 func (s *Sandbox) WaitProcess(containerID, processID string) (int32, error) {
 	if s.state.State != types.StateRunning {
@@ -421,6 +686,10 @@ func createAssets(ctx context.Context, sandboxConfig *SandboxConfig) error {
 }
 
 func (s *Sandbox) getAndStoreGuestDetails() error {
+	if s.storeStaticGuestDetails() {
+		return nil
+	}
+
 	guestDetailRes, err := s.agent.getGuestDetails(&grpc.GuestDetailsRequest{
 		MemBlockSize:    true,
 		MemHotplugProbe: true,
@@ -432,7 +701,7 @@ func (s *Sandbox) getAndStoreGuestDetails() error {
 	if guestDetailRes != nil {
 		s.state.GuestMemoryBlockSizeMB = uint32(guestDetailRes.MemBlockSizeBytes >> 20)
 		if guestDetailRes.AgentDetails != nil {
-			s.seccompSupported = guestDetailRes.AgentDetails.SupportsSeccomp
+			s.state.GuestSeccompSupported = guestDetailRes.AgentDetails.SupportsSeccomp
 		}
 		s.state.GuestMemoryHotplugProbe = guestDetailRes.SupportMemHotplugProbe
 	}
@@ -445,7 +714,7 @@ func (s *Sandbox) getAndStoreGuestDetails() error {
 // It will create and store the sandbox structure, and then ask the hypervisor
 // to physically create that sandbox i.e. starts a VM for that sandbox to eventually
 // be started.
-func createSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Factory) (*Sandbox, error) {
+func createSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Factory) (_ *Sandbox, err error) {
 	span, ctx := trace(ctx, "createSandbox")
 	defer span.Finish()
 
@@ -470,6 +739,25 @@ func createSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Fac
 		return s, nil
 	}
 
+	// Count the sandbox, and the boot memory of its VM, in the node ledger.
+	if err := s.claimNodeResources(func(c *nodeClaim) { c.MemoryMB = s.config.HypervisorConfig.MemorySize }); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil && s.config.NodeLimits.enabled() {
+			if err := s.nodeLedger().release(s.id); err != nil {
+				s.Logger().WithError(err).Error("failed to release the node ledger claim")
+			}
+		}
+	}()
+
+	defer func() {
+		if err != nil && s.config.AssetStore != "" {
+			s.releaseStoredAssets()
+		}
+	}()
+
 	// Below code path is called only during create, because of earlier check.
 	if err := s.agent.createSandbox(s); err != nil {
 		return nil, err
@@ -487,13 +775,139 @@ func newSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Factor
 	span, ctx := trace(ctx, "newSandbox")
 	defer span.Finish()
 
-	if !sandboxConfig.valid() {
-		return nil, fmt.Errorf("Invalid sandbox configuration")
+	if err := sandboxConfig.validate(); err != nil {
+		return nil, fmt.Errorf("Invalid sandbox configuration: %w", err)
+	}
+
+	if err := checkVMMIsolation(sandboxConfig.HypervisorType, &sandboxConfig.HypervisorConfig); err != nil {
+		return nil, err
+	}
+
+	if err := checkVMMSeccomp(sandboxConfig.HypervisorType, &sandboxConfig.HypervisorConfig); err != nil {
+		return nil, err
+	}
+
+	if err := checkMemoryEncryption(sandboxConfig.HypervisorType, &sandboxConfig.HypervisorConfig); err != nil {
+		return nil, err
+	}
+
+	if err := checkConfidentialGuest(&sandboxConfig); err != nil {
+		return nil, err
+	}
+
+	if err := checkGuestHooks(sandboxConfig.GuestHooks); err != nil {
+		return nil, err
+	}
+
+	if err := checkRegistrars(sandboxConfig.Registrars); err != nil {
+		return nil, configFieldError("Registrars", err)
+	}
+
+	if err := checkResourceCeilings(sandboxConfig.ResourceCeilings, &sandboxConfig.HypervisorConfig); err != nil {
+		return nil, configFieldError("ResourceCeilings", err)
+	}
+
+	hotplugPlan := planHotplug(&sandboxConfig)
+	if sandboxConfig.HotplugPlanning.Enable {
+		if err := hotplugPlan.check(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := sandboxConfig.CPUShares.validate(); err != nil {
+		return nil, configFieldError("CPUShares", err)
+	}
+
+	if err := sandboxConfig.AdmissionPolicy.validate(); err != nil {
+		return nil, configFieldError("AdmissionPolicy", err)
+	}
+
+	if err := checkGuestOS(&sandboxConfig, factory); err != nil {
+		return nil, err
+	}
+
+	if err := checkAgentType(&sandboxConfig, factory); err != nil {
+		return nil, configFieldError("AgentType", err)
+	}
+
+	if err := sandboxConfig.RootfsDisk.validate(); err != nil {
+		return nil, configFieldError("RootfsDisk", err)
+	}
+
+	if err := sandboxConfig.TimeSync.validate(); err != nil {
+		return nil, configFieldError("TimeSync", err)
+	}
+
+	if err := sandboxConfig.GuestProvisioning.validate(); err != nil {
+		return nil, configFieldError("GuestProvisioning", err)
+	}
+
+	if err := sandboxConfig.Kdump.validate(); err != nil {
+		return nil, configFieldError("Kdump", err)
+	}
+
+	if err := sandboxConfig.GuestProfiling.validate(); err != nil {
+		return nil, configFieldError("GuestProfiling", err)
+	}
+
+	if err := sandboxConfig.GuestLivepatch.validate(); err != nil {
+		return nil, configFieldError("GuestLivepatch", err)
+	}
+
+	if err := sandboxConfig.GuestMemoryReclaim.validate(); err != nil {
+		return nil, configFieldError("GuestMemoryReclaim", err)
+	}
+
+	if err := sandboxConfig.MetadataProxy.validate(); err != nil {
+		return nil, configFieldError("MetadataProxy", err)
+	}
+
+	if err := sandboxConfig.NetworkConfig.TCFilterCompat.validate(); err != nil {
+		return nil, configFieldError("NetworkConfig.TCFilterCompat", err)
+	}
+
+	if err := sandboxConfig.NetworkConfig.MACAllocation.validate(); err != nil {
+		return nil, configFieldError("NetworkConfig.MACAllocation", err)
+	}
+
+	if err := sandboxConfig.NetworkConfig.DHCPInterfaces.validate(); err != nil {
+		return nil, configFieldError("NetworkConfig.DHCPInterfaces", err)
+	}
+
+	if err := sandboxConfig.NetworkConfig.InterfaceEndpoints.validate(); err != nil {
+		return nil, configFieldError("NetworkConfig.InterfaceEndpoints", err)
+	}
+
+	networkProvider, err := newNetworkProvider(sandboxConfig.NetworkConfig.Provider)
+	if err != nil {
+		return nil, configFieldError("NetworkConfig.Provider", err)
+	}
+
+	if err := sandboxConfig.TrafficCapture.validate(); err != nil {
+		return nil, configFieldError("TrafficCapture", err)
+	}
+
+	if err := checkCloneable(&sandboxConfig, factory); err != nil {
+		return nil, configFieldError("Cloneable", err)
+	}
+
+	if err := sandboxConfig.PeriodicSnapshots.validate(); err != nil {
+		return nil, configFieldError("PeriodicSnapshots", err)
+	}
+
+	if err := sandboxConfig.ShutdownTimeouts.validate(); err != nil {
+		return nil, configFieldError("ShutdownTimeouts", err)
+	}
+
+	if err := sandboxConfig.Lightweight.validate(&sandboxConfig); err != nil {
+		return nil, configFieldError("Lightweight", err)
 	}
 
 	// create agent instance
-	newAagentFunc := getNewAgentFunc(ctx)
-	agent := newAagentFunc()
+	agent, err := newSandboxAgent(ctx, &sandboxConfig)
+	if err != nil {
+		return nil, err
+	}
 
 	hypervisor, err := newHypervisor(sandboxConfig.HypervisorType)
 	if err != nil {
@@ -506,6 +920,7 @@ func newSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Factor
 		hypervisor:      hypervisor,
 		agent:           agent,
 		config:          &sandboxConfig,
+		hotplugPlan:     hotplugPlan,
 		volumes:         sandboxConfig.Volumes,
 		containers:      map[string]*Container{},
 		state:           types.SandboxState{BlockIndexMap: make(map[int]struct{})},
@@ -514,6 +929,7 @@ func newSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Factor
 		shmSize:         sandboxConfig.ShmSize,
 		sharePidNs:      sandboxConfig.SharePidNs,
 		networkNS:       NetworkNamespace{NetNsPath: sandboxConfig.NetworkConfig.NetNSPath},
+		networkProvider: networkProvider,
 		ctx:             ctx,
 	}
 
@@ -521,6 +937,8 @@ func newSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Factor
 		return nil, fmt.Errorf("failed to get fs persist driver: %v", err)
 	}
 
+	s.events = newSandboxEvents(filepath.Join(s.newStore.RunStoragePath(), s.id, sandboxEventJournal))
+
 	if err = globalSandboxList.addSandbox(s); err != nil {
 		return nil, err
 	}
@@ -540,18 +958,88 @@ func newSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Factor
 
 	s.devManager = deviceManager.NewDeviceManager(sandboxConfig.HypervisorConfig.BlockDeviceDriver,
 		sandboxConfig.HypervisorConfig.EnableVhostUserStore,
-		sandboxConfig.HypervisorConfig.VhostUserStorePath, nil)
+		sandboxConfig.HypervisorConfig.VhostUserStorePath, nil,
+		deviceManager.WithVFIOAutoBindDrivers(sandboxConfig.HypervisorConfig.VFIOAutoBindDrivers))
 
 	// Ignore the error. Restore can fail for a new sandbox
 	if err := s.Restore(); err != nil {
 		s.Logger().WithError(err).Debug("restore sandbox failed")
 	}
 
+	// A restored sandbox boots as it was set up when created, the setup
+	// below only applies to a new one.
+	if s.state.State == "" {
+		// Pin the assets taken from the asset store.
+		if sandboxConfig.AssetStore != "" {
+			if err := pinStoredAssets(ctx, &sandboxConfig); err != nil {
+				return nil, err
+			}
+
+			defer func() {
+				if retErr != nil {
+					s.releaseStoredAssets()
+				}
+			}()
+		}
+
+		// The assets are checked once pinned, the remote ones being
+		// local by then.
+		if err := CheckArch(sandboxConfig.HypervisorType, &sandboxConfig.HypervisorConfig); err != nil {
+			return nil, err
+		}
+
+		if sandboxConfig.GuestOS == GuestOSOther {
+			if sandboxConfig.HypervisorConfig.PayloadArgs, err = payloadArgs(spec); err != nil {
+				return nil, err
+			}
+		}
+
+		// The crash kernel memory is reserved on top of the sandbox memory.
+		if sandboxConfig.Kdump.enabled() {
+			if sandboxConfig.HypervisorConfig.MemorySize == 0 {
+				sandboxConfig.HypervisorConfig.MemorySize = defaultMemSzMiB
+			}
+			sandboxConfig.HypervisorConfig.MemorySize += sandboxConfig.Kdump.CrashKernelMB
+		}
+
+		sandboxConfig.HypervisorConfig.KernelParams = append(sandboxConfig.HypervisorConfig.KernelParams, sandboxConfig.guestKernelParams()...)
+
+		if sandboxConfig.MetadataProxy.enabled() {
+			if s.metadataProxy, err = newMetadataProxy(sandboxConfig.MetadataProxy, sandboxConfig.HypervisorType, s.Logger()); err != nil {
+				return nil, err
+			}
+
+			defer func() {
+				if retErr != nil {
+					s.stopMetadataProxy()
+				}
+			}()
+
+			sandboxConfig.HypervisorConfig.KernelParams = append(sandboxConfig.HypervisorConfig.KernelParams, sandboxConfig.MetadataProxy.kernelParams(s.metadataProxy.port)...)
+		}
+
+		if sandboxConfig.HypervisorConfig.GuestNUMA {
+			if err := setupGuestNUMA(&sandboxConfig); err != nil {
+				return nil, err
+			}
+		}
+
+		if sandboxConfig.Cloneable {
+			if err := s.setupCloneableMemory(&sandboxConfig.HypervisorConfig); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// new store doesn't require hypervisor to be stored immediately
 	if err = s.hypervisor.createSandbox(ctx, s.id, s.networkNS, &sandboxConfig.HypervisorConfig); err != nil {
 		return nil, err
 	}
 
+	if caps := s.hypervisor.capabilities(); sandboxConfig.HypervisorConfig.VFIOPeerToPeer && !caps.IsVFIOPeerToPeerSupported() {
+		return nil, fmt.Errorf("Peer-to-peer DMA between VFIO devices is not supported by the hypervisor")
+	}
+
 	if s.disableVMShutdown, err = s.agent.init(ctx, s, sandboxConfig.AgentConfig); err != nil {
 		return nil, err
 	}
@@ -729,8 +1217,9 @@ func (s *Sandbox) removeContainer(containerID string) error {
 func (s *Sandbox) Delete() error {
 	if s.state.State != types.StateReady &&
 		s.state.State != types.StatePaused &&
+		s.state.State != types.StateSuspended &&
 		s.state.State != types.StateStopped {
-		return fmt.Errorf("Sandbox not ready, paused or stopped, impossible to delete")
+		return fmt.Errorf("Sandbox not ready, paused, suspended or stopped, impossible to delete")
 	}
 
 	for _, c := range s.containers {
@@ -751,12 +1240,32 @@ func (s *Sandbox) Delete() error {
 		s.monitor.stop()
 	}
 
+	s.releaseEvents()
+
+	s.stopPeriodicSnapshots()
+
 	if err := s.hypervisor.cleanup(); err != nil {
 		s.Logger().WithError(err).Error("failed to cleanup hypervisor")
 	}
 
 	s.agent.cleanup(s)
 
+	if s.config.NetworkConfig.MACAllocation.enabled() {
+		if err := s.macLeases().release(s.id); err != nil {
+			s.Logger().WithError(err).Error("failed to release MAC address leases")
+		}
+	}
+
+	if s.config.NodeLimits.enabled() {
+		if err := s.nodeLedger().release(s.id); err != nil {
+			s.Logger().WithError(err).Error("failed to release the node ledger claim")
+		}
+	}
+
+	if s.config.AssetStore != "" {
+		s.releaseStoredAssets()
+	}
+
 	return s.newStore.Destroy(s.id)
 }
 
@@ -843,7 +1352,15 @@ func (s *Sandbox) removeNetwork() error {
 		}
 	}
 
-	return s.network.Remove(s.ctx, &s.networkNS, s.hypervisor)
+	if err := s.network.Remove(s.ctx, &s.networkNS, s.hypervisor); err != nil {
+		return err
+	}
+
+	if s.networkProvider != nil && s.networkNS.NetNsPath != "" {
+		return s.networkProvider.Teardown(s.ctx, s.id, s.networkNS.NetNsPath)
+	}
+
+	return nil
 }
 
 func (s *Sandbox) generateNetInfo(inf *vcTypes.Interface) (NetworkInfo, error) {
@@ -878,20 +1395,46 @@ func (s *Sandbox) generateNetInfo(inf *vcTypes.Interface) (NetworkInfo, error) {
 
 // AddInterface adds new nic to the sandbox.
 func (s *Sandbox) AddInterface(inf *vcTypes.Interface) (*vcTypes.Interface, error) {
-	netInfo, err := s.generateNetInfo(inf)
-	if err != nil {
+	if err := s.config.ResourceCeilings.checkInterfaces(len(s.networkNS.Endpoints) + 1); err != nil {
 		return nil, err
 	}
 
-	endpoint, err := createEndpoint(netInfo, len(s.networkNS.Endpoints), s.config.NetworkConfig.InterworkingModel, nil)
+	netInfo, err := s.generateNetInfo(inf)
 	if err != nil {
 		return nil, err
 	}
 
-	endpoint.SetProperties(netInfo)
+	var endpoint Endpoint
+	if s.networkProvider != nil {
+		pi, err := s.networkProvider.Hotplug(s.ctx, s.id, s.networkNS.NetNsPath, inf)
+		if err != nil {
+			return nil, err
+		}
+		if endpoint, err = createProviderEndpoint(pi, netInfo, len(s.networkNS.Endpoints), &s.config.NetworkConfig); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := doNetNS(s.networkNS.NetNsPath, func(_ ns.NetNS) error {
+		// The interface is looked up in the network namespace, where
+		// the physical interfaces, such as the SR-IOV virtual functions
+		// moved there by the CNI plugin, are found.
+		if endpoint == nil {
+			endpoint, err = createEndpoint(netInfo, len(s.networkNS.Endpoints), s.config.NetworkConfig.InterworkingModel, s.config.NetworkConfig.InterfaceEndpoints, nil)
+			if err != nil {
+				return err
+			}
+
+			endpoint.SetProperties(netInfo)
+		}
 		s.Logger().WithField("endpoint-type", endpoint.Type()).Info("Hot attaching endpoint")
-		return endpoint.HotAttach(s.hypervisor)
+		if err := runEndpointOp(&s.endpointOps, endpoint, endpointOpHotAttach, func() error {
+			return endpoint.HotAttach(s)
+		}); err != nil {
+			return err
+		}
+
+		return s.config.NetworkConfig.TCFilterCompat.apply(endpoint)
 	}); err != nil {
 		return nil, err
 	}
@@ -904,29 +1447,76 @@ func (s *Sandbox) AddInterface(inf *vcTypes.Interface) (*vcTypes.Interface, erro
 
 	// Add network for vm
 	inf.PciAddr = endpoint.PciAddr()
-	return s.agent.updateInterface(inf)
+	inf, err = s.agent.updateInterface(inf)
+	if err != nil {
+		return nil, err
+	}
+
+	s.updateRegistration()
+
+	return inf, nil
 }
 
 // RemoveInterface removes a nic of the sandbox.
 func (s *Sandbox) RemoveInterface(inf *vcTypes.Interface) (*vcTypes.Interface, error) {
 	for i, endpoint := range s.networkNS.Endpoints {
 		if endpoint.HardwareAddr() == inf.HwAddr {
+			// Stop the guest from routing traffic through the
+			// interface before it disappears.
+			if err := s.removeInterfaceRoutes(endpoint.Name()); err != nil {
+				return inf, err
+			}
+
 			s.Logger().WithField("endpoint-type", endpoint.Type()).Info("Hot detaching endpoint")
-			if err := endpoint.HotDetach(s.hypervisor, s.networkNS.NetNsCreated, s.networkNS.NetNsPath); err != nil {
+			if err := runEndpointOp(&s.endpointOps, endpoint, endpointOpHotDetach, func() error {
+				return endpoint.HotDetach(s, s.networkNS.NetNsCreated, s.networkNS.NetNsPath)
+			}); err != nil {
 				return inf, err
 			}
+			s.endpointOps.forget(endpoint.Name())
 			s.networkNS.Endpoints = append(s.networkNS.Endpoints[:i], s.networkNS.Endpoints[i+1:]...)
 
 			if err := s.Save(); err != nil {
 				return inf, err
 			}
 
+			if s.networkProvider != nil {
+				if err := s.networkProvider.Hotunplug(s.ctx, s.id, s.networkNS.NetNsPath, endpoint.Name()); err != nil {
+					return inf, err
+				}
+			}
+
+			s.updateRegistration()
+
 			break
 		}
 	}
 	return nil, nil
 }
 
+// removeInterfaceRoutes removes the guest routes going through the
+// network interface name.
+func (s *Sandbox) removeInterfaceRoutes(name string) error {
+	routes, err := s.agent.listRoutes()
+	if err != nil {
+		return err
+	}
+
+	var kept []*vcTypes.Route
+	for _, r := range routes {
+		if r.Device != name {
+			kept = append(kept, r)
+		}
+	}
+
+	if len(kept) == len(routes) {
+		return nil
+	}
+
+	_, err = s.agent.updateRoutes(kept)
+	return err
+}
+
 // ListInterfaces lists all nics and their configurations in the sandbox.
 func (s *Sandbox) ListInterfaces() ([]*vcTypes.Interface, error) {
 	return s.agent.listInterfaces()
@@ -949,6 +1539,10 @@ func (s *Sandbox) startVM() (err error) {
 
 	s.Logger().Info("Starting VM")
 
+	start := time.Now()
+
+	s.recordHypervisorVersion()
+
 	if err := s.network.Run(s.networkNS.NetNsPath, func() error {
 		if s.factory != nil {
 			vm, err := s.factory.GetVM(ctx, VMConfig{
@@ -965,7 +1559,16 @@ func (s *Sandbox) startVM() (err error) {
 			return vm.assignSandbox(s)
 		}
 
-		return s.hypervisor.startSandbox(vmStartTimeout)
+		if err := s.hypervisor.startSandbox(vmStartTimeout); err != nil {
+			return err
+		}
+
+		// The VM of a clone is paused once its state is loaded.
+		if s.config.HypervisorConfig.BootFromTemplate {
+			return s.hypervisor.resumeSandbox()
+		}
+
+		return nil
 	}); err != nil {
 		return err
 	}
@@ -1005,11 +1608,36 @@ func (s *Sandbox) startVM() (err error) {
 
 	s.Logger().Info("Agent started in the sandbox")
 
+	observeDuration(vmBootDurationsHistogram.WithLabelValues(string(s.config.HypervisorType), strconv.FormatBool(s.factory != nil)), start)
+
+	if s.config.HypervisorConfig.BootFromTemplate {
+		if err := s.reseedClone(); err != nil {
+			return err
+		}
+	}
+
+	if err := s.startMetadataProxy(); err != nil {
+		return err
+	}
+
+	if err := s.provisionGuest(); err != nil {
+		return err
+	}
+
+	if err := s.setupKdump(); err != nil {
+		return err
+	}
+
+	if err := s.setupGuestProfiling(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-// stopVM: stop the sandbox's VM
-func (s *Sandbox) stopVM() error {
+// stopVM stops the VM of the sandbox, with its graceful shutdown stages
+// when graceful is set.
+func (s *Sandbox) stopVM(graceful bool) error {
 	span, _ := s.trace("stopVM")
 	defer span.Finish()
 
@@ -1018,6 +1646,9 @@ func (s *Sandbox) stopVM() error {
 		s.Logger().WithError(err).WithField("sandboxid", s.id).Warning("Agent did not stop sandbox")
 	}
 
+	s.stopMetadataProxy()
+	s.stopMountWatcher()
+
 	if s.disableVMShutdown {
 		// Do not kill the VM - allow the agent to shut it down
 		// (only used to support static agent tracing).
@@ -1025,7 +1656,43 @@ func (s *Sandbox) stopVM() error {
 	}
 
 	s.Logger().Info("Stopping VM")
-	return s.hypervisor.stopSandbox()
+	var err error
+	if graceful && s.config.ShutdownTimeouts.enabled() {
+		err = s.shutdownVM()
+	} else {
+		err = s.hypervisor.stopSandbox()
+	}
+
+	// The VM is stopped by a later shutdown stage on escalation, which is
+	// recorded rather than failing the stop.
+	if escalation, ok := err.(*ShutdownEscalationError); ok {
+		s.Logger().WithError(escalation).Warn("VM shutdown escalated")
+		s.publishEvent(SandboxEvent{Type: SandboxEventShutdownEscalation, Err: escalation})
+		err = nil
+	}
+
+	if err == nil {
+		s.restoreVFIOHostDrivers()
+	}
+
+	return err
+}
+
+// restoreVFIOHostDrivers binds the host devices bound to vfio-pci along with
+// the VFIO devices of the sandbox back to their drivers, once the VM is
+// stopped. The cold plugged devices are only released then.
+func (s *Sandbox) restoreVFIOHostDrivers() {
+	if s.devManager == nil {
+		return
+	}
+
+	for _, d := range s.devManager.GetAllDevices() {
+		if vfio, ok := d.(*drivers.VFIODevice); ok {
+			if err := vfio.RestoreHostDrivers(); err != nil {
+				s.Logger().WithError(err).WithField("device", d.DeviceID()).Warn("Could not restore host drivers")
+			}
+		}
+	}
 }
 
 func (s *Sandbox) addContainer(c *Container) error {
@@ -1068,6 +1735,10 @@ func (s *Sandbox) fetchContainers() error {
 // This should be called only when the sandbox is already created.
 // It will add new container config to sandbox.config.Containers
 func (s *Sandbox) CreateContainer(contConfig ContainerConfig) (VCContainer, error) {
+	if err := s.checkLightweightContainer(&contConfig); err != nil {
+		return nil, err
+	}
+
 	// Create the container.
 	c, err := newContainer(s, &contConfig)
 	if err != nil {
@@ -1165,6 +1836,8 @@ func (s *Sandbox) StopContainer(containerID string, force bool) (VCContainer, er
 		return nil, err
 	}
 
+	s.releaseContainerResources(containerID)
+
 	if err = s.storeSandbox(); err != nil {
 		return nil, err
 	}
@@ -1217,6 +1890,8 @@ func (s *Sandbox) DeleteContainer(containerID string) (VCContainer, error) {
 		}
 	}
 
+	s.releaseContainerResources(containerID)
+
 	if err = s.storeSandbox(); err != nil {
 		return nil, err
 	}
@@ -1250,12 +1925,13 @@ func (s *Sandbox) StatusContainer(containerID string) (ContainerStatus, error) {
 		}
 
 		return ContainerStatus{
-			ID:          c.id,
-			State:       c.state,
-			PID:         c.process.Pid,
-			StartTime:   c.process.StartTime,
-			RootFs:      rootfs,
-			Annotations: c.config.Annotations,
+			ID:           c.id,
+			State:        c.state,
+			PID:          c.process.Pid,
+			StartTime:    c.process.StartTime,
+			RootFs:       rootfs,
+			Annotations:  c.config.Annotations,
+			BlockDevices: c.blockDeviceStatuses(),
 		}, nil
 	}
 
@@ -1281,13 +1957,30 @@ func (s *Sandbox) EnterContainer(containerID string, cmd types.Cmd) (VCContainer
 }
 
 // UpdateContainer update a running container.
-func (s *Sandbox) UpdateContainer(containerID string, resources specs.LinuxResources) error {
+func (s *Sandbox) UpdateContainer(containerID string, resources specs.LinuxResources) (err error) {
 	// Fetch the container.
 	c, err := s.findContainer(containerID)
 	if err != nil {
 		return err
 	}
 
+	// The VM is sized from the sandbox copy of the container
+	// configuration, which the container does not share.
+	for i := range s.config.Containers {
+		if s.config.Containers[i].ID != containerID {
+			continue
+		}
+
+		old := s.config.Containers[i].Resources
+		mergeSizingResources(&s.config.Containers[i].Resources, resources)
+		defer func(i int) {
+			if err != nil {
+				s.config.Containers[i].Resources = old
+			}
+		}(i)
+		break
+	}
+
 	err = c.update(resources)
 	if err != nil {
 		return err
@@ -1303,6 +1996,33 @@ func (s *Sandbox) UpdateContainer(containerID string, resources specs.LinuxResou
 	return nil
 }
 
+// mergeSizingResources updates the CPU quota and period, and the memory
+// limit, the VM is sized from with the ones of resources which are set.
+func mergeSizingResources(dst *specs.LinuxResources, resources specs.LinuxResources) {
+	if cpu := resources.CPU; cpu != nil {
+		var c specs.LinuxCPU
+		if dst.CPU != nil {
+			c = *dst.CPU
+		}
+		if p := cpu.Period; p != nil && *p != 0 {
+			c.Period = p
+		}
+		if q := cpu.Quota; q != nil && *q != 0 {
+			c.Quota = q
+		}
+		dst.CPU = &c
+	}
+
+	if mem := resources.Memory; mem != nil && mem.Limit != nil {
+		var m specs.LinuxMemory
+		if dst.Memory != nil {
+			m = *dst.Memory
+		}
+		m.Limit = mem.Limit
+		dst.Memory = &m
+	}
+}
+
 // StatsContainer return the stats of a running container
 func (s *Sandbox) StatsContainer(containerID string) (ContainerStats, error) {
 	// Fetch the container.
@@ -1355,6 +2075,14 @@ func (s *Sandbox) Stats() (SandboxStats, error) {
 	}
 	stats.Cpus = len(tids.vcpus)
 
+	if s.networkProvider != nil {
+		// The network statistics are best effort, the datapath may be
+		// reachable only from its own control plane.
+		if stats.NetworkStats, err = s.networkProvider.Stats(s.ctx, s.id); err != nil {
+			s.Logger().WithError(err).Warn("Could not get the network statistics from the network provider")
+		}
+	}
+
 	return stats, nil
 }
 
@@ -1396,6 +2124,52 @@ func (s *Sandbox) ResumeContainer(containerID string) error {
 	return nil
 }
 
+// CheckpointContainer checkpoints a running or paused container, with CRIU
+// in the guest, to a host directory.
+func (s *Sandbox) CheckpointContainer(containerID string, opts CheckpointOptions) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	// Fetch the container.
+	c, err := s.findContainer(containerID)
+	if err != nil {
+		return err
+	}
+
+	if err := c.checkpoint(opts); err != nil {
+		return err
+	}
+
+	return s.storeSandbox()
+}
+
+// RestoreContainer restores a container checkpointed in this sandbox or in
+// another one in place of a created container, instead of starting it.
+func (s *Sandbox) RestoreContainer(containerID string, opts CheckpointOptions) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	// Fetch the container.
+	c, err := s.findContainer(containerID)
+	if err != nil {
+		return err
+	}
+
+	if err := c.restore(opts); err != nil {
+		return err
+	}
+
+	if err = s.storeSandbox(); err != nil {
+		return err
+	}
+
+	s.Logger().Info("Container is restored")
+
+	return s.updateResources()
+}
+
 // createContainers registers all containers to the proxy, create the
 // containers in the guest and starts one shim per container.
 func (s *Sandbox) createContainers() error {
@@ -1462,6 +2236,10 @@ func (s *Sandbox) Start() error {
 		return err
 	}
 
+	s.startPeriodicSnapshots()
+
+	s.startRegistration()
+
 	s.Logger().Info("Sandbox is started")
 
 	return nil
@@ -1479,17 +2257,36 @@ func (s *Sandbox) Stop(force bool) error {
 		return nil
 	}
 
+	// The agent does not answer while the VM is paused or the guest is
+	// suspended to RAM
+	var err error
+	switch s.state.State {
+	case types.StatePaused:
+		err = s.Resume()
+	case types.StateSuspended:
+		err = s.ResumeFromRAM()
+	}
+	if err != nil && !force {
+		return err
+	}
+
 	if err := s.state.ValidTransition(s.state.State, types.StateStopped); err != nil {
 		return err
 	}
 
+	s.stopPeriodicSnapshots()
+
+	s.stopRegistration()
+
+	s.scheduleShrink(time.Time{})
+
 	for _, c := range s.containers {
 		if err := c.stop(force); err != nil {
 			return err
 		}
 	}
 
-	if err := s.stopVM(); err != nil && !force {
+	if err := s.stopVM(!force); err != nil && !force {
 		return err
 	}
 
@@ -1527,11 +2324,24 @@ func (s *Sandbox) setSandboxState(state types.StateString) error {
 	}
 
 	// update in-memory state
+	s.Lock()
 	s.state.State = state
+	s.Unlock()
+
+	s.publishEvent(SandboxEvent{Type: SandboxEventState, State: state})
 
 	return nil
 }
 
+// getSandboxState returns the in-memory state of the sandbox, for the
+// goroutines reading it while the sandbox changes state.
+func (s *Sandbox) getSandboxState() types.StateString {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.state.State
+}
+
 const maxBlockIndex = 65535
 
 // getAndSetSandboxBlockIndex retrieves an unused sandbox block index from
@@ -1570,10 +2380,39 @@ func (s *Sandbox) unsetSandboxBlockIndex(index int) error {
 
 // HotplugAddDevice is used for add a device to sandbox
 // Sandbox implement DeviceReceiver interface from device/api/interface.go
-func (s *Sandbox) HotplugAddDevice(device api.Device, devType config.DeviceType) error {
+func (s *Sandbox) HotplugAddDevice(device api.Device, devType config.DeviceType) (err error) {
 	span, _ := s.trace("HotplugAddDevice")
 	defer span.Finish()
 
+	start := time.Now()
+	defer func() {
+		observeDuration(hotplugDurationsHistogram.WithLabelValues(string(devType), "add"), start)
+		s.publishEvent(SandboxEvent{Type: SandboxEventHotplug, DeviceID: device.DeviceID(), DeviceType: devType, Err: err})
+	}()
+
+	if isHotplugDeviceType(devType) {
+		if err := s.config.ResourceCeilings.checkHotplugDevices(s.hotpluggedDevices, device.DeviceID()); err != nil {
+			return err
+		}
+
+		if _, ok := s.hotpluggedDevices[device.DeviceID()]; !ok {
+			if err := s.claimNodeResources(func(c *nodeClaim) { c.HotplugDevices = uint32(len(s.hotpluggedDevices)) + 1 }); err != nil {
+				return err
+			}
+		}
+
+		defer func() {
+			if err == nil {
+				if s.hotpluggedDevices == nil {
+					s.hotpluggedDevices = make(map[string]struct{})
+				}
+				s.hotpluggedDevices[device.DeviceID()] = struct{}{}
+			} else if cerr := s.claimNodeResources(func(c *nodeClaim) { c.HotplugDevices = uint32(len(s.hotpluggedDevices)) }); cerr != nil {
+				s.Logger().WithError(cerr).Warn("Could not release the node ledger claim of a device which failed to hotplug")
+			}
+		}()
+	}
+
 	if s.config.SandboxCgroupOnly {
 		// We are about to add a device to the hypervisor,
 		// the device cgroup MUST be updated since the hypervisor
@@ -1592,6 +2431,10 @@ func (s *Sandbox) HotplugAddDevice(device api.Device, devType config.DeviceType)
 			return fmt.Errorf("device type mismatch, expect device type to be %s", devType)
 		}
 
+		if caps := s.hypervisor.capabilities(); !caps.IsVFIOHotplugSupported() {
+			return fmt.Errorf("%s does not support VFIO device hotplug, cold plug the device instead", s.config.HypervisorType)
+		}
+
 		// adding a group of VFIO devices
 		for _, dev := range vfioDevices {
 			if _, err := s.hypervisor.hotplugAddDevice(dev, vfioDev); err != nil {
@@ -1628,8 +2471,22 @@ func (s *Sandbox) HotplugAddDevice(device api.Device, devType config.DeviceType)
 
 // HotplugRemoveDevice is used for removing a device from sandbox
 // Sandbox implement DeviceReceiver interface from device/api/interface.go
-func (s *Sandbox) HotplugRemoveDevice(device api.Device, devType config.DeviceType) error {
+func (s *Sandbox) HotplugRemoveDevice(device api.Device, devType config.DeviceType) (err error) {
+	start := time.Now()
 	defer func() {
+		observeDuration(hotplugDurationsHistogram.WithLabelValues(string(devType), "remove"), start)
+		s.publishEvent(SandboxEvent{Type: SandboxEventHotplug, DeviceID: device.DeviceID(), DeviceType: devType, Unplug: true, Err: err})
+
+		if err != nil {
+			return
+		}
+
+		delete(s.hotpluggedDevices, device.DeviceID())
+
+		if err := s.claimNodeResources(func(c *nodeClaim) { c.HotplugDevices = uint32(len(s.hotpluggedDevices)) }); err != nil {
+			s.Logger().WithError(err).Warn("Could not release the node ledger claim of an unplugged device")
+		}
+
 		if s.config.SandboxCgroupOnly {
 			// Remove device from cgroup, the hypervisor
 			// should not have access to such device anymore.
@@ -1649,7 +2506,7 @@ func (s *Sandbox) HotplugRemoveDevice(device api.Device, devType config.DeviceTy
 		}
 
 		// remove a group of VFIO devices
-		for _, dev := range vfioDevices {
+		for i, dev := range vfioDevices {
 			if _, err := s.hypervisor.hotplugRemoveDevice(dev, vfioDev); err != nil {
 				s.Logger().WithError(err).
 					WithFields(logrus.Fields{
@@ -1657,6 +2514,7 @@ func (s *Sandbox) HotplugRemoveDevice(device api.Device, devType config.DeviceTy
 						"vfio-device-ID":  dev.ID,
 						"vfio-device-BDF": dev.BDF,
 					}).Error("failed to hot unplug VFIO device")
+				s.replugVFIODevices(vfioDevices[:i])
 				return err
 			}
 		}
@@ -1666,6 +2524,13 @@ func (s *Sandbox) HotplugRemoveDevice(device api.Device, devType config.DeviceTy
 		if !ok {
 			return fmt.Errorf("device type mismatch, expect device type to be %s", devType)
 		}
+
+		// The drive stays in the VM until it stops
+		if caps := s.hypervisor.capabilities(); !caps.IsBlockDeviceHotUnplugSupported() {
+			s.Logger().WithField("device", device.DeviceID()).Warn("hypervisor does not support block device hot unplug")
+			return nil
+		}
+
 		_, err := s.hypervisor.hotplugRemoveDevice(blockDrive, blockDev)
 		return err
 	case config.VhostUserBlk:
@@ -1682,6 +2547,22 @@ func (s *Sandbox) HotplugRemoveDevice(device api.Device, devType config.DeviceTy
 	return nil
 }
 
+// replugVFIODevices hotplugs back the devices of a VFIO group already
+// unplugged when unplugging the group fails, leaving the group whole in
+// the guest.
+func (s *Sandbox) replugVFIODevices(devices []*config.VFIODev) {
+	for _, dev := range devices {
+		if _, err := s.hypervisor.hotplugAddDevice(dev, vfioDev); err != nil {
+			s.Logger().WithError(err).
+				WithFields(logrus.Fields{
+					"sandbox":         s.id,
+					"vfio-device-ID":  dev.ID,
+					"vfio-device-BDF": dev.BDF,
+				}).Error("failed to hotplug back VFIO device")
+		}
+	}
+}
+
 // GetAndSetSandboxBlockIndex is used for getting and setting virtio-block indexes
 // Sandbox implement DeviceReceiver interface from device/api/interface.go
 func (s *Sandbox) GetAndSetSandboxBlockIndex() (int, error) {
@@ -1714,6 +2595,27 @@ func (s *Sandbox) AppendDevice(device api.Device) error {
 	return fmt.Errorf("unsupported device type")
 }
 
+// checkVFIOPeerToPeer checks that the host topology lets the VFIO devices
+// of the sandbox DMA to each other, when peer-to-peer DMA is enabled.
+func (s *Sandbox) checkVFIOPeerToPeer() error {
+	if !s.config.HypervisorConfig.VFIOPeerToPeer {
+		return nil
+	}
+
+	var devs []*config.VFIODev
+	for _, d := range s.devManager.GetAllDevices() {
+		if d.DeviceType() == config.DeviceVFIO && d.GetAttachCount() > 0 {
+			devs = append(devs, d.GetDeviceInfo().([]*config.VFIODev)...)
+		}
+	}
+
+	if len(devs) < 2 {
+		return nil
+	}
+
+	return drivers.CheckPeerToPeer(devs)
+}
+
 // AddDevice will add a device to sandbox
 func (s *Sandbox) AddDevice(info config.DeviceInfo) (api.Device, error) {
 	if s.devManager == nil {
@@ -1743,6 +2645,55 @@ func (s *Sandbox) AddDevice(info config.DeviceInfo) (api.Device, error) {
 	return b, nil
 }
 
+// RemoveDevice hot unplugs a device added with AddDevice from the sandbox
+// and releases it on the host. A device still used by a container cannot
+// be removed: the container must be deleted first. Before the device is
+// unplugged, the agent unmounts it in the guest and writes its buffers
+// out, and the removal fails if the guest cannot release it.
+func (s *Sandbox) RemoveDevice(deviceID string) error {
+	if s.devManager == nil {
+		return fmt.Errorf("device manager isn't initialized")
+	}
+
+	device := s.devManager.GetDeviceByID(deviceID)
+	if device == nil {
+		return deviceManager.ErrDeviceNotExist
+	}
+
+	for _, c := range s.containers {
+		for _, dev := range c.devices {
+			if dev.ID == deviceID {
+				return fmt.Errorf("device %s is in use by container %s", deviceID, c.id)
+			}
+		}
+	}
+
+	// Only the last detach unplugs the device from the guest.
+	if device.GetAttachCount() == 1 {
+		if err := s.agent.releaseDevice(s, device); err != nil {
+			return fmt.Errorf("could not release device %s in the guest: %v", deviceID, err)
+		}
+	}
+
+	err := s.devManager.DetachDevice(deviceID, s)
+	if err != nil && err != deviceManager.ErrDeviceNotAttached {
+		return err
+	}
+	detached := err == nil
+
+	if err := s.devManager.RemoveDevice(deviceID); err != nil {
+		if detached {
+			if attachErr := s.devManager.AttachDevice(deviceID, s); attachErr != nil {
+				s.Logger().WithError(attachErr).WithField("device", deviceID).
+					Error("Could not attach back device")
+			}
+		}
+		return err
+	}
+
+	return s.Save()
+}
+
 // updateResources will calculate the resources required for the virtual machine, and
 // adjust the virtual machine sizing accordingly. For a given sandbox, it will calculate the
 // number of vCPUs required based on the sum of container requests, plus default CPUs for the VM.
@@ -1764,6 +2715,16 @@ func (s *Sandbox) updateResources() error {
 	sandboxMemoryByte := s.calculateSandboxMemory()
 	// Add default / rsvd memory for sandbox.
 	sandboxMemoryByte += int64(s.hypervisor.hypervisorConfig().MemorySize) << utils.MibToBytesShift
+	// Keep the memory ResizeSandboxMemory added.
+	sandboxMemoryByte += int64(s.config.HypervisorConfig.MemoryAdjustmentMB) << utils.MibToBytesShift
+
+	if err := s.config.ResourceCeilings.checkResize(sandboxVCPUs, uint32(sandboxMemoryByte>>utils.MibToBytesShift)); err != nil {
+		return err
+	}
+
+	// Hold back shrinks according to the sandbox resize policy
+	sandboxVCPUs, sandboxMemoryMB, retry := s.resize.debounce(s.config.ResizePolicy, sandboxVCPUs, uint32(sandboxMemoryByte>>utils.MibToBytesShift), time.Now())
+	s.scheduleShrink(retry)
 
 	// Update VCPUs
 	s.Logger().WithField("cpus-sandbox", sandboxVCPUs).Debugf("Request to hypervisor to update vCPUs")
@@ -1776,14 +2737,34 @@ func (s *Sandbox) updateResources() error {
 	if oldCPUs < newCPUs {
 		vcpusAdded := newCPUs - oldCPUs
 		if err := s.agent.onlineCPUMem(vcpusAdded, true); err != nil {
-			return err
+			// The guest cannot use the new vCPUs, unplug them so that
+			// the VM is not sized beyond what the guest runs with.
+			if _, _, rerr := s.hypervisor.resizeVCPUs(oldCPUs); rerr != nil {
+				s.Logger().WithError(rerr).Warn("Could not unplug vCPUs the guest failed to online")
+			}
+			return fmt.Errorf("Guest failed to online %d hotplugged vCPUs: %v", vcpusAdded, err)
 		}
 	}
 	s.Logger().Debugf("Sandbox CPUs: %d", newCPUs)
+	s.resize.recordVCPUs(sandboxVCPUs, time.Now())
 
-	// Update Memory
-	s.Logger().WithField("memory-sandbox-size-byte", sandboxMemoryByte).Debugf("Request to hypervisor to update memory")
-	newMemory, updatedMemoryDevice, err := s.hypervisor.resizeMemory(uint32(sandboxMemoryByte>>utils.MibToBytesShift), s.state.GuestMemoryBlockSizeMB, s.state.GuestMemoryHotplugProbe)
+	if err := s.updateMemory(sandboxMemoryMB); err != nil {
+		return err
+	}
+	s.resize.recordMemory(sandboxMemoryMB, time.Now())
+
+	return nil
+}
+
+// updateMemory resizes the memory of the VM to memoryMB, and has the guest
+// online the memory hotplugged.
+func (s *Sandbox) updateMemory(memoryMB uint32) error {
+	if err := s.claimNodeResources(func(c *nodeClaim) { c.MemoryMB = memoryMB }); err != nil {
+		return err
+	}
+
+	s.Logger().WithField("memory-sandbox-size-byte", int64(memoryMB)<<utils.MibToBytesShift).Debugf("Request to hypervisor to update memory")
+	newMemory, updatedMemoryDevice, err := s.hypervisor.resizeMemory(memoryMB, s.state.GuestMemoryBlockSizeMB, s.state.GuestMemoryHotplugProbe)
 	if err != nil {
 		return err
 	}
@@ -1796,11 +2777,55 @@ func (s *Sandbox) updateResources() error {
 		}
 	}
 	if err := s.agent.onlineCPUMem(0, false); err != nil {
-		return err
+		return fmt.Errorf("Guest failed to online hotplugged memory: %v", err)
 	}
 	return nil
 }
 
+// ResizeMemory resizes the memory of the VM of a running sandbox to
+// targetMB. The memory added beyond the boot memory of the VM and the
+// memory limits of its containers is kept when their resources are
+// updated, until the next resize. Shrinking the VM requires the hypervisor
+// to unplug memory.
+func (s *Sandbox) ResizeMemory(targetMB uint32) error {
+	span, _ := s.trace("ResizeMemory")
+	defer span.Finish()
+
+	if s.state.State != types.StateRunning {
+		return fmt.Errorf("Sandbox %s is not running", s.id)
+	}
+
+	requestedMB := s.hypervisor.hypervisorConfig().MemorySize + uint32(s.calculateSandboxMemory()>>utils.MibToBytesShift)
+	if targetMB < requestedMB {
+		return fmt.Errorf("Cannot resize the sandbox memory to %d MiB, below the %d MiB of the VM and of its containers", targetMB, requestedMB)
+	}
+
+	if err := s.config.ResourceCeilings.checkResize(0, targetMB); err != nil {
+		return err
+	}
+
+	currentMB := requestedMB + s.config.HypervisorConfig.MemoryAdjustmentMB
+	if s.resize.memoryMB > currentMB {
+		// A shrink was held back by the resize policy
+		currentMB = s.resize.memoryMB
+	}
+
+	if targetMB < currentMB {
+		if caps := s.hypervisor.capabilities(); !caps.IsMemoryHotUnplugSupported() {
+			return fmt.Errorf("Memory hot unplug is not supported by the hypervisor")
+		}
+	}
+
+	if err := s.updateMemory(targetMB); err != nil {
+		return err
+	}
+
+	s.config.HypervisorConfig.MemoryAdjustmentMB = targetMB - requestedMB
+	s.resize.recordMemory(targetMB, time.Now())
+
+	return s.Save()
+}
+
 func (s *Sandbox) calculateSandboxMemory() int64 {
 	memorySandbox := int64(0)
 	for _, c := range s.config.Containers {
@@ -1819,20 +2844,8 @@ func (s *Sandbox) calculateSandboxMemory() int64 {
 
 func (s *Sandbox) calculateSandboxCPUs() uint32 {
 	mCPU := uint32(0)
-
-	for _, c := range s.config.Containers {
-		// Do not hot add again non-running containers resources
-		if cont, ok := s.containers[c.ID]; ok && cont.state.State == types.StateStopped {
-			s.Logger().WithField("container-id", c.ID).Debug("Do not taking into account CPU resources of not running containers")
-			continue
-		}
-
-		if cpu := c.Resources.CPU; cpu != nil {
-			if cpu.Period != nil && cpu.Quota != nil {
-				mCPU += utils.CalculateMilliCPUs(*cpu.Quota, *cpu.Period)
-			}
-
-		}
+	for _, m := range s.containerMilliCPUs() {
+		mCPU += m
 	}
 	return utils.CalculateVCpusFromMilliCpus(mCPU)
 }
@@ -1848,6 +2861,13 @@ func (s *Sandbox) GetHypervisorType() string {
 //  2) (re-)add hypervisor vCPU threads to the appropriate cgroup
 //  3) If we are managing sandbox cgroup, update the v1constraints cgroup size
 func (s *Sandbox) cgroupsUpdate() error {
+	if err := s.setVCPUsNice(); err != nil {
+		return err
+	}
+
+	if err := s.setVCPUsAffinity(); err != nil {
+		return err
+	}
 
 	// If Kata is configured for SandboxCgroupOnly, the VMM and its processes are already
 	// in the Kata sandbox cgroup (inherited). No need to move threads/processes, and we should
@@ -2044,7 +3064,7 @@ func (s *Sandbox) cpuResources() *specs.LinuxCPU {
 		}
 
 		if c.config.Resources.CPU.Shares != nil {
-			shares = uint64(math.Max(float64(*c.config.Resources.CPU.Shares), float64(shares)))
+			shares = s.config.CPUShares.aggregate(shares, *c.config.Resources.CPU.Shares)
 		}
 
 		if c.config.Resources.CPU.Quota != nil {
@@ -2144,5 +3164,10 @@ func (s *Sandbox) GetPatchedOCISpec() *specs.Spec {
 }
 
 func (s *Sandbox) GetOOMEvent() (string, error) {
-	return s.agent.getOOMEvent()
+	containerID, err := s.agent.getOOMEvent()
+	if err == nil && containerID != "" {
+		s.publishEvent(SandboxEvent{Type: SandboxEventOOM, ContainerID: containerID})
+	}
+
+	return containerID, err
 }
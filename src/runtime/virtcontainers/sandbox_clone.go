@@ -0,0 +1,353 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+const (
+	// cloneDir is the directory, in the run storage of a sandbox, of the
+	// guest memory and device state it is cloned from, or of the ones a
+	// cloneable sandbox saves.
+	cloneDir = "clone"
+
+	cloneMemoryFile = "memory"
+	cloneStateFile  = "state"
+
+	// cloneSnapshotDir is the directory, in the clone directory of a
+	// cloneable sandbox, of its last snapshot. The clones link its files,
+	// mapping the memory privately: the pages no clone wrote are shared.
+	cloneSnapshotDir = "snapshot"
+
+	// The whence values seeking the data and the holes of a sparse file.
+	seekData = 3
+	seekHole = 4
+)
+
+// checkCloneable checks a cloneable sandbox can be created, its guest
+// memory being backed by a file of the sandbox as the VM templates.
+func checkCloneable(sandboxConfig *SandboxConfig, factory Factory) error {
+	if !sandboxConfig.Cloneable {
+		if sandboxConfig.CloneSnapshotMaxAge != 0 {
+			return fmt.Errorf("A clone snapshot maximum age requires a cloneable sandbox")
+		}
+		if sandboxConfig.PeriodicSnapshots.enabled() {
+			return fmt.Errorf("Periodic snapshots require a cloneable sandbox")
+		}
+		return nil
+	}
+
+	if sandboxConfig.HypervisorType != QemuHypervisor {
+		return fmt.Errorf("Cloneable sandboxes require the %q hypervisor", QemuHypervisor)
+	}
+
+	if factory != nil {
+		return fmt.Errorf("Cloneable sandboxes cannot be created with the VM factory")
+	}
+
+	if sandboxConfig.isForeignGuest() {
+		return fmt.Errorf("Guest OS %q cannot be cloned", sandboxConfig.GuestOS)
+	}
+
+	if sandboxConfig.CloneSnapshotMaxAge < 0 {
+		return fmt.Errorf("Invalid clone snapshot maximum age %v", sandboxConfig.CloneSnapshotMaxAge)
+	}
+
+	return nil
+}
+
+func cloneStoragePath(store persistapi.PersistDriver, sandboxID string) string {
+	return filepath.Join(store.RunStoragePath(), sandboxID, cloneDir)
+}
+
+// setupCloneableMemory backs the memory of a new cloneable sandbox with a
+// shared file, saved with the device state when the sandbox is cloned.
+func (s *Sandbox) setupCloneableMemory(conf *HypervisorConfig) error {
+	dir := cloneStoragePath(s.newStore, s.id)
+	if err := os.MkdirAll(dir, DirMode); err != nil {
+		return err
+	}
+
+	conf.BootToBeTemplate = true
+	conf.MemoryPath = filepath.Join(dir, cloneMemoryFile)
+	conf.DevicesStatePath = filepath.Join(dir, cloneStateFile)
+
+	return nil
+}
+
+// snapshot saves the guest memory and the device state of a cloneable
+// sandbox to dir, the VM being paused meanwhile.
+func (s *Sandbox) snapshot(dir string) (err error) {
+	span, _ := s.trace("snapshot")
+	defer span.Finish()
+
+	if !s.config.Cloneable {
+		return fmt.Errorf("Sandbox %s is not cloneable", s.id)
+	}
+
+	if s.state.State != types.StateRunning {
+		return fmt.Errorf("Sandbox %s not running, impossible to clone", s.id)
+	}
+
+	// The guest of a clone runs what the source does, which the clone
+	// would not know of.
+	if len(s.containers) != 0 {
+		return fmt.Errorf("Sandbox %s has containers, only an empty sandbox can be cloned", s.id)
+	}
+
+	// The network devices of a clone, hotplugged in its own namespace,
+	// cannot match the ones of the source.
+	if len(s.networkNS.Endpoints) != 0 {
+		return fmt.Errorf("Sandbox %s has network interfaces, impossible to clone", s.id)
+	}
+
+	if err := os.MkdirAll(dir, DirMode); err != nil {
+		return err
+	}
+
+	if err := s.hypervisor.pauseSandbox(); err != nil {
+		return err
+	}
+	defer func() {
+		if resumeErr := s.hypervisor.resumeSandbox(); resumeErr != nil {
+			s.Logger().WithError(resumeErr).Error("Could not resume cloned sandbox")
+			if err == nil {
+				err = resumeErr
+			}
+		}
+	}()
+
+	// The shared guest memory is not part of the saved state.
+	if err := s.hypervisor.saveSandbox(); err != nil {
+		return err
+	}
+
+	conf := s.config.HypervisorConfig
+	if err := os.Rename(conf.DevicesStatePath, filepath.Join(dir, cloneStateFile)); err != nil {
+		return err
+	}
+
+	return copySparseFile(conf.MemoryPath, filepath.Join(dir, cloneMemoryFile))
+}
+
+// copySparseFile copies the data of src to dst, leaving the holes of src,
+// the guest memory pages never touched, unallocated.
+func copySparseFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if err := out.Truncate(info.Size()); err != nil {
+		return err
+	}
+
+	var offset int64
+	for offset < info.Size() {
+		start, err := in.Seek(offset, seekData)
+		if err != nil {
+			// ENXIO: no data past the offset
+			if pathErr, ok := err.(*os.PathError); ok && pathErr.Err == syscall.ENXIO {
+				break
+			}
+			return err
+		}
+
+		end, err := in.Seek(start, seekHole)
+		if err != nil {
+			return err
+		}
+
+		if _, err := in.Seek(start, io.SeekStart); err != nil {
+			return err
+		}
+		if _, err := out.Seek(start, io.SeekStart); err != nil {
+			return err
+		}
+		if _, err := io.CopyN(out, in, end-start); err != nil {
+			return err
+		}
+
+		offset = end
+	}
+
+	return nil
+}
+
+// snapshotFresh returns whether the snapshot in dir was taken less than
+// maxAge ago, and can be cloned again.
+func snapshotFresh(dir string, maxAge time.Duration) bool {
+	if maxAge == 0 {
+		return false
+	}
+
+	info, err := os.Stat(filepath.Join(dir, cloneStateFile))
+	if err != nil {
+		return false
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, cloneMemoryFile)); err != nil {
+		return false
+	}
+
+	return time.Since(info.ModTime()) < maxAge
+}
+
+// linkSnapshot links the files of the snapshot in snapshotDir to the clone
+// directory dir, where the clone finds them.
+func linkSnapshot(snapshotDir, dir string) error {
+	if err := os.MkdirAll(dir, DirMode); err != nil {
+		return err
+	}
+
+	for _, f := range []string{cloneMemoryFile, cloneStateFile} {
+		if err := os.Link(filepath.Join(snapshotDir, f), filepath.Join(dir, f)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cloneConfig returns the configuration of a clone of the sandbox booted
+// from the snapshot in dir. The clone runs the VM and the guest of the
+// source, only its identity, network namespace and containers being its
+// own.
+func (s *Sandbox) cloneConfig(sandboxConfig SandboxConfig, dir string) SandboxConfig {
+	sandboxConfig.HypervisorType = s.config.HypervisorType
+	sandboxConfig.HypervisorConfig = s.config.HypervisorConfig
+	sandboxConfig.AgentConfig = s.config.AgentConfig
+	sandboxConfig.GuestOS = s.config.GuestOS
+	sandboxConfig.RootfsDisk = s.config.RootfsDisk
+	sandboxConfig.TimeSync = s.config.TimeSync
+	sandboxConfig.GuestProvisioning = s.config.GuestProvisioning
+	sandboxConfig.Kdump = s.config.Kdump
+	sandboxConfig.GuestProfiling = s.config.GuestProfiling
+	sandboxConfig.GuestLivepatch = s.config.GuestLivepatch
+	sandboxConfig.GuestMemoryReclaim = s.config.GuestMemoryReclaim
+	sandboxConfig.MetadataProxy = s.config.MetadataProxy
+	sandboxConfig.TrafficCapture = s.config.TrafficCapture
+	sandboxConfig.Cloneable = false
+	sandboxConfig.CloneSnapshotMaxAge = 0
+	sandboxConfig.PeriodicSnapshots = SnapshotPolicy{}
+
+	conf := &sandboxConfig.HypervisorConfig
+	conf.BootToBeTemplate = false
+	conf.BootFromTemplate = true
+	conf.MemoryPath = filepath.Join(dir, cloneMemoryFile)
+	conf.DevicesStatePath = filepath.Join(dir, cloneStateFile)
+
+	// The crash kernel memory is reserved again for the clone.
+	if s.config.Kdump.enabled() {
+		conf.MemorySize -= s.config.Kdump.CrashKernelMB
+	}
+
+	return sandboxConfig
+}
+
+// clone creates and starts a sandbox running a copy of the guest of the
+// sandbox.
+func (s *Sandbox) clone(ctx context.Context, sandboxConfig SandboxConfig) (*Sandbox, error) {
+	if err := s.checkCloneID(sandboxConfig.ID); err != nil {
+		return nil, err
+	}
+
+	snapshotDir := filepath.Join(cloneStoragePath(s.newStore, s.id), cloneSnapshotDir)
+	if !snapshotFresh(snapshotDir, s.config.CloneSnapshotMaxAge) {
+		// The clones of the previous snapshot keep its files linked.
+		if err := os.RemoveAll(snapshotDir); err != nil {
+			return nil, err
+		}
+
+		if err := s.snapshot(snapshotDir); err != nil {
+			os.RemoveAll(snapshotDir)
+			return nil, fmt.Errorf("Could not snapshot sandbox %s: %v", s.id, err)
+		}
+	}
+
+	dir := cloneStoragePath(s.newStore, sandboxConfig.ID)
+	if err := linkSnapshot(snapshotDir, dir); err != nil {
+		os.RemoveAll(filepath.Dir(dir))
+		return nil, err
+	}
+
+	return s.createClone(ctx, sandboxConfig, dir)
+}
+
+// checkCloneID checks a clone of the sandbox can be created with the ID.
+func (s *Sandbox) checkCloneID(id string) error {
+	if id == "" {
+		return newConfigFieldError("ID", "Missing sandbox ID")
+	}
+
+	if id == s.id {
+		return fmt.Errorf("Sandbox %s cannot be cloned to itself", s.id)
+	}
+
+	if _, err := os.Stat(filepath.Join(s.newStore.RunStoragePath(), id)); err == nil {
+		return fmt.Errorf("Sandbox %s already exists", id)
+	}
+
+	return nil
+}
+
+// createClone creates and starts a clone of the sandbox booted from the
+// snapshot in dir, removed if the clone cannot be created.
+func (s *Sandbox) createClone(ctx context.Context, sandboxConfig SandboxConfig, dir string) (*Sandbox, error) {
+	clone, err := createSandboxFromConfig(ctx, s.cloneConfig(sandboxConfig, dir), nil)
+	if err != nil {
+		os.RemoveAll(filepath.Dir(dir))
+		return nil, err
+	}
+
+	clone.Logger().WithField("source", s.id).Info("Sandbox cloned")
+
+	return clone, nil
+}
+
+// reseedClone reseeds the random number generator of the guest of a clone,
+// so that it does not generate the numbers of the source, and syncs its
+// time.
+func (s *Sandbox) reseedClone() error {
+	data := make([]byte, 512)
+	f, err := os.Open("/dev/urandom")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := io.ReadFull(f, data); err != nil {
+		return err
+	}
+
+	if err := s.agent.reseedRNG(data); err != nil {
+		return err
+	}
+
+	return s.agent.setGuestDateTime(time.Now())
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCloneable(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(checkCloneable(&SandboxConfig{HypervisorType: FirecrackerHypervisor}, nil))
+	assert.NoError(checkCloneable(&SandboxConfig{HypervisorType: QemuHypervisor, Cloneable: true}, nil))
+
+	assert.Error(checkCloneable(&SandboxConfig{HypervisorType: FirecrackerHypervisor, Cloneable: true}, nil))
+	assert.Error(checkCloneable(&SandboxConfig{HypervisorType: QemuHypervisor, Cloneable: true}, &noopFactory{}))
+	assert.Error(checkCloneable(&SandboxConfig{HypervisorType: QemuHypervisor, Cloneable: true, GuestOS: GuestOSOther}, nil))
+
+	assert.NoError(checkCloneable(&SandboxConfig{HypervisorType: QemuHypervisor, Cloneable: true, CloneSnapshotMaxAge: time.Minute}, nil))
+	assert.Error(checkCloneable(&SandboxConfig{HypervisorType: QemuHypervisor, Cloneable: true, CloneSnapshotMaxAge: -time.Minute}, nil))
+	assert.Error(checkCloneable(&SandboxConfig{HypervisorType: QemuHypervisor, CloneSnapshotMaxAge: time.Minute}, nil))
+	assert.Error(checkCloneable(&SandboxConfig{HypervisorType: QemuHypervisor, PeriodicSnapshots: SnapshotPolicy{Interval: time.Hour, Retention: 1}}, nil))
+}
+
+func TestSandboxSnapshotState(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id:         "sandbox",
+		config:     &SandboxConfig{},
+		containers: map[string]*Container{},
+		state:      types.SandboxState{State: types.StateRunning},
+	}
+
+	dir, err := ioutil.TempDir("", "clone")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	// Not cloneable
+	assert.Error(s.snapshot(dir))
+
+	s.config.Cloneable = true
+	s.state.State = types.StatePaused
+	assert.Error(s.snapshot(dir))
+
+	s.state.State = types.StateRunning
+	s.containers["ctr"] = &Container{}
+	assert.Error(s.snapshot(dir))
+
+	s.containers = map[string]*Container{}
+	s.networkNS.Endpoints = []Endpoint{&VethEndpoint{}}
+	assert.Error(s.snapshot(dir))
+}
+
+func TestCopySparseFile(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "clone")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "src")
+	dst := filepath.Join(dir, "dst")
+
+	f, err := os.Create(src)
+	assert.NoError(err)
+	assert.NoError(f.Truncate(4 << 20))
+	_, err = f.WriteAt([]byte("page"), 1<<20)
+	assert.NoError(err)
+	_, err = f.WriteAt([]byte("last"), 4<<20-4)
+	assert.NoError(err)
+	assert.NoError(f.Close())
+
+	assert.NoError(copySparseFile(src, dst))
+
+	expected, err := ioutil.ReadFile(src)
+	assert.NoError(err)
+	data, err := ioutil.ReadFile(dst)
+	assert.NoError(err)
+	assert.Equal(expected, data)
+
+	// A file without data
+	assert.NoError(os.Truncate(src, 0))
+	assert.NoError(os.Truncate(src, 1<<20))
+	assert.NoError(copySparseFile(src, dst))
+
+	info, err := os.Stat(dst)
+	assert.NoError(err)
+	assert.Equal(int64(1<<20), info.Size())
+}
+
+func TestSnapshotFresh(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "clone")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	assert.False(snapshotFresh(dir, time.Minute))
+
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, cloneStateFile), []byte("state"), 0600))
+	assert.False(snapshotFresh(dir, time.Minute))
+
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, cloneMemoryFile), []byte("memory"), 0600))
+	assert.True(snapshotFresh(dir, time.Minute))
+
+	// Each clone takes its own snapshot by default
+	assert.False(snapshotFresh(dir, 0))
+
+	old := time.Now().Add(-2 * time.Minute)
+	assert.NoError(os.Chtimes(filepath.Join(dir, cloneStateFile), old, old))
+	assert.False(snapshotFresh(dir, time.Minute))
+}
+
+func TestLinkSnapshot(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "clone")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	snapshotDir := filepath.Join(dir, "source", cloneSnapshotDir)
+	assert.NoError(os.MkdirAll(snapshotDir, DirMode))
+	assert.NoError(ioutil.WriteFile(filepath.Join(snapshotDir, cloneStateFile), []byte("state"), 0600))
+	assert.NoError(ioutil.WriteFile(filepath.Join(snapshotDir, cloneMemoryFile), []byte("memory"), 0600))
+
+	cloneDir := filepath.Join(dir, "clone", cloneDir)
+	assert.NoError(linkSnapshot(snapshotDir, cloneDir))
+
+	// The clones share the snapshot files, kept once the snapshot is
+	// replaced.
+	assert.NoError(os.RemoveAll(snapshotDir))
+
+	data, err := ioutil.ReadFile(filepath.Join(cloneDir, cloneMemoryFile))
+	assert.NoError(err)
+	assert.Equal("memory", string(data))
+
+	assert.Error(linkSnapshot(snapshotDir, filepath.Join(dir, "other")))
+}
+
+func TestSandboxCloneConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id: "sandbox",
+		config: &SandboxConfig{
+			HypervisorType: QemuHypervisor,
+			HypervisorConfig: HypervisorConfig{
+				MemorySize:       2048 + 256,
+				BootToBeTemplate: true,
+				MemoryPath:       "/run/vc/sbs/sandbox/clone/memory",
+				DevicesStatePath: "/run/vc/sbs/sandbox/clone/state",
+			},
+			Kdump:     Kdump{CrashKernelMB: 256, HostDir: "/var/crash"},
+			Cloneable: true,
+
+			CloneSnapshotMaxAge: time.Minute,
+		},
+	}
+
+	conf := s.cloneConfig(SandboxConfig{ID: "clone", HypervisorType: FirecrackerHypervisor}, "/run/vc/sbs/clone/clone")
+
+	assert.Equal("clone", conf.ID)
+	assert.Equal(QemuHypervisor, conf.HypervisorType)
+	assert.False(conf.Cloneable)
+	assert.Zero(conf.CloneSnapshotMaxAge)
+	assert.False(conf.HypervisorConfig.BootToBeTemplate)
+	assert.True(conf.HypervisorConfig.BootFromTemplate)
+	assert.Equal("/run/vc/sbs/clone/clone/memory", conf.HypervisorConfig.MemoryPath)
+	assert.Equal("/run/vc/sbs/clone/clone/state", conf.HypervisorConfig.DevicesStatePath)
+	assert.Equal(s.config.Kdump, conf.Kdump)
+
+	// newSandbox reserves the crash kernel memory again
+	assert.Equal(uint32(2048), conf.HypervisorConfig.MemorySize)
+
+	// The source is left untouched
+	assert.True(s.config.HypervisorConfig.BootToBeTemplate)
+}
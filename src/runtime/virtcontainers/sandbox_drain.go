@@ -0,0 +1,266 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/sirupsen/logrus"
+)
+
+// drainStopTimeout is how long a sandbox has to stop once its hypervisor
+// was killed.
+const drainStopTimeout = 10 * time.Second
+
+// DrainOutcome is how a sandbox was stopped by DrainAllSandboxes.
+type DrainOutcome string
+
+const (
+	// DrainStopped means the sandbox stopped within its grace period.
+	DrainStopped DrainOutcome = "stopped"
+
+	// DrainForced means the sandbox did not stop within its grace period,
+	// or failed to, and was stopped by force after killing its
+	// hypervisor.
+	DrainForced DrainOutcome = "forced"
+
+	// DrainFailed means the sandbox could not be stopped, even by force,
+	// or deleted.
+	DrainFailed DrainOutcome = "failed"
+)
+
+// DrainPolicy describes how DrainAllSandboxes stops the sandboxes.
+type DrainPolicy struct {
+	// GracePeriod is how long a sandbox has to stop before its hypervisor
+	// is killed. It is cut short by the drain deadline.
+	GracePeriod time.Duration
+
+	// SandboxGracePeriods override GracePeriod for some sandboxes, by ID.
+	SandboxGracePeriods map[string]time.Duration
+
+	// Parallelism is the maximum number of sandboxes stopped at the same
+	// time, no limit if zero.
+	Parallelism int
+
+	// Delete deletes the sandboxes once stopped.
+	Delete bool
+}
+
+// DrainResult is the outcome of the drain of a sandbox.
+type DrainResult struct {
+	SandboxID string        `json:"sandbox_id"`
+	Outcome   DrainOutcome  `json:"outcome"`
+	Duration  time.Duration `json:"duration"`
+
+	// Error is the last error met, also set for forced stops to tell
+	// why the sandbox did not stop gracefully.
+	Error string `json:"error,omitempty"`
+}
+
+// gracePeriod returns the grace period of a sandbox, cut short by the
+// deadline.
+func (p DrainPolicy) gracePeriod(sandboxID string, deadline time.Time) time.Duration {
+	grace := p.GracePeriod
+	if g, ok := p.SandboxGracePeriods[sandboxID]; ok {
+		grace = g
+	}
+
+	if !deadline.IsZero() {
+		if left := time.Until(deadline); left < grace {
+			grace = left
+		}
+	}
+
+	if grace < 0 {
+		return 0
+	}
+	return grace
+}
+
+// DrainAllSandboxes stops all the sandboxes of the host concurrently, to
+// empty a node. Each sandbox gets its grace period to stop, after which
+// its hypervisor is killed and it is stopped by force. No sandbox gets a
+// grace period past the deadline, if any. The results are sorted by
+// sandbox ID.
+func DrainAllSandboxes(ctx context.Context, deadline time.Time, policy DrainPolicy) ([]DrainResult, error) {
+	span, ctx := trace(ctx, "DrainAllSandboxes")
+	defer span.Finish()
+
+	sandboxIDs, err := listSandboxIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	sem := make(chan struct{}, len(sandboxIDs))
+	if policy.Parallelism > 0 && policy.Parallelism < len(sandboxIDs) {
+		sem = make(chan struct{}, policy.Parallelism)
+	}
+
+	results := make([]DrainResult, len(sandboxIDs))
+	var wg sync.WaitGroup
+	for i, sandboxID := range sandboxIDs {
+		wg.Add(1)
+		go func(i int, sandboxID string) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = drainSandbox(ctx, sandboxID, policy.gracePeriod(sandboxID, deadline), policy.Delete)
+		}(i, sandboxID)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].SandboxID < results[j].SandboxID
+	})
+
+	return results, nil
+}
+
+func drainSandbox(ctx context.Context, sandboxID string, grace time.Duration, delete bool) DrainResult {
+	start := time.Now()
+	result := DrainResult{
+		SandboxID: sandboxID,
+		Outcome:   DrainStopped,
+	}
+
+	logger := virtLog.WithFields(logrus.Fields{
+		"sandbox":      sandboxID,
+		"grace-period": grace,
+	})
+
+	done := stopSandboxAsync(ctx, sandboxID, false)
+
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-timer.C:
+		logger.Warn("Sandbox not stopped within its grace period, killing its hypervisor")
+		killSandboxHypervisor(sandboxID)
+		// The stop fails as soon as the agent is gone, unless it
+		// is stuck elsewhere.
+		select {
+		case <-done:
+		case <-time.After(drainStopTimeout):
+			result.Outcome = DrainFailed
+			result.Error = fmt.Sprintf("Sandbox still stopping %v after its hypervisor was killed", drainStopTimeout)
+		}
+		err = context.DeadlineExceeded
+	}
+
+	if err != nil && result.Outcome != DrainFailed {
+		result.Outcome = DrainForced
+		result.Error = err.Error()
+
+		// The forced stop waits for the sandbox lock, held by a stuck
+		// stop, or gets stuck itself.
+		select {
+		case err = <-stopSandboxAsync(ctx, sandboxID, true):
+		case <-time.After(drainStopTimeout):
+			err = fmt.Errorf("Sandbox not stopped by force within %v", drainStopTimeout)
+		}
+		if err != nil {
+			result.Outcome = DrainFailed
+			result.Error = err.Error()
+		}
+	}
+
+	if delete && result.Outcome != DrainFailed {
+		if _, err = DeleteSandbox(ctx, sandboxID); err != nil {
+			result.Outcome = DrainFailed
+			result.Error = err.Error()
+		}
+	}
+
+	result.Duration = time.Since(start)
+	logger.WithField("outcome", result.Outcome).Info("Sandbox drained")
+
+	return result
+}
+
+// stopSandboxAsync stops a sandbox in the background, sending the outcome
+// on the returned channel.
+func stopSandboxAsync(ctx context.Context, sandboxID string, force bool) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		_, err := StopSandbox(ctx, sandboxID, force)
+		done <- err
+	}()
+
+	return done
+}
+
+// killSandboxHypervisor kills the hypervisor of a sandbox, from its stored
+// state, since the sandbox is locked by its ongoing stop.
+func killSandboxHypervisor(sandboxID string) {
+	store, err := persist.GetDriver()
+	if err != nil {
+		return
+	}
+
+	ss, _, err := store.FromDisk(sandboxID)
+	if err != nil || ss.HypervisorState.Pid <= 0 {
+		return
+	}
+
+	logger := virtLog.WithFields(logrus.Fields{
+		"sandbox": sandboxID,
+		"pid":     ss.HypervisorState.Pid,
+	})
+
+	// The hypervisor may have exited already, and its pid been reused.
+	if !isSandboxHypervisor(sandboxID, ss.HypervisorState) {
+		logger.Warn("Process no longer the hypervisor of the sandbox, not killing it")
+		return
+	}
+
+	if err := syscall.Kill(ss.HypervisorState.Pid, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+		logger.WithError(err).Warn("Failed to kill the hypervisor")
+	}
+}
+
+// isSandboxHypervisor tells whether the process of the stored hypervisor
+// state is still the hypervisor of the sandbox: it started at the stored
+// time and its command line refers to the sandbox.
+func isSandboxHypervisor(sandboxID string, hs persistapi.HypervisorState) bool {
+	if hs.PidStartTime == 0 {
+		return false
+	}
+
+	if start, err := processStartTime(hs.Pid); err != nil || start != hs.PidStartTime {
+		return false
+	}
+
+	cmdline, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/cmdline", hs.Pid))
+	if err != nil {
+		return false
+	}
+
+	return bytes.Contains(cmdline, []byte(sandboxID))
+}
+
+// listSandboxIDs returns the IDs of the sandboxes stored on the host.
+func listSandboxIDs() ([]string, error) {
+	store, err := persist.GetDriver()
+	if err != nil {
+		return nil, err
+	}
+
+	return persist.ListSandboxes(store)
+}
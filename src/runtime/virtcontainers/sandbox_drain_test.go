@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainPolicyGracePeriod(t *testing.T) {
+	assert := assert.New(t)
+
+	policy := DrainPolicy{
+		GracePeriod: 30 * time.Second,
+		SandboxGracePeriods: map[string]time.Duration{
+			"slow": time.Minute,
+		},
+	}
+
+	// No deadline
+	assert.Equal(30*time.Second, policy.gracePeriod("fast", time.Time{}))
+	assert.Equal(time.Minute, policy.gracePeriod("slow", time.Time{}))
+
+	// Cut short by the deadline
+	grace := policy.gracePeriod("slow", time.Now().Add(10*time.Second))
+	assert.True(grace <= 10*time.Second)
+	assert.True(grace > 0)
+
+	// Deadline already passed
+	assert.Equal(time.Duration(0), policy.gracePeriod("fast", time.Now().Add(-time.Second)))
+}
+
+func TestDrainAllSandboxes(t *testing.T) {
+	// Start from an empty store, whatever other tests left behind
+	cleanUp()
+	defer cleanUp()
+	assert := assert.New(t)
+
+	// No sandbox
+	results, err := DrainAllSandboxes(context.Background(), time.Time{}, DrainPolicy{})
+	assert.NoError(err)
+	assert.Empty(results)
+
+	ctx := WithNewAgentFunc(context.Background(), newMockAgent)
+	p, sandboxDir, err := createAndStartSandbox(ctx, newTestSandboxConfigNoop())
+	assert.NoError(err)
+	assert.NotNil(p)
+
+	results, err = DrainAllSandboxes(ctx, time.Now().Add(time.Minute), DrainPolicy{
+		GracePeriod: 10 * time.Second,
+		Parallelism: 1,
+		Delete:      true,
+	})
+	assert.NoError(err)
+	assert.Len(results, 1)
+	assert.Equal(p.ID(), results[0].SandboxID)
+	assert.Equal(DrainStopped, results[0].Outcome)
+	assert.Empty(results[0].Error)
+
+	_, err = os.Stat(sandboxDir)
+	assert.True(os.IsNotExist(err))
+}
+
+func TestIsSandboxHypervisor(t *testing.T) {
+	assert := assert.New(t)
+
+	// A process whose command line refers to the sandbox
+	sandboxID := "drain-test-sandbox"
+	cmd := exec.Command("sh", "-c", "sleep 30; true", sandboxID)
+	if !assert.NoError(cmd.Start()) {
+		t.FailNow()
+	}
+	defer cmd.Process.Kill()
+
+	start, err := processStartTime(cmd.Process.Pid)
+	assert.NoError(err)
+	assert.NotZero(start)
+
+	hs := persistapi.HypervisorState{
+		Pid:          cmd.Process.Pid,
+		PidStartTime: start,
+	}
+	assert.True(isSandboxHypervisor(sandboxID, hs))
+
+	// Another sandbox
+	assert.False(isSandboxHypervisor("other-sandbox", hs))
+
+	// A process reusing the pid starts later
+	hs.PidStartTime = start + 1
+	assert.False(isSandboxHypervisor(sandboxID, hs))
+
+	// No start time stored
+	hs.PidStartTime = 0
+	assert.False(isSandboxHypervisor(sandboxID, hs))
+
+	// The process exited
+	hs.PidStartTime = start
+	assert.NoError(cmd.Process.Kill())
+	cmd.Wait()
+	assert.False(isSandboxHypervisor(sandboxID, hs))
+}
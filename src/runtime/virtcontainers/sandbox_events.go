@@ -0,0 +1,292 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+// sandboxEventsChannelSize is how many events a watcher may fall behind by
+// before the next ones are dropped for it.
+const sandboxEventsChannelSize = 128
+
+const (
+	// sandboxEventJournal is the file of the sandbox storage directory
+	// every event of the sandbox is recorded to, one JSON object per line.
+	sandboxEventJournal = "events.json"
+
+	// maxEventJournalSize is the size past which the journal is rotated,
+	// the previous journal being kept with a ".1" suffix.
+	maxEventJournalSize = 256 * 1024
+)
+
+// SandboxEventType is the type of an event of a sandbox.
+type SandboxEventType string
+
+const (
+	// SandboxEventState is a lifecycle transition of the sandbox.
+	SandboxEventState SandboxEventType = "state"
+
+	// SandboxEventOOM is a container of the sandbox running out of
+	// memory, as reported by the agent.
+	SandboxEventOOM SandboxEventType = "oom"
+
+	// SandboxEventHypervisorCrash is the hypervisor process found dead or
+	// unresponsive.
+	SandboxEventHypervisorCrash SandboxEventType = "hypervisor-crash"
+
+	// SandboxEventHotplug is the result of the hotplug, or the hot unplug,
+	// of a device.
+	SandboxEventHotplug SandboxEventType = "hotplug"
+
+	// SandboxEventShutdownEscalation is the VM of the sandbox stopped by
+	// a later stage of its graceful shutdown than the first one.
+	SandboxEventShutdownEscalation SandboxEventType = "shutdown-escalation"
+)
+
+// SandboxEvent is an event of a sandbox.
+type SandboxEvent struct {
+	Type      SandboxEventType
+	SandboxID string
+	Time      time.Time
+
+	// State is the state the sandbox moved to, for the lifecycle events.
+	State types.StateString
+
+	// ContainerID is the container which ran out of memory, for the OOM
+	// events.
+	ContainerID string
+
+	// DeviceID and DeviceType are the device hotplugged, or hot unplugged
+	// when Unplug is true, for the hotplug events.
+	DeviceID   string
+	DeviceType config.DeviceType
+	Unplug     bool
+
+	// Err is why the hotplug failed, for the hotplug events, why the
+	// hypervisor is deemed crashed, or the ShutdownEscalationError of the
+	// shutdown escalation events.
+	Err error
+}
+
+// JournaledSandboxEvent is a sandbox event as recorded to the event
+// journal of the sandbox.
+type JournaledSandboxEvent struct {
+	SandboxEvent
+
+	// Err is the error of the event, as a string.
+	Err string `json:",omitempty"`
+}
+
+// sandboxEvents sends the events of a sandbox to its watchers, and records
+// them to the event journal.
+type sandboxEvents struct {
+	sync.Mutex
+
+	watchers map[chan SandboxEvent]struct{}
+
+	// journal is the path of the event journal, empty if the events are
+	// not recorded.
+	journal string
+
+	// done is closed, along with the channels of the watchers, once the
+	// sandbox is released.
+	done chan struct{}
+}
+
+func newSandboxEvents(journal string) *sandboxEvents {
+	return &sandboxEvents{
+		watchers: make(map[chan SandboxEvent]struct{}),
+		journal:  journal,
+		done:     make(chan struct{}),
+	}
+}
+
+// watch returns a channel receiving the next events, closed when ctx is
+// done or the sandbox is released.
+func (e *sandboxEvents) watch(ctx context.Context) (<-chan SandboxEvent, error) {
+	e.Lock()
+	defer e.Unlock()
+
+	select {
+	case <-e.done:
+		return nil, errors.New("Sandbox is released")
+	default:
+	}
+
+	watcher := make(chan SandboxEvent, sandboxEventsChannelSize)
+	e.watchers[watcher] = struct{}{}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			e.unwatch(watcher)
+		case <-e.done:
+		}
+	}()
+
+	return watcher, nil
+}
+
+func (e *sandboxEvents) unwatch(watcher chan SandboxEvent) {
+	e.Lock()
+	defer e.Unlock()
+
+	if _, ok := e.watchers[watcher]; ok {
+		delete(e.watchers, watcher)
+		close(watcher)
+	}
+}
+
+// publish records an event to the journal and sends it to the watchers,
+// without waiting for the ones which fell behind.
+func (e *sandboxEvents) publish(event SandboxEvent) {
+	e.Lock()
+	defer e.Unlock()
+
+	select {
+	case <-e.done:
+		return
+	default:
+	}
+
+	if err := e.record(event); err != nil {
+		virtLog.WithError(err).Warnf("failed to record %s event", event.Type)
+	}
+
+	for watcher := range e.watchers {
+		select {
+		case watcher <- event:
+		default:
+			virtLog.WithField("channel-size", sandboxEventsChannelSize).Warnf("sandbox event watcher channel is full, throw %s event", event.Type)
+		}
+	}
+}
+
+// record appends an event to the journal. The events published before the
+// sandbox storage directory is created, or after it is removed, are not
+// recorded.
+func (e *sandboxEvents) record(event SandboxEvent) error {
+	if e.journal == "" {
+		return nil
+	}
+
+	if fi, err := os.Stat(e.journal); err == nil && fi.Size() > maxEventJournalSize {
+		if err := os.Rename(e.journal, e.journal+".1"); err != nil {
+			return err
+		}
+	}
+
+	entry := JournaledSandboxEvent{SandboxEvent: event}
+	if event.Err != nil {
+		entry.Err = event.Err.Error()
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(e.journal, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// readEventJournal returns the events recorded to the journal at path,
+// oldest first, including the ones of the rotated journal. Lines which
+// cannot be decoded, such as one cut by a crash, are skipped.
+func readEventJournal(path string) ([]JournaledSandboxEvent, error) {
+	var events []JournaledSandboxEvent
+
+	for _, p := range []string{path + ".1", path} {
+		f, err := os.Open(p)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var event JournaledSandboxEvent
+			if err := json.Unmarshal(scanner.Bytes(), &event); err == nil {
+				events = append(events, event)
+			}
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return events, nil
+}
+
+// close closes the channels of the watchers, no event being sent anymore.
+func (e *sandboxEvents) close() {
+	e.Lock()
+	defer e.Unlock()
+
+	select {
+	case <-e.done:
+		return
+	default:
+	}
+
+	close(e.done)
+	for watcher := range e.watchers {
+		close(watcher)
+	}
+	e.watchers = nil
+}
+
+// publishEvent sends an event of the sandbox to its watchers. Sandboxes
+// which are not created by createSandbox, as in the unit tests, have no
+// watchers.
+func (s *Sandbox) publishEvent(event SandboxEvent) {
+	if s.events == nil {
+		return
+	}
+
+	event.SandboxID = s.id
+	event.Time = time.Now()
+	s.events.publish(event)
+}
+
+// watchEvents returns a channel receiving the next events of the sandbox.
+func (s *Sandbox) watchEvents(ctx context.Context) (<-chan SandboxEvent, error) {
+	if s.events == nil {
+		return nil, errors.New("Sandbox has no events")
+	}
+
+	return s.events.watch(ctx)
+}
+
+// releaseEvents ends the event stream of the sandbox.
+func (s *Sandbox) releaseEvents() {
+	if s.events != nil {
+		s.events.close()
+	}
+}
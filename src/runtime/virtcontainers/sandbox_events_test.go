@@ -0,0 +1,197 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/drivers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// oomAgent is a mock agent reporting an OOM in a container.
+type oomAgent struct {
+	mockAgent
+}
+
+func (n *oomAgent) getOOMEvent() (string, error) {
+	return "cont-oom", nil
+}
+
+func TestSandboxEvents(t *testing.T) {
+	assert := assert.New(t)
+
+	h := &unplugHypervisor{failID: "dev2", plugged: map[string]bool{}}
+	s := &Sandbox{
+		id:         "test-events",
+		hypervisor: h,
+		agent:      &oomAgent{},
+		config:     &SandboxConfig{},
+		events:     newSandboxEvents(""),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := s.watchEvents(ctx)
+	assert.NoError(err)
+
+	assert.NoError(s.setSandboxState(types.StateRunning))
+	event := <-events
+	assert.Equal(SandboxEventState, event.Type)
+	assert.Equal("test-events", event.SandboxID)
+	assert.Equal(types.StateRunning, event.State)
+	assert.False(event.Time.IsZero())
+
+	containerID, err := s.GetOOMEvent()
+	assert.NoError(err)
+	assert.Equal("cont-oom", containerID)
+	event = <-events
+	assert.Equal(SandboxEventOOM, event.Type)
+	assert.Equal("cont-oom", event.ContainerID)
+
+	device := drivers.NewVFIODevice(&config.DeviceInfo{ID: "group"})
+	device.VfioDevs = []*config.VFIODev{{ID: "dev1"}, {ID: "dev2"}}
+	assert.NoError(s.HotplugAddDevice(device, config.DeviceVFIO))
+	event = <-events
+	assert.Equal(SandboxEventHotplug, event.Type)
+	assert.Equal("group", event.DeviceID)
+	assert.Equal(config.DeviceVFIO, event.DeviceType)
+	assert.False(event.Unplug)
+	assert.NoError(event.Err)
+
+	assert.Error(s.HotplugRemoveDevice(device, config.DeviceVFIO))
+	event = <-events
+	assert.Equal(SandboxEventHotplug, event.Type)
+	assert.True(event.Unplug)
+	assert.Error(event.Err)
+
+	// Cancelling the watch closes the channel
+	cancel()
+	_, ok := <-events
+	assert.False(ok)
+
+	events, err = s.watchEvents(context.Background())
+	assert.NoError(err)
+
+	// Releasing the sandbox closes the channels, and no more watches are
+	// accepted
+	s.releaseEvents()
+	_, ok = <-events
+	assert.False(ok)
+	_, err = s.watchEvents(context.Background())
+	assert.Error(err)
+
+	// Events published after the release are dropped
+	assert.NoError(s.setSandboxState(types.StateStopped))
+}
+
+func TestSandboxEventsWatcherFallingBehind(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{id: "test-events", events: newSandboxEvents("")}
+
+	events, err := s.watchEvents(context.Background())
+	assert.NoError(err)
+
+	for i := 0; i < sandboxEventsChannelSize+1; i++ {
+		assert.NoError(s.setSandboxState(types.StateRunning))
+	}
+	assert.Len(events, sandboxEventsChannelSize)
+
+	s.releaseEvents()
+}
+
+func TestSandboxEventJournal(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "events")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	journal := filepath.Join(dir, sandboxEventJournal)
+	e := newSandboxEvents(journal)
+
+	// Every type of event is recorded, without any watcher
+	e.publish(SandboxEvent{Type: SandboxEventState, State: types.StateRunning})
+	e.publish(SandboxEvent{Type: SandboxEventOOM, ContainerID: "cont-oom"})
+	e.publish(SandboxEvent{Type: SandboxEventHypervisorCrash, Err: errors.New("hypervisor exited")})
+	e.publish(SandboxEvent{Type: SandboxEventHotplug, DeviceID: "dev1", Unplug: true})
+
+	events, err := readEventJournal(journal)
+	assert.NoError(err)
+	assert.Len(events, 4)
+	assert.Equal(types.StateRunning, events[0].State)
+	assert.Equal("cont-oom", events[1].ContainerID)
+	assert.Equal("hypervisor exited", events[2].Err)
+	assert.True(events[3].Unplug)
+	assert.Empty(events[3].Err)
+
+	// A full journal is rotated, the previous events are kept, and the
+	// lines which cannot be decoded are skipped
+	f, err := os.OpenFile(journal, os.O_WRONLY|os.O_APPEND, 0600)
+	assert.NoError(err)
+	_, err = f.Write(bytes.Repeat([]byte("x\n"), maxEventJournalSize/2))
+	f.Close()
+	assert.NoError(err)
+
+	e.publish(SandboxEvent{Type: SandboxEventState, State: types.StateStopped})
+	events, err = readEventJournal(journal)
+	assert.NoError(err)
+	assert.Len(events, 5)
+	assert.Equal(types.StateStopped, events[4].State)
+
+	// Events are not recorded once the sandbox is released, nor when the
+	// storage directory is gone.
+	e.close()
+	e.publish(SandboxEvent{Type: SandboxEventState, State: types.StateRunning})
+	events, err = readEventJournal(journal)
+	assert.NoError(err)
+	assert.Len(events, 5)
+
+	e = newSandboxEvents(filepath.Join(dir, "missing", sandboxEventJournal))
+	assert.NoError(e.record(SandboxEvent{Type: SandboxEventState}))
+}
+
+func TestWatchSandboxEvents(t *testing.T) {
+	assert := assert.New(t)
+
+	defer cleanUp()
+
+	_, err := WatchSandboxEvents(context.Background(), "")
+	assert.Error(err)
+
+	_, err = WatchSandboxEvents(context.Background(), testSandboxID)
+	assert.Error(err)
+
+	s, err := testCreateSandbox(t,
+		testSandboxID,
+		MockHypervisor,
+		newHypervisorConfig(nil, nil),
+		NetworkConfig{},
+		nil,
+		nil)
+	assert.NoError(err)
+
+	events, err := WatchSandboxEvents(context.Background(), testSandboxID)
+	assert.NoError(err)
+
+	assert.NoError(s.Start())
+	event := <-events
+	assert.Equal(SandboxEventState, event.Type)
+	assert.Equal(testSandboxID, event.SandboxID)
+	assert.Equal(types.StateRunning, event.State)
+
+	assert.NoError(s.Release())
+	_, ok := <-events
+	assert.False(ok)
+}
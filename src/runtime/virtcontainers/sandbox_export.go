@@ -0,0 +1,397 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// sandboxExportVersion is bumped whenever the layout of the export changes
+// in a way the loader must know about.
+const sandboxExportVersion = 1
+
+const (
+	exportMetadataFile = "export.json"
+	exportSandboxFile  = "state/sandbox.json"
+	exportContainerDir = "state/containers"
+	exportDevicesFile  = "devices.json"
+	exportSpecFile     = "config.json"
+	exportEventsFile   = "events.json"
+	exportLogDir       = "logs"
+
+	// maxExportLogSize is how much of the end of each log is exported.
+	maxExportLogSize = 256 * 1024
+
+	redactedValue = "<redacted>"
+)
+
+// secretNamePattern matches the names of the annotations and environment
+// variables whose values are redacted from an export.
+var secretNamePattern = regexp.MustCompile(`(?i)(secret|token|passw|credential|private|key)`)
+
+// ExportedDevice is a sandbox device and the containers using it.
+type ExportedDevice struct {
+	persistapi.DeviceState
+	Containers []string
+}
+
+// SandboxExport is the state of a sandbox as exported by ExportSandboxState,
+// for analysis away from the node it ran on.
+type SandboxExport struct {
+	Version   int
+	SandboxID string
+	Time      time.Time
+
+	State      persistapi.SandboxState
+	Containers map[string]persistapi.ContainerState
+
+	// Devices is the device topology of the sandbox.
+	Devices []ExportedDevice
+
+	// Spec is the OCI spec of the sandbox container, nil if its bundle
+	// could not be read.
+	Spec *specs.Spec
+
+	// Events is the event journal of the sandbox, oldest first.
+	Events []JournaledSandboxEvent
+
+	// Logs are the ends of the logs the hypervisor left in the sandbox
+	// VM directory, by file name.
+	Logs map[string][]byte
+}
+
+type exportMetadata struct {
+	Version   int
+	SandboxID string
+	Time      time.Time
+}
+
+// ExportSandboxState is the virtcontainers sandbox export entry point. It
+// writes a gzipped tarball of the persisted state of a sandbox, its device
+// topology, its OCI spec, its event journal and the end of its hypervisor
+// logs to w, with the environment and the secret looking annotations
+// redacted. The sandbox does not need to be running.
+func ExportSandboxState(ctx context.Context, sandboxID string, w io.Writer) error {
+	span, _ := trace(ctx, "ExportSandboxState")
+	defer span.Finish()
+
+	if sandboxID == "" {
+		return vcTypes.ErrNeedSandboxID
+	}
+
+	unlock, err := rLockSandbox(sandboxID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	store, err := persist.GetDriver()
+	if err != nil {
+		return err
+	}
+
+	ss, cs, err := store.FromDisk(sandboxID)
+	if err != nil {
+		return err
+	}
+
+	export := SandboxExport{
+		Version:    sandboxExportVersion,
+		SandboxID:  sandboxID,
+		Time:       time.Now().UTC(),
+		State:      ss,
+		Containers: cs,
+		Devices:    exportDevices(ss, cs),
+		Logs:       make(map[string][]byte),
+	}
+
+	if c, ok := cs[sandboxID]; ok && c.BundlePath != "" {
+		if spec, err := readBundleSpec(c.BundlePath); err != nil {
+			virtLog.WithError(err).WithField("sandbox", sandboxID).Warn("failed to read sandbox spec for export")
+		} else {
+			export.Spec = spec
+		}
+	}
+
+	events, err := readEventJournal(filepath.Join(store.RunStoragePath(), sandboxID, sandboxEventJournal))
+	if err != nil {
+		virtLog.WithError(err).WithField("sandbox", sandboxID).Warn("failed to read event journal for export")
+	}
+	export.Events = events
+
+	logs, err := filepath.Glob(filepath.Join(store.RunVMStoragePath(), sandboxID, "*.log"))
+	if err != nil {
+		return err
+	}
+	for _, l := range logs {
+		data, err := readFileTail(l, maxExportLogSize)
+		if err != nil {
+			virtLog.WithError(err).WithField("log", l).Warn("failed to read log for export")
+			continue
+		}
+		export.Logs[filepath.Base(l)] = data
+	}
+
+	export.redact()
+
+	return export.write(w)
+}
+
+// exportDevices returns the sandbox devices sorted by ID, with the
+// containers using them.
+func exportDevices(ss persistapi.SandboxState, cs map[string]persistapi.ContainerState) []ExportedDevice {
+	var devices []ExportedDevice
+	for _, d := range ss.Devices {
+		dev := ExportedDevice{DeviceState: d}
+		for id, c := range cs {
+			for _, m := range c.DeviceMaps {
+				if m.ID == d.ID {
+					dev.Containers = append(dev.Containers, id)
+					break
+				}
+			}
+		}
+		sort.Strings(dev.Containers)
+		devices = append(devices, dev)
+	}
+
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].ID < devices[j].ID
+	})
+
+	return devices
+}
+
+func readBundleSpec(bundlePath string) (*specs.Spec, error) {
+	data, err := ioutil.ReadFile(filepath.Join(bundlePath, "config.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var spec specs.Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, err
+	}
+
+	return &spec, nil
+}
+
+// readFileTail returns at most the last size bytes of a file.
+func readFileTail(path string, size int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if fi.Size() > size {
+		if _, err := f.Seek(-size, io.SeekEnd); err != nil {
+			return nil, err
+		}
+	}
+
+	return ioutil.ReadAll(f)
+}
+
+func redactAnnotations(annotations map[string]string) {
+	for k := range annotations {
+		if secretNamePattern.MatchString(k) {
+			annotations[k] = redactedValue
+		}
+	}
+}
+
+// redact removes the values which may hold secrets. All the environment
+// variables are redacted, since nothing tells the secret ones apart.
+func (e *SandboxExport) redact() {
+	for _, c := range e.State.Config.ContainerConfigs {
+		redactAnnotations(c.Annotations)
+	}
+
+	if e.Spec == nil {
+		return
+	}
+
+	redactAnnotations(e.Spec.Annotations)
+
+	if e.Spec.Process != nil {
+		for i, env := range e.Spec.Process.Env {
+			name := strings.SplitN(env, "=", 2)[0]
+			e.Spec.Process.Env[i] = name + "=" + redactedValue
+		}
+	}
+}
+
+func writeTarEntry(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+
+	_, err := tw.Write(data)
+	return err
+}
+
+func writeTarJSON(tw *tar.Writer, name string, v interface{}, modTime time.Time) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeTarEntry(tw, name, data, modTime)
+}
+
+func (e *SandboxExport) write(w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	metadata := exportMetadata{
+		Version:   e.Version,
+		SandboxID: e.SandboxID,
+		Time:      e.Time,
+	}
+	if err := writeTarJSON(tw, exportMetadataFile, metadata, e.Time); err != nil {
+		return err
+	}
+
+	if err := writeTarJSON(tw, exportSandboxFile, e.State, e.Time); err != nil {
+		return err
+	}
+
+	for id, c := range e.Containers {
+		if err := writeTarJSON(tw, path.Join(exportContainerDir, id+".json"), c, e.Time); err != nil {
+			return err
+		}
+	}
+
+	if err := writeTarJSON(tw, exportDevicesFile, e.Devices, e.Time); err != nil {
+		return err
+	}
+
+	if e.Spec != nil {
+		if err := writeTarJSON(tw, exportSpecFile, e.Spec, e.Time); err != nil {
+			return err
+		}
+	}
+
+	if err := writeTarJSON(tw, exportEventsFile, e.Events, e.Time); err != nil {
+		return err
+	}
+
+	for name, data := range e.Logs {
+		if err := writeTarEntry(tw, path.Join(exportLogDir, name), data, e.Time); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gw.Close()
+}
+
+// LoadSandboxExport reads a sandbox export written by ExportSandboxState,
+// so that it can be analysed offline. Unknown entries are ignored.
+func LoadSandboxExport(r io.Reader) (*SandboxExport, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+
+	export := &SandboxExport{
+		Containers: make(map[string]persistapi.ContainerState),
+		Logs:       make(map[string][]byte),
+	}
+
+	var metadata *exportMetadata
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+
+		dir, name := path.Split(hdr.Name)
+		switch {
+		case hdr.Name == exportMetadataFile:
+			metadata = &exportMetadata{}
+			err = json.Unmarshal(data, metadata)
+		case hdr.Name == exportSandboxFile:
+			err = json.Unmarshal(data, &export.State)
+		case hdr.Name == exportDevicesFile:
+			err = json.Unmarshal(data, &export.Devices)
+		case hdr.Name == exportSpecFile:
+			export.Spec = &specs.Spec{}
+			err = json.Unmarshal(data, export.Spec)
+		case hdr.Name == exportEventsFile:
+			err = json.Unmarshal(data, &export.Events)
+		case path.Clean(dir) == exportContainerDir && strings.HasSuffix(name, ".json"):
+			var c persistapi.ContainerState
+			if err = json.Unmarshal(data, &c); err == nil {
+				export.Containers[strings.TrimSuffix(name, ".json")] = c
+			}
+		case path.Clean(dir) == exportLogDir:
+			export.Logs[name] = data
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid sandbox export entry %s: %v", hdr.Name, err)
+		}
+	}
+
+	if metadata == nil {
+		return nil, fmt.Errorf("not a sandbox export: %s missing", exportMetadataFile)
+	}
+
+	if metadata.Version > sandboxExportVersion {
+		return nil, fmt.Errorf("sandbox export version %d is not supported, the latest supported version is %d", metadata.Version, sandboxExportVersion)
+	}
+
+	export.Version = metadata.Version
+	export.SandboxID = metadata.SandboxID
+	export.Time = metadata.Time
+
+	return export, nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+func TestExportSandboxState(t *testing.T) {
+	assert := assert.New(t)
+
+	sid := "test-export"
+
+	store, err := persist.GetDriver()
+	assert.NoError(err)
+	defer store.Destroy(sid)
+
+	bundle, err := ioutil.TempDir("", "bundle")
+	assert.NoError(err)
+	defer os.RemoveAll(bundle)
+
+	spec := specs.Spec{
+		Process: &specs.Process{
+			Args: []string{"/pause"},
+			Env:  []string{"PATH=/bin", "DB_PASSWORD=hunter2"},
+		},
+		Annotations: map[string]string{
+			"io.kubernetes.cri.sandbox-name": "pod",
+			"example.com/api-token":          "s3cr3t",
+		},
+	}
+	data, err := json.Marshal(spec)
+	assert.NoError(err)
+	assert.NoError(ioutil.WriteFile(filepath.Join(bundle, "config.json"), data, 0600))
+
+	ss := persistapi.SandboxState{
+		SandboxContainer: sid,
+		State:            "running",
+		Devices: []persistapi.DeviceState{
+			{ID: "dev2", Type: "block"},
+			{ID: "dev1", Type: "vfio"},
+		},
+		Config: persistapi.SandboxConfig{
+			HypervisorType: string(QemuHypervisor),
+			ContainerConfigs: []persistapi.ContainerConfig{
+				{ID: "c1", Annotations: map[string]string{"registry-credential": "abc"}},
+			},
+		},
+	}
+	cs := map[string]persistapi.ContainerState{
+		sid: {State: "running", BundlePath: bundle},
+		"c1": {
+			State:      "running",
+			DeviceMaps: []persistapi.DeviceMap{{ID: "dev1", ContainerPath: "/dev/vfio/1"}},
+		},
+	}
+	assert.NoError(store.ToDisk(ss, cs))
+
+	events := newSandboxEvents(filepath.Join(store.RunStoragePath(), sid, sandboxEventJournal))
+	events.publish(SandboxEvent{Type: SandboxEventState, SandboxID: sid, State: "running"})
+	events.publish(SandboxEvent{Type: SandboxEventHotplug, SandboxID: sid, DeviceID: "dev1", Err: errors.New("no slot")})
+
+	vmDir := filepath.Join(store.RunVMStoragePath(), sid)
+	assert.NoError(os.MkdirAll(vmDir, 0700))
+	defer os.RemoveAll(vmDir)
+	qemuLog := strings.Repeat("a", maxExportLogSize) + "the end"
+	assert.NoError(ioutil.WriteFile(filepath.Join(vmDir, "qemu.log"), []byte(qemuLog), 0600))
+
+	// No sandbox ID
+	var buf bytes.Buffer
+	assert.Error(ExportSandboxState(context.Background(), "", &buf))
+
+	assert.NoError(ExportSandboxState(context.Background(), sid, &buf))
+
+	export, err := LoadSandboxExport(&buf)
+	assert.NoError(err)
+
+	assert.Equal(sandboxExportVersion, export.Version)
+	assert.Equal(sid, export.SandboxID)
+	assert.False(export.Time.IsZero())
+	assert.Equal("running", export.State.State)
+	assert.Len(export.Containers, 2)
+	assert.Equal(bundle, export.Containers[sid].BundlePath)
+
+	// Devices are sorted, with the containers using them
+	assert.Len(export.Devices, 2)
+	assert.Equal("dev1", export.Devices[0].ID)
+	assert.Equal([]string{"c1"}, export.Devices[0].Containers)
+	assert.Equal("dev2", export.Devices[1].ID)
+	assert.Empty(export.Devices[1].Containers)
+
+	// Secrets are redacted
+	assert.Equal(redactedValue, export.State.Config.ContainerConfigs[0].Annotations["registry-credential"])
+	assert.NotNil(export.Spec)
+	assert.Equal([]string{"PATH=" + redactedValue, "DB_PASSWORD=" + redactedValue}, export.Spec.Process.Env)
+	assert.Equal([]string{"/pause"}, export.Spec.Process.Args)
+	assert.Equal("pod", export.Spec.Annotations["io.kubernetes.cri.sandbox-name"])
+	assert.Equal(redactedValue, export.Spec.Annotations["example.com/api-token"])
+
+	// The event journal is exported, errors included
+	assert.Len(export.Events, 2)
+	assert.Equal(SandboxEventState, export.Events[0].Type)
+	assert.Equal(SandboxEventHotplug, export.Events[1].Type)
+	assert.Equal("dev1", export.Events[1].DeviceID)
+	assert.Equal("no slot", export.Events[1].Err)
+
+	// Only the end of the logs is exported
+	log := export.Logs["qemu.log"]
+	assert.Len(log, maxExportLogSize)
+	assert.True(strings.HasSuffix(string(log), "the end"))
+}
+
+func TestLoadSandboxExportInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	// Not gzipped
+	_, err := LoadSandboxExport(strings.NewReader("not an export"))
+	assert.Error(err)
+
+	// Minimal export
+	var buf bytes.Buffer
+	e := &SandboxExport{Version: sandboxExportVersion, SandboxID: "test"}
+	assert.NoError(e.write(&buf))
+	data := buf.Bytes()
+
+	export, err := LoadSandboxExport(bytes.NewReader(data))
+	assert.NoError(err)
+	assert.Equal("test", export.SandboxID)
+	assert.Nil(export.Spec)
+
+	// Newer version
+	buf.Reset()
+	e.Version = sandboxExportVersion + 1
+	assert.NoError(e.write(&buf))
+	_, err = LoadSandboxExport(&buf)
+	assert.Error(err)
+}
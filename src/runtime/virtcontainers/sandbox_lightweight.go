@@ -0,0 +1,255 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+var errLightweightSandboxFull = errors.New("A lightweight sandbox runs a single container, along with the sandbox container of its pod")
+
+// LightweightSandbox is the fast path of the sandboxes running a single
+// container, such as functions, which skips the work the sandboxes running
+// pods need. Such a sandbox runs no other container than its own, which
+// joins the sandbox container of its pod, such as the pause container of a
+// Kubernetes pod, if any. It runs in the sandbox cgroup only, so that the
+// threads of the hypervisor are not moved around. Its network interfaces,
+// such as tap devices created beforehand, are looked up by name instead of
+// scanning the network namespace, the TAP interfaces of the VM may be
+// pre-allocated in the network namespace, and the guest details may be
+// known beforehand, from the guest image, instead of being asked to the
+// agent.
+type LightweightSandbox struct {
+	// Enable runs the sandbox as a lightweight sandbox.
+	Enable bool
+
+	// Interfaces are the names of the network interfaces of the network
+	// namespace the guest is connected to. The network namespace is
+	// scanned for all of them when empty.
+	Interfaces []string
+
+	// GuestDetails are the details of the guest, which the agent is not
+	// asked for when set.
+	GuestDetails *LightweightGuestDetails
+}
+
+// LightweightGuestDetails are the details of the guest of a lightweight
+// sandbox, as the agent would report them.
+type LightweightGuestDetails struct {
+	// MemoryBlockSizeMB is the size of the memory blocks of the guest.
+	MemoryBlockSizeMB uint32
+
+	// MemoryHotplugProbe tells whether the guest probes the hot added
+	// memory through its probe interface.
+	MemoryHotplugProbe bool
+
+	// SeccompSupported tells whether the agent applies seccomp profiles.
+	SeccompSupported bool
+}
+
+func (l LightweightSandbox) validate(config *SandboxConfig) error {
+	if !l.Enable {
+		if len(l.Interfaces) != 0 || l.GuestDetails != nil {
+			return newConfigFieldError("Enable", "Lightweight sandbox settings without a lightweight sandbox")
+		}
+		return nil
+	}
+
+	var cTypes []ContainerType
+	for i := range config.Containers {
+		cTypes = append(cTypes, containerConfigType(&config.Containers[i]))
+	}
+	if !lightweightContainersFit(cTypes) {
+		return newConfigFieldError("Enable", fmt.Sprintf("%v, not %d containers", errLightweightSandboxFull, len(config.Containers)))
+	}
+
+	if !config.SandboxCgroupOnly {
+		return newConfigFieldError("Enable", "A lightweight sandbox runs in the sandbox cgroup only")
+	}
+
+	if config.NetworkConfig.NetmonConfig.Enable {
+		return newConfigFieldError("Enable", "A lightweight sandbox does not monitor its network namespace")
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range l.Interfaces {
+		if name == "" || seen[name] {
+			return newConfigFieldError("Interfaces", fmt.Sprintf("Invalid or duplicate interface %q", name))
+		}
+		seen[name] = true
+	}
+
+	if l.GuestDetails != nil && l.GuestDetails.MemoryBlockSizeMB == 0 {
+		return newConfigFieldError("GuestDetails.MemoryBlockSizeMB", "Missing guest memory block size")
+	}
+
+	return nil
+}
+
+// containerConfigType returns the type of a container, as annotated.
+func containerConfigType(config *ContainerConfig) ContainerType {
+	return ContainerType(config.Annotations[annotations.ContainerTypeKey])
+}
+
+// lightweightContainersFit tells whether containers of the given types fit
+// in a lightweight sandbox, which runs a single container along with the
+// sandbox container of its pod, if any.
+func lightweightContainersFit(cTypes []ContainerType) bool {
+	var sandboxes, containers int
+	for _, cType := range cTypes {
+		if cType.IsSandbox() {
+			sandboxes++
+		} else {
+			containers++
+		}
+	}
+
+	return sandboxes <= 1 && containers <= 1
+}
+
+// checkLightweightContainer checks that a container fits in a lightweight
+// sandbox along with the ones it runs already, before adding it.
+func (s *Sandbox) checkLightweightContainer(contConfig *ContainerConfig) error {
+	if !s.config.Lightweight.Enable {
+		return nil
+	}
+
+	cTypes := []ContainerType{containerConfigType(contConfig)}
+	for _, c := range s.containers {
+		cTypes = append(cTypes, containerConfigType(c.config))
+	}
+
+	if !lightweightContainersFit(cTypes) {
+		return errLightweightSandboxFull
+	}
+	return nil
+}
+
+// storeStaticGuestDetails stores the guest details of a lightweight
+// sandbox known beforehand, and tells whether it did.
+func (s *Sandbox) storeStaticGuestDetails() bool {
+	details := s.config.Lightweight.GuestDetails
+	if !s.config.Lightweight.Enable || details == nil {
+		return false
+	}
+
+	s.state.GuestMemoryBlockSizeMB = details.MemoryBlockSizeMB
+	s.state.GuestMemoryHotplugProbe = details.MemoryHotplugProbe
+	s.state.GuestSeccompSupported = details.SeccompSupported
+
+	return true
+}
+
+// createEndpointsFromNames creates the endpoints of the named interfaces
+// of a network namespace, without scanning the others.
+func createEndpointsFromNames(networkNSPath string, config *NetworkConfig, names []string) ([]Endpoint, error) {
+	netnsHandle, err := netns.GetFromPath(networkNSPath)
+	if err != nil {
+		return []Endpoint{}, err
+	}
+	defer netnsHandle.Close()
+
+	netlinkHandle, err := netlink.NewHandleAt(netnsHandle)
+	if err != nil {
+		return []Endpoint{}, err
+	}
+	defer netlinkHandle.Delete()
+
+	var endpoints []Endpoint
+	for idx, name := range names {
+		link, err := netlinkHandle.LinkByName(name)
+		if err != nil {
+			return []Endpoint{}, fmt.Errorf("Could not find interface %s: %v", name, err)
+		}
+
+		netInfo, err := networkInfoFromLink(netlinkHandle, link)
+		if err != nil {
+			return []Endpoint{}, err
+		}
+
+		var endpoint Endpoint
+		if err := doNetNS(networkNSPath, func(_ ns.NetNS) error {
+			endpoint, err = createEndpoint(netInfo, idx, config.InterworkingModel, config.InterfaceEndpoints, link)
+			return err
+		}); err != nil {
+			return []Endpoint{}, err
+		}
+
+		endpoint.SetProperties(netInfo)
+		endpoints = append(endpoints, endpoint)
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].Name() < endpoints[j].Name()
+	})
+
+	networkLogger().WithField("endpoints", endpoints).Info("Endpoints of the lightweight sandbox found")
+
+	return endpoints, nil
+}
+
+// tapQueues returns the queues of the TAP interfaces the VMs of a
+// hypervisor are connected through, QEMU only supporting multiqueue TAP
+// interfaces.
+func tapQueues(hType HypervisorType, conf HypervisorConfig) int {
+	if hType != QemuHypervisor {
+		return 0
+	}
+	return networkQueues(conf)
+}
+
+// preallocateTaps creates in a network namespace the TAP interfaces the VM
+// of a sandbox is connected through, named after the network pairs of its
+// first endpoints, for the sandbox to attach to them rather than creating
+// them when it starts. The existing ones are kept. It returns the names of
+// the TAP interfaces created.
+func preallocateTaps(netNSPath string, count, queues int) ([]string, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("Invalid number of TAP interfaces %d", count)
+	}
+
+	var created []string
+	err := doNetNS(netNSPath, func(_ ns.NetNS) error {
+		netHandle, err := netlink.NewHandle()
+		if err != nil {
+			return err
+		}
+		defer netHandle.Delete()
+
+		for idx := 0; idx < count; idx++ {
+			name := fmt.Sprintf("tap%d_kata", idx)
+			if _, err := netHandle.LinkByName(name); err == nil {
+				continue
+			}
+
+			_, fds, err := createLink(netHandle, name, &netlink.Tuntap{}, queues)
+			if err != nil {
+				return fmt.Errorf("Could not create TAP interface: %s", err)
+			}
+
+			// The TAP interface persists once its queues are closed.
+			utils.CleanupFds(fds, len(fds))
+			created = append(created, name)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	networkLogger().WithField("taps", created).Info("TAP interfaces pre-allocated")
+
+	return created, nil
+}
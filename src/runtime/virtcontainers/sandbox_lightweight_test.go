@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	ktu "github.com/kata-containers/kata-containers/src/runtime/pkg/katatestutils"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/vishvananda/netlink"
+)
+
+func TestLightweightSandboxValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	config := &SandboxConfig{
+		Containers:        []ContainerConfig{{ID: "function"}},
+		SandboxCgroupOnly: true,
+	}
+
+	assert.NoError(LightweightSandbox{}.validate(config))
+	assert.NoError(LightweightSandbox{Enable: true, Interfaces: []string{"tap0"}}.validate(config))
+	assert.NoError(LightweightSandbox{
+		Enable:       true,
+		GuestDetails: &LightweightGuestDetails{MemoryBlockSizeMB: 128},
+	}.validate(config))
+
+	for field, l := range map[string]LightweightSandbox{
+		"Enable":                         {Interfaces: []string{"tap0"}},
+		"Interfaces":                     {Enable: true, Interfaces: []string{"tap0", "tap0"}},
+		"GuestDetails.MemoryBlockSizeMB": {Enable: true, GuestDetails: &LightweightGuestDetails{}},
+	} {
+		err := configFieldError("Lightweight", l.validate(config))
+		assert.Equal("Lightweight."+field, err.Field)
+	}
+
+	config.Containers = append(config.Containers, ContainerConfig{ID: "sidecar"})
+	assert.Error(LightweightSandbox{Enable: true}.validate(config))
+
+	// The container joins the sandbox container of its pod.
+	config.Containers[1].Annotations = map[string]string{annotations.ContainerTypeKey: string(PodSandbox)}
+	assert.NoError(LightweightSandbox{Enable: true}.validate(config))
+
+	config.Containers = config.Containers[:1]
+	config.SandboxCgroupOnly = false
+	assert.Error(LightweightSandbox{Enable: true}.validate(config))
+
+	config.SandboxCgroupOnly = true
+	config.NetworkConfig.NetmonConfig.Enable = true
+	assert.Error(LightweightSandbox{Enable: true}.validate(config))
+}
+
+func TestLightweightSandboxContainers(t *testing.T) {
+	assert := assert.New(t)
+
+	pause := &ContainerConfig{
+		ID:          "pause",
+		Annotations: map[string]string{annotations.ContainerTypeKey: string(PodSandbox)},
+	}
+	function := &ContainerConfig{
+		ID:          "function",
+		Annotations: map[string]string{annotations.ContainerTypeKey: string(PodContainer)},
+	}
+	sidecar := &ContainerConfig{
+		ID:          "sidecar",
+		Annotations: map[string]string{annotations.ContainerTypeKey: string(PodContainer)},
+	}
+
+	s := &Sandbox{
+		config:     &SandboxConfig{Lightweight: LightweightSandbox{Enable: true}},
+		containers: map[string]*Container{},
+	}
+	assert.NoError(s.checkLightweightContainer(function))
+
+	// The container of a Kubernetes pod joins its pause container.
+	s.containers["pause"] = &Container{config: pause}
+	assert.NoError(s.checkLightweightContainer(function))
+
+	s.containers["function"] = &Container{config: function}
+	assert.Equal(errLightweightSandboxFull, s.checkLightweightContainer(sidecar))
+	assert.Equal(errLightweightSandboxFull, s.checkLightweightContainer(pause))
+
+	s.config.Lightweight.Enable = false
+	assert.NoError(s.checkLightweightContainer(sidecar))
+}
+
+func TestLightweightSandboxGuestDetails(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{config: &SandboxConfig{}}
+	assert.False(s.storeStaticGuestDetails())
+
+	s.config.Lightweight = LightweightSandbox{
+		Enable: true,
+		GuestDetails: &LightweightGuestDetails{
+			MemoryBlockSizeMB:  128,
+			MemoryHotplugProbe: true,
+		},
+	}
+	assert.True(s.storeStaticGuestDetails())
+	assert.Equal(uint32(128), s.state.GuestMemoryBlockSizeMB)
+	assert.True(s.state.GuestMemoryHotplugProbe)
+	assert.False(s.state.GuestSeccompSupported)
+}
+
+func TestCreateEndpointsFromNames(t *testing.T) {
+	if tc.NotValid(ktu.NeedRoot()) {
+		t.Skip(testDisabledAsNonRoot)
+	}
+
+	assert := assert.New(t)
+
+	netNSPath, err := createNetNS()
+	assert.NoError(err)
+	defer deleteNetNS(netNSPath)
+
+	// The tap devices are created beforehand, only one of them being
+	// connected to the guest.
+	err = doNetNS(netNSPath, func(_ ns.NetNS) error {
+		netHandle, err := netlink.NewHandle()
+		if err != nil {
+			return err
+		}
+		defer netHandle.Delete()
+
+		for _, name := range []string{"tap0", "tap1"} {
+			if _, _, err := createLink(netHandle, name, &netlink.Tuntap{}, 1); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.NoError(err)
+
+	config := &NetworkConfig{NetNSPath: netNSPath}
+	endpoints, err := createEndpointsFromNames(netNSPath, config, []string{"tap1"})
+	assert.NoError(err)
+	assert.Len(endpoints, 1)
+	assert.Equal(TuntapEndpointType, endpoints[0].Type())
+	assert.Equal("tap1", endpoints[0].Name())
+
+	_, err = createEndpointsFromNames(netNSPath, config, []string{"tap2"})
+	assert.Error(err)
+}
+
+func TestPreallocateTaps(t *testing.T) {
+	if tc.NotValid(ktu.NeedRoot()) {
+		t.Skip(testDisabledAsNonRoot)
+	}
+
+	assert := assert.New(t)
+
+	netNSPath, err := createNetNS()
+	assert.NoError(err)
+	defer deleteNetNS(netNSPath)
+
+	_, err = preallocateTaps(netNSPath, 0, 2)
+	assert.Error(err)
+
+	created, err := preallocateTaps(netNSPath, 2, 2)
+	assert.NoError(err)
+	assert.Equal([]string{"tap0_kata", "tap1_kata"}, created)
+
+	// The existing TAP interfaces are kept.
+	created, err = preallocateTaps(netNSPath, 3, 2)
+	assert.NoError(err)
+	assert.Equal([]string{"tap2_kata"}, created)
+
+	// The VM attaches to the queues of a pre-allocated TAP interface.
+	err = doNetNS(netNSPath, func(_ ns.NetNS) error {
+		netHandle, err := netlink.NewHandle()
+		if err != nil {
+			return err
+		}
+		defer netHandle.Delete()
+
+		link, fds, err := createTap(netHandle, "tap0_kata", 2)
+		if err != nil {
+			return err
+		}
+		defer utils.CleanupFds(fds, len(fds))
+
+		assert.Equal("tap0_kata", link.Attrs().Name)
+		assert.Len(fds, 2)
+		return nil
+	})
+	assert.NoError(err)
+}
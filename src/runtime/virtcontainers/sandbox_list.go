@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+// ListFilter selects the sandboxes ListSandboxesWithFilter returns, and
+// paginates them. The zero value selects all the sandboxes.
+type ListFilter struct {
+	// States are the states of the sandboxes selected, all of them if
+	// empty.
+	States []types.StateString
+
+	// Annotations must all be annotations of the sandboxes selected. An
+	// empty value matches any value of the annotation.
+	Annotations map[string]string
+
+	// After is the ID of the last sandbox of the previous page, the
+	// sandboxes being returned by ID.
+	After string
+
+	// Limit is the maximum number of sandboxes returned, unlimited if 0.
+	Limit int
+}
+
+// SandboxSummary is the state of a sandbox as saved, returned without
+// fetching the sandbox.
+type SandboxSummary struct {
+	ID                string
+	State             types.StateString
+	Hypervisor        HypervisorType
+	HypervisorVersion string
+	HypervisorPid     int
+
+	// Containers are the states of the containers of the sandbox, by ID.
+	Containers map[string]types.StateString
+
+	Annotations map[string]string
+}
+
+func (f *ListFilter) matches(summary SandboxSummary) bool {
+	if len(f.States) > 0 {
+		found := false
+		for _, state := range f.States {
+			if state == summary.State {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	for k, v := range f.Annotations {
+		value, ok := summary.Annotations[k]
+		if !ok || (v != "" && v != value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sandboxSummaryFromState(ss persistapi.SandboxState, cs map[string]persistapi.ContainerState) SandboxSummary {
+	summary := SandboxSummary{
+		ID:                ss.SandboxContainer,
+		State:             types.StateString(ss.State),
+		Hypervisor:        HypervisorType(ss.Config.HypervisorType),
+		HypervisorVersion: ss.HypervisorVersion,
+		HypervisorPid:     ss.HypervisorState.Pid,
+		Containers:        make(map[string]types.StateString, len(cs)),
+		Annotations:       ss.Config.Annotations,
+	}
+
+	for id, c := range cs {
+		summary.Containers[id] = types.StateString(c.State)
+	}
+
+	return summary
+}
+
+// fetchSandboxSummary reads the saved state of a sandbox, under the shared
+// lock of the sandbox.
+func fetchSandboxSummary(sandboxID string) (SandboxSummary, error) {
+	// A driver is only used for one sandbox, the FS one keeps the state
+	// it read.
+	store, err := persist.GetDriver()
+	if err != nil {
+		return SandboxSummary{}, err
+	}
+
+	unlock, err := store.Lock(sandboxID, false)
+	if err != nil {
+		return SandboxSummary{}, err
+	}
+	defer unlock()
+
+	ss, cs, err := store.FromDisk(sandboxID)
+	if err != nil {
+		return SandboxSummary{}, err
+	}
+
+	return sandboxSummaryFromState(ss, cs), nil
+}
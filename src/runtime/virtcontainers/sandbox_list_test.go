@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListSandboxesWithFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	const listAnnotation = "io.katacontainers.test.list"
+
+	sandboxes := []struct {
+		id    string
+		state types.StateString
+		team  string
+	}{
+		{"list-sandbox-a", types.StateRunning, "red"},
+		{"list-sandbox-b", types.StatePaused, "blue"},
+		{"list-sandbox-c", types.StateRunning, "blue"},
+		{"list-sandbox-d", types.StateStopped, "red"},
+	}
+
+	for _, s := range sandboxes {
+		store, err := persist.GetDriver()
+		assert.NoError(err)
+
+		ss := persistapi.SandboxState{
+			SandboxContainer:  s.id,
+			State:             string(s.state),
+			HypervisorVersion: "1.0",
+			HypervisorState:   persistapi.HypervisorState{Pid: 42},
+			Config: persistapi.SandboxConfig{
+				HypervisorType: string(MockHypervisor),
+				Annotations:    map[string]string{listAnnotation: s.team},
+			},
+		}
+		cs := map[string]persistapi.ContainerState{
+			s.id: {State: string(s.state)},
+		}
+		assert.NoError(store.ToDisk(ss, cs))
+		defer store.Destroy(s.id)
+	}
+
+	ids := func(summaries []SandboxSummary) []string {
+		var ids []string
+		for _, s := range summaries {
+			ids = append(ids, s.ID)
+		}
+		return ids
+	}
+
+	ctx := context.Background()
+	all := map[string]string{listAnnotation: ""}
+
+	summaries, err := ListSandboxesWithFilter(ctx, ListFilter{Annotations: all})
+	assert.NoError(err)
+	assert.Equal([]string{"list-sandbox-a", "list-sandbox-b", "list-sandbox-c", "list-sandbox-d"}, ids(summaries))
+
+	s := summaries[0]
+	assert.Equal(types.StateRunning, s.State)
+	assert.Equal(MockHypervisor, s.Hypervisor)
+	assert.Equal("1.0", s.HypervisorVersion)
+	assert.Equal(42, s.HypervisorPid)
+	assert.Equal(map[string]types.StateString{"list-sandbox-a": types.StateRunning}, s.Containers)
+	assert.Equal("red", s.Annotations[listAnnotation])
+
+	summaries, err = ListSandboxesWithFilter(ctx, ListFilter{
+		States:      []types.StateString{types.StateRunning, types.StatePaused},
+		Annotations: all,
+	})
+	assert.NoError(err)
+	assert.Equal([]string{"list-sandbox-a", "list-sandbox-b", "list-sandbox-c"}, ids(summaries))
+
+	summaries, err = ListSandboxesWithFilter(ctx, ListFilter{
+		States:      []types.StateString{types.StateRunning},
+		Annotations: map[string]string{listAnnotation: "blue"},
+	})
+	assert.NoError(err)
+	assert.Equal([]string{"list-sandbox-c"}, ids(summaries))
+
+	// Pages of two sandboxes
+	summaries, err = ListSandboxesWithFilter(ctx, ListFilter{Annotations: all, Limit: 2})
+	assert.NoError(err)
+	assert.Equal([]string{"list-sandbox-a", "list-sandbox-b"}, ids(summaries))
+
+	summaries, err = ListSandboxesWithFilter(ctx, ListFilter{Annotations: all, Limit: 2, After: "list-sandbox-b"})
+	assert.NoError(err)
+	assert.Equal([]string{"list-sandbox-c", "list-sandbox-d"}, ids(summaries))
+
+	summaries, err = ListSandboxesWithFilter(ctx, ListFilter{Annotations: all, Limit: 2, After: "list-sandbox-d"})
+	assert.NoError(err)
+	assert.Empty(summaries)
+}
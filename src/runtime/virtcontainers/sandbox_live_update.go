@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+// checkLiveUpdate checks the VM of the sandbox can be moved to a new process
+// of the hypervisor.
+func (s *Sandbox) checkLiveUpdate() error {
+	if caps := s.hypervisor.capabilities(); !caps.IsSandboxLiveUpdateSupported() {
+		return fmt.Errorf("Sandbox live update is not supported by the hypervisor")
+	}
+
+	if err := s.checkSaveable("live update"); err != nil {
+		return err
+	}
+
+	// The agent is reached again through a new vsock device, the serial
+	// port of the former process going away with it.
+	if !s.config.AgentConfig.UseVSock {
+		return fmt.Errorf("Sandbox %s does not reach its agent through vsock, impossible to live update", s.id)
+	}
+
+	for _, d := range s.devManager.GetAllDevices() {
+		if d.GetAttachCount() == 0 {
+			continue
+		}
+
+		switch d.DeviceType() {
+		case config.VhostUserBlk, config.VhostUserSCSI, config.VhostUserNet, config.VhostUserFS:
+			return fmt.Errorf("Sandbox %s has vhost-user devices, impossible to live update", s.id)
+		}
+	}
+
+	// The network interfaces are attached to the new process through file
+	// descriptors of their own.
+	for _, endpoint := range s.networkNS.Endpoints {
+		switch endpoint.(type) {
+		case *VethEndpoint, *BridgedMacvlanEndpoint, *IPVlanEndpoint, *TuntapEndpoint, *MacvtapEndpoint:
+		default:
+			return fmt.Errorf("Sandbox %s has the %s endpoint %s, impossible to live update", s.id, endpoint.Type(), endpoint.Name())
+		}
+	}
+
+	return nil
+}
+
+// liveUpdateDrives returns the drives attached to the VM of the sandbox.
+func (s *Sandbox) liveUpdateDrives() []*config.BlockDrive {
+	var drives []*config.BlockDrive
+	for _, d := range s.devManager.GetAllDevices() {
+		if d.DeviceType() != config.DeviceBlock || d.GetAttachCount() == 0 {
+			continue
+		}
+
+		if drive, ok := d.GetDeviceInfo().(*config.BlockDrive); ok && drive != nil {
+			drives = append(drives, drive)
+		}
+	}
+
+	return drives
+}
+
+// LiveUpdate moves the VM of the running sandbox to a new process of the
+// hypervisor, started from the hypervisor binary as it is now, for an
+// update of the hypervisor to apply to the sandbox without restarting it.
+// The VM is migrated to the new process on the host, its containers
+// running on. The requests to the agent are held back meanwhile. The VM
+// runs on in the former process if the update fails.
+func (s *Sandbox) LiveUpdate() (err error) {
+	span, _ := s.trace("LiveUpdate")
+	defer span.Finish()
+
+	if err := s.checkLiveUpdate(); err != nil {
+		return err
+	}
+
+	drives := s.liveUpdateDrives()
+
+	// The hypervisor and the agent do not answer the checks while the
+	// VM moves.
+	if s.monitor != nil {
+		s.monitor.holdChecks()
+		defer s.monitor.releaseChecks()
+	}
+
+	vmSocket, err := s.hypervisor.generateSocket(s.id, s.config.AgentConfig.UseVSock)
+	if err != nil {
+		return err
+	}
+	if _, ok := vmSocket.(types.VSock); !ok {
+		return fmt.Errorf("Unexpected socket %v of the new hypervisor process", vmSocket)
+	}
+
+	if err := s.agent.beginVMSwitch(); err != nil {
+		return err
+	}
+
+	err = s.network.Run(s.networkNS.NetNsPath, func() error {
+		for _, endpoint := range s.networkNS.Endpoints {
+			defer closeVMFds(endpoint)
+
+			if err := reopenVMFds(endpoint, s.hypervisor); err != nil {
+				return err
+			}
+		}
+
+		return s.hypervisor.liveUpdate(vmSocket, s.networkNS.Endpoints, drives)
+	})
+	if err != nil {
+		if vsock, ok := vmSocket.(types.VSock); ok && vsock.VhostFd != nil {
+			vsock.VhostFd.Close()
+		}
+
+		if endErr := s.agent.endVMSwitch(s, nil); endErr != nil {
+			s.Logger().WithError(endErr).Warn("Could not resume the requests to the agent")
+		}
+		return err
+	}
+
+	if err := s.agent.endVMSwitch(s, vmSocket); err != nil {
+		return err
+	}
+
+	if err := s.agent.check(); err != nil {
+		return fmt.Errorf("Agent not reachable after the live update: %v", err)
+	}
+
+	s.Logger().Info("Sandbox moved to a new hypervisor process")
+
+	s.recordHypervisorVersion()
+
+	// The vCPUs and the process of the hypervisor are new.
+	if err := s.cgroupsUpdate(); err != nil {
+		return err
+	}
+
+	return s.storeSandbox()
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// liveUpdateHypervisor is a mock hypervisor able to move the VM to a new
+// process.
+type liveUpdateHypervisor struct {
+	mockHypervisor
+	vmSocket interface{}
+	err      error
+}
+
+func (h *liveUpdateHypervisor) capabilities() types.Capabilities {
+	var caps types.Capabilities
+	caps.SetSandboxLiveUpdateSupport()
+	return caps
+}
+
+func (h *liveUpdateHypervisor) generateSocket(id string, useVsock bool) (interface{}, error) {
+	return types.VSock{ContextID: 5, Port: 1024}, nil
+}
+
+func (h *liveUpdateHypervisor) liveUpdate(vmSocket interface{}, endpoints []Endpoint, drives []*config.BlockDrive) error {
+	h.vmSocket = vmSocket
+	return h.err
+}
+
+// vmSwitchAgent is a mock agent recording the VM switches.
+type vmSwitchAgent struct {
+	mockAgent
+	switching bool
+	vmSocket  interface{}
+}
+
+func (a *vmSwitchAgent) beginVMSwitch() error {
+	a.switching = true
+	return nil
+}
+
+func (a *vmSwitchAgent) endVMSwitch(sandbox *Sandbox, vmSocket interface{}) error {
+	a.switching = false
+	a.vmSocket = vmSocket
+	return nil
+}
+
+func TestSandboxLiveUpdate(t *testing.T) {
+	assert := assert.New(t)
+	defer cleanUp()
+
+	store, err := persist.GetDriver()
+	assert.NoError(err)
+
+	a := &vmSwitchAgent{}
+	s := &Sandbox{
+		id:         "test-live-update",
+		containers: map[string]*Container{},
+		devManager: manager.NewDeviceManager(manager.VirtioSCSI, false, "", nil),
+		hypervisor: &mockHypervisor{},
+		agent:      a,
+		newStore:   store,
+		ctx:        context.Background(),
+		config:     &SandboxConfig{ID: "test-live-update"},
+		state:      types.SandboxState{State: types.StateRunning},
+	}
+
+	// Live updates are not supported
+	assert.Error(s.LiveUpdate())
+
+	h := &liveUpdateHypervisor{}
+	s.hypervisor = h
+
+	// The agent is not reached through vsock
+	assert.Error(s.LiveUpdate())
+	assert.Nil(h.vmSocket)
+
+	s.config.AgentConfig.UseVSock = true
+
+	// The VM runs on in the former process
+	h.err = errors.New("migration failed")
+	assert.Error(s.LiveUpdate())
+	assert.Equal(types.VSock{ContextID: 5, Port: 1024}, h.vmSocket)
+	assert.False(a.switching)
+	assert.Nil(a.vmSocket)
+
+	h.err = nil
+	assert.NoError(s.LiveUpdate())
+	assert.False(a.switching)
+	assert.Equal(types.VSock{ContextID: 5, Port: 1024}, a.vmSocket)
+	assert.Equal(types.StateRunning, s.state.State)
+}
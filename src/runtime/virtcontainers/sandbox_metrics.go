@@ -6,6 +6,8 @@
 package virtcontainers
 
 import (
+	"time"
+
 	mutils "github.com/kata-containers/kata-containers/src/runtime/pkg/utils"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
 	"github.com/prometheus/client_golang/prometheus"
@@ -14,6 +16,7 @@ import (
 
 const namespaceHypervisor = "kata_hypervisor"
 const namespaceKatashim = "kata_shim"
+const namespaceSandbox = "kata_sandbox"
 
 var (
 	hypervisorThreads = prometheus.NewGauge(prometheus.GaugeOpts{
@@ -68,8 +71,77 @@ var (
 	},
 		[]string{"action"},
 	)
+
+	vmBootDurationsHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespaceHypervisor,
+		Name:      "boot_durations_histogram_milliseconds",
+		Help:      "VM boot latency distributions, until the agent is started.",
+		Buckets:   prometheus.ExponentialBuckets(10, 2, 12),
+	},
+		[]string{"hypervisor", "factory"},
+	)
+
+	hotplugDurationsHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespaceHypervisor,
+		Name:      "hotplug_durations_histogram_milliseconds",
+		Help:      "Device hotplug and hot unplug latency distributions.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+	},
+		[]string{"type", "op"},
+	)
 )
 
+// sandboxCollector collects the stats of a sandbox, of its containers and
+// of its hypervisor process on each scrape, for a registry to expose them.
+// The metrics have the sandbox ID as constant label, for the collectors of
+// several sandboxes to be registered in one registry.
+type sandboxCollector struct {
+	s *Sandbox
+
+	cpuUsage    *prometheus.Desc
+	memoryUsage *prometheus.Desc
+	vcpus       *prometheus.Desc
+
+	hypervisorRSS     *prometheus.Desc
+	hypervisorThreads *prometheus.Desc
+	hypervisorFDs     *prometheus.Desc
+
+	containerCPUUsage    *prometheus.Desc
+	containerMemoryUsage *prometheus.Desc
+	containerPids        *prometheus.Desc
+	containerPidsLimit   *prometheus.Desc
+	containerZombies     *prometheus.Desc
+	containerNetworkRx   *prometheus.Desc
+	containerNetworkTx   *prometheus.Desc
+}
+
+func newSandboxCollector(s *Sandbox) *sandboxCollector {
+	labels := prometheus.Labels{"sandbox_id": s.id}
+	desc := func(subsystem, name, help string, variableLabels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespaceSandbox, subsystem, name), help, variableLabels, labels)
+	}
+
+	return &sandboxCollector{
+		s: s,
+
+		cpuUsage:    desc("", "cpu_usage_seconds_total", "Sandbox CPU usage, VM and hypervisor included."),
+		memoryUsage: desc("", "memory_usage_bytes", "Sandbox memory usage, VM and hypervisor included."),
+		vcpus:       desc("", "vcpus", "Sandbox vCPUs."),
+
+		hypervisorRSS:     desc("hypervisor", "rss_bytes", "Hypervisor process resident memory."),
+		hypervisorThreads: desc("hypervisor", "threads", "Hypervisor process threads."),
+		hypervisorFDs:     desc("hypervisor", "fds", "Hypervisor process open FDs."),
+
+		containerCPUUsage:    desc("container", "cpu_usage_seconds_total", "Container CPU usage, in the guest.", "container_id"),
+		containerMemoryUsage: desc("container", "memory_usage_bytes", "Container memory usage, in the guest.", "container_id"),
+		containerPids:        desc("container", "pids", "Container processes and threads, in the guest.", "container_id"),
+		containerPidsLimit:   desc("container", "pids_limit", "Container processes and threads limit, in the guest.", "container_id"),
+		containerZombies:     desc("container", "zombies", "Container exited processes not reaped yet, in the guest.", "container_id"),
+		containerNetworkRx:   desc("container", "network_receive_bytes_total", "Container network received bytes, in the guest.", "container_id", "interface"),
+		containerNetworkTx:   desc("container", "network_transmit_bytes_total", "Container network transmitted bytes, in the guest.", "container_id", "interface"),
+	}
+}
+
 func RegisterMetrics() {
 	prometheus.MustRegister(hypervisorThreads)
 	prometheus.MustRegister(hypervisorProcStatus)
@@ -78,6 +150,117 @@ func RegisterMetrics() {
 	prometheus.MustRegister(hypervisorIOStat)
 	prometheus.MustRegister(hypervisorOpenFDs)
 	prometheus.MustRegister(agentRpcDurationsHistogram)
+	prometheus.MustRegister(endpointOpDurationsHistogram)
+	prometheus.MustRegister(endpointOpErrors)
+	prometheus.MustRegister(vmBootDurationsHistogram)
+	prometheus.MustRegister(hotplugDurationsHistogram)
+}
+
+// MetricsCollector returns the collector of the stats of the sandbox, of
+// its containers and of its hypervisor process, labelled with the sandbox
+// ID so that the collectors of several sandboxes can be registered in one
+// registry.
+func (s *Sandbox) MetricsCollector() prometheus.Collector {
+	return newSandboxCollector(s)
+}
+
+// Describe implements prometheus.Collector.
+func (c *sandboxCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		c.cpuUsage,
+		c.memoryUsage,
+		c.vcpus,
+		c.hypervisorRSS,
+		c.hypervisorThreads,
+		c.hypervisorFDs,
+		c.containerCPUUsage,
+		c.containerMemoryUsage,
+		c.containerPids,
+		c.containerPidsLimit,
+		c.containerZombies,
+		c.containerNetworkRx,
+		c.containerNetworkTx,
+	} {
+		ch <- desc
+	}
+}
+
+// Collect implements prometheus.Collector. The stats failing to be read are
+// left out of the scrape.
+func (c *sandboxCollector) Collect(ch chan<- prometheus.Metric) {
+	if stats, err := c.s.Stats(); err == nil {
+		ch <- prometheus.MustNewConstMetric(c.cpuUsage, prometheus.CounterValue,
+			float64(stats.CgroupStats.CPUStats.CPUUsage.TotalUsage)/float64(time.Second))
+		ch <- prometheus.MustNewConstMetric(c.memoryUsage, prometheus.GaugeValue,
+			float64(stats.CgroupStats.MemoryStats.Usage.Usage))
+		ch <- prometheus.MustNewConstMetric(c.vcpus, prometheus.GaugeValue, float64(stats.Cpus))
+	} else {
+		c.s.Logger().WithError(err).Debug("failed to collect the sandbox stats")
+	}
+
+	c.collectHypervisor(ch)
+
+	for _, container := range c.s.GetAllContainers() {
+		cid := container.ID()
+
+		stats, err := c.s.StatsContainer(cid)
+		if err != nil {
+			c.s.Logger().WithError(err).WithField("container", cid).Debug("failed to collect the container stats")
+			continue
+		}
+
+		if cgroup := stats.CgroupStats; cgroup != nil {
+			ch <- prometheus.MustNewConstMetric(c.containerCPUUsage, prometheus.CounterValue,
+				float64(cgroup.CPUStats.CPUUsage.TotalUsage)/float64(time.Second), cid)
+			ch <- prometheus.MustNewConstMetric(c.containerMemoryUsage, prometheus.GaugeValue,
+				float64(cgroup.MemoryStats.Usage.Usage), cid)
+			ch <- prometheus.MustNewConstMetric(c.containerPids, prometheus.GaugeValue,
+				float64(cgroup.PidsStats.Current), cid)
+			// Unlimited containers have no limit to report
+			if cgroup.PidsStats.Limit > 0 {
+				ch <- prometheus.MustNewConstMetric(c.containerPidsLimit, prometheus.GaugeValue,
+					float64(cgroup.PidsStats.Limit), cid)
+			}
+			ch <- prometheus.MustNewConstMetric(c.containerZombies, prometheus.GaugeValue,
+				float64(cgroup.PidsStats.Zombies), cid)
+		}
+
+		for _, net := range stats.NetworkStats {
+			ch <- prometheus.MustNewConstMetric(c.containerNetworkRx, prometheus.CounterValue,
+				float64(net.RxBytes), cid, net.Name)
+			ch <- prometheus.MustNewConstMetric(c.containerNetworkTx, prometheus.CounterValue,
+				float64(net.TxBytes), cid, net.Name)
+		}
+	}
+}
+
+func (c *sandboxCollector) collectHypervisor(ch chan<- prometheus.Metric) {
+	pids := c.s.hypervisor.getPids()
+	if len(pids) == 0 || pids[0] == 0 {
+		return
+	}
+
+	proc, err := procfs.NewProc(pids[0])
+	if err != nil {
+		return
+	}
+
+	if status, err := proc.NewStatus(); err == nil {
+		ch <- prometheus.MustNewConstMetric(c.hypervisorRSS, prometheus.GaugeValue, float64(status.VmRSS))
+	}
+
+	if stat, err := proc.Stat(); err == nil {
+		ch <- prometheus.MustNewConstMetric(c.hypervisorThreads, prometheus.GaugeValue, float64(stat.NumThreads))
+	}
+
+	if fds, err := proc.FileDescriptorsLen(); err == nil {
+		ch <- prometheus.MustNewConstMetric(c.hypervisorFDs, prometheus.GaugeValue, float64(fds))
+	}
+}
+
+// observeDuration records the time elapsed since start, in milliseconds.
+func observeDuration(o prometheus.Observer, start time.Time) {
+	o.Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
 }
 
 // UpdateRuntimeMetrics update shim/hypervisor's metrics
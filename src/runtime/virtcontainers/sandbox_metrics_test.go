@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"os"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxMetricsCollector(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id:         testSandboxID,
+		hypervisor: &mockHypervisor{mockPid: os.Getpid()},
+		agent:      &mockAgent{},
+		config:     &SandboxConfig{},
+		containers: map[string]*Container{},
+	}
+
+	registry := prometheus.NewRegistry()
+	assert.NoError(registry.Register(s.MetricsCollector()))
+
+	// The collectors of several sandboxes are told apart by their labels
+	other := &Sandbox{
+		id:         "other",
+		hypervisor: &mockHypervisor{},
+		agent:      &mockAgent{},
+		config:     &SandboxConfig{},
+		containers: map[string]*Container{},
+	}
+	assert.NoError(registry.Register(other.MetricsCollector()))
+
+	mfs, err := registry.Gather()
+	assert.NoError(err)
+
+	// The sandbox has no cgroup, only the hypervisor process is collected
+	var found []string
+	for _, mf := range mfs {
+		found = append(found, mf.GetName())
+		assert.Len(mf.GetMetric(), 1, mf.GetName())
+		label := mf.GetMetric()[0].GetLabel()[0]
+		assert.Equal("sandbox_id", label.GetName())
+		assert.Equal(testSandboxID, label.GetValue())
+	}
+	assert.ElementsMatch([]string{
+		"kata_sandbox_hypervisor_fds",
+		"kata_sandbox_hypervisor_rss_bytes",
+		"kata_sandbox_hypervisor_threads",
+	}, found)
+}
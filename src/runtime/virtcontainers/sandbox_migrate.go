@@ -0,0 +1,287 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+// migrationDialTimeout is how long the source host of a sandbox migration
+// tries to reach the destination host.
+const migrationDialTimeout = 30 * time.Second
+
+// sandboxMigrationMessage is a message of the migration of a sandbox
+// between two hosts, sent as JSON on the migration connection:
+//
+//  1. the source host sends the states of the sandbox and of its
+//     containers, and the OCI configuration of the containers,
+//  2. the destination host creates the sandbox and starts its VM, and
+//     replies with the URI the VM receives the migrated state on,
+//  3. the source host streams the state of its VM there, and tells once
+//     it is sent,
+//  4. the destination host resumes the VM, reconnects to the agent, and
+//     tells once the sandbox runs.
+//
+// Either host sends a message with an Error instead when it fails, the
+// sandbox running on the source host then.
+type sandboxMigrationMessage struct {
+	State   *sandboxSnapshotState `json:",omitempty"`
+	Bundles map[string][]byte     `json:",omitempty"`
+	URI     string                `json:",omitempty"`
+	Error   string                `json:",omitempty"`
+}
+
+// recvMigrationMessage reads the next message of a sandbox migration, and
+// returns the error it reports, if any.
+func recvMigrationMessage(dec *json.Decoder) (*sandboxMigrationMessage, error) {
+	var msg sandboxMigrationMessage
+	if err := dec.Decode(&msg); err != nil {
+		return nil, fmt.Errorf("Could not read the sandbox migration message: %v", err)
+	}
+
+	if msg.Error != "" {
+		return nil, fmt.Errorf("Sandbox migration failed on the other host: %s", msg.Error)
+	}
+
+	return &msg, nil
+}
+
+// checkMigration checks the VM of the sandbox can be migrated.
+func (s *Sandbox) checkMigration() error {
+	if caps := s.hypervisor.capabilities(); !caps.IsSandboxMigrationSupported() {
+		return fmt.Errorf("Sandbox migration is not supported by the hypervisor")
+	}
+
+	return s.checkSaveable("migrate")
+}
+
+// Migrate migrates the running sandbox to the host receiving it with
+// ReceiveSandbox on destination, handing over the states of the sandbox
+// and of its containers, and the OCI configuration of the containers. The
+// VM keeps running while its memory is sent. Once the sandbox runs on the
+// destination host, its VM is stopped and it is deleted on this host,
+// its containers running on the destination host. The VM runs on if the
+// migration fails.
+func (s *Sandbox) Migrate(destination string) (err error) {
+	span, _ := s.trace("Migrate")
+	defer span.Finish()
+
+	if err := s.checkMigration(); err != nil {
+		return err
+	}
+
+	bundles, err := s.readBundles()
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.DialTimeout("tcp", destination, migrationDialTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	enc := json.NewEncoder(conn)
+	dec := json.NewDecoder(conn)
+
+	ss, cs := s.dump()
+	offer := sandboxMigrationMessage{
+		State:   &sandboxSnapshotState{Sandbox: ss, Containers: cs},
+		Bundles: bundles,
+	}
+	if err := enc.Encode(offer); err != nil {
+		return err
+	}
+
+	reply, err := recvMigrationMessage(dec)
+	if err != nil {
+		return err
+	}
+
+	if err := s.hypervisor.migrateSandbox(reply.URI); err != nil {
+		enc.Encode(sandboxMigrationMessage{Error: err.Error()})
+		return err
+	}
+
+	// The VM is paused once its state is sent, it resumes here unless the
+	// sandbox runs on the destination host.
+	if err = enc.Encode(sandboxMigrationMessage{}); err == nil {
+		_, err = recvMigrationMessage(dec)
+	}
+	if err != nil {
+		if resumeErr := s.hypervisor.resumeSandbox(); resumeErr != nil {
+			s.Logger().WithError(resumeErr).Error("Could not resume the sandbox after its migration failed")
+		}
+		return err
+	}
+
+	s.Logger().WithField("destination", destination).Info("Sandbox migrated")
+
+	return s.releaseMigrated()
+}
+
+// releaseMigrated stops the VM of a sandbox migrated to another host, and
+// releases what the sandbox holds on this host. Its agent, which runs on
+// the destination host, is not called.
+func (s *Sandbox) releaseMigrated() error {
+	if err := s.hypervisor.stopSandbox(); err != nil {
+		s.Logger().WithError(err).Warn("Could not stop the VM of the migrated sandbox")
+	}
+
+	var containers []*Container
+	for _, c := range s.containers {
+		containers = append(containers, c)
+	}
+	s.unshareContainers(containers)
+
+	for _, c := range containers {
+		if err := bindUnmountCoreDumpDir(s.ctx, getMountPath(s.id), c.id); err != nil {
+			c.Logger().WithError(err).Warn("Could not unmount the container core dumps directory")
+		}
+
+		if err := c.setContainerState(types.StateStopped); err != nil {
+			return err
+		}
+	}
+
+	if err := s.setSandboxState(types.StateStopped); err != nil {
+		return err
+	}
+
+	if err := s.removeNetwork(); err != nil {
+		s.Logger().WithError(err).Warn("Could not remove the network of the migrated sandbox")
+	}
+
+	return s.Delete()
+}
+
+// migrationConfig returns the configuration of the sandbox migrated with
+// the state ss, its VM receiving the state of the migrated one on uri.
+func migrationConfig(ss persistapi.SandboxState, uri string) SandboxConfig {
+	sandboxConfig := savedSandboxConfig(ss)
+	sandboxConfig.HypervisorConfig.IncomingMigrationURI = uri
+
+	return sandboxConfig
+}
+
+// incomingMigrationURI returns the URI the VM of a migrated sandbox
+// receives the state of the source VM on: a free TCP port on addr, the
+// address the source host reached this one at.
+func incomingMigrationURI(addr net.Addr) (string, error) {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return "", fmt.Errorf("Unexpected sandbox migration address %v", addr)
+	}
+
+	l, err := net.Listen("tcp", net.JoinHostPort(tcpAddr.IP.String(), "0"))
+	if err != nil {
+		return "", err
+	}
+	defer l.Close()
+
+	return "tcp:" + l.Addr().String(), nil
+}
+
+// receiveSandbox waits on listenAddr for a sandbox migrated from another
+// host with Migrate, creates it with the states and the OCI configurations
+// handed over, and starts its VM, which receives the state of the migrated
+// one. The network namespace of the sandbox must have the interfaces it
+// had on the source host, and the root file systems and the volumes of
+// its containers must be mounted where they were.
+func receiveSandbox(ctx context.Context, listenAddr string) (_ *Sandbox, err error) {
+	l, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := l.Accept()
+	l.Close()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	enc := json.NewEncoder(conn)
+	dec := json.NewDecoder(conn)
+
+	defer func() {
+		if err != nil {
+			enc.Encode(sandboxMigrationMessage{Error: err.Error()})
+		}
+	}()
+
+	offer, err := recvMigrationMessage(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	if offer.State == nil || offer.State.Sandbox.SandboxContainer == "" {
+		return nil, fmt.Errorf("Sandbox migration has no sandbox state")
+	}
+
+	uri, err := incomingMigrationURI(conn.LocalAddr())
+	if err != nil {
+		return nil, err
+	}
+
+	received := func(s *Sandbox) error {
+		if err := enc.Encode(sandboxMigrationMessage{URI: uri}); err != nil {
+			return err
+		}
+
+		if _, err := recvMigrationMessage(dec); err != nil {
+			return err
+		}
+
+		return s.hypervisor.finishIncomingMigration()
+	}
+
+	s, err := recreateSandbox(ctx, migrationConfig(offer.State.Sandbox, uri), offer.State, offer.Bundles, received, (*Sandbox).replumbNetwork)
+	if err != nil {
+		return nil, err
+	}
+
+	// The source host resumes its VM unless told the sandbox runs here.
+	if err = enc.Encode(sandboxMigrationMessage{}); err != nil {
+		if stopErr := s.Stop(true); stopErr != nil {
+			s.Logger().WithError(stopErr).Error("Could not stop the sandbox after its migration failed")
+		} else if deleteErr := s.Delete(); deleteErr != nil {
+			s.Logger().WithError(deleteErr).Error("Could not delete the sandbox after its migration failed")
+		}
+		return nil, err
+	}
+
+	s.Logger().WithField("source", conn.RemoteAddr().String()).Info("Sandbox received")
+
+	return s, nil
+}
+
+// replumbNetwork sets the addresses and the routes of the network
+// namespace of a migrated sandbox, which the destination host may have
+// given other ones, up in the guest.
+func (s *Sandbox) replumbNetwork() error {
+	interfaces, routes, _, err := generateVCNetworkStructures(s.networkNS)
+	if err != nil {
+		return err
+	}
+	interfaces, routes, _ = s.config.NetworkConfig.DHCPInterfaces.strip(interfaces, routes, nil)
+
+	for _, ifc := range interfaces {
+		if _, err := s.agent.updateInterface(ifc); err != nil {
+			return err
+		}
+	}
+
+	_, err = s.agent.updateRoutes(routes)
+	return err
+}
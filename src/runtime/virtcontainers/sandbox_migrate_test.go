@@ -0,0 +1,161 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// migrationHypervisor is a mock hypervisor able to migrate the VM.
+type migrationHypervisor struct {
+	mockHypervisor
+	uri     string
+	resumed bool
+}
+
+func (h *migrationHypervisor) capabilities() types.Capabilities {
+	var caps types.Capabilities
+	caps.SetSandboxMigrationSupport()
+	return caps
+}
+
+func (h *migrationHypervisor) migrateSandbox(uri string) error {
+	h.uri = uri
+	return nil
+}
+
+func (h *migrationHypervisor) resumeSandbox() error {
+	h.resumed = true
+	return nil
+}
+
+func TestIncomingMigrationURI(t *testing.T) {
+	assert := assert.New(t)
+
+	uri, err := incomingMigrationURI(&net.TCPAddr{IP: net.ParseIP("127.0.0.1")})
+	assert.NoError(err)
+	assert.True(strings.HasPrefix(uri, "tcp:127.0.0.1:"))
+	assert.NotEqual("tcp:127.0.0.1:0", uri)
+
+	_, err = incomingMigrationURI(&net.UnixAddr{Name: "/run/migration.sock", Net: "unix"})
+	assert.Error(err)
+}
+
+func TestMigrationConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	ss := persistapi.SandboxState{SandboxContainer: "test-migrate"}
+	ss.Config.KataAgentConfig = &persistapi.KataAgentConfig{}
+	ss.Config.Kdump.CrashKernelMB = 128
+	ss.Config.HypervisorConfig.MemorySize = 2048 + 128
+
+	config := migrationConfig(ss, "tcp:192.168.0.2:4444")
+	assert.Equal("test-migrate", config.ID)
+	assert.Equal("tcp:192.168.0.2:4444", config.HypervisorConfig.IncomingMigrationURI)
+	assert.Empty(config.HypervisorConfig.SnapshotPath)
+	assert.Equal(uint32(2048), config.HypervisorConfig.MemorySize)
+}
+
+func TestSandboxMigrate(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id:         "test-migrate",
+		containers: map[string]*Container{},
+		devManager: manager.NewDeviceManager(manager.VirtioSCSI, false, "", nil),
+		hypervisor: &mockHypervisor{},
+		agent:      &mockAgent{},
+		ctx:        context.Background(),
+		config:     &SandboxConfig{ID: "test-migrate"},
+		state:      types.SandboxState{State: types.StateRunning},
+	}
+
+	// Migrations are not supported
+	assert.Error(s.Migrate("127.0.0.1:0"))
+
+	h := &migrationHypervisor{}
+	s.hypervisor = h
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(err)
+	defer l.Close()
+
+	// A destination host failing to run the sandbox once its VM state
+	// is received
+	done := make(chan *sandboxMigrationMessage)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			close(done)
+			return
+		}
+		defer conn.Close()
+
+		enc := json.NewEncoder(conn)
+		dec := json.NewDecoder(conn)
+
+		offer, err := recvMigrationMessage(dec)
+		if err != nil {
+			close(done)
+			return
+		}
+		enc.Encode(sandboxMigrationMessage{URI: "tcp:127.0.0.1:4444"})
+		recvMigrationMessage(dec)
+		enc.Encode(sandboxMigrationMessage{Error: "agent not reachable"})
+
+		done <- offer
+	}()
+
+	err = s.Migrate(l.Addr().String())
+	assert.Error(err)
+	assert.Contains(err.Error(), "agent not reachable")
+
+	offer := <-done
+	assert.NotNil(offer)
+	assert.Equal("test-migrate", offer.State.Sandbox.SandboxContainer)
+
+	assert.Equal("tcp:127.0.0.1:4444", h.uri)
+	assert.True(h.resumed)
+	assert.Equal(types.StateRunning, s.state.State)
+}
+
+func TestReceiveSandboxNoState(t *testing.T) {
+	assert := assert.New(t)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(err)
+	addr := l.Addr().String()
+	l.Close()
+
+	errCh := make(chan error)
+	go func() {
+		_, err := receiveSandbox(context.Background(), addr)
+		errCh <- err
+	}()
+
+	var conn net.Conn
+	assert.Eventually(func() bool {
+		conn, err = net.Dial("tcp", addr)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	defer conn.Close()
+
+	assert.NoError(json.NewEncoder(conn).Encode(sandboxMigrationMessage{}))
+
+	_, err = recvMigrationMessage(json.NewDecoder(conn))
+	assert.Error(err)
+	assert.Error(<-errCh)
+}
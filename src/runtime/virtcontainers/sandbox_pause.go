@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+// Pause pauses the VM of a running sandbox, such as with the QMP stop
+// command, to quiesce the whole pod without tearing it down. Its vCPUs
+// stop, and its memory and devices stay as they are until Resume. The
+// containers keep their own state, and the sandbox is reported paused.
+func (s *Sandbox) Pause() error {
+	span, _ := s.trace("Pause")
+	defer span.Finish()
+
+	if s.state.State != types.StateRunning {
+		return fmt.Errorf("Sandbox not running, impossible to pause it")
+	}
+
+	if caps := s.hypervisor.capabilities(); !caps.IsSandboxPauseSupported() {
+		return fmt.Errorf("Pausing the sandbox is not supported by the hypervisor")
+	}
+
+	if err := s.hypervisor.pauseSandbox(); err != nil {
+		return err
+	}
+
+	if err := s.setSandboxState(types.StatePaused); err != nil {
+		return err
+	}
+
+	return s.storeSandbox()
+}
+
+// Resume resumes the VM of a sandbox paused by Pause.
+func (s *Sandbox) Resume() error {
+	span, _ := s.trace("Resume")
+	defer span.Finish()
+
+	if s.state.State != types.StatePaused {
+		return fmt.Errorf("Sandbox not paused, impossible to resume it")
+	}
+
+	if err := s.hypervisor.resumeSandbox(); err != nil {
+		return err
+	}
+
+	if err := s.agent.check(); err != nil {
+		return err
+	}
+
+	// The guest clock stopped while the VM was paused
+	if err := s.agent.setGuestDateTime(time.Now()); err != nil {
+		s.Logger().WithError(err).Warn("Failed to sync the guest time after resuming the sandbox")
+	}
+
+	if err := s.setSandboxState(types.StateRunning); err != nil {
+		return err
+	}
+
+	return s.storeSandbox()
+}
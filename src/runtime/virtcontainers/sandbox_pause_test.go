@@ -0,0 +1,83 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// pauseHypervisor is a mock hypervisor able to pause the VM and to suspend
+// the guest to RAM.
+type pauseHypervisor struct {
+	mockHypervisor
+}
+
+func (h *pauseHypervisor) capabilities() types.Capabilities {
+	var caps types.Capabilities
+	caps.SetSandboxPauseSupport()
+	caps.SetGuestSuspendSupport()
+	return caps
+}
+
+func TestSandboxPause(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id:         "test-pause",
+		containers: map[string]*Container{},
+		devManager: manager.NewDeviceManager(manager.VirtioSCSI, false, "", nil),
+		hypervisor: &mockHypervisor{},
+		agent:      &mockAgent{},
+		ctx:        context.Background(),
+		config:     &SandboxConfig{ID: "test-pause"},
+		state:      types.SandboxState{State: types.StateRunning},
+	}
+
+	var err error
+	s.newStore, err = persist.GetDriver()
+	assert.NoError(err)
+	defer os.RemoveAll(filepath.Join(s.newStore.RunStoragePath(), s.id))
+
+	// Pause is not supported
+	assert.Error(s.Pause())
+	assert.Equal(types.StateRunning, s.state.State)
+
+	// Not paused
+	assert.Error(s.Resume())
+
+	s.hypervisor = &pauseHypervisor{}
+	assert.NoError(s.Pause())
+	assert.Equal(types.StatePaused, s.state.State)
+
+	// Already paused, and not suspended to RAM
+	assert.Error(s.Pause())
+	assert.Error(s.ResumeFromRAM())
+
+	// The paused state is saved
+	ss, _, err := s.newStore.FromDisk(s.id)
+	assert.NoError(err)
+	assert.Equal(string(types.StatePaused), ss.State)
+
+	assert.NoError(s.Resume())
+	assert.Equal(types.StateRunning, s.state.State)
+
+	// A sandbox suspended to RAM is not resumed as a paused one.
+	assert.NoError(s.SuspendToRAM())
+	assert.Equal(types.StateSuspended, s.state.State)
+	assert.Error(s.Resume())
+	assert.Error(s.Pause())
+
+	assert.NoError(s.ResumeFromRAM())
+	assert.Equal(types.StateRunning, s.state.State)
+}
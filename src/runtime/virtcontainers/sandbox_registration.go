@@ -0,0 +1,241 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+const (
+	// registrationAttempts is how many times a registrar is called before
+	// a registration is given up.
+	registrationAttempts = 5
+
+	// registrationTimeout bounds each call to a registrar.
+	registrationTimeout = 10 * time.Second
+)
+
+// registrationRetryInterval is the delay before the first retry of a
+// registration, doubled for each following retry.
+var registrationRetryInterval = time.Second
+
+// SandboxRegistrar registers the network interfaces of the sandboxes with
+// an external system, such as a service discovery or an IPAM.
+type SandboxRegistrar interface {
+	// Register registers the interfaces of a started sandbox, as the
+	// guest sees them, with their IP addresses. It is called again with
+	// all the interfaces whenever they are hot added or removed.
+	Register(ctx context.Context, sandboxID string, interfaces []*vcTypes.Interface) error
+
+	// Deregister removes the registration of a sandbox being stopped. It
+	// is also called for the sandboxes the registrar may not have
+	// registered, such as when the runtime restarted meanwhile.
+	Deregister(ctx context.Context, sandboxID string) error
+}
+
+var (
+	sandboxRegistrars     = map[string]SandboxRegistrar{}
+	sandboxRegistrarsLock sync.RWMutex
+)
+
+// RegisterSandboxRegistrar makes a registrar selectable by name in the
+// Registrars of the sandbox configuration. The registrars register
+// themselves from the init function of their package, built into the
+// runtime.
+func RegisterSandboxRegistrar(name string, registrar SandboxRegistrar) {
+	if name == "" || registrar == nil {
+		panic("invalid sandbox registrar registration")
+	}
+
+	sandboxRegistrarsLock.Lock()
+	defer sandboxRegistrarsLock.Unlock()
+
+	if _, ok := sandboxRegistrars[name]; ok {
+		panic(fmt.Sprintf("sandbox registrar %q registered twice", name))
+	}
+	sandboxRegistrars[name] = registrar
+}
+
+func lookupSandboxRegistrar(name string) (SandboxRegistrar, bool) {
+	sandboxRegistrarsLock.RLock()
+	defer sandboxRegistrarsLock.RUnlock()
+
+	registrar, ok := sandboxRegistrars[name]
+	return registrar, ok
+}
+
+// SandboxRegistrarNames returns the names of the registrars the runtime was
+// built with.
+func SandboxRegistrarNames() []string {
+	sandboxRegistrarsLock.RLock()
+	defer sandboxRegistrarsLock.RUnlock()
+
+	names := make([]string, 0, len(sandboxRegistrars))
+	for name := range sandboxRegistrars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// checkRegistrars checks that the registrars of the sandbox are known.
+func checkRegistrars(names []string) error {
+	for _, name := range names {
+		if _, ok := lookupSandboxRegistrar(name); !ok {
+			return fmt.Errorf("Unknown sandbox registrar %q, the runtime is built with %v", name, SandboxRegistrarNames())
+		}
+	}
+	return nil
+}
+
+// retryRegistration calls fn until it succeeds, registrationAttempts times
+// at most, waiting longer between each attempt. It gives up early once
+// stopCh is closed.
+func retryRegistration(stopCh <-chan struct{}, fn func(ctx context.Context) error) error {
+	interval := registrationRetryInterval
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), registrationTimeout)
+		err = fn(ctx)
+		cancel()
+
+		if err == nil || attempt == registrationAttempts {
+			return err
+		}
+
+		select {
+		case <-stopCh:
+			return err
+		case <-time.After(interval):
+		}
+		interval *= 2
+	}
+}
+
+// sandboxRegistration runs the registrations of a sandbox, one at a time,
+// in the background.
+type sandboxRegistration struct {
+	registrars map[string]SandboxRegistrar
+	updateCh   chan struct{}
+	stopCh     chan struct{}
+	wg         sync.WaitGroup
+}
+
+func (s *Sandbox) registrars() map[string]SandboxRegistrar {
+	registrars := make(map[string]SandboxRegistrar)
+	for _, name := range s.config.Registrars {
+		if registrar, ok := lookupSandboxRegistrar(name); ok {
+			registrars[name] = registrar
+		}
+	}
+	return registrars
+}
+
+// startRegistration registers the sandbox with its registrars, once it is
+// started. Failing registrations do not fail the sandbox.
+func (s *Sandbox) startRegistration() {
+	if len(s.config.Registrars) == 0 || s.registration != nil {
+		return
+	}
+
+	r := &sandboxRegistration{
+		registrars: s.registrars(),
+		// A registration pending already registers the latest
+		// interfaces.
+		updateCh: make(chan struct{}, 1),
+		stopCh:   make(chan struct{}),
+	}
+	s.registration = r
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		for {
+			select {
+			case <-r.stopCh:
+				return
+			case <-r.updateCh:
+				s.register(r)
+			}
+		}
+	}()
+
+	s.updateRegistration()
+}
+
+// updateRegistration registers the sandbox again, such as once its
+// interfaces changed. A sandbox the runtime did not start, as after a
+// restart of the runtime, is registered from now on.
+func (s *Sandbox) updateRegistration() {
+	if s.registration == nil {
+		if s.state.State == types.StateRunning {
+			s.startRegistration()
+		}
+		return
+	}
+
+	select {
+	case s.registration.updateCh <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Sandbox) register(r *sandboxRegistration) {
+	var interfaces []*vcTypes.Interface
+	err := retryRegistration(r.stopCh, func(ctx context.Context) error {
+		var err error
+		interfaces, err = s.agent.listInterfaces()
+		return err
+	})
+	if err != nil {
+		s.Logger().WithError(err).Warn("Could not list the interfaces to register")
+		return
+	}
+
+	for name, registrar := range r.registrars {
+		err := retryRegistration(r.stopCh, func(ctx context.Context) error {
+			return registrar.Register(ctx, s.id, interfaces)
+		})
+		if err != nil {
+			s.Logger().WithError(err).WithField("registrar", name).Warn("Could not register the sandbox")
+		}
+	}
+}
+
+// stopRegistration deregisters the sandbox, before it is stopped, so that
+// it is not reached anymore.
+func (s *Sandbox) stopRegistration() {
+	if len(s.config.Registrars) == 0 {
+		return
+	}
+
+	if r := s.registration; r != nil {
+		close(r.stopCh)
+		r.wg.Wait()
+		s.registration = nil
+	}
+
+	// The deregistration is retried even though the registration is
+	// stopped.
+	for name, registrar := range s.registrars() {
+		err := retryRegistration(nil, func(ctx context.Context) error {
+			return registrar.Deregister(ctx, s.id)
+		})
+		if err != nil {
+			s.Logger().WithError(err).WithField("registrar", name).Warn("Could not deregister the sandbox")
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRegistrar struct {
+	sync.Mutex
+	registered   map[string]int
+	deregistered map[string]int
+	failures     int
+}
+
+func (r *testRegistrar) Register(ctx context.Context, sandboxID string, interfaces []*vcTypes.Interface) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.failures > 0 {
+		r.failures--
+		return errors.New("registry unavailable")
+	}
+	r.registered[sandboxID]++
+	return nil
+}
+
+func (r *testRegistrar) Deregister(ctx context.Context, sandboxID string) error {
+	r.Lock()
+	defer r.Unlock()
+
+	r.deregistered[sandboxID]++
+	return nil
+}
+
+func (r *testRegistrar) registrations(sandboxID string) int {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.registered[sandboxID]
+}
+
+func registerTestRegistrar() (*testRegistrar, func()) {
+	r := &testRegistrar{
+		registered:   make(map[string]int),
+		deregistered: make(map[string]int),
+	}
+	RegisterSandboxRegistrar("test", r)
+
+	savedInterval := registrationRetryInterval
+	registrationRetryInterval = time.Millisecond
+
+	return r, func() {
+		registrationRetryInterval = savedInterval
+
+		sandboxRegistrarsLock.Lock()
+		delete(sandboxRegistrars, "test")
+		sandboxRegistrarsLock.Unlock()
+	}
+}
+
+func TestRegisterSandboxRegistrar(t *testing.T) {
+	assert := assert.New(t)
+
+	r, cleanup := registerTestRegistrar()
+	defer cleanup()
+
+	assert.Panics(func() { RegisterSandboxRegistrar("test", r) })
+	assert.Panics(func() { RegisterSandboxRegistrar("", r) })
+	assert.Panics(func() { RegisterSandboxRegistrar("other", nil) })
+
+	assert.Contains(SandboxRegistrarNames(), "test")
+	assert.NoError(checkRegistrars(nil))
+	assert.NoError(checkRegistrars([]string{"test"}))
+	assert.Error(checkRegistrars([]string{"test", "unknown"}))
+}
+
+func TestRetryRegistration(t *testing.T) {
+	assert := assert.New(t)
+
+	savedInterval := registrationRetryInterval
+	registrationRetryInterval = time.Millisecond
+	defer func() {
+		registrationRetryInterval = savedInterval
+	}()
+
+	attempts := 0
+	err := retryRegistration(nil, func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	assert.NoError(err)
+	assert.Equal(3, attempts)
+
+	attempts = 0
+	err = retryRegistration(nil, func(ctx context.Context) error {
+		attempts++
+		return errors.New("failed")
+	})
+	assert.Error(err)
+	assert.Equal(registrationAttempts, attempts)
+
+	// A stopped registration is not retried.
+	stopCh := make(chan struct{})
+	close(stopCh)
+	attempts = 0
+	err = retryRegistration(stopCh, func(ctx context.Context) error {
+		attempts++
+		return errors.New("failed")
+	})
+	assert.Error(err)
+	assert.Equal(1, attempts)
+}
+
+func TestSandboxRegistration(t *testing.T) {
+	assert := assert.New(t)
+
+	r, cleanup := registerTestRegistrar()
+	defer cleanup()
+
+	// A failing registration is retried.
+	r.failures = 2
+
+	s := &Sandbox{
+		id:     "sandbox",
+		agent:  &mockAgent{},
+		config: &SandboxConfig{Registrars: []string{"test"}},
+		state:  types.SandboxState{State: types.StateRunning},
+	}
+
+	s.startRegistration()
+	assert.Eventually(func() bool { return r.registrations(s.id) == 1 }, time.Second, time.Millisecond)
+
+	s.updateRegistration()
+	assert.Eventually(func() bool { return r.registrations(s.id) == 2 }, time.Second, time.Millisecond)
+
+	s.stopRegistration()
+	assert.Nil(s.registration)
+	assert.Equal(1, r.deregistered[s.id])
+
+	// A sandbox the runtime was restarted with is registered once its
+	// interfaces change, and deregistered when it stops.
+	restored := &Sandbox{
+		id:     "restored",
+		agent:  &mockAgent{},
+		config: &SandboxConfig{Registrars: []string{"test"}},
+		state:  types.SandboxState{State: types.StateRunning},
+	}
+	restored.updateRegistration()
+	assert.Eventually(func() bool { return r.registrations(restored.id) == 1 }, time.Second, time.Millisecond)
+	restored.stopRegistration()
+	assert.Equal(1, r.deregistered[restored.id])
+
+	// A sandbox without registrars is not registered.
+	s = &Sandbox{
+		id:     "unregistered",
+		agent:  &mockAgent{},
+		config: &SandboxConfig{},
+		state:  types.SandboxState{State: types.StateRunning},
+	}
+	s.startRegistration()
+	assert.Nil(s.registration)
+	s.stopRegistration()
+	assert.Zero(r.deregistered[s.id])
+}
@@ -0,0 +1,515 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/compatoci"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+const (
+	// sandboxSnapshotVMDir is the directory, in a sandbox snapshot, of the
+	// VM state saved by the hypervisor.
+	sandboxSnapshotVMDir = "vm"
+
+	// sandboxSnapshotStateFile is the file, in a sandbox snapshot, of the
+	// states of the sandbox and of its containers, as the store saves
+	// them. It is written last.
+	sandboxSnapshotStateFile = "sandbox.json"
+
+	// sandboxSnapshotBundlesDir is the directory, in a sandbox snapshot,
+	// of the OCI configuration of the containers, in a directory named
+	// after each of them.
+	sandboxSnapshotBundlesDir = "bundles"
+
+	bundleConfigFile = "config.json"
+)
+
+// sandboxSnapshotState is the content of the state file of a sandbox
+// snapshot.
+type sandboxSnapshotState struct {
+	Sandbox    persistapi.SandboxState
+	Containers map[string]persistapi.ContainerState
+}
+
+// checkSnapshot checks the VM of the sandbox can be saved and restored.
+func (s *Sandbox) checkSnapshot() error {
+	if caps := s.hypervisor.capabilities(); !caps.IsSandboxSnapshotSupported() {
+		return fmt.Errorf("Sandbox snapshots are not supported by the hypervisor")
+	}
+
+	return s.checkSaveable("snapshot")
+}
+
+// checkSaveable checks the state of the VM of the sandbox can be saved,
+// for the operation op.
+func (s *Sandbox) checkSaveable(op string) error {
+	if s.state.State != types.StateRunning {
+		return fmt.Errorf("Sandbox %s not running, impossible to %s", s.id, op)
+	}
+
+	if err := s.checkNotConfidential(op); err != nil {
+		return err
+	}
+
+	// The guest memory of a cloneable sandbox or of a clone is backed by
+	// the files of the clones.
+	if s.config.Cloneable || s.config.HypervisorConfig.BootFromTemplate {
+		return fmt.Errorf("Sandbox %s is cloneable or a clone, impossible to %s", s.id, op)
+	}
+
+	if s.config.isForeignGuest() {
+		return fmt.Errorf("Guest OS %q, impossible to %s", s.config.GuestOS, op)
+	}
+
+	// The state of an assigned device cannot be saved.
+	for _, d := range s.devManager.GetAllDevices() {
+		if d.DeviceType() == config.DeviceVFIO && d.GetAttachCount() > 0 {
+			return fmt.Errorf("Sandbox %s has VFIO devices, impossible to %s", s.id, op)
+		}
+	}
+
+	return nil
+}
+
+// Snapshot saves the guest of the running sandbox, its memory included,
+// the states of the sandbox and of its containers, and the OCI
+// configuration of the containers to dir, which must not exist, for
+// RestoreSandbox to restore it, such as after a host reboot. The VM is
+// paused meanwhile.
+func (s *Sandbox) Snapshot(dir string) (err error) {
+	span, _ := s.trace("Snapshot")
+	defer span.Finish()
+
+	if err := s.checkSnapshot(); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("Sandbox snapshot %s already exists", dir)
+	}
+
+	vmDir := filepath.Join(dir, sandboxSnapshotVMDir)
+	if err := os.MkdirAll(vmDir, DirMode); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(dir)
+		}
+	}()
+
+	if err := s.snapshotBundles(filepath.Join(dir, sandboxSnapshotBundlesDir)); err != nil {
+		return err
+	}
+
+	if err := s.hypervisor.pauseSandbox(); err != nil {
+		return err
+	}
+	defer func() {
+		if resumeErr := s.hypervisor.resumeSandbox(); resumeErr != nil {
+			s.Logger().WithError(resumeErr).Error("Could not resume snapshotted sandbox")
+			if err == nil {
+				err = resumeErr
+			}
+		}
+	}()
+
+	if err := s.hypervisor.snapshotSandbox(vmDir); err != nil {
+		return err
+	}
+
+	ss, cs := s.dump()
+	data, err := json.Marshal(sandboxSnapshotState{Sandbox: ss, Containers: cs})
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, sandboxSnapshotStateFile), data, 0600); err != nil {
+		return err
+	}
+
+	s.Logger().WithField("snapshot", dir).Info("Sandbox snapshotted")
+
+	return nil
+}
+
+// snapshotBundles copies the OCI configuration of the containers to dir,
+// their bundles being gone after a host reboot.
+func (s *Sandbox) snapshotBundles(dir string) error {
+	bundles, err := s.readBundles()
+	if err != nil {
+		return err
+	}
+
+	for id, data := range bundles {
+		if err := os.MkdirAll(filepath.Join(dir, id), DirMode); err != nil {
+			return err
+		}
+
+		if err := ioutil.WriteFile(filepath.Join(dir, id, bundleConfigFile), data, 0600); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readBundles returns the OCI configuration of the containers, by
+// container ID.
+func (s *Sandbox) readBundles() (map[string][]byte, error) {
+	bundles := make(map[string][]byte)
+
+	for id, c := range s.containers {
+		bundlePath, ok := c.config.Annotations[annotations.BundlePathKey]
+		if !ok {
+			return nil, fmt.Errorf("Could not find the bundle of container %s", id)
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(bundlePath, bundleConfigFile))
+		if err != nil {
+			return nil, err
+		}
+		bundles[id] = data
+	}
+
+	return bundles, nil
+}
+
+// readSandboxSnapshot returns the states saved in the sandbox snapshot in
+// dir.
+func readSandboxSnapshot(dir string) (*sandboxSnapshotState, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, sandboxSnapshotStateFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("No complete sandbox snapshot in %s", dir)
+		}
+		return nil, err
+	}
+
+	var state sandboxSnapshotState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+
+	if state.Sandbox.SandboxContainer == "" {
+		return nil, fmt.Errorf("Sandbox snapshot %s has no sandbox ID", dir)
+	}
+
+	return &state, nil
+}
+
+// readSnapshotBundles returns the OCI configuration of the containers
+// saved in the sandbox snapshot in dir, by container ID.
+func readSnapshotBundles(dir string, state *sandboxSnapshotState) (map[string][]byte, error) {
+	bundles := make(map[string][]byte)
+
+	for id := range state.Containers {
+		data, err := ioutil.ReadFile(filepath.Join(dir, sandboxSnapshotBundlesDir, id, bundleConfigFile))
+		if err != nil {
+			return nil, err
+		}
+		bundles[id] = data
+	}
+
+	return bundles, nil
+}
+
+// restoreConfig returns the configuration of the sandbox saved in the
+// snapshot in dir, its VM being restored from the saved one.
+func restoreConfig(ss persistapi.SandboxState, dir string) SandboxConfig {
+	sandboxConfig := savedSandboxConfig(ss)
+	sandboxConfig.HypervisorConfig.SnapshotPath = filepath.Join(dir, sandboxSnapshotVMDir)
+
+	return sandboxConfig
+}
+
+// savedSandboxConfig returns the configuration of the sandbox saved with
+// the state ss, to create it again.
+func savedSandboxConfig(ss persistapi.SandboxState) SandboxConfig {
+	sandboxConfig := *sandboxConfigFromState(ss)
+
+	// The crash kernel memory is reserved again for the created sandbox.
+	if sandboxConfig.Kdump.enabled() {
+		sandboxConfig.HypervisorConfig.MemorySize -= sandboxConfig.Kdump.CrashKernelMB
+	}
+
+	return sandboxConfig
+}
+
+// restoreSandbox creates the sandbox saved in the snapshot in dir and
+// starts its VM from the saved guest, which runs its containers already.
+// The network namespace of the sandbox must have the interfaces it had
+// when it was snapshotted, and the root file systems and the volumes of
+// its containers must be mounted where they were.
+func restoreSandbox(ctx context.Context, dir string) (*Sandbox, error) {
+	snapshot, err := readSandboxSnapshot(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	bundles, err := readSnapshotBundles(dir, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := recreateSandbox(ctx, restoreConfig(snapshot.Sandbox, dir), snapshot, bundles, nil, func(s *Sandbox) error {
+		// The guest clock stopped when the sandbox was snapshotted.
+		if err := s.agent.setGuestDateTime(time.Now()); err != nil {
+			s.Logger().WithError(err).Warn("Failed to sync the guest time after restoring the sandbox")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.Logger().WithField("snapshot", dir).Info("Sandbox restored")
+
+	return s, nil
+}
+
+// recreateSandbox creates a saved sandbox again, with its saved states and
+// the OCI configuration of its containers, and starts its VM, which the
+// hypervisor loads the saved guest in. received, if not nil, runs once the
+// VM is started, until it has the guest state, and ready once the agent
+// answers again.
+func recreateSandbox(ctx context.Context, sandboxConfig SandboxConfig, saved *sandboxSnapshotState, bundles map[string][]byte, received, ready func(s *Sandbox) error) (_ *Sandbox, err error) {
+	store, err := persist.GetDriver()
+	if err != nil || store == nil {
+		return nil, fmt.Errorf("failed to get fs persist driver: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(store.RunStoragePath(), sandboxConfig.ID)); err == nil {
+		return nil, fmt.Errorf("Sandbox %s already exists", sandboxConfig.ID)
+	}
+
+	s, err := createSandbox(ctx, sandboxConfig, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			s.Delete()
+		}
+	}()
+
+	if err = s.createNetwork(); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			s.removeNetwork()
+		}
+	}()
+
+	if err = s.checkRestoredNetwork(saved.Sandbox.Network); err != nil {
+		return nil, err
+	}
+
+	s.loadDevices(saved.Sandbox.Devices)
+
+	containers, err := s.restoreContainers(bundles, saved.Containers)
+	defer func() {
+		if err != nil {
+			s.unshareContainers(containers)
+		}
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.config.SandboxCgroupOnly {
+		if err = s.createCgroupManager(); err != nil {
+			return nil, err
+		}
+
+		if err = s.setupSandboxCgroup(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.network.Run(s.networkNS.NetNsPath, func() error {
+		return s.hypervisor.startSandbox(vmStartTimeout)
+	}); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			s.hypervisor.stopSandbox()
+		}
+	}()
+
+	if received != nil {
+		if err = received(s); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.agent.reconnect(s); err != nil {
+		return nil, err
+	}
+
+	if err = ready(s); err != nil {
+		return nil, err
+	}
+
+	s.postCreatedNetwork()
+
+	for _, c := range containers {
+		if err = s.addContainer(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.setSandboxState(types.StateRunning); err != nil {
+		return nil, err
+	}
+
+	if err = s.storeSandbox(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// checkRestoredNetwork checks the network namespace of a restored sandbox
+// has the interfaces of the saved network, with the MAC addresses the
+// guest has.
+func (s *Sandbox) checkRestoredNetwork(saved persistapi.NetworkInfo) error {
+	hwAddrs := make(map[string]string)
+	for _, e := range s.networkNS.Endpoints {
+		hwAddrs[e.Name()] = e.HardwareAddr()
+	}
+
+	endpoints := s.loadEndpoints(saved.Endpoints)
+	if len(endpoints) != len(hwAddrs) {
+		return fmt.Errorf("Sandbox %s had %d network interfaces, its network namespace has %d", s.id, len(endpoints), len(hwAddrs))
+	}
+
+	for _, e := range endpoints {
+		hwAddr, ok := hwAddrs[e.Name()]
+		if !ok {
+			return fmt.Errorf("Network interface %s of sandbox %s is missing", e.Name(), s.id)
+		}
+		if hwAddr != e.HardwareAddr() {
+			return fmt.Errorf("Network interface %s of sandbox %s has MAC address %s, the guest has %s", e.Name(), s.id, hwAddr, e.HardwareAddr())
+		}
+	}
+
+	return nil
+}
+
+// restoreContainers creates the containers of a restored sandbox from
+// their saved states and OCI configurations, and shares their root file
+// systems and mounts with the guest again. It returns the containers
+// shared, even on failure.
+func (s *Sandbox) restoreContainers(bundles map[string][]byte, states map[string]persistapi.ContainerState) ([]*Container, error) {
+	var containers []*Container
+
+	for i := range s.config.Containers {
+		contConfig := &s.config.Containers[i]
+
+		cs, ok := states[contConfig.ID]
+		if !ok {
+			return containers, fmt.Errorf("Sandbox snapshot has no state for container %s", contConfig.ID)
+		}
+
+		if err := restoreBundle(contConfig, bundles[contConfig.ID]); err != nil {
+			return containers, err
+		}
+
+		spec, err := compatoci.GetContainerSpec(contConfig.Annotations)
+		if err != nil {
+			return containers, err
+		}
+		contConfig.CustomSpec = &spec
+
+		c, err := newContainer(s, contConfig)
+		if err != nil {
+			return containers, err
+		}
+
+		c.loadContState(cs)
+		c.loadContDevices(cs)
+		c.loadContProcess(cs)
+		c.loadContMounts(cs)
+
+		containers = append(containers, c)
+
+		if c.state.BlockDeviceID == "" {
+			if err := bindMountContainerRootfs(s.ctx, getMountPath(s.id), c.id, c.rootFs.Target, false); err != nil {
+				return containers, err
+			}
+		}
+
+		for _, m := range c.mounts {
+			if m.HostPath == "" {
+				continue
+			}
+			if err := bindMount(s.ctx, m.Source, m.HostPath, false, "private"); err != nil {
+				return containers, err
+			}
+		}
+	}
+
+	return containers, nil
+}
+
+// unshareContainers unmounts the root file systems and the mounts of the
+// containers shared by restoreContainers.
+func (s *Sandbox) unshareContainers(containers []*Container) {
+	for _, c := range containers {
+		if err := c.unmountHostMounts(); err != nil {
+			c.Logger().WithError(err).Warn("Could not unmount the container mounts")
+		}
+
+		if c.state.BlockDeviceID == "" {
+			if err := bindUnmountContainerRootfs(s.ctx, getMountPath(s.id), c.id); err != nil {
+				c.Logger().WithError(err).Warn("Could not unmount the container rootfs")
+			}
+		}
+	}
+}
+
+// restoreBundle writes the saved OCI configuration of a container back to
+// its bundle, if it is gone.
+func restoreBundle(contConfig *ContainerConfig, data []byte) error {
+	bundlePath, ok := contConfig.Annotations[annotations.BundlePathKey]
+	if !ok {
+		return fmt.Errorf("Could not find the bundle of container %s", contConfig.ID)
+	}
+
+	configPath := filepath.Join(bundlePath, bundleConfigFile)
+	if _, err := os.Stat(configPath); err == nil {
+		return nil
+	}
+
+	if data == nil {
+		return fmt.Errorf("No saved OCI configuration for container %s", contConfig.ID)
+	}
+
+	if err := os.MkdirAll(bundlePath, DirMode); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(configPath, data, 0600)
+}
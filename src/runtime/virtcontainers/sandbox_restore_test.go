@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// snapshotHypervisor is a mock hypervisor able to save the state of the
+// VM.
+type snapshotHypervisor struct {
+	mockHypervisor
+}
+
+func (h *snapshotHypervisor) capabilities() types.Capabilities {
+	var caps types.Capabilities
+	caps.SetSandboxSnapshotSupport()
+	return caps
+}
+
+func (h *snapshotHypervisor) snapshotSandbox(dir string) error {
+	return ioutil.WriteFile(filepath.Join(dir, "vmstate"), []byte("state"), 0600)
+}
+
+func TestSandboxSnapshot(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "sandbox-snapshot")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpDir)
+
+	bundlePath := filepath.Join(tmpDir, "bundle")
+	assert.NoError(os.MkdirAll(bundlePath, DirMode))
+	assert.NoError(ioutil.WriteFile(filepath.Join(bundlePath, bundleConfigFile), []byte(`{"ociVersion": "1.0.1"}`), 0600))
+
+	contConfig := ContainerConfig{
+		ID:          "container",
+		Annotations: map[string]string{annotations.BundlePathKey: bundlePath},
+		RootFs:      RootFs{Target: "/run/rootfs"},
+	}
+	s := &Sandbox{
+		id:         "test-snapshot",
+		containers: map[string]*Container{},
+		devManager: manager.NewDeviceManager(manager.VirtioSCSI, false, "", nil),
+		hypervisor: &mockHypervisor{},
+		agent:      &mockAgent{},
+		ctx:        context.Background(),
+		config: &SandboxConfig{
+			ID:         "test-snapshot",
+			Containers: []ContainerConfig{contConfig},
+			Kdump:      Kdump{CrashKernelMB: 128},
+			HypervisorConfig: HypervisorConfig{
+				MemorySize: 2048 + 128,
+			},
+		},
+		state: types.SandboxState{State: types.StateRunning},
+	}
+	s.containers["container"] = &Container{
+		id:      "container",
+		config:  &s.config.Containers[0],
+		sandbox: s,
+		state:   types.ContainerState{State: types.StateRunning},
+		process: Process{Token: "token", Pid: 42},
+	}
+
+	dir := filepath.Join(tmpDir, "snapshot")
+
+	// Snapshots are not supported
+	assert.Error(s.Snapshot(dir))
+	_, err = os.Stat(dir)
+	assert.True(os.IsNotExist(err))
+
+	s.hypervisor = &snapshotHypervisor{}
+
+	s.config.Cloneable = true
+	assert.Error(s.Snapshot(dir))
+	s.config.Cloneable = false
+
+	assert.NoError(s.Snapshot(dir))
+	assert.FileExists(filepath.Join(dir, sandboxSnapshotVMDir, "vmstate"))
+	assert.FileExists(filepath.Join(dir, sandboxSnapshotBundlesDir, "container", bundleConfigFile))
+
+	// The snapshot is not overwritten
+	assert.Error(s.Snapshot(dir))
+
+	snapshot, err := readSandboxSnapshot(dir)
+	assert.NoError(err)
+	assert.Equal("test-snapshot", snapshot.Sandbox.SandboxContainer)
+	assert.Equal(string(types.StateRunning), snapshot.Containers["container"].State)
+	assert.Equal("token", snapshot.Containers["container"].Process.Token)
+
+	config := restoreConfig(snapshot.Sandbox, dir)
+	assert.Equal("test-snapshot", config.ID)
+	assert.Equal(filepath.Join(dir, sandboxSnapshotVMDir), config.HypervisorConfig.SnapshotPath)
+	assert.Equal(uint32(2048), config.HypervisorConfig.MemorySize)
+	assert.Len(config.Containers, 1)
+
+	// The bundle is written back once it is gone.
+	assert.NoError(os.RemoveAll(bundlePath))
+	bundles, err := readSnapshotBundles(dir, snapshot)
+	assert.NoError(err)
+	assert.NoError(restoreBundle(&config.Containers[0], bundles["container"]))
+	assert.FileExists(filepath.Join(bundlePath, bundleConfigFile))
+
+	_, err = readSandboxSnapshot(tmpDir)
+	assert.Error(err)
+}
+
+func TestSandboxSnapshotNotRunning(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id:         "test-snapshot",
+		hypervisor: &snapshotHypervisor{},
+		config:     &SandboxConfig{ID: "test-snapshot"},
+		state:      types.SandboxState{State: types.StatePaused},
+	}
+
+	assert.Error(s.checkSnapshot())
+}
+
+func TestCheckRestoredNetwork(t *testing.T) {
+	assert := assert.New(t)
+
+	newEndpoint := func(name, hwAddr string) *VethEndpoint {
+		e := &VethEndpoint{EndpointType: VethEndpointType}
+		e.NetPair.VirtIface.Name = name
+		e.NetPair.TAPIface.HardAddr = hwAddr
+		return e
+	}
+
+	var saved persistapi.NetworkInfo
+	for _, e := range []*VethEndpoint{newEndpoint("eth0", "02:00:00:00:00:01"), newEndpoint("eth1", "02:00:00:00:00:02")} {
+		saved.Endpoints = append(saved.Endpoints, e.save())
+	}
+
+	s := &Sandbox{id: "test-restore"}
+
+	s.networkNS.Endpoints = []Endpoint{newEndpoint("eth1", "02:00:00:00:00:02"), newEndpoint("eth0", "02:00:00:00:00:01")}
+	assert.NoError(s.checkRestoredNetwork(saved))
+
+	s.networkNS.Endpoints = []Endpoint{newEndpoint("eth0", "02:00:00:00:00:01")}
+	assert.Error(s.checkRestoredNetwork(saved))
+
+	s.networkNS.Endpoints = []Endpoint{newEndpoint("eth0", "02:00:00:00:00:01"), newEndpoint("eth2", "02:00:00:00:00:02")}
+	assert.Error(s.checkRestoredNetwork(saved))
+
+	s.networkNS.Endpoints = []Endpoint{newEndpoint("eth0", "02:00:00:00:00:01"), newEndpoint("eth1", "02:00:00:00:00:03")}
+	assert.Error(s.checkRestoredNetwork(saved))
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	agentPowerOffParam = "agent.power_off"
+
+	// defaultShutdownQuitTimeout is how long the hypervisor is given to
+	// exit once asked to, when the shutdown timeouts do not set it.
+	defaultShutdownQuitTimeout = 10 * time.Second
+
+	shutdownPollInterval = 50 * time.Millisecond
+)
+
+// ShutdownStage is a stage of the graceful shutdown of the VM of a sandbox.
+type ShutdownStage string
+
+const (
+	// ShutdownStageGuest waits for the guest to power itself off, once
+	// the agent destroyed the sandbox.
+	ShutdownStageGuest ShutdownStage = "guest"
+
+	// ShutdownStageACPI sends an ACPI power button event to the guest.
+	ShutdownStageACPI ShutdownStage = "acpi"
+
+	// ShutdownStageQuit asks the hypervisor to exit.
+	ShutdownStageQuit ShutdownStage = "quit"
+
+	// ShutdownStageKill kills the hypervisor.
+	ShutdownStageKill ShutdownStage = "kill"
+)
+
+// ShutdownTimeouts are the timeouts of the stages of the graceful shutdown
+// of the VM of a sandbox stopped without force: the guest powering itself
+// off, an ACPI power down, and the hypervisor exiting, before it is killed.
+// The shutdown escalates to the next stage once a stage times out. A zero
+// Guest or ACPI timeout skips its stage, and the VM is stopped by the
+// hypervisor right away when both are zero.
+type ShutdownTimeouts struct {
+	// Guest is how long the guest is given to power itself off, once
+	// the sandbox is destroyed.
+	Guest time.Duration
+
+	// ACPI is how long the guest is given to power off, after an ACPI
+	// power button event.
+	ACPI time.Duration
+
+	// Quit is how long the hypervisor is given to exit, once asked to.
+	Quit time.Duration
+}
+
+func (t ShutdownTimeouts) enabled() bool {
+	return t.Guest > 0 || t.ACPI > 0
+}
+
+func (t ShutdownTimeouts) validate() error {
+	for field, timeout := range map[string]time.Duration{
+		"Guest": t.Guest,
+		"ACPI":  t.ACPI,
+		"Quit":  t.Quit,
+	} {
+		if timeout < 0 {
+			return newConfigFieldError(field, fmt.Sprintf("Negative shutdown timeout %v", timeout))
+		}
+	}
+	return nil
+}
+
+func (t ShutdownTimeouts) kernelParams() []Param {
+	if t.Guest <= 0 {
+		return nil
+	}
+
+	return []Param{{Key: agentPowerOffParam}}
+}
+
+// ShutdownStageFailure is a shutdown stage which did not stop the VM.
+type ShutdownStageFailure struct {
+	Stage ShutdownStage
+	Err   error
+}
+
+// ShutdownEscalationError reports the VM of a sandbox stopped by a later
+// stage of its graceful shutdown, the earlier stages not stopping it, as
+// the error of the shutdown escalation events.
+type ShutdownEscalationError struct {
+	// Stage is the stage which stopped the VM.
+	Stage ShutdownStage
+
+	// Failures are the earlier stages, which did not stop the VM.
+	Failures []ShutdownStageFailure
+}
+
+func (e *ShutdownEscalationError) Error() string {
+	var failures []string
+	for _, f := range e.Failures {
+		failures = append(failures, fmt.Sprintf("%s: %v", f.Stage, f.Err))
+	}
+
+	return fmt.Sprintf("VM stopped at the %s shutdown stage, escalated from %s", e.Stage, strings.Join(failures, ", "))
+}
+
+// processStatFields returns the fields of /proc/<pid>/stat following the
+// command name, starting with the process state.
+func processStatFields(pid int) ([]string, error) {
+	stat, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return nil, err
+	}
+
+	// The command name may contain spaces and parentheses.
+	return strings.Fields(string(stat[strings.LastIndex(string(stat), ")")+1:])), nil
+}
+
+// processStartTime returns the start time of a process, in clock ticks
+// since boot.
+func processStartTime(pid int) (uint64, error) {
+	fields, err := processStatFields(pid)
+	if err != nil {
+		return 0, err
+	}
+
+	// The start time is the 22nd field, the state being the 3rd one.
+	if len(fields) < 20 {
+		return 0, fmt.Errorf("Invalid stat of process %d", pid)
+	}
+	return strconv.ParseUint(fields[19], 10, 64)
+}
+
+// processExited tells whether a process exited, a zombie process waiting
+// to be reaped included.
+func processExited(pid int) bool {
+	if err := syscall.Kill(pid, syscall.Signal(0)); err == syscall.ESRCH {
+		return true
+	}
+
+	fields, err := processStatFields(pid)
+	if err != nil {
+		return true
+	}
+
+	return len(fields) > 0 && (fields[0] == "Z" || fields[0] == "X")
+}
+
+// waitProcessExit waits for a process to exit, timeout at most.
+func waitProcessExit(pid int, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for !processExited(pid) {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %v", timeout)
+		}
+		time.Sleep(shutdownPollInterval)
+	}
+	return nil
+}
+
+// shutdownVM stops the VM of the sandbox with its graceful shutdown
+// stages, once the agent destroyed the sandbox. It returns a
+// ShutdownEscalationError when the VM is stopped by a later stage than the
+// first one.
+func (s *Sandbox) shutdownVM() error {
+	timeouts := s.config.ShutdownTimeouts
+
+	pids := s.hypervisor.getPids()
+	if len(pids) == 0 || pids[0] <= 0 {
+		return s.hypervisor.stopSandbox()
+	}
+	pid := pids[0]
+
+	var failures []ShutdownStageFailure
+	stopped := func(stage ShutdownStage) error {
+		s.Logger().WithField("stage", stage).Info("VM stopped")
+
+		if len(failures) == 0 {
+			return nil
+		}
+		return &ShutdownEscalationError{
+			Stage:    stage,
+			Failures: failures,
+		}
+	}
+
+	type gracefulStage struct {
+		stage   ShutdownStage
+		timeout time.Duration
+		start   func() error
+	}
+
+	for _, g := range []gracefulStage{
+		// The agent powers the guest off once the sandbox is destroyed.
+		{ShutdownStageGuest, timeouts.Guest, nil},
+		{ShutdownStageACPI, timeouts.ACPI, s.hypervisor.powerdownSandbox},
+	} {
+		if g.timeout <= 0 {
+			continue
+		}
+
+		var err error
+		if g.start != nil && !processExited(pid) {
+			err = g.start()
+		}
+		if err == nil {
+			err = waitProcessExit(pid, g.timeout)
+		}
+		if err == nil {
+			// The hypervisor is stopped for its cleanup.
+			if err := s.hypervisor.stopSandbox(); err != nil {
+				s.Logger().WithError(err).Debug("Hypervisor cleanup after the guest powered off failed")
+			}
+			return stopped(g.stage)
+		}
+
+		s.Logger().WithError(err).WithField("stage", g.stage).Warn("Shutdown stage did not stop the VM")
+		failures = append(failures, ShutdownStageFailure{Stage: g.stage, Err: err})
+	}
+
+	quitTimeout := timeouts.Quit
+	if quitTimeout == 0 {
+		quitTimeout = defaultShutdownQuitTimeout
+	}
+
+	// The hypervisor may fail to be asked to exit as it is exiting.
+	err := s.hypervisor.stopSandbox()
+	if err == nil || processExited(pid) {
+		if err = waitProcessExit(pid, quitTimeout); err == nil {
+			return stopped(ShutdownStageQuit)
+		}
+	}
+
+	s.Logger().WithError(err).WithField("stage", ShutdownStageQuit).Warn("Shutdown stage did not stop the VM")
+	failures = append(failures, ShutdownStageFailure{Stage: ShutdownStageQuit, Err: err})
+
+	if err := syscall.Kill(pid, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+		return fmt.Errorf("Could not kill the hypervisor: %v", err)
+	}
+
+	return stopped(ShutdownStageKill)
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// shutdownHypervisor runs a process standing for the hypervisor, which
+// exits at the shutdown stages it is set to stop at.
+type shutdownHypervisor struct {
+	mockHypervisor
+	cmd          *exec.Cmd
+	powerdown    bool
+	powerdownErr error
+	quit         bool
+	powerdowns   int
+}
+
+func newShutdownHypervisor(t *testing.T) *shutdownHypervisor {
+	cmd := exec.Command("sleep", "60")
+	assert.NoError(t, cmd.Start())
+
+	return &shutdownHypervisor{cmd: cmd}
+}
+
+func (h *shutdownHypervisor) getPids() []int {
+	return []int{h.cmd.Process.Pid}
+}
+
+func (h *shutdownHypervisor) powerdownSandbox() error {
+	h.powerdowns++
+	if h.powerdownErr != nil {
+		return h.powerdownErr
+	}
+	if h.powerdown {
+		return h.cmd.Process.Kill()
+	}
+	return nil
+}
+
+func (h *shutdownHypervisor) stopSandbox() error {
+	if h.quit {
+		return h.cmd.Process.Kill()
+	}
+	return nil
+}
+
+func (h *shutdownHypervisor) reap() {
+	h.cmd.Process.Kill()
+	h.cmd.Wait()
+}
+
+func TestShutdownTimeouts(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(ShutdownTimeouts{}.enabled())
+	assert.False(ShutdownTimeouts{Quit: time.Second}.enabled())
+	assert.True(ShutdownTimeouts{ACPI: time.Second}.enabled())
+
+	assert.NoError(ShutdownTimeouts{Guest: time.Second}.validate())
+	err := configFieldError("ShutdownTimeouts", ShutdownTimeouts{Quit: -time.Second}.validate())
+	assert.Equal("ShutdownTimeouts.Quit", err.Field)
+
+	assert.Empty(ShutdownTimeouts{ACPI: time.Second}.kernelParams())
+	assert.Equal([]Param{{Key: agentPowerOffParam}}, ShutdownTimeouts{Guest: time.Second}.kernelParams())
+}
+
+func TestProcessExited(t *testing.T) {
+	assert := assert.New(t)
+
+	cmd := exec.Command("sleep", "60")
+	assert.NoError(cmd.Start())
+	pid := cmd.Process.Pid
+
+	assert.False(processExited(pid))
+	assert.Error(waitProcessExit(pid, 10*time.Millisecond))
+
+	// A zombie process exited already.
+	assert.NoError(cmd.Process.Signal(syscall.SIGKILL))
+	assert.NoError(waitProcessExit(pid, time.Second))
+	assert.True(processExited(pid))
+
+	cmd.Wait()
+	assert.True(processExited(pid))
+}
+
+func TestSandboxShutdownVM(t *testing.T) {
+	assert := assert.New(t)
+
+	// The guest powers off at the ACPI stage.
+	h := newShutdownHypervisor(t)
+	defer h.reap()
+	h.powerdown = true
+
+	s := &Sandbox{
+		hypervisor: h,
+		config: &SandboxConfig{
+			ShutdownTimeouts: ShutdownTimeouts{ACPI: time.Second},
+		},
+	}
+	assert.NoError(s.shutdownVM())
+	assert.Equal(1, h.powerdowns)
+
+	// The guest does not power off, and the hypervisor exits once asked
+	// to.
+	h = newShutdownHypervisor(t)
+	defer h.reap()
+	h.quit = true
+
+	s.hypervisor = h
+	s.config.ShutdownTimeouts = ShutdownTimeouts{
+		Guest: 10 * time.Millisecond,
+		ACPI:  10 * time.Millisecond,
+	}
+	err := s.shutdownVM()
+	assert.Error(err)
+	escalation, ok := err.(*ShutdownEscalationError)
+	assert.True(ok)
+	assert.Equal(ShutdownStageQuit, escalation.Stage)
+	assert.Len(escalation.Failures, 2)
+	assert.Equal(ShutdownStageGuest, escalation.Failures[0].Stage)
+	assert.Equal(ShutdownStageACPI, escalation.Failures[1].Stage)
+
+	// Neither the guest nor the hypervisor stop, and the hypervisor is
+	// killed.
+	h = newShutdownHypervisor(t)
+	defer h.reap()
+
+	s.hypervisor = h
+	s.config.ShutdownTimeouts = ShutdownTimeouts{
+		ACPI: 10 * time.Millisecond,
+		Quit: 10 * time.Millisecond,
+	}
+	err = s.shutdownVM()
+	escalation, ok = err.(*ShutdownEscalationError)
+	assert.True(ok)
+	assert.Equal(ShutdownStageKill, escalation.Stage)
+	assert.Len(escalation.Failures, 2)
+	assert.NoError(waitProcessExit(h.cmd.Process.Pid, time.Second))
+
+	// A failing ACPI power down escalates right away.
+	h = newShutdownHypervisor(t)
+	defer h.reap()
+	h.powerdownErr = errors.New("ACPI power down is not supported")
+	h.quit = true
+
+	s.hypervisor = h
+	s.config.ShutdownTimeouts = ShutdownTimeouts{ACPI: time.Hour}
+	err = s.shutdownVM()
+	escalation, ok = err.(*ShutdownEscalationError)
+	assert.True(ok)
+	assert.Equal(ShutdownStageQuit, escalation.Stage)
+	assert.Equal(h.powerdownErr, escalation.Failures[0].Err)
+}
+
+func TestSandboxStopVMEscalation(t *testing.T) {
+	assert := assert.New(t)
+
+	// The hypervisor exits once asked to, after the ACPI stage timed out.
+	h := newShutdownHypervisor(t)
+	defer h.reap()
+	h.quit = true
+
+	s := &Sandbox{
+		id:         "test-stop-vm-escalation",
+		ctx:        context.Background(),
+		hypervisor: h,
+		agent:      &mockAgent{},
+		events:     newSandboxEvents(""),
+		config: &SandboxConfig{
+			ShutdownTimeouts: ShutdownTimeouts{ACPI: 10 * time.Millisecond},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := s.watchEvents(ctx)
+	assert.NoError(err)
+
+	// The VM is stopped, the escalation being published rather than
+	// returned.
+	assert.NoError(s.stopVM(true))
+
+	event := <-events
+	assert.Equal(SandboxEventShutdownEscalation, event.Type)
+	escalation, ok := event.Err.(*ShutdownEscalationError)
+	assert.True(ok)
+	assert.Equal(ShutdownStageQuit, escalation.Stage)
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+// snapshotsDir is the directory, in the run storage of a sandbox, of its
+// stored periodic snapshots.
+const snapshotsDir = "snapshots"
+
+// SnapshotPolicy is the policy of the periodic snapshots of a cloneable
+// sandbox, stored for the sandbox to be cloned as it was then.
+type SnapshotPolicy struct {
+	// Interval is the time between two snapshots. Periodic snapshots
+	// are disabled if 0.
+	Interval time.Duration
+
+	// Retention is the number of snapshots kept, the oldest ones being
+	// removed.
+	Retention uint
+}
+
+func (p SnapshotPolicy) enabled() bool {
+	return p.Interval > 0
+}
+
+func (p SnapshotPolicy) validate() error {
+	if p.Interval < 0 {
+		return newConfigFieldError("Interval", fmt.Sprintf("Invalid snapshot interval %v", p.Interval))
+	}
+
+	if p.enabled() && p.Retention == 0 {
+		return newConfigFieldError("Retention", "Periodic snapshots require a retention")
+	}
+
+	return nil
+}
+
+// snapshotter takes the periodic snapshots of a sandbox.
+type snapshotter struct {
+	sandbox *Sandbox
+	store   *snapshotStore
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+func (s *Sandbox) snapshotStore() *snapshotStore {
+	return newSnapshotStore(filepath.Join(s.newStore.RunStoragePath(), s.id, snapshotsDir))
+}
+
+// startPeriodicSnapshots starts taking the periodic snapshots of the
+// sandbox, if enabled.
+func (s *Sandbox) startPeriodicSnapshots() {
+	policy := s.config.PeriodicSnapshots
+	if !policy.enabled() || s.snapshotter != nil {
+		return
+	}
+
+	sn := &snapshotter{
+		sandbox: s,
+		store:   s.snapshotStore(),
+		stopCh:  make(chan struct{}),
+	}
+	s.snapshotter = sn
+
+	sn.wg.Add(1)
+	go func() {
+		defer sn.wg.Done()
+
+		tick := time.NewTicker(policy.Interval)
+		defer tick.Stop()
+
+		for {
+			select {
+			case <-sn.stopCh:
+				return
+			case <-tick.C:
+				if err := sn.takeSnapshot(int(policy.Retention)); err != nil {
+					s.Logger().WithError(err).Warn("Periodic snapshot failed")
+				}
+			}
+		}
+	}()
+}
+
+// stopPeriodicSnapshots stops taking the periodic snapshots of the
+// sandbox, once the snapshot being taken, if any, is stored.
+func (s *Sandbox) stopPeriodicSnapshots() {
+	if s.snapshotter == nil {
+		return
+	}
+
+	close(s.snapshotter.stopCh)
+	s.snapshotter.wg.Wait()
+	s.snapshotter = nil
+}
+
+// takeSnapshot snapshots the sandbox to a temporary directory, the VM
+// being paused meanwhile only, stores the snapshot and applies the
+// retention.
+func (sn *snapshotter) takeSnapshot(retention int) error {
+	s := sn.sandbox
+
+	// Nothing changes while paused.
+	if s.state.State != types.StateRunning {
+		return nil
+	}
+
+	dir, err := ioutil.TempDir(filepath.Join(s.newStore.RunStoragePath(), s.id), "snapshot-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	t := time.Now()
+	if err := s.snapshot(dir); err != nil {
+		return err
+	}
+
+	name, err := sn.store.save(dir, t)
+	if err != nil {
+		return err
+	}
+
+	s.Logger().WithField("snapshot", name).Info("Sandbox snapshot stored")
+
+	return sn.store.prune(retention)
+}
+
+// cloneSnapshot creates and starts a sandbox running the guest of the
+// sandbox as it was when the stored snapshot was taken.
+func (s *Sandbox) cloneSnapshot(ctx context.Context, name string, sandboxConfig SandboxConfig) (*Sandbox, error) {
+	if err := s.checkCloneID(sandboxConfig.ID); err != nil {
+		return nil, err
+	}
+
+	dir := cloneStoragePath(s.newStore, sandboxConfig.ID)
+	if err := s.snapshotStore().restore(name, dir); err != nil {
+		os.RemoveAll(filepath.Dir(dir))
+		return nil, fmt.Errorf("Could not restore snapshot %s of sandbox %s: %v", name, s.id, err)
+	}
+
+	return s.createClone(ctx, sandboxConfig, dir)
+}
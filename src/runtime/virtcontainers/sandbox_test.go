@@ -16,6 +16,7 @@ import (
 	"sync"
 	"syscall"
 	"testing"
+	"time"
 
 	ktu "github.com/kata-containers/kata-containers/src/runtime/pkg/katatestutils"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
@@ -28,6 +29,8 @@ import (
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
 )
 
 // dirMode is the permission bits used for creating a directory
@@ -1027,6 +1030,13 @@ func TestStatusSandbox(t *testing.T) {
 	defer cleanUp()
 
 	s.Status()
+
+	// The hotplug plan is the one the VM was created with, even once
+	// containers are added.
+	plan := s.Status().HotplugPlan
+	assert.Equal(t, s.hotplugPlan, plan)
+	s.config.Containers = append(s.config.Containers, ContainerConfig{DeviceInfos: []config.DeviceInfo{{HostPath: "/dev/vfio/1"}}})
+	assert.Equal(t, plan, s.Status().HotplugPlan)
 }
 
 func TestEnterContainer(t *testing.T) {
@@ -1383,6 +1393,121 @@ func TestPreAddDevice(t *testing.T) {
 		"ignoreMounts should contain nothing because it only contains a block device")
 }
 
+func TestSandboxRemoveDevice(t *testing.T) {
+	assert := assert.New(t)
+
+	defer cleanUp()
+	s, err := testCreateSandbox(t,
+		testSandboxID,
+		MockHypervisor,
+		newHypervisorConfig(nil, nil),
+		NetworkConfig{},
+		[]ContainerConfig{newTestContainerConfigNoop("cont-00001")},
+		nil)
+	assert.NoError(err)
+
+	dev, err := s.AddDevice(config.DeviceInfo{
+		HostPath:      "/dev/hda",
+		ContainerPath: "/dev/hda",
+		DevType:       "b",
+	})
+	assert.NoError(err)
+
+	// A device used by a container cannot be removed
+	c := s.containers["cont-00001"]
+	c.devices = []ContainerDevice{{ID: dev.DeviceID()}}
+	assert.Error(s.RemoveDevice(dev.DeviceID()))
+	assert.NotNil(s.devManager.GetDeviceByID(dev.DeviceID()))
+
+	c.devices = nil
+	assert.NoError(s.RemoveDevice(dev.DeviceID()))
+	assert.Nil(s.devManager.GetDeviceByID(dev.DeviceID()))
+
+	assert.Equal(manager.ErrDeviceNotExist, s.RemoveDevice(dev.DeviceID()))
+}
+
+// unplugHypervisor is a mock hypervisor failing to hot unplug the VFIO
+// device failID.
+type unplugHypervisor struct {
+	mockHypervisor
+	failID  string
+	plugged map[string]bool
+}
+
+func (h *unplugHypervisor) hotplugAddDevice(devInfo interface{}, devType deviceType) (interface{}, error) {
+	h.plugged[devInfo.(*config.VFIODev).ID] = true
+	return nil, nil
+}
+
+func (h *unplugHypervisor) hotplugRemoveDevice(devInfo interface{}, devType deviceType) (interface{}, error) {
+	dev := devInfo.(*config.VFIODev)
+	if dev.ID == h.failID {
+		return nil, fmt.Errorf("device busy")
+	}
+	h.plugged[dev.ID] = false
+	return nil, nil
+}
+
+func TestSandboxHotplugRemoveVFIOGroupRollback(t *testing.T) {
+	assert := assert.New(t)
+
+	h := &unplugHypervisor{failID: "dev2", plugged: map[string]bool{}}
+	s := &Sandbox{
+		id:                "test-unplug",
+		hypervisor:        h,
+		config:            &SandboxConfig{},
+		hotpluggedDevices: map[string]struct{}{"group": {}},
+	}
+
+	device := drivers.NewVFIODevice(&config.DeviceInfo{ID: "group"})
+	device.VfioDevs = []*config.VFIODev{{ID: "dev1"}, {ID: "dev2"}}
+	assert.NoError(s.HotplugAddDevice(device, config.DeviceVFIO))
+
+	// The unplugged devices of the group are plugged back
+	assert.Error(s.HotplugRemoveDevice(device, config.DeviceVFIO))
+	assert.True(h.plugged["dev1"])
+	assert.True(h.plugged["dev2"])
+	assert.Contains(s.hotpluggedDevices, "group")
+
+	h.failID = ""
+	assert.NoError(s.HotplugRemoveDevice(device, config.DeviceVFIO))
+	assert.False(h.plugged["dev1"])
+	assert.False(h.plugged["dev2"])
+	assert.NotContains(s.hotpluggedDevices, "group")
+}
+
+// noHotplugHypervisor is a mock hypervisor which can neither hotplug VFIO
+// devices nor hot unplug block devices.
+type noHotplugHypervisor struct {
+	unplugHypervisor
+}
+
+func (h *noHotplugHypervisor) capabilities() types.Capabilities {
+	return types.Capabilities{}
+}
+
+func TestSandboxHotplugUnsupported(t *testing.T) {
+	assert := assert.New(t)
+
+	h := &noHotplugHypervisor{unplugHypervisor{plugged: map[string]bool{}}}
+	s := &Sandbox{
+		id:                "test-unsupported",
+		hypervisor:        h,
+		config:            &SandboxConfig{},
+		hotpluggedDevices: map[string]struct{}{},
+	}
+
+	device := drivers.NewVFIODevice(&config.DeviceInfo{ID: "group"})
+	device.VfioDevs = []*config.VFIODev{{ID: "dev1"}}
+	assert.Error(s.HotplugAddDevice(device, config.DeviceVFIO))
+	assert.False(h.plugged["dev1"])
+
+	// The block device stays in the VM until it stops
+	block := drivers.NewBlockDevice(&config.DeviceInfo{ID: "block"})
+	block.BlockDrive = &config.BlockDrive{ID: "drive"}
+	assert.NoError(s.HotplugRemoveDevice(block, config.DeviceBlock))
+}
+
 func TestGetNetNs(t *testing.T) {
 	s := Sandbox{}
 
@@ -1522,6 +1647,138 @@ func TestSandboxUpdateResources(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// onlineFailAgent is a mock agent failing to online hotplugged resources.
+type onlineFailAgent struct {
+	mockAgent
+}
+
+func (n *onlineFailAgent) onlineCPUMem(cpus uint32, cpuOnly bool) error {
+	return fmt.Errorf("resources not onlined")
+}
+
+func TestSandboxUpdateResourcesOnlineFailure(t *testing.T) {
+	hConfig := newHypervisorConfig(nil, nil)
+
+	defer cleanUp()
+	s, err := testCreateSandbox(t,
+		testSandboxID,
+		MockHypervisor,
+		hConfig,
+		NetworkConfig{},
+		[]ContainerConfig{newTestContainerConfigNoop("cont-00001")},
+		nil)
+	assert.NoError(t, err)
+
+	s.agent = &onlineFailAgent{}
+
+	err = s.updateResources()
+	assert.Error(t, err)
+
+	// The failed resize is not recorded, so that the next one is retried.
+	assert.Equal(t, resizeState{}, s.resize)
+}
+
+// memoryUnplugHypervisor is a mock hypervisor able to unplug memory.
+type memoryUnplugHypervisor struct {
+	mockHypervisor
+}
+
+func (h *memoryUnplugHypervisor) capabilities() types.Capabilities {
+	var caps types.Capabilities
+	caps.SetMemoryHotUnplugSupport()
+	return caps
+}
+
+func TestSandboxResizeMemory(t *testing.T) {
+	assert := assert.New(t)
+
+	defer cleanUp()
+	s, err := testCreateSandbox(t,
+		testSandboxID,
+		MockHypervisor,
+		newHypervisorConfig(nil, nil),
+		NetworkConfig{},
+		[]ContainerConfig{newTestContainerConfigNoop("cont-00001")},
+		nil)
+	assert.NoError(err)
+
+	// The sandbox must be running
+	assert.Error(s.ResizeMemory(2048))
+
+	s.state.State = types.StateRunning
+	memLimit := int64(512 * 1024 * 1024)
+	s.config.Containers[0].Resources.Memory = &specs.LinuxMemory{Limit: &memLimit}
+
+	// Below the memory limits of the containers
+	assert.Error(s.ResizeMemory(256))
+
+	assert.NoError(s.ResizeMemory(2048))
+	assert.Equal(uint32(1536), s.config.HypervisorConfig.MemoryAdjustmentMB)
+
+	// Shrinks need memory hot unplug
+	assert.Error(s.ResizeMemory(1024))
+	assert.Equal(uint32(1536), s.config.HypervisorConfig.MemoryAdjustmentMB)
+
+	s.hypervisor = &memoryUnplugHypervisor{}
+	assert.NoError(s.ResizeMemory(1024))
+	assert.Equal(uint32(512), s.config.HypervisorConfig.MemoryAdjustmentMB)
+
+	// Above the memory ceiling
+	s.config.ResourceCeilings.MaxMemoryMB = 1536
+	assert.Error(s.ResizeMemory(4096))
+	assert.Equal(uint32(512), s.config.HypervisorConfig.MemoryAdjustmentMB)
+}
+
+// missingContainerAgent is a mock agent that lost one container, and
+// fails to report on another one.
+type missingContainerAgent struct {
+	mockAgent
+	missing string
+	failing string
+}
+
+func (n *missingContainerAgent) statsContainer(sandbox *Sandbox, c Container) (*ContainerStats, error) {
+	switch c.id {
+	case n.missing:
+		return nil, grpcStatus.Error(codes.NotFound, "invalid container id")
+	case n.failing:
+		return nil, grpcStatus.Error(codes.DeadlineExceeded, "timeout")
+	}
+	return &ContainerStats{}, nil
+}
+
+func TestSandboxReconcile(t *testing.T) {
+	assert := assert.New(t)
+
+	defer cleanUp()
+	s, err := testCreateSandbox(t,
+		testSandboxID,
+		MockHypervisor,
+		newHypervisorConfig(nil, nil),
+		NetworkConfig{},
+		[]ContainerConfig{
+			newTestContainerConfigNoop("cont-00001"),
+			newTestContainerConfigNoop("cont-00002"),
+			newTestContainerConfigNoop("cont-00003"),
+		},
+		nil)
+	assert.NoError(err)
+
+	// The sandbox must be running
+	assert.Error(s.Reconcile())
+
+	s.state.State = types.StateRunning
+	s.containers["cont-00001"].state.State = types.StateRunning
+	s.containers["cont-00002"].state.State = types.StateRunning
+	s.containers["cont-00003"].state.State = types.StateRunning
+	s.agent = &missingContainerAgent{missing: "cont-00002", failing: "cont-00003"}
+
+	assert.NoError(s.Reconcile())
+	assert.Equal(types.StateRunning, s.containers["cont-00001"].state.State)
+	assert.Equal(types.StateStopped, s.containers["cont-00002"].state.State)
+	assert.Equal(types.StateRunning, s.containers["cont-00003"].state.State)
+}
+
 func TestSandboxExperimentalFeature(t *testing.T) {
 	testFeature := exp.Feature{
 		Name:        "mock",
@@ -1628,3 +1885,17 @@ func TestSandbox_SetupSandboxCgroup(t *testing.T) {
 		})
 	}
 }
+
+func TestGuestKernelParams(t *testing.T) {
+	assert := assert.New(t)
+
+	config := &SandboxConfig{}
+	assert.Empty(config.guestKernelParams())
+
+	config.NetworkConfig.DHCPInterfaces = DHCPInterfaces{"eth0"}
+	config.ShutdownTimeouts = ShutdownTimeouts{Guest: time.Second}
+	assert.Equal([]Param{
+		{Key: agentDHCPParam, Value: "eth0"},
+		{Key: agentPowerOffParam},
+	}, config.guestKernelParams())
+}
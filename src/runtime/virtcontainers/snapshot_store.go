@@ -0,0 +1,340 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	snapshotBlocksDir   = "blocks"
+	snapshotManifestExt = ".json"
+
+	// snapshotBlockSize is the size of the memory blocks stored once
+	// whatever the number of snapshots they are part of.
+	snapshotBlockSize = 256 << 10
+
+	snapshotNameFormat = "20060102-150405.000000000"
+)
+
+// snapshotManifest describes a stored snapshot.
+type snapshotManifest struct {
+	Time       time.Time
+	MemorySize int64
+
+	// State is the digest of the device state.
+	State string
+
+	// Blocks are the digests of the memory blocks, empty for the blocks
+	// the guest never wrote.
+	Blocks []string
+}
+
+// SnapshotInfo describes a stored snapshot of a sandbox.
+type SnapshotInfo struct {
+	Name string
+	Time time.Time
+}
+
+// snapshotStore stores the snapshots of a sandbox, the guest memory being
+// split in blocks stored once, named after their digest, that the manifest
+// of each snapshot references: the memory that did not change between two
+// snapshots takes no space in the second one.
+type snapshotStore struct {
+	dir string
+}
+
+func newSnapshotStore(dir string) *snapshotStore {
+	return &snapshotStore{dir: dir}
+}
+
+func (st *snapshotStore) blockPath(digest string) string {
+	return filepath.Join(st.dir, snapshotBlocksDir, digest)
+}
+
+func (st *snapshotStore) manifestPath(name string) string {
+	return filepath.Join(st.dir, name+snapshotManifestExt)
+}
+
+// putBlock stores data, unless a block of the same digest is stored
+// already, and returns its digest.
+func (st *snapshotStore) putBlock(data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+
+	path := st.blockPath(digest)
+	if _, err := os.Stat(path); err == nil {
+		return digest, nil
+	}
+
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return "", err
+	}
+
+	return digest, os.Rename(tmp, path)
+}
+
+// memoryBlocks stores the memory blocks of the file at path, skipping its
+// holes and the blocks of zeros.
+func (st *snapshotStore) memoryBlocks(path string) ([]string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	size := info.Size()
+	blocks := make([]string, (size+snapshotBlockSize-1)/snapshotBlockSize)
+	zero := make([]byte, snapshotBlockSize)
+	buf := make([]byte, snapshotBlockSize)
+
+	var dataStart, dataEnd int64
+	for i := range blocks {
+		off := int64(i) * snapshotBlockSize
+		if off >= dataEnd {
+			dataStart, err = f.Seek(off, seekData)
+			if err != nil {
+				// ENXIO: no data past the offset
+				if pathErr, ok := err.(*os.PathError); ok && pathErr.Err == syscall.ENXIO {
+					break
+				}
+				return nil, 0, err
+			}
+
+			if dataEnd, err = f.Seek(dataStart, seekHole); err != nil {
+				return nil, 0, err
+			}
+		}
+
+		if off+snapshotBlockSize <= dataStart {
+			continue
+		}
+
+		n, err := f.ReadAt(buf, off)
+		if err != nil && err != io.EOF {
+			return nil, 0, err
+		}
+
+		if bytes.Equal(buf[:n], zero[:n]) {
+			continue
+		}
+
+		if blocks[i], err = st.putBlock(buf[:n]); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return blocks, size, nil
+}
+
+// save stores the snapshot of the memory and device state files in dir,
+// and returns its name.
+func (st *snapshotStore) save(dir string, t time.Time) (string, error) {
+	if err := os.MkdirAll(filepath.Join(st.dir, snapshotBlocksDir), DirMode); err != nil {
+		return "", err
+	}
+
+	blocks, size, err := st.memoryBlocks(filepath.Join(dir, cloneMemoryFile))
+	if err != nil {
+		return "", err
+	}
+
+	state, err := ioutil.ReadFile(filepath.Join(dir, cloneStateFile))
+	if err != nil {
+		return "", err
+	}
+
+	stateDigest, err := st.putBlock(state)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(snapshotManifest{
+		Time:       t,
+		MemorySize: size,
+		State:      stateDigest,
+		Blocks:     blocks,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// The manifest is written last, a snapshot being listed once complete.
+	name := t.UTC().Format(snapshotNameFormat)
+	tmp := st.manifestPath(name) + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return "", err
+	}
+
+	return name, os.Rename(tmp, st.manifestPath(name))
+}
+
+func (st *snapshotStore) manifest(name string) (*snapshotManifest, error) {
+	if _, err := time.Parse(snapshotNameFormat, name); err != nil {
+		return nil, fmt.Errorf("Invalid snapshot name %q", name)
+	}
+
+	data, err := ioutil.ReadFile(st.manifestPath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("Snapshot %q not found", name)
+		}
+		return nil, err
+	}
+
+	var m snapshotManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// list returns the stored snapshots, the oldest first.
+func (st *snapshotStore) list() ([]SnapshotInfo, error) {
+	entries, err := ioutil.ReadDir(st.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var snapshots []SnapshotInfo
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), snapshotManifestExt) {
+			continue
+		}
+
+		name := strings.TrimSuffix(e.Name(), snapshotManifestExt)
+		t, err := time.Parse(snapshotNameFormat, name)
+		if err != nil {
+			continue
+		}
+
+		snapshots = append(snapshots, SnapshotInfo{Name: name, Time: t})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.Before(snapshots[j].Time)
+	})
+
+	return snapshots, nil
+}
+
+// prune removes the oldest snapshots but the last retention ones, and the
+// blocks no snapshot left references.
+func (st *snapshotStore) prune(retention int) error {
+	snapshots, err := st.list()
+	if err != nil {
+		return err
+	}
+
+	for len(snapshots) > retention {
+		if err := os.Remove(st.manifestPath(snapshots[0].Name)); err != nil {
+			return err
+		}
+		snapshots = snapshots[1:]
+	}
+
+	referenced := make(map[string]bool)
+	for _, snapshot := range snapshots {
+		m, err := st.manifest(snapshot.Name)
+		if err != nil {
+			return err
+		}
+
+		referenced[m.State] = true
+		for _, digest := range m.Blocks {
+			referenced[digest] = true
+		}
+	}
+
+	blocks, err := ioutil.ReadDir(filepath.Join(st.dir, snapshotBlocksDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, b := range blocks {
+		if !referenced[b.Name()] {
+			if err := os.Remove(st.blockPath(b.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// restore writes the memory and device state files of a stored snapshot to
+// dir, the memory the guest never wrote being left as holes.
+func (st *snapshotStore) restore(name, dir string) error {
+	m, err := st.manifest(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, DirMode); err != nil {
+		return err
+	}
+
+	state, err := ioutil.ReadFile(st.blockPath(m.State))
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, cloneStateFile), state, 0600); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, cloneMemoryFile), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := f.Truncate(m.MemorySize); err != nil {
+		return err
+	}
+
+	for i, digest := range m.Blocks {
+		if digest == "" {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(st.blockPath(digest))
+		if err != nil {
+			return err
+		}
+
+		if _, err := f.WriteAt(data, int64(i)*snapshotBlockSize); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestSnapshot(assert *assert.Assertions, dir string, pages map[int64]string) {
+	assert.NoError(os.MkdirAll(dir, DirMode))
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, cloneStateFile), []byte("state"), 0600))
+
+	f, err := os.Create(filepath.Join(dir, cloneMemoryFile))
+	assert.NoError(err)
+	defer f.Close()
+
+	assert.NoError(f.Truncate(8 * snapshotBlockSize))
+	for off, data := range pages {
+		_, err := f.WriteAt([]byte(data), off)
+		assert.NoError(err)
+	}
+}
+
+func countBlocks(assert *assert.Assertions, st *snapshotStore) int {
+	blocks, err := ioutil.ReadDir(filepath.Join(st.dir, snapshotBlocksDir))
+	assert.NoError(err)
+	return len(blocks)
+}
+
+func TestSnapshotStore(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "snapshots")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	st := newSnapshotStore(filepath.Join(dir, snapshotsDir))
+	src := filepath.Join(dir, "src")
+
+	snapshots, err := st.list()
+	assert.NoError(err)
+	assert.Empty(snapshots)
+
+	t1 := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	writeTestSnapshot(assert, src, map[int64]string{0: "kernel", 3 * snapshotBlockSize: "heap"})
+	first, err := st.save(src, t1)
+	assert.NoError(err)
+
+	// The state and the two written blocks
+	assert.Equal(3, countBlocks(assert, st))
+
+	// Only the changed block is stored again
+	t2 := t1.Add(time.Minute)
+	writeTestSnapshot(assert, src, map[int64]string{0: "kernel", 3 * snapshotBlockSize: "heap changed"})
+	second, err := st.save(src, t2)
+	assert.NoError(err)
+	assert.Equal(4, countBlocks(assert, st))
+
+	snapshots, err = st.list()
+	assert.NoError(err)
+	assert.Equal([]SnapshotInfo{{Name: first, Time: t1}, {Name: second, Time: t2}}, snapshots)
+
+	restored := filepath.Join(dir, "restored")
+	assert.NoError(st.restore(first, restored))
+
+	expected, err := ioutil.ReadFile(filepath.Join(dir, "src", cloneStateFile))
+	assert.NoError(err)
+	data, err := ioutil.ReadFile(filepath.Join(restored, cloneStateFile))
+	assert.NoError(err)
+	assert.Equal(expected, data)
+
+	memory, err := ioutil.ReadFile(filepath.Join(restored, cloneMemoryFile))
+	assert.NoError(err)
+	assert.Len(memory, 8*snapshotBlockSize)
+	assert.Equal("kernel", string(memory[:6]))
+	assert.Equal("heap\x00", string(memory[3*snapshotBlockSize:3*snapshotBlockSize+5]))
+
+	// The blocks of the removed snapshot only are removed
+	assert.NoError(st.prune(1))
+	assert.Equal(3, countBlocks(assert, st))
+
+	snapshots, err = st.list()
+	assert.NoError(err)
+	assert.Len(snapshots, 1)
+	assert.Equal(second, snapshots[0].Name)
+
+	assert.Error(st.restore(first, restored))
+	assert.Error(st.restore("../../etc", restored))
+}
+
+func TestSnapshotPolicyValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(SnapshotPolicy{}.validate())
+	assert.NoError(SnapshotPolicy{Interval: time.Hour, Retention: 3}.validate())
+
+	err := SnapshotPolicy{Interval: -time.Hour}.validate()
+	assert.Error(err)
+	assert.Equal("Interval", configFieldError("", err).Field)
+
+	err = SnapshotPolicy{Interval: time.Hour}.validate()
+	assert.Error(err)
+	assert.Equal("Retention", configFieldError("", err).Field)
+}
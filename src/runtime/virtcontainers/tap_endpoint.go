@@ -83,9 +83,10 @@ func (endpoint *TapEndpoint) Detach(netNsCreated bool, netNsPath string) error {
 }
 
 // HotAttach for the tap endpoint uses hot plug device
-func (endpoint *TapEndpoint) HotAttach(h hypervisor) error {
+func (endpoint *TapEndpoint) HotAttach(s *Sandbox) error {
+	h := s.hypervisor
 	networkLogger().Info("Hot attaching tap endpoint")
-	if err := tapNetwork(endpoint, h.hypervisorConfig().NumVCPUs, h.hypervisorConfig().DisableVhostNet); err != nil {
+	if err := tapNetwork(endpoint, networkQueues(h.hypervisorConfig()), h.hypervisorConfig().DisableVhostNet); err != nil {
 		networkLogger().WithError(err).Error("Error bridging tap ep")
 		return err
 	}
@@ -98,7 +99,8 @@ func (endpoint *TapEndpoint) HotAttach(h hypervisor) error {
 }
 
 // HotDetach for the tap endpoint uses hot pull device
-func (endpoint *TapEndpoint) HotDetach(h hypervisor, netNsCreated bool, netNsPath string) error {
+func (endpoint *TapEndpoint) HotDetach(s *Sandbox, netNsCreated bool, netNsPath string) error {
+	h := s.hypervisor
 	networkLogger().Info("Hot detaching tap endpoint")
 	if err := doNetNS(netNsPath, func(_ ns.NetNS) error {
 		return unTapNetwork(endpoint.TapInterface.TAPIface.Name)
@@ -136,20 +138,20 @@ func createTapNetworkEndpoint(idx int, ifName string) (*TapEndpoint, error) {
 	return endpoint, nil
 }
 
-func tapNetwork(endpoint *TapEndpoint, numCPUs uint32, disableVhostNet bool) error {
+func tapNetwork(endpoint *TapEndpoint, queues int, disableVhostNet bool) error {
 	netHandle, err := netlink.NewHandle()
 	if err != nil {
 		return err
 	}
 	defer netHandle.Delete()
 
-	tapLink, fds, err := createLink(netHandle, endpoint.TapInterface.TAPIface.Name, &netlink.Tuntap{}, int(numCPUs))
+	tapLink, fds, err := createLink(netHandle, endpoint.TapInterface.TAPIface.Name, &netlink.Tuntap{}, queues)
 	if err != nil {
 		return fmt.Errorf("Could not create TAP interface: %s", err)
 	}
 	endpoint.TapInterface.VMFds = fds
 	if !disableVhostNet {
-		vhostFds, err := createVhostFds(int(numCPUs))
+		vhostFds, err := createVhostFds(queues)
 		if err != nil {
 			return fmt.Errorf("Could not setup vhost fds %s : %s", endpoint.TapInterface.Name, err)
 		}
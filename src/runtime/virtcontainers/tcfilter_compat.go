@@ -0,0 +1,344 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cilium/ebpf"
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/safchain/ethtool"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// tcFilterOffloads are the offloads of the network interface and of the tap
+// which break the traffic redirected by tc filters: the checksums and the
+// segmentation left to a device are never done once the packets are
+// redirected to the other one.
+var tcFilterOffloads = []string{
+	"tx-checksum-ip-generic",
+	"tx-tcp-segmentation",
+	"tx-tcp-ecn-segmentation",
+	"tx-tcp6-segmentation",
+	"generic-receive-offload",
+}
+
+// tcFilterFixupPriority runs the fixup classifier before the redirect
+// filters, which get the priorities the kernel picks, from 0x8000 down.
+const tcFilterFixupPriority = 1
+
+// The netfilter state of the network namespace, which the current thread
+// is in, as opposed to the process.
+var (
+	ipTablesNamesPaths = []string{"/proc/thread-self/net/ip_tables_names", "/proc/thread-self/net/ip6_tables_names"}
+	conntrackCountPath = "/proc/sys/net/netfilter/nf_conntrack_count"
+)
+
+// TCFilterCompat is how the endpoints connected to the VM with tc filters
+// are made to work with the offloads of the network interfaces.
+type TCFilterCompat struct {
+	// KeepOffloads keeps the checksum and segmentation offloads of the
+	// network interface and of the tap, which are disabled otherwise.
+	KeepOffloads bool
+
+	// FixupProg is the path, in the BPF filesystem, of a pinned direct
+	// action classifier run on the traffic of the network interface and
+	// of the tap before it is redirected. It must return TC_ACT_UNSPEC
+	// for the traffic to be redirected.
+	FixupProg string
+}
+
+func (c TCFilterCompat) validate() error {
+	if c.FixupProg != "" && !filepath.IsAbs(c.FixupProg) {
+		return newConfigFieldError("FixupProg", fmt.Sprintf("tc filter fixup program %q must be an absolute path", c.FixupProg))
+	}
+
+	return nil
+}
+
+// apply sets the network interface and the tap of an endpoint connected
+// with tc filters up for the redirected traffic. It must be called in the
+// network namespace of the sandbox, once the endpoint is attached.
+func (c TCFilterCompat) apply(endpoint Endpoint) error {
+	pair := endpoint.NetworkPair()
+	if pair == nil || pair.NetInterworkingModel != NetXConnectTCFilterModel {
+		return nil
+	}
+
+	links := []string{pair.VirtIface.Name, pair.TAPIface.Name}
+
+	if !c.KeepOffloads {
+		for _, name := range links {
+			if err := disableOffloads(name, tcFilterOffloads); err != nil {
+				return fmt.Errorf("Could not disable the offloads of %s: %s", name, err)
+			}
+		}
+	}
+
+	if c.FixupProg != "" {
+		for _, name := range links {
+			if err := addFixupTCFilter(name, c.FixupProg); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// disableOffloads disables the offloads of a network interface, among
+// those it supports.
+func disableOffloads(name string, offloads []string) error {
+	ethHandle, err := ethtool.NewEthtool()
+	if err != nil {
+		return err
+	}
+	defer ethHandle.Close()
+
+	supported, err := ethHandle.FeatureNames(name)
+	if err != nil {
+		return err
+	}
+
+	features := make(map[string]bool)
+	for _, offload := range offloads {
+		if _, ok := supported[offload]; ok {
+			features[offload] = false
+		}
+	}
+
+	if len(features) == 0 {
+		return nil
+	}
+
+	networkLogger().WithField("link", name).WithField("offloads", features).Info("Disabling offloads")
+
+	return ethHandle.Change(name, features)
+}
+
+// addFixupTCFilter runs a pinned classifier on the ingress of a network
+// interface, before the redirect filter.
+//
+// This is equivalent to calling:
+// `tc filter add dev name parent ffff: protocol all pref 1 bpf object-pinned prog direct-action`
+func addFixupTCFilter(name, prog string) error {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return err
+	}
+
+	p, err := ebpf.LoadPinnedProgram(prog)
+	if err != nil {
+		return fmt.Errorf("Could not load tc filter fixup program %s: %s", prog, err)
+	}
+	// The filter holds its own reference to the program.
+	defer p.Close()
+
+	if p.ABI().Type != ebpf.SchedCLS {
+		return fmt.Errorf("tc filter fixup program %s is a %s program, not a classifier", prog, p.ABI().Type)
+	}
+
+	filter := &netlink.BpfFilter{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: link.Attrs().Index,
+			Parent:    netlink.MakeHandle(0xffff, 0),
+			Priority:  tcFilterFixupPriority,
+			Protocol:  unix.ETH_P_ALL,
+		},
+		Fd:           p.FD(),
+		Name:         filepath.Base(prog),
+		DirectAction: true,
+	}
+
+	if err := netlink.FilterAdd(filter); err != nil {
+		return fmt.Errorf("Failed to add fixup filter for %s : %s", name, err)
+	}
+
+	return nil
+}
+
+// NetworkIncompatibility is a setup of the network namespace of a sandbox
+// which the traffic of its endpoints does not go through as expected.
+type NetworkIncompatibility struct {
+	// Endpoint is the endpoint affected, none if all are.
+	Endpoint string `json:",omitempty"`
+	Link     string `json:",omitempty"`
+	Problem  string
+}
+
+// offloadIncompatibilities returns the problematic offloads enabled on a
+// network interface.
+func offloadIncompatibilities(features map[string]bool) []string {
+	var problems []string
+	for _, offload := range tcFilterOffloads {
+		if features[offload] {
+			problems = append(problems, fmt.Sprintf("offload %s is enabled, the packets redirected with it may be dropped", offload))
+		}
+	}
+
+	return problems
+}
+
+// netfilterIncompatibilities returns the netfilter setup of the network
+// namespace that the traffic redirected with tc filters bypasses.
+func netfilterIncompatibilities(tables []string, conntrackCount int) []string {
+	var problems []string
+	for _, table := range tables {
+		problems = append(problems, fmt.Sprintf("iptables table %s is in use, the traffic redirected with tc filters bypasses it", table))
+	}
+
+	if conntrackCount > 0 {
+		problems = append(problems, fmt.Sprintf("%d connections are tracked, the traffic redirected with tc filters is not", conntrackCount))
+	}
+
+	return problems
+}
+
+func netfilterTables() []string {
+	var tables []string
+	for _, path := range ipTablesNamesPaths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		tables = append(tables, strings.Fields(string(data))...)
+	}
+
+	sort.Strings(tables)
+
+	// The IPv4 and IPv6 tables have the same names.
+	var unique []string
+	for i, t := range tables {
+		if i == 0 || tables[i-1] != t {
+			unique = append(unique, t)
+		}
+	}
+
+	return unique
+}
+
+func conntrackCount() int {
+	data, err := ioutil.ReadFile(conntrackCountPath)
+	if err != nil {
+		return 0
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0
+	}
+
+	return count
+}
+
+// hasRedirectTCFilter tells if the ingress of a network interface is
+// redirected.
+func hasRedirectTCFilter(link netlink.Link) (bool, error) {
+	filters, err := netlink.FilterList(link, netlink.MakeHandle(0xffff, 0))
+	if err != nil {
+		return false, err
+	}
+
+	for _, f := range filters {
+		u32, ok := f.(*netlink.U32)
+		if !ok {
+			continue
+		}
+
+		for _, a := range u32.Actions {
+			if m, ok := a.(*netlink.MirredAction); ok && m.MirredAction == netlink.TCA_EGRESS_REDIR {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// tcFilterIncompatibilities returns what breaks the traffic of an endpoint
+// connected with tc filters.
+func tcFilterIncompatibilities(ethHandle *ethtool.Ethtool, endpoint Endpoint) []NetworkIncompatibility {
+	pair := endpoint.NetworkPair()
+
+	var found []NetworkIncompatibility
+	add := func(link, problem string) {
+		found = append(found, NetworkIncompatibility{Endpoint: endpoint.Name(), Link: link, Problem: problem})
+	}
+
+	mtu := -1
+	for _, name := range []string{pair.VirtIface.Name, pair.TAPIface.Name} {
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			add(name, err.Error())
+			continue
+		}
+
+		if mtu >= 0 && link.Attrs().MTU != mtu {
+			add(name, fmt.Sprintf("MTU %d differs from the MTU %d of %s", link.Attrs().MTU, mtu, pair.VirtIface.Name))
+		}
+		mtu = link.Attrs().MTU
+
+		if redirected, err := hasRedirectTCFilter(link); err != nil {
+			add(name, err.Error())
+		} else if !redirected {
+			add(name, "ingress is not redirected")
+		}
+
+		features, err := ethHandle.Features(name)
+		if err != nil {
+			add(name, err.Error())
+			continue
+		}
+		for _, problem := range offloadIncompatibilities(features) {
+			add(name, problem)
+		}
+	}
+
+	return found
+}
+
+// NetworkIncompatibilities returns the setup of the network namespace of
+// the sandbox that the traffic of its endpoints, as they are connected to
+// the VM, does not go through as expected.
+func (s *Sandbox) NetworkIncompatibilities() ([]NetworkIncompatibility, error) {
+	var tcFilterEndpoints []Endpoint
+	for _, endpoint := range s.networkNS.Endpoints {
+		if pair := endpoint.NetworkPair(); pair != nil && pair.NetInterworkingModel == NetXConnectTCFilterModel {
+			tcFilterEndpoints = append(tcFilterEndpoints, endpoint)
+		}
+	}
+
+	if len(tcFilterEndpoints) == 0 || s.networkNS.NetNsPath == "" {
+		return nil, nil
+	}
+
+	var found []NetworkIncompatibility
+	err := doNetNS(s.networkNS.NetNsPath, func(_ ns.NetNS) error {
+		for _, problem := range netfilterIncompatibilities(netfilterTables(), conntrackCount()) {
+			found = append(found, NetworkIncompatibility{Problem: problem})
+		}
+
+		ethHandle, err := ethtool.NewEthtool()
+		if err != nil {
+			return err
+		}
+		defer ethHandle.Close()
+
+		for _, endpoint := range tcFilterEndpoints {
+			found = append(found, tcFilterIncompatibilities(ethHandle, endpoint)...)
+		}
+
+		return nil
+	})
+
+	return found, err
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTCFilterCompatValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(TCFilterCompat{}.validate())
+	assert.NoError(TCFilterCompat{KeepOffloads: true, FixupProg: "/sys/fs/bpf/tc/kata-fixup"}.validate())
+
+	err := TCFilterCompat{FixupProg: "kata-fixup"}.validate()
+	assert.Error(err)
+	assert.Equal("NetworkConfig.TCFilterCompat.FixupProg", configFieldError("NetworkConfig.TCFilterCompat", err).Field)
+}
+
+func TestTCFilterCompatApplyOtherModels(t *testing.T) {
+	assert := assert.New(t)
+
+	// Only the endpoints connected with tc filters are set up
+	c := TCFilterCompat{FixupProg: "/sys/fs/bpf/tc/kata-fixup"}
+
+	macvtap, err := createVethNetworkEndpoint(0, "eth0", NetXConnectMacVtapModel)
+	assert.NoError(err)
+	assert.NoError(c.apply(macvtap))
+
+	assert.NoError(c.apply(&VhostUserEndpoint{SocketPath: "/tmp/vhost-user.sock"}))
+}
+
+func TestOffloadIncompatibilities(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(offloadIncompatibilities(map[string]bool{
+		"tx-checksum-ip-generic": false,
+		"tx-scatter-gather":      true,
+	}))
+
+	problems := offloadIncompatibilities(map[string]bool{
+		"tx-checksum-ip-generic":  true,
+		"generic-receive-offload": true,
+	})
+	assert.Len(problems, 2)
+	assert.Contains(problems[0], "tx-checksum-ip-generic")
+	assert.Contains(problems[1], "generic-receive-offload")
+}
+
+func TestNetfilterIncompatibilities(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(netfilterIncompatibilities(nil, 0))
+
+	problems := netfilterIncompatibilities([]string{"mangle", "nat"}, 12)
+	assert.Len(problems, 3)
+	assert.Contains(problems[1], "nat")
+	assert.Contains(problems[2], "12 connections")
+}
+
+func TestNetfilterState(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "netfilter")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	savedTablesPaths, savedConntrackPath := ipTablesNamesPaths, conntrackCountPath
+	defer func() {
+		ipTablesNamesPaths, conntrackCountPath = savedTablesPaths, savedConntrackPath
+	}()
+
+	ipTablesNamesPaths = []string{filepath.Join(dir, "ip_tables_names"), filepath.Join(dir, "ip6_tables_names")}
+	conntrackCountPath = filepath.Join(dir, "nf_conntrack_count")
+
+	// Without netfilter loaded
+	assert.Empty(netfilterTables())
+	assert.Equal(0, conntrackCount())
+
+	assert.NoError(ioutil.WriteFile(ipTablesNamesPaths[0], []byte("nat\nfilter\n"), 0644))
+	assert.NoError(ioutil.WriteFile(ipTablesNamesPaths[1], []byte("nat\n"), 0644))
+	assert.NoError(ioutil.WriteFile(conntrackCountPath, []byte("7\n"), 0644))
+
+	assert.Equal([]string{"filter", "nat"}, netfilterTables())
+	assert.Equal(7, conntrackCount())
+}
+
+func TestSandboxNetworkIncompatibilitiesNoTCFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	macvtap, err := createVethNetworkEndpoint(0, "eth0", NetXConnectMacVtapModel)
+	assert.NoError(err)
+
+	s := &Sandbox{networkNS: NetworkNamespace{
+		NetNsPath: "/var/run/netns/none",
+		Endpoints: []Endpoint{macvtap},
+	}}
+
+	found, err := s.NetworkIncompatibilities()
+	assert.NoError(err)
+	assert.Empty(found)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	agentNTPServersParam = "agent.ntp_servers"
+	agentNTPPoolsParam   = "agent.ntp_pools"
+	agentPTPKVMParam     = "agent.ptp_kvm"
+)
+
+// TimeSync are the time sources the agent configures in the guest at boot,
+// through systemd-timesyncd when systemd is the guest init, or chronyd,
+// whatever the time sources of the guest image.
+type TimeSync struct {
+	// Servers are the NTP servers, as host names or addresses.
+	Servers []string
+
+	// Pools are the NTP server pools.
+	Pools []string
+
+	// PTPKVM synchronizes the guest clock with the host clock through
+	// the ptp_kvm guest driver, without any network. chronyd only.
+	PTPKVM bool
+}
+
+func (t TimeSync) enabled() bool {
+	return len(t.Servers) > 0 || len(t.Pools) > 0 || t.PTPKVM
+}
+
+func (t TimeSync) validate() error {
+	if err := validateTimeSources("Servers", t.Servers); err != nil {
+		return err
+	}
+
+	return validateTimeSources("Pools", t.Pools)
+}
+
+func validateTimeSources(field string, sources []string) error {
+	for i, s := range sources {
+		// The sources are passed comma separated on the kernel command
+		// line.
+		if s == "" || strings.ContainsAny(s, ", \t\n\"") {
+			return newConfigFieldError(fmt.Sprintf("%s[%d]", field, i), fmt.Sprintf("Invalid time source %q", s))
+		}
+	}
+
+	return nil
+}
+
+// kernelParams returns the kernel parameters passing the time sources to
+// the agent.
+func (t TimeSync) kernelParams() []Param {
+	var params []Param
+
+	if len(t.Servers) > 0 {
+		params = append(params, Param{Key: agentNTPServersParam, Value: strings.Join(t.Servers, ",")})
+	}
+
+	if len(t.Pools) > 0 {
+		params = append(params, Param{Key: agentNTPPoolsParam, Value: strings.Join(t.Pools, ",")})
+	}
+
+	if t.PTPKVM {
+		params = append(params, Param{Key: agentPTPKVMParam})
+	}
+
+	return params
+}
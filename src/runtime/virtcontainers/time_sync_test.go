@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeSyncValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(TimeSync{}.validate())
+	assert.NoError(TimeSync{Servers: []string{"10.0.0.1", "ntp.example.com"}, Pools: []string{"pool.ntp.org"}}.validate())
+
+	for _, ts := range []struct {
+		timeSync TimeSync
+		field    string
+	}{
+		{TimeSync{Servers: []string{"10.0.0.1", ""}}, "Servers[1]"},
+		{TimeSync{Servers: []string{"10.0.0.1,10.0.0.2"}}, "Servers[0]"},
+		{TimeSync{Pools: []string{"pool.ntp.org iburst"}}, "Pools[0]"},
+	} {
+		err := ts.timeSync.validate()
+		assert.Error(err)
+		assert.Equal(ts.field, configFieldError("", err).Field)
+	}
+}
+
+func TestTimeSyncKernelParams(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(TimeSync{}.enabled())
+	assert.Empty(TimeSync{}.kernelParams())
+
+	ts := TimeSync{
+		Servers: []string{"10.0.0.1", "10.0.0.2"},
+		Pools:   []string{"pool.ntp.org"},
+		PTPKVM:  true,
+	}
+	assert.True(ts.enabled())
+	assert.Equal([]string{
+		"agent.ntp_servers=10.0.0.1,10.0.0.2",
+		"agent.ntp_pools=pool.ntp.org",
+		"agent.ptp_kvm",
+	}, SerializeParams(ts.kernelParams(), "="))
+}
@@ -0,0 +1,271 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	defaultTrafficCaptureMaxDuration = time.Minute
+
+	// defaultTrafficCaptureSnapLen captures the whole packets, as
+	// tcpdump does.
+	defaultTrafficCaptureSnapLen = 262144
+
+	// captureReadTimeout is how often the capture checks it is over when
+	// no packet comes.
+	captureReadTimeout = 200 * time.Millisecond
+
+	pcapMagic        = 0xa1b2c3d4
+	pcapVersionMajor = 2
+	pcapVersionMinor = 4
+	pcapLinkEthernet = 1
+)
+
+// TrafficCapture is the policy of the packet captures of the sandbox
+// network interfaces, taken on the host. Capturing is disabled unless
+// enabled.
+type TrafficCapture struct {
+	Enabled bool
+
+	// MaxDuration is the longest capture allowed, a minute if 0.
+	MaxDuration time.Duration
+
+	// SnapLen is how many bytes of each packet are captured, the whole
+	// packet if 0.
+	SnapLen uint32
+}
+
+func (c TrafficCapture) maxDuration() time.Duration {
+	if c.MaxDuration == 0 {
+		return defaultTrafficCaptureMaxDuration
+	}
+	return c.MaxDuration
+}
+
+func (c TrafficCapture) snapLen() uint32 {
+	if c.SnapLen == 0 {
+		return defaultTrafficCaptureSnapLen
+	}
+	return c.SnapLen
+}
+
+func (c TrafficCapture) validate() error {
+	if c.MaxDuration < 0 {
+		return newConfigFieldError("MaxDuration", "Capture duration cannot be negative")
+	}
+
+	return nil
+}
+
+// check returns an error if the policy does not allow a capture.
+func (c TrafficCapture) check(duration time.Duration) error {
+	if !c.Enabled {
+		return fmt.Errorf("Traffic capture is not enabled")
+	}
+
+	if duration < time.Second || duration > c.maxDuration() {
+		return fmt.Errorf("Capture duration must be between 1s and %v", c.maxDuration())
+	}
+
+	return nil
+}
+
+// endpointCaptureLink returns the host interface, closest to the VM, which
+// carries all the traffic of an endpoint.
+func endpointCaptureLink(endpoint Endpoint) (string, error) {
+	if pair := endpoint.NetworkPair(); pair != nil {
+		return pair.TAPIface.Name, nil
+	}
+
+	switch e := endpoint.(type) {
+	case *MacvtapEndpoint:
+		return e.EndpointProperties.Iface.Name, nil
+	case *TapEndpoint:
+		return e.TapInterface.TAPIface.Name, nil
+	}
+
+	return "", fmt.Errorf("The traffic of %s endpoints does not go through the host network stack", endpoint.Type())
+}
+
+func htons(v uint16) uint16 {
+	return v<<8 | v>>8
+}
+
+// openCaptureSocket opens a packet socket bound to a network interface of
+// a network namespace, receiving all the traffic of the interface.
+func openCaptureSocket(netNSPath, name string) (int, error) {
+	fd := -1
+
+	err := doNetNS(netNSPath, func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			return err
+		}
+
+		// The socket stays bound to the interface of the namespace it
+		// is opened in.
+		fd, err = unix.Socket(unix.AF_PACKET, unix.SOCK_RAW|unix.SOCK_CLOEXEC, int(htons(unix.ETH_P_ALL)))
+		if err != nil {
+			return err
+		}
+
+		return unix.Bind(fd, &unix.SockaddrLinklayer{
+			Protocol: htons(unix.ETH_P_ALL),
+			Ifindex:  link.Attrs().Index,
+		})
+	})
+	if err != nil {
+		if fd >= 0 {
+			unix.Close(fd)
+		}
+		return -1, err
+	}
+
+	timeout := unix.NsecToTimeval(captureReadTimeout.Nanoseconds())
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &timeout); err != nil {
+		unix.Close(fd)
+		return -1, err
+	}
+
+	return fd, nil
+}
+
+// pcapWriter writes packets in the pcap format.
+type pcapWriter struct {
+	w       io.Writer
+	snapLen uint32
+}
+
+func newPcapWriter(w io.Writer, snapLen uint32) (*pcapWriter, error) {
+	header := []uint32{pcapMagic, pcapVersionMajor | pcapVersionMinor<<16, 0, 0, snapLen, pcapLinkEthernet}
+	if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+		return nil, err
+	}
+
+	return &pcapWriter{w: w, snapLen: snapLen}, nil
+}
+
+// writePacket writes a packet of length bytes, of which data is captured.
+func (p *pcapWriter) writePacket(t time.Time, data []byte, length int) error {
+	if uint32(len(data)) > p.snapLen {
+		data = data[:p.snapLen]
+	}
+
+	header := []uint32{uint32(t.Unix()), uint32(t.Nanosecond() / 1000), uint32(len(data)), uint32(length)}
+	if err := binary.Write(p.w, binary.LittleEndian, header); err != nil {
+		return err
+	}
+
+	_, err := p.w.Write(data)
+	return err
+}
+
+// captureReader reads a capture as it is taken. Closing it stops the
+// capture.
+type captureReader struct {
+	*io.PipeReader
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (r *captureReader) Close() error {
+	r.closeOnce.Do(func() {
+		close(r.done)
+	})
+	return r.PipeReader.Close()
+}
+
+// capture writes the packets received on fd until the deadline, or the
+// capture is closed.
+func capture(fd int, w *io.PipeWriter, snapLen uint32, deadline time.Time, done chan struct{}) {
+	defer unix.Close(fd)
+
+	p, err := newPcapWriter(w, snapLen)
+	if err != nil {
+		w.CloseWithError(err)
+		return
+	}
+
+	buf := make([]byte, snapLen)
+	for time.Now().Before(deadline) {
+		select {
+		case <-done:
+			w.Close()
+			return
+		default:
+		}
+
+		// The whole length of the truncated packets is returned.
+		n, _, err := unix.Recvfrom(fd, buf, unix.MSG_TRUNC)
+		if err == unix.EAGAIN || err == unix.EINTR {
+			continue
+		}
+		if err != nil {
+			w.CloseWithError(err)
+			return
+		}
+
+		captured := n
+		if captured > len(buf) {
+			captured = len(buf)
+		}
+
+		if err := p.writePacket(time.Now(), buf[:captured], n); err != nil {
+			w.CloseWithError(err)
+			return
+		}
+	}
+
+	w.Close()
+}
+
+// CaptureTraffic captures, on the host, the traffic of a network interface
+// of the sandbox for a while, as the sandbox policy allows, and returns it
+// in the pcap format, streamed while the capture is taken. Closing the
+// capture stops it.
+func (s *Sandbox) CaptureTraffic(iface string, duration time.Duration) (io.ReadCloser, error) {
+	if err := s.config.TrafficCapture.check(duration); err != nil {
+		return nil, err
+	}
+
+	var endpoint Endpoint
+	for _, e := range s.networkNS.Endpoints {
+		if e.Name() == iface {
+			endpoint = e
+		}
+	}
+	if endpoint == nil {
+		return nil, fmt.Errorf("Network interface %s not found", iface)
+	}
+
+	name, err := endpointCaptureLink(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	fd, err := openCaptureSocket(s.networkNS.NetNsPath, name)
+	if err != nil {
+		return nil, fmt.Errorf("Could not capture the traffic of %s: %v", name, err)
+	}
+
+	s.Logger().WithField("interface", iface).WithField("link", name).WithField("duration", duration).Info("Traffic capture started")
+
+	r, w := io.Pipe()
+	done := make(chan struct{})
+	go capture(fd, w, s.config.TrafficCapture.snapLen(), time.Now().Add(duration), done)
+
+	return &captureReader{PipeReader: r, done: done}, nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vishvananda/netlink"
+)
+
+func TestTrafficCaptureValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(TrafficCapture{}.validate())
+	assert.NoError(TrafficCapture{Enabled: true, MaxDuration: time.Minute}.validate())
+
+	err := TrafficCapture{Enabled: true, MaxDuration: -time.Second}.validate()
+	assert.Error(err)
+	assert.Equal("MaxDuration", configFieldError("", err).Field)
+}
+
+func TestTrafficCaptureCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	c := TrafficCapture{Enabled: true}
+	assert.NoError(c.check(time.Second))
+	assert.NoError(c.check(time.Minute))
+	assert.Error(c.check(time.Millisecond))
+	assert.Error(c.check(2 * time.Minute))
+
+	c.MaxDuration = 5 * time.Minute
+	assert.NoError(c.check(2 * time.Minute))
+
+	// Capturing is disabled by default
+	assert.Error(TrafficCapture{}.check(time.Second))
+}
+
+func TestEndpointCaptureLink(t *testing.T) {
+	assert := assert.New(t)
+
+	veth := &VethEndpoint{NetPair: NetworkInterfacePair{TapInterface: TapInterface{TAPIface: NetworkInterface{Name: "tap0_kata"}}}}
+	name, err := endpointCaptureLink(veth)
+	assert.NoError(err)
+	assert.Equal("tap0_kata", name)
+
+	tap := &TapEndpoint{TapInterface: TapInterface{TAPIface: NetworkInterface{Name: "tap1"}}}
+	name, err = endpointCaptureLink(tap)
+	assert.NoError(err)
+	assert.Equal("tap1", name)
+
+	macvtap := &MacvtapEndpoint{EndpointProperties: NetworkInfo{Iface: NetlinkIface{LinkAttrs: netlink.LinkAttrs{Name: "macvtap0"}}}}
+	name, err = endpointCaptureLink(macvtap)
+	assert.NoError(err)
+	assert.Equal("macvtap0", name)
+
+	_, err = endpointCaptureLink(&PhysicalEndpoint{IfaceName: "eth0"})
+	assert.Error(err)
+}
+
+func TestPcapWriter(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	p, err := newPcapWriter(&buf, 4)
+	assert.NoError(err)
+
+	header := make([]uint32, 6)
+	assert.NoError(binary.Read(bytes.NewReader(buf.Bytes()), binary.LittleEndian, header))
+	assert.Equal([]uint32{pcapMagic, 2 | 4<<16, 0, 0, 4, pcapLinkEthernet}, header)
+
+	buf.Reset()
+	now := time.Unix(1600000000, 1500000)
+	assert.NoError(p.writePacket(now, []byte{1, 2, 3, 4, 5, 6}, 60))
+
+	// Packets are truncated to the snapshot length.
+	record := make([]uint32, 4)
+	r := bytes.NewReader(buf.Bytes())
+	assert.NoError(binary.Read(r, binary.LittleEndian, record))
+	assert.Equal([]uint32{1600000000, 1500, 4, 60}, record)
+	assert.Equal([]byte{1, 2, 3, 4}, buf.Bytes()[16:])
+}
@@ -90,9 +90,10 @@ func (endpoint *TuntapEndpoint) Detach(netNsCreated bool, netNsPath string) erro
 }
 
 // HotAttach for the tap endpoint uses hot plug device
-func (endpoint *TuntapEndpoint) HotAttach(h hypervisor) error {
+func (endpoint *TuntapEndpoint) HotAttach(s *Sandbox) error {
+	h := s.hypervisor
 	networkLogger().Info("Hot attaching tap endpoint")
-	if err := tuntapNetwork(endpoint, h.hypervisorConfig().NumVCPUs, h.hypervisorConfig().DisableVhostNet); err != nil {
+	if err := tuntapNetwork(endpoint, networkQueues(h.hypervisorConfig()), h.hypervisorConfig().DisableVhostNet); err != nil {
 		networkLogger().WithError(err).Error("Error bridging tap ep")
 		return err
 	}
@@ -105,7 +106,8 @@ func (endpoint *TuntapEndpoint) HotAttach(h hypervisor) error {
 }
 
 // HotDetach for the tap endpoint uses hot pull device
-func (endpoint *TuntapEndpoint) HotDetach(h hypervisor, netNsCreated bool, netNsPath string) error {
+func (endpoint *TuntapEndpoint) HotDetach(s *Sandbox, netNsCreated bool, netNsPath string) error {
+	h := s.hypervisor
 	networkLogger().Info("Hot detaching tap endpoint")
 	if err := doNetNS(netNsPath, func(_ ns.NetNS) error {
 		return unTuntapNetwork(endpoint.TuntapInterface.TAPIface.Name)
@@ -149,14 +151,14 @@ func createTuntapNetworkEndpoint(idx int, ifName string, hwName net.HardwareAddr
 	return endpoint, nil
 }
 
-func tuntapNetwork(endpoint *TuntapEndpoint, numCPUs uint32, disableVhostNet bool) error {
+func tuntapNetwork(endpoint *TuntapEndpoint, queues int, disableVhostNet bool) error {
 	netHandle, err := netlink.NewHandle()
 	if err != nil {
 		return err
 	}
 	defer netHandle.Delete()
 
-	tapLink, _, err := createLink(netHandle, endpoint.TuntapInterface.TAPIface.Name, &netlink.Tuntap{}, int(numCPUs))
+	tapLink, _, err := createLink(netHandle, endpoint.TuntapInterface.TAPIface.Name, &netlink.Tuntap{}, queues)
 	if err != nil {
 		return fmt.Errorf("Could not create TAP interface: %s", err)
 	}
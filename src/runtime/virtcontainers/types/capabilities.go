@@ -10,6 +10,17 @@ const (
 	blockDeviceHotplugSupport
 	multiQueueSupport
 	fsSharingSupported
+	guestSuspendSupport
+	sandboxPauseSupport
+	vfioPeerToPeerSupport
+	sandboxSnapshotSupport
+	sandboxMigrationSupport
+	memoryHotUnplugSupport
+	blockDeviceHotUnplugSupport
+	vfioHotplugSupport
+	virtioFSHotplugSupport
+	blockDeviceCacheSupport
+	sandboxLiveUpdateSupport
 )
 
 // Capabilities describe a virtcontainers hypervisor capabilities
@@ -57,3 +68,133 @@ func (caps *Capabilities) IsFsSharingSupported() bool {
 func (caps *Capabilities) SetFsSharingSupport() {
 	caps.flags |= fsSharingSupported
 }
+
+// UnsetFsSharingSupport sets the host filesystem sharing capability to
+// false.
+func (caps *Capabilities) UnsetFsSharingSupport() {
+	caps.flags &^= fsSharingSupported
+}
+
+// IsGuestSuspendSupported tells if an hypervisor can suspend the guest to RAM
+// and wake it up.
+func (caps *Capabilities) IsGuestSuspendSupported() bool {
+	return caps.flags&guestSuspendSupport != 0
+}
+
+// SetGuestSuspendSupport sets the guest suspend to RAM capability to true.
+func (caps *Capabilities) SetGuestSuspendSupport() {
+	caps.flags |= guestSuspendSupport
+}
+
+// IsSandboxPauseSupported tells if an hypervisor can pause the VM and
+// resume it.
+func (caps *Capabilities) IsSandboxPauseSupported() bool {
+	return caps.flags&sandboxPauseSupport != 0
+}
+
+// SetSandboxPauseSupport sets the VM pause capability to true.
+func (caps *Capabilities) SetSandboxPauseSupport() {
+	caps.flags |= sandboxPauseSupport
+}
+
+// IsVFIOPeerToPeerSupported tells if an hypervisor lets the VFIO devices
+// of a VM DMA to each other.
+func (caps *Capabilities) IsVFIOPeerToPeerSupported() bool {
+	return caps.flags&vfioPeerToPeerSupport != 0
+}
+
+// SetVFIOPeerToPeerSupport sets the VFIO peer-to-peer DMA capability to true.
+func (caps *Capabilities) SetVFIOPeerToPeerSupport() {
+	caps.flags |= vfioPeerToPeerSupport
+}
+
+// IsSandboxSnapshotSupported tells if an hypervisor can save the state of
+// the VM to files and start a VM from them.
+func (caps *Capabilities) IsSandboxSnapshotSupported() bool {
+	return caps.flags&sandboxSnapshotSupport != 0
+}
+
+// SetSandboxSnapshotSupport sets the VM snapshot capability to true.
+func (caps *Capabilities) SetSandboxSnapshotSupport() {
+	caps.flags |= sandboxSnapshotSupport
+}
+
+// IsSandboxMigrationSupported tells if an hypervisor can stream the state
+// of a running VM to a VM on another host.
+func (caps *Capabilities) IsSandboxMigrationSupported() bool {
+	return caps.flags&sandboxMigrationSupport != 0
+}
+
+// SetSandboxMigrationSupport sets the VM live migration capability to true.
+func (caps *Capabilities) SetSandboxMigrationSupport() {
+	caps.flags |= sandboxMigrationSupport
+}
+
+// IsMemoryHotUnplugSupported tells if an hypervisor can remove memory from
+// a running VM.
+func (caps *Capabilities) IsMemoryHotUnplugSupported() bool {
+	return caps.flags&memoryHotUnplugSupport != 0
+}
+
+// SetMemoryHotUnplugSupport sets the memory hot unplug capability to true.
+func (caps *Capabilities) SetMemoryHotUnplugSupport() {
+	caps.flags |= memoryHotUnplugSupport
+}
+
+// IsBlockDeviceHotUnplugSupported tells if an hypervisor can remove block
+// devices from a running VM.
+func (caps *Capabilities) IsBlockDeviceHotUnplugSupported() bool {
+	return caps.flags&blockDeviceHotUnplugSupport != 0
+}
+
+// SetBlockDeviceHotUnplugSupport sets the block device hot unplug capability
+// to true.
+func (caps *Capabilities) SetBlockDeviceHotUnplugSupport() {
+	caps.flags |= blockDeviceHotUnplugSupport
+}
+
+// IsVFIOHotplugSupported tells if an hypervisor can add VFIO devices to, and
+// remove them from, a running VM.
+func (caps *Capabilities) IsVFIOHotplugSupported() bool {
+	return caps.flags&vfioHotplugSupport != 0
+}
+
+// SetVFIOHotplugSupport sets the VFIO device hotplug capability to true.
+func (caps *Capabilities) SetVFIOHotplugSupport() {
+	caps.flags |= vfioHotplugSupport
+}
+
+// IsVirtioFSHotplugSupported tells if an hypervisor can share host
+// directories through virtio-fs devices added to a running VM.
+func (caps *Capabilities) IsVirtioFSHotplugSupported() bool {
+	return caps.flags&virtioFSHotplugSupport != 0
+}
+
+// SetVirtioFSHotplugSupport sets the virtio-fs device hotplug capability to
+// true.
+func (caps *Capabilities) SetVirtioFSHotplugSupport() {
+	caps.flags |= virtioFSHotplugSupport
+}
+
+// IsBlockDeviceCacheSupported tells if an hypervisor can set the cache mode
+// of each block device.
+func (caps *Capabilities) IsBlockDeviceCacheSupported() bool {
+	return caps.flags&blockDeviceCacheSupport != 0
+}
+
+// SetBlockDeviceCacheSupport sets the block device cache mode capability to
+// true.
+func (caps *Capabilities) SetBlockDeviceCacheSupport() {
+	caps.flags |= blockDeviceCacheSupport
+}
+
+// IsSandboxLiveUpdateSupported tells if an hypervisor can move a running VM
+// to a new process of the hypervisor.
+func (caps *Capabilities) IsSandboxLiveUpdateSupported() bool {
+	return caps.flags&sandboxLiveUpdateSupport != 0
+}
+
+// SetSandboxLiveUpdateSupport sets the VM live update capability to true.
+func (caps *Capabilities) SetSandboxLiveUpdateSupport() {
+	caps.flags |= sandboxLiveUpdateSupport
+}
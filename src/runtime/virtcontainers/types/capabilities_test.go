@@ -33,4 +33,94 @@ func TestFsSharingCapability(t *testing.T) {
 	assert.False(t, caps.IsFsSharingSupported())
 	caps.SetFsSharingSupport()
 	assert.True(t, caps.IsFsSharingSupported())
+	caps.UnsetFsSharingSupport()
+	assert.False(t, caps.IsFsSharingSupported())
+}
+
+func TestGuestSuspendCapability(t *testing.T) {
+	var caps Capabilities
+
+	assert.False(t, caps.IsGuestSuspendSupported())
+	caps.SetGuestSuspendSupport()
+	assert.True(t, caps.IsGuestSuspendSupported())
+}
+
+func TestSandboxPauseCapability(t *testing.T) {
+	var caps Capabilities
+
+	assert.False(t, caps.IsSandboxPauseSupported())
+	caps.SetSandboxPauseSupport()
+	assert.True(t, caps.IsSandboxPauseSupported())
+}
+
+func TestVFIOPeerToPeerCapability(t *testing.T) {
+	var caps Capabilities
+
+	assert.False(t, caps.IsVFIOPeerToPeerSupported())
+	caps.SetVFIOPeerToPeerSupport()
+	assert.True(t, caps.IsVFIOPeerToPeerSupported())
+}
+
+func TestSandboxSnapshotCapability(t *testing.T) {
+	var caps Capabilities
+
+	assert.False(t, caps.IsSandboxSnapshotSupported())
+	caps.SetSandboxSnapshotSupport()
+	assert.True(t, caps.IsSandboxSnapshotSupported())
+}
+
+func TestSandboxMigrationCapability(t *testing.T) {
+	var caps Capabilities
+
+	assert.False(t, caps.IsSandboxMigrationSupported())
+	caps.SetSandboxMigrationSupport()
+	assert.True(t, caps.IsSandboxMigrationSupported())
+}
+
+func TestMemoryHotUnplugCapability(t *testing.T) {
+	var caps Capabilities
+
+	assert.False(t, caps.IsMemoryHotUnplugSupported())
+	caps.SetMemoryHotUnplugSupport()
+	assert.True(t, caps.IsMemoryHotUnplugSupported())
+}
+
+func TestBlockDeviceHotUnplugCapability(t *testing.T) {
+	var caps Capabilities
+
+	assert.False(t, caps.IsBlockDeviceHotUnplugSupported())
+	caps.SetBlockDeviceHotUnplugSupport()
+	assert.True(t, caps.IsBlockDeviceHotUnplugSupported())
+}
+
+func TestVFIOHotplugCapability(t *testing.T) {
+	var caps Capabilities
+
+	assert.False(t, caps.IsVFIOHotplugSupported())
+	caps.SetVFIOHotplugSupport()
+	assert.True(t, caps.IsVFIOHotplugSupported())
+}
+
+func TestVirtioFSHotplugCapability(t *testing.T) {
+	var caps Capabilities
+
+	assert.False(t, caps.IsVirtioFSHotplugSupported())
+	caps.SetVirtioFSHotplugSupport()
+	assert.True(t, caps.IsVirtioFSHotplugSupported())
+}
+
+func TestBlockDeviceCacheCapability(t *testing.T) {
+	var caps Capabilities
+
+	assert.False(t, caps.IsBlockDeviceCacheSupported())
+	caps.SetBlockDeviceCacheSupport()
+	assert.True(t, caps.IsBlockDeviceCacheSupported())
+}
+
+func TestSandboxLiveUpdateCapability(t *testing.T) {
+	var caps Capabilities
+
+	assert.False(t, caps.IsSandboxLiveUpdateSupported())
+	caps.SetSandboxLiveUpdateSupport()
+	assert.True(t, caps.IsSandboxLiveUpdateSupported())
 }
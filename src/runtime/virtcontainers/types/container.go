@@ -14,6 +14,10 @@ type ContainerState struct {
 	// File system of the rootfs incase it is block device
 	Fstype string `json:"fstype"`
 
+	// ScratchDeviceID is the encrypted scratch block device backing the
+	// writable layer of the rootfs.
+	ScratchDeviceID string `json:"scratchDeviceID,omitempty"`
+
 	// CgroupPath is the cgroup hierarchy where sandbox's processes
 	// including the hypervisor are placed.
 	CgroupPath string `json:"cgroupPath,omitempty"`
@@ -26,6 +26,10 @@ const (
 	// StatePaused represents a sandbox/container that has been paused.
 	StatePaused StateString = "paused"
 
+	// StateSuspended represents a sandbox whose guest has been suspended
+	// to RAM.
+	StateSuspended StateString = "suspended"
+
 	// StateStopped represents a sandbox/container that has been stopped.
 	StateStopped StateString = "stopped"
 )
@@ -48,6 +52,10 @@ type SandboxState struct {
 	// GuestMemoryHotplugProbe determines whether guest kernel supports memory hotplug probe interface
 	GuestMemoryHotplugProbe bool `json:"guestMemoryHotplugProbe"`
 
+	// GuestSeccompSupported tells whether the agent applies the seccomp
+	// profiles of the containers
+	GuestSeccompSupported bool `json:"guestSeccompSupported"`
+
 	// CgroupPath is the cgroup hierarchy where sandbox's processes
 	// including the hypervisor are placed.
 	CgroupPath string `json:"cgroupPath,omitempty"`
@@ -56,6 +64,14 @@ type SandboxState struct {
 	// with the value as the path.
 	CgroupPaths map[string]string `json:"cgroupPaths"`
 
+	// HypervisorVersion is the version of the hypervisor the VM was
+	// started with.
+	HypervisorVersion string `json:"hypervisorVersion,omitempty"`
+
+	// HypervisorConfigDigest is the digest of the hypervisor configuration
+	// the VM was started with.
+	HypervisorConfigDigest string `json:"hypervisorConfigDigest,omitempty"`
+
 	// PersistVersion indicates current storage api version.
 	// It's also known as ABI version of kata-runtime.
 	// Note: it won't be written to disk
@@ -74,7 +90,7 @@ func (state *SandboxState) ValidTransition(oldState StateString, newState StateS
 }
 
 func (state *StateString) valid() bool {
-	for _, validState := range []StateString{StateReady, StateRunning, StatePaused, StateStopped} {
+	for _, validState := range []StateString{StateReady, StateRunning, StatePaused, StateSuspended, StateStopped} {
 		if *state == validState {
 			return true
 		}
@@ -95,11 +111,11 @@ func (state *StateString) validTransition(oldState StateString, newState StateSt
 		}
 
 	case StateRunning:
-		if newState == StatePaused || newState == StateStopped {
+		if newState == StatePaused || newState == StateSuspended || newState == StateStopped {
 			return nil
 		}
 
-	case StatePaused:
+	case StatePaused, StateSuspended:
 		if newState == StateRunning || newState == StateStopped {
 			return nil
 		}
@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+)
+
+// VCPUAllocation is how the vCPUs of the VM of a sandbox are allocated to
+// its containers.
+type VCPUAllocation struct {
+	// BootVCPUs are the vCPUs the VM boots with, which are never
+	// unplugged.
+	BootVCPUs uint32
+
+	// HotpluggedVCPUs are the vCPUs hotplugged for the containers.
+	HotpluggedVCPUs uint32
+
+	// ContainerMilliCPUs are the CPUs, in thousandths of a CPU, allocated
+	// to each container from its CPU quota and period. The containers
+	// which are stopped, or have no CPU quota, are not allocated any.
+	ContainerMilliCPUs map[string]uint32
+}
+
+// containerMilliCPUs returns the CPUs, in thousandths of a CPU, allocated
+// to each container of the sandbox.
+func (s *Sandbox) containerMilliCPUs() map[string]uint32 {
+	allocations := make(map[string]uint32)
+
+	for _, c := range s.config.Containers {
+		// Do not hot add again non-running containers resources
+		if cont, ok := s.containers[c.ID]; ok && cont.state.State == types.StateStopped {
+			s.Logger().WithField("container-id", c.ID).Debug("Do not taking into account CPU resources of not running containers")
+			continue
+		}
+
+		if cpu := c.Resources.CPU; cpu != nil && cpu.Period != nil && cpu.Quota != nil {
+			if mCPU := utils.CalculateMilliCPUs(*cpu.Quota, *cpu.Period); mCPU > 0 {
+				allocations[c.ID] += mCPU
+			}
+		}
+	}
+
+	return allocations
+}
+
+// vcpuAllocation returns how the vCPUs of the VM are allocated.
+func (s *Sandbox) vcpuAllocation() VCPUAllocation {
+	return VCPUAllocation{
+		BootVCPUs:          s.hypervisor.hypervisorConfig().NumVCPUs,
+		HotpluggedVCPUs:    uint32(len(s.hypervisor.save().HotpluggedVCPUs)),
+		ContainerMilliCPUs: s.containerMilliCPUs(),
+	}
+}
+
+// releaseContainerResources hot unplugs the vCPUs, and the memory, of the
+// VM a stopped or deleted container was allocated. The container is
+// stopped or deleted whether they are unplugged or not.
+func (s *Sandbox) releaseContainerResources(containerID string) {
+	if s.state.State != types.StateRunning {
+		return
+	}
+
+	if err := s.updateResources(); err != nil {
+		s.Logger().WithError(err).WithField("container", containerID).Warn("Could not release the resources of the container")
+	}
+}
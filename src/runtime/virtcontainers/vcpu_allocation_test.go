@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// vcpuHypervisor is a mock hypervisor booting the VM with a vCPU, and
+// hotplugging the vCPUs requested on top of it.
+type vcpuHypervisor struct {
+	mockHypervisor
+	hotplugged uint32
+}
+
+func (h *vcpuHypervisor) hypervisorConfig() HypervisorConfig {
+	return HypervisorConfig{NumVCPUs: 1}
+}
+
+func (h *vcpuHypervisor) resizeVCPUs(cpus uint32) (uint32, uint32, error) {
+	old := 1 + h.hotplugged
+	h.hotplugged = cpus - 1
+	return old, cpus, nil
+}
+
+func (h *vcpuHypervisor) save() persistapi.HypervisorState {
+	return persistapi.HypervisorState{HotpluggedVCPUs: make([]persistapi.CPUDevice, h.hotplugged)}
+}
+
+func newTestContainerConfigCPU(id string, quota int64) ContainerConfig {
+	period := uint64(100000)
+
+	contConfig := newTestContainerConfigNoop(id)
+	contConfig.Resources.CPU = &specs.LinuxCPU{
+		Quota:  &quota,
+		Period: &period,
+	}
+
+	return contConfig
+}
+
+func TestSandboxVCPUAllocation(t *testing.T) {
+	assert := assert.New(t)
+
+	defer cleanUp()
+	s, err := testCreateSandbox(t,
+		testSandboxID,
+		MockHypervisor,
+		newHypervisorConfig(nil, nil),
+		NetworkConfig{},
+		nil,
+		nil)
+	assert.NoError(err)
+
+	h := &vcpuHypervisor{}
+	s.hypervisor = h
+	s.state.State = types.StateRunning
+
+	_, err = s.CreateContainer(newTestContainerConfigCPU("cont-00001", 150000))
+	assert.NoError(err)
+	_, err = s.CreateContainer(newTestContainerConfigCPU("cont-00002", 50000))
+	assert.NoError(err)
+	_, err = s.CreateContainer(newTestContainerConfigNoop("cont-00003"))
+	assert.NoError(err)
+
+	assert.Equal(uint32(2), h.hotplugged)
+	assert.Equal(VCPUAllocation{
+		BootVCPUs:       1,
+		HotpluggedVCPUs: 2,
+		ContainerMilliCPUs: map[string]uint32{
+			"cont-00001": 1500,
+			"cont-00002": 500,
+		},
+	}, s.Status().VCPUs)
+
+	// Shrinking the quota of a container unplugs the vCPUs it no longer
+	// needs
+	quota := int64(100000)
+	assert.NoError(s.UpdateContainer("cont-00001", specs.LinuxResources{CPU: &specs.LinuxCPU{Quota: &quota}}))
+	assert.Equal(uint32(2), h.hotplugged)
+
+	quota = int64(40000)
+	assert.NoError(s.UpdateContainer("cont-00001", specs.LinuxResources{CPU: &specs.LinuxCPU{Quota: &quota}}))
+	assert.Equal(uint32(1), h.hotplugged)
+
+	// Deleting a container unplugs the vCPUs it was allocated
+	s.containers["cont-00002"].state.State = types.StateStopped
+	_, err = s.DeleteContainer("cont-00002")
+	assert.NoError(err)
+	assert.Equal(uint32(1), h.hotplugged)
+	assert.Equal(map[string]uint32{"cont-00001": 400}, s.Status().VCPUs.ContainerMilliCPUs)
+
+	s.containers["cont-00001"].state.State = types.StateStopped
+	_, err = s.DeleteContainer("cont-00001")
+	assert.NoError(err)
+	assert.Equal(uint32(0), h.hotplugged)
+	assert.Empty(s.Status().VCPUs.ContainerMilliCPUs)
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// hostCPUNodes returns the host NUMA node of each host CPU.
+func hostCPUNodes() (map[int]int, error) {
+	paths, err := filepath.Glob(filepath.Join(sysNodePath, "node[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+
+	cpuNodes := make(map[int]int)
+	for _, p := range paths {
+		node, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(p), "node"))
+		if err != nil {
+			continue
+		}
+
+		cpus, err := hostNUMANodeCPUs(node)
+		if err != nil {
+			return nil, err
+		}
+		for _, cpu := range cpus {
+			cpuNodes[cpu] = node
+		}
+	}
+
+	return cpuNodes, nil
+}
+
+// containersCPUs returns the sorted host CPUs of the cpusets of the
+// containers, such as the exclusive CPUs the kubelet static CPU manager
+// gives them.
+func containersCPUs(containers []ContainerConfig) ([]int, error) {
+	var lists []string
+	for _, c := range containers {
+		if c.Resources.CPU != nil && c.Resources.CPU.Cpus != "" {
+			lists = append(lists, c.Resources.CPU.Cpus)
+		}
+	}
+
+	return parseCPUSets(lists)
+}
+
+// parseCPUSets returns the sorted CPUs of several CPU lists.
+func parseCPUSets(lists []string) ([]int, error) {
+	set := make(map[int]bool)
+	for _, list := range lists {
+		cpus, err := parseCPUList(strings.TrimSpace(list))
+		if err != nil {
+			return nil, err
+		}
+		for _, cpu := range cpus {
+			set[cpu] = true
+		}
+	}
+
+	cpus := make([]int, 0, len(set))
+	for cpu := range set {
+		cpus = append(cpus, cpu)
+	}
+	sort.Ints(cpus)
+
+	return cpus, nil
+}
+
+// appendCPUSetNUMANodes adds to the guest NUMA nodes a node for each host
+// NUMA node of the cpusets of the containers, so that the guest sees the
+// NUMA topology of the host CPUs its vCPUs are pinned to.
+func appendCPUSetNUMANodes(nodes []GuestNUMANode, containers []ContainerConfig) ([]GuestNUMANode, error) {
+	cpus, err := containersCPUs(containers)
+	if err != nil || len(cpus) == 0 {
+		return nodes, err
+	}
+
+	cpuNodes, err := hostCPUNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	present := make(map[int]bool)
+	for _, n := range nodes {
+		present[n.HostNode] = true
+	}
+
+	var hostNodes []int
+	for _, cpu := range cpus {
+		node, ok := cpuNodes[cpu]
+		if !ok || present[node] {
+			continue
+		}
+		present[node] = true
+		hostNodes = append(hostNodes, node)
+	}
+	sort.Ints(hostNodes)
+
+	for _, node := range hostNodes {
+		nodes = append(nodes, GuestNUMANode{HostNode: node})
+	}
+
+	return nodes, nil
+}
+
+// vcpuPinning returns the host CPU each vCPU is pinned to, among cpus. The
+// vCPUs of a guest NUMA node get the CPUs of its host node, as far as cpus
+// has some, so that the guest NUMA topology matches the host one. The vCPUs
+// share the CPUs when there are fewer CPUs than vCPUs.
+func vcpuPinning(vcpus []int, cpus []int, conf *HypervisorConfig, cpuNodes map[int]int) map[int]int {
+	pinning := make(map[int]int)
+	if len(cpus) == 0 {
+		return pinning
+	}
+
+	sort.Ints(vcpus)
+
+	nodes := conf.GuestNUMANodes
+	next := make(map[int]int)
+	for _, vcpu := range vcpus {
+		node := -1
+		candidates := cpus
+
+		if len(nodes) > 0 {
+			node = guestNUMANodeOfVCPU(uint32(vcpu), conf.NumVCPUs, conf.DefaultMaxVCPUs, len(nodes))
+
+			var nodeCPUs []int
+			for _, cpu := range cpus {
+				if n, ok := cpuNodes[cpu]; ok && n == nodes[node].HostNode {
+					nodeCPUs = append(nodeCPUs, cpu)
+				}
+			}
+			if len(nodeCPUs) > 0 {
+				candidates = nodeCPUs
+			}
+		}
+
+		pinning[vcpu] = candidates[next[node]%len(candidates)]
+		next[node]++
+	}
+
+	return pinning
+}
+
+// pinningCPUs returns the host CPUs the vCPUs of the sandbox are pinned to:
+// the cpusets of its containers, or the CPUs the hypervisor can run on when
+// the containers have no cpuset.
+func (s *Sandbox) pinningCPUs() ([]int, error) {
+	var lists []string
+	for _, c := range s.containers {
+		if r := c.config.Resources.CPU; r != nil && r.Cpus != "" {
+			lists = append(lists, r.Cpus)
+		}
+	}
+	if len(lists) > 0 {
+		return parseCPUSets(lists)
+	}
+
+	pids := s.hypervisor.getPids()
+	if len(pids) == 0 || pids[0] <= 0 {
+		return nil, fmt.Errorf("Invalid hypervisor PID: %+v", pids)
+	}
+
+	var set unix.CPUSet
+	if err := unix.SchedGetaffinity(pids[0], &set); err != nil {
+		return nil, fmt.Errorf("Could not get the CPU affinity of the hypervisor: %v", err)
+	}
+
+	var cpus []int
+	for cpu := 0; len(cpus) < set.Count(); cpu++ {
+		if set.IsSet(cpu) {
+			cpus = append(cpus, cpu)
+		}
+	}
+
+	return cpus, nil
+}
+
+// pinVCPUs pins each vCPU thread to a single host CPU. It is applied again
+// when vCPUs are hotplugged and when the cpusets of the containers change.
+func (s *Sandbox) pinVCPUs() error {
+	tids, err := s.hypervisor.getThreadIDs()
+	if err != nil {
+		return fmt.Errorf("failed to get thread ids from hypervisor: %v", err)
+	}
+	if len(tids.vcpus) == 0 {
+		return nil
+	}
+
+	cpus, err := s.pinningCPUs()
+	if err != nil {
+		return err
+	}
+
+	var cpuNodes map[int]int
+	if len(s.config.HypervisorConfig.GuestNUMANodes) > 0 {
+		if cpuNodes, err = hostCPUNodes(); err != nil {
+			return err
+		}
+	}
+
+	vcpus := make([]int, 0, len(tids.vcpus))
+	for vcpu := range tids.vcpus {
+		vcpus = append(vcpus, vcpu)
+	}
+
+	for vcpu, cpu := range vcpuPinning(vcpus, cpus, &s.config.HypervisorConfig, cpuNodes) {
+		var set unix.CPUSet
+		set.Set(cpu)
+
+		if err := unix.SchedSetaffinity(tids.vcpus[vcpu], &set); err != nil {
+			// The cpuset of the sandbox cgroup may not have the CPU yet.
+			s.Logger().WithError(err).WithFields(logrus.Fields{
+				"vcpu":     vcpu,
+				"host-cpu": cpu,
+			}).Warn("Could not pin vCPU thread")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCPUSets(t *testing.T) {
+	assert := assert.New(t)
+
+	cpus, err := parseCPUSets([]string{"4-5", " 2,4 ", "8"})
+	assert.NoError(err)
+	assert.Equal([]int{2, 4, 5, 8}, cpus)
+
+	cpus, err = parseCPUSets(nil)
+	assert.NoError(err)
+	assert.Empty(cpus)
+
+	_, err = parseCPUSets([]string{"1", "x"})
+	assert.Error(err)
+}
+
+func TestVCPUPinning(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := &HypervisorConfig{NumVCPUs: 4, DefaultMaxVCPUs: 4}
+
+	assert.Equal(map[int]int{0: 2, 1: 3, 2: 2, 3: 3},
+		vcpuPinning([]int{3, 1, 0, 2}, []int{2, 3}, conf, nil))
+	assert.Empty(vcpuPinning([]int{0, 1}, nil, conf, nil))
+
+	// The vCPUs of a guest node are pinned to CPUs of its host node.
+	conf.GuestNUMANodes = []GuestNUMANode{{HostNode: 0}, {HostNode: 1}}
+	cpuNodes := map[int]int{2: 0, 3: 0, 10: 1, 11: 1}
+	assert.Equal(map[int]int{0: 2, 1: 3, 2: 10, 3: 11},
+		vcpuPinning([]int{0, 1, 2, 3}, []int{2, 3, 10, 11}, conf, cpuNodes))
+
+	// A guest node without CPUs on its host node gets any of the CPUs.
+	assert.Equal(map[int]int{0: 2, 1: 3, 2: 2, 3: 3},
+		vcpuPinning([]int{0, 1, 2, 3}, []int{2, 3}, conf, cpuNodes))
+}
+
+func TestAppendCPUSetNUMANodes(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "node")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpDir)
+
+	savedSysNodePath := sysNodePath
+	sysNodePath = tmpDir
+	defer func() {
+		sysNodePath = savedSysNodePath
+	}()
+
+	for node, cpus := range map[string]string{"node0": "0-3", "node1": "4-7", "node2": "8-11"} {
+		assert.NoError(os.MkdirAll(filepath.Join(tmpDir, node), DirMode))
+		assert.NoError(ioutil.WriteFile(filepath.Join(tmpDir, node, "cpulist"), []byte(cpus+"\n"), 0644))
+	}
+
+	cpuNodes, err := hostCPUNodes()
+	assert.NoError(err)
+	assert.Len(cpuNodes, 12)
+	assert.Equal(2, cpuNodes[9])
+
+	containers := []ContainerConfig{
+		{Resources: specs.LinuxResources{CPU: &specs.LinuxCPU{Cpus: "9-10"}}},
+		{Resources: specs.LinuxResources{CPU: &specs.LinuxCPU{Cpus: "1,5"}}},
+		{Resources: specs.LinuxResources{}},
+	}
+
+	// The node of the devices is kept first.
+	nodes, err := appendCPUSetNUMANodes([]GuestNUMANode{{HostNode: 1, PCIDevices: []string{"3b:00.0"}}}, containers)
+	assert.NoError(err)
+	assert.Equal([]GuestNUMANode{
+		{HostNode: 1, PCIDevices: []string{"3b:00.0"}},
+		{HostNode: 0},
+		{HostNode: 2},
+	}, nodes)
+
+	nodes, err = appendCPUSetNUMANodes(nil, containers[2:])
+	assert.NoError(err)
+	assert.Empty(nodes)
+}
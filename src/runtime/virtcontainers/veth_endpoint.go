@@ -114,7 +114,8 @@ func (endpoint *VethEndpoint) Detach(netNsCreated bool, netNsPath string) error
 }
 
 // HotAttach for the veth endpoint uses hot plug device
-func (endpoint *VethEndpoint) HotAttach(h hypervisor) error {
+func (endpoint *VethEndpoint) HotAttach(s *Sandbox) error {
+	h := s.hypervisor
 	if err := xConnectVMNetwork(endpoint, h); err != nil {
 		networkLogger().WithError(err).Error("Error bridging virtual ep")
 		return err
@@ -128,7 +129,8 @@ func (endpoint *VethEndpoint) HotAttach(h hypervisor) error {
 }
 
 // HotDetach for the veth endpoint uses hot pull device
-func (endpoint *VethEndpoint) HotDetach(h hypervisor, netNsCreated bool, netNsPath string) error {
+func (endpoint *VethEndpoint) HotDetach(s *Sandbox, netNsCreated bool, netNsPath string) error {
+	h := s.hypervisor
 	if !netNsCreated {
 		return nil
 	}
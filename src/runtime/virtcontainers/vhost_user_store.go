@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// vhostUserSocketTimeout is how long the hotplug of a vhost-user block
+	// device waits for its backend, such as an SPDK target, to serve the
+	// socket of the device.
+	vhostUserSocketTimeout = 10 * time.Second
+
+	vhostUserSocketPollInterval = 100 * time.Millisecond
+)
+
+// validateVhostUserStore checks that the VM memory can be shared with the
+// vhost-user storage backends, which map it to serve the device queues.
+func validateVhostUserStore(conf *HypervisorConfig) error {
+	if !conf.EnableVhostUserStore {
+		return nil
+	}
+
+	if conf.VhostUserStorePath != "" && !filepath.IsAbs(conf.VhostUserStorePath) {
+		return newConfigFieldError("VhostUserStorePath", fmt.Sprintf("Vhost-user store path %q is not absolute", conf.VhostUserStorePath))
+	}
+
+	if !conf.HugePages {
+		return newConfigFieldError("EnableVhostUserStore", "Vhost-user-blk/scsi is enabled without HugePages. This configuration will not work")
+	}
+
+	// The backends cannot access the encrypted guest memory.
+	if conf.MemoryEncryption != MemoryEncryptionNone {
+		return newConfigFieldError("EnableVhostUserStore", "Vhost-user storage devices are not supported with memory encryption")
+	}
+
+	// The memory of the template VM is private to the VMs created from it.
+	if conf.BootToBeTemplate || conf.BootFromTemplate {
+		return newConfigFieldError("EnableVhostUserStore", "Vhost-user storage devices are not supported with VM templating")
+	}
+
+	return nil
+}
+
+// waitVhostUserSocket waits for the backend of a vhost-user device to create
+// the socket of the device, which storage targets usually only do once the
+// device is exported, after the container is created.
+func waitVhostUserSocket(path string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		fi, err := os.Stat(path)
+		if err == nil {
+			if fi.Mode()&os.ModeSocket == 0 {
+				return fmt.Errorf("Vhost-user device path %s is not a socket", path)
+			}
+			return nil
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Timeout waiting for the vhost-user socket %s", path)
+		}
+		time.Sleep(vhostUserSocketPollInterval)
+	}
+}
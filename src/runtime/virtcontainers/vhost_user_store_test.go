@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateVhostUserStore(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := &HypervisorConfig{}
+	assert.NoError(validateVhostUserStore(conf))
+
+	conf.EnableVhostUserStore = true
+	conf.VhostUserStorePath = "vhost-user"
+	conf.HugePages = true
+	assert.Error(validateVhostUserStore(conf))
+
+	conf.VhostUserStorePath = "/var/run/kata-containers/vhost-user"
+	assert.NoError(validateVhostUserStore(conf))
+
+	conf.HugePages = false
+	assert.Error(validateVhostUserStore(conf))
+	conf.HugePages = true
+
+	conf.MemoryEncryption = MemoryEncryptionSEV
+	assert.Error(validateVhostUserStore(conf))
+	conf.MemoryEncryption = MemoryEncryptionNone
+
+	conf.BootFromTemplate = true
+	assert.Error(validateVhostUserStore(conf))
+}
+
+func TestWaitVhostUserSocket(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "vhost-user")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "blk0")
+	assert.Error(waitVhostUserSocket(socket, 0))
+
+	done := make(chan net.Listener)
+	go func() {
+		time.Sleep(2 * vhostUserSocketPollInterval)
+		l, err := net.Listen("unix", socket)
+		assert.NoError(err)
+		done <- l
+	}()
+
+	assert.NoError(waitVhostUserSocket(socket, 5*time.Second))
+	l := <-done
+	if l != nil {
+		l.Close()
+	}
+
+	file := filepath.Join(dir, "blk1")
+	assert.NoError(ioutil.WriteFile(file, nil, 0600))
+	assert.Error(waitVhostUserSocket(file, 0))
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// VirtioFSOptions are the options of a mount shared with the guest through
+// a virtio-fs device and daemon of its own.
+type VirtioFSOptions struct {
+	// Cache is the cache mode of the daemon, "none", "auto" or "always".
+	// Empty uses the cache mode of the sandbox.
+	Cache string
+
+	// DAXWindowMB is the size of the DAX window of the device in MiB,
+	// zero disabling DAX.
+	DAXWindowMB uint32
+
+	// Xattr enables the extended attributes of the shared files.
+	Xattr bool
+}
+
+func (o VirtioFSOptions) validate() error {
+	switch o.Cache {
+	case "", typeVirtioFSNoCache, "auto", "always":
+	default:
+		return fmt.Errorf("Invalid virtio-fs cache mode %q", o.Cache)
+	}
+
+	// The daemon crashes on the DAX mappings of the guest without cache
+	if o.Cache == typeVirtioFSNoCache && o.DAXWindowMB != 0 {
+		return fmt.Errorf("virtio-fs DAX window needs a cache mode other than %q", typeVirtioFSNoCache)
+	}
+
+	return nil
+}
+
+// virtioFSShare is a host directory shared with the guest through a
+// virtio-fs device and daemon of its own.
+type virtioFSShare struct {
+	ID      string
+	Source  string
+	Tag     string
+	Options VirtioFSOptions
+}
+
+// virtioFSShareTag returns the tag the guest mounts a virtio-fs share with.
+func virtioFSShareTag(id string) string {
+	return mountGuestTag + "-" + id
+}
+
+// addVirtioFSShare shares the mount with the guest through a virtio-fs
+// device of its own, unless it already is. The mount is left to the shared
+// directory of the sandbox if the hypervisor cannot add such a device.
+func (c *Container) addVirtioFSShare(m *Mount) error {
+	if m.VirtioFSDeviceID != "" {
+		return nil
+	}
+
+	caps := c.sandbox.hypervisor.capabilities()
+	if !caps.IsVirtioFSHotplugSupported() {
+		c.Logger().WithField("mount", m.Destination).Warn("virtio-fs devices cannot be added, mount shared with the sandbox options")
+		return nil
+	}
+
+	options := *m.VirtioFS
+	if err := options.validate(); err != nil {
+		return err
+	}
+	if options.Cache == "" {
+		options.Cache = c.sandbox.config.HypervisorConfig.VirtioFSCache
+	}
+
+	if fileInfo, err := os.Stat(m.Source); err != nil {
+		return err
+	} else if !fileInfo.IsDir() {
+		return fmt.Errorf("virtio-fs options need a directory mount, %s is not", m.Source)
+	}
+
+	randBytes, err := utils.GenerateRandomBytes(8)
+	if err != nil {
+		return err
+	}
+	id := hex.EncodeToString(randBytes)
+
+	share := &virtioFSShare{
+		ID:      id,
+		Source:  m.Source,
+		Tag:     virtioFSShareTag(id),
+		Options: options,
+	}
+
+	if _, err := c.sandbox.hypervisor.hotplugAddDevice(share, virtioFSShareDev); err != nil {
+		return err
+	}
+
+	c.Logger().WithFields(logrus.Fields{
+		"mount":  m.Destination,
+		"device": id,
+	}).Info("Mount shared through a virtio-fs device")
+
+	m.VirtioFS = &options
+	m.VirtioFSDeviceID = id
+
+	return nil
+}
+
+// removeVirtioFSShare removes the virtio-fs device the mount is shared
+// through, and stops its daemon.
+func (c *Container) removeVirtioFSShare(m *Mount) error {
+	if m.VirtioFSDeviceID == "" {
+		return nil
+	}
+
+	share := &virtioFSShare{
+		ID:  m.VirtioFSDeviceID,
+		Tag: virtioFSShareTag(m.VirtioFSDeviceID),
+	}
+
+	if _, err := c.sandbox.hypervisor.hotplugRemoveDevice(share, virtioFSShareDev); err != nil {
+		return err
+	}
+
+	m.VirtioFSDeviceID = ""
+
+	return nil
+}
+
+// removeVirtioFSShares removes the virtio-fs devices the mounts of the
+// container are shared through.
+func (c *Container) removeVirtioFSShares() error {
+	for i := range c.mounts {
+		if err := c.removeVirtioFSShare(&c.mounts[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpgradeVirtiofsd hands the shared directory and the virtio-fs shares of
+// the sandbox over to new daemons, for an updated virtiofsd binary to be
+// used without restarting the sandbox.
+func (s *Sandbox) UpgradeVirtiofsd() error {
+	span, _ := s.trace("UpgradeVirtiofsd")
+	defer span.Finish()
+
+	if s.state.State != types.StateRunning {
+		return fmt.Errorf("Sandbox not running, impossible to upgrade virtiofsd")
+	}
+
+	if err := s.hypervisor.upgradeVirtiofsd(); err != nil {
+		return err
+	}
+
+	// The pid of the new daemon of the shared directory
+	return s.storeSandbox()
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVirtioFSOptionsValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(VirtioFSOptions{}.validate())
+	assert.NoError(VirtioFSOptions{Cache: "always", DAXWindowMB: 1024, Xattr: true}.validate())
+	assert.NoError(VirtioFSOptions{Cache: "none"}.validate())
+
+	assert.Error(VirtioFSOptions{Cache: "never"}.validate())
+	assert.Error(VirtioFSOptions{Cache: "none", DAXWindowMB: 1024}.validate())
+}
+
+func TestContainerVirtioFSShare(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "file")
+	assert.NoError(ioutil.WriteFile(file, []byte("data"), 0640))
+
+	c := &Container{
+		id: "100",
+		sandbox: &Sandbox{
+			hypervisor: &mockHypervisor{},
+			config: &SandboxConfig{
+				HypervisorConfig: HypervisorConfig{VirtioFSCache: "auto"},
+			},
+		},
+		mounts: []Mount{
+			{
+				Source:      dir,
+				Destination: "/data",
+				Type:        "bind",
+				VirtioFS:    &VirtioFSOptions{DAXWindowMB: 1024, Xattr: true},
+			},
+			{
+				Source:      dir,
+				Destination: "/shared",
+				Type:        "bind",
+			},
+		},
+	}
+
+	assert.NoError(c.addVirtioFSShare(&c.mounts[0]))
+	id := c.mounts[0].VirtioFSDeviceID
+	assert.NotEmpty(id)
+	// The sandbox cache mode is used by default
+	assert.Equal("auto", c.mounts[0].VirtioFS.Cache)
+
+	// A mount already shared keeps its device
+	assert.NoError(c.addVirtioFSShare(&c.mounts[0]))
+	assert.Equal(id, c.mounts[0].VirtioFSDeviceID)
+
+	storages := (&kataAgent{}).handleVirtioFSVolumes(c)
+	assert.Len(storages, 1)
+	assert.Equal(kataVirtioFSDevType, storages[0].Driver)
+	assert.Equal(virtioFSShareTag(id), storages[0].Source)
+	assert.Equal("/data", storages[0].MountPoint)
+	assert.Equal([]string{sharedDirVirtioFSDaxOptions}, storages[0].Options)
+
+	assert.NoError(c.removeVirtioFSShares())
+	assert.Empty(c.mounts[0].VirtioFSDeviceID)
+	assert.Empty((&kataAgent{}).handleVirtioFSVolumes(c))
+
+	// Only directories are shared through virtio-fs devices
+	m := Mount{Source: file, Destination: "/file", VirtioFS: &VirtioFSOptions{}}
+	assert.Error(c.addVirtioFSShare(&m))
+
+	m = Mount{Source: dir, Destination: "/data", VirtioFS: &VirtioFSOptions{Cache: "never"}}
+	assert.Error(c.addVirtioFSShare(&m))
+	assert.Empty(m.VirtioFSDeviceID)
+}
@@ -0,0 +1,311 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// virtiofsdRestartDelay is the delay before restarting a crashed virtio-fs
+// daemon, doubled on each restart in a row.
+var virtiofsdRestartDelay = 100 * time.Millisecond
+
+// virtiofsdRestartReset is how long a restarted virtio-fs daemon has to run
+// for its next crash not to be counted in a row.
+var virtiofsdRestartReset = time.Minute
+
+// virtiofsdUpgradeTimeout is how long a virtio-fs daemon being replaced has
+// to complete the requests in flight and quit, before it is killed.
+var virtiofsdUpgradeTimeout = 5 * time.Second
+
+// virtiofsdProcess is a started virtio-fs daemon.
+type virtiofsdProcess struct {
+	cmd    *exec.Cmd
+	stderr io.ReadCloser
+}
+
+// virtiofsdSupervisor runs a virtio-fs daemon on a vhost-user socket, and
+// restarts it on the same socket when it crashes, for the hypervisor to
+// reconnect to it instead of the guest losing the shared directory.
+type virtiofsdSupervisor struct {
+	// path of the daemon binary
+	path string
+	// socketPath the daemon serves the hypervisor on
+	socketPath string
+	// args returns the daemon arguments, given the socket fd
+	args func(fd uintptr) []string
+	// maxRestarts is how many times in a row the daemon is restarted
+	maxRestarts uint32
+	// onStart is called with the pid of every started daemon
+	onStart func(pid int)
+	// onExit is called when the daemon quits for good, unless stopped
+	onExit func()
+	logger *logrus.Entry
+
+	sync.Mutex
+	cmd     *exec.Cmd
+	stopped bool
+	stopCh  chan struct{}
+	done    chan struct{}
+	// next is the daemon taking over from the running one, switched to
+	// once the running one quits
+	next *virtiofsdProcess
+	// switched is closed once the daemon is switched to next
+	switched chan struct{}
+}
+
+// start starts the daemon, and supervises it until it is stopped.
+func (v *virtiofsdSupervisor) start() error {
+	v.Lock()
+	defer v.Unlock()
+
+	if v.done != nil {
+		return fmt.Errorf("virtiofs daemon on %s already started", v.socketPath)
+	}
+
+	cmd, stderr, err := v.spawn()
+	if err != nil {
+		return err
+	}
+
+	v.stopCh = make(chan struct{})
+	v.done = make(chan struct{})
+	go v.supervise(cmd, stderr)
+
+	return nil
+}
+
+// spawn listens on the socket, and starts the daemon on it.
+func (v *virtiofsdSupervisor) spawn() (*exec.Cmd, io.ReadCloser, error) {
+	// A crashed daemon leaves its socket behind
+	if err := os.Remove(v.socketPath); err != nil && !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{
+		Name: v.socketPath,
+		Net:  "unix",
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	listener.SetUnlinkOnClose(false)
+
+	fd, err := listener.File()
+	listener.Close() // no longer needed since fd is a dup
+	if err != nil {
+		return nil, nil, err
+	}
+	defer fd.Close()
+
+	const sockFd = 3 // Cmd.ExtraFiles[] fds are numbered starting from 3
+	cmd := exec.Command(v.path, v.args(sockFd)...)
+	cmd.ExtraFiles = append(cmd.ExtraFiles, fd)
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, nil, fmt.Errorf("virtiofs daemon %v returned with error: %v", v.path, err)
+	}
+
+	v.cmd = cmd
+	if v.onStart != nil {
+		v.onStart(cmd.Process.Pid)
+	}
+
+	return cmd, stderr, nil
+}
+
+// supervise logs the daemon stderr, and restarts the daemon when it quits,
+// up to maxRestarts times in a row.
+func (v *virtiofsdSupervisor) supervise(cmd *exec.Cmd, stderr io.ReadCloser) {
+	defer close(v.done)
+
+	var restarts uint32
+	for {
+		started := time.Now()
+
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			v.logger.WithField("source", "virtiofsd").Info(scanner.Text())
+		}
+		// Wait to release resources of virtiofsd process
+		err := cmd.Wait()
+
+		v.Lock()
+		if v.stopped {
+			v.Unlock()
+			return
+		}
+
+		if v.next != nil {
+			cmd, stderr = v.switchToNext()
+			v.Unlock()
+			restarts = 0
+			v.logger.WithError(err).Info("virtiofsd replaced")
+			continue
+		}
+
+		if time.Since(started) >= virtiofsdRestartReset {
+			restarts = 0
+		}
+
+		logger := v.logger.WithError(err).WithField("restarts", restarts)
+		if restarts >= v.maxRestarts {
+			v.stopped = true
+			v.Unlock()
+			logger.Info("virtiofsd quits")
+			if v.onExit != nil {
+				v.onExit()
+			}
+			return
+		}
+		v.Unlock()
+
+		delay := virtiofsdRestartDelay << restarts
+		restarts++
+		logger.WithField("delay", delay).Warn("virtiofsd quits, restarting it")
+
+		select {
+		case <-v.stopCh:
+			return
+		case <-time.After(delay):
+		}
+
+		v.Lock()
+		if v.stopped {
+			v.Unlock()
+			return
+		}
+		// The daemon upgraded meanwhile takes over
+		if v.next != nil {
+			cmd, stderr = v.switchToNext()
+			v.Unlock()
+			restarts = 0
+			continue
+		}
+		cmd, stderr, err = v.spawn()
+		if err != nil {
+			v.stopped = true
+		}
+		v.Unlock()
+
+		if err != nil {
+			v.logger.WithError(err).Error("Could not restart virtiofsd")
+			if v.onExit != nil {
+				v.onExit()
+			}
+			return
+		}
+	}
+}
+
+// switchToNext makes the daemon taking over the supervised one. It is
+// called with the supervisor locked.
+func (v *virtiofsdSupervisor) switchToNext() (*exec.Cmd, io.ReadCloser) {
+	next := v.next
+	v.next = nil
+	v.cmd = next.cmd
+	close(v.switched)
+
+	return next.cmd, next.stderr
+}
+
+// upgrade hands the vhost-user socket over to a new daemon, started from
+// the daemon binary as it is now, for the security updates of the binary
+// to apply without restarting the sandbox. The new daemon listens on the
+// socket before the running one is asked to quit, once the requests in
+// flight are completed, and the hypervisor reconnects to the new one.
+func (v *virtiofsdSupervisor) upgrade() error {
+	v.Lock()
+	if v.done == nil || v.stopped {
+		v.Unlock()
+		return fmt.Errorf("virtiofs daemon on %s not running", v.socketPath)
+	}
+	if v.next != nil {
+		v.Unlock()
+		return fmt.Errorf("virtiofs daemon on %s already being upgraded", v.socketPath)
+	}
+
+	running := v.cmd
+	cmd, stderr, err := v.spawn()
+	if err != nil {
+		v.Unlock()
+		return err
+	}
+	// The running daemon is the supervised one until it quits
+	v.cmd = running
+	v.next = &virtiofsdProcess{cmd: cmd, stderr: stderr}
+	v.switched = make(chan struct{})
+	switched := v.switched
+	v.Unlock()
+
+	v.logger.WithField("pid", cmd.Process.Pid).Info("virtiofsd upgrade started")
+
+	// The daemon may be gone already, restarted by supervise
+	running.Process.Signal(syscall.SIGTERM)
+
+	select {
+	case <-switched:
+		return nil
+	case <-v.done:
+		return fmt.Errorf("virtiofs daemon on %s stopped while being upgraded", v.socketPath)
+	case <-time.After(virtiofsdUpgradeTimeout):
+	}
+
+	v.logger.Warn("virtiofsd being replaced did not quit, killing it")
+	running.Process.Kill()
+
+	select {
+	case <-switched:
+		return nil
+	case <-v.done:
+		return fmt.Errorf("virtiofs daemon on %s stopped while being upgraded", v.socketPath)
+	}
+}
+
+// stop kills the daemon without restarting it, and removes its socket.
+func (v *virtiofsdSupervisor) stop() error {
+	v.Lock()
+	if v.done == nil || v.stopped {
+		v.Unlock()
+		return nil
+	}
+	v.stopped = true
+	close(v.stopCh)
+	cmd := v.cmd
+	next := v.next
+	v.next = nil
+	v.Unlock()
+
+	// The daemon may be gone already
+	cmd.Process.Kill()
+	<-v.done
+
+	// So may the daemon taking over, which supervise no longer waits for
+	if next != nil {
+		next.cmd.Process.Kill()
+		next.cmd.Wait()
+	}
+
+	if err := os.Remove(v.socketPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestVirtiofsd returns the supervisor of a fake virtio-fs daemon running
+// script, the pids of the started daemons being sent to pids.
+func newTestVirtiofsd(t *testing.T, dir, script string, pids chan<- int) *virtiofsdSupervisor {
+	path := filepath.Join(dir, "virtiofsd")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0750))
+
+	return &virtiofsdSupervisor{
+		path:       path,
+		socketPath: filepath.Join(dir, "vhost-fs.sock"),
+		args: func(fd uintptr) []string {
+			return nil
+		},
+		onStart: func(pid int) {
+			pids <- pid
+		},
+		logger: virtLog.WithField("subsystem", "virtiofsd"),
+	}
+}
+
+func TestVirtiofsdSupervisorRestart(t *testing.T) {
+	assert := assert.New(t)
+
+	savedRestartDelay := virtiofsdRestartDelay
+	defer func() {
+		virtiofsdRestartDelay = savedRestartDelay
+	}()
+	virtiofsdRestartDelay = time.Millisecond
+
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	pids := make(chan int, 16)
+	v := newTestVirtiofsd(t, dir, "exit 1", pids)
+	v.maxRestarts = 2
+
+	var exited sync.WaitGroup
+	exited.Add(1)
+	v.onExit = exited.Done
+
+	assert.NoError(v.start())
+	assert.Error(v.start())
+
+	// The crashed daemon is restarted twice, before giving up
+	exited.Wait()
+	assert.Len(pids, 3)
+	<-v.done
+
+	// Stopping a daemon gone for good does nothing
+	assert.NoError(v.stop())
+}
+
+func TestVirtiofsdSupervisorStop(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	pids := make(chan int, 16)
+	v := newTestVirtiofsd(t, dir, "exec sleep 60", pids)
+	v.maxRestarts = 2
+	v.onExit = func() {
+		t.Error("Stopped virtiofsd must not exit for good")
+	}
+
+	// Stopping a daemon not started does nothing
+	assert.NoError(v.stop())
+
+	assert.NoError(v.start())
+	_, err = os.Stat(v.socketPath)
+	assert.NoError(err)
+
+	// The killed daemon is not restarted, and its socket is removed
+	assert.NoError(v.stop())
+	assert.Len(pids, 1)
+	_, err = os.Stat(v.socketPath)
+	assert.True(os.IsNotExist(err))
+}
+
+func TestVirtiofsdSupervisorUpgrade(t *testing.T) {
+	assert := assert.New(t)
+
+	savedUpgradeTimeout := virtiofsdUpgradeTimeout
+	defer func() {
+		virtiofsdUpgradeTimeout = savedUpgradeTimeout
+	}()
+	virtiofsdUpgradeTimeout = 10 * time.Millisecond
+
+	for _, script := range []string{
+		"exec sleep 60",
+		// The daemons not quitting on SIGTERM are killed
+		"trap '' TERM\nexec sleep 60",
+	} {
+		dir, err := ioutil.TempDir("", "")
+		assert.NoError(err)
+		defer os.RemoveAll(dir)
+
+		pids := make(chan int, 16)
+		v := newTestVirtiofsd(t, dir, script, pids)
+		v.onExit = func() {
+			t.Error("Upgraded virtiofsd must not exit for good")
+		}
+
+		// Upgrading a daemon not started fails
+		assert.Error(v.upgrade())
+
+		assert.NoError(v.start())
+		running := <-pids
+
+		// The new daemon takes over the supervision and the socket,
+		// once the running one is gone
+		assert.NoError(v.upgrade(), script)
+		assert.Len(pids, 1)
+		upgraded := <-pids
+		assert.NotEqual(running, upgraded)
+		assert.Equal(syscall.ESRCH, syscall.Kill(running, 0))
+		_, err = os.Stat(v.socketPath)
+		assert.NoError(err)
+
+		v.Lock()
+		assert.Equal(upgraded, v.cmd.Process.Pid)
+		v.Unlock()
+
+		assert.NoError(v.stop())
+		assert.Equal(syscall.ESRCH, syscall.Kill(upgraded, 0))
+	}
+}
@@ -0,0 +1,512 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"golang.org/x/sys/unix"
+)
+
+// vmmIsolationCloneFlags are the namespaces a hypervisor process is
+// created in when HypervisorConfig.EnableVMMIsolation is set.
+//
+// The network namespace is not part of the list: the VMM is already
+// started from within the sandbox network namespace.
+const vmmIsolationCloneFlags = syscall.CLONE_NEWNS | syscall.CLONE_NEWPID | syscall.CLONE_NEWUSER
+
+// vmmIsolationInitName is the name the runtime re-executes itself with
+// to pivot into the root of an isolated VMM before executing it.
+const vmmIsolationInitName = "kata-vmm-isolation-init"
+
+const (
+	// vmmIsolationFirstID is the first host user and group ID root inside
+	// the user namespace of an isolated VMM is mapped to. The range sits
+	// far above the IDs of regular users and of the subordinate ID ranges
+	// handed out by useradd.
+	vmmIsolationFirstID = 2000000000
+
+	// vmmIsolationIDCount is the number of host IDs in the range, that is
+	// the number of isolated VMMs which can run at the same time.
+	vmmIsolationIDCount = 65536
+)
+
+// vmmIsolationKVMGroup is the group ID the /dev/kvm group is mapped to in
+// the VMM user namespace.
+const vmmIsolationKVMGroup = 1
+
+// vmmIsolationPath holds the host ID allocations and the root
+// directories of the isolated VMMs.
+var vmmIsolationPath = "/run/vc/vmm-isolation"
+
+// vmmIsolationDevices are the host devices every isolated VMM gets.
+var vmmIsolationDevices = []string{"/dev/kvm", "/dev/net/tun", "/dev/null", "/dev/urandom", "/dev/vfio/vfio"}
+
+// vmmIsolation is the minimal root directory and the unprivileged host
+// ID an isolated VMM runs with.
+//
+// The root only holds bind mounts of the files the VMM needs, at their
+// host paths, so that the paths handed to the VMM stay the same. It is a
+// shared mount point: the files bound into it on device hotplug, while
+// the VMM runs, propagate to the VMM mount namespace.
+type vmmIsolation struct {
+	// owners are the original owners of the host files handed over to
+	// the VMM, indexed by path.
+	owners map[string]persistapi.FileOwner
+
+	// root is the directory the VMM pivots into.
+	root string
+
+	// hostID is the host user and group ID root inside the VMM user
+	// namespace is mapped to.
+	hostID int
+}
+
+func vmmIsolationIDPath(hostID int) string {
+	return filepath.Join(vmmIsolationPath, "ids", strconv.Itoa(hostID))
+}
+
+func vmmIsolationRoot(sandboxID string) string {
+	return filepath.Join(vmmIsolationPath, "root", sandboxID)
+}
+
+// allocateVMMIsolationID reserves a free host ID of the VMM isolation
+// range for the sandbox. The reservation is a file named after the ID,
+// created exclusively, so that concurrent runtimes never share an ID.
+func allocateVMMIsolationID(sandboxID string) (int, error) {
+	if err := os.MkdirAll(filepath.Dir(vmmIsolationIDPath(0)), DirMode); err != nil {
+		return -1, err
+	}
+
+	for hostID := vmmIsolationFirstID; hostID < vmmIsolationFirstID+vmmIsolationIDCount; hostID++ {
+		f, err := os.OpenFile(vmmIsolationIDPath(hostID), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return -1, err
+		}
+
+		_, err = f.WriteString(sandboxID)
+		f.Close()
+		if err != nil {
+			os.Remove(vmmIsolationIDPath(hostID))
+			return -1, err
+		}
+
+		return hostID, nil
+	}
+
+	return -1, fmt.Errorf("all the %d VMM isolation IDs are in use", vmmIsolationIDCount)
+}
+
+func releaseVMMIsolationID(hostID int) error {
+	if err := os.Remove(vmmIsolationIDPath(hostID)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// newVMMIsolation allocates the host ID of the sandbox VMM and builds its
+// root, with the read-only files and the writable directories given.
+func newVMMIsolation(sandboxID string, roPaths, rwPaths []string) (v *vmmIsolation, err error) {
+	hostID, err := allocateVMMIsolationID(sandboxID)
+	if err != nil {
+		return nil, err
+	}
+
+	v = &vmmIsolation{
+		owners: make(map[string]persistapi.FileOwner),
+		root:   vmmIsolationRoot(sandboxID),
+		hostID: hostID,
+	}
+
+	defer func() {
+		if err != nil {
+			v.teardown()
+		}
+	}()
+
+	if err := os.MkdirAll(v.root, mountPerm); err != nil {
+		return nil, err
+	}
+
+	if err := bindMount(context.Background(), v.root, v.root, false, "shared"); err != nil {
+		return nil, err
+	}
+
+	// Mount point of the procfs of the VMM PID namespace.
+	if err := os.MkdirAll(v.rootfs("/proc"), mountPerm); err != nil {
+		return nil, err
+	}
+
+	bound := make(map[string]bool)
+	for _, path := range append(roPaths, "/sys") {
+		if bound[path] {
+			continue
+		}
+		if err := v.bind(path, true); err != nil {
+			return nil, err
+		}
+		bound[path] = true
+	}
+
+	for _, path := range vmmIsolationDevices {
+		if _, err := os.Stat(path); os.IsNotExist(err) || bound[path] {
+			continue
+		}
+		if err := v.bind(path, false); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, path := range rwPaths {
+		if err := v.bind(path, false); err != nil {
+			return nil, err
+		}
+		if err := v.handOver(path); err != nil {
+			return nil, err
+		}
+	}
+
+	return v, nil
+}
+
+// loadVMMIsolation returns the VMM isolation a VMM was started with,
+// from its persisted state, and nil if the VMM was not isolated. The root
+// of the VMM is set once the sandbox ID is known.
+func loadVMMIsolation(hostID int, owners map[string]persistapi.FileOwner) *vmmIsolation {
+	if hostID == 0 {
+		return nil
+	}
+
+	if owners == nil {
+		owners = make(map[string]persistapi.FileOwner)
+	}
+
+	return &vmmIsolation{
+		owners: owners,
+		hostID: hostID,
+	}
+}
+
+// rootfs returns the host path of path in the VMM root.
+func (v *vmmIsolation) rootfs(path string) string {
+	return filepath.Join(v.root, path)
+}
+
+// bind makes the host file at path visible to the VMM, at the same path.
+func (v *vmmIsolation) bind(path string, readonly bool) error {
+	return bindMount(context.Background(), path, v.rootfs(path), readonly, "slave")
+}
+
+// unbind removes the host file at path from the VMM root.
+func (v *vmmIsolation) unbind(path string) error {
+	dst := v.rootfs(path)
+	if err := syscall.Unmount(dst, syscall.MNT_DETACH|UmountNoFollow); err != nil && err != syscall.EINVAL && err != syscall.ENOENT {
+		return err
+	}
+
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// handOver gives the host file at path to the VMM host ID, remembering
+// its original owner.
+func (v *vmmIsolation) handOver(path string) error {
+	if _, ok := v.owners[path]; ok {
+		return nil
+	}
+
+	var st syscall.Stat_t
+	if err := syscall.Stat(path, &st); err != nil {
+		return err
+	}
+
+	if err := os.Chown(path, v.hostID, v.hostID); err != nil {
+		return err
+	}
+
+	v.owners[path] = persistapi.FileOwner{UID: int(st.Uid), GID: int(st.Gid)}
+	return nil
+}
+
+// takeBack gives the host file at path back to its original owner.
+func (v *vmmIsolation) takeBack(path string) error {
+	owner, ok := v.owners[path]
+	if !ok {
+		return nil
+	}
+
+	if err := os.Chown(path, owner.UID, owner.GID); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	delete(v.owners, path)
+	return nil
+}
+
+// addFile hands a file hotplugged to the VMM over, and makes it visible
+// in the VMM root.
+func (v *vmmIsolation) addFile(path string) error {
+	if err := v.bind(path, false); err != nil {
+		return err
+	}
+
+	if err := v.handOver(path); err != nil {
+		v.unbind(path)
+		return err
+	}
+
+	return nil
+}
+
+// removeFile undoes addFile once the file is unplugged from the VMM.
+func (v *vmmIsolation) removeFile(path string) error {
+	if err := v.unbind(path); err != nil {
+		return err
+	}
+
+	return v.takeBack(path)
+}
+
+// addVFIOGroup makes the VFIO group of the host device at sysfsDev
+// available to the VMM. The group is kept until the VMM is torn down, as
+// other devices of the group may still be assigned to the VMM.
+func (v *vmmIsolation) addVFIOGroup(sysfsDev string) error {
+	group, err := os.Readlink(filepath.Join(sysfsDev, "iommu_group"))
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join("/dev/vfio", filepath.Base(group))
+	if _, ok := v.owners[path]; ok {
+		return nil
+	}
+
+	if _, err := os.Stat(v.rootfs("/dev/vfio/vfio")); os.IsNotExist(err) {
+		if err := v.bind("/dev/vfio/vfio", false); err != nil {
+			return err
+		}
+	}
+
+	return v.addFile(path)
+}
+
+// teardown gives the host files back to their owners, removes the VMM
+// root and releases the VMM host ID.
+func (v *vmmIsolation) teardown() error {
+	for path := range v.owners {
+		if err := v.takeBack(path); err != nil {
+			return err
+		}
+	}
+
+	// Detaching the root detaches every file bound into it as well, which
+	// makes removing what is left of the root safe.
+	if err := syscall.Unmount(v.root, syscall.MNT_DETACH); err != nil && err != syscall.EINVAL && err != syscall.ENOENT {
+		return err
+	}
+
+	if err := os.RemoveAll(v.root); err != nil {
+		return err
+	}
+
+	return releaseVMMIsolationID(v.hostID)
+}
+
+// vmmIsolationHelperCaps are the capabilities the VMM isolation helper
+// keeps in the VMM user namespace, to set up the VMM mount namespace and
+// switch to root of the user namespace.
+var vmmIsolationHelperCaps = []uintptr{unix.CAP_SYS_ADMIN, unix.CAP_SETUID, unix.CAP_SETGID}
+
+// sysProcAttr returns the process attributes used to start the VMM
+// isolation helper in a dedicated mount, PID and user namespace.
+//
+// Root of the user namespace is mapped to the unprivileged VMM host ID.
+// When /dev/kvm belongs to a dedicated group, the group is mapped as well,
+// as a supplementary group of the VMM, which can then open /dev/kvm
+// without being handed host root group.
+//
+// The helper keeps the host IDs of the runtime, which may walk the host
+// path of the root, and only switches to root of the user namespace once
+// it pivoted into the root.
+func (v *vmmIsolation) sysProcAttr() *syscall.SysProcAttr {
+	attr := &syscall.SysProcAttr{
+		Cloneflags: vmmIsolationCloneFlags,
+		UidMappings: []syscall.SysProcIDMap{
+			{ContainerID: 0, HostID: v.hostID, Size: 1},
+		},
+		GidMappings: []syscall.SysProcIDMap{
+			{ContainerID: 0, HostID: v.hostID, Size: 1},
+		},
+		AmbientCaps: vmmIsolationHelperCaps,
+	}
+
+	var st syscall.Stat_t
+	if err := syscall.Stat("/dev/kvm", &st); err == nil && st.Gid != 0 {
+		attr.GidMappings = append(attr.GidMappings, syscall.SysProcIDMap{ContainerID: vmmIsolationKVMGroup, HostID: int(st.Gid), Size: 1})
+		attr.GidMappingsEnableSetgroups = true
+	}
+
+	return attr
+}
+
+// command returns the command starting the VMM at path, with args, in
+// the VMM root. The runtime re-executes itself as the VMM isolation
+// helper, which pivots into the root from within the new namespaces.
+func (v *vmmIsolation) command(path string, args ...string) *exec.Cmd {
+	cmd := exec.Command("/proc/self/exe", append([]string{v.root, path}, args...)...)
+	cmd.Args[0] = vmmIsolationInitName
+	cmd.SysProcAttr = v.sysProcAttr()
+	return cmd
+}
+
+// tapIfReq is the part of struct ifreq used by the TUNSETIFF ioctl.
+type tapIfReq struct {
+	Name  [unix.IFNAMSIZ]byte
+	Flags uint16
+	_     [22]byte
+}
+
+// setTapOwner makes the VMM host ID the owner of the tap device name of
+// the current network namespace, letting the unprivileged VMM attach to
+// it. The flags of the tap are overwritten by the VMM when it attaches,
+// only the multi-queue one has to match the tap.
+func (v *vmmIsolation) setTapOwner(name string) error {
+	for _, flags := range []uint16{
+		unix.IFF_TAP | unix.IFF_NO_PI | unix.IFF_VNET_HDR | unix.IFF_MULTI_QUEUE,
+		unix.IFF_TAP | unix.IFF_NO_PI | unix.IFF_VNET_HDR,
+	} {
+		err := v.setTapOwnerWithFlags(name, flags)
+		if err != syscall.EINVAL {
+			return err
+		}
+	}
+
+	return fmt.Errorf("could not attach to tap %s: %v", name, syscall.EINVAL)
+}
+
+func (v *vmmIsolation) setTapOwnerWithFlags(name string, flags uint16) error {
+	f, err := os.OpenFile("/dev/net/tun", os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	req := tapIfReq{Flags: flags}
+	copy(req.Name[:maxInterfaceNameLen], name)
+
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), unix.TUNSETIFF, uintptr(unsafe.Pointer(&req))); errno != 0 {
+		return errno
+	}
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), unix.TUNSETOWNER, uintptr(v.hostID)); errno != 0 {
+		return fmt.Errorf("could not set the owner of tap %s: %v", name, errno)
+	}
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), unix.TUNSETGROUP, uintptr(v.hostID)); errno != 0 {
+		return fmt.Errorf("could not set the group of tap %s: %v", name, errno)
+	}
+
+	return nil
+}
+
+// VMMIsolationInit runs the VMM isolation helper when the program was
+// re-executed as one, and does not return in that case. Programs starting
+// sandboxes with HypervisorConfig.EnableVMMIsolation set must call it
+// first thing in their main function.
+func VMMIsolationInit() {
+	if filepath.Base(os.Args[0]) != vmmIsolationInitName {
+		return
+	}
+
+	if len(os.Args) < 3 {
+		fmt.Fprintf(os.Stderr, "usage: %s <root> <vmm> [<arg>...]\n", vmmIsolationInitName)
+		os.Exit(1)
+	}
+
+	err := vmmIsolationExec(os.Args[1], os.Args[2], os.Args[2:])
+	fmt.Fprintf(os.Stderr, "%s: %v\n", vmmIsolationInitName, err)
+	os.Exit(1)
+}
+
+// vmmIsolationExec pivots into root and executes the VMM at path as root
+// of the VMM user namespace. It runs in the VMM mount and PID namespaces.
+func vmmIsolationExec(root, path string, argv []string) error {
+	if err := syscall.Mount("", "/", "", syscall.MS_SLAVE|syscall.MS_REC, ""); err != nil {
+		return fmt.Errorf("could not make the mounts slave: %v", err)
+	}
+
+	// The copy of the root in this mount namespace is locked to its parent
+	// mount, binding it again gives a mount pivot_root accepts. The new
+	// mount is a slave of the host root as well.
+	if err := syscall.Mount(root, root, "bind", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		return fmt.Errorf("could not bind mount %s: %v", root, err)
+	}
+
+	if err := syscall.Chdir(root); err != nil {
+		return err
+	}
+
+	if err := syscall.Mount("proc", "proc", "proc", syscall.MS_NOSUID|syscall.MS_NODEV|syscall.MS_NOEXEC, ""); err != nil {
+		return fmt.Errorf("could not mount proc: %v", err)
+	}
+
+	if err := syscall.PivotRoot(".", "."); err != nil {
+		return fmt.Errorf("could not pivot into %s: %v", root, err)
+	}
+
+	if err := syscall.Unmount(".", syscall.MNT_DETACH); err != nil {
+		return fmt.Errorf("could not detach the host root: %v", err)
+	}
+
+	if err := syscall.Chdir("/"); err != nil {
+		return err
+	}
+
+	// The /dev/kvm group is mapped only when setgroups is allowed.
+	if allow, err := ioutil.ReadFile("/proc/self/setgroups"); err == nil && strings.TrimSpace(string(allow)) == "allow" {
+		if err := syscall.Setgroups([]int{vmmIsolationKVMGroup}); err != nil {
+			return fmt.Errorf("could not set the supplementary groups: %v", err)
+		}
+	}
+
+	if err := syscall.Setresgid(0, 0, 0); err != nil {
+		return fmt.Errorf("could not switch to group 0: %v", err)
+	}
+
+	if err := syscall.Setresuid(0, 0, 0); err != nil {
+		return fmt.Errorf("could not switch to user 0: %v", err)
+	}
+
+	return syscall.Exec(path, argv, os.Environ())
+}
+
+// checkVMMIsolation verifies the VMM isolation setting is supported by
+// the hypervisor type.
+func checkVMMIsolation(hType HypervisorType, conf *HypervisorConfig) error {
+	if !conf.EnableVMMIsolation {
+		return nil
+	}
+
+	switch hType {
+	case ClhHypervisor, MockHypervisor:
+		return nil
+	default:
+		return fmt.Errorf("VMM isolation is not supported by the %s hypervisor", hType)
+	}
+}
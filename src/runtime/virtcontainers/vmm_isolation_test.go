@@ -0,0 +1,190 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	ktu "github.com/kata-containers/kata-containers/src/runtime/pkg/katatestutils"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVMMIsolationSysProcAttr(t *testing.T) {
+	assert := assert.New(t)
+
+	v := &vmmIsolation{hostID: vmmIsolationFirstID}
+	attr := v.sysProcAttr()
+	assert.NotNil(attr)
+
+	for _, flag := range []uintptr{syscall.CLONE_NEWNS, syscall.CLONE_NEWPID, syscall.CLONE_NEWUSER} {
+		assert.NotZero(attr.Cloneflags & flag)
+	}
+	assert.Zero(attr.Cloneflags & syscall.CLONE_NEWNET)
+
+	assert.Len(attr.UidMappings, 1)
+	assert.Equal(syscall.SysProcIDMap{ContainerID: 0, HostID: vmmIsolationFirstID, Size: 1}, attr.UidMappings[0])
+	assert.Equal(attr.UidMappings[0], attr.GidMappings[0])
+
+	// Host root is never mapped in the VMM user namespace.
+	for _, m := range append(attr.UidMappings, attr.GidMappings...) {
+		assert.NotZero(m.HostID)
+	}
+
+	if len(attr.GidMappings) > 1 {
+		assert.Len(attr.GidMappings, 2)
+		assert.Equal(vmmIsolationKVMGroup, attr.GidMappings[1].ContainerID)
+		assert.True(attr.GidMappingsEnableSetgroups)
+	} else {
+		assert.False(attr.GidMappingsEnableSetgroups)
+	}
+
+	assert.Nil(attr.Credential)
+	assert.Equal(vmmIsolationHelperCaps, attr.AmbientCaps)
+}
+
+func TestVMMIsolationCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	v := &vmmIsolation{root: vmmIsolationRoot("foo"), hostID: vmmIsolationFirstID}
+	cmd := v.command("/usr/bin/cloud-hypervisor", "--api-socket", "/run/api.sock")
+
+	assert.Equal("/proc/self/exe", cmd.Path)
+	assert.Equal([]string{vmmIsolationInitName, v.root, "/usr/bin/cloud-hypervisor", "--api-socket", "/run/api.sock"}, cmd.Args)
+	assert.NotNil(cmd.SysProcAttr)
+}
+
+func TestAllocateVMMIsolationID(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "vmm-isolation")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	savedPath := vmmIsolationPath
+	vmmIsolationPath = dir
+	defer func() {
+		vmmIsolationPath = savedPath
+	}()
+
+	first, err := allocateVMMIsolationID("foo")
+	assert.NoError(err)
+	assert.Equal(vmmIsolationFirstID, first)
+
+	second, err := allocateVMMIsolationID("bar")
+	assert.NoError(err)
+	assert.Equal(vmmIsolationFirstID+1, second)
+
+	owner, err := ioutil.ReadFile(vmmIsolationIDPath(second))
+	assert.NoError(err)
+	assert.Equal("bar", string(owner))
+
+	assert.NoError(releaseVMMIsolationID(first))
+	assert.NoError(releaseVMMIsolationID(first))
+
+	third, err := allocateVMMIsolationID("baz")
+	assert.NoError(err)
+	assert.Equal(first, third)
+}
+
+func TestLoadVMMIsolation(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(loadVMMIsolation(0, nil))
+
+	v := loadVMMIsolation(vmmIsolationFirstID, nil)
+	assert.NotNil(v)
+	assert.Equal(vmmIsolationFirstID, v.hostID)
+	assert.NotNil(v.owners)
+
+	owners := map[string]persistapi.FileOwner{"/dev/foo": {UID: 0, GID: 6}}
+	v = loadVMMIsolation(vmmIsolationFirstID, owners)
+	assert.Equal(owners, v.owners)
+}
+
+func TestNewVMMIsolation(t *testing.T) {
+	if tc.NotValid(ktu.NeedRoot()) {
+		t.Skip(testDisabledAsNonRoot)
+	}
+
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "vmm-isolation")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	savedPath := vmmIsolationPath
+	vmmIsolationPath = filepath.Join(dir, "isolation")
+	defer func() {
+		vmmIsolationPath = savedPath
+	}()
+
+	kernel := filepath.Join(dir, "kernel")
+	assert.NoError(ioutil.WriteFile(kernel, []byte("kernel"), 0644))
+	vmPath := filepath.Join(dir, "vm")
+	assert.NoError(os.Mkdir(vmPath, DirMode))
+
+	v, err := newVMMIsolation("foo", []string{kernel}, []string{vmPath})
+	assert.NoError(err)
+
+	content, err := ioutil.ReadFile(v.rootfs(kernel))
+	assert.NoError(err)
+	assert.Equal("kernel", string(content))
+	assert.Error(ioutil.WriteFile(v.rootfs(kernel), []byte("foo"), 0644))
+
+	var st syscall.Stat_t
+	assert.NoError(syscall.Stat(vmPath, &st))
+	assert.Equal(uint32(v.hostID), st.Uid)
+	assert.Equal(uint32(v.hostID), st.Gid)
+
+	// A file added after the root was built shows up in it.
+	disk := filepath.Join(dir, "disk")
+	assert.NoError(ioutil.WriteFile(disk, nil, 0600))
+	assert.NoError(v.addFile(disk))
+	_, err = os.Stat(v.rootfs(disk))
+	assert.NoError(err)
+	assert.NoError(v.removeFile(disk))
+	_, err = os.Stat(v.rootfs(disk))
+	assert.True(os.IsNotExist(err))
+	assert.NoError(syscall.Stat(disk, &st))
+	assert.Zero(st.Uid)
+
+	assert.NoError(v.teardown())
+
+	assert.NoError(syscall.Stat(vmPath, &st))
+	assert.Zero(st.Uid)
+	assert.Zero(st.Gid)
+	_, err = os.Stat(v.root)
+	assert.True(os.IsNotExist(err))
+	_, err = os.Stat(vmmIsolationIDPath(v.hostID))
+	assert.True(os.IsNotExist(err))
+
+	// The files bound in the root are left untouched.
+	content, err = ioutil.ReadFile(kernel)
+	assert.NoError(err)
+	assert.Equal("kernel", string(content))
+}
+
+func TestCheckVMMIsolation(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := &HypervisorConfig{}
+	for _, h := range []HypervisorType{QemuHypervisor, FirecrackerHypervisor, AcrnHypervisor, ClhHypervisor} {
+		assert.NoError(checkVMMIsolation(h, conf))
+	}
+
+	conf.EnableVMMIsolation = true
+	assert.NoError(checkVMMIsolation(ClhHypervisor, conf))
+	assert.NoError(checkVMMIsolation(MockHypervisor, conf))
+
+	for _, h := range []HypervisorType{QemuHypervisor, FirecrackerHypervisor, AcrnHypervisor} {
+		assert.Error(checkVMMIsolation(h, conf))
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"strings"
+
+	govmmQemu "github.com/intel/govmm/qemu"
+)
+
+// Supported values for HypervisorConfig.VMMSeccomp.
+const (
+	// VMMSeccompDefault leaves the hypervisor with its built-in seccomp
+	// behaviour.
+	VMMSeccompDefault = ""
+
+	// VMMSeccompEnforce applies the hypervisor seccomp allowlist and
+	// kills the hypervisor on a violation.
+	VMMSeccompEnforce = "enforce"
+
+	// VMMSeccompLog applies the hypervisor seccomp allowlist but only
+	// logs violations. It is meant to be used to build profiles.
+	VMMSeccompLog = "log"
+
+	// VMMSeccompDisabled runs the hypervisor without any seccomp filter.
+	VMMSeccompDisabled = "disabled"
+)
+
+// qemuSandboxOptions are the -sandbox sub-options used when the QEMU
+// seccomp filter is enforced. They deny the syscall groups a VMM never
+// needs once it is running.
+var qemuSandboxOptions = []string{
+	"obsolete=deny",
+	"elevateprivileges=deny",
+	"spawn=deny",
+	"resourcecontrol=deny",
+}
+
+// checkVMMSeccomp verifies the VMM seccomp mode is known and supported by
+// the hypervisor type.
+func checkVMMSeccomp(hType HypervisorType, conf *HypervisorConfig) error {
+	mode := conf.VMMSeccomp
+
+	switch mode {
+	case VMMSeccompDefault:
+		return nil
+	case VMMSeccompEnforce, VMMSeccompLog, VMMSeccompDisabled:
+	default:
+		return fmt.Errorf("Unknown VMM seccomp mode %q", mode)
+	}
+
+	switch hType {
+	case ClhHypervisor, MockHypervisor:
+		return nil
+	case QemuHypervisor, FirecrackerHypervisor:
+		if mode == VMMSeccompLog {
+			return fmt.Errorf("VMM seccomp mode %q is not supported by the %s hypervisor", mode, hType)
+		}
+		return nil
+	default:
+		return fmt.Errorf("VMM seccomp is not supported by the %s hypervisor", hType)
+	}
+}
+
+// qemuSandbox is a govmm device adding the QEMU -sandbox option, which
+// controls the seccomp filter QEMU installs on itself.
+type qemuSandbox struct {
+	enable bool
+}
+
+// Valid implements the govmm Device interface.
+func (s qemuSandbox) Valid() bool {
+	return true
+}
+
+// QemuParams implements the govmm Device interface.
+func (s qemuSandbox) QemuParams(config *govmmQemu.Config) []string {
+	if !s.enable {
+		return []string{"-sandbox", "off"}
+	}
+
+	return []string{"-sandbox", strings.Join(append([]string{"on"}, qemuSandboxOptions...), ",")}
+}
+
+// appendQemuSandbox adds the -sandbox option matching the VMM seccomp mode
+// to the QEMU devices.
+func appendQemuSandbox(devices []govmmQemu.Device, mode string) []govmmQemu.Device {
+	switch mode {
+	case VMMSeccompEnforce:
+		return append(devices, qemuSandbox{enable: true})
+	case VMMSeccompDisabled:
+		return append(devices, qemuSandbox{enable: false})
+	default:
+		return devices
+	}
+}
+
+// clhSeccompArgs returns the cloud-hypervisor command line arguments
+// matching the VMM seccomp mode.
+func clhSeccompArgs(mode string) []string {
+	switch mode {
+	case VMMSeccompEnforce:
+		return []string{"--seccomp", "true"}
+	case VMMSeccompLog:
+		return []string{"--seccomp", "log"}
+	case VMMSeccompDisabled:
+		return []string{"--seccomp", "false"}
+	default:
+		return nil
+	}
+}
+
+// fcSeccompArgs returns the firecracker command line arguments matching
+// the VMM seccomp mode.
+//
+// Firecracker levels are 0 (disabled), 1 (syscall allowlist only) and 2
+// (allowlist plus argument checks).
+func fcSeccompArgs(mode string) []string {
+	switch mode {
+	case VMMSeccompEnforce:
+		return []string{"--seccomp-level", "2"}
+	case VMMSeccompDisabled:
+		return []string{"--seccomp-level", "0"}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	govmmQemu "github.com/intel/govmm/qemu"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckVMMSeccomp(t *testing.T) {
+	assert := assert.New(t)
+
+	type testData struct {
+		hType       HypervisorType
+		mode        string
+		expectError bool
+	}
+
+	data := []testData{
+		{QemuHypervisor, VMMSeccompDefault, false},
+		{QemuHypervisor, VMMSeccompEnforce, false},
+		{QemuHypervisor, VMMSeccompDisabled, false},
+		{QemuHypervisor, VMMSeccompLog, true},
+		{FirecrackerHypervisor, VMMSeccompEnforce, false},
+		{FirecrackerHypervisor, VMMSeccompLog, true},
+		{ClhHypervisor, VMMSeccompLog, false},
+		{AcrnHypervisor, VMMSeccompDefault, false},
+		{AcrnHypervisor, VMMSeccompEnforce, true},
+		{ClhHypervisor, "strict", true},
+	}
+
+	for i, d := range data {
+		err := checkVMMSeccomp(d.hType, &HypervisorConfig{VMMSeccomp: d.mode})
+		if d.expectError {
+			assert.Error(err, "test %d (%+v)", i, d)
+		} else {
+			assert.NoError(err, "test %d (%+v)", i, d)
+		}
+	}
+}
+
+func TestAppendQemuSandbox(t *testing.T) {
+	assert := assert.New(t)
+
+	devices := appendQemuSandbox(nil, VMMSeccompDefault)
+	assert.Empty(devices)
+
+	devices = appendQemuSandbox(nil, VMMSeccompEnforce)
+	assert.Len(devices, 1)
+	assert.True(devices[0].Valid())
+	assert.Equal([]string{"-sandbox", "on,obsolete=deny,elevateprivileges=deny,spawn=deny,resourcecontrol=deny"},
+		devices[0].QemuParams(&govmmQemu.Config{}))
+
+	devices = appendQemuSandbox(nil, VMMSeccompDisabled)
+	assert.Len(devices, 1)
+	assert.Equal([]string{"-sandbox", "off"}, devices[0].QemuParams(&govmmQemu.Config{}))
+}
+
+func TestVMMSeccompArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(clhSeccompArgs(VMMSeccompDefault))
+	assert.Equal([]string{"--seccomp", "true"}, clhSeccompArgs(VMMSeccompEnforce))
+	assert.Equal([]string{"--seccomp", "log"}, clhSeccompArgs(VMMSeccompLog))
+	assert.Equal([]string{"--seccomp", "false"}, clhSeccompArgs(VMMSeccompDisabled))
+
+	assert.Empty(fcSeccompArgs(VMMSeccompDefault))
+	assert.Equal([]string{"--seccomp-level", "2"}, fcSeccompArgs(VMMSeccompEnforce))
+	assert.Equal([]string{"--seccomp-level", "0"}, fcSeccompArgs(VMMSeccompDisabled))
+}
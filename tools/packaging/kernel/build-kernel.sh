@@ -51,6 +51,8 @@ experimental_kernel="false"
 force_setup_generate_config="false"
 #GPU kernel support
 gpu_vendor=""
+#RDMA kernel support
+rdma_support="false"
 #
 patches_path=""
 #
@@ -96,6 +98,7 @@ Options:
 	-h          : Display this help.
 	-k <path>   : Path to kernel to build.
 	-p <path>   : Path to a directory with patches to apply to kernel.
+	-r          : Enable RDMA support for Mellanox devices.
 	-t          : Hypervisor_target.
 	-v          : Kernel version to use if kernel path not provided.
 EOT
@@ -179,6 +182,7 @@ get_kernel_frag_path() {
 	local arch_path="$1"
 	local common_path="${arch_path}/../common"
 	local gpu_path="${arch_path}/../gpu"
+	local rdma_path="${arch_path}/../rdma"
 
 	local kernel_path="$2"
 	local arch="$3"
@@ -211,6 +215,12 @@ get_kernel_frag_path() {
 		all_configs="${all_configs} ${gpu_configs}"
 	fi
 
+	if [[ "${rdma_support}" == "true" ]];then
+		info "Add kernel config for RDMA due to '-r'"
+		local rdma_configs="$(ls ${rdma_path}/*.conf)"
+		all_configs="${all_configs} ${rdma_configs}"
+	fi
+
 	info "Constructing config from fragments: ${config_path}"
 
 
@@ -411,6 +421,9 @@ install_kata() {
 	if [[ ${gpu_vendor} != "" ]];then
 		suffix="-${gpu_vendor}-gpu${suffix}"
 	fi
+	if [[ ${rdma_support} == "true" ]];then
+		suffix="-rdma${suffix}"
+	fi
 
 	vmlinuz="vmlinuz-${kernel_version}-${config_version}${suffix}"
 	vmlinux="vmlinux-${kernel_version}-${config_version}${suffix}"
@@ -447,7 +460,7 @@ install_kata() {
 }
 
 main() {
-	while getopts "a:c:defg:hk:p:t:v:" opt; do
+	while getopts "a:c:defg:hk:p:rt:v:" opt; do
 		case "$opt" in
 			a)
 				arch_target="${OPTARG}"
@@ -478,6 +491,9 @@ main() {
 			p)
 				patches_path="${OPTARG}"
 				;;
+			r)
+				rdma_support="true"
+				;;
 			t)
 				hypervisor_target="${OPTARG}"
 				;;